@@ -0,0 +1,64 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// DNSConfig sets defaults for the endpoints generated from the resources of its namespace.
+// Only the settings allowed by the cluster operator are applied.
+// +k8s:openapi-gen=true
+// +groupName=externaldns.k8s.io
+// +kubebuilder:resource:path=dnsconfigs
+// +kubebuilder:metadata:annotations="api-approved.kubernetes.io=unapproved, experimental"
+// +versionName=v1alpha1
+type DNSConfig struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec DNSConfigSpec `json:"spec,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// DNSConfigList is a list of DNSConfig objects
+type DNSConfigList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DNSConfig `json:"items"`
+}
+
+// DNSConfigSpec defines the defaults applied to the endpoints of a namespace
+type DNSConfigSpec struct {
+	// RecordTTL is the TTL of the endpoints that do not set one.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	RecordTTL *int64 `json:"recordTTL,omitempty"`
+	// Targets replace the targets of the A, AAAA and CNAME endpoints.
+	// +optional
+	Targets []string `json:"targets,omitempty"`
+	// FQDNTemplateSuffix is appended to the DNS names made of a single label,
+	// such as the ones generated by an --fqdn-template of {{.Name}}.
+	// +optional
+	FQDNTemplateSuffix string `json:"fqdnTemplateSuffix,omitempty"`
+	// Proxied is whether the endpoints that do not set it are proxied by Cloudflare.
+	// +optional
+	Proxied *bool `json:"proxied,omitempty"`
+}
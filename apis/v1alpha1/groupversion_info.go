@@ -36,5 +36,5 @@ var (
 )
 
 func init() {
-	SchemeBuilder.Register(&DNSEndpoint{}, &DNSEndpointList{})
+	SchemeBuilder.Register(&DNSEndpoint{}, &DNSEndpointList{}, &DNSConfig{}, &DNSConfigList{})
 }
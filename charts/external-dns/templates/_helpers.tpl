@@ -103,3 +103,38 @@ labelSelector:
   matchLabels:
     {{ include "external-dns.selectorLabels" . | nindent 4 }}
 {{- end }}
+
+{{/*
+The values of an argument of extraArgs, as a JSON list, e.g. to grant the permissions its flag requires;
+an argument without value has an empty value.
+Usage: include "external-dns.extraArgValues" (list . "pause-configmap") | fromJsonArray
+*/}}
+{{- define "external-dns.extraArgValues" -}}
+{{- $name := index . 1 }}
+{{- $values := list }}
+{{- with (index . 0).Values.extraArgs }}
+{{- if kindIs "map" . }}
+{{- if hasKey . $name }}
+{{- $value := index . $name }}
+{{- if kindIs "slice" $value }}
+{{- range $value }}
+{{- $values = append $values (. | toString) }}
+{{- end }}
+{{- else if kindIs "invalid" $value }}
+{{- $values = append $values "" }}
+{{- else }}
+{{- $values = append $values ($value | toString) }}
+{{- end }}
+{{- end }}
+{{- else if kindIs "slice" . }}
+{{- range . }}
+{{- if eq . (printf "--%s" $name) }}
+{{- $values = append $values "" }}
+{{- else if hasPrefix (printf "--%s=" $name) . }}
+{{- $values = append $values (trimPrefix (printf "--%s=" $name) .) }}
+{{- end }}
+{{- end }}
+{{- end }}
+{{- end }}
+{{- toJson $values }}
+{{- end }}
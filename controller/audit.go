@@ -0,0 +1,54 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/pkg/audit"
+	"sigs.k8s.io/external-dns/plan"
+)
+
+// auditChanges writes a batch of changes to the audit log, with the error returned by the
+// provider when it failed to apply them.
+func (c *Controller) auditChanges(ctx context.Context, changes *plan.Changes, err error) {
+	if c.Audit == nil {
+		return
+	}
+	now := time.Now()
+	owner := c.Registry.OwnerID()
+	var entries []audit.Entry
+	for _, ep := range changes.Create {
+		entries = append(entries, audit.NewEntry(audit.ActionCreate, owner, nil, ep, err, now))
+	}
+	for i, ep := range changes.UpdateNew {
+		var old *endpoint.Endpoint
+		if i < len(changes.UpdateOld) {
+			old = changes.UpdateOld[i]
+		}
+		entries = append(entries, audit.NewEntry(audit.ActionUpdate, owner, old, ep, err, now))
+	}
+	for _, ep := range changes.Delete {
+		entries = append(entries, audit.NewEntry(audit.ActionDelete, owner, ep, nil, err, now))
+	}
+	for _, ep := range changes.Adopt {
+		entries = append(entries, audit.NewEntry(audit.ActionAdopt, owner, ep, ep, err, now))
+	}
+	audit.Write(ctx, c.Audit, entries)
+}
@@ -0,0 +1,114 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/internal/testutils"
+	"sigs.k8s.io/external-dns/pkg/audit"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider"
+	"sigs.k8s.io/external-dns/registry"
+)
+
+type recordingAuditSink struct {
+	entries []audit.Entry
+}
+
+func (s *recordingAuditSink) Write(_ context.Context, entries []audit.Entry) error {
+	s.entries = append(s.entries, entries...)
+	return nil
+}
+
+type failingApplyProvider struct {
+	provider.BaseProvider
+	records []*endpoint.Endpoint
+}
+
+func (p *failingApplyProvider) Records(context.Context) ([]*endpoint.Endpoint, error) {
+	return p.records, nil
+}
+
+func (p *failingApplyProvider) ApplyChanges(context.Context, *plan.Changes) error {
+	return errors.New("rate limited")
+}
+
+func TestControllerAuditsChanges(t *testing.T) {
+	source := new(testutils.MockSource)
+	source.On("Endpoints").Return([]*endpoint.Endpoint{
+		endpoint.NewEndpoint("create.example.org", endpoint.RecordTypeA, "1.2.3.4").WithLabel(endpoint.ResourceLabelKey, "ingress/default/create"),
+		endpoint.NewEndpoint("update.example.org", endpoint.RecordTypeA, "5.6.7.8"),
+	}, nil)
+	p := &filteredMockProvider{RecordsStore: []*endpoint.Endpoint{
+		endpoint.NewEndpoint("update.example.org", endpoint.RecordTypeA, "1.2.3.4"),
+		endpoint.NewEndpoint("delete.example.org", endpoint.RecordTypeA, "1.2.3.4"),
+	}}
+	r, err := registry.NewNoopRegistry(p)
+	require.NoError(t, err)
+	sink := &recordingAuditSink{}
+	ctrl := &Controller{
+		Source:             source,
+		Registry:           r,
+		Policy:             &plan.SyncPolicy{},
+		ManagedRecordTypes: []string{endpoint.RecordTypeA},
+		Audit:              sink,
+	}
+	require.NoError(t, ctrl.RunOnce(context.Background()))
+
+	require.Len(t, sink.entries, 3)
+	byName := map[string]audit.Entry{}
+	for _, e := range sink.entries {
+		assert.Equal(t, audit.ResultApplied, e.Result)
+		byName[e.DNSName] = e
+	}
+	assert.Equal(t, audit.ActionCreate, byName["create.example.org"].Action)
+	assert.Equal(t, "ingress/default/create", byName["create.example.org"].Resource)
+	assert.Equal(t, audit.ActionUpdate, byName["update.example.org"].Action)
+	assert.Equal(t, []string{"1.2.3.4"}, byName["update.example.org"].Old.Targets)
+	assert.Equal(t, []string{"5.6.7.8"}, byName["update.example.org"].New.Targets)
+	assert.Equal(t, audit.ActionDelete, byName["delete.example.org"].Action)
+	assert.Nil(t, byName["delete.example.org"].New)
+}
+
+func TestControllerAuditsFailedChanges(t *testing.T) {
+	source := new(testutils.MockSource)
+	source.On("Endpoints").Return([]*endpoint.Endpoint{
+		endpoint.NewEndpoint("create.example.org", endpoint.RecordTypeA, "1.2.3.4"),
+	}, nil)
+	r, err := registry.NewNoopRegistry(&failingApplyProvider{})
+	require.NoError(t, err)
+	sink := &recordingAuditSink{}
+	ctrl := &Controller{
+		Source:             source,
+		Registry:           r,
+		Policy:             &plan.SyncPolicy{},
+		ManagedRecordTypes: []string{endpoint.RecordTypeA},
+		Audit:              sink,
+	}
+	require.Error(t, ctrl.RunOnce(context.Background()))
+
+	require.Len(t, sink.entries, 1)
+	assert.Equal(t, audit.ResultFailed, sink.entries[0].Result)
+	assert.Equal(t, "rate limited", sink.entries[0].Error)
+}
@@ -0,0 +1,270 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8syaml "k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/yaml"
+
+	apiv1alpha1 "sigs.k8s.io/external-dns/apis/v1alpha1"
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+)
+
+const (
+	// backupPrefix and backupSuffix surround the time of a snapshot in its file name.
+	backupPrefix = "records-"
+	backupSuffix = ".yaml"
+	// backupTimeFormat sorts the snapshots by time when sorting their file names.
+	backupTimeFormat = "20060102T150405Z"
+	// backupRetention is the number of snapshots kept in the backup directory.
+	backupRetention = 10
+	// backupChunkSize is the number of records per DNSEndpoint of a snapshot, which keeps the
+	// DNSEndpoints small enough to be imported in a cluster.
+	backupChunkSize = 500
+)
+
+// syncedRecords returns the records owned by the registry once the changes are applied.
+func syncedRecords(current []*endpoint.Endpoint, ownerID string, ownerIDTemplate *endpoint.OwnerIDTemplate, changes *plan.Changes) []*endpoint.Endpoint {
+	removed := map[endpoint.EndpointKey]struct{}{}
+	if changes != nil {
+		for _, ep := range append(append(append([]*endpoint.Endpoint{}, changes.Delete...), changes.UpdateOld...), changes.Adopt...) {
+			removed[ep.Key()] = struct{}{}
+		}
+	}
+
+	var records []*endpoint.Endpoint
+	for _, ep := range current {
+		if _, ok := removed[ep.Key()]; ok {
+			continue
+		}
+		if ownerID == "" || ownerIDTemplate.IsOwnedBy(ownerID, ep.Labels) {
+			records = append(records, ep)
+		}
+	}
+	if changes != nil {
+		records = append(records, changes.Create...)
+		records = append(records, changes.UpdateNew...)
+		records = append(records, changes.Adopt...)
+	}
+	return records
+}
+
+// writeBackup writes a snapshot of the records to the backup directory as DNSEndpoints, unless
+// they are the same as those of the latest snapshot, then removes the oldest snapshots beyond
+// backupRetention.
+func writeBackup(dir string, records []*endpoint.Endpoint, now time.Time) error {
+	content, err := marshalBackup(records)
+	if err != nil {
+		return err
+	}
+
+	snapshots, err := listBackups(dir)
+	if err != nil {
+		return err
+	}
+	if len(snapshots) > 0 {
+		latest, err := os.ReadFile(filepath.Join(dir, snapshots[len(snapshots)-1]))
+		if err == nil && bytes.Equal(latest, content) {
+			return nil
+		}
+	}
+
+	name := backupPrefix + now.UTC().Format(backupTimeFormat) + backupSuffix
+	tmp, err := os.CreateTemp(dir, "."+name)
+	if err != nil {
+		return fmt.Errorf("failed to write the backup: %w", err)
+	}
+	if _, err := tmp.Write(content); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write the backup: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write the backup: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), filepath.Join(dir, name)); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write the backup: %w", err)
+	}
+	log.Infof("Backed up %d records to %s", len(records), filepath.Join(dir, name))
+
+	snapshots = append(snapshots, name)
+	sort.Strings(snapshots)
+	for len(snapshots) > backupRetention {
+		if err := os.Remove(filepath.Join(dir, snapshots[0])); err != nil && !errors.Is(err, os.ErrNotExist) {
+			log.Warnf("Failed to remove the backup %s: %v", snapshots[0], err)
+		}
+		snapshots = snapshots[1:]
+	}
+	return nil
+}
+
+// listBackups returns the file names of the snapshots of the backup directory, oldest first.
+func listBackups(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the backup directory: %w", err)
+	}
+	var snapshots []string
+	for _, e := range entries {
+		if !e.IsDir() && strings.HasPrefix(e.Name(), backupPrefix) && strings.HasSuffix(e.Name(), backupSuffix) {
+			snapshots = append(snapshots, e.Name())
+		}
+	}
+	sort.Strings(snapshots)
+	return snapshots, nil
+}
+
+// marshalBackup returns the YAML documents of the DNSEndpoints holding the records, sorted
+// and without their labels, as the ownership is restored by the registry.
+func marshalBackup(records []*endpoint.Endpoint) ([]byte, error) {
+	endpoints := make([]*endpoint.Endpoint, 0, len(records))
+	for _, ep := range records {
+		endpoints = append(endpoints, &endpoint.Endpoint{
+			DNSName:          ep.DNSName,
+			Targets:          ep.Targets,
+			RecordType:       ep.RecordType,
+			SetIdentifier:    ep.SetIdentifier,
+			RecordTTL:        ep.RecordTTL,
+			ProviderSpecific: ep.ProviderSpecific,
+		})
+	}
+	sortRecords(endpoints)
+
+	content, err := marshalDNSEndpoints(endpoints, metav1.ObjectMeta{Name: "external-dns-backup"})
+	if err != nil {
+		return nil, fmt.Errorf("failed to write the backup: %w", err)
+	}
+	return content, nil
+}
+
+// sortRecords sorts the records by name, type and set identifier.
+func sortRecords(records []*endpoint.Endpoint) {
+	sort.SliceStable(records, func(i, j int) bool {
+		a, b := records[i], records[j]
+		if a.DNSName != b.DNSName {
+			return a.DNSName < b.DNSName
+		}
+		if a.RecordType != b.RecordType {
+			return a.RecordType < b.RecordType
+		}
+		return a.SetIdentifier < b.SetIdentifier
+	})
+}
+
+// marshalDNSEndpoints returns the YAML documents of DNSEndpoints of up to backupChunkSize
+// endpoints each, whose names are that of meta followed by their index.
+func marshalDNSEndpoints(endpoints []*endpoint.Endpoint, meta metav1.ObjectMeta) ([]byte, error) {
+	var buf bytes.Buffer
+	for i := 0; i == 0 || i*backupChunkSize < len(endpoints); i++ {
+		chunk := endpoints[i*backupChunkSize : min((i+1)*backupChunkSize, len(endpoints))]
+		chunkMeta := *meta.DeepCopy()
+		chunkMeta.Name = fmt.Sprintf("%s-%d", meta.Name, i)
+		doc, err := yaml.Marshal(&apiv1alpha1.DNSEndpoint{
+			TypeMeta:   metav1.TypeMeta{APIVersion: apiv1alpha1.GroupVersion.String(), Kind: "DNSEndpoint"},
+			ObjectMeta: chunkMeta,
+			Spec:       apiv1alpha1.DNSEndpointSpec{Endpoints: chunk},
+		})
+		if err != nil {
+			return nil, err
+		}
+		if i > 0 {
+			buf.WriteString("---\n")
+		}
+		buf.Write(doc)
+	}
+	return buf.Bytes(), nil
+}
+
+// readBackup returns the records of the DNSEndpoints of a snapshot.
+func readBackup(r io.Reader) ([]*endpoint.Endpoint, error) {
+	var records []*endpoint.Endpoint
+	decoder := k8syaml.NewYAMLOrJSONDecoder(r, 4096)
+	for {
+		var dnsEndpoint apiv1alpha1.DNSEndpoint
+		if err := decoder.Decode(&dnsEndpoint); err != nil {
+			if errors.Is(err, io.EOF) {
+				return records, nil
+			}
+			return nil, fmt.Errorf("failed to read the backup: %w", err)
+		}
+		if dnsEndpoint.Kind == "" && len(dnsEndpoint.Spec.Endpoints) == 0 {
+			continue
+		}
+		if dnsEndpoint.Kind != "DNSEndpoint" {
+			return nil, fmt.Errorf("failed to read the backup: unexpected kind %q", dnsEndpoint.Kind)
+		}
+		records = append(records, dnsEndpoint.Spec.Endpoints...)
+	}
+}
+
+// backupSource is a source returning the records of a snapshot.
+type backupSource struct {
+	records []*endpoint.Endpoint
+}
+
+func (s *backupSource) Endpoints(_ context.Context) ([]*endpoint.Endpoint, error) {
+	return s.records, nil
+}
+
+func (s *backupSource) AddEventHandler(_ context.Context, _ func()) {}
+
+// restore replays the records of a snapshot through the plan of the controller: the missing
+// records are created and the changed ones updated, but no record is deleted.
+func restore(ctx context.Context, ctrl *Controller, snapshot string) error {
+	f, err := os.Open(snapshot)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	records, err := readBackup(f)
+	if err != nil {
+		return err
+	}
+	log.Infof("Restoring %d records from %s", len(records), snapshot)
+
+	ctrl.Source = &backupSource{records: records}
+	ctrl.Policy = &plan.UpsertOnlyPolicy{}
+	ctrl.BackupDir = ""
+	return ctrl.RunOnce(ctx)
+}
+
+// backup writes a snapshot of the records to BackupDir. A failure is reported but doesn't fail
+// the synchronization, as the records are reconciled.
+func (c *Controller) backup(records []*endpoint.Endpoint) {
+	if c.BackupDir == "" {
+		return
+	}
+	if err := writeBackup(c.BackupDir, records, time.Now()); err != nil {
+		log.Errorf("Failed to back up the records: %v", err)
+	}
+}
@@ -0,0 +1,167 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/internal/testutils"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/registry"
+)
+
+func TestSyncedRecords(t *testing.T) {
+	owned := endpoint.NewEndpoint("owned.example.org", endpoint.RecordTypeA, "1.2.3.4").WithLabel(endpoint.OwnerLabelKey, "owner")
+	updated := endpoint.NewEndpoint("updated.example.org", endpoint.RecordTypeA, "1.2.3.4").WithLabel(endpoint.OwnerLabelKey, "owner")
+	deleted := endpoint.NewEndpoint("deleted.example.org", endpoint.RecordTypeA, "1.2.3.4").WithLabel(endpoint.OwnerLabelKey, "owner")
+	other := endpoint.NewEndpoint("other.example.org", endpoint.RecordTypeA, "1.2.3.4").WithLabel(endpoint.OwnerLabelKey, "other")
+	created := endpoint.NewEndpoint("created.example.org", endpoint.RecordTypeA, "1.2.3.4")
+	updatedNew := endpoint.NewEndpoint("updated.example.org", endpoint.RecordTypeA, "5.6.7.8")
+
+	current := []*endpoint.Endpoint{owned, updated, deleted, other}
+	assert.Equal(t, []*endpoint.Endpoint{owned, updated, deleted}, syncedRecords(current, "owner", nil, nil))
+	assert.Equal(t, current, syncedRecords(current, "", nil, nil))
+
+	changes := &plan.Changes{
+		Create:    []*endpoint.Endpoint{created},
+		UpdateOld: []*endpoint.Endpoint{updated},
+		UpdateNew: []*endpoint.Endpoint{updatedNew},
+		Delete:    []*endpoint.Endpoint{deleted},
+	}
+	assert.Equal(t, []*endpoint.Endpoint{owned, created, updatedNew}, syncedRecords(current, "owner", nil, changes))
+}
+
+func TestWriteBackup(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	records := []*endpoint.Endpoint{
+		endpoint.NewEndpointWithTTL("www.example.org", endpoint.RecordTypeA, 300, "1.2.3.4").WithLabel(endpoint.OwnerLabelKey, "owner"),
+		endpoint.NewEndpoint("api.example.org", endpoint.RecordTypeCNAME, "lb.example.org").WithSetIdentifier("eu"),
+	}
+
+	require.NoError(t, writeBackup(dir, records, now))
+	snapshots, err := listBackups(dir)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"records-20250101T000000Z.yaml"}, snapshots)
+
+	// the same records are not written again
+	require.NoError(t, writeBackup(dir, records, now.Add(time.Minute)))
+	snapshots, err = listBackups(dir)
+	require.NoError(t, err)
+	assert.Len(t, snapshots, 1)
+
+	f, err := os.Open(filepath.Join(dir, snapshots[0]))
+	require.NoError(t, err)
+	defer f.Close()
+	restored, err := readBackup(f)
+	require.NoError(t, err)
+	assert.Equal(t, []*endpoint.Endpoint{
+		{DNSName: "api.example.org", RecordType: endpoint.RecordTypeCNAME, Targets: endpoint.Targets{"lb.example.org"}, SetIdentifier: "eu"},
+		{DNSName: "www.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"1.2.3.4"}, RecordTTL: 300},
+	}, restored)
+
+	// only the latest snapshots are kept
+	for i := 1; i <= backupRetention+2; i++ {
+		records = append(records, endpoint.NewEndpoint("www.example.org", endpoint.RecordTypeTXT, strings.Repeat("a", i)))
+		require.NoError(t, writeBackup(dir, records, now.Add(time.Duration(i)*time.Hour)))
+	}
+	snapshots, err = listBackups(dir)
+	require.NoError(t, err)
+	assert.Len(t, snapshots, backupRetention)
+	assert.Equal(t, "records-20250101T120000Z.yaml", snapshots[len(snapshots)-1])
+}
+
+func TestMarshalBackupChunks(t *testing.T) {
+	var records []*endpoint.Endpoint
+	for i := 0; i < backupChunkSize+1; i++ {
+		records = append(records, endpoint.NewEndpoint("www.example.org", endpoint.RecordTypeTXT, strings.Repeat("a", i+1)))
+	}
+	content, err := marshalBackup(records)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "name: external-dns-backup-0\n")
+	assert.Contains(t, string(content), "---\n")
+	assert.Contains(t, string(content), "name: external-dns-backup-1\n")
+
+	restored, err := readBackup(strings.NewReader(string(content)))
+	require.NoError(t, err)
+	assert.Len(t, restored, backupChunkSize+1)
+}
+
+func TestReadBackupInvalid(t *testing.T) {
+	_, err := readBackup(strings.NewReader("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: records\n"))
+	require.ErrorContains(t, err, `unexpected kind "ConfigMap"`)
+
+	_, err = readBackup(strings.NewReader("kind: [DNSEndpoint"))
+	require.Error(t, err)
+}
+
+func TestControllerBackupAndRestore(t *testing.T) {
+	dir := t.TempDir()
+	source := new(testutils.MockSource)
+	source.On("Endpoints").Return([]*endpoint.Endpoint{
+		endpoint.NewEndpoint("www.example.org", endpoint.RecordTypeA, "1.2.3.4"),
+	}, nil)
+
+	for _, streamRecords := range []bool{false, true} {
+		prov := &filteredMockProvider{RecordsStore: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("old.example.org", endpoint.RecordTypeA, "5.6.7.8"),
+		}}
+		r, err := registry.NewNoopRegistry(prov)
+		require.NoError(t, err)
+
+		ctrl := &Controller{
+			Source:             source,
+			Registry:           r,
+			Policy:             &plan.SyncPolicy{},
+			ManagedRecordTypes: []string{endpoint.RecordTypeA},
+			StreamRecords:      streamRecords,
+			BackupDir:          filepath.Join(dir, map[bool]string{false: "batch", true: "stream"}[streamRecords]),
+		}
+		require.NoError(t, os.Mkdir(ctrl.BackupDir, 0o755))
+
+		// the snapshot holds the records once the changes are applied
+		require.NoError(t, ctrl.RunOnce(context.Background()))
+		snapshots, err := listBackups(ctrl.BackupDir)
+		require.NoError(t, err)
+		require.Len(t, snapshots, 1)
+		snapshot := filepath.Join(ctrl.BackupDir, snapshots[0])
+		content, err := os.ReadFile(snapshot)
+		require.NoError(t, err)
+		assert.Contains(t, string(content), "www.example.org")
+		assert.NotContains(t, string(content), "old.example.org")
+
+		// the restore creates the missing records but deletes none
+		prov.RecordsStore = []*endpoint.Endpoint{endpoint.NewEndpoint("new.example.org", endpoint.RecordTypeA, "9.9.9.9")}
+		prov.ApplyChangesCalls = nil
+		require.NoError(t, restore(context.Background(), ctrl, snapshot))
+		require.Len(t, prov.ApplyChangesCalls, 1)
+		assert.Equal(t, []*endpoint.Endpoint{
+			{DNSName: "www.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"1.2.3.4"}},
+		}, prov.ApplyChangesCalls[0].Create)
+		assert.Empty(t, prov.ApplyChangesCalls[0].Delete)
+		assert.Empty(t, ctrl.BackupDir)
+	}
+}
@@ -0,0 +1,149 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/pkg/logging"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/source/annotations"
+)
+
+// writeBudgetWindow is the period over which the records written to the provider are
+// counted against the write budget.
+const writeBudgetWindow = time.Hour
+
+// budgetWrites are the records written to the provider by an application of changes.
+type budgetWrites struct {
+	at    time.Time
+	count int
+}
+
+// changeWrites returns the number of records the changes write to the provider.
+func changeWrites(changes *plan.Changes) int {
+	return len(changes.Create) + len(changes.UpdateNew) + len(changes.Delete)
+}
+
+// recordWrites counts the records written by the changes against the write budget.
+func (c *Controller) recordWrites(changes *plan.Changes) {
+	if c.WriteBudget <= 0 {
+		return
+	}
+	c.writes = append(c.writes, budgetWrites{at: time.Now(), count: changeWrites(changes)})
+	writeBudgetRemaining.Gauge.Set(float64(c.remainingWrites()))
+}
+
+// remainingWrites returns the number of records which can still be written to the provider
+// within the budget, forgetting the writes older than the budget window.
+func (c *Controller) remainingWrites() int {
+	since := time.Now().Add(-writeBudgetWindow)
+	used := 0
+	writes := c.writes[:0]
+	for _, w := range c.writes {
+		if w.at.After(since) {
+			writes = append(writes, w)
+			used += w.count
+		}
+	}
+	c.writes = writes
+	return max(c.WriteBudget-used, 0)
+}
+
+// deferNonUrgentUpdates removes the updates only changing the TTL or the comment of records
+// which don't fit in the remaining write budget, so that they are applied once the writes of
+// the previous hour no longer count. The creates, deletes and other updates are always applied,
+// and counted first.
+func (c *Controller) deferNonUrgentUpdates(changes *plan.Changes) {
+	if c.WriteBudget <= 0 {
+		return
+	}
+	remaining := c.remainingWrites()
+	writeBudgetRemaining.Gauge.Set(float64(remaining))
+
+	current := map[endpoint.EndpointKey]*endpoint.Endpoint{}
+	for _, ep := range changes.UpdateOld {
+		current[ep.Key()] = ep
+	}
+	var nonUrgent []*endpoint.Endpoint
+	for _, ep := range changes.UpdateNew {
+		if old, ok := current[ep.Key()]; ok && !urgentUpdate(old, ep) {
+			nonUrgent = append(nonUrgent, ep)
+		}
+	}
+	remaining -= changeWrites(changes) - len(nonUrgent)
+	if len(nonUrgent) <= remaining {
+		return
+	}
+
+	deferred := map[endpoint.EndpointKey]struct{}{}
+	for _, ep := range nonUrgent[max(remaining, 0):] {
+		deferred[ep.Key()] = struct{}{}
+	}
+	log.Infof("Deferring %d updates of the TTL or the comment of records: the write budget of %d records per hour is exhausted", len(deferred), c.WriteBudget)
+	controllerDeferredUpdatesTotal.Counter.Add(float64(len(deferred)))
+
+	olds := changes.UpdateOld[:0]
+	for _, ep := range changes.UpdateOld {
+		if _, ok := deferred[ep.Key()]; !ok {
+			olds = append(olds, ep)
+		}
+	}
+	changes.UpdateOld = olds
+	updates := changes.UpdateNew[:0]
+	for _, ep := range changes.UpdateNew {
+		if _, ok := deferred[ep.Key()]; ok {
+			logging.ForRecord(ep).Debugf("Deferring the update of %s %s", ep.DNSName, ep.RecordType)
+			continue
+		}
+		updates = append(updates, ep)
+	}
+	changes.UpdateNew = updates
+}
+
+// urgentUpdate returns whether an update changes more than the TTL or the comment of a record.
+func urgentUpdate(old, updated *endpoint.Endpoint) bool {
+	if !old.Targets.Same(updated.Targets) {
+		return true
+	}
+	oldProperties := map[string]string{}
+	for _, p := range old.ProviderSpecific {
+		oldProperties[p.Name] = p.Value
+	}
+	changedComment := false
+	for _, p := range updated.ProviderSpecific {
+		value, ok := oldProperties[p.Name]
+		delete(oldProperties, p.Name)
+		if ok && value == p.Value {
+			continue
+		}
+		if p.Name != annotations.CloudflareRecordCommentKey {
+			return true
+		}
+		changedComment = true
+	}
+	for name := range oldProperties {
+		if name != annotations.CloudflareRecordCommentKey {
+			return true
+		}
+		changedComment = true
+	}
+	return !changedComment && old.RecordTTL == updated.RecordTTL
+}
@@ -0,0 +1,161 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/internal/testutils"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/registry"
+	"sigs.k8s.io/external-dns/source/annotations"
+)
+
+func TestUrgentUpdate(t *testing.T) {
+	record := func(ttl endpoint.TTL, targets ...string) *endpoint.Endpoint {
+		return endpoint.NewEndpointWithTTL("web.example.org", endpoint.RecordTypeA, ttl, targets...)
+	}
+	for _, tc := range []struct {
+		name         string
+		old, updated *endpoint.Endpoint
+		expectUrgent bool
+	}{
+		{name: "ttl", old: record(60, "1.2.3.4"), updated: record(300, "1.2.3.4")},
+		{
+			name:    "comment",
+			old:     record(60, "1.2.3.4").WithProviderSpecific(annotations.CloudflareRecordCommentKey, "old"),
+			updated: record(60, "1.2.3.4").WithProviderSpecific(annotations.CloudflareRecordCommentKey, "new"),
+		},
+		{name: "added comment", old: record(60, "1.2.3.4"), updated: record(60, "1.2.3.4").WithProviderSpecific(annotations.CloudflareRecordCommentKey, "new")},
+		{name: "targets", old: record(60, "1.2.3.4"), updated: record(300, "5.6.7.8"), expectUrgent: true},
+		{
+			name:         "provider specific",
+			old:          record(60, "1.2.3.4").WithProviderSpecific(annotations.CloudflareProxiedKey, "false"),
+			updated:      record(300, "1.2.3.4").WithProviderSpecific(annotations.CloudflareProxiedKey, "true"),
+			expectUrgent: true,
+		},
+		{name: "labels", old: record(60, "1.2.3.4"), updated: record(60, "1.2.3.4").WithLabel(endpoint.OwnerLabelKey, "owner"), expectUrgent: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectUrgent, urgentUpdate(tc.old, tc.updated))
+		})
+	}
+}
+
+func TestDeferNonUrgentUpdates(t *testing.T) {
+	ttlUpdate := func(name string) (*endpoint.Endpoint, *endpoint.Endpoint) {
+		return endpoint.NewEndpointWithTTL(name, endpoint.RecordTypeA, 60, "1.2.3.4"),
+			endpoint.NewEndpointWithTTL(name, endpoint.RecordTypeA, 300, "1.2.3.4")
+	}
+	newChanges := func() *plan.Changes {
+		changes := &plan.Changes{
+			Create: []*endpoint.Endpoint{endpoint.NewEndpoint("create.example.org", endpoint.RecordTypeA, "1.2.3.4")},
+			Delete: []*endpoint.Endpoint{endpoint.NewEndpoint("delete.example.org", endpoint.RecordTypeA, "1.2.3.4")},
+		}
+		for _, name := range []string{"ttl1.example.org", "ttl2.example.org"} {
+			old, updated := ttlUpdate(name)
+			changes.UpdateOld = append(changes.UpdateOld, old)
+			changes.UpdateNew = append(changes.UpdateNew, updated)
+		}
+		changes.UpdateOld = append(changes.UpdateOld, endpoint.NewEndpoint("move.example.org", endpoint.RecordTypeA, "1.2.3.4"))
+		changes.UpdateNew = append(changes.UpdateNew, endpoint.NewEndpoint("move.example.org", endpoint.RecordTypeA, "5.6.7.8"))
+		return changes
+	}
+
+	t.Run("unlimited", func(t *testing.T) {
+		ctrl := &Controller{}
+		changes := newChanges()
+		ctrl.deferNonUrgentUpdates(changes)
+		assert.Len(t, changes.UpdateNew, 3)
+	})
+
+	t.Run("within budget", func(t *testing.T) {
+		ctrl := &Controller{WriteBudget: 5}
+		changes := newChanges()
+		ctrl.deferNonUrgentUpdates(changes)
+		assert.Len(t, changes.UpdateNew, 3)
+		assert.Len(t, changes.UpdateOld, 3)
+	})
+
+	t.Run("tight budget", func(t *testing.T) {
+		ctrl := &Controller{WriteBudget: 4}
+		changes := newChanges()
+		ctrl.deferNonUrgentUpdates(changes)
+		require.Len(t, changes.UpdateNew, 2)
+		require.Len(t, changes.UpdateOld, 2)
+		assert.Equal(t, "ttl1.example.org", changes.UpdateNew[0].DNSName)
+		assert.Equal(t, "move.example.org", changes.UpdateNew[1].DNSName)
+		assert.Len(t, changes.Create, 1)
+		assert.Len(t, changes.Delete, 1)
+	})
+
+	t.Run("exhausted budget", func(t *testing.T) {
+		ctrl := &Controller{WriteBudget: 10, writes: []budgetWrites{
+			{at: time.Now().Add(-2 * time.Hour), count: 10},
+			{at: time.Now().Add(-time.Minute), count: 9},
+		}}
+		changes := newChanges()
+		ctrl.deferNonUrgentUpdates(changes)
+		// the creates, deletes and other updates are applied beyond the budget
+		require.Len(t, changes.UpdateNew, 1)
+		assert.Equal(t, "move.example.org", changes.UpdateNew[0].DNSName)
+		assert.Len(t, changes.Create, 1)
+		assert.Len(t, changes.Delete, 1)
+		// the writes older than an hour are forgotten
+		assert.Len(t, ctrl.writes, 1)
+	})
+}
+
+func TestControllerDefersUpdatesBeyondWriteBudget(t *testing.T) {
+	source := new(testutils.MockSource)
+	source.On("Endpoints").Return([]*endpoint.Endpoint{
+		endpoint.NewEndpointWithTTL("ttl.example.org", endpoint.RecordTypeA, 300, "1.2.3.4"),
+		endpoint.NewEndpoint("create.example.org", endpoint.RecordTypeA, "1.2.3.4"),
+	}, nil)
+
+	for _, streamRecords := range []bool{false, true} {
+		prov := &filteredMockProvider{RecordsStore: []*endpoint.Endpoint{
+			endpoint.NewEndpointWithTTL("ttl.example.org", endpoint.RecordTypeA, 60, "1.2.3.4"),
+		}}
+		r, err := registry.NewNoopRegistry(prov)
+		require.NoError(t, err)
+
+		ctrl := &Controller{
+			Source:             source,
+			Registry:           r,
+			Policy:             &plan.SyncPolicy{},
+			ManagedRecordTypes: []string{endpoint.RecordTypeA},
+			StreamRecords:      streamRecords,
+			WriteBudget:        1,
+		}
+		require.NoError(t, ctrl.RunOnce(context.Background()))
+
+		// the create uses the whole budget, the TTL update is deferred
+		require.Len(t, prov.ApplyChangesCalls, 1)
+		changes := prov.ApplyChangesCalls[0]
+		require.Len(t, changes.Create, 1)
+		assert.Empty(t, changes.UpdateNew)
+		assert.Empty(t, changes.UpdateOld)
+		assert.Len(t, ctrl.writes, 1)
+	}
+}
@@ -0,0 +1,74 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/pkg/canary"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider"
+	"sigs.k8s.io/external-dns/provider/inmemory"
+	"sigs.k8s.io/external-dns/registry"
+)
+
+// invalidTargetProvider rejects the records targeting 0.0.0.0, as a provider validating them would.
+type invalidTargetProvider struct {
+	*inmemory.InMemoryProvider
+}
+
+func (p invalidTargetProvider) ApplyChanges(ctx context.Context, changes *plan.Changes) error {
+	for _, ep := range changes.Create {
+		if ep.Targets.Same(endpoint.Targets{"0.0.0.0"}) {
+			return errors.New("invalid target 0.0.0.0")
+		}
+	}
+	return p.InMemoryProvider.ApplyChanges(ctx, changes)
+}
+
+func TestApplyChangesCanary(t *testing.T) {
+	p := invalidTargetProvider{inmemory.NewInMemoryProvider(inmemory.InMemoryInitZones([]string{"example.org", "canary.example.net"}))}
+	reg, err := registry.NewNoopRegistry(p)
+	require.NoError(t, err)
+	ctrl := &Controller{Registry: reg, Canary: canary.NewStager(p, "canary.example.net", "", time.Second)}
+
+	// the changes rejected in the canary zone are not applied
+	err = ctrl.applyChanges(t.Context(), "", &plan.Changes{Create: []*endpoint.Endpoint{
+		endpoint.NewEndpoint("www.example.org", endpoint.RecordTypeA, "1.2.3.4"),
+		endpoint.NewEndpoint("api.example.org", endpoint.RecordTypeA, "0.0.0.0"),
+	}})
+	require.ErrorIs(t, err, provider.SoftError)
+	records, err := p.Records(t.Context())
+	require.NoError(t, err)
+	assert.Empty(t, records)
+
+	// the changes staged in the canary zone are applied, the staged records are deleted
+	require.NoError(t, ctrl.applyChanges(t.Context(), "", &plan.Changes{Create: []*endpoint.Endpoint{
+		endpoint.NewEndpoint("www.example.org", endpoint.RecordTypeA, "1.2.3.4"),
+	}}))
+	records, err = p.Records(t.Context())
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, "www.example.org", records[0].DNSName)
+}
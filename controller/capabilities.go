@@ -0,0 +1,91 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"maps"
+	"slices"
+
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/pkg/decisions"
+	"sigs.k8s.io/external-dns/pkg/logging"
+	"sigs.k8s.io/external-dns/plan"
+)
+
+// rejectUnsupportedChanges removes the creates and updates of the records the provider can't
+// apply, reporting why, so that the other changes are still applied. The current version of an
+// updated record is kept as is.
+func (c *Controller) rejectUnsupportedChanges(changes *plan.Changes) {
+	rejected := map[endpoint.EndpointKey]struct{}{}
+	supported := func(ep *endpoint.Endpoint) bool {
+		err := c.Capabilities.Validate(ep)
+		if err == nil {
+			return true
+		}
+		logging.ForRecord(ep).Errorf("Not applying the changes of %s %s: %v", ep.DNSName, ep.RecordType, err)
+		c.FilterDecisions.Record(decisions.ForEndpoint(decisions.CapabilitiesFilter, ep, err.Error()))
+		rejected[ep.Key()] = struct{}{}
+		return false
+	}
+
+	creates := changes.Create[:0]
+	for _, ep := range changes.Create {
+		if supported(ep) {
+			creates = append(creates, ep)
+		}
+	}
+	changes.Create = creates
+
+	updates := changes.UpdateNew[:0]
+	for _, ep := range changes.UpdateNew {
+		if supported(ep) {
+			updates = append(updates, ep)
+		}
+	}
+	changes.UpdateNew = updates
+	if len(rejected) == 0 {
+		return
+	}
+
+	olds := changes.UpdateOld[:0]
+	for _, ep := range changes.UpdateOld {
+		if _, ok := rejected[ep.Key()]; !ok {
+			olds = append(olds, ep)
+		}
+	}
+	changes.UpdateOld = olds
+}
+
+// checkProperties reports the provider-specific properties of the desired records which the
+// provider doesn't declare or whose values don't match their schema, as soon as the sources
+// return them. The changes of the records with invalid properties are then rejected.
+func (c *Controller) checkProperties(endpoints []*endpoint.Endpoint) {
+	unknown := map[string]string{}
+	for _, ep := range endpoints {
+		for _, name := range c.Capabilities.UnknownProperties(ep) {
+			unknown[name] = ep.DNSName
+		}
+		if err := c.Capabilities.ValidateProperties(ep); err != nil {
+			logging.ForRecord(ep).Warnf("Endpoint %s %s has an invalid provider-specific property: %v", ep.DNSName, ep.RecordType, err)
+		}
+	}
+	for _, name := range slices.Sorted(maps.Keys(unknown)) {
+		log.Warnf("Provider-specific property %s of endpoint %s is not supported by the provider and is ignored", name, unknown[name])
+	}
+}
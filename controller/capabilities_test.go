@@ -0,0 +1,107 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/internal/testutils"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider"
+	"sigs.k8s.io/external-dns/registry"
+)
+
+func TestControllerRejectsUnsupportedChanges(t *testing.T) {
+	source := new(testutils.MockSource)
+	source.On("Endpoints").Return([]*endpoint.Endpoint{
+		endpoint.NewEndpoint("create.example.org", endpoint.RecordTypeA, "1.2.3.4"),
+		endpoint.NewEndpoint("create.example.org", endpoint.RecordTypeTXT, "text"),
+		endpoint.NewEndpoint("update.example.org", endpoint.RecordTypeA, "1.2.3.4", "5.6.7.8", "9.9.9.9"),
+		endpoint.NewEndpointWithTTL("ttl.example.org", endpoint.RecordTypeA, 300, "1.2.3.4"),
+	}, nil)
+
+	for _, streamRecords := range []bool{false, true} {
+		prov := &filteredMockProvider{RecordsStore: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("update.example.org", endpoint.RecordTypeA, "1.2.3.4"),
+			endpoint.NewEndpointWithTTL("ttl.example.org", endpoint.RecordTypeA, 60, "1.2.3.4"),
+		}}
+		r, err := registry.NewNoopRegistry(prov)
+		require.NoError(t, err)
+
+		ctrl := &Controller{
+			Source:             source,
+			Registry:           r,
+			Policy:             &plan.SyncPolicy{},
+			ManagedRecordTypes: []string{endpoint.RecordTypeA, endpoint.RecordTypeTXT},
+			StreamRecords:      streamRecords,
+			Capabilities:       provider.Capabilities{RecordTypes: []string{endpoint.RecordTypeA}, MaxTargets: 2},
+		}
+		require.NoError(t, ctrl.RunOnce(context.Background()))
+
+		// the changes the provider supports are still applied
+		require.Len(t, prov.ApplyChangesCalls, 1)
+		changes := prov.ApplyChangesCalls[0]
+		require.Len(t, changes.Create, 1)
+		assert.Equal(t, "create.example.org", changes.Create[0].DNSName)
+		assert.Equal(t, endpoint.RecordTypeA, changes.Create[0].RecordType)
+		require.Len(t, changes.UpdateNew, 1)
+		require.Len(t, changes.UpdateOld, 1)
+		assert.Equal(t, "ttl.example.org", changes.UpdateNew[0].DNSName)
+		assert.Equal(t, "ttl.example.org", changes.UpdateOld[0].DNSName)
+	}
+}
+
+func TestControllerRejectsInvalidProperties(t *testing.T) {
+	hook := testutils.LogsUnderTestWithLogLevel(log.WarnLevel, t)
+	source := new(testutils.MockSource)
+	source.On("Endpoints").Return([]*endpoint.Endpoint{
+		endpoint.NewEndpoint("valid.example.org", endpoint.RecordTypeA, "1.2.3.4").WithProviderSpecific("aws/weight", "10"),
+		endpoint.NewEndpoint("invalid.example.org", endpoint.RecordTypeA, "1.2.3.4").WithProviderSpecific("aws/weight", "heavy"),
+		endpoint.NewEndpoint("unknown.example.org", endpoint.RecordTypeA, "1.2.3.4").WithProviderSpecific("aws/wieght", "10"),
+	}, nil)
+
+	prov := &filteredMockProvider{}
+	r, err := registry.NewNoopRegistry(prov)
+	require.NoError(t, err)
+	ctrl := &Controller{
+		Source:             source,
+		Registry:           r,
+		Policy:             &plan.SyncPolicy{},
+		ManagedRecordTypes: []string{endpoint.RecordTypeA},
+		Capabilities: provider.Capabilities{
+			Properties:       []provider.PropertySchema{{Name: "aws/weight", Type: provider.PropertyTypeInt}},
+			PropertyPrefixes: []string{"aws/"},
+		},
+	}
+	require.NoError(t, ctrl.RunOnce(context.Background()))
+
+	// the records with invalid properties are not created, the unknown properties are only reported
+	require.Len(t, prov.ApplyChangesCalls, 1)
+	var created []string
+	for _, ep := range prov.ApplyChangesCalls[0].Create {
+		created = append(created, ep.DNSName)
+	}
+	assert.ElementsMatch(t, []string{"valid.example.org", "unknown.example.org"}, created)
+	testutils.TestHelperLogContains(`Endpoint invalid.example.org A has an invalid provider-specific property: the value "heavy" of property aws/weight is not an integer`, hook, t)
+	testutils.TestHelperLogContains("Provider-specific property aws/wieght of endpoint unknown.example.org is not supported by the provider and is ignored", hook, t)
+}
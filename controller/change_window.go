@@ -0,0 +1,95 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/pkg/changewindow"
+	"sigs.k8s.io/external-dns/pkg/logging"
+	"sigs.k8s.io/external-dns/plan"
+)
+
+// reconfigureChangeWindows replaces the change windows when the configuration is reloaded. It
+// waits for a reconciliation in progress to finish.
+func (c *Controller) reconfigureChangeWindows(windows changewindow.Windows, location *time.Location, creates bool) {
+	c.reconcileMutex.Lock()
+	defer c.reconcileMutex.Unlock()
+	c.ChangeWindows = windows
+	c.ChangeWindowLocation = location
+	c.ChangeWindowCreates = creates
+}
+
+// deferOutsideChangeWindows removes the updates and deletes from the changes planned out of the
+// change windows, so that they are applied by the first synchronization within a window. The
+// creates and adoptions are applied unless ChangeWindowCreates is false, except those replacing
+// a deferred delete, e.g. changing the type of a record.
+func (c *Controller) deferOutsideChangeWindows(changes *plan.Changes) {
+	if len(c.ChangeWindows) == 0 {
+		changeWindowOpen.Gauge.Set(1)
+		return
+	}
+	now := time.Now()
+	if c.ChangeWindowLocation != nil {
+		now = now.In(c.ChangeWindowLocation)
+	}
+	if c.ChangeWindows.Open(now) {
+		changeWindowOpen.Gauge.Set(1)
+		return
+	}
+	changeWindowOpen.Gauge.Set(0)
+
+	deleted := map[string]struct{}{}
+	for _, ep := range changes.Delete {
+		deleted[ep.DNSName] = struct{}{}
+	}
+	var creates, adopts []*endpoint.Endpoint
+	if c.ChangeWindowCreates {
+		for _, ep := range changes.Create {
+			if _, ok := deleted[ep.DNSName]; !ok {
+				creates = append(creates, ep)
+			}
+		}
+		adopts = changes.Adopt
+	}
+
+	deferred := len(changes.UpdateNew) + len(changes.Delete) + len(changes.Create) - len(creates) + len(changes.Adopt) - len(adopts)
+	if deferred == 0 {
+		return
+	}
+	next := "none within 8 days"
+	if at, ok := c.ChangeWindows.Next(now); ok {
+		next = at.Format(time.RFC3339)
+	}
+	log.Infof("Deferring %d changes of records out of the change windows, the next window opens at %s", deferred, next)
+	changeWindowDeferredTotal.Counter.Add(float64(deferred))
+	for _, ep := range changes.UpdateNew {
+		logging.ForRecord(ep).Debugf("Deferring the update of %s %s", ep.DNSName, ep.RecordType)
+	}
+	for _, ep := range changes.Delete {
+		logging.ForRecord(ep).Debugf("Deferring the delete of %s %s", ep.DNSName, ep.RecordType)
+	}
+
+	changes.Create = creates
+	changes.UpdateOld = nil
+	changes.UpdateNew = nil
+	changes.Delete = nil
+	changes.Adopt = adopts
+}
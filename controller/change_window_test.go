@@ -0,0 +1,77 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/pkg/changewindow"
+	"sigs.k8s.io/external-dns/plan"
+)
+
+func TestDeferOutsideChangeWindows(t *testing.T) {
+	newChanges := func() *plan.Changes {
+		return &plan.Changes{
+			Create: []*endpoint.Endpoint{
+				endpoint.NewEndpoint("create.example.org", endpoint.RecordTypeA, "1.2.3.4"),
+				endpoint.NewEndpoint("retype.example.org", endpoint.RecordTypeCNAME, "lb.example.org"),
+			},
+			UpdateOld: []*endpoint.Endpoint{endpoint.NewEndpoint("update.example.org", endpoint.RecordTypeA, "1.2.3.4")},
+			UpdateNew: []*endpoint.Endpoint{endpoint.NewEndpoint("update.example.org", endpoint.RecordTypeA, "5.6.7.8")},
+			Delete: []*endpoint.Endpoint{
+				endpoint.NewEndpoint("delete.example.org", endpoint.RecordTypeA, "1.2.3.4"),
+				endpoint.NewEndpoint("retype.example.org", endpoint.RecordTypeA, "1.2.3.4"),
+			},
+			Adopt: []*endpoint.Endpoint{endpoint.NewEndpoint("adopt.example.org", endpoint.RecordTypeA, "1.2.3.4")},
+		}
+	}
+	windows := func(specs ...string) changewindow.Windows {
+		ws, err := changewindow.ParseAll(specs)
+		require.NoError(t, err)
+		return ws
+	}
+
+	// without windows, or within a window, all the changes are applied
+	c := &Controller{}
+	changes := newChanges()
+	c.deferOutsideChangeWindows(changes)
+	assert.Equal(t, newChanges(), changes)
+
+	c.ChangeWindows = windows("0 0 31 2 * 1h", "* * * * * 1m")
+	changes = newChanges()
+	c.deferOutsideChangeWindows(changes)
+	assert.Equal(t, newChanges(), changes)
+
+	// out of the windows, only the creates not replacing a deleted record are applied
+	c.ChangeWindows = windows("0 0 31 2 * 1h")
+	c.ChangeWindowCreates = true
+	changes = newChanges()
+	c.deferOutsideChangeWindows(changes)
+	assert.Equal(t, &plan.Changes{
+		Create: []*endpoint.Endpoint{endpoint.NewEndpoint("create.example.org", endpoint.RecordTypeA, "1.2.3.4")},
+		Adopt:  []*endpoint.Endpoint{endpoint.NewEndpoint("adopt.example.org", endpoint.RecordTypeA, "1.2.3.4")},
+	}, changes)
+
+	c.ChangeWindowCreates = false
+	changes = newChanges()
+	c.deferOutsideChangeWindows(changes)
+	assert.False(t, changes.HasChanges())
+}
@@ -20,6 +20,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 	"sync"
 	"time"
 
@@ -27,6 +28,13 @@ import (
 	log "github.com/sirupsen/logrus"
 
 	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/pkg/audit"
+	"sigs.k8s.io/external-dns/pkg/canary"
+	"sigs.k8s.io/external-dns/pkg/changewindow"
+	"sigs.k8s.io/external-dns/pkg/damping"
+	"sigs.k8s.io/external-dns/pkg/decisions"
+	"sigs.k8s.io/external-dns/pkg/dnscontrol"
+	"sigs.k8s.io/external-dns/pkg/logging"
 	"sigs.k8s.io/external-dns/pkg/metrics"
 	"sigs.k8s.io/external-dns/plan"
 	"sigs.k8s.io/external-dns/provider"
@@ -91,6 +99,88 @@ var (
 			Help:      "Number of reconcile loops ending up with no changes on the DNS provider side.",
 		},
 	)
+	controllerRepeatedChangesTotal = metrics.NewCounterWithOpts(
+		prometheus.CounterOpts{
+			Namespace: "external_dns",
+			Subsystem: "controller",
+			Name:      "repeated_changes_skipped_total",
+			Help:      "Number of changes not applied to the DNS provider as they were the same as those applied by the previous reconcile loop.",
+		},
+	)
+	controllerSyncTimeoutsTotal = metrics.NewCounterWithOpts(
+		prometheus.CounterOpts{
+			Namespace: "external_dns",
+			Subsystem: "controller",
+			Name:      "sync_timeouts_total",
+			Help:      "Number of reconcile loops aborted as they lasted longer than the sync timeout.",
+		},
+	)
+	planChurn = metrics.NewGaugeWithOpts(
+		prometheus.GaugeOpts{
+			Namespace: "external_dns",
+			Subsystem: "controller",
+			Name:      "plan_churn",
+			Help:      "Number of changes added to or removed from the plan by the last synchronization, compared to the previous one.",
+		},
+	)
+	planChurnTotal = metrics.NewCounterVecWithOpts(
+		prometheus.CounterOpts{
+			Namespace: "external_dns",
+			Subsystem: "controller",
+			Name:      "plan_churn_changes_total",
+			Help:      "Number of changes added to or removed from the plan compared to the previous synchronization, per delta: added or removed (vector).",
+		},
+		[]string{"delta"},
+	)
+	controllerChangeThresholdExceededTotal = metrics.NewCounterWithOpts(
+		prometheus.CounterOpts{
+			Namespace: "external_dns",
+			Subsystem: "controller",
+			Name:      "change_threshold_exceeded_total",
+			Help:      "Number of plans not applied to the DNS provider as they would delete or update more records than allowed.",
+		},
+	)
+	controllerDeferredUpdatesTotal = metrics.NewCounterWithOpts(
+		prometheus.CounterOpts{
+			Namespace: "external_dns",
+			Subsystem: "controller",
+			Name:      "deferred_updates_total",
+			Help:      "Number of updates of the TTL or the comment of records deferred as the write budget was exhausted.",
+		},
+	)
+	writeBudgetRemaining = metrics.NewGaugeWithOpts(
+		prometheus.GaugeOpts{
+			Namespace: "external_dns",
+			Subsystem: "controller",
+			Name:      "write_budget_remaining",
+			Help:      "Number of records which can still be written to the DNS provider within the write budget of the last hour.",
+		},
+	)
+	changeWindowOpen = metrics.NewGaugeWithOpts(
+		prometheus.GaugeOpts{
+			Namespace: "external_dns",
+			Subsystem: "controller",
+			Name:      "change_window_open",
+			Help:      "Whether the updates and deletes of records are applied, 1 within the change windows or without them, 0 otherwise.",
+		},
+	)
+	changeWindowDeferredTotal = metrics.NewCounterWithOpts(
+		prometheus.CounterOpts{
+			Namespace: "external_dns",
+			Subsystem: "controller",
+			Name:      "change_window_deferred_changes_total",
+			Help:      "Number of changes of records deferred as they were planned out of the change windows.",
+		},
+	)
+	propagationDuration = metrics.NewHistogramWithOpts(
+		prometheus.HistogramOpts{
+			Namespace: "external_dns",
+			Subsystem: "controller",
+			Name:      "propagation_duration_seconds",
+			Help:      "Time from the observation of a change of the source objects to the successful application of the resulting changes by the DNS provider.",
+			Buckets:   []float64{1, 5, 10, 15, 30, 60, 120, 300, 600, 1800},
+		},
+	)
 	deprecatedRegistryErrors = metrics.NewCounterWithOpts(
 		prometheus.CounterOpts{
 			Subsystem: "registry",
@@ -136,6 +226,45 @@ var (
 		[]string{"record_type"},
 	)
 
+	sourceObjectRecords = metrics.NewGaugedVectorOpts(
+		prometheus.GaugeOpts{
+			Namespace: "external_dns",
+			Subsystem: "source",
+			Name:      "object_records",
+			Help:      "Whether the records of each source object exist in the registry (1) or not (0), with the owner of the registry record, when the source object metrics are enabled (vector).",
+		},
+		[]string{"dns_name", "record_type", "kind", "namespace", "name", "owner"},
+	)
+
+	zoneLastSyncTimestamp = metrics.NewGaugedVectorOpts(
+		prometheus.GaugeOpts{
+			Namespace: "external_dns",
+			Subsystem: "zone",
+			Name:      "last_sync_timestamp_seconds",
+			Help:      "Timestamp of the last successful sync of each zone with the DNS provider (vector).",
+		},
+		[]string{"zone"},
+	)
+
+	zoneRecordsManaged = metrics.NewGaugedVectorOpts(
+		prometheus.GaugeOpts{
+			Namespace: "external_dns",
+			Subsystem: "zone",
+			Name:      "records_managed",
+			Help:      "Number of records of each zone owned by the registry after its last successful sync (vector).",
+		},
+		[]string{"zone"},
+	)
+
+	reconciliationPaused = metrics.NewGaugeWithOpts(
+		prometheus.GaugeOpts{
+			Namespace: "external_dns",
+			Subsystem: "controller",
+			Name:      "paused",
+			Help:      "Whether the reconciliation is paused by the pause ConfigMap (1) or not (0).",
+		},
+	)
+
 	consecutiveSoftErrors = metrics.NewGaugeWithOpts(
 		prometheus.GaugeOpts{
 			Namespace: "external_dns",
@@ -156,12 +285,29 @@ func init() {
 	metrics.RegisterMetric.MustRegister(deprecatedRegistryErrors)
 	metrics.RegisterMetric.MustRegister(deprecatedSourceErrors)
 	metrics.RegisterMetric.MustRegister(controllerNoChangesTotal)
+	metrics.RegisterMetric.MustRegister(controllerRepeatedChangesTotal)
+	metrics.RegisterMetric.MustRegister(controllerSyncTimeoutsTotal)
+	metrics.RegisterMetric.MustRegister(planChurn)
+	metrics.RegisterMetric.MustRegister(planChurnTotal)
+	metrics.RegisterMetric.MustRegister(controllerChangeThresholdExceededTotal)
+	metrics.RegisterMetric.MustRegister(controllerDeferredUpdatesTotal)
+	metrics.RegisterMetric.MustRegister(writeBudgetRemaining)
+	metrics.RegisterMetric.MustRegister(changeWindowOpen)
+	metrics.RegisterMetric.MustRegister(changeWindowDeferredTotal)
+	metrics.RegisterMetric.MustRegister(propagationDuration)
 
 	metrics.RegisterMetric.MustRegister(registryRecords)
 	metrics.RegisterMetric.MustRegister(sourceRecords)
 	metrics.RegisterMetric.MustRegister(verifiedRecords)
+	metrics.RegisterMetric.MustRegister(sourceObjectRecords)
+	metrics.RegisterMetric.MustRegister(zoneLastSyncTimestamp)
+	metrics.RegisterMetric.MustRegister(zoneRecordsManaged)
+	metrics.RegisterMetric.MustRegister(zoneLimitRejectedCreatesTotal)
+	metrics.RegisterMetric.MustRegister(orphanedRecords)
+	metrics.RegisterMetric.MustRegister(orphanedRecordsDeletedTotal)
 
 	metrics.RegisterMetric.MustRegister(consecutiveSoftErrors)
+	metrics.RegisterMetric.MustRegister(reconciliationPaused)
 }
 
 // Controller is responsible for orchestrating the different components.
@@ -173,6 +319,9 @@ func init() {
 type Controller struct {
 	Source   source.Source
 	Registry registry.Registry
+	// OwnerIDTemplate derives the owner IDs of the records from the owner ID of the registry and
+	// their resources, nil to own them all by the owner ID of the registry
+	OwnerIDTemplate *endpoint.OwnerIDTemplate
 	// The policy that defines which change to DNS records is allowed
 	Policy plan.Policy
 	// The interval between individual synchronizations
@@ -185,24 +334,147 @@ type Controller struct {
 	runAtMutex sync.Mutex
 	// The lastRunAt used for throttling and batching reconciliation
 	lastRunAt time.Time
+	// The changedAt is when the earliest change of the sources not reconciled yet was observed
+	changedAt time.Time
 	// MangedRecordTypes are DNS record types that will be considered for management.
 	ManagedRecordTypes []string
 	// ExcludeRecordTypes are DNS record types that will be excluded from management.
 	ExcludeRecordTypes []string
 	// MinEventSyncInterval is used as a window for batching events
 	MinEventSyncInterval time.Duration
+	// DenyWildcardRecords leaves wildcard records alone: they are neither created, updated nor deleted
+	DenyWildcardRecords bool
+	// StreamRecords reconciles the zones one at a time, so that the records of all the zones
+	// aren't held in memory at once
+	StreamRecords bool
+	// RepeatedChangesBackoff is the duration during which the changes that are the same as those
+	// applied by the previous synchronization are not applied again, disabled if zero
+	RepeatedChangesBackoff time.Duration
+	// MaxDeletionsPerSync is the maximum number of records a plan may delete, unlimited if zero
+	MaxDeletionsPerSync int
+	// MaxChangePercentage is the maximum percentage of the owned records a plan may delete or
+	// update, unlimited if zero
+	MaxChangePercentage int
+	// MaxRecordsPerZone is the maximum number of records of a zone, beyond which the records
+	// aren't created, unlimited if zero
+	MaxRecordsPerZone int
+	// PauseSwitch pauses the reconciliation: the changes are planned but not applied
+	PauseSwitch PauseSwitch
+	// PlanStore persists the last plan, whose changes are logged again after a restart if nil
+	PlanStore PlanStore
+	// The planHistory is the last plan, only the changes to it are logged
+	planHistory planHistory
+	// SourceObjectMetrics exposes a metric per record of each source object, which maps the DNS
+	// names to the objects requesting them and to the owner of their records
+	SourceObjectMetrics bool
+	// BackupDir is the directory where a snapshot of the owned records is written after each
+	// successful synchronization, disabled if empty
+	BackupDir string
+	// AdoptExistingRecords takes the ownership of the existing records without an owner which
+	// match the desired ones
+	AdoptExistingRecords bool
+	// IgnoreTTLDrift doesn't update the records whose TTL only differs from the desired one
+	IgnoreTTLDrift bool
+	// ExternalOwnerMarkers mark the records managed by another tool, which are never changed
+	ExternalOwnerMarkers []string
+	// WriteBudget is the number of records which can be written to the provider per hour before
+	// the updates only changing their TTL or comment are deferred, unlimited if zero
+	WriteBudget int
+	// The writes are the records written to the provider within the write budget window
+	writes []budgetWrites
+	// ChangeWindows are the maintenance windows out of which the updates and deletes of records
+	// are deferred, always applied if empty
+	ChangeWindows changewindow.Windows
+	// ChangeWindowLocation is the time zone of the change windows
+	ChangeWindowLocation *time.Location
+	// ChangeWindowCreates applies the creates out of the change windows too
+	ChangeWindowCreates bool
+	// Canary stages the creates and updates in the canary zone before applying them, disabled if nil
+	Canary *canary.Stager
+	// The Audit sink receives the changes applied to the provider, disabled if nil
+	Audit audit.Sink
+	// Capabilities are those of the provider, the changes it can't apply are rejected
+	Capabilities provider.Capabilities
+	// FilterDecisions records why the desired records were excluded, nil not to record them
+	FilterDecisions *decisions.Recorder
+	// DNSControl keeps the desired records for the DNSControl export, nil not to keep them
+	DNSControl *dnscontrol.Exporter
+	// MissingZones remembers the names matching no zone of the provider, whose records are not
+	// created again until its TTL has elapsed, nil not to remember them
+	MissingZones *provider.MissingZoneCache
+	// Damper holds the records whose targets flap at their last stable targets, nil not to hold them
+	Damper *damping.Damper
+	// JanitorInterval is the interval between the runs of the janitor, which finds the ownership
+	// records left without their record, disabled if zero
+	JanitorInterval time.Duration
+	// JanitorPolicy is what the janitor does with the orphaned ownership records: JanitorPolicyReport
+	// or JanitorPolicyDelete
+	JanitorPolicy string
+	// JanitorOwnerIDs are the other owners, e.g. decommissioned instances, whose orphaned ownership
+	// records are collected too
+	JanitorOwnerIDs []string
+	// DryRun only reports the orphaned ownership records the janitor would delete
+	DryRun bool
+	// The nextJanitorRunAt is when the janitor runs next
+	nextJanitorRunAt time.Time
+	// SyncTimeout is the maximum duration of a synchronization, whose registry and provider calls
+	// are canceled once it has elapsed, unlimited if zero
+	SyncTimeout time.Duration
+	// The inFlight is the step of the synchronization in flight, reported when it times out
+	inFlight syncStep
+	// The appliedChanges are the changes applied by the previous synchronization, per zone
+	appliedChanges map[string]appliedChanges
+	// The reconcileMutex serializes reconciliations and configuration reloads
+	reconcileMutex sync.Mutex
 }
 
 // RunOnce runs a single iteration of a reconciliation loop.
-func (c *Controller) RunOnce(ctx context.Context) error {
+func (c *Controller) RunOnce(ctx context.Context) (err error) {
+	c.reconcileMutex.Lock()
+	defer c.reconcileMutex.Unlock()
+
 	lastReconcileTimestamp.Gauge.SetToCurrentTime()
 
 	c.runAtMutex.Lock()
 	c.lastRunAt = time.Now()
 	c.runAtMutex.Unlock()
 
+	defer c.savePlan(ctx)
+
+	ctx, cancel := c.withSyncTimeout(ctx)
+	defer cancel()
+	defer func() { err = c.syncTimeoutError(ctx, err) }()
+
+	// the changes of the sources are reconciled by the next synchronization if this one fails or is paused
+	changedAt := c.takeChangedAt()
+	reconciled := false
+	defer func() {
+		if !reconciled {
+			c.restoreChangedAt(changedAt)
+		}
+	}()
+
+	paused, err := c.paused(ctx)
+	if err != nil {
+		return err
+	}
+
+	if c.StreamRecords {
+		applied, err := c.syncZones(ctx, paused)
+		if err != nil {
+			return err
+		}
+		if !paused {
+			reconciled = true
+			observePropagation(changedAt, applied, time.Now())
+		}
+		lastSyncTimestamp.Gauge.SetToCurrentTime()
+		return nil
+	}
+
 	regMetrics := newMetricsRecorder()
 
+	c.inFlight.set("reading the records of the registry")
 	regRecords, err := c.Registry.Records(ctx)
 	if err != nil {
 		registryErrorsTotal.Counter.Inc()
@@ -216,6 +488,7 @@ func (c *Controller) RunOnce(ctx context.Context) error {
 
 	ctx = context.WithValue(ctx, provider.RecordsContextKey, regRecords)
 
+	c.inFlight.set("reading the endpoints of the sources")
 	sourceEndpoints, err := c.Source.Endpoints(ctx)
 	if err != nil {
 		sourceErrorsTotal.Counter.Inc()
@@ -224,6 +497,7 @@ func (c *Controller) RunOnce(ctx context.Context) error {
 	}
 
 	sourceEndpointsTotal.Gauge.Set(float64(len(sourceEndpoints)))
+	c.checkProperties(sourceEndpoints)
 
 	sourceMetrics := newMetricsRecorder()
 	countAddressRecords(sourceMetrics, sourceEndpoints, sourceRecords)
@@ -231,41 +505,227 @@ func (c *Controller) RunOnce(ctx context.Context) error {
 	vaMetrics := newMetricsRecorder()
 	countMatchingAddressRecords(vaMetrics, sourceEndpoints, regRecords, verifiedRecords)
 
-	endpoints, err := c.Registry.AdjustEndpoints(sourceEndpoints)
+	// the record types follow the targets before the provider adjusts them, e.g. into aliases
+	endpoints, err := c.Registry.AdjustEndpoints(endpoint.WithSuitableRecordTypes(sourceEndpoints))
 	if err != nil {
 		return fmt.Errorf("adjusting endpoints: %w", err)
 	}
+	if c.SourceObjectMetrics {
+		sourceObjectRecords.Gauge.Reset()
+		recordSourceObjects(sourceObjectRecords, endpoints, regRecords)
+	}
 	registryFilter := c.Registry.GetDomainFilter()
 
+	currentRecords := regRecords
+	if c.DenyWildcardRecords {
+		currentRecords = withoutWildcardRecords(regRecords)
+		endpoints = withoutWildcardRecords(endpoints)
+	}
+	// the records are planned normalized, and applied as the provider and the sources returned them
+	normalizedRecords, currentOriginals := endpoint.NormalizedCopies(currentRecords)
+	normalizedEndpoints, desiredOriginals := endpoint.NormalizedCopies(endpoints)
+
+	var applied *plan.Changes
 	plan := &plan.Plan{
-		Policies:       []plan.Policy{c.Policy},
-		Current:        regRecords,
-		Desired:        endpoints,
-		DomainFilter:   endpoint.MatchAllDomainFilters{c.DomainFilter, registryFilter},
-		ManagedRecords: c.ManagedRecordTypes,
-		ExcludeRecords: c.ExcludeRecordTypes,
-		OwnerID:        c.Registry.OwnerID(),
+		Policies:             []plan.Policy{c.Policy},
+		Current:              normalizedRecords,
+		Desired:              normalizedEndpoints,
+		DomainFilter:         endpoint.MatchAllDomainFilters{c.DomainFilter, registryFilter},
+		ManagedRecords:       c.ManagedRecordTypes,
+		ExcludeRecords:       c.ExcludeRecordTypes,
+		OwnerID:              c.Registry.OwnerID(),
+		OwnerIDTemplate:      c.OwnerIDTemplate,
+		AdoptExisting:        c.AdoptExistingRecords,
+		IgnoreTTL:            c.IgnoreTTLDrift,
+		ExternalOwnerMarkers: c.ExternalOwnerMarkers,
+		FilterDecisions:      c.FilterDecisions,
 	}
 
-	plan = plan.Calculate()
+	// the zones of the registries that don't stream their records are listed only for the metrics
+	// and the limit of records per zone
+	c.inFlight.set("listing the zones")
+	zoneNames, err := registry.ZoneNames(ctx, c.Registry)
+	if err != nil {
+		log.Debugf("Not reporting the sync of the zones, they can't be listed: %v", err)
+	}
+	zones := provider.ZoneIDName{}
+	for _, name := range zoneNames {
+		zones.Add(name, name)
+	}
+	c.DNSControl.Update(zoneNames, endpoints)
 
-	if plan.Changes.HasChanges() {
-		err = c.Registry.ApplyChanges(ctx, plan.Changes)
+	plan = plan.Calculate()
+	c.rejectUnsupportedChanges(plan.Changes)
+	c.skipMissingZoneCreates(plan.Changes)
+	c.limitZoneGrowth(zones, plan.Changes, currentRecords)
+	c.deferNonUrgentUpdates(plan.Changes)
+	c.Damper.Hold(plan.Changes)
+	c.deferOutsideChangeWindows(plan.Changes)
+	c.logPlan(ctx, "", plan.Changes)
+
+	switch {
+	case !plan.Changes.HasChanges():
+		delete(c.appliedChanges, "")
+		controllerNoChangesTotal.Counter.Inc()
+		log.Info("All records are already up to date")
+	case paused:
+		logPausedChanges("", plan.Changes)
+	case c.skipRepeatedChanges("", plan.Changes):
+		// counted by the repeated changes metric
+	default:
+		if err := c.checkChangeThresholds(plan.Changes, currentRecords); err != nil {
+			return err
+		}
+		changes := withOriginalRecords(plan.Changes, currentOriginals, desiredOriginals)
+		err = c.applyChanges(ctx, "", changes)
 		if err != nil {
 			registryErrorsTotal.Counter.Inc()
 			deprecatedRegistryErrors.Counter.Inc()
 			return err
 		}
-	} else {
-		controllerNoChangesTotal.Counter.Inc()
-		log.Info("All records are already up to date")
+		c.recordAppliedChanges("", plan.Changes)
+		c.Damper.Record(plan.Changes)
+		applied = changes
 	}
 
+	if !paused {
+		synced := syncedRecords(currentRecords, c.Registry.OwnerID(), c.OwnerIDTemplate, applied)
+		c.backup(synced)
+		recordZoneSyncs(zoneNames, synced, time.Now())
+		reconciled = true
+		observePropagation(changedAt, applied != nil, time.Now())
+	}
 	lastSyncTimestamp.Gauge.SetToCurrentTime()
 
 	return nil
 }
 
+// withOriginalRecords returns a copy of the changes with the records as the provider and the
+// sources returned them instead of their normalized copies, so that the provider updates and
+// deletes its records as they are.
+func withOriginalRecords(changes *plan.Changes, current, desired map[*endpoint.Endpoint]*endpoint.Endpoint) *plan.Changes {
+	// the adopted records are copies of the current records
+	byKey := make(map[endpoint.EndpointKey]*endpoint.Endpoint, len(current))
+	for c, o := range current {
+		byKey[c.Key()] = o
+	}
+	adopted := make(map[*endpoint.Endpoint]*endpoint.Endpoint, len(changes.Adopt))
+	for _, ep := range changes.Adopt {
+		if o, ok := byKey[ep.Key()]; ok {
+			adopted[ep] = o
+		}
+	}
+
+	return &plan.Changes{
+		Create:    originalRecords(changes.Create, desired),
+		UpdateOld: originalRecords(changes.UpdateOld, current),
+		UpdateNew: originalRecords(changes.UpdateNew, desired),
+		Delete:    originalRecords(changes.Delete, current),
+		Adopt:     originalRecords(changes.Adopt, adopted),
+	}
+}
+
+// originalRecords returns copies of the records the normalized copies were made from, with the
+// labels set by the plan, such as their owner.
+func originalRecords(records []*endpoint.Endpoint, originals map[*endpoint.Endpoint]*endpoint.Endpoint) []*endpoint.Endpoint {
+	if records == nil {
+		return nil
+	}
+	result := make([]*endpoint.Endpoint, len(records))
+	for i, ep := range records {
+		result[i] = ep
+		if o, ok := originals[ep]; ok {
+			result[i] = o.DeepCopy()
+			result[i].Labels = ep.Labels
+		}
+	}
+	return result
+}
+
+// applyChanges applies the changes with the registry, in several calls when the provider
+// doesn't replace the records changing type atomically.
+func (c *Controller) applyChanges(ctx context.Context, zone string, changes *plan.Changes) error {
+	c.inFlight.set("staging the changes in the canary zone")
+	if err := c.Canary.Stage(ctx, changes); err != nil {
+		return err
+	}
+	batches := changes.Sequence(c.Capabilities.Batch)
+	if len(batches) > 1 {
+		log.Debugf("Applying the changes in %d batches, deleting the records replaced by records of another type first", len(batches))
+	}
+	for _, batch := range batches {
+		c.inFlight.set(applyingStep(zone, batch))
+		err := c.Registry.ApplyChanges(ctx, batch)
+		c.auditChanges(ctx, batch, err)
+		if err != nil {
+			return err
+		}
+		c.recordWrites(batch)
+	}
+	return nil
+}
+
+// appliedChanges describes changes applied to the DNS provider.
+type appliedChanges struct {
+	fingerprint string
+	appliedAt   time.Time
+}
+
+// skipRepeatedChanges returns whether the changes of a zone are the same as those applied by the
+// previous synchronization less than RepeatedChangesBackoff ago: applying them again would most
+// likely not change anything, as the provider did not reflect them.
+func (c *Controller) skipRepeatedChanges(zone string, changes *plan.Changes) bool {
+	if c.RepeatedChangesBackoff <= 0 {
+		return false
+	}
+	applied, ok := c.appliedChanges[zone]
+	if !ok || applied.fingerprint != changes.Fingerprint() || time.Since(applied.appliedAt) >= c.RepeatedChangesBackoff {
+		return false
+	}
+	controllerRepeatedChangesTotal.Counter.Inc()
+	log.Infof("No changes applied: they are the same as those applied at %s", applied.appliedAt.Format(time.RFC3339))
+	return true
+}
+
+// recordAppliedChanges remembers the changes applied to a zone by the synchronization.
+func (c *Controller) recordAppliedChanges(zone string, changes *plan.Changes) {
+	if c.RepeatedChangesBackoff <= 0 {
+		return
+	}
+	if c.appliedChanges == nil {
+		c.appliedChanges = map[string]appliedChanges{}
+	}
+	c.appliedChanges[zone] = appliedChanges{fingerprint: changes.Fingerprint(), appliedAt: time.Now()}
+}
+
+// checkChangeThresholds returns a soft error when the changes would delete more records than
+// MaxDeletionsPerSync, or delete and update more than MaxChangePercentage of the current records
+// owned by the registry: such plans usually come from a source that transiently lost its objects,
+// or from a misconfiguration, rather than from the intent of deleting a whole zone.
+func (c *Controller) checkChangeThresholds(changes *plan.Changes, current []*endpoint.Endpoint) error {
+	if c.MaxDeletionsPerSync > 0 && len(changes.Delete) > c.MaxDeletionsPerSync {
+		controllerChangeThresholdExceededTotal.Counter.Inc()
+		return provider.NewSoftErrorf("the plan would delete %d records, more than the maximum of %d: no changes applied", len(changes.Delete), c.MaxDeletionsPerSync)
+	}
+	if c.MaxChangePercentage <= 0 {
+		return nil
+	}
+
+	ownerID := c.Registry.OwnerID()
+	owned := 0
+	for _, ep := range current {
+		if ownerID == "" || c.OwnerIDTemplate.IsOwnedBy(ownerID, ep.Labels) {
+			owned++
+		}
+	}
+	changed := len(changes.Delete) + len(changes.UpdateNew)
+	if owned > 0 && changed*100 > c.MaxChangePercentage*owned {
+		controllerChangeThresholdExceededTotal.Counter.Inc()
+		return provider.NewSoftErrorf("the plan would delete or update %d of the %d owned records, more than the maximum of %d%%: no changes applied", changed, owned, c.MaxChangePercentage)
+	}
+	return nil
+}
+
 func earliest(r time.Time, times ...time.Time) time.Time {
 	for _, t := range times {
 		if t.Before(r) {
@@ -284,6 +744,19 @@ func latest(r time.Time, times ...time.Time) time.Time {
 	return r
 }
 
+// withoutWildcardRecords returns the endpoints that are not wildcard records.
+func withoutWildcardRecords(endpoints []*endpoint.Endpoint) []*endpoint.Endpoint {
+	filtered := make([]*endpoint.Endpoint, 0, len(endpoints))
+	for _, ep := range endpoints {
+		if strings.HasPrefix(ep.DNSName, "*.") {
+			logging.ForRecord(ep).Debugf("Skipping wildcard record %s %s: wildcard records are not managed", ep.DNSName, ep.RecordType)
+			continue
+		}
+		filtered = append(filtered, ep)
+	}
+	return filtered
+}
+
 // Counts the intersections of records in endpoint and registry.
 func countMatchingAddressRecords(rec *metricsRecorder, endpoints []*endpoint.Endpoint, registryRecords []*endpoint.Endpoint, metric metrics.GaugeVecMetric) {
 	recordsMap := make(map[string]map[string]struct{})
@@ -367,6 +840,11 @@ func (c *Controller) Run(ctx context.Context) {
 				consecutiveSoftErrors.Gauge.Set(0)
 			}
 		}
+		if c.shouldRunJanitor(time.Now()) {
+			if err := c.collectOrphanedRecords(ctx); err != nil {
+				log.Errorf("Failed to collect the orphaned ownership records: %v", err)
+			}
+		}
 		select {
 		case <-ticker.C:
 		case <-ctx.Done():
@@ -19,6 +19,7 @@ package controller
 import (
 	"context"
 	"errors"
+	"fmt"
 	"reflect"
 	"sort"
 	"sync"
@@ -32,6 +33,7 @@ import (
 	"sigs.k8s.io/external-dns/provider"
 	"sigs.k8s.io/external-dns/registry"
 
+	promtestutil "github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -484,6 +486,234 @@ func TestWhenMultipleControllerConsidersAllFilteredComain(t *testing.T) {
 	)
 }
 
+func TestControllerDeniesWildcardRecords(t *testing.T) {
+	source := new(testutils.MockSource)
+	source.On("Endpoints").Return([]*endpoint.Endpoint{
+		{DNSName: "*.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"1.2.3.4"}},
+		{DNSName: "www.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"1.2.3.4"}},
+	}, nil)
+
+	provider := &filteredMockProvider{
+		RecordsStore: []*endpoint.Endpoint{
+			{DNSName: "*.example.com", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"8.8.8.8"}},
+		},
+	}
+	r, err := registry.NewNoopRegistry(provider)
+	require.NoError(t, err)
+
+	ctrl := &Controller{
+		Source:              source,
+		Registry:            r,
+		Policy:              &plan.SyncPolicy{},
+		ManagedRecordTypes:  []string{endpoint.RecordTypeA},
+		DenyWildcardRecords: true,
+	}
+
+	require.NoError(t, ctrl.RunOnce(context.Background()))
+	require.Len(t, provider.ApplyChangesCalls, 1)
+	assert.Equal(t, []*endpoint.Endpoint{
+		{DNSName: "www.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"1.2.3.4"}},
+	}, provider.ApplyChangesCalls[0].Create)
+	assert.Empty(t, provider.ApplyChangesCalls[0].Delete)
+}
+
+func TestControllerNormalizesEndpoints(t *testing.T) {
+	source := new(testutils.MockSource)
+	source.On("Endpoints").Return([]*endpoint.Endpoint{
+		{DNSName: "www.example.org", RecordType: endpoint.RecordTypeAAAA, Targets: endpoint.Targets{"2001:db8::1", "2001:db8::2"}},
+		{DNSName: "api.example.org", RecordType: endpoint.RecordTypeCNAME, Targets: endpoint.Targets{"lb.example.com"}},
+	}, nil)
+
+	// the records only differ in their formatting
+	provider := &filteredMockProvider{
+		RecordsStore: []*endpoint.Endpoint{
+			{DNSName: "WWW.example.org.", RecordType: endpoint.RecordTypeAAAA, Targets: endpoint.Targets{"2001:DB8:0:0:0:0:0:2", "2001:db8::1"}},
+			{DNSName: "api.example.org", RecordType: endpoint.RecordTypeCNAME, Targets: endpoint.Targets{"LB.example.com."}},
+		},
+	}
+	r, err := registry.NewNoopRegistry(provider)
+	require.NoError(t, err)
+
+	ctrl := &Controller{
+		Source:             source,
+		Registry:           r,
+		Policy:             &plan.SyncPolicy{},
+		ManagedRecordTypes: []string{endpoint.RecordTypeAAAA, endpoint.RecordTypeCNAME},
+	}
+
+	require.NoError(t, ctrl.RunOnce(context.Background()))
+	assert.Empty(t, provider.ApplyChangesCalls)
+}
+
+func TestControllerChangesOriginalRecords(t *testing.T) {
+	source := new(testutils.MockSource)
+	source.On("Endpoints").Return([]*endpoint.Endpoint{
+		{DNSName: "www.example.org", RecordType: endpoint.RecordTypeAAAA, Targets: endpoint.Targets{"2001:db8::1"}},
+	}, nil)
+
+	provider := &filteredMockProvider{
+		RecordsStore: []*endpoint.Endpoint{
+			{DNSName: "WWW.example.org.", RecordType: endpoint.RecordTypeAAAA, Targets: endpoint.Targets{"2001:DB8:0:0:0:0:0:2"}},
+			{DNSName: "API.example.org.", RecordType: endpoint.RecordTypeAAAA, Targets: endpoint.Targets{"2001:DB8:0:0:0:0:0:3"}},
+		},
+	}
+	r, err := registry.NewNoopRegistry(provider)
+	require.NoError(t, err)
+
+	ctrl := &Controller{
+		Source:             source,
+		Registry:           r,
+		Policy:             &plan.SyncPolicy{},
+		ManagedRecordTypes: []string{endpoint.RecordTypeAAAA},
+	}
+
+	require.NoError(t, ctrl.RunOnce(context.Background()))
+	require.Len(t, provider.ApplyChangesCalls, 1)
+	changes := provider.ApplyChangesCalls[0]
+	// the records are updated and deleted as the provider returned them
+	require.Len(t, changes.UpdateOld, 1)
+	assert.Equal(t, "WWW.example.org.", changes.UpdateOld[0].DNSName)
+	assert.Equal(t, endpoint.Targets{"2001:DB8:0:0:0:0:0:2"}, changes.UpdateOld[0].Targets)
+	require.Len(t, changes.Delete, 1)
+	assert.Equal(t, "API.example.org.", changes.Delete[0].DNSName)
+	assert.Equal(t, "WWW.example.org.", provider.RecordsStore[0].DNSName)
+}
+
+func TestControllerSkipsRepeatedChanges(t *testing.T) {
+	source := new(testutils.MockSource)
+	source.On("Endpoints").Return([]*endpoint.Endpoint{
+		{DNSName: "www.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"1.2.3.4"}},
+	}, nil)
+
+	// the provider never reflects the changes
+	provider := &filteredMockProvider{}
+	r, err := registry.NewNoopRegistry(provider)
+	require.NoError(t, err)
+
+	ctrl := &Controller{
+		Source:                 source,
+		Registry:               r,
+		Policy:                 &plan.SyncPolicy{},
+		ManagedRecordTypes:     []string{endpoint.RecordTypeA},
+		RepeatedChangesBackoff: time.Hour,
+	}
+
+	require.NoError(t, ctrl.RunOnce(context.Background()))
+	noChanges := promtestutil.ToFloat64(controllerNoChangesTotal.Counter)
+	repeated := promtestutil.ToFloat64(controllerRepeatedChangesTotal.Counter)
+	require.NoError(t, ctrl.RunOnce(context.Background()))
+	assert.Len(t, provider.ApplyChangesCalls, 1)
+	// the skipped changes are not counted as a synchronization without changes
+	assert.InDelta(t, noChanges, promtestutil.ToFloat64(controllerNoChangesTotal.Counter), 0)
+	assert.InDelta(t, repeated+1, promtestutil.ToFloat64(controllerRepeatedChangesTotal.Counter), 0)
+
+	// the changes are applied again once the backoff expires
+	applied := ctrl.appliedChanges[""]
+	applied.appliedAt = time.Now().Add(-time.Hour)
+	ctrl.appliedChanges[""] = applied
+	require.NoError(t, ctrl.RunOnce(context.Background()))
+	assert.Len(t, provider.ApplyChangesCalls, 2)
+
+	// the applied changes are forgotten once the records are up to date
+	provider.RecordsStore = []*endpoint.Endpoint{
+		{DNSName: "www.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"1.2.3.4"}},
+	}
+	require.NoError(t, ctrl.RunOnce(context.Background()))
+	assert.Empty(t, ctrl.appliedChanges)
+
+	// without backoff, the changes are applied on every synchronization
+	provider.RecordsStore = nil
+	ctrl.RepeatedChangesBackoff = 0
+	require.NoError(t, ctrl.RunOnce(context.Background()))
+	require.NoError(t, ctrl.RunOnce(context.Background()))
+	assert.Len(t, provider.ApplyChangesCalls, 4)
+}
+
+func TestControllerChangeThresholds(t *testing.T) {
+	var current, desired []*endpoint.Endpoint
+	for i := range 10 {
+		ep := &endpoint.Endpoint{DNSName: fmt.Sprintf("www%d.example.org", i), RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"1.2.3.4"}}
+		current = append(current, ep)
+		// the source lost 3 of its objects
+		if i >= 3 {
+			desired = append(desired, ep)
+		}
+	}
+	source := new(testutils.MockSource)
+	source.On("Endpoints").Return(desired, nil)
+
+	for _, tc := range []struct {
+		name                string
+		maxDeletionsPerSync int
+		maxChangePercentage int
+		applied             bool
+	}{
+		{name: "unlimited", applied: true},
+		{name: "too many deletions", maxDeletionsPerSync: 2},
+		{name: "allowed deletions", maxDeletionsPerSync: 3, applied: true},
+		{name: "too many changes", maxChangePercentage: 20},
+		{name: "allowed changes", maxChangePercentage: 30, applied: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			prov := &filteredMockProvider{RecordsStore: current}
+			r, err := registry.NewNoopRegistry(prov)
+			require.NoError(t, err)
+
+			ctrl := &Controller{
+				Source:              source,
+				Registry:            r,
+				Policy:              &plan.SyncPolicy{},
+				ManagedRecordTypes:  []string{endpoint.RecordTypeA},
+				MaxDeletionsPerSync: tc.maxDeletionsPerSync,
+				MaxChangePercentage: tc.maxChangePercentage,
+			}
+
+			err = ctrl.RunOnce(context.Background())
+			if tc.applied {
+				require.NoError(t, err)
+				require.Len(t, prov.ApplyChangesCalls, 1)
+				assert.Len(t, prov.ApplyChangesCalls[0].Delete, 3)
+			} else {
+				require.ErrorIs(t, err, provider.SoftError)
+				assert.Empty(t, prov.ApplyChangesCalls)
+			}
+		})
+	}
+}
+
+func TestControllerAdoptExistingRecords(t *testing.T) {
+	source := new(testutils.MockSource)
+	source.On("Endpoints").Return([]*endpoint.Endpoint{
+		{DNSName: "www.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"1.2.3.4"}},
+	}, nil)
+
+	for _, adopt := range []bool{false, true} {
+		prov := &filteredMockProvider{RecordsStore: []*endpoint.Endpoint{
+			{DNSName: "www.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"1.2.3.4"}},
+		}}
+		r, err := registry.NewTXTRegistry(prov, "", "", "owner", 0, "", []string{endpoint.RecordTypeA}, nil, false, nil, true)
+		require.NoError(t, err)
+
+		ctrl := &Controller{
+			Source:               source,
+			Registry:             r,
+			Policy:               &plan.SyncPolicy{},
+			ManagedRecordTypes:   []string{endpoint.RecordTypeA},
+			AdoptExistingRecords: adopt,
+		}
+		require.NoError(t, ctrl.RunOnce(context.Background()))
+		if !adopt {
+			assert.Empty(t, prov.ApplyChangesCalls)
+			continue
+		}
+		// only the ownership record is created
+		require.Len(t, prov.ApplyChangesCalls, 1)
+		require.Len(t, prov.ApplyChangesCalls[0].Create, 1)
+		assert.Equal(t, endpoint.RecordTypeTXT, prov.ApplyChangesCalls[0].Create[0].RecordType)
+		assert.Equal(t, "a-www.example.org", prov.ApplyChangesCalls[0].Create[0].DNSName)
+	}
+}
+
 type toggleRegistry struct {
 	registry.NoopRegistry
 	failCount   int
@@ -553,3 +783,107 @@ func TestToggleRegistry(t *testing.T) {
 	r.failCountMu.Unlock()
 	assert.Equal(t, toggleRegistryFailureCount, finalCount, "failCount should be at least %d", toggleRegistryFailureCount)
 }
+
+func TestControllerSequencesTypeChanges(t *testing.T) {
+	source := new(testutils.MockSource)
+	source.On("Endpoints").Return([]*endpoint.Endpoint{
+		endpoint.NewEndpoint("web.example.org", endpoint.RecordTypeA, "1.2.3.4"),
+	}, nil)
+
+	for _, batch := range []bool{false, true} {
+		prov := &filteredMockProvider{RecordsStore: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("web.example.org", endpoint.RecordTypeCNAME, "lb.example.com"),
+		}}
+		r, err := registry.NewNoopRegistry(prov)
+		require.NoError(t, err)
+
+		ctrl := &Controller{
+			Source:             source,
+			Registry:           r,
+			Policy:             &plan.SyncPolicy{},
+			ManagedRecordTypes: []string{endpoint.RecordTypeA, endpoint.RecordTypeCNAME},
+			Capabilities:       provider.Capabilities{Batch: batch},
+		}
+		require.NoError(t, ctrl.RunOnce(context.Background()))
+
+		if batch {
+			// the provider replaces the CNAME atomically
+			require.Len(t, prov.ApplyChangesCalls, 1)
+			assert.Len(t, prov.ApplyChangesCalls[0].Delete, 1)
+			assert.Len(t, prov.ApplyChangesCalls[0].Create, 1)
+			continue
+		}
+		// the CNAME is deleted before the A record is created
+		require.Len(t, prov.ApplyChangesCalls, 2)
+		require.Len(t, prov.ApplyChangesCalls[0].Delete, 1)
+		assert.Equal(t, endpoint.RecordTypeCNAME, prov.ApplyChangesCalls[0].Delete[0].RecordType)
+		assert.Empty(t, prov.ApplyChangesCalls[0].Create)
+		require.Len(t, prov.ApplyChangesCalls[1].Create, 1)
+		assert.Equal(t, endpoint.RecordTypeA, prov.ApplyChangesCalls[1].Create[0].RecordType)
+		assert.Empty(t, prov.ApplyChangesCalls[1].Delete)
+	}
+}
+
+func TestControllerSwitchesRecordTypeWithTargets(t *testing.T) {
+	source := new(testutils.MockSource)
+	source.On("Endpoints").Return([]*endpoint.Endpoint{
+		endpoint.NewEndpoint("web.example.org", endpoint.RecordTypeA, "lb.example.com"),
+	}, nil)
+
+	prov := &filteredMockProvider{RecordsStore: []*endpoint.Endpoint{
+		endpoint.NewEndpoint("web.example.org", endpoint.RecordTypeA, "1.2.3.4"),
+		endpoint.NewEndpoint("a-web.example.org", endpoint.RecordTypeTXT, "\"heritage=external-dns,external-dns/owner=owner\""),
+	}}
+	r, err := registry.NewTXTRegistry(prov, "", "", "owner", 0, "", []string{endpoint.RecordTypeA, endpoint.RecordTypeCNAME}, nil, false, nil, true)
+	require.NoError(t, err)
+
+	ctrl := &Controller{
+		Source:             source,
+		Registry:           r,
+		Policy:             &plan.SyncPolicy{},
+		ManagedRecordTypes: []string{endpoint.RecordTypeA, endpoint.RecordTypeCNAME},
+	}
+	require.NoError(t, ctrl.RunOnce(context.Background()))
+
+	// the A record and its ownership record are replaced by a CNAME record and its own
+	require.Len(t, prov.ApplyChangesCalls, 2)
+	deleted := map[string]string{}
+	for _, ep := range prov.ApplyChangesCalls[0].Delete {
+		deleted[ep.DNSName] = ep.RecordType
+	}
+	assert.Equal(t, map[string]string{"web.example.org": endpoint.RecordTypeA, "a-web.example.org": endpoint.RecordTypeTXT}, deleted)
+	created := map[string]string{}
+	for _, ep := range prov.ApplyChangesCalls[1].Create {
+		created[ep.DNSName] = ep.RecordType
+	}
+	assert.Equal(t, map[string]string{"web.example.org": endpoint.RecordTypeCNAME, "cname-web.example.org": endpoint.RecordTypeTXT}, created)
+	assert.Empty(t, prov.ApplyChangesCalls[1].UpdateNew)
+}
+
+func TestControllerSkipsMissingZoneCreates(t *testing.T) {
+	missingZones := provider.NewMissingZoneCache(time.Hour)
+	missingZones.Report("missing.example.org")
+
+	source := new(testutils.MockSource)
+	source.On("Endpoints").Return([]*endpoint.Endpoint{
+		endpoint.NewEndpoint("missing.example.org", endpoint.RecordTypeA, "1.2.3.4"),
+		endpoint.NewEndpoint("web.example.org", endpoint.RecordTypeA, "1.2.3.4"),
+	}, nil)
+
+	prov := &filteredMockProvider{}
+	r, err := registry.NewNoopRegistry(prov)
+	require.NoError(t, err)
+
+	ctrl := &Controller{
+		Source:             source,
+		Registry:           r,
+		Policy:             &plan.SyncPolicy{},
+		ManagedRecordTypes: []string{endpoint.RecordTypeA},
+		MissingZones:       missingZones,
+	}
+	require.NoError(t, ctrl.RunOnce(context.Background()))
+
+	require.Len(t, prov.ApplyChangesCalls, 1)
+	require.Len(t, prov.ApplyChangesCalls[0].Create, 1)
+	assert.Equal(t, "web.example.org", prov.ApplyChangesCalls[0].Create[0].DNSName)
+}
@@ -0,0 +1,163 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/miekg/dns"
+	log "github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/pkg/apis/externaldns"
+)
+
+// convertSkippedTypes are the types of the resource records left out of the conversion of a zone
+// file: those managed by the DNS provider or its DNSSEC signer rather than ExternalDNS.
+var convertSkippedTypes = map[uint16]bool{
+	dns.TypeSOA:        true,
+	dns.TypeRRSIG:      true,
+	dns.TypeNSEC:       true,
+	dns.TypeNSEC3:      true,
+	dns.TypeNSEC3PARAM: true,
+	dns.TypeDNSKEY:     true,
+	dns.TypeCDS:        true,
+	dns.TypeCDNSKEY:    true,
+}
+
+// convert runs the convert command, e.g. `external-dns convert zonefile --to-crd zone.db`.
+func convert(cfg *externaldns.Config, stdin io.Reader, stdout io.Writer) error {
+	if !cfg.ConvertToCRD {
+		return fmt.Errorf("--to-crd is the only output format")
+	}
+
+	r := stdin
+	if cfg.ConvertZoneFile != "-" {
+		f, err := os.Open(cfg.ConvertZoneFile)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		r = f
+	}
+	records, err := parseZoneFile(r, cfg.ConvertZoneFile, cfg.ConvertOrigin)
+	if err != nil {
+		return err
+	}
+
+	name := cfg.ConvertName
+	if name == "" {
+		name = "zonefile"
+		if cfg.ConvertOrigin != "" {
+			name = strings.ReplaceAll(strings.Trim(strings.ToLower(cfg.ConvertOrigin), "."), ".", "-")
+		}
+	}
+	content, err := marshalDNSEndpoints(records, metav1.ObjectMeta{Name: name, Namespace: cfg.Namespace})
+	if err != nil {
+		return err
+	}
+	_, err = stdout.Write(content)
+	return err
+}
+
+// parseZoneFile returns the records of a zone file, with a target per resource record of the
+// same name, type and set identifier. The SOA and DNSSEC resource records and the NS resource
+// records of the origin are left out.
+func parseZoneFile(r io.Reader, file, origin string) ([]*endpoint.Endpoint, error) {
+	if origin != "" {
+		origin = dns.Fqdn(origin)
+	}
+	zp := dns.NewZoneParser(r, origin, file)
+	skipped := map[string]int{}
+	// the apexes are the origin and the names of the SOA resource records
+	apexes := map[string]bool{strings.ToLower(strings.TrimSuffix(origin, ".")): true}
+	byKey := map[endpoint.EndpointKey]*endpoint.Endpoint{}
+	var records []*endpoint.Endpoint
+	for rr, ok := zp.Next(); ok; rr, ok = zp.Next() {
+		hdr := rr.Header()
+		if hdr.Rrtype == dns.TypeSOA {
+			apexes[strings.ToLower(strings.TrimSuffix(hdr.Name, "."))] = true
+		}
+		if convertSkippedTypes[hdr.Rrtype] {
+			skipped[dns.TypeToString[hdr.Rrtype]]++
+			continue
+		}
+
+		key := endpoint.EndpointKey{
+			DNSName:    strings.ToLower(strings.TrimSuffix(hdr.Name, ".")),
+			RecordType: dns.TypeToString[hdr.Rrtype],
+		}
+		if comment, ok := strings.CutPrefix(zp.Comment(), setIdentifierComment); ok {
+			key.SetIdentifier = strings.TrimSpace(comment)
+		}
+		ep, ok := byKey[key]
+		if !ok {
+			ep = endpoint.NewEndpointWithTTL(key.DNSName, key.RecordType, endpoint.TTL(hdr.Ttl)).WithSetIdentifier(key.SetIdentifier)
+			byKey[key] = ep
+			records = append(records, ep)
+		}
+		// the TTL of a record is that of its resource records, which should all be the same
+		ep.RecordTTL = min(ep.RecordTTL, endpoint.TTL(hdr.Ttl))
+		ep.Targets = append(ep.Targets, zoneFileTarget(rr))
+	}
+	if err := zp.Err(); err != nil {
+		return nil, fmt.Errorf("failed to parse the zone file: %w", err)
+	}
+	records = slices.DeleteFunc(records, func(ep *endpoint.Endpoint) bool {
+		if ep.RecordType == endpoint.RecordTypeNS && apexes[ep.DNSName] {
+			skipped[ep.RecordType] += len(ep.Targets)
+			return true
+		}
+		return false
+	})
+	for rrType, count := range skipped {
+		log.Warnf("Skipped %d %s resource records, which are not managed by ExternalDNS", count, rrType)
+	}
+
+	sortRecords(records)
+	return records, nil
+}
+
+// zoneFileTarget returns the target of an endpoint for a resource record: the domain names
+// without their trailing dot, as written by the sources, and the TXT character strings joined.
+func zoneFileTarget(rr dns.RR) string {
+	switch rr := rr.(type) {
+	case *dns.CNAME:
+		return strings.TrimSuffix(rr.Target, ".")
+	case *dns.NS:
+		return strings.TrimSuffix(rr.Ns, ".")
+	case *dns.PTR:
+		return strings.TrimSuffix(rr.Ptr, ".")
+	case *dns.MX:
+		return fmt.Sprintf("%d %s", rr.Preference, strings.TrimSuffix(rr.Mx, "."))
+	case *dns.SRV:
+		return fmt.Sprintf("%d %d %d %s", rr.Priority, rr.Weight, rr.Port, strings.TrimSuffix(rr.Target, "."))
+	case *dns.TXT:
+		return unescapeTXT(strings.Join(rr.Txt, ""))
+	}
+	return strings.TrimPrefix(rr.String(), rr.Header().String())
+}
+
+// unescapeTXT reverts the escaping of the quotes and backslashes of a TXT character string.
+func unescapeTXT(s string) string {
+	return strings.NewReplacer(`\"`, `"`, `\\`, `\`).Replace(s)
+}
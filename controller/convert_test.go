@@ -0,0 +1,121 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/pkg/apis/externaldns"
+)
+
+const testZoneFile = `$ORIGIN example.org.
+$TTL 3600
+@	IN	SOA	ns1.example.org. admin.example.org. 1 7200 3600 1209600 3600
+@	IN	NS	ns1.example.org.
+@	IN	MX	10 mail
+www	60	IN	A	1.2.3.4
+www	60	IN	A	5.6.7.8
+txt	IN	TXT	"hello \"world\"" " again"
+api	IN	CNAME	lb.example.net.	; set-identifier: eu
+api	IN	CNAME	lb-us.example.net.	; set-identifier: us
+sub	IN	NS	ns.sub.example.org.
+`
+
+func TestParseZoneFile(t *testing.T) {
+	records, err := parseZoneFile(strings.NewReader(testZoneFile), "zone.db", "")
+	require.NoError(t, err)
+	assert.Equal(t, []*endpoint.Endpoint{
+		endpoint.NewEndpointWithTTL("api.example.org", endpoint.RecordTypeCNAME, 3600, "lb.example.net").WithSetIdentifier("eu"),
+		endpoint.NewEndpointWithTTL("api.example.org", endpoint.RecordTypeCNAME, 3600, "lb-us.example.net").WithSetIdentifier("us"),
+		endpoint.NewEndpointWithTTL("example.org", endpoint.RecordTypeMX, 3600, "10 mail.example.org"),
+		endpoint.NewEndpointWithTTL("sub.example.org", endpoint.RecordTypeNS, 3600, "ns.sub.example.org"),
+		endpoint.NewEndpointWithTTL("txt.example.org", endpoint.RecordTypeTXT, 3600, `hello "world" again`),
+		endpoint.NewEndpointWithTTL("www.example.org", endpoint.RecordTypeA, 60, "1.2.3.4", "5.6.7.8"),
+	}, records)
+}
+
+func TestParseZoneFileRelativeNames(t *testing.T) {
+	records, err := parseZoneFile(strings.NewReader("www 60 IN A 1.2.3.4\n"), "zone.db", "example.org")
+	require.NoError(t, err)
+	assert.Equal(t, []*endpoint.Endpoint{endpoint.NewEndpointWithTTL("www.example.org", endpoint.RecordTypeA, 60, "1.2.3.4")}, records)
+
+	_, err = parseZoneFile(strings.NewReader("www 60 IN A not-an-ip\n"), "zone.db", "example.org")
+	require.ErrorContains(t, err, "failed to parse the zone file")
+}
+
+func TestParseZoneFileExported(t *testing.T) {
+	exported := []*endpoint.Endpoint{
+		endpoint.NewEndpointWithTTL("api.example.org", endpoint.RecordTypeCNAME, 300, "lb.example.net").WithSetIdentifier("eu"),
+		endpoint.NewEndpointWithTTL("example.org", endpoint.RecordTypeMX, 300, "10 mail.example.org"),
+		endpoint.NewEndpointWithTTL("txt.example.org", endpoint.RecordTypeTXT, 300, `a \ "b"`+strings.Repeat("c", 300)),
+		endpoint.NewEndpointWithTTL("www.example.org", endpoint.RecordTypeA, 60, "1.2.3.4"),
+	}
+	content, err := marshalZoneFile(exported, []string{"example.org"})
+	require.NoError(t, err)
+	records, err := parseZoneFile(bytes.NewReader(content), "zone.db", "")
+	require.NoError(t, err)
+	assert.Equal(t, exported, records)
+}
+
+func TestConvertZoneFileToCRD(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "zone.db")
+	require.NoError(t, os.WriteFile(path, []byte("www 60 IN A 1.2.3.4\n"), 0o644))
+
+	var out bytes.Buffer
+	require.NoError(t, convert(convertConfig(t, "convert", "zonefile", "--to-crd", "--origin=example.org", "--namespace=dns", path), nil, &out))
+	assert.Equal(t, `apiVersion: externaldns.k8s.io/v1alpha1
+kind: DNSEndpoint
+metadata:
+  creationTimestamp: null
+  name: example-org-0
+  namespace: dns
+spec:
+  endpoints:
+  - dnsName: www.example.org
+    recordTTL: 60
+    recordType: A
+    targets:
+    - 1.2.3.4
+status: {}
+`, out.String())
+
+	out.Reset()
+	require.NoError(t, convert(convertConfig(t, "--namespace=dns", "convert", "zonefile", "--to-crd", "--name=legacy"), strings.NewReader(testZoneFile), &out))
+	records, err := readBackup(&out)
+	require.NoError(t, err)
+	assert.Len(t, records, 6)
+
+	// the output format is required
+	require.Error(t, externaldns.NewConfig().ParseFlags([]string{"convert", "zonefile", path}))
+}
+
+// convertConfig returns the configuration parsed from the arguments of the convert command.
+func convertConfig(t *testing.T, args ...string) *externaldns.Config {
+	t.Helper()
+	cfg := externaldns.NewConfig()
+	require.NoError(t, cfg.ParseFlags(args))
+	require.Equal(t, externaldns.CommandConvertZoneFile, cfg.Command)
+	return cfg
+}
@@ -19,24 +19,44 @@ package controller
 import (
 	"context"
 	"fmt"
+	"io"
 	"net/http"
+	"net/http/pprof"
 	"os"
 	"os/signal"
+	"reflect"
+	"runtime/debug"
+	"strings"
+	"sync"
 	"syscall"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
 	"github.com/aws/aws-sdk-go-v2/service/route53"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
 	sd "github.com/aws/aws-sdk-go-v2/service/servicediscovery"
 	"github.com/go-logr/logr"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	log "github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/klog/v2"
 
 	"sigs.k8s.io/external-dns/endpoint"
 	"sigs.k8s.io/external-dns/pkg/apis/externaldns"
 	"sigs.k8s.io/external-dns/pkg/apis/externaldns/validation"
+	"sigs.k8s.io/external-dns/pkg/audit"
+	"sigs.k8s.io/external-dns/pkg/canary"
+	"sigs.k8s.io/external-dns/pkg/changewindow"
+	"sigs.k8s.io/external-dns/pkg/damping"
+	"sigs.k8s.io/external-dns/pkg/decisions"
+	"sigs.k8s.io/external-dns/pkg/dnscontrol"
+	"sigs.k8s.io/external-dns/pkg/egress"
+	"sigs.k8s.io/external-dns/pkg/envelope"
+	"sigs.k8s.io/external-dns/pkg/logging"
 	"sigs.k8s.io/external-dns/pkg/metrics"
+	"sigs.k8s.io/external-dns/pkg/publicip"
+	"sigs.k8s.io/external-dns/pkg/secrets"
+	"sigs.k8s.io/external-dns/pkg/zoneserial"
 	"sigs.k8s.io/external-dns/plan"
 	"sigs.k8s.io/external-dns/provider"
 	"sigs.k8s.io/external-dns/provider/akamai"
@@ -75,10 +95,35 @@ func Execute() {
 	if err := cfg.ParseFlags(os.Args[1:]); err != nil {
 		log.Fatalf("flag parsing error: %v", err)
 	}
+	if cfg.Command == externaldns.CommandConvertZoneFile {
+		if err := convert(cfg, os.Stdin, os.Stdout); err != nil {
+			log.Fatalf("conversion failed: %v", err)
+		}
+		os.Exit(0)
+	}
 	log.Infof("config: %s", cfg)
 	if err := validation.ValidateConfig(cfg); err != nil {
 		log.Fatalf("config validation failed: %v", err)
 	}
+	if cfg.ValidateConfig {
+		log.Info("config is valid")
+		os.Exit(0)
+	}
+	if cfg.Command == externaldns.CommandSimulate {
+		configureLogger(cfg)
+		if err := simulate(context.Background(), cfg, os.Stdout); err != nil {
+			log.Fatalf("simulation failed: %v", err)
+		}
+		os.Exit(0)
+	}
+	if cfg.Command == externaldns.CommandPreflight {
+		configureLogger(cfg)
+		if err := preflight(context.Background(), cfg, os.Stdout); err != nil {
+			log.Fatalf("preflight failed: %v", err)
+		}
+		os.Exit(0)
+	}
+	initialCfg := *cfg
 
 	configureLogger(cfg)
 
@@ -86,6 +131,20 @@ func Execute() {
 		log.Info("running in dry-run mode. No changes to DNS records will be made.")
 	}
 
+	filterDecisions := decisions.NewRecorder()
+	if cfg.LogFilterDecisions {
+		filterDecisions.Enable()
+	}
+
+	missingZones := provider.NewMissingZoneCache(cfg.MissingZoneCacheTTL)
+
+	dnscontrolExport := dnscontrol.NewExporter()
+	if cfg.DNSControlExport {
+		dnscontrolExport.Enable()
+	}
+
+	damper := damping.NewDamper(cfg.DampingThreshold, cfg.DampingWindow, cfg.DampingCooldown, cfg.DampingAllowClear)
+
 	if log.GetLevel() < log.DebugLevel {
 		// Klog V2 is used by k8s.io/apimachinery/pkg/labels and can throw (a lot) of irrelevant logs
 		// See https://github.com/kubernetes-sigs/external-dns/issues/2348
@@ -97,30 +156,68 @@ func Execute() {
 
 	ctx, cancel := context.WithCancel(context.Background())
 
-	go serveMetrics(cfg.MetricsAddress)
+	if err := tuneRuntime(cfg); err != nil {
+		log.Fatal(err)
+	}
+
+	go serveMetrics(cfg.MetricsAddress, cfg.EnablePprof, filterDecisions, dnscontrolExport, damper)
 	go handleSigterm(cancel)
 
-	endpointsSource, err := buildSource(ctx, cfg)
+	sourceCtx, cancelSource := context.WithCancel(ctx)
+	endpointsSource, err := buildSource(sourceCtx, cfg, filterDecisions)
 	if err != nil {
 		log.Fatal(err)
 	}
 
 	domainFilter := createDomainFilter(cfg)
 
-	prvdr, err := buildProvider(ctx, cfg, domainFilter)
+	secretWatcher := secrets.NewWatcher(buildSecretResolver(cfg), cfg.SecretRefreshInterval)
+	if err := resolveSecrets(ctx, cfg, secretWatcher); err != nil {
+		log.Fatalf("failed to resolve provider credentials: %v", err)
+	}
+	reload := newReloader(os.Args[1:], initialCfg, cfg, secretWatcher, cancelSource)
+
+	prvdr, err := buildProvider(ctx, cfg, domainFilter, filterDecisions, missingZones)
 	if err != nil {
 		log.Fatal(err)
 	}
+	prvdr = reload.wrapProvider(prvdr)
 
 	if cfg.WebhookServer {
 		webhookapi.StartHTTPApi(prvdr, nil, cfg.WebhookProviderReadTimeout, cfg.WebhookProviderWriteTimeout, "127.0.0.1:8888")
 		os.Exit(0)
 	}
 
-	ctrl, err := buildController(cfg, endpointsSource, prvdr, domainFilter)
+	ctrl, err := buildController(cfg, endpointsSource, prvdr, domainFilter, filterDecisions, dnscontrolExport, missingZones, damper)
 	if err != nil {
 		log.Fatal(err)
 	}
+	if cfg.PauseConfigMap != "" {
+		if ctrl.PauseSwitch, err = buildPauseSwitch(cfg); err != nil {
+			log.Fatal(err)
+		}
+	}
+	if cfg.PlanConfigMap != "" {
+		if ctrl.PlanStore, err = buildPlanStore(cfg); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	if cfg.Restore != "" {
+		if err := restore(ctx, ctrl, cfg.Restore); err != nil {
+			log.Fatal(err)
+		}
+
+		os.Exit(0)
+	}
+
+	if cfg.ExportZoneFile != "" {
+		if err := exportZoneFile(ctx, ctrl, cfg.ExportZoneFile, cfg.ExportZones); err != nil {
+			log.Fatal(err)
+		}
+
+		os.Exit(0)
+	}
 
 	if cfg.Once {
 		err := ctrl.RunOnce(ctx)
@@ -135,9 +232,10 @@ func Execute() {
 		// Add RunOnce as the handler function that will be called when ingress/service sources have changed.
 		// Note that k8s Informers will perform an initial list operation, which results in the handler
 		// function initially being called for every Service/Ingress that exists
-		ctrl.Source.AddEventHandler(ctx, func() { ctrl.ScheduleRunOnce(time.Now()) })
+		ctrl.Source.AddEventHandler(sourceCtx, func() { ctrl.SourceChanged(time.Now()) })
 	}
 
+	reload.start(ctx, ctrl)
 	ctrl.ScheduleRunOnce(time.Now())
 	ctrl.Run(ctx)
 }
@@ -146,10 +244,23 @@ func buildProvider(
 	ctx context.Context,
 	cfg *externaldns.Config,
 	domainFilter *endpoint.DomainFilter,
+	filterDecisions *decisions.Recorder,
+	missingZones *provider.MissingZoneCache,
 ) (provider.Provider, error) {
 	var p provider.Provider
 	var err error
 
+	egressConfig, err := egress.NewConfig(cfg.ProviderProxyURL, cfg.ProviderCABundle)
+	if err != nil {
+		return nil, err
+	}
+
+	concurrency := provider.Concurrency{
+		All:    cfg.ProviderConcurrency,
+		Reads:  cfg.ProviderReadConcurrency,
+		Writes: cfg.ProviderWriteConcurrency,
+	}
+
 	zoneNameFilter := endpoint.NewDomainFilter(cfg.ZoneNameFilter)
 	zoneIDFilter := provider.NewZoneIDFilter(cfg.ZoneIDFilter)
 	zoneTypeFilter := provider.NewZoneTypeFilter(cfg.AWSZoneType)
@@ -168,11 +279,12 @@ func buildProvider(
 				EdgercPath:            cfg.AkamaiEdgercPath,
 				EdgercSection:         cfg.AkamaiEdgercSection,
 				DryRun:                cfg.DryRun,
+				Egress:                egressConfig,
 			}, nil)
 	case "alibabacloud":
-		p, err = alibabacloud.NewAlibabaCloudProvider(cfg.AlibabaCloudConfigFile, domainFilter, zoneIDFilter, cfg.AlibabaCloudZoneType, cfg.DryRun)
+		p, err = alibabacloud.NewAlibabaCloudProvider(cfg.AlibabaCloudConfigFile, domainFilter, zoneIDFilter, cfg.AlibabaCloudZoneType, cfg.DryRun, egressConfig)
 	case "aws":
-		configs := aws.CreateV2Configs(cfg)
+		configs := aws.CreateV2Configs(cfg, egressConfig)
 		clients := make(map[string]aws.Route53API, len(configs))
 		for profile, config := range configs {
 			clients[profile] = route53.NewFromConfig(config)
@@ -193,6 +305,9 @@ func buildProvider(
 				PreferCNAME:           cfg.AWSPreferCNAME,
 				DryRun:                cfg.DryRun,
 				ZoneCacheDuration:     cfg.AWSZoneCacheDuration,
+				ZoneSelection:         provider.ZoneSelection(cfg.ZoneSelection),
+				FilterDecisions:       filterDecisions,
+				MissingZones:          missingZones,
 			},
 			clients,
 		)
@@ -202,13 +317,13 @@ func buildProvider(
 			log.Infof("Registry \"%s\" cannot be used with AWS Cloud Map. Switching to \"aws-sd\".", cfg.Registry)
 			cfg.Registry = "aws-sd"
 		}
-		p, err = awssd.NewAWSSDProvider(domainFilter, cfg.AWSZoneType, cfg.DryRun, cfg.AWSSDServiceCleanup, cfg.TXTOwnerID, cfg.AWSSDCreateTag, sd.NewFromConfig(aws.CreateDefaultV2Config(cfg)))
+		p, err = awssd.NewAWSSDProvider(domainFilter, cfg.AWSZoneType, cfg.DryRun, cfg.AWSSDServiceCleanup, cfg.TXTOwnerID, cfg.AWSSDCreateTag, sd.NewFromConfig(aws.CreateDefaultV2Config(cfg, egressConfig)))
 	case "azure-dns", "azure":
-		p, err = azure.NewAzureProvider(cfg.AzureConfigFile, domainFilter, zoneNameFilter, zoneIDFilter, cfg.AzureSubscriptionID, cfg.AzureResourceGroup, cfg.AzureUserAssignedIdentityClientID, cfg.AzureActiveDirectoryAuthorityHost, cfg.AzureZonesCacheDuration, cfg.AzureMaxRetriesCount, cfg.DryRun)
+		p, err = azure.NewAzureProvider(cfg.AzureConfigFile, domainFilter, zoneNameFilter, zoneIDFilter, cfg.AzureSubscriptionID, cfg.AzureResourceGroup, cfg.AzureUserAssignedIdentityClientID, cfg.AzureActiveDirectoryAuthorityHost, cfg.AzureZonesCacheDuration, cfg.AzureMaxRetriesCount, cfg.DryRun, egressConfig)
 	case "azure-private-dns":
-		p, err = azure.NewAzurePrivateDNSProvider(cfg.AzureConfigFile, domainFilter, zoneNameFilter, zoneIDFilter, cfg.AzureSubscriptionID, cfg.AzureResourceGroup, cfg.AzureUserAssignedIdentityClientID, cfg.AzureActiveDirectoryAuthorityHost, cfg.AzureZonesCacheDuration, cfg.AzureMaxRetriesCount, cfg.DryRun)
+		p, err = azure.NewAzurePrivateDNSProvider(cfg.AzureConfigFile, domainFilter, zoneNameFilter, zoneIDFilter, cfg.AzureSubscriptionID, cfg.AzureResourceGroup, cfg.AzureUserAssignedIdentityClientID, cfg.AzureActiveDirectoryAuthorityHost, cfg.AzureZonesCacheDuration, cfg.AzureMaxRetriesCount, cfg.DryRun, egressConfig)
 	case "civo":
-		p, err = civo.NewCivoProvider(domainFilter, cfg.DryRun)
+		p, err = civo.NewCivoProvider(domainFilter, cfg.DryRun, missingZones)
 	case "cloudflare":
 		p, err = cloudflare.NewCloudFlareProvider(
 			domainFilter,
@@ -227,17 +342,19 @@ func buildProvider(
 			cloudflare.DNSRecordsConfig{
 				PerPage: cfg.CloudflareDNSRecordsPerPage,
 				Comment: cfg.CloudflareDNSRecordsComment,
-			})
+			},
+			missingZones,
+			egressConfig)
 	case "google":
-		p, err = google.NewGoogleProvider(ctx, cfg.GoogleProject, domainFilter, zoneIDFilter, cfg.GoogleBatchChangeSize, cfg.GoogleBatchChangeInterval, cfg.GoogleZoneVisibility, cfg.DryRun)
+		p, err = google.NewGoogleProvider(ctx, cfg.GoogleProject, domainFilter, zoneIDFilter, cfg.GoogleBatchChangeSize, cfg.GoogleBatchChangeInterval, cfg.GoogleZoneVisibility, cfg.DryRun, filterDecisions, missingZones, egressConfig)
 	case "digitalocean":
-		p, err = digitalocean.NewDigitalOceanProvider(ctx, domainFilter, cfg.DryRun, cfg.DigitalOceanAPIPageSize)
+		p, err = digitalocean.NewDigitalOceanProvider(ctx, domainFilter, cfg.DigitalOceanProjects, cfg.DryRun, cfg.DigitalOceanAPIPageSize, missingZones, egressConfig, concurrency)
 	case "ovh":
-		p, err = ovh.NewOVHProvider(ctx, domainFilter, cfg.OVHEndpoint, cfg.OVHApiRateLimit, cfg.OVHEnableCNAMERelative, cfg.DryRun)
+		p, err = ovh.NewOVHProvider(ctx, domainFilter, cfg.OVHEndpoint, cfg.OVHApiRateLimit, cfg.OVHEnableCNAMERelative, cfg.DryRun, egressConfig, concurrency)
 	case "linode":
-		p, err = linode.NewLinodeProvider(domainFilter, cfg.DryRun)
+		p, err = linode.NewLinodeProvider(domainFilter, cfg.LinodeDomainTags, cfg.DryRun, missingZones, egressConfig)
 	case "dnsimple":
-		p, err = dnsimple.NewDnsimpleProvider(domainFilter, zoneIDFilter, cfg.DryRun)
+		p, err = dnsimple.NewDnsimpleProvider(domainFilter, zoneIDFilter, cfg.DryRun, missingZones, egressConfig)
 	case "coredns", "skydns":
 		p, err = coredns.NewCoreDNSProvider(domainFilter, cfg.CoreDNSPrefix, cfg.DryRun)
 	case "exoscale":
@@ -247,6 +364,7 @@ func buildProvider(
 			cfg.ExoscaleAPIKey,
 			cfg.ExoscaleAPISecret,
 			cfg.DryRun,
+			egressConfig,
 			exoscale.ExoscaleWithDomain(domainFilter),
 			exoscale.ExoscaleWithLogging(),
 		)
@@ -256,11 +374,15 @@ func buildProvider(
 		p, err = pdns.NewPDNSProvider(
 			ctx,
 			pdns.PDNSConfig{
-				DomainFilter: domainFilter,
-				DryRun:       cfg.DryRun,
-				Server:       cfg.PDNSServer,
-				ServerID:     cfg.PDNSServerID,
-				APIKey:       cfg.PDNSAPIKey,
+				DomainFilter:     domainFilter,
+				DryRun:           cfg.DryRun,
+				Server:           cfg.PDNSServer,
+				FallbackServers:  cfg.PDNSFallbackServers,
+				FailoverCooldown: cfg.ProviderFailoverCooldown,
+				ServerID:         cfg.PDNSServerID,
+				APIKey:           cfg.PDNSAPIKey,
+				SerialVerifier:   serialVerifier(cfg),
+				Egress:           egressConfig,
 				TLSConfig: pdns.TLSConfig{
 					SkipTLSVerify:         cfg.PDNSSkipTLSVerify,
 					CAFilePath:            cfg.TLSCA,
@@ -271,21 +393,31 @@ func buildProvider(
 		)
 	case "oci":
 		var config *oci.OCIConfig
-		// if the instance-principals flag was set, and a compartment OCID was provided, then ignore the
-		// OCI config file, and provide a config that uses instance principal authentication.
-		if cfg.OCIAuthInstancePrincipal {
+		// if the instance-principals or workload-identity flag was set, and a compartment OCID was provided,
+		// then ignore the OCI config file, and provide a config that uses that authentication.
+		if cfg.OCIAuthInstancePrincipal || cfg.OCIAuthWorkloadIdentity {
 			if len(cfg.OCICompartmentOCID) == 0 {
-				err = fmt.Errorf("instance principal authentication requested, but no compartment OCID provided")
+				err = fmt.Errorf("instance principal or workload identity authentication requested, but no compartment OCID provided")
 			} else {
-				authConfig := oci.OCIAuthConfig{UseInstancePrincipal: true}
+				authConfig := oci.OCIAuthConfig{
+					UseInstancePrincipal: cfg.OCIAuthInstancePrincipal,
+					UseWorkloadIdentity:  cfg.OCIAuthWorkloadIdentity,
+					Region:               cfg.OCIRegion,
+				}
 				config = &oci.OCIConfig{Auth: authConfig, CompartmentID: cfg.OCICompartmentOCID}
 			}
 		} else {
 			config, err = oci.LoadOCIConfig(cfg.OCIConfigFile)
 		}
-		config.ZoneCacheDuration = cfg.OCIZoneCacheDuration
 		if err == nil {
-			p, err = oci.NewOCIProvider(*config, domainFilter, zoneIDFilter, cfg.OCIZoneScope, cfg.DryRun)
+			config.ZoneCacheDuration = cfg.OCIZoneCacheDuration
+			if len(cfg.OCIZoneCompartmentOCIDs) > 0 {
+				config.ZoneCompartmentIDs = cfg.OCIZoneCompartmentOCIDs
+			}
+			if len(cfg.OCIViewIDs) > 0 {
+				config.ViewIDs = cfg.OCIViewIDs
+			}
+			p, err = oci.NewOCIProvider(*config, domainFilter, zoneIDFilter, cfg.OCIZoneScope, cfg.DryRun, missingZones, egressConfig)
 		}
 	case "rfc2136":
 		tlsConfig := rfc2136.TLSConfig{
@@ -295,7 +427,11 @@ func buildProvider(
 			ClientCertFilePath:    cfg.TLSClientCert,
 			ClientCertKeyFilePath: cfg.TLSClientCertKey,
 		}
-		p, err = rfc2136.NewRfc2136Provider(cfg.RFC2136Host, cfg.RFC2136Port, cfg.RFC2136Zone, cfg.RFC2136Insecure, cfg.RFC2136TSIGKeyName, cfg.RFC2136TSIGSecret, cfg.RFC2136TSIGSecretAlg, cfg.RFC2136TAXFR, domainFilter, cfg.DryRun, cfg.RFC2136MinTTL, cfg.RFC2136CreatePTR, cfg.RFC2136GSSTSIG, cfg.RFC2136KerberosUsername, cfg.RFC2136KerberosPassword, cfg.RFC2136KerberosRealm, cfg.RFC2136BatchChangeSize, tlsConfig, cfg.RFC2136LoadBalancingStrategy, nil)
+		sig0Config := rfc2136.SIG0Config{
+			KeyDir:   cfg.RFC2136SIG0KeyDir,
+			Rollover: cfg.RFC2136SIG0KeyRollover,
+		}
+		p, err = rfc2136.NewRfc2136Provider(cfg.RFC2136Host, cfg.RFC2136Port, cfg.RFC2136Zone, cfg.RFC2136Insecure, cfg.RFC2136TSIGKeyName, cfg.RFC2136TSIGSecret, cfg.RFC2136TSIGSecretAlg, cfg.RFC2136TAXFR, domainFilter, cfg.DryRun, cfg.RFC2136MinTTL, cfg.RFC2136CreatePTR, cfg.RFC2136GSSTSIG, cfg.RFC2136KerberosUsername, cfg.RFC2136KerberosPassword, cfg.RFC2136KerberosRealm, cfg.RFC2136BatchChangeSize, tlsConfig, sig0Config, cfg.RFC2136LoadBalancingStrategy, serialVerifier(cfg), nil)
 	case "ns1":
 		p, err = ns1.NewNS1Provider(
 			ns1.NS1Config{
@@ -305,14 +441,16 @@ func buildProvider(
 				NS1IgnoreSSL:  cfg.NS1IgnoreSSL,
 				DryRun:        cfg.DryRun,
 				MinTTLSeconds: cfg.NS1MinTTLSeconds,
+				MissingZones:  missingZones,
+				Egress:        egressConfig,
 			},
 		)
 	case "transip":
-		p, err = transip.NewTransIPProvider(cfg.TransIPAccountName, cfg.TransIPPrivateKeyFile, domainFilter, cfg.DryRun)
+		p, err = transip.NewTransIPProvider(cfg.TransIPAccountName, cfg.TransIPPrivateKeyFile, domainFilter, cfg.DryRun, egressConfig)
 	case "scaleway":
-		p, err = scaleway.NewScalewayProvider(ctx, domainFilter, cfg.DryRun)
+		p, err = scaleway.NewScalewayProvider(ctx, domainFilter, cfg.DryRun, egressConfig)
 	case "godaddy":
-		p, err = godaddy.NewGoDaddyProvider(ctx, domainFilter, cfg.GoDaddyTTL, cfg.GoDaddyAPIKey, cfg.GoDaddySecretKey, cfg.GoDaddyOTE, cfg.DryRun)
+		p, err = godaddy.NewGoDaddyProvider(ctx, domainFilter, cfg.GoDaddyTTL, cfg.GoDaddyAPIKey, cfg.GoDaddySecretKey, cfg.GoDaddyOTE, cfg.GoDaddyAPIEndpoint, cfg.DryRun, missingZones, egressConfig, concurrency)
 	case "gandi":
 		p, err = gandi.NewGandiProvider(ctx, domainFilter, cfg.DryRun)
 	case "pihole":
@@ -324,15 +462,36 @@ func buildProvider(
 				DomainFilter:          domainFilter,
 				DryRun:                cfg.DryRun,
 				APIVersion:            cfg.PiholeApiVersion,
+				Egress:                egressConfig,
 			},
 		)
 	case "plural":
-		p, err = plural.NewPluralProvider(cfg.PluralCluster, cfg.PluralProvider)
+		p, err = plural.NewPluralProvider(cfg.PluralCluster, cfg.PluralProvider, egressConfig)
 	case "webhook":
-		p, err = webhook.NewWebhookProvider(cfg.WebhookProviderURL)
+		p, err = webhook.NewWebhookProvider(
+			cfg.WebhookProviderURL,
+			webhook.WithFallbackURLs(cfg.WebhookProviderFallbackURLs...),
+			webhook.WithFailoverCooldown(cfg.ProviderFailoverCooldown),
+			webhook.WithEgress(egressConfig),
+		)
 	default:
 		err = fmt.Errorf("unknown dns provider: %s", cfg.Provider)
 	}
+	if p != nil && provider.GetCapabilities(p).SplitTXT {
+		p = provider.NewTXTSplittingProvider(p)
+	}
+	if p != nil && cfg.FaultInjection {
+		// the profile is validated with the configuration
+		profile, _ := provider.ParseFaultProfile(cfg.FaultInjectionProfile)
+		seed := cfg.FaultInjectionSeed
+		if seed == 0 {
+			seed = time.Now().UnixNano()
+		}
+		p = provider.NewFaultInjectionProvider(p, profile, seed)
+	}
+	if p != nil && cfg.ProviderCircuitBreakerFailures > 0 {
+		p = provider.NewCircuitBreakerProvider(p, cfg.ProviderCircuitBreakerFailures, cfg.ProviderCircuitBreakerOpenDuration)
+	}
 	if p != nil && cfg.ProviderCacheTime > 0 {
 		p = provider.NewCachedProvider(
 			p,
@@ -342,60 +501,241 @@ func buildProvider(
 	return p, err
 }
 
-func buildController(cfg *externaldns.Config, src source.Source, p provider.Provider, filter *endpoint.DomainFilter) (*Controller, error) {
+// serialVerifier returns the verifier of the serials of the zones changed by the provider, nil if disabled.
+func serialVerifier(cfg *externaldns.Config) *zoneserial.Verifier {
+	if !cfg.ZoneSerialCheck {
+		return nil
+	}
+	return zoneserial.NewVerifier(cfg.ZoneSerialSecondaries, cfg.ZoneSerialCheckTimeout)
+}
+
+// canaryStager returns the stager of the changes in the canary zone, nil if disabled. The changes
+// are not staged in dry-run mode, as the provider doesn't apply them.
+func canaryStager(cfg *externaldns.Config, p provider.Provider) *canary.Stager {
+	if cfg.CanaryZone == "" || cfg.DryRun {
+		return nil
+	}
+	return canary.NewStager(p, cfg.CanaryZone, cfg.CanaryNameserver, cfg.CanaryTimeout)
+}
+
+func buildController(cfg *externaldns.Config, src source.Source, p provider.Provider, filter *endpoint.DomainFilter, filterDecisions *decisions.Recorder, dnscontrolExport *dnscontrol.Exporter, missingZones *provider.MissingZoneCache, damper *damping.Damper) (*Controller, error) {
 	policy, ok := plan.Policies[cfg.Policy]
 	if !ok {
 		return nil, fmt.Errorf("unknown policy: %s", cfg.Policy)
 	}
-	reg, err := selectRegistry(cfg, p)
+	// the records of each tenant are owned by the owner id derived from their resources
+	ownerIDTemplate, err := endpoint.NewOwnerIDTemplate(cfg.TXTOwnerIDTemplate)
+	if err != nil {
+		return nil, err
+	}
+	reg, err := selectRegistry(cfg, p, ownerIDTemplate)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.ZoneLockNamespace != "" {
+		locker, err := buildZoneLocker(cfg)
+		if err != nil {
+			return nil, err
+		}
+		reg = registry.NewLockingRegistry(reg, locker)
+	}
+	var auditSink audit.Sink
+	// in dry-run mode, the providers only log the changes
+	if cfg.AuditLog != "" && !cfg.DryRun {
+		if auditSink, err = audit.NewSink(cfg.AuditLog); err != nil {
+			return nil, err
+		}
+	}
+	windows, location, err := buildChangeWindows(cfg)
 	if err != nil {
 		return nil, err
 	}
 	return &Controller{
-		Source:               src,
-		Registry:             reg,
-		Policy:               policy,
-		Interval:             cfg.Interval,
-		DomainFilter:         filter,
-		ManagedRecordTypes:   cfg.ManagedDNSRecordTypes,
-		ExcludeRecordTypes:   cfg.ExcludeDNSRecordTypes,
-		MinEventSyncInterval: cfg.MinEventSyncInterval,
+		Source:                 src,
+		Registry:               reg,
+		OwnerIDTemplate:        ownerIDTemplate,
+		Policy:                 policy,
+		Interval:               cfg.Interval,
+		DomainFilter:           filter,
+		ManagedRecordTypes:     cfg.ManagedDNSRecordTypes,
+		ExcludeRecordTypes:     cfg.ExcludeDNSRecordTypes,
+		MinEventSyncInterval:   cfg.MinEventSyncInterval,
+		DenyWildcardRecords:    denyWildcardRecords(cfg, p),
+		StreamRecords:          cfg.StreamRecords,
+		RepeatedChangesBackoff: cfg.RepeatedChangesBackoff,
+		WriteBudget:            cfg.ProviderWriteBudget,
+		SyncTimeout:            cfg.SyncTimeout,
+		ChangeWindows:          windows,
+		ChangeWindowLocation:   location,
+		ChangeWindowCreates:    cfg.ChangeWindowCreates,
+		Canary:                 canaryStager(cfg, p),
+		MaxDeletionsPerSync:    cfg.MaxDeletionsPerSync,
+		MaxChangePercentage:    cfg.MaxChangePercentage,
+		MaxRecordsPerZone:      cfg.MaxRecordsPerZone,
+		SourceObjectMetrics:    cfg.MetricsSourceObjects,
+		BackupDir:              cfg.BackupDir,
+		AdoptExistingRecords:   cfg.AdoptExistingRecords,
+		IgnoreTTLDrift:         cfg.IgnoreTTLDrift,
+		ExternalOwnerMarkers:   cfg.ExternalOwnerMarkers,
+		JanitorInterval:        cfg.TXTJanitorInterval,
+		JanitorPolicy:          cfg.TXTJanitorPolicy,
+		JanitorOwnerIDs:        cfg.TXTJanitorOwnerIDs,
+		DryRun:                 cfg.DryRun,
+		Capabilities:           provider.GetCapabilities(p),
+		FilterDecisions:        filterDecisions,
+		DNSControl:             dnscontrolExport,
+		MissingZones:           missingZones,
+		Damper:                 damper,
+		Audit:                  auditSink,
 	}, nil
 }
 
+// buildChangeWindows returns the change windows and their time zone.
+func buildChangeWindows(cfg *externaldns.Config) (changewindow.Windows, *time.Location, error) {
+	windows, err := changewindow.ParseAll(cfg.ChangeWindows)
+	if err != nil {
+		return nil, nil, err
+	}
+	location, err := time.LoadLocation(cfg.ChangeWindowTimezone)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid change window time zone %q: %w", cfg.ChangeWindowTimezone, err)
+	}
+	return windows, location, nil
+}
+
+// buildPauseSwitch returns the switch pausing the reconciliation with the pause ConfigMap.
+func buildPauseSwitch(cfg *externaldns.Config) (PauseSwitch, error) {
+	clientGenerator := &source.SingletonClientGenerator{
+		KubeConfig:     cfg.KubeConfig,
+		APIServerURL:   cfg.APIServerURL,
+		RequestTimeout: cfg.RequestTimeout,
+	}
+	client, err := clientGenerator.KubeClient()
+	if err != nil {
+		return nil, err
+	}
+	return NewConfigMapPauseSwitch(client, cfg.PauseConfigMap)
+}
+
+// buildPlanStore returns the store persisting the last plan in the plan ConfigMap.
+func buildPlanStore(cfg *externaldns.Config) (PlanStore, error) {
+	clientGenerator := &source.SingletonClientGenerator{
+		KubeConfig:     cfg.KubeConfig,
+		APIServerURL:   cfg.APIServerURL,
+		RequestTimeout: cfg.RequestTimeout,
+	}
+	client, err := clientGenerator.KubeClient()
+	if err != nil {
+		return nil, err
+	}
+	return NewConfigMapPlanStore(client, cfg.PlanConfigMap)
+}
+
+// buildZoneLocker returns the locker acquiring a Lease per zone before applying its changes. The
+// appliers are told apart by their owner ID and host name, i.e. the pod name.
+func buildZoneLocker(cfg *externaldns.Config) (registry.ZoneLocker, error) {
+	clientGenerator := &source.SingletonClientGenerator{
+		KubeConfig:     cfg.KubeConfig,
+		APIServerURL:   cfg.APIServerURL,
+		RequestTimeout: cfg.RequestTimeout,
+	}
+	client, err := clientGenerator.KubeClient()
+	if err != nil {
+		return nil, err
+	}
+	hostname, err := os.Hostname()
+	if err != nil {
+		return nil, err
+	}
+	return registry.NewLeaseZoneLocker(client, cfg.ZoneLockNamespace, cfg.TXTOwnerID+"/"+hostname, cfg.ZoneLockLeaseDuration), nil
+}
+
+// denyWildcardRecords returns whether wildcard records must be left alone, either because
+// the wildcard policy denies them or because the provider does not support them.
+func denyWildcardRecords(cfg *externaldns.Config, p provider.Provider) bool {
+	if cfg.WildcardPolicy == "deny" {
+		return true
+	}
+	if !provider.GetWildcardSupport(p).Records {
+		log.Infof("The %s provider does not support wildcard records: they are left alone", cfg.Provider)
+		return true
+	}
+	return false
+}
+
 // This function configures the logger format and level based on the provided configuration.
 func configureLogger(cfg *externaldns.Config) {
+	var formatter log.Formatter = &log.TextFormatter{}
 	if cfg.LogFormat == "json" {
-		log.SetFormatter(&log.JSONFormatter{})
+		formatter = &log.JSONFormatter{}
+	}
+	if cfg.LogSamplingInterval > 0 {
+		formatter = logging.NewSamplingFormatter(formatter, cfg.LogSamplingInterval)
 	}
+	log.SetFormatter(formatter)
 	ll, err := log.ParseLevel(cfg.LogLevel)
 	if err != nil {
 		log.Fatalf("failed to parse log level: %v", err)
 	}
 	log.SetLevel(ll)
+
+	if cfg.Provider != "" {
+		log.AddHook(logging.FieldsHook{
+			logging.FieldProvider: cfg.Provider,
+			logging.FieldSource:   strings.Join(cfg.Sources, ","),
+		})
+	}
+	if cfg.LogOutput == "syslog" {
+		hook, err := logging.NewSyslogHook(cfg.LogSyslogAddress, "external-dns", formatter)
+		if err != nil {
+			log.Fatalf("failed to configure syslog: %v", err)
+		}
+		// the lines are formatted and written by the hook only
+		log.AddHook(hook)
+		log.SetFormatter(logging.DiscardFormatter{})
+		log.SetOutput(io.Discard)
+	}
 }
 
 // selectRegistry selects the appropriate registry implementation based on the configuration in cfg.
 // It initializes and returns a registry along with any error encountered during setup.
-// Supported registry types include: dynamodb, noop, txt, and aws-sd.
-func selectRegistry(cfg *externaldns.Config, p provider.Provider) (registry.Registry, error) {
+// Supported registry types include: dynamodb, noop, txt, aws-sd and txt-to-dynamodb.
+func selectRegistry(cfg *externaldns.Config, p provider.Provider, ownerIDTemplate *endpoint.OwnerIDTemplate) (registry.Registry, error) {
+	// the TXT records encrypted with a wrapped data key are longer than a character-string of 255 bytes
+	if cfg.TXTEncryptKMS != "" && (cfg.Registry == "txt" || cfg.Registry == "txt-to-dynamodb") && !provider.GetCapabilities(p).SplitTXT {
+		return nil, fmt.Errorf("--txt-encrypt-kms requires a provider splitting the TXT values longer than 255 bytes, such as aws, gandi, godaddy, google, oci or pdns, which %s doesn't", cfg.Provider)
+	}
+	opts, err := registryOptions(cfg)
+	if err != nil {
+		return nil, err
+	}
+	txtOpts := append([]registry.Option{
+		registry.WithTXTNameTemplate(cfg.TXTNameTemplate),
+		registry.WithTXTNameTemplateMigration(cfg.TXTNameTemplateMigration),
+		registry.WithOwnerIDTemplate(ownerIDTemplate),
+	}, opts...)
 	var r registry.Registry
-	var err error
+	wildcardReplacement := txtWildcardReplacement(cfg, p)
 	switch cfg.Registry {
 	case "dynamodb":
-		var dynamodbOpts []func(*dynamodb.Options)
-		if cfg.AWSDynamoDBRegion != "" {
-			dynamodbOpts = []func(*dynamodb.Options){
-				func(opts *dynamodb.Options) {
-					opts.Region = cfg.AWSDynamoDBRegion
-				},
-			}
+		r, err = registry.NewDynamoDBRegistry(p, cfg.TXTOwnerID, newDynamoDBClient(cfg), cfg.AWSDynamoDBTable, cfg.TXTPrefix, cfg.TXTSuffix, wildcardReplacement, cfg.ManagedDNSRecordTypes, cfg.ExcludeDNSRecordTypes, []byte(cfg.TXTEncryptAESKey), cfg.TXTCacheInterval, cfg.AWSDynamoDBCreateTable, cfg.AWSDynamoDBTableTags, opts...)
+	case "txt-to-dynamodb":
+		var txtRegistry *registry.TXTRegistry
+		txtRegistry, err = registry.NewTXTRegistry(p, cfg.TXTPrefix, cfg.TXTSuffix, cfg.TXTOwnerID, cfg.TXTCacheInterval, wildcardReplacement, cfg.ManagedDNSRecordTypes, cfg.ExcludeDNSRecordTypes, cfg.TXTEncryptEnabled, []byte(cfg.TXTEncryptAESKey), cfg.TXTNewFormatOnly, txtOpts...)
+		if err != nil {
+			return nil, err
+		}
+		// The records are cached by the TXT registry, which the DynamoDB registry reads them from.
+		var dynamodbRegistry *registry.DynamoDBRegistry
+		dynamodbRegistry, err = registry.NewDynamoDBRegistry(p, cfg.TXTOwnerID, newDynamoDBClient(cfg), cfg.AWSDynamoDBTable, cfg.TXTPrefix, cfg.TXTSuffix, wildcardReplacement, cfg.ManagedDNSRecordTypes, cfg.ExcludeDNSRecordTypes, []byte(cfg.TXTEncryptAESKey), 0, cfg.AWSDynamoDBCreateTable, cfg.AWSDynamoDBTableTags, opts...)
+		if err != nil {
+			return nil, err
 		}
-		r, err = registry.NewDynamoDBRegistry(p, cfg.TXTOwnerID, dynamodb.NewFromConfig(aws.CreateDefaultV2Config(cfg), dynamodbOpts...), cfg.AWSDynamoDBTable, cfg.TXTPrefix, cfg.TXTSuffix, cfg.TXTWildcardReplacement, cfg.ManagedDNSRecordTypes, cfg.ExcludeDNSRecordTypes, []byte(cfg.TXTEncryptAESKey), cfg.TXTCacheInterval)
+		r, err = registry.NewMigrationRegistry(txtRegistry, dynamodbRegistry, cfg.RegistryMigrationCutover)
 	case "noop":
 		r, err = registry.NewNoopRegistry(p)
 	case "txt":
-		r, err = registry.NewTXTRegistry(p, cfg.TXTPrefix, cfg.TXTSuffix, cfg.TXTOwnerID, cfg.TXTCacheInterval, cfg.TXTWildcardReplacement, cfg.ManagedDNSRecordTypes, cfg.ExcludeDNSRecordTypes, cfg.TXTEncryptEnabled, []byte(cfg.TXTEncryptAESKey), cfg.TXTNewFormatOnly)
+		r, err = registry.NewTXTRegistry(p, cfg.TXTPrefix, cfg.TXTSuffix, cfg.TXTOwnerID, cfg.TXTCacheInterval, wildcardReplacement, cfg.ManagedDNSRecordTypes, cfg.ExcludeDNSRecordTypes, cfg.TXTEncryptEnabled, []byte(cfg.TXTEncryptAESKey), cfg.TXTNewFormatOnly, txtOpts...)
 	case "aws-sd":
 		r, err = registry.NewAWSSDRegistry(p, cfg.TXTOwnerID)
 	default:
@@ -404,12 +744,85 @@ func selectRegistry(cfg *externaldns.Config, p provider.Provider) (registry.Regi
 	return r, err
 }
 
+// registryOptions returns the options of the TXT and DynamoDB registries: with --txt-encrypt-kms,
+// the cipher encrypting the TXT records with a data key wrapped by the key management service,
+// which falls back to the AES key to decrypt the records encrypted with it.
+func registryOptions(cfg *externaldns.Config) ([]registry.Option, error) {
+	if cfg.TXTEncryptKMS == "" {
+		return nil, nil
+	}
+	ctx := context.Background()
+	var km envelope.KeyManager
+	var err error
+	switch cfg.TXTEncryptKMS {
+	case envelope.KMSAWS:
+		km = envelope.NewAWSKMS(aws.CreateDefaultV2Config(cfg, nil), cfg.TXTEncryptKMSKey)
+	case envelope.KMSGCP:
+		km, err = envelope.NewGCPKMS(ctx, cfg.TXTEncryptKMSKey)
+	case envelope.KMSVaultTransit:
+		km, err = envelope.NewVaultTransit(secrets.VaultConfigFromEnv(), cfg.TXTEncryptKMSKey, nil)
+	default:
+		return nil, fmt.Errorf("unknown key management service %q", cfg.TXTEncryptKMS)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var fallback endpoint.TextCipher
+	if cfg.TXTEncryptAESKey != "" {
+		key, err := endpoint.DecodeAESKey([]byte(cfg.TXTEncryptAESKey))
+		if err != nil {
+			return nil, err
+		}
+		if fallback, err = endpoint.NewAESCipher(key); err != nil {
+			return nil, err
+		}
+	}
+	c, err := envelope.NewCipher(ctx, km, fallback)
+	if err != nil {
+		return nil, err
+	}
+	log.Infof("Encrypting the TXT records with data keys wrapped by %s", cfg.TXTEncryptKMS)
+	return []registry.Option{registry.WithTXTCipher(c)}, nil
+}
+
+// newDynamoDBClient creates the client of the DynamoDB table of the registry.
+func newDynamoDBClient(cfg *externaldns.Config) *dynamodb.Client {
+	var dynamodbOpts []func(*dynamodb.Options)
+	if cfg.AWSDynamoDBRegion != "" {
+		dynamodbOpts = []func(*dynamodb.Options){
+			func(opts *dynamodb.Options) {
+				opts.Region = cfg.AWSDynamoDBRegion
+			},
+		}
+	}
+	return dynamodb.NewFromConfig(aws.CreateDefaultV2Config(cfg, nil), dynamodbOpts...)
+}
+
+// defaultTXTWildcardReplacement replaces the wildcard in the names of the ownership TXT records
+// when the provider does not support wildcard TXT records. The underscore keeps it from colliding with a hostname.
+const defaultTXTWildcardReplacement = "_wildcard"
+
+// txtWildcardReplacement returns the label that replaces the wildcard in the names of the ownership
+// TXT records. It defaults to _wildcard for the providers that do not accept `*` in TXT record names.
+func txtWildcardReplacement(cfg *externaldns.Config, p provider.Provider) string {
+	replacement := cfg.TXTWildcardReplacement
+	if replacement == "" && (cfg.Registry == "txt" || cfg.Registry == "dynamodb" || cfg.Registry == "txt-to-dynamodb") && !provider.GetWildcardSupport(p).TXTNames {
+		replacement = defaultTXTWildcardReplacement
+		log.Infof("The %s provider does not support wildcard TXT records: using --txt-wildcard-replacement=%s", cfg.Provider, replacement)
+	}
+	if replacement != "" && !strings.HasPrefix(replacement, "_") {
+		log.Warnf("The TXT wildcard replacement %q can be a hostname label: the ownership records of *.example.com and %s.example.com collide, use a replacement starting with an underscore", replacement, replacement)
+	}
+	return replacement
+}
+
 // buildSource creates and configures the source(s) for endpoint discovery based on the provided configuration.
 // It initializes the source configuration, generates the required sources, and combines them into a single,
-// deduplicated source. Returns the combined source or an error if source creation fails.
-func buildSource(ctx context.Context, cfg *externaldns.Config) (source.Source, error) {
-	sourceCfg := source.NewSourceConfig(cfg)
-	sources, err := source.ByNames(ctx, &source.SingletonClientGenerator{
+// deduplicated source. Returns the combined source or an error if source creation fails. The objects
+// and endpoints excluded by the filters are recorded by filterDecisions, nil not to record them.
+func buildSource(ctx context.Context, cfg *externaldns.Config, filterDecisions *decisions.Recorder) (source.Source, error) {
+	clientGenerator := &source.SingletonClientGenerator{
 		KubeConfig:   cfg.KubeConfig,
 		APIServerURL: cfg.APIServerURL,
 		RequestTimeout: func() time.Duration {
@@ -418,12 +831,61 @@ func buildSource(ctx context.Context, cfg *externaldns.Config) (source.Source, e
 			}
 			return cfg.RequestTimeout
 		}(),
-	}, cfg.Sources, sourceCfg)
+	}
+	return buildSourceWithClients(ctx, cfg, clientGenerator, filterDecisions)
+}
+
+// buildSourceWithClients builds the configured sources with the clients of the given generator.
+func buildSourceWithClients(ctx context.Context, cfg *externaldns.Config, clientGenerator source.ClientGenerator, filterDecisions *decisions.Recorder) (source.Source, error) {
+	sourceCfg := source.NewSourceConfig(cfg)
+	sourceCfg.FilterDecisions = filterDecisions
+	sources, err := source.ByNames(ctx, clientGenerator, cfg.Sources, sourceCfg)
 	if err != nil {
 		return nil, err
 	}
+	// Choose the published IP family per source, before sources are combined.
+	for i, name := range cfg.Sources {
+		ipFamily := cfg.TargetIPFamily
+		if override, ok := cfg.SourceTargetIPFamilies[name]; ok {
+			ipFamily = override
+		}
+		sources[i] = source.NewIPFamilySource(sources[i], ipFamily)
+	}
 	// Combine multiple sources into a single, deduplicated source.
 	combinedSource := source.NewDedupSource(source.NewMultiSource(sources, sourceCfg.DefaultTargets, sourceCfg.ForceDefaultTargets))
+	// Record the comments of the resources along with their owner and name.
+	combinedSource = source.NewCommentSource(combinedSource)
+	// Drop the endpoints of the resources of the excluded namespaces.
+	combinedSource = source.NewNamespaceFilterSource(combinedSource, cfg.NamespaceRegex, cfg.ExcludeNamespaces, filterDecisions)
+	// Apply the defaults set by namespace owners, before filtering their targets.
+	if len(cfg.DNSConfigAllowedSettings) > 0 {
+		dynamicClient, err := clientGenerator.DynamicKubernetesClient()
+		if err != nil {
+			return nil, err
+		}
+		combinedSource, err = source.NewDNSConfigSource(ctx, dynamicClient, combinedSource, cfg.DNSConfigAllowedSettings)
+		if err != nil {
+			return nil, err
+		}
+	}
+	// Shorten the TTLs while cert-manager renews the certificates, after the defaults are applied.
+	if cfg.CertManagerRenewalTTL > 0 {
+		dynamicClient, err := clientGenerator.DynamicKubernetesClient()
+		if err != nil {
+			return nil, err
+		}
+		combinedSource, err = source.NewCertRenewalSource(ctx, dynamicClient, combinedSource, cfg.CertManagerRenewalTTL, cfg.CertManagerRenewalLead)
+		if err != nil {
+			return nil, err
+		}
+	}
+	// Resolve the targets referring to other objects, before filtering them.
+	combinedSource = source.NewTargetRefSource(combinedSource, clientGenerator, cfg.TailscaleOperatorNamespace)
+	// Replace the public-ip targets with the public IP address of the cluster.
+	if len(cfg.PublicIPEndpoints) > 0 {
+		discoverer := publicip.NewDiscoverer(cfg.PublicIPEndpoints)
+		combinedSource = source.NewPublicIPSource(combinedSource, discoverer, cfg.PublicIPRefreshInterval)
+	}
 	// Filter targets
 	targetFilter := endpoint.NewTargetNetFilterWithExclusions(cfg.TargetNetFilter, cfg.ExcludeTargetNets)
 	combinedSource = source.NewNAT64Source(combinedSource, cfg.NAT64Networks)
@@ -431,6 +893,71 @@ func buildSource(ctx context.Context, cfg *externaldns.Config) (source.Source, e
 	return combinedSource, nil
 }
 
+// envSecretPrefix distinguishes secrets resolved into environment variables from
+// secrets resolved into configuration fields.
+const envSecretPrefix = "env/"
+
+// buildSecretResolver returns a resolver for secret references in provider credentials.
+// The AWS Secrets Manager client is only created once such a reference is resolved.
+func buildSecretResolver(cfg *externaldns.Config) *secrets.Resolver {
+	resolver := secrets.NewResolver()
+	resolver.Register(secrets.SchemeVault, secrets.NewVaultBackend(secrets.VaultConfigFromEnv(), nil))
+
+	var (
+		awsOnce    sync.Once
+		awsBackend secrets.Backend
+	)
+	resolver.Register(secrets.SchemeAWSSecretsManager, secrets.BackendFunc(func(ctx context.Context, path string) (string, error) {
+		awsOnce.Do(func() {
+			awsBackend = secrets.NewAWSSecretsManagerBackend(secretsmanager.NewFromConfig(aws.CreateDefaultV2Config(cfg, nil)))
+		})
+		return awsBackend.Fetch(ctx, path)
+	}))
+	return resolver
+}
+
+// resolveSecrets replaces secret references in the sensitive configuration fields and
+// in the environment variables listed with --secret-env by their current values.
+func resolveSecrets(ctx context.Context, cfg *externaldns.Config, watcher *secrets.Watcher) error {
+	v := reflect.ValueOf(cfg).Elem()
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.Tag.Get("secure") != "yes" || f.Type.Kind() != reflect.String {
+			continue
+		}
+		value, err := watcher.Add(ctx, f.Name, v.Field(i).String())
+		if err != nil {
+			return err
+		}
+		v.Field(i).SetString(value)
+	}
+	for _, name := range cfg.SecretEnvVars {
+		value, err := watcher.Add(ctx, envSecretPrefix+name, os.Getenv(name))
+		if err != nil {
+			return err
+		}
+		if err := os.Setenv(name, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// applySecrets writes rotated secrets back into the configuration and environment.
+func applySecrets(cfg *externaldns.Config, changed map[string]string) {
+	v := reflect.ValueOf(cfg).Elem()
+	for name, value := range changed {
+		if env, ok := strings.CutPrefix(name, envSecretPrefix); ok {
+			_ = os.Setenv(env, value)
+			continue
+		}
+		if f := v.FieldByName(name); f.IsValid() && f.Kind() == reflect.String {
+			f.SetString(value)
+		}
+	}
+}
+
 // RegexDomainFilter overrides DomainFilter
 func createDomainFilter(cfg *externaldns.Config) *endpoint.DomainFilter {
 	if cfg.RegexDomainFilter != nil && cfg.RegexDomainFilter.String() != "" {
@@ -453,18 +980,61 @@ func handleSigterm(cancel func()) {
 // serveMetrics starts an HTTP server that serves health and metrics endpoints.
 // The /healthz endpoint returns a 200 OK status to indicate the service is healthy.
 // The /metrics endpoint serves Prometheus metrics.
+// The /debug/filter-decisions endpoint serves the decisions of the filters when they are recorded.
+// The /export/dnscontrol endpoint serves the desired records as a DNSControl configuration when exported.
+// The /debug/damped-records endpoint serves the records held as their targets flap when damped.
+// The /debug/pprof endpoints serve the runtime profiles when enabled.
 // The server listens on the specified address and logs debug information about the endpoints.
-func serveMetrics(address string) {
-	http.HandleFunc("/healthz", func(w http.ResponseWriter, _ *http.Request) {
-		w.WriteHeader(http.StatusOK)
-		_, _ = w.Write([]byte("OK"))
-	})
-
+func serveMetrics(address string, enablePprof bool, filterDecisions *decisions.Recorder, dnscontrolExport *dnscontrol.Exporter, damper *damping.Damper) {
 	log.Debugf("serving 'healthz' on '%s/healthz'", address)
 	log.Debugf("serving 'metrics' on '%s/metrics'", address)
+	log.Debugf("serving 'filter decisions' on '%s/debug/filter-decisions'", address)
+	log.Debugf("serving 'DNSControl export' on '%s/export/dnscontrol'", address)
+	log.Debugf("serving 'damped records' on '%s/debug/damped-records'", address)
+	if enablePprof {
+		log.Debugf("serving 'pprof' on '%s/debug/pprof/'", address)
+	}
 	log.Debugf("registered '%d' metrics", len(metrics.RegisterMetric.Metrics))
 
-	http.Handle("/metrics", promhttp.Handler())
+	log.Fatal(http.ListenAndServe(address, metricsHandler(enablePprof, filterDecisions, dnscontrolExport, damper)))
+}
+
+// metricsHandler returns the handler of the endpoints served by serveMetrics. A dedicated
+// mux is used, so that the profiles are only served when enabled.
+func metricsHandler(enablePprof bool, filterDecisions *decisions.Recorder, dnscontrolExport *dnscontrol.Exporter, damper *damping.Damper) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("OK"))
+	})
+	mux.Handle("/metrics", promhttp.Handler())
+	mux.Handle("/debug/filter-decisions", filterDecisions)
+	mux.Handle("/export/dnscontrol", dnscontrolExport)
+	mux.Handle("/debug/damped-records", damper)
+	if enablePprof {
+		mux.HandleFunc("/debug/pprof/", pprof.Index)
+		mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+		mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+		mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+		mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	}
+	return mux
+}
 
-	log.Fatal(http.ListenAndServe(address, nil))
+// tuneRuntime sets the soft memory limit and the garbage collection target of the Go runtime,
+// overriding the GOMEMLIMIT and GOGC environment variables when configured.
+func tuneRuntime(cfg *externaldns.Config) error {
+	if cfg.GoMemoryLimit != "" {
+		limit, err := resource.ParseQuantity(cfg.GoMemoryLimit)
+		if err != nil {
+			return fmt.Errorf("invalid Go memory limit %q: %w", cfg.GoMemoryLimit, err)
+		}
+		debug.SetMemoryLimit(limit.Value())
+		log.Infof("Go memory limit set to %s", cfg.GoMemoryLimit)
+	}
+	if cfg.GoGCPercent > 0 {
+		debug.SetGCPercent(cfg.GoGCPercent)
+		log.Infof("Go garbage collection target set to %d%%", cfg.GoGCPercent)
+	}
+	return nil
 }
@@ -25,8 +25,10 @@ import (
 	"net/http/httptest"
 	"os"
 	"os/signal"
+	"path/filepath"
 	"reflect"
 	"regexp"
+	"runtime/debug"
 	"syscall"
 	"testing"
 	"time"
@@ -36,6 +38,10 @@ import (
 	"github.com/stretchr/testify/require"
 	"sigs.k8s.io/external-dns/endpoint"
 	"sigs.k8s.io/external-dns/pkg/apis/externaldns"
+	"sigs.k8s.io/external-dns/pkg/decisions"
+	"sigs.k8s.io/external-dns/pkg/dnscontrol"
+	"sigs.k8s.io/external-dns/pkg/logging"
+	"sigs.k8s.io/external-dns/pkg/secrets"
 	"sigs.k8s.io/external-dns/plan"
 	"sigs.k8s.io/external-dns/provider"
 )
@@ -118,11 +124,11 @@ func TestSelectRegistry(t *testing.T) {
 				log.StandardLogger().ExitFunc = func(int) {}
 				log.StandardLogger().SetOutput(b)
 
-				_, err := selectRegistry(tt.cfg, tt.provider)
+				_, err := selectRegistry(tt.cfg, tt.provider, nil)
 				assert.NoError(t, err)
 				assert.Contains(t, b.String(), "unknown registry: unknown")
 			} else {
-				reg, err := selectRegistry(tt.cfg, tt.provider)
+				reg, err := selectRegistry(tt.cfg, tt.provider, nil)
 				assert.NoError(t, err)
 				assert.Contains(t, reflect.TypeOf(reg).String(), tt.wantType)
 			}
@@ -130,6 +136,89 @@ func TestSelectRegistry(t *testing.T) {
 	}
 }
 
+func TestTXTWildcardReplacement(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		cfg      *externaldns.Config
+		provider provider.Provider
+		want     string
+	}{
+		{
+			name:     "provider supporting wildcard TXT records",
+			cfg:      &externaldns.Config{Registry: "txt"},
+			provider: &MockProvider{},
+			want:     "",
+		},
+		{
+			name:     "provider not supporting wildcard TXT records",
+			cfg:      &externaldns.Config{Registry: "txt"},
+			provider: &noWildcardMockProvider{},
+			want:     "_wildcard",
+		},
+		{
+			name:     "explicit replacement",
+			cfg:      &externaldns.Config{Registry: "txt", TXTWildcardReplacement: "_any"},
+			provider: &noWildcardMockProvider{},
+			want:     "_any",
+		},
+		{
+			name:     "noop registry",
+			cfg:      &externaldns.Config{Registry: "noop"},
+			provider: &noWildcardMockProvider{},
+			want:     "",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, txtWildcardReplacement(tt.cfg, tt.provider))
+		})
+	}
+}
+
+func TestRegistryOptions(t *testing.T) {
+	vault := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/transit/encrypt/external-dns", r.URL.Path)
+		_, _ = w.Write([]byte(`{"data": {"ciphertext": "vault:v1:d3JhcHBlZA=="}}`))
+	}))
+	defer vault.Close()
+	t.Setenv("VAULT_ADDR", vault.URL)
+
+	cfg := &externaldns.Config{Registry: "txt", TXTOwnerID: "owner-id", TXTEncryptEnabled: true}
+	_, err := selectRegistry(cfg, &MockProvider{}, nil)
+	require.Error(t, err, "the TXT records can't be encrypted without key")
+
+	// the TXT records are encrypted with the data key wrapped by the KMS
+	cfg.TXTEncryptKMS = "vault-transit"
+	cfg.TXTEncryptKMSKey = "transit/external-dns"
+	opts, err := registryOptions(cfg)
+	require.NoError(t, err)
+	assert.Len(t, opts, 1)
+	_, err = selectRegistry(cfg, &splitTXTMockProvider{}, nil)
+	require.NoError(t, err)
+
+	// the encrypted TXT records are longer than a character-string, so they are split by the provider
+	_, err = selectRegistry(cfg, &MockProvider{}, nil)
+	require.ErrorContains(t, err, "--txt-encrypt-kms requires a provider splitting the TXT values")
+	cfg.Registry = "noop"
+	_, err = selectRegistry(cfg, &MockProvider{}, nil)
+	require.NoError(t, err)
+	cfg.Registry = "txt"
+
+	cfg.TXTEncryptAESKey = "too short"
+	_, err = registryOptions(cfg)
+	require.Error(t, err)
+
+	cfg.TXTEncryptKMS = ""
+	opts, err = registryOptions(cfg)
+	require.NoError(t, err)
+	assert.Empty(t, opts)
+}
+
+func TestDenyWildcardRecords(t *testing.T) {
+	assert.False(t, denyWildcardRecords(&externaldns.Config{WildcardPolicy: "allow"}, &MockProvider{}))
+	assert.True(t, denyWildcardRecords(&externaldns.Config{WildcardPolicy: "deny"}, &MockProvider{}))
+	assert.True(t, denyWildcardRecords(&externaldns.Config{WildcardPolicy: "allow"}, &noWildcardMockProvider{}))
+}
+
 func TestCreateDomainFilter(t *testing.T) {
 	tests := []struct {
 		name                 string
@@ -241,7 +330,7 @@ func TestServeMetrics(t *testing.T) {
 	require.NoError(t, err)
 	addresse := fmt.Sprintf("localhost:%d", port)
 
-	go serveMetrics(fmt.Sprintf(":%d", port))
+	go serveMetrics(fmt.Sprintf(":%d", port), false, decisions.NewRecorder(), dnscontrol.NewExporter(), nil)
 
 	// Wait for the TCP socket to be ready
 	require.Eventually(t, func() bool {
@@ -260,6 +349,52 @@ func TestServeMetrics(t *testing.T) {
 	resp, err = http.Get(fmt.Sprintf("http://%s/metrics", addresse))
 	require.NoError(t, err)
 	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	// the filter decisions are served only with --log-filter-decisions
+	resp, err = http.Get(fmt.Sprintf("http://%s/debug/filter-decisions", addresse))
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	// the DNSControl export is served only with --dnscontrol-export
+	resp, err = http.Get(fmt.Sprintf("http://%s/export/dnscontrol", addresse))
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestMetricsHandlerPprof(t *testing.T) {
+	for _, enablePprof := range []bool{false, true} {
+		svr := httptest.NewServer(metricsHandler(enablePprof, nil, nil, nil))
+		resp, err := http.Get(svr.URL + "/debug/pprof/cmdline")
+		require.NoError(t, err)
+		_ = resp.Body.Close()
+		svr.Close()
+		if enablePprof {
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+		} else {
+			assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+		}
+	}
+}
+
+func TestTuneRuntime(t *testing.T) {
+	memoryLimit := debug.SetMemoryLimit(-1)
+	gcPercent := debug.SetGCPercent(100)
+	debug.SetGCPercent(gcPercent)
+	t.Cleanup(func() {
+		debug.SetMemoryLimit(memoryLimit)
+		debug.SetGCPercent(gcPercent)
+	})
+
+	require.NoError(t, tuneRuntime(&externaldns.Config{GoMemoryLimit: "512Mi", GoGCPercent: 50}))
+	assert.Equal(t, int64(512*1024*1024), debug.SetMemoryLimit(-1))
+	assert.Equal(t, 50, debug.SetGCPercent(50))
+
+	// the runtime is left unchanged when not configured
+	require.NoError(t, tuneRuntime(&externaldns.Config{}))
+	assert.Equal(t, int64(512*1024*1024), debug.SetMemoryLimit(-1))
+	assert.Equal(t, 50, debug.SetGCPercent(50))
+
+	require.Error(t, tuneRuntime(&externaldns.Config{GoMemoryLimit: "lots"}))
 }
 
 func TestConfigureLogger(t *testing.T) {
@@ -328,6 +463,16 @@ func TestConfigureLogger(t *testing.T) {
 	}
 }
 
+func TestConfigureLoggerSampling(t *testing.T) {
+	formatter := log.StandardLogger().Formatter
+	defer log.SetFormatter(formatter)
+
+	configureLogger(&externaldns.Config{LogLevel: "info", LogFormat: "json", LogSamplingInterval: time.Minute})
+	sampling, ok := log.StandardLogger().Formatter.(*logging.SamplingFormatter)
+	require.True(t, ok)
+	assert.IsType(t, &log.JSONFormatter{}, sampling.Formatter)
+}
+
 func TestBuildProvider(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -408,7 +553,7 @@ func TestBuildProvider(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			domainFilter := endpoint.NewDomainFilter([]string{"example.com"})
 
-			p, err := buildProvider(t.Context(), tt.cfg, domainFilter)
+			p, err := buildProvider(t.Context(), tt.cfg, domainFilter, nil, nil)
 
 			if tt.expectedError != "" {
 				assert.Error(t, err)
@@ -465,7 +610,7 @@ func TestBuildSource(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			src, err := buildSource(t.Context(), tt.cfg)
+			src, err := buildSource(t.Context(), tt.cfg, nil)
 
 			if tt.expectedError {
 				assert.Error(t, err)
@@ -478,6 +623,43 @@ func TestBuildSource(t *testing.T) {
 	}
 }
 
+func TestResolveSecrets(t *testing.T) {
+	dir := t.TempDir()
+	keyFile := filepath.Join(dir, "key")
+	require.NoError(t, os.WriteFile(keyFile, []byte("file-key\n"), 0o600))
+	t.Setenv("EXTERNAL_DNS_TEST_SECRET", "env-secret")
+	t.Setenv("EXTERNAL_DNS_TEST_TOKEN", "secret+file:"+keyFile)
+
+	cfg := &externaldns.Config{
+		GoDaddyAPIKey:    "secret+file:" + keyFile,
+		GoDaddySecretKey: "secret+env:EXTERNAL_DNS_TEST_SECRET",
+		PDNSAPIKey:       "plain-key",
+		SecretEnvVars:    []string{"EXTERNAL_DNS_TEST_TOKEN"},
+	}
+	watcher := secrets.NewWatcher(buildSecretResolver(cfg), 0)
+
+	require.NoError(t, resolveSecrets(t.Context(), cfg, watcher))
+	assert.Equal(t, "file-key", cfg.GoDaddyAPIKey)
+	assert.Equal(t, "env-secret", cfg.GoDaddySecretKey)
+	assert.Equal(t, "plain-key", cfg.PDNSAPIKey)
+	assert.Equal(t, "file-key", os.Getenv("EXTERNAL_DNS_TEST_TOKEN"))
+	assert.Equal(t, 3, watcher.Len())
+
+	require.NoError(t, os.WriteFile(keyFile, []byte("rotated-key"), 0o600))
+	applySecrets(cfg, watcher.Refresh(t.Context()))
+	assert.Equal(t, "rotated-key", cfg.GoDaddyAPIKey)
+	assert.Equal(t, "env-secret", cfg.GoDaddySecretKey)
+	assert.Equal(t, "rotated-key", os.Getenv("EXTERNAL_DNS_TEST_TOKEN"))
+}
+
+func TestResolveSecretsError(t *testing.T) {
+	cfg := &externaldns.Config{
+		GoDaddyAPIKey: "secret+env:EXTERNAL_DNS_TEST_MISSING",
+	}
+	err := resolveSecrets(t.Context(), cfg, secrets.NewWatcher(buildSecretResolver(cfg), 0))
+	assert.ErrorIs(t, err, secrets.ErrSecretNotFound)
+}
+
 // mocks
 type MockProvider struct{}
 
@@ -496,3 +678,19 @@ func (m *MockProvider) AdjustEndpoints(endpoints []*endpoint.Endpoint) ([]*endpo
 func (m *MockProvider) GetDomainFilter() endpoint.DomainFilterInterface {
 	return nil
 }
+
+type splitTXTMockProvider struct {
+	MockProvider
+}
+
+func (m *splitTXTMockProvider) Capabilities() provider.Capabilities {
+	return provider.Capabilities{SplitTXT: true}
+}
+
+type noWildcardMockProvider struct {
+	MockProvider
+}
+
+func (m *noWildcardMockProvider) WildcardSupport() provider.WildcardSupport {
+	return provider.WildcardSupport{}
+}
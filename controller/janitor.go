@@ -0,0 +1,128 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/pkg/metrics"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/registry"
+)
+
+const (
+	// JanitorPolicyReport only logs and counts the orphaned ownership records.
+	JanitorPolicyReport = "report"
+	// JanitorPolicyDelete deletes the orphaned ownership records.
+	JanitorPolicyDelete = "delete"
+)
+
+var (
+	orphanedRecords = metrics.NewGaugedVectorOpts(
+		prometheus.GaugeOpts{
+			Namespace: "external_dns",
+			Subsystem: "registry",
+			Name:      "orphaned_records",
+			Help:      "Number of ownership records without their record found by the last janitor run, per owner (vector).",
+		},
+		[]string{"owner"},
+	)
+	orphanedRecordsDeletedTotal = metrics.NewCounterVecWithOpts(
+		prometheus.CounterOpts{
+			Namespace: "external_dns",
+			Subsystem: "registry",
+			Name:      "orphaned_records_deleted_total",
+			Help:      "Number of ownership records without their record deleted by the janitor, per owner.",
+		},
+		[]string{"owner"},
+	)
+)
+
+// shouldRunJanitor returns whether the janitor is due, and schedules its next run.
+func (c *Controller) shouldRunJanitor(now time.Time) bool {
+	if c.JanitorInterval <= 0 || now.Before(c.nextJanitorRunAt) {
+		return false
+	}
+	c.nextJanitorRunAt = now.Add(c.JanitorInterval)
+	return true
+}
+
+// collectOrphanedRecords finds the ownership records of this owner and of the janitor owners
+// left without their record, and deletes them with the delete policy. It never runs along with
+// a reconciliation, whose ownership records could be written before their records.
+func (c *Controller) collectOrphanedRecords(ctx context.Context) error {
+	c.reconcileMutex.Lock()
+	defer c.reconcileMutex.Unlock()
+
+	owners := append([]string{c.Registry.OwnerID()}, c.JanitorOwnerIDs...)
+	orphans, err := registry.OrphanedRecords(ctx, c.Registry, owners)
+	if err != nil {
+		return err
+	}
+
+	counts := make(map[string]int, len(owners))
+	for _, owner := range owners {
+		counts[owner] = 0
+	}
+	for _, orphan := range orphans {
+		owner := orphan.Labels[endpoint.OwnerLabelKey]
+		counts[owner]++
+		log.Infof("The ownership record %s of owner %q has no record", orphan, owner)
+	}
+	orphanedRecords.Gauge.Reset()
+	for owner, count := range counts {
+		orphanedRecords.SetWithLabels(float64(count), owner)
+	}
+	if len(orphans) == 0 {
+		log.Debug("No orphaned ownership record found")
+		return nil
+	}
+
+	paused, err := c.paused(ctx)
+	if err != nil {
+		return err
+	}
+	switch {
+	case c.JanitorPolicy != JanitorPolicyDelete:
+		log.Infof("Found %d orphaned ownership records, not deleting them with the %s policy", len(orphans), c.JanitorPolicy)
+		return nil
+	case c.DryRun:
+		log.Infof("Would delete %d orphaned ownership records (dry run)", len(orphans))
+		return nil
+	case paused:
+		log.Infof("Not deleting %d orphaned ownership records while the reconciliation is paused", len(orphans))
+		return nil
+	}
+
+	log.Infof("Deleting %d orphaned ownership records", len(orphans))
+	err = registry.DeleteOrphanedRecords(ctx, c.Registry, orphans)
+	c.auditChanges(ctx, &plan.Changes{Delete: orphans}, err)
+	if err != nil {
+		return err
+	}
+	for owner, count := range counts {
+		if count > 0 {
+			orphanedRecordsDeletedTotal.CounterVec.WithLabelValues(owner).Add(float64(count))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	promtestutil "github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider/inmemory"
+	"sigs.k8s.io/external-dns/registry"
+)
+
+func TestShouldRunJanitor(t *testing.T) {
+	now := time.Now()
+	ctrl := &Controller{}
+	assert.False(t, ctrl.shouldRunJanitor(now))
+
+	ctrl.JanitorInterval = time.Hour
+	assert.True(t, ctrl.shouldRunJanitor(now))
+	assert.False(t, ctrl.shouldRunJanitor(now.Add(time.Minute)))
+	assert.True(t, ctrl.shouldRunJanitor(now.Add(time.Hour)))
+}
+
+func TestCollectOrphanedRecords(t *testing.T) {
+	ctx := context.Background()
+	ownership := func(name, owner string) *endpoint.Endpoint {
+		return endpoint.NewEndpoint(name, endpoint.RecordTypeTXT, "\"heritage=external-dns,external-dns/owner="+owner+"\"")
+	}
+	p := inmemory.NewInMemoryProvider()
+	require.NoError(t, p.CreateZone("example.org"))
+	require.NoError(t, p.ApplyChanges(ctx, &plan.Changes{
+		Create: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("www.example.org", endpoint.RecordTypeA, "1.2.3.4"),
+			ownership("a-www.example.org", "owner"),
+			ownership("a-gone.example.org", "owner"),
+			ownership("a-old.example.org", "old-cluster"),
+			ownership("a-other.example.org", "other"),
+		},
+	}))
+	r, err := registry.NewTXTRegistry(p, "", "", "owner", 0, "", nil, nil, false, nil, false)
+	require.NoError(t, err)
+
+	countRecords := func() int {
+		records, err := p.Records(ctx)
+		require.NoError(t, err)
+		return len(records)
+	}
+
+	// the orphans are only reported with the report policy, in dry run and while paused
+	pause := &staticPauseSwitch{paused: true}
+	for _, ctrl := range []*Controller{
+		{Registry: r, JanitorPolicy: JanitorPolicyReport, JanitorOwnerIDs: []string{"old-cluster"}},
+		{Registry: r, JanitorPolicy: JanitorPolicyDelete, JanitorOwnerIDs: []string{"old-cluster"}, DryRun: true},
+		{Registry: r, JanitorPolicy: JanitorPolicyDelete, JanitorOwnerIDs: []string{"old-cluster"}, PauseSwitch: pause},
+	} {
+		require.NoError(t, ctrl.collectOrphanedRecords(ctx))
+		assert.Equal(t, 5, countRecords())
+		assert.InDelta(t, 1, promtestutil.ToFloat64(orphanedRecords.Gauge.WithLabelValues("owner")), 0)
+		assert.InDelta(t, 1, promtestutil.ToFloat64(orphanedRecords.Gauge.WithLabelValues("old-cluster")), 0)
+	}
+
+	deleted := promtestutil.ToFloat64(orphanedRecordsDeletedTotal.CounterVec.WithLabelValues("owner"))
+	ctrl := &Controller{Registry: r, JanitorPolicy: JanitorPolicyDelete, JanitorOwnerIDs: []string{"old-cluster"}}
+	require.NoError(t, ctrl.collectOrphanedRecords(ctx))
+	assert.Equal(t, 3, countRecords())
+	assert.InDelta(t, deleted+1, promtestutil.ToFloat64(orphanedRecordsDeletedTotal.CounterVec.WithLabelValues("owner")), 0)
+
+	require.NoError(t, ctrl.collectOrphanedRecords(ctx))
+	assert.InDelta(t, 0, promtestutil.ToFloat64(orphanedRecords.Gauge.WithLabelValues("owner")), 0)
+}
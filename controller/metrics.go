@@ -16,7 +16,14 @@ limitations under the License.
 
 package controller
 
-import "sigs.k8s.io/external-dns/endpoint"
+import (
+	"strings"
+	"time"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/pkg/metrics"
+	"sigs.k8s.io/external-dns/provider"
+)
 
 type metricsRecorder struct {
 	counterPerEndpointType map[string]int
@@ -52,3 +59,59 @@ func (m *metricsRecorder) getEndpointTypeCount(endpointType string) int {
 func (m *metricsRecorder) loadFloat64(endpointType string) float64 {
 	return float64(m.getEndpointTypeCount(endpointType))
 }
+
+// recordSourceObjects sets, for each endpoint of the sources, whether its record exists in the
+// registry, with the object requesting it and the owner of the record.
+func recordSourceObjects(metric metrics.GaugeVecMetric, endpoints, registryRecords []*endpoint.Endpoint) {
+	owners := make(map[endpoint.EndpointKey]string, len(registryRecords))
+	for _, record := range registryRecords {
+		owners[record.Key()] = record.Labels[endpoint.OwnerLabelKey]
+	}
+
+	for _, ep := range endpoints {
+		kind, namespace, name := splitResource(ep.Labels[endpoint.ResourceLabelKey])
+		owner, found := owners[ep.Key()]
+		value := 0.0
+		if found {
+			value = 1
+		}
+		metric.SetWithLabels(value, ep.DNSName, ep.RecordType, kind, namespace, name, owner)
+	}
+}
+
+// splitResource splits the resource label of an endpoint, e.g. ingress/default/web or node/worker-1.
+func splitResource(resource string) (kind, namespace, name string) {
+	parts := strings.SplitN(resource, "/", 3)
+	switch len(parts) {
+	case 3:
+		return parts[0], parts[1], parts[2]
+	case 2:
+		return parts[0], "", parts[1]
+	default:
+		return resource, "", ""
+	}
+}
+
+// recordZoneSyncs sets the time of the successful sync and the number of owned records of each
+// zone, given the records owned once the changes are applied. The zones without records are
+// reported too, so that a zone whose records were all deleted is still monitored.
+func recordZoneSyncs(zoneNames []string, records []*endpoint.Endpoint, now time.Time) {
+	if len(zoneNames) == 0 {
+		return
+	}
+	zones := provider.ZoneIDName{}
+	counts := make(map[string]int, len(zoneNames))
+	for _, name := range zoneNames {
+		zones.Add(name, name)
+		counts[name] = 0
+	}
+	for _, record := range records {
+		if _, zone := zones.FindZone(record.DNSName); zone != "" {
+			counts[zone]++
+		}
+	}
+	for zone, count := range counts {
+		zoneLastSyncTimestamp.SetWithLabels(float64(now.Unix()), zone)
+		zoneRecordsManaged.SetWithLabels(float64(count), zone)
+	}
+}
@@ -17,14 +17,20 @@ limitations under the License.
 package controller
 
 import (
+	"context"
 	"testing"
+	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
+	promtestutil "github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"sigs.k8s.io/external-dns/endpoint"
 	"sigs.k8s.io/external-dns/internal/testutils"
 	"sigs.k8s.io/external-dns/pkg/apis/externaldns"
+	"sigs.k8s.io/external-dns/pkg/metrics"
 	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider/inmemory"
 	"sigs.k8s.io/external-dns/registry"
 )
 
@@ -374,3 +380,100 @@ func TestGaugeMetricsWithMixedRecords(t *testing.T) {
 	testutils.TestHelperVerifyMetricsGaugeVectorWithLabels(t, 0, registryRecords.Gauge, map[string]string{"record_type": "mx"})
 	testutils.TestHelperVerifyMetricsGaugeVectorWithLabels(t, 43, registryRecords.Gauge, map[string]string{"record_type": "ptr"})
 }
+
+func TestRecordSourceObjects(t *testing.T) {
+	metric := metrics.NewGaugedVectorOpts(prometheus.GaugeOpts{Name: "object_records"},
+		[]string{"dns_name", "record_type", "kind", "namespace", "name", "owner"})
+
+	recordSourceObjects(metric,
+		[]*endpoint.Endpoint{
+			endpoint.NewEndpoint("shop.example.org", endpoint.RecordTypeA, "1.2.3.4").
+				WithLabel(endpoint.ResourceLabelKey, "ingress/web/shop"),
+			endpoint.NewEndpoint("new.example.org", endpoint.RecordTypeCNAME, "lb.example.com").
+				WithLabel(endpoint.ResourceLabelKey, "service/web/new"),
+			endpoint.NewEndpoint("worker-1.example.org", endpoint.RecordTypeA, "10.0.0.1").
+				WithLabel(endpoint.ResourceLabelKey, "node/worker-1"),
+		},
+		[]*endpoint.Endpoint{
+			endpoint.NewEndpoint("shop.example.org", endpoint.RecordTypeA, "1.2.3.4").
+				WithLabel(endpoint.OwnerLabelKey, "default"),
+			endpoint.NewEndpoint("worker-1.example.org", endpoint.RecordTypeA, "10.0.0.2").
+				WithLabel(endpoint.OwnerLabelKey, "other"),
+			endpoint.NewEndpoint("new.example.org", endpoint.RecordTypeA, "1.2.3.4"),
+		},
+	)
+
+	assert.Equal(t, 3, promtestutil.CollectAndCount(&metric.Gauge))
+	testutils.TestHelperVerifyMetricsGaugeVectorWithLabels(t, 1, metric.Gauge, map[string]string{
+		"dns_name": "shop.example.org", "record_type": "a", "kind": "ingress", "namespace": "web", "name": "shop", "owner": "default",
+	})
+	testutils.TestHelperVerifyMetricsGaugeVectorWithLabels(t, 0, metric.Gauge, map[string]string{
+		"dns_name": "new.example.org", "record_type": "cname", "kind": "service", "namespace": "web", "name": "new", "owner": "",
+	})
+	testutils.TestHelperVerifyMetricsGaugeVectorWithLabels(t, 1, metric.Gauge, map[string]string{
+		"dns_name": "worker-1.example.org", "record_type": "a", "kind": "node", "namespace": "", "name": "worker-1", "owner": "other",
+	})
+}
+
+func TestSplitResource(t *testing.T) {
+	for _, tc := range []struct {
+		resource, kind, namespace, name string
+	}{
+		{"ingress/web/shop", "ingress", "web", "shop"},
+		{"node/worker-1", "node", "", "worker-1"},
+		{"", "", "", ""},
+	} {
+		kind, namespace, name := splitResource(tc.resource)
+		assert.Equal(t, []string{tc.kind, tc.namespace, tc.name}, []string{kind, namespace, name}, tc.resource)
+	}
+}
+
+func TestRecordZoneSyncs(t *testing.T) {
+	zoneLastSyncTimestamp.Gauge.Reset()
+	zoneRecordsManaged.Gauge.Reset()
+	now := time.Unix(1700000000, 0)
+
+	recordZoneSyncs([]string{"example.org", "sub.example.org", "empty.example.com"}, []*endpoint.Endpoint{
+		endpoint.NewEndpoint("www.example.org", endpoint.RecordTypeA, "1.2.3.4"),
+		endpoint.NewEndpoint("api.example.org", endpoint.RecordTypeA, "1.2.3.4"),
+		endpoint.NewEndpoint("www.sub.example.org", endpoint.RecordTypeA, "1.2.3.4"),
+		endpoint.NewEndpoint("www.other.net", endpoint.RecordTypeA, "1.2.3.4"),
+	}, now)
+
+	for zone, count := range map[string]float64{"example.org": 2, "sub.example.org": 1, "empty.example.com": 0} {
+		assert.InDelta(t, count, promtestutil.ToFloat64(zoneRecordsManaged.Gauge.WithLabelValues(zone)), 0, zone)
+		assert.InDelta(t, float64(now.Unix()), promtestutil.ToFloat64(zoneLastSyncTimestamp.Gauge.WithLabelValues(zone)), 0, zone)
+	}
+	assert.Equal(t, 3, promtestutil.CollectAndCount(zoneRecordsManaged.Gauge))
+}
+
+func TestControllerRecordsZoneSyncs(t *testing.T) {
+	for _, streamRecords := range []bool{false, true} {
+		zoneLastSyncTimestamp.Gauge.Reset()
+		zoneRecordsManaged.Gauge.Reset()
+
+		p := inmemory.NewInMemoryProvider()
+		require.NoError(t, p.CreateZone("example.org"))
+		require.NoError(t, p.CreateZone("example.com"))
+		r, err := registry.NewNoopRegistry(p)
+		require.NoError(t, err)
+		source := new(testutils.MockSource)
+		source.On("Endpoints").Return([]*endpoint.Endpoint{
+			endpoint.NewEndpoint("www.example.org", endpoint.RecordTypeA, "1.2.3.4"),
+			endpoint.NewEndpoint("api.example.org", endpoint.RecordTypeA, "1.2.3.4"),
+		}, nil)
+		ctrl := &Controller{
+			Source:             source,
+			Registry:           r,
+			Policy:             &plan.SyncPolicy{},
+			ManagedRecordTypes: []string{endpoint.RecordTypeA},
+			StreamRecords:      streamRecords,
+		}
+		require.NoError(t, ctrl.RunOnce(context.Background()))
+
+		assert.InDelta(t, 2, promtestutil.ToFloat64(zoneRecordsManaged.Gauge.WithLabelValues("example.org")), 0)
+		assert.InDelta(t, 0, promtestutil.ToFloat64(zoneRecordsManaged.Gauge.WithLabelValues("example.com")), 0)
+		assert.Positive(t, promtestutil.ToFloat64(zoneLastSyncTimestamp.Gauge.WithLabelValues("example.org")))
+		assert.Positive(t, promtestutil.ToFloat64(zoneLastSyncTimestamp.Gauge.WithLabelValues("example.com")))
+	}
+}
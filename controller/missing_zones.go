@@ -0,0 +1,36 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"sigs.k8s.io/external-dns/pkg/logging"
+	"sigs.k8s.io/external-dns/plan"
+)
+
+// skipMissingZoneCreates removes the creates of the records whose name recently matched no
+// zone of the provider: they would only be skipped by the provider again.
+func (c *Controller) skipMissingZoneCreates(changes *plan.Changes) {
+	creates := changes.Create[:0]
+	for _, ep := range changes.Create {
+		if c.MissingZones.Missing(ep.DNSName) {
+			logging.ForRecord(ep).Debugf("Not creating %s %s: the name matches no zone", ep.DNSName, ep.RecordType)
+			continue
+		}
+		creates = append(creates, ep)
+	}
+	changes.Create = creates
+}
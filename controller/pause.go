@@ -0,0 +1,108 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider"
+)
+
+// PausedKey is the key of the pause ConfigMap which pauses the reconciliation when true.
+const PausedKey = "paused"
+
+// PauseSwitch tells whether the reconciliation is paused: the changes are still planned and
+// reported, but not applied to the DNS provider.
+type PauseSwitch interface {
+	Paused(ctx context.Context) (bool, error)
+}
+
+// configMapPauseSwitch pauses the reconciliation when the paused key of a ConfigMap is true.
+// A missing ConfigMap or key doesn't pause it.
+type configMapPauseSwitch struct {
+	client    kubernetes.Interface
+	namespace string
+	name      string
+}
+
+// NewConfigMapPauseSwitch returns a PauseSwitch reading the paused key of the ConfigMap
+// namespace/name.
+func NewConfigMapPauseSwitch(client kubernetes.Interface, configMap string) (PauseSwitch, error) {
+	namespace, name, ok := strings.Cut(configMap, "/")
+	if !ok || namespace == "" || name == "" {
+		return nil, fmt.Errorf("invalid pause ConfigMap %q: expected namespace/name", configMap)
+	}
+	return &configMapPauseSwitch{client: client, namespace: namespace, name: name}, nil
+}
+
+func (s *configMapPauseSwitch) Paused(ctx context.Context) (bool, error) {
+	cm, err := s.client.CoreV1().ConfigMaps(s.namespace).Get(ctx, s.name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to read the pause ConfigMap %s/%s: %w", s.namespace, s.name, err)
+	}
+	value, ok := cm.Data[PausedKey]
+	if !ok {
+		return false, nil
+	}
+	paused, err := strconv.ParseBool(strings.TrimSpace(value))
+	if err != nil {
+		return false, fmt.Errorf("invalid %s value %q in the pause ConfigMap %s/%s: %w", PausedKey, value, s.namespace, s.name, err)
+	}
+	return paused, nil
+}
+
+// paused returns whether the reconciliation is paused. Failing to tell fails the synchronization
+// with a soft error, so that the changes are neither applied during a freeze nor held back forever.
+func (c *Controller) paused(ctx context.Context) (bool, error) {
+	if c.PauseSwitch == nil {
+		return false, nil
+	}
+	paused, err := c.PauseSwitch.Paused(ctx)
+	if err != nil {
+		return false, provider.NewSoftError(err)
+	}
+	if paused {
+		reconciliationPaused.Gauge.Set(1)
+	} else {
+		reconciliationPaused.Gauge.Set(0)
+	}
+	return paused, nil
+}
+
+// logPausedChanges reports the changes that are not applied as the reconciliation is paused. The
+// changes themselves are logged by logPlan once planned.
+func logPausedChanges(zone string, changes *plan.Changes) {
+	log.WithFields(log.Fields{
+		"zone":    zone,
+		"creates": len(changes.Create),
+		"updates": len(changes.UpdateNew),
+		"deletes": len(changes.Delete),
+		"adopts":  len(changes.Adopt),
+	}).Warn("Reconciliation paused: the changes are not applied")
+}
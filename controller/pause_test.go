@@ -0,0 +1,128 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	promtestutil "github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/internal/testutils"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider"
+	"sigs.k8s.io/external-dns/registry"
+)
+
+func TestConfigMapPauseSwitch(t *testing.T) {
+	ctx := context.Background()
+	client := fake.NewClientset()
+	pause, err := NewConfigMapPauseSwitch(client, "kube-system/external-dns-pause")
+	require.NoError(t, err)
+
+	// a missing ConfigMap doesn't pause the reconciliation
+	paused, err := pause.Paused(ctx)
+	require.NoError(t, err)
+	assert.False(t, paused)
+
+	cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "kube-system", Name: "external-dns-pause"}}
+	_, err = client.CoreV1().ConfigMaps("kube-system").Create(ctx, cm, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	for _, tc := range []struct {
+		data      map[string]string
+		paused    bool
+		expectErr bool
+	}{
+		{data: nil},
+		{data: map[string]string{PausedKey: "true"}, paused: true},
+		{data: map[string]string{PausedKey: " True\n"}, paused: true},
+		{data: map[string]string{PausedKey: "false"}},
+		{data: map[string]string{PausedKey: "yes"}, expectErr: true},
+	} {
+		cm.Data = tc.data
+		_, err = client.CoreV1().ConfigMaps("kube-system").Update(ctx, cm, metav1.UpdateOptions{})
+		require.NoError(t, err)
+
+		paused, err := pause.Paused(ctx)
+		if tc.expectErr {
+			require.Error(t, err)
+			continue
+		}
+		require.NoError(t, err)
+		assert.Equal(t, tc.paused, paused, tc.data)
+	}
+
+	for _, configMap := range []string{"external-dns-pause", "/external-dns-pause", "kube-system/"} {
+		_, err := NewConfigMapPauseSwitch(client, configMap)
+		require.Error(t, err, configMap)
+	}
+}
+
+type staticPauseSwitch struct {
+	paused bool
+	err    error
+}
+
+func (s *staticPauseSwitch) Paused(_ context.Context) (bool, error) {
+	return s.paused, s.err
+}
+
+func TestControllerPaused(t *testing.T) {
+	source := new(testutils.MockSource)
+	source.On("Endpoints").Return([]*endpoint.Endpoint{
+		{DNSName: "www.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"1.2.3.4"}},
+	}, nil)
+
+	for _, streamRecords := range []bool{false, true} {
+		prov := &filteredMockProvider{}
+		r, err := registry.NewNoopRegistry(prov)
+		require.NoError(t, err)
+
+		pause := &staticPauseSwitch{paused: true}
+		ctrl := &Controller{
+			Source:             source,
+			Registry:           r,
+			Policy:             &plan.SyncPolicy{},
+			ManagedRecordTypes: []string{endpoint.RecordTypeA},
+			StreamRecords:      streamRecords,
+			PauseSwitch:        pause,
+		}
+
+		// the changes are planned but not applied
+		require.NoError(t, ctrl.RunOnce(context.Background()))
+		assert.Empty(t, prov.ApplyChangesCalls)
+		assert.Equal(t, 1, prov.RecordsCallCount)
+		assert.InDelta(t, 1, promtestutil.ToFloat64(reconciliationPaused.Gauge), 0)
+
+		// the synchronization fails when the switch can't tell whether it's paused
+		pause.err = assert.AnError
+		require.ErrorIs(t, ctrl.RunOnce(context.Background()), provider.SoftError)
+		assert.Empty(t, prov.ApplyChangesCalls)
+
+		pause.paused, pause.err = false, nil
+		require.NoError(t, ctrl.RunOnce(context.Background()))
+		assert.Len(t, prov.ApplyChangesCalls, 1)
+		assert.InDelta(t, 0, promtestutil.ToFloat64(reconciliationPaused.Gauge), 0)
+	}
+}
@@ -0,0 +1,211 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+)
+
+// PlanKey is the key of the plan ConfigMap holding the last plan.
+const PlanKey = "plan"
+
+// PlanStore persists the last plan, the changes planned for each zone, so that the planned changes
+// are not logged again after a restart.
+type PlanStore interface {
+	Load(ctx context.Context) (map[string][]string, error)
+	Save(ctx context.Context, changes map[string][]string) error
+}
+
+// configMapPlanStore persists the last plan in JSON under the plan key of a ConfigMap, created if missing.
+type configMapPlanStore struct {
+	client    kubernetes.Interface
+	namespace string
+	name      string
+}
+
+// NewConfigMapPlanStore returns a PlanStore persisting the last plan in the ConfigMap namespace/name.
+func NewConfigMapPlanStore(client kubernetes.Interface, configMap string) (PlanStore, error) {
+	namespace, name, ok := strings.Cut(configMap, "/")
+	if !ok || namespace == "" || name == "" {
+		return nil, fmt.Errorf("invalid plan ConfigMap %q: expected namespace/name", configMap)
+	}
+	return &configMapPlanStore{client: client, namespace: namespace, name: name}, nil
+}
+
+func (s *configMapPlanStore) Load(ctx context.Context) (map[string][]string, error) {
+	cm, err := s.client.CoreV1().ConfigMaps(s.namespace).Get(ctx, s.name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return map[string][]string{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the plan ConfigMap %s/%s: %w", s.namespace, s.name, err)
+	}
+	changes := map[string][]string{}
+	if data, ok := cm.Data[PlanKey]; ok {
+		if err := json.Unmarshal([]byte(data), &changes); err != nil {
+			return nil, fmt.Errorf("invalid %s value in the plan ConfigMap %s/%s: %w", PlanKey, s.namespace, s.name, err)
+		}
+	}
+	return changes, nil
+}
+
+func (s *configMapPlanStore) Save(ctx context.Context, changes map[string][]string) error {
+	data, err := json.Marshal(changes)
+	if err != nil {
+		return err
+	}
+	configMaps := s.client.CoreV1().ConfigMaps(s.namespace)
+	cm, err := configMaps.Get(ctx, s.name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		cm = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Namespace: s.namespace, Name: s.name},
+			Data:       map[string]string{PlanKey: string(data)},
+		}
+		_, err = configMaps.Create(ctx, cm, metav1.CreateOptions{})
+	} else if err == nil {
+		if cm.Data == nil {
+			cm.Data = map[string]string{}
+		}
+		cm.Data[PlanKey] = string(data)
+		_, err = configMaps.Update(ctx, cm, metav1.UpdateOptions{})
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write the plan ConfigMap %s/%s: %w", s.namespace, s.name, err)
+	}
+	return nil
+}
+
+// planHistory is the last plan, whose changes are not logged again by the next synchronizations.
+type planHistory struct {
+	// changes are the changes last planned for each zone, sorted
+	changes map[string][]string
+	// loaded tells whether the changes were loaded from the plan store
+	loaded bool
+	// dirty tells whether the changes differ from those saved in the plan store
+	dirty bool
+	// churn is the number of changes added to or removed from the plans of the synchronization
+	churn int
+}
+
+// planLines returns the changes, one per line, sorted.
+func planLines(changes *plan.Changes) []string {
+	var lines []string
+	for _, c := range []struct {
+		action    string
+		endpoints []*endpoint.Endpoint
+	}{
+		{"create", changes.Create},
+		{"update", changes.UpdateNew},
+		{"delete", changes.Delete},
+		{"adopt", changes.Adopt},
+	} {
+		for _, ep := range c.endpoints {
+			line := c.action + " " + ep.DNSName + " " + ep.RecordType
+			if ep.SetIdentifier != "" {
+				line += " " + ep.SetIdentifier
+			}
+			if ep.RecordTTL.IsConfigured() {
+				line += " ttl=" + strconv.FormatInt(int64(ep.RecordTTL), 10)
+			}
+			lines = append(lines, line+" "+ep.Targets.String())
+		}
+	}
+	slices.Sort(lines)
+	return lines
+}
+
+// logPlan logs the changes planned for a zone which weren't planned by the previous synchronization,
+// and those no longer planned, applied or withdrawn, at debug level, rather than all the planned
+// changes every synchronization.
+func (c *Controller) logPlan(ctx context.Context, zone string, changes *plan.Changes) {
+	h := &c.planHistory
+	if !h.loaded {
+		h.loaded = true
+		h.changes = map[string][]string{}
+		if c.PlanStore != nil {
+			changes, err := c.PlanStore.Load(ctx)
+			if err != nil {
+				log.Warnf("Logging all the planned changes: %v", err)
+			} else {
+				h.changes = changes
+			}
+		}
+	}
+
+	lines := planLines(changes)
+	previous := h.changes[zone]
+	added, removed := 0, 0
+	for _, line := range lines {
+		if _, found := slices.BinarySearch(previous, line); !found {
+			log.WithField("zone", zone).Infof("Planned: %s", line)
+			added++
+		}
+	}
+	for _, line := range previous {
+		if _, found := slices.BinarySearch(lines, line); !found {
+			log.WithField("zone", zone).Debugf("No longer planned: %s", line)
+			removed++
+		}
+	}
+	if unchanged := len(lines) - added; unchanged > 0 {
+		log.WithField("zone", zone).Debugf("%d planned changes already planned by the previous synchronization", unchanged)
+	}
+	planChurnTotal.CounterVec.WithLabelValues("added").Add(float64(added))
+	planChurnTotal.CounterVec.WithLabelValues("removed").Add(float64(removed))
+	h.churn += added + removed
+
+	if added+removed == 0 {
+		return
+	}
+	h.dirty = true
+	if len(lines) == 0 {
+		delete(h.changes, zone)
+	} else {
+		h.changes[zone] = lines
+	}
+}
+
+// savePlan reports the churn of the plans of the synchronization, and saves them in the plan
+// store if they changed. Failing to save them is only logged.
+func (c *Controller) savePlan(ctx context.Context) {
+	h := &c.planHistory
+	planChurn.Gauge.Set(float64(h.churn))
+	h.churn = 0
+	if c.PlanStore == nil || !h.dirty {
+		return
+	}
+	if err := c.PlanStore.Save(ctx, maps.Clone(h.changes)); err != nil {
+		log.Warnf("Failed to save the plan: %v", err)
+		return
+	}
+	h.dirty = false
+}
@@ -0,0 +1,135 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	promtestutil "github.com/prometheus/client_golang/prometheus/testutil"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/internal/testutils"
+	"sigs.k8s.io/external-dns/plan"
+)
+
+func TestPlanLines(t *testing.T) {
+	assert.Equal(t, []string{
+		"adopt old.example.org A 1.2.3.4",
+		"create www.example.org A ttl=300 1.2.3.4;5.6.7.8",
+		"delete gone.example.org CNAME lb.example.org",
+		"update api.example.org A eu 5.6.7.8",
+	}, planLines(&plan.Changes{
+		Create:    []*endpoint.Endpoint{endpoint.NewEndpointWithTTL("www.example.org", endpoint.RecordTypeA, 300, "1.2.3.4", "5.6.7.8")},
+		UpdateOld: []*endpoint.Endpoint{endpoint.NewEndpoint("api.example.org", endpoint.RecordTypeA, "1.2.3.4").WithSetIdentifier("eu")},
+		UpdateNew: []*endpoint.Endpoint{endpoint.NewEndpoint("api.example.org", endpoint.RecordTypeA, "5.6.7.8").WithSetIdentifier("eu")},
+		Delete:    []*endpoint.Endpoint{endpoint.NewEndpoint("gone.example.org", endpoint.RecordTypeCNAME, "lb.example.org")},
+		Adopt:     []*endpoint.Endpoint{endpoint.NewEndpoint("old.example.org", endpoint.RecordTypeA, "1.2.3.4")},
+	}))
+}
+
+func TestLogPlan(t *testing.T) {
+	ctx := context.Background()
+	client := fake.NewClientset()
+	store, err := NewConfigMapPlanStore(client, "kube-system/external-dns-plan")
+	require.NoError(t, err)
+
+	www := endpoint.NewEndpoint("www.example.org", endpoint.RecordTypeA, "1.2.3.4")
+	api := endpoint.NewEndpoint("api.example.org", endpoint.RecordTypeA, "1.2.3.4")
+	sync := func(c *Controller, changes *plan.Changes) {
+		c.logPlan(ctx, "example.org", changes)
+		c.savePlan(ctx)
+	}
+
+	// the first plan is logged in full and saved
+	hook := testutils.LogsUnderTestWithLogLevel(log.InfoLevel, t)
+	c := &Controller{PlanStore: store}
+	sync(c, &plan.Changes{Create: []*endpoint.Endpoint{www}})
+	testutils.TestHelperLogContains("Planned: create www.example.org A 1.2.3.4", hook, t)
+	assert.InDelta(t, 1, promtestutil.ToFloat64(planChurn.Gauge), 0)
+	saved, err := store.Load(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]string{"example.org": {"create www.example.org A 1.2.3.4"}}, saved)
+
+	// the same plan isn't logged again, nor after a restart
+	for _, c := range []*Controller{c, {PlanStore: store}} {
+		hook.Reset()
+		sync(c, &plan.Changes{Create: []*endpoint.Endpoint{www}})
+		assert.Empty(t, hook.AllEntries())
+		assert.InDelta(t, 0, promtestutil.ToFloat64(planChurn.Gauge), 0)
+	}
+
+	// only the changes added to the plan are logged
+	hook.Reset()
+	sync(c, &plan.Changes{Create: []*endpoint.Endpoint{www, api}})
+	testutils.TestHelperLogContains("Planned: create api.example.org A 1.2.3.4", hook, t)
+	testutils.TestHelperLogNotContains("Planned: create www.example.org A 1.2.3.4", hook, t)
+
+	// the changes no longer planned are forgotten
+	hook.Reset()
+	sync(c, &plan.Changes{})
+	assert.Empty(t, hook.AllEntries())
+	assert.InDelta(t, 2, promtestutil.ToFloat64(planChurn.Gauge), 0)
+	saved, err = store.Load(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, saved)
+}
+
+func TestConfigMapPlanStore(t *testing.T) {
+	ctx := context.Background()
+	client := fake.NewClientset()
+	store, err := NewConfigMapPlanStore(client, "kube-system/external-dns-plan")
+	require.NoError(t, err)
+
+	// a missing ConfigMap holds no plan, and is created when the plan is saved
+	changes, err := store.Load(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, changes)
+	require.NoError(t, store.Save(ctx, map[string][]string{"": {"create www.example.org A 1.2.3.4"}}))
+	cm, err := client.CoreV1().ConfigMaps("kube-system").Get(ctx, "external-dns-plan", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"": ["create www.example.org A 1.2.3.4"]}`, cm.Data[PlanKey])
+
+	// the other keys of the ConfigMap are kept
+	cm.Data["note"] = "managed by external-dns"
+	_, err = client.CoreV1().ConfigMaps("kube-system").Update(ctx, cm, metav1.UpdateOptions{})
+	require.NoError(t, err)
+	require.NoError(t, store.Save(ctx, map[string][]string{}))
+	cm, err = client.CoreV1().ConfigMaps("kube-system").Get(ctx, "external-dns-plan", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{PlanKey: "{}", "note": "managed by external-dns"}, cm.Data)
+
+	// an invalid plan fails to load
+	_, err = client.CoreV1().ConfigMaps("kube-system").Update(ctx, &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "kube-system", Name: "external-dns-plan"},
+		Data:       map[string]string{PlanKey: "not json"},
+	}, metav1.UpdateOptions{})
+	require.NoError(t, err)
+	_, err = store.Load(ctx)
+	require.Error(t, err)
+
+	for _, configMap := range []string{"external-dns-plan", "/external-dns-plan", "kube-system/"} {
+		_, err := NewConfigMapPlanStore(client, configMap)
+		require.Error(t, err, configMap)
+	}
+}
@@ -0,0 +1,268 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/pkg/apis/externaldns"
+	"sigs.k8s.io/external-dns/pkg/secrets"
+	"sigs.k8s.io/external-dns/provider"
+	"sigs.k8s.io/external-dns/source"
+)
+
+// preflightResult is the result of a check of the preflight command, a line of its report.
+type preflightResult struct {
+	check  string
+	passed bool
+	detail string
+}
+
+func preflightPass(check, format string, args ...any) preflightResult {
+	return preflightResult{check: check, passed: true, detail: fmt.Sprintf(format, args...)}
+}
+
+func preflightFail(check string, err error) preflightResult {
+	return preflightResult{check: check, detail: err.Error()}
+}
+
+// sourceResource is a resource a source lists and watches.
+type sourceResource struct {
+	group, resource string
+	clusterScoped   bool
+}
+
+// sourceResources are the resources watched by the sources, as granted by the ClusterRole of
+// the Helm chart. The sources which are not listed don't watch Kubernetes resources.
+var sourceResources = map[string][]sourceResource{
+	"service":              {{resource: "services"}, {group: "discovery.k8s.io", resource: "endpointslices"}, {resource: "pods"}, {resource: "nodes", clusterScoped: true}},
+	"ingress":              {{group: "networking.k8s.io", resource: "ingresses"}},
+	"node":                 {{resource: "nodes", clusterScoped: true}},
+	"pod":                  {{resource: "pods"}, {resource: "nodes", clusterScoped: true}},
+	"gateway-httproute":    {{group: "gateway.networking.k8s.io", resource: "gateways"}, {group: "gateway.networking.k8s.io", resource: "httproutes"}, {resource: "namespaces", clusterScoped: true}},
+	"gateway-grpcroute":    {{group: "gateway.networking.k8s.io", resource: "gateways"}, {group: "gateway.networking.k8s.io", resource: "grpcroutes"}, {resource: "namespaces", clusterScoped: true}},
+	"gateway-tlsroute":     {{group: "gateway.networking.k8s.io", resource: "gateways"}, {group: "gateway.networking.k8s.io", resource: "tlsroutes"}, {resource: "namespaces", clusterScoped: true}},
+	"gateway-tcproute":     {{group: "gateway.networking.k8s.io", resource: "gateways"}, {group: "gateway.networking.k8s.io", resource: "tcproutes"}, {resource: "namespaces", clusterScoped: true}},
+	"gateway-udproute":     {{group: "gateway.networking.k8s.io", resource: "gateways"}, {group: "gateway.networking.k8s.io", resource: "udproutes"}, {resource: "namespaces", clusterScoped: true}},
+	"istio-gateway":        {{group: "networking.istio.io", resource: "gateways"}, {resource: "services"}},
+	"istio-virtualservice": {{group: "networking.istio.io", resource: "virtualservices"}, {group: "networking.istio.io", resource: "gateways"}, {resource: "services"}},
+	"ambassador-host":      {{group: "getambassador.io", resource: "hosts"}, {resource: "services"}},
+	"contour-httpproxy":    {{group: "projectcontour.io", resource: "httpproxies"}, {resource: "services"}},
+	"gloo-proxy":           {{group: "gloo.solo.io", resource: "proxies"}, {group: "gateway.solo.io", resource: "virtualservices"}, {resource: "services"}},
+	"traefik-proxy":        {{group: "traefik.io", resource: "ingressroutes"}, {group: "traefik.io", resource: "ingressroutetcps"}, {group: "traefik.io", resource: "ingressrouteudps"}, {resource: "services"}},
+	"openshift-route":      {{group: "route.openshift.io", resource: "routes"}},
+	"skipper-routegroup":   {{group: "zalando.org", resource: "routegroups"}},
+	"kong-tcpingress":      {{group: "configuration.konghq.com", resource: "tcpingresses"}, {resource: "services"}},
+	"f5-virtualserver":     {{group: "cis.f5.com", resource: "virtualservers"}},
+	"f5-transportserver":   {{group: "cis.f5.com", resource: "transportservers"}},
+}
+
+// preflight checks that external-dns can run with the configuration, and writes a pass/fail
+// report to w. It returns an error if any check failed.
+func preflight(ctx context.Context, cfg *externaldns.Config, w io.Writer) error {
+	var results []preflightResult
+
+	clientGenerator := &source.SingletonClientGenerator{
+		KubeConfig:     cfg.KubeConfig,
+		APIServerURL:   cfg.APIServerURL,
+		RequestTimeout: cfg.RequestTimeout,
+	}
+	if client, err := clientGenerator.KubeClient(); err != nil {
+		results = append(results, preflightFail("kubernetes", err))
+	} else {
+		results = append(results, checkSourcePermissions(ctx, cfg, client)...)
+	}
+
+	if err := resolveSecrets(ctx, cfg, secrets.NewWatcher(buildSecretResolver(cfg), 0)); err != nil {
+		results = append(results, preflightFail("credentials", err))
+	} else if p, err := buildProvider(ctx, cfg, createDomainFilter(cfg), nil, nil); err != nil {
+		results = append(results, preflightFail("credentials", err))
+	} else {
+		results = append(results, checkProvider(ctx, cfg, p)...)
+	}
+
+	return writePreflightReport(w, results)
+}
+
+// checkSourcePermissions checks that the configured sources are allowed to list and watch
+// the resources they need, in the namespace of --namespace.
+func checkSourcePermissions(ctx context.Context, cfg *externaldns.Config, client kubernetes.Interface) []preflightResult {
+	var results []preflightResult
+	for _, name := range cfg.Sources {
+		resources, ok := sourceResources[name]
+		if name == "crd" {
+			group, _, _ := strings.Cut(cfg.CRDSourceAPIVersion, "/")
+			resources, ok = []sourceResource{{group: group, resource: strings.ToLower(cfg.CRDSourceKind) + "s"}}, true
+		}
+		check := "source " + name
+		if !ok {
+			results = append(results, preflightPass(check, "no Kubernetes resources to check"))
+			continue
+		}
+
+		var denied []string
+		for _, r := range resources {
+			namespace := cfg.Namespace
+			if r.clusterScoped {
+				namespace = ""
+			}
+			for _, verb := range []string{"list", "watch"} {
+				allowed, err := canI(ctx, client, namespace, verb, r)
+				if err != nil {
+					return append(results, preflightFail(check, fmt.Errorf("failed to check the permissions: %w", err)))
+				}
+				if !allowed {
+					denied = append(denied, verb+" "+r.String())
+				}
+			}
+		}
+		if len(denied) > 0 {
+			results = append(results, preflightFail(check, fmt.Errorf("not allowed to %s", strings.Join(denied, ", "))))
+			continue
+		}
+		results = append(results, preflightPass(check, "allowed to list and watch %d resources", len(resources)))
+	}
+	return results
+}
+
+func (r sourceResource) String() string {
+	if r.group == "" {
+		return r.resource
+	}
+	return r.resource + "." + r.group
+}
+
+// canI returns whether external-dns is allowed the verb on the resource.
+func canI(ctx context.Context, client kubernetes.Interface, namespace, verb string, r sourceResource) (bool, error) {
+	review, err := client.AuthorizationV1().SelfSubjectAccessReviews().Create(ctx, &authorizationv1.SelfSubjectAccessReview{
+		Spec: authorizationv1.SelfSubjectAccessReviewSpec{
+			ResourceAttributes: &authorizationv1.ResourceAttributes{
+				Namespace: namespace,
+				Verb:      verb,
+				Group:     r.group,
+				Resource:  r.resource,
+			},
+		},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return false, err
+	}
+	return review.Status.Allowed, nil
+}
+
+// checkProvider checks the credentials of the provider, that each domain of the domain filter
+// is in an accessible zone, and that the registry can read the records.
+func checkProvider(ctx context.Context, cfg *externaldns.Config, p provider.Provider) []preflightResult {
+	zones, err := provider.ZoneNames(ctx, p)
+	if err != nil {
+		return []preflightResult{preflightFail("credentials", err)}
+	}
+
+	var results []preflightResult
+	if zones == nil {
+		// the provider doesn't list its zones: reading its records checks the credentials
+		records, err := p.Records(ctx)
+		if err != nil {
+			return []preflightResult{preflightFail("credentials", err)}
+		}
+		results = append(results,
+			preflightPass("credentials", "%d records readable", len(records)),
+			preflightPass("zones", "not listed by the %s provider", cfg.Provider))
+	} else {
+		results = append(results, preflightPass("credentials", "%d zones accessible", len(zones)))
+		results = append(results, checkZones(cfg.DomainFilter, zones)...)
+	}
+
+	ownerIDTemplate, err := endpoint.NewOwnerIDTemplate(cfg.TXTOwnerIDTemplate)
+	if err != nil {
+		return append(results, preflightFail("registry "+cfg.Registry, err))
+	}
+	reg, err := selectRegistry(cfg, p, ownerIDTemplate)
+	if err != nil {
+		return append(results, preflightFail("registry "+cfg.Registry, err))
+	}
+	records, err := reg.Records(ctx)
+	if err != nil {
+		return append(results, preflightFail("registry "+cfg.Registry, err))
+	}
+	owned := 0
+	for _, ep := range records {
+		if ownerIDTemplate.IsOwnedBy(cfg.TXTOwnerID, ep.Labels) {
+			owned++
+		}
+	}
+	return append(results, preflightPass("registry "+cfg.Registry, "%d records, %d owned by %q", len(records), owned, cfg.TXTOwnerID))
+}
+
+// checkZones checks that each domain of the domain filter is in, or contains, an accessible zone.
+func checkZones(domains, zones []string) []preflightResult {
+	var results []preflightResult
+	for _, domain := range domains {
+		domain = strings.TrimSuffix(strings.TrimPrefix(domain, "."), ".")
+		if domain == "" {
+			continue
+		}
+		var matching []string
+		for _, zone := range zones {
+			name := strings.TrimSuffix(zone, ".")
+			if name == domain || strings.HasSuffix(name, "."+domain) || strings.HasSuffix(domain, "."+name) {
+				matching = append(matching, name)
+			}
+		}
+		check := "domain " + domain
+		if len(matching) == 0 {
+			results = append(results, preflightFail(check, errors.New("no accessible zone for the domain")))
+			continue
+		}
+		slices.Sort(matching)
+		results = append(results, preflightPass(check, "zones %s", strings.Join(matching, ", ")))
+	}
+	return results
+}
+
+// writePreflightReport writes the results, and returns an error if any check failed.
+func writePreflightReport(w io.Writer, results []preflightResult) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "CHECK\tRESULT\tDETAIL")
+	failures := 0
+	for _, r := range results {
+		result := "PASS"
+		if !r.passed {
+			result = "FAIL"
+			failures++
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", r.check, result, r.detail)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	if failures > 0 {
+		return fmt.Errorf("%d of %d checks failed", failures, len(results))
+	}
+	return nil
+}
@@ -0,0 +1,149 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/pkg/apis/externaldns"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider/inmemory"
+)
+
+// newAccessReviewClient returns a client allowing the access to the resources for which allowed
+// returns true, and recording the reviews.
+func newAccessReviewClient(allowed func(*authorizationv1.ResourceAttributes) bool, reviews *[]authorizationv1.ResourceAttributes) *fake.Clientset {
+	client := fake.NewClientset()
+	client.PrependReactor("create", "selfsubjectaccessreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		review := action.(k8stesting.CreateAction).GetObject().(*authorizationv1.SelfSubjectAccessReview)
+		*reviews = append(*reviews, *review.Spec.ResourceAttributes)
+		review.Status.Allowed = allowed(review.Spec.ResourceAttributes)
+		return true, review, nil
+	})
+	return client
+}
+
+func TestCheckSourcePermissions(t *testing.T) {
+	cfg := externaldns.NewConfig()
+	cfg.Sources = []string{"ingress", "service", "crd", "fake"}
+	cfg.Namespace = "apps"
+	cfg.CRDSourceAPIVersion = "externaldns.k8s.io/v1alpha1"
+	cfg.CRDSourceKind = "DNSEndpoint"
+
+	var reviews []authorizationv1.ResourceAttributes
+	client := newAccessReviewClient(func(attrs *authorizationv1.ResourceAttributes) bool {
+		// endpointslices can be listed but not watched
+		return attrs.Resource != "endpointslices" || attrs.Verb == "list"
+	}, &reviews)
+
+	results := checkSourcePermissions(context.Background(), cfg, client)
+	assert.Equal(t, []preflightResult{
+		{check: "source ingress", passed: true, detail: "allowed to list and watch 1 resources"},
+		{check: "source service", detail: "not allowed to watch endpointslices.discovery.k8s.io"},
+		{check: "source crd", passed: true, detail: "allowed to list and watch 1 resources"},
+		{check: "source fake", passed: true, detail: "no Kubernetes resources to check"},
+	}, results)
+
+	assert.Contains(t, reviews, authorizationv1.ResourceAttributes{Namespace: "apps", Verb: "watch", Group: "networking.k8s.io", Resource: "ingresses"})
+	assert.Contains(t, reviews, authorizationv1.ResourceAttributes{Namespace: "apps", Verb: "list", Group: "externaldns.k8s.io", Resource: "dnsendpoints"})
+	// the nodes are not namespaced
+	assert.Contains(t, reviews, authorizationv1.ResourceAttributes{Verb: "list", Resource: "nodes"})
+}
+
+func TestCheckSourcePermissionsReviewError(t *testing.T) {
+	cfg := externaldns.NewConfig()
+	cfg.Sources = []string{"ingress"}
+
+	client := fake.NewClientset()
+	client.PrependReactor("create", "selfsubjectaccessreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, errors.New("unauthorized")
+	})
+
+	results := checkSourcePermissions(context.Background(), cfg, client)
+	require.Len(t, results, 1)
+	assert.False(t, results[0].passed)
+	assert.Equal(t, "failed to check the permissions: unauthorized", results[0].detail)
+}
+
+func TestCheckProvider(t *testing.T) {
+	p := inmemory.NewInMemoryProvider(inmemory.InMemoryInitZones([]string{"example.org", "dev.example.com"}))
+	require.NoError(t, p.ApplyChanges(context.Background(), &plan.Changes{
+		Create: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("www.example.org", endpoint.RecordTypeA, "1.2.3.4"),
+			endpoint.NewEndpoint("api.example.org", endpoint.RecordTypeA, "1.2.3.5").WithLabel(endpoint.OwnerLabelKey, "owner"),
+		},
+	}))
+
+	cfg := externaldns.NewConfig()
+	cfg.Provider = "inmemory"
+	cfg.Registry = "noop"
+	cfg.TXTOwnerID = "owner"
+	cfg.DomainFilter = []string{"example.org", "example.com", ".example.net"}
+
+	results := checkProvider(context.Background(), cfg, p)
+	assert.Equal(t, []preflightResult{
+		{check: "credentials", passed: true, detail: "2 zones accessible"},
+		{check: "domain example.org", passed: true, detail: "zones example.org"},
+		{check: "domain example.com", passed: true, detail: "zones dev.example.com"},
+		{check: "domain example.net", detail: "no accessible zone for the domain"},
+		{check: "registry noop", passed: true, detail: `2 records, 1 owned by "owner"`},
+	}, results)
+}
+
+func TestCheckProviderCredentials(t *testing.T) {
+	cfg := externaldns.NewConfig()
+	cfg.Provider = "mock"
+	cfg.Registry = "noop"
+
+	p := &filteredMockProvider{}
+	results := checkProvider(context.Background(), cfg, p)
+	assert.Equal(t, []preflightResult{
+		{check: "credentials", passed: true, detail: "0 records readable"},
+		{check: "zones", passed: true, detail: "not listed by the mock provider"},
+		{check: "registry noop", passed: true, detail: `0 records, 0 owned by ""`},
+	}, results)
+
+	results = checkProvider(context.Background(), cfg, &errorMockProvider{})
+	assert.Equal(t, []preflightResult{{check: "credentials", detail: "error for testing"}}, results)
+}
+
+func TestWritePreflightReport(t *testing.T) {
+	var out bytes.Buffer
+	require.NoError(t, writePreflightReport(&out, []preflightResult{
+		{check: "credentials", passed: true, detail: "2 zones accessible"},
+	}))
+	assert.Equal(t, "CHECK        RESULT  DETAIL\ncredentials  PASS    2 zones accessible\n", out.String())
+
+	out.Reset()
+	err := writePreflightReport(&out, []preflightResult{
+		{check: "credentials", passed: true, detail: "2 zones accessible"},
+		{check: "domain example.net", detail: "no accessible zone for the domain"},
+	})
+	require.EqualError(t, err, "1 of 2 checks failed")
+	assert.Contains(t, out.String(), "domain example.net  FAIL    no accessible zone for the domain\n")
+}
@@ -0,0 +1,64 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"time"
+)
+
+// SourceChanged schedules a synchronization on a change of the source objects, and records
+// when the change was observed to measure the time taken to propagate it to the DNS provider.
+func (c *Controller) SourceChanged(now time.Time) {
+	c.runAtMutex.Lock()
+	if c.changedAt.IsZero() {
+		c.changedAt = now
+	}
+	c.runAtMutex.Unlock()
+	c.ScheduleRunOnce(now)
+}
+
+// takeChangedAt returns when the earliest change of the sources not reconciled yet was
+// observed, and forgets it: the changes observed from now on are left to the next synchronization.
+func (c *Controller) takeChangedAt() time.Time {
+	c.runAtMutex.Lock()
+	defer c.runAtMutex.Unlock()
+	changedAt := c.changedAt
+	c.changedAt = time.Time{}
+	return changedAt
+}
+
+// restoreChangedAt remembers again a change of the sources which wasn't reconciled.
+func (c *Controller) restoreChangedAt(changedAt time.Time) {
+	if changedAt.IsZero() {
+		return
+	}
+	c.runAtMutex.Lock()
+	defer c.runAtMutex.Unlock()
+	if c.changedAt.IsZero() || changedAt.Before(c.changedAt) {
+		c.changedAt = changedAt
+	}
+}
+
+// observePropagation records the time taken to apply the changes resulting from a change of the
+// sources. Nothing is recorded when no change was observed, e.g. on a periodic synchronization,
+// or when the change didn't require any change of the records.
+func observePropagation(changedAt time.Time, applied bool, now time.Time) {
+	if changedAt.IsZero() || !applied {
+		return
+	}
+	propagationDuration.Histogram.Observe(now.Sub(changedAt).Seconds())
+}
@@ -0,0 +1,126 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/internal/testutils"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider/inmemory"
+	"sigs.k8s.io/external-dns/registry"
+)
+
+// propagationSamples returns the number of propagations observed and the sum of their durations.
+func propagationSamples(t *testing.T) (uint64, float64) {
+	t.Helper()
+	var m dto.Metric
+	require.NoError(t, propagationDuration.Histogram.Write(&m))
+	return m.Histogram.GetSampleCount(), m.Histogram.GetSampleSum()
+}
+
+func TestSourceChanged(t *testing.T) {
+	now := time.Now()
+	ctrl := &Controller{}
+	ctrl.SourceChanged(now)
+	ctrl.SourceChanged(now.Add(time.Minute))
+	assert.Equal(t, now, ctrl.changedAt, "the earliest change is kept")
+
+	assert.Equal(t, now, ctrl.takeChangedAt())
+	assert.True(t, ctrl.changedAt.IsZero())
+
+	ctrl.SourceChanged(now.Add(time.Minute))
+	ctrl.restoreChangedAt(now)
+	assert.Equal(t, now, ctrl.changedAt, "a change not reconciled is older than those observed since")
+	ctrl.restoreChangedAt(time.Time{})
+	assert.Equal(t, now, ctrl.changedAt)
+}
+
+func TestObservePropagation(t *testing.T) {
+	count, sum := propagationSamples(t)
+	now := time.Now()
+
+	observePropagation(time.Time{}, true, now)
+	observePropagation(now.Add(-time.Minute), false, now)
+	newCount, _ := propagationSamples(t)
+	assert.Equal(t, count, newCount)
+
+	observePropagation(now.Add(-time.Minute), true, now)
+	newCount, newSum := propagationSamples(t)
+	assert.Equal(t, count+1, newCount)
+	assert.InDelta(t, sum+60, newSum, 0.001)
+}
+
+func TestControllerObservesPropagation(t *testing.T) {
+	for _, streamRecords := range []bool{false, true} {
+		p := inmemory.NewInMemoryProvider()
+		require.NoError(t, p.CreateZone("example.org"))
+		r, err := registry.NewNoopRegistry(p)
+		require.NoError(t, err)
+		source := new(testutils.MockSource)
+		source.On("Endpoints").Return([]*endpoint.Endpoint{
+			endpoint.NewEndpoint("www.example.org", endpoint.RecordTypeA, "1.2.3.4"),
+		}, nil)
+		ctrl := &Controller{
+			Source:             source,
+			Registry:           r,
+			Policy:             &plan.SyncPolicy{},
+			ManagedRecordTypes: []string{endpoint.RecordTypeA},
+			StreamRecords:      streamRecords,
+		}
+
+		count, _ := propagationSamples(t)
+		ctrl.SourceChanged(time.Now().Add(-time.Minute))
+		require.NoError(t, ctrl.RunOnce(context.Background()))
+		newCount, newSum := propagationSamples(t)
+		assert.Equal(t, count+1, newCount)
+		assert.GreaterOrEqual(t, newSum, 60.0)
+		assert.True(t, ctrl.changedAt.IsZero())
+
+		// the records are up to date, no propagation is observed
+		ctrl.SourceChanged(time.Now())
+		require.NoError(t, ctrl.RunOnce(context.Background()))
+		newCount, _ = propagationSamples(t)
+		assert.Equal(t, count+1, newCount)
+		assert.True(t, ctrl.changedAt.IsZero())
+	}
+}
+
+func TestControllerKeepsChangeOnFailure(t *testing.T) {
+	r, err := registry.NewNoopRegistry(inmemory.NewInMemoryProvider())
+	require.NoError(t, err)
+	source := new(testutils.MockSource)
+	source.On("Endpoints").Return([]*endpoint.Endpoint(nil), errors.New("source failure"))
+	ctrl := &Controller{
+		Source:   source,
+		Registry: r,
+		Policy:   &plan.SyncPolicy{},
+	}
+
+	changedAt := time.Now().Add(-time.Minute)
+	ctrl.SourceChanged(changedAt)
+	require.Error(t, ctrl.RunOnce(context.Background()))
+	assert.Equal(t, changedAt, ctrl.changedAt)
+}
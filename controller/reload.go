@@ -0,0 +1,278 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"reflect"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/pkg/apis/externaldns"
+	"sigs.k8s.io/external-dns/pkg/apis/externaldns/validation"
+	"sigs.k8s.io/external-dns/pkg/secrets"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider"
+	"sigs.k8s.io/external-dns/source"
+)
+
+// immutableFields are the configuration fields that cannot change on reload,
+// in addition to the fields tagged as secure.
+var immutableFields = []string{"Provider", "Registry", "TXTOwnerID", "TXTOwnerIDTemplate", "TXTPrefix", "TXTSuffix", "TXTNameTemplate", "TXTNameTemplateMigration", "TXTNewFormatOnly", "TXTCacheInterval", "TXTEncryptEnabled", "RegistryMigrationCutover", "AWSDynamoDBTable", "AWSDynamoDBCreateTable", "AWSDynamoDBTableTags", "DryRun", "ProviderProxyURL", "ProviderCABundle", "PauseConfigMap", "PlanConfigMap", "ZoneLockNamespace", "ZoneLockLeaseDuration", "AuditLog", "CanaryZone", "CanaryNameserver", "CanaryTimeout", "TXTEncryptKMS", "TXTEncryptKMSKey", "MissingZoneCacheTTL", "DampingThreshold", "DampingWindow", "DampingCooldown", "DampingAllowClear"}
+
+// reloader rebuilds the components that depend on the configuration without
+// restarting the process, when the configuration file changes or when
+// provider credentials are rotated.
+type reloader struct {
+	mu sync.Mutex
+	// args are the command line arguments the configuration is parsed from
+	args []string
+	// initial is the configuration as parsed at startup, before secrets were resolved
+	initial externaldns.Config
+	// cfg is the configuration in use, a copy owned by the reloader and replaced under mu
+	cfg      *externaldns.Config
+	secrets  *secrets.Watcher
+	ctrl     *Controller
+	provider *provider.ReloadableProvider
+	// cancelSource stops the informers of the source in use
+	cancelSource context.CancelFunc
+	// lastConfig is the content of the configuration file in use
+	lastConfig []byte
+}
+
+func newReloader(args []string, initial externaldns.Config, cfg *externaldns.Config, watcher *secrets.Watcher, cancelSource context.CancelFunc) *reloader {
+	current := *cfg
+	r := &reloader{
+		args:         args,
+		initial:      initial,
+		cfg:          &current,
+		secrets:      watcher,
+		cancelSource: cancelSource,
+	}
+	if cfg.ConfigFile != "" {
+		r.lastConfig, _ = os.ReadFile(cfg.ConfigFile)
+	}
+	return r
+}
+
+func (r *reloader) watchesSecrets() bool {
+	return r.secrets.Len() > 0 && r.cfg.SecretRefreshInterval > 0
+}
+
+func (r *reloader) watchesConfig() bool {
+	return r.cfg.ConfigFile != "" && r.cfg.ConfigReloadInterval > 0
+}
+
+// wrapProvider makes the provider replaceable when reloading is enabled.
+func (r *reloader) wrapProvider(p provider.Provider) provider.Provider {
+	if !r.watchesSecrets() && !r.watchesConfig() {
+		return p
+	}
+	r.provider = provider.NewReloadableProvider(p)
+	return r.provider
+}
+
+// start watches the configuration file and the provider credentials until ctx is canceled.
+func (r *reloader) start(ctx context.Context, ctrl *Controller) {
+	r.ctrl = ctrl
+	if r.watchesSecrets() {
+		go r.secrets.Run(ctx, func(changed map[string]string) {
+			if err := r.rotateSecrets(ctx, changed); err != nil {
+				log.Errorf("Failed to rebuild provider with rotated credentials: %v", err)
+			}
+		})
+	}
+	if r.watchesConfig() {
+		go r.watchConfig(ctx)
+	}
+}
+
+// rotateSecrets rebuilds the provider with the rotated credentials.
+func (r *reloader) rotateSecrets(ctx context.Context, changed map[string]string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	// the configuration in use may be read concurrently, the rotated one replaces it
+	cfg := *r.cfg
+	applySecrets(&cfg, changed)
+	p, err := buildProvider(ctx, &cfg, createDomainFilter(&cfg), r.ctrl.FilterDecisions, r.ctrl.MissingZones)
+	if err != nil {
+		return err
+	}
+	r.provider.Swap(p)
+	r.cfg = &cfg
+	log.Info("Rebuilt provider with rotated credentials")
+	return nil
+}
+
+func (r *reloader) watchConfig(ctx context.Context) {
+	r.mu.Lock()
+	configFile, interval := r.cfg.ConfigFile, r.cfg.ConfigReloadInterval
+	r.mu.Unlock()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			content, err := os.ReadFile(configFile)
+			if err != nil {
+				log.Warnf("Failed to read config file: %v", err)
+				continue
+			}
+			if bytes.Equal(content, r.lastConfig) {
+				continue
+			}
+			r.lastConfig = content
+			log.Infof("Config file %s changed, reloading configuration", configFile)
+			if err := r.reloadConfig(ctx); err != nil {
+				log.Errorf("Failed to reload configuration, keeping the previous one: %v", err)
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// reloadConfig parses the configuration again and rebuilds the sources, the
+// domain filter, the provider and the controller settings from it.
+func (r *reloader) reloadConfig(ctx context.Context) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	cfg := externaldns.NewConfig()
+	if err := cfg.ParseFlags(r.args); err != nil {
+		return err
+	}
+	if err := validation.ValidateConfig(cfg); err != nil {
+		return err
+	}
+	if err := checkImmutable(&r.initial, cfg); err != nil {
+		return err
+	}
+	policy, ok := plan.Policies[cfg.Policy]
+	if !ok {
+		return fmt.Errorf("unknown policy: %s", cfg.Policy)
+	}
+	// credentials are immutable, keep the resolved ones
+	copySecureFields(r.cfg, cfg)
+
+	windows, location, err := buildChangeWindows(cfg)
+	if err != nil {
+		return err
+	}
+
+	sourceCtx, cancelSource := context.WithCancel(ctx)
+	src, err := buildSource(sourceCtx, cfg, r.ctrl.FilterDecisions)
+	if err != nil {
+		cancelSource()
+		return err
+	}
+	domainFilter := createDomainFilter(cfg)
+	p, err := buildProvider(ctx, cfg, domainFilter, r.ctrl.FilterDecisions, r.ctrl.MissingZones)
+	if err != nil {
+		cancelSource()
+		return err
+	}
+
+	r.provider.Swap(p)
+	r.ctrl.reconfigure(src, policy, domainFilter, cfg, denyWildcardRecords(cfg, p), provider.GetCapabilities(p))
+	r.ctrl.reconfigureChangeWindows(windows, location, cfg.ChangeWindowCreates)
+	if cfg.UpdateEvents {
+		src.AddEventHandler(sourceCtx, func() { r.ctrl.SourceChanged(time.Now()) })
+	}
+	r.cancelSource()
+	r.cancelSource = cancelSource
+	r.cfg = cfg
+
+	log.Infof("Reloaded configuration: %s", cfg)
+	r.ctrl.ScheduleRunOnce(time.Now())
+	return nil
+}
+
+// reconfigure replaces the parts of the controller that can change when the
+// configuration is reloaded. It waits for a reconciliation in progress to finish.
+func (c *Controller) reconfigure(src source.Source, policy plan.Policy, domainFilter endpoint.DomainFilterInterface, cfg *externaldns.Config, denyWildcards bool, capabilities provider.Capabilities) {
+	c.reconcileMutex.Lock()
+	defer c.reconcileMutex.Unlock()
+	c.Source = src
+	c.Policy = policy
+	c.DomainFilter = domainFilter
+	c.ManagedRecordTypes = cfg.ManagedDNSRecordTypes
+	c.ExcludeRecordTypes = cfg.ExcludeDNSRecordTypes
+	c.DenyWildcardRecords = denyWildcards
+	c.StreamRecords = cfg.StreamRecords
+	c.RepeatedChangesBackoff = cfg.RepeatedChangesBackoff
+	c.MaxDeletionsPerSync = cfg.MaxDeletionsPerSync
+	c.MaxChangePercentage = cfg.MaxChangePercentage
+	c.MaxRecordsPerZone = cfg.MaxRecordsPerZone
+	c.Capabilities = capabilities
+	c.JanitorInterval = cfg.TXTJanitorInterval
+	c.JanitorPolicy = cfg.TXTJanitorPolicy
+	c.JanitorOwnerIDs = cfg.TXTJanitorOwnerIDs
+	c.WriteBudget = cfg.ProviderWriteBudget
+	c.SyncTimeout = cfg.SyncTimeout
+	c.SourceObjectMetrics = cfg.MetricsSourceObjects
+	c.BackupDir = cfg.BackupDir
+	c.AdoptExistingRecords = cfg.AdoptExistingRecords
+	c.IgnoreTTLDrift = cfg.IgnoreTTLDrift
+	c.ExternalOwnerMarkers = cfg.ExternalOwnerMarkers
+
+	c.runAtMutex.Lock()
+	defer c.runAtMutex.Unlock()
+	c.Interval = cfg.Interval
+	c.MinEventSyncInterval = cfg.MinEventSyncInterval
+}
+
+// checkImmutable returns an error if a field that requires a restart differs between both configurations.
+func checkImmutable(previous, next *externaldns.Config) error {
+	pv, nv := reflect.ValueOf(previous).Elem(), reflect.ValueOf(next).Elem()
+	t := pv.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.Tag.Get("secure") != "yes" && !isImmutableField(f.Name) {
+			continue
+		}
+		if !reflect.DeepEqual(pv.Field(i).Interface(), nv.Field(i).Interface()) {
+			return fmt.Errorf("%s cannot be changed without a restart", f.Name)
+		}
+	}
+	return nil
+}
+
+func isImmutableField(name string) bool {
+	for _, f := range immutableFields {
+		if f == name {
+			return true
+		}
+	}
+	return false
+}
+
+func copySecureFields(from, to *externaldns.Config) {
+	fv, tv := reflect.ValueOf(from).Elem(), reflect.ValueOf(to).Elem()
+	t := fv.Type()
+	for i := 0; i < t.NumField(); i++ {
+		if t.Field(i).Tag.Get("secure") == "yes" {
+			tv.Field(i).Set(fv.Field(i))
+		}
+	}
+}
@@ -0,0 +1,176 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/pkg/apis/externaldns"
+	"sigs.k8s.io/external-dns/pkg/secrets"
+	"sigs.k8s.io/external-dns/provider"
+)
+
+const reloadTestConfig = `
+source: [fake]
+provider: inmemory
+registry: noop
+interval: 1m
+domain-filter: [example.org]
+`
+
+func newTestReloader(t *testing.T, content string) (*reloader, *Controller, string) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+	args := []string{"--config=" + path}
+	cfg := externaldns.NewConfig()
+	require.NoError(t, cfg.ParseFlags(args))
+
+	sourceCtx, cancelSource := context.WithCancel(t.Context())
+	src, err := buildSource(sourceCtx, cfg, nil)
+	require.NoError(t, err)
+	domainFilter := createDomainFilter(cfg)
+	r := newReloader(args, *cfg, cfg, secrets.NewWatcher(secrets.NewResolver(), 0), cancelSource)
+	p, err := buildProvider(t.Context(), cfg, domainFilter, nil, nil)
+	require.NoError(t, err)
+	ctrl, err := buildController(cfg, src, r.wrapProvider(p), domainFilter, nil, nil, nil, nil)
+	require.NoError(t, err)
+	r.ctrl = ctrl
+	return r, ctrl, path
+}
+
+func TestReloaderWrapProvider(t *testing.T) {
+	t.Setenv("EXTERNAL_DNS_TEST_SECRET", "env-secret")
+	p := &MockProvider{}
+
+	cfg := &externaldns.Config{PDNSAPIKey: "secret+env:EXTERNAL_DNS_TEST_SECRET", SecretRefreshInterval: time.Minute}
+	watcher := secrets.NewWatcher(buildSecretResolver(cfg), time.Minute)
+	assert.Same(t, p, newReloader(nil, *cfg, cfg, watcher, nil).wrapProvider(p), "no secret references")
+
+	require.NoError(t, resolveSecrets(t.Context(), cfg, watcher))
+	assert.IsType(t, &provider.ReloadableProvider{}, newReloader(nil, *cfg, cfg, watcher, nil).wrapProvider(p))
+
+	cfg.SecretRefreshInterval = 0
+	assert.Same(t, p, newReloader(nil, *cfg, cfg, watcher, nil).wrapProvider(p), "refresh disabled")
+
+	cfg.ConfigFile, cfg.ConfigReloadInterval = "config.yaml", time.Second
+	assert.IsType(t, &provider.ReloadableProvider{}, newReloader(nil, *cfg, cfg, watcher, nil).wrapProvider(p), "config reload enabled")
+}
+
+func TestReloaderRotateSecrets(t *testing.T) {
+	r, _, _ := newTestReloader(t, reloadTestConfig)
+	shared := r.cfg
+	r.cfg.PDNSAPIKey = "old-key"
+
+	// the rotated credentials replace a copy of the configuration, which may be read concurrently
+	require.NoError(t, r.rotateSecrets(t.Context(), map[string]string{"PDNSAPIKey": "new-key"}))
+	assert.Equal(t, "new-key", r.cfg.PDNSAPIKey)
+	assert.Equal(t, "old-key", shared.PDNSAPIKey)
+}
+
+func TestReloadConfig(t *testing.T) {
+	r, ctrl, path := newTestReloader(t, reloadTestConfig)
+	assert.Equal(t, time.Minute, ctrl.Interval)
+
+	require.NoError(t, os.WriteFile(path, []byte(`
+source: [fake]
+provider: inmemory
+registry: noop
+interval: 2m
+min-event-sync-interval: 10s
+domain-filter: [example.com]
+managed-record-types: [A]
+max-records-per-zone: 100
+`), 0o600))
+	require.NoError(t, r.reloadConfig(t.Context()))
+
+	assert.Equal(t, 2*time.Minute, ctrl.Interval)
+	assert.Equal(t, 10*time.Second, ctrl.MinEventSyncInterval)
+	assert.Equal(t, []string{"A"}, ctrl.ManagedRecordTypes)
+	assert.Equal(t, 100, ctrl.MaxRecordsPerZone)
+	assert.True(t, ctrl.DomainFilter.Match("www.example.com"))
+	assert.False(t, ctrl.DomainFilter.Match("www.example.org"))
+	assert.Equal(t, 2*time.Minute, r.cfg.Interval)
+	assert.Equal(t, []string{"example.com"}, r.cfg.DomainFilter)
+	require.NoError(t, ctrl.RunOnce(t.Context()))
+}
+
+func TestReloadConfigRejectsImmutableChanges(t *testing.T) {
+	for _, tt := range []struct {
+		title   string
+		content string
+		err     string
+	}{
+		{
+			title:   "provider",
+			content: "source: [fake]\nprovider: aws\nregistry: noop\n",
+			err:     "Provider cannot be changed without a restart",
+		},
+		{
+			title:   "registry",
+			content: "source: [fake]\nprovider: inmemory\nregistry: txt\n",
+			err:     "Registry cannot be changed without a restart",
+		},
+		{
+			title:   "credentials",
+			content: "source: [fake]\nprovider: inmemory\nregistry: noop\npdns-api-key: secret\n",
+			err:     "PDNSAPIKey cannot be changed without a restart",
+		},
+		{
+			title:   "txt prefix",
+			content: "source: [fake]\nprovider: inmemory\nregistry: noop\ntxt-prefix: owner.\n",
+			err:     "TXTPrefix cannot be changed without a restart",
+		},
+		{
+			title:   "invalid config",
+			content: "source: [fake]\nprovider: inmemory\nregistry: noop\nunknown-flag: true\n",
+			err:     "unknown long flag",
+		},
+	} {
+		t.Run(tt.title, func(t *testing.T) {
+			r, ctrl, path := newTestReloader(t, reloadTestConfig)
+			src := ctrl.Source
+
+			require.NoError(t, os.WriteFile(path, []byte(tt.content), 0o600))
+			assert.ErrorContains(t, r.reloadConfig(t.Context()), tt.err)
+			assert.Same(t, src, ctrl.Source, "source is kept")
+			assert.Equal(t, "inmemory", r.cfg.Provider)
+			assert.Equal(t, endpoint.NewDomainFilter([]string{"example.org"}), ctrl.DomainFilter)
+		})
+	}
+}
+
+func TestReloaderWatchesConfigFile(t *testing.T) {
+	r, ctrl, path := newTestReloader(t, reloadTestConfig+"config-reload-interval: 10ms\n")
+	r.start(t.Context(), ctrl)
+
+	require.NoError(t, os.WriteFile(path, []byte(reloadTestConfig+"config-reload-interval: 10ms\nmin-event-sync-interval: 3s\n"), 0o600))
+	assert.Eventually(t, func() bool {
+		ctrl.runAtMutex.Lock()
+		defer ctrl.runAtMutex.Unlock()
+		return ctrl.MinEventSyncInterval == 3*time.Second
+	}, time.Second, 10*time.Millisecond)
+}
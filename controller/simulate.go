@@ -0,0 +1,103 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/pkg/apis/externaldns"
+	"sigs.k8s.io/external-dns/plan"
+)
+
+// simulate runs the objects of the manifest of the simulate command through the configured
+// sources and filters, and prints the records they would create, or why they would be left alone.
+func simulate(ctx context.Context, cfg *externaldns.Config, w io.Writer) error {
+	manifest := io.Reader(os.Stdin)
+	if cfg.SimulateManifest != "-" {
+		f, err := os.Open(cfg.SimulateManifest)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		manifest = f
+	}
+
+	clientGenerator, err := newManifestClientGenerator(manifest)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	src, err := buildSourceWithClients(ctx, cfg, clientGenerator, nil)
+	if err != nil {
+		return err
+	}
+	endpoints, err := src.Endpoints(ctx)
+	if err != nil {
+		return err
+	}
+	endpoints = endpoint.NormalizeEndpoints(endpoints)
+	sort.SliceStable(endpoints, func(i, j int) bool {
+		if endpoints[i].DNSName != endpoints[j].DNSName {
+			return endpoints[i].DNSName < endpoints[j].DNSName
+		}
+		return endpoints[i].RecordType < endpoints[j].RecordType
+	})
+
+	domainFilter := createDomainFilter(cfg)
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "NAME\tTYPE\tTTL\tTARGETS\tRESOURCE\tSTATUS")
+	for _, ep := range endpoints {
+		ttl := "default"
+		if ep.RecordTTL.IsConfigured() {
+			ttl = fmt.Sprint(int64(ep.RecordTTL))
+		}
+		resource := ep.Labels[endpoint.ResourceLabelKey]
+		if resource == "" {
+			resource = "-"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n", ep.DNSName, ep.RecordType, ttl, strings.Join(ep.Targets, ","), resource, simulatedStatus(cfg, domainFilter, ep))
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	if len(endpoints) == 0 {
+		fmt.Fprintln(w, "No records would be created: check the annotations and the source flags, with --log-level=debug for details")
+	}
+	return nil
+}
+
+// simulatedStatus returns whether the record would be managed or why it would be left alone.
+func simulatedStatus(cfg *externaldns.Config, domainFilter endpoint.DomainFilterInterface, ep *endpoint.Endpoint) string {
+	switch {
+	case !domainFilter.Match(ep.DNSName):
+		return "skipped: not matching the domain filter"
+	case !plan.IsManagedRecord(ep.RecordType, cfg.ManagedDNSRecordTypes, cfg.ExcludeDNSRecordTypes):
+		return "skipped: record type not managed"
+	case cfg.WildcardPolicy == "deny" && strings.HasPrefix(ep.DNSName, "*."):
+		return "skipped: wildcard records denied"
+	default:
+		return "managed"
+	}
+}
@@ -0,0 +1,30 @@
+//go:build simulate
+
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"io"
+
+	"sigs.k8s.io/external-dns/source"
+)
+
+// newManifestClientGenerator returns the clients serving the objects of the manifest to the sources.
+func newManifestClientGenerator(manifest io.Reader) (source.ClientGenerator, error) {
+	return source.NewManifestClientGenerator(manifest)
+}
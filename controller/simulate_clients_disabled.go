@@ -0,0 +1,34 @@
+//go:build !simulate
+
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"errors"
+	"io"
+
+	"sigs.k8s.io/external-dns/source"
+)
+
+// errSimulateNotBuilt is returned by the simulate command when the fake clients serving the manifest
+// are not compiled in, to keep them out of the released binary.
+var errSimulateNotBuilt = errors.New("the simulate command is not supported by this binary, build external-dns with -tags simulate")
+
+func newManifestClientGenerator(_ io.Reader) (source.ClientGenerator, error) {
+	return nil, errSimulateNotBuilt
+}
@@ -0,0 +1,41 @@
+//go:build !simulate
+
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/pkg/apis/externaldns"
+)
+
+func TestSimulateNotBuilt(t *testing.T) {
+	manifest := filepath.Join(t.TempDir(), "manifest.yaml")
+	require.NoError(t, os.WriteFile(manifest, []byte("kind: Ingress\napiVersion: networking.k8s.io/v1\n"), 0o600))
+
+	cfg := externaldns.NewConfig()
+	cfg.SimulateManifest = manifest
+	assert.ErrorIs(t, simulate(context.Background(), cfg, &bytes.Buffer{}), errSimulateNotBuilt)
+}
@@ -0,0 +1,102 @@
+//go:build simulate
+
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/pkg/apis/externaldns"
+)
+
+const simulateManifest = `
+apiVersion: networking.k8s.io/v1
+kind: Ingress
+metadata:
+  name: web
+  namespace: default
+  annotations:
+    external-dns.alpha.kubernetes.io/ttl: "60"
+spec:
+  rules:
+  - host: www.example.org
+  - host: www.example.com
+status:
+  loadBalancer:
+    ingress:
+    - ip: 1.2.3.4
+---
+apiVersion: v1
+kind: List
+items:
+- apiVersion: v1
+  kind: Service
+  metadata:
+    name: api
+    namespace: default
+    annotations:
+      external-dns.alpha.kubernetes.io/hostname: api.example.org
+  spec:
+    type: LoadBalancer
+  status:
+    loadBalancer:
+      ingress:
+      - hostname: lb.example.net
+`
+
+func TestSimulate(t *testing.T) {
+	manifest := filepath.Join(t.TempDir(), "manifest.yaml")
+	require.NoError(t, os.WriteFile(manifest, []byte(simulateManifest), 0o600))
+
+	cfg := externaldns.NewConfig()
+	cfg.Sources = []string{"ingress", "service"}
+	cfg.DomainFilter = []string{"example.org"}
+	cfg.ManagedDNSRecordTypes = []string{"A", "CNAME"}
+	cfg.SimulateManifest = manifest
+
+	var out bytes.Buffer
+	require.NoError(t, simulate(context.Background(), cfg, &out))
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	require.Len(t, lines, 4, out.String())
+	assert.Equal(t, []string{"NAME", "TYPE", "TTL", "TARGETS", "RESOURCE", "STATUS"}, strings.Fields(lines[0]))
+	assert.Equal(t, []string{"api.example.org", "CNAME", "default", "lb.example.net", "service/default/api", "managed"}, strings.Fields(lines[1]))
+	assert.Equal(t, []string{"www.example.com", "A", "60", "1.2.3.4", "ingress/default/web", "skipped:", "not", "matching", "the", "domain", "filter"}, strings.Fields(lines[2]))
+	assert.Equal(t, []string{"www.example.org", "A", "60", "1.2.3.4", "ingress/default/web", "managed"}, strings.Fields(lines[3]))
+}
+
+func TestSimulateErrors(t *testing.T) {
+	cfg := externaldns.NewConfig()
+	cfg.Sources = []string{"service"}
+
+	cfg.SimulateManifest = filepath.Join(t.TempDir(), "missing.yaml")
+	assert.Error(t, simulate(context.Background(), cfg, &bytes.Buffer{}))
+
+	invalid := filepath.Join(t.TempDir(), "invalid.yaml")
+	require.NoError(t, os.WriteFile(invalid, []byte("metadata:\n  name: no-kind\n"), 0o600))
+	cfg.SimulateManifest = invalid
+	assert.ErrorContains(t, simulate(context.Background(), cfg, &bytes.Buffer{}), "no kind")
+}
@@ -0,0 +1,211 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/pkg/logging"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider"
+	"sigs.k8s.io/external-dns/registry"
+)
+
+// syncZones reconciles the zones of the registry one at a time: the desired endpoints are
+// assigned to their zone, then the plan of each zone is calculated and applied as soon as
+// its records are read, so that only the records of one zone are held in memory.
+// When paused, the changes of the zones are only reported. It returns whether changes were applied.
+func (c *Controller) syncZones(ctx context.Context, paused bool) (bool, error) {
+	c.inFlight.set("listing the zones")
+	zoneNames, err := registry.ZoneNames(ctx, c.Registry)
+	if err != nil {
+		registryErrorsTotal.Counter.Inc()
+		deprecatedRegistryErrors.Counter.Inc()
+		return false, err
+	}
+	zones := provider.ZoneIDName{}
+	for _, name := range zoneNames {
+		zones.Add(name, name)
+	}
+
+	c.inFlight.set("reading the endpoints of the sources")
+	sourceEndpoints, err := c.Source.Endpoints(ctx)
+	if err != nil {
+		sourceErrorsTotal.Counter.Inc()
+		deprecatedSourceErrors.Counter.Inc()
+		return false, err
+	}
+
+	sourceEndpointsTotal.Gauge.Set(float64(len(sourceEndpoints)))
+	c.checkProperties(sourceEndpoints)
+
+	sourceMetrics := newMetricsRecorder()
+	countAddressRecords(sourceMetrics, sourceEndpoints, sourceRecords)
+
+	// the record types follow the targets before the provider adjusts them, e.g. into aliases
+	endpoints, err := c.Registry.AdjustEndpoints(endpoint.WithSuitableRecordTypes(sourceEndpoints))
+	if err != nil {
+		return false, fmt.Errorf("adjusting endpoints: %w", err)
+	}
+	if c.DenyWildcardRecords {
+		endpoints = withoutWildcardRecords(endpoints)
+	}
+	c.DNSControl.Update(zoneNames, endpoints)
+	// the records are planned normalized, and applied as the provider and the sources returned them
+	endpoints, desiredOriginals := endpoint.NormalizedCopies(endpoints)
+
+	// the registries that can't stream their records return them all at once, with an empty zone name
+	desired := map[string][]*endpoint.Endpoint{}
+	var unzoned []*endpoint.Endpoint
+	for _, ep := range endpoints {
+		zone := ""
+		if len(zones) > 0 {
+			if _, zone = zones.FindZone(ep.DNSName); zone == "" {
+				if c.MissingZones.Report(ep.DNSName) {
+					logging.ForRecord(ep).Debugf("Skipping endpoint %s %s: no matching zone", ep.DNSName, ep.RecordType)
+				}
+				unzoned = append(unzoned, ep)
+				continue
+			}
+		}
+		desired[zone] = append(desired[zone], ep)
+	}
+	if c.SourceObjectMetrics {
+		sourceObjectRecords.Gauge.Reset()
+		recordSourceObjects(sourceObjectRecords, unzoned, nil)
+	}
+
+	registryFilter := c.Registry.GetDomainFilter()
+	regMetrics := newMetricsRecorder()
+	vaMetrics := newMetricsRecorder()
+	regEndpoints := 0
+	hasChanges := false
+	// the zones whose changes are skipped as repeated are only counted by the repeated changes metric
+	skippedChanges := false
+	var thresholdErr error
+	var synced []*endpoint.Endpoint
+	// zoneSynced keeps the records owned once the changes of a zone are applied for the backup,
+	// and reports the sync of the zone
+	zoneSynced := func(zone string, records []*endpoint.Endpoint, changes *plan.Changes) {
+		owned := syncedRecords(records, c.Registry.OwnerID(), c.OwnerIDTemplate, changes)
+		if c.BackupDir != "" {
+			synced = append(synced, owned...)
+		}
+		if zone != "" {
+			recordZoneSyncs([]string{zone}, owned, time.Now())
+		}
+	}
+
+	c.inFlight.set("reading the records of the registry")
+	err = registry.StreamRecords(ctx, c.Registry, func(zone string, records []*endpoint.Endpoint) error {
+		defer c.inFlight.set(fmt.Sprintf("reading the records of the registry after zone %q", zone))
+		regEndpoints += len(records)
+		countAddressRecords(regMetrics, records, registryRecords)
+		countMatchingAddressRecords(vaMetrics, desired[zone], records, verifiedRecords)
+		if c.SourceObjectMetrics {
+			recordSourceObjects(sourceObjectRecords, desired[zone], records)
+		}
+
+		if c.DenyWildcardRecords {
+			records = withoutWildcardRecords(records)
+		}
+		normalizedRecords, currentOriginals := endpoint.NormalizedCopies(records)
+
+		plan := &plan.Plan{
+			Policies:             []plan.Policy{c.Policy},
+			Current:              normalizedRecords,
+			Desired:              desired[zone],
+			DomainFilter:         endpoint.MatchAllDomainFilters{c.DomainFilter, registryFilter},
+			ManagedRecords:       c.ManagedRecordTypes,
+			ExcludeRecords:       c.ExcludeRecordTypes,
+			OwnerID:              c.Registry.OwnerID(),
+			OwnerIDTemplate:      c.OwnerIDTemplate,
+			AdoptExisting:        c.AdoptExistingRecords,
+			IgnoreTTL:            c.IgnoreTTLDrift,
+			ExternalOwnerMarkers: c.ExternalOwnerMarkers,
+			FilterDecisions:      c.FilterDecisions,
+		}
+
+		plan = plan.Calculate()
+		c.rejectUnsupportedChanges(plan.Changes)
+		c.skipMissingZoneCreates(plan.Changes)
+		c.limitZoneGrowth(zones, plan.Changes, records)
+		c.deferNonUrgentUpdates(plan.Changes)
+		c.Damper.Hold(plan.Changes)
+		c.deferOutsideChangeWindows(plan.Changes)
+		c.logPlan(ctx, zone, plan.Changes)
+
+		if !plan.Changes.HasChanges() {
+			delete(c.appliedChanges, zone)
+			if !paused {
+				zoneSynced(zone, records, nil)
+			}
+			return nil
+		}
+		if paused {
+			logPausedChanges(zone, plan.Changes)
+			return nil
+		}
+		if c.skipRepeatedChanges(zone, plan.Changes) {
+			skippedChanges = true
+			zoneSynced(zone, records, nil)
+			return nil
+		}
+		// the zones whose plan changes too many records are left alone, the others are still reconciled
+		if err := c.checkChangeThresholds(plan.Changes, records); err != nil {
+			logging.ForZone(zone).Errorf("Not applying the changes of zone %q: %v", zone, err)
+			thresholdErr = errors.Join(thresholdErr, err)
+			return nil
+		}
+		hasChanges = true
+		logging.ForZone(zone).Debugf("Applying the changes of zone %q", zone)
+		changes := withOriginalRecords(plan.Changes, currentOriginals, desiredOriginals)
+		if err := c.applyChanges(ctx, zone, changes); err != nil {
+			return err
+		}
+		c.recordAppliedChanges(zone, plan.Changes)
+		c.Damper.Record(plan.Changes)
+		zoneSynced(zone, records, changes)
+		return nil
+	})
+	if err != nil {
+		registryErrorsTotal.Counter.Inc()
+		deprecatedRegistryErrors.Counter.Inc()
+		return false, err
+	}
+
+	registryEndpointsTotal.Gauge.Set(float64(regEndpoints))
+	if thresholdErr != nil {
+		return false, thresholdErr
+	}
+	// a snapshot is only taken when all the zones are reconciled, so that it's complete
+	if !paused {
+		c.backup(synced)
+	}
+
+	if !hasChanges && !skippedChanges {
+		controllerNoChangesTotal.Counter.Inc()
+		log.Info("All records are already up to date")
+	}
+	return hasChanges, nil
+}
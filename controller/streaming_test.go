@@ -0,0 +1,152 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/internal/testutils"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider"
+	"sigs.k8s.io/external-dns/provider/inmemory"
+	"sigs.k8s.io/external-dns/registry"
+)
+
+func TestControllerStreamRecords(t *testing.T) {
+	ctx := context.Background()
+	p := inmemory.NewInMemoryProvider(inmemory.InMemoryInitZones([]string{"example.org", "sub.example.org"}))
+	var applied []*plan.Changes
+	p.OnApplyChanges = func(_ context.Context, changes *plan.Changes) {
+		applied = append(applied, changes)
+	}
+	r, err := registry.NewTXTRegistry(p, "", "", "owner", time.Hour, "", []string{endpoint.RecordTypeA}, []string{}, false, nil, true)
+	require.NoError(t, err)
+
+	desired := []*endpoint.Endpoint{
+		endpoint.NewEndpoint("www.example.org", endpoint.RecordTypeA, "1.2.3.4"),
+		endpoint.NewEndpoint("www.sub.example.org", endpoint.RecordTypeA, "1.2.3.4"),
+		endpoint.NewEndpoint("www.example.com", endpoint.RecordTypeA, "1.2.3.4"),
+	}
+	source := new(testutils.MockSource)
+	source.On("Endpoints").Return(desired, nil).Once()
+	source.On("Endpoints").Return(desired[:1], nil)
+
+	ctrl := &Controller{
+		Source:             source,
+		Registry:           r,
+		Policy:             &plan.SyncPolicy{},
+		ManagedRecordTypes: []string{endpoint.RecordTypeA},
+		StreamRecords:      true,
+	}
+
+	// the changes are applied zone by zone, and the endpoints outside of the zones are skipped
+	require.NoError(t, ctrl.RunOnce(ctx))
+	require.Len(t, applied, 2)
+	assert.Equal(t, []string{"a-www.example.org", "www.example.org"}, changeNames(applied[0].Create))
+	assert.Equal(t, []string{"a-www.sub.example.org", "www.sub.example.org"}, changeNames(applied[1].Create))
+
+	// the records of the nested zone are only deleted from it
+	applied = nil
+	require.NoError(t, ctrl.RunOnce(ctx))
+	require.Len(t, applied, 1)
+	assert.Equal(t, []string{"a-www.sub.example.org", "www.sub.example.org"}, changeNames(applied[0].Delete))
+
+	records, err := p.Records(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a-www.example.org", "www.example.org"}, changeNames(records))
+}
+
+func TestControllerStreamRecordsFallback(t *testing.T) {
+	source := new(testutils.MockSource)
+	source.On("Endpoints").Return([]*endpoint.Endpoint{
+		endpoint.NewEndpoint("www.example.org", endpoint.RecordTypeA, "1.2.3.4"),
+	}, nil)
+
+	// the registries that cannot stream their records reconcile all of them at once
+	provider := &filteredMockProvider{}
+	r, err := registry.NewNoopRegistry(provider)
+	require.NoError(t, err)
+
+	ctrl := &Controller{
+		Source:             source,
+		Registry:           r,
+		Policy:             &plan.SyncPolicy{},
+		ManagedRecordTypes: []string{endpoint.RecordTypeA},
+		StreamRecords:      true,
+	}
+
+	require.NoError(t, ctrl.RunOnce(context.Background()))
+	require.Len(t, provider.ApplyChangesCalls, 1)
+	assert.Equal(t, []string{"www.example.org"}, changeNames(provider.ApplyChangesCalls[0].Create))
+}
+
+func TestControllerStreamRecordsChangeThresholds(t *testing.T) {
+	ctx := context.Background()
+	p := inmemory.NewInMemoryProvider(inmemory.InMemoryInitZones([]string{"example.org", "sub.example.org"}))
+	var applied []*plan.Changes
+	p.OnApplyChanges = func(_ context.Context, changes *plan.Changes) {
+		applied = append(applied, changes)
+	}
+	r, err := registry.NewTXTRegistry(p, "", "", "owner", time.Hour, "", []string{endpoint.RecordTypeA}, []string{}, false, nil, true)
+	require.NoError(t, err)
+
+	source := new(testutils.MockSource)
+	source.On("Endpoints").Return([]*endpoint.Endpoint{
+		endpoint.NewEndpoint("www.example.org", endpoint.RecordTypeA, "1.2.3.4"),
+		endpoint.NewEndpoint("www.sub.example.org", endpoint.RecordTypeA, "1.2.3.4"),
+	}, nil).Once()
+	source.On("Endpoints").Return([]*endpoint.Endpoint{
+		endpoint.NewEndpoint("www.example.org", endpoint.RecordTypeA, "1.2.3.4"),
+		endpoint.NewEndpoint("new.example.org", endpoint.RecordTypeA, "1.2.3.4"),
+	}, nil)
+
+	ctrl := &Controller{
+		Source:              source,
+		Registry:            r,
+		Policy:              &plan.SyncPolicy{},
+		ManagedRecordTypes:  []string{endpoint.RecordTypeA},
+		StreamRecords:       true,
+		MaxChangePercentage: 50,
+	}
+	require.NoError(t, ctrl.RunOnce(ctx))
+
+	// deleting all the records of the nested zone is refused, the other zone is still reconciled
+	applied = nil
+	require.ErrorIs(t, ctrl.RunOnce(ctx), provider.SoftError)
+	require.Len(t, applied, 1)
+	assert.Equal(t, []string{"a-new.example.org", "new.example.org"}, changeNames(applied[0].Create))
+
+	records, err := p.Records(ctx)
+	require.NoError(t, err)
+	assert.Contains(t, changeNames(records), "www.sub.example.org")
+}
+
+func changeNames(endpoints []*endpoint.Endpoint) []string {
+	names := make([]string, 0, len(endpoints))
+	for _, ep := range endpoints {
+		names = append(names, ep.DNSName)
+	}
+	sort.Strings(names)
+	return names
+}
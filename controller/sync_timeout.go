@@ -0,0 +1,85 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider"
+)
+
+// maxInFlightChanges is the number of changes described when a synchronization times out while applying them.
+const maxInFlightChanges = 3
+
+// errSyncTimeout is the cause of the context of a synchronization canceled once SyncTimeout has elapsed.
+var errSyncTimeout = errors.New("synchronization timed out")
+
+// syncStep is the step of the synchronization in flight, reported when it times out.
+type syncStep struct {
+	mu   sync.Mutex
+	step string
+}
+
+func (s *syncStep) set(step string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.step = step
+}
+
+func (s *syncStep) String() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.step
+}
+
+// applyingStep describes the changes of a zone being applied, the first few of them.
+func applyingStep(zone string, changes *plan.Changes) string {
+	step := "applying the changes"
+	if zone != "" {
+		step += fmt.Sprintf(" of zone %q", zone)
+	}
+	lines := planLines(changes)
+	if len(lines) > maxInFlightChanges {
+		return fmt.Sprintf("%s: %s and %d more", step, strings.Join(lines[:maxInFlightChanges], ", "), len(lines)-maxInFlightChanges)
+	}
+	return step + ": " + strings.Join(lines, ", ")
+}
+
+// withSyncTimeout returns the context of a synchronization, whose registry and provider calls
+// are canceled once SyncTimeout has elapsed, if set.
+func (c *Controller) withSyncTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	c.inFlight.set("starting")
+	if c.SyncTimeout <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeoutCause(ctx, c.SyncTimeout, errSyncTimeout)
+}
+
+// syncTimeoutError returns the error of a synchronization which timed out as a soft error telling
+// the step in flight, so that the next synchronization runs, and the other errors as they are.
+func (c *Controller) syncTimeoutError(ctx context.Context, err error) error {
+	if err == nil || !errors.Is(context.Cause(ctx), errSyncTimeout) {
+		return err
+	}
+	controllerSyncTimeoutsTotal.Counter.Inc()
+	return provider.NewSoftError(fmt.Errorf("%w after %s while %s: %w", errSyncTimeout, c.SyncTimeout, c.inFlight.String(), err))
+}
@@ -0,0 +1,92 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/internal/testutils"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider"
+	"sigs.k8s.io/external-dns/registry"
+)
+
+// hungApplyProvider hangs applying the changes until its context is canceled, as a hung API would.
+type hungApplyProvider struct {
+	provider.BaseProvider
+}
+
+func (p *hungApplyProvider) Records(context.Context) ([]*endpoint.Endpoint, error) {
+	return nil, nil
+}
+
+func (p *hungApplyProvider) ApplyChanges(ctx context.Context, _ *plan.Changes) error {
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func TestRunOnceSyncTimeout(t *testing.T) {
+	source := new(testutils.MockSource)
+	source.On("Endpoints").Return([]*endpoint.Endpoint{
+		endpoint.NewEndpoint("www.example.org", endpoint.RecordTypeA, "1.2.3.4"),
+	}, nil)
+	r, err := registry.NewNoopRegistry(&hungApplyProvider{})
+	require.NoError(t, err)
+	ctrl := &Controller{
+		Source:             source,
+		Registry:           r,
+		Policy:             &plan.SyncPolicy{},
+		ManagedRecordTypes: []string{endpoint.RecordTypeA},
+		SyncTimeout:        50 * time.Millisecond,
+	}
+
+	// the hung call is canceled, and the changes in flight are reported in a soft error
+	err = ctrl.RunOnce(context.Background())
+	require.ErrorIs(t, err, provider.SoftError)
+	require.ErrorIs(t, err, errSyncTimeout)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.ErrorContains(t, err, "synchronization timed out after 50ms while applying the changes: create www.example.org A 1.2.3.4")
+
+	// without timeout, the errors are returned as they are
+	ctrl.SyncTimeout = 0
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	err = ctrl.RunOnce(ctx)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.NotErrorIs(t, err, errSyncTimeout)
+}
+
+func TestApplyingStep(t *testing.T) {
+	assert.Equal(t, "applying the changes: create www.example.org A 1.2.3.4", applyingStep("", &plan.Changes{
+		Create: []*endpoint.Endpoint{endpoint.NewEndpoint("www.example.org", endpoint.RecordTypeA, "1.2.3.4")},
+	}))
+
+	// only the first changes are described
+	var creates []*endpoint.Endpoint
+	for _, name := range []string{"a", "b", "c", "d", "e"} {
+		creates = append(creates, endpoint.NewEndpoint(name+".example.org", endpoint.RecordTypeA, "1.2.3.4"))
+	}
+	assert.Equal(t,
+		`applying the changes of zone "example.org": create a.example.org A 1.2.3.4, create b.example.org A 1.2.3.4, create c.example.org A 1.2.3.4 and 2 more`,
+		applyingStep("example.org", &plan.Changes{Create: creates}))
+}
@@ -0,0 +1,84 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/pkg/decisions"
+	"sigs.k8s.io/external-dns/pkg/logging"
+	"sigs.k8s.io/external-dns/pkg/metrics"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider"
+)
+
+var zoneLimitRejectedCreatesTotal = metrics.NewCounterVecWithOpts(
+	prometheus.CounterOpts{
+		Namespace: "external_dns",
+		Subsystem: "zone",
+		Name:      "limit_rejected_creates_total",
+		Help:      "Number of records not created as their zone would have more records than the maximum per zone, per zone.",
+	},
+	[]string{"zone"},
+)
+
+// limitZoneGrowth removes the creates of the records which would grow their zone past
+// MaxRecordsPerZone, reporting why, so that a misconfigured source can't create an unbounded
+// number of records. The updates, the deletes and the creates fitting in their zone are still
+// applied. The records whose name matches none of the zones are counted together.
+func (c *Controller) limitZoneGrowth(zones provider.ZoneIDName, changes *plan.Changes, current []*endpoint.Endpoint) {
+	if c.MaxRecordsPerZone <= 0 || len(changes.Create) == 0 {
+		return
+	}
+	zoneOf := func(ep *endpoint.Endpoint) string {
+		_, zone := zones.FindZone(ep.DNSName)
+		return zone
+	}
+	counts := map[string]int{}
+	for _, ep := range current {
+		counts[zoneOf(ep)]++
+	}
+	for _, ep := range changes.Delete {
+		counts[zoneOf(ep)]--
+	}
+
+	reason := fmt.Sprintf("the zone would have more than the maximum of %d records", c.MaxRecordsPerZone)
+	rejected := map[string]int{}
+	creates := changes.Create[:0]
+	for _, ep := range changes.Create {
+		zone := zoneOf(ep)
+		if counts[zone] >= c.MaxRecordsPerZone {
+			logging.ForRecord(ep).Debugf("Not creating %s %s: %s", ep.DNSName, ep.RecordType, reason)
+			c.FilterDecisions.Record(decisions.ForEndpoint(decisions.ZoneLimitFilter, ep, reason))
+			rejected[zone]++
+			continue
+		}
+		counts[zone]++
+		creates = append(creates, ep)
+	}
+	changes.Create = creates
+
+	for _, zone := range slices.Sorted(maps.Keys(rejected)) {
+		logging.ForZone(zone).Errorf("Not creating %d records in zone %q: %s", rejected[zone], zone, reason)
+		zoneLimitRejectedCreatesTotal.CounterVec.WithLabelValues(zone).Add(float64(rejected[zone]))
+	}
+}
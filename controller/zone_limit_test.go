@@ -0,0 +1,90 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"testing"
+
+	promtestutil "github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider"
+)
+
+func TestLimitZoneGrowth(t *testing.T) {
+	zones := provider.ZoneIDName{}
+	zones.Add("example.org", "example.org")
+	zones.Add("example.com", "example.com")
+	current := []*endpoint.Endpoint{
+		endpoint.NewEndpoint("a.example.org", endpoint.RecordTypeA, "1.2.3.4"),
+		endpoint.NewEndpoint("b.example.org", endpoint.RecordTypeA, "1.2.3.4"),
+		endpoint.NewEndpoint("a.example.com", endpoint.RecordTypeA, "1.2.3.4"),
+	}
+	newChanges := func() *plan.Changes {
+		return &plan.Changes{
+			Create: []*endpoint.Endpoint{
+				endpoint.NewEndpoint("c.example.org", endpoint.RecordTypeA, "1.2.3.4"),
+				endpoint.NewEndpoint("d.example.org", endpoint.RecordTypeA, "1.2.3.4"),
+				endpoint.NewEndpoint("b.example.com", endpoint.RecordTypeA, "1.2.3.4"),
+			},
+			Delete: []*endpoint.Endpoint{
+				endpoint.NewEndpoint("a.example.com", endpoint.RecordTypeA, "1.2.3.4"),
+			},
+		}
+	}
+	names := func(endpoints []*endpoint.Endpoint) []string {
+		var names []string
+		for _, ep := range endpoints {
+			names = append(names, ep.DNSName)
+		}
+		return names
+	}
+
+	t.Run("unlimited", func(t *testing.T) {
+		ctrl := &Controller{}
+		changes := newChanges()
+		ctrl.limitZoneGrowth(zones, changes, current)
+		assert.Len(t, changes.Create, 3)
+	})
+
+	t.Run("limited", func(t *testing.T) {
+		rejected := promtestutil.ToFloat64(zoneLimitRejectedCreatesTotal.CounterVec.WithLabelValues("example.org"))
+		ctrl := &Controller{MaxRecordsPerZone: 3}
+		changes := newChanges()
+		ctrl.limitZoneGrowth(zones, changes, current)
+		// the deletes make room for the creates of their zone
+		assert.Equal(t, []string{"c.example.org", "b.example.com"}, names(changes.Create))
+		assert.Len(t, changes.Delete, 1)
+		assert.InDelta(t, rejected+1, promtestutil.ToFloat64(zoneLimitRejectedCreatesTotal.CounterVec.WithLabelValues("example.org")), 0)
+	})
+
+	t.Run("full zone", func(t *testing.T) {
+		ctrl := &Controller{MaxRecordsPerZone: 1}
+		changes := newChanges()
+		ctrl.limitZoneGrowth(zones, changes, current)
+		assert.Equal(t, []string{"b.example.com"}, names(changes.Create))
+	})
+
+	t.Run("without zones", func(t *testing.T) {
+		ctrl := &Controller{MaxRecordsPerZone: 4}
+		changes := newChanges()
+		ctrl.limitZoneGrowth(provider.ZoneIDName{}, changes, current)
+		assert.Equal(t, []string{"c.example.org", "d.example.org"}, names(changes.Create))
+	})
+}
@@ -0,0 +1,197 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/miekg/dns"
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/registry"
+)
+
+const (
+	// zoneFileDefaultTTL is the TTL of the records without a configured TTL in a zone file.
+	zoneFileDefaultTTL = 300
+	// txtChunkSize is the maximum length of a character string of a TXT record.
+	txtChunkSize = 255
+	// setIdentifierComment prefixes the set identifier of a record in the comment of its
+	// resource records.
+	setIdentifierComment = "; set-identifier: "
+)
+
+// exportZoneFile writes the records owned by the registry of the controller to the path, or
+// to the standard output for -, as RFC 1035 zone file text.
+func exportZoneFile(ctx context.Context, ctrl *Controller, path string, zones []string) error {
+	records, err := exportedRecords(ctx, ctrl.Registry, zones)
+	if err != nil {
+		return err
+	}
+	content, err := marshalZoneFile(syncedRecords(records, ctrl.Registry.OwnerID(), ctrl.OwnerIDTemplate, nil), zones)
+	if err != nil {
+		return err
+	}
+	if path == "-" {
+		_, err = os.Stdout.Write(content)
+		return err
+	}
+	if err := os.WriteFile(path, content, 0o644); err != nil {
+		return fmt.Errorf("failed to write the zone file: %w", err)
+	}
+	log.Infof("Exported the records to %s", path)
+	return nil
+}
+
+// exportedRecords returns the records of the registry, or only those of the zones when given,
+// which are then read without reading the records of the other zones when the registry supports it.
+func exportedRecords(ctx context.Context, r registry.Registry, zones []string) ([]*endpoint.Endpoint, error) {
+	if len(zones) == 0 {
+		return r.Records(ctx)
+	}
+	var records []*endpoint.Endpoint
+	seen := map[endpoint.EndpointKey]struct{}{}
+	for _, zone := range zones {
+		zoneRecords, err := registry.RecordsFor(ctx, r, registry.RecordsFilter{Zone: zone})
+		if err != nil {
+			return nil, err
+		}
+		// the records of a subdomain are read again with the zones of its parent domains
+		for _, ep := range zoneRecords {
+			if _, ok := seen[ep.Key()]; !ok {
+				seen[ep.Key()] = struct{}{}
+				records = append(records, ep)
+			}
+		}
+	}
+	return records, nil
+}
+
+// marshalZoneFile returns the records as zone file text, sorted. Without zones, the records are
+// written with their absolute names. Otherwise, the records of each zone follow an $ORIGIN
+// directive and the records outside of the zones are left out.
+func marshalZoneFile(records []*endpoint.Endpoint, zones []string) ([]byte, error) {
+	records = append([]*endpoint.Endpoint{}, records...)
+	sortRecords(records)
+
+	var buf bytes.Buffer
+	if len(zones) == 0 {
+		if err := writeZoneRecords(&buf, records, ""); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	}
+
+	byZone := map[string][]*endpoint.Endpoint{}
+	for _, ep := range records {
+		if zone := recordZone(ep.DNSName, zones); zone != "" {
+			byZone[zone] = append(byZone[zone], ep)
+		}
+	}
+	for i, zone := range zones {
+		if i > 0 {
+			buf.WriteString("\n")
+		}
+		origin := dns.Fqdn(strings.ToLower(zone))
+		fmt.Fprintf(&buf, "$ORIGIN %s\n", origin)
+		if err := writeZoneRecords(&buf, byZone[zone], origin); err != nil {
+			return nil, err
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+// recordZone returns the longest of the zones containing the name, or an empty string.
+func recordZone(name string, zones []string) string {
+	name = strings.ToLower(strings.TrimSuffix(name, "."))
+	var match string
+	for _, zone := range zones {
+		z := strings.ToLower(strings.TrimSuffix(zone, "."))
+		if (name == z || strings.HasSuffix(name, "."+z)) && len(z) > len(match) {
+			match = zone
+		}
+	}
+	return match
+}
+
+// writeZoneRecords writes a resource record per target of the records, with names relative to
+// the origin when it's not empty. The set identifier of a record, which has no representation
+// in a zone file, is written as a comment alongside its resource records.
+func writeZoneRecords(w io.Writer, records []*endpoint.Endpoint, origin string) error {
+	for _, ep := range records {
+		ttl := int64(zoneFileDefaultTTL)
+		if ep.RecordTTL.IsConfigured() {
+			ttl = int64(ep.RecordTTL)
+		}
+		for _, target := range ep.Targets {
+			if ep.RecordType == endpoint.RecordTypeTXT {
+				target = quoteTXT(target)
+			}
+			rr, err := dns.NewRR(fmt.Sprintf("%s %d IN %s %s", dns.Fqdn(ep.DNSName), ttl, ep.RecordType, target))
+			if err != nil {
+				return fmt.Errorf("failed to export the %s record %s: %w", ep.RecordType, ep.DNSName, err)
+			}
+			if rr == nil {
+				continue
+			}
+			line := rr.String()
+			if origin != "" {
+				name, rest, _ := strings.Cut(line, "\t")
+				line = relativeName(name, origin) + "\t" + rest
+			}
+			if ep.SetIdentifier != "" {
+				line += "\t" + setIdentifierComment + ep.SetIdentifier
+			}
+			fmt.Fprintln(w, line)
+		}
+	}
+	return nil
+}
+
+// relativeName returns the name relative to the origin, or @ for the origin itself.
+func relativeName(name, origin string) string {
+	if strings.EqualFold(name, origin) {
+		return "@"
+	}
+	if len(name) > len(origin) && strings.EqualFold(name[len(name)-len(origin)-1:], "."+origin) {
+		return name[:len(name)-len(origin)-1]
+	}
+	return name
+}
+
+// quoteTXT returns the TXT target as quoted character strings of up to txtChunkSize bytes,
+// unless it's already quoted.
+func quoteTXT(target string) string {
+	if strings.HasPrefix(target, "\"") {
+		return target
+	}
+	var quoted []string
+	for {
+		n := min(len(target), txtChunkSize)
+		chunk := strings.ReplaceAll(target[:n], `\`, `\\`)
+		quoted = append(quoted, `"`+strings.ReplaceAll(chunk, `"`, `\"`)+`"`)
+		if target = target[n:]; target == "" {
+			return strings.Join(quoted, " ")
+		}
+	}
+}
@@ -0,0 +1,121 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider/inmemory"
+	"sigs.k8s.io/external-dns/registry"
+)
+
+func testZoneFileRecords() []*endpoint.Endpoint {
+	return []*endpoint.Endpoint{
+		endpoint.NewEndpointWithTTL("www.example.org", endpoint.RecordTypeA, 60, "1.2.3.4", "5.6.7.8"),
+		endpoint.NewEndpoint("example.org", endpoint.RecordTypeMX, "10 mail.example.org"),
+		endpoint.NewEndpoint("txt.example.org", endpoint.RecordTypeTXT, `hello "world"`),
+		endpoint.NewEndpoint("api.example.com", endpoint.RecordTypeCNAME, "lb.example.net").WithSetIdentifier("eu"),
+	}
+}
+
+func TestMarshalZoneFile(t *testing.T) {
+	content, err := marshalZoneFile(testZoneFileRecords(), nil)
+	require.NoError(t, err)
+	assert.Equal(t, strings.Join([]string{
+		"api.example.com.\t300\tIN\tCNAME\tlb.example.net.\t; set-identifier: eu",
+		"example.org.\t300\tIN\tMX\t10 mail.example.org.",
+		"txt.example.org.\t300\tIN\tTXT\t" + `"hello \"world\""`,
+		"www.example.org.\t60\tIN\tA\t1.2.3.4",
+		"www.example.org.\t60\tIN\tA\t5.6.7.8",
+		"",
+	}, "\n"), string(content))
+}
+
+func TestMarshalZoneFileZones(t *testing.T) {
+	content, err := marshalZoneFile(testZoneFileRecords(), []string{"example.org", "www.example.org"})
+	require.NoError(t, err)
+	assert.Equal(t, strings.Join([]string{
+		"$ORIGIN example.org.",
+		"@\t300\tIN\tMX\t10 mail.example.org.",
+		"txt\t300\tIN\tTXT\t" + `"hello \"world\""`,
+		"",
+		"$ORIGIN www.example.org.",
+		"@\t60\tIN\tA\t1.2.3.4",
+		"@\t60\tIN\tA\t5.6.7.8",
+		"",
+	}, "\n"), string(content))
+}
+
+func TestMarshalZoneFileInvalidRecord(t *testing.T) {
+	_, err := marshalZoneFile([]*endpoint.Endpoint{endpoint.NewEndpoint("www.example.org", endpoint.RecordTypeA, "not-an-ip")}, nil)
+	require.ErrorContains(t, err, "failed to export the A record www.example.org")
+}
+
+func TestQuoteTXT(t *testing.T) {
+	assert.Equal(t, `"already quoted"`, quoteTXT(`"already quoted"`))
+	assert.Equal(t, `"a\\b"`, quoteTXT(`a\b`))
+	assert.Equal(t, `""`, quoteTXT(""))
+
+	long := strings.Repeat("a", 300)
+	assert.Equal(t, `"`+strings.Repeat("a", 255)+`" "`+strings.Repeat("a", 45)+`"`, quoteTXT(long))
+}
+
+func TestExportZoneFile(t *testing.T) {
+	prov := &filteredMockProvider{RecordsStore: []*endpoint.Endpoint{
+		endpoint.NewEndpoint("www.example.org", endpoint.RecordTypeA, "1.2.3.4"),
+	}}
+	r, err := registry.NewNoopRegistry(prov)
+	require.NoError(t, err)
+	ctrl := &Controller{Registry: r}
+
+	path := filepath.Join(t.TempDir(), "records.zone")
+	require.NoError(t, exportZoneFile(context.Background(), ctrl, path, []string{"example.org"}))
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "$ORIGIN example.org.\nwww\t300\tIN\tA\t1.2.3.4\n", string(content))
+	assert.Empty(t, prov.ApplyChangesCalls)
+}
+
+func TestExportedRecordsOfZones(t *testing.T) {
+	p := inmemory.NewInMemoryProvider(inmemory.InMemoryInitZones([]string{"example.org", "dev.example.org", "example.com"}))
+	require.NoError(t, p.ApplyChanges(context.Background(), &plan.Changes{
+		Create: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("www.example.org", endpoint.RecordTypeA, "1.2.3.4"),
+			endpoint.NewEndpoint("www.dev.example.org", endpoint.RecordTypeA, "1.2.3.5"),
+			endpoint.NewEndpoint("www.example.com", endpoint.RecordTypeA, "1.2.3.6"),
+		},
+	}))
+	r, err := registry.NewNoopRegistry(p)
+	require.NoError(t, err)
+
+	records, err := exportedRecords(context.Background(), r, []string{"example.org", "dev.example.org"})
+	require.NoError(t, err)
+	names := make([]string, 0, len(records))
+	for _, ep := range records {
+		names = append(names, ep.DNSName)
+	}
+	assert.ElementsMatch(t, []string{"www.example.org", "www.dev.example.org"}, names)
+}
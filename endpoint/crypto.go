@@ -23,6 +23,7 @@ import (
 	"crypto/cipher"
 	"crypto/rand"
 	"encoding/base64"
+	"errors"
 	"fmt"
 	"io"
 
@@ -31,6 +32,53 @@ import (
 
 const standardGcmNonceSize = 12
 
+// ErrCipherUnavailable is returned by a TextCipher when the key of an encrypted text can't be
+// obtained, e.g. from an unreachable key management service. Such a text isn't taken for
+// plain-text labels, as it would make the records it owns look unowned.
+var ErrCipherUnavailable = errors.New("the encryption key is unavailable")
+
+// TextCipher encrypts and decrypts the labels of the TXT registry. The nonce, base64-encoded, is
+// kept in the labels, so that the same labels are encrypted the same way.
+type TextCipher interface {
+	EncryptText(text string, nonceEncoded []byte) (string, error)
+	DecryptText(text string) (decryptResult string, encryptNonce string, err error)
+}
+
+// DecodeAESKey returns the AES-256 key, 32 bytes long in either plain text or base64-encoded format.
+func DecodeAESKey(aesKey []byte) ([]byte, error) {
+	if len(aesKey) == 32 {
+		return aesKey, nil
+	}
+	key, err := base64.StdEncoding.DecodeString(string(aesKey))
+	if err != nil || len(key) != 32 {
+		return nil, errors.New("the AES Encryption key must be 32 bytes long, in either plain text or base64-encoded format")
+	}
+	return key, nil
+}
+
+// aesCipher is a TextCipher encrypting with AES-GCM and a static key.
+type aesCipher struct {
+	key []byte
+}
+
+// NewAESCipher returns a TextCipher encrypting with AES-GCM and a static key, 32 bytes long for
+// AES-256. Only the standard library ciphers are used, which are FIPS 140 compliant when built
+// with GOFIPS140 or GOEXPERIMENT=boringcrypto.
+func NewAESCipher(aesKey []byte) (TextCipher, error) {
+	if _, err := aes.NewCipher(aesKey); err != nil {
+		return nil, err
+	}
+	return aesCipher{key: aesKey}, nil
+}
+
+func (c aesCipher) EncryptText(text string, nonceEncoded []byte) (string, error) {
+	return EncryptText(text, c.key, nonceEncoded)
+}
+
+func (c aesCipher) DecryptText(text string) (string, string, error) {
+	return DecryptText(text, c.key)
+}
+
 // GenerateNonce creates a random nonce of a fixed size
 func GenerateNonce() ([]byte, error) {
 	nonce := make([]byte, standardGcmNonceSize)
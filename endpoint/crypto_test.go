@@ -90,3 +90,30 @@ type faultyReader struct{}
 func (f *faultyReader) Read(p []byte) (n int, err error) {
 	return 0, io.ErrUnexpectedEOF
 }
+
+func TestAESCipher(t *testing.T) {
+	for _, k := range []string{"passphrasewhichneedstobe32bytes!", "ZPitL0NGVQBZbTD6DwXJzD8RiStSazzYXQsdUowLURY="} {
+		key, err := DecodeAESKey([]byte(k))
+		require.NoError(t, err)
+		require.Len(t, key, 32)
+		c, err := NewAESCipher(key)
+		require.NoError(t, err)
+
+		nonce, err := GenerateNonce()
+		require.NoError(t, err)
+		encrypted, err := c.EncryptText("heritage=external-dns,external-dns/owner=default", nonce)
+		require.NoError(t, err)
+		decrypted, decryptedNonce, err := DecryptText(encrypted, key)
+		require.NoError(t, err)
+		require.Equal(t, "heritage=external-dns,external-dns/owner=default", decrypted)
+		require.Equal(t, string(nonce), decryptedNonce)
+		decrypted, _, err = c.DecryptText(encrypted)
+		require.NoError(t, err)
+		require.Equal(t, "heritage=external-dns,external-dns/owner=default", decrypted)
+	}
+
+	_, err := DecodeAESKey([]byte("too short"))
+	require.Error(t, err)
+	_, err = NewAESCipher([]byte("too short"))
+	require.Error(t, err)
+}
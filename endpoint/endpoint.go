@@ -47,6 +47,15 @@ const (
 	RecordTypeNAPTR = "NAPTR"
 )
 
+const (
+	// IPFamilyIPv4 publishes the A records of a DNS name that has both A and AAAA records
+	IPFamilyIPv4 = "ipv4"
+	// IPFamilyIPv6 publishes the AAAA records of a DNS name that has both A and AAAA records
+	IPFamilyIPv6 = "ipv6"
+	// IPFamilyDual publishes both the A and AAAA records of a DNS name
+	IPFamilyDual = "dual"
+)
+
 var (
 	KnownRecordTypes = []string{
 		RecordTypeA,
@@ -58,6 +67,12 @@ var (
 		RecordTypeMX,
 		RecordTypeNAPTR,
 	}
+
+	KnownIPFamilies = []string{
+		IPFamilyIPv4,
+		IPFamilyIPv6,
+		IPFamilyDual,
+	}
 )
 
 // TTL is a structure defining the TTL of a DNS record
@@ -217,6 +232,7 @@ type EndpointKey struct {
 
 // Endpoint is a high-level way of a connection between a service and an IP
 // +kubebuilder:object:generate=true
+// +kubebuilder:validation:XValidation:rule="!has(self.mxPriority) || self.recordType == 'MX'",message="mxPriority only applies to MX records"
 type Endpoint struct {
 	// The hostname of the DNS record
 	DNSName string `json:"dnsName,omitempty"`
@@ -228,6 +244,11 @@ type Endpoint struct {
 	SetIdentifier string `json:"setIdentifier,omitempty"`
 	// TTL for the record
 	RecordTTL TTL `json:"recordTTL,omitempty"`
+	// The priority of the mail exchanges of an MX record, given to the targets without one
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=65535
+	// +optional
+	MXPriority *int32 `json:"mxPriority,omitempty"`
 	// Labels stores labels defined for the Endpoint
 	// +optional
 	Labels Labels `json:"labels,omitempty"`
@@ -394,19 +415,42 @@ func (e *Endpoint) CheckEndpoint() bool {
 	return true
 }
 
+// MXTarget is the structured form of the target of an MX record, e.g. "10 mail.example.com".
+type MXTarget struct {
+	// Priority is the preference of the mail exchange, the lowest being preferred.
+	Priority uint16
+	// Host is the host name of the mail exchange.
+	Host string
+}
+
+// NewMXTarget returns the target of an MX record for the given priority and host.
+func NewMXTarget(priority uint16, host string) string {
+	return MXTarget{Priority: priority, Host: host}.String()
+}
+
+// ParseMXTarget parses the target of an MX record, made of a priority and a host name
+// as per https://www.rfc-editor.org/rfc/rfc974.txt, e.g. "10 mail.example.com".
+func ParseMXTarget(target string) (MXTarget, error) {
+	fields := strings.Fields(target)
+	if len(fields) != 2 {
+		return MXTarget{}, fmt.Errorf("invalid MX record target %q: MX records must have a priority and a host, e.g. '10 example.com'", target)
+	}
+	priority, err := strconv.ParseUint(fields[0], 10, 16)
+	if err != nil {
+		return MXTarget{}, fmt.Errorf("invalid MX record target %q: the priority must be an integer between 0 and 65535", target)
+	}
+	return MXTarget{Priority: uint16(priority), Host: strings.TrimSuffix(fields[1], ".")}, nil
+}
+
+// String returns the target of the MX record.
+func (t MXTarget) String() string {
+	return fmt.Sprintf("%d %s", t.Priority, t.Host)
+}
+
 func (t Targets) ValidateMXRecord() bool {
 	for _, target := range t {
-		// MX records must have a preference value to indicate priority, e.g. "10 example.com"
-		// as per https://www.rfc-editor.org/rfc/rfc974.txt
-		targetParts := strings.Fields(strings.TrimSpace(target))
-		if len(targetParts) != 2 {
-			log.Debugf("Invalid MX record target: %s. MX records must have a preference value to indicate priority, e.g. '10 example.com'", target)
-			return false
-		}
-		preferenceRaw := targetParts[0]
-		_, err := strconv.ParseUint(preferenceRaw, 10, 16)
-		if err != nil {
-			log.Debugf("Invalid SRV record target: %s. Invalid integer value in target.", target)
+		if _, err := ParseMXTarget(target); err != nil {
+			log.Debug(err)
 			return false
 		}
 	}
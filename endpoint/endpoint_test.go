@@ -22,6 +22,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestNewEndpoint(t *testing.T) {
@@ -781,6 +782,15 @@ func TestPDNScheckEndpoint(t *testing.T) {
 			},
 			expected: false,
 		},
+		{
+			description: "Invalid MX record with out of range priority",
+			endpoint: Endpoint{
+				DNSName:    "example.com",
+				RecordType: RecordTypeMX,
+				Targets:    Targets{"65536 example.com"},
+			},
+			expected: false,
+		},
 		{
 			description: "Valid SRV record target",
 			endpoint: Endpoint{
@@ -815,3 +825,16 @@ func TestPDNScheckEndpoint(t *testing.T) {
 		assert.Equal(t, tt.expected, actual)
 	}
 }
+
+func TestParseMXTarget(t *testing.T) {
+	target, err := ParseMXTarget(" 10  mail.example.com. ")
+	require.NoError(t, err)
+	assert.Equal(t, MXTarget{Priority: 10, Host: "mail.example.com"}, target)
+	assert.Equal(t, "10 mail.example.com", target.String())
+	assert.Equal(t, "0 mail.example.com", NewMXTarget(0, "mail.example.com"))
+
+	for _, invalid := range []string{"", "mail.example.com", "-1 mail.example.com", "65536 mail.example.com", "10 mail.example.com extra"} {
+		_, err := ParseMXTarget(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
@@ -36,6 +36,17 @@ const (
 	ResourceLabelKey = "resource"
 	// OwnedRecordLabelKey is the name of the label that identifies the record that is owned by the labeled TXT registry record
 	OwnedRecordLabelKey = "ownedRecord"
+	// CommentLabelKey is the name of the label that holds the comment of the k8s resource, recorded with the owner and the resource
+	CommentLabelKey = "comment"
+	// ZoneIDLabelKey is the name of the label that pins the record to a zone of the provider, instead of the zone
+	// with the longest name matching it
+	ZoneIDLabelKey = "zone-id"
+	// ZoneSelectionLabelKey is the name of the label that chooses the zones of the record when both public and private
+	// zones match it
+	ZoneSelectionLabelKey = "zone-selection"
+	// KubernetesLabelPrefix is the prefix of the names of the labels copied from the labels of the k8s resource,
+	// e.g. label/team, recorded with the owner and the resource
+	KubernetesLabelPrefix = "label/"
 
 	// AWSSDDescriptionLabel label responsible for storing raw owner/resource combination information in the Labels
 	// supposed to be inserted by AWS SD Provider, and parsed into OwnerLabelKey and ResourceLabelKey key by AWS SD Registry
@@ -88,8 +99,22 @@ func NewLabelsFromStringPlain(labelText string) (Labels, error) {
 }
 
 func NewLabelsFromString(labelText string, aesKey []byte) (Labels, error) {
-	if len(aesKey) != 0 {
-		decryptedText, encryptionNonce, err := DecryptText(strings.Trim(labelText, "\""), aesKey)
+	if len(aesKey) == 0 {
+		return NewLabelsFromStringPlain(labelText)
+	}
+	c, err := NewAESCipher(aesKey)
+	if err != nil {
+		return NewLabelsFromStringPlain(labelText)
+	}
+	return NewLabelsFromStringWithCipher(labelText, c)
+}
+
+// NewLabelsFromStringWithCipher same to NewLabelsFromString, but decrypts the text with the cipher,
+// if not nil. The texts which can't be decrypted are parsed as plain-text labels, unless the key
+// of the cipher is unavailable.
+func NewLabelsFromStringWithCipher(labelText string, c TextCipher) (Labels, error) {
+	if c != nil {
+		decryptedText, encryptionNonce, err := c.DecryptText(strings.Trim(labelText, "\""))
 		// in case if we have a decryption error, try process original text
 		// decryption errors should be ignored here, because we can already have plain-text labels in the registry
 		if err == nil {
@@ -100,10 +125,25 @@ func NewLabelsFromString(labelText string, aesKey []byte) (Labels, error) {
 
 			return labels, err
 		}
+		if errors.Is(err, ErrCipherUnavailable) {
+			return nil, err
+		}
 	}
 	return NewLabelsFromStringPlain(labelText)
 }
 
+// KubernetesLabels returns the labels copied from the labels of the k8s resource, by their name
+// in the resource.
+func (l Labels) KubernetesLabels() map[string]string {
+	labels := map[string]string{}
+	for key, value := range l {
+		if name, ok := strings.CutPrefix(key, KubernetesLabelPrefix); ok && name != "" {
+			labels[name] = value
+		}
+	}
+	return labels
+}
+
 // SerializePlain transforms endpoints labels into a external-dns recognizable format string
 // withQuotes adds additional quotes
 func (l Labels) SerializePlain(withQuotes bool) string {
@@ -132,6 +172,19 @@ func (l Labels) Serialize(withQuotes bool, txtEncryptEnabled bool, aesKey []byte
 	if !txtEncryptEnabled {
 		return l.SerializePlain(withQuotes)
 	}
+	c, err := NewAESCipher(aesKey)
+	if err != nil {
+		// if encryption failed, the external-dns will crash
+		log.Fatalf("Failed to encrypt the text using the encryption key %#v. Got error %#v.", aesKey, err)
+	}
+	return l.SerializeWithCipher(withQuotes, c)
+}
+
+// SerializeWithCipher same to SerializePlain, but encrypt data with the cipher, if not nil
+func (l Labels) SerializeWithCipher(withQuotes bool, c TextCipher) string {
+	if c == nil {
+		return l.SerializePlain(withQuotes)
+	}
 
 	var encryptionNonce []byte
 	if extractedNonce, nonceExists := l[txtEncryptionNonce]; nonceExists {
@@ -148,10 +201,10 @@ func (l Labels) Serialize(withQuotes bool, txtEncryptEnabled bool, aesKey []byte
 	text := l.SerializePlain(false)
 	log.Debugf("Encrypt the serialized text %#v before returning it.", text)
 	var err error
-	text, err = EncryptText(text, aesKey, encryptionNonce)
+	text, err = c.EncryptText(text, encryptionNonce)
 	if err != nil {
 		// if encryption failed, the external-dns will crash
-		log.Fatalf("Failed to encrypt the text %#v using the encryption key %#v. Got error %#v.", text, aesKey, err)
+		log.Fatalf("Failed to encrypt the text %#v. Got error %#v.", text, err)
 	}
 
 	if withQuotes {
@@ -178,6 +178,21 @@ func (suite *LabelsSuite) TestDeserialize() {
 	suite.Nil(multipleHeritage, "if error should return nil")
 }
 
+func (suite *LabelsSuite) TestKubernetesLabels() {
+	labels := Labels{
+		OwnerLabelKey:                                 "owner",
+		KubernetesLabelPrefix + "team":                "payments",
+		KubernetesLabelPrefix + "example.com/billing": "cc-1234",
+		KubernetesLabelPrefix:                         "empty",
+	}
+	suite.Equal(map[string]string{"team": "payments", "example.com/billing": "cc-1234"}, labels.KubernetesLabels())
+
+	// the labels are recorded along with the owner
+	parsed, err := NewLabelsFromStringPlain(labels.SerializePlain(true))
+	suite.NoError(err)
+	suite.Equal(labels.KubernetesLabels(), parsed.KubernetesLabels())
+}
+
 func TestLabels(t *testing.T) {
 	suite.Run(t, new(LabelsSuite))
 }
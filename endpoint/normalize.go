@@ -0,0 +1,177 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package endpoint
+
+import (
+	"net/netip"
+	"sort"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// NormalizeDNSName returns the canonical form of a DNS name: lowercase, without surrounding
+// spaces nor trailing dot.
+func NormalizeDNSName(dnsName string) string {
+	return strings.TrimSuffix(strings.ToLower(strings.TrimSpace(dnsName)), ".")
+}
+
+// NormalizeTarget returns the canonical form of a target of the given record type:
+//   - the IP addresses of A and AAAA records are in their shortest form, e.g. 2001:db8::1
+//   - the host names of CNAME, NS, PTR, MX and SRV records are normalized as DNS names
+//   - the other targets, such as those of TXT records, are left untouched as they are case-sensitive
+func NormalizeTarget(recordType, target string) string {
+	switch recordType {
+	case RecordTypeA, RecordTypeAAAA:
+		if ip, err := netip.ParseAddr(strings.TrimSpace(target)); err == nil {
+			return ip.String()
+		}
+		// alias records point to host names
+		return NormalizeDNSName(target)
+	case RecordTypeCNAME, RecordTypeNS, RecordTypePTR:
+		return NormalizeDNSName(target)
+	case RecordTypeMX, RecordTypeSRV:
+		// the host name is the last field, after the priority, weight and port
+		fields := strings.Fields(target)
+		if len(fields) == 0 {
+			return target
+		}
+		fields[len(fields)-1] = NormalizeDNSName(fields[len(fields)-1])
+		return strings.Join(fields, " ")
+	default:
+		return target
+	}
+}
+
+// Normalize converts the endpoint to its canonical form, so that the endpoints of the sources
+// and of the providers can be compared: its name and targets are normalized, and its targets
+// are sorted without duplicates.
+func (e *Endpoint) Normalize() *Endpoint {
+	e.DNSName = NormalizeDNSName(e.DNSName)
+	if len(e.Targets) == 0 {
+		return e
+	}
+
+	targets := make(Targets, 0, len(e.Targets))
+	seen := make(map[string]struct{}, len(e.Targets))
+	for _, target := range e.Targets {
+		target = NormalizeTarget(e.RecordType, target)
+		if _, ok := seen[target]; ok {
+			continue
+		}
+		seen[target] = struct{}{}
+		targets = append(targets, target)
+	}
+	sort.Stable(targets)
+	e.Targets = targets
+	return e
+}
+
+// NormalizeEndpoints normalizes the endpoints in place and returns them without the nil
+// and duplicate endpoints, which have the same name, record type, set identifier and targets.
+func NormalizeEndpoints(endpoints []*Endpoint) []*Endpoint {
+	result := make([]*Endpoint, 0, len(endpoints))
+	collected := make(map[string]struct{}, len(endpoints))
+
+	for _, ep := range endpoints {
+		if ep == nil {
+			continue
+		}
+		ep.Normalize()
+
+		identifier := strings.Join([]string{ep.RecordType, ep.DNSName, ep.SetIdentifier, ep.Targets.String()}, "/")
+		if _, ok := collected[identifier]; ok {
+			log.Debugf("Removing duplicate endpoint %s", ep)
+			continue
+		}
+
+		collected[identifier] = struct{}{}
+		result = append(result, ep)
+	}
+
+	return result
+}
+
+// NormalizedCopies returns normalized copies of the endpoints, without the nil and duplicate
+// ones, along with the endpoint each copy was made from. Unlike NormalizeEndpoints, it leaves the
+// endpoints unchanged, so that the records of a provider can be compared normalized but still
+// be updated and deleted as the provider returned them.
+func NormalizedCopies(endpoints []*Endpoint) ([]*Endpoint, map[*Endpoint]*Endpoint) {
+	copies := make([]*Endpoint, 0, len(endpoints))
+	originals := make(map[*Endpoint]*Endpoint, len(endpoints))
+	for _, ep := range endpoints {
+		if ep == nil {
+			continue
+		}
+		c := ep.DeepCopy()
+		originals[c] = ep
+		copies = append(copies, c)
+	}
+	return NormalizeEndpoints(copies), originals
+}
+
+// SuitableRecordType returns the record type suitable for a target: A or AAAA for the
+// IPv4 and IPv6 addresses, and CNAME for anything else.
+func SuitableRecordType(target string) string {
+	ip, err := netip.ParseAddr(target)
+	switch {
+	case err != nil:
+		return RecordTypeCNAME
+	case ip.Is4():
+		return RecordTypeA
+	default:
+		return RecordTypeAAAA
+	}
+}
+
+// WithSuitableRecordTypes returns the endpoints with the record type suitable for their targets,
+// so that an endpoint whose target changes from an IP address to a host name, or from IPv4 to
+// IPv6, replaces the record of the former type instead of updating it with invalid targets.
+// Only the A, AAAA and CNAME endpoints are switched, and they are split when their targets
+// are of several kinds.
+func WithSuitableRecordTypes(endpoints []*Endpoint) []*Endpoint {
+	result := make([]*Endpoint, 0, len(endpoints))
+	for _, ep := range endpoints {
+		if ep == nil || len(ep.Targets) == 0 || (ep.RecordType != RecordTypeA && ep.RecordType != RecordTypeAAAA && ep.RecordType != RecordTypeCNAME) {
+			result = append(result, ep)
+			continue
+		}
+
+		var recordTypes []string
+		byType := map[string]Targets{}
+		for _, target := range ep.Targets {
+			recordType := SuitableRecordType(target)
+			if _, ok := byType[recordType]; !ok {
+				recordTypes = append(recordTypes, recordType)
+			}
+			byType[recordType] = append(byType[recordType], target)
+		}
+		if len(recordTypes) == 1 && recordTypes[0] == ep.RecordType {
+			result = append(result, ep)
+			continue
+		}
+
+		for _, recordType := range recordTypes {
+			log.Debugf("Switching the targets %v of %s from %s to %s records", byType[recordType], ep.DNSName, ep.RecordType, recordType)
+			switched := ep.DeepCopy()
+			switched.RecordType = recordType
+			switched.Targets = byType[recordType]
+			result = append(result, switched)
+		}
+	}
+	return result
+}
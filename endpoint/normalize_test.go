@@ -0,0 +1,133 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package endpoint
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeDNSName(t *testing.T) {
+	for _, tc := range []struct {
+		input, expected string
+	}{
+		{"example.org", "example.org"},
+		{"Example.ORG.", "example.org"},
+		{" www.example.org. ", "www.example.org"},
+		{"", ""},
+	} {
+		assert.Equal(t, tc.expected, NormalizeDNSName(tc.input), tc.input)
+	}
+}
+
+func TestNormalizeTarget(t *testing.T) {
+	for _, tc := range []struct {
+		recordType, target, expected string
+	}{
+		{RecordTypeA, "1.2.3.4", "1.2.3.4"},
+		{RecordTypeA, " 1.2.3.4", "1.2.3.4"},
+		{RecordTypeA, "LB.Example.com.", "lb.example.com"},
+		{RecordTypeAAAA, "2001:0DB8:0000:0000:0000:0000:0000:0001", "2001:db8::1"},
+		{RecordTypeAAAA, "2001:db8::1", "2001:db8::1"},
+		{RecordTypeCNAME, "LB.Example.com.", "lb.example.com"},
+		{RecordTypeNS, "ns1.example.com.", "ns1.example.com"},
+		{RecordTypeMX, "10  Mail.Example.com.", "10 mail.example.com"},
+		{RecordTypeSRV, "10 5 443 Target.Example.com.", "10 5 443 target.example.com"},
+		{RecordTypeSRV, "", ""},
+		{RecordTypeTXT, "\"Heritage=External-DNS\"", "\"Heritage=External-DNS\""},
+	} {
+		assert.Equal(t, tc.expected, NormalizeTarget(tc.recordType, tc.target), tc.recordType+" "+tc.target)
+	}
+}
+
+func TestEndpointNormalize(t *testing.T) {
+	ep := &Endpoint{
+		DNSName:    "WWW.example.org.",
+		RecordType: RecordTypeAAAA,
+		Targets:    Targets{"2001:db8::2", "2001:DB8:0:0:0:0:0:1", "2001:db8::1"},
+	}
+
+	assert.Same(t, ep, ep.Normalize())
+	assert.Equal(t, "www.example.org", ep.DNSName)
+	assert.Equal(t, Targets{"2001:db8::1", "2001:db8::2"}, ep.Targets)
+
+	// normalizing is idempotent
+	ep.Normalize()
+	assert.Equal(t, Targets{"2001:db8::1", "2001:db8::2"}, ep.Targets)
+
+	empty := &Endpoint{DNSName: "example.org."}
+	assert.Nil(t, empty.Normalize().Targets)
+}
+
+func TestNormalizeEndpoints(t *testing.T) {
+	endpoints := NormalizeEndpoints([]*Endpoint{
+		{DNSName: "www.example.org", RecordType: RecordTypeA, Targets: Targets{"1.2.3.4", "5.6.7.8"}},
+		nil,
+		{DNSName: "WWW.example.org.", RecordType: RecordTypeA, Targets: Targets{"5.6.7.8", "1.2.3.4"}},
+		{DNSName: "www.example.org", RecordType: RecordTypeA, SetIdentifier: "b", Targets: Targets{"1.2.3.4", "5.6.7.8"}},
+		{DNSName: "www.example.org", RecordType: RecordTypeCNAME, Targets: Targets{"lb.example.com."}},
+	})
+
+	assert.Equal(t, []*Endpoint{
+		{DNSName: "www.example.org", RecordType: RecordTypeA, Targets: Targets{"1.2.3.4", "5.6.7.8"}},
+		{DNSName: "www.example.org", RecordType: RecordTypeA, SetIdentifier: "b", Targets: Targets{"1.2.3.4", "5.6.7.8"}},
+		{DNSName: "www.example.org", RecordType: RecordTypeCNAME, Targets: Targets{"lb.example.com"}},
+	}, endpoints)
+}
+
+func TestNormalizedCopies(t *testing.T) {
+	original := &Endpoint{DNSName: "WWW.example.org.", RecordType: RecordTypeAAAA, Targets: Targets{"2001:DB8:0:0:0:0:0:2", "2001:db8::1"}}
+	copies, originals := NormalizedCopies([]*Endpoint{original, nil})
+
+	assert.Equal(t, []*Endpoint{
+		{DNSName: "www.example.org", RecordType: RecordTypeAAAA, Targets: Targets{"2001:db8::1", "2001:db8::2"}},
+	}, copies)
+	assert.Same(t, original, originals[copies[0]])
+	assert.Equal(t, "WWW.example.org.", original.DNSName)
+	assert.Equal(t, Targets{"2001:DB8:0:0:0:0:0:2", "2001:db8::1"}, original.Targets)
+}
+
+func TestSuitableRecordType(t *testing.T) {
+	assert.Equal(t, RecordTypeA, SuitableRecordType("1.2.3.4"))
+	assert.Equal(t, RecordTypeAAAA, SuitableRecordType("2001:db8::1"))
+	assert.Equal(t, RecordTypeCNAME, SuitableRecordType("lb.example.com"))
+}
+
+func TestWithSuitableRecordTypes(t *testing.T) {
+	labels := Labels{ResourceLabelKey: "service/default/web"}
+	endpoints := WithSuitableRecordTypes([]*Endpoint{
+		{DNSName: "ip-to-host.example.org", RecordType: RecordTypeA, Targets: Targets{"lb.example.com"}, Labels: labels},
+		{DNSName: "v4-to-v6.example.org", RecordType: RecordTypeA, Targets: Targets{"2001:db8::1"}},
+		{DNSName: "host-to-ip.example.org", RecordType: RecordTypeCNAME, Targets: Targets{"1.2.3.4"}, RecordTTL: 60},
+		{DNSName: "mixed.example.org", RecordType: RecordTypeA, Targets: Targets{"1.2.3.4", "2001:db8::1", "5.6.7.8"}},
+		{DNSName: "unchanged.example.org", RecordType: RecordTypeAAAA, Targets: Targets{"2001:db8::1"}},
+		{DNSName: "txt.example.org", RecordType: RecordTypeTXT, Targets: Targets{"1.2.3.4"}},
+		nil,
+	})
+
+	assert.Equal(t, []*Endpoint{
+		{DNSName: "ip-to-host.example.org", RecordType: RecordTypeCNAME, Targets: Targets{"lb.example.com"}, Labels: labels},
+		{DNSName: "v4-to-v6.example.org", RecordType: RecordTypeAAAA, Targets: Targets{"2001:db8::1"}},
+		{DNSName: "host-to-ip.example.org", RecordType: RecordTypeA, Targets: Targets{"1.2.3.4"}, RecordTTL: 60},
+		{DNSName: "mixed.example.org", RecordType: RecordTypeA, Targets: Targets{"1.2.3.4", "5.6.7.8"}},
+		{DNSName: "mixed.example.org", RecordType: RecordTypeAAAA, Targets: Targets{"2001:db8::1"}},
+		{DNSName: "unchanged.example.org", RecordType: RecordTypeAAAA, Targets: Targets{"2001:db8::1"}},
+		{DNSName: "txt.example.org", RecordType: RecordTypeTXT, Targets: Targets{"1.2.3.4"}},
+		nil,
+	}, endpoints)
+}
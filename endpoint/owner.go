@@ -0,0 +1,108 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package endpoint
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// OwnerIDTemplate is a template of the owner IDs of the records, derived from the owner ID of the
+// instance and the resource of each record, e.g. `{{ .OwnerID }}-{{ .Namespace }}`, so that the
+// records of each tenant are owned by a distinct owner. A nil template owns all the records by the
+// owner ID of the instance.
+type OwnerIDTemplate struct {
+	tmpl *template.Template
+}
+
+// OwnerIDTemplateData is the data of the owner ID templates.
+type OwnerIDTemplateData struct {
+	// OwnerID is the owner ID of the instance
+	OwnerID string
+	// Kind, Namespace and Name identify the k8s resource of the record
+	Kind      string
+	Namespace string
+	Name      string
+	// Labels are the Kubernetes labels of the resource copied to the record, see KubernetesLabels
+	Labels map[string]string
+}
+
+// NewOwnerIDTemplate parses the template of the owner IDs of the records, returning nil for an
+// empty template.
+func NewOwnerIDTemplate(text string) (*OwnerIDTemplate, error) {
+	if text == "" {
+		return nil, nil
+	}
+	tmpl, err := template.New("owner-id").Option("missingkey=zero").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the owner ID template: %w", err)
+	}
+	return &OwnerIDTemplate{tmpl: tmpl}, nil
+}
+
+// OwnerID returns the owner ID of the records with these labels for the owner ID of the instance,
+// which is the owner ID of the instance itself without template, or for the records of no
+// resource. The owner ID of the instance is also returned when the template fails, or renders an
+// owner ID which can't be recorded by the registry.
+func (t *OwnerIDTemplate) OwnerID(ownerID string, labels Labels) string {
+	resource := labels[ResourceLabelKey]
+	if t == nil || resource == "" {
+		return ownerID
+	}
+
+	data := OwnerIDTemplateData{OwnerID: ownerID, Labels: labels.KubernetesLabels()}
+	switch parts := strings.Split(resource, "/"); len(parts) {
+	case 3:
+		data.Kind, data.Namespace, data.Name = parts[0], parts[1], parts[2]
+	case 2:
+		data.Kind, data.Name = parts[0], parts[1]
+	}
+	var b strings.Builder
+	if err := t.tmpl.Execute(&b, data); err != nil {
+		log.Warnf("Failed to apply the owner ID template to the resource %s: %v", resource, err)
+		return ownerID
+	}
+	owner := strings.TrimSpace(b.String())
+	if owner == "" || strings.ContainsAny(owner, `,="`) {
+		log.Warnf("The owner ID template renders an invalid owner ID %q for the resource %s", owner, resource)
+		return ownerID
+	}
+	return owner
+}
+
+// IsOwnedBy returns whether the records with these labels are owned by the instance with this owner ID.
+func (t *OwnerIDTemplate) IsOwnedBy(ownerID string, labels Labels) bool {
+	owner, ok := labels[OwnerLabelKey]
+	return ok && owner == t.OwnerID(ownerID, labels)
+}
+
+// FilterEndpointsByOwnerID returns the endpoints owned by the instance with this owner ID, like
+// FilterEndpointsByOwnerID with the owner IDs derived from the template.
+func (t *OwnerIDTemplate) FilterEndpointsByOwnerID(ownerID string, eps []*Endpoint) []*Endpoint {
+	filtered := []*Endpoint{}
+	for _, ep := range eps {
+		if !t.IsOwnedBy(ownerID, ep.Labels) {
+			log.Debugf(`Skipping endpoint %v because owner id does not match, found: "%s", required: "%s"`, ep, ep.Labels[OwnerLabelKey], t.OwnerID(ownerID, ep.Labels))
+		} else {
+			filtered = append(filtered, ep)
+		}
+	}
+	return filtered
+}
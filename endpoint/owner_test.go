@@ -0,0 +1,66 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package endpoint
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOwnerIDTemplate(t *testing.T) {
+	labels := Labels{
+		OwnerLabelKey:                  "cluster-team-a",
+		ResourceLabelKey:               "service/team-a/web",
+		KubernetesLabelPrefix + "team": "payments",
+	}
+
+	// without template, the records are owned by the owner ID of the instance
+	tmpl, err := NewOwnerIDTemplate("")
+	require.NoError(t, err)
+	assert.Nil(t, tmpl)
+	assert.Equal(t, "cluster", tmpl.OwnerID("cluster", labels))
+	assert.False(t, tmpl.IsOwnedBy("cluster", labels))
+	assert.True(t, tmpl.IsOwnedBy("cluster-team-a", labels))
+
+	tmpl, err = NewOwnerIDTemplate("{{ .OwnerID }}-{{ .Namespace }}")
+	require.NoError(t, err)
+	assert.Equal(t, "cluster-team-a", tmpl.OwnerID("cluster", labels))
+	assert.True(t, tmpl.IsOwnedBy("cluster", labels))
+	assert.False(t, tmpl.IsOwnedBy("other", labels))
+	assert.Len(t, tmpl.FilterEndpointsByOwnerID("cluster", []*Endpoint{{Labels: labels}, {Labels: Labels{OwnerLabelKey: "cluster-team-a"}}}), 1)
+
+	// the records of no resource are owned by the owner ID of the instance
+	assert.Equal(t, "cluster", tmpl.OwnerID("cluster", Labels{}))
+	assert.True(t, tmpl.IsOwnedBy("cluster", Labels{OwnerLabelKey: "cluster"}))
+
+	tmpl, err = NewOwnerIDTemplate(`{{ .OwnerID }}-{{ index .Labels "team" }}-{{ .Kind }}-{{ .Name }}`)
+	require.NoError(t, err)
+	assert.Equal(t, "cluster-payments-service-web", tmpl.OwnerID("cluster", labels))
+
+	// the owner IDs which can't be recorded fall back to the owner ID of the instance
+	tmpl, err = NewOwnerIDTemplate(`{{ .OwnerID }},{{ .Namespace }}`)
+	require.NoError(t, err)
+	assert.Equal(t, "cluster", tmpl.OwnerID("cluster", labels))
+	tmpl, err = NewOwnerIDTemplate(`{{ .Unknown }}`)
+	require.NoError(t, err)
+	assert.Equal(t, "cluster", tmpl.OwnerID("cluster", labels))
+
+	_, err = NewOwnerIDTemplate("{{ .OwnerID")
+	require.Error(t, err)
+}
@@ -12,6 +12,11 @@ func (in *Endpoint) DeepCopyInto(out *Endpoint) {
 		*out = make(Targets, len(*in))
 		copy(*out, *in)
 	}
+	if in.MXPriority != nil {
+		in, out := &in.MXPriority, &out.MXPriority
+		*out = new(int32)
+		**out = **in
+	}
 	if in.Labels != nil {
 		in, out := &in.Labels, &out.Labels
 		*out = make(Labels, len(*in))
@@ -0,0 +1,159 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externaldns
+
+import (
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/alecthomas/kingpin/v2"
+	"github.com/goccy/go-yaml"
+)
+
+const (
+	// configFileFlag is the name of the flag pointing to the configuration file.
+	configFileFlag = "config"
+	// configFileEnvar is the environment variable kingpin maps to --config.
+	configFileEnvar = "EXTERNAL_DNS_CONFIG"
+)
+
+// withConfigFile appends the flags defined in the configuration file referenced
+// by --config to args. Flags given on the command line take precedence over the
+// ones defined in the file.
+func withConfigFile(args []string) ([]string, error) {
+	pc, err := App(NewConfig()).ParseContext(args)
+	if err != nil {
+		return nil, err
+	}
+	path := os.Getenv(configFileEnvar)
+	explicit := map[string]bool{}
+	for _, element := range pc.Elements {
+		flag, ok := element.Clause.(*kingpin.FlagClause)
+		if !ok {
+			continue
+		}
+		name := flag.Model().Name
+		explicit[name] = true
+		if name == configFileFlag && element.Value != nil {
+			path = *element.Value
+		}
+	}
+	if path == "" {
+		return args, nil
+	}
+
+	flags, err := ReadConfigFile(path)
+	if err != nil {
+		return nil, err
+	}
+	result := append([]string{}, args...)
+	for _, name := range sortedKeys(flags) {
+		if explicit[name] {
+			continue
+		}
+		result = append(result, flags[name]...)
+	}
+	return result, nil
+}
+
+// ReadConfigFile reads a YAML file and returns the equivalent command line
+// arguments keyed by flag name. The file is either a versioned Configuration or
+// a plain mapping of flag names to values.
+func ReadConfigFile(path string) (map[string][]string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading config file: %w", err)
+	}
+	var values map[string]any
+	if err := yaml.Unmarshal(content, &values); err != nil {
+		return nil, fmt.Errorf("parsing config file %s: %w", path, err)
+	}
+	if _, ok := values["apiVersion"]; ok {
+		flags, err := parseConfiguration(content)
+		if err != nil {
+			return nil, fmt.Errorf("config file %s: %w", path, err)
+		}
+		return flags, nil
+	}
+
+	flags := make(map[string][]string, len(values))
+	for name, value := range values {
+		if name == configFileFlag {
+			return nil, fmt.Errorf("config file %s: flag %q cannot be set in a config file", path, name)
+		}
+		args, err := flagArgs(name, value)
+		if err != nil {
+			return nil, fmt.Errorf("config file %s: %w", path, err)
+		}
+		flags[name] = args
+	}
+	return flags, nil
+}
+
+// flagArgs converts a config file entry to command line arguments.
+func flagArgs(name string, value any) ([]string, error) {
+	switch v := value.(type) {
+	case nil:
+		return nil, fmt.Errorf("flag %q has no value", name)
+	case bool:
+		if v {
+			return []string{"--" + name}, nil
+		}
+		return []string{"--no-" + name}, nil
+	case []any:
+		args := make([]string, 0, len(v))
+		for _, item := range v {
+			if !isScalar(item) {
+				return nil, fmt.Errorf("flag %q must be a list of scalar values", name)
+			}
+			args = append(args, fmt.Sprintf("--%s=%v", name, item))
+		}
+		return args, nil
+	case map[string]any:
+		args := make([]string, 0, len(v))
+		for _, key := range sortedKeys(v) {
+			if !isScalar(v[key]) {
+				return nil, fmt.Errorf("flag %q must be a map of scalar values", name)
+			}
+			args = append(args, fmt.Sprintf("--%s=%s=%v", name, key, v[key]))
+		}
+		return args, nil
+	default:
+		if !isScalar(v) {
+			return nil, fmt.Errorf("flag %q has an unsupported value", name)
+		}
+		return []string{fmt.Sprintf("--%s=%v", name, v)}, nil
+	}
+}
+
+func isScalar(value any) bool {
+	switch value.(type) {
+	case string, bool, int, int64, uint64, float64:
+		return true
+	}
+	return false
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
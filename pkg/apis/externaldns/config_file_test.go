@@ -0,0 +1,118 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externaldns
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeConfigFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestParseFlagsWithConfigFile(t *testing.T) {
+	path := writeConfigFile(t, `
+source:
+  - service
+  - ingress
+provider: google
+domain-filter: [example.org, company.com]
+interval: 2m
+dry-run: true
+exclude-unschedulable: false
+google-batch-change-size: 42
+aws-sd-create-tag:
+  team: dns
+`)
+
+	cfg := NewConfig()
+	require.NoError(t, cfg.ParseFlags([]string{"--config=" + path, "--interval=5m", "--domain-filter=cli.org"}))
+
+	assert.Equal(t, path, cfg.ConfigFile)
+	assert.Equal(t, []string{"service", "ingress"}, cfg.Sources)
+	assert.Equal(t, "google", cfg.Provider)
+	assert.Equal(t, []string{"cli.org"}, cfg.DomainFilter, "command line takes precedence")
+	assert.Equal(t, 5*time.Minute, cfg.Interval, "command line takes precedence")
+	assert.True(t, cfg.DryRun)
+	assert.False(t, cfg.ExcludeUnschedulable)
+	assert.Equal(t, 42, cfg.GoogleBatchChangeSize)
+	assert.Equal(t, map[string]string{"team": "dns"}, cfg.AWSSDCreateTag)
+}
+
+func TestParseFlagsWithConfigFileFromEnv(t *testing.T) {
+	path := writeConfigFile(t, "interval: 3m\n")
+	t.Setenv("EXTERNAL_DNS_CONFIG", path)
+
+	cfg := NewConfig()
+	require.NoError(t, cfg.ParseFlags([]string{"--source=service", "--provider=google"}))
+	assert.Equal(t, path, cfg.ConfigFile)
+	assert.Equal(t, 3*time.Minute, cfg.Interval)
+}
+
+func TestParseFlagsWithInvalidConfigFile(t *testing.T) {
+	for _, tt := range []struct {
+		title   string
+		content string
+		err     string
+	}{
+		{
+			title:   "unknown flag",
+			content: "unknown-flag: true\n",
+			err:     "unknown long flag '--unknown-flag'",
+		},
+		{
+			title:   "nested config",
+			content: "config: other.yaml\n",
+			err:     `flag "config" cannot be set in a config file`,
+		},
+		{
+			title:   "empty value",
+			content: "interval:\n",
+			err:     `flag "interval" has no value`,
+		},
+		{
+			title:   "nested list",
+			content: "domain-filter: [[a]]\n",
+			err:     `flag "domain-filter" must be a list of scalar values`,
+		},
+		{
+			title:   "invalid yaml",
+			content: "interval: [\n",
+			err:     "parsing config file",
+		},
+	} {
+		t.Run(tt.title, func(t *testing.T) {
+			path := writeConfigFile(t, tt.content)
+			cfg := NewConfig()
+			err := cfg.ParseFlags([]string{"--source=service", "--provider=google", "--config", path})
+			assert.ErrorContains(t, err, tt.err)
+		})
+	}
+
+	cfg := NewConfig()
+	err := cfg.ParseFlags([]string{"--source=service", "--provider=google", "--config=/does/not/exist.yaml"})
+	assert.ErrorContains(t, err, "reading config file")
+}
@@ -0,0 +1,201 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externaldns
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/goccy/go-yaml"
+)
+
+const (
+	// ConfigurationAPIVersion is the API version of the structured configuration file.
+	ConfigurationAPIVersion = "externaldns.k8s.io/v1alpha1"
+	// ConfigurationKind is the kind of the structured configuration file.
+	ConfigurationKind = "Configuration"
+)
+
+// Configuration is the structured, versioned form of the configuration file.
+// Settings are keyed by flag name and grouped by the source or provider they
+// apply to.
+type Configuration struct {
+	APIVersion string                   `yaml:"apiVersion"`
+	Kind       string                   `yaml:"kind"`
+	Sources    []ComponentConfiguration `yaml:"sources"`
+	Provider   *ComponentConfiguration  `yaml:"provider"`
+	Registry   *ComponentConfiguration  `yaml:"registry"`
+	Settings   map[string]any           `yaml:"settings"`
+}
+
+// ComponentConfiguration selects a source, provider or registry and holds the
+// settings specific to it.
+type ComponentConfiguration struct {
+	Name     string         `yaml:"name"`
+	Settings map[string]any `yaml:"settings"`
+}
+
+// componentSettingPrefixes lists the prefixes of the settings of the sources,
+// providers and registries whose settings aren't all prefixed by their name.
+var componentSettingPrefixes = map[string][]string{
+	"service":           {"publish-internal-services", "publish-host-ip", "resolve-service-load-balancer-hostname", "always-publish-not-ready-addresses"},
+	"ingress":           {"ignore-ingress-"},
+	"node":              {"exclude-unschedulable", "expose-internal-ipv6"},
+	"pod":               {"ignore-non-host-network-pods"},
+	"gateway-httproute": {"gateway-"},
+	"gateway-grpcroute": {"gateway-"},
+	"gateway-tlsroute":  {"gateway-"},
+	"gateway-tcproute":  {"gateway-"},
+	"gateway-udproute":  {"gateway-"},
+	"cloudfoundry":      {"cf-"},
+	"contour-httpproxy": {"contour-"},
+	"gloo-proxy":        {"gloo-"},
+	"crd":               {"crd-source-"},
+	"generic-crd":       {"generic-crd-source"},
+	"connector":         {"connector-source-"},
+	"openshift-route":   {"openshift-router-"},
+	"traefik-proxy":     {"traefik-"},
+	"alibabacloud":      {"alibaba-cloud-"},
+	"azure-dns":         {"azure-"},
+	"azure-private-dns": {"azure-"},
+	"coredns":           {"tls-"},
+	"skydns":            {"coredns-", "tls-"},
+	"pdns":              {"tls-", "zone-serial-"},
+	"rfc2136":           {"zone-serial-"},
+	"webhook":           {"webhook-provider-"},
+	"txt":               {"registry-migration-"},
+	"dynamodb":          {"registry-migration-"},
+	"txt-to-dynamodb":   {"txt-", "dynamodb-", "registry-migration-"},
+}
+
+// isComponentSetting returns whether the setting is specific to the component,
+// i.e. prefixed by its name or by one of its other prefixes.
+func isComponentSetting(component, name string) bool {
+	if strings.HasPrefix(name, component+"-") {
+		return true
+	}
+	for _, prefix := range componentSettingPrefixes[component] {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseConfiguration strictly decodes a structured configuration file and
+// returns the equivalent command line arguments keyed by flag name.
+func parseConfiguration(content []byte) (map[string][]string, error) {
+	var c Configuration
+	if err := yaml.UnmarshalWithOptions(content, &c, yaml.Strict()); err != nil {
+		return nil, err
+	}
+	if c.APIVersion != ConfigurationAPIVersion {
+		return nil, fmt.Errorf("unsupported apiVersion %q, expected %q", c.APIVersion, ConfigurationAPIVersion)
+	}
+	if c.Kind != ConfigurationKind {
+		return nil, fmt.Errorf("unsupported kind %q, expected %q", c.Kind, ConfigurationKind)
+	}
+
+	known := knownFlags()
+	values := map[string]any{}
+	set := func(section, name string, value any) error {
+		if !known[name] {
+			return fmt.Errorf("%s: unknown setting %q", section, name)
+		}
+		if name == configFileFlag {
+			return fmt.Errorf("%s: setting %q cannot be set in a config file", section, name)
+		}
+		if _, ok := values[name]; ok {
+			return fmt.Errorf("%s: setting %q is set more than once", section, name)
+		}
+		values[name] = value
+		return nil
+	}
+	// component is empty for the settings which apply to all the components
+	setAll := func(section, component string, settings map[string]any) error {
+		for _, name := range sortedKeys(settings) {
+			if component != "" && known[name] && !isComponentSetting(component, name) {
+				return fmt.Errorf("%s: setting %q doesn't belong to %s, move it to settings", section, name, component)
+			}
+			if err := set(section, name, settings[name]); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if len(c.Sources) > 0 {
+		names := make([]any, 0, len(c.Sources))
+		for i, s := range c.Sources {
+			if s.Name == "" {
+				return nil, fmt.Errorf("sources[%d]: name is required", i)
+			}
+			names = append(names, s.Name)
+			if err := setAll(fmt.Sprintf("sources[%d] (%s)", i, s.Name), s.Name, s.Settings); err != nil {
+				return nil, err
+			}
+		}
+		if err := set("sources", "source", names); err != nil {
+			return nil, err
+		}
+	}
+	for _, component := range []struct {
+		flag string
+		c    *ComponentConfiguration
+	}{
+		{"provider", c.Provider},
+		{"registry", c.Registry},
+	} {
+		if component.c == nil {
+			continue
+		}
+		if component.c.Name == "" {
+			return nil, fmt.Errorf("%s: name is required", component.flag)
+		}
+		if err := set(component.flag, component.flag, component.c.Name); err != nil {
+			return nil, err
+		}
+		if err := setAll(component.flag, component.c.Name, component.c.Settings); err != nil {
+			return nil, err
+		}
+	}
+	if err := setAll("settings", "", c.Settings); err != nil {
+		return nil, err
+	}
+
+	flags := make(map[string][]string, len(values))
+	var errs []error
+	for _, name := range sortedKeys(values) {
+		args, err := flagArgs(name, values[name])
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		flags[name] = args
+	}
+	return flags, errors.Join(errs...)
+}
+
+// knownFlags returns the names of all the flags.
+func knownFlags() map[string]bool {
+	known := map[string]bool{}
+	for _, flag := range App(NewConfig()).Model().Flags {
+		known[flag.Name] = true
+	}
+	return known
+}
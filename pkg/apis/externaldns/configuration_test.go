@@ -0,0 +1,154 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externaldns
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFlagsWithConfiguration(t *testing.T) {
+	path := writeConfigFile(t, `
+apiVersion: externaldns.k8s.io/v1alpha1
+kind: Configuration
+sources:
+  - name: service
+    settings:
+      service-type-filter: [LoadBalancer]
+      publish-internal-services: true
+  - name: ingress
+    settings:
+      ingress-class: [nginx]
+provider:
+  name: aws
+  settings:
+    aws-zone-type: public
+    aws-zone-tags: [env=prod]
+registry:
+  name: txt
+  settings:
+    txt-owner-id: cluster-a
+settings:
+  interval: 2m
+  domain-filter: [example.org]
+`)
+
+	cfg := NewConfig()
+	require.NoError(t, cfg.ParseFlags([]string{"--config=" + path}))
+
+	assert.Equal(t, []string{"service", "ingress"}, cfg.Sources)
+	assert.Equal(t, []string{"LoadBalancer"}, cfg.ServiceTypeFilter)
+	assert.True(t, cfg.PublishInternal)
+	assert.Equal(t, []string{"nginx"}, cfg.IngressClassNames)
+	assert.Equal(t, "aws", cfg.Provider)
+	assert.Equal(t, "public", cfg.AWSZoneType)
+	assert.Equal(t, []string{"env=prod"}, cfg.AWSZoneTagFilter)
+	assert.Equal(t, "txt", cfg.Registry)
+	assert.Equal(t, "cluster-a", cfg.TXTOwnerID)
+	assert.Equal(t, 2*time.Minute, cfg.Interval)
+	assert.Equal(t, []string{"example.org"}, cfg.DomainFilter)
+}
+
+func TestParseFlagsWithInvalidConfiguration(t *testing.T) {
+	const header = "apiVersion: externaldns.k8s.io/v1alpha1\nkind: Configuration\n"
+	for _, tt := range []struct {
+		title   string
+		content string
+		err     string
+	}{
+		{
+			title:   "unsupported api version",
+			content: "apiVersion: externaldns.k8s.io/v2\nkind: Configuration\n",
+			err:     `unsupported apiVersion "externaldns.k8s.io/v2"`,
+		},
+		{
+			title:   "unsupported kind",
+			content: "apiVersion: externaldns.k8s.io/v1alpha1\nkind: Other\n",
+			err:     `unsupported kind "Other"`,
+		},
+		{
+			title:   "unknown field",
+			content: header + "sourcez: []\n",
+			err:     `unknown field "sourcez"`,
+		},
+		{
+			title:   "unknown component field",
+			content: header + "provider:\n  name: aws\n  zone: public\n",
+			err:     `unknown field "zone"`,
+		},
+		{
+			title:   "duplicate key",
+			content: header + "settings:\n  interval: 1m\n  interval: 2m\n",
+			err:     `mapping key "interval" already defined`,
+		},
+		{
+			title:   "unknown setting",
+			content: header + "provider:\n  name: aws\n  settings:\n    aws-zone: public\n",
+			err:     `provider: unknown setting "aws-zone"`,
+		},
+		{
+			title:   "missing source name",
+			content: header + "sources:\n  - settings:\n      ingress-class: [nginx]\n",
+			err:     "sources[0]: name is required",
+		},
+		{
+			title:   "missing provider name",
+			content: header + "provider:\n  settings:\n    aws-zone-type: public\n",
+			err:     "provider: name is required",
+		},
+		{
+			title:   "setting defined twice",
+			content: header + "sources:\n  - name: service\n    settings:\n      service-type-filter: [A]\nsettings:\n  service-type-filter: [B]\n",
+			err:     `settings: setting "service-type-filter" is set more than once`,
+		},
+		{
+			title:   "setting of another component",
+			content: header + "sources:\n  - name: service\n    settings:\n      ingress-class: [nginx]\n",
+			err:     `sources[0] (service): setting "ingress-class" doesn't belong to service, move it to settings`,
+		},
+		{
+			title:   "global setting in a component",
+			content: header + "provider:\n  name: aws\n  settings:\n    domain-filter: [example.com]\n",
+			err:     `provider: setting "domain-filter" doesn't belong to aws, move it to settings`,
+		},
+		{
+			title:   "component set in settings",
+			content: header + "provider:\n  name: aws\nsettings:\n  provider: google\n",
+			err:     `settings: setting "provider" is set more than once`,
+		},
+		{
+			title:   "nested config",
+			content: header + "settings:\n  config: other.yaml\n",
+			err:     `settings: setting "config" cannot be set in a config file`,
+		},
+		{
+			title:   "invalid value",
+			content: header + "settings:\n  domain-filter: [[a]]\n",
+			err:     `flag "domain-filter" must be a list of scalar values`,
+		},
+	} {
+		t.Run(tt.title, func(t *testing.T) {
+			path := writeConfigFile(t, tt.content)
+			cfg := NewConfig()
+			err := cfg.ParseFlags([]string{"--source=service", "--provider=google", "--config", path})
+			assert.ErrorContains(t, err, tt.err)
+		})
+	}
+}
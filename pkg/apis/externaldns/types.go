@@ -36,6 +36,18 @@ const (
 	passwordMask = "******"
 )
 
+// The commands of ExternalDNS, given as first argument.
+const (
+	// CommandRun synchronizes the records, the default command.
+	CommandRun = "run"
+	// CommandSimulate prints the records the sources would create for the objects of a manifest.
+	CommandSimulate = "simulate"
+	// CommandPreflight checks the access to the cluster, the provider and the registry.
+	CommandPreflight = "preflight"
+	// CommandConvertZoneFile converts a zone file to DNSEndpoint manifests.
+	CommandConvertZoneFile = "convert zonefile"
+)
+
 // Config is a project-wide configuration
 type Config struct {
 	APIServerURL                                  string
@@ -46,8 +58,12 @@ type Config struct {
 	SkipperRouteGroupVersion                      string
 	Sources                                       []string
 	Namespace                                     string
+	NamespaceRegex                                *regexp.Regexp
+	ExcludeNamespaces                             []string
 	AnnotationFilter                              string
+	AnnotationPrefixAliases                       []string
 	LabelFilter                                   string
+	PropagateLabels                               []string
 	IngressClassNames                             []string
 	FQDNTemplate                                  string
 	CombineFQDNAndAnnotation                      bool
@@ -56,6 +72,7 @@ type Config struct {
 	IgnoreIngressTLSSpec                          bool
 	IgnoreIngressRulesSpec                        bool
 	ListenEndpointEvents                          bool
+	ServiceDefaultHostnames                       bool
 	ExposeInternalIPV6                            bool
 	GatewayName                                   string
 	GatewayNamespace                              string
@@ -68,6 +85,18 @@ type Config struct {
 	ConnectorSourceServer                         string
 	Provider                                      string
 	ProviderCacheTime                             time.Duration
+	ProviderProxyURL                              string
+	ProviderCABundle                              string
+	ProviderConcurrency                           int
+	ProviderReadConcurrency                       int
+	ProviderWriteConcurrency                      int
+	ProviderFailoverCooldown                      time.Duration
+	MissingZoneCacheTTL                           time.Duration
+	ProviderCircuitBreakerFailures                int
+	ProviderCircuitBreakerOpenDuration            time.Duration
+	FaultInjection                                bool
+	FaultInjectionProfile                         string
+	FaultInjectionSeed                            int64
 	GoogleProject                                 string
 	GoogleBatchChangeSize                         int
 	GoogleBatchChangeInterval                     time.Duration
@@ -78,6 +107,7 @@ type Config struct {
 	RegexDomainExclusion                          *regexp.Regexp
 	ZoneNameFilter                                []string
 	ZoneIDFilter                                  []string
+	ZoneSelection                                 string
 	TargetNetFilter                               []string
 	ExcludeTargetNets                             []string
 	AlibabaCloudConfigFile                        string
@@ -100,6 +130,9 @@ type Config struct {
 	AWSZoneMatchParent                            bool
 	AWSDynamoDBRegion                             string
 	AWSDynamoDBTable                              string
+	AWSDynamoDBCreateTable                        bool
+	RegistryMigrationCutover                      bool
+	AWSDynamoDBTableTags                          map[string]string
 	AzureConfigFile                               string
 	AzureResourceGroup                            string
 	AzureSubscriptionID                           string
@@ -118,14 +151,18 @@ type Config struct {
 	CloudflareRecordComment                       string
 	CoreDNSPrefix                                 string
 	AkamaiServiceConsumerDomain                   string
-	AkamaiClientToken                             string
-	AkamaiClientSecret                            string
-	AkamaiAccessToken                             string
+	AkamaiClientToken                             string `secure:"yes"`
+	AkamaiClientSecret                            string `secure:"yes"`
+	AkamaiAccessToken                             string `secure:"yes"`
 	AkamaiEdgercPath                              string
 	AkamaiEdgercSection                           string
 	OCIConfigFile                                 string
 	OCICompartmentOCID                            string
 	OCIAuthInstancePrincipal                      bool
+	OCIAuthWorkloadIdentity                       bool
+	OCIRegion                                     string
+	OCIZoneCompartmentOCIDs                       []string
+	OCIViewIDs                                    []string
 	OCIZoneScope                                  string
 	OCIZoneCacheDuration                          time.Duration
 	InMemoryZones                                 []string
@@ -133,6 +170,7 @@ type Config struct {
 	OVHApiRateLimit                               int
 	OVHEnableCNAMERelative                        bool
 	PDNSServer                                    string
+	PDNSFallbackServers                           []string
 	PDNSServerID                                  string
 	PDNSAPIKey                                    string `secure:"yes"`
 	PDNSSkipTLSVerify                             bool
@@ -140,21 +178,68 @@ type Config struct {
 	TLSClientCert                                 string
 	TLSClientCertKey                              string
 	Policy                                        string
+	WildcardPolicy                                string
 	Registry                                      string
 	TXTOwnerID                                    string
+	TXTOwnerIDTemplate                            string
 	TXTPrefix                                     string
 	TXTSuffix                                     string
 	TXTEncryptEnabled                             bool
 	TXTEncryptAESKey                              string `secure:"yes"`
+	TXTEncryptKMS                                 string
+	TXTEncryptKMSKey                              string
 	TXTNewFormatOnly                              bool
+	TXTNameTemplate                               string
+	TXTNameTemplateMigration                      bool
+	TXTJanitorInterval                            time.Duration
+	TXTJanitorPolicy                              string
+	TXTJanitorOwnerIDs                            []string
 	Interval                                      time.Duration
 	MinEventSyncInterval                          time.Duration
+	SyncTimeout                                   time.Duration
 	Once                                          bool
 	DryRun                                        bool
 	UpdateEvents                                  bool
+	StreamRecords                                 bool
+	RepeatedChangesBackoff                        time.Duration
+	MaxDeletionsPerSync                           int
+	MaxChangePercentage                           int
+	MaxRecordsPerZone                             int
+	ProviderWriteBudget                           int
+	DampingThreshold                              int
+	DampingWindow                                 time.Duration
+	DampingCooldown                               time.Duration
+	DampingAllowClear                             bool
+	ChangeWindows                                 []string
+	ChangeWindowTimezone                          string
+	ChangeWindowCreates                           bool
+	CanaryZone                                    string
+	CanaryNameserver                              string
+	CanaryTimeout                                 time.Duration
+	PauseConfigMap                                string
+	PlanConfigMap                                 string
+	ZoneLockNamespace                             string
+	ZoneLockLeaseDuration                         time.Duration
+	BackupDir                                     string
+	Restore                                       string
+	ExportZoneFile                                string
+	ExportZones                                   []string
+	AdoptExistingRecords                          bool
+	IgnoreTTLDrift                                bool
+	ExternalOwnerMarkers                          []string
 	LogFormat                                     string
 	MetricsAddress                                string
+	MetricsSourceObjects                          bool
+	EnablePprof                                   bool
+	DNSControlExport                              bool
+	GoMemoryLimit                                 string
+	GoGCPercent                                   int
 	LogLevel                                      string
+	LogFilterDecisions                            bool
+	LogOutput                                     string
+	LogSyslogAddress                              string
+	LogSamplingInterval                           time.Duration
+	AuditLog                                      string
 	TXTCacheInterval                              time.Duration
 	TXTWildcardReplacement                        string
 	ExoscaleEndpoint                              string
@@ -164,6 +249,8 @@ type Config struct {
 	ExoscaleAPIZone                               string
 	CRDSourceAPIVersion                           string
 	CRDSourceKind                                 string
+	GenericCRDSources                             []string
+	ACMEChallengeSolverName                       string
 	ServiceTypeFilter                             []string
 	CFAPIEndpoint                                 string
 	CFUsername                                    string
@@ -187,18 +274,26 @@ type Config struct {
 	RFC2136BatchChangeSize                        int
 	RFC2136UseTLS                                 bool
 	RFC2136SkipTLSVerify                          bool
+	RFC2136SIG0KeyDir                             string
+	RFC2136SIG0KeyRollover                        time.Duration
+	ZoneSerialCheck                               bool
+	ZoneSerialSecondaries                         []string
+	ZoneSerialCheckTimeout                        time.Duration
 	NS1Endpoint                                   string
 	NS1IgnoreSSL                                  bool
 	NS1MinTTLSeconds                              int
 	TransIPAccountName                            string
 	TransIPPrivateKeyFile                         string
 	DigitalOceanAPIPageSize                       int
+	DigitalOceanProjects                          []string
+	LinodeDomainTags                              []string
 	ManagedDNSRecordTypes                         []string
 	ExcludeDNSRecordTypes                         []string
 	GoDaddyAPIKey                                 string `secure:"yes"`
 	GoDaddySecretKey                              string `secure:"yes"`
 	GoDaddyTTL                                    int64
 	GoDaddyOTE                                    bool
+	GoDaddyAPIEndpoint                            string
 	OCPRouterName                                 string
 	PiholeServer                                  string
 	PiholePassword                                string `secure:"yes"`
@@ -207,14 +302,39 @@ type Config struct {
 	PluralCluster                                 string
 	PluralProvider                                string
 	WebhookProviderURL                            string
+	WebhookProviderFallbackURLs                   []string
 	WebhookProviderReadTimeout                    time.Duration
 	WebhookProviderWriteTimeout                   time.Duration
 	WebhookServer                                 bool
 	TraefikDisableLegacy                          bool
 	TraefikDisableNew                             bool
+	TraefikService                                string
+	ContourEnvoyService                           string
 	NAT64Networks                                 []string
 	ExcludeUnschedulable                          bool
 	ForceDefaultTargets                           bool
+	SecretRefreshInterval                         time.Duration
+	SecretEnvVars                                 []string
+	ConfigFile                                    string
+	ConfigReloadInterval                          time.Duration
+	ValidateConfig                                bool
+	Command                                       string
+	SimulateManifest                              string
+	ConvertZoneFile                               string
+	ConvertToCRD                                  bool
+	ConvertOrigin                                 string
+	ConvertName                                   string
+	DNSConfigAllowedSettings                      []string
+	CertManagerRenewalTTL                         time.Duration
+	CertManagerRenewalLead                        time.Duration
+	PublicIPEndpoints                             []string
+	PublicIPRefreshInterval                       time.Duration
+	TailscaleOperatorNamespace                    string
+	TargetIPFamily                                string
+	SourceTargetIPFamilies                        map[string]string
+	SourceResyncPeriods                           map[string]string
+	InformerWatchList                             bool
+	NodeAddressPreference                         []string
 }
 
 var defaultConfig = &Config{
@@ -236,6 +356,7 @@ var defaultConfig = &Config{
 	AWSBatchChangeSizeValues:    1000,
 	AWSDynamoDBRegion:           "",
 	AWSDynamoDBTable:            "external-dns",
+	AWSDynamoDBTableTags:        map[string]string{},
 	AWSEvaluateTargetHealth:     true,
 	AWSPreferCNAME:              false,
 	AWSSDCreateTag:              map[string]string{},
@@ -260,8 +381,11 @@ var defaultConfig = &Config{
 	CloudflareRegionalServices:                    false,
 	CloudflareRegionKey:                           "earth",
 
+	CertManagerRenewalLead:       time.Hour,
 	CombineFQDNAndAnnotation:     false,
 	Compatibility:                "",
+	ConfigFile:                   "",
+	ConfigReloadInterval:         10 * time.Second,
 	ConnectorSourceServer:        "localhost:8080",
 	CoreDNSPrefix:                "/skydns/",
 	CRDSourceAPIVersion:          "externaldns.k8s.io/v1alpha1",
@@ -301,6 +425,7 @@ var defaultConfig = &Config{
 	KubeConfig:                   "",
 	LabelFilter:                  labels.Everything().String(),
 	LogFormat:                    "text",
+	LogOutput:                    "stderr",
 	LogLevel:                     logrus.InfoLevel.String(),
 	ManagedDNSRecordTypes:        []string{endpoint.RecordTypeA, endpoint.RecordTypeAAAA, endpoint.RecordTypeCNAME},
 	MetricsAddress:               ":7979",
@@ -326,10 +451,13 @@ var defaultConfig = &Config{
 	PiholeTLSInsecureSkipVerify:  false,
 	PluralCluster:                "",
 	PluralProvider:               "",
+	NamespaceRegex:               regexp.MustCompile(""),
 	PodSourceDomain:              "",
 	Policy:                       "sync",
+	WildcardPolicy:               "allow",
 	Provider:                     "",
 	ProviderCacheTime:            0,
+	PublicIPRefreshInterval:      5 * time.Minute,
 	PublishHostIP:                false,
 	PublishInternal:              false,
 	RegexDomainExclusion:         regexp.MustCompile(""),
@@ -346,6 +474,8 @@ var defaultConfig = &Config{
 	RFC2136LoadBalancingStrategy: "disabled",
 	RFC2136MinTTL:                0,
 	RFC2136Port:                  0,
+	RFC2136SIG0KeyDir:            "",
+	RFC2136SIG0KeyRollover:       0,
 	RFC2136SkipTLSVerify:         false,
 	RFC2136TAXFR:                 true,
 	RFC2136TSIGKeyName:           "",
@@ -353,9 +483,12 @@ var defaultConfig = &Config{
 	RFC2136TSIGSecretAlg:         "",
 	RFC2136UseTLS:                false,
 	RFC2136Zone:                  []string{},
+	SecretEnvVars:                []string{},
+	SecretRefreshInterval:        0,
 	ServiceTypeFilter:            []string{},
 	SkipperRouteGroupVersion:     "zalando.org/v1",
 	Sources:                      nil,
+	TailscaleOperatorNamespace:   "tailscale",
 	TargetNetFilter:              []string{},
 	TLSCA:                        "",
 	TLSClientCert:                "",
@@ -367,6 +500,12 @@ var defaultConfig = &Config{
 	TXTCacheInterval:             0,
 	TXTEncryptAESKey:             "",
 	TXTEncryptEnabled:            false,
+	TXTEncryptKMS:                "",
+	TXTEncryptKMSKey:             "",
+	TXTNameTemplate:              "",
+	TXTNameTemplateMigration:     false,
+	TXTJanitorInterval:           0,
+	TXTJanitorPolicy:             "report",
 	TXTNewFormatOnly:             false,
 	TXTOwnerID:                   "default",
 	TXTPrefix:                    "",
@@ -378,13 +517,31 @@ var defaultConfig = &Config{
 	WebhookProviderWriteTimeout:  10 * time.Second,
 	WebhookServer:                false,
 	ZoneIDFilter:                 []string{},
+	ZoneSelection:                "both",
+	ZoneLockLeaseDuration:        2 * time.Minute,
+	DampingWindow:                10 * time.Minute,
+	DampingCooldown:              30 * time.Minute,
+	ChangeWindowTimezone:         "UTC",
+	ChangeWindowCreates:          true,
+	CanaryTimeout:                time.Minute,
+	ZoneSerialCheckTimeout:       time.Minute,
 	ForceDefaultTargets:          false,
+	SourceTargetIPFamilies:       map[string]string{},
+	SourceResyncPeriods:          map[string]string{},
+	TargetIPFamily:               endpoint.IPFamilyDual,
+
+	ProviderCircuitBreakerOpenDuration: 5 * time.Minute,
+	ProviderFailoverCooldown:           30 * time.Second,
+	FaultInjectionProfile:              "light",
 }
 
 // NewConfig returns new Config object
 func NewConfig() *Config {
 	return &Config{
-		AWSSDCreateTag: map[string]string{},
+		AWSSDCreateTag:         map[string]string{},
+		AWSDynamoDBTableTags:   map[string]string{},
+		SourceTargetIPFamilies: map[string]string{},
+		SourceResyncPeriods:    map[string]string{},
 	}
 }
 
@@ -418,14 +575,20 @@ func allLogLevelsAsStrings() []string {
 	return levels
 }
 
-// ParseFlags adds and parses flags from command line
+// ParseFlags adds and parses flags from command line and from the configuration file given with --config
 func (cfg *Config) ParseFlags(args []string) error {
+	args, err := withConfigFile(args)
+	if err != nil {
+		return err
+	}
+
 	app := App(cfg)
 
-	_, err := app.Parse(args)
+	command, err := app.Parse(args)
 	if err != nil {
 		return err
 	}
+	cfg.Command = command
 
 	return nil
 }
@@ -435,12 +598,30 @@ func App(cfg *Config) *kingpin.Application {
 	app.Version(Version)
 	app.DefaultEnvars()
 
+	// Flags related to the configuration file
+	app.Flag(configFileFlag, "Path to a YAML file mapping flag names to values, e.g. `interval: 2m`, or a versioned Configuration (apiVersion: externaldns.k8s.io/v1alpha1); flags given on the command line take precedence (optional)").Default(defaultConfig.ConfigFile).StringVar(&cfg.ConfigFile)
+	app.Flag("config-reload-interval", "The interval between two consecutive checks of the configuration file for changes; sources, filters and intervals are rebuilt without restart when it changes, while the provider type and credentials are immutable (default: 10s, 0s to disable)").Default(defaultConfig.ConfigReloadInterval.String()).DurationVar(&cfg.ConfigReloadInterval)
+	app.Flag("validate-config", "When enabled, validates the flags and the configuration file, then exits (default: disabled)").BoolVar(&cfg.ValidateConfig)
+
+	// Commands, the flags of the application being accepted by all of them
+	app.Command(CommandRun, "Synchronizes the records of the sources with the DNS provider (default)").Default()
+	simulate := app.Command(CommandSimulate, "Prints the records the configured sources and filters would create for the objects of a manifest, without connecting to the cluster nor to the provider; requires a binary built with the simulate tag")
+	simulate.Flag("filename", "Path to a manifest of Kubernetes objects, or - for the standard input").Short('f').Required().NoEnvar().StringVar(&cfg.SimulateManifest)
+	app.Command(CommandPreflight, "Checks the permissions of the configured sources on the cluster, the credentials of the provider and the zones matching the domain filter, and the access to the registry, prints a pass/fail report, then exits with a failure if any check failed")
+	convert := app.Command("convert", "Converts DNS records from other formats; the sources and the provider are not required")
+	zonefile := convert.Command("zonefile", "Converts an RFC 1035 zone file, e.g. exported from BIND, to DNSEndpoint manifests for the crd source, in the namespace of --namespace")
+	zonefile.Flag("to-crd", "Write DNSEndpoint manifests, the only output format").Required().NoEnvar().BoolVar(&cfg.ConvertToCRD)
+	zonefile.Flag("origin", "The origin of the relative names of the zone file without an $ORIGIN directive (optional)").NoEnvar().StringVar(&cfg.ConvertOrigin)
+	zonefile.Flag("name", "The name of the DNSEndpoints, followed by their index (default: the --origin, e.g. example-org, or zonefile)").NoEnvar().StringVar(&cfg.ConvertName)
+	zonefile.Arg("file", "The zone file, or - for the standard input").Default("-").StringVar(&cfg.ConvertZoneFile)
+
 	// Flags related to Kubernetes
 	app.Flag("server", "The Kubernetes API server to connect to (default: auto-detect)").Default(defaultConfig.APIServerURL).StringVar(&cfg.APIServerURL)
 	app.Flag("kubeconfig", "Retrieve target cluster configuration from a Kubernetes configuration file (default: auto-detect)").Default(defaultConfig.KubeConfig).StringVar(&cfg.KubeConfig)
 	app.Flag("request-timeout", "Request timeout when calling Kubernetes APIs. 0s means no timeout").Default(defaultConfig.RequestTimeout.String()).DurationVar(&cfg.RequestTimeout)
 	app.Flag("resolve-service-load-balancer-hostname", "Resolve the hostname of LoadBalancer-type Service object to IP addresses in order to create DNS A/AAAA records instead of CNAMEs").BoolVar(&cfg.ResolveServiceLoadBalancerHostname)
 	app.Flag("listen-endpoint-events", "Trigger a reconcile on changes to EndpointSlices, for Service source (default: false)").BoolVar(&cfg.ListenEndpointEvents)
+	app.Flag("service-default-hostnames", "Generate the records of the LoadBalancer Services without hostname annotation from --fqdn-template, unless their namespace has the external-dns.alpha.kubernetes.io/default-hostnames=false annotation; the template then doesn't apply to the other Services (default: disabled)").BoolVar(&cfg.ServiceDefaultHostnames)
 
 	// Flags related to cloud foundry
 	app.Flag("cf-api-endpoint", "The fully-qualified domain name of the cloud foundry instance you are targeting").Default(defaultConfig.CFAPIEndpoint).StringVar(&cfg.CFAPIEndpoint)
@@ -456,16 +637,20 @@ func App(cfg *Config) *kingpin.Application {
 	// Flags related to processing source
 	app.Flag("always-publish-not-ready-addresses", "Always publish also not ready addresses for headless services (optional)").BoolVar(&cfg.AlwaysPublishNotReadyAddresses)
 	app.Flag("annotation-filter", "Filter resources queried for endpoints by annotation, using label selector semantics").Default(defaultConfig.AnnotationFilter).StringVar(&cfg.AnnotationFilter)
+	app.Flag("annotation-prefix-alias", "Read the annotations of this prefix as aliases of the external-dns.alpha.kubernetes.io/ ones, e.g. dns.mycorp.io/ to read dns.mycorp.io/hostname as external-dns.alpha.kubernetes.io/hostname, which takes precedence; specify multiple times for multiple prefixes (optional)").StringsVar(&cfg.AnnotationPrefixAliases)
 	app.Flag("combine-fqdn-annotation", "Combine FQDN template and Annotations instead of overwriting (default: false)").BoolVar(&cfg.CombineFQDNAndAnnotation)
 	app.Flag("compatibility", "Process annotation semantics from legacy implementations (optional, options: mate, molecule, kops-dns-controller)").Default(defaultConfig.Compatibility).EnumVar(&cfg.Compatibility, "", "mate", "molecule", "kops-dns-controller")
 	app.Flag("connector-source-server", "The server to connect for connector source, valid only when using connector source").Default(defaultConfig.ConnectorSourceServer).StringVar(&cfg.ConnectorSourceServer)
 	app.Flag("crd-source-apiversion", "API version of the CRD for crd source, e.g. `externaldns.k8s.io/v1alpha1`, valid only when using crd source").Default(defaultConfig.CRDSourceAPIVersion).StringVar(&cfg.CRDSourceAPIVersion)
 	app.Flag("crd-source-kind", "Kind of the CRD for the crd source in API group and version specified by crd-source-apiversion").Default(defaultConfig.CRDSourceKind).StringVar(&cfg.CRDSourceKind)
+	app.Flag("generic-crd-source", "A custom resource for the generic-crd source, as <group>/<version>/<kind>,<hostname JSONPath>[,<target JSONPath>]; specify multiple times for multiple custom resources").StringsVar(&cfg.GenericCRDSources)
+	app.Flag("acme-challenge-solver-name", "Only publish the ACME challenges of the cert-manager webhook solver with this name with the acme-challenge source (default: all the DNS-01 challenges)").StringVar(&cfg.ACMEChallengeSolverName)
 	app.Flag("default-targets", "Set globally default host/IP that will apply as a target instead of source addresses. Specify multiple times for multiple targets (optional)").StringsVar(&cfg.DefaultTargets)
 	app.Flag("force-default-targets", "Force the application of --default-targets, overriding any targets provided by the source (DEPRECATED: This reverts to (improved) legacy behavior which allows empty CRD targets for migration to new state)").Default(strconv.FormatBool(defaultConfig.ForceDefaultTargets)).BoolVar(&cfg.ForceDefaultTargets)
 	app.Flag("exclude-record-types", "Record types to exclude from management; specify multiple times to exclude many; (optional)").Default().StringsVar(&cfg.ExcludeDNSRecordTypes)
 	app.Flag("exclude-target-net", "Exclude target nets (optional)").StringsVar(&cfg.ExcludeTargetNets)
 	app.Flag("exclude-unschedulable", "Exclude nodes that are considered unschedulable (default: true)").Default(strconv.FormatBool(defaultConfig.ExcludeUnschedulable)).BoolVar(&cfg.ExcludeUnschedulable)
+	app.Flag("node-address-preference", "When using the node source, the node address types to publish in order of preference; for each IP family, the addresses of the first type the node has are used. Replaces the default ExternalIP then InternalIP choice and --expose-internal-ipv6; specify multiple times for multiple types (optional, options: ExternalIP, InternalIP, ProviderID)").EnumsVar(&cfg.NodeAddressPreference, "ExternalIP", "InternalIP", "ProviderID")
 	app.Flag("expose-internal-ipv6", "When using the node source, expose internal IPv6 addresses (optional). Default is true.").BoolVar(&cfg.ExposeInternalIPV6)
 	app.Flag("fqdn-template", "A templated string that's used to generate DNS names from sources that don't define a hostname themselves, or to add a hostname suffix when paired with the fake source (optional). Accepts comma separated list for multiple global FQDN.").Default(defaultConfig.FQDNTemplate).StringVar(&cfg.FQDNTemplate)
 	app.Flag("gateway-label-filter", "Filter Gateways of Route endpoints via label selector (default: all gateways)").StringVar(&cfg.GatewayLabelFilter)
@@ -476,31 +661,59 @@ func App(cfg *Config) *kingpin.Application {
 	app.Flag("ignore-ingress-tls-spec", "Ignore the spec.tls section in Ingress resources (default: false)").BoolVar(&cfg.IgnoreIngressTLSSpec)
 	app.Flag("ignore-non-host-network-pods", "Ignore pods not running on host network when using pod source (default: false)").BoolVar(&cfg.IgnoreNonHostNetworkPods)
 	app.Flag("ingress-class", "Require an Ingress to have this class name (defaults to any class; specify multiple times to allow more than one class)").StringsVar(&cfg.IngressClassNames)
-	app.Flag("label-filter", "Filter resources queried for endpoints by label selector; currently supported by source types crd, gateway-httproute, gateway-grpcroute, gateway-tlsroute, gateway-tcproute, gateway-udproute, ingress, node, openshift-route, service and ambassador-host").Default(defaultConfig.LabelFilter).StringVar(&cfg.LabelFilter)
+	app.Flag("label-filter", "Filter resources queried for endpoints by label selector; applied by the Kubernetes API so the other resources are never cached; supported by source types ambassador-host, contour-httpproxy, crd, f5-transportserver, f5-virtualserver, gateway-httproute, gateway-grpcroute, gateway-tlsroute, gateway-tcproute, gateway-udproute, generic-crd, ingress, istio-gateway, istio-virtualservice, kong-tcpingress, node, openshift-route, pod, service and traefik-proxy").Default(defaultConfig.LabelFilter).StringVar(&cfg.LabelFilter)
+	app.Flag("propagate-label", "Copy this Kubernetes label of the resources to the labels of their endpoints, e.g. team or cost-center, recorded by the registry along with the owner and written as record metadata by the providers supporting it; specify multiple times for multiple labels (optional)").StringsVar(&cfg.PropagateLabels)
 	managedRecordTypesHelp := fmt.Sprintf("Record types to manage; specify multiple times to include many; (default: %s) (supported records: A, AAAA, CNAME, NS, SRV, TXT)", strings.Join(defaultConfig.ManagedDNSRecordTypes, ","))
 	app.Flag("managed-record-types", managedRecordTypesHelp).Default(defaultConfig.ManagedDNSRecordTypes...).StringsVar(&cfg.ManagedDNSRecordTypes)
 	app.Flag("namespace", "Limit resources queried for endpoints to a specific namespace (default: all namespaces)").Default(defaultConfig.Namespace).StringVar(&cfg.Namespace)
+	app.Flag("namespace-regex", "Limit resources queried for endpoints to the namespaces matching this regex, e.g. ^team-; the endpoints of cluster-scoped resources, such as nodes, are not filtered (default: all namespaces)").Default(defaultConfig.NamespaceRegex.String()).RegexpVar(&cfg.NamespaceRegex)
+	app.Flag("exclude-namespaces", "Exclude the resources of these namespaces from the endpoints, e.g. kube-system; specify multiple times for multiple namespaces (optional)").StringsVar(&cfg.ExcludeNamespaces)
+	app.Flag("dns-config-allowed-setting", "Apply the DNSConfig of a namespace to the endpoints generated from its resources, allowing it to set this setting; specify multiple times for multiple settings (optional, default: DNSConfig disabled, options: ttl, targets, fqdn-template-suffix, proxied)").EnumsVar(&cfg.DNSConfigAllowedSettings, "ttl", "targets", "fqdn-template-suffix", "proxied")
+	app.Flag("cert-manager-renewal-ttl", "Shorten the TTL of the records to this value while cert-manager presents an ACME challenge for them or renews their Certificate, restoring it afterwards (default: disabled)").DurationVar(&cfg.CertManagerRenewalTTL)
+	app.Flag("cert-manager-renewal-lead", "When --cert-manager-renewal-ttl is set, shorten the TTL of the records this long before the renewal time of their Certificate, so that the resolvers have dropped the longer TTL when the renewal starts").Default(defaultConfig.CertManagerRenewalLead.String()).DurationVar(&cfg.CertManagerRenewalLead)
+	app.Flag("public-ip-endpoint", "Replace the public-ip targets, e.g. set with the target annotation, with the public IP address of the cluster discovered with this endpoint, an http(s) URL answering the address of its client as plain text or a STUN server as stun:<host>[:<port>]; specify multiple times for fallback endpoints (optional)").StringsVar(&cfg.PublicIPEndpoints)
+	app.Flag("public-ip-refresh-interval", "The interval between two discoveries of the public IP address; the records are updated as soon as it changes").Default(defaultConfig.PublicIPRefreshInterval.String()).DurationVar(&cfg.PublicIPRefreshInterval)
+	app.Flag("tailscale-operator-namespace", "The namespace of the Tailscale operator, where the tailscale/<namespace>/<name> targets look up the state of the proxies exposing the services on the tailnet").Default(defaultConfig.TailscaleOperatorNamespace).StringVar(&cfg.TailscaleOperatorNamespace)
+	app.Flag("target-ip-family", "Publish the A records (ipv4), the AAAA records (ipv6) or both (dual) of the DNS names that have both; can be overridden per resource with the external-dns.alpha.kubernetes.io/ip-family annotation (default: dual, options: ipv4, ipv6, dual)").Default(defaultConfig.TargetIPFamily).EnumVar(&cfg.TargetIPFamily, endpoint.KnownIPFamilies...)
+	app.Flag("source-target-ip-family", "Override --target-ip-family for a source, e.g. service=ipv6; specify multiple times for multiple sources (optional)").StringMapVar(&cfg.SourceTargetIPFamilies)
+	app.Flag("source-resync-period", "Resync the informers of a source periodically, e.g. ingress=30m, delivering all the cached objects to the event handlers again; specify multiple times for multiple sources (default: never, the objects are only listed on start and when a watch expires)").StringMapVar(&cfg.SourceResyncPeriods)
+	app.Flag("informer-watch-list", "When enabled, the informers stream the objects with a watch instead of listing them all at once, on start and when a watch expires, to reduce the memory and CPU used on large clusters; falls back to lists if the Kubernetes API server doesn't support it (default: disabled)").BoolVar(&cfg.InformerWatchList)
 	app.Flag("nat64-networks", "Adding an A record for each AAAA record in NAT64-enabled networks; specify multiple times for multiple possible nets (optional)").StringsVar(&cfg.NAT64Networks)
 	app.Flag("openshift-router-name", "if source is openshift-route then you can pass the ingress controller name. Based on this name external-dns will select the respective router from the route status and map that routerCanonicalHostname to the route host while creating a CNAME record.").StringVar(&cfg.OCPRouterName)
 	app.Flag("pod-source-domain", "Domain to use for pods records (optional)").Default(defaultConfig.PodSourceDomain).StringVar(&cfg.PodSourceDomain)
 	app.Flag("publish-host-ip", "Allow external-dns to publish host-ip for headless services (optional)").BoolVar(&cfg.PublishHostIP)
 	app.Flag("publish-internal-services", "Allow external-dns to publish DNS records for ClusterIP services (optional)").BoolVar(&cfg.PublishInternal)
 	app.Flag("service-type-filter", "The service types to filter by. Specify multiple times for multiple filters to be applied. (optional, default: all, expected: ClusterIP, NodePort, LoadBalancer or ExternalName)").Default(defaultConfig.ServiceTypeFilter...).StringsVar(&cfg.ServiceTypeFilter)
-	app.Flag("source", "The resource types that are queried for endpoints; specify multiple times for multiple sources (required, options: service, ingress, node, pod, fake, connector, gateway-httproute, gateway-grpcroute, gateway-tlsroute, gateway-tcproute, gateway-udproute, istio-gateway, istio-virtualservice, cloudfoundry, contour-httpproxy, gloo-proxy, crd, empty, skipper-routegroup, openshift-route, ambassador-host, kong-tcpingress, f5-virtualserver, f5-transportserver, traefik-proxy)").Required().PlaceHolder("source").EnumsVar(&cfg.Sources, "service", "ingress", "node", "pod", "gateway-httproute", "gateway-grpcroute", "gateway-tlsroute", "gateway-tcproute", "gateway-udproute", "istio-gateway", "istio-virtualservice", "cloudfoundry", "contour-httpproxy", "gloo-proxy", "fake", "connector", "crd", "empty", "skipper-routegroup", "openshift-route", "ambassador-host", "kong-tcpingress", "f5-virtualserver", "f5-transportserver", "traefik-proxy")
+	app.Flag("source", "The resource types that are queried for endpoints; specify multiple times for multiple sources (required, options: service, ingress, node, pod, fake, connector, gateway-httproute, gateway-grpcroute, gateway-tlsroute, gateway-tcproute, gateway-udproute, istio-gateway, istio-virtualservice, cloudfoundry, contour-httpproxy, gloo-proxy, crd, generic-crd, empty, skipper-routegroup, openshift-route, ambassador-host, kong-tcpingress, f5-virtualserver, f5-transportserver, traefik-proxy, acme-challenge)").PlaceHolder("source").EnumsVar(&cfg.Sources, "service", "ingress", "node", "pod", "gateway-httproute", "gateway-grpcroute", "gateway-tlsroute", "gateway-tcproute", "gateway-udproute", "istio-gateway", "istio-virtualservice", "cloudfoundry", "contour-httpproxy", "gloo-proxy", "fake", "connector", "crd", "generic-crd", "empty", "skipper-routegroup", "openshift-route", "ambassador-host", "kong-tcpingress", "f5-virtualserver", "f5-transportserver", "traefik-proxy", "acme-challenge")
 	app.Flag("target-net-filter", "Limit possible targets by a net filter; specify multiple times for multiple possible nets (optional)").StringsVar(&cfg.TargetNetFilter)
 	app.Flag("traefik-disable-legacy", "Disable listeners on Resources under the traefik.containo.us API Group").Default(strconv.FormatBool(defaultConfig.TraefikDisableLegacy)).BoolVar(&cfg.TraefikDisableLegacy)
 	app.Flag("traefik-disable-new", "Disable listeners on Resources under the traefik.io API Group").Default(strconv.FormatBool(defaultConfig.TraefikDisableNew)).BoolVar(&cfg.TraefikDisableNew)
+	app.Flag("contour-envoy-service", "The Envoy service of Contour, as <namespace>/<name>, whose load balancer addresses are the targets of the HTTPProxies without a load balancer status, e.g. projectcontour/envoy (optional)").Default(defaultConfig.ContourEnvoyService).StringVar(&cfg.ContourEnvoyService)
+	app.Flag("traefik-service", "The Traefik service, as <namespace>/<name>, whose load balancer addresses are the targets of the Traefik resources without a target annotation (optional)").Default(defaultConfig.TraefikService).StringVar(&cfg.TraefikService)
 
 	// Flags related to providers
 	providers := []string{"akamai", "alibabacloud", "aws", "aws-sd", "azure", "azure-dns", "azure-private-dns", "civo", "cloudflare", "coredns", "digitalocean", "dnsimple", "exoscale", "gandi", "godaddy", "google", "inmemory", "linode", "ns1", "oci", "ovh", "pdns", "pihole", "plural", "rfc2136", "scaleway", "skydns", "transip", "webhook"}
-	app.Flag("provider", "The DNS provider where the DNS records will be created (required, options: "+strings.Join(providers, ", ")+")").Required().PlaceHolder("provider").EnumVar(&cfg.Provider, providers...)
+	app.Flag("provider", "The DNS provider where the DNS records will be created (required, options: "+strings.Join(providers, ", ")+")").PlaceHolder("provider").EnumVar(&cfg.Provider, providers...)
 	app.Flag("provider-cache-time", "The time to cache the DNS provider record list requests.").Default(defaultConfig.ProviderCacheTime.String()).DurationVar(&cfg.ProviderCacheTime)
+	app.Flag("provider-proxy-url", "The proxy, http(s):// or socks5://, through which all the DNS providers send their requests, except to the hosts of the NO_PROXY environment variable and to the loopback addresses (default: the proxy of the HTTPS_PROXY environment variable)").Default(defaultConfig.ProviderProxyURL).StringVar(&cfg.ProviderProxyURL)
+	app.Flag("provider-ca-bundle", "The file of a PEM CA bundle trusted by all the DNS providers in addition to the system roots, e.g. of an egress proxy intercepting TLS (optional)").Default(defaultConfig.ProviderCABundle).StringVar(&cfg.ProviderCABundle)
+	app.Flag("provider-concurrency", "The maximum number of requests sent at the same time by the DNS providers sending them in parallel: DigitalOcean, GoDaddy and OVH (default: 0, the default of the provider derived from the quotas of its API)").Default(strconv.Itoa(defaultConfig.ProviderConcurrency)).IntVar(&cfg.ProviderConcurrency)
+	app.Flag("provider-read-concurrency", "The maximum number of read requests sent at the same time by the DNS providers sending them in parallel, overriding provider-concurrency (default: 0, provider-concurrency)").Default(strconv.Itoa(defaultConfig.ProviderReadConcurrency)).IntVar(&cfg.ProviderReadConcurrency)
+	app.Flag("provider-write-concurrency", "The maximum number of write requests sent at the same time by the DNS providers sending them in parallel, overriding provider-concurrency (default: 0, provider-concurrency)").Default(strconv.Itoa(defaultConfig.ProviderWriteConcurrency)).IntVar(&cfg.ProviderWriteConcurrency)
+	app.Flag("provider-failover-cooldown", "The time an API endpoint of a DNS provider is skipped for once it failed, when fallback endpoints are configured, e.g. with --pdns-server-fallback (default: 30s)").Default(defaultConfig.ProviderFailoverCooldown.String()).DurationVar(&cfg.ProviderFailoverCooldown)
+	app.Flag("missing-zone-cache-ttl", "The time during which the records of a DNS name matching no zone of the DNS provider are not applied again; the name is only logged again once it matches a zone (default: 0s, disabled)").Default(defaultConfig.MissingZoneCacheTTL.String()).DurationVar(&cfg.MissingZoneCacheTTL)
+	app.Flag("provider-circuit-breaker-failures", "The number of consecutive failures of the DNS provider after which it's no longer called for --provider-circuit-breaker-open-duration, then probed with a single call (default: 0, disabled)").Default(strconv.Itoa(defaultConfig.ProviderCircuitBreakerFailures)).IntVar(&cfg.ProviderCircuitBreakerFailures)
+	app.Flag("provider-circuit-breaker-open-duration", "The duration during which the DNS provider is not called once its circuit breaker is open").Default(defaultConfig.ProviderCircuitBreakerOpenDuration.String()).DurationVar(&cfg.ProviderCircuitBreakerOpenDuration)
+	app.Flag("fault-injection", "When enabled, randomly delays, fails and partially applies the calls to the DNS provider, to validate alerting and retries in non-production environments; never enable it in production (default: disabled)").BoolVar(&cfg.FaultInjection)
+	app.Flag("fault-injection-profile", "The faults injected by --fault-injection: light, heavy, or a comma-separated list of delay, error and partial probabilities and a max-delay, e.g. error=0.1,delay=0.2,max-delay=10s").Default(defaultConfig.FaultInjectionProfile).StringVar(&cfg.FaultInjectionProfile)
+	app.Flag("fault-injection-seed", "The seed of the faults injected by --fault-injection, to reproduce them (default: 0, a random seed)").Default("0").Int64Var(&cfg.FaultInjectionSeed)
 	app.Flag("domain-filter", "Limit possible target zones by a domain suffix; specify multiple times for multiple domains (optional)").Default("").StringsVar(&cfg.DomainFilter)
 	app.Flag("exclude-domains", "Exclude subdomains (optional)").Default("").StringsVar(&cfg.ExcludeDomains)
 	app.Flag("regex-domain-filter", "Limit possible domains and target zones by a Regex filter; Overrides domain-filter (optional)").Default(defaultConfig.RegexDomainFilter.String()).RegexpVar(&cfg.RegexDomainFilter)
 	app.Flag("regex-domain-exclusion", "Regex filter that excludes domains and target zones matched by regex-domain-filter (optional); Require 'regex-domain-filter' ").Default(defaultConfig.RegexDomainExclusion.String()).RegexpVar(&cfg.RegexDomainExclusion)
 	app.Flag("zone-name-filter", "Filter target zones by zone domain (For now, only AzureDNS provider is using this flag); specify multiple times for multiple zones (optional)").Default("").StringsVar(&cfg.ZoneNameFilter)
 	app.Flag("zone-id-filter", "Filter target zones by hosted zone id; specify multiple times for multiple zones (optional)").Default("").StringsVar(&cfg.ZoneIDFilter)
+	app.Flag("zone-selection", "Choose the zones of the records when both public and private zones match them, overridden per record by the zone-selection annotation; only the AWS provider has both in the same provider (default: both, options: both, prefer-private, prefer-public)").Default(defaultConfig.ZoneSelection).EnumVar(&cfg.ZoneSelection, "both", "prefer-private", "prefer-public")
 	app.Flag("google-project", "When using the Google provider, current project is auto-detected, when running on GCP. Specify other project with this. Must be specified when running outside GCP.").Default(defaultConfig.GoogleProject).StringVar(&cfg.GoogleProject)
 	app.Flag("google-batch-change-size", "When using the Google provider, set the maximum number of changes that will be applied in each batch.").Default(strconv.Itoa(defaultConfig.GoogleBatchChangeSize)).IntVar(&cfg.GoogleBatchChangeSize)
 	app.Flag("google-batch-change-interval", "When using the Google provider, set the interval between batch changes.").Default(defaultConfig.GoogleBatchChangeInterval.String()).DurationVar(&cfg.GoogleBatchChangeInterval)
@@ -550,12 +763,17 @@ func App(cfg *Config) *kingpin.Application {
 	app.Flag("oci-compartment-ocid", "When using the OCI provider, specify the OCID of the OCI compartment containing all managed zones and records.  Required when using OCI IAM instance principal authentication.").StringVar(&cfg.OCICompartmentOCID)
 	app.Flag("oci-zone-scope", "When using OCI provider, filter for zones with this scope (optional, options: GLOBAL, PRIVATE). Defaults to GLOBAL, setting to empty value will target both.").Default(defaultConfig.OCIZoneScope).EnumVar(&cfg.OCIZoneScope, "", "GLOBAL", "PRIVATE")
 	app.Flag("oci-auth-instance-principal", "When using the OCI provider, specify whether OCI IAM instance principal authentication should be used (instead of key-based auth via the OCI config file).").Default(strconv.FormatBool(defaultConfig.OCIAuthInstancePrincipal)).BoolVar(&cfg.OCIAuthInstancePrincipal)
+	app.Flag("oci-auth-workload-identity", "When using the OCI provider, specify whether OCI IAM workload identity authentication should be used (instead of key-based auth via the OCI config file).").Default(strconv.FormatBool(defaultConfig.OCIAuthWorkloadIdentity)).BoolVar(&cfg.OCIAuthWorkloadIdentity)
+	app.Flag("oci-region", "When using the OCI provider with OCI IAM workload identity authentication, specify the region of the OCI DNS API (optional, defaults to the region of the environment).").Default(defaultConfig.OCIRegion).StringVar(&cfg.OCIRegion)
+	app.Flag("oci-zone-compartment-ocid", "When using the OCI provider, also manage the zones of the OCI compartment with this OCID (optional, specify multiple times for multiple compartments)").StringsVar(&cfg.OCIZoneCompartmentOCIDs)
+	app.Flag("oci-view-id", "When using the OCI provider, only manage the private zones of the private view with this OCID (optional, specify multiple times for multiple views)").StringsVar(&cfg.OCIViewIDs)
 	app.Flag("oci-zones-cache-duration", "When using the OCI provider, set the zones list cache TTL (0s to disable).").Default(defaultConfig.OCIZoneCacheDuration.String()).DurationVar(&cfg.OCIZoneCacheDuration)
 	app.Flag("inmemory-zone", "Provide a list of pre-configured zones for the inmemory provider; specify multiple times for multiple zones (optional)").Default("").StringsVar(&cfg.InMemoryZones)
 	app.Flag("ovh-endpoint", "When using the OVH provider, specify the endpoint (default: ovh-eu)").Default(defaultConfig.OVHEndpoint).StringVar(&cfg.OVHEndpoint)
 	app.Flag("ovh-api-rate-limit", "When using the OVH provider, specify the API request rate limit, X operations by seconds (default: 20)").Default(strconv.Itoa(defaultConfig.OVHApiRateLimit)).IntVar(&cfg.OVHApiRateLimit)
 	app.Flag("ovh-enable-cname-relative", "When using the OVH provider, specify if CNAME should be treated as relative on target without final dot (default: false)").Default(strconv.FormatBool(defaultConfig.OVHEnableCNAMERelative)).BoolVar(&cfg.OVHEnableCNAMERelative)
 	app.Flag("pdns-server", "When using the PowerDNS/PDNS provider, specify the URL to the pdns server (required when --provider=pdns)").Default(defaultConfig.PDNSServer).StringVar(&cfg.PDNSServer)
+	app.Flag("pdns-server-fallback", "When using the PowerDNS/PDNS provider, specify the URL of a redundant pdns server the requests fail over to, in order, while the previous ones are unhealthy; specify multiple times for multiple servers (optional)").StringsVar(&cfg.PDNSFallbackServers)
 	app.Flag("pdns-server-id", "When using the PowerDNS/PDNS provider, specify the id of the server to retrieve. Should be `localhost` except when the server is behind a proxy (optional when --provider=pdns) (default: localhost)").Default(defaultConfig.PDNSServerID).StringVar(&cfg.PDNSServerID)
 	app.Flag("pdns-api-key", "When using the PowerDNS/PDNS provider, specify the API key to use to authorize requests (required when --provider=pdns)").Default(defaultConfig.PDNSAPIKey).StringVar(&cfg.PDNSAPIKey)
 	app.Flag("pdns-skip-tls-verify", "When using the PowerDNS/PDNS provider, disable verification of any TLS certificates (optional when --provider=pdns) (default: false)").Default(strconv.FormatBool(defaultConfig.PDNSSkipTLSVerify)).BoolVar(&cfg.PDNSSkipTLSVerify)
@@ -563,11 +781,18 @@ func App(cfg *Config) *kingpin.Application {
 	app.Flag("ns1-ignoressl", "When using the NS1 provider, specify whether to verify the SSL certificate (default: false)").Default(strconv.FormatBool(defaultConfig.NS1IgnoreSSL)).BoolVar(&cfg.NS1IgnoreSSL)
 	app.Flag("ns1-min-ttl", "Minimal TTL (in seconds) for records. This value will be used if the provided TTL for a service/ingress is lower than this.").IntVar(&cfg.NS1MinTTLSeconds)
 	app.Flag("digitalocean-api-page-size", "Configure the page size used when querying the DigitalOcean API.").Default(strconv.Itoa(defaultConfig.DigitalOceanAPIPageSize)).IntVar(&cfg.DigitalOceanAPIPageSize)
+	app.Flag("digitalocean-project", "When using the DigitalOcean provider, only manage the domains of the project with this ID (optional, specify multiple times for multiple projects)").StringsVar(&cfg.DigitalOceanProjects)
+	app.Flag("linode-domain-tag", "When using the Linode provider, only manage the domains with this tag (optional, specify multiple times for multiple tags)").StringsVar(&cfg.LinodeDomainTags)
 	// GoDaddy flags
 	app.Flag("godaddy-api-key", "When using the GoDaddy provider, specify the API Key (required when --provider=godaddy)").Default(defaultConfig.GoDaddyAPIKey).StringVar(&cfg.GoDaddyAPIKey)
 	app.Flag("godaddy-api-secret", "When using the GoDaddy provider, specify the API secret (required when --provider=godaddy)").Default(defaultConfig.GoDaddySecretKey).StringVar(&cfg.GoDaddySecretKey)
 	app.Flag("godaddy-api-ttl", "TTL (in seconds) for records. This value will be used if the provided TTL for a service/ingress is not provided.").Int64Var(&cfg.GoDaddyTTL)
 	app.Flag("godaddy-api-ote", "When using the GoDaddy provider, use OTE api (optional, default: false, when --provider=godaddy)").BoolVar(&cfg.GoDaddyOTE)
+	app.Flag("godaddy-api-endpoint", "When using the GoDaddy provider, the URL of the API, e.g. of a proxy or of a mock server, instead of the OTE or production API (optional)").Default(defaultConfig.GoDaddyAPIEndpoint).StringVar(&cfg.GoDaddyAPIEndpoint)
+
+	// Flags related to provider credentials
+	app.Flag("secret-refresh-interval", "The interval between two consecutive refreshes of provider credentials given as secret references (e.g. secret+file:/path, secret+vault:secret/data/dns#key, secret+aws-sm:name#key); the provider is rebuilt when a credential is rotated (default: disabled)").Default(defaultConfig.SecretRefreshInterval.String()).DurationVar(&cfg.SecretRefreshInterval)
+	app.Flag("secret-env", "Name of an environment variable read by the provider whose value is a secret reference to resolve (e.g. CF_API_TOKEN); specify multiple times for multiple variables (optional)").StringsVar(&cfg.SecretEnvVars)
 
 	// Flags related to TLS communication
 	app.Flag("tls-ca", "When using TLS communication, the path to the certificate authority to verify server communications (optionally specify --tls-client-cert for two-way TLS)").Default(defaultConfig.TLSCA).StringVar(&cfg.TLSCA)
@@ -598,8 +823,15 @@ func App(cfg *Config) *kingpin.Application {
 	app.Flag("rfc2136-batch-change-size", "When using the RFC2136 provider, set the maximum number of changes that will be applied in each batch.").Default(strconv.Itoa(defaultConfig.RFC2136BatchChangeSize)).IntVar(&cfg.RFC2136BatchChangeSize)
 	app.Flag("rfc2136-use-tls", "When using the RFC2136 provider, communicate with name server over tls").BoolVar(&cfg.RFC2136UseTLS)
 	app.Flag("rfc2136-skip-tls-verify", "When using TLS with the RFC2136 provider, disable verification of any TLS certificates").BoolVar(&cfg.RFC2136SkipTLSVerify)
+	app.Flag("rfc2136-sig0-key-dir", "When using the RFC2136 provider, sign the updates with SIG(0) using the newest private key of the directory, as generated by dnssec-keygen -T KEY, instead of TSIG (default: disabled)").Default(defaultConfig.RFC2136SIG0KeyDir).StringVar(&cfg.RFC2136SIG0KeyDir)
+	app.Flag("rfc2136-sig0-key-rollover", "When using the RFC2136 provider with SIG(0), replace the key after this duration, publishing the new KEY record with an update signed by the old key; the directory must be writable and persistent (default: 0, disabled)").Default(defaultConfig.RFC2136SIG0KeyRollover.String()).DurationVar(&cfg.RFC2136SIG0KeyRollover)
 	app.Flag("rfc2136-load-balancing-strategy", "When using the RFC2136 provider, specify the load balancing strategy (default: disabled, options: random, round-robin, disabled)").Default(defaultConfig.RFC2136LoadBalancingStrategy).EnumVar(&cfg.RFC2136LoadBalancingStrategy, "random", "round-robin", "disabled")
 
+	// Flags related to the verification of the zone serials, with the PowerDNS and RFC2136 providers
+	app.Flag("zone-serial-check", "When using the PowerDNS or RFC2136 provider, verify after applying changes that the SOA serial of the changed zones increased on the primary and, with zone-serial-secondary, that the secondaries serve it (default: disabled)").BoolVar(&cfg.ZoneSerialCheck)
+	app.Flag("zone-serial-secondary", "When using zone-serial-check, a secondary DNS server, host[:port], which must serve the serial of the primary within zone-serial-check-timeout; specify multiple times for multiple secondaries (optional)").StringsVar(&cfg.ZoneSerialSecondaries)
+	app.Flag("zone-serial-check-timeout", "When using zone-serial-check, how long the secondaries have to serve the serial of the primary after the changes (default: 1m)").Default(defaultConfig.ZoneSerialCheckTimeout.String()).DurationVar(&cfg.ZoneSerialCheckTimeout)
+
 	// Flags related to TransIP provider
 	app.Flag("transip-account", "When using the TransIP provider, specify the account name (required when --provider=transip)").Default(defaultConfig.TransIPAccountName).StringVar(&cfg.TransIPAccountName)
 	app.Flag("transip-keyfile", "When using the TransIP provider, specify the path to the private key file (required when --provider=transip)").Default(defaultConfig.TransIPPrivateKeyFile).StringVar(&cfg.TransIPPrivateKeyFile)
@@ -616,34 +848,85 @@ func App(cfg *Config) *kingpin.Application {
 
 	// Flags related to policies
 	app.Flag("policy", "Modify how DNS records are synchronized between sources and providers (default: sync, options: sync, upsert-only, create-only)").Default(defaultConfig.Policy).EnumVar(&cfg.Policy, "sync", "upsert-only", "create-only")
+	app.Flag("wildcard-policy", "Whether wildcard records, such as *.example.com, are managed; with deny, or with a provider that does not support them, they are neither created, updated nor deleted (default: allow, options: allow, deny)").Default(defaultConfig.WildcardPolicy).EnumVar(&cfg.WildcardPolicy, "allow", "deny")
 
 	// Flags related to the registry
-	app.Flag("registry", "The registry implementation to use to keep track of DNS record ownership (default: txt, options: txt, noop, dynamodb, aws-sd)").Default(defaultConfig.Registry).EnumVar(&cfg.Registry, "txt", "noop", "dynamodb", "aws-sd")
+	app.Flag("registry", "The registry implementation to use to keep track of DNS record ownership (default: txt, options: txt, noop, dynamodb, aws-sd, txt-to-dynamodb)").Default(defaultConfig.Registry).EnumVar(&cfg.Registry, "txt", "noop", "dynamodb", "aws-sd", "txt-to-dynamodb")
 	app.Flag("txt-owner-id", "When using the TXT or DynamoDB registry, a name that identifies this instance of ExternalDNS (default: default)").Default(defaultConfig.TXTOwnerID).StringVar(&cfg.TXTOwnerID)
+	app.Flag("txt-owner-id-template", "When using the TXT registry, a template of the owner ids of the records derived from txt-owner-id and the resource of each record, e.g. '{{ .OwnerID }}-{{ .Namespace }}', so that the records of each tenant can only be changed by the resources of this tenant and can later be managed by a separate instance; the fields are OwnerID, Kind, Namespace, Name and Labels, the labels copied with propagate-label (optional)").Default(defaultConfig.TXTOwnerIDTemplate).StringVar(&cfg.TXTOwnerIDTemplate)
 	app.Flag("txt-prefix", "When using the TXT registry, a custom string that's prefixed to each ownership DNS record (optional). Could contain record type template like '%{record_type}-prefix-'. Mutual exclusive with txt-suffix!").Default(defaultConfig.TXTPrefix).StringVar(&cfg.TXTPrefix)
 	app.Flag("txt-suffix", "When using the TXT registry, a custom string that's suffixed to the host portion of each ownership DNS record (optional). Could contain record type template like '-%{record_type}-suffix'. Mutual exclusive with txt-prefix!").Default(defaultConfig.TXTSuffix).StringVar(&cfg.TXTSuffix)
 	app.Flag("txt-wildcard-replacement", "When using the TXT registry, a custom string that's used instead of an asterisk for TXT records corresponding to wildcard DNS records (optional)").Default(defaultConfig.TXTWildcardReplacement).StringVar(&cfg.TXTWildcardReplacement)
 	app.Flag("txt-encrypt-enabled", "When using the TXT registry, set if TXT records should be encrypted before stored (default: disabled)").BoolVar(&cfg.TXTEncryptEnabled)
 	app.Flag("txt-encrypt-aes-key", "When using the TXT registry, set TXT record decryption and encryption 32 byte aes key (required when --txt-encrypt=true)").Default(defaultConfig.TXTEncryptAESKey).StringVar(&cfg.TXTEncryptAESKey)
+	app.Flag("txt-encrypt-kms", "When using the TXT registry, encrypt the TXT records with data keys wrapped by a key management service instead of the AES key, which only decrypts the records encrypted with it while migrating; one of: aws-kms, gcp-kms, vault-transit").Default(defaultConfig.TXTEncryptKMS).EnumVar(&cfg.TXTEncryptKMS, "", "aws-kms", "gcp-kms", "vault-transit")
+	app.Flag("txt-encrypt-kms-key", "The key wrapping the data keys of --txt-encrypt-kms: the ID, ARN or alias of an AWS KMS key, the resource name of a GCP Cloud KMS key, or <mount>/<key> of a Vault transit key").Default(defaultConfig.TXTEncryptKMSKey).StringVar(&cfg.TXTEncryptKMSKey)
 	app.Flag("txt-new-format-only", "When using the TXT registry, only use new format records which include record type information (e.g., prefix: 'a-'). Reduces number of TXT records (default: disabled)").BoolVar(&cfg.TXTNewFormatOnly)
+	app.Flag("txt-name-template", "When using the TXT registry, a template for the names of the ownership DNS records, replacing txt-prefix and txt-suffix (optional). Supports %{record_type}, %{host}, %{domain} and %{hash}, e.g. '_owner.%{record_type}.%{host}.%{domain}'").Default(defaultConfig.TXTNameTemplate).StringVar(&cfg.TXTNameTemplate)
+	app.Flag("txt-name-template-migration", "When using the TXT registry with txt-name-template, also read and write the ownership DNS records named after txt-prefix or txt-suffix, to migrate them (default: disabled)").BoolVar(&cfg.TXTNameTemplateMigration)
+	app.Flag("txt-janitor-interval", "When using the TXT registry, the interval between the runs of the janitor finding the ownership DNS records left without their record, e.g. by an interrupted apply (default: disabled)").Default(defaultConfig.TXTJanitorInterval.String()).DurationVar(&cfg.TXTJanitorInterval)
+	app.Flag("txt-janitor-policy", "When using the TXT registry with txt-janitor-interval, what the janitor does with the orphaned ownership DNS records: log and count them, or delete them unless dry-run is enabled (default: report, options: report, delete)").Default(defaultConfig.TXTJanitorPolicy).EnumVar(&cfg.TXTJanitorPolicy, "report", "delete")
+	app.Flag("txt-janitor-owner-id", "When using the TXT registry with txt-janitor-interval, another owner id, e.g. of a decommissioned instance, whose orphaned ownership DNS records are collected too; they must be named like those of this instance; specify multiple times for multiple owners (optional)").StringsVar(&cfg.TXTJanitorOwnerIDs)
 	app.Flag("dynamodb-region", "When using the DynamoDB registry, the AWS region of the DynamoDB table (optional)").Default(cfg.AWSDynamoDBRegion).StringVar(&cfg.AWSDynamoDBRegion)
 	app.Flag("dynamodb-table", "When using the DynamoDB registry, the name of the DynamoDB table (default: \"external-dns\")").Default(defaultConfig.AWSDynamoDBTable).StringVar(&cfg.AWSDynamoDBTable)
+	app.Flag("dynamodb-create-table", "When using the DynamoDB registry, create the DynamoDB table with on-demand billing if it does not exist (default: disabled)").BoolVar(&cfg.AWSDynamoDBCreateTable)
+	app.Flag("dynamodb-table-tag", "When using the DynamoDB registry with dynamodb-create-table, add a tag to the created table, e.g. team=dns; specify multiple times for multiple tags (optional)").StringMapVar(&cfg.AWSDynamoDBTableTags)
+	app.Flag("registry-migration-cutover", "When using the txt-to-dynamodb registry, read the ownership of the records from the DynamoDB table instead of the TXT records; both are still written (default: disabled)").BoolVar(&cfg.RegistryMigrationCutover)
 
 	// Flags related to the main control loop
 	app.Flag("txt-cache-interval", "The interval between cache synchronizations in duration format (default: disabled)").Default(defaultConfig.TXTCacheInterval.String()).DurationVar(&cfg.TXTCacheInterval)
 	app.Flag("interval", "The interval between two consecutive synchronizations in duration format (default: 1m)").Default(defaultConfig.Interval.String()).DurationVar(&cfg.Interval)
 	app.Flag("min-event-sync-interval", "The minimum interval between two consecutive synchronizations triggered from kubernetes events in duration format (default: 5s)").Default(defaultConfig.MinEventSyncInterval.String()).DurationVar(&cfg.MinEventSyncInterval)
+	app.Flag("sync-timeout", "The maximum duration of a synchronization; once elapsed, its calls to the registry and to the DNS provider are canceled and the step in flight is reported in a soft error (default: 0, unlimited)").Default(defaultConfig.SyncTimeout.String()).DurationVar(&cfg.SyncTimeout)
 	app.Flag("once", "When enabled, exits the synchronization loop after the first iteration (default: disabled)").BoolVar(&cfg.Once)
 	app.Flag("dry-run", "When enabled, prints DNS record changes rather than actually performing them (default: disabled)").BoolVar(&cfg.DryRun)
 	app.Flag("events", "When enabled, in addition to running every interval, the reconciliation loop will get triggered when supported sources change (default: disabled)").BoolVar(&cfg.UpdateEvents)
+	app.Flag("stream-records", "When enabled, reconciles the zones one at a time to bound the memory used with many records; with the providers that cannot list their records per zone, all the records are still read at once (default: disabled)").BoolVar(&cfg.StreamRecords)
+	app.Flag("repeated-changes-backoff", "The duration during which the changes that are the same as those applied by the previous synchronization are not applied again, as the provider did not reflect them (default: disabled)").Default(defaultConfig.RepeatedChangesBackoff.String()).DurationVar(&cfg.RepeatedChangesBackoff)
+	app.Flag("max-deletions-per-sync", "The maximum number of records a synchronization may delete; a synchronization that would delete more records applies no changes and fails with a soft error (default: 0, unlimited)").Default(strconv.Itoa(defaultConfig.MaxDeletionsPerSync)).IntVar(&cfg.MaxDeletionsPerSync)
+	app.Flag("max-change-percentage", "The maximum percentage, from 1 to 100, of the owned records a synchronization may delete or update; a synchronization that would change more records applies no changes and fails with a soft error (default: 0, unlimited)").Default(strconv.Itoa(defaultConfig.MaxChangePercentage)).IntVar(&cfg.MaxChangePercentage)
+	app.Flag("max-records-per-zone", "The maximum number of records of a zone; the records which would grow a zone past it are not created, while the other changes are still applied (default: 0, unlimited)").Default(strconv.Itoa(defaultConfig.MaxRecordsPerZone)).IntVar(&cfg.MaxRecordsPerZone)
+	app.Flag("provider-write-budget", "The number of records which can be written to the DNS provider per hour; once exhausted, the updates only changing the TTL or the comment of records are deferred, while the creates, deletes and other updates are still applied (default: 0, unlimited)").Default(strconv.Itoa(defaultConfig.ProviderWriteBudget)).IntVar(&cfg.ProviderWriteBudget)
+	app.Flag("damping-threshold", "The number of times the targets of a record may change within damping-window; the records whose targets change more often, e.g. as two load balancer controllers fight over a resource, are held at their last stable targets and counted by the external_dns_controller_damped_records metric (default: 0, disabled)").Default(strconv.Itoa(defaultConfig.DampingThreshold)).IntVar(&cfg.DampingThreshold)
+	app.Flag("damping-window", "The duration over which the changes of the targets of a record are counted against damping-threshold (default: 10m)").Default(defaultConfig.DampingWindow.String()).DurationVar(&cfg.DampingWindow)
+	app.Flag("damping-cooldown", "The duration during which the desired targets of a held record must not change before they're applied; 0 releases the held records by hand only, which requires damping-allow-clear (default: 30m)").Default(defaultConfig.DampingCooldown.String()).DurationVar(&cfg.DampingCooldown)
+	app.Flag("damping-allow-clear", "Allow releasing the held records by hand with POST requests to /debug/damped-records on the metrics address, which otherwise only lists them (default: disabled)").BoolVar(&cfg.DampingAllowClear)
+	app.Flag("change-window", "A maintenance window out of which the updates and deletes of records are deferred to the next window, made of the 5 fields of a cron schedule of its openings followed by its duration, e.g. '0 22 * * 1-5 4h' from 22:00 to 02:00 after each weekday; specify multiple times for multiple windows (default: always open)").StringsVar(&cfg.ChangeWindows)
+	app.Flag("change-window-timezone", "The time zone of the change windows, e.g. Europe/Paris (default: UTC)").Default(defaultConfig.ChangeWindowTimezone).StringVar(&cfg.ChangeWindowTimezone)
+	app.Flag("change-window-creates", "Apply the creates of records out of the change windows too; use --no-change-window-creates to defer them as well (default: true)").Default(strconv.FormatBool(defaultConfig.ChangeWindowCreates)).BoolVar(&cfg.ChangeWindowCreates)
+	app.Flag("canary-zone", "A zone of the provider in which the creates and updates of records are staged before they are applied, e.g. canary.example.net to stage www.example.com as www.example.com.canary.example.net; the changes are not applied if the provider rejects them (default: disabled)").Default(defaultConfig.CanaryZone).StringVar(&cfg.CanaryZone)
+	app.Flag("canary-nameserver", "When using canary-zone, a nameserver of the canary zone, host[:port], which must serve the staged records with their targets within canary-timeout (optional)").Default(defaultConfig.CanaryNameserver).StringVar(&cfg.CanaryNameserver)
+	app.Flag("canary-timeout", "When using canary-nameserver, how long the nameserver has to serve the records staged in the canary zone (default: 1m)").Default(defaultConfig.CanaryTimeout.String()).DurationVar(&cfg.CanaryTimeout)
+	app.Flag("pause-configmap", "The namespace/name of a ConfigMap whose paused key pauses the reconciliation when true: the changes are still planned and logged, but not applied (optional)").Default(defaultConfig.PauseConfigMap).StringVar(&cfg.PauseConfigMap)
+	app.Flag("plan-configmap", "The namespace/name of a ConfigMap, created if missing, where the last plan is saved so that only the changes to it are logged after a restart (optional)").Default(defaultConfig.PlanConfigMap).StringVar(&cfg.PlanConfigMap)
+	app.Flag("zone-lock-namespace", "The namespace of the Leases locking the zones while their changes are applied, so that several instances sharing the zones, e.g. the replicas of a deployment, never write to the same zone at once (optional)").Default(defaultConfig.ZoneLockNamespace).StringVar(&cfg.ZoneLockNamespace)
+	app.Flag("zone-lock-lease-duration", "The duration after which the Lease of a zone expires when its holder did not release it, e.g. because it crashed while applying changes (default: 2m)").Default(defaultConfig.ZoneLockLeaseDuration.String()).DurationVar(&cfg.ZoneLockLeaseDuration)
+	app.Flag("backup-dir", "The directory where a snapshot of the owned records is written, as DNSEndpoints, after each successful synchronization; the last 10 snapshots are kept (optional)").Default(defaultConfig.BackupDir).StringVar(&cfg.BackupDir)
+	app.Flag("restore", "Replay a snapshot written with --backup-dir through the plan, creating the missing records and updating the changed ones without deleting any, then exit (optional)").Default(defaultConfig.Restore).StringVar(&cfg.Restore)
+	app.Flag("export-zone-file", "Write the owned records as RFC 1035 zone file text to this path, or to the standard output for -, then exit (optional)").Default(defaultConfig.ExportZoneFile).StringVar(&cfg.ExportZoneFile)
+	app.Flag("export-zone", "Limit --export-zone-file to the records of this zone, written relative to its $ORIGIN; specify multiple times for multiple zones (default: all the owned records, with absolute names)").StringsVar(&cfg.ExportZones)
+	app.Flag("adopt-existing-records", "Take the ownership of the existing records without an owner which have the name, type and targets of a desired record, by creating their TXT records, instead of leaving them alone; only supported by the txt registry (default: disabled)").BoolVar(&cfg.AdoptExistingRecords)
+	app.Flag("ignore-ttl-drift", "Don't update the records whose TTL only differs from the desired one, e.g. when the TTL policies of the DNS provider never match it; the TTL is still set when the records are created or updated otherwise (default: disabled)").BoolVar(&cfg.IgnoreTTLDrift)
+	app.Flag("external-owner-marker", "A text marking the records managed by another tool, e.g. terraform, in their provider-specific properties such as comments and tags, or in a TXT record of their name; these records and their names are never changed, even without an owner; specify multiple times for multiple markers, matched case-insensitively (optional)").StringsVar(&cfg.ExternalOwnerMarkers)
 
 	// Miscellaneous flags
 	app.Flag("log-format", "The format in which log messages are printed (default: text, options: text, json)").Default(defaultConfig.LogFormat).EnumVar(&cfg.LogFormat, "text", "json")
 	app.Flag("metrics-address", "Specify where to serve the metrics and health check endpoint (default: :7979)").Default(defaultConfig.MetricsAddress).StringVar(&cfg.MetricsAddress)
+	app.Flag("metrics-source-objects", "When enabled, exposes the external_dns_source_object_records metric, which maps each DNS name to the source object requesting it and to the owner of its record; its cardinality grows with the number of records (default: disabled)").BoolVar(&cfg.MetricsSourceObjects)
+	app.Flag("enable-pprof", "When enabled, serves the runtime profiles of the Go pprof package on /debug/pprof/ of the metrics address (default: disabled)").BoolVar(&cfg.EnablePprof)
+	app.Flag("dnscontrol-export", "When enabled, serves the desired records of the zones as a DNSControl configuration on /export/dnscontrol of the metrics address, for the zones reviewed and applied through DNSControl (default: disabled)").BoolVar(&cfg.DNSControlExport)
+	app.Flag("go-memory-limit", "Set the soft memory limit of the Go runtime, e.g. 512Mi, overriding the GOMEMLIMIT environment variable (default: unset)").Default(defaultConfig.GoMemoryLimit).StringVar(&cfg.GoMemoryLimit)
+	app.Flag("go-gc-percent", "Set the garbage collection target percentage of the Go runtime, overriding the GOGC environment variable; 0 leaves it unchanged (default: 0)").Default(strconv.Itoa(defaultConfig.GoGCPercent)).IntVar(&cfg.GoGCPercent)
 	app.Flag("log-level", "Set the level of logging. (default: info, options: panic, debug, info, warning, error, fatal)").Default(defaultConfig.LogLevel).EnumVar(&cfg.LogLevel, allLogLevelsAsStrings()...)
+	app.Flag("log-filter-decisions", "When enabled, logs why the candidate endpoints and the objects of the sources are excluded, e.g. by the domain, annotation or label filters or by another owner, and serves the latest decisions on /debug/filter-decisions of the metrics address (default: disabled)").BoolVar(&cfg.LogFilterDecisions)
+	app.Flag("log-output", "Where the log messages are written (default: stderr, options: stderr, syslog)").Default(defaultConfig.LogOutput).EnumVar(&cfg.LogOutput, "stderr", "syslog")
+	app.Flag("log-syslog-address", "When logging to syslog, the address of the syslog daemon, e.g. udp://syslog:514 (default: the local syslog daemon)").Default(defaultConfig.LogSyslogAddress).StringVar(&cfg.LogSyslogAddress)
+	app.Flag("log-sampling-interval", "When set, the same warning or debug message is logged at most once per interval, with the number of messages suppressed meanwhile; errors and info messages are always logged (default: disabled)").Default(defaultConfig.LogSamplingInterval.String()).DurationVar(&cfg.LogSamplingInterval)
+	app.Flag("audit-log", "When set, writes each change applied to the DNS provider as a JSON object to an audit log: appended to this file, or posted to this URL if it starts with http:// or https:// (default: disabled)").Default(defaultConfig.AuditLog).StringVar(&cfg.AuditLog)
 
 	// Webhook provider
 	app.Flag("webhook-provider-url", "The URL of the remote endpoint to call for the webhook provider (default: http://localhost:8888)").Default(defaultConfig.WebhookProviderURL).StringVar(&cfg.WebhookProviderURL)
+	app.Flag("webhook-provider-fallback-url", "The URL of a redundant remote endpoint the calls of the webhook provider fail over to, in order, while the previous ones are unhealthy; specify multiple times for multiple endpoints (optional)").StringsVar(&cfg.WebhookProviderFallbackURLs)
 	app.Flag("webhook-provider-read-timeout", "The read timeout for the webhook provider in duration format (default: 5s)").Default(defaultConfig.WebhookProviderReadTimeout.String()).DurationVar(&cfg.WebhookProviderReadTimeout)
 	app.Flag("webhook-provider-write-timeout", "The write timeout for the webhook provider in duration format (default: 10s)").Default(defaultConfig.WebhookProviderWriteTimeout.String()).DurationVar(&cfg.WebhookProviderWriteTimeout)
 
@@ -31,6 +31,7 @@ import (
 
 var (
 	minimalConfig = &Config{
+		Command:                                CommandRun,
 		APIServerURL:                           "",
 		KubeConfig:                             "",
 		RequestTimeout:                         time.Second * 30,
@@ -38,9 +39,13 @@ var (
 		SkipperRouteGroupVersion:               "zalando.org/v1",
 		Sources:                                []string{"service"},
 		Namespace:                              "",
+		NamespaceRegex:                         regexp.MustCompile(""),
 		FQDNTemplate:                           "",
 		Compatibility:                          "",
 		Provider:                               "google",
+		ProviderCircuitBreakerOpenDuration:     5 * time.Minute,
+		ProviderFailoverCooldown:               30 * time.Second,
+		FaultInjectionProfile:                  "light",
 		GoogleProject:                          "",
 		GoogleBatchChangeSize:                  1000,
 		GoogleBatchChangeInterval:              time.Second,
@@ -51,6 +56,7 @@ var (
 		RegexDomainExclusion:                   regexp.MustCompile(""),
 		ZoneNameFilter:                         []string{""},
 		ZoneIDFilter:                           []string{""},
+		ZoneSelection:                          "both",
 		AlibabaCloudConfigFile:                 "/etc/kubernetes/alibaba-cloud.json",
 		AWSZoneType:                            "",
 		AWSZoneTagFilter:                       []string{""},
@@ -69,6 +75,7 @@ var (
 		AWSSDServiceCleanup:                    false,
 		AWSSDCreateTag:                         map[string]string{},
 		AWSDynamoDBTable:                       "external-dns",
+		AWSDynamoDBTableTags:                   map[string]string{},
 		AzureConfigFile:                        "/etc/kubernetes/azure.json",
 		AzureResourceGroup:                     "",
 		AzureSubscriptionID:                    "",
@@ -97,17 +104,20 @@ var (
 		PDNSServerID:                                  "localhost",
 		PDNSAPIKey:                                    "",
 		Policy:                                        "sync",
+		WildcardPolicy:                                "allow",
 		Registry:                                      "txt",
 		TXTOwnerID:                                    "default",
 		TXTPrefix:                                     "",
 		TXTCacheInterval:                              0,
 		TXTNewFormatOnly:                              false,
+		TXTJanitorPolicy:                              "report",
 		Interval:                                      time.Minute,
 		MinEventSyncInterval:                          5 * time.Second,
 		Once:                                          false,
 		DryRun:                                        false,
 		UpdateEvents:                                  false,
 		LogFormat:                                     "text",
+		LogOutput:                                     "stderr",
 		MetricsAddress:                                ":7979",
 		LogLevel:                                      logrus.InfoLevel.String(),
 		ConnectorSourceServer:                         "localhost:8080",
@@ -124,23 +134,44 @@ var (
 		RFC2136BatchChangeSize:                        50,
 		RFC2136Host:                                   []string{""},
 		RFC2136LoadBalancingStrategy:                  "disabled",
+		ZoneSerialCheckTimeout:                        time.Minute,
 		OCPRouterName:                                 "default",
 		PiholeApiVersion:                              "5",
 		WebhookProviderURL:                            "http://localhost:8888",
 		WebhookProviderReadTimeout:                    5 * time.Second,
 		WebhookProviderWriteTimeout:                   10 * time.Second,
 		ExcludeUnschedulable:                          true,
+		ConfigReloadInterval:                          10 * time.Second,
+		CertManagerRenewalLead:                        time.Hour,
+		PublicIPRefreshInterval:                       5 * time.Minute,
+		TailscaleOperatorNamespace:                    "tailscale",
+		TargetIPFamily:                                "dual",
+		SourceTargetIPFamilies:                        map[string]string{},
+		SourceResyncPeriods:                           map[string]string{},
+		ZoneLockLeaseDuration:                         2 * time.Minute,
+		DampingWindow:                                 10 * time.Minute,
+		DampingCooldown:                               30 * time.Minute,
+		ChangeWindowTimezone:                          "UTC",
+		ChangeWindowCreates:                           true,
+		CanaryTimeout:                                 time.Minute,
 	}
 
 	overriddenConfig = &Config{
+		Command:                                CommandRun,
 		APIServerURL:                           "http://127.0.0.1:8080",
 		KubeConfig:                             "/some/path",
 		RequestTimeout:                         time.Second * 77,
 		GlooNamespaces:                         []string{"gloo-not-system", "gloo-second-system"},
 		SkipperRouteGroupVersion:               "zalando.org/v2",
+		ContourEnvoyService:                    "contour/envoy",
 		Sources:                                []string{"service", "ingress", "connector"},
 		Namespace:                              "namespace",
+		NamespaceRegex:                         regexp.MustCompile("^team-"),
+		ExcludeNamespaces:                      []string{"team-sandbox", "kube-system"},
+		AnnotationPrefixAliases:                []string{"dns.mycorp.io/"},
+		PropagateLabels:                        []string{"team", "cost-center"},
 		IgnoreHostnameAnnotation:               true,
+		ServiceDefaultHostnames:                true,
 		IgnoreNonHostNetworkPods:               true,
 		IgnoreIngressTLSSpec:                   true,
 		IgnoreIngressRulesSpec:                 true,
@@ -157,6 +188,7 @@ var (
 		RegexDomainExclusion:                   regexp.MustCompile("xapi\\.(example\\.org|company\\.com)$"),
 		ZoneNameFilter:                         []string{"yapi.example.org", "yapi.company.com"},
 		ZoneIDFilter:                           []string{"/hostedzone/ZTST1", "/hostedzone/ZTST2"},
+		ZoneSelection:                          "prefer-private",
 		TargetNetFilter:                        []string{"10.0.0.0/9", "10.1.0.0/9"},
 		ExcludeTargetNets:                      []string{"1.0.0.0/9", "1.1.0.0/9"},
 		AlibabaCloudConfigFile:                 "/etc/kubernetes/alibaba-cloud.json",
@@ -177,6 +209,9 @@ var (
 		AWSSDServiceCleanup:                    true,
 		AWSSDCreateTag:                         map[string]string{"key1": "value1", "key2": "value2"},
 		AWSDynamoDBTable:                       "custom-table",
+		AWSDynamoDBCreateTable:                 true,
+		RegistryMigrationCutover:               true,
+		AWSDynamoDBTableTags:                   map[string]string{"team": "dns"},
 		AzureConfigFile:                        "azure.json",
 		AzureResourceGroup:                     "arg",
 		AzureSubscriptionID:                    "arg",
@@ -198,10 +233,15 @@ var (
 		OCIConfigFile:                                 "oci.yaml",
 		OCIZoneScope:                                  "PRIVATE",
 		OCIZoneCacheDuration:                          30 * time.Second,
+		OCIAuthWorkloadIdentity:                       true,
+		OCIRegion:                                     "us-ashburn-1",
+		OCIZoneCompartmentOCIDs:                       []string{"ocid1.compartment.oc1..a", "ocid1.compartment.oc1..b"},
+		OCIViewIDs:                                    []string{"ocid1.dnsview.oc1..a"},
 		InMemoryZones:                                 []string{"example.org", "company.com"},
 		OVHEndpoint:                                   "ovh-ca",
 		OVHApiRateLimit:                               42,
 		PDNSServer:                                    "http://ns.example.com:8081",
+		PDNSFallbackServers:                           []string{"http://ns2.example.com:8081", "http://ns3.example.com:8081"},
 		PDNSServerID:                                  "localhost",
 		PDNSAPIKey:                                    "some-secret-key",
 		PDNSSkipTLSVerify:                             true,
@@ -210,18 +250,76 @@ var (
 		TLSClientCertKey:                              "/path/to/key.pem",
 		PodSourceDomain:                               "example.org",
 		Policy:                                        "upsert-only",
+		WildcardPolicy:                                "deny",
 		Registry:                                      "noop",
 		TXTOwnerID:                                    "owner-1",
+		TXTOwnerIDTemplate:                            "{{ .OwnerID }}-{{ .Namespace }}",
 		TXTPrefix:                                     "associated-txt-record",
 		TXTCacheInterval:                              12 * time.Hour,
 		TXTNewFormatOnly:                              true,
+		TXTJanitorInterval:                            6 * time.Hour,
+		TXTJanitorPolicy:                              "delete",
+		TXTJanitorOwnerIDs:                            []string{"old-cluster", "older-cluster"},
 		Interval:                                      10 * time.Minute,
 		MinEventSyncInterval:                          50 * time.Second,
+		SyncTimeout:                                   10 * time.Minute,
 		Once:                                          true,
 		DryRun:                                        true,
 		UpdateEvents:                                  true,
+		StreamRecords:                                 true,
+		RepeatedChangesBackoff:                        10 * time.Minute,
+		MaxDeletionsPerSync:                           50,
+		MaxChangePercentage:                           20,
+		MaxRecordsPerZone:                             5000,
+		ProviderWriteBudget:                           500,
+		DampingThreshold:                              5,
+		DampingWindow:                                 15 * time.Minute,
+		DampingCooldown:                               time.Hour,
+		DampingAllowClear:                             true,
+		ChangeWindows:                                 []string{"0 22 * * 1-5 4h", "0 10 * * 6 2h"},
+		ChangeWindowTimezone:                          "Europe/Paris",
+		ChangeWindowCreates:                           false,
+		CanaryZone:                                    "canary.example.net",
+		CanaryNameserver:                              "ns1.example.net:5353",
+		CanaryTimeout:                                 30 * time.Second,
+		PauseConfigMap:                                "kube-system/external-dns-pause",
+		PlanConfigMap:                                 "kube-system/external-dns-plan",
+		ProviderProxyURL:                              "http://proxy.example.org:3128",
+		ProviderCABundle:                              "/etc/ssl/proxy-ca.pem",
+		ProviderConcurrency:                           8,
+		ProviderReadConcurrency:                       16,
+		ProviderWriteConcurrency:                      2,
+		ProviderFailoverCooldown:                      time.Minute,
+		TXTEncryptKMS:                                 "vault-transit",
+		TXTEncryptKMSKey:                              "transit/external-dns",
+		ZoneLockNamespace:                             "kube-system",
+		ZoneLockLeaseDuration:                         5 * time.Minute,
+		BackupDir:                                     "/var/lib/external-dns/backup",
+		Restore:                                       "records.yaml",
+		ExportZoneFile:                                "records.zone",
+		ExportZones:                                   []string{"example.org", "example.com"},
+		AdoptExistingRecords:                          true,
+		IgnoreTTLDrift:                                true,
+		ExternalOwnerMarkers:                          []string{"terraform", "managed-by=opentofu"},
+		GoDaddyAPIEndpoint:                            "https://godaddy-proxy.example.org",
+		ProviderCircuitBreakerFailures:                5,
+		ProviderCircuitBreakerOpenDuration:            10 * time.Minute,
+		FaultInjection:                                true,
+		FaultInjectionProfile:                         "error=0.1,max-delay=5s",
+		FaultInjectionSeed:                            42,
+		MissingZoneCacheTTL:                           30 * time.Minute,
+		LogFilterDecisions:                            true,
+		DNSControlExport:                              true,
+		AuditLog:                                      "/var/log/external-dns/audit.jsonl",
 		LogFormat:                                     "json",
+		LogOutput:                                     "syslog",
+		LogSyslogAddress:                              "udp://syslog:514",
+		LogSamplingInterval:                           time.Minute,
 		MetricsAddress:                                "127.0.0.1:9099",
+		MetricsSourceObjects:                          true,
+		EnablePprof:                                   true,
+		GoMemoryLimit:                                 "512Mi",
+		GoGCPercent:                                   50,
 		LogLevel:                                      logrus.DebugLevel.String(),
 		ConnectorSourceServer:                         "localhost:8081",
 		ExoscaleAPIEnvironment:                        "api1",
@@ -230,20 +328,38 @@ var (
 		ExoscaleAPISecret:                             "2",
 		CRDSourceAPIVersion:                           "test.k8s.io/v1alpha1",
 		CRDSourceKind:                                 "Endpoint",
+		ACMEChallengeSolverName:                       "external-dns",
 		NS1Endpoint:                                   "https://api.example.com/v1",
 		NS1IgnoreSSL:                                  true,
 		TransIPAccountName:                            "transip",
 		TransIPPrivateKeyFile:                         "/path/to/transip.key",
 		DigitalOceanAPIPageSize:                       100,
+		DigitalOceanProjects:                          []string{"project-a", "project-b"},
+		LinodeDomainTags:                              []string{"tag-a", "tag-b"},
 		ManagedDNSRecordTypes:                         []string{endpoint.RecordTypeA, endpoint.RecordTypeAAAA, endpoint.RecordTypeCNAME, endpoint.RecordTypeNS},
 		RFC2136BatchChangeSize:                        100,
 		RFC2136Host:                                   []string{"rfc2136-host1", "rfc2136-host2"},
 		RFC2136LoadBalancingStrategy:                  "round-robin",
+		RFC2136SIG0KeyDir:                             "/etc/external-dns/sig0",
+		RFC2136SIG0KeyRollover:                        720 * time.Hour,
+		ZoneSerialCheck:                               true,
+		ZoneSerialSecondaries:                         []string{"ns2.example.org", "192.0.2.2:5353"},
+		ZoneSerialCheckTimeout:                        30 * time.Second,
 		PiholeApiVersion:                              "6",
 		WebhookProviderURL:                            "http://localhost:8888",
 		WebhookProviderReadTimeout:                    5 * time.Second,
 		WebhookProviderWriteTimeout:                   10 * time.Second,
 		ExcludeUnschedulable:                          false,
+		ConfigReloadInterval:                          10 * time.Second,
+		CertManagerRenewalTTL:                         30 * time.Second,
+		CertManagerRenewalLead:                        2 * time.Hour,
+		PublicIPEndpoints:                             []string{"https://api.ipify.org", "stun:stun.l.google.com:19302"},
+		PublicIPRefreshInterval:                       time.Minute,
+		TailscaleOperatorNamespace:                    "tailscale-system",
+		TargetIPFamily:                                "ipv6",
+		SourceTargetIPFamilies:                        map[string]string{"service": "ipv4"},
+		SourceResyncPeriods:                           map[string]string{"ingress": "30m"},
+		InformerWatchList:                             true,
 	}
 )
 
@@ -272,14 +388,22 @@ func TestParseFlags(t *testing.T) {
 				"--request-timeout=77s",
 				"--gloo-namespace=gloo-not-system",
 				"--gloo-namespace=gloo-second-system",
+				"--contour-envoy-service=contour/envoy",
 				"--skipper-routegroup-groupversion=zalando.org/v2",
 				"--source=service",
 				"--source=ingress",
 				"--source=connector",
 				"--namespace=namespace",
+				"--namespace-regex=^team-",
+				"--annotation-prefix-alias=dns.mycorp.io/",
+				"--propagate-label=team",
+				"--propagate-label=cost-center",
+				"--exclude-namespaces=team-sandbox",
+				"--exclude-namespaces=kube-system",
 				"--fqdn-template={{.Name}}.service.example.com",
 				"--ignore-non-host-network-pods",
 				"--ignore-hostname-annotation",
+				"--service-default-hostnames",
 				"--ignore-ingress-tls-spec",
 				"--ignore-ingress-rules-spec",
 				"--compatibility=mate",
@@ -311,12 +435,19 @@ func TestParseFlags(t *testing.T) {
 				"--ovh-endpoint=ovh-ca",
 				"--ovh-api-rate-limit=42",
 				"--pdns-server=http://ns.example.com:8081",
+				"--pdns-server-fallback=http://ns2.example.com:8081",
+				"--pdns-server-fallback=http://ns3.example.com:8081",
 				"--pdns-server-id=localhost",
 				"--pdns-api-key=some-secret-key",
 				"--pdns-skip-tls-verify",
 				"--oci-config-file=oci.yaml",
 				"--oci-zone-scope=PRIVATE",
 				"--oci-zones-cache-duration=30s",
+				"--oci-auth-workload-identity",
+				"--oci-region=us-ashburn-1",
+				"--oci-zone-compartment-ocid=ocid1.compartment.oc1..a",
+				"--oci-zone-compartment-ocid=ocid1.compartment.oc1..b",
+				"--oci-view-id=ocid1.dnsview.oc1..a",
 				"--tls-ca=/path/to/ca.crt",
 				"--tls-client-cert=/path/to/cert.pem",
 				"--tls-client-cert-key=/path/to/key.pem",
@@ -331,10 +462,21 @@ func TestParseFlags(t *testing.T) {
 				"--zone-name-filter=yapi.company.com",
 				"--zone-id-filter=/hostedzone/ZTST1",
 				"--zone-id-filter=/hostedzone/ZTST2",
+				"--zone-selection=prefer-private",
 				"--target-net-filter=10.0.0.0/9",
 				"--target-net-filter=10.1.0.0/9",
 				"--exclude-target-net=1.0.0.0/9",
 				"--exclude-target-net=1.1.0.0/9",
+				"--target-ip-family=ipv6",
+				"--source-target-ip-family=service=ipv4",
+				"--source-resync-period=ingress=30m",
+				"--informer-watch-list",
+				"--cert-manager-renewal-ttl=30s",
+				"--cert-manager-renewal-lead=2h",
+				"--public-ip-endpoint=https://api.ipify.org",
+				"--public-ip-endpoint=stun:stun.l.google.com:19302",
+				"--public-ip-refresh-interval=1m",
+				"--tailscale-operator-namespace=tailscale-system",
 				"--aws-zone-type=private",
 				"--aws-zone-tags=tag=foo",
 				"--aws-zone-match-parent",
@@ -355,20 +497,85 @@ func TestParseFlags(t *testing.T) {
 				"--no-aws-evaluate-target-health",
 				"--pihole-api-version=6",
 				"--policy=upsert-only",
+				"--wildcard-policy=deny",
 				"--registry=noop",
 				"--txt-owner-id=owner-1",
+				"--txt-owner-id-template={{ .OwnerID }}-{{ .Namespace }}",
 				"--txt-prefix=associated-txt-record",
 				"--txt-cache-interval=12h",
 				"--txt-new-format-only",
+				"--txt-janitor-interval=6h",
+				"--txt-janitor-policy=delete",
+				"--txt-janitor-owner-id=old-cluster",
+				"--txt-janitor-owner-id=older-cluster",
 				"--dynamodb-table=custom-table",
+				"--dynamodb-create-table",
+				"--registry-migration-cutover",
+				"--dynamodb-table-tag=team=dns",
 				"--interval=10m",
 				"--min-event-sync-interval=50s",
+				"--sync-timeout=10m",
 				"--once",
 				"--dry-run",
 				"--events",
+				"--stream-records",
+				"--repeated-changes-backoff=10m",
+				"--max-deletions-per-sync=50",
+				"--max-change-percentage=20",
+				"--max-records-per-zone=5000",
+				"--provider-write-budget=500",
+				"--damping-threshold=5",
+				"--damping-window=15m",
+				"--damping-cooldown=1h",
+				"--damping-allow-clear",
+				"--change-window=0 22 * * 1-5 4h",
+				"--change-window=0 10 * * 6 2h",
+				"--change-window-timezone=Europe/Paris",
+				"--no-change-window-creates",
+				"--canary-zone=canary.example.net",
+				"--canary-nameserver=ns1.example.net:5353",
+				"--canary-timeout=30s",
+				"--pause-configmap=kube-system/external-dns-pause",
+				"--plan-configmap=kube-system/external-dns-plan",
+				"--provider-proxy-url=http://proxy.example.org:3128",
+				"--provider-ca-bundle=/etc/ssl/proxy-ca.pem",
+				"--provider-concurrency=8",
+				"--provider-read-concurrency=16",
+				"--provider-write-concurrency=2",
+				"--provider-failover-cooldown=1m",
+				"--txt-encrypt-kms=vault-transit",
+				"--txt-encrypt-kms-key=transit/external-dns",
+				"--zone-lock-namespace=kube-system",
+				"--zone-lock-lease-duration=5m",
+				"--backup-dir=/var/lib/external-dns/backup",
+				"--restore=records.yaml",
+				"--export-zone-file=records.zone",
+				"--export-zone=example.org",
+				"--export-zone=example.com",
+				"--adopt-existing-records",
+				"--ignore-ttl-drift",
+				"--external-owner-marker=terraform",
+				"--external-owner-marker=managed-by=opentofu",
+				"--godaddy-api-endpoint=https://godaddy-proxy.example.org",
+				"--provider-circuit-breaker-failures=5",
+				"--provider-circuit-breaker-open-duration=10m",
+				"--fault-injection",
+				"--fault-injection-profile=error=0.1,max-delay=5s",
+				"--fault-injection-seed=42",
+				"--missing-zone-cache-ttl=30m",
 				"--log-format=json",
 				"--metrics-address=127.0.0.1:9099",
+				"--metrics-source-objects",
+				"--enable-pprof",
+				"--go-memory-limit=512Mi",
+				"--go-gc-percent=50",
 				"--log-level=debug",
+				"--log-filter-decisions",
+				"--dnscontrol-export",
+				"--log-output=syslog",
+				"--log-syslog-address=udp://syslog:514",
+				"--log-sampling-interval=1m",
+				"--audit-log=/var/log/external-dns/audit.jsonl",
 				"--connector-source-server=localhost:8081",
 				"--exoscale-apienv=api1",
 				"--exoscale-apizone=zone1",
@@ -376,11 +583,16 @@ func TestParseFlags(t *testing.T) {
 				"--exoscale-apisecret=2",
 				"--crd-source-apiversion=test.k8s.io/v1alpha1",
 				"--crd-source-kind=Endpoint",
+				"--acme-challenge-solver-name=external-dns",
 				"--ns1-endpoint=https://api.example.com/v1",
 				"--ns1-ignoressl",
 				"--transip-account=transip",
 				"--transip-keyfile=/path/to/transip.key",
 				"--digitalocean-api-page-size=100",
+				"--digitalocean-project=project-a",
+				"--digitalocean-project=project-b",
+				"--linode-domain-tag=tag-a",
+				"--linode-domain-tag=tag-b",
 				"--managed-record-types=A",
 				"--managed-record-types=AAAA",
 				"--managed-record-types=CNAME",
@@ -388,6 +600,12 @@ func TestParseFlags(t *testing.T) {
 				"--no-exclude-unschedulable",
 				"--rfc2136-batch-change-size=100",
 				"--rfc2136-load-balancing-strategy=round-robin",
+				"--rfc2136-sig0-key-dir=/etc/external-dns/sig0",
+				"--rfc2136-sig0-key-rollover=720h",
+				"--zone-serial-check",
+				"--zone-serial-secondary=ns2.example.org",
+				"--zone-serial-secondary=192.0.2.2:5353",
+				"--zone-serial-check-timeout=30s",
 				"--rfc2136-host=rfc2136-host1",
 				"--rfc2136-host=rfc2136-host2",
 			},
@@ -403,12 +621,18 @@ func TestParseFlags(t *testing.T) {
 				"EXTERNAL_DNS_REQUEST_TIMEOUT":                                   "77s",
 				"EXTERNAL_DNS_CONTOUR_LOAD_BALANCER":                             "heptio-contour-other/contour-other",
 				"EXTERNAL_DNS_GLOO_NAMESPACE":                                    "gloo-not-system\ngloo-second-system",
+				"EXTERNAL_DNS_CONTOUR_ENVOY_SERVICE":                             "contour/envoy",
 				"EXTERNAL_DNS_SKIPPER_ROUTEGROUP_GROUPVERSION":                   "zalando.org/v2",
 				"EXTERNAL_DNS_SOURCE":                                            "service\ningress\nconnector",
 				"EXTERNAL_DNS_NAMESPACE":                                         "namespace",
+				"EXTERNAL_DNS_NAMESPACE_REGEX":                                   "^team-",
+				"EXTERNAL_DNS_ANNOTATION_PREFIX_ALIAS":                           "dns.mycorp.io/",
+				"EXTERNAL_DNS_PROPAGATE_LABEL":                                   "team\ncost-center",
+				"EXTERNAL_DNS_EXCLUDE_NAMESPACES":                                "team-sandbox\nkube-system",
 				"EXTERNAL_DNS_FQDN_TEMPLATE":                                     "{{.Name}}.service.example.com",
 				"EXTERNAL_DNS_IGNORE_NON_HOST_NETWORK_PODS":                      "1",
 				"EXTERNAL_DNS_IGNORE_HOSTNAME_ANNOTATION":                        "1",
+				"EXTERNAL_DNS_SERVICE_DEFAULT_HOSTNAMES":                         "1",
 				"EXTERNAL_DNS_IGNORE_INGRESS_TLS_SPEC":                           "1",
 				"EXTERNAL_DNS_IGNORE_INGRESS_RULES_SPEC":                         "1",
 				"EXTERNAL_DNS_COMPATIBILITY":                                     "mate",
@@ -438,6 +662,10 @@ func TestParseFlags(t *testing.T) {
 				"EXTERNAL_DNS_OCI_CONFIG_FILE":                                   "oci.yaml",
 				"EXTERNAL_DNS_OCI_ZONE_SCOPE":                                    "PRIVATE",
 				"EXTERNAL_DNS_OCI_ZONES_CACHE_DURATION":                          "30s",
+				"EXTERNAL_DNS_OCI_AUTH_WORKLOAD_IDENTITY":                        "1",
+				"EXTERNAL_DNS_OCI_REGION":                                        "us-ashburn-1",
+				"EXTERNAL_DNS_OCI_ZONE_COMPARTMENT_OCID":                         "ocid1.compartment.oc1..a\nocid1.compartment.oc1..b",
+				"EXTERNAL_DNS_OCI_VIEW_ID":                                       "ocid1.dnsview.oc1..a",
 				"EXTERNAL_DNS_INMEMORY_ZONE":                                     "example.org\ncompany.com",
 				"EXTERNAL_DNS_OVH_ENDPOINT":                                      "ovh-ca",
 				"EXTERNAL_DNS_OVH_API_RATE_LIMIT":                                "42",
@@ -448,7 +676,17 @@ func TestParseFlags(t *testing.T) {
 				"EXTERNAL_DNS_REGEX_DOMAIN_EXCLUSION":                            "xapi\\.(example\\.org|company\\.com)$",
 				"EXTERNAL_DNS_TARGET_NET_FILTER":                                 "10.0.0.0/9\n10.1.0.0/9",
 				"EXTERNAL_DNS_EXCLUDE_TARGET_NET":                                "1.0.0.0/9\n1.1.0.0/9",
+				"EXTERNAL_DNS_TARGET_IP_FAMILY":                                  "ipv6",
+				"EXTERNAL_DNS_SOURCE_TARGET_IP_FAMILY":                           "service=ipv4",
+				"EXTERNAL_DNS_SOURCE_RESYNC_PERIOD":                              "ingress=30m",
+				"EXTERNAL_DNS_INFORMER_WATCH_LIST":                               "1",
+				"EXTERNAL_DNS_CERT_MANAGER_RENEWAL_TTL":                          "30s",
+				"EXTERNAL_DNS_CERT_MANAGER_RENEWAL_LEAD":                         "2h",
+				"EXTERNAL_DNS_PUBLIC_IP_ENDPOINT":                                "https://api.ipify.org\nstun:stun.l.google.com:19302",
+				"EXTERNAL_DNS_PUBLIC_IP_REFRESH_INTERVAL":                        "1m",
+				"EXTERNAL_DNS_TAILSCALE_OPERATOR_NAMESPACE":                      "tailscale-system",
 				"EXTERNAL_DNS_PDNS_SERVER":                                       "http://ns.example.com:8081",
+				"EXTERNAL_DNS_PDNS_SERVER_FALLBACK":                              "http://ns2.example.com:8081\nhttp://ns3.example.com:8081",
 				"EXTERNAL_DNS_PDNS_ID":                                           "localhost",
 				"EXTERNAL_DNS_PDNS_API_KEY":                                      "some-secret-key",
 				"EXTERNAL_DNS_PDNS_SKIP_TLS_VERIFY":                              "1",
@@ -458,6 +696,7 @@ func TestParseFlags(t *testing.T) {
 				"EXTERNAL_DNS_TLS_CLIENT_CERT_KEY":                               "/path/to/key.pem",
 				"EXTERNAL_DNS_ZONE_NAME_FILTER":                                  "yapi.example.org\nyapi.company.com",
 				"EXTERNAL_DNS_ZONE_ID_FILTER":                                    "/hostedzone/ZTST1\n/hostedzone/ZTST2",
+				"EXTERNAL_DNS_ZONE_SELECTION":                                    "prefer-private",
 				"EXTERNAL_DNS_AWS_ZONE_TYPE":                                     "private",
 				"EXTERNAL_DNS_AWS_ZONE_TAGS":                                     "tag=foo",
 				"EXTERNAL_DNS_AWS_ZONE_MATCH_PARENT":                             "true",
@@ -475,21 +714,82 @@ func TestParseFlags(t *testing.T) {
 				"EXTERNAL_DNS_AWS_SD_SERVICE_CLEANUP":                            "true",
 				"EXTERNAL_DNS_AWS_SD_CREATE_TAG":                                 "key1=value1\nkey2=value2",
 				"EXTERNAL_DNS_DYNAMODB_TABLE":                                    "custom-table",
+				"EXTERNAL_DNS_DYNAMODB_CREATE_TABLE":                             "1",
+				"EXTERNAL_DNS_REGISTRY_MIGRATION_CUTOVER":                        "1",
+				"EXTERNAL_DNS_DYNAMODB_TABLE_TAG":                                "team=dns",
 				"EXTERNAL_DNS_PIHOLE_API_VERSION":                                "6",
 				"EXTERNAL_DNS_POLICY":                                            "upsert-only",
+				"EXTERNAL_DNS_WILDCARD_POLICY":                                   "deny",
 				"EXTERNAL_DNS_REGISTRY":                                          "noop",
 				"EXTERNAL_DNS_TXT_OWNER_ID":                                      "owner-1",
+				"EXTERNAL_DNS_TXT_OWNER_ID_TEMPLATE":                             "{{ .OwnerID }}-{{ .Namespace }}",
 				"EXTERNAL_DNS_TXT_PREFIX":                                        "associated-txt-record",
 				"EXTERNAL_DNS_TXT_CACHE_INTERVAL":                                "12h",
 				"EXTERNAL_DNS_TXT_NEW_FORMAT_ONLY":                               "1",
+				"EXTERNAL_DNS_TXT_JANITOR_INTERVAL":                              "6h",
+				"EXTERNAL_DNS_TXT_JANITOR_POLICY":                                "delete",
+				"EXTERNAL_DNS_TXT_JANITOR_OWNER_ID":                              "old-cluster\nolder-cluster",
 				"EXTERNAL_DNS_INTERVAL":                                          "10m",
 				"EXTERNAL_DNS_MIN_EVENT_SYNC_INTERVAL":                           "50s",
+				"EXTERNAL_DNS_SYNC_TIMEOUT":                                      "10m",
 				"EXTERNAL_DNS_ONCE":                                              "1",
 				"EXTERNAL_DNS_DRY_RUN":                                           "1",
 				"EXTERNAL_DNS_EVENTS":                                            "1",
+				"EXTERNAL_DNS_STREAM_RECORDS":                                    "1",
+				"EXTERNAL_DNS_REPEATED_CHANGES_BACKOFF":                          "10m",
+				"EXTERNAL_DNS_MAX_DELETIONS_PER_SYNC":                            "50",
+				"EXTERNAL_DNS_MAX_CHANGE_PERCENTAGE":                             "20",
+				"EXTERNAL_DNS_MAX_RECORDS_PER_ZONE":                              "5000",
+				"EXTERNAL_DNS_PROVIDER_WRITE_BUDGET":                             "500",
+				"EXTERNAL_DNS_DAMPING_THRESHOLD":                                 "5",
+				"EXTERNAL_DNS_DAMPING_WINDOW":                                    "15m",
+				"EXTERNAL_DNS_DAMPING_COOLDOWN":                                  "1h",
+				"EXTERNAL_DNS_DAMPING_ALLOW_CLEAR":                               "1",
+				"EXTERNAL_DNS_CHANGE_WINDOW":                                     "0 22 * * 1-5 4h\n0 10 * * 6 2h",
+				"EXTERNAL_DNS_CHANGE_WINDOW_TIMEZONE":                            "Europe/Paris",
+				"EXTERNAL_DNS_CHANGE_WINDOW_CREATES":                             "0",
+				"EXTERNAL_DNS_CANARY_ZONE":                                       "canary.example.net",
+				"EXTERNAL_DNS_CANARY_NAMESERVER":                                 "ns1.example.net:5353",
+				"EXTERNAL_DNS_CANARY_TIMEOUT":                                    "30s",
+				"EXTERNAL_DNS_PAUSE_CONFIGMAP":                                   "kube-system/external-dns-pause",
+				"EXTERNAL_DNS_PLAN_CONFIGMAP":                                    "kube-system/external-dns-plan",
+				"EXTERNAL_DNS_PROVIDER_PROXY_URL":                                "http://proxy.example.org:3128",
+				"EXTERNAL_DNS_PROVIDER_CA_BUNDLE":                                "/etc/ssl/proxy-ca.pem",
+				"EXTERNAL_DNS_PROVIDER_CONCURRENCY":                              "8",
+				"EXTERNAL_DNS_PROVIDER_READ_CONCURRENCY":                         "16",
+				"EXTERNAL_DNS_PROVIDER_WRITE_CONCURRENCY":                        "2",
+				"EXTERNAL_DNS_PROVIDER_FAILOVER_COOLDOWN":                        "1m",
+				"EXTERNAL_DNS_TXT_ENCRYPT_KMS":                                   "vault-transit",
+				"EXTERNAL_DNS_TXT_ENCRYPT_KMS_KEY":                               "transit/external-dns",
+				"EXTERNAL_DNS_ZONE_LOCK_NAMESPACE":                               "kube-system",
+				"EXTERNAL_DNS_ZONE_LOCK_LEASE_DURATION":                          "5m",
+				"EXTERNAL_DNS_BACKUP_DIR":                                        "/var/lib/external-dns/backup",
+				"EXTERNAL_DNS_RESTORE":                                           "records.yaml",
+				"EXTERNAL_DNS_EXPORT_ZONE_FILE":                                  "records.zone",
+				"EXTERNAL_DNS_EXPORT_ZONE":                                       "example.org\nexample.com",
+				"EXTERNAL_DNS_ADOPT_EXISTING_RECORDS":                            "1",
+				"EXTERNAL_DNS_IGNORE_TTL_DRIFT":                                  "1",
+				"EXTERNAL_DNS_EXTERNAL_OWNER_MARKER":                             "terraform\nmanaged-by=opentofu",
+				"EXTERNAL_DNS_GODADDY_API_ENDPOINT":                              "https://godaddy-proxy.example.org",
+				"EXTERNAL_DNS_PROVIDER_CIRCUIT_BREAKER_FAILURES":                 "5",
+				"EXTERNAL_DNS_PROVIDER_CIRCUIT_BREAKER_OPEN_DURATION":            "10m",
+				"EXTERNAL_DNS_FAULT_INJECTION":                                   "1",
+				"EXTERNAL_DNS_FAULT_INJECTION_PROFILE":                           "error=0.1,max-delay=5s",
+				"EXTERNAL_DNS_FAULT_INJECTION_SEED":                              "42",
+				"EXTERNAL_DNS_MISSING_ZONE_CACHE_TTL":                            "30m",
 				"EXTERNAL_DNS_LOG_FORMAT":                                        "json",
 				"EXTERNAL_DNS_METRICS_ADDRESS":                                   "127.0.0.1:9099",
+				"EXTERNAL_DNS_METRICS_SOURCE_OBJECTS":                            "1",
+				"EXTERNAL_DNS_ENABLE_PPROF":                                      "1",
+				"EXTERNAL_DNS_GO_MEMORY_LIMIT":                                   "512Mi",
+				"EXTERNAL_DNS_GO_GC_PERCENT":                                     "50",
 				"EXTERNAL_DNS_LOG_LEVEL":                                         "debug",
+				"EXTERNAL_DNS_LOG_FILTER_DECISIONS":                              "1",
+				"EXTERNAL_DNS_DNSCONTROL_EXPORT":                                 "1",
+				"EXTERNAL_DNS_LOG_OUTPUT":                                        "syslog",
+				"EXTERNAL_DNS_LOG_SYSLOG_ADDRESS":                                "udp://syslog:514",
+				"EXTERNAL_DNS_LOG_SAMPLING_INTERVAL":                             "1m",
+				"EXTERNAL_DNS_AUDIT_LOG":                                         "/var/log/external-dns/audit.jsonl",
 				"EXTERNAL_DNS_CONNECTOR_SOURCE_SERVER":                           "localhost:8081",
 				"EXTERNAL_DNS_EXOSCALE_APIENV":                                   "api1",
 				"EXTERNAL_DNS_EXOSCALE_APIZONE":                                  "zone1",
@@ -497,15 +797,23 @@ func TestParseFlags(t *testing.T) {
 				"EXTERNAL_DNS_EXOSCALE_APISECRET":                                "2",
 				"EXTERNAL_DNS_CRD_SOURCE_APIVERSION":                             "test.k8s.io/v1alpha1",
 				"EXTERNAL_DNS_CRD_SOURCE_KIND":                                   "Endpoint",
+				"EXTERNAL_DNS_ACME_CHALLENGE_SOLVER_NAME":                        "external-dns",
 				"EXTERNAL_DNS_NS1_ENDPOINT":                                      "https://api.example.com/v1",
 				"EXTERNAL_DNS_NS1_IGNORESSL":                                     "1",
 				"EXTERNAL_DNS_TRANSIP_ACCOUNT":                                   "transip",
 				"EXTERNAL_DNS_TRANSIP_KEYFILE":                                   "/path/to/transip.key",
 				"EXTERNAL_DNS_DIGITALOCEAN_API_PAGE_SIZE":                        "100",
+				"EXTERNAL_DNS_DIGITALOCEAN_PROJECT":                              "project-a\nproject-b",
+				"EXTERNAL_DNS_LINODE_DOMAIN_TAG":                                 "tag-a\ntag-b",
 				"EXTERNAL_DNS_MANAGED_RECORD_TYPES":                              "A\nAAAA\nCNAME\nNS",
 				"EXTERNAL_DNS_EXCLUDE_UNSCHEDULABLE":                             "false",
 				"EXTERNAL_DNS_RFC2136_BATCH_CHANGE_SIZE":                         "100",
 				"EXTERNAL_DNS_RFC2136_LOAD_BALANCING_STRATEGY":                   "round-robin",
+				"EXTERNAL_DNS_RFC2136_SIG0_KEY_DIR":                              "/etc/external-dns/sig0",
+				"EXTERNAL_DNS_RFC2136_SIG0_KEY_ROLLOVER":                         "720h",
+				"EXTERNAL_DNS_ZONE_SERIAL_CHECK":                                 "1",
+				"EXTERNAL_DNS_ZONE_SERIAL_SECONDARY":                             "ns2.example.org\n192.0.2.2:5353",
+				"EXTERNAL_DNS_ZONE_SERIAL_CHECK_TIMEOUT":                         "30s",
 				"EXTERNAL_DNS_RFC2136_HOST":                                      "rfc2136-host1\nrfc2136-host2",
 			},
 			expected: overriddenConfig,
@@ -552,3 +860,31 @@ func TestPasswordsNotLogged(t *testing.T) {
 	assert.NotContains(t, s, "pdns-api-key")
 	assert.NotContains(t, s, "tsig-secret")
 }
+
+func TestParseFlagsSimulateCommand(t *testing.T) {
+	cfg := NewConfig()
+	require.NoError(t, cfg.ParseFlags([]string{"--provider=inmemory", "--source=ingress", "simulate", "-f", "ingress.yaml", "--domain-filter=example.org"}))
+	assert.Equal(t, CommandSimulate, cfg.Command)
+	assert.Equal(t, "ingress.yaml", cfg.SimulateManifest)
+	assert.Equal(t, []string{"ingress"}, cfg.Sources)
+	assert.Equal(t, []string{"example.org"}, cfg.DomainFilter)
+
+	// the manifest is required
+	require.Error(t, NewConfig().ParseFlags([]string{"simulate", "--provider=inmemory", "--source=ingress"}))
+}
+
+func TestParseFlagsPreflightCommand(t *testing.T) {
+	cfg := NewConfig()
+	require.NoError(t, cfg.ParseFlags([]string{"preflight", "--provider=google", "--source=service"}))
+	assert.Equal(t, CommandPreflight, cfg.Command)
+	assert.Equal(t, "google", cfg.Provider)
+
+	// the flags of the application can be given before the command
+	cfg = NewConfig()
+	require.NoError(t, cfg.ParseFlags([]string{"--provider=google", "preflight", "--source=service"}))
+	assert.Equal(t, CommandPreflight, cfg.Command)
+
+	cfg = NewConfig()
+	require.NoError(t, cfg.ParseFlags([]string{"--provider=google", "--source=service"}))
+	assert.Equal(t, CommandRun, cfg.Command)
+}
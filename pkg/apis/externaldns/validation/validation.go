@@ -19,10 +19,25 @@ package validation
 import (
 	"errors"
 	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+	"text/template"
+	"time"
 
+	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/labels"
+	k8svalidation "k8s.io/apimachinery/pkg/util/validation"
 
+	"sigs.k8s.io/external-dns/endpoint"
 	"sigs.k8s.io/external-dns/pkg/apis/externaldns"
+	"sigs.k8s.io/external-dns/pkg/canary"
+	"sigs.k8s.io/external-dns/pkg/changewindow"
+	"sigs.k8s.io/external-dns/pkg/egress"
+	"sigs.k8s.io/external-dns/pkg/failover"
+	"sigs.k8s.io/external-dns/pkg/publicip"
+	"sigs.k8s.io/external-dns/pkg/zoneserial"
+	"sigs.k8s.io/external-dns/provider"
 )
 
 // ValidateConfig performs validation on the Config object
@@ -41,14 +56,252 @@ func ValidateConfig(cfg *externaldns.Config) error {
 		return errors.New("FQDN Template must be set if ignoring annotations")
 	}
 
+	if cfg.ServiceDefaultHostnames && cfg.FQDNTemplate == "" {
+		return errors.New("--service-default-hostnames requires --fqdn-template")
+	}
+
 	if len(cfg.TXTPrefix) > 0 && len(cfg.TXTSuffix) > 0 {
 		return errors.New("txt-prefix and txt-suffix are mutual exclusive")
 	}
 
+	if cfg.TXTNameTemplate != "" && (len(cfg.TXTPrefix) > 0 || len(cfg.TXTSuffix) > 0) && !cfg.TXTNameTemplateMigration {
+		return errors.New("txt-name-template is mutual exclusive with txt-prefix and txt-suffix, unless migrating from them with txt-name-template-migration")
+	}
+
+	if cfg.TXTNameTemplateMigration && cfg.TXTNameTemplate == "" {
+		return errors.New("txt-name-template-migration requires txt-name-template")
+	}
+
+	if cfg.MaxDeletionsPerSync < 0 {
+		return errors.New("--max-deletions-per-sync must not be negative")
+	}
+
+	if cfg.MaxChangePercentage < 0 || cfg.MaxChangePercentage > 100 {
+		return errors.New("--max-change-percentage must be between 0 and 100")
+	}
+
+	if cfg.MaxRecordsPerZone < 0 {
+		return errors.New("--max-records-per-zone must not be negative")
+	}
+
+	if cfg.ZoneLockNamespace != "" && cfg.ZoneLockLeaseDuration < time.Second {
+		return errors.New("--zone-lock-lease-duration must be at least one second")
+	}
+
+	if cfg.SyncTimeout < 0 {
+		return errors.New("--sync-timeout must not be negative")
+	}
+
+	if cfg.ProviderWriteBudget < 0 {
+		return errors.New("--provider-write-budget must not be negative")
+	}
+
+	if _, err := changewindow.ParseAll(cfg.ChangeWindows); err != nil {
+		return err
+	}
+
+	if _, err := time.LoadLocation(cfg.ChangeWindowTimezone); err != nil {
+		return fmt.Errorf("invalid --change-window-timezone: %w", err)
+	}
+
+	if cfg.TXTEncryptKMS != "" && cfg.TXTEncryptKMSKey == "" {
+		return errors.New("--txt-encrypt-kms requires --txt-encrypt-kms-key")
+	}
+
+	if cfg.CanaryNameserver != "" {
+		if cfg.CanaryZone == "" {
+			return errors.New("--canary-nameserver requires --canary-zone")
+		}
+		if err := canary.ValidateServer(cfg.CanaryNameserver); err != nil {
+			return fmt.Errorf("--canary-nameserver: %w", err)
+		}
+		if cfg.CanaryTimeout < time.Second {
+			return errors.New("--canary-timeout must be at least one second")
+		}
+	}
+
+	if cfg.DampingThreshold < 0 {
+		return errors.New("--damping-threshold must not be negative")
+	}
+
+	if cfg.DampingThreshold > 0 && (cfg.DampingWindow <= 0 || cfg.DampingCooldown < 0) {
+		return errors.New("--damping-window must be positive and --damping-cooldown must not be negative")
+	}
+
+	if cfg.DampingThreshold > 0 && cfg.DampingCooldown == 0 && !cfg.DampingAllowClear {
+		return errors.New("--damping-cooldown=0 requires --damping-allow-clear, the held records being only released by hand")
+	}
+
+	if cfg.GoMemoryLimit != "" {
+		if limit, err := resource.ParseQuantity(cfg.GoMemoryLimit); err != nil || limit.Sign() <= 0 {
+			return errors.New("--go-memory-limit must be a positive quantity of bytes, e.g. 512Mi")
+		}
+	}
+
+	if cfg.LogSyslogAddress != "" && cfg.LogOutput != "syslog" {
+		return errors.New("--log-syslog-address requires --log-output=syslog")
+	}
+
+	if cfg.LogSamplingInterval < 0 {
+		return errors.New("--log-sampling-interval must not be negative")
+	}
+
+	if cfg.GoGCPercent < 0 {
+		return errors.New("--go-gc-percent must not be negative")
+	}
+
+	if cfg.PauseConfigMap != "" {
+		if namespace, name, ok := strings.Cut(cfg.PauseConfigMap, "/"); !ok || namespace == "" || name == "" {
+			return errors.New("--pause-configmap must be namespace/name")
+		}
+	}
+
+	if cfg.ProviderProxyURL != "" {
+		if err := egress.ValidateProxyURL(cfg.ProviderProxyURL); err != nil {
+			return fmt.Errorf("--provider-proxy-url: %w", err)
+		}
+	}
+
+	if cfg.ProviderConcurrency < 0 || cfg.ProviderReadConcurrency < 0 || cfg.ProviderWriteConcurrency < 0 {
+		return errors.New("--provider-concurrency, --provider-read-concurrency and --provider-write-concurrency must not be negative")
+	}
+
+	if cfg.ProviderFailoverCooldown < 0 {
+		return errors.New("--provider-failover-cooldown must not be negative")
+	}
+
+	for flag, endpoints := range map[string][]string{"--pdns-server-fallback": cfg.PDNSFallbackServers, "--webhook-provider-fallback-url": cfg.WebhookProviderFallbackURLs} {
+		for _, endpoint := range endpoints {
+			if err := failover.ValidateEndpoint(endpoint); err != nil {
+				return fmt.Errorf("%s: %w", flag, err)
+			}
+		}
+	}
+
+	if cfg.PlanConfigMap != "" {
+		if namespace, name, ok := strings.Cut(cfg.PlanConfigMap, "/"); !ok || namespace == "" || name == "" {
+			return errors.New("--plan-configmap must be namespace/name")
+		}
+	}
+
+	if cfg.ProviderCircuitBreakerFailures < 0 {
+		return errors.New("--provider-circuit-breaker-failures must not be negative")
+	}
+
+	if cfg.FaultInjection {
+		if _, err := provider.ParseFaultProfile(cfg.FaultInjectionProfile); err != nil {
+			return fmt.Errorf("invalid --fault-injection-profile: %w", err)
+		}
+	}
+
+	if len(cfg.ExportZones) > 0 && cfg.ExportZoneFile == "" {
+		return errors.New("--export-zone requires --export-zone-file")
+	}
+
+	if slices.Contains(cfg.ExternalOwnerMarkers, "") {
+		return errors.New("--external-owner-marker must not be empty")
+	}
+
+	if cfg.AdoptExistingRecords && cfg.Registry != "txt" {
+		return errors.New("--adopt-existing-records is only supported by the txt registry")
+	}
+
+	if cfg.RegistryMigrationCutover && cfg.Registry != "txt-to-dynamodb" {
+		return errors.New("--registry-migration-cutover is only supported by the txt-to-dynamodb registry")
+	}
+
+	if cfg.TXTJanitorInterval < 0 {
+		return errors.New("--txt-janitor-interval must not be negative")
+	}
+
+	if cfg.TXTJanitorInterval > 0 && cfg.Registry != "txt" {
+		return errors.New("--txt-janitor-interval is only supported by the txt registry")
+	}
+
+	if slices.Contains(cfg.TXTJanitorOwnerIDs, "") {
+		return errors.New("--txt-janitor-owner-id must not be empty")
+	}
+
+	if cfg.TXTOwnerIDTemplate != "" {
+		if cfg.Registry != "txt" {
+			return errors.New("--txt-owner-id-template is only supported by the txt registry")
+		}
+		if _, err := template.New("owner-id").Parse(cfg.TXTOwnerIDTemplate); err != nil {
+			return fmt.Errorf("invalid --txt-owner-id-template: %w", err)
+		}
+	}
+
+	if cfg.ZoneSerialCheck && cfg.Provider != "pdns" && cfg.Provider != "rfc2136" {
+		return errors.New("--zone-serial-check is only supported by the pdns and rfc2136 providers")
+	}
+
+	if len(cfg.ZoneSerialSecondaries) > 0 && !cfg.ZoneSerialCheck {
+		return errors.New("--zone-serial-secondary requires --zone-serial-check")
+	}
+
+	for _, secondary := range cfg.ZoneSerialSecondaries {
+		if err := zoneserial.ValidateServer(secondary); err != nil {
+			return fmt.Errorf("--zone-serial-secondary: %w", err)
+		}
+	}
+
+	if cfg.ZoneSerialCheck && cfg.ZoneSerialCheckTimeout < time.Second {
+		return errors.New("--zone-serial-check-timeout must be at least one second")
+	}
+
 	_, err := labels.Parse(cfg.LabelFilter)
 	if err != nil {
 		return errors.New("--label-filter does not specify a valid label selector")
 	}
+
+	for _, prefix := range cfg.AnnotationPrefixAliases {
+		if errs := k8svalidation.IsDNS1123Subdomain(strings.TrimSuffix(prefix, "/")); len(errs) > 0 {
+			return fmt.Errorf("--annotation-prefix-alias %q is not a valid annotation prefix: %s", prefix, strings.Join(errs, "; "))
+		}
+	}
+
+	if cfg.Namespace != "" {
+		if slices.Contains(cfg.ExcludeNamespaces, cfg.Namespace) {
+			return fmt.Errorf("--namespace %q is excluded by --exclude-namespaces", cfg.Namespace)
+		}
+		if cfg.NamespaceRegex != nil && !cfg.NamespaceRegex.MatchString(cfg.Namespace) {
+			return fmt.Errorf("--namespace %q does not match --namespace-regex %q", cfg.Namespace, cfg.NamespaceRegex)
+		}
+	}
+
+	for name, ipFamily := range cfg.SourceTargetIPFamilies {
+		if !slices.Contains(cfg.Sources, name) {
+			return fmt.Errorf("--source-target-ip-family is set for source %q which is not enabled", name)
+		}
+		if !slices.Contains(endpoint.KnownIPFamilies, ipFamily) {
+			return fmt.Errorf("--source-target-ip-family has an unsupported IP family %q for source %q", ipFamily, name)
+		}
+	}
+	for name, period := range cfg.SourceResyncPeriods {
+		if !slices.Contains(cfg.Sources, name) {
+			return fmt.Errorf("--source-resync-period is set for source %q which is not enabled", name)
+		}
+		if d, err := time.ParseDuration(period); err != nil || d < 0 {
+			return fmt.Errorf("--source-resync-period has an invalid duration %q for source %q", period, name)
+		}
+	}
+	if slices.Contains(cfg.Sources, "acme-challenge") && !slices.Contains(cfg.ManagedDNSRecordTypes, endpoint.RecordTypeTXT) {
+		return errors.New("the acme-challenge source requires TXT in --managed-record-types")
+	}
+	for _, endpoint := range cfg.PublicIPEndpoints {
+		if err := publicip.ValidateEndpoint(endpoint); err != nil {
+			return fmt.Errorf("--public-ip-endpoint %q is invalid: %w", endpoint, err)
+		}
+	}
+	if len(cfg.PublicIPEndpoints) > 0 && cfg.PublicIPRefreshInterval < time.Second {
+		return errors.New("--public-ip-refresh-interval must be at least 1s")
+	}
+	if cfg.CertManagerRenewalTTL < 0 || (cfg.CertManagerRenewalTTL > 0 && cfg.CertManagerRenewalTTL < time.Second) {
+		return errors.New("--cert-manager-renewal-ttl must be at least 1s")
+	}
+	if cfg.CertManagerRenewalLead < 0 {
+		return errors.New("--cert-manager-renewal-lead must not be negative")
+	}
 	return nil
 }
 
@@ -73,11 +326,41 @@ func validateConfigForProvider(cfg *externaldns.Config) error {
 		return validateConfigForAkamai(cfg)
 	case "rfc2136":
 		return validateConfigForRfc2136(cfg)
+	case "godaddy":
+		return validateConfigForGoDaddy(cfg)
+	case "oci":
+		return validateConfigForOCI(cfg)
 	default:
 		return nil
 	}
 }
 
+func validateConfigForGoDaddy(cfg *externaldns.Config) error {
+	if cfg.GoDaddyAPIEndpoint == "" {
+		return nil
+	}
+	if cfg.GoDaddyOTE {
+		return errors.New("--godaddy-api-endpoint and --godaddy-api-ote are mutually exclusive")
+	}
+	if u, err := url.Parse(cfg.GoDaddyAPIEndpoint); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("--godaddy-api-endpoint must be an http or https URL, got %q", cfg.GoDaddyAPIEndpoint)
+	}
+	return nil
+}
+
+func validateConfigForOCI(cfg *externaldns.Config) error {
+	if cfg.OCIAuthInstancePrincipal && cfg.OCIAuthWorkloadIdentity {
+		return errors.New("--oci-auth-instance-principal and --oci-auth-workload-identity are mutually exclusive")
+	}
+	if (cfg.OCIAuthInstancePrincipal || cfg.OCIAuthWorkloadIdentity) && cfg.OCICompartmentOCID == "" {
+		return errors.New("--oci-compartment-ocid is required with --oci-auth-instance-principal or --oci-auth-workload-identity")
+	}
+	if len(cfg.OCIViewIDs) > 0 && cfg.OCIZoneScope == "GLOBAL" {
+		return errors.New("--oci-view-id only applies to private zones, set --oci-zone-scope to PRIVATE or empty")
+	}
+	return nil
+}
+
 func validateConfigForAzure(cfg *externaldns.Config) error {
 	if cfg.AzureConfigFile == "" {
 		return errors.New("no Azure config file specified")
@@ -113,6 +396,15 @@ func validateConfigForRfc2136(cfg *externaldns.Config) error {
 			return errors.New("--rfc2136-kerberos-realm, --rfc2136-kerberos-username, and --rfc2136-kerberos-password are required when specifying --rfc2136-gss-tsig option")
 		}
 	}
+	if cfg.RFC2136SIG0KeyDir != "" && (cfg.RFC2136Insecure || cfg.RFC2136GSSTSIG) {
+		return errors.New("--rfc2136-sig0-key-dir is mutually exclusive with --rfc2136-insecure and --rfc2136-gss-tsig")
+	}
+	if cfg.RFC2136SIG0KeyRollover < 0 {
+		return errors.New("--rfc2136-sig0-key-rollover cannot be negative")
+	}
+	if cfg.RFC2136SIG0KeyRollover > 0 && cfg.RFC2136SIG0KeyDir == "" {
+		return errors.New("--rfc2136-sig0-key-dir is required when specifying --rfc2136-sig0-key-rollover")
+	}
 	if cfg.RFC2136BatchChangeSize < 1 {
 		return errors.New("batch size specified for rfc2136 cannot be less than 1")
 	}
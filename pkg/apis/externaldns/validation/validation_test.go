@@ -17,8 +17,11 @@ limitations under the License.
 package validation
 
 import (
+	"regexp"
 	"testing"
+	"time"
 
+	"sigs.k8s.io/external-dns/endpoint"
 	"sigs.k8s.io/external-dns/pkg/apis/externaldns"
 
 	"github.com/stretchr/testify/assert"
@@ -61,6 +64,22 @@ func TestValidateFlags(t *testing.T) {
 	cfg.TXTSuffix = "bar"
 	require.Error(t, ValidateConfig(cfg))
 
+	cfg = newValidConfig(t)
+	cfg.TXTNameTemplate = "_owner.%{record_type}.%{host}.%{domain}"
+	require.NoError(t, ValidateConfig(cfg))
+
+	cfg = newValidConfig(t)
+	cfg.TXTNameTemplate = "_owner.%{record_type}.%{host}.%{domain}"
+	cfg.TXTPrefix = "foo"
+	require.Error(t, ValidateConfig(cfg))
+
+	cfg.TXTNameTemplateMigration = true
+	require.NoError(t, ValidateConfig(cfg))
+
+	cfg = newValidConfig(t)
+	cfg.TXTNameTemplateMigration = true
+	require.Error(t, ValidateConfig(cfg))
+
 	cfg = newValidConfig(t)
 	cfg.LabelFilter = "foo"
 	require.NoError(t, ValidateConfig(cfg))
@@ -72,6 +91,207 @@ func TestValidateFlags(t *testing.T) {
 	cfg = newValidConfig(t)
 	cfg.LabelFilter = "#invalid-selector"
 	require.Error(t, ValidateConfig(cfg))
+
+	cfg = newValidConfig(t)
+	cfg.MaxDeletionsPerSync = -1
+	require.Error(t, ValidateConfig(cfg))
+
+	for _, percentage := range []int{-1, 101} {
+		cfg = newValidConfig(t)
+		cfg.MaxChangePercentage = percentage
+		require.Error(t, ValidateConfig(cfg))
+	}
+
+	cfg = newValidConfig(t)
+	cfg.MaxChangePercentage = 100
+	require.NoError(t, ValidateConfig(cfg))
+
+	cfg = newValidConfig(t)
+	cfg.MaxRecordsPerZone = -1
+	require.Error(t, ValidateConfig(cfg))
+
+	cfg = newValidConfig(t)
+	cfg.ProviderWriteBudget = -1
+	require.Error(t, ValidateConfig(cfg))
+
+	cfg = newValidConfig(t)
+	cfg.SyncTimeout = -time.Minute
+	require.Error(t, ValidateConfig(cfg))
+
+	cfg = newValidConfig(t)
+	cfg.DampingThreshold = 3
+	cfg.DampingWindow = 10 * time.Minute
+	cfg.DampingCooldown = 30 * time.Minute
+	require.NoError(t, ValidateConfig(cfg))
+	cfg.DampingCooldown = 0
+	require.Error(t, ValidateConfig(cfg))
+	cfg.DampingAllowClear = true
+	require.NoError(t, ValidateConfig(cfg))
+	cfg.DampingWindow = 0
+	require.Error(t, ValidateConfig(cfg))
+	cfg.DampingThreshold = -1
+	require.Error(t, ValidateConfig(cfg))
+
+	cfg = newValidConfig(t)
+	cfg.ChangeWindows = []string{"0 22 * * 1-5 4h"}
+	cfg.ChangeWindowTimezone = "Europe/Paris"
+	require.NoError(t, ValidateConfig(cfg))
+	cfg.ChangeWindowTimezone = "Mars/Olympus_Mons"
+	require.Error(t, ValidateConfig(cfg))
+	cfg.ChangeWindowTimezone = "UTC"
+	cfg.ChangeWindows = []string{"0 22 * * 1-5"}
+	require.Error(t, ValidateConfig(cfg))
+
+	cfg = newValidConfig(t)
+	cfg.CanaryNameserver = "ns1.example.net"
+	cfg.CanaryTimeout = time.Minute
+	require.Error(t, ValidateConfig(cfg))
+	cfg.CanaryZone = "canary.example.net"
+	require.NoError(t, ValidateConfig(cfg))
+	cfg.CanaryTimeout = 0
+	require.Error(t, ValidateConfig(cfg))
+	cfg.CanaryTimeout = time.Minute
+	cfg.CanaryNameserver = "ns1.example.net:53:53"
+	require.Error(t, ValidateConfig(cfg))
+
+	for _, limit := range []string{"512", "not-a-quantity", "-1Gi", "0"} {
+		cfg = newValidConfig(t)
+		cfg.GoMemoryLimit = limit
+		if limit == "512" {
+			require.NoError(t, ValidateConfig(cfg))
+		} else {
+			require.Error(t, ValidateConfig(cfg), limit)
+		}
+	}
+
+	cfg = newValidConfig(t)
+	cfg.GoGCPercent = -1
+	require.Error(t, ValidateConfig(cfg))
+
+	cfg = newValidConfig(t)
+	cfg.LogSyslogAddress = "udp://syslog:514"
+	require.Error(t, ValidateConfig(cfg))
+	cfg.LogOutput = "syslog"
+	require.NoError(t, ValidateConfig(cfg))
+
+	cfg = newValidConfig(t)
+	cfg.LogSamplingInterval = -1
+	require.Error(t, ValidateConfig(cfg))
+
+	cfg = newValidConfig(t)
+	cfg.ZoneLockNamespace = "kube-system"
+	cfg.ZoneLockLeaseDuration = 0
+	require.Error(t, ValidateConfig(cfg))
+
+	for _, configMap := range []string{"external-dns-pause", "/external-dns-pause", "kube-system/"} {
+		cfg = newValidConfig(t)
+		cfg.PauseConfigMap = configMap
+		require.Error(t, ValidateConfig(cfg), configMap)
+	}
+
+	cfg = newValidConfig(t)
+	cfg.PauseConfigMap = "kube-system/external-dns-pause"
+	require.NoError(t, ValidateConfig(cfg))
+
+	for proxyURL, valid := range map[string]bool{"http://proxy.example.org:3128": true, "socks5://10.0.0.1:1080": true, "ftp://proxy.example.org": false, "proxy.example.org:3128": false, "http://": false} {
+		cfg = newValidConfig(t)
+		cfg.ProviderProxyURL = proxyURL
+		if valid {
+			require.NoError(t, ValidateConfig(cfg), proxyURL)
+		} else {
+			require.Error(t, ValidateConfig(cfg), proxyURL)
+		}
+	}
+
+	cfg = newValidConfig(t)
+	cfg.ProviderConcurrency, cfg.ProviderReadConcurrency = 8, 16
+	require.NoError(t, ValidateConfig(cfg))
+	cfg.ProviderWriteConcurrency = -1
+	require.Error(t, ValidateConfig(cfg))
+
+	cfg = newValidConfig(t)
+	cfg.ProviderFailoverCooldown = -time.Second
+	require.Error(t, ValidateConfig(cfg))
+
+	cfg = newValidConfig(t)
+	cfg.PDNSFallbackServers = []string{"http://ns2.example.com:8081"}
+	cfg.WebhookProviderFallbackURLs = []string{"https://webhook.region2.example.com"}
+	require.NoError(t, ValidateConfig(cfg))
+	cfg.WebhookProviderFallbackURLs = append(cfg.WebhookProviderFallbackURLs, "webhook.region3.example.com")
+	require.ErrorContains(t, ValidateConfig(cfg), "--webhook-provider-fallback-url")
+
+	cfg = newValidConfig(t)
+	cfg.PlanConfigMap = "external-dns-plan"
+	require.Error(t, ValidateConfig(cfg))
+	cfg.PlanConfigMap = "kube-system/external-dns-plan"
+	require.NoError(t, ValidateConfig(cfg))
+
+	cfg = newValidConfig(t)
+	cfg.TXTEncryptKMS = "gcp-kms"
+	require.Error(t, ValidateConfig(cfg))
+	cfg.TXTEncryptKMSKey = "projects/dns/locations/global/keyRings/external-dns/cryptoKeys/txt"
+	require.NoError(t, ValidateConfig(cfg))
+
+	cfg = newValidConfig(t)
+	cfg.ProviderCircuitBreakerFailures = -1
+	require.Error(t, ValidateConfig(cfg))
+
+	cfg = newValidConfig(t)
+	cfg.FaultInjectionProfile = "chaos"
+	require.NoError(t, ValidateConfig(cfg))
+	cfg.FaultInjection = true
+	require.Error(t, ValidateConfig(cfg))
+	cfg.FaultInjectionProfile = "heavy"
+	require.NoError(t, ValidateConfig(cfg))
+
+	cfg = newValidConfig(t)
+	cfg.ExportZones = []string{"example.org"}
+	require.Error(t, ValidateConfig(cfg))
+	cfg.ExportZoneFile = "-"
+	require.NoError(t, ValidateConfig(cfg))
+
+	cfg = newValidConfig(t)
+	cfg.ExternalOwnerMarkers = []string{"terraform", ""}
+	require.Error(t, ValidateConfig(cfg))
+	cfg.ExternalOwnerMarkers = []string{"terraform"}
+	require.NoError(t, ValidateConfig(cfg))
+
+	cfg = newValidConfig(t)
+	cfg.AdoptExistingRecords = true
+	cfg.Registry = "txt"
+	require.NoError(t, ValidateConfig(cfg))
+	cfg.Registry = "dynamodb"
+	require.Error(t, ValidateConfig(cfg))
+
+	cfg = newValidConfig(t)
+	cfg.TXTJanitorInterval = time.Hour
+	cfg.Registry = "txt"
+	require.NoError(t, ValidateConfig(cfg))
+	cfg.TXTJanitorOwnerIDs = []string{""}
+	require.Error(t, ValidateConfig(cfg))
+	cfg.TXTJanitorOwnerIDs = nil
+	cfg.Registry = "noop"
+	require.Error(t, ValidateConfig(cfg))
+	cfg.Registry = "txt"
+	cfg.TXTJanitorInterval = -time.Hour
+	require.Error(t, ValidateConfig(cfg))
+
+	cfg = newValidConfig(t)
+	cfg.TXTOwnerIDTemplate = "{{ .OwnerID }}-{{ .Namespace }}"
+	cfg.Registry = "txt"
+	require.NoError(t, ValidateConfig(cfg))
+	cfg.TXTOwnerIDTemplate = "{{ .OwnerID"
+	require.Error(t, ValidateConfig(cfg))
+	cfg.TXTOwnerIDTemplate = "{{ .OwnerID }}-{{ .Namespace }}"
+	cfg.Registry = "dynamodb"
+	require.Error(t, ValidateConfig(cfg))
+
+	cfg = newValidConfig(t)
+	cfg.RegistryMigrationCutover = true
+	cfg.Registry = "txt-to-dynamodb"
+	require.NoError(t, ValidateConfig(cfg))
+	cfg.Registry = "dynamodb"
+	require.Error(t, ValidateConfig(cfg))
 }
 
 func newValidConfig(t *testing.T) *externaldns.Config {
@@ -94,6 +314,17 @@ func TestValidateBadIgnoreHostnameAnnotationsConfig(t *testing.T) {
 	assert.Error(t, ValidateConfig(cfg))
 }
 
+func TestValidateServiceDefaultHostnamesConfig(t *testing.T) {
+	cfg := newValidConfig(t)
+	cfg.ServiceDefaultHostnames = true
+	cfg.FQDNTemplate = ""
+
+	assert.Error(t, ValidateConfig(cfg))
+
+	cfg.FQDNTemplate = "{{.Name}}.example.org"
+	assert.NoError(t, ValidateConfig(cfg))
+}
+
 func TestValidateBadRfc2136Config(t *testing.T) {
 	cfg := externaldns.NewConfig()
 
@@ -250,6 +481,33 @@ func TestValidateGoodRfc2136GssTsigConfig(t *testing.T) {
 	}
 }
 
+func TestValidateRfc2136SIG0Config(t *testing.T) {
+	newConfig := func() *externaldns.Config {
+		return &externaldns.Config{
+			LogFormat:              "json",
+			Sources:                []string{"test-source"},
+			Provider:               "rfc2136",
+			RFC2136SIG0KeyDir:      "/etc/external-dns/sig0",
+			RFC2136SIG0KeyRollover: 720 * time.Hour,
+			RFC2136BatchChangeSize: 50,
+		}
+	}
+	require.NoError(t, ValidateConfig(newConfig()))
+
+	for _, update := range []func(*externaldns.Config){
+		func(cfg *externaldns.Config) { cfg.RFC2136Insecure = true },
+		func(cfg *externaldns.Config) {
+			cfg.RFC2136GSSTSIG, cfg.RFC2136KerberosRealm, cfg.RFC2136KerberosUsername, cfg.RFC2136KerberosPassword = true, "test-realm", "test-user", "test-pass"
+		},
+		func(cfg *externaldns.Config) { cfg.RFC2136SIG0KeyRollover = -time.Hour },
+		func(cfg *externaldns.Config) { cfg.RFC2136SIG0KeyDir = "" },
+	} {
+		cfg := newConfig()
+		update(cfg)
+		assert.Error(t, ValidateConfig(cfg))
+	}
+}
+
 func TestValidateBadAkamaiConfig(t *testing.T) {
 	invalidAkamaiConfigs := []*externaldns.Config{
 		{
@@ -351,3 +609,192 @@ func TestValidateGoodAzureConfig(t *testing.T) {
 
 	assert.NoError(t, err)
 }
+
+func TestValidateGoDaddyAPIEndpoint(t *testing.T) {
+	for _, tc := range []struct {
+		endpoint string
+		ote      bool
+		valid    bool
+	}{
+		{endpoint: "", ote: true, valid: true},
+		{endpoint: "https://godaddy-proxy.example.org/api", valid: true},
+		{endpoint: "http://localhost:8080", valid: true},
+		{endpoint: "https://godaddy-proxy.example.org", ote: true},
+		{endpoint: "godaddy-proxy.example.org"},
+		{endpoint: "ftp://godaddy-proxy.example.org"},
+	} {
+		cfg := externaldns.NewConfig()
+		cfg.LogFormat = "json"
+		cfg.Sources = []string{"test-source"}
+		cfg.Provider = "godaddy"
+		cfg.GoDaddyAPIEndpoint = tc.endpoint
+		cfg.GoDaddyOTE = tc.ote
+
+		err := ValidateConfig(cfg)
+		if tc.valid {
+			assert.NoError(t, err, tc.endpoint)
+		} else {
+			assert.Error(t, err, tc.endpoint)
+		}
+	}
+}
+
+func TestValidateOCIConfig(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		configure func(cfg *externaldns.Config)
+		valid     bool
+	}{
+		{name: "config file", configure: func(cfg *externaldns.Config) {}, valid: true},
+		{name: "workload identity", configure: func(cfg *externaldns.Config) {
+			cfg.OCIAuthWorkloadIdentity = true
+			cfg.OCICompartmentOCID = "ocid1.compartment.oc1..a"
+		}, valid: true},
+		{name: "workload identity without compartment", configure: func(cfg *externaldns.Config) {
+			cfg.OCIAuthWorkloadIdentity = true
+		}},
+		{name: "instance principal without compartment", configure: func(cfg *externaldns.Config) {
+			cfg.OCIAuthInstancePrincipal = true
+		}},
+		{name: "both principals", configure: func(cfg *externaldns.Config) {
+			cfg.OCIAuthInstancePrincipal = true
+			cfg.OCIAuthWorkloadIdentity = true
+			cfg.OCICompartmentOCID = "ocid1.compartment.oc1..a"
+		}},
+		{name: "private views", configure: func(cfg *externaldns.Config) {
+			cfg.OCIViewIDs = []string{"ocid1.dnsview.oc1..a"}
+			cfg.OCIZoneScope = "PRIVATE"
+		}, valid: true},
+		{name: "global views", configure: func(cfg *externaldns.Config) {
+			cfg.OCIViewIDs = []string{"ocid1.dnsview.oc1..a"}
+			cfg.OCIZoneScope = "GLOBAL"
+		}},
+	} {
+		cfg := newValidConfig(t)
+		cfg.Provider = "oci"
+		tc.configure(cfg)
+
+		err := ValidateConfig(cfg)
+		if tc.valid {
+			assert.NoError(t, err, tc.name)
+		} else {
+			assert.Error(t, err, tc.name)
+		}
+	}
+}
+
+func TestValidateSourceTargetIPFamilies(t *testing.T) {
+	cfg := newValidConfig(t)
+
+	cfg.SourceTargetIPFamilies = map[string]string{"test-source": "ipv6"}
+	assert.NoError(t, ValidateConfig(cfg))
+
+	cfg.SourceTargetIPFamilies = map[string]string{"other-source": "ipv6"}
+	assert.ErrorContains(t, ValidateConfig(cfg), `source "other-source" which is not enabled`)
+
+	cfg.SourceTargetIPFamilies = map[string]string{"test-source": "ipv5"}
+	assert.ErrorContains(t, ValidateConfig(cfg), `unsupported IP family "ipv5"`)
+}
+
+func TestValidateSourceResyncPeriods(t *testing.T) {
+	cfg := newValidConfig(t)
+
+	cfg.SourceResyncPeriods = map[string]string{"test-source": "30m"}
+	assert.NoError(t, ValidateConfig(cfg))
+
+	cfg.SourceResyncPeriods = map[string]string{"other-source": "30m"}
+	assert.ErrorContains(t, ValidateConfig(cfg), `source "other-source" which is not enabled`)
+
+	for _, period := range []string{"30", "-1m"} {
+		cfg.SourceResyncPeriods = map[string]string{"test-source": period}
+		assert.ErrorContains(t, ValidateConfig(cfg), `invalid duration "`+period+`"`)
+	}
+}
+
+func TestValidateACMEChallengeSource(t *testing.T) {
+	cfg := newValidConfig(t)
+	cfg.Sources = []string{"acme-challenge"}
+
+	cfg.ManagedDNSRecordTypes = []string{endpoint.RecordTypeA, endpoint.RecordTypeCNAME}
+	assert.ErrorContains(t, ValidateConfig(cfg), "requires TXT in --managed-record-types")
+
+	cfg.ManagedDNSRecordTypes = append(cfg.ManagedDNSRecordTypes, endpoint.RecordTypeTXT)
+	assert.NoError(t, ValidateConfig(cfg))
+}
+
+func TestValidatePublicIP(t *testing.T) {
+	cfg := newValidConfig(t)
+
+	cfg.PublicIPEndpoints = []string{"https://api.ipify.org", "stun:stun.l.google.com:19302"}
+	cfg.PublicIPRefreshInterval = time.Minute
+	assert.NoError(t, ValidateConfig(cfg))
+
+	cfg.PublicIPEndpoints = []string{"api.ipify.org"}
+	assert.ErrorContains(t, ValidateConfig(cfg), `--public-ip-endpoint "api.ipify.org" is invalid`)
+
+	cfg.PublicIPEndpoints = []string{"https://api.ipify.org"}
+	cfg.PublicIPRefreshInterval = 0
+	assert.ErrorContains(t, ValidateConfig(cfg), "--public-ip-refresh-interval must be at least 1s")
+}
+
+func TestValidateZoneSerialCheck(t *testing.T) {
+	cfg := newValidConfig(t)
+	cfg.ZoneSerialCheck = true
+	cfg.ZoneSerialCheckTimeout = time.Minute
+	assert.ErrorContains(t, ValidateConfig(cfg), "only supported by the pdns and rfc2136 providers")
+
+	cfg.Provider = "pdns"
+	cfg.ZoneSerialSecondaries = []string{"ns2.example.org", "192.0.2.2:5353"}
+	assert.NoError(t, ValidateConfig(cfg))
+
+	cfg.ZoneSerialSecondaries = []string{":53"}
+	assert.ErrorContains(t, ValidateConfig(cfg), "--zone-serial-secondary")
+
+	cfg.ZoneSerialSecondaries = nil
+	cfg.ZoneSerialCheckTimeout = 0
+	assert.ErrorContains(t, ValidateConfig(cfg), "--zone-serial-check-timeout must be at least one second")
+
+	cfg = newValidConfig(t)
+	cfg.ZoneSerialSecondaries = []string{"ns2.example.org"}
+	assert.ErrorContains(t, ValidateConfig(cfg), "requires --zone-serial-check")
+}
+
+func TestValidateCertManagerRenewal(t *testing.T) {
+	cfg := newValidConfig(t)
+
+	cfg.CertManagerRenewalTTL = 30 * time.Second
+	cfg.CertManagerRenewalLead = time.Hour
+	assert.NoError(t, ValidateConfig(cfg))
+
+	cfg.CertManagerRenewalTTL = 500 * time.Millisecond
+	assert.ErrorContains(t, ValidateConfig(cfg), "--cert-manager-renewal-ttl must be at least 1s")
+
+	cfg.CertManagerRenewalTTL = 30 * time.Second
+	cfg.CertManagerRenewalLead = -time.Minute
+	assert.ErrorContains(t, ValidateConfig(cfg), "--cert-manager-renewal-lead must not be negative")
+}
+
+func TestValidateNamespaceFilters(t *testing.T) {
+	cfg := newValidConfig(t)
+	cfg.NamespaceRegex = regexp.MustCompile("^team-")
+	cfg.ExcludeNamespaces = []string{"team-sandbox"}
+	assert.NoError(t, ValidateConfig(cfg))
+
+	cfg.Namespace = "team-web"
+	assert.NoError(t, ValidateConfig(cfg))
+
+	cfg.Namespace = "team-sandbox"
+	assert.EqualError(t, ValidateConfig(cfg), `--namespace "team-sandbox" is excluded by --exclude-namespaces`)
+
+	cfg.Namespace = "default"
+	assert.EqualError(t, ValidateConfig(cfg), `--namespace "default" does not match --namespace-regex "^team-"`)
+}
+
+func TestValidateAnnotationPrefixAliases(t *testing.T) {
+	cfg := newValidConfig(t)
+	cfg.AnnotationPrefixAliases = []string{"dns.mycorp.io/", "dns.example.org"}
+	assert.NoError(t, ValidateConfig(cfg))
+
+	cfg.AnnotationPrefixAliases = []string{"DNS_mycorp/"}
+	assert.ErrorContains(t, ValidateConfig(cfg), `--annotation-prefix-alias "DNS_mycorp/" is not a valid annotation prefix`)
+}
@@ -0,0 +1,238 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package audit writes the changes applied to the DNS provider, one JSON object per record,
+// to an audit trail kept apart from the logs.
+package audit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/pkg/metrics"
+)
+
+var writeErrorsTotal = metrics.NewCounterWithOpts(
+	prometheus.CounterOpts{
+		Namespace: "external_dns",
+		Subsystem: "audit",
+		Name:      "errors_total",
+		Help:      "Number of applied changes which could not be written to the audit log.",
+	},
+)
+
+func init() {
+	metrics.RegisterMetric.MustRegister(writeErrorsTotal)
+}
+
+// The actions applied to the records.
+const (
+	ActionCreate = "create"
+	ActionUpdate = "update"
+	ActionDelete = "delete"
+	ActionAdopt  = "adopt"
+)
+
+// The results of the application of the changes by the provider.
+const (
+	ResultApplied = "applied"
+	ResultFailed  = "failed"
+)
+
+// Record is the state of a record before or after a change.
+type Record struct {
+	Targets          []string          `json:"targets"`
+	TTL              int64             `json:"ttl,omitempty"`
+	ProviderSpecific map[string]string `json:"providerSpecific,omitempty"`
+}
+
+// NewRecord returns the state of the record of an endpoint, nil if there's no endpoint.
+func NewRecord(ep *endpoint.Endpoint) *Record {
+	if ep == nil {
+		return nil
+	}
+	r := &Record{Targets: []string(ep.Targets), TTL: int64(ep.RecordTTL)}
+	if len(ep.ProviderSpecific) > 0 {
+		r.ProviderSpecific = make(map[string]string, len(ep.ProviderSpecific))
+		for _, p := range ep.ProviderSpecific {
+			r.ProviderSpecific[p.Name] = p.Value
+		}
+	}
+	return r
+}
+
+// Entry is a change of a record applied, or attempted, by the controller.
+type Entry struct {
+	Time time.Time `json:"time"`
+	// Owner is the owner ID of the controller which applied the change.
+	Owner         string `json:"owner"`
+	Action        string `json:"action"`
+	DNSName       string `json:"dnsName"`
+	RecordType    string `json:"recordType"`
+	SetIdentifier string `json:"setIdentifier,omitempty"`
+	// Resource is the source object requesting the record, e.g. ingress/default/web.
+	Resource string `json:"resource,omitempty"`
+	// Old and New are the record before and after the change, Old is empty on a creation
+	// and New on a deletion.
+	Old *Record `json:"old,omitempty"`
+	New *Record `json:"new,omitempty"`
+	// Result is whether the provider applied the change, and Error the error it returned if not.
+	Result string `json:"result"`
+	Error  string `json:"error,omitempty"`
+}
+
+// NewEntry returns the entry of a change of a record from old to updated, either of which
+// is nil on a creation or a deletion. err is the error returned by the provider.
+func NewEntry(action, owner string, old, updated *endpoint.Endpoint, err error, now time.Time) Entry {
+	ep := updated
+	if ep == nil {
+		ep = old
+	}
+	resource := ep.Labels[endpoint.ResourceLabelKey]
+	if resource == "" && old != nil {
+		resource = old.Labels[endpoint.ResourceLabelKey]
+	}
+	e := Entry{
+		Time:          now,
+		Owner:         owner,
+		Action:        action,
+		DNSName:       ep.DNSName,
+		RecordType:    ep.RecordType,
+		SetIdentifier: ep.SetIdentifier,
+		Resource:      resource,
+		Old:           NewRecord(old),
+		New:           NewRecord(updated),
+		Result:        ResultApplied,
+	}
+	if err != nil {
+		e.Result = ResultFailed
+		e.Error = err.Error()
+	}
+	return e
+}
+
+// Sink writes the entries of the audit log.
+type Sink interface {
+	Write(ctx context.Context, entries []Entry) error
+}
+
+// NewSink returns the sink of an audit log: a webhook receiving the entries when the
+// target is an http or https URL, and otherwise the file the entries are appended to.
+func NewSink(target string) (Sink, error) {
+	if strings.HasPrefix(target, "http://") || strings.HasPrefix(target, "https://") {
+		return NewWebhookSink(target, defaultWebhookTimeout), nil
+	}
+	return NewFileSink(target)
+}
+
+// Write writes the entries to the sink, if any. A failure is logged and counted rather than
+// returned: the changes are already applied to the provider, and must not be applied again.
+func Write(ctx context.Context, sink Sink, entries []Entry) {
+	if sink == nil || len(entries) == 0 {
+		return
+	}
+	if err := sink.Write(ctx, entries); err != nil {
+		writeErrorsTotal.Counter.Add(float64(len(entries)))
+		log.Errorf("Failed to write %d applied changes to the audit log: %v", len(entries), err)
+	}
+}
+
+// FileSink appends the entries to a file, one JSON object per line.
+type FileSink struct {
+	mu   sync.Mutex
+	file *os.File
+}
+
+// NewFileSink opens the file the entries are appended to, creating it if needed.
+func NewFileSink(path string) (*FileSink, error) {
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open the audit log: %w", err)
+	}
+	return &FileSink{file: file}, nil
+}
+
+func (s *FileSink) Write(_ context.Context, entries []Entry) error {
+	data, err := encodeLines(entries)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, err = s.file.Write(data)
+	return err
+}
+
+// Close closes the file.
+func (s *FileSink) Close() error {
+	return s.file.Close()
+}
+
+const defaultWebhookTimeout = 10 * time.Second
+
+// WebhookSink posts the entries to a URL, as newline delimited JSON objects.
+type WebhookSink struct {
+	url    string
+	client *http.Client
+}
+
+// NewWebhookSink returns a sink posting the entries to the URL.
+func NewWebhookSink(url string, timeout time.Duration) *WebhookSink {
+	return &WebhookSink{url: url, client: &http.Client{Timeout: timeout}}
+}
+
+func (s *WebhookSink) Write(ctx context.Context, entries []Entry) error {
+	data, err := encodeLines(entries)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-ndjson")
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("audit webhook returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+func encodeLines(entries []Entry) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	for _, e := range entries {
+		if err := enc.Encode(e); err != nil {
+			return nil, err
+		}
+	}
+	return buf.Bytes(), nil
+}
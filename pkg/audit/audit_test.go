@@ -0,0 +1,135 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	promtestutil "github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+func TestNewEntry(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	old := endpoint.NewEndpointWithTTL("web.example.org", endpoint.RecordTypeA, 60, "1.2.3.4").
+		WithLabel(endpoint.ResourceLabelKey, "ingress/default/web")
+	updated := endpoint.NewEndpointWithTTL("web.example.org", endpoint.RecordTypeA, 300, "5.6.7.8").
+		WithProviderSpecific("alias", "false")
+
+	e := NewEntry(ActionUpdate, "owner", old, updated, nil, now)
+	assert.Equal(t, Entry{
+		Time:       now,
+		Owner:      "owner",
+		Action:     ActionUpdate,
+		DNSName:    "web.example.org",
+		RecordType: endpoint.RecordTypeA,
+		Resource:   "ingress/default/web",
+		Old:        &Record{Targets: []string{"1.2.3.4"}, TTL: 60},
+		New:        &Record{Targets: []string{"5.6.7.8"}, TTL: 300, ProviderSpecific: map[string]string{"alias": "false"}},
+		Result:     ResultApplied,
+	}, e)
+
+	e = NewEntry(ActionDelete, "owner", old, nil, errors.New("throttled"), now)
+	assert.Equal(t, "web.example.org", e.DNSName)
+	assert.Nil(t, e.New)
+	assert.Equal(t, ResultFailed, e.Result)
+	assert.Equal(t, "throttled", e.Error)
+}
+
+func TestFileSink(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	entries := []Entry{
+		NewEntry(ActionCreate, "owner", nil, endpoint.NewEndpoint("a.example.org", endpoint.RecordTypeA, "1.2.3.4"), nil, time.Now()),
+		NewEntry(ActionCreate, "owner", nil, endpoint.NewEndpoint("b.example.org", endpoint.RecordTypeA, "1.2.3.4"), nil, time.Now()),
+	}
+	for range 2 {
+		// the entries are appended to the existing file
+		sink, err := NewSink(path)
+		require.NoError(t, err)
+		require.NoError(t, sink.Write(context.Background(), entries))
+		require.NoError(t, sink.(*FileSink).Close())
+	}
+
+	file, err := os.Open(path)
+	require.NoError(t, err)
+	defer file.Close()
+	var names []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var e Entry
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &e))
+		names = append(names, e.DNSName)
+	}
+	assert.Equal(t, []string{"a.example.org", "b.example.org", "a.example.org", "b.example.org"}, names)
+
+	_, err = NewFileSink(filepath.Join(t.TempDir(), "missing", "audit.jsonl"))
+	require.Error(t, err)
+}
+
+func TestWebhookSink(t *testing.T) {
+	var body string
+	status := http.StatusOK
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "application/x-ndjson", r.Header.Get("Content-Type"))
+		data, _ := io.ReadAll(r.Body)
+		body = string(data)
+		w.WriteHeader(status)
+	}))
+	defer svr.Close()
+
+	sink, err := NewSink(svr.URL)
+	require.NoError(t, err)
+	require.IsType(t, &WebhookSink{}, sink)
+	entries := []Entry{NewEntry(ActionDelete, "owner", endpoint.NewEndpoint("a.example.org", endpoint.RecordTypeA, "1.2.3.4"), nil, nil, time.Time{})}
+	require.NoError(t, sink.Write(context.Background(), entries))
+	assert.JSONEq(t, `{"time":"0001-01-01T00:00:00Z","owner":"owner","action":"delete","dnsName":"a.example.org","recordType":"A","old":{"targets":["1.2.3.4"]},"result":"applied"}`, body)
+
+	status = http.StatusInternalServerError
+	require.Error(t, sink.Write(context.Background(), entries))
+}
+
+type failingSink struct{}
+
+func (failingSink) Write(context.Context, []Entry) error {
+	return errors.New("unavailable")
+}
+
+func TestWrite(t *testing.T) {
+	before := promtestutil.ToFloat64(writeErrorsTotal.Counter)
+	entries := []Entry{{DNSName: "a.example.org"}, {DNSName: "b.example.org"}}
+
+	Write(context.Background(), nil, entries)
+	Write(context.Background(), failingSink{}, nil)
+	assert.InDelta(t, before, promtestutil.ToFloat64(writeErrorsTotal.Counter), 0)
+
+	Write(context.Background(), failingSink{}, entries)
+	assert.InDelta(t, before+2, promtestutil.ToFloat64(writeErrorsTotal.Counter), 0)
+}
@@ -0,0 +1,312 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package canary stages the creates and updates of the records in a canary zone before they are
+// applied to the real zones: each record is created under the canary zone, e.g.
+// www.example.com.canary.example.net, verified with DNS queries, then deleted. The changes
+// rejected by the provider, or not served as expected, are not applied to the real zones.
+package canary
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/miekg/dns"
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/pkg/metrics"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider"
+)
+
+const (
+	// defaultPort is the port of the nameserver without one.
+	defaultPort = "53"
+	// pollInterval is the interval between the queries of the records not served as expected yet.
+	pollInterval = 2 * time.Second
+	// maxNameLength is the longest DNS name, the records whose canary name is longer are not staged.
+	maxNameLength = 253
+)
+
+var (
+	stagedRecordsTotal = metrics.NewCounterWithOpts(
+		prometheus.CounterOpts{
+			Namespace: "external_dns",
+			Subsystem: "canary",
+			Name:      "staged_records_total",
+			Help:      "Number of records created in the canary zone before applying the changes to the real zones.",
+		},
+	)
+	failuresTotal = metrics.NewCounterVecWithOpts(
+		prometheus.CounterOpts{
+			Namespace: "external_dns",
+			Subsystem: "canary",
+			Name:      "failures_total",
+			Help:      "Number of changes not applied as they failed in the canary zone, per stage: apply or verify (vector).",
+		},
+		[]string{"stage"},
+	)
+)
+
+func init() {
+	metrics.RegisterMetric.MustRegister(stagedRecordsTotal)
+	metrics.RegisterMetric.MustRegister(failuresTotal)
+}
+
+// Stager stages the changes in the canary zone.
+type Stager struct {
+	provider   provider.Provider
+	zone       string
+	nameserver string
+	timeout    time.Duration
+	interval   time.Duration
+	// query returns the targets of the records of a name and type served by a nameserver
+	query func(ctx context.Context, server, name string, recordType uint16) ([]string, error)
+}
+
+// NewStager returns a stager creating the records in the canary zone with the provider, and
+// waiting up to the timeout for the nameserver, host[:port], to serve them. The records are not
+// verified without nameserver.
+func NewStager(p provider.Provider, zone, nameserver string, timeout time.Duration) *Stager {
+	if nameserver != "" {
+		nameserver = withDefaultPort(nameserver)
+	}
+	return &Stager{
+		provider:   p,
+		zone:       strings.TrimSuffix(strings.ToLower(zone), "."),
+		nameserver: nameserver,
+		timeout:    timeout,
+		interval:   pollInterval,
+		query: func(ctx context.Context, server, name string, recordType uint16) ([]string, error) {
+			return Query(ctx, new(dns.Client), server, name, recordType)
+		},
+	}
+}
+
+// ValidateServer returns an error if the nameserver isn't a host with an optional port.
+func ValidateServer(server string) error {
+	host, _, err := net.SplitHostPort(withDefaultPort(server))
+	if err != nil || host == "" {
+		return fmt.Errorf("invalid DNS server %q, expected host[:port]", server)
+	}
+	return nil
+}
+
+// withDefaultPort returns the server, host[:port], with the DNS port if it has none.
+func withDefaultPort(server string) string {
+	if _, _, err := net.SplitHostPort(server); err == nil {
+		return server
+	}
+	if !strings.Contains(server, ":") || net.ParseIP(server) != nil {
+		return net.JoinHostPort(server, defaultPort)
+	}
+	return server
+}
+
+// Name returns the name of a record in the canary zone.
+func (s *Stager) Name(name string) string {
+	return strings.TrimSuffix(name, ".") + "." + s.zone
+}
+
+// Stage creates the records created or updated by the changes in the canary zone, verifies that
+// they are served with their targets, and deletes them. It returns an error if the provider
+// rejects them or they are not served within the timeout: the changes must not be applied then.
+// A nil stager stages nothing.
+func (s *Stager) Stage(ctx context.Context, changes *plan.Changes) error {
+	if s == nil {
+		return nil
+	}
+	staged := s.rewrite(slices.Concat(changes.Create, changes.UpdateNew))
+	if len(staged) == 0 {
+		return nil
+	}
+	log.Infof("Staging %d records in the canary zone %s", len(staged), s.zone)
+
+	// the records left over by a previous stage, e.g. interrupted, are replaced
+	leftovers, err := s.leftovers(ctx, staged)
+	if err != nil {
+		return fmt.Errorf("failed to list the records of the canary zone %s: %w", s.zone, err)
+	}
+	if len(leftovers) > 0 {
+		log.Debugf("Deleting %d records left over in the canary zone %s", len(leftovers), s.zone)
+		if err := s.provider.ApplyChanges(ctx, &plan.Changes{Delete: leftovers}); err != nil {
+			return fmt.Errorf("failed to delete the records left over in the canary zone %s: %w", s.zone, err)
+		}
+	}
+	if err := s.provider.ApplyChanges(ctx, &plan.Changes{Create: staged}); err != nil {
+		failuresTotal.CounterVec.WithLabelValues("apply").Inc()
+		return provider.NewSoftErrorf("the canary zone %s rejected the changes, they are not applied: %v", s.zone, err)
+	}
+	stagedRecordsTotal.Counter.Add(float64(len(staged)))
+	defer s.cleanup(staged)
+
+	if s.nameserver == "" {
+		return nil
+	}
+	if err := s.verify(ctx, staged); err != nil {
+		failuresTotal.CounterVec.WithLabelValues("verify").Inc()
+		return provider.NewSoftErrorf("the changes staged in the canary zone %s are not served by %s, they are not applied: %v", s.zone, s.nameserver, err)
+	}
+	log.Debugf("The %d records staged in the canary zone %s are served by %s", len(staged), s.zone, s.nameserver)
+	return nil
+}
+
+// rewrite returns copies of the records named in the canary zone, without labels so that they
+// are not owned by any instance.
+func (s *Stager) rewrite(records []*endpoint.Endpoint) []*endpoint.Endpoint {
+	staged := make([]*endpoint.Endpoint, 0, len(records))
+	for _, ep := range records {
+		name := s.Name(ep.DNSName)
+		if len(name) > maxNameLength {
+			log.Debugf("Not staging %s %s: its name in the canary zone is too long", ep.DNSName, ep.RecordType)
+			continue
+		}
+		c := ep.DeepCopy()
+		c.DNSName = name
+		c.Labels = endpoint.NewLabels()
+		staged = append(staged, c)
+	}
+	return staged
+}
+
+// leftovers returns the records of the canary zone with the names and types of the staged records.
+func (s *Stager) leftovers(ctx context.Context, staged []*endpoint.Endpoint) ([]*endpoint.Endpoint, error) {
+	records, err := provider.ZoneRecords(ctx, s.provider, s.zone)
+	if err != nil {
+		return nil, err
+	}
+	keys := make(map[endpoint.EndpointKey]struct{}, len(staged))
+	for _, ep := range staged {
+		keys[ep.Key()] = struct{}{}
+	}
+	var leftovers []*endpoint.Endpoint
+	for _, ep := range records {
+		if _, ok := keys[ep.Key()]; ok {
+			leftovers = append(leftovers, ep)
+		}
+	}
+	return leftovers, nil
+}
+
+// cleanup deletes the staged records. The failures are only logged: the records are replaced by
+// the next stage.
+func (s *Stager) cleanup(staged []*endpoint.Endpoint) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	if err := s.provider.ApplyChanges(ctx, &plan.Changes{Delete: staged}); err != nil {
+		log.Warnf("Failed to delete the records staged in the canary zone %s: %v", s.zone, err)
+	}
+}
+
+// verify queries the nameserver until it serves all the staged records, or the timeout.
+func (s *Stager) verify(ctx context.Context, staged []*endpoint.Endpoint) error {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+	pending := slices.Clone(staged)
+	for {
+		var lastErr error
+		waiting := pending[:0]
+		for _, ep := range pending {
+			if err := s.served(ctx, ep); err != nil {
+				lastErr = err
+				waiting = append(waiting, ep)
+			}
+		}
+		pending = waiting
+		if len(pending) == 0 {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%d records not served within %s, e.g. %w", len(pending), s.timeout, lastErr)
+		case <-time.After(s.interval):
+		}
+	}
+}
+
+// served returns an error unless the nameserver serves the record with its targets. Only the
+// targets of the A, AAAA and CNAME records are compared, and of none of the alias records, which
+// are served with the addresses of their targets: the other records must merely be served.
+func (s *Stager) served(ctx context.Context, ep *endpoint.Endpoint) error {
+	recordType, ok := dns.StringToType[ep.RecordType]
+	if !ok {
+		return nil
+	}
+	targets, err := s.query(ctx, s.nameserver, ep.DNSName, recordType)
+	if err != nil {
+		return err
+	}
+	if len(targets) == 0 {
+		return fmt.Errorf("%s %s is not served", ep.DNSName, ep.RecordType)
+	}
+	if alias, _ := ep.GetProviderSpecificProperty("alias"); alias == "true" {
+		return nil
+	}
+	switch ep.RecordType {
+	case endpoint.RecordTypeA, endpoint.RecordTypeAAAA, endpoint.RecordTypeCNAME:
+		if !endpoint.NewTargets(targets...).Same(normalize(ep.Targets)) {
+			return fmt.Errorf("%s %s is served with the targets %v instead of %v", ep.DNSName, ep.RecordType, targets, ep.Targets)
+		}
+	}
+	return nil
+}
+
+// normalize returns the targets in lower case without trailing dot, as returned by Query.
+func normalize(targets endpoint.Targets) endpoint.Targets {
+	normalized := make([]string, 0, len(targets))
+	for _, t := range targets {
+		normalized = append(normalized, strings.TrimSuffix(strings.ToLower(t), "."))
+	}
+	return endpoint.NewTargets(normalized...)
+}
+
+// Query returns the targets of the records of a name and type served by a server, host:port: the
+// addresses of the A and AAAA records, the lower case names of the CNAME records without trailing
+// dot, and the data of the other records.
+func Query(ctx context.Context, client *dns.Client, server, name string, recordType uint16) ([]string, error) {
+	msg := new(dns.Msg)
+	msg.SetQuestion(dns.Fqdn(name), recordType)
+	resp, _, err := client.ExchangeContext(ctx, msg, server)
+	if err != nil {
+		return nil, fmt.Errorf("querying %s %s on %s: %w", name, dns.TypeToString[recordType], server, err)
+	}
+	if resp.Rcode != dns.RcodeSuccess && resp.Rcode != dns.RcodeNameError {
+		return nil, fmt.Errorf("querying %s %s on %s: %s", name, dns.TypeToString[recordType], server, dns.RcodeToString[resp.Rcode])
+	}
+	var targets []string
+	for _, rr := range resp.Answer {
+		if rr.Header().Rrtype != recordType {
+			continue
+		}
+		switch rr := rr.(type) {
+		case *dns.A:
+			targets = append(targets, rr.A.String())
+		case *dns.AAAA:
+			targets = append(targets, rr.AAAA.String())
+		case *dns.CNAME:
+			targets = append(targets, strings.TrimSuffix(strings.ToLower(rr.Target), "."))
+		default:
+			targets = append(targets, strings.TrimPrefix(rr.String(), rr.Header().String()))
+		}
+	}
+	return targets, nil
+}
@@ -0,0 +1,210 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package canary
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/miekg/dns"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider"
+	"sigs.k8s.io/external-dns/provider/inmemory"
+)
+
+// rejectingProvider rejects the changes creating records.
+type rejectingProvider struct {
+	*inmemory.InMemoryProvider
+}
+
+func (p rejectingProvider) ApplyChanges(ctx context.Context, changes *plan.Changes) error {
+	if len(changes.Create) > 0 {
+		return errors.New("invalid record")
+	}
+	return p.InMemoryProvider.ApplyChanges(ctx, changes)
+}
+
+// newTestStager returns a stager of the canary zone of an in-memory provider, whose records are
+// served by the nameserver as they are in the provider unless their targets are overridden.
+func newTestStager(t *testing.T, p provider.Provider, served map[string]endpoint.Targets) *Stager {
+	t.Helper()
+	s := NewStager(p, "Canary.Example.NET.", "ns1.example.net", 50*time.Millisecond)
+	s.interval = 10 * time.Millisecond
+	s.query = func(ctx context.Context, server, name string, recordType uint16) ([]string, error) {
+		assert.Equal(t, "ns1.example.net:53", server)
+		records, err := p.Records(ctx)
+		require.NoError(t, err)
+		for _, ep := range records {
+			if ep.DNSName == name && ep.RecordType == dns.TypeToString[recordType] {
+				if targets, ok := served[name]; ok {
+					return targets, nil
+				}
+				return ep.Targets, nil
+			}
+		}
+		return nil, nil
+	}
+	return s
+}
+
+func testChanges() *plan.Changes {
+	return &plan.Changes{
+		Create: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("www.example.com", endpoint.RecordTypeA, "1.2.3.4").WithLabel(endpoint.OwnerLabelKey, "owner"),
+			endpoint.NewEndpoint("www.example.com", endpoint.RecordTypeTXT, "\"hello\""),
+		},
+		UpdateOld: []*endpoint.Endpoint{endpoint.NewEndpoint("api.example.com", endpoint.RecordTypeCNAME, "old.example.com")},
+		UpdateNew: []*endpoint.Endpoint{endpoint.NewEndpoint("api.example.com", endpoint.RecordTypeCNAME, "New.Example.com.")},
+		Delete:    []*endpoint.Endpoint{endpoint.NewEndpoint("old.example.com", endpoint.RecordTypeA, "1.2.3.4")},
+	}
+}
+
+func TestStage(t *testing.T) {
+	p := inmemory.NewInMemoryProvider(inmemory.InMemoryInitZones([]string{"canary.example.net"}))
+	// a record left over by a previous stage
+	require.NoError(t, p.ApplyChanges(t.Context(), &plan.Changes{Create: []*endpoint.Endpoint{
+		endpoint.NewEndpoint("www.example.com.canary.example.net", endpoint.RecordTypeA, "5.6.7.8"),
+	}}))
+	var created []*endpoint.Endpoint
+	p.OnApplyChanges = func(_ context.Context, changes *plan.Changes) {
+		created = append(created, changes.Create...)
+	}
+	s := newTestStager(t, p, nil)
+
+	changes := testChanges()
+	require.NoError(t, s.Stage(t.Context(), changes))
+
+	names := []string{}
+	for _, ep := range created {
+		names = append(names, ep.DNSName+" "+ep.RecordType)
+		assert.Empty(t, ep.Labels[endpoint.OwnerLabelKey], "the staged records are not owned")
+	}
+	assert.ElementsMatch(t, []string{
+		"www.example.com.canary.example.net A",
+		"www.example.com.canary.example.net TXT",
+		"api.example.com.canary.example.net CNAME",
+	}, names)
+	// the staged records are deleted, the changes are left unchanged
+	records, err := p.Records(t.Context())
+	require.NoError(t, err)
+	assert.Empty(t, records)
+	assert.Equal(t, testChanges(), changes)
+}
+
+func TestStageNotServed(t *testing.T) {
+	p := inmemory.NewInMemoryProvider(inmemory.InMemoryInitZones([]string{"canary.example.net"}))
+	s := newTestStager(t, p, map[string]endpoint.Targets{"www.example.com.canary.example.net": {"5.6.7.8"}})
+
+	err := s.Stage(t.Context(), testChanges())
+	require.ErrorIs(t, err, provider.SoftError)
+	assert.ErrorContains(t, err, "www.example.com.canary.example.net A is served with the targets [5.6.7.8] instead of 1.2.3.4")
+	records, err := p.Records(t.Context())
+	require.NoError(t, err)
+	assert.Empty(t, records)
+}
+
+func TestStageRejected(t *testing.T) {
+	p := rejectingProvider{inmemory.NewInMemoryProvider(inmemory.InMemoryInitZones([]string{"canary.example.net"}))}
+	s := newTestStager(t, p, nil)
+
+	err := s.Stage(t.Context(), testChanges())
+	require.ErrorIs(t, err, provider.SoftError)
+	assert.ErrorContains(t, err, "the canary zone canary.example.net rejected the changes, they are not applied: invalid record")
+}
+
+func TestStageNothing(t *testing.T) {
+	var s *Stager
+	require.NoError(t, s.Stage(t.Context(), testChanges()))
+
+	// the deletes are not staged
+	p := rejectingProvider{inmemory.NewInMemoryProvider(inmemory.InMemoryInitZones([]string{"canary.example.net"}))}
+	s = newTestStager(t, p, nil)
+	require.NoError(t, s.Stage(t.Context(), &plan.Changes{Delete: testChanges().Delete}))
+}
+
+func TestStageWithoutNameserver(t *testing.T) {
+	p := inmemory.NewInMemoryProvider(inmemory.InMemoryInitZones([]string{"canary.example.net"}))
+	s := NewStager(p, "canary.example.net", "", time.Second)
+	s.query = func(context.Context, string, string, uint16) ([]string, error) {
+		t.Fatal("the staged records are not verified without nameserver")
+		return nil, nil
+	}
+	require.NoError(t, s.Stage(t.Context(), testChanges()))
+}
+
+func TestValidateServer(t *testing.T) {
+	for _, server := range []string{"ns1.example.net", "ns1.example.net:5353", "192.0.2.1", "2001:db8::1", "[2001:db8::1]:53"} {
+		require.NoError(t, ValidateServer(server), server)
+	}
+	for _, server := range []string{"", ":53", "ns1.example.net:53:53"} {
+		require.Error(t, ValidateServer(server), server)
+	}
+}
+
+func TestQuery(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	server := &dns.Server{PacketConn: conn, Handler: dns.HandlerFunc(func(w dns.ResponseWriter, r *dns.Msg) {
+		m := new(dns.Msg)
+		m.SetReply(r)
+		hdr := dns.RR_Header{Name: r.Question[0].Name, Rrtype: r.Question[0].Qtype, Class: dns.ClassINET, Ttl: 300}
+		switch r.Question[0].Name {
+		case "www.example.com.canary.example.net.":
+			m.Answer = append(m.Answer, &dns.A{Hdr: hdr, A: net.ParseIP("1.2.3.4")})
+		case "api.example.com.canary.example.net.":
+			m.Answer = append(m.Answer, &dns.CNAME{Hdr: hdr, Target: "New.Example.com."})
+		case "txt.example.com.canary.example.net.":
+			m.Answer = append(m.Answer, &dns.TXT{Hdr: hdr, Txt: []string{"hello"}})
+		case "missing.example.com.canary.example.net.":
+			m.Rcode = dns.RcodeNameError
+		default:
+			m.Rcode = dns.RcodeRefused
+		}
+		_ = w.WriteMsg(m)
+	})}
+	go func() { _ = server.ActivateAndServe() }()
+	t.Cleanup(func() { _ = server.Shutdown() })
+	addr := conn.LocalAddr().String()
+
+	for name, want := range map[string][]string{
+		"www.example.com.canary.example.net":     {"1.2.3.4"},
+		"api.example.com.canary.example.net":     {"new.example.com"},
+		"txt.example.com.canary.example.net":     {"\"hello\""},
+		"missing.example.com.canary.example.net": nil,
+	} {
+		recordType := map[string]uint16{
+			"api.example.com.canary.example.net": dns.TypeCNAME,
+			"txt.example.com.canary.example.net": dns.TypeTXT,
+		}[name]
+		if recordType == 0 {
+			recordType = dns.TypeA
+		}
+		targets, err := Query(t.Context(), new(dns.Client), addr, name, recordType)
+		require.NoError(t, err, name)
+		assert.Equal(t, want, targets, name)
+	}
+
+	_, err = Query(t.Context(), new(dns.Client), addr, "www.example.org", dns.TypeA)
+	require.ErrorContains(t, err, "REFUSED")
+}
@@ -0,0 +1,205 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package changewindow parses the maintenance windows during which the DNS records may be
+// changed, opening at the times of a cron schedule for a duration, e.g. `0 22 * * 1-5 4h` for
+// 22:00 to 02:00 after each weekday.
+package changewindow
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// maxDuration is the longest duration of a window, bounding the search of its opening.
+	maxDuration = 7 * 24 * time.Hour
+	// lookahead is how far the next opening of the windows is searched.
+	lookahead = 8 * 24 * time.Hour
+)
+
+// field is the values matched by a field of a cron schedule, indexed by value.
+type field []bool
+
+// Window is a maintenance window, opening at the minutes matching a cron schedule.
+type Window struct {
+	spec     string
+	minute   field
+	hour     field
+	dom      field
+	month    field
+	dow      field
+	anyDOM   bool
+	anyDOW   bool
+	duration time.Duration
+}
+
+// Parse parses a window made of the 5 fields of a cron schedule, minute, hour, day of month,
+// month and day of week, followed by its duration, e.g. `0 22 * * 1-5 4h`. The fields are
+// `*`, values, ranges `a-b` and steps `*/n` or `a-b/n`, separated by commas; Sunday is 0 or 7.
+func Parse(spec string) (Window, error) {
+	fields := strings.Fields(spec)
+	if len(fields) != 6 {
+		return Window{}, fmt.Errorf("invalid change window %q: expected 5 cron fields and a duration, e.g. \"0 22 * * 1-5 4h\"", spec)
+	}
+	w := Window{spec: strings.Join(fields, " ")}
+	var err error
+	for _, f := range []struct {
+		name     string
+		text     string
+		min, max int
+		values   *field
+	}{
+		{"minute", fields[0], 0, 59, &w.minute},
+		{"hour", fields[1], 0, 23, &w.hour},
+		{"day of month", fields[2], 1, 31, &w.dom},
+		{"month", fields[3], 1, 12, &w.month},
+		{"day of week", fields[4], 0, 7, &w.dow},
+	} {
+		if *f.values, err = parseField(f.text, f.min, f.max); err != nil {
+			return Window{}, fmt.Errorf("invalid %s of the change window %q: %w", f.name, spec, err)
+		}
+	}
+	// Sunday is either 0 or 7
+	w.dow[0] = w.dow[0] || w.dow[7]
+	w.anyDOM = fields[2] == "*"
+	w.anyDOW = fields[4] == "*"
+
+	if w.duration, err = time.ParseDuration(fields[5]); err != nil {
+		return Window{}, fmt.Errorf("invalid duration of the change window %q: %w", spec, err)
+	}
+	if w.duration < time.Minute || w.duration > maxDuration {
+		return Window{}, fmt.Errorf("invalid duration of the change window %q: must be between 1m and %s", spec, maxDuration)
+	}
+	return w, nil
+}
+
+// parseField returns the values matched by a field of a cron schedule.
+func parseField(text string, minValue, maxValue int) (field, error) {
+	values := make(field, maxValue+1)
+	for _, part := range strings.Split(text, ",") {
+		rng, stepText, hasStep := strings.Cut(part, "/")
+		step := 1
+		if hasStep {
+			var err error
+			if step, err = strconv.Atoi(stepText); err != nil || step < 1 {
+				return nil, fmt.Errorf("invalid step %q", stepText)
+			}
+		}
+		low, high := minValue, maxValue
+		if rng != "*" {
+			lowText, highText, isRange := strings.Cut(rng, "-")
+			var err error
+			if low, err = strconv.Atoi(lowText); err != nil {
+				return nil, fmt.Errorf("invalid value %q", lowText)
+			}
+			high = low
+			if isRange {
+				if high, err = strconv.Atoi(highText); err != nil {
+					return nil, fmt.Errorf("invalid value %q", highText)
+				}
+			} else if hasStep {
+				high = maxValue
+			}
+		}
+		if low < minValue || high > maxValue || low > high {
+			return nil, fmt.Errorf("%q is out of the range %d-%d", rng, minValue, maxValue)
+		}
+		for v := low; v <= high; v += step {
+			values[v] = true
+		}
+	}
+	return values, nil
+}
+
+// String returns the specification of the window.
+func (w Window) String() string {
+	return w.spec
+}
+
+// opensAt returns whether the window opens at the minute of the time.
+func (w Window) opensAt(t time.Time) bool {
+	if !w.minute[t.Minute()] || !w.hour[t.Hour()] || !w.month[int(t.Month())] {
+		return false
+	}
+	dom, dow := w.dom[t.Day()], w.dow[int(t.Weekday())]
+	// like cron, the days of month or of week match when both are restricted
+	switch {
+	case w.anyDOM && w.anyDOW:
+		return true
+	case w.anyDOM:
+		return dow
+	case w.anyDOW:
+		return dom
+	default:
+		return dom || dow
+	}
+}
+
+// Contains returns whether the window is open at the time, in the location of the time.
+func (w Window) Contains(t time.Time) bool {
+	start := t.Truncate(time.Minute)
+	for opening := start; t.Sub(opening) < w.duration; opening = opening.Add(-time.Minute) {
+		if w.opensAt(opening) {
+			return true
+		}
+	}
+	return false
+}
+
+// Windows are the windows during which the changes are allowed, always if there are none.
+type Windows []Window
+
+// ParseAll parses the windows.
+func ParseAll(specs []string) (Windows, error) {
+	windows := make(Windows, 0, len(specs))
+	for _, spec := range specs {
+		w, err := Parse(spec)
+		if err != nil {
+			return nil, err
+		}
+		windows = append(windows, w)
+	}
+	return windows, nil
+}
+
+// Open returns whether the changes are allowed at the time.
+func (ws Windows) Open(t time.Time) bool {
+	if len(ws) == 0 {
+		return true
+	}
+	for _, w := range ws {
+		if w.Contains(t) {
+			return true
+		}
+	}
+	return false
+}
+
+// Next returns the next time a window opens after the time, and false if none opens within 8 days.
+func (ws Windows) Next(t time.Time) (time.Time, bool) {
+	start := t.Truncate(time.Minute).Add(time.Minute)
+	for opening := start; opening.Sub(start) < lookahead; opening = opening.Add(time.Minute) {
+		for _, w := range ws {
+			if w.opensAt(opening) {
+				return opening, true
+			}
+		}
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,114 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package changewindow
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	for _, tc := range []struct {
+		spec  string
+		valid bool
+	}{
+		{"0 22 * * 1-5 4h", true},
+		{"*/15 * * * * 5m", true},
+		{"0 0 1,15 * 0,7 24h", true},
+		{"30 2-6/2 * * * 1h", true},
+		{"30 2-6/2 * * SAT 1h", false},
+		{"0 22 * * 1-5", false},
+		{"0 24 * * * 1h", false},
+		{"0 22 * * 5-1 1h", false},
+		{"0 22 * * * 30s", false},
+		{"0 22 * * * 192h", false},
+		{"0 22 * * */0 1h", false},
+	} {
+		t.Run(tc.spec, func(t *testing.T) {
+			_, err := Parse(tc.spec)
+			if tc.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestWindowContains(t *testing.T) {
+	// from 22:00 to 02:00 after each weekday
+	w, err := Parse("0 22 * * 1-5 4h")
+	require.NoError(t, err)
+	assert.Equal(t, "0 22 * * 1-5 4h", w.String())
+
+	at := func(day, hour, minute int) time.Time {
+		// 2025-01-06 is a Monday
+		return time.Date(2025, 1, day, hour, minute, 30, 0, time.UTC)
+	}
+	assert.False(t, w.Contains(at(6, 21, 59)))
+	assert.True(t, w.Contains(at(6, 22, 0)))
+	assert.True(t, w.Contains(at(7, 1, 59)))
+	assert.False(t, w.Contains(at(7, 2, 0)))
+	// Friday night to Saturday morning, but not Saturday night
+	assert.True(t, w.Contains(at(11, 0, 30)))
+	assert.False(t, w.Contains(at(11, 22, 30)))
+	// in the location of the time
+	paris, err := time.LoadLocation("Europe/Paris")
+	require.NoError(t, err)
+	assert.True(t, w.Contains(time.Date(2025, 1, 6, 22, 30, 0, 0, paris)))
+	assert.False(t, w.Contains(time.Date(2025, 1, 6, 22, 30, 0, 0, paris).UTC().Add(-2*time.Hour)))
+}
+
+func TestWindowDays(t *testing.T) {
+	// like cron, either the day of month or the day of week matches when both are restricted
+	w, err := Parse("0 12 1 * 0 1h")
+	require.NoError(t, err)
+	assert.True(t, w.Contains(time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)))  // Wednesday the 1st
+	assert.True(t, w.Contains(time.Date(2025, 1, 5, 12, 0, 0, 0, time.UTC)))  // Sunday
+	assert.False(t, w.Contains(time.Date(2025, 1, 6, 12, 0, 0, 0, time.UTC))) // Monday
+
+	// Sunday is 7 too
+	w, err = Parse("0 12 * * 7 1h")
+	require.NoError(t, err)
+	assert.True(t, w.Contains(time.Date(2025, 1, 5, 12, 0, 0, 0, time.UTC)))
+}
+
+func TestWindows(t *testing.T) {
+	assert.True(t, Windows(nil).Open(time.Now()))
+
+	ws, err := ParseAll([]string{"0 22 * * 1-5 4h", "0 10 * * 6 2h"})
+	require.NoError(t, err)
+	saturday := time.Date(2025, 1, 11, 11, 0, 0, 0, time.UTC)
+	assert.True(t, ws.Open(saturday))
+	assert.False(t, ws.Open(saturday.Add(2*time.Hour)))
+
+	next, ok := ws.Next(saturday.Add(2 * time.Hour))
+	require.True(t, ok)
+	assert.Equal(t, time.Date(2025, 1, 13, 22, 0, 0, 0, time.UTC), next)
+
+	// the 31st of February never opens
+	ws, err = ParseAll([]string{"0 0 31 2 * 1h"})
+	require.NoError(t, err)
+	_, ok = ws.Next(saturday)
+	assert.False(t, ok)
+
+	_, err = ParseAll([]string{"0 22 * * 1-5 4h", "invalid"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,295 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package damping holds the records whose targets flap, e.g. when two load balancer controllers
+// fight over a resource, at their last stable targets until they're cleared by hand or the
+// desired targets settle.
+package damping
+
+import (
+	"encoding/json"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/pkg/metrics"
+	"sigs.k8s.io/external-dns/plan"
+)
+
+var (
+	heldRecords = metrics.NewGaugeWithOpts(
+		prometheus.GaugeOpts{
+			Namespace: "external_dns",
+			Subsystem: "controller",
+			Name:      "damped_records",
+			Help:      "Number of records held at their last stable targets as their targets flap.",
+		},
+	)
+	heldUpdatesTotal = metrics.NewCounterWithOpts(
+		prometheus.CounterOpts{
+			Namespace: "external_dns",
+			Subsystem: "controller",
+			Name:      "damped_updates_total",
+			Help:      "Number of updates of the targets of records not applied as their targets flap.",
+		},
+	)
+)
+
+func init() {
+	metrics.RegisterMetric.MustRegister(heldRecords)
+	metrics.RegisterMetric.MustRegister(heldUpdatesTotal)
+}
+
+// HeldRecord is a record held at its last stable targets.
+type HeldRecord struct {
+	DNSName       string           `json:"dnsName"`
+	RecordType    string           `json:"recordType"`
+	SetIdentifier string           `json:"setIdentifier,omitempty"`
+	Targets       endpoint.Targets `json:"targets"`
+	Desired       endpoint.Targets `json:"desired"`
+	HeldSince     time.Time        `json:"heldSince"`
+}
+
+// record is the history of the target changes of a record.
+type record struct {
+	// changes are the times the targets of the record were changed within the window
+	changes []time.Time
+	// held is the record held at its last stable targets, nil unless held
+	held *HeldRecord
+	// settledAt is when the desired targets of the held record last changed
+	settledAt time.Time
+}
+
+// Damper holds the records whose targets are changed more than threshold times within the window.
+// The held records are released once their desired targets don't change for the cool-down, or by
+// hand only without cool-down. A nil damper holds no record.
+type Damper struct {
+	mu         sync.Mutex
+	threshold  int
+	window     time.Duration
+	cooldown   time.Duration
+	allowClear bool
+	records    map[endpoint.EndpointKey]*record
+	now        func() time.Time
+}
+
+// NewDamper returns a damper holding the records whose targets are changed more than threshold
+// times within the window, until their desired targets don't change for the cool-down, or nil
+// when the threshold or the window isn't positive. The held records can only be released by hand
+// through ServeHTTP when allowClear is set.
+func NewDamper(threshold int, window, cooldown time.Duration, allowClear bool) *Damper {
+	if threshold <= 0 || window <= 0 {
+		return nil
+	}
+	return &Damper{
+		threshold:  threshold,
+		window:     window,
+		cooldown:   cooldown,
+		allowClear: allowClear,
+		records:    map[endpoint.EndpointKey]*record{},
+		now:        time.Now,
+	}
+}
+
+// Hold removes the updates of the targets of the held records from the changes, and holds the
+// records whose targets were already changed threshold times within the window.
+func (d *Damper) Hold(changes *plan.Changes) {
+	if d == nil {
+		return
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	now := d.now()
+
+	olds := changes.UpdateOld[:0]
+	updates := changes.UpdateNew[:0]
+	for i, update := range changes.UpdateNew {
+		if i >= len(changes.UpdateOld) || !d.hold(changes.UpdateOld[i], update, now) {
+			if i < len(changes.UpdateOld) {
+				olds = append(olds, changes.UpdateOld[i])
+			}
+			updates = append(updates, update)
+		}
+	}
+	changes.UpdateOld = olds
+	changes.UpdateNew = updates
+
+	// the held records back at their stable targets are released after the cool-down too
+	if d.cooldown > 0 {
+		for key, r := range d.records {
+			if r.held != nil && now.Sub(r.settledAt) >= d.cooldown {
+				log.Infof("Releasing %s %s: its desired targets didn't change for %s", r.held.DNSName, r.held.RecordType, d.cooldown)
+				delete(d.records, key)
+			}
+		}
+	}
+	d.updateMetrics()
+}
+
+// hold returns whether the update of a record is held.
+func (d *Damper) hold(current, update *endpoint.Endpoint, now time.Time) bool {
+	if current.Targets.Same(update.Targets) {
+		return false
+	}
+	key := update.Key()
+	r, ok := d.records[key]
+	if !ok {
+		return false
+	}
+
+	if r.held == nil {
+		r.changes = d.recent(r.changes, now)
+		if len(r.changes) < d.threshold {
+			return false
+		}
+		r.held = &HeldRecord{
+			DNSName:       update.DNSName,
+			RecordType:    update.RecordType,
+			SetIdentifier: update.SetIdentifier,
+			Targets:       current.Targets,
+			Desired:       update.Targets,
+			HeldSince:     now,
+		}
+		r.settledAt = now
+		log.Warnf("Holding %s %s at its targets %s: they were changed %d times within %s, the last desired targets are %s",
+			update.DNSName, update.RecordType, current.Targets, len(r.changes), d.window, update.Targets)
+	} else if !r.held.Desired.Same(update.Targets) {
+		r.held.Desired = update.Targets
+		r.settledAt = now
+	}
+
+	if d.cooldown > 0 && now.Sub(r.settledAt) >= d.cooldown {
+		log.Infof("Releasing %s %s: its desired targets %s didn't change for %s", update.DNSName, update.RecordType, update.Targets, d.cooldown)
+		delete(d.records, key)
+		return false
+	}
+	heldUpdatesTotal.Counter.Inc()
+	log.Debugf("Holding the update of %s %s to %s", update.DNSName, update.RecordType, update.Targets)
+	return true
+}
+
+// Record records the changes of the targets of the records applied to the provider.
+func (d *Damper) Record(changes *plan.Changes) {
+	if d == nil || changes == nil {
+		return
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	now := d.now()
+	for i, update := range changes.UpdateNew {
+		if i >= len(changes.UpdateOld) || changes.UpdateOld[i].Targets.Same(update.Targets) {
+			continue
+		}
+		r, ok := d.records[update.Key()]
+		if !ok {
+			r = &record{}
+			d.records[update.Key()] = r
+		}
+		r.changes = append(d.recent(r.changes, now), now)
+	}
+	// the history of the records no longer changed is forgotten
+	for key, r := range d.records {
+		if r.changes = d.recent(r.changes, now); r.held == nil && len(r.changes) == 0 {
+			delete(d.records, key)
+		}
+	}
+}
+
+// recent returns the changes within the window.
+func (d *Damper) recent(changes []time.Time, now time.Time) []time.Time {
+	return slices.DeleteFunc(changes, func(at time.Time) bool { return now.Sub(at) >= d.window })
+}
+
+// Held returns the held records, sorted by name.
+func (d *Damper) Held() []HeldRecord {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	held := []HeldRecord{}
+	for _, r := range d.records {
+		if r.held != nil {
+			held = append(held, *r.held)
+		}
+	}
+	slices.SortFunc(held, func(a, b HeldRecord) int {
+		return strings.Compare(a.DNSName+"/"+a.RecordType+"/"+a.SetIdentifier, b.DNSName+"/"+b.RecordType+"/"+b.SetIdentifier)
+	})
+	return held
+}
+
+// Clear releases the held records of a name, or all of them if the name is empty, and returns
+// the number of records released. Their desired targets are applied by the next synchronization.
+func (d *Damper) Clear(name string) int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	name = strings.TrimSuffix(strings.ToLower(name), ".")
+	released := 0
+	for key, r := range d.records {
+		if name != "" && key.DNSName != name {
+			continue
+		}
+		if r.held != nil {
+			log.Infof("Releasing %s %s by hand", r.held.DNSName, r.held.RecordType)
+			released++
+		}
+		delete(d.records, key)
+	}
+	d.updateMetrics()
+	return released
+}
+
+func (d *Damper) updateMetrics() {
+	held := 0
+	for _, r := range d.records {
+		if r.held != nil {
+			held++
+		}
+	}
+	heldRecords.Gauge.Set(float64(held))
+}
+
+// ServeHTTP writes the held records in JSON for GET requests and, when clearing them is allowed,
+// releases the held records of the name parameter, or all of them without name, for POST requests.
+func (d *Damper) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if d == nil {
+		http.Error(w, "the damping of the records is disabled", http.StatusNotFound)
+		return
+	}
+	allow := "GET"
+	if d.allowClear {
+		allow = "GET, POST"
+	}
+	var body any
+	switch {
+	case r.Method == http.MethodGet:
+		body = d.Held()
+	case r.Method == http.MethodPost && d.allowClear:
+		body = map[string]int{"released": d.Clear(r.URL.Query().Get("name"))}
+	default:
+		w.Header().Set("Allow", allow)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		log.Warnf("Failed to write the damped records: %v", err)
+	}
+}
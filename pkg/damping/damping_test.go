@@ -0,0 +1,164 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package damping
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+)
+
+// testDamper returns a damper whose clock is advanced by the returned function.
+func testDamper(threshold int, window, cooldown time.Duration) (*Damper, func(time.Duration)) {
+	d := NewDamper(threshold, window, cooldown, true)
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	d.now = func() time.Time { return now }
+	return d, func(elapsed time.Duration) { now = now.Add(elapsed) }
+}
+
+func update(from, to string) *plan.Changes {
+	return &plan.Changes{
+		UpdateOld: []*endpoint.Endpoint{endpoint.NewEndpoint("web.example.org", endpoint.RecordTypeCNAME, from)},
+		UpdateNew: []*endpoint.Endpoint{endpoint.NewEndpoint("web.example.org", endpoint.RecordTypeCNAME, to)},
+	}
+}
+
+// apply holds the changes and records them when applied, returning whether the update is applied.
+func apply(d *Damper, changes *plan.Changes) bool {
+	d.Hold(changes)
+	d.Record(changes)
+	return len(changes.UpdateNew) > 0
+}
+
+func TestDamperHold(t *testing.T) {
+	d, advance := testDamper(2, 10*time.Minute, 30*time.Minute)
+
+	// the targets may change threshold times within the window
+	assert.True(t, apply(d, update("lb-a.example.org", "lb-b.example.org")))
+	advance(time.Minute)
+	assert.True(t, apply(d, update("lb-b.example.org", "lb-a.example.org")))
+	advance(time.Minute)
+	assert.False(t, apply(d, update("lb-a.example.org", "lb-b.example.org")))
+	assert.Equal(t, []HeldRecord{{
+		DNSName:    "web.example.org",
+		RecordType: endpoint.RecordTypeCNAME,
+		Targets:    endpoint.Targets{"lb-a.example.org"},
+		Desired:    endpoint.Targets{"lb-b.example.org"},
+		HeldSince:  time.Date(2025, 1, 1, 0, 2, 0, 0, time.UTC),
+	}}, d.Held())
+
+	// the other updates are still applied
+	other := &plan.Changes{
+		UpdateOld: []*endpoint.Endpoint{endpoint.NewEndpoint("api.example.org", endpoint.RecordTypeA, "192.0.2.1")},
+		UpdateNew: []*endpoint.Endpoint{endpoint.NewEndpoint("api.example.org", endpoint.RecordTypeA, "192.0.2.2")},
+	}
+	assert.True(t, apply(d, other))
+
+	// the held record is released once its desired targets don't change for the cool-down
+	advance(20 * time.Minute)
+	assert.False(t, apply(d, update("lb-a.example.org", "lb-c.example.org")))
+	advance(20 * time.Minute)
+	assert.False(t, apply(d, update("lb-a.example.org", "lb-c.example.org")))
+	advance(10 * time.Minute)
+	assert.True(t, apply(d, update("lb-a.example.org", "lb-c.example.org")))
+	assert.Empty(t, d.Held())
+
+	// the changes out of the window are forgotten
+	advance(time.Hour)
+	assert.True(t, apply(d, update("lb-c.example.org", "lb-a.example.org")))
+	advance(11 * time.Minute)
+	assert.True(t, apply(d, update("lb-a.example.org", "lb-c.example.org")))
+	advance(11 * time.Minute)
+	assert.True(t, apply(d, update("lb-c.example.org", "lb-a.example.org")))
+}
+
+func TestDamperClear(t *testing.T) {
+	d, advance := testDamper(1, 10*time.Minute, 0)
+
+	assert.True(t, apply(d, update("lb-a.example.org", "lb-b.example.org")))
+	assert.False(t, apply(d, update("lb-b.example.org", "lb-a.example.org")))
+
+	// without cool-down, the held records are only released by hand
+	advance(24 * time.Hour)
+	assert.False(t, apply(d, update("lb-b.example.org", "lb-a.example.org")))
+	assert.Equal(t, 0, d.Clear("api.example.org"))
+	assert.Equal(t, 1, d.Clear("Web.Example.org."))
+	assert.True(t, apply(d, update("lb-b.example.org", "lb-a.example.org")))
+}
+
+func TestDamperDisabled(t *testing.T) {
+	d := NewDamper(0, 10*time.Minute, 0, true)
+	assert.Nil(t, d)
+	for range 5 {
+		assert.True(t, apply(d, update("lb-a.example.org", "lb-b.example.org")))
+	}
+
+	w := httptest.NewRecorder()
+	d.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/debug/damped-records", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestDamperServeHTTP(t *testing.T) {
+	d, _ := testDamper(1, 10*time.Minute, 0)
+	apply(d, update("lb-a.example.org", "lb-b.example.org"))
+	apply(d, update("lb-b.example.org", "lb-a.example.org"))
+
+	w := httptest.NewRecorder()
+	d.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/debug/damped-records", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `[{
+		"dnsName": "web.example.org",
+		"recordType": "CNAME",
+		"targets": ["lb-b.example.org"],
+		"desired": ["lb-a.example.org"],
+		"heldSince": "2025-01-01T00:00:00Z"
+	}]`, w.Body.String())
+
+	w = httptest.NewRecorder()
+	d.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/debug/damped-records?name=web.example.org", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"released": 1}`, w.Body.String())
+	assert.Empty(t, d.Held())
+
+	w = httptest.NewRecorder()
+	d.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, "/debug/damped-records", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+}
+
+func TestDamperServeHTTPReadOnly(t *testing.T) {
+	d := NewDamper(1, 10*time.Minute, 0, false)
+	apply(d, update("lb-a.example.org", "lb-b.example.org"))
+	apply(d, update("lb-b.example.org", "lb-a.example.org"))
+
+	w := httptest.NewRecorder()
+	d.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/debug/damped-records", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	// the held records can't be released by hand unless it's allowed
+	w = httptest.NewRecorder()
+	d.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/debug/damped-records", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+	assert.Equal(t, "GET", w.Header().Get("Allow"))
+	assert.Len(t, d.Held(), 1)
+}
@@ -0,0 +1,192 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package decisions records why candidate endpoints and the objects of the sources were excluded,
+// to explain why a record is not created.
+package decisions
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+// The filters which exclude candidate endpoints or objects.
+const (
+	DomainFilter        = "domain-filter"
+	AnnotationFilter    = "annotation-filter"
+	LabelFilter         = "label-filter"
+	NamespaceFilter     = "namespace-filter"
+	RecordTypeFilter    = "record-type-filter"
+	ZoneFilter          = "zone-filter"
+	OwnerFilter         = "owner"
+	CapabilitiesFilter  = "provider-capabilities"
+	ExternalOwnerFilter = "external-owner"
+	ZoneLimitFilter     = "max-records-per-zone"
+)
+
+const (
+	// maxDecisions is the number of decisions kept by a recorder, the oldest are dropped first.
+	maxDecisions = 10000
+	// maxAge is the duration after which a decision which wasn't made again is dropped.
+	maxAge = time.Hour
+)
+
+// Decision explains why a candidate endpoint, or an object of a source, was excluded.
+type Decision struct {
+	// Filter is the filter or rule which excluded the endpoint or object, e.g. domain-filter.
+	Filter string `json:"filter"`
+	// DNSName and RecordType identify the excluded endpoint, they are empty for an object.
+	DNSName    string `json:"dnsName,omitempty"`
+	RecordType string `json:"recordType,omitempty"`
+	// Resource is the resource of the excluded object or endpoint, e.g. ingress/default/web.
+	Resource string `json:"resource,omitempty"`
+	// Reason is a human readable explanation of the decision.
+	Reason string `json:"reason"`
+	// Time is the last time the decision was made.
+	Time time.Time `json:"time"`
+}
+
+// ForEndpoint returns the decision excluding an endpoint.
+func ForEndpoint(filter string, ep *endpoint.Endpoint, reason string) Decision {
+	return Decision{
+		Filter:     filter,
+		DNSName:    ep.DNSName,
+		RecordType: ep.RecordType,
+		Resource:   ep.Labels[endpoint.ResourceLabelKey],
+		Reason:     reason,
+	}
+}
+
+// ForResource returns the decision excluding an object of a source, e.g. service/default/web.
+func ForResource(filter, resource, reason string) Decision {
+	return Decision{Filter: filter, Resource: resource, Reason: reason}
+}
+
+func (d Decision) key() string {
+	return d.Filter + "/" + d.Resource + "/" + d.DNSName + "/" + d.RecordType
+}
+
+// Recorder keeps the latest decision for each excluded endpoint or object. A disabled
+// recorder, or a nil one, ignores the decisions.
+type Recorder struct {
+	mu        sync.Mutex
+	enabled   bool
+	decisions map[string]Decision
+	now       func() time.Time
+}
+
+// NewRecorder returns a disabled recorder.
+func NewRecorder() *Recorder {
+	return &Recorder{decisions: map[string]Decision{}, now: time.Now}
+}
+
+// Enable makes the recorder log and keep the decisions.
+func (r *Recorder) Enable() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.enabled = true
+}
+
+// Enabled returns whether the recorder keeps the decisions, so that the callers can skip
+// the work needed to explain them.
+func (r *Recorder) Enabled() bool {
+	if r == nil {
+		return false
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.enabled
+}
+
+// Record logs and keeps a decision if the recorder is enabled.
+func (r *Recorder) Record(d Decision) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !r.enabled {
+		return
+	}
+
+	d.Time = r.now()
+	log.WithFields(log.Fields{
+		"filter":     d.Filter,
+		"dnsName":    d.DNSName,
+		"recordType": d.RecordType,
+		"resource":   d.Resource,
+	}).Infof("Excluded by %s: %s", d.Filter, d.Reason)
+
+	r.decisions[d.key()] = d
+	if len(r.decisions) > maxDecisions {
+		r.prune()
+	}
+}
+
+// prune drops the decisions which weren't made again for maxAge, then the oldest ones
+// beyond maxDecisions.
+func (r *Recorder) prune() {
+	now := r.now()
+	for key, d := range r.decisions {
+		if now.Sub(d.Time) > maxAge {
+			delete(r.decisions, key)
+		}
+	}
+	if len(r.decisions) <= maxDecisions {
+		return
+	}
+	decisions := r.sorted()
+	sort.SliceStable(decisions, func(i, j int) bool { return decisions[i].Time.Before(decisions[j].Time) })
+	for _, d := range decisions[:len(decisions)-maxDecisions] {
+		delete(r.decisions, d.key())
+	}
+}
+
+// Decisions returns the kept decisions sorted by filter, resource, name and record type.
+func (r *Recorder) Decisions() []Decision {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.prune()
+	return r.sorted()
+}
+
+func (r *Recorder) sorted() []Decision {
+	decisions := make([]Decision, 0, len(r.decisions))
+	for _, d := range r.decisions {
+		decisions = append(decisions, d)
+	}
+	sort.Slice(decisions, func(i, j int) bool { return decisions[i].key() < decisions[j].key() })
+	return decisions
+}
+
+// ServeHTTP writes the kept decisions as JSON, or Not Found if the recorder is disabled.
+func (r *Recorder) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	if !r.Enabled() {
+		http.Error(w, "filter decisions are not recorded", http.StatusNotFound)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(r.Decisions()); err != nil {
+		log.Warnf("Failed to write the filter decisions: %v", err)
+	}
+}
@@ -0,0 +1,107 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package decisions
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+func TestRecorder(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	r := NewRecorder()
+	r.now = func() time.Time { return now }
+
+	ep := endpoint.NewEndpoint("www.example.org", endpoint.RecordTypeA, "1.2.3.4").
+		WithLabel(endpoint.ResourceLabelKey, "ingress/default/web")
+
+	// a disabled recorder ignores the decisions
+	r.Record(ForEndpoint(DomainFilter, ep, "the name does not match the domain filter"))
+	assert.False(t, r.Enabled())
+	assert.Empty(t, r.Decisions())
+
+	// a nil recorder, of the components built without one, ignores them too
+	var disabled *Recorder
+	disabled.Record(ForEndpoint(DomainFilter, ep, "the name does not match the domain filter"))
+	assert.False(t, disabled.Enabled())
+
+	r.Enable()
+	r.Record(ForEndpoint(DomainFilter, ep, "the name does not match the domain filter"))
+	r.Record(ForResource(AnnotationFilter, "service/default/api", "the annotations do not match"))
+	now = now.Add(time.Minute)
+	// the same decision is kept once, with the time it was last made
+	r.Record(ForEndpoint(DomainFilter, ep, "the name does not match the domain filter"))
+
+	assert.Equal(t, []Decision{
+		{Filter: AnnotationFilter, Resource: "service/default/api", Reason: "the annotations do not match", Time: now.Add(-time.Minute)},
+		{Filter: DomainFilter, DNSName: "www.example.org", RecordType: endpoint.RecordTypeA, Resource: "ingress/default/web", Reason: "the name does not match the domain filter", Time: now},
+	}, r.Decisions())
+
+	// the decisions which weren't made again are dropped
+	now = now.Add(maxAge)
+	assert.Equal(t, []Decision{
+		{Filter: DomainFilter, DNSName: "www.example.org", RecordType: endpoint.RecordTypeA, Resource: "ingress/default/web", Reason: "the name does not match the domain filter", Time: now.Add(-maxAge)},
+	}, r.Decisions())
+}
+
+func TestRecorderLimit(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	r := NewRecorder()
+	r.now = func() time.Time { return now }
+	r.Enable()
+
+	first := ForResource(LabelFilter, "node/first", "the labels do not match")
+	r.Record(first)
+	for i := 0; i < maxDecisions; i++ {
+		now = now.Add(time.Millisecond)
+		r.Record(ForResource(LabelFilter, "node/"+time.Duration(i).String(), "the labels do not match"))
+	}
+
+	decisions := r.Decisions()
+	assert.Len(t, decisions, maxDecisions)
+	assert.NotContains(t, decisions, first)
+}
+
+func TestRecorderServeHTTP(t *testing.T) {
+	r := NewRecorder()
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/debug/filter-decisions", nil))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	r.Enable()
+	r.Record(ForResource(OwnerFilter, "crd/default/records", "owned by another owner"))
+
+	rec = httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/debug/filter-decisions", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+
+	var decisions []Decision
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &decisions))
+	require.Len(t, decisions, 1)
+	assert.Equal(t, "crd/default/records", decisions[0].Resource)
+	assert.Equal(t, OwnerFilter, decisions[0].Filter)
+}
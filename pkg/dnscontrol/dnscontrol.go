@@ -0,0 +1,358 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package dnscontrol exports the desired records of the zones in the formats of the
+// configuration of DNSControl, so that some zones can be reviewed and applied through
+// DNSControl pipelines rather than by the provider.
+package dnscontrol
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/provider"
+)
+
+const (
+	// FormatJSON is the JSON configuration of DNSControl, e.g. dnsconfig.json.
+	FormatJSON = "json"
+	// FormatJS is the JavaScript configuration of DNSControl, e.g. dnsconfig.js.
+	FormatJS = "js"
+
+	// registrarName is the name of the registrar of the domains, which only manages their records.
+	registrarName = "none"
+	// defaultProviderName is the name of the DNS provider of the domains in creds.json, unless
+	// another one is requested.
+	defaultProviderName = "none"
+	// apexName is the name of the records of the apex of a domain.
+	apexName = "@"
+)
+
+var (
+	// ErrNotSynced is returned when the desired records are requested before the first synchronization.
+	ErrNotSynced = errors.New("the records are not synchronized yet")
+	// ErrUnknownZones is returned when the records of all the zones are requested while the provider
+	// can't list its zones.
+	ErrUnknownZones = errors.New("the zones of the provider can't be listed, a zone must be given")
+)
+
+// supportedRecordTypes are the types of the records exported, the others are skipped.
+var supportedRecordTypes = []string{
+	endpoint.RecordTypeA, endpoint.RecordTypeAAAA, endpoint.RecordTypeCNAME, endpoint.RecordTypeTXT,
+	endpoint.RecordTypeMX, endpoint.RecordTypeSRV, endpoint.RecordTypeNS, endpoint.RecordTypePTR,
+}
+
+// Record is a record of a domain, as in the JSON configuration of DNSControl. The names of its
+// target are fully qualified.
+type Record struct {
+	Type         string `json:"type"`
+	Name         string `json:"name"`
+	Target       string `json:"target"`
+	TTL          int64  `json:"ttl,omitempty"`
+	MXPreference uint16 `json:"mxpreference,omitempty"`
+	SRVPriority  uint16 `json:"srvpriority,omitempty"`
+	SRVWeight    uint16 `json:"srvweight,omitempty"`
+	SRVPort      uint16 `json:"srvport,omitempty"`
+}
+
+// Domain is a zone and its desired records.
+type Domain struct {
+	Name    string
+	Records []Record
+}
+
+// Exporter keeps the desired records of the latest synchronization. A disabled exporter, or a
+// nil one, ignores them.
+type Exporter struct {
+	mu        sync.Mutex
+	enabled   bool
+	synced    bool
+	zones     []string
+	endpoints []*endpoint.Endpoint
+}
+
+// NewExporter returns a disabled exporter.
+func NewExporter() *Exporter {
+	return &Exporter{}
+}
+
+// Enable makes the exporter keep the desired records.
+func (e *Exporter) Enable() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.enabled = true
+}
+
+// Enabled returns whether the exporter keeps the desired records.
+func (e *Exporter) Enabled() bool {
+	if e == nil {
+		return false
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.enabled
+}
+
+// Update keeps a copy of the desired records and of the names of the zones of the provider, which
+// may be unknown, if the exporter is enabled.
+func (e *Exporter) Update(zones []string, endpoints []*endpoint.Endpoint) {
+	if e == nil {
+		return
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if !e.enabled {
+		return
+	}
+
+	e.synced = true
+	e.zones = slices.Clone(zones)
+	e.endpoints = make([]*endpoint.Endpoint, 0, len(endpoints))
+	for _, ep := range endpoints {
+		e.endpoints = append(e.endpoints, ep.DeepCopy())
+	}
+}
+
+// Domains returns the desired records of the zones, or of the given zone only unless it is empty.
+// The records of a name are in its most specific zone. It returns ErrNotSynced until the first
+// update, and ErrUnknownZones if no zone is given while those of the provider are unknown.
+func (e *Exporter) Domains(zone string) ([]Domain, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if !e.synced {
+		return nil, ErrNotSynced
+	}
+	if zone == "" && len(e.zones) == 0 {
+		return nil, ErrUnknownZones
+	}
+
+	zones := provider.ZoneIDName{}
+	for _, name := range e.zones {
+		zones.Add(name, name)
+	}
+	if zone != "" {
+		zones.Add(zone, zone)
+	}
+
+	byZone := map[string][]Record{}
+	for _, ep := range e.endpoints {
+		_, zoneName := zones.FindZone(ep.DNSName)
+		if zoneName == "" || (zone != "" && zoneName != zone) {
+			continue
+		}
+		if !slices.Contains(supportedRecordTypes, ep.RecordType) {
+			log.Debugf("Not exporting %s %s to DNSControl: unsupported record type", ep.DNSName, ep.RecordType)
+			continue
+		}
+		for _, target := range ep.Targets {
+			r, err := newRecord(ep, zoneName, target)
+			if err != nil {
+				log.Debugf("Not exporting %s %s to DNSControl: %v", ep.DNSName, ep.RecordType, err)
+				continue
+			}
+			byZone[zoneName] = append(byZone[zoneName], r)
+		}
+	}
+	if _, ok := byZone[zone]; zone != "" && !ok {
+		// The requested zone is exported even without records, so that they are all deleted.
+		byZone[zone] = []Record{}
+	}
+
+	domains := make([]Domain, 0, len(byZone))
+	for name, records := range byZone {
+		sort.SliceStable(records, func(i, j int) bool {
+			if records[i].Name != records[j].Name {
+				return records[i].Name < records[j].Name
+			}
+			return records[i].Type < records[j].Type
+		})
+		domains = append(domains, Domain{Name: name, Records: records})
+	}
+	sort.Slice(domains, func(i, j int) bool { return domains[i].Name < domains[j].Name })
+	return domains, nil
+}
+
+// newRecord returns the record of a target of an endpoint of a zone.
+func newRecord(ep *endpoint.Endpoint, zoneName, target string) (Record, error) {
+	r := Record{Type: ep.RecordType, Name: apexName, Target: target}
+	if ep.DNSName != zoneName {
+		r.Name = strings.TrimSuffix(ep.DNSName, "."+zoneName)
+	}
+	if ep.RecordTTL.IsConfigured() {
+		r.TTL = int64(ep.RecordTTL)
+	}
+
+	switch ep.RecordType {
+	case endpoint.RecordTypeCNAME, endpoint.RecordTypeNS, endpoint.RecordTypePTR:
+		r.Target = fqdn(target)
+	case endpoint.RecordTypeMX:
+		var host string
+		if _, err := fmt.Sscanf(target, "%d %s", &r.MXPreference, &host); err != nil {
+			return Record{}, fmt.Errorf("invalid MX target %q: %w", target, err)
+		}
+		r.Target = fqdn(host)
+	case endpoint.RecordTypeSRV:
+		var host string
+		if _, err := fmt.Sscanf(target, "%d %d %d %s", &r.SRVPriority, &r.SRVWeight, &r.SRVPort, &host); err != nil {
+			return Record{}, fmt.Errorf("invalid SRV target %q: %w", target, err)
+		}
+		r.Target = fqdn(host)
+	}
+	return r, nil
+}
+
+// fqdn returns the fully qualified form of a name, with a trailing dot.
+func fqdn(name string) string {
+	return strings.TrimSuffix(name, ".") + "."
+}
+
+// jsonConfig is the JSON configuration of DNSControl.
+type jsonConfig struct {
+	Registrars   []jsonProvider `json:"registrars"`
+	DNSProviders []jsonProvider `json:"dns_providers"`
+	Domains      []jsonDomain   `json:"domains"`
+}
+
+type jsonProvider struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+type jsonDomain struct {
+	Name         string         `json:"name"`
+	Registrar    string         `json:"registrar"`
+	DNSProviders map[string]int `json:"dnsProviders"`
+	Records      []Record       `json:"records"`
+}
+
+// WriteJSON writes the JSON configuration of DNSControl managing the records of the domains with
+// the DNS provider of the given name in creds.json.
+func WriteJSON(w io.Writer, domains []Domain, providerName string) error {
+	cfg := jsonConfig{
+		Registrars:   []jsonProvider{{Name: registrarName, Type: "NONE"}},
+		DNSProviders: []jsonProvider{{Name: providerName, Type: "-"}},
+		Domains:      []jsonDomain{},
+	}
+	for _, d := range domains {
+		cfg.Domains = append(cfg.Domains, jsonDomain{
+			Name:         d.Name,
+			Registrar:    registrarName,
+			DNSProviders: map[string]int{providerName: -1},
+			Records:      d.Records,
+		})
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(cfg)
+}
+
+// WriteJS writes the JavaScript configuration of DNSControl managing the records of the domains
+// with the DNS provider of the given name in creds.json.
+func WriteJS(w io.Writer, domains []Domain, providerName string) error {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "var REG_NONE = NewRegistrar(%s);\n", quote(registrarName))
+	fmt.Fprintf(&b, "var DSP = NewDnsProvider(%s);\n", quote(providerName))
+	for _, d := range domains {
+		fmt.Fprintf(&b, "\nD(%s, REG_NONE, DnsProvider(DSP)", quote(d.Name))
+		for _, r := range d.Records {
+			b.WriteString(",\n    ")
+			b.WriteString(jsRecord(r))
+		}
+		b.WriteString("\n);\n")
+	}
+	_, err := w.Write(b.Bytes())
+	return err
+}
+
+// jsRecord returns the call of the record function of DNSControl creating the record.
+func jsRecord(r Record) string {
+	args := []string{quote(r.Name)}
+	switch r.Type {
+	case endpoint.RecordTypeMX:
+		args = append(args, fmt.Sprint(r.MXPreference))
+	case endpoint.RecordTypeSRV:
+		args = append(args, fmt.Sprint(r.SRVPriority), fmt.Sprint(r.SRVWeight), fmt.Sprint(r.SRVPort))
+	}
+	args = append(args, quote(r.Target))
+	if r.TTL > 0 {
+		args = append(args, fmt.Sprintf("TTL(%d)", r.TTL))
+	}
+	return r.Type + "(" + strings.Join(args, ", ") + ")"
+}
+
+// quote returns a JavaScript string literal, JSON strings being valid ones.
+func quote(s string) string {
+	data, _ := json.Marshal(s)
+	return string(data)
+}
+
+// ServeHTTP writes the configuration of DNSControl managing the desired records, of all the zones
+// or of the zone of the zone parameter, in the format of the format parameter, JSON by default.
+// The provider parameter is the name of the DNS provider in creds.json.
+func (e *Exporter) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !e.Enabled() {
+		http.Error(w, "the DNSControl export is disabled", http.StatusNotFound)
+		return
+	}
+
+	query := r.URL.Query()
+	zone := strings.TrimSuffix(strings.ToLower(query.Get("zone")), ".")
+	providerName := query.Get("provider")
+	if providerName == "" {
+		providerName = defaultProviderName
+	}
+	format := query.Get("format")
+	if format == "" {
+		format = FormatJSON
+	}
+	if format != FormatJSON && format != FormatJS {
+		http.Error(w, fmt.Sprintf("unknown format %q, options: %s, %s", format, FormatJSON, FormatJS), http.StatusBadRequest)
+		return
+	}
+
+	domains, err := e.Domains(zone)
+	switch {
+	case errors.Is(err, ErrNotSynced):
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		return
+	case err != nil:
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var b bytes.Buffer
+	if format == FormatJS {
+		w.Header().Set("Content-Type", "application/javascript")
+		_ = WriteJS(&b, domains, providerName)
+	} else {
+		w.Header().Set("Content-Type", "application/json")
+		_ = WriteJSON(&b, domains, providerName)
+	}
+	if _, err := w.Write(b.Bytes()); err != nil {
+		log.Warnf("Failed to write the DNSControl export: %v", err)
+	}
+}
@@ -0,0 +1,176 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dnscontrol
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+func testEndpoints() []*endpoint.Endpoint {
+	return []*endpoint.Endpoint{
+		endpoint.NewEndpointWithTTL("www.example.com", endpoint.RecordTypeA, 300, "192.0.2.2", "192.0.2.1"),
+		endpoint.NewEndpoint("alias.example.com", endpoint.RecordTypeCNAME, "www.example.com"),
+		endpoint.NewEndpoint("example.com", endpoint.RecordTypeMX, "10 mail.example.com"),
+		endpoint.NewEndpoint("example.com", endpoint.RecordTypeTXT, `v=spf1 include:"example.org" -all`),
+		endpoint.NewEndpoint("_sip._tcp.dev.example.com", endpoint.RecordTypeSRV, "10 5 5060 sip.example.com."),
+		endpoint.NewEndpoint("app.example.org", endpoint.RecordTypeAAAA, "2001:db8::1"),
+		endpoint.NewEndpoint("www.example.net", endpoint.RecordTypeA, "192.0.2.3"),
+		endpoint.NewEndpoint("example.com", endpoint.RecordTypeNAPTR, `100 10 "u" "E2U+sip" "!^.*$!sip:info@example.com!" .`),
+		endpoint.NewEndpoint("bad.example.com", endpoint.RecordTypeMX, "mail.example.com"),
+	}
+}
+
+func TestExporter(t *testing.T) {
+	e := NewExporter()
+
+	// a disabled exporter ignores the records
+	e.Update([]string{"example.com"}, testEndpoints())
+	_, err := e.Domains("")
+	require.ErrorIs(t, err, ErrNotSynced)
+
+	// a nil exporter, of the controllers built without one, ignores them too
+	var disabled *Exporter
+	disabled.Update([]string{"example.com"}, testEndpoints())
+	assert.False(t, disabled.Enabled())
+
+	e.Enable()
+	_, err = e.Domains("")
+	require.ErrorIs(t, err, ErrNotSynced)
+
+	endpoints := testEndpoints()
+	e.Update([]string{"example.com", "dev.example.com", "example.org"}, endpoints)
+	// the exporter keeps a copy of the records
+	endpoints[0].Targets[0] = "192.0.2.10"
+
+	domains, err := e.Domains("")
+	require.NoError(t, err)
+	assert.Equal(t, []Domain{
+		{Name: "dev.example.com", Records: []Record{
+			{Type: "SRV", Name: "_sip._tcp", Target: "sip.example.com.", SRVPriority: 10, SRVWeight: 5, SRVPort: 5060},
+		}},
+		{Name: "example.com", Records: []Record{
+			{Type: "MX", Name: "@", Target: "mail.example.com.", MXPreference: 10},
+			{Type: "TXT", Name: "@", Target: `v=spf1 include:"example.org" -all`},
+			{Type: "CNAME", Name: "alias", Target: "www.example.com."},
+			{Type: "A", Name: "www", Target: "192.0.2.2", TTL: 300},
+			{Type: "A", Name: "www", Target: "192.0.2.1", TTL: 300},
+		}},
+		{Name: "example.org", Records: []Record{
+			{Type: "AAAA", Name: "app", Target: "2001:db8::1"},
+		}},
+	}, domains)
+
+	// a zone which isn't listed by the provider can be requested
+	domains, err = e.Domains("example.net")
+	require.NoError(t, err)
+	assert.Equal(t, []Domain{{Name: "example.net", Records: []Record{{Type: "A", Name: "www", Target: "192.0.2.3"}}}}, domains)
+
+	domains, err = e.Domains("empty.example.com")
+	require.NoError(t, err)
+	assert.Equal(t, []Domain{{Name: "empty.example.com", Records: []Record{}}}, domains)
+
+	// the zones of the providers which can't list them must be requested
+	e.Update(nil, endpoints)
+	_, err = e.Domains("")
+	require.ErrorIs(t, err, ErrUnknownZones)
+}
+
+func TestWriteJS(t *testing.T) {
+	e := NewExporter()
+	e.Enable()
+	e.Update([]string{"example.com", "dev.example.com"}, testEndpoints())
+	domains, err := e.Domains("")
+	require.NoError(t, err)
+
+	var b bytes.Buffer
+	require.NoError(t, WriteJS(&b, domains, "bind"))
+	assert.Equal(t, `var REG_NONE = NewRegistrar("none");
+var DSP = NewDnsProvider("bind");
+
+D("dev.example.com", REG_NONE, DnsProvider(DSP),
+    SRV("_sip._tcp", 10, 5, 5060, "sip.example.com.")
+);
+
+D("example.com", REG_NONE, DnsProvider(DSP),
+    MX("@", 10, "mail.example.com."),
+    TXT("@", "v=spf1 include:\"example.org\" -all"),
+    CNAME("alias", "www.example.com."),
+    A("www", "192.0.2.2", TTL(300)),
+    A("www", "192.0.2.1", TTL(300))
+);
+`, b.String())
+}
+
+func TestWriteJSON(t *testing.T) {
+	var b bytes.Buffer
+	require.NoError(t, WriteJSON(&b, []Domain{
+		{Name: "example.com", Records: []Record{
+			{Type: "MX", Name: "@", Target: "mail.example.com.", MXPreference: 10},
+			{Type: "A", Name: "www", Target: "192.0.2.1", TTL: 300},
+		}},
+	}, "bind"))
+	assert.JSONEq(t, `{
+		"registrars": [{"name": "none", "type": "NONE"}],
+		"dns_providers": [{"name": "bind", "type": "-"}],
+		"domains": [{
+			"name": "example.com",
+			"registrar": "none",
+			"dnsProviders": {"bind": -1},
+			"records": [
+				{"type": "MX", "name": "@", "target": "mail.example.com.", "mxpreference": 10},
+				{"type": "A", "name": "www", "target": "192.0.2.1", "ttl": 300}
+			]
+		}]
+	}`, b.String())
+}
+
+func TestExporterServeHTTP(t *testing.T) {
+	e := NewExporter()
+	get := func(query string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		e.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/export/dnscontrol"+query, nil))
+		return w
+	}
+
+	assert.Equal(t, http.StatusNotFound, get("").Code)
+
+	e.Enable()
+	assert.Equal(t, http.StatusServiceUnavailable, get("").Code)
+
+	e.Update(nil, testEndpoints())
+	assert.Equal(t, http.StatusBadRequest, get("").Code)
+	assert.Equal(t, http.StatusBadRequest, get("?zone=example.com&format=yaml").Code)
+
+	w := get("?zone=Example.net.&format=js&provider=bind")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/javascript", w.Header().Get("Content-Type"))
+	assert.Contains(t, w.Body.String(), `var DSP = NewDnsProvider("bind");`)
+	assert.Contains(t, w.Body.String(), `A("www", "192.0.2.3")`)
+
+	w = get("?zone=example.net")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+	assert.Contains(t, w.Body.String(), `"dnsProviders": {`)
+}
@@ -0,0 +1,143 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package egress configures the proxy and the CA bundle of the HTTP clients of the providers,
+// e.g. behind an egress proxy intercepting TLS. The default transport of the process is left
+// untouched: the providers are given a Config and pass its transport to their clients and SDKs,
+// so that the other HTTP clients, e.g. of the secret stores or of the audit webhook, don't use
+// the proxy.
+package egress
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+
+	"golang.org/x/net/http/httpproxy"
+)
+
+// Config is the proxy and the CA bundle of the HTTP clients of the providers. A nil Config keeps
+// the defaults: the proxy of the environment and the system roots.
+type Config struct {
+	// proxy returns the proxy of the requests, nil without proxy URL
+	proxy func(*http.Request) (*url.URL, error)
+	// caBundle is the PEM of the CA bundle, nil without CA bundle
+	caBundle []byte
+	// roots are the system roots with the CA bundle, nil without CA bundle
+	roots *x509.CertPool
+}
+
+// NewConfig returns the config of the proxy, http(s):// or socks5://, through which the providers
+// send their requests, except to the hosts of the NO_PROXY environment variable and to the
+// loopback addresses, and of the file of the PEM CA bundle they trust in addition to the system
+// roots. It returns nil when both are empty.
+func NewConfig(proxyURL, caBundleFile string) (*Config, error) {
+	if proxyURL == "" && caBundleFile == "" {
+		return nil, nil
+	}
+
+	c := &Config{}
+	if proxyURL != "" {
+		if err := ValidateProxyURL(proxyURL); err != nil {
+			return nil, err
+		}
+		f := (&httpproxy.Config{HTTPProxy: proxyURL, HTTPSProxy: proxyURL, NoProxy: noProxy()}).ProxyFunc()
+		c.proxy = func(req *http.Request) (*url.URL, error) { return f(req.URL) }
+	}
+
+	if caBundleFile != "" {
+		var err error
+		if c.caBundle, err = os.ReadFile(caBundleFile); err != nil {
+			return nil, fmt.Errorf("failed to read the CA bundle: %w", err)
+		}
+		if c.roots, err = x509.SystemCertPool(); err != nil {
+			c.roots = x509.NewCertPool()
+		}
+		if !c.roots.AppendCertsFromPEM(c.caBundle) {
+			return nil, fmt.Errorf("no PEM certificate found in the CA bundle %s", caBundleFile)
+		}
+	}
+	return c, nil
+}
+
+// ValidateProxyURL returns an error if the proxy URL isn't an absolute http, https or socks5 URL.
+func ValidateProxyURL(proxyURL string) error {
+	u, err := url.Parse(proxyURL)
+	if err != nil {
+		return fmt.Errorf("invalid proxy URL %q: %w", proxyURL, err)
+	}
+	switch u.Scheme {
+	case "http", "https", "socks5":
+	default:
+		return fmt.Errorf("invalid proxy URL %q: the scheme must be http, https or socks5", proxyURL)
+	}
+	if u.Host == "" {
+		return fmt.Errorf("invalid proxy URL %q: no host", proxyURL)
+	}
+	return nil
+}
+
+// noProxy returns the hosts excluded from the proxy by the environment.
+func noProxy() string {
+	if v := os.Getenv("NO_PROXY"); v != "" {
+		return v
+	}
+	return os.Getenv("no_proxy")
+}
+
+// Configured returns whether a proxy or a CA bundle is configured, for the SDKs which build their
+// own clients, which are left as they are otherwise.
+func (c *Config) Configured() bool {
+	return c != nil
+}
+
+// Apply configures a transport built by a provider with the proxy and the CA bundle, and returns
+// it. The CA bundle is added to the roots the transport already trusts, if any.
+func (c *Config) Apply(t *http.Transport) *http.Transport {
+	if c == nil {
+		return t
+	}
+	if c.proxy != nil {
+		t.Proxy = c.proxy
+	}
+	if c.caBundle != nil {
+		if t.TLSClientConfig == nil {
+			t.TLSClientConfig = &tls.Config{}
+		}
+		switch rootCAs := t.TLSClientConfig.RootCAs; rootCAs {
+		case nil:
+			t.TLSClientConfig.RootCAs = c.roots
+		case c.roots:
+		default:
+			pool := rootCAs.Clone()
+			pool.AppendCertsFromPEM(c.caBundle)
+			t.TLSClientConfig.RootCAs = pool
+		}
+	}
+	return t
+}
+
+// Transport returns a copy of the default transport configured with the proxy and the CA bundle,
+// for the clients of the providers which would use the default transport otherwise.
+func (c *Config) Transport() *http.Transport {
+	if t, ok := http.DefaultTransport.(*http.Transport); ok {
+		return c.Apply(t.Clone())
+	}
+	return c.Apply(&http.Transport{Proxy: http.ProxyFromEnvironment})
+}
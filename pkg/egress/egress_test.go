@@ -0,0 +1,118 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package egress
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigureProxy(t *testing.T) {
+	t.Setenv("NO_PROXY", "internal.example.org")
+
+	var proxied []string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied = append(proxied, r.URL.String())
+	}))
+	defer proxy.Close()
+	c, err := NewConfig(proxy.URL, "")
+	require.NoError(t, err)
+	assert.True(t, c.Configured())
+
+	// the requests of the providers, with their own transports or a copy of the default one, go through the proxy
+	for _, client := range []*http.Client{{Transport: c.Transport()}, {Transport: c.Apply(&http.Transport{})}} {
+		resp, err := client.Get("http://api.example.org/zones")
+		require.NoError(t, err)
+		resp.Body.Close()
+	}
+	assert.Equal(t, []string{"http://api.example.org/zones", "http://api.example.org/zones"}, proxied)
+
+	// the default transport, used by the other clients, is left untouched
+	assert.Equal(t, reflect.ValueOf(http.ProxyFromEnvironment).Pointer(), reflect.ValueOf(http.DefaultTransport.(*http.Transport).Proxy).Pointer())
+
+	// except those to the hosts of NO_PROXY and to the loopback addresses
+	for _, target := range []string{"http://internal.example.org/", "http://127.0.0.1:8888/"} {
+		req, err := http.NewRequest(http.MethodGet, target, nil)
+		require.NoError(t, err)
+		u, err := c.Transport().Proxy(req)
+		require.NoError(t, err)
+		assert.Nil(t, u, target)
+	}
+
+	// the defaults are kept without proxy nor CA bundle
+	c, err = NewConfig("", "")
+	require.NoError(t, err)
+	assert.False(t, c.Configured())
+	assert.Nil(t, c.Apply(&http.Transport{}).Proxy)
+}
+
+func TestConfigureCABundle(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	defer server.Close()
+
+	bundle := filepath.Join(t.TempDir(), "ca.pem")
+	require.NoError(t, os.WriteFile(bundle, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}), 0o600))
+
+	var none *Config
+	_, err := (&http.Client{Transport: none.Transport()}).Get(server.URL)
+	require.Error(t, err, "the server isn't trusted without the CA bundle")
+
+	c, err := NewConfig("", bundle)
+	require.NoError(t, err)
+	assert.True(t, c.Configured())
+	resp, err := (&http.Client{Transport: c.Transport()}).Get(server.URL)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	// the default transport, used by the other clients, doesn't trust the CA bundle
+	_, err = (&http.Client{}).Get(server.URL)
+	require.Error(t, err)
+
+	// the CA bundle is added to the roots of the transports trusting their own CAs
+	t1 := c.Apply(&http.Transport{})
+	t1.TLSClientConfig.RootCAs = x509.NewCertPool()
+	t2 := c.Apply(t1.Clone())
+	assert.NotSame(t, t1.TLSClientConfig.RootCAs, t2.TLSClientConfig.RootCAs)
+	resp, err = (&http.Client{Transport: t2}).Get(server.URL)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	_, err = NewConfig("", filepath.Join(t.TempDir(), "missing.pem"))
+	require.ErrorContains(t, err, "failed to read the CA bundle")
+	notPEM := filepath.Join(t.TempDir(), "ca.txt")
+	require.NoError(t, os.WriteFile(notPEM, []byte("not a certificate"), 0o600))
+	_, err = NewConfig("", notPEM)
+	require.ErrorContains(t, err, "no PEM certificate found")
+}
+
+func TestValidateProxyURL(t *testing.T) {
+	for _, proxyURL := range []string{"http://proxy.example.org:3128", "https://proxy.example.org", "socks5://10.0.0.1:1080"} {
+		require.NoError(t, ValidateProxyURL(proxyURL), proxyURL)
+	}
+	for _, proxyURL := range []string{"proxy.example.org:3128", "ftp://proxy.example.org", "http://", "http://[::1"} {
+		require.Error(t, ValidateProxyURL(proxyURL), proxyURL)
+	}
+}
@@ -0,0 +1,127 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package envelope
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+)
+
+// AWSKMS wraps the data keys with an AWS KMS key, through the JSON API of KMS signed with the
+// credentials of the AWS configuration.
+type AWSKMS struct {
+	config   aws.Config
+	keyID    string
+	region   string
+	endpoint string
+}
+
+// NewAWSKMS returns a key manager wrapping the data keys with the AWS KMS key, by its ID, ARN,
+// alias name or alias ARN. The KMS of the region of the ARN, or else of the configuration, is called.
+func NewAWSKMS(config aws.Config, keyID string) *AWSKMS {
+	region := config.Region
+	if arn := strings.Split(keyID, ":"); len(arn) > 3 && arn[0] == "arn" && arn[3] != "" {
+		region = arn[3]
+	}
+	endpoint := "https://kms." + region + ".amazonaws.com/"
+	if config.BaseEndpoint != nil {
+		endpoint = *config.BaseEndpoint
+	}
+	return &AWSKMS{config: config, keyID: keyID, region: region, endpoint: endpoint}
+}
+
+// Encrypt implements KeyManager.
+func (k *AWSKMS) Encrypt(ctx context.Context, plaintext []byte) ([]byte, error) {
+	var out struct {
+		CiphertextBlob []byte
+	}
+	if err := k.call(ctx, "Encrypt", map[string]any{"KeyId": k.keyID, "Plaintext": plaintext}, &out); err != nil {
+		return nil, err
+	}
+	return out.CiphertextBlob, nil
+}
+
+// Decrypt implements KeyManager.
+func (k *AWSKMS) Decrypt(ctx context.Context, ciphertext []byte) ([]byte, error) {
+	var out struct {
+		Plaintext []byte
+	}
+	if err := k.call(ctx, "Decrypt", map[string]any{"KeyId": k.keyID, "CiphertextBlob": ciphertext}, &out); err != nil {
+		return nil, err
+	}
+	return out.Plaintext, nil
+}
+
+// call calls the action of the KMS API, whose binary fields are base64-encoded in JSON.
+func (k *AWSKMS) call(ctx context.Context, action string, in, out any) error {
+	body, err := json.Marshal(in)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, k.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-amz-json-1.1")
+	req.Header.Set("X-Amz-Target", "TrentService."+action)
+
+	if k.config.Credentials == nil {
+		return fmt.Errorf("kms %s: no AWS credentials", action)
+	}
+	credentials, err := k.config.Credentials.Retrieve(ctx)
+	if err != nil {
+		return fmt.Errorf("kms %s: %w", action, err)
+	}
+	hash := sha256.Sum256(body)
+	if err := v4.NewSigner().SignHTTP(ctx, credentials, req, hex.EncodeToString(hash[:]), "kms", k.region, time.Now()); err != nil {
+		return fmt.Errorf("kms %s: %w", action, err)
+	}
+
+	var client aws.HTTPClient = http.DefaultClient
+	if k.config.HTTPClient != nil {
+		client = k.config.HTTPClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("kms %s: %w", action, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		var kmsErr struct {
+			Type    string `json:"__type"`
+			Message string `json:"message"`
+		}
+		data, _ := io.ReadAll(resp.Body)
+		_ = json.Unmarshal(data, &kmsErr)
+		return fmt.Errorf("kms %s of the key %s returned status %d: %s %s", action, k.keyID, resp.StatusCode, kmsErr.Type, kmsErr.Message)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("decoding kms %s response: %w", action, err)
+	}
+	return nil
+}
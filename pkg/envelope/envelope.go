@@ -0,0 +1,191 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package envelope encrypts the labels of the TXT registry with envelope encryption: the labels
+// are encrypted with a data key, itself encrypted by a key kept in a key management service (KMS),
+// AWS KMS, GCP Cloud KMS or the Vault transit secrets engine, instead of a static AES key.
+package envelope
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+const (
+	// KMSAWS wraps the data keys with an AWS KMS key.
+	KMSAWS = "aws-kms"
+	// KMSGCP wraps the data keys with a GCP Cloud KMS key.
+	KMSGCP = "gcp-kms"
+	// KMSVaultTransit wraps the data keys with a key of the Vault transit secrets engine.
+	KMSVaultTransit = "vault-transit"
+)
+
+// KMSs are the key management services supported.
+var KMSs = []string{KMSAWS, KMSGCP, KMSVaultTransit}
+
+// prefix marks the texts encrypted with a wrapped data key.
+const prefix = "kms:"
+
+const (
+	dataKeySize = 32
+	kmsTimeout  = 10 * time.Second
+	// minUnwrapBackoff and maxUnwrapBackoff bound the delay before unwrapping again a data key
+	// which couldn't be unwrapped, doubled at each failure.
+	minUnwrapBackoff = 5 * time.Second
+	maxUnwrapBackoff = 5 * time.Minute
+)
+
+// KeyManager encrypts and decrypts the data keys with a key which never leaves the key management service.
+type KeyManager interface {
+	Encrypt(ctx context.Context, plaintext []byte) ([]byte, error)
+	Decrypt(ctx context.Context, ciphertext []byte) ([]byte, error)
+}
+
+// Cipher is an endpoint.TextCipher encrypting the texts with AES-256-GCM and a data key generated
+// at startup and wrapped by the key manager. The wrapped data key is stored along with each text,
+// as kms:<wrapped data key>:<ciphertext>, so that the texts encrypted by the other instances or
+// before a restart can be decrypted: their data keys are unwrapped by the key manager once, then
+// cached. The texts are longer than a TXT string of 255 bytes, so their records are split by the
+// provider. The data keys which can't be unwrapped aren't unwrapped again before a backoff delay.
+// The plaintext data keys are only kept in memory.
+type Cipher struct {
+	km       KeyManager
+	fallback endpoint.TextCipher
+
+	dataKey []byte
+	// wrapped is the wrapped data key, base64-encoded
+	wrapped string
+
+	mu sync.Mutex
+	// keys are the data keys unwrapped, by wrapped data key
+	keys map[string][]byte
+	// failures are the failures to unwrap the data keys, by wrapped data key
+	failures map[string]*unwrapFailure
+	now      func() time.Time
+}
+
+// unwrapFailure is the last failure to unwrap a data key, returned until retryAt.
+type unwrapFailure struct {
+	err     error
+	backoff time.Duration
+	retryAt time.Time
+}
+
+// NewCipher returns a Cipher generating its data key and wrapping it with the key manager. The
+// texts which aren't encrypted with a wrapped data key are decrypted with the fallback cipher if
+// not nil, e.g. with the static AES key the TXT records are migrated from.
+func NewCipher(ctx context.Context, km KeyManager, fallback endpoint.TextCipher) (*Cipher, error) {
+	dataKey := make([]byte, dataKeySize)
+	if _, err := rand.Read(dataKey); err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, kmsTimeout)
+	defer cancel()
+	wrapped, err := km.Encrypt(ctx, dataKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to wrap the data key of the TXT records: %w", err)
+	}
+	encoded := base64.StdEncoding.EncodeToString(wrapped)
+	return &Cipher{
+		km:       km,
+		fallback: fallback,
+		dataKey:  dataKey,
+		wrapped:  encoded,
+		keys:     map[string][]byte{encoded: dataKey},
+		failures: map[string]*unwrapFailure{},
+		now:      time.Now,
+	}, nil
+}
+
+// EncryptText implements endpoint.TextCipher.
+func (c *Cipher) EncryptText(text string, nonceEncoded []byte) (string, error) {
+	ciphertext, err := endpoint.EncryptText(text, c.dataKey, nonceEncoded)
+	if err != nil {
+		return "", err
+	}
+	return prefix + c.wrapped + ":" + ciphertext, nil
+}
+
+// DecryptText implements endpoint.TextCipher. It returns an endpoint.ErrCipherUnavailable error
+// if the data key of the text can't be unwrapped.
+func (c *Cipher) DecryptText(text string) (string, string, error) {
+	wrapped, ciphertext, ok := strings.Cut(strings.TrimPrefix(text, prefix), ":")
+	if !strings.HasPrefix(text, prefix) || !ok {
+		if c.fallback != nil {
+			return c.fallback.DecryptText(text)
+		}
+		return "", "", fmt.Errorf("the text %#v isn't encrypted with a wrapped data key", text)
+	}
+	dataKey, err := c.dataKeyOf(wrapped)
+	if err != nil {
+		return "", "", err
+	}
+	return endpoint.DecryptText(ciphertext, dataKey)
+}
+
+// dataKeyOf returns the data key unwrapped from the wrapped data key, base64-encoded.
+func (c *Cipher) dataKeyOf(wrapped string) ([]byte, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if dataKey, ok := c.keys[wrapped]; ok {
+		return dataKey, nil
+	}
+	failure := c.failures[wrapped]
+	if failure != nil && c.now().Before(failure.retryAt) {
+		return nil, failure.err
+	}
+	ciphertext, err := base64.StdEncoding.DecodeString(wrapped)
+	if err != nil {
+		return nil, fmt.Errorf("invalid wrapped data key: %w", err)
+	}
+	dataKey, err := c.unwrap(ciphertext)
+	if err != nil {
+		backoff := minUnwrapBackoff
+		if failure != nil {
+			backoff = min(2*failure.backoff, maxUnwrapBackoff)
+		}
+		log.Debugf("Failed to unwrap the data key of TXT records, retrying in %s", backoff)
+		c.failures[wrapped] = &unwrapFailure{err: err, backoff: backoff, retryAt: c.now().Add(backoff)}
+		return nil, err
+	}
+	log.Debug("Unwrapped the data key of TXT records encrypted by another instance")
+	delete(c.failures, wrapped)
+	c.keys[wrapped] = dataKey
+	return dataKey, nil
+}
+
+// unwrap unwraps a data key with the key manager.
+func (c *Cipher) unwrap(ciphertext []byte) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), kmsTimeout)
+	defer cancel()
+	dataKey, err := c.km.Decrypt(ctx, ciphertext)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to unwrap the data key of the TXT records: %w", endpoint.ErrCipherUnavailable, err)
+	}
+	if len(dataKey) != dataKeySize {
+		return nil, fmt.Errorf("the unwrapped data key is %d bytes long instead of %d", len(dataKey), dataKeySize)
+	}
+	return dataKey, nil
+}
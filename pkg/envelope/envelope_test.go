@@ -0,0 +1,182 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package envelope
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+// fakeKeyManager wraps the data keys by reversing them, as a key management service would.
+type fakeKeyManager struct {
+	decrypts int
+	err      error
+}
+
+func reverse(b []byte) []byte {
+	r := make([]byte, len(b))
+	for i := range b {
+		r[len(b)-1-i] = b[i]
+	}
+	return r
+}
+
+func (k *fakeKeyManager) Encrypt(_ context.Context, plaintext []byte) ([]byte, error) {
+	return reverse(plaintext), k.err
+}
+
+func (k *fakeKeyManager) Decrypt(_ context.Context, ciphertext []byte) ([]byte, error) {
+	k.decrypts++
+	return reverse(ciphertext), k.err
+}
+
+const labels = "heritage=external-dns,external-dns/owner=default,external-dns/resource=ingress/default/web"
+
+func TestCipher(t *testing.T) {
+	ctx := context.Background()
+	km := &fakeKeyManager{}
+	c, err := NewCipher(ctx, km, nil)
+	require.NoError(t, err)
+	nonce, err := endpoint.GenerateNonce()
+	require.NoError(t, err)
+
+	encrypted, err := c.EncryptText(labels, nonce)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(encrypted, "kms:"+c.wrapped+":"))
+	assert.NotContains(t, encrypted, "external-dns")
+
+	// the texts encrypted with its own data key are decrypted without the key manager
+	decrypted, decryptedNonce, err := c.DecryptText(encrypted)
+	require.NoError(t, err)
+	assert.Equal(t, labels, decrypted)
+	assert.Equal(t, string(nonce), decryptedNonce)
+	assert.Zero(t, km.decrypts)
+
+	// the data keys of the other instances are unwrapped once
+	other, err := NewCipher(ctx, km, nil)
+	require.NoError(t, err)
+	assert.NotEqual(t, c.wrapped, other.wrapped)
+	for range 2 {
+		decrypted, _, err = other.DecryptText(encrypted)
+		require.NoError(t, err)
+		assert.Equal(t, labels, decrypted)
+	}
+	assert.Equal(t, 1, km.decrypts)
+
+	// the texts which aren't encrypted with a wrapped data key aren't decrypted
+	for _, text := range []string{labels, "v=spf1 include:_spf.example.org ~all", "kms:not base64:AAAA"} {
+		_, _, err := c.DecryptText(text)
+		require.Error(t, err, text)
+		assert.NotErrorIs(t, err, endpoint.ErrCipherUnavailable, text)
+	}
+}
+
+func TestCipherFallback(t *testing.T) {
+	aesKey := []byte("passphrasewhichneedstobe32bytes!")
+	fallback, err := endpoint.NewAESCipher(aesKey)
+	require.NoError(t, err)
+	c, err := NewCipher(context.Background(), &fakeKeyManager{}, fallback)
+	require.NoError(t, err)
+
+	// the texts encrypted with the AES key are decrypted with it, while migrating
+	nonce, err := endpoint.GenerateNonce()
+	require.NoError(t, err)
+	encrypted, err := endpoint.EncryptText(labels, aesKey, nonce)
+	require.NoError(t, err)
+	decrypted, _, err := c.DecryptText(encrypted)
+	require.NoError(t, err)
+	assert.Equal(t, labels, decrypted)
+
+	// and encrypted again with a wrapped data key
+	l, err := endpoint.NewLabelsFromStringWithCipher(`"`+encrypted+`"`, c)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(l.SerializeWithCipher(true, c), `"kms:`))
+}
+
+func TestCipherUnavailable(t *testing.T) {
+	ctx := context.Background()
+	encrypting, err := NewCipher(ctx, &fakeKeyManager{}, nil)
+	require.NoError(t, err)
+	nonce, err := endpoint.GenerateNonce()
+	require.NoError(t, err)
+	encrypted, err := encrypting.EncryptText(labels, nonce)
+	require.NoError(t, err)
+
+	km := &fakeKeyManager{}
+	c, err := NewCipher(ctx, km, nil)
+	require.NoError(t, err)
+	km.err = errors.New("connection refused")
+
+	// the records of the texts whose data key can't be unwrapped aren't taken for unowned records
+	_, _, err = c.DecryptText(encrypted)
+	require.ErrorIs(t, err, endpoint.ErrCipherUnavailable)
+	_, err = endpoint.NewLabelsFromStringWithCipher(encrypted, c)
+	require.ErrorIs(t, err, endpoint.ErrCipherUnavailable)
+
+	_, err = NewCipher(ctx, km, nil)
+	require.ErrorContains(t, err, "failed to wrap the data key")
+}
+
+func TestCipherUnwrapBackoff(t *testing.T) {
+	ctx := context.Background()
+	encrypting, err := NewCipher(ctx, &fakeKeyManager{}, nil)
+	require.NoError(t, err)
+	nonce, err := endpoint.GenerateNonce()
+	require.NoError(t, err)
+	encrypted, err := encrypting.EncryptText(labels, nonce)
+	require.NoError(t, err)
+
+	km := &fakeKeyManager{}
+	c, err := NewCipher(ctx, km, nil)
+	require.NoError(t, err)
+	now := time.Now()
+	c.now = func() time.Time { return now }
+	km.err = errors.New("connection refused")
+
+	// the texts of a data key which can't be unwrapped fail without calling the key manager again
+	for range 3 {
+		_, _, err = c.DecryptText(encrypted)
+		require.ErrorIs(t, err, endpoint.ErrCipherUnavailable)
+	}
+	assert.Equal(t, 1, km.decrypts)
+
+	// until the backoff delay, doubled at each failure
+	now = now.Add(minUnwrapBackoff)
+	_, _, err = c.DecryptText(encrypted)
+	require.ErrorIs(t, err, endpoint.ErrCipherUnavailable)
+	assert.Equal(t, 2, km.decrypts)
+	now = now.Add(minUnwrapBackoff)
+	_, _, err = c.DecryptText(encrypted)
+	require.ErrorIs(t, err, endpoint.ErrCipherUnavailable)
+	assert.Equal(t, 2, km.decrypts)
+
+	km.err = nil
+	now = now.Add(minUnwrapBackoff)
+	decrypted, _, err := c.DecryptText(encrypted)
+	require.NoError(t, err)
+	assert.Equal(t, labels, decrypted)
+	assert.Equal(t, 3, km.decrypts)
+	assert.Empty(t, c.failures)
+}
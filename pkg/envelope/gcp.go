@@ -0,0 +1,63 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package envelope
+
+import (
+	"context"
+	"encoding/base64"
+
+	cloudkms "google.golang.org/api/cloudkms/v1"
+	"google.golang.org/api/option"
+)
+
+// GCPKMS wraps the data keys with a GCP Cloud KMS key, with the application default credentials.
+type GCPKMS struct {
+	keys *cloudkms.ProjectsLocationsKeyRingsCryptoKeysService
+	name string
+}
+
+// NewGCPKMS returns a key manager wrapping the data keys with the Cloud KMS key, by its resource
+// name: projects/<project>/locations/<location>/keyRings/<key ring>/cryptoKeys/<key>.
+func NewGCPKMS(ctx context.Context, name string, opts ...option.ClientOption) (*GCPKMS, error) {
+	service, err := cloudkms.NewService(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &GCPKMS{keys: service.Projects.Locations.KeyRings.CryptoKeys, name: name}, nil
+}
+
+// Encrypt implements KeyManager.
+func (k *GCPKMS) Encrypt(ctx context.Context, plaintext []byte) ([]byte, error) {
+	resp, err := k.keys.Encrypt(k.name, &cloudkms.EncryptRequest{
+		Plaintext: base64.StdEncoding.EncodeToString(plaintext),
+	}).Context(ctx).Do()
+	if err != nil {
+		return nil, err
+	}
+	return base64.StdEncoding.DecodeString(resp.Ciphertext)
+}
+
+// Decrypt implements KeyManager.
+func (k *GCPKMS) Decrypt(ctx context.Context, ciphertext []byte) ([]byte, error) {
+	resp, err := k.keys.Decrypt(k.name, &cloudkms.DecryptRequest{
+		Ciphertext: base64.StdEncoding.EncodeToString(ciphertext),
+	}).Context(ctx).Do()
+	if err != nil {
+		return nil, err
+	}
+	return base64.StdEncoding.DecodeString(resp.Plaintext)
+}
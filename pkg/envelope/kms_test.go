@@ -0,0 +1,150 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package envelope
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/api/option"
+
+	"sigs.k8s.io/external-dns/pkg/secrets"
+)
+
+var dataKey = []byte("0123456789abcdef0123456789abcdef")
+
+func TestAWSKMS(t *testing.T) {
+	var actions []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.True(t, strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=AKID/"))
+		assert.Contains(t, r.Header.Get("Authorization"), "/eu-west-1/kms/aws4_request")
+		action := strings.TrimPrefix(r.Header.Get("X-Amz-Target"), "TrentService.")
+		actions = append(actions, action)
+		var in map[string]string
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&in))
+		assert.Equal(t, "arn:aws:kms:eu-west-1:123456789012:key/external-dns", in["KeyId"])
+		switch action {
+		case "Encrypt":
+			_ = json.NewEncoder(w).Encode(map[string]string{"CiphertextBlob": base64.StdEncoding.EncodeToString([]byte("wrapped"))})
+		case "Decrypt":
+			if in["CiphertextBlob"] != base64.StdEncoding.EncodeToString([]byte("wrapped")) {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte(`{"__type":"InvalidCiphertextException","message":"invalid ciphertext"}`))
+				return
+			}
+			_ = json.NewEncoder(w).Encode(map[string]string{"Plaintext": base64.StdEncoding.EncodeToString(dataKey)})
+		}
+	}))
+	defer server.Close()
+
+	// the region of the key ARN is the one signed
+	k := NewAWSKMS(aws.Config{
+		Region:       "us-east-1",
+		Credentials:  credentials.NewStaticCredentialsProvider("AKID", "SECRET", ""),
+		BaseEndpoint: aws.String(server.URL),
+	}, "arn:aws:kms:eu-west-1:123456789012:key/external-dns")
+	ctx := context.Background()
+	wrapped, err := k.Encrypt(ctx, dataKey)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("wrapped"), wrapped)
+	unwrapped, err := k.Decrypt(ctx, wrapped)
+	require.NoError(t, err)
+	assert.Equal(t, dataKey, unwrapped)
+	_, err = k.Decrypt(ctx, []byte("other"))
+	require.ErrorContains(t, err, "InvalidCiphertextException")
+	assert.Equal(t, []string{"Encrypt", "Decrypt", "Decrypt"}, actions)
+
+	assert.Equal(t, "https://kms.us-east-1.amazonaws.com/", NewAWSKMS(aws.Config{Region: "us-east-1"}, "alias/external-dns").endpoint)
+}
+
+func TestGCPKMS(t *testing.T) {
+	const name = "projects/dns/locations/global/keyRings/external-dns/cryptoKeys/txt"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var in map[string]string
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&in))
+		switch r.URL.Path {
+		case "/v1/" + name + ":encrypt":
+			_ = json.NewEncoder(w).Encode(map[string]string{"name": name, "ciphertext": base64.StdEncoding.EncodeToString([]byte("wrapped"))})
+		case "/v1/" + name + ":decrypt":
+			assert.Equal(t, base64.StdEncoding.EncodeToString([]byte("wrapped")), in["ciphertext"])
+			_ = json.NewEncoder(w).Encode(map[string]string{"plaintext": base64.StdEncoding.EncodeToString(dataKey)})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	k, err := NewGCPKMS(ctx, name, option.WithEndpoint(server.URL), option.WithoutAuthentication())
+	require.NoError(t, err)
+	wrapped, err := k.Encrypt(ctx, dataKey)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("wrapped"), wrapped)
+	unwrapped, err := k.Decrypt(ctx, wrapped)
+	require.NoError(t, err)
+	assert.Equal(t, dataKey, unwrapped)
+}
+
+func TestVaultTransit(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "token", r.Header.Get("X-Vault-Token"))
+		assert.Equal(t, "dns", r.Header.Get("X-Vault-Namespace"))
+		var in map[string]string
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&in))
+		switch r.URL.Path {
+		case "/v1/transit/encrypt/external-dns":
+			_ = json.NewEncoder(w).Encode(map[string]any{"data": map[string]string{"ciphertext": "vault:v1:" + in["plaintext"]}})
+		case "/v1/transit/decrypt/external-dns":
+			_ = json.NewEncoder(w).Encode(map[string]any{"data": map[string]string{"plaintext": strings.TrimPrefix(in["ciphertext"], "vault:v1:")}})
+		default:
+			w.WriteHeader(http.StatusForbidden)
+		}
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	config := secrets.VaultConfig{Address: server.URL, Token: "token", Namespace: "dns"}
+	k, err := NewVaultTransit(config, "transit/external-dns", nil)
+	require.NoError(t, err)
+	wrapped, err := k.Encrypt(ctx, dataKey)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(wrapped), "vault:v1:"))
+	unwrapped, err := k.Decrypt(ctx, wrapped)
+	require.NoError(t, err)
+	assert.Equal(t, dataKey, unwrapped)
+
+	k, err = NewVaultTransit(config, "other/external-dns", nil)
+	require.NoError(t, err)
+	_, err = k.Encrypt(ctx, dataKey)
+	require.ErrorContains(t, err, "status 403")
+
+	for _, key := range []string{"external-dns", "/external-dns", "transit/"} {
+		_, err := NewVaultTransit(config, key, nil)
+		require.Error(t, err, key)
+	}
+	_, err = NewVaultTransit(secrets.VaultConfig{}, "transit/external-dns", nil)
+	require.ErrorContains(t, err, "VAULT_ADDR")
+}
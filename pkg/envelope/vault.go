@@ -0,0 +1,114 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package envelope
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"sigs.k8s.io/external-dns/pkg/secrets"
+)
+
+const vaultRequestTimeout = 10 * time.Second
+
+// VaultTransit wraps the data keys with a key of the Vault transit secrets engine.
+type VaultTransit struct {
+	config secrets.VaultConfig
+	mount  string
+	key    string
+	client *http.Client
+}
+
+// NewVaultTransit returns a key manager wrapping the data keys with the transit key <mount>/<key>,
+// e.g. transit/external-dns, of the Vault server of the configuration.
+func NewVaultTransit(config secrets.VaultConfig, key string, client *http.Client) (*VaultTransit, error) {
+	i := strings.LastIndex(key, "/")
+	if i <= 0 || i == len(key)-1 {
+		return nil, fmt.Errorf("invalid vault transit key %q: expected <mount>/<key>", key)
+	}
+	if config.Address == "" {
+		return nil, errors.New("vault address is not configured, set VAULT_ADDR")
+	}
+	if client == nil {
+		client = &http.Client{Timeout: vaultRequestTimeout}
+	}
+	return &VaultTransit{config: config, mount: strings.Trim(key[:i], "/"), key: key[i+1:], client: client}, nil
+}
+
+// Encrypt implements KeyManager. The ciphertext is the one of Vault, vault:v<version>:<base64>.
+func (k *VaultTransit) Encrypt(ctx context.Context, plaintext []byte) ([]byte, error) {
+	var out struct {
+		Ciphertext string `json:"ciphertext"`
+	}
+	if err := k.call(ctx, "encrypt", map[string]string{"plaintext": base64.StdEncoding.EncodeToString(plaintext)}, &out); err != nil {
+		return nil, err
+	}
+	return []byte(out.Ciphertext), nil
+}
+
+// Decrypt implements KeyManager.
+func (k *VaultTransit) Decrypt(ctx context.Context, ciphertext []byte) ([]byte, error) {
+	var out struct {
+		Plaintext string `json:"plaintext"`
+	}
+	if err := k.call(ctx, "decrypt", map[string]string{"ciphertext": string(ciphertext)}, &out); err != nil {
+		return nil, err
+	}
+	return base64.StdEncoding.DecodeString(out.Plaintext)
+}
+
+// call posts to the encrypt or decrypt endpoint of the transit key, and decodes the data of the response.
+func (k *VaultTransit) call(ctx context.Context, operation string, in map[string]string, out any) error {
+	body, err := json.Marshal(in)
+	if err != nil {
+		return err
+	}
+	url := strings.TrimSuffix(k.config.Address, "/") + "/v1/" + k.mount + "/" + operation + "/" + k.key
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Vault-Token", k.config.Token)
+	if k.config.Namespace != "" {
+		req.Header.Set("X-Vault-Namespace", k.config.Namespace)
+	}
+
+	resp, err := k.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("vault returned status %d to %s with the transit key %s/%s", resp.StatusCode, operation, k.mount, k.key)
+	}
+
+	var data struct {
+		Data json.RawMessage `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return fmt.Errorf("decoding vault response: %w", err)
+	}
+	return json.Unmarshal(data.Data, out)
+}
@@ -0,0 +1,234 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package failover sends the requests of the HTTP clients of the providers to the first healthy
+// endpoint of an ordered list of redundant or regional API endpoints, so that the outage of one
+// of them doesn't stop the reconciliation.
+package failover
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// DefaultCooldown is the time an endpoint which failed is skipped for, unless configured with
+// WithCooldown.
+const DefaultCooldown = 30 * time.Second
+
+// Option configures the failover transport.
+type Option func(*Transport)
+
+// WithCooldown sets the time an endpoint which failed is skipped for, before the requests are
+// sent to it again. The zero value keeps the default.
+func WithCooldown(d time.Duration) Option {
+	return func(t *Transport) {
+		if d > 0 {
+			t.cooldown = d
+		}
+	}
+}
+
+// ValidateEndpoint returns an error if the endpoint isn't an absolute http or https URL.
+func ValidateEndpoint(endpoint string) error {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return fmt.Errorf("invalid API endpoint %q: %w", endpoint, err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("invalid API endpoint %q: the scheme must be http or https", endpoint)
+	}
+	if u.Host == "" {
+		return fmt.Errorf("invalid API endpoint %q: no host", endpoint)
+	}
+	return nil
+}
+
+// Transport is a round tripper sending the requests built for the first, primary, endpoint of the
+// list to the first healthy one. An endpoint is unhealthy for the cooldown once a request to it
+// failed to connect or was answered with a 502, 503 or 504 status, and the request is then sent
+// to the next endpoint. The unhealthy endpoints are still tried last, in order, when no other
+// endpoint answered. The requests to other URLs are sent as they are.
+type Transport struct {
+	endpoints []*url.URL
+	next      http.RoundTripper
+	cooldown  time.Duration
+
+	mu sync.Mutex
+	// unhealthyUntil is the end of the cooldown of each endpoint, zero while it's healthy
+	unhealthyUntil []time.Time
+	now            func() time.Time
+}
+
+// NewTransport returns a transport failing over between the endpoints, in order, which sends the
+// requests with next, or the default transport if nil.
+func NewTransport(endpoints []string, next http.RoundTripper, opts ...Option) (*Transport, error) {
+	if len(endpoints) == 0 {
+		return nil, errors.New("no API endpoint")
+	}
+	t := &Transport{
+		next:           next,
+		cooldown:       DefaultCooldown,
+		unhealthyUntil: make([]time.Time, len(endpoints)),
+		now:            time.Now,
+	}
+	for _, opt := range opts {
+		opt(t)
+	}
+	if t.next == nil {
+		t.next = http.DefaultTransport
+	}
+	for _, endpoint := range endpoints {
+		if err := ValidateEndpoint(endpoint); err != nil {
+			return nil, err
+		}
+		u, _ := url.Parse(endpoint)
+		u.Path = strings.TrimSuffix(u.Path, "/")
+		u.RawPath = ""
+		t.endpoints = append(t.endpoints, u)
+	}
+	return t, nil
+}
+
+// RoundTrip sends the request to the first healthy endpoint which answers it.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	suffix, ok := t.suffix(req.URL)
+	if !ok {
+		return t.next.RoundTrip(req)
+	}
+
+	var resp *http.Response
+	var err error
+	for n, i := range t.order() {
+		if n > 0 {
+			// the request can only be sent again if its body can be read again
+			if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+				break
+			}
+			if resp != nil {
+				resp.Body.Close()
+			}
+		}
+		attempt := req.Clone(req.Context())
+		if n > 0 && req.GetBody != nil {
+			if attempt.Body, err = req.GetBody(); err != nil {
+				return nil, err
+			}
+		}
+		attempt.URL = t.resolve(i, suffix, req.URL)
+		attempt.Host = ""
+
+		resp, err = t.next.RoundTrip(attempt)
+		if !t.failed(resp, err) {
+			t.markHealthy(i)
+			return resp, err
+		}
+		if req.Context().Err() != nil {
+			// the request was canceled, which tells nothing about the health of the endpoint
+			return resp, err
+		}
+		t.markUnhealthy(i, resp, err)
+	}
+	return resp, err
+}
+
+// suffix returns the path of the URL relative to the primary endpoint, and whether the URL is one of it.
+func (t *Transport) suffix(u *url.URL) (string, bool) {
+	primary := t.endpoints[0]
+	if u.Scheme != primary.Scheme || u.Host != primary.Host {
+		return "", false
+	}
+	if primary.Path == "" {
+		return u.Path, true
+	}
+	if u.Path != primary.Path && !strings.HasPrefix(u.Path, primary.Path+"/") {
+		return "", false
+	}
+	return strings.TrimPrefix(u.Path, primary.Path), true
+}
+
+// resolve returns the URL of the endpoint i for the path relative to the primary endpoint.
+func (t *Transport) resolve(i int, suffix string, u *url.URL) *url.URL {
+	resolved := *u
+	resolved.Scheme = t.endpoints[i].Scheme
+	resolved.Host = t.endpoints[i].Host
+	resolved.Path = t.endpoints[i].Path + suffix
+	resolved.RawPath = ""
+	return &resolved
+}
+
+// order returns the indexes of the healthy endpoints followed by those of the unhealthy ones.
+func (t *Transport) order() []int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	now := t.now()
+	healthy := make([]int, 0, len(t.endpoints))
+	var unhealthy []int
+	for i, until := range t.unhealthyUntil {
+		if now.Before(until) {
+			unhealthy = append(unhealthy, i)
+		} else {
+			healthy = append(healthy, i)
+		}
+	}
+	return append(healthy, unhealthy...)
+}
+
+// failed returns whether the endpoint failed to answer the request.
+func (t *Transport) failed(resp *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+	switch resp.StatusCode {
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+func (t *Transport) markHealthy(i int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if !t.unhealthyUntil[i].IsZero() {
+		log.Infof("API endpoint %s is healthy again", t.endpoints[i].Host)
+		t.unhealthyUntil[i] = time.Time{}
+	}
+}
+
+func (t *Transport) markUnhealthy(i int, resp *http.Response, err error) {
+	d := t.cooldown
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	now := t.now()
+	wasHealthy := !now.Before(t.unhealthyUntil[i])
+	t.unhealthyUntil[i] = now.Add(d)
+	if !wasHealthy {
+		return
+	}
+	if err == nil {
+		err = fmt.Errorf("status %s", resp.Status)
+	}
+	if len(t.endpoints) > 1 {
+		log.Warnf("API endpoint %s is unhealthy, failing over to the other endpoints for %s: %v", t.endpoints[i].Host, d, err)
+	}
+}
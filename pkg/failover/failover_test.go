@@ -0,0 +1,162 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package failover
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// apiServer is an API endpoint answering with its status, and recording the paths and bodies it received.
+type apiServer struct {
+	*httptest.Server
+	mu       sync.Mutex
+	status   int
+	requests []string
+}
+
+func newAPIServer(t *testing.T) *apiServer {
+	s := &apiServer{status: http.StatusOK}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.requests = append(s.requests, strings.TrimSpace(r.URL.Path+" "+string(body)))
+		w.WriteHeader(s.status)
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *apiServer) setStatus(status int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.status = status
+}
+
+func (s *apiServer) received() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.requests
+}
+
+func TestTransport(t *testing.T) {
+	primary, secondary := newAPIServer(t), newAPIServer(t)
+	transport, err := NewTransport([]string{primary.URL + "/api/v1/", secondary.URL + "/region2/api/v1"}, nil)
+	require.NoError(t, err)
+	now := time.Now()
+	transport.now = func() time.Time { return now }
+	client := &http.Client{Transport: transport}
+
+	post := func(body string) int {
+		resp, err := client.Post(primary.URL+"/api/v1/zones", "text/plain", strings.NewReader(body))
+		require.NoError(t, err)
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	// the requests are sent to the primary endpoint while it's healthy
+	assert.Equal(t, http.StatusOK, post("1"))
+	assert.Equal(t, []string{"/api/v1/zones 1"}, primary.received())
+	assert.Empty(t, secondary.received())
+
+	// and to the next endpoint, with their body, once it fails
+	primary.setStatus(http.StatusServiceUnavailable)
+	assert.Equal(t, http.StatusOK, post("2"))
+	assert.Equal(t, []string{"/region2/api/v1/zones 2"}, secondary.received())
+
+	// the unhealthy endpoint is skipped for the cooldown
+	assert.Equal(t, http.StatusOK, post("3"))
+	assert.Len(t, primary.received(), 2)
+	assert.Equal(t, []string{"/region2/api/v1/zones 2", "/region2/api/v1/zones 3"}, secondary.received())
+
+	// and preferred again once it's over and the endpoint recovered
+	primary.setStatus(http.StatusOK)
+	now = now.Add(DefaultCooldown)
+	assert.Equal(t, http.StatusOK, post("4"))
+	assert.Equal(t, "/api/v1/zones 4", primary.received()[2])
+	assert.Len(t, secondary.received(), 2)
+
+	// the errors of the requests themselves don't fail over
+	primary.setStatus(http.StatusNotFound)
+	assert.Equal(t, http.StatusNotFound, post("5"))
+	assert.Len(t, secondary.received(), 2)
+}
+
+func TestTransportAllUnhealthy(t *testing.T) {
+	primary, secondary := newAPIServer(t), newAPIServer(t)
+	primary.setStatus(http.StatusBadGateway)
+	secondary.Close()
+	transport, err := NewTransport([]string{primary.URL, secondary.URL}, nil)
+	require.NoError(t, err)
+	client := &http.Client{Transport: transport}
+
+	// the answer of the last endpoint tried is returned when none is healthy
+	resp, err := client.Get(primary.URL + "/records")
+	require.ErrorContains(t, err, "connection refused")
+	assert.Nil(t, resp)
+
+	// the unhealthy endpoints are still tried, in order
+	resp, err = client.Get(primary.URL + "/records")
+	require.Error(t, err)
+	assert.Nil(t, resp)
+	assert.Equal(t, []string{"/records", "/records"}, primary.received())
+}
+
+func TestTransportOtherURLs(t *testing.T) {
+	primary, other := newAPIServer(t), newAPIServer(t)
+	primary.setStatus(http.StatusServiceUnavailable)
+	transport, err := NewTransport([]string{primary.URL + "/api", "http://127.0.0.1:1"}, nil)
+	require.NoError(t, err)
+	client := &http.Client{Transport: transport}
+
+	// the requests out of the primary endpoint are sent as they are
+	for _, u := range []string{other.URL + "/api/zones", primary.URL + "/apis/zones"} {
+		resp, err := client.Get(u)
+		require.NoError(t, err)
+		resp.Body.Close()
+	}
+	assert.Equal(t, []string{"/api/zones"}, other.received())
+	assert.Equal(t, []string{"/apis/zones"}, primary.received())
+}
+
+func TestNewTransport(t *testing.T) {
+	_, err := NewTransport(nil, nil)
+	require.EqualError(t, err, "no API endpoint")
+	_, err = NewTransport([]string{"https://api.example.org", "api2.example.org"}, nil)
+	require.EqualError(t, err, `invalid API endpoint "api2.example.org": the scheme must be http or https`)
+	_, err = NewTransport([]string{"https://"}, nil)
+	require.EqualError(t, err, `invalid API endpoint "https://": no host`)
+}
+
+func TestWithCooldown(t *testing.T) {
+	transport, err := NewTransport([]string{"https://api.example.org"}, nil, WithCooldown(time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, time.Minute, transport.cooldown)
+
+	transport, err = NewTransport([]string{"https://api.example.org"}, nil, WithCooldown(0))
+	require.NoError(t, err)
+	assert.Equal(t, DefaultCooldown, transport.cooldown)
+}
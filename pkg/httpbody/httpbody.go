@@ -0,0 +1,133 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package httpbody reads the bodies of the responses of the DNS provider APIs defensively, so
+// that a misbehaving server or proxy returning a huge body can't exhaust the memory of the
+// controller, and an HTML error page is reported as such instead of as an unmarshal error.
+package httpbody
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// DefaultMaxBytes is the maximum size of a response body read by the provider clients.
+const DefaultMaxBytes = 64 << 20
+
+// snippetBytes is the number of bytes of an unexpected response quoted in the error.
+const snippetBytes = 256
+
+var (
+	// ErrTooLarge is returned when a body is larger than its maximum size.
+	ErrTooLarge = errors.New("response body too large")
+	// ErrUnexpectedContentType is returned when a response has an unexpected content type.
+	ErrUnexpectedContentType = errors.New("unexpected content type")
+)
+
+// Guard reads the bodies of the responses of an API.
+type Guard struct {
+	// MaxBytes is the maximum size of a body, DefaultMaxBytes if zero.
+	MaxBytes int64
+	// ContentTypes are the media types of the bodies of the API, any if empty.
+	ContentTypes []string
+}
+
+// Read reads and closes the body of the response. It returns ErrTooLarge if the body is
+// larger than MaxBytes, and ErrUnexpectedContentType, quoting the beginning of the body, if
+// a non-empty body doesn't have one of the ContentTypes.
+func (g Guard) Read(res *http.Response) ([]byte, error) {
+	defer res.Body.Close()
+
+	contentType := res.Header.Get("Content-Type")
+	if len(g.ContentTypes) > 0 && !g.accepts(contentType) {
+		snippet, err := io.ReadAll(io.LimitReader(res.Body, snippetBytes+1))
+		if err != nil || len(snippet) == 0 {
+			return nil, err
+		}
+		return nil, fmt.Errorf("%w %q of the response with status %q, expected %s: %s",
+			ErrUnexpectedContentType, contentType, res.Status, strings.Join(g.ContentTypes, " or "), quote(snippet))
+	}
+
+	maxBytes := g.MaxBytes
+	if maxBytes <= 0 {
+		maxBytes = DefaultMaxBytes
+	}
+	return ReadAll(res.Body, maxBytes)
+}
+
+func (g Guard) accepts(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	return slices.ContainsFunc(g.ContentTypes, func(t string) bool {
+		return strings.EqualFold(t, mediaType)
+	})
+}
+
+// quote returns the beginning of a body, truncated to snippetBytes.
+func quote(snippet []byte) string {
+	truncated := len(snippet) > snippetBytes
+	if truncated {
+		snippet = snippet[:snippetBytes]
+	}
+	s := strings.Join(strings.Fields(string(snippet)), " ")
+	if truncated {
+		s += "..."
+	}
+	return s
+}
+
+// ReadAll reads r until EOF like io.ReadAll, but returns ErrTooLarge instead of reading more
+// than maxBytes.
+func ReadAll(r io.Reader, maxBytes int64) ([]byte, error) {
+	return io.ReadAll(LimitReader(r, maxBytes))
+}
+
+// LimitReader returns a reader reading from r, which returns ErrTooLarge once more than
+// maxBytes have been read, unlike io.LimitReader which silently stops at the limit.
+func LimitReader(r io.Reader, maxBytes int64) io.Reader {
+	return &limitedReader{r: r, maxBytes: maxBytes, remaining: maxBytes}
+}
+
+type limitedReader struct {
+	r         io.Reader
+	maxBytes  int64
+	remaining int64
+}
+
+func (l *limitedReader) Read(p []byte) (int, error) {
+	if l.remaining <= 0 {
+		// the limit is reached: the body is too large unless it ends here
+		var b [1]byte
+		n, err := l.r.Read(b[:])
+		if n > 0 {
+			return 0, fmt.Errorf("%w: more than %d bytes", ErrTooLarge, l.maxBytes)
+		}
+		return 0, err
+	}
+	if int64(len(p)) > l.remaining {
+		p = p[:l.remaining]
+	}
+	n, err := l.r.Read(p)
+	l.remaining -= int64(n)
+	return n, err
+}
@@ -0,0 +1,107 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package httpbody
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newResponse(contentType, body string) *http.Response {
+	header := http.Header{}
+	if contentType != "" {
+		header.Set("Content-Type", contentType)
+	}
+	return &http.Response{
+		Status: "502 Bad Gateway",
+		Header: header,
+		Body:   io.NopCloser(strings.NewReader(body)),
+	}
+}
+
+func TestReadAll(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		body     string
+		maxBytes int64
+		tooLarge bool
+	}{
+		{name: "empty", body: "", maxBytes: 4},
+		{name: "below the limit", body: "abc", maxBytes: 4},
+		{name: "at the limit", body: "abcd", maxBytes: 4},
+		{name: "above the limit", body: "abcde", maxBytes: 4, tooLarge: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			body, err := ReadAll(strings.NewReader(tc.body), tc.maxBytes)
+			if tc.tooLarge {
+				require.ErrorIs(t, err, ErrTooLarge)
+				assert.ErrorContains(t, err, "more than 4 bytes")
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.body, string(body))
+		})
+	}
+}
+
+func TestGuardRead(t *testing.T) {
+	guard := Guard{MaxBytes: 16, ContentTypes: []string{"application/json"}}
+
+	for _, tc := range []struct {
+		name        string
+		contentType string
+		body        string
+		expected    string
+		expectedErr error
+	}{
+		{name: "json", contentType: "application/json", body: `{"a":1}`, expected: `{"a":1}`},
+		{name: "json with parameters", contentType: "Application/JSON; charset=utf-8", body: `{"a":1}`, expected: `{"a":1}`},
+		{name: "empty body without content type", body: ""},
+		{name: "html error page", contentType: "text/html", body: "<html>\n  <h1>Bad Gateway</h1>\n</html>", expectedErr: ErrUnexpectedContentType},
+		{name: "missing content type", body: `{"a":1}`, expectedErr: ErrUnexpectedContentType},
+		{name: "too large", contentType: "application/json", body: `{"a":"0123456789abcdef"}`, expectedErr: ErrTooLarge},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			body, err := guard.Read(newResponse(tc.contentType, tc.body))
+			if tc.expectedErr != nil {
+				require.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, string(body))
+		})
+	}
+}
+
+func TestGuardReadQuotesUnexpectedBody(t *testing.T) {
+	_, err := Guard{ContentTypes: []string{"application/json"}}.Read(newResponse("text/html", "<html>\n  <h1>Bad Gateway</h1>\n</html>"))
+	assert.EqualError(t, err, `unexpected content type "text/html" of the response with status "502 Bad Gateway", expected application/json: <html> <h1>Bad Gateway</h1> </html>`)
+
+	_, err = Guard{ContentTypes: []string{"application/json"}}.Read(newResponse("text/html", strings.Repeat("a", 300)))
+	assert.ErrorContains(t, err, ": "+strings.Repeat("a", snippetBytes)+"...")
+}
+
+func TestGuardReadDefaults(t *testing.T) {
+	body, err := Guard{}.Read(newResponse("text/plain", "anything"))
+	require.NoError(t, err)
+	assert.Equal(t, "anything", string(body))
+}
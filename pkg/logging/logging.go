@@ -0,0 +1,76 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package logging structures the log lines with fields, samples the repetitive ones and
+// sends them to syslog.
+package logging
+
+import (
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+// The fields of the log lines.
+const (
+	FieldProvider   = "provider"
+	FieldSource     = "source"
+	FieldZone       = "zone"
+	FieldRecord     = "record"
+	FieldRecordType = "recordType"
+	FieldResource   = "resource"
+	FieldSuppressed = "suppressed"
+)
+
+// ForZone returns the log entry of the lines about a zone.
+func ForZone(zone string) *log.Entry {
+	return log.WithField(FieldZone, zone)
+}
+
+// ForRecord returns the log entry of the lines about the record of an endpoint, with the
+// source object requesting it when known.
+func ForRecord(ep *endpoint.Endpoint) *log.Entry {
+	fields := log.Fields{FieldRecord: ep.DNSName, FieldRecordType: ep.RecordType}
+	if resource := ep.Labels[endpoint.ResourceLabelKey]; resource != "" {
+		fields[FieldResource] = resource
+	}
+	return log.WithFields(fields)
+}
+
+// FieldsHook adds fields to all the log lines, e.g. the provider, so that the lines of
+// several instances can be told apart once collected.
+type FieldsHook log.Fields
+
+func (h FieldsHook) Levels() []log.Level {
+	return log.AllLevels
+}
+
+func (h FieldsHook) Fire(entry *log.Entry) error {
+	for key, value := range h {
+		if _, ok := entry.Data[key]; !ok {
+			entry.Data[key] = value
+		}
+	}
+	return nil
+}
+
+// DiscardFormatter formats the log lines as nothing, for the loggers whose lines are only
+// written by hooks.
+type DiscardFormatter struct{}
+
+func (DiscardFormatter) Format(*log.Entry) ([]byte, error) {
+	return nil, nil
+}
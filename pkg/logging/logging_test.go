@@ -0,0 +1,58 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logging
+
+import (
+	"bytes"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+func TestForRecord(t *testing.T) {
+	ep := endpoint.NewEndpoint("web.example.org", endpoint.RecordTypeA, "1.2.3.4")
+	assert.Equal(t, log.Fields{FieldRecord: "web.example.org", FieldRecordType: "A"}, ForRecord(ep).Data)
+
+	ep.WithLabel(endpoint.ResourceLabelKey, "ingress/default/web")
+	assert.Equal(t, "ingress/default/web", ForRecord(ep).Data[FieldResource])
+	assert.Equal(t, log.Fields{FieldZone: "example.org"}, ForZone("example.org").Data)
+}
+
+func TestFieldsHook(t *testing.T) {
+	var buf bytes.Buffer
+	logger := log.New()
+	logger.SetOutput(&buf)
+	logger.SetFormatter(&log.JSONFormatter{DisableTimestamp: true})
+	logger.AddHook(FieldsHook{FieldProvider: "aws", FieldSource: "ingress,service"})
+
+	logger.WithField(FieldSource, "overridden").Info("synced")
+	assert.JSONEq(t, `{"level":"info","msg":"synced","provider":"aws","source":"overridden"}`, buf.String())
+
+	buf.Reset()
+	logger.Info("synced")
+	assert.JSONEq(t, `{"level":"info","msg":"synced","provider":"aws","source":"ingress,service"}`, buf.String())
+}
+
+func TestDiscardFormatter(t *testing.T) {
+	data, err := DiscardFormatter{}.Format(log.NewEntry(log.New()))
+	require.NoError(t, err)
+	assert.Empty(t, data)
+}
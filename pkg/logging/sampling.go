@@ -0,0 +1,121 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logging
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// maxSamples is the number of distinct lines above which the lines not logged within the
+// interval are forgotten.
+const maxSamples = 10000
+
+// SamplingFormatter logs the warning and debug lines repeated within the interval only once:
+// the same line, with the same fields, is only logged again once the interval has elapsed,
+// with the number of lines suppressed meanwhile. The errors and the info lines are always logged.
+type SamplingFormatter struct {
+	log.Formatter
+	interval time.Duration
+	mu       sync.Mutex
+	samples  map[string]*sample
+	now      func() time.Time
+}
+
+type sample struct {
+	loggedAt   time.Time
+	suppressed int
+}
+
+// NewSamplingFormatter returns a formatter sampling the lines formatted by the formatter.
+func NewSamplingFormatter(formatter log.Formatter, interval time.Duration) *SamplingFormatter {
+	return &SamplingFormatter{Formatter: formatter, interval: interval, samples: map[string]*sample{}, now: time.Now}
+}
+
+// Format formats the line, or returns nothing when the line is suppressed.
+func (f *SamplingFormatter) Format(entry *log.Entry) ([]byte, error) {
+	if !sampled(entry.Level) {
+		return f.Formatter.Format(entry)
+	}
+	suppressed, ok := f.allow(sampleKey(entry))
+	if !ok {
+		return nil, nil
+	}
+	if suppressed > 0 {
+		dup := entry.Dup()
+		dup.Level, dup.Message, dup.Caller = entry.Level, entry.Message, entry.Caller
+		dup.Data[FieldSuppressed] = suppressed
+		entry = dup
+	}
+	return f.Formatter.Format(entry)
+}
+
+// allow returns whether to log a line, and the number of times it was suppressed since it
+// was last logged.
+func (f *SamplingFormatter) allow(key string) (int, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	now := f.now()
+	s, ok := f.samples[key]
+	if ok && now.Sub(s.loggedAt) < f.interval {
+		s.suppressed++
+		return 0, false
+	}
+	if len(f.samples) >= maxSamples {
+		f.prune(now)
+	}
+	suppressed := 0
+	if ok {
+		suppressed = s.suppressed
+	}
+	f.samples[key] = &sample{loggedAt: now}
+	return suppressed, true
+}
+
+// prune forgets the lines not logged within the interval, whose suppressed lines are not reported.
+func (f *SamplingFormatter) prune(now time.Time) {
+	for key, s := range f.samples {
+		if now.Sub(s.loggedAt) >= f.interval {
+			delete(f.samples, key)
+		}
+	}
+}
+
+func sampled(level log.Level) bool {
+	return level == log.WarnLevel || level == log.DebugLevel || level == log.TraceLevel
+}
+
+func sampleKey(entry *log.Entry) string {
+	keys := make([]string, 0, len(entry.Data))
+	for key := range entry.Data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	var b strings.Builder
+	b.WriteString(entry.Level.String())
+	b.WriteString("\x00")
+	b.WriteString(entry.Message)
+	for _, key := range keys {
+		fmt.Fprintf(&b, "\x00%s=%v", key, entry.Data[key])
+	}
+	return b.String()
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logging
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSamplingFormatter(t *testing.T) {
+	now := time.Now()
+	formatter := NewSamplingFormatter(&log.TextFormatter{DisableTimestamp: true}, time.Minute)
+	formatter.now = func() time.Time { return now }
+	var buf bytes.Buffer
+	logger := log.New()
+	logger.SetOutput(&buf)
+	logger.SetFormatter(formatter)
+	logger.SetLevel(log.DebugLevel)
+	lines := func() []string {
+		defer buf.Reset()
+		return strings.Split(strings.TrimSpace(buf.String()), "\n")
+	}
+
+	for range 3 {
+		logger.WithField(FieldRecord, "a.example.org").Warn("skipping endpoint")
+		logger.WithField(FieldRecord, "b.example.org").Warn("skipping endpoint")
+		logger.Debug("no matching zone")
+		logger.Info("all records are already up to date")
+		logger.Error("failed to apply")
+	}
+	assert.Equal(t, []string{
+		`level=warning msg="skipping endpoint" record=a.example.org`,
+		`level=warning msg="skipping endpoint" record=b.example.org`,
+		`level=debug msg="no matching zone"`,
+		`level=info msg="all records are already up to date"`,
+		`level=error msg="failed to apply"`,
+		`level=info msg="all records are already up to date"`,
+		`level=error msg="failed to apply"`,
+		`level=info msg="all records are already up to date"`,
+		`level=error msg="failed to apply"`,
+	}, lines())
+
+	// once the interval has elapsed, the lines are logged again with the number suppressed
+	now = now.Add(time.Minute)
+	logger.WithField(FieldRecord, "a.example.org").Warn("skipping endpoint")
+	logger.Debug("no matching zone")
+	assert.Equal(t, []string{
+		`level=warning msg="skipping endpoint" record=a.example.org suppressed=2`,
+		`level=debug msg="no matching zone" suppressed=2`,
+	}, lines())
+
+	now = now.Add(time.Minute)
+	logger.Debug("no matching zone")
+	assert.Equal(t, []string{`level=debug msg="no matching zone"`}, lines())
+}
+
+func TestSamplingFormatterPrune(t *testing.T) {
+	now := time.Now()
+	formatter := NewSamplingFormatter(&log.TextFormatter{}, time.Minute)
+	formatter.now = func() time.Time { return now }
+	for i := range maxSamples {
+		formatter.allow(strings.Repeat("x", i))
+	}
+	assert.Len(t, formatter.samples, maxSamples)
+
+	now = now.Add(time.Minute)
+	_, ok := formatter.allow("new")
+	assert.True(t, ok)
+	assert.Len(t, formatter.samples, 1)
+}
@@ -0,0 +1,85 @@
+//go:build !windows && !plan9
+
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logging
+
+import (
+	"fmt"
+	"log/syslog"
+	"net/url"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// syslogWriter is the part of syslog.Writer writing the lines at each severity.
+type syslogWriter interface {
+	Crit(string) error
+	Err(string) error
+	Warning(string) error
+	Info(string) error
+	Debug(string) error
+}
+
+// SyslogHook sends the log lines to syslog, at the severity of their level. The lines are
+// formatted by its own formatter, so that the logger can discard its output.
+type SyslogHook struct {
+	writer    syslogWriter
+	formatter log.Formatter
+}
+
+// NewSyslogHook connects to the syslog daemon at the address, e.g. udp://syslog:514, or to
+// the local one when the address is empty.
+func NewSyslogHook(address, tag string, formatter log.Formatter) (*SyslogHook, error) {
+	var network, raddr string
+	if address != "" {
+		u, err := url.Parse(address)
+		if err != nil || u.Host == "" {
+			return nil, fmt.Errorf("invalid syslog address %q, e.g. udp://syslog:514", address)
+		}
+		network, raddr = u.Scheme, u.Host
+	}
+	writer, err := syslog.Dial(network, raddr, syslog.LOG_INFO|syslog.LOG_DAEMON, tag)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to syslog: %w", err)
+	}
+	return &SyslogHook{writer: writer, formatter: formatter}, nil
+}
+
+func (h *SyslogHook) Levels() []log.Level {
+	return log.AllLevels
+}
+
+func (h *SyslogHook) Fire(entry *log.Entry) error {
+	data, err := h.formatter.Format(entry)
+	if err != nil || len(data) == 0 {
+		return err
+	}
+	line := string(data)
+	switch entry.Level {
+	case log.PanicLevel, log.FatalLevel:
+		return h.writer.Crit(line)
+	case log.ErrorLevel:
+		return h.writer.Err(line)
+	case log.WarnLevel:
+		return h.writer.Warning(line)
+	case log.InfoLevel:
+		return h.writer.Info(line)
+	default:
+		return h.writer.Debug(line)
+	}
+}
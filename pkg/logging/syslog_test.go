@@ -0,0 +1,74 @@
+//go:build !windows && !plan9
+
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logging
+
+import (
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeSyslogWriter struct {
+	lines []string
+}
+
+func (w *fakeSyslogWriter) write(severity, line string) error {
+	w.lines = append(w.lines, severity+" "+line)
+	return nil
+}
+
+func (w *fakeSyslogWriter) Crit(line string) error    { return w.write("crit", line) }
+func (w *fakeSyslogWriter) Err(line string) error     { return w.write("err", line) }
+func (w *fakeSyslogWriter) Warning(line string) error { return w.write("warning", line) }
+func (w *fakeSyslogWriter) Info(line string) error    { return w.write("info", line) }
+func (w *fakeSyslogWriter) Debug(line string) error   { return w.write("debug", line) }
+
+func TestSyslogHook(t *testing.T) {
+	writer := &fakeSyslogWriter{}
+	hook := &SyslogHook{writer: writer, formatter: &log.TextFormatter{DisableTimestamp: true}}
+	logger := log.New()
+	logger.SetLevel(log.DebugLevel)
+	logger.SetFormatter(DiscardFormatter{})
+	logger.AddHook(hook)
+
+	logger.Error("failed")
+	logger.Warn("skipping")
+	logger.Info("synced")
+	logger.Debug("details")
+	assert.Equal(t, []string{
+		"err level=error msg=failed\n",
+		"warning level=warning msg=skipping\n",
+		"info level=info msg=synced\n",
+		"debug level=debug msg=details\n",
+	}, writer.lines)
+}
+
+func TestSyslogHookSkipsSuppressedLines(t *testing.T) {
+	writer := &fakeSyslogWriter{}
+	hook := &SyslogHook{writer: writer, formatter: DiscardFormatter{}}
+	require.NoError(t, hook.Fire(log.NewEntry(log.New())))
+	assert.Empty(t, writer.lines)
+}
+
+func TestNewSyslogHookInvalidAddress(t *testing.T) {
+	_, err := NewSyslogHook("syslog:514", "external-dns", &log.TextFormatter{})
+	require.Error(t, err)
+}
@@ -54,7 +54,7 @@ func NewMetricsRegister() *MetricRegistry {
 //	}
 func (m *MetricRegistry) MustRegister(cs IMetric) {
 	switch v := cs.(type) {
-	case CounterMetric, GaugeMetric, CounterVecMetric, GaugeVecMetric:
+	case CounterMetric, GaugeMetric, CounterVecMetric, GaugeVecMetric, HistogramMetric:
 		if _, exists := m.mName[cs.Get().FQDN]; exists {
 			return
 		} else {
@@ -70,6 +70,8 @@ func (m *MetricRegistry) MustRegister(cs IMetric) {
 			m.Registerer.MustRegister(metric.Gauge)
 		case CounterVecMetric:
 			m.Registerer.MustRegister(metric.CounterVec)
+		case HistogramMetric:
+			m.Registerer.MustRegister(metric.Histogram)
 		}
 		log.Debugf("Register metric: %s", cs.Get().FQDN)
 	default:
@@ -78,6 +78,15 @@ func (g GaugeVecMetric) Get() *Metric {
 	return &g.Metric
 }
 
+type HistogramMetric struct {
+	Metric
+	Histogram prometheus.Histogram
+}
+
+func (g HistogramMetric) Get() *Metric {
+	return &g.Metric
+}
+
 // SetWithLabels sets the value of the Gauge metric for the specified label values.
 // All label values are converted to lowercase before being applied.
 func (g GaugeVecMetric) SetWithLabels(value float64, lvs ...string) {
@@ -144,3 +153,18 @@ func NewCounterVecWithOpts(opts prometheus.CounterOpts, labelNames []string) Cou
 		CounterVec: prometheus.NewCounterVec(opts, labelNames),
 	}
 }
+
+// NewHistogramWithOpts creates a new Histogram based on the provided HistogramOpts.
+func NewHistogramWithOpts(opts prometheus.HistogramOpts) HistogramMetric {
+	return HistogramMetric{
+		Metric: Metric{
+			Type:      "histogram",
+			Name:      opts.Name,
+			FQDN:      fmt.Sprintf("%s_%s", opts.Subsystem, opts.Name),
+			Namespace: opts.Namespace,
+			Subsystem: opts.Subsystem,
+			Help:      opts.Help,
+		},
+		Histogram: prometheus.NewHistogram(opts),
+	}
+}
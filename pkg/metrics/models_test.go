@@ -84,6 +84,26 @@ func TestNewCounterVecWithOpts(t *testing.T) {
 	assert.NotNil(t, counterVecMetric.CounterVec)
 }
 
+func TestNewHistogramWithOpts(t *testing.T) {
+	opts := prometheus.HistogramOpts{
+		Name:      "test_histogram",
+		Namespace: "test_namespace",
+		Subsystem: "test_subsystem",
+		Help:      "This is a test histogram",
+		Buckets:   []float64{1, 10},
+	}
+
+	histogramMetric := NewHistogramWithOpts(opts)
+
+	assert.Equal(t, "histogram", histogramMetric.Type)
+	assert.Equal(t, "test_histogram", histogramMetric.Name)
+	assert.Equal(t, "test_namespace", histogramMetric.Namespace)
+	assert.Equal(t, "test_subsystem", histogramMetric.Subsystem)
+	assert.Equal(t, "This is a test histogram", histogramMetric.Help)
+	assert.Equal(t, "test_subsystem_test_histogram", histogramMetric.FQDN)
+	assert.NotNil(t, histogramMetric.Histogram)
+}
+
 func TestGaugeV_SetWithLabels(t *testing.T) {
 	opts := prometheus.GaugeOpts{
 		Name:      "test_gauge",
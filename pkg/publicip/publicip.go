@@ -0,0 +1,264 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package publicip discovers the public IP address of the cluster as seen from the Internet,
+// with HTTP services echoing the address of their client or with STUN servers, for clusters
+// behind a NAT, e.g. on a residential connection.
+package publicip
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/pkg/httpbody"
+)
+
+const (
+	// stunScheme is the scheme of the STUN endpoints, as stun:<host>[:<port>].
+	stunScheme = "stun:"
+	// stunDefaultPort is the port of the STUN endpoints without one.
+	stunDefaultPort = "3478"
+	// maxHTTPBodyBytes is the maximum size of the response of an HTTP endpoint, which only holds an address.
+	maxHTTPBodyBytes = 1024
+	// timeout limits the query of an endpoint, so that an unavailable one falls back to the next.
+	timeout = 10 * time.Second
+)
+
+// STUN binding requests and responses, see RFC 5389.
+const (
+	stunBindingRequest       = 0x0001
+	stunBindingSuccess       = 0x0101
+	stunMagicCookie          = 0x2112a442
+	stunHeaderSize           = 20
+	stunAttrMappedAddress    = 0x0001
+	stunAttrXORMappedAddress = 0x0020
+	stunFamilyIPv4           = 0x01
+	stunFamilyIPv6           = 0x02
+)
+
+// Discoverer discovers the public IP address with the first of its endpoints that answers.
+type Discoverer struct {
+	endpoints  []string
+	timeout    time.Duration
+	httpClient *http.Client
+}
+
+// NewDiscoverer creates a new Discoverer querying the given endpoints in order, each being
+// either an HTTP(S) URL answering the address of its client as plain text, or a STUN server
+// as stun:<host>[:<port>].
+func NewDiscoverer(endpoints []string) *Discoverer {
+	return &Discoverer{
+		endpoints:  endpoints,
+		timeout:    timeout,
+		httpClient: &http.Client{Timeout: timeout},
+	}
+}
+
+// ValidateEndpoint returns an error if an endpoint is neither an HTTP(S) URL nor a STUN server.
+func ValidateEndpoint(endpoint string) error {
+	if address, ok := strings.CutPrefix(endpoint, stunScheme); ok {
+		if address == "" {
+			return errors.New("missing STUN server host")
+		}
+		return nil
+	}
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return err
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return errors.New("expected an http(s) URL or stun:<host>[:<port>]")
+	}
+	return nil
+}
+
+// Discover returns the public IP address answered by the first endpoint that answers.
+func (d *Discoverer) Discover(ctx context.Context) (netip.Addr, error) {
+	var errs []error
+	for _, endpoint := range d.endpoints {
+		addr, err := d.discover(ctx, endpoint)
+		if err == nil {
+			log.Debugf("Discovered the public IP address %s with %s", addr, endpoint)
+			return addr, nil
+		}
+		log.Debugf("Failed to discover the public IP address with %s: %v", endpoint, err)
+		errs = append(errs, fmt.Errorf("%s: %w", endpoint, err))
+	}
+	return netip.Addr{}, fmt.Errorf("failed to discover the public IP address: %w", errors.Join(errs...))
+}
+
+func (d *Discoverer) discover(ctx context.Context, endpoint string) (netip.Addr, error) {
+	ctx, cancel := context.WithTimeout(ctx, d.timeout)
+	defer cancel()
+
+	if address, ok := strings.CutPrefix(endpoint, stunScheme); ok {
+		return discoverSTUN(ctx, address)
+	}
+	return d.discoverHTTP(ctx, endpoint)
+}
+
+// discoverHTTP returns the address answered as plain text by an HTTP endpoint.
+func (d *Discoverer) discoverHTTP(ctx context.Context, endpoint string) (netip.Addr, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return netip.Addr{}, err
+	}
+	res, err := d.httpClient.Do(req)
+	if err != nil {
+		return netip.Addr{}, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return netip.Addr{}, fmt.Errorf("unexpected status %q", res.Status)
+	}
+	body, err := httpbody.ReadAll(res.Body, maxHTTPBodyBytes)
+	if err != nil {
+		return netip.Addr{}, err
+	}
+	return parseAddr(strings.TrimSpace(string(body)))
+}
+
+// discoverSTUN returns the address mapped by a STUN server to a binding request.
+func discoverSTUN(ctx context.Context, address string) (netip.Addr, error) {
+	if _, _, err := net.SplitHostPort(address); err != nil {
+		address = net.JoinHostPort(address, stunDefaultPort)
+	}
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "udp", address)
+	if err != nil {
+		return netip.Addr{}, err
+	}
+	defer conn.Close()
+	if deadline, ok := ctx.Deadline(); ok {
+		if err := conn.SetDeadline(deadline); err != nil {
+			return netip.Addr{}, err
+		}
+	}
+
+	request := make([]byte, stunHeaderSize)
+	binary.BigEndian.PutUint16(request[0:2], stunBindingRequest)
+	binary.BigEndian.PutUint32(request[4:8], stunMagicCookie)
+	if _, err := rand.Read(request[8:stunHeaderSize]); err != nil {
+		return netip.Addr{}, err
+	}
+	if _, err := conn.Write(request); err != nil {
+		return netip.Addr{}, err
+	}
+
+	response := make([]byte, 1500)
+	n, err := conn.Read(response)
+	if err != nil {
+		return netip.Addr{}, err
+	}
+	return parseSTUNResponse(response[:n], request[8:stunHeaderSize])
+}
+
+// parseSTUNResponse returns the mapped address of a binding success response to the
+// request of the given transaction ID, preferring the XOR-MAPPED-ADDRESS attribute.
+func parseSTUNResponse(response, transactionID []byte) (netip.Addr, error) {
+	if len(response) < stunHeaderSize {
+		return netip.Addr{}, errors.New("STUN response too short")
+	}
+	if binary.BigEndian.Uint16(response[0:2]) != stunBindingSuccess {
+		return netip.Addr{}, fmt.Errorf("unexpected STUN message type %#04x", binary.BigEndian.Uint16(response[0:2]))
+	}
+	if binary.BigEndian.Uint32(response[4:8]) != stunMagicCookie || string(response[8:stunHeaderSize]) != string(transactionID) {
+		return netip.Addr{}, errors.New("STUN response to another request")
+	}
+
+	length := int(binary.BigEndian.Uint16(response[2:4]))
+	if stunHeaderSize+length > len(response) {
+		return netip.Addr{}, errors.New("truncated STUN response")
+	}
+	attributes := response[stunHeaderSize : stunHeaderSize+length]
+
+	var mapped netip.Addr
+	for len(attributes) >= 4 {
+		attrType := binary.BigEndian.Uint16(attributes[0:2])
+		attrLength := int(binary.BigEndian.Uint16(attributes[2:4]))
+		if 4+attrLength > len(attributes) {
+			return netip.Addr{}, errors.New("truncated STUN attribute")
+		}
+		value := attributes[4 : 4+attrLength]
+		switch attrType {
+		case stunAttrXORMappedAddress:
+			return parseSTUNAddress(value, response[4:stunHeaderSize])
+		case stunAttrMappedAddress:
+			if addr, err := parseSTUNAddress(value, nil); err == nil {
+				mapped = addr
+			}
+		}
+		// attributes are padded to a multiple of 4 bytes.
+		padded := 4 + (attrLength+3)&^3
+		if padded > len(attributes) {
+			break
+		}
+		attributes = attributes[padded:]
+	}
+	if mapped.IsValid() {
+		return mapped, nil
+	}
+	return netip.Addr{}, errors.New("no mapped address in the STUN response")
+}
+
+// parseSTUNAddress parses the value of a (XOR-)MAPPED-ADDRESS attribute. The address is
+// XORed with key, the magic cookie followed by the transaction ID, unless key is nil.
+func parseSTUNAddress(value, key []byte) (netip.Addr, error) {
+	if len(value) < 4 {
+		return netip.Addr{}, errors.New("STUN address too short")
+	}
+	var size int
+	switch value[1] {
+	case stunFamilyIPv4:
+		size = 4
+	case stunFamilyIPv6:
+		size = 16
+	default:
+		return netip.Addr{}, fmt.Errorf("unknown STUN address family %#02x", value[1])
+	}
+	if len(value) < 4+size {
+		return netip.Addr{}, errors.New("STUN address too short")
+	}
+	ip := make([]byte, size)
+	copy(ip, value[4:4+size])
+	if key != nil {
+		for i := range ip {
+			ip[i] ^= key[i]
+		}
+	}
+	addr, _ := netip.AddrFromSlice(ip)
+	return addr, nil
+}
+
+func parseAddr(s string) (netip.Addr, error) {
+	addr, err := netip.ParseAddr(s)
+	if err != nil {
+		return netip.Addr{}, fmt.Errorf("invalid IP address %q", s)
+	}
+	return addr.Unmap(), nil
+}
@@ -0,0 +1,166 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package publicip
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stunResponse builds a binding success response to request with the given attributes.
+func stunResponse(request []byte, attributes ...[]byte) []byte {
+	response := make([]byte, stunHeaderSize)
+	binary.BigEndian.PutUint16(response[0:2], stunBindingSuccess)
+	copy(response[4:stunHeaderSize], request[4:stunHeaderSize])
+	for _, attribute := range attributes {
+		response = append(response, attribute...)
+	}
+	binary.BigEndian.PutUint16(response[2:4], uint16(len(response)-stunHeaderSize))
+	return response
+}
+
+// stunAddressAttribute encodes addr in a (XOR-)MAPPED-ADDRESS attribute, XORed with key unless nil.
+func stunAddressAttribute(attrType uint16, addr netip.Addr, key []byte) []byte {
+	ip := addr.AsSlice()
+	family := byte(stunFamilyIPv4)
+	if addr.Is6() {
+		family = stunFamilyIPv6
+	}
+	attribute := make([]byte, 8, 8+len(ip))
+	binary.BigEndian.PutUint16(attribute[0:2], attrType)
+	binary.BigEndian.PutUint16(attribute[2:4], uint16(4+len(ip)))
+	attribute[5] = family
+	for i, b := range ip {
+		if key != nil {
+			b ^= key[i]
+		}
+		attribute = append(attribute, b)
+	}
+	return attribute
+}
+
+// startSTUNServer starts a STUN server answering the binding requests with addr.
+func startSTUNServer(t *testing.T, addr netip.Addr) string {
+	t.Helper()
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	go func() {
+		buf := make([]byte, 1500)
+		for {
+			n, client, err := conn.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+			request := buf[:n]
+			_, _ = conn.WriteTo(stunResponse(request, stunAddressAttribute(stunAttrXORMappedAddress, addr, request[4:stunHeaderSize])), client)
+		}
+	}()
+	return conn.LocalAddr().String()
+}
+
+func TestValidateEndpoint(t *testing.T) {
+	for endpoint, valid := range map[string]bool{
+		"https://api.ipify.org":        true,
+		"http://ifconfig.me/ip":        true,
+		"stun:stun.l.google.com:19302": true,
+		"stun:stun.example.org":        true,
+		"stun:":                        false,
+		"ftp://example.org":            false,
+		"api.ipify.org":                false,
+	} {
+		if valid {
+			assert.NoError(t, ValidateEndpoint(endpoint), endpoint)
+		} else {
+			assert.Error(t, ValidateEndpoint(endpoint), endpoint)
+		}
+	}
+}
+
+func TestDiscoverHTTP(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprintln(w, "203.0.113.10")
+	}))
+	defer server.Close()
+
+	addr, err := NewDiscoverer([]string{server.URL}).Discover(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, netip.MustParseAddr("203.0.113.10"), addr)
+}
+
+func TestDiscoverSTUN(t *testing.T) {
+	for _, expected := range []netip.Addr{netip.MustParseAddr("203.0.113.10"), netip.MustParseAddr("2001:db8::10")} {
+		address := startSTUNServer(t, expected)
+
+		addr, err := NewDiscoverer([]string{stunScheme + address}).Discover(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, expected, addr)
+	}
+}
+
+func TestDiscoverFallsBack(t *testing.T) {
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer failing.Close()
+	invalid := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprint(w, "<html>")
+	}))
+	defer invalid.Close()
+
+	_, err := NewDiscoverer([]string{failing.URL, invalid.URL}).Discover(context.Background())
+	require.ErrorContains(t, err, "unexpected status")
+	require.ErrorContains(t, err, `invalid IP address "<html>"`)
+
+	address := startSTUNServer(t, netip.MustParseAddr("203.0.113.10"))
+	addr, err := NewDiscoverer([]string{failing.URL, stunScheme + address}).Discover(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, netip.MustParseAddr("203.0.113.10"), addr)
+}
+
+func TestParseSTUNResponse(t *testing.T) {
+	request := make([]byte, stunHeaderSize)
+	binary.BigEndian.PutUint32(request[4:8], stunMagicCookie)
+	copy(request[8:], "transaction1")
+	transactionID := request[8:stunHeaderSize]
+	addr := netip.MustParseAddr("203.0.113.10")
+
+	// MAPPED-ADDRESS is used without XOR-MAPPED-ADDRESS, after an unknown padded attribute.
+	unknown := []byte{0x80, 0x22, 0x00, 0x03, 'f', 'o', 'o', 0x00}
+	got, err := parseSTUNResponse(stunResponse(request, unknown, stunAddressAttribute(stunAttrMappedAddress, addr, nil)), transactionID)
+	require.NoError(t, err)
+	assert.Equal(t, addr, got)
+
+	_, err = parseSTUNResponse(stunResponse(request), transactionID)
+	assert.ErrorContains(t, err, "no mapped address")
+
+	_, err = parseSTUNResponse(stunResponse(request), []byte("transaction2"))
+	assert.ErrorContains(t, err, "another request")
+
+	_, err = parseSTUNResponse(request[:10], transactionID)
+	assert.ErrorContains(t, err, "too short")
+}
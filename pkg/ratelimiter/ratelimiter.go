@@ -0,0 +1,77 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package ratelimiter shares the rate limiters of the DNS provider APIs across the process.
+// The APIs limit the requests per credential, so the clients using the same credential, e.g.
+// working on zones in parallel or rebuilt when the credentials are reloaded, share a limiter
+// instead of each multiplying the request rate past the quota.
+package ratelimiter
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"sync"
+
+	"golang.org/x/time/rate"
+)
+
+var (
+	mu       sync.Mutex
+	limiters = map[string]*rate.Limiter{}
+)
+
+// Shared returns the limiter of the API of the provider for the credential, which is created
+// with the limit and burst on first use. The credential is only kept as a fingerprint.
+func Shared(provider, credential string, limit rate.Limit, burst int) *rate.Limiter {
+	key := provider + "/" + fingerprint(credential)
+
+	mu.Lock()
+	defer mu.Unlock()
+	if limiter, ok := limiters[key]; ok {
+		return limiter
+	}
+	limiter := rate.NewLimiter(limit, burst)
+	limiters[key] = limiter
+	return limiter
+}
+
+// fingerprint returns a hash identifying the credential without revealing it.
+func fingerprint(credential string) string {
+	sum := sha256.Sum256([]byte(credential))
+	return hex.EncodeToString(sum[:])
+}
+
+// Transport returns a round tripper waiting for the limiter before each request of the base
+// round tripper, or of http.DefaultTransport if nil.
+func Transport(limiter *rate.Limiter, base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &transport{limiter: limiter, base: base}
+}
+
+type transport struct {
+	limiter *rate.Limiter
+	base    http.RoundTripper
+}
+
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := t.limiter.Wait(req.Context()); err != nil {
+		return nil, err
+	}
+	return t.base.RoundTrip(req)
+}
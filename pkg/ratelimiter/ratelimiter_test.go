@@ -0,0 +1,85 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ratelimiter
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
+)
+
+func TestShared(t *testing.T) {
+	limiter := Shared("test", "secret", rate.Every(time.Second), 10)
+	assert.Same(t, limiter, Shared("test", "secret", rate.Every(time.Minute), 1))
+	assert.Equal(t, rate.Every(time.Second), limiter.Limit())
+	assert.Equal(t, 10, limiter.Burst())
+
+	assert.NotSame(t, limiter, Shared("test", "other-secret", rate.Every(time.Second), 10))
+	assert.NotSame(t, limiter, Shared("other-test", "secret", rate.Every(time.Second), 10))
+
+	// the credentials are not kept
+	mu.Lock()
+	defer mu.Unlock()
+	for key := range limiters {
+		assert.NotContains(t, key, "secret")
+	}
+}
+
+func TestSharedConcurrent(t *testing.T) {
+	var wg sync.WaitGroup
+	got := make([]*rate.Limiter, 10)
+	for i := range got {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			got[i] = Shared("concurrent", "secret", rate.Every(time.Second), 1)
+		}()
+	}
+	wg.Wait()
+	for _, limiter := range got {
+		assert.Same(t, got[0], limiter)
+	}
+}
+
+func TestTransport(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests++
+	}))
+	defer server.Close()
+
+	// the burst is used by the first request, the second one has to wait
+	client := &http.Client{Transport: Transport(rate.NewLimiter(rate.Every(time.Hour), 1), nil)}
+	resp, err := client.Get(server.URL)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+	require.NoError(t, err)
+	_, err = client.Do(req)
+	require.ErrorContains(t, err, "rate: Wait")
+	assert.Equal(t, 1, requests)
+}
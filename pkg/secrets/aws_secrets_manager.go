@@ -0,0 +1,66 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secrets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager/types"
+)
+
+// SecretsManagerAPI is the subset of the AWS Secrets Manager API that we actually use.
+type SecretsManagerAPI interface {
+	GetSecretValue(ctx context.Context, input *secretsmanager.GetSecretValueInput, optFns ...func(*secretsmanager.Options)) (*secretsmanager.GetSecretValueOutput, error)
+}
+
+// AWSSecretsManagerBackend reads secrets from AWS Secrets Manager.
+// References have the form `<secret-id>` or `<secret-id>#<key>` when the
+// secret string is a JSON object.
+type AWSSecretsManagerBackend struct {
+	client SecretsManagerAPI
+}
+
+// NewAWSSecretsManagerBackend returns an AWS Secrets Manager backend using the given client.
+func NewAWSSecretsManagerBackend(client SecretsManagerAPI) *AWSSecretsManagerBackend {
+	return &AWSSecretsManagerBackend{client: client}
+}
+
+// Fetch implements Backend.
+func (b *AWSSecretsManagerBackend) Fetch(ctx context.Context, ref string) (string, error) {
+	id, key := splitKey(ref)
+	out, err := b.client.GetSecretValue(ctx, &secretsmanager.GetSecretValueInput{
+		SecretId: aws.String(id),
+	})
+	if err != nil {
+		var notFound *types.ResourceNotFoundException
+		if errors.As(err, &notFound) {
+			return "", fmt.Errorf("aws secret %q: %w", id, ErrSecretNotFound)
+		}
+		return "", err
+	}
+	if out.SecretString == nil {
+		return "", fmt.Errorf("aws secret %q has no string value", id)
+	}
+	if key != "" {
+		return selectKey([]byte(*out.SecretString), key)
+	}
+	return *out.SecretString, nil
+}
@@ -0,0 +1,154 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+const (
+	// ReferencePrefix is the prefix of the secret references, so that the values which merely look
+	// like `<scheme>:<path>`, e.g. a Vault transit ciphertext, aren't read as references.
+	ReferencePrefix = "secret+"
+
+	// SchemeEnv reads the secret from an environment variable, e.g. `secret+env:GODADDY_API_KEY`.
+	SchemeEnv = "env"
+	// SchemeFile reads the secret from a file, e.g. `secret+file:/var/run/secrets/godaddy/key`.
+	SchemeFile = "file"
+	// SchemeVault reads the secret from a HashiCorp Vault KV v2 engine, e.g. `secret+vault:secret/data/godaddy#key`.
+	SchemeVault = "vault"
+	// SchemeAWSSecretsManager reads the secret from AWS Secrets Manager, e.g. `secret+aws-sm:external-dns/godaddy#key`.
+	SchemeAWSSecretsManager = "aws-sm"
+)
+
+// ErrSecretNotFound is returned when a backend cannot find the requested secret.
+var ErrSecretNotFound = errors.New("secret not found")
+
+// Backend fetches the current value of a secret from an external store.
+type Backend interface {
+	Fetch(ctx context.Context, path string) (string, error)
+}
+
+// BackendFunc adapts a plain function to the Backend interface.
+type BackendFunc func(ctx context.Context, path string) (string, error)
+
+// Fetch calls f(ctx, path).
+func (f BackendFunc) Fetch(ctx context.Context, path string) (string, error) {
+	return f(ctx, path)
+}
+
+// Resolver turns secret references of the form `secret+<scheme>:<path>` into secret values
+// using the backend registered for the scheme. Values that are not references are
+// returned unchanged, so plain credentials keep working.
+type Resolver struct {
+	backends map[string]Backend
+}
+
+// NewResolver returns a Resolver with the env and file backends registered.
+func NewResolver() *Resolver {
+	r := &Resolver{backends: map[string]Backend{}}
+	r.Register(SchemeEnv, BackendFunc(fetchEnv))
+	r.Register(SchemeFile, BackendFunc(fetchFile))
+	return r
+}
+
+// Register adds or replaces the backend used for the given scheme.
+func (r *Resolver) Register(scheme string, backend Backend) {
+	r.backends[scheme] = backend
+}
+
+// IsReference reports whether value refers to a secret in one of the registered backends.
+func (r *Resolver) IsReference(value string) bool {
+	_, _, ok := r.parse(value)
+	return ok
+}
+
+// Resolve returns the secret referenced by value, or value itself if it is not a reference.
+func (r *Resolver) Resolve(ctx context.Context, value string) (string, error) {
+	backend, path, ok := r.parse(value)
+	if !ok {
+		return value, nil
+	}
+	secret, err := backend.Fetch(ctx, path)
+	if err != nil {
+		return "", fmt.Errorf("resolving secret %q: %w", value, err)
+	}
+	return secret, nil
+}
+
+func (r *Resolver) parse(value string) (Backend, string, bool) {
+	ref, ok := strings.CutPrefix(value, ReferencePrefix)
+	if !ok {
+		return nil, "", false
+	}
+	scheme, path, found := strings.Cut(ref, ":")
+	if !found || path == "" {
+		return nil, "", false
+	}
+	backend, ok := r.backends[scheme]
+	return backend, path, ok
+}
+
+// splitKey splits a `path#key` reference into its path and optional key.
+func splitKey(ref string) (string, string) {
+	path, key, _ := strings.Cut(ref, "#")
+	return path, key
+}
+
+// selectKey returns the string value stored under key in the JSON object raw.
+func selectKey(raw []byte, key string) (string, error) {
+	var data map[string]any
+	if err := json.Unmarshal(raw, &data); err != nil {
+		return "", fmt.Errorf("decoding secret as JSON object: %w", err)
+	}
+	value, ok := data[key]
+	if !ok {
+		return "", fmt.Errorf("key %q: %w", key, ErrSecretNotFound)
+	}
+	s, ok := value.(string)
+	if !ok {
+		return "", fmt.Errorf("key %q is not a string", key)
+	}
+	return s, nil
+}
+
+func fetchEnv(_ context.Context, name string) (string, error) {
+	value, ok := os.LookupEnv(name)
+	if !ok {
+		return "", fmt.Errorf("environment variable %q: %w", name, ErrSecretNotFound)
+	}
+	return value, nil
+}
+
+// fetchFile reads the secret from a file on each call so that mounted Kubernetes
+// secrets are picked up after the kubelet rotates them.
+func fetchFile(_ context.Context, ref string) (string, error) {
+	path, key := splitKey(ref)
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	if key != "" {
+		return selectKey(content, key)
+	}
+	return strings.TrimSpace(string(content)), nil
+}
@@ -0,0 +1,212 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secrets
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolverResolve(t *testing.T) {
+	dir := t.TempDir()
+	plainFile := filepath.Join(dir, "token")
+	require.NoError(t, os.WriteFile(plainFile, []byte("file-token\n"), 0o600))
+	jsonFile := filepath.Join(dir, "creds.json")
+	require.NoError(t, os.WriteFile(jsonFile, []byte(`{"key":"json-key","secret":"json-secret"}`), 0o600))
+	t.Setenv("SECRETS_TEST_TOKEN", "env-token")
+
+	r := NewResolver()
+
+	tests := []struct {
+		name     string
+		value    string
+		expected string
+		isRef    bool
+		err      error
+	}{
+		{name: "plain value", value: "plain-secret", expected: "plain-secret"},
+		{name: "unknown scheme", value: "https://example.com", expected: "https://example.com"},
+		{name: "empty path", value: "secret+env:", expected: "secret+env:"},
+		{name: "without prefix", value: "env:SECRETS_TEST_TOKEN", expected: "env:SECRETS_TEST_TOKEN"},
+		{name: "vault ciphertext", value: "vault:v1:d3JhcHBlZA==", expected: "vault:v1:d3JhcHBlZA=="},
+		{name: "env", value: "secret+env:SECRETS_TEST_TOKEN", expected: "env-token", isRef: true},
+		{name: "missing env", value: "secret+env:SECRETS_TEST_MISSING", isRef: true, err: ErrSecretNotFound},
+		{name: "file is trimmed", value: "secret+file:" + plainFile, expected: "file-token", isRef: true},
+		{name: "file with key", value: "secret+file:" + jsonFile + "#secret", expected: "json-secret", isRef: true},
+		{name: "file with missing key", value: "secret+file:" + jsonFile + "#missing", isRef: true, err: ErrSecretNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.isRef, r.IsReference(tt.value))
+			got, err := r.Resolve(context.Background(), tt.value)
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+}
+
+func TestResolverRegister(t *testing.T) {
+	r := NewResolver()
+	assert.False(t, r.IsReference("secret+custom:foo"))
+
+	r.Register("custom", BackendFunc(func(_ context.Context, path string) (string, error) {
+		return "value-of-" + path, nil
+	}))
+	got, err := r.Resolve(context.Background(), "secret+custom:foo")
+	require.NoError(t, err)
+	assert.Equal(t, "value-of-foo", got)
+}
+
+func TestVaultBackend(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "s.token" || r.Header.Get("X-Vault-Namespace") != "team" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		switch r.URL.Path {
+		case "/v1/secret/data/godaddy":
+			_, _ = w.Write([]byte(`{"data":{"data":{"key":"vault-key"},"metadata":{"version":3}}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	backend := NewVaultBackend(VaultConfig{Address: server.URL + "/", Token: "s.token", Namespace: "team"}, nil)
+
+	got, err := backend.Fetch(context.Background(), "secret/data/godaddy#key")
+	require.NoError(t, err)
+	assert.Equal(t, "vault-key", got)
+
+	_, err = backend.Fetch(context.Background(), "secret/data/godaddy#missing")
+	assert.ErrorIs(t, err, ErrSecretNotFound)
+
+	_, err = backend.Fetch(context.Background(), "secret/data/unknown#key")
+	assert.ErrorIs(t, err, ErrSecretNotFound)
+
+	_, err = backend.Fetch(context.Background(), "secret/data/godaddy")
+	assert.ErrorContains(t, err, "must select a key")
+
+	_, err = NewVaultBackend(VaultConfig{Address: server.URL, Token: "wrong"}, nil).Fetch(context.Background(), "secret/data/godaddy#key")
+	assert.ErrorContains(t, err, "status 403")
+
+	_, err = NewVaultBackend(VaultConfig{}, nil).Fetch(context.Background(), "secret/data/godaddy#key")
+	assert.ErrorContains(t, err, "VAULT_ADDR")
+}
+
+func TestVaultConfigFromEnv(t *testing.T) {
+	t.Setenv("VAULT_ADDR", "https://vault:8200")
+	t.Setenv("VAULT_TOKEN", "s.token")
+	t.Setenv("VAULT_NAMESPACE", "ns")
+	assert.Equal(t, VaultConfig{Address: "https://vault:8200", Token: "s.token", Namespace: "ns"}, VaultConfigFromEnv())
+}
+
+type fakeSecretsManager struct {
+	secrets map[string]*string
+}
+
+func (f *fakeSecretsManager) GetSecretValue(_ context.Context, input *secretsmanager.GetSecretValueInput, _ ...func(*secretsmanager.Options)) (*secretsmanager.GetSecretValueOutput, error) {
+	value, ok := f.secrets[*input.SecretId]
+	if !ok {
+		return nil, &types.ResourceNotFoundException{}
+	}
+	return &secretsmanager.GetSecretValueOutput{SecretString: value}, nil
+}
+
+func TestAWSSecretsManagerBackend(t *testing.T) {
+	plain := "plain-token"
+	object := `{"apiKey":"aws-key"}`
+	backend := NewAWSSecretsManagerBackend(&fakeSecretsManager{secrets: map[string]*string{
+		"plain":  &plain,
+		"object": &object,
+		"binary": nil,
+	}})
+
+	got, err := backend.Fetch(context.Background(), "plain")
+	require.NoError(t, err)
+	assert.Equal(t, "plain-token", got)
+
+	got, err = backend.Fetch(context.Background(), "object#apiKey")
+	require.NoError(t, err)
+	assert.Equal(t, "aws-key", got)
+
+	_, err = backend.Fetch(context.Background(), "missing")
+	assert.ErrorIs(t, err, ErrSecretNotFound)
+
+	_, err = backend.Fetch(context.Background(), "binary")
+	assert.ErrorContains(t, err, "no string value")
+
+	_, err = backend.Fetch(context.Background(), "plain#key")
+	assert.Error(t, err)
+}
+
+func TestWatcherRefresh(t *testing.T) {
+	values := map[string]string{"a": "1", "b": "2"}
+	failing := false
+	r := NewResolver()
+	r.Register("test", BackendFunc(func(_ context.Context, path string) (string, error) {
+		if failing {
+			return "", errors.New("backend unavailable")
+		}
+		return values[path], nil
+	}))
+
+	w := NewWatcher(r, 0)
+	got, err := w.Add(context.Background(), "plain", "not-a-reference")
+	require.NoError(t, err)
+	assert.Equal(t, "not-a-reference", got)
+	assert.Equal(t, 0, w.Len())
+
+	got, err = w.Add(context.Background(), "first", "secret+test:a")
+	require.NoError(t, err)
+	assert.Equal(t, "1", got)
+	_, err = w.Add(context.Background(), "second", "secret+test:b")
+	require.NoError(t, err)
+	assert.Equal(t, 2, w.Len())
+
+	assert.Empty(t, w.Refresh(context.Background()))
+
+	values["a"] = "rotated"
+	assert.Equal(t, map[string]string{"first": "rotated"}, w.Refresh(context.Background()))
+	assert.Empty(t, w.Refresh(context.Background()))
+
+	failing = true
+	values["b"] = "rotated"
+	assert.Empty(t, w.Refresh(context.Background()))
+}
+
+func TestWatcherRunDisabled(t *testing.T) {
+	w := NewWatcher(NewResolver(), 0)
+	// must return immediately when the interval is zero
+	w.Run(context.Background(), func(map[string]string) {
+		t.Error("unexpected call to onChange")
+	})
+}
@@ -0,0 +1,111 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+const vaultRequestTimeout = 10 * time.Second
+
+// VaultConfig holds the connection settings of the Vault backend.
+type VaultConfig struct {
+	// Address of the Vault server, e.g. https://vault.example.com:8200
+	Address string
+	// Token used to authenticate against Vault
+	Token string
+	// Namespace is the Vault Enterprise namespace (optional)
+	Namespace string
+}
+
+// VaultConfigFromEnv reads the Vault settings from the standard VAULT_ADDR,
+// VAULT_TOKEN and VAULT_NAMESPACE environment variables.
+func VaultConfigFromEnv() VaultConfig {
+	return VaultConfig{
+		Address:   os.Getenv("VAULT_ADDR"),
+		Token:     os.Getenv("VAULT_TOKEN"),
+		Namespace: os.Getenv("VAULT_NAMESPACE"),
+	}
+}
+
+// VaultBackend reads secrets from a KV version 2 secrets engine.
+// References have the form `<mount>/data/<path>#<key>`.
+type VaultBackend struct {
+	config VaultConfig
+	client *http.Client
+}
+
+// NewVaultBackend returns a Vault backend using the given configuration.
+func NewVaultBackend(config VaultConfig, client *http.Client) *VaultBackend {
+	if client == nil {
+		client = &http.Client{Timeout: vaultRequestTimeout}
+	}
+	return &VaultBackend{config: config, client: client}
+}
+
+type vaultKVResponse struct {
+	Data struct {
+		Data json.RawMessage `json:"data"`
+	} `json:"data"`
+}
+
+// Fetch implements Backend.
+func (b *VaultBackend) Fetch(ctx context.Context, ref string) (string, error) {
+	if b.config.Address == "" {
+		return "", errors.New("vault address is not configured, set VAULT_ADDR")
+	}
+	path, key := splitKey(ref)
+	if key == "" {
+		return "", fmt.Errorf("vault reference %q must select a key with '#<key>'", ref)
+	}
+
+	url := strings.TrimSuffix(b.config.Address, "/") + "/v1/" + strings.TrimPrefix(path, "/")
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("X-Vault-Token", b.config.Token)
+	if b.config.Namespace != "" {
+		req.Header.Set("X-Vault-Namespace", b.config.Namespace)
+	}
+
+	resp, err := b.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return "", fmt.Errorf("vault path %q: %w", path, ErrSecretNotFound)
+	case resp.StatusCode != http.StatusOK:
+		return "", fmt.Errorf("vault returned status %d for path %q", resp.StatusCode, path)
+	}
+
+	var body vaultKVResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return "", fmt.Errorf("decoding vault response: %w", err)
+	}
+	return selectKey(body.Data.Data, key)
+}
@@ -0,0 +1,112 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secrets
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// Watcher keeps track of a set of named secret references and periodically
+// re-resolves them, reporting the ones whose value has changed.
+type Watcher struct {
+	resolver *Resolver
+	interval time.Duration
+
+	mu      sync.Mutex
+	refs    map[string]string
+	current map[string]string
+}
+
+// NewWatcher returns a Watcher that refreshes secrets every interval.
+// An interval of zero disables periodic refreshes.
+func NewWatcher(resolver *Resolver, interval time.Duration) *Watcher {
+	return &Watcher{
+		resolver: resolver,
+		interval: interval,
+		refs:     map[string]string{},
+		current:  map[string]string{},
+	}
+}
+
+// Add resolves ref and tracks it under name. Values that are not secret
+// references are returned as is and not tracked.
+func (w *Watcher) Add(ctx context.Context, name, ref string) (string, error) {
+	if !w.resolver.IsReference(ref) {
+		return ref, nil
+	}
+	value, err := w.resolver.Resolve(ctx, ref)
+	if err != nil {
+		return "", err
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.refs[name] = ref
+	w.current[name] = value
+	return value, nil
+}
+
+// Len returns the number of tracked secrets.
+func (w *Watcher) Len() int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return len(w.refs)
+}
+
+// Refresh re-resolves all tracked secrets and returns those whose value changed.
+// Secrets that fail to resolve keep their previous value.
+func (w *Watcher) Refresh(ctx context.Context) map[string]string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	changed := map[string]string{}
+	for name, ref := range w.refs {
+		value, err := w.resolver.Resolve(ctx, ref)
+		if err != nil {
+			log.Warnf("Failed to refresh secret %s: %v", name, err)
+			continue
+		}
+		if value != w.current[name] {
+			w.current[name] = value
+			changed[name] = value
+		}
+	}
+	return changed
+}
+
+// Run refreshes the tracked secrets every interval until ctx is canceled and
+// calls onChange with the secrets that were rotated.
+func (w *Watcher) Run(ctx context.Context, onChange func(changed map[string]string)) {
+	if w.interval <= 0 {
+		return
+	}
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if changed := w.Refresh(ctx); len(changed) > 0 {
+				log.Infof("Detected rotation of %d secret(s)", len(changed))
+				onChange(changed)
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
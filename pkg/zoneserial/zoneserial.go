@@ -0,0 +1,256 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package zoneserial verifies that the changes applied to the zones of a self-hosted DNS server
+// are published: the SOA serial of each changed zone must increase on the primary, and the
+// secondaries, notified by the primary, must then serve the new serial.
+package zoneserial
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/miekg/dns"
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/pkg/metrics"
+)
+
+const (
+	// defaultPort is the port of the secondaries without one.
+	defaultPort = "53"
+	// pollInterval is the interval between the queries of the secondaries which don't serve the
+	// serial of the primary yet.
+	pollInterval = 2 * time.Second
+)
+
+// errNoSOA is returned when the answer to a SOA query holds no SOA record, e.g. from a server
+// which isn't authoritative for the zone.
+var errNoSOA = errors.New("no SOA record")
+
+var (
+	serialCheckFailuresTotal = metrics.NewCounterVecWithOpts(
+		prometheus.CounterOpts{
+			Namespace: "external_dns",
+			Subsystem: "zone",
+			Name:      "serial_check_failures_total",
+			Help:      "Number of changes whose SOA serial didn't increase on the primary, or wasn't served by a secondary within the timeout, per zone and server (vector).",
+		},
+		[]string{"zone", "server"},
+	)
+	secondarySerialLag = metrics.NewGaugedVectorOpts(
+		prometheus.GaugeOpts{
+			Namespace: "external_dns",
+			Subsystem: "zone",
+			Name:      "secondary_serial_lag",
+			Help:      "Number of serials each secondary was behind the primary at the end of the last check of each zone (vector).",
+		},
+		[]string{"zone", "secondary"},
+	)
+	secondarySyncSeconds = metrics.NewGaugedVectorOpts(
+		prometheus.GaugeOpts{
+			Namespace: "external_dns",
+			Subsystem: "zone",
+			Name:      "secondary_sync_seconds",
+			Help:      "Time taken by each secondary to serve the serial of the last changes of each zone, or the timeout when it didn't (vector).",
+		},
+		[]string{"zone", "secondary"},
+	)
+)
+
+func init() {
+	metrics.RegisterMetric.MustRegister(serialCheckFailuresTotal)
+	metrics.RegisterMetric.MustRegister(secondarySerialLag)
+	metrics.RegisterMetric.MustRegister(secondarySyncSeconds)
+}
+
+// SerialFunc returns the SOA serial of a zone on the primary.
+type SerialFunc func(ctx context.Context, zone string) (uint32, error)
+
+// Verifier verifies the serials of the changed zones.
+type Verifier struct {
+	secondaries []string
+	timeout     time.Duration
+	interval    time.Duration
+	// query returns the serial of a zone served by a secondary
+	query func(ctx context.Context, server, zone string) (uint32, error)
+}
+
+// NewVerifier returns a verifier waiting up to the timeout for the secondaries, host[:port], to
+// serve the serial of the primary.
+func NewVerifier(secondaries []string, timeout time.Duration) *Verifier {
+	servers := make([]string, 0, len(secondaries))
+	for _, secondary := range secondaries {
+		servers = append(servers, withDefaultPort(secondary))
+	}
+	return &Verifier{
+		secondaries: servers,
+		timeout:     timeout,
+		interval:    pollInterval,
+		query: func(ctx context.Context, server, zone string) (uint32, error) {
+			return Query(ctx, new(dns.Client), server, zone)
+		},
+	}
+}
+
+// ValidateServer returns an error if a secondary isn't a host with an optional port.
+func ValidateServer(server string) error {
+	host, _, err := net.SplitHostPort(withDefaultPort(server))
+	if err != nil || host == "" {
+		return fmt.Errorf("invalid DNS server %q, expected host[:port]", server)
+	}
+	return nil
+}
+
+// withDefaultPort returns the server, host[:port], with the DNS port if it has none.
+func withDefaultPort(server string) string {
+	if _, _, err := net.SplitHostPort(server); err == nil {
+		return server
+	}
+	if !strings.Contains(server, ":") || net.ParseIP(server) != nil {
+		return net.JoinHostPort(server, defaultPort)
+	}
+	return server
+}
+
+// Query returns the SOA serial of a zone served by a server, host:port.
+func Query(ctx context.Context, client *dns.Client, server, zone string) (uint32, error) {
+	msg := new(dns.Msg)
+	msg.SetQuestion(dns.Fqdn(zone), dns.TypeSOA)
+	resp, _, err := client.ExchangeContext(ctx, msg, server)
+	if err != nil {
+		return 0, fmt.Errorf("querying the SOA record of zone %s on %s: %w", zone, server, err)
+	}
+	if resp.Rcode != dns.RcodeSuccess {
+		return 0, fmt.Errorf("querying the SOA record of zone %s on %s: %s", zone, server, dns.RcodeToString[resp.Rcode])
+	}
+	for _, rr := range resp.Answer {
+		if soa, ok := rr.(*dns.SOA); ok {
+			return soa.Serial, nil
+		}
+	}
+	return 0, fmt.Errorf("querying the SOA record of zone %s on %s: %w", zone, server, errNoSOA)
+}
+
+// Serials returns the serials of the zones on the primary before the changes are applied. The
+// zones whose serial can't be read are left out, they are not verified.
+func (v *Verifier) Serials(ctx context.Context, zones []string, primary SerialFunc) map[string]uint32 {
+	serials := make(map[string]uint32, len(zones))
+	for _, zone := range zones {
+		serial, err := primary(ctx, zone)
+		if err != nil {
+			log.Warnf("Not verifying the serial of zone %s: %v", zone, err)
+			continue
+		}
+		serials[zone] = serial
+	}
+	return serials
+}
+
+// Verify checks that the serial of each zone on the primary, once the changes are applied, is newer
+// than the serial read before, then waits in the background for the secondaries to serve it.
+// The failures are logged and counted rather than returned, as the changes are applied anyway.
+func (v *Verifier) Verify(ctx context.Context, before map[string]uint32, primary SerialFunc) {
+	for zone, previous := range before {
+		serial, err := primary(ctx, zone)
+		if err != nil {
+			log.Warnf("Not verifying the serial of zone %s: %v", zone, err)
+			continue
+		}
+		if !newer(serial, previous) {
+			log.Errorf("The serial of zone %s is still %d on the primary after the changes: they may not be transferred to the secondaries", zone, serial)
+			serialCheckFailuresTotal.CounterVec.WithLabelValues(zoneLabel(zone), "primary").Inc()
+			continue
+		}
+		log.Debugf("The serial of zone %s increased from %d to %d on the primary", zone, previous, serial)
+		if len(v.secondaries) > 0 {
+			go v.waitSecondaries(zone, serial)
+		}
+	}
+}
+
+// waitSecondaries queries the secondaries until they serve the serial of a zone, or the timeout.
+func (v *Verifier) waitSecondaries(zone string, serial uint32) {
+	ctx, cancel := context.WithTimeout(context.Background(), v.timeout)
+	defer cancel()
+	start := time.Now()
+	lags := make(map[string]uint32, len(v.secondaries))
+	pending := slices.Clone(v.secondaries)
+	for {
+		waiting := pending[:0]
+		for _, secondary := range pending {
+			current, err := v.query(ctx, secondary, zone)
+			if err != nil {
+				log.Debugf("Waiting for secondary %s to serve serial %d of zone %s: %v", secondary, serial, zone, err)
+				waiting = append(waiting, secondary)
+				continue
+			}
+			lags[secondary] = lag(serial, current)
+			if lags[secondary] > 0 {
+				waiting = append(waiting, secondary)
+				continue
+			}
+			log.Debugf("Secondary %s serves serial %d of zone %s after %s", secondary, serial, zone, time.Since(start))
+			secondarySerialLag.SetWithLabels(0, zoneLabel(zone), secondary)
+			secondarySyncSeconds.SetWithLabels(time.Since(start).Seconds(), zoneLabel(zone), secondary)
+		}
+		pending = waiting
+		if len(pending) == 0 {
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			for _, secondary := range pending {
+				behind, ok := lags[secondary]
+				if ok {
+					log.Errorf("Secondary %s is %d serials behind the primary for zone %s after %s: check the NOTIFY and the transfers of the zone", secondary, behind, zone, v.timeout)
+					secondarySerialLag.SetWithLabels(float64(behind), zoneLabel(zone), secondary)
+				} else {
+					log.Errorf("Secondary %s could not be queried for the serial of zone %s within %s", secondary, zone, v.timeout)
+				}
+				secondarySyncSeconds.SetWithLabels(v.timeout.Seconds(), zoneLabel(zone), secondary)
+				serialCheckFailuresTotal.CounterVec.WithLabelValues(zoneLabel(zone), secondary).Inc()
+			}
+			return
+		case <-time.After(v.interval):
+		}
+	}
+}
+
+// newer returns whether a serial is newer than another, with the serial number arithmetic of RFC 1982.
+func newer(serial, than uint32) bool {
+	return int32(serial-than) > 0
+}
+
+// lag returns the number of serials a serial is behind another, zero if it isn't.
+func lag(serial, of uint32) uint32 {
+	if !newer(serial, of) {
+		return 0
+	}
+	return serial - of
+}
+
+// zoneLabel returns the zone without the trailing dot, for the metrics.
+func zoneLabel(zone string) string {
+	return strings.TrimSuffix(zone, ".")
+}
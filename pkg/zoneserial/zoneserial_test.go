@@ -0,0 +1,128 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package zoneserial
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/miekg/dns"
+	promtestutil "github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewer(t *testing.T) {
+	assert.True(t, newer(2, 1))
+	assert.False(t, newer(1, 1))
+	assert.False(t, newer(1, 2))
+	// the serials wrap around
+	assert.True(t, newer(1, 0xffffffff))
+	assert.Equal(t, uint32(2), lag(1, 0xffffffff))
+	assert.Equal(t, uint32(0), lag(1, 2))
+}
+
+func TestValidateServer(t *testing.T) {
+	for _, server := range []string{"ns2.example.org", "ns2.example.org:5353", "192.0.2.1", "2001:db8::1", "[2001:db8::1]:53"} {
+		require.NoError(t, ValidateServer(server), server)
+	}
+	for _, server := range []string{"", ":53", "ns2.example.org:53:53"} {
+		require.Error(t, ValidateServer(server), server)
+	}
+	assert.Equal(t, []string{"ns2.example.org:53", "192.0.2.1:5353", "[2001:db8::1]:53"},
+		NewVerifier([]string{"ns2.example.org", "192.0.2.1:5353", "2001:db8::1"}, time.Second).secondaries)
+}
+
+func TestQuery(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	server := &dns.Server{PacketConn: conn, Handler: dns.HandlerFunc(func(w dns.ResponseWriter, r *dns.Msg) {
+		m := new(dns.Msg)
+		m.SetReply(r)
+		if r.Question[0].Name == "example.org." {
+			m.Answer = append(m.Answer, &dns.SOA{
+				Hdr:    dns.RR_Header{Name: "example.org.", Rrtype: dns.TypeSOA, Class: dns.ClassINET, Ttl: 300},
+				Ns:     "ns1.example.org.",
+				Mbox:   "hostmaster.example.org.",
+				Serial: 2025010101,
+			})
+		} else {
+			m.Rcode = dns.RcodeRefused
+		}
+		_ = w.WriteMsg(m)
+	})}
+	go func() { _ = server.ActivateAndServe() }()
+	t.Cleanup(func() { _ = server.Shutdown() })
+
+	serial, err := Query(context.Background(), new(dns.Client), conn.LocalAddr().String(), "example.org")
+	require.NoError(t, err)
+	assert.Equal(t, uint32(2025010101), serial)
+
+	_, err = Query(context.Background(), new(dns.Client), conn.LocalAddr().String(), "example.com")
+	require.ErrorContains(t, err, "REFUSED")
+}
+
+func TestVerifyPrimary(t *testing.T) {
+	serials := map[string]uint32{"example.org.": 2, "example.com.": 5}
+	primary := func(_ context.Context, zone string) (uint32, error) {
+		serial, ok := serials[zone]
+		if !ok {
+			return 0, errors.New("unknown zone")
+		}
+		return serial, nil
+	}
+	v := NewVerifier(nil, time.Second)
+
+	before := v.Serials(context.Background(), []string{"example.org.", "example.com.", "example.net."}, primary)
+	assert.Equal(t, map[string]uint32{"example.org.": 2, "example.com.": 5}, before)
+
+	failures := promtestutil.ToFloat64(serialCheckFailuresTotal.CounterVec.WithLabelValues("example.com", "primary"))
+	serials["example.org."] = 3
+	v.Verify(context.Background(), before, primary)
+	assert.InDelta(t, failures+1, promtestutil.ToFloat64(serialCheckFailuresTotal.CounterVec.WithLabelValues("example.com", "primary")), 0)
+	assert.InDelta(t, 0, promtestutil.ToFloat64(serialCheckFailuresTotal.CounterVec.WithLabelValues("example.org", "primary")), 0)
+}
+
+func TestWaitSecondaries(t *testing.T) {
+	var mu sync.Mutex
+	queries := map[string]int{}
+	v := NewVerifier([]string{"ns2.example.org", "ns3.example.org"}, 50*time.Millisecond)
+	v.interval = time.Millisecond
+	v.query = func(_ context.Context, server, _ string) (uint32, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		queries[server]++
+		if server == "ns2.example.org:53" && queries[server] > 2 {
+			return 10, nil
+		}
+		return 7, nil
+	}
+
+	failures := promtestutil.ToFloat64(serialCheckFailuresTotal.CounterVec.WithLabelValues("example.org", "ns3.example.org:53"))
+	v.waitSecondaries("example.org.", 10)
+
+	assert.Equal(t, 3, queries["ns2.example.org:53"])
+	assert.InDelta(t, 0, promtestutil.ToFloat64(secondarySerialLag.Gauge.WithLabelValues("example.org", "ns2.example.org:53")), 0)
+	assert.Less(t, promtestutil.ToFloat64(secondarySyncSeconds.Gauge.WithLabelValues("example.org", "ns2.example.org:53")), 0.05)
+	assert.InDelta(t, 3, promtestutil.ToFloat64(secondarySerialLag.Gauge.WithLabelValues("example.org", "ns3.example.org:53")), 0)
+	assert.InDelta(t, 0.05, promtestutil.ToFloat64(secondarySyncSeconds.Gauge.WithLabelValues("example.org", "ns3.example.org:53")), 0)
+	assert.InDelta(t, failures+1, promtestutil.ToFloat64(serialCheckFailuresTotal.CounterVec.WithLabelValues("example.org", "ns3.example.org:53")), 0)
+}
@@ -0,0 +1,121 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plan
+
+import (
+	"fmt"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/pkg/decisions"
+)
+
+// externalOwners finds the current records managed by another tool, e.g. Terraform, from the
+// markers it leaves on them.
+type externalOwners struct {
+	// keys are the names and set identifiers of the records with a marker in a provider-specific
+	// property, e.g. the comment or the tags of the record.
+	keys map[planKey]string
+	// names are the names of the records with a marker in a TXT record of their name.
+	names map[string]string
+}
+
+// newExternalOwners returns the external owners of the current records, matching the markers
+// case-insensitively.
+func newExternalOwners(current []*endpoint.Endpoint, markers []string) externalOwners {
+	owners := externalOwners{keys: map[planKey]string{}, names: map[string]string{}}
+	if len(markers) == 0 {
+		return owners
+	}
+	for _, ep := range current {
+		for _, property := range ep.ProviderSpecific {
+			if marker, ok := matchMarker(property.Value, markers); ok {
+				owners.keys[planKey{dnsName: normalizeDNSName(ep.DNSName), setIdentifier: ep.SetIdentifier}] =
+					fmt.Sprintf("its %s property contains %q", property.Name, marker)
+			}
+		}
+		if ep.RecordType != endpoint.RecordTypeTXT {
+			continue
+		}
+		for _, target := range ep.Targets {
+			if marker, ok := matchMarker(target, markers); ok {
+				owners.names[normalizeDNSName(ep.DNSName)] = fmt.Sprintf("the TXT record of its name contains %q", marker)
+			}
+		}
+	}
+	return owners
+}
+
+func matchMarker(value string, markers []string) (string, bool) {
+	value = strings.ToLower(value)
+	for _, marker := range markers {
+		if marker != "" && strings.Contains(value, strings.ToLower(marker)) {
+			return marker, true
+		}
+	}
+	return "", false
+}
+
+// owner returns why the record, or the records of its name, are managed by another tool.
+func (o externalOwners) owner(ep *endpoint.Endpoint) (string, bool) {
+	name := normalizeDNSName(ep.DNSName)
+	if reason, ok := o.keys[planKey{dnsName: name, setIdentifier: ep.SetIdentifier}]; ok {
+		return reason, true
+	}
+	reason, ok := o.names[name]
+	return reason, ok
+}
+
+// filter removes the changes of the records managed by another tool, and the creates of records
+// of their names, as they would conflict. The removed changes are recorded by filterDecisions.
+func (o externalOwners) filter(changes *Changes, filterDecisions *decisions.Recorder) {
+	if len(o.keys) == 0 && len(o.names) == 0 {
+		return
+	}
+	keep := func(ep *endpoint.Endpoint) bool {
+		reason, ok := o.owner(ep)
+		if ok {
+			log.Debugf("Skipping endpoint %v because it is managed by another tool: %s", ep, reason)
+			filterDecisions.Record(decisions.ForEndpoint(decisions.ExternalOwnerFilter, ep,
+				"the record is managed by another tool: "+reason))
+		}
+		return !ok
+	}
+	filterEndpoints := func(eps []*endpoint.Endpoint) []*endpoint.Endpoint {
+		var kept []*endpoint.Endpoint
+		for _, ep := range eps {
+			if keep(ep) {
+				kept = append(kept, ep)
+			}
+		}
+		return kept
+	}
+
+	changes.Create = filterEndpoints(changes.Create)
+	changes.Delete = filterEndpoints(changes.Delete)
+	changes.Adopt = filterEndpoints(changes.Adopt)
+	var updateOld, updateNew []*endpoint.Endpoint
+	for i, old := range changes.UpdateOld {
+		if i < len(changes.UpdateNew) && keep(old) {
+			updateOld = append(updateOld, old)
+			updateNew = append(updateNew, changes.UpdateNew[i])
+		}
+	}
+	changes.UpdateOld, changes.UpdateNew = updateOld, updateNew
+}
@@ -0,0 +1,84 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plan
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+func TestPlanExternalOwnerMarkers(t *testing.T) {
+	current := []*endpoint.Endpoint{
+		endpoint.NewEndpoint("commented.example.org", endpoint.RecordTypeA, "1.1.1.1").
+			WithProviderSpecific("external-dns.alpha.kubernetes.io/cloudflare-record-comment", "Managed by Terraform"),
+		endpoint.NewEndpoint("marked.example.org", endpoint.RecordTypeA, "1.1.1.1"),
+		endpoint.NewEndpoint("marked.example.org", endpoint.RecordTypeTXT, "managed-by=terraform"),
+		endpoint.NewEndpoint("unwanted.example.org", endpoint.RecordTypeA, "1.1.1.1"),
+		endpoint.NewEndpoint("changed.example.org", endpoint.RecordTypeA, "1.1.1.1"),
+	}
+	desired := []*endpoint.Endpoint{
+		endpoint.NewEndpoint("commented.example.org", endpoint.RecordTypeA, "2.2.2.2"),
+		endpoint.NewEndpoint("marked.example.org", endpoint.RecordTypeAAAA, "2001:db8::1"),
+		endpoint.NewEndpoint("changed.example.org", endpoint.RecordTypeA, "2.2.2.2"),
+	}
+	p := &Plan{
+		Policies:       []Policy{&SyncPolicy{}},
+		Current:        current,
+		Desired:        desired,
+		ManagedRecords: []string{endpoint.RecordTypeA, endpoint.RecordTypeAAAA},
+	}
+
+	// without markers, all the records are changed
+	changes := p.Calculate().Changes
+	assert.Len(t, changes.UpdateNew, 2)
+	assert.Len(t, changes.Create, 1)
+	assert.Len(t, changes.Delete, 2)
+
+	// the marked records are neither updated nor deleted, and no record is created in their names,
+	// even if the TXT record holding the marker is not managed
+	p.ExternalOwnerMarkers = []string{"terraform"}
+	changes = p.Calculate().Changes
+	assert.Empty(t, changes.Create)
+	assert.Equal(t, []*endpoint.Endpoint{current[4]}, changes.UpdateOld)
+	assert.Equal(t, []*endpoint.Endpoint{desired[2]}, changes.UpdateNew)
+	assert.Equal(t, []*endpoint.Endpoint{current[3]}, changes.Delete)
+}
+
+func TestExternalOwners(t *testing.T) {
+	owners := newExternalOwners([]*endpoint.Endpoint{
+		endpoint.NewEndpoint("a.example.org", endpoint.RecordTypeA, "1.1.1.1").WithSetIdentifier("eu").
+			WithProviderSpecific("comment", "owner: OpenTofu"),
+		endpoint.NewEndpoint("b.example.org", endpoint.RecordTypeTXT, "other", "managed by tofu"),
+		endpoint.NewEndpoint("c.example.org", endpoint.RecordTypeA, "1.1.1.1").WithProviderSpecific("comment", "hand-made"),
+	}, []string{"", "opentofu", "tofu"})
+
+	reason, ok := owners.owner(endpoint.NewEndpoint("A.example.org.", endpoint.RecordTypeA, "1.1.1.1").WithSetIdentifier("eu"))
+	assert.True(t, ok)
+	assert.Equal(t, `its comment property contains "opentofu"`, reason)
+	_, ok = owners.owner(endpoint.NewEndpoint("a.example.org", endpoint.RecordTypeA, "1.1.1.1").WithSetIdentifier("us"))
+	assert.False(t, ok)
+
+	reason, ok = owners.owner(endpoint.NewEndpoint("b.example.org", endpoint.RecordTypeCNAME, "c.example.org").WithSetIdentifier("us"))
+	assert.True(t, ok)
+	assert.Equal(t, `the TXT record of its name contains "tofu"`, reason)
+
+	_, ok = owners.owner(endpoint.NewEndpoint("c.example.org", endpoint.RecordTypeA, "1.1.1.1"))
+	assert.False(t, ok)
+}
@@ -0,0 +1,75 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plan
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+// Fingerprint returns a hash of the changes, which is the same for the same changes regardless
+// of the order of the records, of their targets, labels and provider specific properties.
+func (c *Changes) Fingerprint() string {
+	h := sha256.New()
+	for _, section := range []struct {
+		name      string
+		endpoints []*endpoint.Endpoint
+	}{
+		{"create", c.Create},
+		{"updateOld", c.UpdateOld},
+		{"updateNew", c.UpdateNew},
+		{"delete", c.Delete},
+		{"adopt", c.Adopt},
+	} {
+		lines := make([]string, 0, len(section.endpoints))
+		for _, ep := range section.endpoints {
+			lines = append(lines, fingerprintLine(ep))
+		}
+		sort.Strings(lines)
+		for _, line := range lines {
+			fmt.Fprintf(h, "%s %s\n", section.name, line)
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// fingerprintLine returns a line describing the endpoint, with its targets, labels and
+// provider specific properties sorted.
+func fingerprintLine(ep *endpoint.Endpoint) string {
+	targets := append([]string(nil), ep.Targets...)
+	sort.Strings(targets)
+
+	labels := make([]string, 0, len(ep.Labels))
+	for k, v := range ep.Labels {
+		labels = append(labels, k+"="+v)
+	}
+	sort.Strings(labels)
+
+	properties := make([]string, 0, len(ep.ProviderSpecific))
+	for _, p := range ep.ProviderSpecific {
+		properties = append(properties, p.Name+"="+p.Value)
+	}
+	sort.Strings(properties)
+
+	return fmt.Sprintf("%q %d %q %q %q %q %q", ep.DNSName, ep.RecordTTL, ep.RecordType, ep.SetIdentifier,
+		strings.Join(targets, ";"), strings.Join(labels, ";"), strings.Join(properties, ";"))
+}
@@ -0,0 +1,56 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plan
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+func TestChangesFingerprint(t *testing.T) {
+	foo := func() *endpoint.Endpoint {
+		return endpoint.NewEndpoint("foo.example.org", endpoint.RecordTypeA, "1.2.3.4", "5.6.7.8").
+			WithProviderSpecific("alias", "false").
+			WithProviderSpecific("weight", "10")
+	}
+	bar := func() *endpoint.Endpoint {
+		return endpoint.NewEndpoint("bar.example.org", endpoint.RecordTypeCNAME, "lb.example.com")
+	}
+
+	changes := &Changes{Create: []*endpoint.Endpoint{foo(), bar()}}
+	fingerprint := changes.Fingerprint()
+	assert.Len(t, fingerprint, 64)
+
+	// the order of the records and of their attributes doesn't matter
+	reordered := foo()
+	reordered.Targets = endpoint.Targets{"5.6.7.8", "1.2.3.4"}
+	reordered.ProviderSpecific[0], reordered.ProviderSpecific[1] = reordered.ProviderSpecific[1], reordered.ProviderSpecific[0]
+	assert.Equal(t, fingerprint, (&Changes{Create: []*endpoint.Endpoint{bar(), reordered}}).Fingerprint())
+
+	for name, other := range map[string]*Changes{
+		"no changes":    {},
+		"other section": {Delete: []*endpoint.Endpoint{foo(), bar()}},
+		"other target":  {Create: []*endpoint.Endpoint{foo(), endpoint.NewEndpoint("bar.example.org", endpoint.RecordTypeCNAME, "other.example.com")}},
+		"other ttl":     {Create: []*endpoint.Endpoint{foo(), endpoint.NewEndpointWithTTL("bar.example.org", endpoint.RecordTypeCNAME, 300, "lb.example.com")}},
+		"other labels":  {Create: []*endpoint.Endpoint{foo(), {DNSName: "bar.example.org", RecordType: endpoint.RecordTypeCNAME, Targets: endpoint.Targets{"lb.example.com"}, Labels: endpoint.Labels{endpoint.OwnerLabelKey: "owner"}}}},
+	} {
+		assert.NotEqual(t, fingerprint, other.Fingerprint(), name)
+	}
+}
@@ -18,6 +18,7 @@ package plan
 
 import (
 	"fmt"
+	"maps"
 	"strings"
 
 	"github.com/google/go-cmp/cmp"
@@ -25,6 +26,7 @@ import (
 	"golang.org/x/net/idna"
 
 	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/pkg/decisions"
 )
 
 // PropertyComparator is used in Plan for comparing the previous and current custom annotations.
@@ -50,6 +52,22 @@ type Plan struct {
 	ExcludeRecords []string
 	// OwnerID of records to manage
 	OwnerID string
+	// OwnerIDTemplate derives the owner IDs of the records from OwnerID and their resources, nil
+	// to own all of them by OwnerID
+	OwnerIDTemplate *endpoint.OwnerIDTemplate
+	// AdoptExisting takes the ownership of the existing records without an owner which match
+	// the desired ones, instead of leaving them alone
+	AdoptExisting bool
+	// IgnoreTTL doesn't update the records whose TTL only differs from the desired one, for
+	// providers whose TTL policies never match it
+	IgnoreTTL bool
+	// ExternalOwnerMarkers are the texts marking the records managed by another tool, e.g.
+	// Terraform, in their provider-specific properties or the TXT records of their names. These
+	// records are never changed.
+	ExternalOwnerMarkers []string
+	// FilterDecisions records the desired records excluded by the filters and the owners, nil not
+	// to record them
+	FilterDecisions *decisions.Recorder
 }
 
 // Changes holds lists of actions to be executed by dns providers
@@ -62,6 +80,8 @@ type Changes struct {
 	UpdateNew []*endpoint.Endpoint `json:"updateNew,omitempty"`
 	// Records that need to be deleted
 	Delete []*endpoint.Endpoint `json:"delete,omitempty"`
+	// Records that exist without an owner and whose ownership needs to be taken, unchanged
+	Adopt []*endpoint.Endpoint `json:"adopt,omitempty"`
 }
 
 // planKey is a key for a row in `planTable`.
@@ -156,7 +176,7 @@ func (t *planTable) newPlanKey(e *endpoint.Endpoint) planKey {
 }
 
 func (c *Changes) HasChanges() bool {
-	if len(c.Create) > 0 || len(c.Delete) > 0 {
+	if len(c.Create) > 0 || len(c.Delete) > 0 || len(c.Adopt) > 0 {
 		return true
 	}
 	return !cmp.Equal(c.UpdateNew, c.UpdateOld)
@@ -167,15 +187,17 @@ func (c *Changes) HasChanges() bool {
 // processing. It returns a copy of Plan with the changes populated.
 func (p *Plan) Calculate() *Plan {
 	t := newPlanTable()
+	// the TXT records holding markers may not be managed, so they're looked up before filtering
+	externalOwners := newExternalOwners(p.Current, p.ExternalOwnerMarkers)
 
 	if p.DomainFilter == nil {
 		p.DomainFilter = endpoint.MatchAllDomainFilters(nil)
 	}
 
-	for _, current := range filterRecordsForPlan(p.Current, p.DomainFilter, p.ManagedRecords, p.ExcludeRecords) {
+	for _, current := range filterRecordsForPlan(p.Current, p.DomainFilter, p.ManagedRecords, p.ExcludeRecords, nil) {
 		t.addCurrent(current)
 	}
-	for _, desired := range filterRecordsForPlan(p.Desired, p.DomainFilter, p.ManagedRecords, p.ExcludeRecords) {
+	for _, desired := range filterRecordsForPlan(p.Desired, p.DomainFilter, p.ManagedRecords, p.ExcludeRecords, p.FilterDecisions) {
 		t.addCandidate(desired)
 	}
 
@@ -204,8 +226,8 @@ func (p *Plan) Calculate() *Plan {
 			// apply changes for each record type
 			recordsByType := t.resolver.ResolveRecordTypes(key, row)
 			for _, records := range recordsByType {
-				// record type not desired
-				if records.current != nil && len(records.candidates) == 0 {
+				// record type not desired, by the same tenant
+				if records.current != nil && len(records.candidates) == 0 && p.sameTenant(records.current, row.candidates) {
 					changes.Delete = append(changes.Delete, records.current)
 				}
 
@@ -222,7 +244,9 @@ func (p *Plan) Calculate() *Plan {
 				if records.current != nil && len(records.candidates) > 0 {
 					update := t.resolver.ResolveUpdate(records.current, records.candidates)
 
-					if shouldUpdateTTL(update, records.current) || targetChanged(update, records.current) || p.shouldUpdateProviderSpecific(update, records.current) {
+					if p.shouldAdopt(update, records.current) {
+						changes.Adopt = append(changes.Adopt, adopt(records.current, update, p.OwnerID, p.OwnerIDTemplate))
+					} else if (!p.IgnoreTTL && shouldUpdateTTL(update, records.current)) || targetChanged(update, records.current) || p.shouldUpdateProviderSpecific(update, records.current) || kubernetesLabelsChanged(update, records.current) {
 						inheritOwner(records.current, update)
 						changes.UpdateNew = append(changes.UpdateNew, update)
 						changes.UpdateOld = append(changes.UpdateOld, records.current)
@@ -234,16 +258,22 @@ func (p *Plan) Calculate() *Plan {
 				// only add creates if the external dns has ownership claim on the domain
 				ownersMatch := true
 				for _, current := range row.current {
-					if p.OwnerID != "" && !current.IsOwnedBy(p.OwnerID) {
+					if p.OwnerID != "" && (!p.OwnerIDTemplate.IsOwnedBy(p.OwnerID, current.Labels) || !p.sameTenant(current, creates)) {
 						ownersMatch = false
 					}
 				}
 
 				if ownersMatch {
 					changes.Create = append(changes.Create, creates...)
-				} else if log.GetLevel() == log.DebugLevel {
-					for _, current := range row.current {
-						log.Debugf(`Skipping endpoint %v because owner id does not match for one or more items to create, found: "%s", required: "%s"`, current, current.Labels[endpoint.OwnerLabelKey], p.OwnerID)
+				} else {
+					if log.GetLevel() == log.DebugLevel {
+						for _, current := range row.current {
+							log.Debugf(`Skipping endpoint %v because owner id does not match for one or more items to create, found: "%s", required: "%s"`, current, current.Labels[endpoint.OwnerLabelKey], p.OwnerID)
+						}
+					}
+					for _, create := range creates {
+						p.FilterDecisions.Record(decisions.ForEndpoint(decisions.OwnerFilter, create,
+							fmt.Sprintf("the name %s is taken by records not owned by %q", create.DNSName, p.OwnerIDTemplate.OwnerID(p.OwnerID, create.Labels))))
 					}
 				}
 			}
@@ -254,12 +284,19 @@ func (p *Plan) Calculate() *Plan {
 		changes = pol.Apply(changes)
 	}
 
+	externalOwners.filter(changes, p.FilterDecisions)
+
 	// filter out updates this external dns does not have ownership claim over
 	if p.OwnerID != "" {
-		changes.Delete = endpoint.FilterEndpointsByOwnerID(p.OwnerID, changes.Delete)
+		for _, update := range changes.UpdateNew {
+			if owner, required := update.Labels[endpoint.OwnerLabelKey], p.OwnerIDTemplate.OwnerID(p.OwnerID, update.Labels); owner != required {
+				p.FilterDecisions.Record(decisions.ForEndpoint(decisions.OwnerFilter, update,
+					fmt.Sprintf("the record is owned by %q, not %q", owner, required)))
+			}
+		}
+		changes.Delete = p.OwnerIDTemplate.FilterEndpointsByOwnerID(p.OwnerID, changes.Delete)
 		changes.Delete = endpoint.RemoveDuplicates(changes.Delete)
-		changes.UpdateOld = endpoint.FilterEndpointsByOwnerID(p.OwnerID, changes.UpdateOld)
-		changes.UpdateNew = endpoint.FilterEndpointsByOwnerID(p.OwnerID, changes.UpdateNew)
+		changes.UpdateOld, changes.UpdateNew = filterUpdatesByOwnerID(p.OwnerID, p.OwnerIDTemplate, changes.UpdateOld, changes.UpdateNew)
 	}
 
 	plan := &Plan{
@@ -274,6 +311,37 @@ func (p *Plan) Calculate() *Plan {
 	return plan
 }
 
+// sameTenant returns whether the current record is owned by the owner of the desired records,
+// which differ with an owner ID template when they're desired by the resources of another tenant.
+func (p *Plan) sameTenant(current *endpoint.Endpoint, desired []*endpoint.Endpoint) bool {
+	if p.OwnerID == "" {
+		return true
+	}
+	for _, ep := range desired {
+		if current.Labels[endpoint.OwnerLabelKey] != p.OwnerIDTemplate.OwnerID(p.OwnerID, ep.Labels) {
+			return false
+		}
+	}
+	return true
+}
+
+// filterUpdatesByOwnerID returns the updates whose desired records are owned by the owner, the
+// current records inheriting their owner, so that a resource never updates the records of another
+// tenant of the instance.
+func filterUpdatesByOwnerID(ownerID string, ownerIDTemplate *endpoint.OwnerIDTemplate, updateOld, updateNew []*endpoint.Endpoint) ([]*endpoint.Endpoint, []*endpoint.Endpoint) {
+	filteredOld := []*endpoint.Endpoint{}
+	filteredNew := []*endpoint.Endpoint{}
+	for i, update := range updateNew {
+		if i >= len(updateOld) || !ownerIDTemplate.IsOwnedBy(ownerID, update.Labels) {
+			log.Debugf(`Skipping endpoint %v because owner id does not match, found: "%s", required: "%s"`, update, update.Labels[endpoint.OwnerLabelKey], ownerIDTemplate.OwnerID(ownerID, update.Labels))
+			continue
+		}
+		filteredOld = append(filteredOld, updateOld[i])
+		filteredNew = append(filteredNew, update)
+	}
+	return filteredOld, filteredNew
+}
+
 func inheritOwner(from, to *endpoint.Endpoint) {
 	if to.Labels == nil {
 		to.Labels = map[string]string{}
@@ -284,10 +352,71 @@ func inheritOwner(from, to *endpoint.Endpoint) {
 	to.Labels[endpoint.OwnerLabelKey] = from.Labels[endpoint.OwnerLabelKey]
 }
 
+// shouldAdopt returns whether the ownership of the current record, which has no owner, is taken
+// as it already has the desired targets.
+func (p *Plan) shouldAdopt(desired, current *endpoint.Endpoint) bool {
+	if !p.AdoptExisting || p.OwnerID == "" || current.Labels[endpoint.OwnerLabelKey] != "" {
+		return false
+	}
+	return !targetChanged(desired, current)
+}
+
+// adopt returns the current record owned by the owner, with the resource of the desired one.
+func adopt(current, desired *endpoint.Endpoint, ownerID string, ownerIDTemplate *endpoint.OwnerIDTemplate) *endpoint.Endpoint {
+	adopted := current.DeepCopy()
+	if adopted.Labels == nil {
+		adopted.Labels = endpoint.NewLabels()
+	}
+	adopted.Labels[endpoint.OwnerLabelKey] = ownerIDTemplate.OwnerID(ownerID, desired.Labels)
+	if resource, ok := desired.Labels[endpoint.ResourceLabelKey]; ok {
+		adopted.Labels[endpoint.ResourceLabelKey] = resource
+	}
+	return adopted
+}
+
 func targetChanged(desired, current *endpoint.Endpoint) bool {
 	return !desired.Targets.Same(current.Targets)
 }
 
+// IsTTLOnlyUpdate returns whether an update only changes the TTL of a record, which some
+// registries and providers apply without rewriting the rest of the record.
+func IsTTLOnlyUpdate(current, desired *endpoint.Endpoint) bool {
+	return current.RecordTTL != desired.RecordTTL &&
+		current.Targets.Same(desired.Targets) &&
+		!providerSpecificChanged(desired, current) &&
+		sameLabels(current.Labels, desired.Labels)
+}
+
+// sameLabels returns whether both labels have the same values, a missing label being empty.
+func sameLabels(a, b endpoint.Labels) bool {
+	for key, value := range a {
+		if b[key] != value {
+			return false
+		}
+	}
+	for key, value := range b {
+		if a[key] != value {
+			return false
+		}
+	}
+	return true
+}
+
+// TTLOnlyUpdates returns the keys of the updated records whose TTL only changes.
+func (c *Changes) TTLOnlyUpdates() map[endpoint.EndpointKey]bool {
+	current := map[endpoint.EndpointKey]*endpoint.Endpoint{}
+	for _, ep := range c.UpdateOld {
+		current[ep.Key()] = ep
+	}
+	updates := map[endpoint.EndpointKey]bool{}
+	for _, ep := range c.UpdateNew {
+		if old, ok := current[ep.Key()]; ok && IsTTLOnlyUpdate(old, ep) {
+			updates[ep.Key()] = true
+		}
+	}
+	return updates
+}
+
 func shouldUpdateTTL(desired, current *endpoint.Endpoint) bool {
 	if !desired.RecordTTL.IsConfigured() {
 		return false
@@ -295,7 +424,21 @@ func shouldUpdateTTL(desired, current *endpoint.Endpoint) bool {
 	return desired.RecordTTL != current.RecordTTL
 }
 
+// kubernetesLabelsChanged returns whether the labels copied from the Kubernetes resource of a record
+// changed. They're only compared to the records of a registry, which records them along with the owner,
+// as the other records never have them.
+func kubernetesLabelsChanged(desired, current *endpoint.Endpoint) bool {
+	if current.Labels[endpoint.OwnerLabelKey] == "" {
+		return false
+	}
+	return !maps.Equal(desired.Labels.KubernetesLabels(), current.Labels.KubernetesLabels())
+}
+
 func (p *Plan) shouldUpdateProviderSpecific(desired, current *endpoint.Endpoint) bool {
+	return providerSpecificChanged(desired, current)
+}
+
+func providerSpecificChanged(desired, current *endpoint.Endpoint) bool {
 	desiredProperties := map[string]endpoint.ProviderSpecificProperty{}
 
 	for _, d := range desired.ProviderSpecific {
@@ -322,17 +465,24 @@ func (p *Plan) shouldUpdateProviderSpecific(desired, current *endpoint.Endpoint)
 // Per RFC 1034, CNAME records conflict with all other records - it is the
 // only record with this property. The behavior of the planner may need to be
 // made more sophisticated to codify this.
-func filterRecordsForPlan(records []*endpoint.Endpoint, domainFilter endpoint.MatchAllDomainFilters, managedRecords, excludeRecords []string) []*endpoint.Endpoint {
+//
+// The exclusions are recorded by filterDecisions to explain them, which is nil for the current
+// records.
+func filterRecordsForPlan(records []*endpoint.Endpoint, domainFilter endpoint.MatchAllDomainFilters, managedRecords, excludeRecords []string, filterDecisions *decisions.Recorder) []*endpoint.Endpoint {
 	filtered := []*endpoint.Endpoint{}
 
 	for _, record := range records {
 		// Ignore records that do not match the domain filter provided
 		if !domainFilter.Match(record.DNSName) {
 			log.Debugf("ignoring record %s that does not match domain filter", record.DNSName)
+			filterDecisions.Record(decisions.ForEndpoint(decisions.DomainFilter, record, "the name does not match the domain filter"))
 			continue
 		}
 		if IsManagedRecord(record.RecordType, managedRecords, excludeRecords) {
 			filtered = append(filtered, record)
+		} else {
+			filterDecisions.Record(decisions.ForEndpoint(decisions.RecordTypeFilter, record,
+				fmt.Sprintf("the record type %s is not managed", record.RecordType)))
 		}
 	}
 
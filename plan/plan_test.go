@@ -28,6 +28,7 @@ import (
 
 	"sigs.k8s.io/external-dns/endpoint"
 	"sigs.k8s.io/external-dns/internal/testutils"
+	"sigs.k8s.io/external-dns/pkg/decisions"
 )
 
 type PlanTestSuite struct {
@@ -379,6 +380,64 @@ func (suite *PlanTestSuite) TestSyncSecondRoundWithTTLChange() {
 	validateEntries(suite.T(), changes.Delete, expectedDelete)
 }
 
+func (suite *PlanTestSuite) TestSyncSecondRoundWithIgnoredTTLChange() {
+	current := []*endpoint.Endpoint{suite.bar127A}
+	desired := []*endpoint.Endpoint{suite.bar127AWithTTL}
+
+	p := &Plan{
+		Policies:       []Policy{&SyncPolicy{}},
+		Current:        current,
+		Desired:        desired,
+		ManagedRecords: []string{endpoint.RecordTypeA, endpoint.RecordTypeCNAME},
+		IgnoreTTL:      true,
+	}
+
+	changes := p.Calculate().Changes
+	suite.False(changes.HasChanges())
+}
+
+func (suite *PlanTestSuite) TestSyncSecondRoundWithKubernetesLabelChange() {
+	current := endpoint.NewEndpoint("foo", endpoint.RecordTypeA, "1.2.3.4").
+		WithLabel(endpoint.OwnerLabelKey, "pwner").
+		WithLabel(endpoint.KubernetesLabelPrefix+"team", "payments")
+	desired := endpoint.NewEndpoint("foo", endpoint.RecordTypeA, "1.2.3.4").
+		WithLabel(endpoint.KubernetesLabelPrefix+"team", "checkout")
+	unlabelled := endpoint.NewEndpoint("foo", endpoint.RecordTypeA, "1.2.3.4")
+	// the records of the noop registry don't record the labels
+	unowned := endpoint.NewEndpoint("foo", endpoint.RecordTypeA, "1.2.3.4")
+
+	for _, tc := range []struct {
+		name    string
+		current *endpoint.Endpoint
+		desired *endpoint.Endpoint
+		updated bool
+	}{
+		{name: "changed", current: current, desired: desired, updated: true},
+		{name: "removed", current: current, desired: unlabelled, updated: true},
+		{name: "unchanged", current: current, desired: current, updated: false},
+		{name: "unowned", current: unowned, desired: desired, updated: false},
+	} {
+		suite.Run(tc.name, func() {
+			p := &Plan{
+				Policies:       []Policy{&SyncPolicy{}},
+				Current:        []*endpoint.Endpoint{tc.current},
+				Desired:        []*endpoint.Endpoint{tc.desired},
+				ManagedRecords: []string{endpoint.RecordTypeA},
+				OwnerID:        "pwner",
+			}
+			changes := p.Calculate().Changes
+			if tc.updated {
+				suite.Len(changes.UpdateNew, 1)
+				suite.Equal("pwner", changes.UpdateNew[0].Labels[endpoint.OwnerLabelKey])
+			} else {
+				suite.Empty(changes.UpdateNew)
+			}
+			suite.Empty(changes.Create)
+			suite.Empty(changes.Delete)
+		})
+	}
+}
+
 func (suite *PlanTestSuite) TestSyncSecondRoundWithProviderSpecificChange() {
 	current := []*endpoint.Endpoint{suite.bar127AWithProviderSpecificTrue}
 	desired := []*endpoint.Endpoint{suite.bar127AWithProviderSpecificFalse}
@@ -1021,6 +1080,131 @@ func TestPlan(t *testing.T) {
 	suite.Run(t, new(PlanTestSuite))
 }
 
+func TestPlanRecordsFilterDecisions(t *testing.T) {
+	recorder := decisions.NewRecorder()
+	recorder.Enable()
+
+	owned := func(ep *endpoint.Endpoint, owner string) *endpoint.Endpoint {
+		return ep.WithLabel(endpoint.OwnerLabelKey, owner)
+	}
+	p := &Plan{
+		Current: []*endpoint.Endpoint{
+			owned(endpoint.NewEndpoint("bar.other.tld", endpoint.RecordTypeA, "1.1.1.1"), "owner"),
+			owned(endpoint.NewEndpoint("taken.example.org", endpoint.RecordTypeA, "1.1.1.1"), "other"),
+			owned(endpoint.NewEndpoint("update.example.org", endpoint.RecordTypeA, "1.1.1.1"), "other"),
+		},
+		Desired: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("foo.other.tld", endpoint.RecordTypeA, "1.2.3.4"),
+			endpoint.NewEndpoint("txt.example.org", endpoint.RecordTypeTXT, "text"),
+			endpoint.NewEndpoint("taken.example.org", endpoint.RecordTypeAAAA, "2001:db8::1"),
+			endpoint.NewEndpoint("update.example.org", endpoint.RecordTypeA, "2.2.2.2"),
+		},
+		DomainFilter:    endpoint.MatchAllDomainFilters{endpoint.NewDomainFilter([]string{"example.org"})},
+		ManagedRecords:  []string{endpoint.RecordTypeA, endpoint.RecordTypeAAAA},
+		OwnerID:         "owner",
+		FilterDecisions: recorder,
+	}
+	changes := p.Calculate().Changes
+	assert.False(t, changes.HasChanges())
+
+	var explained []string
+	for _, d := range recorder.Decisions() {
+		explained = append(explained, d.Filter+" "+d.DNSName+" "+d.RecordType+": "+d.Reason)
+	}
+	// the current records excluded by the filters are not candidates, so they are not explained
+	assert.ElementsMatch(t, []string{
+		`domain-filter foo.other.tld A: the name does not match the domain filter`,
+		`record-type-filter txt.example.org TXT: the record type TXT is not managed`,
+		`owner taken.example.org AAAA: the name taken.example.org is taken by records not owned by "owner"`,
+		`owner update.example.org A: the record is owned by "other", not "owner"`,
+	}, explained)
+}
+
+func TestPlanAdoptExisting(t *testing.T) {
+	current := []*endpoint.Endpoint{
+		endpoint.NewEndpointWithTTL("same.example.org", endpoint.RecordTypeA, 60, "1.1.1.1"),
+		endpoint.NewEndpoint("changed.example.org", endpoint.RecordTypeA, "1.1.1.1"),
+		endpoint.NewEndpoint("owned.example.org", endpoint.RecordTypeA, "1.1.1.1").WithLabel(endpoint.OwnerLabelKey, "owner"),
+		endpoint.NewEndpoint("other.example.org", endpoint.RecordTypeA, "1.1.1.1").WithLabel(endpoint.OwnerLabelKey, "other"),
+		endpoint.NewEndpoint("unwanted.example.org", endpoint.RecordTypeA, "1.1.1.1"),
+	}
+	desired := []*endpoint.Endpoint{
+		endpoint.NewEndpointWithTTL("same.example.org", endpoint.RecordTypeA, 300, "1.1.1.1").WithLabel(endpoint.ResourceLabelKey, "ingress/default/same"),
+		endpoint.NewEndpoint("changed.example.org", endpoint.RecordTypeA, "2.2.2.2"),
+		endpoint.NewEndpoint("owned.example.org", endpoint.RecordTypeA, "1.1.1.1"),
+		endpoint.NewEndpoint("other.example.org", endpoint.RecordTypeA, "1.1.1.1"),
+	}
+
+	// the records without owner are left alone by default
+	p := &Plan{
+		Policies:       []Policy{&SyncPolicy{}},
+		Current:        current,
+		Desired:        desired,
+		ManagedRecords: []string{endpoint.RecordTypeA},
+		OwnerID:        "owner",
+	}
+	changes := p.Calculate().Changes
+	assert.False(t, changes.HasChanges())
+
+	// only the records without owner which have the desired targets are adopted, unchanged
+	p.AdoptExisting = true
+	changes = p.Calculate().Changes
+	assert.True(t, changes.HasChanges())
+	assert.Empty(t, changes.Create)
+	assert.Empty(t, changes.UpdateNew)
+	assert.Empty(t, changes.Delete)
+	assert.Equal(t, []*endpoint.Endpoint{
+		endpoint.NewEndpointWithTTL("same.example.org", endpoint.RecordTypeA, 60, "1.1.1.1").
+			WithLabel(endpoint.OwnerLabelKey, "owner").
+			WithLabel(endpoint.ResourceLabelKey, "ingress/default/same"),
+	}, changes.Adopt)
+	assert.Empty(t, current[0].Labels[endpoint.OwnerLabelKey])
+
+	// nothing is adopted without an owner ID
+	p.OwnerID = ""
+	assert.Empty(t, p.Calculate().Changes.Adopt)
+}
+
+func TestPlanOwnerIDTemplate(t *testing.T) {
+	ownerIDTemplate, err := endpoint.NewOwnerIDTemplate("{{ .OwnerID }}-{{ .Namespace }}")
+	require.NoError(t, err)
+
+	owned := func(name, recordType, target, owner, resource string) *endpoint.Endpoint {
+		return endpoint.NewEndpoint(name, recordType, target).
+			WithLabel(endpoint.OwnerLabelKey, owner).
+			WithLabel(endpoint.ResourceLabelKey, resource)
+	}
+	current := []*endpoint.Endpoint{
+		owned("taken.example.org", endpoint.RecordTypeA, "1.1.1.1", "cluster-team-a", "service/team-a/web"),
+		owned("changed.example.org", endpoint.RecordTypeA, "1.1.1.1", "cluster-team-a", "service/team-a/api"),
+		owned("released.example.org", endpoint.RecordTypeA, "1.1.1.1", "cluster-team-a", "service/team-a/old"),
+		owned("other.example.org", endpoint.RecordTypeA, "1.1.1.1", "other-team-a", "service/team-a/old"),
+		owned("dual.example.org", endpoint.RecordTypeAAAA, "2001:db8::1", "cluster-team-a", "service/team-a/dual"),
+	}
+	desired := []*endpoint.Endpoint{
+		endpoint.NewEndpoint("taken.example.org", endpoint.RecordTypeA, "2.2.2.2").WithLabel(endpoint.ResourceLabelKey, "service/team-b/web"),
+		endpoint.NewEndpoint("changed.example.org", endpoint.RecordTypeA, "2.2.2.2").WithLabel(endpoint.ResourceLabelKey, "service/team-a/api"),
+		endpoint.NewEndpoint("dual.example.org", endpoint.RecordTypeA, "2.2.2.2").WithLabel(endpoint.ResourceLabelKey, "service/team-b/dual"),
+		endpoint.NewEndpoint("new.example.org", endpoint.RecordTypeA, "2.2.2.2").WithLabel(endpoint.ResourceLabelKey, "service/team-b/new"),
+	}
+	p := &Plan{
+		Policies:        []Policy{&SyncPolicy{}},
+		Current:         current,
+		Desired:         desired,
+		ManagedRecords:  []string{endpoint.RecordTypeA, endpoint.RecordTypeAAAA},
+		OwnerID:         "cluster",
+		OwnerIDTemplate: ownerIDTemplate,
+	}
+	changes := p.Calculate().Changes
+
+	// the records of a tenant are never created, updated or deleted for the resources of another one
+	validateEntries(t, changes.Create, []*endpoint.Endpoint{desired[3]})
+	validateEntries(t, changes.UpdateOld, []*endpoint.Endpoint{current[1]})
+	validateEntries(t, changes.UpdateNew, []*endpoint.Endpoint{desired[1]})
+	assert.Equal(t, "cluster-team-a", changes.UpdateNew[0].Labels[endpoint.OwnerLabelKey])
+	validateEntries(t, changes.Delete, []*endpoint.Endpoint{current[2]})
+}
+
 // validateEntries validates that the list of entries matches expected.
 func validateEntries(t *testing.T, entries, expected []*endpoint.Endpoint) {
 	if !testutils.SameEndpoints(entries, expected) {
@@ -1181,3 +1365,41 @@ func TestShouldUpdateProviderSpecific(tt *testing.T) {
 		})
 	}
 }
+
+func TestIsTTLOnlyUpdate(t *testing.T) {
+	current := endpoint.NewEndpointWithTTL("web.example.org", endpoint.RecordTypeA, 60, "1.2.3.4").
+		WithLabel(endpoint.OwnerLabelKey, "owner")
+	for _, tc := range []struct {
+		name     string
+		desired  *endpoint.Endpoint
+		expected bool
+	}{
+		{
+			name:     "ttl",
+			desired:  endpoint.NewEndpointWithTTL("web.example.org", endpoint.RecordTypeA, 300, "1.2.3.4").WithLabel(endpoint.OwnerLabelKey, "owner"),
+			expected: true,
+		},
+		{
+			name:    "same ttl",
+			desired: endpoint.NewEndpointWithTTL("web.example.org", endpoint.RecordTypeA, 60, "1.2.3.4").WithLabel(endpoint.OwnerLabelKey, "owner"),
+		},
+		{
+			name:    "targets",
+			desired: endpoint.NewEndpointWithTTL("web.example.org", endpoint.RecordTypeA, 300, "5.6.7.8").WithLabel(endpoint.OwnerLabelKey, "owner"),
+		},
+		{
+			name:    "provider specific",
+			desired: endpoint.NewEndpointWithTTL("web.example.org", endpoint.RecordTypeA, 300, "1.2.3.4").WithLabel(endpoint.OwnerLabelKey, "owner").WithProviderSpecific("alias", "true"),
+		},
+		{
+			name:    "labels",
+			desired: endpoint.NewEndpointWithTTL("web.example.org", endpoint.RecordTypeA, 300, "1.2.3.4").WithLabel(endpoint.OwnerLabelKey, "owner").WithLabel(endpoint.ResourceLabelKey, "ingress/default/web"),
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, IsTTLOnlyUpdate(current, tc.desired))
+			changes := &Changes{UpdateOld: []*endpoint.Endpoint{current}, UpdateNew: []*endpoint.Endpoint{tc.desired}}
+			assert.Equal(t, tc.expected, changes.TTLOnlyUpdates()[tc.desired.Key()])
+		})
+	}
+}
@@ -45,6 +45,7 @@ func (p *UpsertOnlyPolicy) Apply(changes *Changes) *Changes {
 		Create:    changes.Create,
 		UpdateOld: changes.UpdateOld,
 		UpdateNew: changes.UpdateNew,
+		Adopt:     changes.Adopt,
 	}
 }
 
@@ -55,5 +56,6 @@ type CreateOnlyPolicy struct{}
 func (p *CreateOnlyPolicy) Apply(changes *Changes) *Changes {
 	return &Changes{
 		Create: changes.Create,
+		Adopt:  changes.Adopt,
 	}
 }
@@ -34,6 +34,8 @@ func TestApply(t *testing.T) {
 	// another two simple entries
 	bar := []*endpoint.Endpoint{{DNSName: "bar", Targets: endpoint.Targets{"v1"}}}
 	baz := []*endpoint.Endpoint{{DNSName: "baz", Targets: endpoint.Targets{"v1"}}}
+	// an existing entry to adopt
+	qux := []*endpoint.Endpoint{{DNSName: "qux", Targets: endpoint.Targets{"v1"}}}
 
 	for _, tc := range []struct {
 		policy   Policy
@@ -43,20 +45,20 @@ func TestApply(t *testing.T) {
 		{
 			// SyncPolicy doesn't modify the set of changes.
 			&SyncPolicy{},
-			&Changes{Create: baz, UpdateOld: fooV1, UpdateNew: fooV2, Delete: bar},
-			&Changes{Create: baz, UpdateOld: fooV1, UpdateNew: fooV2, Delete: bar},
+			&Changes{Create: baz, UpdateOld: fooV1, UpdateNew: fooV2, Delete: bar, Adopt: qux},
+			&Changes{Create: baz, UpdateOld: fooV1, UpdateNew: fooV2, Delete: bar, Adopt: qux},
 		},
 		{
 			// UpsertOnlyPolicy clears the list of deletions.
 			&UpsertOnlyPolicy{},
-			&Changes{Create: baz, UpdateOld: fooV1, UpdateNew: fooV2, Delete: bar},
-			&Changes{Create: baz, UpdateOld: fooV1, UpdateNew: fooV2, Delete: empty},
+			&Changes{Create: baz, UpdateOld: fooV1, UpdateNew: fooV2, Delete: bar, Adopt: qux},
+			&Changes{Create: baz, UpdateOld: fooV1, UpdateNew: fooV2, Delete: empty, Adopt: qux},
 		},
 		{
 			// CreateOnlyPolicy clears the list of updates and deletions.
 			&CreateOnlyPolicy{},
-			&Changes{Create: baz, UpdateOld: fooV1, UpdateNew: fooV2, Delete: bar},
-			&Changes{Create: baz, UpdateOld: empty, UpdateNew: empty, Delete: empty},
+			&Changes{Create: baz, UpdateOld: fooV1, UpdateNew: fooV2, Delete: bar, Adopt: qux},
+			&Changes{Create: baz, UpdateOld: empty, UpdateNew: empty, Delete: empty, Adopt: qux},
 		},
 	} {
 		// apply policy
@@ -67,6 +69,7 @@ func TestApply(t *testing.T) {
 		validateEntries(t, changes.UpdateOld, tc.expected.UpdateOld)
 		validateEntries(t, changes.UpdateNew, tc.expected.UpdateNew)
 		validateEntries(t, changes.Delete, tc.expected.Delete)
+		validateEntries(t, changes.Adopt, tc.expected.Adopt)
 	}
 }
 
@@ -0,0 +1,68 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plan
+
+import (
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+// Sequence splits the changes into the batches to apply in turn. A CNAME record can't coexist
+// with the other records of its name, so when a record is replaced by a record of another type,
+// such as a CNAME by an A record, the deletion must be applied before the creation. The first
+// batch then holds the deletions conflicting with the creations, and the second one the other
+// changes. The changes are returned as a single batch when they have no such conflict, or when
+// the provider replaces the records atomically.
+func (c *Changes) Sequence(atomicReplace bool) []*Changes {
+	if atomicReplace || len(c.Create) == 0 || len(c.Delete) == 0 {
+		return []*Changes{c}
+	}
+
+	created := map[string][]string{}
+	for _, ep := range c.Create {
+		created[ep.DNSName] = append(created[ep.DNSName], ep.RecordType)
+	}
+	conflicts := func(ep *endpoint.Endpoint) bool {
+		for _, recordType := range created[ep.DNSName] {
+			if recordType != ep.RecordType && (recordType == endpoint.RecordTypeCNAME || ep.RecordType == endpoint.RecordTypeCNAME) {
+				return true
+			}
+		}
+		return false
+	}
+
+	first := &Changes{}
+	var deletes []*endpoint.Endpoint
+	for _, ep := range c.Delete {
+		if conflicts(ep) {
+			first.Delete = append(first.Delete, ep)
+		} else {
+			deletes = append(deletes, ep)
+		}
+	}
+	if len(first.Delete) == 0 {
+		return []*Changes{c}
+	}
+
+	second := &Changes{
+		Create:    c.Create,
+		UpdateOld: c.UpdateOld,
+		UpdateNew: c.UpdateNew,
+		Delete:    deletes,
+		Adopt:     c.Adopt,
+	}
+	return []*Changes{first, second}
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plan
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+func TestChangesSequence(t *testing.T) {
+	cname := endpoint.NewEndpoint("web.example.org", endpoint.RecordTypeCNAME, "lb.example.com")
+	a := endpoint.NewEndpoint("web.example.org", endpoint.RecordTypeA, "1.2.3.4")
+	aaaa := endpoint.NewEndpoint("api.example.org", endpoint.RecordTypeAAAA, "2001:db8::1")
+	txt := endpoint.NewEndpoint("api.example.org", endpoint.RecordTypeTXT, "text")
+	other := endpoint.NewEndpoint("old.example.org", endpoint.RecordTypeA, "5.6.7.8")
+	updateOld := endpoint.NewEndpoint("update.example.org", endpoint.RecordTypeA, "1.1.1.1")
+	updateNew := endpoint.NewEndpoint("update.example.org", endpoint.RecordTypeA, "2.2.2.2")
+
+	for _, tc := range []struct {
+		title         string
+		changes       *Changes
+		atomicReplace bool
+		expected      []*Changes
+	}{
+		{
+			title:    "no type change",
+			changes:  &Changes{Create: []*endpoint.Endpoint{aaaa, txt}, Delete: []*endpoint.Endpoint{other}},
+			expected: []*Changes{{Create: []*endpoint.Endpoint{aaaa, txt}, Delete: []*endpoint.Endpoint{other}}},
+		},
+		{
+			title: "CNAME replaced by A",
+			changes: &Changes{
+				Create:    []*endpoint.Endpoint{a, aaaa},
+				UpdateOld: []*endpoint.Endpoint{updateOld},
+				UpdateNew: []*endpoint.Endpoint{updateNew},
+				Delete:    []*endpoint.Endpoint{cname, other},
+			},
+			expected: []*Changes{
+				{Delete: []*endpoint.Endpoint{cname}},
+				{
+					Create:    []*endpoint.Endpoint{a, aaaa},
+					UpdateOld: []*endpoint.Endpoint{updateOld},
+					UpdateNew: []*endpoint.Endpoint{updateNew},
+					Delete:    []*endpoint.Endpoint{other},
+				},
+			},
+		},
+		{
+			title:    "A replaced by CNAME",
+			changes:  &Changes{Create: []*endpoint.Endpoint{cname}, Delete: []*endpoint.Endpoint{a}},
+			expected: []*Changes{{Delete: []*endpoint.Endpoint{a}}, {Create: []*endpoint.Endpoint{cname}}},
+		},
+		{
+			title:         "atomic replace",
+			changes:       &Changes{Create: []*endpoint.Endpoint{a}, Delete: []*endpoint.Endpoint{cname}},
+			atomicReplace: true,
+			expected:      []*Changes{{Create: []*endpoint.Endpoint{a}, Delete: []*endpoint.Endpoint{cname}}},
+		},
+	} {
+		t.Run(tc.title, func(t *testing.T) {
+			batches := tc.changes.Sequence(tc.atomicReplace)
+			require.Len(t, batches, len(tc.expected))
+			for i := range batches {
+				assert.ElementsMatch(t, tc.expected[i].Create, batches[i].Create)
+				assert.ElementsMatch(t, tc.expected[i].UpdateOld, batches[i].UpdateOld)
+				assert.ElementsMatch(t, tc.expected[i].UpdateNew, batches[i].UpdateNew)
+				assert.ElementsMatch(t, tc.expected[i].Delete, batches[i].Delete)
+			}
+		})
+	}
+}
@@ -18,17 +18,20 @@ package akamai
 
 import (
 	"context"
-	"errors"
 	"fmt"
+	"net/http"
+	"net/url"
 	"os"
 	"strconv"
 	"strings"
 
+	client "github.com/akamai/AkamaiOPEN-edgegrid-golang/client-v1"
 	dns "github.com/akamai/AkamaiOPEN-edgegrid-golang/configdns-v2"
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/edgegrid"
 	log "github.com/sirupsen/logrus"
 
 	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/pkg/egress"
 	"sigs.k8s.io/external-dns/plan"
 	"sigs.k8s.io/external-dns/provider"
 )
@@ -38,16 +41,30 @@ const (
 	defaultTTL = 600
 	maxUint    = ^uint(0)
 	maxInt     = int(maxUint >> 1)
+
+	// Operations of the recordset changes of a change list
+	changeOpAdd    = "ADD"
+	changeOpDelete = "DELETE"
+	changeOpEdit   = "EDIT"
 )
 
 // edgeDNSClient is a proxy interface of the Akamai edgegrid configdns-v2 package that can be stubbed for testing.
 type AkamaiDNSService interface {
 	ListZones(queryArgs dns.ZoneListQueryArgs) (*dns.ZoneListResponse, error)
 	GetRecordsets(zone string, queryArgs dns.RecordsetQueryArgs) (*dns.RecordSetResponse, error)
-	GetRecord(zone string, name string, recordtype string) (*dns.RecordBody, error)
-	DeleteRecord(record *dns.RecordBody, zone string, recLock bool) error
-	UpdateRecord(record *dns.RecordBody, zone string, recLock bool) error
-	CreateRecordsets(recordsets *dns.Recordsets, zone string, recLock bool) error
+	CreateChangeList(zone string) error
+	AddChangeListRecordset(zone string, change RecordsetChange) error
+	SubmitChangeList(zone string) error
+	DeleteChangeList(zone string) error
+}
+
+// RecordsetChange is a recordset addition, edition or deletion of an Edge DNS change list.
+type RecordsetChange struct {
+	Name  string   `json:"name"`
+	Type  string   `json:"type"`
+	Op    string   `json:"op"`
+	TTL   int      `json:"ttl,omitempty"`
+	Rdata []string `json:"rdata,omitempty"`
 }
 
 type AkamaiConfig struct {
@@ -62,6 +79,7 @@ type AkamaiConfig struct {
 	MaxBody               int
 	AccountKey            string
 	DryRun                bool
+	Egress                *egress.Config
 }
 
 // AkamaiProvider implements the DNS provider for Akamai.
@@ -149,6 +167,10 @@ func NewAkamaiProvider(akamaiConfig AkamaiConfig, akaService AkamaiDNSService) (
 
 	// Init library for direct endpoint calls
 	dns.Init(edgeGridConfig)
+	// the client of the library is global, it uses the default one otherwise
+	if akamaiConfig.Egress.Configured() {
+		client.Client = &http.Client{Transport: akamaiConfig.Egress.Transport()}
+	}
 
 	return provider, nil
 }
@@ -161,20 +183,41 @@ func (p AkamaiProvider) GetRecordsets(zone string, queryArgs dns.RecordsetQueryA
 	return dns.GetRecordsets(zone, queryArgs)
 }
 
-func (p AkamaiProvider) CreateRecordsets(recordsets *dns.Recordsets, zone string, reclock bool) error {
-	return recordsets.Save(zone, reclock)
+// CreateChangeList creates the change list of a zone from its active version.
+func (p AkamaiProvider) CreateChangeList(zone string) error {
+	return p.changeListRequest("POST", "/config-dns/v2/changelists?overwrite=false&zone="+url.QueryEscape(zone), nil)
 }
 
-func (p AkamaiProvider) GetRecord(zone string, name string, recordtype string) (*dns.RecordBody, error) {
-	return dns.GetRecord(zone, name, recordtype)
+// AddChangeListRecordset adds a recordset change to the change list of a zone.
+func (p AkamaiProvider) AddChangeListRecordset(zone string, change RecordsetChange) error {
+	return p.changeListRequest("POST", "/config-dns/v2/changelists/"+zone+"/recordsets/add-change", change)
 }
 
-func (p AkamaiProvider) DeleteRecord(record *dns.RecordBody, zone string, recLock bool) error {
-	return record.Delete(zone, recLock)
+// SubmitChangeList activates the change list of a zone as a new version of the zone.
+func (p AkamaiProvider) SubmitChangeList(zone string) error {
+	return p.changeListRequest("POST", "/config-dns/v2/changelists/"+zone+"/submit", nil)
 }
 
-func (p AkamaiProvider) UpdateRecord(record *dns.RecordBody, zone string, recLock bool) error {
-	return record.Update(zone, recLock)
+// DeleteChangeList discards the change list of a zone.
+func (p AkamaiProvider) DeleteChangeList(zone string) error {
+	return p.changeListRequest("DELETE", "/config-dns/v2/changelists/"+zone, nil)
+}
+
+func (p AkamaiProvider) changeListRequest(method, path string, body interface{}) error {
+	req, err := client.NewJSONRequest(*p.config, method, path, body)
+	if err != nil {
+		return err
+	}
+	res, err := client.Do(*p.config, req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if client.IsError(res) {
+		return client.NewAPIError(res)
+	}
+
+	return nil
 }
 
 // Fetch zones using Edgegrid DNS v2 API
@@ -267,21 +310,16 @@ func (p AkamaiProvider) ApplyChanges(ctx context.Context, changes *plan.Changes)
 	}
 	log.Debugf("Processing zones: [%v]", zoneNameIDMapper)
 
-	// Create recordsets
 	log.Debugf("Create Changes requested [%v]", changes.Create)
-	if err := p.createRecordsets(zoneNameIDMapper, changes.Create); err != nil {
-		return err
-	}
-	// Delete recordsets
 	log.Debugf("Delete Changes requested [%v]", changes.Delete)
-	if err := p.deleteRecordsets(zoneNameIDMapper, changes.Delete); err != nil {
-		return err
-	}
-	// Update recordsets
 	log.Debugf("Update Changes requested [%v]", changes.UpdateNew)
-	if err := p.updateNewRecordsets(zoneNameIDMapper, changes.UpdateNew); err != nil {
-		return err
+	changesByZone := edgeChangesByZone(zoneNameIDMapper, changes)
+	for zone, zoneChanges := range changesByZone {
+		if err := p.applyChangeList(zone, zoneChanges); err != nil {
+			return err
+		}
 	}
+
 	// Check that all old endpoints were accounted for
 	revRecs := changes.Delete
 	revRecs = append(revRecs, changes.UpdateNew...)
@@ -361,125 +399,85 @@ func ttlAsInt(src endpoint.TTL) int {
 	return ttl
 }
 
-// Create Endpoint Recordsets
-func (p AkamaiProvider) createRecordsets(zoneNameIDMapper provider.ZoneIDName, endpoints []*endpoint.Endpoint) error {
-	if len(endpoints) == 0 {
-		log.Info("No endpoints to create")
+// applyChangeList submits the changes of a zone as a single change list, so that they're activated
+// atomically. The change list is discarded when it can't be completed or activated, leaving the
+// active version of the zone untouched.
+func (p AkamaiProvider) applyChangeList(zone string, changes []RecordsetChange) error {
+	if len(changes) == 0 {
 		return nil
 	}
+	for _, change := range changes {
+		log.WithFields(log.Fields{
+			"record": change.Name,
+			"type":   change.Type,
+			"ttl":    change.TTL,
+			"target": fmt.Sprintf("%v", change.Rdata),
+			"zone":   zone,
+		}).Infof("Akamai Edge DNS recordset change %s", change.Op)
+	}
 
-	endpointsByZone := edgeChangesByZone(zoneNameIDMapper, endpoints)
-
-	// create all recordsets by zone
-	for zone, endpoints := range endpointsByZone {
-		recordsets := &dns.Recordsets{Recordsets: make([]dns.Recordset, 0)}
-		for _, endpoint := range endpoints {
-			newrec := newAkamaiRecordset(endpoint.DNSName,
-				endpoint.RecordType,
-				ttlAsInt(endpoint.RecordTTL),
-				cleanTargets(endpoint.RecordType, endpoint.Targets...))
-			logfields := log.Fields{
-				"record": newrec.Name,
-				"type":   newrec.Type,
-				"ttl":    newrec.TTL,
-				"target": fmt.Sprintf("%v", newrec.Rdata),
-				"zone":   zone,
-			}
-			log.WithFields(logfields).Info("Creating recordsets")
-			recordsets.Recordsets = append(recordsets.Recordsets, newrec)
-		}
+	if p.dryRun {
+		return nil
+	}
 
-		if p.dryRun {
-			continue
-		}
-		// Create recordsets all at once
-		err := p.client.CreateRecordsets(recordsets, zone, true)
-		if err != nil {
-			log.Errorf("Failed to create endpoints for DNS zone %s. Error: %s", zone, err.Error())
+	if err := p.client.CreateChangeList(zone); err != nil {
+		log.Errorf("Failed to create the change list of DNS zone %s. Error: %s", zone, err.Error())
+		return err
+	}
+	for _, change := range changes {
+		if err := p.client.AddChangeListRecordset(zone, change); err != nil {
+			log.Errorf("Failed to add the %s of %s %s to the change list of DNS zone %s. Error: %s", change.Op, change.Type, change.Name, zone, err.Error())
+			p.discardChangeList(zone)
 			return err
 		}
 	}
+	if err := p.client.SubmitChangeList(zone); err != nil {
+		log.Errorf("Failed to activate the change list of DNS zone %s, rolling back. Error: %s", zone, err.Error())
+		p.discardChangeList(zone)
+		return err
+	}
+	log.Infof("Activated %d recordset changes in DNS zone %s", len(changes), zone)
 
 	return nil
 }
 
-func (p AkamaiProvider) deleteRecordsets(zoneNameIDMapper provider.ZoneIDName, endpoints []*endpoint.Endpoint) error {
-	for _, endpoint := range endpoints {
-		zoneName, _ := zoneNameIDMapper.FindZone(endpoint.DNSName)
-		if zoneName == "" {
-			log.Debugf("Skipping Akamai Edge DNS endpoint deletion: '%s' type: '%s', it does not match against Domain filters", endpoint.DNSName, endpoint.RecordType)
-			continue
-		}
-		log.Infof("Akamai Edge DNS recordset deletion- Zone: '%s', DNSName: '%s', RecordType: '%s', Targets: '%+v'", zoneName, endpoint.DNSName, endpoint.RecordType, endpoint.Targets)
-
-		if p.dryRun {
-			continue
-		}
-
-		recName := strings.TrimSuffix(endpoint.DNSName, ".")
-		rec, err := p.client.GetRecord(zoneName, recName, endpoint.RecordType)
-		if err != nil {
-			recordError := &dns.RecordError{}
-			if errors.As(err, &recordError) {
-				return fmt.Errorf("endpoint deletion. record validation failed. error: %w", err)
-			}
-			log.Infof("Endpoint deletion. Record doesn't exist. Name: %s, Type: %s", recName, endpoint.RecordType)
-			continue
-		}
-		if err := p.client.DeleteRecord(rec, zoneName, true); err != nil {
-			log.Errorf("edge dns recordset deletion failed. error: %s", err.Error())
-			return err
-		}
+func (p AkamaiProvider) discardChangeList(zone string) {
+	if err := p.client.DeleteChangeList(zone); err != nil {
+		log.Errorf("Failed to discard the change list of DNS zone %s. Error: %s", zone, err.Error())
 	}
-
-	return nil
 }
 
-// Update endpoint recordsets
-func (p AkamaiProvider) updateNewRecordsets(zoneNameIDMapper provider.ZoneIDName, endpoints []*endpoint.Endpoint) error {
-	for _, endpoint := range endpoints {
-		zoneName, _ := zoneNameIDMapper.FindZone(endpoint.DNSName)
-		if zoneName == "" {
-			log.Debugf("Skipping Akamai Edge DNS endpoint update: '%s' type: '%s', it does not match against Domain filters", endpoint.DNSName, endpoint.RecordType)
-			continue
-		}
-		log.Infof("Akamai Edge DNS recordset update - Zone: '%s', DNSName: '%s', RecordType: '%s', Targets: '%+v'", zoneName, endpoint.DNSName, endpoint.RecordType, endpoint.Targets)
-
-		if p.dryRun {
-			continue
-		}
-
-		recName := strings.TrimSuffix(endpoint.DNSName, ".")
-		rec, err := p.client.GetRecord(zoneName, recName, endpoint.RecordType)
-		if err != nil {
-			log.Errorf("Endpoint update. Record validation failed. Error: %s", err.Error())
-			return err
-		}
-		rec.TTL = ttlAsInt(endpoint.RecordTTL)
-		rec.Target = cleanTargets(endpoint.RecordType, endpoint.Targets...)
-		if err := p.client.UpdateRecord(rec, zoneName, true); err != nil {
-			log.Errorf("Akamai Edge DNS recordset update failed. Error: %s", err.Error())
-			return err
-		}
+func newRecordsetChange(op string, ep *endpoint.Endpoint) RecordsetChange {
+	change := RecordsetChange{
+		Name: strings.TrimSuffix(ep.DNSName, "."),
+		Type: ep.RecordType,
+		Op:   op,
+	}
+	if op != changeOpDelete {
+		change.TTL = ttlAsInt(ep.RecordTTL)
+		change.Rdata = cleanTargets(ep.RecordType, ep.Targets...)
 	}
 
-	return nil
+	return change
 }
 
-// edgeChangesByZone separates a multi-zone change into a single change per zone.
-func edgeChangesByZone(zoneMap provider.ZoneIDName, endpoints []*endpoint.Endpoint) map[string][]*endpoint.Endpoint {
-	createsByZone := make(map[string][]*endpoint.Endpoint, len(zoneMap))
-	for _, z := range zoneMap {
-		createsByZone[z] = make([]*endpoint.Endpoint, 0)
-	}
-	for _, ep := range endpoints {
-		zone, _ := zoneMap.FindZone(ep.DNSName)
-		if zone != "" {
-			createsByZone[zone] = append(createsByZone[zone], ep)
-			continue
+// edgeChangesByZone separates a multi-zone change into the recordset changes of each zone,
+// deletions first so that a recordset can be replaced within the same change list.
+func edgeChangesByZone(zoneMap provider.ZoneIDName, changes *plan.Changes) map[string][]RecordsetChange {
+	changesByZone := make(map[string][]RecordsetChange, len(zoneMap))
+	add := func(op string, endpoints []*endpoint.Endpoint) {
+		for _, ep := range endpoints {
+			zone, _ := zoneMap.FindZoneForEndpoint(ep)
+			if zone == "" {
+				log.Debugf("Skipping Akamai Edge DNS %s of endpoint: '%s' type: '%s', it does not match against Domain filters", op, ep.DNSName, ep.RecordType)
+				continue
+			}
+			changesByZone[zone] = append(changesByZone[zone], newRecordsetChange(op, ep))
 		}
-		log.Debugf("Skipping Akamai Edge DNS creation of endpoint: '%s' type: '%s', it does not match against Domain filters", ep.DNSName, ep.RecordType)
 	}
+	add(changeOpDelete, changes.Delete)
+	add(changeOpEdit, changes.UpdateNew)
+	add(changeOpAdd, changes.Create)
 
-	return createsByZone
+	return changesByZone
 }
@@ -19,6 +19,7 @@ package akamai
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"testing"
 
 	log "github.com/sirupsen/logrus"
@@ -41,11 +42,18 @@ type edgednsStubData struct {
 
 type edgednsStub struct {
 	stubData map[string]edgednsStubData
+	// changes added to the change list of each zone
+	changes map[string][]RecordsetChange
+	// change list operations, in call order
+	calls     []string
+	addErr    error
+	submitErr error
 }
 
 func newStub() *edgednsStub {
 	return &edgednsStub{
 		stubData: make(map[string]edgednsStubData),
+		changes:  make(map[string][]RecordsetChange),
 	}
 }
 
@@ -132,21 +140,27 @@ func (r *edgednsStub) GetRecordsets(zone string, queryArgs dns.RecordsetQueryArg
 	return resp, nil
 }
 
-func (r *edgednsStub) CreateRecordsets(recordsets *dns.Recordsets, zone string, reclock bool) error {
+func (r *edgednsStub) CreateChangeList(zone string) error {
+	r.calls = append(r.calls, "create "+zone)
 	return nil
 }
 
-func (r *edgednsStub) GetRecord(zone string, name string, record_type string) (*dns.RecordBody, error) {
-	resp := &dns.RecordBody{}
-
-	return resp, nil
+func (r *edgednsStub) AddChangeListRecordset(zone string, change RecordsetChange) error {
+	r.calls = append(r.calls, "add "+zone)
+	if r.addErr != nil {
+		return r.addErr
+	}
+	r.changes[zone] = append(r.changes[zone], change)
+	return nil
 }
 
-func (r *edgednsStub) DeleteRecord(record *dns.RecordBody, zone string, recLock bool) error {
-	return nil
+func (r *edgednsStub) SubmitChangeList(zone string) error {
+	r.calls = append(r.calls, "submit "+zone)
+	return r.submitErr
 }
 
-func (r *edgednsStub) UpdateRecord(record *dns.RecordBody, zone string, recLock bool) error {
+func (r *edgednsStub) DeleteChangeList(zone string) error {
+	r.calls = append(r.calls, "delete "+zone)
 	return nil
 }
 
@@ -261,107 +275,91 @@ func TestAkamaiRecordsFilters(t *testing.T) {
 	}
 }
 
-// TestCreateRecords tests create function
-// (p AkamaiProvider) createRecordsets(zoneNameIDMapper provider.ZoneIDName, endpoints []*endpoint.Endpoint) error
-func TestCreateRecords(t *testing.T) {
-	stub := newStub()
-	domfilter := &endpoint.DomainFilter{}
-	idfilter := provider.ZoneIDFilter{}
-	c, err := createAkamaiStubProvider(stub, domfilter, idfilter)
-	assert.NoError(t, err)
-
-	zoneNameIDMapper := provider.ZoneIDName{"example.com": "example.com"}
-	endpoints := make([]*endpoint.Endpoint, 0)
-	endpoints = append(endpoints, endpoint.NewEndpoint("www.example.com", endpoint.RecordTypeA, "10.0.0.2", "10.0.0.3"))
-	endpoints = append(endpoints, endpoint.NewEndpoint("www.example.com", endpoint.RecordTypeTXT, "heritage=external-dns,external-dns/owner=default"))
+func TestEdgeChangesByZone(t *testing.T) {
+	zoneNameIDMapper := provider.ZoneIDName{"example.com": "example.com", "other.com": "other.com"}
+	changes := &plan.Changes{
+		Create: []*endpoint.Endpoint{
+			endpoint.NewEndpointWithTTL("www.example.com", endpoint.RecordTypeA, 300, "10.0.0.2", "10.0.0.3"),
+			endpoint.NewEndpoint("www.example.com", endpoint.RecordTypeTXT, "heritage=external-dns,external-dns/owner=default"),
+			endpoint.NewEndpoint("www.exclude.me", endpoint.RecordTypeA, "10.0.0.2"),
+		},
+		UpdateNew: []*endpoint.Endpoint{
+			endpoint.NewEndpointWithTTL("update.other.com", endpoint.RecordTypeCNAME, 300, "target.example.com."),
+		},
+		Delete: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("delete.example.com.", endpoint.RecordTypeA, "10.0.0.4"),
+		},
+	}
 
-	err = c.createRecordsets(zoneNameIDMapper, endpoints)
-	assert.NoError(t, err)
+	assert.Equal(t, map[string][]RecordsetChange{
+		"example.com": {
+			{Name: "delete.example.com", Type: endpoint.RecordTypeA, Op: changeOpDelete},
+			{Name: "www.example.com", Type: endpoint.RecordTypeA, Op: changeOpAdd, TTL: 300, Rdata: []string{"10.0.0.2", "10.0.0.3"}},
+			{Name: "www.example.com", Type: endpoint.RecordTypeTXT, Op: changeOpAdd, TTL: defaultTTL, Rdata: []string{"\"heritage=external-dns,external-dns/owner=default\""}},
+		},
+		"other.com": {
+			{Name: "update.other.com", Type: endpoint.RecordTypeCNAME, Op: changeOpEdit, TTL: 300, Rdata: []string{"target.example.com"}},
+		},
+	}, edgeChangesByZone(zoneNameIDMapper, changes))
 }
 
-func TestCreateRecordsDomainFilter(t *testing.T) {
+func TestApplyChangeList(t *testing.T) {
 	stub := newStub()
-	domfilter := &endpoint.DomainFilter{}
-	idfilter := provider.ZoneIDFilter{}
-	c, err := createAkamaiStubProvider(stub, domfilter, idfilter)
-	assert.NoError(t, err)
-
-	zoneNameIDMapper := provider.ZoneIDName{"example.com": "example.com"}
-	endpoints := make([]*endpoint.Endpoint, 0)
-	endpoints = append(endpoints, endpoint.NewEndpoint("www.example.com", endpoint.RecordTypeA, "10.0.0.2", "10.0.0.3"))
-	endpoints = append(endpoints, endpoint.NewEndpoint("www.example.com", endpoint.RecordTypeTXT, "heritage=external-dns,external-dns/owner=default"))
-	exclude := append(endpoints, endpoint.NewEndpoint("www.exclude.me", endpoint.RecordTypeA, "10.0.0.2", "10.0.0.3"))
+	c, err := createAkamaiStubProvider(stub, &endpoint.DomainFilter{}, provider.ZoneIDFilter{})
+	require.NoError(t, err)
 
-	err = c.createRecordsets(zoneNameIDMapper, exclude)
-	assert.NoError(t, err)
+	changes := []RecordsetChange{
+		{Name: "delete.example.com", Type: endpoint.RecordTypeA, Op: changeOpDelete},
+		{Name: "www.example.com", Type: endpoint.RecordTypeA, Op: changeOpAdd, TTL: 300, Rdata: []string{"10.0.0.2"}},
+	}
+	require.NoError(t, c.applyChangeList("example.com", changes))
+	assert.Equal(t, []string{"create example.com", "add example.com", "add example.com", "submit example.com"}, stub.calls)
+	assert.Equal(t, changes, stub.changes["example.com"])
 }
 
-// TestDeleteRecords validate delete
-func TestDeleteRecords(t *testing.T) {
+func TestApplyChangeListEmpty(t *testing.T) {
 	stub := newStub()
-	domfilter := &endpoint.DomainFilter{}
-	idfilter := provider.ZoneIDFilter{}
-	c, err := createAkamaiStubProvider(stub, domfilter, idfilter)
-	assert.NoError(t, err)
-
-	zoneNameIDMapper := provider.ZoneIDName{"example.com": "example.com"}
-	endpoints := make([]*endpoint.Endpoint, 0)
-	endpoints = append(endpoints, endpoint.NewEndpoint("www.example.com", endpoint.RecordTypeA, "10.0.0.2", "10.0.0.3"))
-	endpoints = append(endpoints, endpoint.NewEndpoint("www.example.com", endpoint.RecordTypeTXT, "heritage=external-dns,external-dns/owner=default"))
+	c, err := createAkamaiStubProvider(stub, &endpoint.DomainFilter{}, provider.ZoneIDFilter{})
+	require.NoError(t, err)
 
-	err = c.deleteRecordsets(zoneNameIDMapper, endpoints)
-	assert.NoError(t, err)
+	require.NoError(t, c.applyChangeList("example.com", nil))
+	assert.Empty(t, stub.calls)
 }
 
-func TestDeleteRecordsDomainFilter(t *testing.T) {
+func TestApplyChangeListDryRun(t *testing.T) {
 	stub := newStub()
-	domfilter := endpoint.NewDomainFilter([]string{"example.com"})
-	idfilter := provider.ZoneIDFilter{}
-	c, err := createAkamaiStubProvider(stub, domfilter, idfilter)
+	c, err := createAkamaiStubProvider(stub, &endpoint.DomainFilter{}, provider.ZoneIDFilter{})
 	require.NoError(t, err)
+	c.dryRun = true
 
-	zoneNameIDMapper := provider.ZoneIDName{"example.com": "example.com"}
-	endpoints := make([]*endpoint.Endpoint, 0)
-	endpoints = append(endpoints, endpoint.NewEndpoint("www.example.com", endpoint.RecordTypeA, "10.0.0.2", "10.0.0.3"))
-	endpoints = append(endpoints, endpoint.NewEndpoint("www.example.com", endpoint.RecordTypeTXT, "heritage=external-dns,external-dns/owner=default"))
-	exclude := append(endpoints, endpoint.NewEndpoint("www.exclude.me", endpoint.RecordTypeA, "10.0.0.2", "10.0.0.3"))
-
-	err = c.deleteRecordsets(zoneNameIDMapper, exclude)
-	assert.NoError(t, err)
+	changes := []RecordsetChange{{Name: "www.example.com", Type: endpoint.RecordTypeA, Op: changeOpAdd, TTL: 300, Rdata: []string{"10.0.0.2"}}}
+	require.NoError(t, c.applyChangeList("example.com", changes))
+	assert.Empty(t, stub.calls)
 }
 
-// Test record update func
-func TestUpdateRecords(t *testing.T) {
+func TestApplyChangeListAddFailure(t *testing.T) {
 	stub := newStub()
-	domfilter := &endpoint.DomainFilter{}
-	idfilter := provider.ZoneIDFilter{}
-	c, err := createAkamaiStubProvider(stub, domfilter, idfilter)
+	stub.addErr = errors.New("invalid recordset")
+	c, err := createAkamaiStubProvider(stub, &endpoint.DomainFilter{}, provider.ZoneIDFilter{})
 	require.NoError(t, err)
 
-	zoneNameIDMapper := provider.ZoneIDName{"example.com": "example.com"}
-	endpoints := make([]*endpoint.Endpoint, 0)
-	endpoints = append(endpoints, endpoint.NewEndpoint("www.example.com", endpoint.RecordTypeA, "10.0.0.2", "10.0.0.3"))
-	endpoints = append(endpoints, endpoint.NewEndpoint("www.example.com", endpoint.RecordTypeTXT, "heritage=external-dns,external-dns/owner=default"))
-
-	err = c.updateNewRecordsets(zoneNameIDMapper, endpoints)
-	require.NoError(t, err)
+	changes := []RecordsetChange{
+		{Name: "www.example.com", Type: endpoint.RecordTypeA, Op: changeOpAdd, TTL: 300, Rdata: []string{"10.0.0.2"}},
+		{Name: "api.example.com", Type: endpoint.RecordTypeA, Op: changeOpAdd, TTL: 300, Rdata: []string{"10.0.0.3"}},
+	}
+	assert.ErrorIs(t, c.applyChangeList("example.com", changes), stub.addErr)
+	assert.Equal(t, []string{"create example.com", "add example.com", "delete example.com"}, stub.calls)
 }
 
-func TestUpdateRecordsDomainFilter(t *testing.T) {
+func TestApplyChangeListActivationFailure(t *testing.T) {
 	stub := newStub()
-	domfilter := endpoint.NewDomainFilter([]string{"example.com"})
-	idfilter := provider.ZoneIDFilter{}
-	c, err := createAkamaiStubProvider(stub, domfilter, idfilter)
+	stub.submitErr = errors.New("activation failed")
+	c, err := createAkamaiStubProvider(stub, &endpoint.DomainFilter{}, provider.ZoneIDFilter{})
 	require.NoError(t, err)
 
-	zoneNameIDMapper := provider.ZoneIDName{"example.com": "example.com"}
-	endpoints := make([]*endpoint.Endpoint, 0)
-	endpoints = append(endpoints, endpoint.NewEndpoint("www.example.com", endpoint.RecordTypeA, "10.0.0.2", "10.0.0.3"))
-	endpoints = append(endpoints, endpoint.NewEndpoint("www.example.com", endpoint.RecordTypeTXT, "heritage=external-dns,external-dns/owner=default"))
-	exclude := append(endpoints, endpoint.NewEndpoint("www.exclude.me", endpoint.RecordTypeA, "10.0.0.2", "10.0.0.3"))
-
-	err = c.updateNewRecordsets(zoneNameIDMapper, exclude)
-	require.NoError(t, err)
+	changes := []RecordsetChange{{Name: "www.example.com", Type: endpoint.RecordTypeA, Op: changeOpAdd, TTL: 300, Rdata: []string{"10.0.0.2"}}}
+	assert.ErrorIs(t, c.applyChangeList("example.com", changes), stub.submitErr)
+	assert.Equal(t, []string{"create example.com", "add example.com", "submit example.com", "delete example.com"}, stub.calls)
 }
 
 func TestAkamaiApplyChanges(t *testing.T) {
@@ -387,4 +385,7 @@ func TestAkamaiApplyChanges(t *testing.T) {
 	changes.UpdateNew = []*endpoint.Endpoint{{DNSName: "update.example.com", Targets: endpoint.Targets{"target-new"}, RecordType: "CNAME", RecordTTL: 300}}
 	apply := c.ApplyChanges(context.Background(), changes)
 	assert.NoError(t, apply)
+	assert.Equal(t, []string{"create example.com", "add example.com", "add example.com", "add example.com", "add example.com", "add example.com", "add example.com", "add example.com", "add example.com", "add example.com", "submit example.com"}, stub.calls)
+	assert.Equal(t, changeOpDelete, stub.changes["example.com"][0].Op)
+	assert.Equal(t, changeOpEdit, stub.changes["example.com"][1].Op)
 }
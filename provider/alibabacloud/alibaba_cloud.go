@@ -19,6 +19,7 @@ package alibabacloud
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"os"
 	"sort"
 	"strings"
@@ -33,6 +34,7 @@ import (
 	log "github.com/sirupsen/logrus"
 
 	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/pkg/egress"
 	"sigs.k8s.io/external-dns/plan"
 	"sigs.k8s.io/external-dns/provider"
 )
@@ -82,6 +84,7 @@ type AlibabaCloudProvider struct {
 	privateZone          bool
 	clientLock           sync.RWMutex
 	nextExpire           time.Time
+	egressConfig         *egress.Config
 }
 
 type alibabaCloudConfig struct {
@@ -97,7 +100,7 @@ type alibabaCloudConfig struct {
 // NewAlibabaCloudProvider creates a new Alibaba Cloud provider.
 //
 // Returns the provider or an error if a provider could not be created.
-func NewAlibabaCloudProvider(configFile string, domainFilter *endpoint.DomainFilter, zoneIDFileter provider.ZoneIDFilter, zoneType string, dryRun bool) (*AlibabaCloudProvider, error) {
+func NewAlibabaCloudProvider(configFile string, domainFilter *endpoint.DomainFilter, zoneIDFileter provider.ZoneIDFilter, zoneType string, dryRun bool, egressConfig *egress.Config) (*AlibabaCloudProvider, error) {
 	cfg := alibabaCloudConfig{}
 	if configFile != "" {
 		contents, err := os.ReadFile(configFile)
@@ -117,7 +120,7 @@ func NewAlibabaCloudProvider(configFile string, domainFilter *endpoint.DomainFil
 	}
 
 	// Public DNS service
-	var dnsClient AlibabaCloudDNSAPI
+	var dnsClient *alidns.Client
 	var err error
 
 	if cfg.RoleName == "" {
@@ -138,9 +141,10 @@ func NewAlibabaCloudProvider(configFile string, domainFilter *endpoint.DomainFil
 	if err != nil {
 		return nil, fmt.Errorf("failed to create Alibaba Cloud DNS client: %w", err)
 	}
+	withEgress(dnsClient, egressConfig)
 
 	// Private DNS service
-	var pvtzClient AlibabaCloudPrivateZoneAPI
+	var pvtzClient *pvtz.Client
 	if cfg.RoleName == "" {
 		pvtzClient, err = pvtz.NewClientWithAccessKey(
 			"cn-hangzhou", // The Private Zone location is fixed
@@ -159,6 +163,7 @@ func NewAlibabaCloudProvider(configFile string, domainFilter *endpoint.DomainFil
 	if err != nil {
 		return nil, err
 	}
+	withEgress(pvtzClient, egressConfig)
 
 	provider := &AlibabaCloudProvider{
 		domainFilter: domainFilter,
@@ -168,6 +173,7 @@ func NewAlibabaCloudProvider(configFile string, domainFilter *endpoint.DomainFil
 		dnsClient:    dnsClient,
 		pvtzClient:   pvtzClient,
 		privateZone:  zoneType == "private",
+		egressConfig: egressConfig,
 	}
 
 	if cfg.RoleName != "" {
@@ -177,6 +183,14 @@ func NewAlibabaCloudProvider(configFile string, domainFilter *endpoint.DomainFil
 	return provider, nil
 }
 
+// withEgress configures the transport of a client with the proxy and the CA bundle of the
+// providers, if any.
+func withEgress(client interface{ SetTransport(http.RoundTripper) }, egressConfig *egress.Config) {
+	if egressConfig.Configured() {
+		client.SetTransport(egressConfig.Transport())
+	}
+}
+
 func getCloudConfigFromStsToken() (alibabaCloudConfig, error) {
 	cfg := alibabaCloudConfig{}
 	// Load config from Metadata Service
@@ -272,6 +286,8 @@ func (p *AlibabaCloudProvider) refreshStsToken(sleepTime time.Duration) {
 			log.Errorf("Failed to new client with sts token %v", err)
 			continue
 		}
+		withEgress(dnsClient, p.egressConfig)
+		withEgress(pvtzClient, p.egressConfig)
 		log.Infof("Refresh client from sts token, next expire time %v", cfg.ExpireTime)
 		p.clientLock.Lock()
 		p.dnsClient = dnsClient
@@ -33,6 +33,7 @@ import (
 	log "github.com/sirupsen/logrus"
 
 	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/pkg/decisions"
 	"sigs.k8s.io/external-dns/plan"
 	"sigs.k8s.io/external-dns/provider"
 )
@@ -222,6 +223,10 @@ type Route53Change struct {
 	OwnedRecord string
 	sizeBytes   int
 	sizeValues  int
+	// zoneID is the hosted zone the change is pinned to, if any
+	zoneID string
+	// zoneSelection chooses between the public and private zones matching the change
+	zoneSelection provider.ZoneSelection
 }
 
 type Route53Changes []*Route53Change
@@ -288,10 +293,16 @@ type AWSProvider struct {
 	zoneTagFilter provider.ZoneTagFilter
 	// extend filter for subdomains in the zone (e.g. first.us-east-1.example.com)
 	zoneMatchParent bool
-	preferCNAME     bool
-	zonesCache      *zonesListCache
+	// choose between the public and private zones matching a record
+	zoneSelection provider.ZoneSelection
+	preferCNAME   bool
+	zonesCache    *zonesListCache
 	// queue for collecting changes to submit them in the next iteration, but after all other changes
 	failedChangesQueue map[string]Route53Changes
+	// filterDecisions records the changes of the records matching no hosted zone, nil not to record them
+	filterDecisions *decisions.Recorder
+	// missingZones remembers the names of the records matching no hosted zone, nil not to remember them
+	missingZones *provider.MissingZoneCache
 }
 
 // AWSConfig contains configuration to create a new AWS provider.
@@ -309,6 +320,9 @@ type AWSConfig struct {
 	PreferCNAME           bool
 	DryRun                bool
 	ZoneCacheDuration     time.Duration
+	ZoneSelection         provider.ZoneSelection
+	FilterDecisions       *decisions.Recorder
+	MissingZones          *provider.MissingZoneCache
 }
 
 // NewAWSProvider initializes a new AWS Route53 based Provider.
@@ -328,7 +342,10 @@ func NewAWSProvider(awsConfig AWSConfig, clients map[string]Route53API) (*AWSPro
 		preferCNAME:           awsConfig.PreferCNAME,
 		dryRun:                awsConfig.DryRun,
 		zonesCache:            &zonesListCache{duration: awsConfig.ZoneCacheDuration},
+		zoneSelection:         awsConfig.ZoneSelection,
 		failedChangesQueue:    make(map[string]Route53Changes),
+		filterDecisions:       awsConfig.FilterDecisions,
+		missingZones:          awsConfig.MissingZones,
 	}
 
 	return pr, nil
@@ -463,6 +480,54 @@ func (p *AWSProvider) Records(ctx context.Context) (endpoints []*endpoint.Endpoi
 	return p.records(ctx, zones)
 }
 
+// ZoneNames returns the names of the hosted zones, sorted.
+func (p *AWSProvider) ZoneNames(ctx context.Context) ([]string, error) {
+	zones, err := p.zones(ctx)
+	if err != nil {
+		return nil, provider.NewSoftErrorf("records retrieval failed: %w", err)
+	}
+	return provider.SortedZoneNames(zoneIDNames(zones)), nil
+}
+
+// StreamRecords calls fn with the records of the hosted zones of each name in turn, sorted by name.
+func (p *AWSProvider) StreamRecords(ctx context.Context, fn func(zone string, records []*endpoint.Endpoint) error) error {
+	zones, err := p.zones(ctx)
+	if err != nil {
+		return provider.NewSoftErrorf("records retrieval failed: %w", err)
+	}
+	return provider.StreamZoneRecords(zoneIDNames(zones), func(zoneIDs []string) ([]*endpoint.Endpoint, error) {
+		named := make(map[string]*profiledZone, len(zoneIDs))
+		for _, id := range zoneIDs {
+			named[id] = zones[id]
+		}
+		return p.records(ctx, named)
+	}, fn)
+}
+
+// ZoneRecords returns the records of the hosted zones containing the domain or its subdomains.
+func (p *AWSProvider) ZoneRecords(ctx context.Context, domain string) ([]*endpoint.Endpoint, error) {
+	zones, err := p.zones(ctx)
+	if err != nil {
+		return nil, provider.NewSoftErrorf("records retrieval failed: %w", err)
+	}
+	matching := make(map[string]*profiledZone)
+	for id, z := range zones {
+		if provider.ZoneOverlapsDomain(*z.zone.Name, domain) {
+			matching[id] = z
+		}
+	}
+	return p.records(ctx, matching)
+}
+
+// zoneIDNames returns the names of the hosted zones by ID.
+func zoneIDNames(zones map[string]*profiledZone) provider.ZoneIDName {
+	names := provider.ZoneIDName{}
+	for id, z := range zones {
+		names.Add(id, *z.zone.Name)
+	}
+	return names
+}
+
 func (p *AWSProvider) records(ctx context.Context, zones map[string]*profiledZone) ([]*endpoint.Endpoint, error) {
 	endpoints := make([]*endpoint.Endpoint, 0)
 
@@ -660,7 +725,7 @@ func (p *AWSProvider) submitChanges(ctx context.Context, changes Route53Changes,
 	}
 
 	// separate into per-zone change sets to be passed to the API.
-	changesByZone := changesByZone(zones, changes)
+	changesByZone := changesByZone(zones, changes, p.filterDecisions, p.missingZones)
 	if len(changesByZone) == 0 {
 		log.Info("All records are already up to date, there are no changes for the matching hosted zones")
 	}
@@ -935,6 +1000,8 @@ func (p *AWSProvider) newChange(action route53types.ChangeAction, ep *endpoint.E
 	if ownedRecord, ok := ep.Labels[endpoint.OwnedRecordLabelKey]; ok {
 		change.OwnedRecord = ownedRecord
 	}
+	change.zoneID = ep.Labels[endpoint.ZoneIDLabelKey]
+	change.zoneSelection = provider.ZoneSelectionFor(ep, p.zoneSelection)
 
 	return change
 }
@@ -1094,8 +1161,9 @@ func sortChangesByActionNameType(cs Route53Changes) Route53Changes {
 	return cs
 }
 
-// changesByZone separates a multi-zone change into a single change per zone.
-func changesByZone(zones map[string]*profiledZone, changeSet Route53Changes) map[string]Route53Changes {
+// changesByZone separates a multi-zone change into a single change per zone. The changes matching
+// no zone are recorded by filterDecisions, and their names reported to missingZones.
+func changesByZone(zones map[string]*profiledZone, changeSet Route53Changes, filterDecisions *decisions.Recorder, missingZones *provider.MissingZoneCache) map[string]Route53Changes {
 	changes := make(map[string]Route53Changes)
 
 	for _, z := range zones {
@@ -1105,9 +1173,17 @@ func changesByZone(zones map[string]*profiledZone, changeSet Route53Changes) map
 	for _, c := range changeSet {
 		hostname := provider.EnsureTrailingDot(*c.ResourceRecordSet.Name)
 
-		zones := suitableZones(hostname, zones)
+		zones := changeZones(c, hostname, zones)
 		if len(zones) == 0 {
-			log.Debugf("Skipping record %s because no hosted zone matching record DNS Name was detected", *c.ResourceRecordSet.Name)
+			if missingZones.Report(*c.ResourceRecordSet.Name) {
+				log.Debugf("Skipping record %s because no hosted zone matching record DNS Name was detected", *c.ResourceRecordSet.Name)
+			}
+			filterDecisions.Record(decisions.Decision{
+				Filter:     decisions.ZoneFilter,
+				DNSName:    provider.EnsureTrailingDot(*c.ResourceRecordSet.Name),
+				RecordType: string(c.ResourceRecordSet.Type),
+				Reason:     "no hosted zone matches the name, the zones may be excluded by the zone id, type or tag filters",
+			})
 			continue
 		}
 		for _, z := range zones {
@@ -1140,6 +1216,49 @@ func changesByZone(zones map[string]*profiledZone, changeSet Route53Changes) map
 	return changes
 }
 
+// changeZones returns the zone the change is pinned to, or the suitable zones for its name
+// chosen by its zone selection. A change pinned to an unknown zone, or to a zone which doesn't
+// contain its name, has no zone.
+func changeZones(c *Route53Change, hostname string, zones map[string]*profiledZone) []*profiledZone {
+	if c.zoneID == "" {
+		return selectZones(suitableZones(hostname, zones), c.zoneSelection)
+	}
+	for _, z := range zones {
+		if cleanZoneID(*z.zone.Id) != cleanZoneID(c.zoneID) {
+			continue
+		}
+		if *z.zone.Name == hostname || strings.HasSuffix(hostname, "."+*z.zone.Name) {
+			return []*profiledZone{z}
+		}
+		log.Warnf("Skipping record %s pinned to the hosted zone %s, which doesn't contain its name", hostname, c.zoneID)
+		return nil
+	}
+	log.Warnf("Skipping record %s pinned to the hosted zone %s, which is not managed", hostname, c.zoneID)
+	return nil
+}
+
+// selectZones returns the public or private zones among the suitable ones chosen by the zone
+// selection.
+func selectZones(suitable []*profiledZone, selection provider.ZoneSelection) []*profiledZone {
+	var public, private []*profiledZone
+	for _, z := range suitable {
+		if z.zone.Config == nil || !z.zone.Config.PrivateZone {
+			public = append(public, z)
+		} else {
+			private = append(private, z)
+		}
+	}
+	usePublic, usePrivate := selection.Select(len(public) > 0, len(private) > 0)
+	var selected []*profiledZone
+	if usePrivate {
+		selected = append(selected, private...)
+	}
+	if usePublic {
+		selected = append(selected, public...)
+	}
+	return selected
+}
+
 // suitableZones returns all suitable private zones and the most suitable public zone
 //
 //	for a given hostname and a set of zones.
@@ -1232,6 +1351,30 @@ func cleanZoneID(id string) string {
 	return strings.TrimPrefix(id, "/hostedzone/")
 }
 
+// Capabilities implements provider.CapabilitiesReporter.
+func (p *AWSProvider) Capabilities() provider.Capabilities {
+	return provider.Capabilities{
+		RecordTypes: []string{endpoint.RecordTypeA, endpoint.RecordTypeAAAA, endpoint.RecordTypeCNAME, endpoint.RecordTypeSRV, endpoint.RecordTypeTXT, endpoint.RecordTypeNS, endpoint.RecordTypeMX},
+		ApexAlias:   true,
+		Batch:       true,
+		SplitTXT:    true,
+		Properties: []provider.PropertySchema{
+			{Name: providerSpecificAlias, Type: provider.PropertyTypeBool},
+			{Name: providerSpecificTargetHostedZone, Type: provider.PropertyTypeString},
+			{Name: providerSpecificEvaluateTargetHealth, Type: provider.PropertyTypeBool},
+			{Name: providerSpecificWeight, Type: provider.PropertyTypeInt, Validate: provider.IntRange(0, 255)},
+			{Name: providerSpecificRegion, Type: provider.PropertyTypeString},
+			{Name: providerSpecificFailover, Type: provider.PropertyTypeString, Values: []string{string(route53types.ResourceRecordSetFailoverPrimary), string(route53types.ResourceRecordSetFailoverSecondary)}},
+			{Name: providerSpecificGeolocationContinentCode, Type: provider.PropertyTypeString},
+			{Name: providerSpecificGeolocationCountryCode, Type: provider.PropertyTypeString},
+			{Name: providerSpecificGeolocationSubdivisionCode, Type: provider.PropertyTypeString},
+			{Name: providerSpecificMultiValueAnswer, Type: provider.PropertyTypeString},
+			{Name: providerSpecificHealthCheckID, Type: provider.PropertyTypeString},
+		},
+		PropertyPrefixes: []string{"aws/"},
+	}
+}
+
 func (p *AWSProvider) SupportedRecordType(recordType route53types.RRType) bool {
 	switch recordType {
 	case route53types.RRTypeMx:
@@ -642,6 +642,45 @@ func TestAWSRecords(t *testing.T) {
 	})
 }
 
+func TestAWSStreamRecords(t *testing.T) {
+	p, _ := newAWSProvider(t, endpoint.NewDomainFilter([]string{"ext-dns-test-2.teapot.zalan.do."}), provider.NewZoneIDFilter([]string{}), provider.NewZoneTypeFilter(""), false, false, []route53types.ResourceRecordSet{
+		{
+			Name:            aws.String("list-test.zone-1.ext-dns-test-2.teapot.zalan.do."),
+			Type:            route53types.RRTypeA,
+			TTL:             aws.Int64(defaultTTL),
+			ResourceRecords: []route53types.ResourceRecord{{Value: aws.String("1.2.3.4")}},
+		},
+		{
+			Name:            aws.String("list-test.zone-2.ext-dns-test-2.teapot.zalan.do."),
+			Type:            route53types.RRTypeA,
+			TTL:             aws.Int64(defaultTTL),
+			ResourceRecords: []route53types.ResourceRecord{{Value: aws.String("8.8.8.8")}},
+		},
+	})
+	ctx := context.Background()
+
+	names, err := p.ZoneNames(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"zone-1.ext-dns-test-2.teapot.zalan.do", "zone-2.ext-dns-test-2.teapot.zalan.do", "zone-3.ext-dns-test-2.teapot.zalan.do"}, names)
+
+	streamed := map[string][]*endpoint.Endpoint{}
+	require.NoError(t, p.StreamRecords(ctx, func(zone string, records []*endpoint.Endpoint) error {
+		streamed[zone] = records
+		return nil
+	}))
+	assert.Len(t, streamed, 3)
+	assert.True(t, testutils.SameEndpoints(streamed["zone-1.ext-dns-test-2.teapot.zalan.do"], []*endpoint.Endpoint{
+		endpoint.NewEndpointWithTTL("list-test.zone-1.ext-dns-test-2.teapot.zalan.do", endpoint.RecordTypeA, endpoint.TTL(defaultTTL), "1.2.3.4"),
+	}))
+	assert.True(t, testutils.SameEndpoints(streamed["zone-2.ext-dns-test-2.teapot.zalan.do"], []*endpoint.Endpoint{
+		endpoint.NewEndpointWithTTL("list-test.zone-2.ext-dns-test-2.teapot.zalan.do", endpoint.RecordTypeA, endpoint.TTL(defaultTTL), "8.8.8.8"),
+	}))
+
+	records, err := p.ZoneRecords(ctx, "foo.zone-2.ext-dns-test-2.teapot.zalan.do")
+	require.NoError(t, err)
+	assert.True(t, testutils.SameEndpoints(records, streamed["zone-2.ext-dns-test-2.teapot.zalan.do"]))
+}
+
 func TestAWSRecordsSoftError(t *testing.T) {
 	pvd, subClient := newAWSProvider(t, endpoint.NewDomainFilter([]string{"ext-dns-test-2.teapot.zalan.do."}), provider.NewZoneIDFilter([]string{}), provider.NewZoneTypeFilter(""), false, false, []route53types.ResourceRecordSet{
 		{
@@ -1393,7 +1432,7 @@ func TestAWSChangesByZones(t *testing.T) {
 		},
 	}
 
-	changesByZone := changesByZone(zones, changes)
+	changesByZone := changesByZone(zones, changes, nil, nil)
 	require.Len(t, changesByZone, 3)
 
 	validateAWSChangeRecords(t, changesByZone["foo-example-org"], Route53Changes{
@@ -2153,6 +2192,73 @@ func TestAWSSuitableZones(t *testing.T) {
 	}
 }
 
+func TestAWSChangeZonesPinned(t *testing.T) {
+	zones := map[string]*profiledZone{
+		"example-org":         {profile: defaultAWSProfile, zone: &route53types.HostedZone{Id: aws.String("/hostedzone/example-org"), Name: aws.String("example.org.")}},
+		"bar-example-org":     {profile: defaultAWSProfile, zone: &route53types.HostedZone{Id: aws.String("/hostedzone/bar-example-org"), Name: aws.String("bar.example.org.")}},
+		"example-org-private": {profile: defaultAWSProfile, zone: &route53types.HostedZone{Id: aws.String("/hostedzone/example-org-private"), Name: aws.String("example.org."), Config: &route53types.HostedZoneConfig{PrivateZone: true}}},
+	}
+
+	for _, tc := range []struct {
+		zoneID   string
+		hostname string
+		expected []*profiledZone
+	}{
+		// the pinned zone overrides the most suitable public zone and the private zones
+		{"example-org", "foo.bar.example.org.", []*profiledZone{zones["example-org"]}},
+		{"/hostedzone/example-org-private", "foo.bar.example.org.", []*profiledZone{zones["example-org-private"]}},
+		{"bar-example-org", "foo.example.org.", nil},
+		{"unknown", "foo.example.org.", nil},
+	} {
+		c := &Route53Change{zoneID: tc.zoneID}
+		assert.Equal(t, tc.expected, changeZones(c, tc.hostname, zones), tc.zoneID)
+	}
+
+	c := &Route53Change{}
+	assert.Len(t, changeZones(c, "foo.bar.example.org.", zones), 2)
+}
+
+func TestAWSChangeZonesSelection(t *testing.T) {
+	zones := map[string]*profiledZone{
+		"example-org":         {profile: defaultAWSProfile, zone: &route53types.HostedZone{Id: aws.String("/hostedzone/example-org"), Name: aws.String("example.org.")}},
+		"example-org-private": {profile: defaultAWSProfile, zone: &route53types.HostedZone{Id: aws.String("/hostedzone/example-org-private"), Name: aws.String("example.org."), Config: &route53types.HostedZoneConfig{PrivateZone: true}}},
+		"internal-private":    {profile: defaultAWSProfile, zone: &route53types.HostedZone{Id: aws.String("/hostedzone/internal-private"), Name: aws.String("internal.example.org."), Config: &route53types.HostedZoneConfig{PrivateZone: true}}},
+		"example-com":         {profile: defaultAWSProfile, zone: &route53types.HostedZone{Id: aws.String("/hostedzone/example-com"), Name: aws.String("example.com.")}},
+		"example-net-private": {profile: defaultAWSProfile, zone: &route53types.HostedZone{Id: aws.String("/hostedzone/example-net-private"), Name: aws.String("example.net."), Config: &route53types.HostedZoneConfig{PrivateZone: true}}},
+	}
+
+	for _, tc := range []struct {
+		selection provider.ZoneSelection
+		hostname  string
+		expected  []string
+	}{
+		{provider.ZoneSelectionBoth, "foo.example.org.", []string{"example-org", "example-org-private"}},
+		{provider.ZoneSelectionPreferPrivate, "foo.example.org.", []string{"example-org-private"}},
+		{provider.ZoneSelectionPreferPublic, "foo.example.org.", []string{"example-org"}},
+		// the preferred kind of zones doesn't match
+		{provider.ZoneSelectionPreferPrivate, "foo.example.com.", []string{"example-com"}},
+		{provider.ZoneSelectionPreferPublic, "foo.example.net.", []string{"example-net-private"}},
+		{provider.ZoneSelectionPreferPrivate, "foo.internal.example.org.", []string{"example-org-private", "internal-private"}},
+	} {
+		var got []string
+		for _, z := range changeZones(&Route53Change{zoneSelection: tc.selection}, tc.hostname, zones) {
+			got = append(got, cleanZoneID(*z.zone.Id))
+		}
+		assert.ElementsMatch(t, tc.expected, got, "%s %s", tc.selection, tc.hostname)
+	}
+}
+
+func TestAWSNewChangeZoneSelection(t *testing.T) {
+	p, _ := newAWSProviderWithTagFilter(t, endpoint.NewDomainFilter([]string{}), provider.NewZoneIDFilter([]string{}), provider.NewZoneTypeFilter(""), provider.NewZoneTagFilter([]string{}), defaultEvaluateTargetHealth, false, nil)
+	p.zoneSelection = provider.ZoneSelectionPreferPrivate
+
+	ep := endpoint.NewEndpoint("foo.example.org", endpoint.RecordTypeA, "1.2.3.4")
+	assert.Equal(t, provider.ZoneSelectionPreferPrivate, p.newChange(route53types.ChangeActionCreate, ep).zoneSelection)
+
+	ep.WithLabel(endpoint.ZoneSelectionLabelKey, string(provider.ZoneSelectionPreferPublic))
+	assert.Equal(t, provider.ZoneSelectionPreferPublic, p.newChange(route53types.ChangeActionCreate, ep).zoneSelection)
+}
+
 func createAWSZone(t *testing.T, provider *AWSProvider, zone *route53types.HostedZone) {
 	params := &route53.CreateHostedZoneInput{
 		CallerReference:  aws.String("external-dns.alpha.kubernetes.io/test-zone"),
@@ -31,6 +31,7 @@ import (
 	"github.com/sirupsen/logrus"
 
 	"sigs.k8s.io/external-dns/pkg/apis/externaldns"
+	"sigs.k8s.io/external-dns/pkg/egress"
 )
 
 // AWSSessionConfig contains configuration to create a new AWS provider.
@@ -39,14 +40,16 @@ type AWSSessionConfig struct {
 	AssumeRoleExternalID string
 	APIRetries           int
 	Profile              string
+	Egress               *egress.Config
 }
 
-func CreateDefaultV2Config(cfg *externaldns.Config) awsv2.Config {
+func CreateDefaultV2Config(cfg *externaldns.Config, egressConfig *egress.Config) awsv2.Config {
 	result, err := newV2Config(
 		AWSSessionConfig{
 			AssumeRole:           cfg.AWSAssumeRole,
 			AssumeRoleExternalID: cfg.AWSAssumeRoleExternalID,
 			APIRetries:           cfg.AWSAPIRetries,
+			Egress:               egressConfig,
 		},
 	)
 	if err != nil {
@@ -55,10 +58,10 @@ func CreateDefaultV2Config(cfg *externaldns.Config) awsv2.Config {
 	return result
 }
 
-func CreateV2Configs(cfg *externaldns.Config) map[string]awsv2.Config {
+func CreateV2Configs(cfg *externaldns.Config, egressConfig *egress.Config) map[string]awsv2.Config {
 	result := make(map[string]awsv2.Config)
 	if len(cfg.AWSProfiles) == 0 || (len(cfg.AWSProfiles) == 1 && cfg.AWSProfiles[0] == "") {
-		cfg := CreateDefaultV2Config(cfg)
+		cfg := CreateDefaultV2Config(cfg, egressConfig)
 		result[defaultAWSProfile] = cfg
 	} else {
 		for _, profile := range cfg.AWSProfiles {
@@ -68,6 +71,7 @@ func CreateV2Configs(cfg *externaldns.Config) map[string]awsv2.Config {
 					AssumeRoleExternalID: cfg.AWSAssumeRoleExternalID,
 					APIRetries:           cfg.AWSAPIRetries,
 					Profile:              profile,
+					Egress:               egressConfig,
 				},
 			)
 			if err != nil {
@@ -84,7 +88,7 @@ func newV2Config(awsConfig AWSSessionConfig) (awsv2.Config, error) {
 		config.WithRetryer(func() awsv2.Retryer {
 			return retry.AddWithMaxAttempts(retry.NewStandard(), awsConfig.APIRetries)
 		}),
-		config.WithHTTPClient(instrumented_http.NewClient(&http.Client{}, &instrumented_http.Callbacks{
+		config.WithHTTPClient(instrumented_http.NewClient(&http.Client{Transport: awsConfig.Egress.Transport()}, &instrumented_http.Callbacks{
 			PathProcessor: func(path string) string {
 				parts := strings.Split(path, "/")
 				return parts[len(parts)-1]
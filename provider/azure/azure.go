@@ -30,6 +30,7 @@ import (
 	dns "github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/dns/armdns"
 
 	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/pkg/egress"
 	"sigs.k8s.io/external-dns/plan"
 	"sigs.k8s.io/external-dns/provider"
 )
@@ -69,13 +70,13 @@ type AzureProvider struct {
 // NewAzureProvider creates a new Azure provider.
 //
 // Returns the provider or an error if a provider could not be created.
-func NewAzureProvider(configFile string, domainFilter *endpoint.DomainFilter, zoneNameFilter *endpoint.DomainFilter, zoneIDFilter provider.ZoneIDFilter, subscriptionID string, resourceGroup string, userAssignedIdentityClientID string, activeDirectoryAuthorityHost string, zonesCacheDuration time.Duration, maxRetriesCount int, dryRun bool) (*AzureProvider, error) {
+func NewAzureProvider(configFile string, domainFilter *endpoint.DomainFilter, zoneNameFilter *endpoint.DomainFilter, zoneIDFilter provider.ZoneIDFilter, subscriptionID string, resourceGroup string, userAssignedIdentityClientID string, activeDirectoryAuthorityHost string, zonesCacheDuration time.Duration, maxRetriesCount int, dryRun bool, egressConfig *egress.Config) (*AzureProvider, error) {
 	cfg, err := getConfig(configFile, subscriptionID, resourceGroup, userAssignedIdentityClientID, activeDirectoryAuthorityHost)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read Azure config file '%s': %w", configFile, err)
 	}
 
-	cred, clientOpts, err := getCredentials(*cfg, maxRetriesCount)
+	cred, clientOpts, err := getCredentials(*cfg, maxRetriesCount, egressConfig)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get credentials: %w", err)
 	}
@@ -103,6 +104,12 @@ func NewAzureProvider(configFile string, domainFilter *endpoint.DomainFilter, zo
 	}, nil
 }
 
+// WildcardSupport implements provider.WildcardSupporter, as Azure DNS only accepts an asterisk
+// as the whole first label of a record set name, which excludes the names of the wildcard TXT records.
+func (p *AzureProvider) WildcardSupport() provider.WildcardSupport {
+	return provider.WildcardSupport{Records: true}
+}
+
 // Records gets the current records.
 //
 // Returns the current records or an error if the operation failed.
@@ -220,7 +227,7 @@ func (p *AzureProvider) mapChanges(zones []dns.Zone, changes *plan.Changes) (azu
 		}
 	}
 	mapChange := func(changeMap azureChangeMap, change *endpoint.Endpoint) {
-		zone, _ := zoneNameIDMapper.FindZone(change.DNSName)
+		zone, _ := zoneNameIDMapper.FindZoneForEndpoint(change)
 		if zone == "" {
 			if _, ok := ignored[change.DNSName]; !ok {
 				ignored[change.DNSName] = true
@@ -302,6 +309,7 @@ func (p *AzureProvider) updateRecords(ctx context.Context, updated azureChangeMa
 
 			recordSet, err := p.newRecordSet(ep)
 			if err == nil {
+				recordSet.Properties.Metadata = recordMetadata(ep)
 				_, err = p.recordSetsClient.CreateOrUpdate(
 					ctx,
 					p.resourceGroup,
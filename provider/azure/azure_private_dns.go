@@ -29,6 +29,7 @@ import (
 	log "github.com/sirupsen/logrus"
 
 	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/pkg/egress"
 	"sigs.k8s.io/external-dns/plan"
 	"sigs.k8s.io/external-dns/provider"
 )
@@ -64,13 +65,13 @@ type AzurePrivateDNSProvider struct {
 // NewAzurePrivateDNSProvider creates a new Azure Private DNS provider.
 //
 // Returns the provider or an error if a provider could not be created.
-func NewAzurePrivateDNSProvider(configFile string, domainFilter *endpoint.DomainFilter, zoneNameFilter *endpoint.DomainFilter, zoneIDFilter provider.ZoneIDFilter, subscriptionID string, resourceGroup string, userAssignedIdentityClientID string, activeDirectoryAuthorityHost string, zonesCacheDuration time.Duration, maxRetriesCount int, dryRun bool) (*AzurePrivateDNSProvider, error) {
+func NewAzurePrivateDNSProvider(configFile string, domainFilter *endpoint.DomainFilter, zoneNameFilter *endpoint.DomainFilter, zoneIDFilter provider.ZoneIDFilter, subscriptionID string, resourceGroup string, userAssignedIdentityClientID string, activeDirectoryAuthorityHost string, zonesCacheDuration time.Duration, maxRetriesCount int, dryRun bool, egressConfig *egress.Config) (*AzurePrivateDNSProvider, error) {
 	cfg, err := getConfig(configFile, subscriptionID, resourceGroup, userAssignedIdentityClientID, activeDirectoryAuthorityHost)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read Azure config file '%s': %w", configFile, err)
 	}
 
-	cred, clientOpts, err := getCredentials(*cfg, maxRetriesCount)
+	cred, clientOpts, err := getCredentials(*cfg, maxRetriesCount, egressConfig)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get credentials: %w", err)
 	}
@@ -98,6 +99,12 @@ func NewAzurePrivateDNSProvider(configFile string, domainFilter *endpoint.Domain
 	}, nil
 }
 
+// WildcardSupport implements provider.WildcardSupporter, as Azure Private DNS only accepts an asterisk
+// as the whole first label of a record set name, which excludes the names of the wildcard TXT records.
+func (p *AzurePrivateDNSProvider) WildcardSupport() provider.WildcardSupport {
+	return provider.WildcardSupport{Records: true}
+}
+
 // Records gets the current records.
 //
 // Returns the current records or an error if the operation failed.
@@ -225,7 +232,7 @@ func (p *AzurePrivateDNSProvider) mapChanges(zones []privatedns.PrivateZone, cha
 		}
 	}
 	mapChange := func(changeMap azurePrivateDNSChangeMap, change *endpoint.Endpoint) {
-		zone, _ := zoneNameIDMapper.FindZone(change.DNSName)
+		zone, _ := zoneNameIDMapper.FindZoneForEndpoint(change)
 		if zone == "" {
 			if _, ok := ignored[change.DNSName]; !ok {
 				ignored[change.DNSName] = true
@@ -309,6 +316,7 @@ func (p *AzurePrivateDNSProvider) updateRecords(ctx context.Context, updated azu
 
 			recordSet, err := p.newRecordSet(ep)
 			if err == nil {
+				recordSet.Properties.Metadata = recordMetadata(ep)
 				_, err = p.recordSetsClient.CreateOrUpdate(
 					ctx,
 					p.resourceGroup,
@@ -612,3 +612,8 @@ func testAzureApplyChangesInternalZoneName(t *testing.T, dryRun bool, client Rec
 		t.Fatal(err)
 	}
 }
+
+func TestAzureWildcardSupport(t *testing.T) {
+	assert.Equal(t, provider.WildcardSupport{Records: true}, provider.GetWildcardSupport(&AzureProvider{}))
+	assert.Equal(t, provider.WildcardSupport{Records: true}, provider.GetWildcardSupport(&AzurePrivateDNSProvider{}))
+}
@@ -25,6 +25,8 @@ import (
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
 	dns "github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/dns/armdns"
 	privatedns "github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/privatedns/armprivatedns"
+
+	"sigs.k8s.io/external-dns/endpoint"
 )
 
 // Helper function (shared with test code)
@@ -45,3 +47,24 @@ func parseMxTarget[T dns.MxRecord | privatedns.MxRecord](mxTarget string) (T, er
 		Exchange:   to.Ptr(exchange),
 	}, nil
 }
+
+// recordMetadata returns the metadata of the record set of an endpoint, the labels copied from the
+// labels of its Kubernetes resource. Their names are restricted to letters, digits and underscores,
+// e.g. cost_center for cost-center.
+func recordMetadata(ep *endpoint.Endpoint) map[string]*string {
+	labels := ep.Labels.KubernetesLabels()
+	if len(labels) == 0 {
+		return nil
+	}
+	metadata := make(map[string]*string, len(labels))
+	for name, value := range labels {
+		name = strings.Map(func(r rune) rune {
+			if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' {
+				return r
+			}
+			return '_'
+		}, name)
+		metadata[name] = to.Ptr(value)
+	}
+	return metadata
+}
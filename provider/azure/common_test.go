@@ -25,6 +25,8 @@ import (
 	privatedns "github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/privatedns/armprivatedns"
 
 	"github.com/stretchr/testify/assert"
+
+	"sigs.k8s.io/external-dns/endpoint"
 )
 
 func Test_parseMxTarget(t *testing.T) {
@@ -85,3 +87,16 @@ func Test_parseMxTarget(t *testing.T) {
 		})
 	}
 }
+
+func Test_recordMetadata(t *testing.T) {
+	ep := endpoint.NewEndpoint("web.example.com", endpoint.RecordTypeA, "1.2.3.4").
+		WithLabel(endpoint.OwnerLabelKey, "default").
+		WithLabel(endpoint.KubernetesLabelPrefix+"cost-center", "cc-1234").
+		WithLabel(endpoint.KubernetesLabelPrefix+"example.com/team", "payments")
+
+	assert.Equal(t, map[string]*string{
+		"cost_center":      to.Ptr("cc-1234"),
+		"example_com_team": to.Ptr("payments"),
+	}, recordMetadata(ep))
+	assert.Nil(t, recordMetadata(endpoint.NewEndpoint("web.example.com", endpoint.RecordTypeA, "1.2.3.4")))
+}
@@ -31,6 +31,8 @@ import (
 	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
 	"github.com/google/uuid"
 	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/pkg/egress"
 )
 
 // config represents common config items for Azure DNS and Azure Private DNS
@@ -105,7 +107,7 @@ func (p *customHeaderPolicy) Do(req *policy.Request) (*http.Response, error) {
 func CustomHeaderPolicynew() policy.Policy { return &customHeaderPolicy{} }
 
 // getCredentials retrieves Azure API credentials.
-func getCredentials(cfg config, maxRetries int) (azcore.TokenCredential, *arm.ClientOptions, error) {
+func getCredentials(cfg config, maxRetries int, egressConfig *egress.Config) (azcore.TokenCredential, *arm.ClientOptions, error) {
 	cloudCfg, err := getCloudConfiguration(cfg.Cloud)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to get cloud configuration: %w", err)
@@ -126,6 +128,9 @@ func getCredentials(cfg config, maxRetries int) (azcore.TokenCredential, *arm.Cl
 			CustomHeaderPolicynew(),
 		},
 	}
+	if egressConfig.Configured() {
+		clientOpts.Transport = &http.Client{Transport: egressConfig.Transport()}
+	}
 	log.Debugf("Configured Azure client with maxRetries: %d", clientOpts.Retry.MaxRetries)
 	armClientOpts := &arm.ClientOptions{
 		ClientOptions: clientOpts,
@@ -0,0 +1,68 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"strings"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+// OwnershipKey returns the key grouping a record with the records of the same name and the TXT
+// records of the registry owning it: the name of the record the endpoint owns, or its own name.
+func OwnershipKey(ep *endpoint.Endpoint) string {
+	name := ep.Labels[endpoint.OwnedRecordLabelKey]
+	if name == "" {
+		name = ep.DNSName
+	}
+	return strings.ToLower(strings.TrimSuffix(name, "."))
+}
+
+// ChunkByOwnership splits the endpoints in chunks of at most size endpoints for the providers
+// submitting the changes in batches, keeping the endpoints with the same ownership key in the
+// same chunk, so that a record is never written without its TXT records. A group larger than
+// size is a chunk on its own; a size of 0 or less returns a single chunk.
+func ChunkByOwnership(endpoints []*endpoint.Endpoint, size int) [][]*endpoint.Endpoint {
+	if len(endpoints) == 0 {
+		return nil
+	}
+	if size <= 0 || len(endpoints) <= size {
+		return [][]*endpoint.Endpoint{endpoints}
+	}
+
+	var keys []string
+	groups := map[string][]*endpoint.Endpoint{}
+	for _, ep := range endpoints {
+		key := OwnershipKey(ep)
+		if _, ok := groups[key]; !ok {
+			keys = append(keys, key)
+		}
+		groups[key] = append(groups[key], ep)
+	}
+
+	var chunks [][]*endpoint.Endpoint
+	var chunk []*endpoint.Endpoint
+	for _, key := range keys {
+		group := groups[key]
+		if len(chunk) > 0 && len(chunk)+len(group) > size {
+			chunks = append(chunks, chunk)
+			chunk = nil
+		}
+		chunk = append(chunk, group...)
+	}
+	return append(chunks, chunk)
+}
@@ -0,0 +1,60 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+func TestChunkByOwnership(t *testing.T) {
+	ownership := func(name, owned string) *endpoint.Endpoint {
+		return endpoint.NewEndpoint(name, endpoint.RecordTypeTXT, "\"heritage=external-dns,external-dns/owner=owner\"").
+			WithLabel(endpoint.OwnedRecordLabelKey, owned)
+	}
+	foo := endpoint.NewEndpoint("foo.example.org", endpoint.RecordTypeA, "1.2.3.4")
+	bar := endpoint.NewEndpoint("bar.example.org", endpoint.RecordTypeA, "1.2.3.4")
+	barAAAA := endpoint.NewEndpoint("bar.example.org", endpoint.RecordTypeAAAA, "2001:db8::1")
+	baz := endpoint.NewEndpoint("baz.example.org", endpoint.RecordTypeA, "1.2.3.4")
+	fooTXT := ownership("a-foo.example.org", "foo.example.org")
+	barTXT := ownership("a-bar.example.org", "bar.example.org")
+
+	// the registry appends the ownership records after the records
+	endpoints := []*endpoint.Endpoint{foo, bar, barAAAA, baz, fooTXT, barTXT}
+
+	assert.Equal(t, [][]*endpoint.Endpoint{endpoints}, ChunkByOwnership(endpoints, 0))
+	assert.Equal(t, [][]*endpoint.Endpoint{endpoints}, ChunkByOwnership(endpoints, 6))
+	assert.Equal(t, [][]*endpoint.Endpoint{
+		{foo, fooTXT},
+		{bar, barAAAA, barTXT},
+		{baz},
+	}, ChunkByOwnership(endpoints, 2))
+	assert.Equal(t, [][]*endpoint.Endpoint{
+		{foo, fooTXT},
+		{bar, barAAAA, barTXT, baz},
+	}, ChunkByOwnership(endpoints, 4))
+	assert.Nil(t, ChunkByOwnership(nil, 2))
+}
+
+func TestOwnershipKey(t *testing.T) {
+	assert.Equal(t, "foo.example.org", OwnershipKey(endpoint.NewEndpoint("Foo.example.org.", endpoint.RecordTypeA, "1.2.3.4")))
+	assert.Equal(t, "foo.example.org", OwnershipKey(endpoint.NewEndpoint("txt-foo.example.org", endpoint.RecordTypeTXT, "x").
+		WithLabel(endpoint.OwnedRecordLabelKey, "foo.example.org")))
+}
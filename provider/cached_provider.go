@@ -96,6 +96,29 @@ func (c *CachedProvider) ApplyChanges(ctx context.Context, changes *plan.Changes
 	return c.Provider.ApplyChanges(ctx, changes)
 }
 
+// ZoneRecords lists the records of the zones of a domain from the underlying provider, bypassing the cache.
+func (c *CachedProvider) ZoneRecords(ctx context.Context, domain string) ([]*endpoint.Endpoint, error) {
+	return ZoneRecords(ctx, c.Provider, domain)
+}
+
+// ZoneNames returns the names of the zones of the underlying provider.
+func (c *CachedProvider) ZoneNames(ctx context.Context) ([]string, error) {
+	return ZoneNames(ctx, c.Provider)
+}
+
+// StreamRecords streams the records of the underlying provider, bypassing the cache.
+func (c *CachedProvider) StreamRecords(ctx context.Context, fn func(zone string, records []*endpoint.Endpoint) error) error {
+	return StreamRecords(ctx, c.Provider, fn)
+}
+
+func (c *CachedProvider) WildcardSupport() WildcardSupport {
+	return GetWildcardSupport(c.Provider)
+}
+
+func (c *CachedProvider) Capabilities() Capabilities {
+	return GetCapabilities(c.Provider)
+}
+
 func (c *CachedProvider) Reset() {
 	c.cache = nil
 	c.lastRead = time.Time{}
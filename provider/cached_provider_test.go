@@ -204,3 +204,63 @@ func TestCachedProviderForcesCacheRefreshOnUpdate(t *testing.T) {
 		})
 	})
 }
+
+type noWildcardProvider struct {
+	*testProviderFunc
+}
+
+func (p noWildcardProvider) WildcardSupport() WildcardSupport {
+	return WildcardSupport{Records: true}
+}
+
+func TestCachedProviderWildcardSupport(t *testing.T) {
+	assert.Equal(t, WildcardSupport{Records: true, TXTNames: true}, GetWildcardSupport(NewCachedProvider(newTestProviderFunc(t), time.Minute)))
+	assert.Equal(t, WildcardSupport{Records: true}, GetWildcardSupport(NewCachedProvider(noWildcardProvider{newTestProviderFunc(t)}, time.Minute)))
+}
+
+type zoneRecordsProvider struct {
+	*testProviderFunc
+}
+
+func (p zoneRecordsProvider) ZoneRecords(ctx context.Context, domain string) ([]*endpoint.Endpoint, error) {
+	return []*endpoint.Endpoint{{DNSName: "www." + domain}}, nil
+}
+
+func TestCachedProviderZoneRecords(t *testing.T) {
+	testProvider := newTestProviderFunc(t)
+	testProvider.records = func(ctx context.Context) ([]*endpoint.Endpoint, error) {
+		return []*endpoint.Endpoint{{DNSName: "domain.fqdn"}}, nil
+	}
+
+	// the providers without zone records fall back to all the records
+	endpoints, err := ZoneRecords(context.Background(), NewCachedProvider(testProvider, time.Minute), "example.org")
+	require.NoError(t, err)
+	assert.Equal(t, []*endpoint.Endpoint{{DNSName: "domain.fqdn"}}, endpoints)
+
+	provider := NewCachedProvider(zoneRecordsProvider{newTestProviderFunc(t)}, time.Minute)
+	endpoints, err = ZoneRecords(context.Background(), provider, "example.org")
+	require.NoError(t, err)
+	assert.Equal(t, []*endpoint.Endpoint{{DNSName: "www.example.org"}}, endpoints)
+}
+
+func TestCachedProviderStreamRecords(t *testing.T) {
+	testProvider := newTestProviderFunc(t)
+	testProvider.records = func(ctx context.Context) ([]*endpoint.Endpoint, error) {
+		return []*endpoint.Endpoint{{DNSName: "domain.fqdn"}}, nil
+	}
+	provider := NewCachedProvider(testProvider, time.Minute)
+
+	// the providers that cannot stream their records return them all at once
+	names, err := ZoneNames(context.Background(), provider)
+	require.NoError(t, err)
+	assert.Empty(t, names)
+
+	var zones []string
+	err = StreamRecords(context.Background(), provider, func(zone string, records []*endpoint.Endpoint) error {
+		zones = append(zones, zone)
+		assert.Equal(t, []*endpoint.Endpoint{{DNSName: "domain.fqdn"}}, records)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{""}, zones)
+}
@@ -0,0 +1,87 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"fmt"
+	"slices"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+// Capabilities describes the records a provider can apply, so that the changes it can't apply
+// are rejected with an explanation before calling it, instead of failing in the middle of them.
+// The webhook providers report them on the capabilities endpoint of the webhook API.
+type Capabilities struct {
+	// RecordTypes are the record types supported by the provider, any if empty.
+	RecordTypes []string `json:"recordTypes,omitempty"`
+	// MaxTargets is the maximum number of targets of a record, unlimited if zero.
+	MaxTargets int `json:"maxTargets,omitempty"`
+	// MinTTL is the minimum TTL of a record, unlimited if zero. The records without a TTL
+	// get the default TTL of the provider.
+	MinTTL endpoint.TTL `json:"minTTL,omitempty"`
+	// ApexAlias is whether the provider supports alias records at the apex of a zone.
+	ApexAlias bool `json:"apexAlias,omitempty"`
+	// Batch is whether the provider applies the changes of a zone at once.
+	Batch bool `json:"batch,omitempty"`
+	// SplitTXT is whether the TXT targets of the provider are in the zone file format, as quoted
+	// strings of at most 255 bytes: the longer values are split before being applied, and the
+	// strings of a target are merged back when read, by a TXTSplittingProvider. See the latter for
+	// the providers which don't report it.
+	SplitTXT bool `json:"splitTXT,omitempty"`
+	// Properties are the provider-specific properties supported by the provider, whose values
+	// are validated before applying the records.
+	Properties []PropertySchema `json:"properties,omitempty"`
+	// PropertyPrefixes are the prefixes of the names of the properties of the provider, the
+	// properties with these prefixes which are not declared are reported as unknown.
+	PropertyPrefixes []string `json:"propertyPrefixes,omitempty"`
+}
+
+// CapabilitiesReporter is implemented by the providers that describe their capabilities.
+type CapabilitiesReporter interface {
+	Capabilities() Capabilities
+}
+
+// GetCapabilities returns the capabilities of a provider. The providers that don't implement
+// CapabilitiesReporter are assumed to apply any record.
+func GetCapabilities(p Provider) Capabilities {
+	if r, ok := p.(CapabilitiesReporter); ok {
+		return r.Capabilities()
+	}
+	return Capabilities{}
+}
+
+// Validate returns an error explaining why the provider can't apply the record, or nil.
+func (c Capabilities) Validate(ep *endpoint.Endpoint) error {
+	if len(c.RecordTypes) > 0 && !slices.Contains(c.RecordTypes, ep.RecordType) {
+		return fmt.Errorf("the provider does not support %s records, only %v", ep.RecordType, c.RecordTypes)
+	}
+	if c.MaxTargets > 0 && len(ep.Targets) > c.MaxTargets {
+		return fmt.Errorf("the record has %d targets, more than the maximum of %d of the provider", len(ep.Targets), c.MaxTargets)
+	}
+	if c.MinTTL > 0 && ep.RecordTTL.IsConfigured() && ep.RecordTTL < c.MinTTL {
+		return fmt.Errorf("the TTL %d is below the minimum of %d of the provider", ep.RecordTTL, c.MinTTL)
+	}
+	if ep.RecordType == endpoint.RecordTypeMX {
+		for _, target := range ep.Targets {
+			if _, err := endpoint.ParseMXTarget(target); err != nil {
+				return err
+			}
+		}
+	}
+	return c.ValidateProperties(ep)
+}
@@ -0,0 +1,85 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+type capabilitiesProvider struct {
+	*testProviderFunc
+}
+
+func (p capabilitiesProvider) Capabilities() Capabilities {
+	return Capabilities{RecordTypes: []string{endpoint.RecordTypeA}, Batch: true}
+}
+
+func TestGetCapabilities(t *testing.T) {
+	assert.Equal(t, Capabilities{}, GetCapabilities(newTestProviderFunc(t)))
+
+	expected := Capabilities{RecordTypes: []string{endpoint.RecordTypeA}, Batch: true}
+	assert.Equal(t, expected, GetCapabilities(capabilitiesProvider{newTestProviderFunc(t)}))
+	// the wrappers report the capabilities of the provider they wrap
+	assert.Equal(t, expected, GetCapabilities(NewCachedProvider(capabilitiesProvider{newTestProviderFunc(t)}, time.Minute)))
+	assert.Equal(t, expected, GetCapabilities(NewReloadableProvider(capabilitiesProvider{newTestProviderFunc(t)})))
+}
+
+func TestCapabilitiesValidate(t *testing.T) {
+	capabilities := Capabilities{
+		RecordTypes: []string{endpoint.RecordTypeA, endpoint.RecordTypeCNAME},
+		MaxTargets:  2,
+		MinTTL:      60,
+	}
+
+	for _, tc := range []struct {
+		name string
+		ep   *endpoint.Endpoint
+		err  string
+	}{
+		{name: "supported", ep: endpoint.NewEndpointWithTTL("www.example.org", endpoint.RecordTypeA, 60, "1.2.3.4", "5.6.7.8")},
+		{name: "default TTL", ep: endpoint.NewEndpoint("www.example.org", endpoint.RecordTypeA, "1.2.3.4")},
+		{name: "record type", ep: endpoint.NewEndpoint("www.example.org", endpoint.RecordTypeTXT, "text"),
+			err: "the provider does not support TXT records, only [A CNAME]"},
+		{name: "targets", ep: endpoint.NewEndpoint("www.example.org", endpoint.RecordTypeA, "1.2.3.4", "5.6.7.8", "9.9.9.9"),
+			err: "the record has 3 targets, more than the maximum of 2 of the provider"},
+		{name: "TTL", ep: endpoint.NewEndpointWithTTL("www.example.org", endpoint.RecordTypeA, 30, "1.2.3.4"),
+			err: "the TTL 30 is below the minimum of 60 of the provider"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := capabilities.Validate(tc.ep)
+			if tc.err == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.EqualError(t, err, tc.err)
+		})
+	}
+
+	// the providers that don't report their capabilities accept any record
+	require.NoError(t, Capabilities{}.Validate(endpoint.NewEndpointWithTTL("www.example.org", endpoint.RecordTypeNAPTR, 1, "1", "2", "3")))
+
+	// the MX targets must have a priority, whatever the provider
+	require.NoError(t, Capabilities{}.Validate(endpoint.NewEndpoint("example.org", endpoint.RecordTypeMX, "10 mail.example.org")))
+	require.EqualError(t, Capabilities{}.Validate(endpoint.NewEndpoint("example.org", endpoint.RecordTypeMX, "mail.example.org")),
+		`invalid MX record target "mail.example.org": MX records must have a priority and a host, e.g. '10 example.com'`)
+}
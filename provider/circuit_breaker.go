@@ -0,0 +1,211 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/pkg/metrics"
+	"sigs.k8s.io/external-dns/plan"
+)
+
+var (
+	circuitBreakerState = metrics.NewGaugeWithOpts(
+		prometheus.GaugeOpts{
+			Namespace: "external_dns",
+			Subsystem: "provider",
+			Name:      "circuit_breaker_state",
+			Help:      "State of the circuit breaker of the provider: 0 if closed, 1 if half-open, 2 if open.",
+		},
+	)
+	circuitBreakerRejectedCallsTotal = metrics.NewCounterWithOpts(
+		prometheus.CounterOpts{
+			Namespace: "external_dns",
+			Subsystem: "provider",
+			Name:      "circuit_breaker_rejected_calls_total",
+			Help:      "Number of calls to the provider rejected as its circuit breaker is open.",
+		},
+	)
+)
+
+func init() {
+	metrics.RegisterMetric.MustRegister(circuitBreakerState)
+	metrics.RegisterMetric.MustRegister(circuitBreakerRejectedCallsTotal)
+}
+
+type circuitState int
+
+const (
+	circuitClosed circuitState = iota
+	circuitHalfOpen
+	circuitOpen
+)
+
+// CircuitBreakerProvider stops calling the provider for a while after consecutive failures, so
+// that an unavailable API is neither called at each synchronization nor floods the logs.
+// Once the open duration has elapsed, a single call probes the API: the circuit is closed again
+// if it succeeds, and opened again if it fails.
+type CircuitBreakerProvider struct {
+	Provider
+	failureThreshold int
+	openDuration     time.Duration
+
+	mu       sync.Mutex
+	state    circuitState
+	failures int
+	openedAt time.Time
+	probing  bool
+	now      func() time.Time
+}
+
+// NewCircuitBreakerProvider returns a provider whose circuit is opened after failureThreshold
+// consecutive failures of the provider, for openDuration.
+func NewCircuitBreakerProvider(provider Provider, failureThreshold int, openDuration time.Duration) *CircuitBreakerProvider {
+	circuitBreakerState.Gauge.Set(float64(circuitClosed))
+	return &CircuitBreakerProvider{
+		Provider:         provider,
+		failureThreshold: failureThreshold,
+		openDuration:     openDuration,
+		now:              time.Now,
+	}
+}
+
+func (c *CircuitBreakerProvider) Records(ctx context.Context) ([]*endpoint.Endpoint, error) {
+	if err := c.allow(); err != nil {
+		return nil, err
+	}
+	records, err := c.Provider.Records(ctx)
+	c.done(err)
+	return records, err
+}
+
+func (c *CircuitBreakerProvider) ApplyChanges(ctx context.Context, changes *plan.Changes) error {
+	if err := c.allow(); err != nil {
+		return err
+	}
+	err := c.Provider.ApplyChanges(ctx, changes)
+	c.done(err)
+	return err
+}
+
+func (c *CircuitBreakerProvider) ZoneRecords(ctx context.Context, domain string) ([]*endpoint.Endpoint, error) {
+	if err := c.allow(); err != nil {
+		return nil, err
+	}
+	records, err := ZoneRecords(ctx, c.Provider, domain)
+	c.done(err)
+	return records, err
+}
+
+func (c *CircuitBreakerProvider) ZoneNames(ctx context.Context) ([]string, error) {
+	if err := c.allow(); err != nil {
+		return nil, err
+	}
+	names, err := ZoneNames(ctx, c.Provider)
+	c.done(err)
+	return names, err
+}
+
+func (c *CircuitBreakerProvider) StreamRecords(ctx context.Context, fn func(zone string, records []*endpoint.Endpoint) error) error {
+	if err := c.allow(); err != nil {
+		return err
+	}
+	// only the failures of the provider count, not those of fn
+	var fnErr error
+	err := StreamRecords(ctx, c.Provider, func(zone string, records []*endpoint.Endpoint) error {
+		fnErr = fn(zone, records)
+		return fnErr
+	})
+	if fnErr != nil && errors.Is(err, fnErr) {
+		c.done(nil)
+	} else {
+		c.done(err)
+	}
+	return err
+}
+
+func (c *CircuitBreakerProvider) WildcardSupport() WildcardSupport {
+	return GetWildcardSupport(c.Provider)
+}
+
+func (c *CircuitBreakerProvider) Capabilities() Capabilities {
+	return GetCapabilities(c.Provider)
+}
+
+// allow returns a soft error when the circuit is open, or when it's half-open and already
+// probing the provider.
+func (c *CircuitBreakerProvider) allow() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.state == circuitOpen {
+		retryAt := c.openedAt.Add(c.openDuration)
+		if c.now().Before(retryAt) {
+			circuitBreakerRejectedCallsTotal.Counter.Inc()
+			return NewSoftErrorf("the provider is not called after %d consecutive failures, until %s", c.failures, retryAt.Format(time.RFC3339))
+		}
+		log.Info("Provider circuit breaker half-open: probing the provider")
+		c.setState(circuitHalfOpen)
+	}
+	if c.state == circuitHalfOpen {
+		if c.probing {
+			circuitBreakerRejectedCallsTotal.Counter.Inc()
+			return NewSoftErrorf("the provider is not called while probing it after %d consecutive failures", c.failures)
+		}
+		c.probing = true
+	}
+	return nil
+}
+
+// done updates the circuit with the result of a call allowed by allow. A canceled call is
+// neither a success nor a failure.
+func (c *CircuitBreakerProvider) done(err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.probing = false
+	if errors.Is(err, context.Canceled) {
+		return
+	}
+	if err == nil {
+		if c.state != circuitClosed {
+			log.Infof("Provider circuit breaker closed: the provider succeeded after %d consecutive failures", c.failures)
+		}
+		c.failures = 0
+		c.setState(circuitClosed)
+		return
+	}
+
+	c.failures++
+	if c.state == circuitHalfOpen || c.failures >= c.failureThreshold {
+		log.Warnf("Provider circuit breaker open for %s after %d consecutive failures: %v", c.openDuration, c.failures, err)
+		c.openedAt = c.now()
+		c.setState(circuitOpen)
+	}
+}
+
+func (c *CircuitBreakerProvider) setState(state circuitState) {
+	c.state = state
+	circuitBreakerState.Gauge.Set(float64(state))
+}
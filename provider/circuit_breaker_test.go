@@ -0,0 +1,179 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	promtestutil "github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+)
+
+func newTestCircuitBreakerProvider(p Provider, now *time.Time) *CircuitBreakerProvider {
+	c := NewCircuitBreakerProvider(p, 2, time.Minute)
+	c.now = func() time.Time { return *now }
+	return c
+}
+
+func TestCircuitBreakerProviderOpensAfterConsecutiveFailures(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	calls := 0
+	testProvider := newTestProviderFunc(t)
+	testProvider.records = func(ctx context.Context) ([]*endpoint.Endpoint, error) {
+		calls++
+		return nil, errors.New("unavailable")
+	}
+	c := newTestCircuitBreakerProvider(testProvider, &now)
+
+	_, err := c.Records(context.Background())
+	require.Error(t, err)
+	assert.NotErrorIs(t, err, SoftError)
+	assert.InDelta(t, float64(circuitClosed), promtestutil.ToFloat64(circuitBreakerState.Gauge), 0)
+
+	_, err = c.Records(context.Background())
+	require.Error(t, err)
+	assert.InDelta(t, float64(circuitOpen), promtestutil.ToFloat64(circuitBreakerState.Gauge), 0)
+
+	rejected := promtestutil.ToFloat64(circuitBreakerRejectedCallsTotal.Counter)
+	testProvider.records = recordsNotCalled(t)
+	_, err = c.Records(context.Background())
+	require.ErrorIs(t, err, SoftError)
+	assert.ErrorContains(t, err, "after 2 consecutive failures")
+	err = c.ApplyChanges(context.Background(), &plan.Changes{})
+	require.ErrorIs(t, err, SoftError)
+	assert.InDelta(t, rejected+2, promtestutil.ToFloat64(circuitBreakerRejectedCallsTotal.Counter), 0)
+	assert.Equal(t, 2, calls)
+}
+
+func TestCircuitBreakerProviderSuccessResetsFailures(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	fail := true
+	testProvider := newTestProviderFunc(t)
+	testProvider.records = func(ctx context.Context) ([]*endpoint.Endpoint, error) {
+		if fail {
+			return nil, errors.New("unavailable")
+		}
+		return nil, nil
+	}
+	c := newTestCircuitBreakerProvider(testProvider, &now)
+
+	for _, f := range []bool{true, false, true, false} {
+		fail = f
+		_, err := c.Records(context.Background())
+		assert.Equal(t, f, err != nil)
+	}
+	assert.Equal(t, circuitClosed, c.state)
+}
+
+func TestCircuitBreakerProviderHalfOpen(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		probeErr error
+		expected circuitState
+	}{
+		{name: "probe succeeds", expected: circuitClosed},
+		{name: "probe fails", probeErr: errors.New("still unavailable"), expected: circuitOpen},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+			testProvider := newTestProviderFunc(t)
+			testProvider.records = func(ctx context.Context) ([]*endpoint.Endpoint, error) {
+				return nil, errors.New("unavailable")
+			}
+			c := newTestCircuitBreakerProvider(testProvider, &now)
+			for range 2 {
+				_, _ = c.Records(context.Background())
+			}
+			require.Equal(t, circuitOpen, c.state)
+
+			now = now.Add(time.Minute)
+			testProvider.records = func(ctx context.Context) ([]*endpoint.Endpoint, error) {
+				// a concurrent call is rejected while probing
+				_, err := c.Records(ctx)
+				assert.ErrorIs(t, err, SoftError)
+				assert.Equal(t, circuitHalfOpen, c.state)
+				return nil, tc.probeErr
+			}
+			_, err := c.Records(context.Background())
+			assert.Equal(t, tc.probeErr, err)
+			assert.Equal(t, tc.expected, c.state)
+			if tc.expected == circuitOpen {
+				assert.Equal(t, now, c.openedAt)
+			}
+		})
+	}
+}
+
+func TestCircuitBreakerProviderIgnoresCanceledCalls(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	testProvider := newTestProviderFunc(t)
+	testProvider.records = func(ctx context.Context) ([]*endpoint.Endpoint, error) {
+		return nil, context.Canceled
+	}
+	c := newTestCircuitBreakerProvider(testProvider, &now)
+
+	for range 3 {
+		_, err := c.Records(context.Background())
+		require.ErrorIs(t, err, context.Canceled)
+	}
+	assert.Equal(t, circuitClosed, c.state)
+	assert.Zero(t, c.failures)
+}
+
+func TestCircuitBreakerProviderStreamRecords(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	testProvider := newTestProviderFunc(t)
+	testProvider.records = func(ctx context.Context) ([]*endpoint.Endpoint, error) {
+		return []*endpoint.Endpoint{{DNSName: "domain.fqdn"}}, nil
+	}
+	c := newTestCircuitBreakerProvider(testProvider, &now)
+
+	fnErr := errors.New("fn failed")
+	for range 3 {
+		err := c.StreamRecords(context.Background(), func(zone string, records []*endpoint.Endpoint) error {
+			return fnErr
+		})
+		require.ErrorIs(t, err, fnErr)
+	}
+	assert.Equal(t, circuitClosed, c.state)
+
+	testProvider.records = func(ctx context.Context) ([]*endpoint.Endpoint, error) {
+		return nil, errors.New("unavailable")
+	}
+	for range 2 {
+		err := c.StreamRecords(context.Background(), func(zone string, records []*endpoint.Endpoint) error {
+			t.Error("unexpected call to fn")
+			return nil
+		})
+		require.Error(t, err)
+	}
+	assert.Equal(t, circuitOpen, c.state)
+}
+
+func TestCircuitBreakerProviderForwardsOptionalInterfaces(t *testing.T) {
+	expected := Capabilities{RecordTypes: []string{endpoint.RecordTypeA}, Batch: true}
+	c := NewCircuitBreakerProvider(capabilitiesProvider{newTestProviderFunc(t)}, 2, time.Minute)
+	assert.Equal(t, expected, GetCapabilities(c))
+	assert.Equal(t, GetWildcardSupport(newTestProviderFunc(t)), GetWildcardSupport(c))
+}
@@ -37,6 +37,7 @@ type CivoProvider struct {
 	Client       civogo.Client
 	domainFilter *endpoint.DomainFilter
 	DryRun       bool
+	missingZones *provider.MissingZoneCache
 }
 
 // CivoChanges All API calls calculated from the plan
@@ -71,7 +72,7 @@ type CivoChangeDelete struct {
 }
 
 // NewCivoProvider initializes a new Civo DNS based Provider.
-func NewCivoProvider(domainFilter *endpoint.DomainFilter, dryRun bool) (*CivoProvider, error) {
+func NewCivoProvider(domainFilter *endpoint.DomainFilter, dryRun bool, missingZones *provider.MissingZoneCache) (*CivoProvider, error) {
 	token, ok := os.LookupEnv("CIVO_TOKEN")
 	if !ok {
 		return nil, fmt.Errorf("no token found")
@@ -96,6 +97,7 @@ func NewCivoProvider(domainFilter *endpoint.DomainFilter, dryRun bool) (*CivoPro
 		Client:       *civoClient,
 		domainFilter: domainFilter,
 		DryRun:       dryRun,
+		missingZones: missingZones,
 	}
 	return provider, nil
 }
@@ -470,9 +472,9 @@ func (p *CivoProvider) ApplyChanges(ctx context.Context, changes *plan.Changes)
 		recordsByZoneID[zone.ID] = append(recordsByZoneID[zone.ID], records...)
 	}
 
-	createsByZone := endpointsByZone(zoneNameIDMapper, changes.Create)
-	updatesByZone := endpointsByZone(zoneNameIDMapper, changes.UpdateNew)
-	deletesByZone := endpointsByZone(zoneNameIDMapper, changes.Delete)
+	createsByZone := endpointsByZone(zoneNameIDMapper, changes.Create, p.missingZones)
+	updatesByZone := endpointsByZone(zoneNameIDMapper, changes.UpdateNew, p.missingZones)
+	deletesByZone := endpointsByZone(zoneNameIDMapper, changes.Delete, p.missingZones)
 
 	// Generate Creates
 	err = processCreateActions(zonesByID, recordsByZoneID, createsByZone, &civoChange)
@@ -495,13 +497,17 @@ func (p *CivoProvider) ApplyChanges(ctx context.Context, changes *plan.Changes)
 	return p.submitChanges(ctx, civoChange)
 }
 
-func endpointsByZone(zoneNameIDMapper provider.ZoneIDName, endpoints []*endpoint.Endpoint) map[string][]*endpoint.Endpoint {
+// endpointsByZone separates the endpoints by zone. The names matching no zone are reported to
+// missingZones.
+func endpointsByZone(zoneNameIDMapper provider.ZoneIDName, endpoints []*endpoint.Endpoint, missingZones *provider.MissingZoneCache) map[string][]*endpoint.Endpoint {
 	endpointsByZone := make(map[string][]*endpoint.Endpoint)
 
 	for _, ep := range endpoints {
-		zoneID, _ := zoneNameIDMapper.FindZone(ep.DNSName)
+		zoneID, _ := zoneNameIDMapper.FindZoneForEndpoint(ep)
 		if zoneID == "" {
-			log.Debugf("Skipping record %s because no hosted zone matching record DNS Name was detected", ep.DNSName)
+			if missingZones.Report(ep.DNSName) {
+				log.Debugf("Skipping record %s because no hosted zone matching record DNS Name was detected", ep.DNSName)
+			}
 			continue
 		}
 		endpointsByZone[zoneID] = append(endpointsByZone[zoneID], ep)
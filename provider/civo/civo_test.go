@@ -34,14 +34,14 @@ import (
 
 func TestNewCivoProvider(t *testing.T) {
 	_ = os.Setenv("CIVO_TOKEN", "xxxxxxxxxxxxxxx")
-	_, err := NewCivoProvider(endpoint.NewDomainFilter([]string{"test.civo.com"}), true)
+	_, err := NewCivoProvider(endpoint.NewDomainFilter([]string{"test.civo.com"}), true, nil)
 	require.NoError(t, err)
 
 	_ = os.Unsetenv("CIVO_TOKEN")
 }
 
 func TestNewCivoProviderNoToken(t *testing.T) {
-	_, err := NewCivoProvider(endpoint.NewDomainFilter([]string{"test.civo.com"}), true)
+	_, err := NewCivoProvider(endpoint.NewDomainFilter([]string{"test.civo.com"}), true, nil)
 	assert.Error(t, err)
 
 	assert.Equal(t, "no token found", err.Error())
@@ -31,8 +31,11 @@ import (
 	"github.com/cloudflare/cloudflare-go"
 	log "github.com/sirupsen/logrus"
 	"golang.org/x/net/publicsuffix"
+	"golang.org/x/time/rate"
 
 	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/pkg/egress"
+	"sigs.k8s.io/external-dns/pkg/ratelimiter"
 	"sigs.k8s.io/external-dns/plan"
 	"sigs.k8s.io/external-dns/provider"
 	"sigs.k8s.io/external-dns/source/annotations"
@@ -224,6 +227,7 @@ type CloudFlareProvider struct {
 	CustomHostnamesConfig  CustomHostnamesConfig
 	DNSRecordsConfig       DNSRecordsConfig
 	RegionalServicesConfig RegionalServicesConfig
+	missingZones           *provider.MissingZoneCache
 }
 
 // cloudFlareChange differentiates between ChangActions
@@ -243,22 +247,24 @@ type RecordParamsTypes interface {
 // updateDNSRecordParam is a function that returns the appropriate Record Param based on the cloudFlareChange passed in
 func updateDNSRecordParam(cfc cloudFlareChange) cloudflare.UpdateDNSRecordParams {
 	return cloudflare.UpdateDNSRecordParams{
-		Name:    cfc.ResourceRecord.Name,
-		TTL:     cfc.ResourceRecord.TTL,
-		Proxied: cfc.ResourceRecord.Proxied,
-		Type:    cfc.ResourceRecord.Type,
-		Content: cfc.ResourceRecord.Content,
+		Name:     cfc.ResourceRecord.Name,
+		TTL:      cfc.ResourceRecord.TTL,
+		Proxied:  cfc.ResourceRecord.Proxied,
+		Type:     cfc.ResourceRecord.Type,
+		Content:  cfc.ResourceRecord.Content,
+		Priority: cfc.ResourceRecord.Priority,
 	}
 }
 
 // getCreateDNSRecordParam is a function that returns the appropriate Record Param based on the cloudFlareChange passed in
 func getCreateDNSRecordParam(cfc cloudFlareChange) cloudflare.CreateDNSRecordParams {
 	return cloudflare.CreateDNSRecordParams{
-		Name:    cfc.ResourceRecord.Name,
-		TTL:     cfc.ResourceRecord.TTL,
-		Proxied: cfc.ResourceRecord.Proxied,
-		Type:    cfc.ResourceRecord.Type,
-		Content: cfc.ResourceRecord.Content,
+		Name:     cfc.ResourceRecord.Name,
+		TTL:      cfc.ResourceRecord.TTL,
+		Proxied:  cfc.ResourceRecord.Proxied,
+		Type:     cfc.ResourceRecord.Type,
+		Content:  cfc.ResourceRecord.Content,
+		Priority: cfc.ResourceRecord.Priority,
 	}
 }
 
@@ -288,6 +294,8 @@ func NewCloudFlareProvider(
 	regionalServicesConfig RegionalServicesConfig,
 	customHostnamesConfig CustomHostnamesConfig,
 	dnsRecordsConfig DNSRecordsConfig,
+	missingZones *provider.MissingZoneCache,
+	egressConfig *egress.Config,
 ) (*CloudFlareProvider, error) {
 	// initialize via chosen auth method and returns new API object
 	var (
@@ -303,9 +311,9 @@ func NewCloudFlareProvider(
 			}
 			token = strings.TrimSpace(string(tokenBytes))
 		}
-		config, err = cloudflare.NewWithAPIToken(token)
+		config, err = cloudflare.NewWithAPIToken(token, sharedRateLimit(token, egressConfig))
 	} else {
-		config, err = cloudflare.New(os.Getenv("CF_API_KEY"), os.Getenv("CF_API_EMAIL"))
+		config, err = cloudflare.New(os.Getenv("CF_API_KEY"), os.Getenv("CF_API_EMAIL"), sharedRateLimit(os.Getenv("CF_API_KEY")+os.Getenv("CF_API_EMAIL"), egressConfig))
 	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize cloudflare provider: %w", err)
@@ -324,9 +332,17 @@ func NewCloudFlareProvider(
 		DryRun:                 dryRun,
 		RegionalServicesConfig: regionalServicesConfig,
 		DNSRecordsConfig:       dnsRecordsConfig,
+		missingZones:           missingZones,
 	}, nil
 }
 
+// sharedRateLimit returns the option limiting the requests of the client to the default API limit
+// of 1200 requests per 5 minutes, with the limiter of the other clients using the same credential.
+func sharedRateLimit(credential string, egressConfig *egress.Config) cloudflare.Option {
+	limiter := ratelimiter.Shared("cloudflare", credential, rate.Limit(4), 1)
+	return cloudflare.HTTPClient(&http.Client{Transport: ratelimiter.Transport(limiter, egressConfig.Transport())})
+}
+
 // Zones returns the list of hosted zones.
 func (p *CloudFlareProvider) Zones(ctx context.Context) ([]cloudflare.Zone, error) {
 	var result []cloudflare.Zone
@@ -378,32 +394,102 @@ func (p *CloudFlareProvider) Records(ctx context.Context) ([]*endpoint.Endpoint,
 
 	var endpoints []*endpoint.Endpoint
 	for _, zone := range zones {
-		records, err := p.listDNSRecordsWithAutoPagination(ctx, zone.ID)
+		zoneEndpoints, err := p.zoneRecords(ctx, zone)
 		if err != nil {
 			return nil, err
 		}
+		endpoints = append(endpoints, zoneEndpoints...)
+	}
 
-		// nil if custom hostnames are not enabled
-		chs, chErr := p.listCustomHostnamesWithPagination(ctx, zone.ID)
-		if chErr != nil {
-			return nil, chErr
+	return endpoints, nil
+}
+
+// ZoneNames returns the names of the zones, sorted.
+func (p *CloudFlareProvider) ZoneNames(ctx context.Context) ([]string, error) {
+	zones, err := p.Zones(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return provider.SortedZoneNames(zoneIDNames(zones)), nil
+}
+
+// StreamRecords calls fn with the records of each zone in turn, sorted by name.
+func (p *CloudFlareProvider) StreamRecords(ctx context.Context, fn func(zone string, records []*endpoint.Endpoint) error) error {
+	zones, err := p.Zones(ctx)
+	if err != nil {
+		return err
+	}
+	byID := make(map[string]cloudflare.Zone, len(zones))
+	for _, zone := range zones {
+		byID[zone.ID] = zone
+	}
+	return provider.StreamZoneRecords(zoneIDNames(zones), func(zoneIDs []string) ([]*endpoint.Endpoint, error) {
+		var endpoints []*endpoint.Endpoint
+		for _, id := range zoneIDs {
+			zoneEndpoints, err := p.zoneRecords(ctx, byID[id])
+			if err != nil {
+				return nil, err
+			}
+			endpoints = append(endpoints, zoneEndpoints...)
 		}
+		return endpoints, nil
+	}, fn)
+}
 
-		// As CloudFlare does not support "sets" of targets, but instead returns
-		// a single entry for each name/type/target, we have to group by name
-		// and record to allow the planner to calculate the correct plan. See #992.
-		zoneEndpoints := groupByNameAndTypeWithCustomHostnames(records, chs)
+// ZoneRecords returns the records of the zones containing the domain or its subdomains.
+func (p *CloudFlareProvider) ZoneRecords(ctx context.Context, domain string) ([]*endpoint.Endpoint, error) {
+	zones, err := p.Zones(ctx)
+	if err != nil {
+		return nil, err
+	}
 
-		if err := p.addEnpointsProviderSpecificRegionKeyProperty(ctx, zone.ID, zoneEndpoints); err != nil {
+	var endpoints []*endpoint.Endpoint
+	for _, zone := range zones {
+		if !provider.ZoneOverlapsDomain(zone.Name, domain) {
+			continue
+		}
+		zoneEndpoints, err := p.zoneRecords(ctx, zone)
+		if err != nil {
 			return nil, err
 		}
-
 		endpoints = append(endpoints, zoneEndpoints...)
 	}
-
 	return endpoints, nil
 }
 
+// zoneRecords returns the records of the zone, with their custom hostnames.
+func (p *CloudFlareProvider) zoneRecords(ctx context.Context, zone cloudflare.Zone) ([]*endpoint.Endpoint, error) {
+	records, err := p.listDNSRecordsWithAutoPagination(ctx, zone.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	// nil if custom hostnames are not enabled
+	chs, chErr := p.listCustomHostnamesWithPagination(ctx, zone.ID)
+	if chErr != nil {
+		return nil, chErr
+	}
+
+	// As CloudFlare does not support "sets" of targets, but instead returns
+	// a single entry for each name/type/target, we have to group by name
+	// and record to allow the planner to calculate the correct plan. See #992.
+	zoneEndpoints := groupByNameAndTypeWithCustomHostnames(records, chs)
+
+	if err := p.addEnpointsProviderSpecificRegionKeyProperty(ctx, zone.ID, zoneEndpoints); err != nil {
+		return nil, err
+	}
+	return zoneEndpoints, nil
+}
+
+// zoneIDNames returns the names of the zones by ID.
+func zoneIDNames(zones []cloudflare.Zone) provider.ZoneIDName {
+	names := provider.ZoneIDName{}
+	for _, zone := range zones {
+		names.Add(zone.ID, zone.Name)
+	}
+	return names
+}
+
 // ApplyChanges applies a given set of changes in a given zone.
 func (p *CloudFlareProvider) ApplyChanges(ctx context.Context, changes *plan.Changes) error {
 	var cloudflareChanges []*cloudFlareChange
@@ -643,6 +729,19 @@ func (p *CloudFlareProvider) submitChanges(ctx context.Context, changes []*cloud
 	return nil
 }
 
+// Capabilities implements provider.CapabilitiesReporter.
+func (p *CloudFlareProvider) Capabilities() provider.Capabilities {
+	return provider.Capabilities{
+		Properties: []provider.PropertySchema{
+			{Name: annotations.CloudflareProxiedKey, Type: provider.PropertyTypeBool},
+			{Name: annotations.CloudflareCustomHostnameKey, Type: provider.PropertyTypeString},
+			{Name: annotations.CloudflareRegionKey, Type: provider.PropertyTypeString},
+			{Name: annotations.CloudflareRecordCommentKey, Type: provider.PropertyTypeString},
+		},
+		PropertyPrefixes: []string{annotations.CloudflarePrefix},
+	}
+}
+
 // AdjustEndpoints modifies the endpoints as needed by the specific provider
 func (p *CloudFlareProvider) AdjustEndpoints(endpoints []*endpoint.Endpoint) ([]*endpoint.Endpoint, error) {
 	var adjustedEndpoints []*endpoint.Endpoint
@@ -671,6 +770,14 @@ func (p *CloudFlareProvider) AdjustEndpoints(endpoints []*endpoint.Endpoint) ([]
 			}
 		}
 
+		// The generic comment of the resource is written as the record comment, unless
+		// the resource sets a Cloudflare comment.
+		if comment, ok := e.Labels[endpoint.CommentLabelKey]; ok {
+			if _, ok := e.GetProviderSpecificProperty(annotations.CloudflareRecordCommentKey); !ok {
+				e.SetProviderSpecificProperty(annotations.CloudflareRecordCommentKey, comment)
+			}
+		}
+
 		adjustedEndpoints = append(adjustedEndpoints, e)
 	}
 	return adjustedEndpoints, nil
@@ -689,7 +796,9 @@ func (p *CloudFlareProvider) changesByZone(zones []cloudflare.Zone, changeSet []
 	for _, c := range changeSet {
 		zoneID, _ := zoneNameIDMapper.FindZone(c.ResourceRecord.Name)
 		if zoneID == "" {
-			log.Debugf("Skipping record %q because no hosted zone matching record DNS Name was detected", c.ResourceRecord.Name)
+			if p.missingZones.Report(c.ResourceRecord.Name) {
+				log.Debugf("Skipping record %q because no hosted zone matching record DNS Name was detected", c.ResourceRecord.Name)
+			}
 			continue
 		}
 		changes[zoneID] = append(changes[zoneID], c)
@@ -753,6 +862,17 @@ func (p *CloudFlareProvider) newCloudFlareChange(action changeAction, ep *endpoi
 		comment = p.DNSRecordsConfig.trimAndValidateComment(ep.DNSName, comment, p.ZoneHasPaidPlan)
 	}
 
+	// the priority of an MX record is a field of its own, its content being the host
+	var priority *uint16
+	if ep.RecordType == endpoint.RecordTypeMX {
+		if mx, err := endpoint.ParseMXTarget(target); err == nil {
+			target = mx.Host
+			priority = &mx.Priority
+		} else {
+			log.Warn(err)
+		}
+	}
+
 	return &cloudFlareChange{
 		Action: action,
 		ResourceRecord: cloudflare.DNSRecord{
@@ -760,10 +880,11 @@ func (p *CloudFlareProvider) newCloudFlareChange(action changeAction, ep *endpoi
 			TTL:  ttl,
 			// We have to use pointers to bools now, as the upstream cloudflare-go library requires them
 			// see: https://github.com/cloudflare/cloudflare-go/pull/595
-			Proxied: &proxied,
-			Type:    ep.RecordType,
-			Content: target,
-			Comment: comment,
+			Proxied:  &proxied,
+			Type:     ep.RecordType,
+			Content:  target,
+			Priority: priority,
+			Comment:  comment,
 		},
 		RegionalHostname:    p.regionalHostname(ep),
 		CustomHostnamesPrev: prevCustomHostnames,
@@ -771,6 +892,24 @@ func (p *CloudFlareProvider) newCloudFlareChange(action changeAction, ep *endpoi
 	}
 }
 
+// supportedRecordType returns true if the record type is supported by the provider
+func supportedRecordType(recordType string) bool {
+	switch recordType {
+	case endpoint.RecordTypeMX:
+		return true
+	default:
+		return provider.SupportedRecordType(recordType)
+	}
+}
+
+// recordTarget returns the target of a record, made of the priority and the host of an MX record.
+func recordTarget(r cloudflare.DNSRecord) string {
+	if r.Type == endpoint.RecordTypeMX && r.Priority != nil {
+		return endpoint.NewMXTarget(*r.Priority, r.Content)
+	}
+	return r.Content
+}
+
 func newDNSRecordIndex(r cloudflare.DNSRecord) DNSRecordIndex {
 	return DNSRecordIndex{Name: r.Name, Type: r.Type, Content: r.Content}
 }
@@ -884,7 +1023,7 @@ func groupByNameAndTypeWithCustomHostnames(records DNSRecordsMap, chs CustomHost
 	groups := map[string][]cloudflare.DNSRecord{}
 
 	for _, r := range records {
-		if !provider.SupportedRecordType(r.Type) {
+		if !supportedRecordType(r.Type) {
 			continue
 		}
 
@@ -910,7 +1049,7 @@ func groupByNameAndTypeWithCustomHostnames(records DNSRecordsMap, chs CustomHost
 		}
 		targets := make([]string, len(records))
 		for i, record := range records {
-			targets[i] = record.Content
+			targets[i] = recordTarget(record)
 		}
 		e := endpoint.NewEndpointWithTTL(
 			records[0].Name,
@@ -30,6 +30,7 @@ import (
 	"github.com/maxatome/go-testdeep/td"
 	log "github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"sigs.k8s.io/external-dns/endpoint"
 	"sigs.k8s.io/external-dns/internal/testutils"
 	"sigs.k8s.io/external-dns/plan"
@@ -118,21 +119,23 @@ func getDNSRecordFromRecordParams(rp any) cloudflare.DNSRecord {
 	switch params := rp.(type) {
 	case cloudflare.CreateDNSRecordParams:
 		return cloudflare.DNSRecord{
-			ID:      params.ID,
-			Name:    params.Name,
-			TTL:     params.TTL,
-			Proxied: params.Proxied,
-			Type:    params.Type,
-			Content: params.Content,
+			ID:       params.ID,
+			Name:     params.Name,
+			TTL:      params.TTL,
+			Proxied:  params.Proxied,
+			Type:     params.Type,
+			Content:  params.Content,
+			Priority: params.Priority,
 		}
 	case cloudflare.UpdateDNSRecordParams:
 		return cloudflare.DNSRecord{
-			ID:      params.ID,
-			Name:    params.Name,
-			TTL:     params.TTL,
-			Proxied: params.Proxied,
-			Type:    params.Type,
-			Content: params.Content,
+			ID:       params.ID,
+			Name:     params.Name,
+			TTL:      params.TTL,
+			Proxied:  params.Proxied,
+			Type:     params.Type,
+			Content:  params.Content,
+			Priority: params.Priority,
 		}
 	default:
 		return cloudflare.DNSRecord{}
@@ -508,6 +511,51 @@ func TestCloudflareCname(t *testing.T) {
 	)
 }
 
+func TestCloudflareMX(t *testing.T) {
+	endpoints := []*endpoint.Endpoint{
+		{
+			RecordType: "MX",
+			DNSName:    "bar.com",
+			Targets:    endpoint.Targets{"10 mail.bar.com"},
+		},
+	}
+
+	AssertActions(t, &CloudFlareProvider{}, endpoints, []MockAction{
+		{
+			Name:     "Create",
+			ZoneId:   "001",
+			RecordId: generateDNSRecordID("MX", "bar.com", "mail.bar.com"),
+			RecordData: cloudflare.DNSRecord{
+				ID:       generateDNSRecordID("MX", "bar.com", "mail.bar.com"),
+				Type:     "MX",
+				Name:     "bar.com",
+				Content:  "mail.bar.com",
+				Priority: cloudflare.Uint16Ptr(10),
+				TTL:      1,
+				Proxied:  proxyDisabled,
+			},
+		},
+	},
+		[]string{endpoint.RecordTypeA, endpoint.RecordTypeCNAME},
+	)
+}
+
+func TestCloudflareMXRecords(t *testing.T) {
+	client := NewMockCloudFlareClientWithRecords(map[string][]cloudflare.DNSRecord{
+		"001": {
+			{ID: "1", Name: "bar.com", Type: "MX", Content: "mail.bar.com", Priority: cloudflare.Uint16Ptr(10), TTL: 120},
+			{ID: "2", Name: "bar.com", Type: "MX", Content: "backup.bar.com", Priority: cloudflare.Uint16Ptr(20), TTL: 120},
+		},
+	})
+	p := &CloudFlareProvider{Client: client}
+
+	records, err := p.Records(context.Background())
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, "MX", records[0].RecordType)
+	assert.ElementsMatch(t, endpoint.Targets{"10 mail.bar.com", "20 backup.bar.com"}, records[0].Targets)
+}
+
 func TestCloudflareCustomTTL(t *testing.T) {
 	endpoints := []*endpoint.Endpoint{
 		{
@@ -877,6 +925,37 @@ func TestCloudflareRecords(t *testing.T) {
 	}
 }
 
+func TestCloudflareStreamRecords(t *testing.T) {
+	client := NewMockCloudFlareClientWithRecords(map[string][]cloudflare.DNSRecord{
+		"001": ExampleDomain,
+	})
+	p := &CloudFlareProvider{
+		Client:           client,
+		DNSRecordsConfig: DNSRecordsConfig{PerPage: 1},
+	}
+	ctx := context.Background()
+
+	names, err := p.ZoneNames(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"bar.com", "foo.com"}, names)
+
+	streamed := map[string][]*endpoint.Endpoint{}
+	require.NoError(t, p.StreamRecords(ctx, func(zone string, records []*endpoint.Endpoint) error {
+		streamed[zone] = records
+		return nil
+	}))
+	assert.Len(t, streamed["bar.com"], 2)
+	assert.Empty(t, streamed["foo.com"])
+	assert.Contains(t, streamed, "foo.com")
+
+	records, err := p.ZoneRecords(ctx, "foobar.bar.com")
+	require.NoError(t, err)
+	assert.Len(t, records, 2)
+	records, err = p.ZoneRecords(ctx, "foo.com")
+	require.NoError(t, err)
+	assert.Empty(t, records)
+}
+
 func TestCloudflareProvider(t *testing.T) {
 	var err error
 
@@ -952,6 +1031,8 @@ func TestCloudflareProvider(t *testing.T) {
 				RegionalServicesConfig{Enabled: false},
 				CustomHostnamesConfig{Enabled: false},
 				DNSRecordsConfig{PerPage: 5000, Comment: ""},
+				nil,
+				nil,
 			)
 			if err != nil && !tc.ShouldFail {
 				t.Errorf("should not fail, %s", err)
@@ -1472,6 +1553,28 @@ func TestProviderPropertiesIdempotency(t *testing.T) {
 	}
 }
 
+func TestCloudflareAdjustEndpointsComment(t *testing.T) {
+	provider := &CloudFlareProvider{}
+	endpoints, err := provider.AdjustEndpoints([]*endpoint.Endpoint{
+		endpoint.NewEndpoint("generic.bar.com", endpoint.RecordTypeA, "1.2.3.4").
+			WithLabel(endpoint.CommentLabelKey, "owned by team a"),
+		endpoint.NewEndpoint("specific.bar.com", endpoint.RecordTypeA, "1.2.3.4").
+			WithLabel(endpoint.CommentLabelKey, "owned by team a").
+			WithProviderSpecific(annotations.CloudflareRecordCommentKey, "cloudflare comment"),
+		endpoint.NewEndpoint("none.bar.com", endpoint.RecordTypeA, "1.2.3.4"),
+	})
+	assert.NoError(t, err)
+
+	comment, ok := endpoints[0].GetProviderSpecificProperty(annotations.CloudflareRecordCommentKey)
+	assert.True(t, ok)
+	assert.Equal(t, "owned by team a", comment)
+	comment, ok = endpoints[1].GetProviderSpecificProperty(annotations.CloudflareRecordCommentKey)
+	assert.True(t, ok)
+	assert.Equal(t, "cloudflare comment", comment)
+	_, ok = endpoints[2].GetProviderSpecificProperty(annotations.CloudflareRecordCommentKey)
+	assert.False(t, ok)
+}
+
 func TestCloudflareComplexUpdate(t *testing.T) {
 	client := NewMockCloudFlareClientWithRecords(map[string][]cloudflare.DNSRecord{
 		"001": ExampleDomain,
@@ -1621,6 +1724,8 @@ func TestCloudFlareProvider_Region(t *testing.T) {
 		RegionalServicesConfig{Enabled: false, RegionKey: "us"},
 		CustomHostnamesConfig{Enabled: false},
 		DNSRecordsConfig{PerPage: 50, Comment: ""},
+		nil,
+		nil,
 	)
 	assert.NoError(t, err, "should not fail to create provider")
 	assert.True(t, provider.RegionalServicesConfig.Enabled, "expect regional services to be enabled")
@@ -1639,6 +1744,8 @@ func TestCloudFlareProvider_newCloudFlareChange(t *testing.T) {
 		RegionalServicesConfig{Enabled: true, RegionKey: "us"},
 		CustomHostnamesConfig{Enabled: false},
 		DNSRecordsConfig{PerPage: 50},
+		nil,
+		nil,
 	)
 	if err != nil {
 		t.Fatal(err)
@@ -1682,6 +1789,8 @@ func TestCloudFlareProvider_newCloudFlareChange(t *testing.T) {
 		RegionalServicesConfig{Enabled: true, RegionKey: "us"},
 		CustomHostnamesConfig{Enabled: false},
 		DNSRecordsConfig{PerPage: 50, Comment: paidValidCommentBuilder.String()},
+		nil,
+		nil,
 	)
 	if err != nil {
 		t.Fatal(err)
@@ -0,0 +1,49 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+// Concurrency is the maximum numbers of requests a provider sending them in parallel sends at the
+// same time: All for both the reads and the writes, and Reads and Writes, which override it. The
+// provider uses its own defaults, derived from the quotas of its API, for the zero values.
+type Concurrency struct {
+	All    int
+	Reads  int
+	Writes int
+}
+
+// ReadLimit returns the maximum number of read requests the provider sends at the same time, the
+// given default of the provider unless it's configured.
+func (c Concurrency) ReadLimit(defaultLimit int) int {
+	return c.limit(c.Reads, defaultLimit)
+}
+
+// WriteLimit returns the maximum number of write requests the provider sends at the same time,
+// the given default of the provider unless it's configured.
+func (c Concurrency) WriteLimit(defaultLimit int) int {
+	return c.limit(c.Writes, defaultLimit)
+}
+
+func (c Concurrency) limit(operation, defaultLimit int) int {
+	switch {
+	case operation > 0:
+		return operation
+	case c.All > 0:
+		return c.All
+	default:
+		return defaultLimit
+	}
+}
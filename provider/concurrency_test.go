@@ -0,0 +1,43 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConcurrency(t *testing.T) {
+	// the defaults of the providers apply unless configured
+	c := Concurrency{}
+	assert.Equal(t, 5, c.ReadLimit(5))
+	assert.Equal(t, 2, c.WriteLimit(2))
+
+	// the provider concurrency applies to both reads and writes
+	c = Concurrency{All: 10}
+	assert.Equal(t, 10, c.ReadLimit(5))
+	assert.Equal(t, 10, c.WriteLimit(2))
+
+	// and the read and write concurrencies override it
+	c = Concurrency{All: 10, Reads: 20, Writes: 1}
+	assert.Equal(t, 20, c.ReadLimit(5))
+	assert.Equal(t, 1, c.WriteLimit(2))
+	c = Concurrency{Writes: 3}
+	assert.Equal(t, 5, c.ReadLimit(5))
+	assert.Equal(t, 3, c.WriteLimit(2))
+}
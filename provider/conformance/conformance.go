@@ -0,0 +1,284 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package conformance is a test suite checking that a provider creates, updates and deletes
+// records as ExternalDNS expects. It runs with go test against any provider, e.g. a webhook
+// provider or a provider configured with real credentials:
+//
+//	func TestConformance(t *testing.T) {
+//		conformance.Run(t, p, conformance.Options{Zone: "conformance.example.org"})
+//	}
+package conformance
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/idna"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider"
+)
+
+// Options configures the suite.
+type Options struct {
+	// Zone is the zone the records are created in. It must exist and be managed by the provider,
+	// and should be dedicated to the suite.
+	Zone string
+	// RecordTypes are the record types whose creation, update and deletion are tested,
+	// A, AAAA, CNAME, TXT, MX and SRV by default.
+	RecordTypes []string
+	// BatchSize is the number of records created and deleted in a single call, 100 by default.
+	BatchSize int
+	// Prefix is the first label of the names of the records, unique to each run by default so
+	// that concurrent runs don't conflict.
+	Prefix string
+	// Timeout is how long the records are read again until they reflect the changes, for the
+	// providers applying the changes asynchronously. They are read once when zero.
+	Timeout time.Duration
+	// SkipUnicode and SkipLongTXT skip the tests of the names with non-ASCII characters and of
+	// the TXT values longer than 255 characters, for the providers that don't support them.
+	SkipUnicode bool
+	SkipLongTXT bool
+}
+
+// The targets of the records of each type, before and after their update.
+var sampleTargets = map[string][2]string{
+	endpoint.RecordTypeA:     {"192.0.2.1", "192.0.2.2"},
+	endpoint.RecordTypeAAAA:  {"2001:db8::1", "2001:db8::2"},
+	endpoint.RecordTypeCNAME: {"target1.example.com", "target2.example.com"},
+	endpoint.RecordTypeTXT:   {"conformance-1", "conformance-2"},
+	endpoint.RecordTypeMX:    {"10 mail1.example.com", "20 mail2.example.com"},
+	endpoint.RecordTypeSRV:   {"10 5 5060 sip1.example.com", "20 10 5061 sip2.example.com"},
+	endpoint.RecordTypeNS:    {"ns1.example.com", "ns2.example.com"},
+	endpoint.RecordTypePTR:   {"host1.example.com", "host2.example.com"},
+}
+
+// Run runs the suite against the provider. The records created are deleted at the end of
+// each test, even when it fails.
+func Run(t *testing.T, p provider.Provider, opts Options) {
+	t.Helper()
+	require.NotEmpty(t, opts.Zone, "the zone of the conformance suite is required")
+	if len(opts.RecordTypes) == 0 {
+		opts.RecordTypes = []string{endpoint.RecordTypeA, endpoint.RecordTypeAAAA, endpoint.RecordTypeCNAME, endpoint.RecordTypeTXT, endpoint.RecordTypeMX, endpoint.RecordTypeSRV}
+	}
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = 100
+	}
+	if opts.Prefix == "" {
+		opts.Prefix = fmt.Sprintf("conformance-%d", time.Now().Unix())
+	}
+	s := &suite{provider: p, opts: opts}
+
+	t.Run("Lifecycle", func(t *testing.T) {
+		for _, recordType := range opts.RecordTypes {
+			t.Run(recordType, func(t *testing.T) {
+				s.testLifecycle(t, recordType)
+			})
+		}
+	})
+	t.Run("LargeBatch", s.testLargeBatch)
+	t.Run("Unicode", func(t *testing.T) {
+		if opts.SkipUnicode {
+			t.Skip("skipped by the options")
+		}
+		s.testUnicode(t)
+	})
+	t.Run("LongTXT", func(t *testing.T) {
+		if opts.SkipLongTXT {
+			t.Skip("skipped by the options")
+		}
+		s.testLongTXT(t)
+	})
+}
+
+type suite struct {
+	provider provider.Provider
+	opts     Options
+}
+
+// name returns the name of a record of the suite.
+func (s *suite) name(label string) string {
+	return strings.ToLower(label + "." + s.opts.Prefix + "." + s.opts.Zone)
+}
+
+// testLifecycle creates, updates and deletes a record of a type.
+func (s *suite) testLifecycle(t *testing.T, recordType string) {
+	targets, ok := sampleTargets[recordType]
+	if !ok {
+		t.Skipf("no sample target for record type %s", recordType)
+	}
+	name := s.name(strings.ToLower(recordType))
+	created := endpoint.NewEndpointWithTTL(name, recordType, 300, targets[0])
+	updated := endpoint.NewEndpointWithTTL(name, recordType, 300, targets[1])
+
+	s.apply(t, &plan.Changes{Create: []*endpoint.Endpoint{created}}, created)
+	s.expect(t, []*endpoint.Endpoint{created}, nil)
+
+	s.apply(t, &plan.Changes{UpdateOld: []*endpoint.Endpoint{created}, UpdateNew: []*endpoint.Endpoint{updated}})
+	s.expect(t, []*endpoint.Endpoint{updated}, nil)
+
+	s.apply(t, &plan.Changes{Delete: []*endpoint.Endpoint{updated}})
+	s.expect(t, nil, []*endpoint.Endpoint{updated})
+}
+
+// testLargeBatch creates and deletes many records in a single call.
+func (s *suite) testLargeBatch(t *testing.T) {
+	records := make([]*endpoint.Endpoint, 0, s.opts.BatchSize)
+	for i := range s.opts.BatchSize {
+		records = append(records, endpoint.NewEndpointWithTTL(s.name(fmt.Sprintf("batch-%d", i)), endpoint.RecordTypeA, 300, fmt.Sprintf("192.0.2.%d", i%250+1)))
+	}
+	s.apply(t, &plan.Changes{Create: records}, records...)
+	s.expect(t, records, nil)
+
+	s.apply(t, &plan.Changes{Delete: records})
+	s.expect(t, nil, records)
+}
+
+// testUnicode creates and deletes a record whose name has non-ASCII characters. The names
+// reach the providers in their ASCII form, and the records are read back in that form.
+func (s *suite) testUnicode(t *testing.T) {
+	name, err := idna.Lookup.ToASCII(s.name("bücher-ñandú"))
+	require.NoError(t, err)
+	record := endpoint.NewEndpointWithTTL(name, endpoint.RecordTypeA, 300, "192.0.2.1")
+
+	s.apply(t, &plan.Changes{Create: []*endpoint.Endpoint{record}}, record)
+	s.expect(t, []*endpoint.Endpoint{record}, nil)
+
+	s.apply(t, &plan.Changes{Delete: []*endpoint.Endpoint{record}})
+	s.expect(t, nil, []*endpoint.Endpoint{record})
+}
+
+// testLongTXT creates and deletes a TXT record whose value is longer than the 255 characters
+// of a character string, which the provider must split and join back.
+func (s *suite) testLongTXT(t *testing.T) {
+	value := strings.Repeat("external-dns-conformance-", 20)
+	record := endpoint.NewEndpointWithTTL(s.name("long-txt"), endpoint.RecordTypeTXT, 300, value)
+
+	s.apply(t, &plan.Changes{Create: []*endpoint.Endpoint{record}}, record)
+	s.expect(t, []*endpoint.Endpoint{record}, nil)
+
+	s.apply(t, &plan.Changes{Delete: []*endpoint.Endpoint{record}})
+	s.expect(t, nil, []*endpoint.Endpoint{record})
+}
+
+// apply applies the changes, and deletes the records created at the end of the test if they
+// still exist.
+func (s *suite) apply(t *testing.T, changes *plan.Changes, created ...*endpoint.Endpoint) {
+	t.Helper()
+	if len(created) > 0 {
+		t.Cleanup(func() { s.cleanup(t, created) })
+	}
+	require.NoError(t, s.provider.ApplyChanges(context.Background(), changes))
+}
+
+// expect checks that the provider has the present records, with the same targets, and
+// doesn't have the absent ones, reading the records again until the timeout if needed.
+func (s *suite) expect(t *testing.T, present, absent []*endpoint.Endpoint) {
+	t.Helper()
+	deadline := time.Now().Add(s.opts.Timeout)
+	for {
+		problems, err := s.check(present, absent)
+		require.NoError(t, err)
+		if len(problems) == 0 {
+			return
+		}
+		if time.Now().After(deadline) {
+			assert.Empty(t, problems, "the records of the provider don't reflect the changes")
+			t.FailNow()
+		}
+		time.Sleep(time.Second)
+	}
+}
+
+func (s *suite) check(present, absent []*endpoint.Endpoint) ([]string, error) {
+	records, err := s.records()
+	if err != nil {
+		return nil, err
+	}
+	var problems []string
+	for _, ep := range present {
+		record, ok := records[recordKey(ep)]
+		switch {
+		case !ok:
+			problems = append(problems, fmt.Sprintf("%s %s is missing", ep.DNSName, ep.RecordType))
+		case !sameTargets(record.Targets, ep.Targets):
+			problems = append(problems, fmt.Sprintf("%s %s has targets %v instead of %v", ep.DNSName, ep.RecordType, record.Targets, ep.Targets))
+		}
+	}
+	for _, ep := range absent {
+		if _, ok := records[recordKey(ep)]; ok {
+			problems = append(problems, fmt.Sprintf("%s %s was not deleted", ep.DNSName, ep.RecordType))
+		}
+	}
+	return problems, nil
+}
+
+// records returns the records of the zone of the suite by name and type.
+func (s *suite) records() (map[string]*endpoint.Endpoint, error) {
+	records, err := provider.ZoneRecords(context.Background(), s.provider, s.opts.Zone)
+	if err != nil {
+		return nil, err
+	}
+	byKey := make(map[string]*endpoint.Endpoint, len(records))
+	for _, ep := range records {
+		byKey[recordKey(ep)] = ep
+	}
+	return byKey, nil
+}
+
+// cleanup deletes the records which still exist, as they are read from the provider.
+func (s *suite) cleanup(t *testing.T, created []*endpoint.Endpoint) {
+	records, err := s.records()
+	if err != nil {
+		t.Logf("Failed to clean up the records of the suite: %v", err)
+		return
+	}
+	var leftovers []*endpoint.Endpoint
+	for _, ep := range created {
+		if record, ok := records[recordKey(ep)]; ok {
+			leftovers = append(leftovers, record)
+		}
+	}
+	if len(leftovers) == 0 {
+		return
+	}
+	if err := s.provider.ApplyChanges(context.Background(), &plan.Changes{Delete: leftovers}); err != nil {
+		t.Logf("Failed to clean up the records of the suite: %v", err)
+	}
+}
+
+func recordKey(ep *endpoint.Endpoint) string {
+	return strings.ToLower(strings.TrimSuffix(ep.DNSName, ".")) + " " + ep.RecordType
+}
+
+// sameTargets compares the targets regardless of their order, case and trailing dot.
+func sameTargets(a, b endpoint.Targets) bool {
+	normalize := func(targets endpoint.Targets) endpoint.Targets {
+		normalized := make(endpoint.Targets, 0, len(targets))
+		for _, target := range targets {
+			normalized = append(normalized, strings.ToLower(strings.TrimSuffix(target, ".")))
+		}
+		return normalized
+	}
+	return normalize(a).Same(normalize(b))
+}
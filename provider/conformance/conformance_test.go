@@ -0,0 +1,79 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package conformance
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	providertesting "sigs.k8s.io/external-dns/provider/testing"
+	"sigs.k8s.io/external-dns/provider/webhook"
+	webhookapi "sigs.k8s.io/external-dns/provider/webhook/api"
+)
+
+var allRecordTypes = []string{
+	endpoint.RecordTypeA, endpoint.RecordTypeAAAA, endpoint.RecordTypeCNAME, endpoint.RecordTypeTXT,
+	endpoint.RecordTypeMX, endpoint.RecordTypeSRV, endpoint.RecordTypeNS, endpoint.RecordTypePTR,
+}
+
+func TestConformanceInMemory(t *testing.T) {
+	p := providertesting.NewProvider(providertesting.WithZones("example.org"))
+	Run(t, p, Options{Zone: "example.org", RecordTypes: allRecordTypes})
+
+	records, err := p.Records(t.Context())
+	require.NoError(t, err)
+	require.Empty(t, records, "the records of the suite are deleted")
+}
+
+// TestConformanceWebhook runs the suite against the webhook provider, served by the webhook
+// API in front of the in-memory provider.
+func TestConformanceWebhook(t *testing.T) {
+	server := webhookapi.WebhookServer{Provider: providertesting.NewProvider(providertesting.WithZones("example.org"))}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", server.NegotiateHandler)
+	mux.HandleFunc(webhookapi.UrlRecords, server.RecordsHandler)
+	mux.HandleFunc(webhookapi.UrlAdjustEndpoints, server.AdjustEndpointsHandler)
+	svr := httptest.NewServer(mux)
+	defer svr.Close()
+
+	p, err := webhook.NewWebhookProvider(svr.URL)
+	require.NoError(t, err)
+	Run(t, p, Options{Zone: "example.org"})
+}
+
+// TestConformanceExternalWebhook runs the suite against the webhook provider listening on
+// EXTERNAL_DNS_CONFORMANCE_WEBHOOK_URL, with the zone EXTERNAL_DNS_CONFORMANCE_ZONE.
+func TestConformanceExternalWebhook(t *testing.T) {
+	url, zone := os.Getenv("EXTERNAL_DNS_CONFORMANCE_WEBHOOK_URL"), os.Getenv("EXTERNAL_DNS_CONFORMANCE_ZONE")
+	if url == "" || zone == "" {
+		t.Skip("EXTERNAL_DNS_CONFORMANCE_WEBHOOK_URL and EXTERNAL_DNS_CONFORMANCE_ZONE are not set")
+	}
+	p, err := webhook.NewWebhookProvider(url)
+	require.NoError(t, err)
+	Run(t, p, Options{Zone: zone})
+}
+
+func TestSameTargets(t *testing.T) {
+	require.True(t, sameTargets(endpoint.Targets{"B.example.com.", "a.example.com"}, endpoint.Targets{"a.example.com", "b.example.com"}))
+	require.True(t, sameTargets(endpoint.Targets{"2001:db8:0::1"}, endpoint.Targets{"2001:db8::1"}))
+	require.False(t, sameTargets(endpoint.Targets{"a.example.com"}, endpoint.Targets{"a.example.com", "b.example.com"}))
+}
@@ -19,6 +19,7 @@ package digitalocean
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"os"
 	"strconv"
 	"strings"
@@ -26,9 +27,11 @@ import (
 	"github.com/digitalocean/godo"
 	log "github.com/sirupsen/logrus"
 	"golang.org/x/oauth2"
+	"golang.org/x/sync/errgroup"
 
 	"sigs.k8s.io/external-dns/endpoint"
 	"sigs.k8s.io/external-dns/pkg/apis/externaldns"
+	"sigs.k8s.io/external-dns/pkg/egress"
 	"sigs.k8s.io/external-dns/plan"
 	"sigs.k8s.io/external-dns/provider"
 )
@@ -36,17 +39,28 @@ import (
 const (
 	// defaultTTL is the default TTL value
 	defaultTTL = 300
+	// defaultReadConcurrency is the maximum number of pages fetched at the same time, unless
+	// configured: the API allows 250 requests per minute
+	defaultReadConcurrency = 5
+	// domainURNPrefix prefixes the name of the domains in the URN of the project resources
+	domainURNPrefix = "do:domain:"
 )
 
 // DigitalOceanProvider is an implementation of Provider for Digital Ocean's DNS.
 type DigitalOceanProvider struct {
 	provider.BaseProvider
 	Client godo.DomainsService
+	// Projects lists the domains of the projects
+	Projects godo.ProjectsService
 	// only consider hosted zones managing domains ending in this suffix
 	domainFilter *endpoint.DomainFilter
+	// only consider the domains of these projects, if any
+	projects []string
 	// page size when querying paginated APIs
-	apiPageSize int
-	DryRun      bool
+	apiPageSize  int
+	DryRun       bool
+	missingZones *provider.MissingZoneCache
+	concurrency  provider.Concurrency
 }
 
 type digitalOceanChangeCreate struct {
@@ -77,12 +91,13 @@ func (c *digitalOceanChanges) Empty() bool {
 }
 
 // NewDigitalOceanProvider initializes a new DigitalOcean DNS based Provider.
-func NewDigitalOceanProvider(ctx context.Context, domainFilter *endpoint.DomainFilter, dryRun bool, apiPageSize int) (*DigitalOceanProvider, error) {
+func NewDigitalOceanProvider(ctx context.Context, domainFilter *endpoint.DomainFilter, projects []string, dryRun bool, apiPageSize int, missingZones *provider.MissingZoneCache, egressConfig *egress.Config, concurrency provider.Concurrency) (*DigitalOceanProvider, error) {
 	token, ok := os.LookupEnv("DO_TOKEN")
 	if !ok {
 		return nil, fmt.Errorf("no token found")
 	}
-	oauthClient := oauth2.NewClient(ctx, oauth2.StaticTokenSource(&oauth2.Token{
+	// the base client of the OAuth2 client is that of the context
+	oauthClient := oauth2.NewClient(context.WithValue(ctx, oauth2.HTTPClient, &http.Client{Transport: egressConfig.Transport()}), oauth2.StaticTokenSource(&oauth2.Token{
 		AccessToken: token,
 	}))
 	client, err := godo.New(oauthClient, godo.SetUserAgent(externaldns.UserAgent()))
@@ -92,9 +107,13 @@ func NewDigitalOceanProvider(ctx context.Context, domainFilter *endpoint.DomainF
 
 	p := &DigitalOceanProvider{
 		Client:       client.Domains,
+		Projects:     client.Projects,
 		domainFilter: domainFilter,
+		projects:     projects,
 		apiPageSize:  apiPageSize,
 		DryRun:       dryRun,
+		missingZones: missingZones,
+		concurrency:  concurrency,
 	}
 	return p, nil
 }
@@ -108,7 +127,16 @@ func (p *DigitalOceanProvider) Zones(ctx context.Context) ([]godo.Domain, error)
 		return nil, err
 	}
 
+	projectDomains, err := p.fetchProjectDomains(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	for _, zone := range zones {
+		if projectDomains != nil && !projectDomains[zone.Name] {
+			log.Debugf("Skipping domain %s, it isn't in projects %v", zone.Name, p.projects)
+			continue
+		}
 		if p.domainFilter.Match(zone.Name) {
 			result = append(result, zone)
 		}
@@ -117,6 +145,28 @@ func (p *DigitalOceanProvider) Zones(ctx context.Context) ([]godo.Domain, error)
 	return result, nil
 }
 
+// fetchProjectDomains returns the domains of the projects, or nil when the domains aren't restricted to projects.
+func (p *DigitalOceanProvider) fetchProjectDomains(ctx context.Context) (map[string]bool, error) {
+	if len(p.projects) == 0 {
+		return nil, nil
+	}
+	domains := map[string]bool{}
+	for _, project := range p.projects {
+		resources, err := listAllPages(ctx, p.apiPageSize, p.concurrency.ReadLimit(defaultReadConcurrency), func(ctx context.Context, opts *godo.ListOptions) ([]godo.ProjectResource, *godo.Response, error) {
+			return p.Projects.ListResources(ctx, project, opts)
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list the resources of project %s: %w", project, err)
+		}
+		for _, resource := range resources {
+			if name, ok := strings.CutPrefix(resource.URN, domainURNPrefix); ok {
+				domains[name] = true
+			}
+		}
+	}
+	return domains, nil
+}
+
 // Merge Endpoints with the same Name and Type into a single endpoint with multiple Targets.
 func mergeEndpointsByNameType(endpoints []*endpoint.Endpoint) []*endpoint.Endpoint {
 	endpointsByNameType := map[string][]*endpoint.Endpoint{}
@@ -149,6 +199,12 @@ func mergeEndpointsByNameType(endpoints []*endpoint.Endpoint) []*endpoint.Endpoi
 	return result
 }
 
+// WildcardSupport implements provider.WildcardSupporter, as DigitalOcean only accepts an asterisk
+// as the whole first label of a record name, which excludes the names of the wildcard TXT records.
+func (p *DigitalOceanProvider) WildcardSupport() provider.WildcardSupport {
+	return provider.WildcardSupport{Records: true}
+}
+
 // Records returns the list of records in a given zone.
 func (p *DigitalOceanProvider) Records(ctx context.Context) ([]*endpoint.Endpoint, error) {
 	zones, err := p.Zones(ctx)
@@ -199,53 +255,71 @@ func (p *DigitalOceanProvider) Records(ctx context.Context) ([]*endpoint.Endpoin
 }
 
 func (p *DigitalOceanProvider) fetchRecords(ctx context.Context, zoneName string) ([]godo.DomainRecord, error) {
-	allRecords := []godo.DomainRecord{}
-	listOptions := &godo.ListOptions{PerPage: p.apiPageSize}
-	for {
-		records, resp, err := p.Client.Records(ctx, zoneName, listOptions)
-		if err != nil {
+	return listAllPages(ctx, p.apiPageSize, p.concurrency.ReadLimit(defaultReadConcurrency), func(ctx context.Context, opts *godo.ListOptions) ([]godo.DomainRecord, *godo.Response, error) {
+		return p.Client.Records(ctx, zoneName, opts)
+	})
+}
+
+func (p *DigitalOceanProvider) fetchZones(ctx context.Context) ([]godo.Domain, error) {
+	return listAllPages(ctx, p.apiPageSize, p.concurrency.ReadLimit(defaultReadConcurrency), p.Client.List)
+}
+
+// listAllPages returns the items of all the pages of a paginated API. When the first page tells the
+// total number of items, the other pages are fetched concurrently, otherwise they're followed one
+// after the other.
+func listAllPages[T any](ctx context.Context, pageSize, concurrency int, list func(context.Context, *godo.ListOptions) ([]T, *godo.Response, error)) ([]T, error) {
+	items, resp, err := list(ctx, &godo.ListOptions{PerPage: pageSize})
+	if err != nil {
+		return nil, err
+	}
+	if resp == nil || resp.Links == nil || resp.Links.IsLastPage() {
+		return items, nil
+	}
+
+	if resp.Meta != nil && pageSize > 0 && len(items) == pageSize {
+		pages := make([][]T, (resp.Meta.Total+pageSize-1)/pageSize)
+		if len(pages) == 0 {
+			return items, nil
+		}
+		pages[0] = items
+		eg, ctx := errgroup.WithContext(ctx)
+		eg.SetLimit(concurrency)
+		for i := 1; i < len(pages); i++ {
+			eg.Go(func() error {
+				page, _, err := list(ctx, &godo.ListOptions{Page: i + 1, PerPage: pageSize})
+				pages[i] = page
+				return err
+			})
+		}
+		if err := eg.Wait(); err != nil {
 			return nil, err
 		}
-		allRecords = append(allRecords, records...)
-
-		if resp == nil || resp.Links == nil || resp.Links.IsLastPage() {
-			break
+		all := make([]T, 0, resp.Meta.Total)
+		for _, page := range pages {
+			all = append(all, page...)
 		}
+		return all, nil
+	}
 
+	all := items
+	listOptions := &godo.ListOptions{PerPage: pageSize}
+	for {
 		page, err := resp.Links.CurrentPage()
 		if err != nil {
 			return nil, err
 		}
-
 		listOptions.Page = page + 1
-	}
-
-	return allRecords, nil
-}
 
-func (p *DigitalOceanProvider) fetchZones(ctx context.Context) ([]godo.Domain, error) {
-	allZones := []godo.Domain{}
-	listOptions := &godo.ListOptions{PerPage: p.apiPageSize}
-	for {
-		zones, resp, err := p.Client.List(ctx, listOptions)
+		items, resp, err = list(ctx, listOptions)
 		if err != nil {
 			return nil, err
 		}
-		allZones = append(allZones, zones...)
+		all = append(all, items...)
 
 		if resp == nil || resp.Links == nil || resp.Links.IsLastPage() {
-			break
-		}
-
-		page, err := resp.Links.CurrentPage()
-		if err != nil {
-			return nil, err
+			return all, nil
 		}
-
-		listOptions.Page = page + 1
 	}
-
-	return allZones, nil
 }
 
 func (p *DigitalOceanProvider) getRecordsByDomain(ctx context.Context) (map[string][]godo.DomainRecord, provider.ZoneIDName, error) {
@@ -400,13 +474,17 @@ func getTTLFromEndpoint(ep *endpoint.Endpoint) int {
 	return defaultTTL
 }
 
-func endpointsByZone(zoneNameIDMapper provider.ZoneIDName, endpoints []*endpoint.Endpoint) map[string][]*endpoint.Endpoint {
+// endpointsByZone separates the endpoints by zone. The names matching no zone are reported to
+// missingZones.
+func endpointsByZone(zoneNameIDMapper provider.ZoneIDName, endpoints []*endpoint.Endpoint, missingZones *provider.MissingZoneCache) map[string][]*endpoint.Endpoint {
 	endpointsByZone := make(map[string][]*endpoint.Endpoint)
 
 	for _, ep := range endpoints {
-		zoneID, _ := zoneNameIDMapper.FindZone(ep.DNSName)
+		zoneID, _ := zoneNameIDMapper.FindZoneForEndpoint(ep)
 		if zoneID == "" {
-			log.Debugf("Skipping record %s because no hosted zone matching record DNS Name was detected", ep.DNSName)
+			if missingZones.Report(ep.DNSName) {
+				log.Debugf("Skipping record %s because no hosted zone matching record DNS Name was detected", ep.DNSName)
+			}
 			continue
 		}
 		endpointsByZone[zoneID] = append(endpointsByZone[zoneID], ep)
@@ -641,9 +719,9 @@ func (p *DigitalOceanProvider) ApplyChanges(ctx context.Context, planChanges *pl
 		return err
 	}
 
-	createsByDomain := endpointsByZone(zoneNameIDMapper, planChanges.Create)
-	updatesByDomain := endpointsByZone(zoneNameIDMapper, planChanges.UpdateNew)
-	deletesByDomain := endpointsByZone(zoneNameIDMapper, planChanges.Delete)
+	createsByDomain := endpointsByZone(zoneNameIDMapper, planChanges.Create, p.missingZones)
+	updatesByDomain := endpointsByZone(zoneNameIDMapper, planChanges.UpdateNew, p.missingZones)
+	deletesByDomain := endpointsByZone(zoneNameIDMapper, planChanges.Delete, p.missingZones)
 
 	var changes digitalOceanChanges
 
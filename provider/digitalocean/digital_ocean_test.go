@@ -22,6 +22,7 @@ import (
 	"os"
 	"reflect"
 	"sort"
+	"sync"
 	"testing"
 
 	"github.com/digitalocean/godo"
@@ -31,6 +32,7 @@ import (
 
 	"sigs.k8s.io/external-dns/endpoint"
 	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider"
 )
 
 type mockDigitalOceanClient struct{}
@@ -306,6 +308,78 @@ func TestDigitalOceanZones(t *testing.T) {
 	})
 }
 
+type mockDigitalOceanProjects struct {
+	godo.ProjectsService
+	resources map[string][]godo.ProjectResource
+}
+
+func (m *mockDigitalOceanProjects) ListResources(_ context.Context, projectID string, _ *godo.ListOptions) ([]godo.ProjectResource, *godo.Response, error) {
+	resources, ok := m.resources[projectID]
+	if !ok {
+		return nil, nil, fmt.Errorf("project %s not found", projectID)
+	}
+	return resources, nil, nil
+}
+
+func TestDigitalOceanZonesProjects(t *testing.T) {
+	provider := &DigitalOceanProvider{
+		Client: &mockDigitalOceanClient{},
+		Projects: &mockDigitalOceanProjects{resources: map[string][]godo.ProjectResource{
+			"team-a": {{URN: "do:domain:foo.com"}, {URN: "do:droplet:1234"}},
+			"team-b": {{URN: "do:domain:bar.com"}, {URN: "do:domain:bar.de"}},
+		}},
+		domainFilter: endpoint.NewDomainFilter([]string{"com"}),
+		projects:     []string{"team-a", "team-b"},
+	}
+
+	zones, err := provider.Zones(context.Background())
+	require.NoError(t, err)
+	validateDigitalOceanZones(t, zones, []godo.Domain{{Name: "foo.com"}, {Name: "bar.com"}})
+
+	provider.projects = []string{"team-c"}
+	_, err = provider.Zones(context.Background())
+	require.Error(t, err)
+}
+
+func TestDigitalOceanListAllPagesConcurrently(t *testing.T) {
+	var mu sync.Mutex
+	var requested []int
+	list := func(_ context.Context, opts *godo.ListOptions) ([]int, *godo.Response, error) {
+		mu.Lock()
+		requested = append(requested, opts.Page)
+		mu.Unlock()
+		page := max(opts.Page, 1)
+		items := []int{page*2 - 1, page * 2}
+		if page == 4 {
+			items = items[:1]
+		}
+		return items, &godo.Response{
+			Links: &godo.Links{Pages: &godo.Pages{Next: fmt.Sprintf("http://example.com/v2/domains/?page=%d", page+1)}},
+			Meta:  &godo.Meta{Total: 7},
+		}, nil
+	}
+
+	items, err := listAllPages(context.Background(), 2, defaultReadConcurrency, list)
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 3, 4, 5, 6, 7}, items)
+	assert.ElementsMatch(t, []int{0, 2, 3, 4}, requested)
+}
+
+func TestDigitalOceanListAllPagesFailure(t *testing.T) {
+	list := func(_ context.Context, opts *godo.ListOptions) ([]int, *godo.Response, error) {
+		if opts.Page == 3 {
+			return nil, nil, fmt.Errorf("rate limited")
+		}
+		return []int{1, 2}, &godo.Response{
+			Links: &godo.Links{Pages: &godo.Pages{Next: "http://example.com/v2/domains/?page=2"}},
+			Meta:  &godo.Meta{Total: 6},
+		}, nil
+	}
+
+	_, err := listAllPages(context.Background(), 2, defaultReadConcurrency, list)
+	require.EqualError(t, err, "rate limited")
+}
+
 func TestDigitalOceanMakeDomainEditRequest(t *testing.T) {
 	// Ensure that records at the root of the zone get `@` as the name.
 	r1 := makeDomainEditRequest("example.com", "example.com", endpoint.RecordTypeA,
@@ -662,12 +736,12 @@ func TestDigitalOceanProcessDeleteActions(t *testing.T) {
 
 func TestNewDigitalOceanProvider(t *testing.T) {
 	_ = os.Setenv("DO_TOKEN", "xxxxxxxxxxxxxxxxx")
-	_, err := NewDigitalOceanProvider(context.Background(), endpoint.NewDomainFilter([]string{"ext-dns-test.zalando.to."}), true, 50)
+	_, err := NewDigitalOceanProvider(context.Background(), endpoint.NewDomainFilter([]string{"ext-dns-test.zalando.to."}), nil, true, 50, nil, nil, provider.Concurrency{})
 	if err != nil {
 		t.Errorf("should not fail, %s", err)
 	}
 	_ = os.Unsetenv("DO_TOKEN")
-	_, err = NewDigitalOceanProvider(context.Background(), endpoint.NewDomainFilter([]string{"ext-dns-test.zalando.to."}), true, 50)
+	_, err = NewDigitalOceanProvider(context.Background(), endpoint.NewDomainFilter([]string{"ext-dns-test.zalando.to."}), nil, true, 50, nil, nil, provider.Concurrency{})
 	if err == nil {
 		t.Errorf("expected to fail")
 	}
@@ -19,6 +19,7 @@ package dnsimple
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"os"
 	"strconv"
 	"strings"
@@ -29,6 +30,7 @@ import (
 
 	"sigs.k8s.io/external-dns/endpoint"
 	"sigs.k8s.io/external-dns/pkg/apis/externaldns"
+	"sigs.k8s.io/external-dns/pkg/egress"
 	"sigs.k8s.io/external-dns/plan"
 	"sigs.k8s.io/external-dns/provider"
 )
@@ -90,6 +92,7 @@ type dnsimpleProvider struct {
 	domainFilter *endpoint.DomainFilter
 	zoneIDFilter provider.ZoneIDFilter
 	dryRun       bool
+	missingZones *provider.MissingZoneCache
 }
 
 type dnsimpleChange struct {
@@ -98,14 +101,15 @@ type dnsimpleChange struct {
 }
 
 // NewDnsimpleProvider initializes a new Dnsimple based provider
-func NewDnsimpleProvider(domainFilter *endpoint.DomainFilter, zoneIDFilter provider.ZoneIDFilter, dryRun bool) (provider.Provider, error) {
+func NewDnsimpleProvider(domainFilter *endpoint.DomainFilter, zoneIDFilter provider.ZoneIDFilter, dryRun bool, missingZones *provider.MissingZoneCache, egressConfig *egress.Config) (provider.Provider, error) {
 	oauthToken := os.Getenv("DNSIMPLE_OAUTH")
 	if len(oauthToken) == 0 {
 		return nil, fmt.Errorf("no dnsimple oauth token provided")
 	}
 
 	ts := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: oauthToken})
-	tc := oauth2.NewClient(context.Background(), ts)
+	// the base client of the OAuth2 client is that of the context
+	tc := oauth2.NewClient(context.WithValue(context.Background(), oauth2.HTTPClient, &http.Client{Transport: egressConfig.Transport()}), ts)
 
 	client := dnsimple.NewClient(tc)
 	client.SetUserAgent(externaldns.UserAgent())
@@ -116,6 +120,7 @@ func NewDnsimpleProvider(domainFilter *endpoint.DomainFilter, zoneIDFilter provi
 		domainFilter: domainFilter,
 		zoneIDFilter: zoneIDFilter,
 		dryRun:       dryRun,
+		missingZones: missingZones,
 	}
 
 	provider.accountID = os.Getenv("DNSIMPLE_ACCOUNT_ID")
@@ -267,7 +272,9 @@ func (p *dnsimpleProvider) submitChanges(ctx context.Context, changes []*dnsimpl
 	for _, change := range changes {
 		zone := dnsimpleSuitableZone(change.ResourceRecordSet.Name, zones)
 		if zone == nil {
-			log.Debugf("Skipping record %s because no hosted zone matching record DNS Name was detected", change.ResourceRecordSet.Name)
+			if p.missingZones.Report(change.ResourceRecordSet.Name) {
+				log.Debugf("Skipping record %s because no hosted zone matching record DNS Name was detected", change.ResourceRecordSet.Name)
+			}
 			continue
 		}
 
@@ -22,9 +22,11 @@ import (
 
 	egoscale "github.com/exoscale/egoscale/v2"
 	exoapi "github.com/exoscale/egoscale/v2/api"
+	"github.com/hashicorp/go-retryablehttp"
 	log "github.com/sirupsen/logrus"
 
 	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/pkg/egress"
 	"sigs.k8s.io/external-dns/plan"
 	"sigs.k8s.io/external-dns/provider"
 )
@@ -54,10 +56,19 @@ type ExoscaleProvider struct {
 type ExoscaleOption func(*ExoscaleProvider)
 
 // NewExoscaleProvider returns ExoscaleProvider DNS provider interface implementation
-func NewExoscaleProvider(env, zone, key, secret string, dryRun bool, opts ...ExoscaleOption) (*ExoscaleProvider, error) {
+func NewExoscaleProvider(env, zone, key, secret string, dryRun bool, egressConfig *egress.Config, opts ...ExoscaleOption) (*ExoscaleProvider, error) {
+	var clientOpts []egoscale.ClientOpt
+	if egressConfig.Configured() {
+		// the default client of the SDK retries the requests, with its own transport
+		rc := retryablehttp.NewClient()
+		rc.Logger = nil
+		rc.HTTPClient.Transport = egressConfig.Transport()
+		clientOpts = append(clientOpts, egoscale.ClientOptWithHTTPClient(rc.StandardClient()))
+	}
 	client, err := egoscale.NewClient(
 		key,
 		secret,
+		clientOpts...,
 	)
 	if err != nil {
 		return nil, err
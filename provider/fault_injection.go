@@ -0,0 +1,257 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/pkg/metrics"
+	"sigs.k8s.io/external-dns/plan"
+)
+
+const (
+	faultDelay   = "delay"
+	faultError   = "error"
+	faultPartial = "partial"
+)
+
+var injectedFaultsTotal = metrics.NewCounterVecWithOpts(
+	prometheus.CounterOpts{
+		Namespace: "external_dns",
+		Subsystem: "provider",
+		Name:      "injected_faults_total",
+		Help:      "Number of faults injected in the calls to the provider by --fault-injection, by kind of fault: delay, error or partial.",
+	},
+	[]string{"fault"},
+)
+
+func init() {
+	metrics.RegisterMetric.MustRegister(injectedFaultsTotal)
+}
+
+// FaultProfile is the probability of each kind of fault injected in a call to the provider.
+type FaultProfile struct {
+	// Delay is the probability of delaying a call by up to MaxDelay.
+	Delay float64
+	// MaxDelay is the maximum delay of a delayed call.
+	MaxDelay time.Duration
+	// Error is the probability of failing a call without calling the provider.
+	Error float64
+	// Partial is the probability of applying only a part of the changes, then failing.
+	Partial float64
+}
+
+// FaultProfiles are the predefined profiles of --fault-injection-profile.
+var FaultProfiles = map[string]FaultProfile{
+	"light": {Delay: 0.1, MaxDelay: 5 * time.Second, Error: 0.05, Partial: 0.05},
+	"heavy": {Delay: 0.3, MaxDelay: 30 * time.Second, Error: 0.3, Partial: 0.2},
+}
+
+// ParseFaultProfile returns a predefined profile by name, or the profile described as a
+// comma-separated list of fault=probability and max-delay=duration, e.g. "error=0.1,max-delay=5s".
+func ParseFaultProfile(s string) (FaultProfile, error) {
+	if profile, ok := FaultProfiles[s]; ok {
+		return profile, nil
+	}
+	var profile FaultProfile
+	for _, item := range strings.Split(s, ",") {
+		key, value, ok := strings.Cut(strings.TrimSpace(item), "=")
+		if !ok {
+			return FaultProfile{}, fmt.Errorf("invalid fault %q: expected a profile name or fault=value", item)
+		}
+		if key == "max-delay" {
+			d, err := time.ParseDuration(value)
+			if err != nil || d < 0 {
+				return FaultProfile{}, fmt.Errorf("invalid max-delay %q", value)
+			}
+			profile.MaxDelay = d
+			continue
+		}
+		p, err := strconv.ParseFloat(value, 64)
+		if err != nil || p < 0 || p > 1 {
+			return FaultProfile{}, fmt.Errorf("invalid probability %q of %s: expected a number between 0 and 1", value, key)
+		}
+		switch key {
+		case faultDelay:
+			profile.Delay = p
+		case faultError:
+			profile.Error = p
+		case faultPartial:
+			profile.Partial = p
+		default:
+			return FaultProfile{}, fmt.Errorf("unknown fault %q: expected %s, %s, %s or max-delay", key, faultDelay, faultError, faultPartial)
+		}
+	}
+	if profile.Delay > 0 && profile.MaxDelay == 0 {
+		return FaultProfile{}, fmt.Errorf("a max-delay is required to delay the calls")
+	}
+	return profile, nil
+}
+
+// FaultInjectionProvider randomly delays, fails or partially applies the calls to the provider,
+// to validate the alerting and the retries of a non-production deployment. The injected errors
+// are soft errors, retried at the next synchronization as those of an unavailable API.
+type FaultInjectionProvider struct {
+	Provider
+	profile FaultProfile
+
+	mu    sync.Mutex
+	rand  *rand.Rand
+	sleep func(ctx context.Context, d time.Duration) error
+}
+
+// NewFaultInjectionProvider returns a provider injecting faults with the given profile. The
+// faults are reproducible for a given seed and sequence of calls.
+func NewFaultInjectionProvider(provider Provider, profile FaultProfile, seed int64) *FaultInjectionProvider {
+	log.Warnf("Fault injection enabled with seed %d: the calls to the provider are randomly delayed (%.2f), failed (%.2f) and partially applied (%.2f)", seed, profile.Delay, profile.Error, profile.Partial)
+	return &FaultInjectionProvider{
+		Provider: provider,
+		profile:  profile,
+		rand:     rand.New(rand.NewSource(seed)),
+		sleep:    sleepContext,
+	}
+}
+
+func (f *FaultInjectionProvider) Records(ctx context.Context) ([]*endpoint.Endpoint, error) {
+	if err := f.inject(ctx, "Records"); err != nil {
+		return nil, err
+	}
+	return f.Provider.Records(ctx)
+}
+
+func (f *FaultInjectionProvider) ApplyChanges(ctx context.Context, changes *plan.Changes) error {
+	if err := f.inject(ctx, "ApplyChanges"); err != nil {
+		return err
+	}
+	if !f.roll(f.profile.Partial) {
+		return f.Provider.ApplyChanges(ctx, changes)
+	}
+	partial, applied, total := f.partialChanges(changes)
+	injectedFaultsTotal.CounterVec.WithLabelValues(faultPartial).Inc()
+	log.Warnf("Fault injection: applying %d of %d changes", applied, total)
+	if err := f.Provider.ApplyChanges(ctx, partial); err != nil {
+		return err
+	}
+	return NewSoftErrorf("fault injection: applied %d of %d changes", applied, total)
+}
+
+func (f *FaultInjectionProvider) ZoneRecords(ctx context.Context, domain string) ([]*endpoint.Endpoint, error) {
+	if err := f.inject(ctx, "ZoneRecords"); err != nil {
+		return nil, err
+	}
+	return ZoneRecords(ctx, f.Provider, domain)
+}
+
+func (f *FaultInjectionProvider) ZoneNames(ctx context.Context) ([]string, error) {
+	return ZoneNames(ctx, f.Provider)
+}
+
+func (f *FaultInjectionProvider) StreamRecords(ctx context.Context, fn func(zone string, records []*endpoint.Endpoint) error) error {
+	if err := f.inject(ctx, "StreamRecords"); err != nil {
+		return err
+	}
+	return StreamRecords(ctx, f.Provider, fn)
+}
+
+func (f *FaultInjectionProvider) WildcardSupport() WildcardSupport {
+	return GetWildcardSupport(f.Provider)
+}
+
+func (f *FaultInjectionProvider) Capabilities() Capabilities {
+	return GetCapabilities(f.Provider)
+}
+
+// inject delays the call or returns an injected error, according to the profile.
+func (f *FaultInjectionProvider) inject(ctx context.Context, method string) error {
+	if f.roll(f.profile.Delay) {
+		d := f.duration(f.profile.MaxDelay)
+		injectedFaultsTotal.CounterVec.WithLabelValues(faultDelay).Inc()
+		log.Warnf("Fault injection: delaying %s by %s", method, d)
+		if err := f.sleep(ctx, d); err != nil {
+			return err
+		}
+	}
+	if f.roll(f.profile.Error) {
+		injectedFaultsTotal.CounterVec.WithLabelValues(faultError).Inc()
+		log.Warnf("Fault injection: failing %s", method)
+		return NewSoftErrorf("fault injection: %s failed", method)
+	}
+	return nil
+}
+
+// partialChanges returns the first changes of a random number of them, less than all. An update
+// is kept or dropped with both its old and new records.
+func (f *FaultInjectionProvider) partialChanges(changes *plan.Changes) (*plan.Changes, int, int) {
+	total := len(changes.Create) + len(changes.UpdateNew) + len(changes.Delete) + len(changes.Adopt)
+	applied := 0
+	if total > 1 {
+		f.mu.Lock()
+		applied = f.rand.Intn(total)
+		f.mu.Unlock()
+	}
+	left := applied
+	take := func(records []*endpoint.Endpoint) []*endpoint.Endpoint {
+		n := min(left, len(records))
+		left -= n
+		return records[:n]
+	}
+	partial := &plan.Changes{Create: take(changes.Create)}
+	partial.UpdateNew = take(changes.UpdateNew)
+	partial.UpdateOld = changes.UpdateOld[:min(len(partial.UpdateNew), len(changes.UpdateOld))]
+	partial.Delete = take(changes.Delete)
+	partial.Adopt = take(changes.Adopt)
+	return partial, applied, total
+}
+
+func (f *FaultInjectionProvider) roll(probability float64) bool {
+	if probability <= 0 {
+		return false
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.rand.Float64() < probability
+}
+
+func (f *FaultInjectionProvider) duration(maxDelay time.Duration) time.Duration {
+	if maxDelay <= 0 {
+		return 0
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return time.Duration(f.rand.Int63n(int64(maxDelay)))
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
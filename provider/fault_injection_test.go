@@ -0,0 +1,165 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	promtestutil "github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+)
+
+func TestParseFaultProfile(t *testing.T) {
+	for _, tt := range []struct {
+		profile  string
+		expected FaultProfile
+		err      string
+	}{
+		{profile: "light", expected: FaultProfiles["light"]},
+		{profile: "heavy", expected: FaultProfiles["heavy"]},
+		{profile: "error=0.5", expected: FaultProfile{Error: 0.5}},
+		{profile: "delay=0.2, max-delay=10s,partial=1", expected: FaultProfile{Delay: 0.2, MaxDelay: 10 * time.Second, Partial: 1}},
+		{profile: "chaos", err: "expected a profile name"},
+		{profile: "error=2", err: "between 0 and 1"},
+		{profile: "timeout=0.1", err: "unknown fault"},
+		{profile: "max-delay=soon", err: "invalid max-delay"},
+		{profile: "delay=0.1", err: "max-delay is required"},
+	} {
+		t.Run(tt.profile, func(t *testing.T) {
+			profile, err := ParseFaultProfile(tt.profile)
+			if tt.err != "" {
+				require.ErrorContains(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, profile)
+		})
+	}
+}
+
+func TestFaultInjectionProviderNoFaults(t *testing.T) {
+	testProvider := newTestProviderFunc(t)
+	testProvider.records = func(ctx context.Context) ([]*endpoint.Endpoint, error) {
+		return []*endpoint.Endpoint{endpoint.NewEndpoint("a.example.org", endpoint.RecordTypeA, "1.2.3.4")}, nil
+	}
+	applied := 0
+	testProvider.applyChanges = func(ctx context.Context, changes *plan.Changes) error {
+		applied++
+		return nil
+	}
+	f := NewFaultInjectionProvider(testProvider, FaultProfile{}, 1)
+
+	records, err := f.Records(context.Background())
+	require.NoError(t, err)
+	assert.Len(t, records, 1)
+	require.NoError(t, f.ApplyChanges(context.Background(), &plan.Changes{}))
+	assert.Equal(t, 1, applied)
+}
+
+func TestFaultInjectionProviderError(t *testing.T) {
+	testProvider := newTestProviderFunc(t)
+	testProvider.records = recordsNotCalled(t)
+	testProvider.applyChanges = applyChangesNotCalled(t)
+	f := NewFaultInjectionProvider(testProvider, FaultProfile{Error: 1}, 1)
+	injected := promtestutil.ToFloat64(injectedFaultsTotal.CounterVec.WithLabelValues(faultError))
+
+	_, err := f.Records(context.Background())
+	require.ErrorIs(t, err, SoftError)
+	assert.ErrorContains(t, err, "fault injection: Records failed")
+	err = f.ApplyChanges(context.Background(), &plan.Changes{})
+	require.ErrorIs(t, err, SoftError)
+	err = StreamRecords(context.Background(), f, func(string, []*endpoint.Endpoint) error { return nil })
+	require.ErrorIs(t, err, SoftError)
+	assert.InDelta(t, injected+3, promtestutil.ToFloat64(injectedFaultsTotal.CounterVec.WithLabelValues(faultError)), 0)
+}
+
+func TestFaultInjectionProviderDelay(t *testing.T) {
+	testProvider := newTestProviderFunc(t)
+	testProvider.records = func(ctx context.Context) ([]*endpoint.Endpoint, error) {
+		return nil, nil
+	}
+	f := NewFaultInjectionProvider(testProvider, FaultProfile{Delay: 1, MaxDelay: time.Minute}, 1)
+	var delays []time.Duration
+	f.sleep = func(ctx context.Context, d time.Duration) error {
+		delays = append(delays, d)
+		return nil
+	}
+
+	_, err := f.Records(context.Background())
+	require.NoError(t, err)
+	require.Len(t, delays, 1)
+	assert.Less(t, delays[0], time.Minute)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	f.sleep = sleepContext
+	_, err = f.Records(ctx)
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+func TestFaultInjectionProviderPartial(t *testing.T) {
+	changes := &plan.Changes{
+		Create: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("a.example.org", endpoint.RecordTypeA, "1.2.3.4"),
+			endpoint.NewEndpoint("b.example.org", endpoint.RecordTypeA, "1.2.3.4"),
+		},
+		UpdateOld: []*endpoint.Endpoint{endpoint.NewEndpoint("c.example.org", endpoint.RecordTypeA, "1.2.3.4")},
+		UpdateNew: []*endpoint.Endpoint{endpoint.NewEndpoint("c.example.org", endpoint.RecordTypeA, "5.6.7.8")},
+		Delete:    []*endpoint.Endpoint{endpoint.NewEndpoint("d.example.org", endpoint.RecordTypeA, "1.2.3.4")},
+	}
+	var applied *plan.Changes
+	testProvider := newTestProviderFunc(t)
+	testProvider.applyChanges = func(ctx context.Context, changes *plan.Changes) error {
+		applied = changes
+		return nil
+	}
+
+	for seed := range int64(20) {
+		f := NewFaultInjectionProvider(testProvider, FaultProfile{Partial: 1}, seed)
+		err := f.ApplyChanges(context.Background(), changes)
+		require.ErrorIs(t, err, SoftError)
+		require.NotNil(t, applied)
+		n := len(applied.Create) + len(applied.UpdateNew) + len(applied.Delete)
+		assert.Less(t, n, 4)
+		assert.ErrorContains(t, err, "of 4 changes")
+		assert.Len(t, applied.UpdateOld, len(applied.UpdateNew))
+		assert.Equal(t, changes.Create[:len(applied.Create)], applied.Create)
+	}
+}
+
+func TestFaultInjectionProviderIsReproducible(t *testing.T) {
+	testProvider := newTestProviderFunc(t)
+	testProvider.records = func(ctx context.Context) ([]*endpoint.Endpoint, error) {
+		return nil, nil
+	}
+	results := func() []bool {
+		f := NewFaultInjectionProvider(testProvider, FaultProfile{Error: 0.5}, 42)
+		var failed []bool
+		for range 20 {
+			_, err := f.Records(context.Background())
+			failed = append(failed, err != nil)
+		}
+		return failed
+	}
+	assert.Equal(t, results(), results())
+}
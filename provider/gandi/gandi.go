@@ -105,6 +105,15 @@ func (p *GandiProvider) Zones() (zones []string, err error) {
 	return zones, nil
 }
 
+// Capabilities implements provider.CapabilitiesReporter. The values of the LiveDNS record sets
+// are in the zone file format, so the TXT values longer than a character-string are split into
+// quoted character-strings.
+func (p *GandiProvider) Capabilities() provider.Capabilities {
+	return provider.Capabilities{
+		SplitTXT: true,
+	}
+}
+
 func (p *GandiProvider) Records(ctx context.Context) ([]*endpoint.Endpoint, error) {
 	liveDNSZones, err := p.Zones()
 	if err != nil {
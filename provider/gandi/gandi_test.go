@@ -190,6 +190,10 @@ func TestNewGandiProvider(t *testing.T) {
 	}
 }
 
+func TestGandiCapabilities(t *testing.T) {
+	assert.True(t, (&GandiProvider{}).Capabilities().SplitTXT)
+}
+
 func TestGandiProvider_RecordsReturnsCorrectEndpoints(t *testing.T) {
 	mockedClient := &mockGandiClient{
 		RecordsToReturn: []livedns.DomainRecord{
@@ -22,16 +22,19 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"io"
 	"math/rand"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	log "github.com/sirupsen/logrus"
 	"golang.org/x/time/rate"
 
 	"sigs.k8s.io/external-dns/pkg/apis/externaldns"
+	"sigs.k8s.io/external-dns/pkg/egress"
+	"sigs.k8s.io/external-dns/pkg/httpbody"
+	"sigs.k8s.io/external-dns/pkg/ratelimiter"
 )
 
 const (
@@ -46,6 +49,10 @@ var (
 	ErrAPIDown = errors.New("godaddy: the GoDaddy API is down")
 )
 
+// responseGuard rejects the responses which are not from the GoDaddy API, e.g. the error pages
+// of a proxy, and the bodies too large to be held in memory.
+var responseGuard = httpbody.Guard{ContentTypes: []string{"application/json"}}
+
 // APIError error
 type APIError struct {
 	Code    string
@@ -112,13 +119,17 @@ func (r GDErrorResponse) String() string {
 	return "<error>"
 }
 
-// NewClient represents a new client to call the API
-func NewClient(useOTE bool, apiKey, apiSecret string) (*Client, error) {
+// NewClient represents a new client to call the API. The apiEndpoint, e.g. of a proxy or of a
+// mock server, overrides the OTE and production endpoints when set.
+func NewClient(useOTE bool, apiEndpoint, apiKey, apiSecret string, egressConfig *egress.Config) (*Client, error) {
 	var endpoint string
 
-	if useOTE {
+	switch {
+	case apiEndpoint != "":
+		endpoint = strings.TrimSuffix(apiEndpoint, "/")
+	case useOTE:
 		endpoint = "https://api.ote-godaddy.com"
-	} else {
+	default:
 		endpoint = "https://api.godaddy.com"
 	}
 
@@ -126,9 +137,9 @@ func NewClient(useOTE bool, apiKey, apiSecret string) (*Client, error) {
 		APIKey:      apiKey,
 		APISecret:   apiSecret,
 		APIEndPoint: endpoint,
-		Client:      &http.Client{},
+		Client:      &http.Client{Transport: egressConfig.Transport()},
 		// Add one token every second
-		Ratelimiter: rate.NewLimiter(rate.Every(time.Second), 60),
+		Ratelimiter: ratelimiter.Shared("godaddy", apiKey, rate.Every(time.Second), 60),
 		Timeout:     DefaultTimeout,
 	}
 
@@ -237,7 +248,9 @@ func (c *Client) Do(req *http.Request) (*http.Response, error) {
 		c.Logger.LogRequest(req)
 	}
 
-	c.Ratelimiter.Wait(req.Context())
+	if err := c.Ratelimiter.Wait(req.Context()); err != nil {
+		return nil, err
+	}
 	resp, err := c.Client.Do(req)
 	if err != nil {
 		return nil, err
@@ -254,9 +267,18 @@ func (c *Client) Do(req *http.Request) (*http.Response, error) {
 		retryAfterSec := retryAfter + jitter/2
 
 		sleepTime := time.Duration(retryAfterSec) * time.Second
-		time.Sleep(sleepTime)
+		// the wait is aborted with the request, e.g. when the synchronization times out
+		select {
+		case <-time.After(sleepTime):
+		case <-req.Context().Done():
+			resp.Body.Close()
+			return nil, req.Context().Err()
+		}
 
-		c.Ratelimiter.Wait(req.Context())
+		if err := c.Ratelimiter.Wait(req.Context()); err != nil {
+			resp.Body.Close()
+			return nil, err
+		}
 		resp, err = c.Client.Do(req)
 		if err != nil {
 			return nil, fmt.Errorf("doing request after waiting for retry after: %w", err)
@@ -327,8 +349,7 @@ func (c *Client) CallAPIWithContext(ctx context.Context, method, path string, re
 // type if needed Helper function, called from CallAPI
 func (c *Client) UnmarshalResponse(response *http.Response, resType interface{}) error {
 	// Read all the response body
-	defer response.Body.Close()
-	body, err := io.ReadAll(response.Body)
+	body, err := responseGuard.Read(response)
 	if err != nil {
 		return err
 	}
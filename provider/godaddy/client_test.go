@@ -17,6 +17,7 @@ limitations under the License.
 package godaddy
 
 import (
+	"context"
 	"errors"
 	"net/http"
 	"net/http/httptest"
@@ -24,7 +25,10 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"golang.org/x/time/rate"
+
+	"sigs.k8s.io/external-dns/pkg/httpbody"
 )
 
 // Tests that
@@ -70,3 +74,63 @@ func TestClient_DoWhenQuotaExceeded(t *testing.T) {
 		assert.Equal("rate limit exceeded", apiErr.Message)
 	}
 }
+
+func TestClient_DoRetryAfterCanceled(t *testing.T) {
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "3600")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer mockServer.Close()
+
+	client := Client{
+		APIEndPoint: mockServer.URL,
+		Client:      &http.Client{},
+		Ratelimiter: rate.NewLimiter(rate.Every(time.Second), 60),
+		Timeout:     DefaultTimeout,
+	}
+
+	// the wait for the quota to be restored is aborted with the request
+	ctx, cancel := context.WithTimeout(t.Context(), 100*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	err := client.CallAPIWithContext(ctx, http.MethodGet, "/v1/domains/example.net/records", nil, nil)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), time.Minute)
+}
+
+func TestNewClientAPIEndpoint(t *testing.T) {
+	var paths []string
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	defer mockServer.Close()
+
+	// the requests go through the path of the proxy
+	client, err := NewClient(false, mockServer.URL+"/godaddy/", "key", "secret", nil)
+	require.NoError(t, err)
+	assert.Equal(t, mockServer.URL+"/godaddy", client.APIEndPoint)
+	assert.Equal(t, []string{"/godaddy/v1/domains"}, paths)
+}
+
+func TestClientUnmarshalResponseFromProxy(t *testing.T) {
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.WriteHeader(http.StatusBadGateway)
+		_, _ = w.Write([]byte(`<html><body><h1>502 Bad Gateway</h1></body></html>`))
+	}))
+	defer mockServer.Close()
+
+	client := Client{
+		APIEndPoint: mockServer.URL,
+		Client:      &http.Client{},
+		Ratelimiter: rate.NewLimiter(rate.Every(time.Second), 60),
+		Timeout:     DefaultTimeout,
+	}
+
+	var records []gdRecordField
+	err := client.Get("/v1/domains/example.net/records", &records)
+	require.ErrorIs(t, err, httpbody.ErrUnexpectedContentType)
+	assert.ErrorContains(t, err, "502 Bad Gateway")
+}
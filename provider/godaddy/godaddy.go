@@ -27,6 +27,7 @@ import (
 	"golang.org/x/sync/errgroup"
 
 	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/pkg/egress"
 	"sigs.k8s.io/external-dns/plan"
 	"sigs.k8s.io/external-dns/provider"
 )
@@ -38,6 +39,14 @@ const (
 	gdDelete   = 2
 
 	domainsURI = "/v1/domains?statuses=ACTIVE,PENDING_DNS_ACTIVE"
+
+	// gdMaxDataLength is the length of the longest data of a record GoDaddy accepts, the quoted
+	// character-strings of the long TXT values included
+	gdMaxDataLength = 512
+
+	// defaultReadConcurrency is the maximum number of zones whose records are read at the same
+	// time, unless configured: the API allows 60 requests per minute
+	defaultReadConcurrency = 3
 )
 
 var actionNames = []string{
@@ -47,11 +56,11 @@ var actionNames = []string{
 }
 
 type gdClient interface {
-	Patch(string, interface{}, interface{}) error
-	Post(string, interface{}, interface{}) error
-	Put(string, interface{}, interface{}) error
-	Get(string, interface{}) error
-	Delete(string, interface{}) error
+	PatchWithContext(context.Context, string, interface{}, interface{}) error
+	PostWithContext(context.Context, string, interface{}, interface{}) error
+	PutWithContext(context.Context, string, interface{}, interface{}) error
+	GetWithContext(context.Context, string, interface{}) error
+	DeleteWithContext(context.Context, string, interface{}) error
 }
 
 // GDProvider declare GoDaddy provider
@@ -62,6 +71,8 @@ type GDProvider struct {
 	client       gdClient
 	ttl          int64
 	DryRun       bool
+	missingZones *provider.MissingZoneCache
+	concurrency  provider.Concurrency
 }
 
 type gdEndpoint struct {
@@ -135,8 +146,8 @@ func (z gdZoneIDName) findZoneRecord(hostname string) (suitableZoneID string, su
 }
 
 // NewGoDaddyProvider initializes a new GoDaddy DNS based Provider.
-func NewGoDaddyProvider(ctx context.Context, domainFilter *endpoint.DomainFilter, ttl int64, apiKey, apiSecret string, useOTE, dryRun bool) (*GDProvider, error) {
-	client, err := NewClient(useOTE, apiKey, apiSecret)
+func NewGoDaddyProvider(ctx context.Context, domainFilter *endpoint.DomainFilter, ttl int64, apiKey, apiSecret string, useOTE bool, apiEndpoint string, dryRun bool, missingZones *provider.MissingZoneCache, egressConfig *egress.Config, concurrency provider.Concurrency) (*GDProvider, error) {
+	client, err := NewClient(useOTE, apiEndpoint, apiKey, apiSecret, egressConfig)
 	if err != nil {
 		return nil, err
 	}
@@ -146,14 +157,16 @@ func NewGoDaddyProvider(ctx context.Context, domainFilter *endpoint.DomainFilter
 		domainFilter: domainFilter,
 		ttl:          maxOf(defaultTTL, ttl),
 		DryRun:       dryRun,
+		missingZones: missingZones,
+		concurrency:  concurrency,
 	}, nil
 }
 
-func (p *GDProvider) zones() ([]string, error) {
+func (p *GDProvider) zones(ctx context.Context) ([]string, error) {
 	zones := []gdZone{}
 	filteredZones := []string{}
 
-	if err := p.client.Get(domainsURI, &zones); err != nil {
+	if err := p.client.GetWithContext(ctx, domainsURI, &zones); err != nil {
 		return nil, err
 	}
 
@@ -171,7 +184,7 @@ func (p *GDProvider) zones() ([]string, error) {
 
 func (p *GDProvider) zonesRecords(ctx context.Context, all bool) ([]string, []gdRecords, error) {
 	var allRecords []gdRecords
-	zones, err := p.zones()
+	zones, err := p.zones(ctx)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -179,7 +192,7 @@ func (p *GDProvider) zonesRecords(ctx context.Context, all bool) ([]string, []gd
 	if len(zones) == 0 {
 		allRecords = []gdRecords{}
 	} else if len(zones) == 1 {
-		record, err := p.records(&ctx, zones[0], all)
+		record, err := p.records(ctx, zones[0], all)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -189,11 +202,12 @@ func (p *GDProvider) zonesRecords(ctx context.Context, all bool) ([]string, []gd
 		chRecords := make(chan gdRecords, len(zones))
 
 		eg, ctx := errgroup.WithContext(ctx)
+		eg.SetLimit(p.concurrency.ReadLimit(defaultReadConcurrency))
 
 		for _, zoneName := range zones {
 			zone := zoneName
 			eg.Go(func() error {
-				record, err := p.records(&ctx, zone, all)
+				record, err := p.records(ctx, zone, all)
 				if err != nil {
 					return err
 				}
@@ -218,12 +232,12 @@ func (p *GDProvider) zonesRecords(ctx context.Context, all bool) ([]string, []gd
 	return zones, allRecords, nil
 }
 
-func (p *GDProvider) records(ctx *context.Context, zone string, all bool) (*gdRecords, error) {
+func (p *GDProvider) records(ctx context.Context, zone string, all bool) (*gdRecords, error) {
 	var recordsIds []gdRecordField
 
 	log.Debugf("GoDaddy: Getting records for %s", zone)
 
-	if err := p.client.Get(fmt.Sprintf("/v1/domains/%s/records", zone), &recordsIds); err != nil {
+	if err := p.client.GetWithContext(ctx, fmt.Sprintf("/v1/domains/%s/records", zone), &recordsIds); err != nil {
 		return nil, err
 	}
 
@@ -240,7 +254,7 @@ func (p *GDProvider) records(ctx *context.Context, zone string, all bool) (*gdRe
 	}
 
 	for _, rec := range recordsIds {
-		if provider.SupportedRecordType(rec.Type) {
+		if supportedRecordType(rec.Type) {
 			log.Debugf("GoDaddy: Record %s for %s is %+v", rec.Name, zone, rec)
 
 			results.records = append(results.records, rec)
@@ -280,7 +294,7 @@ func (p *GDProvider) groupByNameAndType(zoneRecords []gdRecords) []*endpoint.End
 			targets := []string{}
 
 			for _, record := range records {
-				targets = append(targets, record.Data)
+				targets = append(targets, recordTarget(record))
 			}
 
 			var recordName string
@@ -305,6 +319,14 @@ func (p *GDProvider) groupByNameAndType(zoneRecords []gdRecords) []*endpoint.End
 	return endpoints
 }
 
+// Capabilities implements provider.CapabilitiesReporter. The TXT values longer than a
+// character-string are split into quoted character-strings, as in a zone file.
+func (p *GDProvider) Capabilities() provider.Capabilities {
+	return provider.Capabilities{
+		SplitTXT: true,
+	}
+}
+
 // Records returns the list of records in all relevant zones.
 func (p *GDProvider) Records(ctx context.Context) ([]*endpoint.Endpoint, error) {
 	_, records, err := p.zonesRecords(ctx, false)
@@ -330,7 +352,7 @@ func (p *GDProvider) appendChange(action int, endpoints []*endpoint.Endpoint, al
 	return allChanges
 }
 
-func (p *GDProvider) changeAllRecords(endpoints []gdEndpoint, zoneRecords []*gdRecords) error {
+func (p *GDProvider) changeAllRecords(ctx context.Context, endpoints []gdEndpoint, zoneRecords []*gdRecords) error {
 	zoneNameIDMapper := gdZoneIDName{}
 
 	for _, zoneRecord := range zoneRecords {
@@ -342,20 +364,23 @@ func (p *GDProvider) changeAllRecords(endpoints []gdEndpoint, zoneRecords []*gdR
 		zone, zoneRecord := zoneNameIDMapper.findZoneRecord(dnsName)
 
 		if zone == "" {
-			log.Debugf("Skipping record %s because no hosted zone matching record DNS Name was detected", dnsName)
+			if p.missingZones.Report(dnsName) {
+				log.Debugf("Skipping record %s because no hosted zone matching record DNS Name was detected", dnsName)
+			}
 		} else {
 			dnsName = strings.TrimSuffix(dnsName, "."+zone)
 			if dnsName == zone {
 				dnsName = ""
 			}
 
-			if e.endpoint.RecordType == endpoint.RecordTypeA && (len(dnsName) == 0) {
+			// the records of the apex, such as its MX records, are named @
+			if len(dnsName) == 0 {
 				dnsName = "@"
 			}
 
 			e.endpoint.RecordTTL = endpoint.TTL(maxOf(defaultTTL, int64(e.endpoint.RecordTTL)))
 
-			if err := zoneRecord.applyEndpoint(e.action, p.client, *e.endpoint, dnsName, p.DryRun); err != nil {
+			if err := zoneRecord.applyEndpoint(ctx, e.action, p.client, *e.endpoint, dnsName, p.DryRun); err != nil {
 				log.Errorf("Unable to apply change %s on record %s type %s, %v", actionNames[e.action], dnsName, e.endpoint.RecordType, err)
 
 				return err
@@ -377,6 +402,11 @@ func (p *GDProvider) ApplyChanges(ctx context.Context, changes *plan.Changes) er
 		return err
 	}
 
+	accepted := *changes
+	accepted.Create = withinDataLimit(changes.Create)
+	accepted.UpdateNew = withinDataLimit(changes.UpdateNew)
+	changes = &accepted
+
 	changedZoneRecords := make([]*gdRecords, len(records))
 
 	for i := range records {
@@ -430,22 +460,17 @@ func (p *GDProvider) ApplyChanges(ctx context.Context, changes *plan.Changes) er
 
 	log.Infof("GoDaddy: %d changes will be done", len(allChanges))
 
-	if err = p.changeAllRecords(allChanges, changedZoneRecords); err != nil {
+	if err = p.changeAllRecords(ctx, allChanges, changedZoneRecords); err != nil {
 		return err
 	}
 
 	return nil
 }
 
-func (p *gdRecords) addRecord(client gdClient, endpoint endpoint.Endpoint, dnsName string, dryRun bool) error {
+func (p *gdRecords) addRecord(ctx context.Context, client gdClient, endpoint endpoint.Endpoint, dnsName string, dryRun bool) error {
 	var response GDErrorResponse
 	for _, target := range endpoint.Targets {
-		change := gdRecordField{
-			Type: endpoint.RecordType,
-			Name: dnsName,
-			TTL:  int64(endpoint.RecordTTL),
-			Data: target,
-		}
+		change := newRecordField(endpoint.RecordType, dnsName, endpoint.RecordTTL, target)
 
 		p.records = append(p.records, change)
 		p.changed = true
@@ -453,7 +478,7 @@ func (p *gdRecords) addRecord(client gdClient, endpoint endpoint.Endpoint, dnsNa
 		log.Debugf("GoDaddy: Add an entry %s to zone %s", change.String(), p.zone)
 		if dryRun {
 			log.Infof("[DryRun] - Add record %s.%s of type %s %s", change.Name, p.zone, change.Type, toString(change))
-		} else if err := client.Patch(fmt.Sprintf("/v1/domains/%s/records", p.zone), []gdRecordField{change}, &response); err != nil {
+		} else if err := client.PatchWithContext(ctx, fmt.Sprintf("/v1/domains/%s/records", p.zone), []gdRecordField{change}, &response); err != nil {
 			log.Errorf("Add record %s.%s of type %s failed: %s", change.Name, p.zone, change.Type, response)
 
 			return err
@@ -463,17 +488,12 @@ func (p *gdRecords) addRecord(client gdClient, endpoint endpoint.Endpoint, dnsNa
 	return nil
 }
 
-func (p *gdRecords) replaceRecord(client gdClient, endpoint endpoint.Endpoint, dnsName string, dryRun bool) error {
+func (p *gdRecords) replaceRecord(ctx context.Context, client gdClient, endpoint endpoint.Endpoint, dnsName string, dryRun bool) error {
 	changed := []gdReplaceRecordField{}
 	records := []string{}
 
 	for _, target := range endpoint.Targets {
-		change := gdRecordField{
-			Type: endpoint.RecordType,
-			Name: dnsName,
-			TTL:  int64(endpoint.RecordTTL),
-			Data: target,
-		}
+		change := newRecordField(endpoint.RecordType, dnsName, endpoint.RecordTTL, target)
 
 		for index, record := range p.records {
 			if record.Type == change.Type && record.Name == change.Name {
@@ -502,7 +522,7 @@ func (p *gdRecords) replaceRecord(client gdClient, endpoint endpoint.Endpoint, d
 	}
 
 	log.Debugf("Replace record %s.%s of type %s %s", dnsName, p.zone, endpoint.RecordType, records)
-	if err := client.Put(fmt.Sprintf("/v1/domains/%s/records/%s/%s", p.zone, endpoint.RecordType, dnsName), changed, &response); err != nil {
+	if err := client.PutWithContext(ctx, fmt.Sprintf("/v1/domains/%s/records/%s/%s", p.zone, endpoint.RecordType, dnsName), changed, &response); err != nil {
 		log.Errorf("Replace record %s.%s of type %s failed: %v", dnsName, p.zone, endpoint.RecordType, response)
 
 		return err
@@ -512,16 +532,11 @@ func (p *gdRecords) replaceRecord(client gdClient, endpoint endpoint.Endpoint, d
 }
 
 // Remove one record from the record list
-func (p *gdRecords) deleteRecord(client gdClient, endpoint endpoint.Endpoint, dnsName string, dryRun bool) error {
+func (p *gdRecords) deleteRecord(ctx context.Context, client gdClient, endpoint endpoint.Endpoint, dnsName string, dryRun bool) error {
 	records := []string{}
 
 	for _, target := range endpoint.Targets {
-		change := gdRecordField{
-			Type: endpoint.RecordType,
-			Name: dnsName,
-			TTL:  int64(endpoint.RecordTTL),
-			Data: target,
-		}
+		change := newRecordField(endpoint.RecordType, dnsName, endpoint.RecordTTL, target)
 		records = append(records, target)
 
 		log.Debugf("GoDaddy: Delete an entry %s from zone %s", change.String(), p.zone)
@@ -550,7 +565,7 @@ func (p *gdRecords) deleteRecord(client gdClient, endpoint endpoint.Endpoint, dn
 	}
 
 	var response GDErrorResponse
-	if err := client.Delete(fmt.Sprintf("/v1/domains/%s/records/%s/%s", p.zone, endpoint.RecordType, dnsName), &response); err != nil {
+	if err := client.DeleteWithContext(ctx, fmt.Sprintf("/v1/domains/%s/records/%s/%s", p.zone, endpoint.RecordType, dnsName), &response); err != nil {
 		log.Errorf("Delete record %s.%s of type %s failed: %v", dnsName, p.zone, endpoint.RecordType, response)
 
 		return err
@@ -559,14 +574,14 @@ func (p *gdRecords) deleteRecord(client gdClient, endpoint endpoint.Endpoint, dn
 	return nil
 }
 
-func (p *gdRecords) applyEndpoint(action int, client gdClient, endpoint endpoint.Endpoint, dnsName string, dryRun bool) error {
+func (p *gdRecords) applyEndpoint(ctx context.Context, action int, client gdClient, endpoint endpoint.Endpoint, dnsName string, dryRun bool) error {
 	switch action {
 	case gdCreate:
-		return p.addRecord(client, endpoint, dnsName, dryRun)
+		return p.addRecord(ctx, client, endpoint, dnsName, dryRun)
 	case gdReplace:
-		return p.replaceRecord(client, endpoint, dnsName, dryRun)
+		return p.replaceRecord(ctx, client, endpoint, dnsName, dryRun)
 	case gdDelete:
-		return p.deleteRecord(client, endpoint, dnsName, dryRun)
+		return p.deleteRecord(ctx, client, endpoint, dnsName, dryRun)
 	}
 
 	return nil
@@ -576,6 +591,63 @@ func (c gdRecordField) String() string {
 	return fmt.Sprintf("%s %d IN %s %s", c.Name, c.TTL, c.Type, c.Data)
 }
 
+// supportedRecordType returns true if the record type is supported by the provider
+func supportedRecordType(recordType string) bool {
+	switch recordType {
+	case endpoint.RecordTypeMX:
+		return true
+	default:
+		return provider.SupportedRecordType(recordType)
+	}
+}
+
+// newRecordField returns the record of a target. The priority of an MX target is a field
+// of its own, the data of the record being the host.
+func newRecordField(recordType, name string, ttl endpoint.TTL, target string) gdRecordField {
+	change := gdRecordField{
+		Type: recordType,
+		Name: name,
+		TTL:  int64(ttl),
+		Data: target,
+	}
+	if recordType == endpoint.RecordTypeMX {
+		if mx, err := endpoint.ParseMXTarget(target); err == nil {
+			priority := int(mx.Priority)
+			change.Data = mx.Host
+			change.Priority = &priority
+		}
+	}
+	return change
+}
+
+// recordTarget returns the target of a record, made of the priority and the host of an MX record.
+func recordTarget(record gdRecordField) string {
+	if record.Type == endpoint.RecordTypeMX && record.Priority != nil {
+		return fmt.Sprintf("%d %s", *record.Priority, record.Data)
+	}
+	return record.Data
+}
+
+// withinDataLimit returns the endpoints whose targets GoDaddy accepts, logging the others: the
+// TXT values too long to fit in the data of a record even once split into character-strings.
+func withinDataLimit(endpoints []*endpoint.Endpoint) []*endpoint.Endpoint {
+	var result []*endpoint.Endpoint
+	for _, ep := range endpoints {
+		tooLong := false
+		for _, target := range ep.Targets {
+			if len(target) > gdMaxDataLength {
+				log.Errorf("GoDaddy: skipping %s record %s, its data of %d characters is longer than the %d accepted", ep.RecordType, ep.DNSName, len(target), gdMaxDataLength)
+				tooLong = true
+				break
+			}
+		}
+		if !tooLong {
+			result = append(result, ep)
+		}
+	}
+	return result
+}
+
 func countTargets(p *plan.Changes) int {
 	changes := [][]*endpoint.Endpoint{p.Create, p.UpdateNew, p.UpdateOld, p.Delete}
 	count := 0
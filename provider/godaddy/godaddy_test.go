@@ -21,6 +21,7 @@ import (
 	"encoding/json"
 	"errors"
 	"sort"
+	"strings"
 	"testing"
 
 	log "github.com/sirupsen/logrus"
@@ -29,6 +30,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"sigs.k8s.io/external-dns/endpoint"
 	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider"
 )
 
 type mockGoDaddyClient struct {
@@ -47,9 +49,9 @@ var (
 	zoneNameExampleNet string = "example.net"
 )
 
-func (c *mockGoDaddyClient) Post(endpoint string, input interface{}, output interface{}) error {
+func (c *mockGoDaddyClient) PostWithContext(_ context.Context, endpoint string, input interface{}, output interface{}) error {
 	log.Infof("POST: %s - %v", endpoint, input)
-	stub := c.Called(endpoint, input)
+	stub := c.MethodCalled("Post", endpoint, input)
 	data, err := json.Marshal(stub.Get(0))
 	require.NoError(c.currentTest, err)
 	err = json.Unmarshal(data, output)
@@ -57,9 +59,9 @@ func (c *mockGoDaddyClient) Post(endpoint string, input interface{}, output inte
 	return stub.Error(1)
 }
 
-func (c *mockGoDaddyClient) Patch(endpoint string, input interface{}, output interface{}) error {
+func (c *mockGoDaddyClient) PatchWithContext(_ context.Context, endpoint string, input interface{}, output interface{}) error {
 	log.Infof("PATCH: %s - %v", endpoint, input)
-	stub := c.Called(endpoint, input)
+	stub := c.MethodCalled("Patch", endpoint, input)
 	data, err := json.Marshal(stub.Get(0))
 	require.NoError(c.currentTest, err)
 	err = json.Unmarshal(data, output)
@@ -67,9 +69,9 @@ func (c *mockGoDaddyClient) Patch(endpoint string, input interface{}, output int
 	return stub.Error(1)
 }
 
-func (c *mockGoDaddyClient) Put(endpoint string, input interface{}, output interface{}) error {
+func (c *mockGoDaddyClient) PutWithContext(_ context.Context, endpoint string, input interface{}, output interface{}) error {
 	log.Infof("PUT: %s - %v", endpoint, input)
-	stub := c.Called(endpoint, input)
+	stub := c.MethodCalled("Put", endpoint, input)
 	data, err := json.Marshal(stub.Get(0))
 	require.NoError(c.currentTest, err)
 	err = json.Unmarshal(data, output)
@@ -77,9 +79,9 @@ func (c *mockGoDaddyClient) Put(endpoint string, input interface{}, output inter
 	return stub.Error(1)
 }
 
-func (c *mockGoDaddyClient) Get(endpoint string, output interface{}) error {
+func (c *mockGoDaddyClient) GetWithContext(_ context.Context, endpoint string, output interface{}) error {
 	log.Infof("GET: %s", endpoint)
-	stub := c.Called(endpoint)
+	stub := c.MethodCalled("Get", endpoint)
 	data, err := json.Marshal(stub.Get(0))
 	require.NoError(c.currentTest, err)
 	err = json.Unmarshal(data, output)
@@ -87,9 +89,9 @@ func (c *mockGoDaddyClient) Get(endpoint string, output interface{}) error {
 	return stub.Error(1)
 }
 
-func (c *mockGoDaddyClient) Delete(endpoint string, output interface{}) error {
+func (c *mockGoDaddyClient) DeleteWithContext(_ context.Context, endpoint string, output interface{}) error {
 	log.Infof("DELETE: %s", endpoint)
-	stub := c.Called(endpoint)
+	stub := c.MethodCalled("Delete", endpoint)
 	data, err := json.Marshal(stub.Get(0))
 	require.NoError(c.currentTest, err)
 	err = json.Unmarshal(data, output)
@@ -115,7 +117,7 @@ func TestGoDaddyZones(t *testing.T) {
 		},
 	}, nil).Once()
 
-	domains, err := provider.zones()
+	domains, err := provider.zones(t.Context())
 
 	assert.NoError(err)
 	assert.Contains(domains, "example.com")
@@ -125,7 +127,7 @@ func TestGoDaddyZones(t *testing.T) {
 
 	// Error on getting zones
 	client.On("Get", domainsURI).Return(nil, ErrAPIDown).Once()
-	domains, err = provider.zones()
+	domains, err = provider.zones(t.Context())
 	assert.Error(err)
 	assert.Nil(domains)
 	client.AssertExpectations(t)
@@ -457,3 +459,77 @@ func TestGoDaddyErrorResponse(t *testing.T) {
 
 	client.AssertExpectations(t)
 }
+
+func TestGoDaddyCapabilities(t *testing.T) {
+	assert.True(t, (&GDProvider{}).Capabilities().SplitTXT)
+}
+
+func TestGoDaddyChangeLongTXT(t *testing.T) {
+	client := newMockGoDaddyClient(t)
+	gd := &GDProvider{
+		client: client,
+	}
+	splitting := provider.NewTXTSplittingProvider(gd)
+	// an encrypted ownership record, whose character-strings fit in the data of a record
+	long := strings.Repeat("encrypted", 50)
+	data := `"` + long[:255] + `" "` + long[255:] + `"`
+	require.LessOrEqual(t, len(data), gdMaxDataLength)
+	// a value whose character-strings don't fit in the data of a record
+	tooLong := strings.Repeat("x", 600)
+
+	client.On("Get", domainsURI).Return([]gdZone{{Domain: zoneNameExampleNet}}, nil)
+	client.On("Get", "/v1/domains/example.net/records").Return([]gdRecordField{
+		{Name: "old", Type: "TXT", TTL: defaultTTL, Data: data},
+	}, nil)
+	client.On("Patch", "/v1/domains/example.net/records", []gdRecordField{
+		{Name: "new", Type: "TXT", TTL: defaultTTL, Data: data},
+	}).Return(nil, nil).Once()
+	client.On("Delete", "/v1/domains/example.net/records/TXT/old").Return(nil, nil).Once()
+
+	records, err := splitting.Records(context.Background())
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, endpoint.Targets{`"` + long + `"`}, records[0].Targets)
+
+	desired, err := splitting.AdjustEndpoints([]*endpoint.Endpoint{
+		endpoint.NewEndpointWithTTL("new.example.net", endpoint.RecordTypeTXT, defaultTTL, long),
+		endpoint.NewEndpointWithTTL("toolong.example.net", endpoint.RecordTypeTXT, defaultTTL, tooLong),
+	})
+	require.NoError(t, err)
+
+	// the record too long is skipped instead of being rejected by GoDaddy
+	require.NoError(t, splitting.ApplyChanges(context.Background(), &plan.Changes{
+		Create: desired,
+		Delete: records,
+	}))
+	client.AssertExpectations(t)
+}
+
+func TestGoDaddyChangeMX(t *testing.T) {
+	client := newMockGoDaddyClient(t)
+	provider := &GDProvider{
+		client: client,
+	}
+	priority := func(p int) *int { return &p }
+
+	client.On("Get", domainsURI).Return([]gdZone{{Domain: zoneNameExampleNet}}, nil)
+	client.On("Get", "/v1/domains/example.net/records").Return([]gdRecordField{
+		{Name: "old", Type: "MX", TTL: defaultTTL, Data: "mail.example.net", Priority: priority(10)},
+		{Name: "old", Type: "MX", TTL: defaultTTL, Data: "backup.example.net", Priority: priority(20)},
+	}, nil)
+	client.On("Patch", "/v1/domains/example.net/records", []gdRecordField{
+		{Name: "@", Type: "MX", TTL: defaultTTL, Data: "mail.example.net", Priority: priority(10)},
+	}).Return(nil, nil).Once()
+	client.On("Delete", "/v1/domains/example.net/records/MX/old").Return(nil, nil).Once()
+
+	records, err := provider.Records(context.Background())
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.ElementsMatch(t, endpoint.Targets{"10 mail.example.net", "20 backup.example.net"}, records[0].Targets)
+
+	require.NoError(t, provider.ApplyChanges(context.Background(), &plan.Changes{
+		Create: []*endpoint.Endpoint{endpoint.NewEndpointWithTTL("example.net", endpoint.RecordTypeMX, defaultTTL, "10 mail.example.net")},
+		Delete: []*endpoint.Endpoint{records[0]},
+	}))
+	client.AssertExpectations(t)
+}
@@ -19,6 +19,7 @@ package google
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"sort"
 	"strings"
 	"time"
@@ -26,12 +27,15 @@ import (
 	"cloud.google.com/go/compute/metadata"
 	"github.com/linki/instrumented_http"
 	log "github.com/sirupsen/logrus"
+	"golang.org/x/oauth2"
 	"golang.org/x/oauth2/google"
 	dns "google.golang.org/api/dns/v1"
 	googleapi "google.golang.org/api/googleapi"
 	"google.golang.org/api/option"
 
 	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/pkg/decisions"
+	"sigs.k8s.io/external-dns/pkg/egress"
 	"sigs.k8s.io/external-dns/plan"
 	"sigs.k8s.io/external-dns/provider"
 )
@@ -122,11 +126,16 @@ type GoogleProvider struct {
 	changesClient changesServiceInterface
 	// The context parameter to be passed for gcloud API calls.
 	ctx context.Context
+	// Records the changes of the records matching no managed zone, nil not to record them
+	filterDecisions *decisions.Recorder
+	// Remembers the names of the records matching no managed zone, nil not to remember them
+	missingZones *provider.MissingZoneCache
 }
 
 // NewGoogleProvider initializes a new Google CloudDNS based Provider.
-func NewGoogleProvider(ctx context.Context, project string, domainFilter *endpoint.DomainFilter, zoneIDFilter provider.ZoneIDFilter, batchChangeSize int, batchChangeInterval time.Duration, zoneVisibility string, dryRun bool) (*GoogleProvider, error) {
-	gcloud, err := google.DefaultClient(ctx, dns.NdevClouddnsReadwriteScope)
+func NewGoogleProvider(ctx context.Context, project string, domainFilter *endpoint.DomainFilter, zoneIDFilter provider.ZoneIDFilter, batchChangeSize int, batchChangeInterval time.Duration, zoneVisibility string, dryRun bool, filterDecisions *decisions.Recorder, missingZones *provider.MissingZoneCache, egressConfig *egress.Config) (*GoogleProvider, error) {
+	// the base client of the OAuth2 client is that of the context
+	gcloud, err := google.DefaultClient(context.WithValue(ctx, oauth2.HTTPClient, &http.Client{Transport: egressConfig.Transport()}), dns.NdevClouddnsReadwriteScope)
 	if err != nil {
 		return nil, err
 	}
@@ -166,6 +175,8 @@ func NewGoogleProvider(ctx context.Context, project string, domainFilter *endpoi
 		managedZonesClient:       managedZonesService{dnsClient.ManagedZones},
 		changesClient:            changesService{dnsClient.Changes},
 		ctx:                      ctx,
+		filterDecisions:          filterDecisions,
+		missingZones:             missingZones,
 	}, nil
 }
 
@@ -212,7 +223,50 @@ func (p *GoogleProvider) Records(ctx context.Context) (endpoints []*endpoint.End
 	if err != nil {
 		return nil, err
 	}
+	return p.records(ctx, zones)
+}
+
+// ZoneNames returns the names of the managed zones, sorted.
+func (p *GoogleProvider) ZoneNames(ctx context.Context) ([]string, error) {
+	zones, err := p.Zones(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return provider.SortedZoneNames(zoneIDNames(zones)), nil
+}
+
+// StreamRecords calls fn with the records of the managed zones of each DNS name in turn, sorted by name.
+func (p *GoogleProvider) StreamRecords(ctx context.Context, fn func(zone string, records []*endpoint.Endpoint) error) error {
+	zones, err := p.Zones(ctx)
+	if err != nil {
+		return err
+	}
+	return provider.StreamZoneRecords(zoneIDNames(zones), func(zoneNames []string) ([]*endpoint.Endpoint, error) {
+		named := make(map[string]*dns.ManagedZone, len(zoneNames))
+		for _, name := range zoneNames {
+			named[name] = zones[name]
+		}
+		return p.records(ctx, named)
+	}, fn)
+}
 
+// ZoneRecords returns the records of the managed zones containing the domain or its subdomains.
+func (p *GoogleProvider) ZoneRecords(ctx context.Context, domain string) ([]*endpoint.Endpoint, error) {
+	zones, err := p.Zones(ctx)
+	if err != nil {
+		return nil, err
+	}
+	matching := make(map[string]*dns.ManagedZone)
+	for name, z := range zones {
+		if provider.ZoneOverlapsDomain(z.DnsName, domain) {
+			matching[name] = z
+		}
+	}
+	return p.records(ctx, matching)
+}
+
+// records returns the records of the managed zones.
+func (p *GoogleProvider) records(ctx context.Context, zones map[string]*dns.ManagedZone) (endpoints []*endpoint.Endpoint, _ error) {
 	f := func(resp *dns.ResourceRecordSetsListResponse) error {
 		for _, r := range resp.Rrsets {
 			if !p.SupportedRecordType(r.Type) {
@@ -233,6 +287,15 @@ func (p *GoogleProvider) Records(ctx context.Context) (endpoints []*endpoint.End
 	return endpoints, nil
 }
 
+// zoneIDNames returns the DNS names of the managed zones by zone name.
+func zoneIDNames(zones map[string]*dns.ManagedZone) provider.ZoneIDName {
+	names := provider.ZoneIDName{}
+	for name, z := range zones {
+		names.Add(name, z.DnsName)
+	}
+	return names
+}
+
 // ApplyChanges applies a given set of changes in a given zone.
 func (p *GoogleProvider) ApplyChanges(ctx context.Context, changes *plan.Changes) error {
 	change := &dns.Change{}
@@ -244,7 +307,34 @@ func (p *GoogleProvider) ApplyChanges(ctx context.Context, changes *plan.Changes
 
 	change.Deletions = append(change.Deletions, p.newFilteredRecords(changes.Delete)...)
 
-	return p.submitChange(ctx, change)
+	return p.submitChange(ctx, change, ownershipKeys(changes))
+}
+
+// ownershipKeys returns the ownership keys of the record sets of the changes by name, so that
+// the records are submitted in the same batch as their TXT registry records.
+func ownershipKeys(changes *plan.Changes) map[string]string {
+	keys := map[string]string{}
+	for _, endpoints := range [][]*endpoint.Endpoint{changes.Create, changes.UpdateNew, changes.UpdateOld, changes.Delete} {
+		for _, ep := range endpoints {
+			keys[provider.EnsureTrailingDot(ep.DNSName)] = provider.OwnershipKey(ep)
+		}
+	}
+	return keys
+}
+
+// WildcardSupport implements provider.WildcardSupporter, as Cloud DNS only accepts an asterisk
+// as the whole first label of a record name, which excludes the names of the wildcard TXT records.
+func (p *GoogleProvider) WildcardSupport() provider.WildcardSupport {
+	return provider.WildcardSupport{Records: true}
+}
+
+// Capabilities implements provider.CapabilitiesReporter.
+func (p *GoogleProvider) Capabilities() provider.Capabilities {
+	return provider.Capabilities{
+		RecordTypes: []string{endpoint.RecordTypeA, endpoint.RecordTypeAAAA, endpoint.RecordTypeCNAME, endpoint.RecordTypeSRV, endpoint.RecordTypeTXT, endpoint.RecordTypeNS, endpoint.RecordTypeMX},
+		Batch:       true,
+		SplitTXT:    true,
+	}
 }
 
 // SupportedRecordType returns true if the record type is supported by the provider
@@ -271,7 +361,7 @@ func (p *GoogleProvider) newFilteredRecords(endpoints []*endpoint.Endpoint) []*d
 }
 
 // submitChange takes a zone and a Change and sends it to Google.
-func (p *GoogleProvider) submitChange(ctx context.Context, change *dns.Change) error {
+func (p *GoogleProvider) submitChange(ctx context.Context, change *dns.Change, ownershipKeys map[string]string) error {
 	if len(change.Additions) == 0 && len(change.Deletions) == 0 {
 		log.Info("All records are already up to date")
 		return nil
@@ -283,10 +373,10 @@ func (p *GoogleProvider) submitChange(ctx context.Context, change *dns.Change) e
 	}
 
 	// separate into per-zone change sets to be passed to the API.
-	changes := separateChange(zones, change)
+	changes := separateChange(zones, change, p.filterDecisions, p.missingZones)
 
 	for zone, change := range changes {
-		for batch, c := range batchChange(change, p.batchChangeSize) {
+		for batch, c := range batchChange(change, p.batchChangeSize, ownershipKeys) {
 			log.Infof("Change zone: %v batch #%d", zone, batch)
 			for _, del := range c.Deletions {
 				log.Infof("Del records: %s %s %s %d", del.Name, del.Type, del.Rrdatas, del.Ttl)
@@ -311,7 +401,7 @@ func (p *GoogleProvider) submitChange(ctx context.Context, change *dns.Change) e
 }
 
 // batchChange separates a zone in multiple transaction.
-func batchChange(change *dns.Change, batchSize int) []*dns.Change {
+func batchChange(change *dns.Change, batchSize int, ownershipKeys map[string]string) []*dns.Change {
 	var changes []*dns.Change
 
 	if batchSize == 0 {
@@ -325,21 +415,29 @@ func batchChange(change *dns.Change, batchSize int) []*dns.Change {
 
 	changesByName := map[string]*dnsChange{}
 
+	// the record sets are grouped by name, and with the TXT registry records owning them
+	key := func(name string) string {
+		if key, ok := ownershipKeys[name]; ok {
+			return key
+		}
+		return strings.ToLower(strings.TrimSuffix(name, "."))
+	}
+
 	for _, a := range change.Additions {
-		change, ok := changesByName[a.Name]
+		change, ok := changesByName[key(a.Name)]
 		if !ok {
 			change = &dnsChange{}
-			changesByName[a.Name] = change
+			changesByName[key(a.Name)] = change
 		}
 
 		change.additions = append(change.additions, a)
 	}
 
 	for _, a := range change.Deletions {
-		change, ok := changesByName[a.Name]
+		change, ok := changesByName[key(a.Name)]
 		if !ok {
 			change = &dnsChange{}
-			changesByName[a.Name] = change
+			changesByName[key(a.Name)] = change
 		}
 
 		change.deletions = append(change.deletions, a)
@@ -383,8 +481,9 @@ func batchChange(change *dns.Change, batchSize int) []*dns.Change {
 	return changes
 }
 
-// separateChange separates a multi-zone change into a single change per zone.
-func separateChange(zones map[string]*dns.ManagedZone, change *dns.Change) map[string]*dns.Change {
+// separateChange separates a multi-zone change into a single change per zone. The changes matching
+// no zone are recorded by filterDecisions, and their names reported to missingZones.
+func separateChange(zones map[string]*dns.ManagedZone, change *dns.Change, filterDecisions *decisions.Recorder, missingZones *provider.MissingZoneCache) map[string]*dns.Change {
 	changes := make(map[string]*dns.Change)
 	zoneNameIDMapper := provider.ZoneIDName{}
 	for _, z := range zones {
@@ -398,7 +497,15 @@ func separateChange(zones map[string]*dns.ManagedZone, change *dns.Change) map[s
 		if zoneName, _ := zoneNameIDMapper.FindZone(provider.EnsureTrailingDot(a.Name)); zoneName != "" {
 			changes[zoneName].Additions = append(changes[zoneName].Additions, a)
 		} else {
-			log.Warnf("No matching zone for record addition: %s %s %s %d", a.Name, a.Type, a.Rrdatas, a.Ttl)
+			if missingZones.Report(a.Name) {
+				log.Warnf("No matching zone for record addition: %s %s %s %d", a.Name, a.Type, a.Rrdatas, a.Ttl)
+			}
+			filterDecisions.Record(decisions.Decision{
+				Filter:     decisions.ZoneFilter,
+				DNSName:    a.Name,
+				RecordType: a.Type,
+				Reason:     "no managed zone matches the name, the zones may be excluded by the zone id or visibility filters",
+			})
 		}
 	}
 
@@ -406,7 +513,15 @@ func separateChange(zones map[string]*dns.ManagedZone, change *dns.Change) map[s
 		if zoneName, _ := zoneNameIDMapper.FindZone(provider.EnsureTrailingDot(d.Name)); zoneName != "" {
 			changes[zoneName].Deletions = append(changes[zoneName].Deletions, d)
 		} else {
-			log.Warnf("No matching zone for record deletion: %s %s %s %d", d.Name, d.Type, d.Rrdatas, d.Ttl)
+			if missingZones.Report(d.Name) {
+				log.Warnf("No matching zone for record deletion: %s %s %s %d", d.Name, d.Type, d.Rrdatas, d.Ttl)
+			}
+			filterDecisions.Record(decisions.Decision{
+				Filter:     decisions.ZoneFilter,
+				DNSName:    d.Name,
+				RecordType: d.Type,
+				Reason:     "no managed zone matches the name, the zones may be excluded by the zone id or visibility filters",
+			})
 		}
 	}
 
@@ -278,6 +278,33 @@ func TestGoogleRecords(t *testing.T) {
 	validateEndpoints(t, records, originalEndpoints)
 }
 
+func TestGoogleStreamRecords(t *testing.T) {
+	originalEndpoints := []*endpoint.Endpoint{
+		endpoint.NewEndpointWithTTL("list-test.zone-1.ext-dns-test-2.gcp.zalan.do", endpoint.RecordTypeA, endpoint.TTL(1), "1.2.3.4"),
+		endpoint.NewEndpointWithTTL("list-test.zone-2.ext-dns-test-2.gcp.zalan.do", endpoint.RecordTypeA, endpoint.TTL(2), "8.8.8.8"),
+	}
+
+	provider := newGoogleProvider(t, endpoint.NewDomainFilter([]string{"ext-dns-test-2.gcp.zalan.do."}), provider.NewZoneIDFilter([]string{""}), false, originalEndpoints, nil, nil)
+	ctx := context.Background()
+
+	names, err := provider.ZoneNames(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"zone-1.ext-dns-test-2.gcp.zalan.do", "zone-2.ext-dns-test-2.gcp.zalan.do", "zone-3.ext-dns-test-2.gcp.zalan.do"}, names)
+
+	streamed := map[string][]*endpoint.Endpoint{}
+	require.NoError(t, provider.StreamRecords(ctx, func(zone string, records []*endpoint.Endpoint) error {
+		streamed[zone] = records
+		return nil
+	}))
+	assert.Len(t, streamed, 3)
+	validateEndpoints(t, streamed["zone-1.ext-dns-test-2.gcp.zalan.do"], originalEndpoints[:1])
+	validateEndpoints(t, streamed["zone-2.ext-dns-test-2.gcp.zalan.do"], originalEndpoints[1:])
+
+	records, err := provider.ZoneRecords(ctx, "zone-2.ext-dns-test-2.gcp.zalan.do")
+	require.NoError(t, err)
+	validateEndpoints(t, records, originalEndpoints[1:])
+}
+
 func TestGoogleRecordsFilter(t *testing.T) {
 	originalEndpoints := []*endpoint.Endpoint{
 		endpoint.NewEndpointWithTTL("update-test.zone-1.ext-dns-test-2.gcp.zalan.do", endpoint.RecordTypeA, defaultTTL, "8.8.8.8"),
@@ -515,7 +542,7 @@ func TestSeparateChanges(t *testing.T) {
 		},
 	}
 
-	changes := separateChange(zones, change)
+	changes := separateChange(zones, change, nil, nil)
 	require.Len(t, changes, 2)
 
 	validateChange(t, changes["foo-example-org"], &dns.Change{
@@ -551,7 +578,7 @@ func TestGoogleBatchChangeSet(t *testing.T) {
 		})
 	}
 
-	batchCs := batchChange(cs, googleDefaultBatchChangeSize)
+	batchCs := batchChange(cs, googleDefaultBatchChangeSize, nil)
 
 	require.Len(t, batchCs, 1)
 
@@ -576,7 +603,7 @@ func TestGoogleBatchChangeSetExceeding(t *testing.T) {
 		})
 	}
 
-	batchCs := batchChange(cs, testLimit)
+	batchCs := batchChange(cs, testLimit, nil)
 
 	require.Len(t, batchCs, expectedBatchCount)
 
@@ -608,11 +635,37 @@ func TestGoogleBatchChangeSetExceedingNameChange(t *testing.T) {
 		Ttl:  20,
 	})
 
-	batchCs := batchChange(cs, testLimit)
+	batchCs := batchChange(cs, testLimit, nil)
 
 	require.Empty(t, batchCs)
 }
 
+func TestGoogleBatchChangeSetOwnership(t *testing.T) {
+	changes := &plan.Changes{
+		Create: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("host-1.example.org", endpoint.RecordTypeA, "1.2.3.4"),
+			endpoint.NewEndpoint("host-2.example.org", endpoint.RecordTypeA, "1.2.3.4"),
+			endpoint.NewEndpoint("a-host-1.example.org", endpoint.RecordTypeTXT, "\"heritage=external-dns,external-dns/owner=owner\"").
+				WithLabel(endpoint.OwnedRecordLabelKey, "host-1.example.org"),
+			endpoint.NewEndpoint("a-host-2.example.org", endpoint.RecordTypeTXT, "\"heritage=external-dns,external-dns/owner=owner\"").
+				WithLabel(endpoint.OwnedRecordLabelKey, "host-2.example.org"),
+		},
+	}
+	cs := &dns.Change{}
+	for _, ep := range changes.Create {
+		cs.Additions = append(cs.Additions, newRecord(ep))
+	}
+
+	// each record is submitted along with its TXT registry record
+	batchCs := batchChange(cs, 2, ownershipKeys(changes))
+	require.Len(t, batchCs, 2)
+	for i, c := range batchCs {
+		require.Len(t, c.Additions, 2)
+		host := fmt.Sprintf("host-%d.example.org.", i+1)
+		assert.ElementsMatch(t, []string{host, "a-" + host}, []string{c.Additions[0].Name, c.Additions[1].Name})
+	}
+}
+
 func TestSoftErrListZonesConflict(t *testing.T) {
 	p := newGoogleProvider(t, endpoint.NewDomainFilter([]string{"ext-dns-test-2.gcp.zalan.do."}), provider.NewZoneIDFilter([]string{}), false, []*endpoint.Endpoint{}, provider.NewSoftError(fmt.Errorf("failed to list zones")), nil)
 
@@ -841,3 +894,7 @@ func clearGoogleRecords(t *testing.T, provider *GoogleProvider, zone string) {
 func validateEndpoints(t *testing.T, endpoints []*endpoint.Endpoint, expected []*endpoint.Endpoint) {
 	assert.True(t, testutils.SameEndpoints(endpoints, expected), "actual and expected endpoints don't match. %s:%s", endpoints, expected)
 }
+
+func TestGoogleWildcardSupport(t *testing.T) {
+	assert.Equal(t, provider.WildcardSupport{Records: true}, provider.GetWildcardSupport(&GoogleProvider{}))
+}
@@ -19,6 +19,7 @@ package inmemory
 import (
 	"context"
 	"errors"
+	"sort"
 	"strings"
 
 	log "github.com/sirupsen/logrus"
@@ -139,6 +140,60 @@ func (im *InMemoryProvider) Records(ctx context.Context) ([]*endpoint.Endpoint,
 	return endpoints, nil
 }
 
+// ZoneRecords returns the endpoints of the zones containing the domain or its subdomains
+func (im *InMemoryProvider) ZoneRecords(ctx context.Context, domain string) ([]*endpoint.Endpoint, error) {
+	defer im.OnRecords()
+
+	endpoints := make([]*endpoint.Endpoint, 0)
+
+	for zoneID, zoneName := range im.Zones() {
+		if !provider.ZoneOverlapsDomain(zoneName, domain) {
+			continue
+		}
+		records, err := im.client.Records(zoneID)
+		if err != nil {
+			return nil, err
+		}
+
+		endpoints = append(endpoints, copyEndpoints(records)...)
+	}
+
+	return endpoints, nil
+}
+
+// ZoneNames returns the names of the zones, sorted
+func (im *InMemoryProvider) ZoneNames(ctx context.Context) ([]string, error) {
+	names := make([]string, 0, len(im.Zones()))
+	for _, zoneName := range im.Zones() {
+		names = append(names, zoneName)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// StreamRecords calls fn with the endpoints of each zone in turn, sorted by zone name
+func (im *InMemoryProvider) StreamRecords(ctx context.Context, fn func(zone string, records []*endpoint.Endpoint) error) error {
+	defer im.OnRecords()
+
+	zones := im.Zones()
+	zoneIDs := make([]string, 0, len(zones))
+	for zoneID := range zones {
+		zoneIDs = append(zoneIDs, zoneID)
+	}
+	sort.Slice(zoneIDs, func(i, j int) bool { return zones[zoneIDs[i]] < zones[zoneIDs[j]] })
+
+	for _, zoneID := range zoneIDs {
+		records, err := im.client.Records(zoneID)
+		if err != nil {
+			return err
+		}
+		if err := fn(zones[zoneID], copyEndpoints(records)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // ApplyChanges simply modifies records in memory
 // error checking occurs before any modifications are made, i.e. batch processing
 // create record - record should not exist
@@ -36,6 +36,8 @@ func TestInMemoryProvider(t *testing.T) {
 	t.Run("ApplyChanges", testInMemoryApplyChanges)
 	t.Run("NewInMemoryProvider", testNewInMemoryProvider)
 	t.Run("CreateZone", testInMemoryCreateZone)
+	t.Run("ZoneRecords", testInMemoryZoneRecords)
+	t.Run("StreamRecords", testInMemoryStreamRecords)
 }
 
 func testInMemoryRecords(t *testing.T) {
@@ -586,3 +588,64 @@ func makeZone(s ...string) map[endpoint.EndpointKey]*endpoint.Endpoint {
 
 	return output
 }
+
+func testInMemoryZoneRecords(t *testing.T) {
+	im := NewInMemoryProvider()
+	ctx := context.Background()
+	for _, zone := range []string{"example.org", "sub.example.org", "example.com"} {
+		require.NoError(t, im.CreateZone(zone))
+		require.NoError(t, im.ApplyChanges(ctx, &plan.Changes{
+			Create: []*endpoint.Endpoint{endpoint.NewEndpoint("www."+zone, endpoint.RecordTypeA, "1.2.3.4")},
+		}))
+	}
+
+	for _, tc := range []struct {
+		domain   string
+		expected []string
+	}{
+		{domain: "example.org", expected: []string{"www.example.org", "www.sub.example.org"}},
+		{domain: "www.sub.example.org", expected: []string{"www.example.org", "www.sub.example.org"}},
+		{domain: "example.com", expected: []string{"www.example.com"}},
+		{domain: "example.net", expected: []string{}},
+	} {
+		t.Run(tc.domain, func(t *testing.T) {
+			records, err := im.ZoneRecords(ctx, tc.domain)
+			require.NoError(t, err)
+			names := []string{}
+			for _, record := range records {
+				names = append(names, record.DNSName)
+			}
+			assert.ElementsMatch(t, tc.expected, names)
+		})
+	}
+}
+
+func testInMemoryStreamRecords(t *testing.T) {
+	im := NewInMemoryProvider()
+	ctx := context.Background()
+	for _, zone := range []string{"example.org", "example.com"} {
+		require.NoError(t, im.CreateZone(zone))
+		require.NoError(t, im.ApplyChanges(ctx, &plan.Changes{
+			Create: []*endpoint.Endpoint{endpoint.NewEndpoint("www."+zone, endpoint.RecordTypeA, "1.2.3.4")},
+		}))
+	}
+
+	names, err := im.ZoneNames(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"example.com", "example.org"}, names)
+
+	var zones []string
+	err = im.StreamRecords(ctx, func(zone string, records []*endpoint.Endpoint) error {
+		zones = append(zones, zone)
+		require.Len(t, records, 1)
+		assert.Equal(t, "www."+zone, records[0].DNSName)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"example.com", "example.org"}, zones)
+
+	err = im.StreamRecords(ctx, func(zone string, records []*endpoint.Endpoint) error {
+		return ErrZoneNotFound
+	})
+	assert.ErrorIs(t, err, ErrZoneNotFound)
+}
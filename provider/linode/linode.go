@@ -20,7 +20,9 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"net/netip"
 	"os"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -33,6 +35,16 @@ import (
 	"sigs.k8s.io/external-dns/provider"
 
 	"sigs.k8s.io/external-dns/pkg/apis/externaldns"
+	"sigs.k8s.io/external-dns/pkg/egress"
+)
+
+const (
+	// linodeAXFRIPsKey is the property of the comma-separated IPs allowed to transfer the domain of a record.
+	linodeAXFRIPsKey = "linode/axfr-ips"
+	// linodeDomainTTLKey is the property of the default TTL of the domain of a record.
+	linodeDomainTTLKey = "linode/domain-ttl"
+	// linodeMaxTTL is the longest TTL Linode accepts, the other ones are rounded to the nearest valid value.
+	linodeMaxTTL = 2419200
 )
 
 // LinodeDomainClient interface to ease testing
@@ -42,6 +54,7 @@ type LinodeDomainClient interface {
 	CreateDomainRecord(ctx context.Context, domainID int, domainrecord linodego.DomainRecordCreateOptions) (*linodego.DomainRecord, error)
 	DeleteDomainRecord(ctx context.Context, domainID int, id int) error
 	UpdateDomainRecord(ctx context.Context, domainID int, id int, domainrecord linodego.DomainRecordUpdateOptions) (*linodego.DomainRecord, error)
+	UpdateDomain(ctx context.Context, domainID int, domain linodego.DomainUpdateOptions) (*linodego.Domain, error)
 }
 
 // LinodeProvider is an implementation of Provider for Digital Ocean's DNS.
@@ -49,7 +62,10 @@ type LinodeProvider struct {
 	provider.BaseProvider
 	Client       LinodeDomainClient
 	domainFilter *endpoint.DomainFilter
+	// only consider the domains with one of these tags, if any
+	domainTags   []string
 	DryRun       bool
+	missingZones *provider.MissingZoneCache
 }
 
 // LinodeChanges All API calls calculated from the plan
@@ -57,6 +73,7 @@ type LinodeChanges struct {
 	Creates []LinodeChangeCreate
 	Deletes []LinodeChangeDelete
 	Updates []LinodeChangeUpdate
+	Domains []LinodeChangeDomain
 }
 
 // LinodeChangeCreate Linode Domain Record Creates
@@ -72,6 +89,12 @@ type LinodeChangeUpdate struct {
 	Options      linodego.DomainRecordUpdateOptions
 }
 
+// LinodeChangeDomain Linode Domain Updates
+type LinodeChangeDomain struct {
+	Domain  linodego.Domain
+	Options linodego.DomainUpdateOptions
+}
+
 // LinodeChangeDelete Linode Domain Record Deletes
 type LinodeChangeDelete struct {
 	Domain       linodego.Domain
@@ -79,7 +102,7 @@ type LinodeChangeDelete struct {
 }
 
 // NewLinodeProvider initializes a new Linode DNS based Provider.
-func NewLinodeProvider(domainFilter *endpoint.DomainFilter, dryRun bool) (*LinodeProvider, error) {
+func NewLinodeProvider(domainFilter *endpoint.DomainFilter, domainTags []string, dryRun bool, missingZones *provider.MissingZoneCache, egressConfig *egress.Config) (*LinodeProvider, error) {
 	token, ok := os.LookupEnv("LINODE_TOKEN")
 	if !ok {
 		return nil, fmt.Errorf("no token found")
@@ -90,6 +113,7 @@ func NewLinodeProvider(domainFilter *endpoint.DomainFilter, dryRun bool) (*Linod
 	oauth2Client := &http.Client{
 		Transport: &oauth2.Transport{
 			Source: tokenSource,
+			Base:   egressConfig.Transport(),
 		},
 	}
 
@@ -99,10 +123,64 @@ func NewLinodeProvider(domainFilter *endpoint.DomainFilter, dryRun bool) (*Linod
 	return &LinodeProvider{
 		Client:       &linodeClient,
 		domainFilter: domainFilter,
+		domainTags:   domainTags,
 		DryRun:       dryRun,
+		missingZones: missingZones,
 	}, nil
 }
 
+// Capabilities implements provider.CapabilitiesReporter.
+func (p *LinodeProvider) Capabilities() provider.Capabilities {
+	return provider.Capabilities{
+		RecordTypes: []string{endpoint.RecordTypeA, endpoint.RecordTypeAAAA, endpoint.RecordTypeCNAME, endpoint.RecordTypeTXT, endpoint.RecordTypeSRV, endpoint.RecordTypeNS},
+		Properties: []provider.PropertySchema{
+			{Name: linodeAXFRIPsKey, Type: provider.PropertyTypeString, Validate: validateAXFRIPs},
+			{Name: linodeDomainTTLKey, Type: provider.PropertyTypeInt, Validate: provider.IntRange(0, linodeMaxTTL)},
+		},
+		PropertyPrefixes: []string{"linode/"},
+	}
+}
+
+// AdjustEndpoints sorts the zone transfer allowlists of the endpoints like the ones of their domains, and
+// drops the allowlists and the default TTLs left to Linode, which the domains don't report either.
+func (p *LinodeProvider) AdjustEndpoints(endpoints []*endpoint.Endpoint) ([]*endpoint.Endpoint, error) {
+	for _, ep := range endpoints {
+		if value, ok := ep.GetProviderSpecificProperty(linodeAXFRIPsKey); ok {
+			if ips := parseAXFRIPs(value); len(ips) > 0 {
+				ep.SetProviderSpecificProperty(linodeAXFRIPsKey, strings.Join(ips, ","))
+			} else {
+				ep.DeleteProviderSpecificProperty(linodeAXFRIPsKey)
+			}
+		}
+		if value, ok := ep.GetProviderSpecificProperty(linodeDomainTTLKey); ok && value == "0" {
+			ep.DeleteProviderSpecificProperty(linodeDomainTTLKey)
+		}
+	}
+	return endpoints, nil
+}
+
+// validateAXFRIPs checks that a zone transfer allowlist only has IP addresses.
+func validateAXFRIPs(value string) error {
+	for _, ip := range parseAXFRIPs(value) {
+		if _, err := netip.ParseAddr(ip); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// parseAXFRIPs returns the sorted IPs of a comma-separated zone transfer allowlist.
+func parseAXFRIPs(value string) []string {
+	ips := []string{}
+	for _, ip := range strings.Split(value, ",") {
+		if ip = strings.TrimSpace(ip); ip != "" {
+			ips = append(ips, ip)
+		}
+	}
+	slices.Sort(ips)
+	return slices.Compact(ips)
+}
+
 // Zones return the list of hosted zones.
 func (p *LinodeProvider) Zones(ctx context.Context) ([]linodego.Domain, error) {
 	zones, err := p.fetchZones(ctx)
@@ -138,7 +216,14 @@ func (p *LinodeProvider) Records(ctx context.Context) ([]*endpoint.Endpoint, err
 					name = zone.Domain
 				}
 
-				endpoints = append(endpoints, endpoint.NewEndpointWithTTL(name, string(r.Type), endpoint.TTL(r.TTLSec), r.Target))
+				ep := endpoint.NewEndpointWithTTL(name, string(r.Type), endpoint.TTL(r.TTLSec), r.Target)
+				if len(zone.AXfrIPs) > 0 {
+					ep.WithProviderSpecific(linodeAXFRIPsKey, strings.Join(parseAXFRIPs(strings.Join(zone.AXfrIPs, ",")), ","))
+				}
+				if zone.TTLSec != 0 {
+					ep.WithProviderSpecific(linodeDomainTTLKey, strconv.Itoa(zone.TTLSec))
+				}
+				endpoints = append(endpoints, ep)
 			}
 		}
 	}
@@ -167,6 +252,10 @@ func (p *LinodeProvider) fetchZones(ctx context.Context) ([]linodego.Domain, err
 		if !p.domainFilter.Match(zone.Domain) {
 			continue
 		}
+		if len(p.domainTags) > 0 && !slices.ContainsFunc(zone.Tags, func(tag string) bool { return slices.Contains(p.domainTags, tag) }) {
+			log.Debugf("Skipping domain %s, it has none of the tags %v", zone.Domain, p.domainTags)
+			continue
+		}
 
 		zones = append(zones, zone)
 	}
@@ -176,6 +265,27 @@ func (p *LinodeProvider) fetchZones(ctx context.Context) ([]linodego.Domain, err
 
 // submitChanges takes a zone and a collection of Changes and sends them as a single transaction.
 func (p *LinodeProvider) submitChanges(ctx context.Context, changes LinodeChanges) error {
+	for _, change := range changes.Domains {
+		logFields := log.Fields{
+			"axfrIPs":  change.Options.AXfrIPs,
+			"ttl":      change.Options.TTLSec,
+			"action":   "UpdateDomain",
+			"zoneName": change.Domain.Domain,
+			"zoneID":   change.Domain.ID,
+		}
+
+		log.WithFields(logFields).Info("Updating domain.")
+
+		if p.DryRun {
+			log.WithFields(logFields).Info("Would update domain.")
+		} else if _, err := p.Client.UpdateDomain(ctx, change.Domain.ID, change.Options); err != nil {
+			log.WithFields(logFields).Errorf(
+				"Failed to Update domain: %v",
+				err,
+			)
+		}
+	}
+
 	for _, change := range changes.Creates {
 		logFields := log.Fields{
 			"record":   change.Options.Name,
@@ -290,9 +400,9 @@ func (p *LinodeProvider) ApplyChanges(ctx context.Context, changes *plan.Changes
 		recordsByZoneID[strconv.Itoa(zone.ID)] = append(recordsByZoneID[strconv.Itoa(zone.ID)], records...)
 	}
 
-	createsByZone := endpointsByZone(zoneNameIDMapper, changes.Create)
-	updatesByZone := endpointsByZone(zoneNameIDMapper, changes.UpdateNew)
-	deletesByZone := endpointsByZone(zoneNameIDMapper, changes.Delete)
+	createsByZone := endpointsByZone(zoneNameIDMapper, changes.Create, p.missingZones)
+	updatesByZone := endpointsByZone(zoneNameIDMapper, changes.UpdateNew, p.missingZones)
+	deletesByZone := endpointsByZone(zoneNameIDMapper, changes.Delete, p.missingZones)
 
 	var linodeCreates []LinodeChangeCreate
 	var linodeUpdates []LinodeChangeUpdate
@@ -491,16 +601,69 @@ func (p *LinodeProvider) ApplyChanges(ctx context.Context, changes *plan.Changes
 		Creates: linodeCreates,
 		Deletes: linodeDeletes,
 		Updates: linodeUpdates,
+		Domains: domainChanges(zonesByID, createsByZone, updatesByZone),
 	})
 }
 
-func endpointsByZone(zoneNameIDMapper provider.ZoneIDName, endpoints []*endpoint.Endpoint) map[string][]endpoint.Endpoint {
+// domainChanges returns the updates of the zone transfer allowlists and default TTLs of the domains
+// set by the properties of their created and updated records, which differ from the current ones.
+func domainChanges(zonesByID map[string]linodego.Domain, endpointsByZone ...map[string][]endpoint.Endpoint) []LinodeChangeDomain {
+	var changes []LinodeChangeDomain
+
+	for zoneID, zone := range zonesByID {
+		var axfrIPs, ttl *string
+		for _, byZone := range endpointsByZone {
+			for _, ep := range byZone[zoneID] {
+				if value, ok := ep.GetProviderSpecificProperty(linodeAXFRIPsKey); ok {
+					if axfrIPs != nil && *axfrIPs != value {
+						log.Warnf("Conflicting %s of the records of domain %s, using %q", linodeAXFRIPsKey, zone.Domain, *axfrIPs)
+					} else {
+						axfrIPs = &value
+					}
+				}
+				if value, ok := ep.GetProviderSpecificProperty(linodeDomainTTLKey); ok {
+					if ttl != nil && *ttl != value {
+						log.Warnf("Conflicting %s of the records of domain %s, using %q", linodeDomainTTLKey, zone.Domain, *ttl)
+					} else {
+						ttl = &value
+					}
+				}
+			}
+		}
+
+		options := zone.GetUpdateOptions()
+		changed := false
+		if axfrIPs != nil {
+			if ips := parseAXFRIPs(*axfrIPs); !slices.Equal(ips, parseAXFRIPs(strings.Join(zone.AXfrIPs, ","))) {
+				options.AXfrIPs = ips
+				changed = true
+			}
+		}
+		if ttl != nil {
+			if seconds, err := strconv.Atoi(*ttl); err == nil && seconds != zone.TTLSec {
+				options.TTLSec = seconds
+				changed = true
+			}
+		}
+		if changed {
+			changes = append(changes, LinodeChangeDomain{Domain: zone, Options: options})
+		}
+	}
+
+	return changes
+}
+
+// endpointsByZone separates the endpoints by zone. The names matching no zone are reported to
+// missingZones.
+func endpointsByZone(zoneNameIDMapper provider.ZoneIDName, endpoints []*endpoint.Endpoint, missingZones *provider.MissingZoneCache) map[string][]endpoint.Endpoint {
 	endpointsByZone := make(map[string][]endpoint.Endpoint)
 
 	for _, ep := range endpoints {
-		zoneID, _ := zoneNameIDMapper.FindZone(ep.DNSName)
+		zoneID, _ := zoneNameIDMapper.FindZoneForEndpoint(ep)
 		if zoneID == "" {
-			log.Debugf("Skipping record %s because no hosted zone matching record DNS Name was detected", ep.DNSName)
+			if missingZones.Report(ep.DNSName) {
+				log.Debugf("Skipping record %s because no hosted zone matching record DNS Name was detected", ep.DNSName)
+			}
 			continue
 		}
 		endpointsByZone[zoneID] = append(endpointsByZone[zoneID], *ep)
@@ -59,6 +59,11 @@ func (m *MockDomainClient) UpdateDomainRecord(ctx context.Context, domainID int,
 	return args.Get(0).(*linodego.DomainRecord), args.Error(1)
 }
 
+func (m *MockDomainClient) UpdateDomain(ctx context.Context, domainID int, opts linodego.DomainUpdateOptions) (*linodego.Domain, error) {
+	args := m.Called(ctx, domainID, opts)
+	return args.Get(0).(*linodego.Domain), args.Error(1)
+}
+
 func createZones() []linodego.Domain {
 	return []linodego.Domain{
 		{ID: 1, Domain: "foo.com"},
@@ -145,11 +150,11 @@ func TestLinodeConvertRecordType(t *testing.T) {
 
 func TestNewLinodeProvider(t *testing.T) {
 	_ = os.Setenv("LINODE_TOKEN", "xxxxxxxxxxxxxxxxx")
-	_, err := NewLinodeProvider(endpoint.NewDomainFilter([]string{"ext-dns-test.zalando.to."}), true)
+	_, err := NewLinodeProvider(endpoint.NewDomainFilter([]string{"ext-dns-test.zalando.to."}), nil, true, nil, nil)
 	require.NoError(t, err)
 
 	_ = os.Unsetenv("LINODE_TOKEN")
-	_, err = NewLinodeProvider(endpoint.NewDomainFilter([]string{"ext-dns-test.zalando.to."}), true)
+	_, err = NewLinodeProvider(endpoint.NewDomainFilter([]string{"ext-dns-test.zalando.to."}), nil, true, nil, nil)
 	require.Error(t, err)
 }
 
@@ -216,6 +221,37 @@ func TestLinodeFetchZonesWithFilter(t *testing.T) {
 	assert.Equal(t, expected, actual)
 }
 
+func TestLinodeFetchZonesWithTags(t *testing.T) {
+	mockDomainClient := MockDomainClient{}
+
+	provider := &LinodeProvider{
+		Client:       &mockDomainClient,
+		domainFilter: endpoint.NewDomainFilter([]string{}),
+		domainTags:   []string{"team-a", "team-b"},
+		DryRun:       false,
+	}
+
+	mockDomainClient.On(
+		"ListDomains",
+		mock.Anything,
+		mock.Anything,
+	).Return([]linodego.Domain{
+		{ID: 1, Domain: "foo.com", Tags: []string{"team-a"}},
+		{ID: 2, Domain: "bar.io"},
+		{ID: 3, Domain: "baz.com", Tags: []string{"team-c", "team-b"}},
+	}, nil).Once()
+
+	expected := []linodego.Domain{
+		{ID: 1, Domain: "foo.com", Tags: []string{"team-a"}},
+		{ID: 3, Domain: "baz.com", Tags: []string{"team-c", "team-b"}},
+	}
+	actual, err := provider.fetchZones(context.Background())
+	require.NoError(t, err)
+
+	mockDomainClient.AssertExpectations(t)
+	assert.Equal(t, expected, actual)
+}
+
 func TestLinodeGetStrippedRecordName(t *testing.T) {
 	assert.Empty(t, getStrippedRecordName(linodego.Domain{
 		Domain: "foo.com",
@@ -539,3 +575,121 @@ func TestLinodeApplyChangesNoChanges(t *testing.T) {
 
 	mockDomainClient.AssertExpectations(t)
 }
+
+func TestLinodeRecordsDomainProperties(t *testing.T) {
+	mockDomainClient := MockDomainClient{}
+
+	provider := &LinodeProvider{
+		Client:       &mockDomainClient,
+		domainFilter: endpoint.NewDomainFilter([]string{}),
+		DryRun:       false,
+	}
+
+	mockDomainClient.On(
+		"ListDomains",
+		mock.Anything,
+		mock.Anything,
+	).Return([]linodego.Domain{{ID: 1, Domain: "example.com", AXfrIPs: []string{"192.0.2.2", "192.0.2.1"}, TTLSec: 3600}}, nil).Once()
+
+	mockDomainClient.On(
+		"ListDomainRecords",
+		mock.Anything,
+		1,
+		mock.Anything,
+	).Return([]linodego.DomainRecord{{ID: 11, Name: "", Type: "A", Target: "targetA"}}, nil).Once()
+
+	actual, err := provider.Records(context.Background())
+	require.NoError(t, err)
+
+	expected := []*endpoint.Endpoint{
+		{
+			DNSName: "example.com", Targets: []string{"targetA"}, RecordType: "A", RecordTTL: 0, Labels: endpoint.NewLabels(),
+			ProviderSpecific: endpoint.ProviderSpecific{
+				{Name: linodeAXFRIPsKey, Value: "192.0.2.1,192.0.2.2"},
+				{Name: linodeDomainTTLKey, Value: "3600"},
+			},
+		},
+	}
+
+	mockDomainClient.AssertExpectations(t)
+	assert.Equal(t, expected, actual)
+}
+
+func TestLinodeAdjustEndpoints(t *testing.T) {
+	provider := &LinodeProvider{}
+
+	endpoints := []*endpoint.Endpoint{
+		endpoint.NewEndpoint("a.example.com", endpoint.RecordTypeA, "1.2.3.4").
+			WithProviderSpecific(linodeAXFRIPsKey, " 192.0.2.2, 192.0.2.1,192.0.2.2").
+			WithProviderSpecific(linodeDomainTTLKey, "3600"),
+		endpoint.NewEndpoint("b.example.com", endpoint.RecordTypeA, "1.2.3.4").
+			WithProviderSpecific(linodeAXFRIPsKey, "").
+			WithProviderSpecific(linodeDomainTTLKey, "0"),
+	}
+
+	actual, err := provider.AdjustEndpoints(endpoints)
+	require.NoError(t, err)
+
+	value, ok := actual[0].GetProviderSpecificProperty(linodeAXFRIPsKey)
+	assert.True(t, ok)
+	assert.Equal(t, "192.0.2.1,192.0.2.2", value)
+	value, ok = actual[0].GetProviderSpecificProperty(linodeDomainTTLKey)
+	assert.True(t, ok)
+	assert.Equal(t, "3600", value)
+	assert.Empty(t, actual[1].ProviderSpecific)
+}
+
+func TestLinodeApplyChangesDomainProperties(t *testing.T) {
+	mockDomainClient := MockDomainClient{}
+
+	provider := &LinodeProvider{
+		Client:       &mockDomainClient,
+		domainFilter: endpoint.NewDomainFilter([]string{}),
+		DryRun:       false,
+	}
+
+	zone := linodego.Domain{ID: 1, Domain: "example.com", Type: linodego.DomainTypeMaster, TTLSec: 300}
+
+	mockDomainClient.On(
+		"ListDomains",
+		mock.Anything,
+		mock.Anything,
+	).Return([]linodego.Domain{zone}, nil).Once()
+
+	mockDomainClient.On(
+		"ListDomainRecords",
+		mock.Anything,
+		1,
+		mock.Anything,
+	).Return([]linodego.DomainRecord{{ID: 11, Name: "", Type: "A", Target: "targetA"}}, nil).Once()
+
+	options := zone.GetUpdateOptions()
+	options.AXfrIPs = []string{"192.0.2.1", "192.0.2.2"}
+	options.TTLSec = 3600
+	mockDomainClient.On(
+		"UpdateDomain",
+		mock.Anything,
+		1,
+		options,
+	).Return(&linodego.Domain{}, nil).Once()
+
+	mockDomainClient.On(
+		"UpdateDomainRecord",
+		mock.Anything,
+		1,
+		11,
+		mock.Anything,
+	).Return(&linodego.DomainRecord{}, nil).Once()
+
+	err := provider.ApplyChanges(context.Background(), &plan.Changes{
+		UpdateNew: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("example.com", endpoint.RecordTypeA, "targetA").
+				WithProviderSpecific(linodeAXFRIPsKey, "192.0.2.1,192.0.2.2").
+				WithProviderSpecific(linodeDomainTTLKey, "3600"),
+		},
+		UpdateOld: []*endpoint.Endpoint{},
+	})
+	require.NoError(t, err)
+
+	mockDomainClient.AssertExpectations(t)
+}
@@ -0,0 +1,109 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/pkg/metrics"
+)
+
+var missingZoneNames = metrics.NewGaugeWithOpts(
+	prometheus.GaugeOpts{
+		Namespace: "external_dns",
+		Subsystem: "provider",
+		Name:      "missing_zone_names",
+		Help:      "Number of DNS names matching no zone of the provider, whose records are not applied again until the missing zone cache TTL has elapsed.",
+	},
+)
+
+func init() {
+	metrics.RegisterMetric.MustRegister(missingZoneNames)
+}
+
+// MissingZoneCache remembers the DNS names matching no zone of the provider. Their records
+// are not applied again until the TTL has elapsed, and the names are only logged when they
+// start or stop matching no zone, instead of at each synchronization. A nil cache doesn't
+// remember the names.
+type MissingZoneCache struct {
+	mu  sync.Mutex
+	ttl time.Duration
+	// reportedAt is when each name was last reported to match no zone
+	reportedAt map[string]time.Time
+	now        func() time.Time
+}
+
+// NewMissingZoneCache returns a cache in which the records of a DNS name matching no zone are
+// not applied again during the TTL, or nil not to cache the names when it's zero.
+func NewMissingZoneCache(ttl time.Duration) *MissingZoneCache {
+	missingZoneNames.Gauge.Set(0)
+	if ttl <= 0 {
+		return nil
+	}
+	return &MissingZoneCache{ttl: ttl, reportedAt: map[string]time.Time{}, now: time.Now}
+}
+
+// Report records that a DNS name matches no zone of the provider, and returns whether to log
+// it: always when the names are not cached, and otherwise only when the name wasn't already
+// known to match no zone.
+func (c *MissingZoneCache) Report(name string) bool {
+	if c == nil {
+		return true
+	}
+	name = endpoint.NormalizeDNSName(name)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.prune()
+	_, known := c.reportedAt[name]
+	c.reportedAt[name] = c.now()
+	missingZoneNames.Gauge.Set(float64(len(c.reportedAt)))
+	if known {
+		log.Debugf("DNS name %s still matches no zone, its records are not applied for %s", name, c.ttl)
+	}
+	return !known
+}
+
+// Missing returns whether a DNS name was reported to match no zone less than the TTL ago, so
+// that its records are not applied again until then.
+func (c *MissingZoneCache) Missing(name string) bool {
+	if c == nil {
+		return false
+	}
+	name = endpoint.NormalizeDNSName(name)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.prune()
+	reportedAt, ok := c.reportedAt[name]
+	return ok && c.now().Sub(reportedAt) < c.ttl
+}
+
+// prune forgets the names which were not reported again after their records were applied
+// once the TTL elapsed: they match a zone again, or are no longer desired.
+func (c *MissingZoneCache) prune() {
+	for name, reportedAt := range c.reportedAt {
+		if c.now().Sub(reportedAt) >= 2*c.ttl {
+			log.Infof("DNS name %s is no longer reported to match no zone", name)
+			delete(c.reportedAt, name)
+		}
+	}
+	missingZoneNames.Gauge.Set(float64(len(c.reportedAt)))
+}
@@ -0,0 +1,60 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMissingZoneCache(t *testing.T) {
+	now := time.Now()
+	missingZones := NewMissingZoneCache(time.Hour)
+	missingZones.now = func() time.Time { return now }
+
+	assert.False(t, missingZones.Missing("web.example.org"))
+
+	// the name is only logged when it starts matching no zone
+	assert.True(t, missingZones.Report("web.example.org."))
+	assert.False(t, missingZones.Report("WEB.example.org"))
+	assert.True(t, missingZones.Missing("web.example.org"))
+	assert.Equal(t, 1.0, testutil.ToFloat64(missingZoneNames.Gauge))
+
+	// the records are applied again once the TTL has elapsed
+	now = now.Add(time.Hour)
+	assert.False(t, missingZones.Missing("web.example.org"))
+	assert.False(t, missingZones.Report("web.example.org"))
+	assert.True(t, missingZones.Missing("web.example.org"))
+
+	// the name is forgotten when it's not reported again
+	now = now.Add(2 * time.Hour)
+	assert.False(t, missingZones.Missing("web.example.org"))
+	assert.Equal(t, 0.0, testutil.ToFloat64(missingZoneNames.Gauge))
+	assert.True(t, missingZones.Report("web.example.org"))
+}
+
+func TestMissingZoneCacheDisabled(t *testing.T) {
+	missingZones := NewMissingZoneCache(0)
+	assert.Nil(t, missingZones)
+
+	assert.True(t, missingZones.Report("web.example.org"))
+	assert.True(t, missingZones.Report("web.example.org"))
+	assert.False(t, missingZones.Missing("web.example.org"))
+}
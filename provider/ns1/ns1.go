@@ -29,6 +29,7 @@ import (
 	"gopkg.in/ns1/ns1-go.v2/rest/model/dns"
 
 	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/pkg/egress"
 	"sigs.k8s.io/external-dns/plan"
 	"sigs.k8s.io/external-dns/provider"
 )
@@ -91,6 +92,8 @@ type NS1Config struct {
 	NS1IgnoreSSL  bool
 	DryRun        bool
 	MinTTLSeconds int
+	MissingZones  *provider.MissingZoneCache
+	Egress        *egress.Config
 }
 
 // NS1Provider is the NS1 provider
@@ -101,11 +104,12 @@ type NS1Provider struct {
 	zoneIDFilter  provider.ZoneIDFilter
 	dryRun        bool
 	minTTLSeconds int
+	missingZones  *provider.MissingZoneCache
 }
 
 // NewNS1Provider creates a new NS1 Provider
 func NewNS1Provider(config NS1Config) (*NS1Provider, error) {
-	return newNS1ProviderWithHTTPClient(config, http.DefaultClient)
+	return newNS1ProviderWithHTTPClient(config, &http.Client{Transport: config.Egress.Transport()})
 }
 
 func newNS1ProviderWithHTTPClient(config NS1Config, client *http.Client) (*NS1Provider, error) {
@@ -122,7 +126,7 @@ func newNS1ProviderWithHTTPClient(config NS1Config, client *http.Client) (*NS1Pr
 	if config.NS1IgnoreSSL {
 		log.Info("ns1-ignoressl flag is True, skipping SSL verification")
 		defaultTransport := http.DefaultTransport.(*http.Transport)
-		tr := &http.Transport{
+		tr := config.Egress.Apply(&http.Transport{
 			Proxy:                 defaultTransport.Proxy,
 			DialContext:           defaultTransport.DialContext,
 			MaxIdleConns:          defaultTransport.MaxIdleConns,
@@ -130,7 +134,7 @@ func newNS1ProviderWithHTTPClient(config NS1Config, client *http.Client) (*NS1Pr
 			ExpectContinueTimeout: defaultTransport.ExpectContinueTimeout,
 			TLSHandshakeTimeout:   defaultTransport.TLSHandshakeTimeout,
 			TLSClientConfig:       &tls.Config{InsecureSkipVerify: true},
-		}
+		})
 		client.Transport = tr
 	}
 
@@ -141,6 +145,7 @@ func newNS1ProviderWithHTTPClient(config NS1Config, client *http.Client) (*NS1Pr
 		domainFilter:  config.DomainFilter,
 		zoneIDFilter:  config.ZoneIDFilter,
 		minTTLSeconds: config.MinTTLSeconds,
+		missingZones:  config.MissingZones,
 	}, nil
 }
 
@@ -208,7 +213,7 @@ func (p *NS1Provider) ns1SubmitChanges(changes []*ns1Change) error {
 	}
 
 	// separate into per-zone change sets to be passed to the API.
-	changesByZone := ns1ChangesByZone(zones, changes)
+	changesByZone := ns1ChangesByZone(zones, changes, p.missingZones)
 	for zoneName, changes := range changesByZone {
 		for _, change := range changes {
 			record := p.ns1BuildRecord(zoneName, change)
@@ -302,8 +307,9 @@ func newNS1Changes(action string, endpoints []*endpoint.Endpoint) []*ns1Change {
 	return changes
 }
 
-// ns1ChangesByZone separates a multi-zone change into a single change per zone.
-func ns1ChangesByZone(zones []*dns.Zone, changeSets []*ns1Change) map[string][]*ns1Change {
+// ns1ChangesByZone separates a multi-zone change into a single change per zone. The names
+// matching no zone are reported to missingZones.
+func ns1ChangesByZone(zones []*dns.Zone, changeSets []*ns1Change, missingZones *provider.MissingZoneCache) map[string][]*ns1Change {
 	changes := make(map[string][]*ns1Change)
 	zoneNameIDMapper := provider.ZoneIDName{}
 	for _, z := range zones {
@@ -314,7 +320,9 @@ func ns1ChangesByZone(zones []*dns.Zone, changeSets []*ns1Change) map[string][]*
 	for _, c := range changeSets {
 		zone, _ := zoneNameIDMapper.FindZone(c.Endpoint.DNSName)
 		if zone == "" {
-			log.Debugf("Skipping record %s because no hosted zone matching record DNS Name was detected", c.Endpoint.DNSName)
+			if missingZones.Report(c.Endpoint.DNSName) {
+				log.Debugf("Skipping record %s because no hosted zone matching record DNS Name was detected", c.Endpoint.DNSName)
+			}
 			continue
 		}
 		changes[zone] = append(changes[zone], c)
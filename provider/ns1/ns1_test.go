@@ -314,7 +314,7 @@ func TestNewNS1ChangesByZone(t *testing.T) {
 		},
 	}
 
-	changes := ns1ChangesByZone(zones, changeSets)
+	changes := ns1ChangesByZone(zones, changeSets, nil)
 	assert.Len(t, changes["bar.com"], 1)
 	assert.Len(t, changes["foo.com"], 3)
 }
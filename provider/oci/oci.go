@@ -20,7 +20,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
 	"os"
+	"slices"
 	"strings"
 	"time"
 
@@ -31,6 +33,7 @@ import (
 	log "github.com/sirupsen/logrus"
 
 	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/pkg/egress"
 	"sigs.k8s.io/external-dns/plan"
 	"sigs.k8s.io/external-dns/provider"
 )
@@ -51,8 +54,12 @@ type OCIAuthConfig struct {
 
 // OCIConfig holds the configuration for the OCI Provider.
 type OCIConfig struct {
-	Auth              OCIAuthConfig `yaml:"auth"`
-	CompartmentID     string        `yaml:"compartment"`
+	Auth          OCIAuthConfig `yaml:"auth"`
+	CompartmentID string        `yaml:"compartment"`
+	// ZoneCompartmentIDs are the additional compartments whose zones are managed.
+	ZoneCompartmentIDs []string `yaml:"zoneCompartments"`
+	// ViewIDs restricts the private zones to the ones of these private views.
+	ViewIDs           []string `yaml:"views"`
 	ZoneCacheDuration time.Duration
 }
 
@@ -68,6 +75,7 @@ type OCIProvider struct {
 	zoneScope    string
 	zoneCache    *zoneCache
 	dryRun       bool
+	missingZones *provider.MissingZoneCache
 }
 
 // ociDNSClient is the subset of the OCI DNS API required by the OCI Provider.
@@ -93,7 +101,7 @@ func LoadOCIConfig(path string) (*OCIConfig, error) {
 }
 
 // NewOCIProvider initializes a new OCI DNS based Provider.
-func NewOCIProvider(cfg OCIConfig, domainFilter *endpoint.DomainFilter, zoneIDFilter provider.ZoneIDFilter, zoneScope string, dryRun bool) (*OCIProvider, error) {
+func NewOCIProvider(cfg OCIConfig, domainFilter *endpoint.DomainFilter, zoneIDFilter provider.ZoneIDFilter, zoneScope string, dryRun bool, missingZones *provider.MissingZoneCache, egressConfig *egress.Config) (*OCIProvider, error) {
 	var client ociDNSClient
 	var err error
 	var configProvider common.ConfigurationProvider
@@ -105,8 +113,11 @@ func NewOCIProvider(cfg OCIConfig, domainFilter *endpoint.DomainFilter, zoneIDFi
 		if err := os.Setenv(auth.ResourcePrincipalVersionEnvVar, auth.ResourcePrincipalVersion2_2); err != nil {
 			return nil, fmt.Errorf("unable to set OCI SDK environment variable: %s: %w", auth.ResourcePrincipalVersionEnvVar, err)
 		}
-		if err := os.Setenv(auth.ResourcePrincipalRegionEnvVar, cfg.Auth.Region); err != nil {
-			return nil, fmt.Errorf("unable to set OCI SDK environment variable: %s: %w", auth.ResourcePrincipalRegionEnvVar, err)
+		// Without a region, the one of the environment is used.
+		if cfg.Auth.Region != "" {
+			if err := os.Setenv(auth.ResourcePrincipalRegionEnvVar, cfg.Auth.Region); err != nil {
+				return nil, fmt.Errorf("unable to set OCI SDK environment variable: %s: %w", auth.ResourcePrincipalRegionEnvVar, err)
+			}
 		}
 		configProvider, err = auth.OkeWorkloadIdentityConfigurationProvider()
 		if err != nil {
@@ -128,10 +139,14 @@ func NewOCIProvider(cfg OCIConfig, domainFilter *endpoint.DomainFilter, zoneIDFi
 		)
 	}
 
-	client, err = dns.NewDnsClientWithConfigurationProvider(configProvider)
+	dnsClient, err := dns.NewDnsClientWithConfigurationProvider(configProvider)
 	if err != nil {
 		return nil, fmt.Errorf("initializing OCI DNS API client: %w", err)
 	}
+	if egressConfig.Configured() {
+		dnsClient.HTTPClient = &http.Client{Transport: egressConfig.Transport()}
+	}
+	client = dnsClient
 
 	return &OCIProvider{
 		client:       client,
@@ -142,7 +157,8 @@ func NewOCIProvider(cfg OCIConfig, domainFilter *endpoint.DomainFilter, zoneIDFi
 		zoneCache: &zoneCache{
 			duration: cfg.ZoneCacheDuration,
 		},
-		dryRun: dryRun,
+		dryRun:       dryRun,
+		missingZones: missingZones,
 	}, nil
 }
 
@@ -158,13 +174,25 @@ func (p *OCIProvider) zones(ctx context.Context) (map[string]dns.ZoneSummary, er
 		scopes = dns.GetGetZoneScopeEnumValues()
 	}
 	log.Debugf("Matching zones against domain filters: %v", p.domainFilter.Filters)
-	for _, scope := range scopes {
-		if err := p.addPaginatedZones(ctx, zones, scope); err != nil {
-			return nil, err
+	compartments := p.compartments()
+	for _, compartment := range compartments {
+		for _, scope := range scopes {
+			// Private zones are only listed in the selected views, if any.
+			if scope == dns.GetZoneScopePrivate && len(p.cfg.ViewIDs) > 0 {
+				for _, view := range p.cfg.ViewIDs {
+					if err := p.addPaginatedZones(ctx, zones, compartment, scope, &view); err != nil {
+						return nil, err
+					}
+				}
+				continue
+			}
+			if err := p.addPaginatedZones(ctx, zones, compartment, scope, nil); err != nil {
+				return nil, err
+			}
 		}
 	}
 	if len(zones) == 0 {
-		log.Warnf("No zones in compartment %q match domain filters %v", p.cfg.CompartmentID, p.domainFilter)
+		log.Warnf("No zones in compartments %q match domain filters %v", compartments, p.domainFilter)
 	}
 	p.zoneCache.Reset(zones)
 	return zones, nil
@@ -203,18 +231,30 @@ func mergeEndpointsMultiTargets(endpoints []*endpoint.Endpoint) []*endpoint.Endp
 	return mergedEndpoints
 }
 
-func (p *OCIProvider) addPaginatedZones(ctx context.Context, zones map[string]dns.ZoneSummary, scope dns.GetZoneScopeEnum) error {
+// compartments returns the compartments whose zones are managed, without duplicates.
+func (p *OCIProvider) compartments() []string {
+	compartments := []string{p.cfg.CompartmentID}
+	for _, compartment := range p.cfg.ZoneCompartmentIDs {
+		if !slices.Contains(compartments, compartment) {
+			compartments = append(compartments, compartment)
+		}
+	}
+	return compartments
+}
+
+func (p *OCIProvider) addPaginatedZones(ctx context.Context, zones map[string]dns.ZoneSummary, compartment string, scope dns.GetZoneScopeEnum, view *string) error {
 	var page *string
 	// Loop until we have listed all zones.
 	for {
 		resp, err := p.client.ListZones(ctx, dns.ListZonesRequest{
-			CompartmentId: &p.cfg.CompartmentID,
+			CompartmentId: &compartment,
 			ZoneType:      dns.ListZonesZoneTypePrimary,
 			Scope:         dns.ListZonesScopeEnum(scope),
+			ViewId:        view,
 			Page:          page,
 		})
 		if err != nil {
-			return provider.NewSoftError(fmt.Errorf("listing zones in %s: %w", p.cfg.CompartmentID, err))
+			return provider.NewSoftError(fmt.Errorf("listing zones in %s: %w", compartment, err))
 		}
 		for _, zone := range resp.Items {
 			if p.domainFilter.Match(*zone.Name) && p.zoneIDFilter.Match(*zone.Id) {
@@ -254,6 +294,15 @@ func (p *OCIProvider) newFilteredRecordOperations(endpoints []*endpoint.Endpoint
 	return ops
 }
 
+// Capabilities implements provider.CapabilitiesReporter. The rdata of the records is in the
+// zone file format, so the TXT values longer than a character-string are split into quoted
+// character-strings.
+func (p *OCIProvider) Capabilities() provider.Capabilities {
+	return provider.Capabilities{
+		SplitTXT: true,
+	}
+}
+
 // Records returns the list of records in a given hosted zone.
 func (p *OCIProvider) Records(ctx context.Context) ([]*endpoint.Endpoint, error) {
 	zones, err := p.zones(ctx)
@@ -268,7 +317,7 @@ func (p *OCIProvider) Records(ctx context.Context) ([]*endpoint.Endpoint, error)
 			resp, err := p.client.GetZoneRecords(ctx, dns.GetZoneRecordsRequest{
 				ZoneNameOrId:  zone.Id,
 				Page:          page,
-				CompartmentId: &p.cfg.CompartmentID,
+				CompartmentId: p.zoneCompartment(zone),
 			})
 			if err != nil {
 				return nil, provider.NewSoftError(fmt.Errorf("getting records for zone %q: %w", *zone.Id, err))
@@ -322,7 +371,7 @@ func (p *OCIProvider) ApplyChanges(ctx context.Context, changes *plan.Changes) e
 	}
 
 	// Separate into per-zone change sets to be passed to OCI API.
-	opsByZone := operationsByZone(zones, ops)
+	opsByZone := operationsByZone(zones, ops, p.missingZones)
 	for zoneID, ops := range opsByZone {
 		log.Infof("Change zone: %q", zoneID)
 		for _, op := range ops {
@@ -336,7 +385,7 @@ func (p *OCIProvider) ApplyChanges(ctx context.Context, changes *plan.Changes) e
 
 	for zoneID, ops := range opsByZone {
 		if _, err := p.client.PatchZoneRecords(ctx, dns.PatchZoneRecordsRequest{
-			CompartmentId:           &p.cfg.CompartmentID,
+			CompartmentId:           p.zoneCompartment(zones[zoneID]),
 			ZoneNameOrId:            &zoneID,
 			PatchZoneRecordsDetails: dns.PatchZoneRecordsDetails{Items: ops},
 		}); err != nil {
@@ -347,6 +396,14 @@ func (p *OCIProvider) ApplyChanges(ctx context.Context, changes *plan.Changes) e
 	return nil
 }
 
+// zoneCompartment returns the compartment of a zone, which may be any of the managed compartments.
+func (p *OCIProvider) zoneCompartment(zone dns.ZoneSummary) *string {
+	if zone.CompartmentId != nil {
+		return zone.CompartmentId
+	}
+	return &p.cfg.CompartmentID
+}
+
 // AdjustEndpoints modifies the endpoints as needed by the specific provider
 func (p *OCIProvider) AdjustEndpoints(endpoints []*endpoint.Endpoint) ([]*endpoint.Endpoint, error) {
 	var adjustedEndpoints []*endpoint.Endpoint
@@ -384,8 +441,9 @@ func newRecordOperation(ep *endpoint.Endpoint, opType dns.RecordOperationOperati
 	}
 }
 
-// operationsByZone segments a slice of RecordOperations by their zone.
-func operationsByZone(zones map[string]dns.ZoneSummary, ops []dns.RecordOperation) map[string][]dns.RecordOperation {
+// operationsByZone segments a slice of RecordOperations by their zone. The domains matching no
+// zone are reported to missingZones.
+func operationsByZone(zones map[string]dns.ZoneSummary, ops []dns.RecordOperation, missingZones *provider.MissingZoneCache) map[string][]dns.RecordOperation {
 	changes := make(map[string][]dns.RecordOperation)
 
 	zoneNameIDMapper := provider.ZoneIDName{}
@@ -398,7 +456,9 @@ func operationsByZone(zones map[string]dns.ZoneSummary, ops []dns.RecordOperatio
 		if zoneID, _ := zoneNameIDMapper.FindZone(*op.Domain); zoneID != "" {
 			changes[zoneID] = append(changes[zoneID], op)
 		} else {
-			log.Warnf("No matching zone for record operation %s", op)
+			if missingZones.Report(*op.Domain) {
+				log.Warnf("No matching zone for record operation %s", op)
+			}
 		}
 	}
 
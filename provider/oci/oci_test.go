@@ -230,6 +230,8 @@ hKRtDhmSdWBo3tJK12RrAe4t7CUe8gMgTvU7ExlcA3xQkseFPx9K
 				provider.NewZoneIDFilter([]string{""}),
 				string(dns.GetZoneScopeGlobal),
 				false,
+				nil,
+				nil,
 			)
 			if err == nil {
 				require.NoError(t, err)
@@ -241,6 +243,10 @@ hKRtDhmSdWBo3tJK12RrAe4t7CUe8gMgTvU7ExlcA3xQkseFPx9K
 	}
 }
 
+func TestOCICapabilities(t *testing.T) {
+	require.True(t, (&OCIProvider{}).Capabilities().SplitTXT)
+}
+
 func TestOCIZones(t *testing.T) {
 	fooZoneId := "ocid1.dns-zone.oc1..e1e042ef0bfbb5c251b9713fd7bf8959"
 	barZoneId := "ocid1.dns-zone.oc1..502aeddba262b92fd13ed7874f6f1404"
@@ -318,6 +324,61 @@ func TestOCIZones(t *testing.T) {
 	}
 }
 
+// compartmentOCIDNSClient lists the zones of each compartment and view.
+type compartmentOCIDNSClient struct {
+	mockOCIDNSClient
+	zones    map[string][]dns.ZoneSummary
+	requests []dns.ListZonesRequest
+}
+
+func (c *compartmentOCIDNSClient) ListZones(_ context.Context, request dns.ListZonesRequest) (dns.ListZonesResponse, error) {
+	c.requests = append(c.requests, request)
+	key := *request.CompartmentId + "/" + string(request.Scope)
+	if request.ViewId != nil {
+		key += "/" + *request.ViewId
+	}
+	return dns.ListZonesResponse{Items: c.zones[key]}, nil
+}
+
+func TestOCIZonesCompartmentsAndViews(t *testing.T) {
+	inView := dns.ZoneSummary{
+		Id:            common.String("ocid1.dns-zone.oc1..view"),
+		Name:          common.String("internal.com"),
+		CompartmentId: common.String("ocid1.compartment.oc1..network"),
+		ViewId:        common.String("ocid1.dnsview.oc1..cluster"),
+	}
+	global := dns.ZoneSummary{
+		Id:            common.String("ocid1.dns-zone.oc1..global"),
+		Name:          common.String("public.com"),
+		CompartmentId: common.String("ocid1.compartment.oc1..network"),
+	}
+	client := &compartmentOCIDNSClient{zones: map[string][]dns.ZoneSummary{
+		"ocid1.compartment.oc1..network/PRIVATE/ocid1.dnsview.oc1..cluster": {inView},
+		"ocid1.compartment.oc1..network/GLOBAL":                             {global},
+	}}
+	p := newOCIProvider(client, endpoint.NewDomainFilter([]string{"com"}), provider.NewZoneIDFilter([]string{""}), "", false)
+	p.cfg.ZoneCompartmentIDs = []string{"ocid1.compartment.oc1..network", p.cfg.CompartmentID}
+	p.cfg.ViewIDs = []string{"ocid1.dnsview.oc1..cluster"}
+
+	zones, err := p.zones(context.Background())
+	require.NoError(t, err)
+	validateOCIZones(t, zones, map[string]dns.ZoneSummary{
+		*inView.Id: inView,
+		*global.Id: global,
+	})
+	// Both compartments are listed once, and the private zones only in the view.
+	require.Len(t, client.requests, 4)
+	for _, request := range client.requests {
+		if request.Scope == dns.ListZonesScopePrivate {
+			require.Equal(t, "ocid1.dnsview.oc1..cluster", *request.ViewId)
+		} else {
+			require.Nil(t, request.ViewId)
+		}
+	}
+	require.Equal(t, inView.CompartmentId, p.zoneCompartment(inView))
+	require.Equal(t, &p.cfg.CompartmentID, p.zoneCompartment(testGlobalZoneSummaryFoo))
+}
+
 func TestOCIRecords(t *testing.T) {
 	testCases := []struct {
 		name         string
@@ -518,7 +579,7 @@ func TestOperationsByZone(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			result := operationsByZone(tc.zones, tc.ops)
+			result := operationsByZone(tc.zones, tc.ops, nil)
 			require.Equal(t, tc.expected, result)
 		})
 	}
@@ -31,9 +31,11 @@ import (
 	"github.com/patrickmn/go-cache"
 	log "github.com/sirupsen/logrus"
 	"golang.org/x/sync/errgroup"
+	"golang.org/x/sync/semaphore"
 
 	"sigs.k8s.io/external-dns/endpoint"
 	"sigs.k8s.io/external-dns/pkg/apis/externaldns"
+	"sigs.k8s.io/external-dns/pkg/egress"
 	"sigs.k8s.io/external-dns/plan"
 	"sigs.k8s.io/external-dns/provider"
 
@@ -47,6 +49,13 @@ const (
 	ovhUpdate
 )
 
+const (
+	// defaultReadConcurrency and defaultWriteConcurrency are the maximum numbers of read and
+	// write requests sent at the same time, unless configured, below the default API rate limit
+	defaultReadConcurrency  = 10
+	defaultWriteConcurrency = 5
+)
+
 var (
 	// ErrRecordToMutateNotFound when ApplyChange has to update/delete and didn't found the record in the existing zone (Change with no record ID)
 	ErrRecordToMutateNotFound = errors.New("record to mutate not found in current zone")
@@ -59,6 +68,9 @@ type OVHProvider struct {
 	client ovhClient
 
 	apiRateLimiter ratelimit.Limiter
+	// readSlots and writeSlots limit the read and write requests sent at the same time, unlimited if nil
+	readSlots  *semaphore.Weighted
+	writeSlots *semaphore.Weighted
 
 	domainFilter *endpoint.DomainFilter
 
@@ -123,18 +135,21 @@ type ovhChange struct {
 }
 
 // NewOVHProvider initializes a new OVH DNS based Provider.
-func NewOVHProvider(ctx context.Context, domainFilter *endpoint.DomainFilter, endpoint string, apiRateLimit int, enableCNAMERelative, dryRun bool) (*OVHProvider, error) {
+func NewOVHProvider(ctx context.Context, domainFilter *endpoint.DomainFilter, endpoint string, apiRateLimit int, enableCNAMERelative, dryRun bool, egressConfig *egress.Config, concurrency provider.Concurrency) (*OVHProvider, error) {
 	client, err := ovh.NewEndpointClient(endpoint)
 	if err != nil {
 		return nil, err
 	}
 
 	client.UserAgent = externaldns.UserAgent()
+	client.Client.Transport = egressConfig.Transport()
 
 	return &OVHProvider{
 		client:                    client,
 		domainFilter:              domainFilter,
 		apiRateLimiter:            ratelimit.New(apiRateLimit),
+		readSlots:                 semaphore.NewWeighted(int64(concurrency.ReadLimit(defaultReadConcurrency))),
+		writeSlots:                semaphore.NewWeighted(int64(concurrency.WriteLimit(defaultWriteConcurrency))),
 		DryRun:                    dryRun,
 		cacheInstance:             cache.New(cache.NoExpiration, cache.NoExpiration),
 		dnsClient:                 new(dns.Client),
@@ -292,6 +307,11 @@ func (p *OVHProvider) refresh(ctx context.Context, zone string) error {
 		log.Infof("OVH: Dry-run: Would have refresh DNS zone %q", zone)
 		return nil
 	}
+	release, err := p.acquire(ctx, p.writeSlots)
+	if err != nil {
+		return provider.NewSoftError(err)
+	}
+	defer release()
 	if err := p.client.PostWithContext(ctx, fmt.Sprintf("/domain/zone/%s/refresh", url.PathEscape(zone)), nil, nil); err != nil {
 		return provider.NewSoftError(err)
 	}
@@ -300,6 +320,11 @@ func (p *OVHProvider) refresh(ctx context.Context, zone string) error {
 }
 
 func (p *OVHProvider) change(ctx context.Context, change ovhChange) error {
+	release, err := p.acquire(ctx, p.writeSlots)
+	if err != nil {
+		return err
+	}
+	defer release()
 	p.apiRateLimiter.Take()
 
 	switch change.Action {
@@ -335,6 +360,17 @@ func (p *OVHProvider) change(ctx context.Context, change ovhChange) error {
 	}
 }
 
+// acquire waits for a slot to send a request, read or write, and returns the function releasing it.
+func (p *OVHProvider) acquire(ctx context.Context, slots *semaphore.Weighted) (func(), error) {
+	if slots == nil {
+		return func() {}, nil
+	}
+	if err := slots.Acquire(ctx, 1); err != nil {
+		return nil, err
+	}
+	return func() { slots.Release(1) }, nil
+}
+
 func (p *OVHProvider) invalidateCache(zone string) {
 	p.cacheInstance.Delete(zone + "#soa")
 }
@@ -365,6 +401,11 @@ func (p *OVHProvider) zones(ctx context.Context) ([]string, error) {
 	var zones []string
 	var filteredZones []string
 
+	release, err := p.acquire(ctx, p.readSlots)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
 	p.apiRateLimiter.Take()
 	if err := p.client.GetWithContext(ctx, "/domain/zone", &zones); err != nil {
 		return nil, err
@@ -415,15 +456,22 @@ func (p *OVHProvider) records(ctx context.Context, zone *string, records chan<-
 
 	log.Debugf("OVH: Getting records for %s from API", *zone)
 
+	release, err := p.acquire(ctx, p.readSlots)
+	if err != nil {
+		return err
+	}
 	p.apiRateLimiter.Take()
 	var soa ovhSoa
 	if p.UseCache {
 		if err := p.client.GetWithContext(ctx, "/domain/zone/"+url.PathEscape(*zone)+"/soa", &soa); err != nil {
+			release()
 			return err
 		}
 	}
 
-	if err := p.client.GetWithContext(ctx, fmt.Sprintf("/domain/zone/%s/record", url.PathEscape(*zone)), &recordsIds); err != nil {
+	err = p.client.GetWithContext(ctx, fmt.Sprintf("/domain/zone/%s/record", url.PathEscape(*zone)), &recordsIds)
+	release()
+	if err != nil {
 		return err
 	}
 	chRecords := make(chan ovhRecord, len(recordsIds))
@@ -452,8 +500,14 @@ func (p *OVHProvider) record(ctx context.Context, zone *string, id uint64, recor
 
 	log.Debugf("OVH: Getting record %d for %s", id, *zone)
 
+	release, err := p.acquire(ctx, p.readSlots)
+	if err != nil {
+		return err
+	}
 	p.apiRateLimiter.Take()
-	if err := p.client.GetWithContext(ctx, fmt.Sprintf("/domain/zone/%s/record/%d", url.PathEscape(*zone), id), &record); err != nil {
+	err = p.client.GetWithContext(ctx, fmt.Sprintf("/domain/zone/%s/record/%d", url.PathEscape(*zone), id), &record)
+	release()
+	if err != nil {
 		return err
 	}
 	if provider.SupportedRecordType(record.FieldType) {
@@ -21,6 +21,7 @@ import (
 	"encoding/json"
 	"errors"
 	"sort"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -31,8 +32,11 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"go.uber.org/ratelimit"
+	"golang.org/x/sync/errgroup"
+	"golang.org/x/sync/semaphore"
 	"sigs.k8s.io/external-dns/endpoint"
 	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider"
 )
 
 type mockOvhClient struct {
@@ -614,6 +618,30 @@ func TestOvhChange(t *testing.T) {
 	client.AssertExpectations(t)
 }
 
+func TestOvhChangeConcurrency(t *testing.T) {
+	client := new(mockOvhClient)
+	provider := &OVHProvider{client: client, apiRateLimiter: ratelimit.NewUnlimited(), writeSlots: semaphore.NewWeighted(2), cacheInstance: cache.New(cache.NoExpiration, cache.NoExpiration)}
+
+	// no more than the write concurrency of changes are sent at the same time
+	var inflight, peak atomic.Int32
+	client.On("PostWithContext", "/domain/zone/example.net/record", mock.Anything).Run(func(mock.Arguments) {
+		n := inflight.Add(1)
+		for p := peak.Load(); n > p && !peak.CompareAndSwap(p, n); p = peak.Load() {
+		}
+		time.Sleep(10 * time.Millisecond)
+		inflight.Add(-1)
+	}).Return(nil, nil).Times(6)
+	eg, ctx := errgroup.WithContext(t.Context())
+	for range 6 {
+		eg.Go(func() error {
+			return provider.change(ctx, ovhChange{Action: ovhCreate, ovhRecord: ovhRecord{Zone: "example.net"}})
+		})
+	}
+	assert.NoError(t, eg.Wait())
+	client.AssertExpectations(t)
+	assert.Equal(t, int32(2), peak.Load())
+}
+
 func TestOvhRecordString(t *testing.T) {
 	record := ovhRecord{ID: 24, Zone: "example.org", ovhRecordFields: ovhRecordFields{FieldType: "A", ovhRecordFieldUpdate: ovhRecordFieldUpdate{SubDomain: "ovh", TTL: 10, Target: "203.0.113.42"}}}
 
@@ -622,13 +650,13 @@ func TestOvhRecordString(t *testing.T) {
 
 func TestNewOvhProvider(t *testing.T) {
 	domainFilter := &endpoint.DomainFilter{}
-	_, err := NewOVHProvider(t.Context(), domainFilter, "ovh-eu", 20, false, true)
+	_, err := NewOVHProvider(t.Context(), domainFilter, "ovh-eu", 20, false, true, nil, provider.Concurrency{})
 	td.CmpError(t, err)
 
 	t.Setenv("OVH_APPLICATION_KEY", "aaaaaa")
 	t.Setenv("OVH_APPLICATION_SECRET", "bbbbbb")
 	t.Setenv("OVH_CONSUMER_KEY", "cccccc")
 
-	_, err = NewOVHProvider(t.Context(), domainFilter, "ovh-eu", 20, false, true)
+	_, err = NewOVHProvider(t.Context(), domainFilter, "ovh-eu", 20, false, true, nil, provider.Concurrency{})
 	td.CmpNoError(t, err)
 }
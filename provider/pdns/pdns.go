@@ -26,6 +26,7 @@ import (
 	"math"
 	"net"
 	"net/http"
+	"slices"
 	"sort"
 	"strings"
 	"time"
@@ -34,7 +35,10 @@ import (
 	log "github.com/sirupsen/logrus"
 
 	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/pkg/egress"
+	"sigs.k8s.io/external-dns/pkg/failover"
 	"sigs.k8s.io/external-dns/pkg/tlsutils"
+	"sigs.k8s.io/external-dns/pkg/zoneserial"
 	"sigs.k8s.io/external-dns/plan"
 	"sigs.k8s.io/external-dns/provider"
 )
@@ -65,9 +69,18 @@ type PDNSConfig struct {
 	DomainFilter *endpoint.DomainFilter
 	DryRun       bool
 	Server       string
-	ServerID     string
-	APIKey       string
-	TLSConfig    TLSConfig
+	// FallbackServers are the URLs of the servers the requests fail over to, in order, when Server is unhealthy
+	FallbackServers []string
+	// FailoverCooldown is the time a server which failed is skipped for, the default of the
+	// failover transport if zero
+	FailoverCooldown time.Duration
+	ServerID         string
+	APIKey           string
+	TLSConfig        TLSConfig
+	// SerialVerifier verifies the serials of the changed zones, disabled if nil
+	SerialVerifier *zoneserial.Verifier
+	// Egress is the proxy and CA bundle of the requests to the servers
+	Egress *egress.Config
 }
 
 // TLSConfig is comprised of the TLS-related fields necessary to create a new PDNSProvider
@@ -78,7 +91,7 @@ type TLSConfig struct {
 	ClientCertKeyFilePath string
 }
 
-func (tlsConfig *TLSConfig) setHTTPClient(pdnsClientConfig *pgo.Configuration) error {
+func (tlsConfig *TLSConfig) setHTTPClient(pdnsClientConfig *pgo.Configuration, egressConfig *egress.Config) error {
 	log.Debug("Configuring TLS for PDNS Provider.")
 	tlsClientConfig, err := tlsutils.NewTLSConfig(
 		tlsConfig.ClientCertFilePath,
@@ -93,7 +106,7 @@ func (tlsConfig *TLSConfig) setHTTPClient(pdnsClientConfig *pgo.Configuration) e
 	}
 
 	// Timeouts taken from net.http.DefaultTransport
-	transporter := &http.Transport{
+	transporter := egressConfig.Apply(&http.Transport{
 		Proxy: http.ProxyFromEnvironment,
 		DialContext: (&net.Dialer{
 			Timeout:   30 * time.Second,
@@ -105,7 +118,7 @@ func (tlsConfig *TLSConfig) setHTTPClient(pdnsClientConfig *pgo.Configuration) e
 		TLSHandshakeTimeout:   10 * time.Second,
 		ExpectContinueTimeout: 1 * time.Second,
 		TLSClientConfig:       tlsClientConfig,
-	}
+	})
 	pdnsClientConfig.HTTPClient = &http.Client{
 		Transport: transporter,
 	}
@@ -213,7 +226,8 @@ func (c *PDNSAPIClient) PatchZone(zoneID string, zoneStruct pgo.Zone) (resp *htt
 // PDNSProvider is an implementation of the Provider interface for PowerDNS
 type PDNSProvider struct {
 	provider.BaseProvider
-	client PDNSAPIProvider
+	client         PDNSAPIProvider
+	serialVerifier *zoneserial.Verifier
 }
 
 // NewPDNSProvider initializes a new PowerDNS based Provider.
@@ -236,9 +250,16 @@ func NewPDNSProvider(ctx context.Context, config PDNSConfig) (*PDNSProvider, err
 
 	pdnsClientConfig := pgo.NewConfiguration()
 	pdnsClientConfig.BasePath = config.Server + apiBase
-	if err := config.TLSConfig.setHTTPClient(pdnsClientConfig); err != nil {
+	if err := config.TLSConfig.setHTTPClient(pdnsClientConfig, config.Egress); err != nil {
 		return nil, err
 	}
+	if len(config.FallbackServers) > 0 {
+		transport, err := failover.NewTransport(append([]string{config.Server}, config.FallbackServers...), pdnsClientConfig.HTTPClient.Transport, failover.WithCooldown(config.FailoverCooldown))
+		if err != nil {
+			return nil, err
+		}
+		pdnsClientConfig.HTTPClient.Transport = transport
+	}
 
 	provider := &PDNSProvider{
 		client: &PDNSAPIClient{
@@ -248,6 +269,7 @@ func NewPDNSProvider(ctx context.Context, config PDNSConfig) (*PDNSProvider, err
 			client:       pgo.NewAPIClient(pdnsClientConfig),
 			domainFilter: config.DomainFilter,
 		},
+		serialVerifier: config.SerialVerifier,
 	}
 	return provider, nil
 }
@@ -406,6 +428,15 @@ func (p *PDNSProvider) mutateRecords(endpoints []*endpoint.Endpoint, changetype
 	return nil
 }
 
+// Capabilities implements provider.CapabilitiesReporter. The contents of the records are in the
+// zone file format, so the TXT values longer than a character-string are split into quoted
+// character-strings.
+func (p *PDNSProvider) Capabilities() provider.Capabilities {
+	return provider.Capabilities{
+		SplitTXT: true,
+	}
+}
+
 // Records returns all DNS records controlled by the configured PDNS server (for all zones)
 func (p *PDNSProvider) Records(ctx context.Context) (endpoints []*endpoint.Endpoint, _ error) {
 	zones, _, err := p.client.ListZones()
@@ -451,6 +482,11 @@ func (p *PDNSProvider) AdjustEndpoints(endpoints []*endpoint.Endpoint) ([]*endpo
 func (p *PDNSProvider) ApplyChanges(ctx context.Context, changes *plan.Changes) error {
 	startTime := time.Now()
 
+	var serials map[string]uint32
+	if p.serialVerifier != nil {
+		serials = p.serialVerifier.Serials(ctx, p.changedZones(changes), p.zoneSerial)
+	}
+
 	// Create
 	for _, change := range changes.Create {
 		log.Infof("CREATE: %+v", change)
@@ -497,5 +533,40 @@ func (p *PDNSProvider) ApplyChanges(ctx context.Context, changes *plan.Changes)
 		}
 	}
 	log.Infof("Changes pushed out to PowerDNS in %s\n", time.Since(startTime))
+	if serials != nil {
+		p.serialVerifier.Verify(ctx, serials, p.zoneSerial)
+	}
 	return nil
 }
+
+// changedZones returns the names of the zones of the changed records, whose serials are verified.
+func (p *PDNSProvider) changedZones(changes *plan.Changes) []string {
+	endpoints := slices.Concat(changes.Create, changes.UpdateNew, changes.Delete)
+	if len(endpoints) == 0 {
+		return nil
+	}
+	zones, err := p.ConvertEndpointsToZones(endpoints, PdnsReplace)
+	if err != nil {
+		log.Warnf("Not verifying the serials of the changed zones: %v", err)
+		return nil
+	}
+	names := make([]string, 0, len(zones))
+	for _, zone := range zones {
+		names = append(names, zone.Name)
+	}
+	return names
+}
+
+// zoneSerial returns the serial of a zone, as listed by the API.
+func (p *PDNSProvider) zoneSerial(_ context.Context, name string) (uint32, error) {
+	zones, _, err := p.client.ListZones()
+	if err != nil {
+		return 0, err
+	}
+	for _, zone := range zones {
+		if zone.Name == name {
+			return uint32(zone.Serial), nil
+		}
+	}
+	return 0, fmt.Errorf("zone %s not found", name)
+}
@@ -20,14 +20,18 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"net/http/httptest"
 	"regexp"
 	"strings"
 	"testing"
+	"time"
 
 	pgo "github.com/ffledgling/pdns-go"
 	"github.com/stretchr/testify/suite"
 
 	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/pkg/zoneserial"
+	"sigs.k8s.io/external-dns/plan"
 	"sigs.k8s.io/external-dns/provider"
 )
 
@@ -703,6 +707,28 @@ func (c *PDNSAPIClientStubEmptyZones) PatchZone(zoneID string, zoneStruct pgo.Zo
 	return &http.Response{}, nil
 }
 
+/******************************************************************************/
+// API whose zones have a serial, increased by PatchZone()
+type PDNSAPIClientStubSerials struct {
+	PDNSAPIClientStubEmptyZones
+	serials        map[string]int32
+	listZonesCalls int
+}
+
+func (c *PDNSAPIClientStubSerials) ListZones() ([]pgo.Zone, *http.Response, error) {
+	c.listZonesCalls++
+	zones, resp, err := c.PDNSAPIClientStubEmptyZones.ListZones()
+	for i := range zones {
+		zones[i].Serial = c.serials[zones[i].Name]
+	}
+	return zones, resp, err
+}
+
+func (c *PDNSAPIClientStubSerials) PatchZone(zoneID string, zoneStruct pgo.Zone) (*http.Response, error) {
+	c.serials[zoneStruct.Name]++
+	return c.PDNSAPIClientStubEmptyZones.PatchZone(zoneID, zoneStruct)
+}
+
 /******************************************************************************/
 // API that returns error on PatchZone()
 type PDNSAPIClientStubPatchZoneFailure struct {
@@ -853,6 +879,42 @@ func (suite *NewPDNSProviderTestSuite) TestPDNSProviderCreateTLS() {
 	}), "Enabled TLS Config with all flags should raise no error")
 }
 
+func (suite *NewPDNSProviderTestSuite) TestPDNSProviderFallbackServers() {
+	primary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer primary.Close()
+	var paths []string
+	fallback := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte("[]"))
+	}))
+	defer fallback.Close()
+
+	_, err := NewPDNSProvider(context.Background(), PDNSConfig{
+		Server:          primary.URL,
+		FallbackServers: []string{"pdns2:8081"},
+		APIKey:          "foo",
+		DomainFilter:    endpoint.NewDomainFilter([]string{""}),
+	})
+	suite.Error(err, "invalid fallback server should raise an error")
+
+	p, err := NewPDNSProvider(context.Background(), PDNSConfig{
+		Server:          primary.URL,
+		FallbackServers: []string{fallback.URL},
+		ServerID:        "localhost",
+		APIKey:          "foo",
+		DomainFilter:    endpoint.NewDomainFilter([]string{""}),
+	})
+	suite.Require().NoError(err)
+
+	// the zones are listed from the fallback server while the primary one is unavailable
+	_, err = p.Records(context.Background())
+	suite.NoError(err)
+	suite.Equal([]string{"/api/v1/servers/localhost/zones"}, paths)
+}
+
 func (suite *NewPDNSProviderTestSuite) TestPDNSRRSetToEndpoints() {
 	// Function definition: convertRRSetToEndpoints(rr pgo.RrSet) (endpoints []*endpoint.Endpoint, _ error)
 
@@ -1064,6 +1126,27 @@ func (suite *NewPDNSProviderTestSuite) TestPDNSmutateRecords() {
 	suite.ErrorIs(err, provider.SoftError)
 }
 
+func (suite *NewPDNSProviderTestSuite) TestPDNSApplyChangesVerifiesSerials() {
+	c := &PDNSAPIClientStubSerials{serials: map[string]int32{"example.com.": 2025010101}}
+	p := &PDNSProvider{
+		client:         c,
+		serialVerifier: zoneserial.NewVerifier(nil, time.Second),
+	}
+
+	suite.Equal([]string{"example.com."}, p.changedZones(&plan.Changes{Create: endpointsSimpleRecord}))
+	serial, err := p.zoneSerial(context.Background(), "example.com.")
+	suite.NoError(err)
+	suite.Equal(uint32(2025010101), serial)
+	_, err = p.zoneSerial(context.Background(), "example.org.")
+	suite.Error(err)
+
+	c.listZonesCalls = 0
+	suite.NoError(p.ApplyChanges(context.Background(), &plan.Changes{Create: endpointsSimpleRecord}))
+	// the zones are listed for the changed zones, their serials before and after the changes, and the changes
+	suite.Equal(4, c.listZonesCalls)
+	suite.Equal(int32(2025010102), c.serials["example.com."])
+}
+
 func (suite *NewPDNSProviderTestSuite) TestPDNSClientPartitionZones() {
 	zoneList := []pgo.Zone{
 		ZoneEmpty,
@@ -1158,6 +1241,10 @@ func (suite *NewPDNSProviderTestSuite) TestPDNSAdjustEndpoints() {
 	}
 }
 
+func (suite *NewPDNSProviderTestSuite) TestPDNSCapabilities() {
+	suite.True((&PDNSProvider{}).Capabilities().SplitTXT)
+}
+
 func TestNewPDNSProviderTestSuite(t *testing.T) {
 	suite.Run(t, new(NewPDNSProviderTestSuite))
 }
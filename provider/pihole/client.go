@@ -33,6 +33,7 @@ import (
 	"golang.org/x/net/html"
 
 	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/pkg/httpbody"
 	"sigs.k8s.io/external-dns/provider"
 )
 
@@ -65,11 +66,11 @@ func newPiholeClient(cfg PiholeConfig) (piholeAPI, error) {
 	// Setup an HTTP client using the cookiejar
 	httpClient := &http.Client{
 		Jar: jar,
-		Transport: &http.Transport{
+		Transport: cfg.Egress.Apply(&http.Transport{
 			TLSClientConfig: &tls.Config{
 				InsecureSkipVerify: cfg.TLSInsecureSkipVerify,
 			},
-		},
+		}),
 	}
 	cl := instrumented_http.NewClient(httpClient, &instrumented_http.Callbacks{})
 
@@ -112,7 +113,7 @@ func (p *piholeClient) listRecords(ctx context.Context, rtype string) ([]*endpoi
 		return nil, err
 	}
 	defer body.Close()
-	raw, err := io.ReadAll(body)
+	raw, err := httpbody.ReadAll(body, httpbody.DefaultMaxBytes)
 	if err != nil {
 		return nil, err
 	}
@@ -238,7 +239,7 @@ func (p *piholeClient) apply(ctx context.Context, action string, ep *endpoint.En
 	}
 	defer body.Close()
 
-	raw, err := io.ReadAll(body)
+	raw, err := httpbody.ReadAll(body, httpbody.DefaultMaxBytes)
 	if err != nil {
 		return nil
 	}
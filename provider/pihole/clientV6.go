@@ -23,7 +23,6 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"io"
 	"net/http"
 	"net/netip"
 	"net/url"
@@ -34,6 +33,7 @@ import (
 	log "github.com/sirupsen/logrus"
 
 	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/pkg/httpbody"
 	"sigs.k8s.io/external-dns/provider"
 )
 
@@ -43,6 +43,10 @@ const (
 	apiConfigDNS    = "/api/config/dns"
 )
 
+// responseGuard rejects the responses which are not from the Pi-hole API, e.g. the error pages
+// of a proxy, and the bodies too large to be held in memory.
+var responseGuard = httpbody.Guard{ContentTypes: []string{contentTypeJSON}}
+
 // piholeClient implements the piholeAPI.
 type piholeClientV6 struct {
 	cfg        PiholeConfig
@@ -58,11 +62,11 @@ func newPiholeClientV6(cfg PiholeConfig) (piholeAPI, error) {
 
 	// Setup an HTTP client
 	httpClient := &http.Client{
-		Transport: &http.Transport{
+		Transport: cfg.Egress.Apply(&http.Transport{
 			TLSClientConfig: &tls.Config{
 				InsecureSkipVerify: cfg.TLSInsecureSkipVerify,
 			},
-		},
+		}),
 	}
 
 	cl := instrumented_http.NewClient(httpClient, &instrumented_http.Callbacks{})
@@ -286,12 +290,12 @@ func (p *piholeClientV6) apply(ctx context.Context, action string, ep *endpoint.
 
 	switch ep.RecordType {
 	case endpoint.RecordTypeA, endpoint.RecordTypeAAAA:
-		apiUrl = p.generateApiUrl(apiUrl, fmt.Sprintf("%s %s", ep.Targets, ep.DNSName))
+		apiUrl = p.generateApiUrl(apiUrl, fmt.Sprintf("%s %s", ep.Targets[0], ep.DNSName))
 	case endpoint.RecordTypeCNAME:
 		if ep.RecordTTL.IsConfigured() {
-			apiUrl = p.generateApiUrl(apiUrl, fmt.Sprintf("%s,%s,%d", ep.DNSName, ep.Targets, ep.RecordTTL))
+			apiUrl = p.generateApiUrl(apiUrl, fmt.Sprintf("%s,%s,%d", ep.DNSName, ep.Targets[0], ep.RecordTTL))
 		} else {
-			apiUrl = p.generateApiUrl(apiUrl, fmt.Sprintf("%s,%s", ep.DNSName, ep.Targets))
+			apiUrl = p.generateApiUrl(apiUrl, fmt.Sprintf("%s,%s", ep.DNSName, ep.Targets[0]))
 		}
 	}
 
@@ -316,8 +320,11 @@ func (p *piholeClientV6) retrieveNewToken(ctx context.Context) error {
 	apiUrl := fmt.Sprintf("%s"+apiAuthPath, p.cfg.Server)
 	log.Debugf("Fetching new token from %s", apiUrl)
 
-	// Define the JSON payload
-	jsonData := []byte(`{"password":"` + p.cfg.Password + `"}`)
+	// Define the JSON payload, escaping the characters of the password which are special in JSON.
+	jsonData, err := json.Marshal(map[string]string{"password": p.cfg.Password})
+	if err != nil {
+		return err
+	}
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost, apiUrl, bytes.NewBuffer(jsonData))
 	if err != nil {
@@ -362,8 +369,7 @@ func (p *piholeClientV6) checkTokenValidity(ctx context.Context) (bool, error) {
 		return false, err
 	}
 
-	jRes, err := io.ReadAll(res.Body)
-	defer res.Body.Close()
+	jRes, err := responseGuard.Read(res)
 	if err != nil {
 		return false, err
 	}
@@ -377,17 +383,17 @@ func (p *piholeClientV6) checkTokenValidity(ctx context.Context) (bool, error) {
 }
 
 func (p *piholeClientV6) do(req *http.Request) ([]byte, error) {
-	req.Header.Add("content-type", contentTypeJSON)
+	req.Header.Set("content-type", contentTypeJSON)
 	if p.token != "" {
-		req.Header.Add("X-FTL-SID", p.token)
+		// Set rather than add the session, as the request is sent again with a renewed one on expiry.
+		req.Header.Set("X-FTL-SID", p.token)
 	}
 	res, err := p.httpClient.Do(req)
 	if err != nil {
 		return nil, err
 	}
 
-	jRes, err := io.ReadAll(res.Body)
-	defer res.Body.Close()
+	jRes, err := responseGuard.Read(res)
 	if err != nil {
 		return nil, err
 	}
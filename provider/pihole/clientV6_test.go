@@ -28,6 +28,7 @@ import (
 	"testing"
 
 	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/pkg/httpbody"
 )
 
 func TestIsValidIPv4(t *testing.T) {
@@ -88,7 +89,11 @@ func TestIsValidIPv6(t *testing.T) {
 
 func newTestServerV6(t *testing.T, hdlr http.HandlerFunc) *httptest.Server {
 	t.Helper()
-	svr := httptest.NewServer(hdlr)
+	// the Pi-hole API always responds with JSON, unlike a proxy in front of it
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", contentTypeJSON)
+		hdlr(w, r)
+	}))
 	return svr
 }
 
@@ -711,6 +716,46 @@ func TestDoRetryOne(t *testing.T) {
 
 }
 
+func TestDoRetryRenewedSession(t *testing.T) {
+	srvRetry := newTestServerV6(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/api/auth" && r.Method == http.MethodPost:
+			var requestData map[string]string
+			if err := json.NewDecoder(r.Body).Decode(&requestData); err != nil || requestData["password"] != `pass"word` {
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(`{"error": {"key": "bad_request", "message": "Invalid JSON", "hint": null}, "took": 0.1}`))
+				return
+			}
+			w.Write([]byte(`{"session": {"valid": true, "totp": false, "sid": "renewed", "validity": 1800}, "took": 0.1}`))
+		case r.URL.Path == "/api/auth" && r.Method == http.MethodGet:
+			valid := r.Header.Get("X-FTL-SID") == "renewed"
+			w.Write([]byte(fmt.Sprintf(`{"session": {"valid": %t, "totp": false, "validity": 1800}, "took": 0.1}`, valid)))
+		case r.URL.Path == "/api/config/dns/hosts":
+			if sids := r.Header.Values("X-FTL-SID"); len(sids) != 1 || sids[0] != "renewed" {
+				w.WriteHeader(http.StatusUnauthorized)
+				w.Write([]byte(`{"error": {"key": "unauthorized", "message": "Unauthorized", "hint": null}, "took": 0.1}`))
+				return
+			}
+			w.Write([]byte(`{"config": {"dns": {"hosts": []}}, "took": 0.1}`))
+		default:
+			http.NotFound(w, r)
+		}
+	})
+	defer srvRetry.Close()
+
+	cl, err := newPiholeClientV6(PiholeConfig{Server: srvRetry.URL, APIVersion: "6", Password: `pass"word`})
+	if err != nil {
+		t.Fatal("Should escape the password", err)
+	}
+	// The session expired since the client logged in.
+	cl.(*piholeClientV6).token = "expired"
+
+	rq, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, srvRetry.URL+"/api/config/dns/hosts", nil)
+	if _, err := cl.(*piholeClientV6).do(rq); err != nil {
+		t.Fatal("Should retry with the renewed session only", err)
+	}
+}
+
 func TestCreateRecordV6(t *testing.T) {
 	var ep *endpoint.Endpoint
 	srvr := newTestServerV6(t, func(w http.ResponseWriter, r *http.Request) {
@@ -911,3 +956,25 @@ func TestDeleteRecordV6(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func TestDoFromProxy(t *testing.T) {
+	srvr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.WriteHeader(http.StatusBadGateway)
+		w.Write([]byte(`<html><body><h1>502 Bad Gateway</h1></body></html>`))
+	}))
+	defer srvr.Close()
+
+	cl, err := newPiholeClientV6(PiholeConfig{Server: srvr.URL, APIVersion: "6"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	req, err := http.NewRequest(http.MethodGet, srvr.URL+"/api/config/dns/hosts", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = cl.(*piholeClientV6).do(req)
+	if !errors.Is(err, httpbody.ErrUnexpectedContentType) {
+		t.Fatalf("Expected an unexpected content type error, got: %v", err)
+	}
+}
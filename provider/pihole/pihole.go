@@ -19,8 +19,10 @@ package pihole
 import (
 	"context"
 	"errors"
+	"slices"
 
 	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/pkg/egress"
 	"sigs.k8s.io/external-dns/plan"
 	"sigs.k8s.io/external-dns/provider"
 )
@@ -49,6 +51,8 @@ type PiholeConfig struct {
 	DryRun bool
 	// PiHole API version =<5 or >=6, default is 5
 	APIVersion string
+	// The egress proxy and CA bundle of the requests to the server.
+	Egress *egress.Config
 }
 
 // Helper struct for de-duping DNS entry updates.
@@ -89,16 +93,76 @@ func (p *PiholeProvider) Records(ctx context.Context) ([]*endpoint.Endpoint, err
 		return nil, err
 	}
 	aRecords = append(aRecords, aaaaRecords...)
-	return append(aRecords, cnameRecords...), nil
+	return mergeTargets(append(aRecords, cnameRecords...)), nil
+}
+
+// mergeTargets merges the records of the same name and type, as Pi-hole lists one host entry per IP address.
+func mergeTargets(records []*endpoint.Endpoint) []*endpoint.Endpoint {
+	merged := make([]*endpoint.Endpoint, 0, len(records))
+	indexes := make(map[piholeEntryKey]int)
+	for _, ep := range records {
+		key := piholeEntryKey{ep.DNSName, ep.RecordType}
+		idx, ok := indexes[key]
+		if !ok {
+			indexes[key] = len(merged)
+			merged = append(merged, ep)
+			continue
+		}
+		if slices.Contains(merged[idx].Targets, ep.Targets[0]) {
+			continue
+		}
+		// Copy the endpoint rather than changing the one of the API.
+		existing := *merged[idx]
+		existing.Targets = append(slices.Clone(existing.Targets), ep.Targets[0])
+		merged[idx] = &existing
+	}
+	return merged
+}
+
+// splitTargets returns an endpoint per target of the given one, as Pi-hole only has a single target per entry.
+func splitTargets(ep *endpoint.Endpoint, targets []string) []*endpoint.Endpoint {
+	if len(ep.Targets) == 1 && len(targets) == 1 && ep.Targets[0] == targets[0] {
+		return []*endpoint.Endpoint{ep}
+	}
+	split := make([]*endpoint.Endpoint, 0, len(targets))
+	for _, target := range targets {
+		single := *ep
+		single.Targets = endpoint.Targets{target}
+		split = append(split, &single)
+	}
+	return split
+}
+
+// missingTargets returns the targets of the endpoint which are not in the other one.
+func missingTargets(ep, other *endpoint.Endpoint) []string {
+	var missing []string
+	for _, target := range ep.Targets {
+		if !slices.Contains(other.Targets, target) {
+			missing = append(missing, target)
+		}
+	}
+	return missing
+}
+
+// WildcardSupport implements provider.WildcardSupporter, as Pi-hole local DNS has no wildcard records.
+func (p *PiholeProvider) WildcardSupport() provider.WildcardSupport {
+	return provider.WildcardSupport{}
+}
+
+// Capabilities implements provider.CapabilitiesReporter, as Pi-hole local DNS only has A, AAAA and CNAME records.
+func (p *PiholeProvider) Capabilities() provider.Capabilities {
+	return provider.Capabilities{
+		RecordTypes: []string{endpoint.RecordTypeA, endpoint.RecordTypeAAAA, endpoint.RecordTypeCNAME},
+	}
 }
 
 // ApplyChanges implements Provider, syncing desired state with the Pi-hole server Local DNS.
 func (p *PiholeProvider) ApplyChanges(ctx context.Context, changes *plan.Changes) error {
+	var deletes, creates []*endpoint.Endpoint
+
 	// Handle pure deletes first.
 	for _, ep := range changes.Delete {
-		if err := p.api.deleteRecord(ctx, ep); err != nil {
-			return err
-		}
+		deletes = append(deletes, splitTargets(ep, ep.Targets)...)
 	}
 
 	// Handle updated state - there are no endpoints for updating in place.
@@ -109,15 +173,24 @@ func (p *PiholeProvider) ApplyChanges(ctx context.Context, changes *plan.Changes
 	}
 
 	for _, ep := range changes.UpdateOld {
-		// Check if this existing entry has an exact match for an updated entry and skip it if so.
+		// Only replace the targets which changed, or all of them when the TTL of a CNAME changed.
 		key := piholeEntryKey{ep.DNSName, ep.RecordType}
-		if newRecord := updateNew[key]; newRecord != nil {
-			// PiHole only has a single target; no need to compare other fields.
-			if newRecord.Targets[0] == ep.Targets[0] {
-				delete(updateNew, key)
-				continue
-			}
+		newRecord := updateNew[key]
+		if newRecord == nil {
+			deletes = append(deletes, splitTargets(ep, ep.Targets)...)
+			continue
 		}
+		delete(updateNew, key)
+		if ep.RecordType == endpoint.RecordTypeCNAME && ep.RecordTTL != newRecord.RecordTTL {
+			deletes = append(deletes, splitTargets(ep, ep.Targets)...)
+			creates = append(creates, splitTargets(newRecord, newRecord.Targets)...)
+			continue
+		}
+		deletes = append(deletes, splitTargets(ep, missingTargets(ep, newRecord))...)
+		creates = append(creates, splitTargets(newRecord, missingTargets(newRecord, ep))...)
+	}
+
+	for _, ep := range deletes {
 		if err := p.api.deleteRecord(ctx, ep); err != nil {
 			return err
 		}
@@ -125,15 +198,30 @@ func (p *PiholeProvider) ApplyChanges(ctx context.Context, changes *plan.Changes
 
 	// Handle pure creates before applying new updated state.
 	for _, ep := range changes.Create {
+		if err := p.createRecord(ctx, ep); err != nil {
+			return err
+		}
+	}
+	for _, ep := range creates {
 		if err := p.api.createRecord(ctx, ep); err != nil {
 			return err
 		}
 	}
 	for _, ep := range updateNew {
-		if err := p.api.createRecord(ctx, ep); err != nil {
+		if err := p.createRecord(ctx, ep); err != nil {
 			return err
 		}
 	}
 
 	return nil
 }
+
+// createRecord creates an entry per target of the endpoint.
+func (p *PiholeProvider) createRecord(ctx context.Context, ep *endpoint.Endpoint) error {
+	for _, single := range splitTargets(ep, ep.Targets) {
+		if err := p.api.createRecord(ctx, single); err != nil {
+			return err
+		}
+	}
+	return nil
+}
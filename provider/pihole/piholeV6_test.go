@@ -432,3 +432,63 @@ func TestProviderV6(t *testing.T) {
 
 	requests.clear()
 }
+
+func TestProviderV6MultipleTargets(t *testing.T) {
+	requests := requestTrackerV6{}
+	p := &PiholeProvider{
+		api: &testPiholeClientV6{endpoints: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("test.example.com", endpoint.RecordTypeA, "192.168.1.1"),
+			endpoint.NewEndpoint("test.example.com", endpoint.RecordTypeA, "192.168.1.2"),
+			endpoint.NewEndpointWithTTL("alias.example.com", endpoint.RecordTypeCNAME, 300, "test.example.com"),
+		}, requests: &requests},
+	}
+
+	// Test the host entries of a name are merged
+	records, err := p.Records(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []*endpoint.Endpoint{
+		endpoint.NewEndpoint("test.example.com", endpoint.RecordTypeA, "192.168.1.1", "192.168.1.2"),
+		endpoint.NewEndpointWithTTL("alias.example.com", endpoint.RecordTypeCNAME, 300, "test.example.com"),
+	}
+	if !reflect.DeepEqual(records, expected) {
+		t.Fatal("Expected merged records, got:", records)
+	}
+	if len(p.api.(*testPiholeClientV6).endpoints[0].Targets) != 1 {
+		t.Fatal("Merging the records should not change the ones of the API")
+	}
+
+	// Test only the changed targets are replaced
+	if err := p.ApplyChanges(context.Background(), &plan.Changes{
+		Create: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("new.example.com", endpoint.RecordTypeAAAA, "fc00::1", "fc00::2"),
+		},
+		UpdateOld: records,
+		UpdateNew: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("test.example.com", endpoint.RecordTypeA, "192.168.1.2", "192.168.1.3"),
+			endpoint.NewEndpointWithTTL("alias.example.com", endpoint.RecordTypeCNAME, 600, "test.example.com"),
+		},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	expectedDeletes := []*endpoint.Endpoint{
+		endpoint.NewEndpoint("test.example.com", endpoint.RecordTypeA, "192.168.1.1"),
+		endpoint.NewEndpointWithTTL("alias.example.com", endpoint.RecordTypeCNAME, 300, "test.example.com"),
+	}
+	if !reflect.DeepEqual(requests.deleteRequests, expectedDeletes) {
+		t.Error("Unexpected delete requests, got:", requests.deleteRequests, "expected:", expectedDeletes)
+	}
+	expectedCreates := []*endpoint.Endpoint{
+		endpoint.NewEndpoint("new.example.com", endpoint.RecordTypeAAAA, "fc00::1"),
+		endpoint.NewEndpoint("new.example.com", endpoint.RecordTypeAAAA, "fc00::2"),
+		endpoint.NewEndpoint("test.example.com", endpoint.RecordTypeA, "192.168.1.3"),
+		endpoint.NewEndpointWithTTL("alias.example.com", endpoint.RecordTypeCNAME, 600, "test.example.com"),
+	}
+	if !reflect.DeepEqual(requests.createRequests, expectedCreates) {
+		t.Error("Unexpected create requests, got:", requests.createRequests, "expected:", expectedCreates)
+	}
+
+	requests.clear()
+}
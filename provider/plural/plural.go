@@ -24,6 +24,7 @@ import (
 	log "github.com/sirupsen/logrus"
 
 	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/pkg/egress"
 	"sigs.k8s.io/external-dns/plan"
 	"sigs.k8s.io/external-dns/provider"
 )
@@ -43,7 +44,7 @@ type RecordChange struct {
 	Record *DnsRecord
 }
 
-func NewPluralProvider(cluster, provider string) (*PluralProvider, error) {
+func NewPluralProvider(cluster, provider string, egressConfig *egress.Config) (*PluralProvider, error) {
 	token := os.Getenv("PLURAL_ACCESS_TOKEN")
 	if token == "" {
 		return nil, fmt.Errorf("no plural access token provided, you must set the PLURAL_ACCESS_TOKEN env var")
@@ -54,6 +55,7 @@ func NewPluralProvider(cluster, provider string) (*PluralProvider, error) {
 		Endpoint: os.Getenv("PLURAL_ENDPOINT"),
 		Cluster:  cluster,
 		Provider: provider,
+		Egress:   egressConfig,
 	}
 
 	cl, err := NewClient(config)
@@ -66,8 +68,8 @@ func NewPluralProvider(cluster, provider string) (*PluralProvider, error) {
 	}, nil
 }
 
-func (p *PluralProvider) Records(_ context.Context) (endpoints []*endpoint.Endpoint, err error) {
-	records, err := p.Client.DnsRecords()
+func (p *PluralProvider) Records(ctx context.Context) (endpoints []*endpoint.Endpoint, err error) {
+	records, err := p.Client.DnsRecords(ctx)
 	if err != nil {
 		return
 	}
@@ -83,7 +85,7 @@ func (p *PluralProvider) AdjustEndpoints(endpoints []*endpoint.Endpoint) ([]*end
 	return endpoints, nil
 }
 
-func (p *PluralProvider) ApplyChanges(_ context.Context, diffs *plan.Changes) error {
+func (p *PluralProvider) ApplyChanges(ctx context.Context, diffs *plan.Changes) error {
 	var changes []*RecordChange
 	for _, ep := range diffs.Create {
 		changes = append(changes, makeChange(CreateAction, ep.Targets, ep))
@@ -97,7 +99,7 @@ func (p *PluralProvider) ApplyChanges(_ context.Context, diffs *plan.Changes) er
 		changes = append(changes, makeChange(DeleteAction, []string{}, deleted))
 	}
 
-	return p.applyChanges(changes)
+	return p.applyChanges(ctx, changes)
 }
 
 func makeChange(change string, target []string, endpoint *endpoint.Endpoint) *RecordChange {
@@ -111,7 +113,7 @@ func makeChange(change string, target []string, endpoint *endpoint.Endpoint) *Re
 	}
 }
 
-func (p *PluralProvider) applyChanges(changes []*RecordChange) error {
+func (p *PluralProvider) applyChanges(ctx context.Context, changes []*RecordChange) error {
 	for _, change := range changes {
 		logFields := log.Fields{
 			"name":   change.Record.Name,
@@ -121,13 +123,13 @@ func (p *PluralProvider) applyChanges(changes []*RecordChange) error {
 		log.WithFields(logFields).Info("Changing record.")
 
 		if change.Action == CreateAction {
-			_, err := p.Client.CreateRecord(change.Record)
+			_, err := p.Client.CreateRecord(ctx, change.Record)
 			if err != nil {
 				return err
 			}
 		}
 		if change.Action == DeleteAction {
-			if err := p.Client.DeleteRecord(change.Record.Name, change.Record.Type); err != nil {
+			if err := p.Client.DeleteRecord(ctx, change.Record.Name, change.Record.Type); err != nil {
 				return err
 			}
 		}
@@ -35,13 +35,13 @@ type ClientStub struct {
 }
 
 // CreateRecord provides a mock function with given fields: record
-func (c *ClientStub) CreateRecord(record *DnsRecord) (*DnsRecord, error) {
+func (c *ClientStub) CreateRecord(_ context.Context, record *DnsRecord) (*DnsRecord, error) {
 	c.mockDnsRecords = append(c.mockDnsRecords, record)
 	return record, nil
 }
 
 // DeleteRecord provides a mock function with given fields: name, ttype
-func (c *ClientStub) DeleteRecord(name string, ttype string) error {
+func (c *ClientStub) DeleteRecord(_ context.Context, name string, ttype string) error {
 	newRecords := make([]*DnsRecord, 0)
 	for _, record := range c.mockDnsRecords {
 		if record.Name == name && record.Type == ttype {
@@ -54,7 +54,7 @@ func (c *ClientStub) DeleteRecord(name string, ttype string) error {
 }
 
 // DnsRecords provides a mock function with given fields:
-func (c *ClientStub) DnsRecords() ([]*DnsRecord, error) {
+func (c *ClientStub) DnsRecords(_ context.Context) ([]*DnsRecord, error) {
 	return c.mockDnsRecords, nil
 }
 
@@ -0,0 +1,131 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+// PropertyType is the type of the value of a provider-specific property.
+type PropertyType string
+
+const (
+	// PropertyTypeString accepts any value.
+	PropertyTypeString PropertyType = "string"
+	// PropertyTypeBool accepts true or false.
+	PropertyTypeBool PropertyType = "bool"
+	// PropertyTypeInt accepts integers.
+	PropertyTypeInt PropertyType = "int"
+)
+
+// PropertySchema describes a provider-specific property supported by a provider.
+type PropertySchema struct {
+	// Name is the name of the property, e.g. aws/weight.
+	Name string `json:"name"`
+	// Type is the type of the value of the property.
+	Type PropertyType `json:"type"`
+	// Values are the values accepted for the property, any value of the type if empty.
+	Values []string `json:"values,omitempty"`
+	// Validate checks the value of the property once its type is valid, if set. It is not
+	// reported by the webhook providers, whose properties are only checked against their type
+	// and values.
+	Validate func(value string) error `json:"-"`
+}
+
+// validate returns an error explaining why the value is not valid for the property, or nil.
+func (s PropertySchema) validate(value string) error {
+	switch s.Type {
+	case PropertyTypeBool:
+		if _, err := strconv.ParseBool(value); err != nil {
+			return fmt.Errorf("the value %q of property %s is not a boolean", value, s.Name)
+		}
+	case PropertyTypeInt:
+		if _, err := strconv.ParseInt(value, 10, 64); err != nil {
+			return fmt.Errorf("the value %q of property %s is not an integer", value, s.Name)
+		}
+	}
+	if len(s.Values) > 0 && !slices.Contains(s.Values, value) {
+		return fmt.Errorf("the value %q of property %s is not one of %v", value, s.Name, s.Values)
+	}
+	if s.Validate != nil {
+		if err := s.Validate(value); err != nil {
+			return fmt.Errorf("the value %q of property %s is not valid: %w", value, s.Name, err)
+		}
+	}
+	return nil
+}
+
+// IntRange returns a validation of integer properties accepting the values from min to max.
+func IntRange(minValue, maxValue int64) func(string) error {
+	return func(value string) error {
+		i, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return err
+		}
+		if i < minValue || i > maxValue {
+			return fmt.Errorf("must be between %d and %d", minValue, maxValue)
+		}
+		return nil
+	}
+}
+
+// property returns the schema of a property, if the provider declares it.
+func (c Capabilities) property(name string) (PropertySchema, bool) {
+	for _, schema := range c.Properties {
+		if schema.Name == name {
+			return schema, true
+		}
+	}
+	return PropertySchema{}, false
+}
+
+// ValidateProperties returns an error for the first property of the record whose value
+// doesn't match the schema declared by the provider.
+func (c Capabilities) ValidateProperties(ep *endpoint.Endpoint) error {
+	for _, p := range ep.ProviderSpecific {
+		if schema, ok := c.property(p.Name); ok {
+			if err := schema.validate(p.Value); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// UnknownProperties returns the provider-specific properties of the record which have one of
+// the prefixes of the provider, but which the provider doesn't declare: they are most likely
+// misspelled, and ignored by the provider.
+func (c Capabilities) UnknownProperties(ep *endpoint.Endpoint) []string {
+	var unknown []string
+	for _, p := range ep.ProviderSpecific {
+		if _, ok := c.property(p.Name); ok {
+			continue
+		}
+		for _, prefix := range c.PropertyPrefixes {
+			if strings.HasPrefix(p.Name, prefix) {
+				unknown = append(unknown, p.Name)
+				break
+			}
+		}
+	}
+	return unknown
+}
@@ -0,0 +1,72 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+var propertiesCapabilities = Capabilities{
+	Properties: []PropertySchema{
+		{Name: "test/proxied", Type: PropertyTypeBool},
+		{Name: "test/weight", Type: PropertyTypeInt, Validate: IntRange(0, 255)},
+		{Name: "test/failover", Type: PropertyTypeString, Values: []string{"PRIMARY", "SECONDARY"}},
+		{Name: "test/comment", Type: PropertyTypeString},
+	},
+	PropertyPrefixes: []string{"test/"},
+}
+
+func TestCapabilitiesValidateProperties(t *testing.T) {
+	for _, tc := range []struct {
+		name, property, value string
+		err                   string
+	}{
+		{name: "bool", property: "test/proxied", value: "true"},
+		{name: "int", property: "test/weight", value: "10"},
+		{name: "value", property: "test/failover", value: "PRIMARY"},
+		{name: "string", property: "test/comment", value: "anything"},
+		{name: "undeclared", property: "other/weight", value: "heavy"},
+		{name: "not bool", property: "test/proxied", value: "yes please", err: `the value "yes please" of property test/proxied is not a boolean`},
+		{name: "not int", property: "test/weight", value: "heavy", err: `the value "heavy" of property test/weight is not an integer`},
+		{name: "out of range", property: "test/weight", value: "300", err: `the value "300" of property test/weight is not valid: must be between 0 and 255`},
+		{name: "unknown value", property: "test/failover", value: "TERTIARY", err: `the value "TERTIARY" of property test/failover is not one of [PRIMARY SECONDARY]`},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ep := endpoint.NewEndpoint("www.example.org", endpoint.RecordTypeA, "1.2.3.4").WithProviderSpecific(tc.property, tc.value)
+			err := propertiesCapabilities.Validate(ep)
+			if tc.err == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.EqualError(t, err, tc.err)
+		})
+	}
+}
+
+func TestCapabilitiesUnknownProperties(t *testing.T) {
+	ep := endpoint.NewEndpoint("www.example.org", endpoint.RecordTypeA, "1.2.3.4").
+		WithProviderSpecific("test/proxied", "true").
+		WithProviderSpecific("test/wieght", "10").
+		WithProviderSpecific("other/weight", "10")
+	assert.Equal(t, []string{"test/wieght"}, propertiesCapabilities.UnknownProperties(ep))
+	assert.Empty(t, Capabilities{}.UnknownProperties(ep))
+}
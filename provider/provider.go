@@ -21,6 +21,7 @@ import (
 	"errors"
 	"fmt"
 	"net"
+	"slices"
 	"strings"
 
 	"sigs.k8s.io/external-dns/endpoint"
@@ -57,6 +58,121 @@ type Provider interface {
 	GetDomainFilter() endpoint.DomainFilterInterface
 }
 
+// WildcardSupport describes the support of a provider for wildcard records.
+type WildcardSupport struct {
+	// Records is whether the provider supports wildcard records, such as *.example.com.
+	Records bool
+	// TXTNames is whether the provider accepts an asterisk within a label of the name of a TXT record,
+	// such as a-*.example.com, which the TXT registry creates for wildcard records unless told otherwise.
+	TXTNames bool
+}
+
+// WildcardSupporter is implemented by the providers with a limited support for wildcard records.
+type WildcardSupporter interface {
+	WildcardSupport() WildcardSupport
+}
+
+// GetWildcardSupport returns the support of a provider for wildcard records.
+// The providers that don't implement WildcardSupporter are assumed to fully support them.
+func GetWildcardSupport(p Provider) WildcardSupport {
+	if s, ok := p.(WildcardSupporter); ok {
+		return s.WildcardSupport()
+	}
+	return WildcardSupport{Records: true, TXTNames: true}
+}
+
+// ZoneRecordsReader is implemented by the providers that can list the records of the zones
+// of a domain, without listing all the records they manage.
+type ZoneRecordsReader interface {
+	// ZoneRecords returns the records of the zones containing the domain or its subdomains.
+	// They may include records outside of the domain.
+	ZoneRecords(ctx context.Context, domain string) ([]*endpoint.Endpoint, error)
+}
+
+// ZoneRecords returns records including those of the given domain. It lists all the records
+// of the providers that don't implement ZoneRecordsReader.
+func ZoneRecords(ctx context.Context, p Provider, domain string) ([]*endpoint.Endpoint, error) {
+	if r, ok := p.(ZoneRecordsReader); ok && domain != "" {
+		return r.ZoneRecords(ctx, domain)
+	}
+	return p.Records(ctx)
+}
+
+// RecordsStreamer is implemented by the providers that can list their records one zone at a time,
+// so that the records of all the zones don't have to be held in memory at once.
+type RecordsStreamer interface {
+	// ZoneNames returns the names of the zones managed by the provider.
+	ZoneNames(ctx context.Context) ([]string, error)
+	// StreamRecords calls fn with the records of each zone in turn, stopping at the first error.
+	StreamRecords(ctx context.Context, fn func(zone string, records []*endpoint.Endpoint) error) error
+}
+
+// ZoneNames returns the names of the zones of a provider, or none for the providers that
+// don't implement RecordsStreamer.
+func ZoneNames(ctx context.Context, p Provider) ([]string, error) {
+	if s, ok := p.(RecordsStreamer); ok {
+		return s.ZoneNames(ctx)
+	}
+	return nil, nil
+}
+
+// StreamRecords calls fn with the records of each zone of a provider in turn. It calls fn once,
+// with an empty zone name and all the records, for the providers that don't implement RecordsStreamer.
+func StreamRecords(ctx context.Context, p Provider, fn func(zone string, records []*endpoint.Endpoint) error) error {
+	if s, ok := p.(RecordsStreamer); ok {
+		return s.StreamRecords(ctx, fn)
+	}
+	records, err := p.Records(ctx)
+	if err != nil {
+		return err
+	}
+	return fn("", records)
+}
+
+// ZoneOverlapsDomain returns whether a zone contains the domain or a subdomain of it, i.e.
+// whether it holds records of the domain or of its subdomains.
+func ZoneOverlapsDomain(zone, domain string) bool {
+	zone = strings.ToLower(strings.TrimSuffix(zone, "."))
+	domain = strings.ToLower(strings.TrimSuffix(domain, "."))
+	return zone == domain || strings.HasSuffix(domain, "."+zone) || strings.HasSuffix(zone, "."+domain)
+}
+
+// SortedZoneNames returns the distinct names of the zones, without trailing dot, sorted.
+func SortedZoneNames(zones ZoneIDName) []string {
+	names := make([]string, 0, len(zones))
+	for _, name := range zones {
+		name = strings.TrimSuffix(name, ".")
+		if !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+	return names
+}
+
+// StreamZoneRecords implements RecordsStreamer for the providers listing the records of their zones
+// by ID: it calls fn with the records of the zones of each name in turn, sorted by name, listed with
+// list from the IDs of the zones of the name, e.g. the public and the private zones of a domain.
+func StreamZoneRecords(zones ZoneIDName, list func(zoneIDs []string) ([]*endpoint.Endpoint, error), fn func(zone string, records []*endpoint.Endpoint) error) error {
+	for _, name := range SortedZoneNames(zones) {
+		var zoneIDs []string
+		for id, zoneName := range zones {
+			if strings.TrimSuffix(zoneName, ".") == name {
+				zoneIDs = append(zoneIDs, id)
+			}
+		}
+		slices.Sort(zoneIDs)
+		records, err := list(zoneIDs)
+		if err != nil {
+			return err
+		}
+		if err := fn(name, records); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 type BaseProvider struct{}
 
 func (b BaseProvider) AdjustEndpoints(endpoints []*endpoint.Endpoint) ([]*endpoint.Endpoint, error) {
@@ -17,12 +17,15 @@ limitations under the License.
 package provider
 
 import (
+	"errors"
 	"io"
 	"os"
 	"testing"
 
 	log "github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/external-dns/endpoint"
 )
 
 func TestMain(m *testing.M) {
@@ -54,3 +57,40 @@ func TestDifference(t *testing.T) {
 	assert.Equal(t, []string{"foo"}, remove)
 	assert.Equal(t, []string{"bar"}, leave)
 }
+
+func TestZoneOverlapsDomain(t *testing.T) {
+	assert.True(t, ZoneOverlapsDomain("example.org.", "example.org"))
+	assert.True(t, ZoneOverlapsDomain("example.org", "foo.Example.org"))
+	assert.True(t, ZoneOverlapsDomain("foo.example.org", "example.org"))
+	assert.False(t, ZoneOverlapsDomain("example.org", "otherexample.org"))
+	assert.False(t, ZoneOverlapsDomain("foo.example.org", "bar.example.org"))
+}
+
+func TestStreamZoneRecords(t *testing.T) {
+	zones := ZoneIDName{
+		"public":  "example.org.",
+		"private": "example.org.",
+		"other":   "example.com.",
+	}
+	assert.Equal(t, []string{"example.com", "example.org"}, SortedZoneNames(zones))
+
+	var listed [][]string
+	var streamed []string
+	err := StreamZoneRecords(zones, func(zoneIDs []string) ([]*endpoint.Endpoint, error) {
+		listed = append(listed, zoneIDs)
+		return nil, nil
+	}, func(zone string, records []*endpoint.Endpoint) error {
+		streamed = append(streamed, zone)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{{"other"}, {"private", "public"}}, listed)
+	assert.Equal(t, []string{"example.com", "example.org"}, streamed)
+
+	err = StreamZoneRecords(zones, func(zoneIDs []string) ([]*endpoint.Endpoint, error) {
+		return nil, errors.New("list failed")
+	}, func(zone string, records []*endpoint.Endpoint) error {
+		return nil
+	})
+	require.Error(t, err)
+}
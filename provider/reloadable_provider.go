@@ -0,0 +1,87 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"sync"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+)
+
+// ReloadableProvider delegates all calls to a Provider that can be replaced at
+// runtime, e.g. after its credentials have been rotated.
+type ReloadableProvider struct {
+	mu       sync.RWMutex
+	provider Provider
+}
+
+// NewReloadableProvider returns a ReloadableProvider delegating to p.
+func NewReloadableProvider(p Provider) *ReloadableProvider {
+	return &ReloadableProvider{provider: p}
+}
+
+// Swap replaces the underlying provider. Calls that are already in flight
+// complete against the previous provider.
+func (r *ReloadableProvider) Swap(p Provider) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.provider = p
+}
+
+func (r *ReloadableProvider) current() Provider {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.provider
+}
+
+func (r *ReloadableProvider) Records(ctx context.Context) ([]*endpoint.Endpoint, error) {
+	return r.current().Records(ctx)
+}
+
+func (r *ReloadableProvider) ApplyChanges(ctx context.Context, changes *plan.Changes) error {
+	return r.current().ApplyChanges(ctx, changes)
+}
+
+func (r *ReloadableProvider) AdjustEndpoints(endpoints []*endpoint.Endpoint) ([]*endpoint.Endpoint, error) {
+	return r.current().AdjustEndpoints(endpoints)
+}
+
+func (r *ReloadableProvider) GetDomainFilter() endpoint.DomainFilterInterface {
+	return r.current().GetDomainFilter()
+}
+
+func (r *ReloadableProvider) ZoneRecords(ctx context.Context, domain string) ([]*endpoint.Endpoint, error) {
+	return ZoneRecords(ctx, r.current(), domain)
+}
+
+func (r *ReloadableProvider) ZoneNames(ctx context.Context) ([]string, error) {
+	return ZoneNames(ctx, r.current())
+}
+
+func (r *ReloadableProvider) StreamRecords(ctx context.Context, fn func(zone string, records []*endpoint.Endpoint) error) error {
+	return StreamRecords(ctx, r.current(), fn)
+}
+
+func (r *ReloadableProvider) WildcardSupport() WildcardSupport {
+	return GetWildcardSupport(r.current())
+}
+
+func (r *ReloadableProvider) Capabilities() Capabilities {
+	return GetCapabilities(r.current())
+}
@@ -0,0 +1,65 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+)
+
+func TestReloadableProviderDelegatesToCurrentProvider(t *testing.T) {
+	first := newTestProviderFunc(t)
+	first.records = func(ctx context.Context) ([]*endpoint.Endpoint, error) {
+		return []*endpoint.Endpoint{{DNSName: "first.fqdn"}}, nil
+	}
+	first.getDomainFilter = func() endpoint.DomainFilterInterface {
+		return endpoint.NewDomainFilter([]string{"first.fqdn"})
+	}
+
+	p := NewReloadableProvider(first)
+	endpoints, err := p.Records(context.Background())
+	require.NoError(t, err)
+	require.Len(t, endpoints, 1)
+	assert.Equal(t, "first.fqdn", endpoints[0].DNSName)
+	assert.True(t, p.GetDomainFilter().Match("first.fqdn"))
+
+	second := newTestProviderFunc(t)
+	applied := false
+	second.applyChanges = func(ctx context.Context, changes *plan.Changes) error {
+		applied = true
+		return nil
+	}
+	second.adjustEndpoints = func(endpoints []*endpoint.Endpoint) ([]*endpoint.Endpoint, error) {
+		return endpoints[:1], nil
+	}
+	first.records = recordsNotCalled(t)
+
+	p.Swap(second)
+
+	require.NoError(t, p.ApplyChanges(context.Background(), &plan.Changes{}))
+	assert.True(t, applied)
+
+	adjusted, err := p.AdjustEndpoints([]*endpoint.Endpoint{{DNSName: "a"}, {DNSName: "b"}})
+	require.NoError(t, err)
+	assert.Len(t, adjusted, 1)
+}
@@ -0,0 +1,87 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rfc2136
+
+import (
+	"time"
+
+	"github.com/miekg/dns"
+	log "github.com/sirupsen/logrus"
+)
+
+// gssRolloverMargin is how long before its expiry a GSS-TSIG security context is rolled over.
+const gssRolloverMargin = 5 * time.Minute
+
+// gssHandle signs and verifies the messages with the GSS-TSIG security contexts it negotiated.
+type gssHandle interface {
+	dns.TsigProvider
+	DeleteContext(keyName string) error
+	Close() error
+}
+
+// gssContext is a GSS-TSIG security context negotiated with a nameserver.
+type gssContext struct {
+	keyName string
+	expiry  time.Time
+	handle  gssHandle
+}
+
+// gssKey returns the GSS-TSIG security context of the nameserver, negotiated once and reused
+// until it nears its expiry: it's rolled over then, a new context is negotiated and the old one
+// is deleted, instead of negotiating a context for each message.
+func (r *rfc2136Provider) gssKey(nameserver string) (*gssContext, error) {
+	r.gssMu.Lock()
+	defer r.gssMu.Unlock()
+	current := r.gssContexts[nameserver]
+	if current != nil && time.Until(current.expiry) > gssRolloverMargin {
+		return current, nil
+	}
+
+	keyName, expiry, handle, err := r.negotiateGSS(nameserver)
+	if err != nil {
+		return nil, err
+	}
+	log.Debugf("Negotiated the GSS-TSIG context %s with %s, expiring at %s", keyName, nameserver, expiry)
+	if current != nil {
+		current.delete()
+	}
+	next := &gssContext{keyName: keyName, expiry: expiry, handle: handle}
+	r.gssContexts[nameserver] = next
+	return next, nil
+}
+
+// dropGSSKey forgets the GSS-TSIG security context of the nameserver, e.g. rejected by the
+// nameserver after it restarted, so that the next message negotiates a new one.
+func (r *rfc2136Provider) dropGSSKey(nameserver string, ctx *gssContext) {
+	r.gssMu.Lock()
+	defer r.gssMu.Unlock()
+	if r.gssContexts[nameserver] == ctx {
+		delete(r.gssContexts, nameserver)
+		ctx.delete()
+	}
+}
+
+// delete deletes the context on the nameserver and releases its handle; the failures are only
+// logged, as the nameserver forgets the context when it expires anyway.
+func (c *gssContext) delete() {
+	if err := c.handle.DeleteContext(c.keyName); err != nil {
+		log.Debugf("Failed to delete the GSS-TSIG context %s: %v", c.keyName, err)
+	}
+	if err := c.handle.Close(); err != nil {
+		log.Debugf("Failed to close the GSS-TSIG client: %v", err)
+	}
+}
@@ -0,0 +1,84 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rfc2136
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/miekg/dns"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeGSSHandle struct {
+	dns.TsigProvider
+	deleted []string
+	closed  bool
+}
+
+func (h *fakeGSSHandle) DeleteContext(keyName string) error {
+	h.deleted = append(h.deleted, keyName)
+	return nil
+}
+
+func (h *fakeGSSHandle) Close() error {
+	h.closed = true
+	return nil
+}
+
+func TestRfc2136GSSKeyRollover(t *testing.T) {
+	var handles []*fakeGSSHandle
+	lifetime := time.Hour
+	r := &rfc2136Provider{
+		gssContexts: map[string]*gssContext{},
+		negotiateGSS: func(nameserver string) (string, time.Time, gssHandle, error) {
+			h := &fakeGSSHandle{}
+			handles = append(handles, h)
+			return "key" + strconv.Itoa(len(handles)), time.Now().Add(lifetime), h, nil
+		},
+	}
+
+	// the context is negotiated once per nameserver and reused
+	first, err := r.gssKey("ns1:53")
+	require.NoError(t, err)
+	again, err := r.gssKey("ns1:53")
+	require.NoError(t, err)
+	assert.Same(t, first, again)
+	other, err := r.gssKey("ns2:53")
+	require.NoError(t, err)
+	assert.NotSame(t, first, other)
+	assert.Len(t, handles, 2)
+
+	// the context is rolled over when it nears its expiry, the old one is deleted
+	first.expiry = time.Now().Add(gssRolloverMargin / 2)
+	next, err := r.gssKey("ns1:53")
+	require.NoError(t, err)
+	assert.Equal(t, "key3", next.keyName)
+	assert.Equal(t, []string{"key1"}, handles[0].deleted)
+	assert.True(t, handles[0].closed)
+
+	// a context rejected by the nameserver is dropped, once
+	r.dropGSSKey("ns1:53", next)
+	r.dropGSSKey("ns1:53", next)
+	assert.Equal(t, []string{"key3"}, handles[2].deleted)
+	renegotiated, err := r.gssKey("ns1:53")
+	require.NoError(t, err)
+	assert.Equal(t, "key4", renegotiated.keyName)
+	assert.Empty(t, handles[1].deleted)
+}
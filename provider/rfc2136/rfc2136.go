@@ -21,8 +21,10 @@ import (
 	"crypto/tls"
 	"errors"
 	"fmt"
+	"maps"
 	"math/rand"
 	"net"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
@@ -37,6 +39,7 @@ import (
 
 	"sigs.k8s.io/external-dns/endpoint"
 	"sigs.k8s.io/external-dns/pkg/tlsutils"
+	"sigs.k8s.io/external-dns/pkg/zoneserial"
 	"sigs.k8s.io/external-dns/plan"
 	"sigs.k8s.io/external-dns/provider"
 )
@@ -66,12 +69,22 @@ type rfc2136Provider struct {
 	krb5Username string
 	krb5Password string
 	krb5Realm    string
+	// the security contexts negotiated with the nameservers, by nameserver
+	gssContexts  map[string]*gssContext
+	gssMu        sync.Mutex
+	negotiateGSS func(nameserver string) (keyName string, expiry time.Time, handle gssHandle, err error)
+
+	// signs the updates with SIG(0) instead of TSIG, disabled if nil
+	sig0 *sig0Signer
 
 	// only consider hosted zones managing domains ending in this suffix
 	domainFilter *endpoint.DomainFilter
 	dryRun       bool
 	actions      rfc2136Actions
 
+	// verifies the serials of the changed zones, disabled if nil
+	serialVerifier *zoneserial.Verifier
+
 	// Counter for load balancing, and error handling
 	counter int
 	mu      sync.Mutex // Mutex for thread-safe counter
@@ -104,15 +117,22 @@ var tsigAlgs = map[string]string{
 	"hmac-sha512": dns.HmacSHA512,
 }
 
+// tsigAlg returns the TSIG algorithm of its name, case-insensitive, e.g. hmac-sha512.
+func tsigAlg(name string) (string, bool) {
+	alg, ok := tsigAlgs[strings.TrimSuffix(strings.ToLower(name), ".")]
+	return alg, ok
+}
+
 type rfc2136Actions interface {
-	SendMessage(msg *dns.Msg) error
-	IncomeTransfer(m *dns.Msg, nameserver string) (env chan *dns.Envelope, err error)
+	SendMessage(ctx context.Context, msg *dns.Msg) error
+	IncomeTransfer(ctx context.Context, m *dns.Msg, nameserver string) (env chan *dns.Envelope, err error)
+	Serial(ctx context.Context, zone string) (uint32, error)
 }
 
 // NewRfc2136Provider is a factory function for OpenStack rfc2136 providers
-func NewRfc2136Provider(hosts []string, port int, zoneNames []string, insecure bool, keyName string, secret string, secretAlg string, axfr bool, domainFilter *endpoint.DomainFilter, dryRun bool, minTTL time.Duration, createPTR bool, gssTsig bool, krb5Username string, krb5Password string, krb5Realm string, batchChangeSize int, tlsConfig TLSConfig, loadBalancingStrategy string, actions rfc2136Actions) (provider.Provider, error) {
-	secretAlgChecked, ok := tsigAlgs[secretAlg]
-	if !ok && !insecure && !gssTsig {
+func NewRfc2136Provider(hosts []string, port int, zoneNames []string, insecure bool, keyName string, secret string, secretAlg string, axfr bool, domainFilter *endpoint.DomainFilter, dryRun bool, minTTL time.Duration, createPTR bool, gssTsig bool, krb5Username string, krb5Password string, krb5Realm string, batchChangeSize int, tlsConfig TLSConfig, sig0Config SIG0Config, loadBalancingStrategy string, serialVerifier *zoneserial.Verifier, actions rfc2136Actions) (provider.Provider, error) {
+	secretAlgChecked, ok := tsigAlg(secretAlg)
+	if !ok && !insecure && !gssTsig && sig0Config.KeyDir == "" {
 		return nil, fmt.Errorf("%s is not supported TSIG algorithm", secretAlg)
 	}
 
@@ -141,6 +161,7 @@ func NewRfc2136Provider(hosts []string, port int, zoneNames []string, insecure b
 		krb5Username:          krb5Username,
 		krb5Password:          krb5Password,
 		krb5Realm:             strings.ToUpper(krb5Realm),
+		gssContexts:           map[string]*gssContext{},
 		domainFilter:          domainFilter,
 		dryRun:                dryRun,
 		axfr:                  axfr,
@@ -148,6 +169,7 @@ func NewRfc2136Provider(hosts []string, port int, zoneNames []string, insecure b
 		batchChangeSize:       batchChangeSize,
 		tlsConfig:             tlsConfig,
 		loadBalancingStrategy: loadBalancingStrategy,
+		serialVerifier:        serialVerifier,
 		randGen:               rand.New(rand.NewSource(time.Now().UnixNano())),
 		counter:               0,
 		lastErr:               nil,
@@ -157,6 +179,16 @@ func NewRfc2136Provider(hosts []string, port int, zoneNames []string, insecure b
 	} else {
 		r.actions = r
 	}
+	r.negotiateGSS = func(nameserver string) (string, time.Time, gssHandle, error) {
+		return r.KeyData(nameserver)
+	}
+	if sig0Config.KeyDir != "" {
+		signer, err := newSIG0Signer(sig0Config)
+		if err != nil {
+			return nil, err
+		}
+		r.sig0 = signer
+	}
 
 	if !insecure {
 		r.tsigKeyName = dns.Fqdn(keyName)
@@ -168,24 +200,30 @@ func NewRfc2136Provider(hosts []string, port int, zoneNames []string, insecure b
 	return r, nil
 }
 
-// KeyData will return TKEY name and TSIG handle to use for followon actions with a secure connection
-func (r *rfc2136Provider) KeyData(nameserver string) (keyName string, handle *gss.Client, err error) {
-	handle, err = gss.NewClient(new(dns.Client))
+// KeyData will return TKEY name, its expiry and TSIG handle to use for followon actions with a secure connection
+func (r *rfc2136Provider) KeyData(nameserver string) (keyName string, expiry time.Time, handle gssHandle, err error) {
+	client, err := gss.NewClient(new(dns.Client))
 	if err != nil {
-		return keyName, handle, err
+		return keyName, expiry, nil, err
 	}
 
-	keyName, _, err = handle.NegotiateContextWithCredentials(nameserver, r.krb5Realm, r.krb5Username, r.krb5Password)
+	keyName, expiry, err = client.NegotiateContextWithCredentials(nameserver, r.krb5Realm, r.krb5Username, r.krb5Password)
 	if err != nil {
-		return keyName, handle, err
+		_ = client.Close()
+		return keyName, expiry, nil, err
 	}
 
-	return keyName, handle, nil
+	return keyName, expiry, client, nil
+}
+
+// tsigHMAC returns whether the messages are signed with TSIG and the HMAC of the secret.
+func (r *rfc2136Provider) tsigHMAC() bool {
+	return !r.insecure && !r.gssTsig && r.sig0 == nil
 }
 
 // Records returns the list of records.
 func (r *rfc2136Provider) Records(ctx context.Context) ([]*endpoint.Endpoint, error) {
-	rrs, err := r.List()
+	rrs, err := r.List(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -215,7 +253,8 @@ OuterLoop:
 			rrValues = []string{rr.(*dns.AAAA).AAAA.String()}
 			rrType = "AAAA"
 		case dns.TypeTXT:
-			rrValues = (rr.(*dns.TXT).Txt)
+			// the strings of a value longer than 255 bytes are merged back
+			rrValues = []string{strings.Join(rr.(*dns.TXT).Txt, "")}
 			rrType = "TXT"
 		case dns.TypeNS:
 			rrValues = []string{rr.(*dns.NS).Ns}
@@ -223,6 +262,10 @@ OuterLoop:
 		case dns.TypePTR:
 			rrValues = []string{rr.(*dns.PTR).Ptr}
 			rrType = "PTR"
+		case dns.TypeMX:
+			mx := rr.(*dns.MX)
+			rrValues = []string{endpoint.NewMXTarget(mx.Preference, strings.TrimSuffix(mx.Mx, "."))}
+			rrType = "MX"
 		default:
 			continue // Unhandled record type
 		}
@@ -247,9 +290,9 @@ OuterLoop:
 	return eps, nil
 }
 
-func (r *rfc2136Provider) IncomeTransfer(m *dns.Msg, nameserver string) (env chan *dns.Envelope, err error) {
+func (r *rfc2136Provider) IncomeTransfer(ctx context.Context, m *dns.Msg, nameserver string) (env chan *dns.Envelope, err error) {
 	t := new(dns.Transfer)
-	if !r.insecure && !r.gssTsig {
+	if r.tsigHMAC() {
 		t.TsigSecret = map[string]string{r.tsigKeyName: r.tsigSecret}
 	}
 
@@ -257,15 +300,18 @@ func (r *rfc2136Provider) IncomeTransfer(m *dns.Msg, nameserver string) (env cha
 	if err != nil {
 		return nil, fmt.Errorf("error setting up TLS: %w", err)
 	}
-	conn, err := c.Dial(nameserver)
+	conn, err := c.DialContext(ctx, nameserver)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect for transfer: %w", err)
 	}
 	t.Conn = conn
+	// the transfer is aborted once the context is done, the connection being closed by the
+	// transfer otherwise
+	context.AfterFunc(ctx, func() { _ = conn.Close() })
 	return t.In(m, nameserver)
 }
 
-func (r *rfc2136Provider) List() ([]dns.RR, error) {
+func (r *rfc2136Provider) List(ctx context.Context) ([]dns.RR, error) {
 	if !r.axfr {
 		log.Debug("axfr is disabled")
 		return make([]dns.RR, 0), nil
@@ -277,16 +323,19 @@ func (r *rfc2136Provider) List() ([]dns.RR, error) {
 
 		m := new(dns.Msg)
 		m.SetAxfr(dns.Fqdn(zone))
-		if !r.insecure && !r.gssTsig {
+		if r.tsigHMAC() {
 			m.SetTsig(r.tsigKeyName, r.tsigSecretAlg, clockSkew, time.Now().Unix())
 		}
 
 		var lastErr error
 		for i := 0; i < len(r.nameservers); i++ {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
 			nameserver := r.getNextNameserver()
 			log.Debugf("Fetching records from nameserver: %s", nameserver)
 
-			env, err := r.actions.IncomeTransfer(m, nameserver)
+			env, err := r.actions.IncomeTransfer(ctx, m, nameserver)
 			if err != nil {
 				lastErr = fmt.Errorf("failed to fetch records via AXFR: %w", err)
 				r.lastErr = lastErr
@@ -304,6 +353,11 @@ func (r *rfc2136Provider) List() ([]dns.RR, error) {
 				}
 				records = append(records, e.RR...)
 			}
+			// the transfer is incomplete once the synchronization is canceled, and the other
+			// nameservers aren't tried
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
 			// If records were fetched successfully, break out of the loop
 			if len(records) > 0 {
 				break
@@ -319,14 +373,14 @@ func (r *rfc2136Provider) List() ([]dns.RR, error) {
 	return records, nil
 }
 
-func (r *rfc2136Provider) AddReverseRecord(ip string, hostname string) error {
+func (r *rfc2136Provider) AddReverseRecord(ctx context.Context, ip string, hostname string) error {
 	changes := r.GenerateReverseRecord(ip, hostname)
-	return r.ApplyChanges(context.Background(), &plan.Changes{Create: changes})
+	return r.ApplyChanges(ctx, &plan.Changes{Create: changes})
 }
 
-func (r *rfc2136Provider) RemoveReverseRecord(ip string, hostname string) error {
+func (r *rfc2136Provider) RemoveReverseRecord(ctx context.Context, ip string, hostname string) error {
 	changes := r.GenerateReverseRecord(ip, hostname)
-	return r.ApplyChanges(context.Background(), &plan.Changes{Delete: changes})
+	return r.ApplyChanges(ctx, &plan.Changes{Delete: changes})
 }
 
 func (r *rfc2136Provider) GenerateReverseRecord(ip string, hostname string) []*endpoint.Endpoint {
@@ -350,9 +404,23 @@ func (r *rfc2136Provider) GenerateReverseRecord(ip string, hostname string) []*e
 func (r *rfc2136Provider) ApplyChanges(ctx context.Context, changes *plan.Changes) error {
 	log.Debugf("ApplyChanges (Create: %d, UpdateOld: %d, UpdateNew: %d, Delete: %d)", len(changes.Create), len(changes.UpdateOld), len(changes.UpdateNew), len(changes.Delete))
 
+	if r.sig0 != nil && !r.dryRun && r.sig0.due(time.Now()) {
+		// the changes are still applied with the old key if the rollover fails
+		send := func(msg *dns.Msg) error { return r.actions.SendMessage(ctx, msg) }
+		if err := r.sig0.roll(r.zoneNames, send); err != nil {
+			log.Errorf("RFC2136 SIG(0) key rollover failed: %v", err)
+		}
+	}
+
+	var serials map[string]uint32
+	if r.serialVerifier != nil && !r.dryRun {
+		serials = r.serialVerifier.Serials(ctx, r.changedZones(changes), r.actions.Serial)
+	}
+
 	var errs []error
 
-	for c, chunk := range chunkBy(changes.Create, r.batchChangeSize) {
+	// the records are sent along with their TXT registry records, in the same message
+	for c, chunk := range provider.ChunkByOwnership(changes.Create, r.batchChangeSize) {
 		log.Debugf("Processing batch %d of create changes", c)
 
 		m := make(map[string]*dns.Msg)
@@ -373,14 +441,14 @@ func (r *rfc2136Provider) ApplyChanges(ctx context.Context, changes *plan.Change
 			r.AddRecord(m[zone], ep)
 
 			if r.createPTR && (ep.RecordType == "A" || ep.RecordType == "AAAA") {
-				r.AddReverseRecord(ep.Targets[0], ep.DNSName)
+				r.AddReverseRecord(ctx, ep.Targets[0], ep.DNSName)
 			}
 		}
 
 		// only send if there are records available
 		for _, z := range m {
 			if len(z.Ns) > 0 {
-				if err := r.actions.SendMessage(z); err != nil {
+				if err := r.actions.SendMessage(ctx, z); err != nil {
 					log.Errorf("RFC2136 create record failed: %v", err)
 					errs = append(errs, err)
 					continue
@@ -389,7 +457,14 @@ func (r *rfc2136Provider) ApplyChanges(ctx context.Context, changes *plan.Change
 		}
 	}
 
-	for c, chunk := range chunkBy(changes.UpdateNew, r.batchChangeSize) {
+	// the old version of each updated record, as the chunks don't keep the order of the updates
+	updateOld := make(map[*endpoint.Endpoint]*endpoint.Endpoint, len(changes.UpdateNew))
+	for i, ep := range changes.UpdateNew {
+		if i < len(changes.UpdateOld) {
+			updateOld[ep] = changes.UpdateOld[i]
+		}
+	}
+	for c, chunk := range provider.ChunkByOwnership(changes.UpdateNew, r.batchChangeSize) {
 		log.Debugf("Processing batch %d of update changes", c)
 
 		m := make(map[string]*dns.Msg)
@@ -399,7 +474,7 @@ func (r *rfc2136Provider) ApplyChanges(ctx context.Context, changes *plan.Change
 			m[z] = new(dns.Msg)
 		}
 
-		for i, ep := range chunk {
+		for _, ep := range chunk {
 			if !r.domainFilter.Match(ep.DNSName) {
 				log.Debugf("Skipping record %s because it was filtered out by the specified --domain-filter", ep.DNSName)
 				continue
@@ -408,17 +483,17 @@ func (r *rfc2136Provider) ApplyChanges(ctx context.Context, changes *plan.Change
 			zone := findMsgZone(ep, r.zoneNames)
 			m[zone].SetUpdate(zone)
 
-			r.UpdateRecord(m[zone], changes.UpdateOld[i], ep)
+			r.UpdateRecord(m[zone], updateOld[ep], ep)
 			if r.createPTR && (ep.RecordType == "A" || ep.RecordType == "AAAA") {
-				r.RemoveReverseRecord(changes.UpdateOld[i].Targets[0], ep.DNSName)
-				r.AddReverseRecord(ep.Targets[0], ep.DNSName)
+				r.RemoveReverseRecord(ctx, updateOld[ep].Targets[0], ep.DNSName)
+				r.AddReverseRecord(ctx, ep.Targets[0], ep.DNSName)
 			}
 		}
 
 		// only send if there are records available
 		for _, z := range m {
 			if len(z.Ns) > 0 {
-				if err := r.actions.SendMessage(z); err != nil {
+				if err := r.actions.SendMessage(ctx, z); err != nil {
 					log.Errorf("RFC2136 update record failed: %v", err)
 					errs = append(errs, err)
 					continue
@@ -427,7 +502,7 @@ func (r *rfc2136Provider) ApplyChanges(ctx context.Context, changes *plan.Change
 		}
 	}
 
-	for c, chunk := range chunkBy(changes.Delete, r.batchChangeSize) {
+	for c, chunk := range provider.ChunkByOwnership(changes.Delete, r.batchChangeSize) {
 		log.Debugf("Processing batch %d of delete changes", c)
 
 		m := make(map[string]*dns.Msg)
@@ -447,14 +522,14 @@ func (r *rfc2136Provider) ApplyChanges(ctx context.Context, changes *plan.Change
 
 			r.RemoveRecord(m[zone], ep)
 			if r.createPTR && (ep.RecordType == "A" || ep.RecordType == "AAAA") {
-				r.RemoveReverseRecord(ep.Targets[0], ep.DNSName)
+				r.RemoveReverseRecord(ctx, ep.Targets[0], ep.DNSName)
 			}
 		}
 
 		// only send if there are records available
 		for _, z := range m {
 			if len(z.Ns) > 0 {
-				if err := r.actions.SendMessage(z); err != nil {
+				if err := r.actions.SendMessage(ctx, z); err != nil {
 					log.Errorf("RFC2136 delete record failed: %v", err)
 					errs = append(errs, err)
 					continue
@@ -466,10 +541,37 @@ func (r *rfc2136Provider) ApplyChanges(ctx context.Context, changes *plan.Change
 	if len(errs) > 0 {
 		return fmt.Errorf("RFC2136 had errors in one or more of its batches: %v", errs)
 	}
+	if serials != nil {
+		r.serialVerifier.Verify(ctx, serials, r.actions.Serial)
+	}
 
 	return nil
 }
 
+// changedZones returns the zones of the changed records, whose serials are verified.
+func (r *rfc2136Provider) changedZones(changes *plan.Changes) []string {
+	zones := map[string]struct{}{}
+	for _, endpoints := range [][]*endpoint.Endpoint{changes.Create, changes.UpdateNew, changes.Delete} {
+		for _, ep := range endpoints {
+			if r.domainFilter.Match(ep.DNSName) {
+				zones[findMsgZone(ep, r.zoneNames)] = struct{}{}
+			}
+		}
+	}
+	// the records without a zone are sent to the root zone, which isn't served by the nameserver
+	delete(zones, ".")
+	return slices.Sorted(maps.Keys(zones))
+}
+
+// Serial returns the SOA serial of a zone on the first nameserver.
+func (r *rfc2136Provider) Serial(ctx context.Context, zone string) (uint32, error) {
+	c, err := makeClient(r, r.nameservers[0])
+	if err != nil {
+		return 0, fmt.Errorf("error setting up TLS: %w", err)
+	}
+	return zoneserial.Query(ctx, c, r.nameservers[0], zone)
+}
+
 func (r *rfc2136Provider) UpdateRecord(m *dns.Msg, oldEp *endpoint.Endpoint, newEp *endpoint.Endpoint) error {
 	err := r.RemoveRecord(m, oldEp)
 	if err != nil {
@@ -559,7 +661,7 @@ func (r *rfc2136Provider) getNextNameserver() string {
 	return nameserver
 }
 
-func (r *rfc2136Provider) SendMessage(msg *dns.Msg) error {
+func (r *rfc2136Provider) SendMessage(ctx context.Context, msg *dns.Msg) error {
 	if r.dryRun {
 		log.Debugf("SendMessage.skipped")
 		return nil
@@ -568,6 +670,11 @@ func (r *rfc2136Provider) SendMessage(msg *dns.Msg) error {
 
 	var lastErr error
 	for i := 0; i < len(r.nameservers); i++ {
+		// the other nameservers aren't tried once the synchronization is canceled
+		if err := ctx.Err(); err != nil {
+			r.lastErr = err
+			return err
+		}
 		nameserver := r.getNextNameserver()
 		log.Debugf("Sending message to nameserver: %s", nameserver)
 
@@ -578,27 +685,35 @@ func (r *rfc2136Provider) SendMessage(msg *dns.Msg) error {
 			continue
 		}
 
-		if !r.insecure {
+		var gssCtx *gssContext
+		if !r.insecure && r.sig0 == nil {
 			if r.gssTsig {
-				keyName, handle, err := r.KeyData(nameserver)
+				gssCtx, err = r.gssKey(nameserver)
 				if err != nil {
 					lastErr = err
 					r.lastErr = lastErr
 					continue
 				}
-				defer handle.Close()
-				defer handle.DeleteContext(keyName)
 
-				c.TsigProvider = handle
+				c.TsigProvider = gssCtx.handle
 
-				msg.SetTsig(keyName, tsig.GSS, clockSkew, time.Now().Unix())
+				msg.SetTsig(gssCtx.keyName, tsig.GSS, clockSkew, time.Now().Unix())
 			} else {
 				c.TsigProvider = tsig.HMAC{r.tsigKeyName: r.tsigSecret}
 				msg.SetTsig(r.tsigKeyName, r.tsigSecretAlg, clockSkew, time.Now().Unix())
 			}
 		}
 
-		resp, _, err := c.Exchange(msg, nameserver)
+		var resp *dns.Msg
+		if r.sig0 != nil {
+			resp, err = r.sig0.exchange(ctx, c, msg, nameserver)
+		} else {
+			resp, _, err = c.ExchangeContext(ctx, msg, nameserver)
+		}
+		if gssCtx != nil && (err != nil || resp.Rcode == dns.RcodeNotAuth) {
+			// the context may have been forgotten by the nameserver, a new one is negotiated
+			r.dropGSSKey(nameserver, gssCtx)
+		}
 		if err != nil {
 			if resp != nil && resp.Rcode != dns.RcodeSuccess {
 				log.Infof("error in dns.Client.Exchange: %s", err)
@@ -626,22 +741,6 @@ func (r *rfc2136Provider) SendMessage(msg *dns.Msg) error {
 	return lastErr
 }
 
-func chunkBy(slice []*endpoint.Endpoint, chunkSize int) [][]*endpoint.Endpoint {
-	var chunks [][]*endpoint.Endpoint
-
-	for i := 0; i < len(slice); i += chunkSize {
-		end := i + chunkSize
-
-		if end > len(slice) {
-			end = len(slice)
-		}
-
-		chunks = append(chunks, slice[i:end])
-	}
-
-	return chunks
-}
-
 func findMsgZone(ep *endpoint.Endpoint, zoneNames []string) string {
 	for _, zone := range zoneNames {
 		if strings.HasSuffix(ep.DNSName, zone) {
@@ -35,6 +35,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/pkg/zoneserial"
 	"sigs.k8s.io/external-dns/plan"
 	"sigs.k8s.io/external-dns/provider"
 )
@@ -48,6 +49,8 @@ type rfc2136Stub struct {
 	randGen               *rand.Rand
 	lastNameserver        string
 	loadBalancingStrategy string
+	serials               map[string]uint32
+	serialQueries         []string
 }
 
 func newStub() *rfc2136Stub {
@@ -100,8 +103,11 @@ func getSortedChanges(msgs []*dns.Msg) []string {
 	return r
 }
 
-func (r *rfc2136Stub) SendMessage(msg *dns.Msg) error {
+func (r *rfc2136Stub) SendMessage(_ context.Context, msg *dns.Msg) error {
 	r.lastNameserver = r.getNextNameserver()
+	if r.serials != nil {
+		r.serials[msg.Question[0].Name]++
+	}
 	log.Info("Sending message to nameserver: ", r.lastNameserver)
 	zone := extractZoneFromMessage(msg.String())
 	// Make sure the zone starts with . to make sure HasSuffix does not match forbar.com for zone bar.com
@@ -135,6 +141,15 @@ func (r *rfc2136Stub) SendMessage(msg *dns.Msg) error {
 	return nil
 }
 
+func (r *rfc2136Stub) Serial(_ context.Context, zone string) (uint32, error) {
+	r.serialQueries = append(r.serialQueries, zone)
+	serial, ok := r.serials[zone]
+	if !ok {
+		return 0, fmt.Errorf("unknown zone %s", zone)
+	}
+	return serial, nil
+}
+
 func (r *rfc2136Stub) setOutput(output []string) error {
 	r.output = make([]*dns.Envelope, len(output))
 	for i, e := range output {
@@ -149,7 +164,7 @@ func (r *rfc2136Stub) setOutput(output []string) error {
 	return nil
 }
 
-func (r *rfc2136Stub) IncomeTransfer(m *dns.Msg, a string) (env chan *dns.Envelope, err error) {
+func (r *rfc2136Stub) IncomeTransfer(_ context.Context, m *dns.Msg, a string) (env chan *dns.Envelope, err error) {
 	outChan := make(chan *dns.Envelope)
 	go func() {
 		for _, e := range r.output {
@@ -186,7 +201,7 @@ func createRfc2136StubProvider(stub *rfc2136Stub, zoneNames ...string) (provider
 		ClientCertFilePath:    "",
 		ClientCertKeyFilePath: "",
 	}
-	return NewRfc2136Provider([]string{""}, 0, zoneNames, false, "key", "secret", "hmac-sha512", true, &endpoint.DomainFilter{}, false, 300*time.Second, false, false, "", "", "", 50, tlsConfig, "", stub)
+	return NewRfc2136Provider([]string{""}, 0, zoneNames, false, "key", "secret", "hmac-sha512", true, &endpoint.DomainFilter{}, false, 300*time.Second, false, false, "", "", "", 50, tlsConfig, SIG0Config{}, "", nil, stub)
 }
 
 func createRfc2136StubProviderWithHosts(stub *rfc2136Stub) (provider.Provider, error) {
@@ -197,15 +212,15 @@ func createRfc2136StubProviderWithHosts(stub *rfc2136Stub) (provider.Provider, e
 		ClientCertFilePath:    "",
 		ClientCertKeyFilePath: "",
 	}
-	return NewRfc2136Provider([]string{"rfc2136-host1", "rfc2136-host2", "rfc2136-host3"}, 0, nil, false, "key", "secret", "hmac-sha512", true, &endpoint.DomainFilter{}, false, 300*time.Second, false, false, "", "", "", 50, tlsConfig, "", stub)
+	return NewRfc2136Provider([]string{"rfc2136-host1", "rfc2136-host2", "rfc2136-host3"}, 0, nil, false, "key", "secret", "hmac-sha512", true, &endpoint.DomainFilter{}, false, 300*time.Second, false, false, "", "", "", 50, tlsConfig, SIG0Config{}, "", nil, stub)
 }
 
 func createRfc2136TLSStubProvider(stub *rfc2136Stub, tlsConfig TLSConfig) (provider.Provider, error) {
-	return NewRfc2136Provider([]string{"rfc2136-host"}, 0, nil, false, "key", "secret", "hmac-sha512", true, &endpoint.DomainFilter{}, false, 300*time.Second, false, false, "", "", "", 50, tlsConfig, "", stub)
+	return NewRfc2136Provider([]string{"rfc2136-host"}, 0, nil, false, "key", "secret", "hmac-sha512", true, &endpoint.DomainFilter{}, false, 300*time.Second, false, false, "", "", "", 50, tlsConfig, SIG0Config{}, "", nil, stub)
 }
 
 func createRfc2136TLSStubProviderWithHosts(stub *rfc2136Stub, tlsConfig TLSConfig) (provider.Provider, error) {
-	return NewRfc2136Provider([]string{"rfc2136-host1", "rfc2136-host2"}, 0, nil, false, "key", "secret", "hmac-sha512", true, &endpoint.DomainFilter{}, false, 300*time.Second, false, false, "", "", "", 50, tlsConfig, "", stub)
+	return NewRfc2136Provider([]string{"rfc2136-host1", "rfc2136-host2"}, 0, nil, false, "key", "secret", "hmac-sha512", true, &endpoint.DomainFilter{}, false, 300*time.Second, false, false, "", "", "", 50, tlsConfig, SIG0Config{}, "", nil, stub)
 }
 
 func createRfc2136StubProviderWithReverse(stub *rfc2136Stub) (provider.Provider, error) {
@@ -218,7 +233,7 @@ func createRfc2136StubProviderWithReverse(stub *rfc2136Stub) (provider.Provider,
 	}
 
 	zones := []string{"foo.com", "3.2.1.in-addr.arpa"}
-	return NewRfc2136Provider([]string{""}, 0, zones, false, "key", "secret", "hmac-sha512", true, endpoint.NewDomainFilter(zones), false, 300*time.Second, true, false, "", "", "", 50, tlsConfig, "", stub)
+	return NewRfc2136Provider([]string{""}, 0, zones, false, "key", "secret", "hmac-sha512", true, endpoint.NewDomainFilter(zones), false, 300*time.Second, true, false, "", "", "", 50, tlsConfig, SIG0Config{}, "", nil, stub)
 }
 
 func createRfc2136StubProviderWithZones(stub *rfc2136Stub) (provider.Provider, error) {
@@ -230,7 +245,7 @@ func createRfc2136StubProviderWithZones(stub *rfc2136Stub) (provider.Provider, e
 		ClientCertKeyFilePath: "",
 	}
 	zones := []string{"foo.com", "foobar.com"}
-	return NewRfc2136Provider([]string{""}, 0, zones, false, "key", "secret", "hmac-sha512", true, &endpoint.DomainFilter{}, false, 300*time.Second, false, false, "", "", "", 50, tlsConfig, "", stub)
+	return NewRfc2136Provider([]string{""}, 0, zones, false, "key", "secret", "hmac-sha512", true, &endpoint.DomainFilter{}, false, 300*time.Second, false, false, "", "", "", 50, tlsConfig, SIG0Config{}, "", nil, stub)
 }
 
 func createRfc2136StubProviderWithZonesFilters(stub *rfc2136Stub) (provider.Provider, error) {
@@ -242,7 +257,7 @@ func createRfc2136StubProviderWithZonesFilters(stub *rfc2136Stub) (provider.Prov
 		ClientCertKeyFilePath: "",
 	}
 	zones := []string{"foo.com", "foobar.com"}
-	return NewRfc2136Provider([]string{""}, 0, zones, false, "key", "secret", "hmac-sha512", true, endpoint.NewDomainFilter(zones), false, 300*time.Second, false, false, "", "", "", 50, tlsConfig, "", stub)
+	return NewRfc2136Provider([]string{""}, 0, zones, false, "key", "secret", "hmac-sha512", true, endpoint.NewDomainFilter(zones), false, 300*time.Second, false, false, "", "", "", 50, tlsConfig, SIG0Config{}, "", nil, stub)
 }
 
 func createRfc2136StubProviderWithStrategy(stub *rfc2136Stub, strategy string) (provider.Provider, error) {
@@ -253,7 +268,7 @@ func createRfc2136StubProviderWithStrategy(stub *rfc2136Stub, strategy string) (
 		ClientCertFilePath:    "",
 		ClientCertKeyFilePath: "",
 	}
-	return NewRfc2136Provider([]string{"rfc2136-host1", "rfc2136-host2", "rfc2136-host3"}, 0, nil, false, "key", "secret", "hmac-sha512", true, &endpoint.DomainFilter{}, false, 300*time.Second, false, false, "", "", "", 50, tlsConfig, strategy, stub)
+	return NewRfc2136Provider([]string{"rfc2136-host1", "rfc2136-host2", "rfc2136-host3"}, 0, nil, false, "key", "secret", "hmac-sha512", true, &endpoint.DomainFilter{}, false, 300*time.Second, false, false, "", "", "", 50, tlsConfig, SIG0Config{}, strategy, nil, stub)
 }
 
 func extractUpdateSectionFromMessage(msg fmt.Stringer) []string {
@@ -535,6 +550,65 @@ func TestRfc2136GetRecords(t *testing.T) {
 	assert.True(t, contains(recs, "v2.foo.com"))
 }
 
+func TestRfc2136GetRecordsMergesTXTStrings(t *testing.T) {
+	stub := newStub()
+	err := stub.setOutput([]string{
+		`v1.foo.com 3600 TXT "v=DKIM1; k=rsa; " "p=MIIBIjANBg"`,
+	})
+	assert.NoError(t, err)
+
+	provider, err := createRfc2136StubProvider(stub, "foo.com")
+	assert.NoError(t, err)
+
+	recs, err := provider.Records(context.Background())
+	assert.NoError(t, err)
+
+	require.Len(t, recs, 1)
+	assert.Equal(t, endpoint.Targets{"v=DKIM1; k=rsa; p=MIIBIjANBg"}, recs[0].Targets)
+}
+
+func TestRfc2136MX(t *testing.T) {
+	stub := newStub()
+	err := stub.setOutput([]string{
+		"foo.com 3600 MX 10 mail.foo.com.",
+		"foo.com 3600 MX 20 backup.foo.com.",
+	})
+	assert.NoError(t, err)
+
+	provider, err := createRfc2136StubProvider(stub, "foo.com")
+	assert.NoError(t, err)
+
+	recs, err := provider.Records(context.Background())
+	assert.NoError(t, err)
+
+	require.Len(t, recs, 1)
+	assert.Equal(t, endpoint.RecordTypeMX, recs[0].RecordType)
+	assert.Equal(t, endpoint.Targets{"10 mail.foo.com", "20 backup.foo.com"}, recs[0].Targets)
+
+	err = provider.ApplyChanges(context.Background(), &plan.Changes{
+		Create: []*endpoint.Endpoint{endpoint.NewEndpoint("mx.foo.com", endpoint.RecordTypeMX, "30 relay.foo.com")},
+	})
+	assert.NoError(t, err)
+
+	require.Len(t, stub.createMsgs, 1)
+	assert.Contains(t, stub.createMsgs[0].String(), "MX\t30 relay.foo.com.")
+}
+
+func TestRfc2136SendMessageCanceled(t *testing.T) {
+	p, err := NewRfc2136Provider([]string{"127.0.0.1"}, 53, []string{"foo.com"}, false, "key", "secret", "hmac-sha512", true, &endpoint.DomainFilter{}, false, 300*time.Second, false, false, "", "", "", 50, TLSConfig{}, SIG0Config{}, "", nil, nil)
+	require.NoError(t, err)
+
+	m := new(dns.Msg)
+	m.SetUpdate("foo.com.")
+
+	// the nameservers aren't contacted once the synchronization is canceled
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	require.ErrorIs(t, p.(*rfc2136Provider).SendMessage(ctx, m), context.Canceled)
+	_, err = p.Records(ctx)
+	require.ErrorIs(t, err, context.Canceled)
+}
+
 // Make sure the test version of SendMessage raises an error
 // if a zone update ever contains records outside of it's zone
 // as the TestRfc2136ApplyChanges tests all assume this
@@ -546,17 +620,17 @@ func TestRfc2136SendMessage(t *testing.T) {
 	rr, err := dns.NewRR(fmt.Sprintf("%s %d %s %s", "v1.foo.com.", 0, "A", "1.2.3.4"))
 	m.Insert([]dns.RR{rr})
 
-	err = stub.SendMessage(m)
+	err = stub.SendMessage(context.Background(), m)
 	assert.NoError(t, err)
 
 	rr, err = dns.NewRR(fmt.Sprintf("%s %d %s %s", "v1.bar.com.", 0, "A", "1.2.3.4"))
 	m.Insert([]dns.RR{rr})
 
-	err = stub.SendMessage(m)
+	err = stub.SendMessage(context.Background(), m)
 	assert.Error(t, err)
 
 	m.SetUpdate(".")
-	err = stub.SendMessage(m)
+	err = stub.SendMessage(context.Background(), m)
 	assert.NoError(t, err)
 }
 
@@ -681,6 +755,26 @@ func TestRfc2136ApplyChangesWithZones(t *testing.T) {
 	assert.Contains(t, updateMsgs[1], "v2.foobar.com")
 }
 
+func TestRfc2136ApplyChangesVerifiesSerials(t *testing.T) {
+	stub := newStub()
+	stub.serials = map[string]uint32{"foo.com.": 1, "foobar.com.": 7}
+	provider, err := NewRfc2136Provider([]string{""}, 0, []string{"foo.com", "foobar.com"}, false, "key", "secret", "hmac-sha512", true, &endpoint.DomainFilter{}, false, 300*time.Second, false, false, "", "", "", 50, TLSConfig{}, SIG0Config{}, "", zoneserial.NewVerifier(nil, time.Second), stub)
+	require.NoError(t, err)
+
+	err = provider.ApplyChanges(context.Background(), &plan.Changes{
+		Create: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("v1.foo.com", endpoint.RecordTypeA, "1.2.3.4"),
+			endpoint.NewEndpoint("v1.example.org", endpoint.RecordTypeA, "1.2.3.4"),
+		},
+		Delete: []*endpoint.Endpoint{endpoint.NewEndpoint("v2.foo.com", endpoint.RecordTypeA, "1.2.3.4")},
+	})
+	require.NoError(t, err)
+
+	// the serial of the changed zone is read before and after the changes, the root zone is left out
+	assert.Equal(t, []string{"foo.com.", "foo.com."}, stub.serialQueries)
+	assert.Equal(t, uint32(3), stub.serials["foo.com."])
+}
+
 // These tests use the foo.com and foobar.com zones and with filters set to both zones
 // createMsgs and updateMsgs need sorted when are used
 func TestRfc2136ApplyChangesWithZonesFilters(t *testing.T) {
@@ -877,24 +971,6 @@ func TestRfc2136ApplyChangesWithUpdate(t *testing.T) {
 	assert.Contains(t, stub.updateMsgs[1].String(), "boom")
 }
 
-func TestChunkBy(t *testing.T) {
-	var records []*endpoint.Endpoint
-
-	for i := 0; i < 10; i++ {
-		records = append(records, &endpoint.Endpoint{
-			DNSName:    "v1.foo.com",
-			RecordType: "A",
-			Targets:    []string{"1.1.2.2"},
-			RecordTTL:  endpoint.TTL(400),
-		})
-	}
-
-	chunks := chunkBy(records, 2)
-	if len(chunks) != 5 {
-		t.Errorf("incorrect number of chunks returned")
-	}
-}
-
 func contains(arr []*endpoint.Endpoint, name string) bool {
 	for _, a := range arr {
 		if a.DNSName == name {
@@ -931,7 +1007,7 @@ func TestRoundRobinLoadBalancing(t *testing.T) {
 	m.Insert([]dns.RR{rr})
 
 	for i := 0; i < 10; i++ {
-		err := stub.SendMessage(m)
+		err := stub.SendMessage(context.Background(), m)
 		assert.NoError(t, err)
 		expectedNameserver := "rfc2136-host" + strconv.Itoa((i%3)+1)
 		assert.Equal(t, expectedNameserver, stub.lastNameserver)
@@ -952,7 +1028,7 @@ func TestRandomLoadBalancing(t *testing.T) {
 	nameserverCounts := map[string]int{}
 
 	for i := 0; i < 25; i++ {
-		err := stub.SendMessage(m)
+		err := stub.SendMessage(context.Background(), m)
 		assert.NoError(t, err)
 		nameserverCounts[stub.lastNameserver]++
 	}
@@ -0,0 +1,266 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rfc2136
+
+import (
+	"context"
+	"crypto"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/miekg/dns"
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+// sig0ExchangeTimeout is how long the nameserver has to respond to a message signed with SIG(0).
+const sig0ExchangeTimeout = 5 * time.Second
+
+// SIG0Config is comprised of the fields necessary if the updates are authenticated with SIG(0),
+// signed with a private key whose public KEY record is published in the zone, instead of TSIG.
+type SIG0Config struct {
+	// KeyDir is the directory of the key files, K<name>+<algorithm>+<tag>.key and .private as
+	// generated by dnssec-keygen -T KEY; the newest key is used
+	KeyDir string
+	// Rollover is how often the key is replaced by a new one, never if zero; the key directory
+	// must be writable and persistent then
+	Rollover time.Duration
+}
+
+// sig0Key is a SIG(0) key pair.
+type sig0Key struct {
+	key     *dns.KEY
+	private crypto.Signer
+	// file is the path of the key files, without their extension
+	file string
+	// created is when the key was created, the modification time of its private key file
+	created time.Time
+}
+
+// sig0Signer signs the updates with the SIG(0) key of a directory, and rolls it over on a schedule.
+type sig0Signer struct {
+	dir      string
+	rollover time.Duration
+
+	mu  sync.Mutex
+	key *sig0Key
+}
+
+// newSIG0Signer returns a signer using the newest key of the directory.
+func newSIG0Signer(config SIG0Config) (*sig0Signer, error) {
+	key, err := loadSIG0Key(config.KeyDir)
+	if err != nil {
+		return nil, err
+	}
+	log.Infof("Signing the RFC2136 updates with the SIG(0) key %s", filepath.Base(key.file))
+	return &sig0Signer{dir: config.KeyDir, rollover: config.Rollover, key: key}, nil
+}
+
+// loadSIG0Key returns the newest key of the directory.
+func loadSIG0Key(dir string) (*sig0Key, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "K*.private"))
+	if err != nil {
+		return nil, err
+	}
+	var newest *sig0Key
+	for _, file := range files {
+		key, err := readSIG0Key(strings.TrimSuffix(file, ".private"))
+		if err != nil {
+			return nil, err
+		}
+		if newest == nil || key.created.After(newest.created) {
+			newest = key
+		}
+	}
+	if newest == nil {
+		return nil, fmt.Errorf("no SIG(0) key found in %s", dir)
+	}
+	return newest, nil
+}
+
+// readSIG0Key reads the public KEY record and the private key of the key files.
+func readSIG0Key(file string) (*sig0Key, error) {
+	public, err := os.Open(file + ".key")
+	if err != nil {
+		return nil, err
+	}
+	defer public.Close()
+	rr, err := dns.ReadRR(public, file+".key")
+	if err != nil {
+		return nil, err
+	}
+	key, ok := rr.(*dns.KEY)
+	if !ok {
+		return nil, fmt.Errorf("%s.key is not a KEY record", file)
+	}
+
+	private, err := os.Open(file + ".private")
+	if err != nil {
+		return nil, err
+	}
+	defer private.Close()
+	privateKey, err := key.ReadPrivateKey(private, file+".private")
+	if err != nil {
+		return nil, err
+	}
+	signer, ok := privateKey.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("%s.private is not a signing key", file)
+	}
+	info, err := private.Stat()
+	if err != nil {
+		return nil, err
+	}
+	return &sig0Key{key: key, private: signer, file: file, created: info.ModTime()}, nil
+}
+
+// current returns the key in use.
+func (s *sig0Signer) current() *sig0Key {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.key
+}
+
+// sign returns the message in wire format, signed with the key in use.
+func (s *sig0Signer) sign(msg *dns.Msg) ([]byte, error) {
+	key := s.current()
+	now := time.Now().Unix()
+	sig := &dns.SIG{RRSIG: dns.RRSIG{
+		Algorithm:  key.key.Algorithm,
+		SignerName: key.key.Hdr.Name,
+		KeyTag:     key.key.KeyTag(),
+		Inception:  uint32(now - clockSkew),
+		Expiration: uint32(now + clockSkew),
+	}}
+	return sig.Sign(key.private, msg)
+}
+
+// exchange sends the message signed with the key in use, and returns the response.
+func (s *sig0Signer) exchange(ctx context.Context, c *dns.Client, msg *dns.Msg, nameserver string) (*dns.Msg, error) {
+	buf, err := s.sign(msg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign the message with SIG(0): %w", err)
+	}
+	conn, err := c.DialContext(ctx, nameserver)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	deadline := time.Now().Add(sig0ExchangeTimeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	if err := conn.SetDeadline(deadline); err != nil {
+		return nil, err
+	}
+	if _, err := conn.Write(buf); err != nil {
+		return nil, err
+	}
+	resp, err := conn.ReadMsg()
+	if err != nil {
+		return nil, err
+	}
+	if resp.Id != msg.Id {
+		return resp, dns.ErrId
+	}
+	return resp, nil
+}
+
+// due returns whether the key in use is due for its rollover.
+func (s *sig0Signer) due(now time.Time) bool {
+	return s.rollover > 0 && now.Sub(s.current().created) >= s.rollover
+}
+
+// roll replaces the key in use by a new one of the same name and algorithm. The new KEY record
+// replaces the old one in the zone by an update signed with the old key, sent by send; the files
+// of the new key are written before, and those of the old key are removed after.
+func (s *sig0Signer) roll(zoneNames []string, send func(*dns.Msg) error) error {
+	old := s.current()
+	next, err := generateSIG0Key(old.key)
+	if err != nil {
+		return fmt.Errorf("failed to generate the new SIG(0) key: %w", err)
+	}
+	next.file = filepath.Join(s.dir, fmt.Sprintf("K%s+%03d+%05d", dns.Fqdn(next.key.Hdr.Name), next.key.Algorithm, next.key.KeyTag()))
+	if err := next.write(); err != nil {
+		return fmt.Errorf("failed to write the new SIG(0) key: %w", err)
+	}
+
+	zone := findMsgZone(&endpoint.Endpoint{DNSName: strings.TrimSuffix(next.key.Hdr.Name, ".")}, zoneNames)
+	m := new(dns.Msg)
+	m.SetUpdate(zone)
+	m.Remove([]dns.RR{dns.Copy(old.key)})
+	m.Insert([]dns.RR{next.key})
+	if err := send(m); err != nil {
+		next.remove()
+		return fmt.Errorf("failed to publish the new SIG(0) key: %w", err)
+	}
+
+	s.mu.Lock()
+	s.key = next
+	s.mu.Unlock()
+	old.remove()
+	log.Infof("Rolled over the SIG(0) key %s to %s", filepath.Base(old.file), filepath.Base(next.file))
+	return nil
+}
+
+// generateSIG0Key generates a key pair of the name and algorithm of the key.
+func generateSIG0Key(old *dns.KEY) (*sig0Key, error) {
+	key := &dns.KEY{DNSKEY: dns.DNSKEY{
+		Hdr:       dns.RR_Header{Name: old.Hdr.Name, Rrtype: dns.TypeKEY, Class: dns.ClassINET, Ttl: old.Hdr.Ttl},
+		Flags:     old.Flags,
+		Protocol:  old.Protocol,
+		Algorithm: old.Algorithm,
+	}}
+	bits := 256
+	switch key.Algorithm {
+	case dns.RSASHA1, dns.RSASHA256, dns.RSASHA512:
+		bits = 2048
+	case dns.ECDSAP384SHA384:
+		bits = 384
+	}
+	private, err := key.Generate(bits)
+	if err != nil {
+		return nil, err
+	}
+	return &sig0Key{key: key, private: private.(crypto.Signer), created: time.Now()}, nil
+}
+
+// write writes the key files, readable only by their owner.
+func (k *sig0Key) write() error {
+	if err := os.WriteFile(k.file+".key", []byte(k.key.String()+"\n"), 0o600); err != nil {
+		return err
+	}
+	if err := os.WriteFile(k.file+".private", []byte(k.key.PrivateKeyString(k.private)), 0o600); err != nil {
+		_ = os.Remove(k.file + ".key")
+		return err
+	}
+	return nil
+}
+
+// remove removes the key files; the failures are only logged, the newest key being used anyway.
+func (k *sig0Key) remove() {
+	for _, ext := range []string{".key", ".private"} {
+		if err := os.Remove(k.file + ext); err != nil {
+			log.Warnf("Failed to remove the SIG(0) key file: %v", err)
+		}
+	}
+}
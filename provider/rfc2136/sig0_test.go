@@ -0,0 +1,172 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rfc2136
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/miekg/dns"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+)
+
+// writeSIG0Key writes a key of the name to the directory, as dnssec-keygen would, created at the time.
+func writeSIG0Key(t *testing.T, dir, name string, created time.Time) *sig0Key {
+	t.Helper()
+	key, err := generateSIG0Key(&dns.KEY{DNSKEY: dns.DNSKEY{
+		Hdr:       dns.RR_Header{Name: name, Ttl: 3600},
+		Flags:     512,
+		Protocol:  3,
+		Algorithm: dns.ECDSAP256SHA256,
+	}})
+	require.NoError(t, err)
+	key.file = filepath.Join(dir, fmt.Sprintf("K%s+%03d+%05d", name, key.key.Algorithm, key.key.KeyTag()))
+	key.created = created
+	require.NoError(t, key.write())
+	for _, ext := range []string{".key", ".private"} {
+		require.NoError(t, os.Chtimes(key.file+ext, created, created))
+	}
+	return key
+}
+
+func TestSIG0Signer(t *testing.T) {
+	dir := t.TempDir()
+	_, err := newSIG0Signer(SIG0Config{KeyDir: dir})
+	require.ErrorContains(t, err, "no SIG(0) key found")
+
+	writeSIG0Key(t, dir, "external-dns.foo.com.", time.Now().Add(-2*time.Hour))
+	newest := writeSIG0Key(t, dir, "external-dns.foo.com.", time.Now().Add(-time.Hour))
+	signer, err := newSIG0Signer(SIG0Config{KeyDir: dir, Rollover: 2 * time.Hour})
+	require.NoError(t, err)
+	assert.Equal(t, newest.file, signer.current().file)
+	assert.False(t, signer.due(time.Now()))
+	assert.True(t, signer.due(time.Now().Add(time.Hour)))
+
+	// the messages are signed with the newest key
+	m := new(dns.Msg)
+	m.SetUpdate("foo.com.")
+	m.Insert([]dns.RR{&dns.A{Hdr: dns.RR_Header{Name: "www.foo.com.", Rrtype: dns.TypeA, Class: dns.ClassINET, Ttl: 300}, A: net.ParseIP("1.2.3.4")}})
+	buf, err := signer.sign(m)
+	require.NoError(t, err)
+	signed := new(dns.Msg)
+	require.NoError(t, signed.Unpack(buf))
+	require.Len(t, signed.Extra, 1)
+	sig, ok := signed.Extra[0].(*dns.SIG)
+	require.True(t, ok)
+	assert.Equal(t, newest.key.KeyTag(), sig.KeyTag)
+	require.NoError(t, sig.Verify(newest.key, buf))
+}
+
+func TestRfc2136SIG0Exchange(t *testing.T) {
+	dir := t.TempDir()
+	key := writeSIG0Key(t, dir, "external-dns.foo.com.", time.Now())
+
+	var received []*dns.Msg
+	mux := dns.NewServeMux()
+	mux.HandleFunc("foo.com.", func(w dns.ResponseWriter, req *dns.Msg) {
+		received = append(received, req)
+		resp := new(dns.Msg)
+		resp.SetReply(req)
+		_ = w.WriteMsg(resp)
+	})
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	started := make(chan struct{})
+	server := &dns.Server{
+		Listener:          listener,
+		Handler:           mux,
+		MsgAcceptFunc:     func(dns.Header) dns.MsgAcceptAction { return dns.MsgAccept },
+		NotifyStartedFunc: func() { close(started) },
+	}
+	go func() { _ = server.ActivateAndServe() }()
+	defer server.Shutdown()
+	<-started
+
+	_, port, err := net.SplitHostPort(listener.Addr().String())
+	require.NoError(t, err)
+	portNumber, err := strconv.Atoi(port)
+	require.NoError(t, err)
+	p, err := NewRfc2136Provider([]string{"127.0.0.1"}, portNumber, []string{"foo.com"}, false, "", "", "", false, &endpoint.DomainFilter{}, false, 0, false, false, "", "", "", 50, TLSConfig{}, SIG0Config{KeyDir: dir}, "", nil, nil)
+	require.NoError(t, err)
+
+	// the updates are signed with SIG(0), without TSIG
+	require.NoError(t, p.ApplyChanges(context.Background(), &plan.Changes{Create: []*endpoint.Endpoint{
+		endpoint.NewEndpoint("www.foo.com", endpoint.RecordTypeA, "1.2.3.4"),
+	}}))
+	require.Len(t, received, 1)
+	assert.Nil(t, received[0].IsTsig())
+	require.NotEmpty(t, received[0].Extra)
+	sig, ok := received[0].Extra[len(received[0].Extra)-1].(*dns.SIG)
+	require.True(t, ok)
+	assert.Equal(t, key.key.KeyTag(), sig.KeyTag)
+	assert.Equal(t, "external-dns.foo.com.", sig.SignerName)
+}
+
+func TestRfc2136SIG0Rollover(t *testing.T) {
+	dir := t.TempDir()
+	old := writeSIG0Key(t, dir, "external-dns.foo.com.", time.Now().Add(-2*time.Hour))
+
+	stub := newStub()
+	p, err := NewRfc2136Provider([]string{""}, 0, []string{"foo.com"}, false, "", "", "", false, &endpoint.DomainFilter{}, false, 0, false, false, "", "", "", 50, TLSConfig{}, SIG0Config{KeyDir: dir, Rollover: time.Hour}, "", nil, stub)
+	require.NoError(t, err)
+	signer := p.(*rfc2136Provider).sig0
+
+	// the rollover is due, the new KEY record replaces the old one before the changes are applied
+	require.NoError(t, p.ApplyChanges(context.Background(), &plan.Changes{}))
+	next := signer.current()
+	assert.NotEqual(t, old.key.KeyTag(), next.key.KeyTag())
+	require.Len(t, stub.updateMsgs, 1)
+	require.Len(t, stub.createMsgs, 1)
+	assert.Contains(t, stub.updateMsgs[0].String(), old.key.PublicKey)
+	assert.Contains(t, stub.createMsgs[0].String(), next.key.PublicKey)
+
+	// only the files of the new key remain, and it's loaded on restart
+	files, err := filepath.Glob(filepath.Join(dir, "K*"))
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{next.file + ".key", next.file + ".private"}, files)
+	loaded, err := loadSIG0Key(dir)
+	require.NoError(t, err)
+	assert.Equal(t, next.key.PublicKey, loaded.key.PublicKey)
+
+	// the new key isn't due yet
+	require.NoError(t, p.ApplyChanges(context.Background(), &plan.Changes{}))
+	assert.Len(t, stub.createMsgs, 1)
+}
+
+func TestRfc2136SIG0RolloverFailure(t *testing.T) {
+	dir := t.TempDir()
+	old := writeSIG0Key(t, dir, "external-dns.foo.com.", time.Now().Add(-2*time.Hour))
+	signer, err := newSIG0Signer(SIG0Config{KeyDir: dir, Rollover: time.Hour})
+	require.NoError(t, err)
+
+	// the old key is kept if the new one fails to be published
+	require.ErrorContains(t, signer.roll([]string{"foo.com"}, func(*dns.Msg) error { return assert.AnError }), "failed to publish the new SIG(0) key")
+	assert.Equal(t, old.file, signer.current().file)
+	files, err := filepath.Glob(filepath.Join(dir, "K*"))
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{old.file + ".key", old.file + ".private"}, files)
+}
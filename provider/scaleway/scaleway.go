@@ -19,9 +19,11 @@ package scaleway
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"os"
 	"strconv"
 	"strings"
+	"time"
 
 	domain "github.com/scaleway/scaleway-sdk-go/api/domain/v2beta1"
 	"github.com/scaleway/scaleway-sdk-go/scw"
@@ -29,6 +31,7 @@ import (
 
 	"sigs.k8s.io/external-dns/endpoint"
 	"sigs.k8s.io/external-dns/pkg/apis/externaldns"
+	"sigs.k8s.io/external-dns/pkg/egress"
 	"sigs.k8s.io/external-dns/plan"
 	"sigs.k8s.io/external-dns/provider"
 )
@@ -55,7 +58,7 @@ type ScalewayChange struct {
 }
 
 // NewScalewayProvider initializes a new Scaleway DNS provider
-func NewScalewayProvider(ctx context.Context, domainFilter *endpoint.DomainFilter, dryRun bool) (*ScalewayProvider, error) {
+func NewScalewayProvider(ctx context.Context, domainFilter *endpoint.DomainFilter, dryRun bool, egressConfig *egress.Config) (*ScalewayProvider, error) {
 	var err error
 	defaultPageSize := uint64(1000)
 	if envPageSize, ok := os.LookupEnv("SCW_DEFAULT_PAGE_SIZE"); ok {
@@ -77,12 +80,17 @@ func NewScalewayProvider(ctx context.Context, domainFilter *endpoint.DomainFilte
 		}
 	}
 
-	scwClient, err := scw.NewClient(
+	opts := []scw.ClientOption{
 		scw.WithProfile(p),
 		scw.WithEnv(),
 		scw.WithUserAgent(externaldns.UserAgent()),
 		scw.WithDefaultPageSize(uint32(defaultPageSize)),
-	)
+	}
+	if egressConfig.Configured() {
+		// same timeout as the default client of the SDK
+		opts = append(opts, scw.WithHTTPClient(&http.Client{Timeout: 30 * time.Second, Transport: egressConfig.Transport()}))
+	}
+	scwClient, err := scw.NewClient(opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,429 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package testing provides an in-memory provider behaving like the provider of a DNS service:
+// the records belong to zones, are validated according to their type, and the changes are
+// applied atomically. Errors and latency can be injected, so that the sources, registries and
+// webhooks built on ExternalDNS can be tested against a realistic provider.
+package testing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+	"sync"
+	"time"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider"
+)
+
+var (
+	// ErrZoneAlreadyExists is returned when creating a zone which already exists.
+	ErrZoneAlreadyExists = errors.New("zone already exists")
+	// ErrZoneNotFound is returned when adding a record matching no zone.
+	ErrZoneNotFound = errors.New("zone not found")
+	// ErrRecordAlreadyExists is returned when creating a record which already exists.
+	ErrRecordAlreadyExists = errors.New("record already exists")
+	// ErrRecordNotFound is returned when updating or deleting a record which doesn't exist.
+	ErrRecordNotFound = errors.New("record not found")
+	// ErrInvalidRecord is returned when a record is invalid for its type, or conflicts with
+	// the other records of its name.
+	ErrInvalidRecord = errors.New("invalid record")
+)
+
+// The methods of the provider in which faults can be injected.
+const (
+	MethodRecords       = "Records"
+	MethodApplyChanges  = "ApplyChanges"
+	MethodZoneNames     = "ZoneNames"
+	MethodStreamRecords = "StreamRecords"
+)
+
+// Fault is an error returned by a method of the provider instead of serving the call.
+type Fault struct {
+	// Method is the method failing, e.g. MethodApplyChanges.
+	Method string
+	// Err is the error returned, e.g. provider.NewSoftError(err) for a transient error.
+	Err error
+	// Times is the number of calls failing, all of them until the faults are cleared if zero.
+	Times int
+}
+
+// Option configures the provider.
+type Option func(*Provider)
+
+// WithZones creates the zones of the provider.
+func WithZones(zones ...string) Option {
+	return func(p *Provider) {
+		for _, zone := range zones {
+			_ = p.CreateZone(zone)
+		}
+	}
+}
+
+// WithLatency delays each call to the provider, as the API of a DNS service would.
+func WithLatency(latency time.Duration) Option {
+	return func(p *Provider) {
+		p.latency = latency
+	}
+}
+
+// WithCapabilities sets the capabilities of the provider, the records they don't allow are
+// rejected like invalid records.
+func WithCapabilities(capabilities provider.Capabilities) Option {
+	return func(p *Provider) {
+		p.capabilities = capabilities
+	}
+}
+
+type zoneRecords map[endpoint.EndpointKey]*endpoint.Endpoint
+
+// Provider is an in-memory provider, safe for concurrent use.
+type Provider struct {
+	provider.BaseProvider
+	mu           sync.Mutex
+	zones        provider.ZoneIDName
+	records      map[string]zoneRecords
+	latency      time.Duration
+	capabilities provider.Capabilities
+	faults       []*Fault
+	calls        map[string]int
+	applied      []*plan.Changes
+}
+
+// NewProvider returns a provider without zone, unless configured otherwise.
+func NewProvider(opts ...Option) *Provider {
+	p := &Provider{
+		zones:   provider.ZoneIDName{},
+		records: map[string]zoneRecords{},
+		calls:   map[string]int{},
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+// CreateZone creates a zone without record.
+func (p *Provider) CreateZone(zone string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	zone = endpoint.NormalizeDNSName(zone)
+	if _, ok := p.records[zone]; ok {
+		return fmt.Errorf("%w: %s", ErrZoneAlreadyExists, zone)
+	}
+	p.zones.Add(zone, zone)
+	p.records[zone] = zoneRecords{}
+	return nil
+}
+
+// Seed adds records to their zone, without validating them and without failure or latency,
+// to set up the state of a test.
+func (p *Provider) Seed(records ...*endpoint.Endpoint) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, ep := range records {
+		zone := p.zoneOf(ep)
+		if zone == "" {
+			return fmt.Errorf("%w for %s", ErrZoneNotFound, ep.DNSName)
+		}
+		p.records[zone][ep.Key()] = ep.DeepCopy()
+	}
+	return nil
+}
+
+// InjectFault makes a method of the provider fail.
+func (p *Provider) InjectFault(fault Fault) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.faults = append(p.faults, &fault)
+}
+
+// ClearFaults removes the faults injected.
+func (p *Provider) ClearFaults() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.faults = nil
+}
+
+// Calls returns the number of calls to a method, including the failed ones.
+func (p *Provider) Calls(method string) int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.calls[method]
+}
+
+// AppliedChanges returns the changes applied successfully, in order.
+func (p *Provider) AppliedChanges() []*plan.Changes {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]*plan.Changes(nil), p.applied...)
+}
+
+// Capabilities returns the capabilities the provider was configured with.
+func (p *Provider) Capabilities() provider.Capabilities {
+	return p.capabilities
+}
+
+// GetDomainFilter returns a filter matching the zones of the provider.
+func (p *Provider) GetDomainFilter() endpoint.DomainFilterInterface {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return endpoint.NewDomainFilter(p.zoneNames())
+}
+
+// Records returns the records of all the zones.
+func (p *Provider) Records(ctx context.Context) ([]*endpoint.Endpoint, error) {
+	if err := p.call(ctx, MethodRecords); err != nil {
+		return nil, err
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	var records []*endpoint.Endpoint
+	for _, zone := range p.zoneNames() {
+		records = append(records, p.zoneRecords(zone)...)
+	}
+	return records, nil
+}
+
+// ZoneNames returns the names of the zones, sorted.
+func (p *Provider) ZoneNames(ctx context.Context) ([]string, error) {
+	if err := p.call(ctx, MethodZoneNames); err != nil {
+		return nil, err
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.zoneNames(), nil
+}
+
+// StreamRecords calls fn with the records of each zone in turn.
+func (p *Provider) StreamRecords(ctx context.Context, fn func(zone string, records []*endpoint.Endpoint) error) error {
+	if err := p.call(ctx, MethodStreamRecords); err != nil {
+		return err
+	}
+	p.mu.Lock()
+	zones := map[string][]*endpoint.Endpoint{}
+	names := p.zoneNames()
+	for _, zone := range names {
+		zones[zone] = p.zoneRecords(zone)
+	}
+	p.mu.Unlock()
+	for _, zone := range names {
+		if err := fn(zone, zones[zone]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ApplyChanges applies the changes atomically: none is applied when one of them fails. The
+// records matching no zone are skipped, the records created must not exist, the records updated
+// or deleted must exist, and the records created or updated must be valid for their type and
+// the capabilities of the provider, and a CNAME record can't coexist with other records.
+func (p *Provider) ApplyChanges(ctx context.Context, changes *plan.Changes) error {
+	if err := p.call(ctx, MethodApplyChanges); err != nil {
+		return err
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	updated := map[string]zoneRecords{}
+	zoneFor := func(ep *endpoint.Endpoint) zoneRecords {
+		zone := p.zoneOf(ep)
+		if zone == "" {
+			return nil
+		}
+		if _, ok := updated[zone]; !ok {
+			updated[zone] = make(zoneRecords, len(p.records[zone]))
+			for key, record := range p.records[zone] {
+				updated[zone][key] = record
+			}
+		}
+		return updated[zone]
+	}
+	remove := func(ep *endpoint.Endpoint) error {
+		records := zoneFor(ep)
+		if records == nil {
+			return nil
+		}
+		if _, ok := records[ep.Key()]; !ok {
+			return fmt.Errorf("%w: %s %s", ErrRecordNotFound, ep.DNSName, ep.RecordType)
+		}
+		delete(records, ep.Key())
+		return nil
+	}
+	add := func(ep *endpoint.Endpoint, create bool) error {
+		records := zoneFor(ep)
+		if records == nil {
+			return nil
+		}
+		if err := p.validate(ep); err != nil {
+			return err
+		}
+		if _, ok := records[ep.Key()]; ok && create {
+			return fmt.Errorf("%w: %s %s", ErrRecordAlreadyExists, ep.DNSName, ep.RecordType)
+		}
+		records[ep.Key()] = ep.DeepCopy()
+		return nil
+	}
+
+	for _, ep := range append(append([]*endpoint.Endpoint{}, changes.Delete...), changes.UpdateOld...) {
+		if err := remove(ep); err != nil {
+			return err
+		}
+	}
+	for _, ep := range changes.UpdateNew {
+		if err := add(ep, false); err != nil {
+			return err
+		}
+	}
+	for _, ep := range changes.Create {
+		if err := add(ep, true); err != nil {
+			return err
+		}
+	}
+	for _, records := range updated {
+		if err := checkCNAMEConflicts(records); err != nil {
+			return err
+		}
+	}
+
+	for zone, records := range updated {
+		p.records[zone] = records
+	}
+	p.applied = append(p.applied, changes)
+	return nil
+}
+
+// call waits for the latency of the provider, and returns the error of the first fault of the method.
+func (p *Provider) call(ctx context.Context, method string) error {
+	p.mu.Lock()
+	p.calls[method]++
+	latency := p.latency
+	var err error
+	for i, fault := range p.faults {
+		if fault.Method != method {
+			continue
+		}
+		err = fault.Err
+		if fault.Times > 0 {
+			if fault.Times--; fault.Times == 0 {
+				p.faults = append(p.faults[:i], p.faults[i+1:]...)
+			}
+		}
+		break
+	}
+	p.mu.Unlock()
+
+	if latency > 0 {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(latency):
+		}
+	}
+	return err
+}
+
+func (p *Provider) zoneOf(ep *endpoint.Endpoint) string {
+	zone, _ := p.zones.FindZoneForEndpoint(ep)
+	return zone
+}
+
+func (p *Provider) zoneNames() []string {
+	names := make([]string, 0, len(p.records))
+	for zone := range p.records {
+		names = append(names, zone)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (p *Provider) zoneRecords(zone string) []*endpoint.Endpoint {
+	records := make([]*endpoint.Endpoint, 0, len(p.records[zone]))
+	for _, ep := range p.records[zone] {
+		records = append(records, ep.DeepCopy())
+	}
+	sort.Slice(records, func(i, j int) bool {
+		if records[i].DNSName != records[j].DNSName {
+			return records[i].DNSName < records[j].DNSName
+		}
+		if records[i].RecordType != records[j].RecordType {
+			return records[i].RecordType < records[j].RecordType
+		}
+		return records[i].SetIdentifier < records[j].SetIdentifier
+	})
+	return records
+}
+
+// validate returns an error when the targets of a record are invalid for its type, or when
+// the provider doesn't support it.
+func (p *Provider) validate(ep *endpoint.Endpoint) error {
+	invalid := func(reason string) error {
+		return fmt.Errorf("%w: %s %s: %s", ErrInvalidRecord, ep.DNSName, ep.RecordType, reason)
+	}
+	if len(ep.Targets) == 0 {
+		return invalid("no target")
+	}
+	for _, target := range ep.Targets {
+		ip := net.ParseIP(target)
+		switch ep.RecordType {
+		case endpoint.RecordTypeA:
+			if ip == nil || ip.To4() == nil {
+				return invalid(target + " is not an IPv4 address")
+			}
+		case endpoint.RecordTypeAAAA:
+			if ip == nil || ip.To4() != nil {
+				return invalid(target + " is not an IPv6 address")
+			}
+		case endpoint.RecordTypeCNAME, endpoint.RecordTypeNS, endpoint.RecordTypePTR:
+			if ip != nil {
+				return invalid(target + " is not a hostname")
+			}
+		}
+	}
+	if ep.RecordType == endpoint.RecordTypeCNAME && len(ep.Targets) > 1 {
+		return invalid("a CNAME record has a single target")
+	}
+	if !ep.CheckEndpoint() {
+		return invalid("malformed targets " + ep.Targets.String())
+	}
+	if err := p.capabilities.Validate(ep); err != nil {
+		return invalid(err.Error())
+	}
+	return nil
+}
+
+// checkCNAMEConflicts returns an error when a name has a CNAME record and other records.
+func checkCNAMEConflicts(records zoneRecords) error {
+	types := map[string]map[string]bool{}
+	for _, ep := range records {
+		if types[ep.DNSName] == nil {
+			types[ep.DNSName] = map[string]bool{}
+		}
+		types[ep.DNSName][ep.RecordType] = true
+	}
+	for name, recordTypes := range types {
+		if recordTypes[endpoint.RecordTypeCNAME] && len(recordTypes) > 1 {
+			return fmt.Errorf("%w: %s has a CNAME record and other records", ErrInvalidRecord, name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,207 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testing
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider"
+)
+
+var (
+	_ provider.Provider             = &Provider{}
+	_ provider.RecordsStreamer      = &Provider{}
+	_ provider.CapabilitiesReporter = &Provider{}
+)
+
+func TestProviderZones(t *testing.T) {
+	p := NewProvider(WithZones("example.org", "sub.example.org"))
+	require.ErrorIs(t, p.CreateZone("example.org."), ErrZoneAlreadyExists)
+
+	zones, err := p.ZoneNames(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"example.org", "sub.example.org"}, zones)
+	assert.True(t, p.GetDomainFilter().Match("www.sub.example.org"))
+	assert.False(t, p.GetDomainFilter().Match("example.com"))
+
+	require.NoError(t, p.Seed(
+		endpoint.NewEndpoint("www.example.org", endpoint.RecordTypeA, "1.2.3.4"),
+		endpoint.NewEndpoint("www.sub.example.org", endpoint.RecordTypeA, "1.2.3.4"),
+	))
+	require.ErrorIs(t, p.Seed(endpoint.NewEndpoint("www.example.com", endpoint.RecordTypeA, "1.2.3.4")), ErrZoneNotFound)
+
+	streamed := map[string][]string{}
+	require.NoError(t, p.StreamRecords(context.Background(), func(zone string, records []*endpoint.Endpoint) error {
+		for _, ep := range records {
+			streamed[zone] = append(streamed[zone], ep.DNSName)
+		}
+		return nil
+	}))
+	assert.Equal(t, map[string][]string{"example.org": {"www.example.org"}, "sub.example.org": {"www.sub.example.org"}}, streamed)
+}
+
+func TestProviderApplyChanges(t *testing.T) {
+	p := NewProvider(WithZones("example.org"))
+	require.NoError(t, p.Seed(
+		endpoint.NewEndpoint("update.example.org", endpoint.RecordTypeA, "1.2.3.4"),
+		endpoint.NewEndpoint("delete.example.org", endpoint.RecordTypeA, "1.2.3.4"),
+	))
+
+	changes := &plan.Changes{
+		Create:    []*endpoint.Endpoint{endpoint.NewEndpoint("create.example.org", endpoint.RecordTypeAAAA, "2001:db8::1")},
+		UpdateOld: []*endpoint.Endpoint{endpoint.NewEndpoint("update.example.org", endpoint.RecordTypeA, "1.2.3.4")},
+		UpdateNew: []*endpoint.Endpoint{endpoint.NewEndpoint("update.example.org", endpoint.RecordTypeA, "5.6.7.8")},
+		Delete:    []*endpoint.Endpoint{endpoint.NewEndpoint("delete.example.org", endpoint.RecordTypeA, "1.2.3.4")},
+	}
+	// the records matching no zone are skipped
+	changes.Create = append(changes.Create, endpoint.NewEndpoint("www.example.com", endpoint.RecordTypeA, "1.2.3.4"))
+	require.NoError(t, p.ApplyChanges(context.Background(), changes))
+
+	records, err := p.Records(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []*endpoint.Endpoint{
+		endpoint.NewEndpoint("create.example.org", endpoint.RecordTypeAAAA, "2001:db8::1"),
+		endpoint.NewEndpoint("update.example.org", endpoint.RecordTypeA, "5.6.7.8"),
+	}, records)
+	assert.Equal(t, []*plan.Changes{changes}, p.AppliedChanges())
+}
+
+func TestProviderApplyChangesErrors(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		changes     *plan.Changes
+		expectedErr error
+	}{
+		{
+			name:        "existing record",
+			changes:     &plan.Changes{Create: []*endpoint.Endpoint{endpoint.NewEndpoint("www.example.org", endpoint.RecordTypeA, "5.6.7.8")}},
+			expectedErr: ErrRecordAlreadyExists,
+		},
+		{
+			name:        "missing record",
+			changes:     &plan.Changes{Delete: []*endpoint.Endpoint{endpoint.NewEndpoint("missing.example.org", endpoint.RecordTypeA, "1.2.3.4")}},
+			expectedErr: ErrRecordNotFound,
+		},
+		{
+			name:        "ipv6 address in A record",
+			changes:     &plan.Changes{Create: []*endpoint.Endpoint{endpoint.NewEndpoint("v6.example.org", endpoint.RecordTypeA, "2001:db8::1")}},
+			expectedErr: ErrInvalidRecord,
+		},
+		{
+			name:        "ipv4 address in AAAA record",
+			changes:     &plan.Changes{Create: []*endpoint.Endpoint{endpoint.NewEndpoint("v4.example.org", endpoint.RecordTypeAAAA, "1.2.3.4")}},
+			expectedErr: ErrInvalidRecord,
+		},
+		{
+			name:        "CNAME with several targets",
+			changes:     &plan.Changes{Create: []*endpoint.Endpoint{endpoint.NewEndpoint("alias.example.org", endpoint.RecordTypeCNAME, "a.example.com", "b.example.com")}},
+			expectedErr: ErrInvalidRecord,
+		},
+		{
+			name:        "MX without preference",
+			changes:     &plan.Changes{Create: []*endpoint.Endpoint{endpoint.NewEndpoint("example.org", endpoint.RecordTypeMX, "mail.example.org")}},
+			expectedErr: ErrInvalidRecord,
+		},
+		{
+			name:        "CNAME conflicting with other records",
+			changes:     &plan.Changes{Create: []*endpoint.Endpoint{endpoint.NewEndpoint("www.example.org", endpoint.RecordTypeCNAME, "lb.example.com")}},
+			expectedErr: ErrInvalidRecord,
+		},
+		{
+			name: "unsupported record type",
+			changes: &plan.Changes{Create: []*endpoint.Endpoint{
+				endpoint.NewEndpoint("ok.example.org", endpoint.RecordTypeA, "1.2.3.4"),
+				endpoint.NewEndpoint("example.org", endpoint.RecordTypeNS, "ns.example.com"),
+			}},
+			expectedErr: ErrInvalidRecord,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			p := NewProvider(WithZones("example.org"), WithCapabilities(provider.Capabilities{
+				RecordTypes: []string{endpoint.RecordTypeA, endpoint.RecordTypeAAAA, endpoint.RecordTypeCNAME, endpoint.RecordTypeMX},
+			}))
+			require.NoError(t, p.Seed(endpoint.NewEndpoint("www.example.org", endpoint.RecordTypeA, "1.2.3.4")))
+
+			require.ErrorIs(t, p.ApplyChanges(context.Background(), tc.changes), tc.expectedErr)
+			// none of the changes is applied
+			records, err := p.Records(context.Background())
+			require.NoError(t, err)
+			assert.Equal(t, []*endpoint.Endpoint{endpoint.NewEndpoint("www.example.org", endpoint.RecordTypeA, "1.2.3.4")}, records)
+			assert.Empty(t, p.AppliedChanges())
+		})
+	}
+}
+
+func TestProviderReplaceCNAME(t *testing.T) {
+	p := NewProvider(WithZones("example.org"))
+	require.NoError(t, p.Seed(endpoint.NewEndpoint("www.example.org", endpoint.RecordTypeCNAME, "lb.example.com")))
+
+	// a CNAME record can be replaced by another type within a batch
+	require.NoError(t, p.ApplyChanges(context.Background(), &plan.Changes{
+		Create: []*endpoint.Endpoint{endpoint.NewEndpoint("www.example.org", endpoint.RecordTypeA, "1.2.3.4")},
+		Delete: []*endpoint.Endpoint{endpoint.NewEndpoint("www.example.org", endpoint.RecordTypeCNAME, "lb.example.com")},
+	}))
+	records, err := p.Records(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []*endpoint.Endpoint{endpoint.NewEndpoint("www.example.org", endpoint.RecordTypeA, "1.2.3.4")}, records)
+}
+
+func TestProviderFaults(t *testing.T) {
+	p := NewProvider(WithZones("example.org"))
+	throttled := provider.NewSoftError(errors.New("throttled"))
+	p.InjectFault(Fault{Method: MethodApplyChanges, Err: throttled, Times: 2})
+	p.InjectFault(Fault{Method: MethodRecords, Err: errors.New("unavailable")})
+
+	changes := &plan.Changes{Create: []*endpoint.Endpoint{endpoint.NewEndpoint("www.example.org", endpoint.RecordTypeA, "1.2.3.4")}}
+	for range 2 {
+		err := p.ApplyChanges(context.Background(), changes)
+		require.ErrorIs(t, err, provider.SoftError)
+	}
+	require.NoError(t, p.ApplyChanges(context.Background(), changes))
+	assert.Equal(t, 3, p.Calls(MethodApplyChanges))
+
+	for range 3 {
+		_, err := p.Records(context.Background())
+		require.Error(t, err)
+	}
+	p.ClearFaults()
+	records, err := p.Records(context.Background())
+	require.NoError(t, err)
+	assert.Len(t, records, 1)
+	assert.Equal(t, 4, p.Calls(MethodRecords))
+}
+
+func TestProviderLatency(t *testing.T) {
+	p := NewProvider(WithZones("example.org"), WithLatency(20*time.Millisecond))
+	start := time.Now()
+	_, err := p.Records(context.Background())
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, time.Since(start), 20*time.Millisecond)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = p.Records(ctx)
+	require.ErrorIs(t, err, context.Canceled)
+}
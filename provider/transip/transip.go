@@ -20,6 +20,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
 	"strings"
 
 	log "github.com/sirupsen/logrus"
@@ -27,6 +28,7 @@ import (
 	"github.com/transip/gotransip/v6/domain"
 
 	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/pkg/egress"
 	"sigs.k8s.io/external-dns/plan"
 	"sigs.k8s.io/external-dns/provider"
 )
@@ -48,7 +50,7 @@ type TransIPProvider struct {
 }
 
 // NewTransIPProvider initializes a new TransIP Provider.
-func NewTransIPProvider(accountName, privateKeyFile string, domainFilter *endpoint.DomainFilter, dryRun bool) (*TransIPProvider, error) {
+func NewTransIPProvider(accountName, privateKeyFile string, domainFilter *endpoint.DomainFilter, dryRun bool, egressConfig *egress.Config) (*TransIPProvider, error) {
 	// check given arguments
 	if accountName == "" {
 		return nil, errors.New("required --transip-account not set")
@@ -70,6 +72,7 @@ func NewTransIPProvider(accountName, privateKeyFile string, domainFilter *endpoi
 		AccountName:    accountName,
 		PrivateKeyPath: privateKeyFile,
 		Mode:           apiMode,
+		HTTPClient:     &http.Client{Transport: egressConfig.Transport()},
 	})
 	if err != nil {
 		return nil, fmt.Errorf("could not setup TransIP API client: %w", err)
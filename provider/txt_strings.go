@@ -0,0 +1,260 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+)
+
+// maxTXTStringLength is the length of the longest character-string of a TXT record (RFC 1035).
+const maxTXTStringLength = 255
+
+// TXTSplittingProvider splits the TXT targets longer than a character-string into several
+// quoted strings before applying them, and merges the strings of a TXT target back into a single
+// quoted string when reading them, for the providers whose TXT targets are in the zone file
+// format (Capabilities.SplitTXT). The long values of SPF or DKIM records can then be applied
+// as a single target.
+//
+// The aws, gandi, godaddy, google, oci and pdns providers report SplitTXT. The others don't:
+//   - rfc2136 builds its records with the DNS library, which splits the long strings itself, and
+//     merges the strings of a record when reading it;
+//   - akamai quotes the TXT values itself, replacing the quotes embedded in the ownership records,
+//     so it takes a value as a single string;
+//   - the others, such as azure, cloudflare, digitalocean or linode, take the value of a TXT record
+//     as a single string, which their API splits into character-strings or rejects when too long;
+//   - the webhook providers report it on the capabilities endpoint of the webhook API.
+type TXTSplittingProvider struct {
+	Provider
+
+	mu sync.Mutex
+	// originals are the targets of the provider merged when read, by name and merged target, so
+	// that the records split differently, e.g. by hand, are deleted as they are.
+	originals map[string]string
+}
+
+// NewTXTSplittingProvider returns a provider splitting and merging the TXT targets of the given one.
+func NewTXTSplittingProvider(p Provider) *TXTSplittingProvider {
+	return &TXTSplittingProvider{Provider: p, originals: map[string]string{}}
+}
+
+func (t *TXTSplittingProvider) Records(ctx context.Context) ([]*endpoint.Endpoint, error) {
+	records, err := t.Provider.Records(ctx)
+	if err != nil {
+		return nil, err
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.originals = map[string]string{}
+	t.merge(records)
+	return records, nil
+}
+
+func (t *TXTSplittingProvider) ZoneRecords(ctx context.Context, domain string) ([]*endpoint.Endpoint, error) {
+	records, err := ZoneRecords(ctx, t.Provider, domain)
+	if err != nil {
+		return nil, err
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.merge(records)
+	return records, nil
+}
+
+func (t *TXTSplittingProvider) ZoneNames(ctx context.Context) ([]string, error) {
+	return ZoneNames(ctx, t.Provider)
+}
+
+func (t *TXTSplittingProvider) StreamRecords(ctx context.Context, fn func(zone string, records []*endpoint.Endpoint) error) error {
+	return StreamRecords(ctx, t.Provider, func(zone string, records []*endpoint.Endpoint) error {
+		t.mu.Lock()
+		t.merge(records)
+		t.mu.Unlock()
+		return fn(zone, records)
+	})
+}
+
+func (t *TXTSplittingProvider) WildcardSupport() WildcardSupport {
+	return GetWildcardSupport(t.Provider)
+}
+
+func (t *TXTSplittingProvider) Capabilities() Capabilities {
+	return GetCapabilities(t.Provider)
+}
+
+// AdjustEndpoints merges the TXT targets made of several strings, and quotes the long unquoted
+// ones, so that they are the same as the targets read once applied.
+func (t *TXTSplittingProvider) AdjustEndpoints(endpoints []*endpoint.Endpoint) ([]*endpoint.Endpoint, error) {
+	for _, ep := range endpoints {
+		if ep.RecordType != endpoint.RecordTypeTXT {
+			continue
+		}
+		for i, target := range ep.Targets {
+			strs, ok := parseTXTStrings(target)
+			switch {
+			case ok && len(strs) > 1:
+				ep.Targets[i] = quoteTXTString(strings.Join(strs, ""))
+			case !ok && len(target) > maxTXTStringLength:
+				ep.Targets[i] = quoteTXTString(target)
+			}
+		}
+	}
+	return t.Provider.AdjustEndpoints(endpoints)
+}
+
+// ApplyChanges splits the long TXT targets of the changes, the records read being deleted with
+// their original strings.
+func (t *TXTSplittingProvider) ApplyChanges(ctx context.Context, changes *plan.Changes) error {
+	t.mu.Lock()
+	split := &plan.Changes{
+		Create:    t.split(changes.Create, false),
+		UpdateOld: t.split(changes.UpdateOld, true),
+		UpdateNew: t.split(changes.UpdateNew, false),
+		Delete:    t.split(changes.Delete, true),
+	}
+	t.mu.Unlock()
+	return t.Provider.ApplyChanges(ctx, split)
+}
+
+// merge merges the strings of the TXT targets in place, keeping their original form.
+func (t *TXTSplittingProvider) merge(records []*endpoint.Endpoint) {
+	for _, ep := range records {
+		if ep.RecordType != endpoint.RecordTypeTXT {
+			continue
+		}
+		for i, target := range ep.Targets {
+			strs, ok := parseTXTStrings(target)
+			if !ok || len(strs) < 2 {
+				continue
+			}
+			merged := quoteTXTString(strings.Join(strs, ""))
+			t.originals[ep.DNSName+"/"+merged] = target
+			ep.Targets[i] = merged
+		}
+	}
+}
+
+// split returns copies of the endpoints whose long TXT targets are split, or replaced by their
+// original form for the records read.
+func (t *TXTSplittingProvider) split(endpoints []*endpoint.Endpoint, read bool) []*endpoint.Endpoint {
+	if endpoints == nil {
+		return nil
+	}
+	result := make([]*endpoint.Endpoint, 0, len(endpoints))
+	for _, ep := range endpoints {
+		if ep.RecordType != endpoint.RecordTypeTXT {
+			result = append(result, ep)
+			continue
+		}
+		ep = ep.DeepCopy()
+		for i, target := range ep.Targets {
+			if original, ok := t.originals[ep.DNSName+"/"+target]; read && ok {
+				ep.Targets[i] = original
+				continue
+			}
+			ep.Targets[i] = splitTXT(target)
+		}
+		result = append(result, ep)
+	}
+	return result
+}
+
+// splitTXT returns a TXT target as quoted strings of at most 255 bytes, or as is if its value
+// fits in a single string. The strings are not split within a UTF-8 character.
+func splitTXT(target string) string {
+	value := target
+	if strs, ok := parseTXTStrings(target); ok {
+		value = strings.Join(strs, "")
+	}
+	if len(value) <= maxTXTStringLength {
+		return target
+	}
+
+	var strs []string
+	for len(value) > maxTXTStringLength {
+		n := maxTXTStringLength
+		for n > 0 && !utf8.RuneStart(value[n]) {
+			n--
+		}
+		strs = append(strs, quoteTXTString(value[:n]))
+		value = value[n:]
+	}
+	strs = append(strs, quoteTXTString(value))
+	return strings.Join(strs, " ")
+}
+
+// parseTXTStrings returns the strings of a TXT target in the zone file format, made of quoted
+// strings separated by spaces, and false if it is not in this format.
+func parseTXTStrings(target string) ([]string, bool) {
+	var strs []string
+	s := strings.TrimSpace(target)
+	for s != "" {
+		if s[0] != '"' {
+			return nil, false
+		}
+		var b strings.Builder
+		i := 1
+		for ; i < len(s) && s[i] != '"'; i++ {
+			if s[i] != '\\' {
+				b.WriteByte(s[i])
+				continue
+			}
+			i++
+			switch {
+			case i+2 < len(s) && isDigit(s[i]) && isDigit(s[i+1]) && isDigit(s[i+2]):
+				n := int(s[i]-'0')*100 + int(s[i+1]-'0')*10 + int(s[i+2]-'0')
+				if n > 255 {
+					return nil, false
+				}
+				b.WriteByte(byte(n))
+				i += 2
+			case i < len(s):
+				b.WriteByte(s[i])
+			}
+		}
+		if i >= len(s) {
+			return nil, false
+		}
+		strs = append(strs, b.String())
+		s = strings.TrimLeft(s[i+1:], " \t")
+	}
+	return strs, len(strs) > 0
+}
+
+// quoteTXTString returns a quoted string of a TXT target, escaping the quotes and backslashes.
+func quoteTXTString(s string) string {
+	var b strings.Builder
+	b.Grow(len(s) + 2)
+	b.WriteByte('"')
+	for i := 0; i < len(s); i++ {
+		if s[i] == '"' || s[i] == '\\' {
+			b.WriteByte('\\')
+		}
+		b.WriteByte(s[i])
+	}
+	b.WriteByte('"')
+	return b.String()
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
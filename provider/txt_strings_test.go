@@ -0,0 +1,142 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+)
+
+func TestSplitTXT(t *testing.T) {
+	long := strings.Repeat("a", 300)
+	for _, tc := range []struct {
+		name     string
+		target   string
+		expected string
+	}{
+		{"short unquoted", "v=spf1 -all", "v=spf1 -all"},
+		{"short quoted", `"v=spf1 -all"`, `"v=spf1 -all"`},
+		{"long unquoted", long, `"` + long[:255] + `" "` + long[255:] + `"`},
+		{"long quoted", `"` + long + `"`, `"` + long[:255] + `" "` + long[255:] + `"`},
+		{"already split", `"` + long[:100] + `" "` + long[100:] + `"`, `"` + long[:255] + `" "` + long[255:] + `"`},
+		{"escaped quotes", `"` + long + `\"b"`, `"` + long[:255] + `" "` + long[255:] + `\"b"`},
+		{
+			"utf-8 character on the boundary",
+			strings.Repeat("a", 254) + "é" + "b",
+			`"` + strings.Repeat("a", 254) + `" "éb"`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, splitTXT(tc.target))
+		})
+	}
+}
+
+func TestParseTXTStrings(t *testing.T) {
+	for _, tc := range []struct {
+		target   string
+		expected []string
+		ok       bool
+	}{
+		{`"a" "b"`, []string{"a", "b"}, true},
+		{`"a"  "b c" `, []string{"a", "b c"}, true},
+		{`"a\"b" "c\\d"`, []string{`a"b`, `c\d`}, true},
+		{`"\065\066"`, []string{"AB"}, true},
+		{`""`, []string{""}, true},
+		{`v=spf1 -all`, nil, false},
+		{`"a" b`, nil, false},
+		{`"a`, nil, false},
+		{`"\999"`, nil, false},
+		{``, nil, false},
+	} {
+		t.Run(tc.target, func(t *testing.T) {
+			strs, ok := parseTXTStrings(tc.target)
+			assert.Equal(t, tc.ok, ok)
+			assert.Equal(t, tc.expected, strs)
+		})
+	}
+}
+
+func TestTXTSplittingProvider(t *testing.T) {
+	long := strings.Repeat("a", 300)
+	merged := `"` + long + `"`
+	split := `"` + long[:255] + `" "` + long[255:] + `"`
+	// a record split by hand, which is deleted as it is
+	byHand := `"` + long[:100] + `" "` + long[100:] + `"`
+
+	testProvider := newTestProviderFunc(t)
+	testProvider.records = func(ctx context.Context) ([]*endpoint.Endpoint, error) {
+		return []*endpoint.Endpoint{
+			endpoint.NewEndpoint("split.example.com", endpoint.RecordTypeTXT, split),
+			endpoint.NewEndpoint("hand.example.com", endpoint.RecordTypeTXT, byHand),
+			endpoint.NewEndpoint("short.example.com", endpoint.RecordTypeTXT, `"short"`),
+			endpoint.NewEndpoint("a.example.com", endpoint.RecordTypeA, "192.0.2.1"),
+		}, nil
+	}
+	testProvider.adjustEndpoints = func(endpoints []*endpoint.Endpoint) ([]*endpoint.Endpoint, error) {
+		return endpoints, nil
+	}
+	p := NewTXTSplittingProvider(testProvider)
+
+	records, err := p.Records(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, endpoint.Targets{merged}, records[0].Targets)
+	assert.Equal(t, endpoint.Targets{merged}, records[1].Targets)
+	assert.Equal(t, endpoint.Targets{`"short"`}, records[2].Targets)
+	assert.Equal(t, endpoint.Targets{"192.0.2.1"}, records[3].Targets)
+
+	// the desired targets are the same as the targets read, whether split or not
+	desired, err := p.AdjustEndpoints([]*endpoint.Endpoint{
+		endpoint.NewEndpoint("split.example.com", endpoint.RecordTypeTXT, long),
+		endpoint.NewEndpoint("hand.example.com", endpoint.RecordTypeTXT, split),
+		endpoint.NewEndpoint("short.example.com", endpoint.RecordTypeTXT, "short"),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, endpoint.Targets{merged}, desired[0].Targets)
+	assert.Equal(t, endpoint.Targets{merged}, desired[1].Targets)
+	assert.Equal(t, endpoint.Targets{"short"}, desired[2].Targets)
+
+	var applied *plan.Changes
+	testProvider.applyChanges = func(ctx context.Context, changes *plan.Changes) error {
+		applied = changes
+		return nil
+	}
+	changes := &plan.Changes{
+		Create:    []*endpoint.Endpoint{endpoint.NewEndpoint("new.example.com", endpoint.RecordTypeTXT, merged)},
+		UpdateOld: []*endpoint.Endpoint{endpoint.NewEndpoint("split.example.com", endpoint.RecordTypeTXT, merged)},
+		UpdateNew: []*endpoint.Endpoint{endpoint.NewEndpointWithTTL("split.example.com", endpoint.RecordTypeTXT, 300, merged)},
+		Delete: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("hand.example.com", endpoint.RecordTypeTXT, merged),
+			endpoint.NewEndpoint("a.example.com", endpoint.RecordTypeA, "192.0.2.1"),
+		},
+	}
+	require.NoError(t, p.ApplyChanges(context.Background(), changes))
+	assert.Equal(t, endpoint.Targets{split}, applied.Create[0].Targets)
+	assert.Equal(t, endpoint.Targets{split}, applied.UpdateOld[0].Targets)
+	assert.Equal(t, endpoint.Targets{split}, applied.UpdateNew[0].Targets)
+	assert.Equal(t, endpoint.Targets{byHand}, applied.Delete[0].Targets)
+	assert.Equal(t, endpoint.Targets{"192.0.2.1"}, applied.Delete[1].Targets)
+	// the changes of the plan are left as they are
+	assert.Equal(t, endpoint.Targets{merged}, changes.Create[0].Targets)
+	assert.Equal(t, endpoint.Targets{merged}, changes.Delete[0].Targets)
+}
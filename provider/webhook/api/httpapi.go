@@ -17,7 +17,6 @@ limitations under the License.
 package api
 
 import (
-	"context"
 	"encoding/json"
 	"net"
 	"net/http"
@@ -36,6 +35,7 @@ const (
 	UrlAdjustEndpoints        = "/adjustendpoints"
 	UrlApplyChanges           = "/applychanges"
 	UrlRecords                = "/records"
+	UrlCapabilities           = "/capabilities"
 )
 
 type WebhookServer struct {
@@ -45,7 +45,7 @@ type WebhookServer struct {
 func (p *WebhookServer) RecordsHandler(w http.ResponseWriter, req *http.Request) {
 	switch req.Method {
 	case http.MethodGet:
-		records, err := p.Provider.Records(context.Background())
+		records, err := p.Provider.Records(req.Context())
 		if err != nil {
 			log.Errorf("Failed to get Records: %v", err)
 			w.WriteHeader(http.StatusInternalServerError)
@@ -64,7 +64,7 @@ func (p *WebhookServer) RecordsHandler(w http.ResponseWriter, req *http.Request)
 			w.WriteHeader(http.StatusBadRequest)
 			return
 		}
-		err := p.Provider.ApplyChanges(context.Background(), &changes)
+		err := p.Provider.ApplyChanges(req.Context(), &changes)
 		if err != nil {
 			log.Errorf("Failed to apply changes: %v", err)
 			w.WriteHeader(http.StatusInternalServerError)
@@ -104,6 +104,20 @@ func (p *WebhookServer) AdjustEndpointsHandler(w http.ResponseWriter, req *http.
 	}
 }
 
+// CapabilitiesHandler returns the capabilities of the provider, so that ExternalDNS rejects the
+// changes it can't apply before sending them.
+func (p *WebhookServer) CapabilitiesHandler(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		log.Errorf("Unsupported method %s", req.Method)
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	w.Header().Set(ContentTypeHeader, MediaTypeFormatAndVersion)
+	if err := json.NewEncoder(w).Encode(provider.GetCapabilities(p.Provider)); err != nil {
+		log.Errorf("Failed to encode capabilities: %v", err)
+	}
+}
+
 func (p *WebhookServer) NegotiateHandler(w http.ResponseWriter, _ *http.Request) {
 	w.Header().Set(ContentTypeHeader, MediaTypeFormatAndVersion)
 	err := json.NewEncoder(w).Encode(p.Provider.GetDomainFilter())
@@ -120,6 +134,7 @@ func (p *WebhookServer) NegotiateHandler(w http.ResponseWriter, _ *http.Request)
 // - /records (GET): returns the current records
 // - /records (POST): applies the changes
 // - /adjustendpoints (POST): executes the AdjustEndpoints method
+// - /capabilities (GET): returns the capabilities of the provider
 func StartHTTPApi(provider provider.Provider, startedChan chan struct{}, readTimeout, writeTimeout time.Duration, providerPort string) {
 	p := WebhookServer{
 		Provider: provider,
@@ -129,6 +144,7 @@ func StartHTTPApi(provider provider.Provider, startedChan chan struct{}, readTim
 	m.HandleFunc("/", p.NegotiateHandler)
 	m.HandleFunc(UrlRecords, p.RecordsHandler)
 	m.HandleFunc(UrlAdjustEndpoints, p.AdjustEndpointsHandler)
+	m.HandleFunc(UrlCapabilities, p.CapabilitiesHandler)
 
 	s := &http.Server{
 		Addr:         providerPort,
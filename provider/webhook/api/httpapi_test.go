@@ -33,6 +33,7 @@ import (
 
 	"sigs.k8s.io/external-dns/endpoint"
 	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider"
 )
 
 var records []*endpoint.Endpoint
@@ -355,3 +356,47 @@ func TestNegotiateHandler_FiltersWithSpecialEncodings(t *testing.T) {
 
 	require.Equal(t, http.StatusOK, res.StatusCode)
 }
+
+type capabilitiesWebhookProvider struct {
+	FakeWebhookProvider
+}
+
+func (p capabilitiesWebhookProvider) Capabilities() provider.Capabilities {
+	return provider.Capabilities{
+		RecordTypes: []string{endpoint.RecordTypeA, endpoint.RecordTypeTXT},
+		MinTTL:      60,
+		Properties: []provider.PropertySchema{
+			{Name: "webhook/proxied", Type: provider.PropertyTypeBool, Validate: func(string) error { return nil }},
+		},
+	}
+}
+
+func TestCapabilitiesHandler(t *testing.T) {
+	server := &WebhookServer{Provider: capabilitiesWebhookProvider{}}
+	w := httptest.NewRecorder()
+	server.CapabilitiesHandler(w, httptest.NewRequest(http.MethodGet, UrlCapabilities, nil))
+	res := w.Result()
+	defer res.Body.Close()
+
+	require.Equal(t, http.StatusOK, res.StatusCode)
+	require.Equal(t, MediaTypeFormatAndVersion, res.Header.Get(ContentTypeHeader))
+	var capabilities provider.Capabilities
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&capabilities))
+	// the validation functions of the properties are not reported
+	assert.Equal(t, provider.Capabilities{
+		RecordTypes: []string{endpoint.RecordTypeA, endpoint.RecordTypeTXT},
+		MinTTL:      60,
+		Properties:  []provider.PropertySchema{{Name: "webhook/proxied", Type: provider.PropertyTypeBool}},
+	}, capabilities)
+
+	// the providers which don't report their capabilities apply any record
+	server = &WebhookServer{Provider: &FakeWebhookProvider{}}
+	w = httptest.NewRecorder()
+	server.CapabilitiesHandler(w, httptest.NewRequest(http.MethodGet, UrlCapabilities, nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{}`, w.Body.String())
+
+	w = httptest.NewRecorder()
+	server.CapabilitiesHandler(w, httptest.NewRequest(http.MethodPost, UrlCapabilities, nil))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
@@ -23,8 +23,12 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"time"
 
 	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/pkg/egress"
+	"sigs.k8s.io/external-dns/pkg/failover"
+	"sigs.k8s.io/external-dns/pkg/httpbody"
 	"sigs.k8s.io/external-dns/pkg/metrics"
 	"sigs.k8s.io/external-dns/plan"
 	"sigs.k8s.io/external-dns/provider"
@@ -95,6 +99,7 @@ type WebhookProvider struct {
 	client          *http.Client
 	remoteServerURL *url.URL
 	DomainFilter    *endpoint.DomainFilter
+	capabilities    provider.Capabilities
 }
 
 func init() {
@@ -106,7 +111,46 @@ func init() {
 	metrics.RegisterMetric.MustRegister(adjustEndpointsRequestsGauge)
 }
 
-func NewWebhookProvider(u string) (*WebhookProvider, error) {
+// Option configures the HTTP client of the webhook provider.
+type Option func(*options)
+
+type options struct {
+	fallbackURLs     []string
+	failoverCooldown time.Duration
+	egress           *egress.Config
+}
+
+// WithFallbackURLs sets the URLs the webhook is called at, in order, while it's unhealthy at the
+// URL of the provider.
+func WithFallbackURLs(urls ...string) Option {
+	return func(o *options) {
+		o.fallbackURLs = urls
+	}
+}
+
+// WithFailoverCooldown sets the time the webhook isn't called at a URL which failed, the default
+// of the failover transport for the zero value.
+func WithFailoverCooldown(d time.Duration) Option {
+	return func(o *options) {
+		o.failoverCooldown = d
+	}
+}
+
+// WithEgress sends the requests to the webhook through the proxy, and trusting the CA bundle, of
+// the egress configuration.
+func WithEgress(cfg *egress.Config) Option {
+	return func(o *options) {
+		o.egress = cfg
+	}
+}
+
+// NewWebhookProvider returns a provider calling the webhook at the URL.
+func NewWebhookProvider(u string, opts ...Option) (*WebhookProvider, error) {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
 	parsedURL, err := url.Parse(u)
 	if err != nil {
 		return nil, err
@@ -119,7 +163,14 @@ func NewWebhookProvider(u string) (*WebhookProvider, error) {
 	}
 	req.Header.Set(acceptHeader, webhookapi.MediaTypeFormatAndVersion)
 
-	client := &http.Client{}
+	client := &http.Client{Transport: o.egress.Transport()}
+	if len(o.fallbackURLs) > 0 {
+		transport, err := failover.NewTransport(append([]string{u}, o.fallbackURLs...), client.Transport, failover.WithCooldown(o.failoverCooldown))
+		if err != nil {
+			return nil, err
+		}
+		client.Transport = transport
+	}
 
 	resp, err := requestWithRetry(client, req)
 	if err != nil {
@@ -133,7 +184,7 @@ func NewWebhookProvider(u string) (*WebhookProvider, error) {
 	}
 
 	df := &endpoint.DomainFilter{}
-	if err := json.NewDecoder(resp.Body).Decode(df); err != nil {
+	if err := json.NewDecoder(httpbody.LimitReader(resp.Body, httpbody.DefaultMaxBytes)).Decode(df); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response body of DomainFilter: %w", err)
 	}
 
@@ -141,11 +192,40 @@ func NewWebhookProvider(u string) (*WebhookProvider, error) {
 		client:          client,
 		remoteServerURL: parsedURL,
 		DomainFilter:    df,
+		capabilities:    fetchCapabilities(client, parsedURL),
 	}, nil
 }
 
+// fetchCapabilities returns the capabilities of the webhook. The webhooks which don't serve them
+// are assumed to apply any record, as the providers not reporting their capabilities.
+func fetchCapabilities(client *http.Client, remoteServerURL *url.URL) provider.Capabilities {
+	var capabilities provider.Capabilities
+	req, err := http.NewRequest(http.MethodGet, remoteServerURL.JoinPath(webhookapi.UrlCapabilities).String(), nil)
+	if err != nil {
+		log.Warnf("Failed to create the request of the capabilities of the webhook: %v", err)
+		return capabilities
+	}
+	req.Header.Set(acceptHeader, webhookapi.MediaTypeFormatAndVersion)
+	resp, err := client.Do(req)
+	if err != nil {
+		log.Warnf("Failed to get the capabilities of the webhook, the changes are not validated: %v", err)
+		return capabilities
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK || resp.Header.Get(webhookapi.ContentTypeHeader) != webhookapi.MediaTypeFormatAndVersion {
+		log.Debugf("The webhook does not report its capabilities, the changes are not validated (code %d)", resp.StatusCode)
+		return capabilities
+	}
+	if err := json.NewDecoder(httpbody.LimitReader(resp.Body, httpbody.DefaultMaxBytes)).Decode(&capabilities); err != nil {
+		log.Warnf("Failed to decode the capabilities of the webhook, the changes are not validated: %v", err)
+		return provider.Capabilities{}
+	}
+	return capabilities
+}
+
 func requestWithRetry(client *http.Client, req *http.Request) (*http.Response, error) {
-	resp, err := backoff.Retry(context.Background(), func() (*http.Response, error) {
+	resp, err := backoff.Retry(req.Context(), func() (*http.Response, error) {
 		resp, err := client.Do(req)
 		if err != nil {
 			log.Debugf("Failed to connect to webhook: %v", err)
@@ -165,7 +245,7 @@ func (p WebhookProvider) Records(ctx context.Context) ([]*endpoint.Endpoint, err
 	recordsRequestsGauge.Gauge.Inc()
 	u := p.remoteServerURL.JoinPath("records").String()
 
-	req, err := http.NewRequest(http.MethodGet, u, nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
 	if err != nil {
 		recordsErrorsGauge.Gauge.Inc()
 		log.Debugf("Failed to create request: %s", err.Error())
@@ -191,7 +271,7 @@ func (p WebhookProvider) Records(ctx context.Context) ([]*endpoint.Endpoint, err
 	}
 
 	var endpoints []*endpoint.Endpoint
-	if err := json.NewDecoder(resp.Body).Decode(&endpoints); err != nil {
+	if err := json.NewDecoder(httpbody.LimitReader(resp.Body, httpbody.DefaultMaxBytes)).Decode(&endpoints); err != nil {
 		recordsErrorsGauge.Gauge.Inc()
 		log.Debugf("Failed to decode response body: %s", err.Error())
 		return nil, err
@@ -200,7 +280,7 @@ func (p WebhookProvider) Records(ctx context.Context) ([]*endpoint.Endpoint, err
 }
 
 // ApplyChanges will make a POST to remoteServerURL/records with the changes
-func (p WebhookProvider) ApplyChanges(_ context.Context, changes *plan.Changes) error {
+func (p WebhookProvider) ApplyChanges(ctx context.Context, changes *plan.Changes) error {
 	applyChangesRequestsGauge.Gauge.Inc()
 	u := p.remoteServerURL.JoinPath(webhookapi.UrlRecords).String()
 
@@ -211,7 +291,7 @@ func (p WebhookProvider) ApplyChanges(_ context.Context, changes *plan.Changes)
 		return err
 	}
 
-	req, err := http.NewRequest(http.MethodPost, u, b)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u, b)
 	if err != nil {
 		applyChangesErrorsGauge.Gauge.Inc()
 		log.Debugf("Failed to create request: %s", err.Error())
@@ -289,7 +369,7 @@ func (p WebhookProvider) AdjustEndpoints(e []*endpoint.Endpoint) ([]*endpoint.En
 		return nil, err
 	}
 
-	if err := json.NewDecoder(resp.Body).Decode(&endpoints); err != nil {
+	if err := json.NewDecoder(httpbody.LimitReader(resp.Body, httpbody.DefaultMaxBytes)).Decode(&endpoints); err != nil {
 		adjustEndpointsErrorsGauge.Gauge.Inc()
 		log.Debugf("Failed to decode response body: %s", err.Error())
 		return nil, err
@@ -298,6 +378,11 @@ func (p WebhookProvider) AdjustEndpoints(e []*endpoint.Endpoint) ([]*endpoint.En
 	return endpoints, nil
 }
 
+// Capabilities returns the capabilities reported by the webhook when it was negotiated.
+func (p WebhookProvider) Capabilities() provider.Capabilities {
+	return p.capabilities
+}
+
 // GetDomainFilter make calls to get the serialized version of the domain filter
 func (p WebhookProvider) GetDomainFilter() endpoint.DomainFilterInterface {
 	return p.DomainFilter
@@ -35,6 +35,50 @@ import (
 	webhookapi "sigs.k8s.io/external-dns/provider/webhook/api"
 )
 
+// withoutCapabilities returns the handler of a webhook which doesn't report its capabilities.
+func withoutCapabilities(handler http.HandlerFunc) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == webhookapi.UrlCapabilities {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		handler(w, r)
+	})
+}
+
+func TestCapabilities(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(webhookapi.ContentTypeHeader, webhookapi.MediaTypeFormatAndVersion)
+		switch r.URL.Path {
+		case "/":
+			w.Write([]byte(`{}`))
+		case webhookapi.UrlCapabilities:
+			w.Write([]byte(`{"recordTypes":["A","TXT"],"maxTargets":1,"splitTXT":true,"properties":[{"name":"webhook/proxied","type":"bool"}]}`))
+		}
+	}))
+	defer svr.Close()
+
+	p, err := NewWebhookProvider(svr.URL)
+	require.NoError(t, err)
+	assert.Equal(t, provider.Capabilities{
+		RecordTypes: []string{endpoint.RecordTypeA, endpoint.RecordTypeTXT},
+		MaxTargets:  1,
+		SplitTXT:    true,
+		Properties:  []provider.PropertySchema{{Name: "webhook/proxied", Type: provider.PropertyTypeBool}},
+	}, provider.GetCapabilities(p))
+
+	// the webhooks which don't report their capabilities apply any record
+	svr = httptest.NewServer(withoutCapabilities(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(webhookapi.ContentTypeHeader, webhookapi.MediaTypeFormatAndVersion)
+		w.Write([]byte(`{}`))
+	}))
+	defer svr.Close()
+
+	p, err = NewWebhookProvider(svr.URL)
+	require.NoError(t, err)
+	assert.Equal(t, provider.Capabilities{}, provider.GetCapabilities(p))
+}
+
 func TestNewWebhookProvider_InvalidURL(t *testing.T) {
 	_, err := NewWebhookProvider("://invalid-url")
 	require.Error(t, err)
@@ -46,7 +90,7 @@ func TestNewWebhookProvider_HTTPRequestFailure(t *testing.T) {
 }
 
 func TestNewWebhookProvider_InvalidResponseBody(t *testing.T) {
-	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	svr := httptest.NewServer(withoutCapabilities(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set(webhookapi.ContentTypeHeader, webhookapi.MediaTypeFormatAndVersion)
 		w.WriteHeader(http.StatusOK)
 		w.Write([]byte("invalid-json")) // Invalid JSON
@@ -59,7 +103,7 @@ func TestNewWebhookProvider_InvalidResponseBody(t *testing.T) {
 }
 
 func TestNewWebhookProvider_Non2XXStatusCode(t *testing.T) {
-	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	svr := httptest.NewServer(withoutCapabilities(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusBadRequest)
 	}))
 	defer svr.Close()
@@ -70,7 +114,7 @@ func TestNewWebhookProvider_Non2XXStatusCode(t *testing.T) {
 }
 
 func TestNewWebhookProvider_WrongContentTypeHeader(t *testing.T) {
-	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	svr := httptest.NewServer(withoutCapabilities(func(w http.ResponseWriter, r *http.Request) {
 		if r.URL.Path == "/" {
 			w.Header().Set(webhookapi.ContentTypeHeader, webhookapi.MediaTypeFormatAndVersion+"wrong")
 			_, _ = w.Write([]byte(`{}`))
@@ -85,7 +129,7 @@ func TestNewWebhookProvider_WrongContentTypeHeader(t *testing.T) {
 }
 
 func TestInvalidDomainFilter(t *testing.T) {
-	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	svr := httptest.NewServer(withoutCapabilities(func(w http.ResponseWriter, r *http.Request) {
 		if r.URL.Path == "/" {
 			w.Header().Set(webhookapi.ContentTypeHeader, webhookapi.MediaTypeFormatAndVersion)
 			w.WriteHeader(http.StatusOK)
@@ -104,7 +148,7 @@ func TestInvalidDomainFilter(t *testing.T) {
 func TestValidDomainfilter(t *testing.T) {
 	// initialize domain filter
 	domainFilter := endpoint.NewDomainFilter([]string{"example.com"})
-	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	svr := httptest.NewServer(withoutCapabilities(func(w http.ResponseWriter, r *http.Request) {
 		if r.URL.Path == "/" {
 			w.Header().Set(webhookapi.ContentTypeHeader, webhookapi.MediaTypeFormatAndVersion)
 			json.NewEncoder(w).Encode(domainFilter)
@@ -119,7 +163,7 @@ func TestValidDomainfilter(t *testing.T) {
 }
 
 func TestRecords(t *testing.T) {
-	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	svr := httptest.NewServer(withoutCapabilities(func(w http.ResponseWriter, r *http.Request) {
 		if r.URL.Path == "/" {
 			w.Header().Set(webhookapi.ContentTypeHeader, webhookapi.MediaTypeFormatAndVersion)
 			w.Write([]byte(`{}`))
@@ -142,8 +186,41 @@ func TestRecords(t *testing.T) {
 	}}, endpoints)
 }
 
+func TestFallbackURLs(t *testing.T) {
+	var paths []string
+	fallback := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.Method+" "+r.URL.Path)
+		switch r.URL.Path {
+		case "/webhook":
+			w.Header().Set(webhookapi.ContentTypeHeader, webhookapi.MediaTypeFormatAndVersion)
+			w.Write([]byte(`{}`))
+		case "/webhook/records":
+			if r.Method == http.MethodGet {
+				w.Write([]byte(`[{"dnsName" : "test.example.com"}]`))
+				return
+			}
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer fallback.Close()
+	primary := httptest.NewServer(http.NotFoundHandler())
+	primary.Close()
+
+	_, err := NewWebhookProvider(primary.URL, WithFallbackURLs("localhost:8888"))
+	require.Error(t, err)
+
+	// the webhook is called at the fallback URL while the primary one is unreachable
+	p, err := NewWebhookProvider(primary.URL, WithFallbackURLs(fallback.URL+"/webhook"))
+	require.NoError(t, err)
+	endpoints, err := p.Records(t.Context())
+	require.NoError(t, err)
+	require.Equal(t, []*endpoint.Endpoint{{DNSName: "test.example.com"}}, endpoints)
+	require.NoError(t, p.ApplyChanges(t.Context(), &plan.Changes{}))
+	assert.Equal(t, []string{"GET /webhook", "GET /webhook/capabilities", "GET /webhook/records", "POST /webhook/records"}, paths)
+}
+
 func TestRecordsWithErrors(t *testing.T) {
-	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	svr := httptest.NewServer(withoutCapabilities(func(w http.ResponseWriter, r *http.Request) {
 		if r.URL.Path == "/" {
 			w.Header().Set(webhookapi.ContentTypeHeader, webhookapi.MediaTypeFormatAndVersion)
 			w.Write([]byte(`{}`))
@@ -167,7 +244,7 @@ func TestRecords_HTTPRequestErrorMissingHost0(t *testing.T) {
 		client:          &http.Client{},
 	}
 
-	_, err := wpr.Records(nil)
+	_, err := wpr.Records(t.Context())
 	require.Error(t, err)
 	require.Contains(t, err.Error(), "invalid URL escape")
 }
@@ -178,13 +255,13 @@ func TestRecords_HTTPRequestErrorMissingHost(t *testing.T) {
 		client:          &http.Client{},
 	}
 
-	_, err := wpr.Records(nil)
+	_, err := wpr.Records(t.Context())
 	require.Error(t, err)
 	require.Contains(t, err.Error(), "unsupported protocol scheme")
 }
 
 func TestRecords_DecodeError(t *testing.T) {
-	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	svr := httptest.NewServer(withoutCapabilities(func(w http.ResponseWriter, r *http.Request) {
 		if r.URL.Path == webhookapi.UrlRecords {
 			w.WriteHeader(http.StatusOK)
 			_, _ = w.Write([]byte("invalid-json")) // Simulate invalid JSON response
@@ -205,7 +282,7 @@ func TestRecords_DecodeError(t *testing.T) {
 }
 
 func TestRecords_NonOKStatusCode(t *testing.T) {
-	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	svr := httptest.NewServer(withoutCapabilities(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusNetworkAuthenticationRequired)
 		return
 	}))
@@ -218,14 +295,14 @@ func TestRecords_NonOKStatusCode(t *testing.T) {
 		client:          &http.Client{},
 	}
 
-	_, err := p.Records(nil)
+	_, err := p.Records(t.Context())
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "failed to get records with code 511")
 }
 
 func TestApplyChanges(t *testing.T) {
 	successfulApplyChanges := true
-	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	svr := httptest.NewServer(withoutCapabilities(func(w http.ResponseWriter, r *http.Request) {
 		if r.URL.Path == "/" {
 			w.Header().Set(webhookapi.ContentTypeHeader, webhookapi.MediaTypeFormatAndVersion)
 			w.Write([]byte(`{}`))
@@ -275,7 +352,7 @@ func TestApplyChanges_GetFailed(t *testing.T) {
 }
 
 func TestApplyChanges_StatusCodeError(t *testing.T) {
-	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	svr := httptest.NewServer(withoutCapabilities(func(w http.ResponseWriter, r *http.Request) {
 		if r.URL.Path == "/" {
 			w.Header().Set(webhookapi.ContentTypeHeader, webhookapi.MediaTypeFormatAndVersion)
 			w.Write([]byte(`{}`))
@@ -296,7 +373,7 @@ func TestApplyChanges_StatusCodeError(t *testing.T) {
 }
 
 func TestAdjustEndpoints(t *testing.T) {
-	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	svr := httptest.NewServer(withoutCapabilities(func(w http.ResponseWriter, r *http.Request) {
 		if r.URL.Path == "/" {
 			w.Header().Set(webhookapi.ContentTypeHeader, webhookapi.MediaTypeFormatAndVersion)
 			w.Write([]byte(`{}`))
@@ -348,7 +425,7 @@ func TestAdjustEndpoints(t *testing.T) {
 }
 
 func TestAdjustendpointsWithError(t *testing.T) {
-	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	svr := httptest.NewServer(withoutCapabilities(func(w http.ResponseWriter, r *http.Request) {
 		if r.URL.Path == "/" {
 			w.Header().Set(webhookapi.ContentTypeHeader, webhookapi.MediaTypeFormatAndVersion)
 			w.Write([]byte(`{}`))
@@ -378,7 +455,7 @@ func TestAdjustendpointsWithError(t *testing.T) {
 
 // test apply changes with an endpoint with a provider specific property
 func TestApplyChangesWithProviderSpecificProperty(t *testing.T) {
-	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	svr := httptest.NewServer(withoutCapabilities(func(w http.ResponseWriter, r *http.Request) {
 		if r.URL.Path == "/" {
 			w.Header().Set(webhookapi.ContentTypeHeader, webhookapi.MediaTypeFormatAndVersion)
 			w.Write([]byte(`{}`))
@@ -449,7 +526,7 @@ func TestAdjustEndpoints_HTTPRequestErrorMissingHost(t *testing.T) {
 }
 
 func TestAdjustEndpoints_NonOKStatusCode(t *testing.T) {
-	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	svr := httptest.NewServer(withoutCapabilities(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusNetworkAuthenticationRequired)
 		return
 	}))
@@ -477,7 +554,7 @@ func TestAdjustEndpoints_NonOKStatusCode(t *testing.T) {
 }
 
 func TestAdjustEndpoints_DecodeError(t *testing.T) {
-	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	svr := httptest.NewServer(withoutCapabilities(func(w http.ResponseWriter, r *http.Request) {
 		if r.URL.Path == webhookapi.UrlAdjustEndpoints {
 			w.Header().Set(webhookapi.ContentTypeHeader, webhookapi.MediaTypeFormatAndVersion)
 			w.WriteHeader(http.StatusOK)
@@ -501,7 +578,7 @@ func TestAdjustEndpoints_DecodeError(t *testing.T) {
 }
 
 func TestRequestWithRetry_Success(t *testing.T) {
-	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	server := httptest.NewServer(withoutCapabilities(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusOK)
 		io.WriteString(w, "ok")
 	}))
@@ -518,7 +595,7 @@ func TestRequestWithRetry_Success(t *testing.T) {
 }
 
 func TestRequestWithRetry_NonRetriableStatus(t *testing.T) {
-	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	server := httptest.NewServer(withoutCapabilities(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusBadRequest)
 	}))
 	defer server.Close()
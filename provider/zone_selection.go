@@ -0,0 +1,70 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+// ZoneSelection chooses the zones of a record when both public and private zones match its name,
+// e.g. the public and private hosted zones of a split-horizon domain.
+type ZoneSelection string
+
+const (
+	// ZoneSelectionBoth writes the record to the most suitable public zone and to all the
+	// matching private zones.
+	ZoneSelectionBoth ZoneSelection = "both"
+	// ZoneSelectionPreferPrivate writes the record to the matching private zones, or to the most
+	// suitable public zone when no private zone matches.
+	ZoneSelectionPreferPrivate ZoneSelection = "prefer-private"
+	// ZoneSelectionPreferPublic writes the record to the most suitable public zone, or to the
+	// matching private zones when no public zone matches.
+	ZoneSelectionPreferPublic ZoneSelection = "prefer-public"
+)
+
+// ZoneSelections are the valid zone selections.
+var ZoneSelections = []string{string(ZoneSelectionBoth), string(ZoneSelectionPreferPrivate), string(ZoneSelectionPreferPublic)}
+
+// ZoneSelectionFor returns the zone selection of the endpoint set by its zone-selection label, or
+// the default one.
+func ZoneSelectionFor(ep *endpoint.Endpoint, defaultSelection ZoneSelection) ZoneSelection {
+	value, ok := ep.Labels[endpoint.ZoneSelectionLabelKey]
+	if !ok {
+		return defaultSelection
+	}
+	switch s := ZoneSelection(value); s {
+	case ZoneSelectionBoth, ZoneSelectionPreferPrivate, ZoneSelectionPreferPublic:
+		return s
+	}
+	log.Warnf("Ignoring the invalid zone selection %q of the record %s, expected one of %v", value, ep.DNSName, ZoneSelections)
+	return defaultSelection
+}
+
+// Select returns whether the matching public and private zones are used, given whether a public
+// zone and a private zone match the name of a record.
+func (s ZoneSelection) Select(publicMatch, privateMatch bool) (usePublic, usePrivate bool) {
+	switch s {
+	case ZoneSelectionPreferPrivate:
+		return publicMatch && !privateMatch, privateMatch
+	case ZoneSelectionPreferPublic:
+		return publicMatch, privateMatch && !publicMatch
+	default:
+		return publicMatch, privateMatch
+	}
+}
@@ -0,0 +1,56 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+func TestZoneSelectionSelect(t *testing.T) {
+	for _, tc := range []struct {
+		selection                       ZoneSelection
+		publicMatch, privateMatch       bool
+		expectedPublic, expectedPrivate bool
+	}{
+		{ZoneSelectionBoth, true, true, true, true},
+		{ZoneSelectionBoth, true, false, true, false},
+		{ZoneSelectionPreferPrivate, true, true, false, true},
+		{ZoneSelectionPreferPrivate, true, false, true, false},
+		{ZoneSelectionPreferPublic, true, true, true, false},
+		{ZoneSelectionPreferPublic, false, true, false, true},
+		{"", true, true, true, true},
+	} {
+		usePublic, usePrivate := tc.selection.Select(tc.publicMatch, tc.privateMatch)
+		assert.Equal(t, tc.expectedPublic, usePublic, "%s public", tc.selection)
+		assert.Equal(t, tc.expectedPrivate, usePrivate, "%s private", tc.selection)
+	}
+}
+
+func TestZoneSelectionFor(t *testing.T) {
+	ep := endpoint.NewEndpoint("foo.example.org", endpoint.RecordTypeA, "1.2.3.4")
+	assert.Equal(t, ZoneSelectionBoth, ZoneSelectionFor(ep, ZoneSelectionBoth))
+
+	ep.WithLabel(endpoint.ZoneSelectionLabelKey, "prefer-private")
+	assert.Equal(t, ZoneSelectionPreferPrivate, ZoneSelectionFor(ep, ZoneSelectionBoth))
+
+	ep.WithLabel(endpoint.ZoneSelectionLabelKey, "private-only")
+	assert.Equal(t, ZoneSelectionPreferPublic, ZoneSelectionFor(ep, ZoneSelectionPreferPublic))
+}
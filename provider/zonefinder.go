@@ -21,6 +21,8 @@ import (
 
 	log "github.com/sirupsen/logrus"
 	"golang.org/x/net/idna"
+
+	"sigs.k8s.io/external-dns/endpoint"
 )
 
 type ZoneIDName map[string]string
@@ -67,3 +69,24 @@ func (z ZoneIDName) FindZone(hostname string) (suitableZoneID, suitableZoneName
 	}
 	return
 }
+
+// FindZoneForEndpoint returns the zone the endpoint is pinned to by its zone-id label, e.g.
+// when overlapping zones match its name, or the most suitable zone for its name otherwise.
+// It returns no zone when the endpoint is pinned to an unknown zone, or to a zone which
+// doesn't contain its name, rather than writing the record to another zone.
+func (z ZoneIDName) FindZoneForEndpoint(ep *endpoint.Endpoint) (suitableZoneID, suitableZoneName string) {
+	zoneID := ep.Labels[endpoint.ZoneIDLabelKey]
+	if zoneID == "" {
+		return z.FindZone(ep.DNSName)
+	}
+	zoneName, ok := z[zoneID]
+	if !ok {
+		log.Warnf("Skipping record %s pinned to the zone %s, which is not managed", ep.DNSName, zoneID)
+		return "", ""
+	}
+	if id, _ := (ZoneIDName{zoneID: zoneName}).FindZone(ep.DNSName); id == "" {
+		log.Warnf("Skipping record %s pinned to the zone %s, which doesn't contain its name", ep.DNSName, zoneID)
+		return "", ""
+	}
+	return zoneID, zoneName
+}
@@ -22,6 +22,7 @@ import (
 	log "github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 
+	"sigs.k8s.io/external-dns/endpoint"
 	"sigs.k8s.io/external-dns/internal/testutils"
 )
 
@@ -82,3 +83,33 @@ func TestZoneIDName(t *testing.T) {
 
 	testutils.TestHelperLogContains("Failed to convert label '???' of hostname '???' to its Unicode form: idna: disallowed rune U+003F", hook, t)
 }
+
+func TestZoneIDNameFindZoneForEndpoint(t *testing.T) {
+	z := ZoneIDName{}
+	z.Add("public", "example.org")
+	z.Add("internal", "internal.example.org")
+
+	// the zone with the longest name is found by default
+	zoneID, zoneName := z.FindZoneForEndpoint(endpoint.NewEndpoint("web.internal.example.org", endpoint.RecordTypeA, "1.2.3.4"))
+	assert.Equal(t, "internal", zoneID)
+	assert.Equal(t, "internal.example.org", zoneName)
+
+	// the pinned zone overrides it
+	zoneID, zoneName = z.FindZoneForEndpoint(endpoint.NewEndpoint("web.internal.example.org", endpoint.RecordTypeA, "1.2.3.4").
+		WithLabel(endpoint.ZoneIDLabelKey, "public"))
+	assert.Equal(t, "public", zoneID)
+	assert.Equal(t, "example.org", zoneName)
+
+	hook := testutils.LogsUnderTestWithLogLevel(log.WarnLevel, t)
+
+	// an unknown zone, or a zone not containing the name, is never replaced by another
+	zoneID, _ = z.FindZoneForEndpoint(endpoint.NewEndpoint("web.example.org", endpoint.RecordTypeA, "1.2.3.4").
+		WithLabel(endpoint.ZoneIDLabelKey, "internal"))
+	assert.Empty(t, zoneID)
+	testutils.TestHelperLogContains("Skipping record web.example.org pinned to the zone internal, which doesn't contain its name", hook, t)
+
+	zoneID, _ = z.FindZoneForEndpoint(endpoint.NewEndpoint("web.example.org", endpoint.RecordTypeA, "1.2.3.4").
+		WithLabel(endpoint.ZoneIDLabelKey, "unknown"))
+	assert.Empty(t, zoneID)
+	testutils.TestHelperLogContains("Skipping record web.example.org pinned to the zone unknown, which is not managed", hook, t)
+}
@@ -0,0 +1,41 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package registry
+
+import (
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+// txtCipher returns the cipher of the labels of the TXT records, the one of the options or the
+// AES-GCM one of the AES key, 32 bytes long in plain text or base64-encoded, along with the key.
+// Both are nil without AES key nor cipher.
+func txtCipher(aesKey []byte, o options) (endpoint.TextCipher, []byte, error) {
+	if len(aesKey) == 0 {
+		aesKey = nil
+	} else {
+		var err error
+		if aesKey, err = endpoint.DecodeAESKey(aesKey); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if o.txtCipher != nil || aesKey == nil {
+		return o.txtCipher, aesKey, nil
+	}
+	c, err := endpoint.NewAESCipher(aesKey)
+	return c, aesKey, err
+}
@@ -18,7 +18,6 @@ package registry
 
 import (
 	"context"
-	b64 "encoding/base64"
 	"errors"
 	"fmt"
 	"strings"
@@ -28,16 +27,33 @@ import (
 	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
 	dynamodbtypes "github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/prometheus/client_golang/prometheus"
 	log "github.com/sirupsen/logrus"
 	"k8s.io/apimachinery/pkg/util/sets"
 
 	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/pkg/metrics"
 	"sigs.k8s.io/external-dns/plan"
 	"sigs.k8s.io/external-dns/provider"
 )
 
+var dynamodbConsumedCapacityUnitsTotal = metrics.NewCounterVecWithOpts(
+	prometheus.CounterOpts{
+		Namespace: "external_dns",
+		Subsystem: "registry",
+		Name:      "dynamodb_consumed_capacity_units_total",
+		Help:      "Number of capacity units consumed by the DynamoDB registry.",
+	},
+	[]string{"operation"},
+)
+
+func init() {
+	metrics.RegisterMetric.MustRegister(dynamodbConsumedCapacityUnitsTotal)
+}
+
 // DynamoDBAPI is the subset of the AWS DynamoDB API that we actually use.  Add methods as required. Signatures must match exactly.
 type DynamoDBAPI interface {
+	CreateTable(context.Context, *dynamodb.CreateTableInput, ...func(*dynamodb.Options)) (*dynamodb.CreateTableOutput, error)
 	DescribeTable(context.Context, *dynamodb.DescribeTableInput, ...func(*dynamodb.Options)) (*dynamodb.DescribeTableOutput, error)
 	Scan(context.Context, *dynamodb.ScanInput, ...func(*dynamodb.Options)) (*dynamodb.ScanOutput, error)
 	BatchExecuteStatement(context.Context, *dynamodb.BatchExecuteStatementInput, ...func(*dynamodb.Options)) (*dynamodb.BatchExecuteStatementOutput, error)
@@ -51,12 +67,17 @@ type DynamoDBRegistry struct {
 	dynamodbAPI DynamoDBAPI
 	table       string
 
+	// create the table, with these tags, when it doesn't exist
+	createTable bool
+	tableTags   map[string]string
+
 	// For migration from TXT registry
 	mapper              nameMapper
 	wildcardReplacement string
 	managedRecordTypes  []string
 	excludeRecordTypes  []string
 	txtEncryptAESKey    []byte
+	txtCipher           endpoint.TextCipher
 
 	// cache the dynamodb records owned by us.
 	labels         map[endpoint.EndpointKey]endpoint.Labels
@@ -73,8 +94,13 @@ const dynamodbAttributeMigrate = "dynamodb/needs-migration"
 // DynamoDB allows a maximum batch size of 25 items.
 var dynamodbMaxBatchSize uint8 = 25
 
-// NewDynamoDBRegistry returns a new DynamoDBRegistry object.
-func NewDynamoDBRegistry(provider provider.Provider, ownerID string, dynamodbAPI DynamoDBAPI, table string, txtPrefix, txtSuffix, txtWildcardReplacement string, managedRecordTypes, excludeRecordTypes []string, txtEncryptAESKey []byte, cacheInterval time.Duration) (*DynamoDBRegistry, error) {
+// dynamodbTableCreationTimeout is how long to wait for a created table to become active.
+var dynamodbTableCreationTimeout = 5 * time.Minute
+
+// NewDynamoDBRegistry returns a new DynamoDBRegistry object. When createTable is true,
+// the table is created with the given tags if it doesn't exist. The TXT records migrated to the
+// table are decrypted with the AES key, unless the options set another cipher.
+func NewDynamoDBRegistry(provider provider.Provider, ownerID string, dynamodbAPI DynamoDBAPI, table string, txtPrefix, txtSuffix, txtWildcardReplacement string, managedRecordTypes, excludeRecordTypes []string, txtEncryptAESKey []byte, cacheInterval time.Duration, createTable bool, tableTags map[string]string, opts ...Option) (*DynamoDBRegistry, error) {
 	if ownerID == "" {
 		return nil, errors.New("owner id cannot be empty")
 	}
@@ -82,13 +108,9 @@ func NewDynamoDBRegistry(provider provider.Provider, ownerID string, dynamodbAPI
 		return nil, errors.New("table cannot be empty")
 	}
 
-	if len(txtEncryptAESKey) == 0 {
-		txtEncryptAESKey = nil
-	} else if len(txtEncryptAESKey) != 32 {
-		var err error
-		if txtEncryptAESKey, err = b64.StdEncoding.DecodeString(string(txtEncryptAESKey)); err != nil || len(txtEncryptAESKey) != 32 {
-			return nil, errors.New("the AES Encryption key must be 32 bytes long, in either plain text or base64-encoded format")
-		}
+	textCipher, txtEncryptAESKey, err := txtCipher(txtEncryptAESKey, newOptions(opts))
+	if err != nil {
+		return nil, err
 	}
 	if len(txtPrefix) > 0 && len(txtSuffix) > 0 {
 		return nil, errors.New("txt-prefix and txt-suffix are mutually exclusive")
@@ -101,11 +123,14 @@ func NewDynamoDBRegistry(provider provider.Provider, ownerID string, dynamodbAPI
 		ownerID:             ownerID,
 		dynamodbAPI:         dynamodbAPI,
 		table:               table,
+		createTable:         createTable,
+		tableTags:           tableTags,
 		mapper:              mapper,
 		wildcardReplacement: txtWildcardReplacement,
 		managedRecordTypes:  managedRecordTypes,
 		excludeRecordTypes:  excludeRecordTypes,
 		txtEncryptAESKey:    txtEncryptAESKey,
+		txtCipher:           textCipher,
 		cacheInterval:       cacheInterval,
 	}, nil
 }
@@ -152,7 +177,7 @@ func (im *DynamoDBRegistry) Records(ctx context.Context) ([]*endpoint.Endpoint,
 
 			if record.RecordType == endpoint.RecordTypeTXT {
 				// We simply assume that TXT records for the TXT registry will always have only one target.
-				if labels, err := endpoint.NewLabelsFromString(record.Targets[0], im.txtEncryptAESKey); err == nil {
+				if labels, err := endpoint.NewLabelsFromStringWithCipher(record.Targets[0], im.txtCipher); err == nil {
 					endpointName, recordType := im.mapper.toEndpointName(record.DNSName)
 					key := endpoint.EndpointKey{
 						DNSName:       endpointName,
@@ -317,6 +342,20 @@ func (im *DynamoDBRegistry) ApplyChanges(ctx context.Context, changes *plan.Chan
 			}
 			context = fmt.Sprintf("inserting dynamodb record %q", record)
 		} else {
+			if response.Error.Code == dynamodbtypes.BatchStatementErrorCodeEnumConditionalCheckFailed {
+				// The record is owned by a different owner, or was not replicated yet to this region
+				// of a global table. Skip it until the labels are read again.
+				key, err := fromDynamoKey(request.Parameters[1])
+				if err != nil {
+					return err
+				}
+				log.Infof("Skipping update of %v because the dynamodb record is not owned by us", key)
+				filteredChanges.UpdateOld = removeEndpointWithKey(filteredChanges.UpdateOld, key)
+				filteredChanges.UpdateNew = removeEndpointWithKey(filteredChanges.UpdateNew, key)
+				im.recordsCache = nil
+				im.labels = nil
+				return nil
+			}
 			var record string
 			if err := attributevalue.Unmarshal(request.Parameters[1], &record); err != nil {
 				return fmt.Errorf("inserting dynamodb record: %w", err)
@@ -357,10 +396,52 @@ func (im *DynamoDBRegistry) ApplyChanges(ctx context.Context, changes *plan.Chan
 		if err != nil {
 			return fmt.Errorf("deleting dynamodb record: %w", err)
 		}
+		if response.Error.Code == dynamodbtypes.BatchStatementErrorCodeEnumConditionalCheckFailed {
+			// The record was already deleted, possibly in another region of a global table.
+			log.Debugf("Dynamodb record %q was already deleted", record)
+			return nil
+		}
 		return fmt.Errorf("deleting dynamodb record %q: %s: %s", record, response.Error.Code, *response.Error.Message)
 	})
 }
 
+// insertLabels inserts the labels of the endpoints missing from the table, e.g. to backfill the
+// ownership read from another registry. The endpoints already owned by another owner are skipped.
+func (im *DynamoDBRegistry) insertLabels(ctx context.Context, endpoints []*endpoint.Endpoint) error {
+	if im.labels == nil {
+		if err := im.readLabels(ctx); err != nil {
+			return err
+		}
+	}
+
+	var statements []dynamodbtypes.BatchStatementRequest
+	for _, ep := range endpoints {
+		key := ep.Key()
+		if _, ok := im.labels[key]; ok {
+			continue
+		}
+		statements = im.appendInsert(statements, key, ep.Labels)
+		im.labels[key] = ep.Labels
+		im.orphanedLabels.Delete(key)
+	}
+	if len(statements) == 0 {
+		return nil
+	}
+	im.recordsCache = nil
+	return im.executeStatements(ctx, statements, func(request dynamodbtypes.BatchStatementRequest, response dynamodbtypes.BatchStatementResponse) error {
+		key, err := fromDynamoKey(request.Parameters[0])
+		if err != nil {
+			return err
+		}
+		delete(im.labels, key)
+		if response.Error.Code == dynamodbtypes.BatchStatementErrorCodeEnumDuplicateItem {
+			log.Infof("Skipping the backfill of %v because the dynamodb record is not owned by us", key)
+			return nil
+		}
+		return fmt.Errorf("inserting dynamodb record %v: %s: %s", key, response.Error.Code, *response.Error.Message)
+	})
+}
+
 // AdjustEndpoints modifies the endpoints as needed by the specific provider.
 func (im *DynamoDBRegistry) AdjustEndpoints(endpoints []*endpoint.Endpoint) ([]*endpoint.Endpoint, error) {
 	return im.provider.AdjustEndpoints(endpoints)
@@ -370,6 +451,10 @@ func (im *DynamoDBRegistry) readLabels(ctx context.Context) error {
 	table, err := im.dynamodbAPI.DescribeTable(ctx, &dynamodb.DescribeTableInput{
 		TableName: aws.String(im.table),
 	})
+	var notFound *dynamodbtypes.ResourceNotFoundException
+	if im.createTable && errors.As(err, &notFound) {
+		table, err = im.createDynamoDBTable(ctx)
+	}
 	if err != nil {
 		return fmt.Errorf("describing table %q: %w", im.table, err)
 	}
@@ -401,14 +486,16 @@ func (im *DynamoDBRegistry) readLabels(ctx context.Context) error {
 		ExpressionAttributeValues: map[string]dynamodbtypes.AttributeValue{
 			":ownerval": &dynamodbtypes.AttributeValueMemberS{Value: im.ownerID},
 		},
-		ProjectionExpression: aws.String("k,l"),
-		ConsistentRead:       aws.Bool(true),
+		ProjectionExpression:   aws.String("k,l"),
+		ConsistentRead:         aws.Bool(true),
+		ReturnConsumedCapacity: dynamodbtypes.ReturnConsumedCapacityTotal,
 	})
 	for scanPaginator.HasMorePages() {
 		output, err := scanPaginator.NextPage(ctx)
 		if err != nil {
 			return fmt.Errorf("scanning table %q: %w", im.table, err)
 		}
+		recordConsumedCapacity("scan", output.ConsumedCapacity)
 		for _, item := range output.Items {
 			k, err := fromDynamoKey(item["k"])
 			if err != nil {
@@ -427,6 +514,60 @@ func (im *DynamoDBRegistry) readLabels(ctx context.Context) error {
 	return nil
 }
 
+// createDynamoDBTable creates the table with on-demand billing and waits for it to be active.
+func (im *DynamoDBRegistry) createDynamoDBTable(ctx context.Context) (*dynamodb.DescribeTableOutput, error) {
+	log.Infof("Creating dynamodb table %q", im.table)
+
+	tags := make([]dynamodbtypes.Tag, 0, len(im.tableTags))
+	for k, v := range im.tableTags {
+		tags = append(tags, dynamodbtypes.Tag{Key: aws.String(k), Value: aws.String(v)})
+	}
+	input := &dynamodb.CreateTableInput{
+		TableName: aws.String(im.table),
+		AttributeDefinitions: []dynamodbtypes.AttributeDefinition{
+			{AttributeName: aws.String("k"), AttributeType: dynamodbtypes.ScalarAttributeTypeS},
+		},
+		KeySchema: []dynamodbtypes.KeySchemaElement{
+			{AttributeName: aws.String("k"), KeyType: dynamodbtypes.KeyTypeHash},
+		},
+		BillingMode: dynamodbtypes.BillingModePayPerRequest,
+	}
+	if len(tags) > 0 {
+		input.Tags = tags
+	}
+	if _, err := im.dynamodbAPI.CreateTable(ctx, input); err != nil {
+		// the table may have been created concurrently, e.g. by another replica
+		var inUse *dynamodbtypes.ResourceInUseException
+		if !errors.As(err, &inUse) {
+			return nil, fmt.Errorf("creating table %q: %w", im.table, err)
+		}
+	}
+
+	return dynamodb.NewTableExistsWaiter(im.dynamodbAPI).WaitForOutput(ctx, &dynamodb.DescribeTableInput{
+		TableName: aws.String(im.table),
+	}, dynamodbTableCreationTimeout)
+}
+
+// recordConsumedCapacity adds the capacity units consumed by an operation to the metrics.
+func recordConsumedCapacity(operation string, capacities ...*dynamodbtypes.ConsumedCapacity) {
+	for _, capacity := range capacities {
+		if capacity != nil && capacity.CapacityUnits != nil {
+			dynamodbConsumedCapacityUnitsTotal.CounterVec.WithLabelValues(operation).Add(*capacity.CapacityUnits)
+		}
+	}
+}
+
+// removeEndpointWithKey removes the endpoints with the given key.
+func removeEndpointWithKey(endpoints []*endpoint.Endpoint, key endpoint.EndpointKey) []*endpoint.Endpoint {
+	filtered := endpoints[:0]
+	for _, ep := range endpoints {
+		if ep.Key() != key {
+			filtered = append(filtered, ep)
+		}
+	}
+	return filtered
+}
+
 func fromDynamoKey(key dynamodbtypes.AttributeValue) (endpoint.EndpointKey, error) {
 	var ep string
 	if err := attributevalue.Unmarshal(key, &ep); err != nil {
@@ -495,10 +636,11 @@ func (im *DynamoDBRegistry) appendUpdate(statements []dynamodbtypes.BatchStateme
 	}
 
 	return append(statements, dynamodbtypes.BatchStatementRequest{
-		Statement: aws.String(fmt.Sprintf("UPDATE %q SET \"l\"=? WHERE \"k\"=?", im.table)),
+		Statement: aws.String(fmt.Sprintf("UPDATE %q SET \"l\"=? WHERE \"k\"=? AND \"o\"=?", im.table)),
 		Parameters: []dynamodbtypes.AttributeValue{
 			toDynamoLabels(newE),
 			toDynamoKey(key),
+			&dynamodbtypes.AttributeValueMemberS{Value: im.ownerID},
 		},
 	})
 }
@@ -525,11 +667,15 @@ func (im *DynamoDBRegistry) executeStatements(ctx context.Context, statements []
 		}
 
 		output, err := im.dynamodbAPI.BatchExecuteStatement(ctx, &dynamodb.BatchExecuteStatementInput{
-			Statements: chunk,
+			Statements:             chunk,
+			ReturnConsumedCapacity: dynamodbtypes.ReturnConsumedCapacityTotal,
 		})
 		if err != nil {
 			return err
 		}
+		for i := range output.ConsumedCapacity {
+			recordConsumedCapacity("batch_execute_statement", &output.ConsumedCapacity[i])
+		}
 
 		for i, response := range output.Responses {
 			request := chunk[i]
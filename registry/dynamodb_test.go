@@ -40,31 +40,31 @@ import (
 func TestDynamoDBRegistryNew(t *testing.T) {
 	api, p := newDynamoDBAPIStub(t, nil)
 
-	_, err := NewDynamoDBRegistry(p, "test-owner", api, "test-table", "", "", "", []string{}, []string{}, []byte(""), time.Hour)
+	_, err := NewDynamoDBRegistry(p, "test-owner", api, "test-table", "", "", "", []string{}, []string{}, []byte(""), time.Hour, false, nil)
 	require.NoError(t, err)
 
-	_, err = NewDynamoDBRegistry(p, "test-owner", api, "test-table", "testPrefix", "", "", []string{}, []string{}, []byte(""), time.Hour)
+	_, err = NewDynamoDBRegistry(p, "test-owner", api, "test-table", "testPrefix", "", "", []string{}, []string{}, []byte(""), time.Hour, false, nil)
 	require.NoError(t, err)
 
-	_, err = NewDynamoDBRegistry(p, "test-owner", api, "test-table", "", "testSuffix", "", []string{}, []string{}, []byte(""), time.Hour)
+	_, err = NewDynamoDBRegistry(p, "test-owner", api, "test-table", "", "testSuffix", "", []string{}, []string{}, []byte(""), time.Hour, false, nil)
 	require.NoError(t, err)
 
-	_, err = NewDynamoDBRegistry(p, "test-owner", api, "test-table", "", "", "testWildcard", []string{}, []string{}, []byte(""), time.Hour)
+	_, err = NewDynamoDBRegistry(p, "test-owner", api, "test-table", "", "", "testWildcard", []string{}, []string{}, []byte(""), time.Hour, false, nil)
 	require.NoError(t, err)
 
-	_, err = NewDynamoDBRegistry(p, "test-owner", api, "test-table", "", "", "testWildcard", []string{}, []string{}, []byte(";k&l)nUC/33:{?d{3)54+,AD?]SX%yh^"), time.Hour)
+	_, err = NewDynamoDBRegistry(p, "test-owner", api, "test-table", "", "", "testWildcard", []string{}, []string{}, []byte(";k&l)nUC/33:{?d{3)54+,AD?]SX%yh^"), time.Hour, false, nil)
 	require.NoError(t, err)
 
-	_, err = NewDynamoDBRegistry(p, "", api, "test-table", "", "", "", []string{}, []string{}, []byte(""), time.Hour)
+	_, err = NewDynamoDBRegistry(p, "", api, "test-table", "", "", "", []string{}, []string{}, []byte(""), time.Hour, false, nil)
 	require.EqualError(t, err, "owner id cannot be empty")
 
-	_, err = NewDynamoDBRegistry(p, "test-owner", api, "", "", "", "", []string{}, []string{}, []byte(""), time.Hour)
+	_, err = NewDynamoDBRegistry(p, "test-owner", api, "", "", "", "", []string{}, []string{}, []byte(""), time.Hour, false, nil)
 	require.EqualError(t, err, "table cannot be empty")
 
-	_, err = NewDynamoDBRegistry(p, "test-owner", api, "test-table", "", "", "", []string{}, []string{}, []byte(";k&l)nUC/33:{?d{3)54+,AD?]SX%yh^x"), time.Hour)
+	_, err = NewDynamoDBRegistry(p, "test-owner", api, "test-table", "", "", "", []string{}, []string{}, []byte(";k&l)nUC/33:{?d{3)54+,AD?]SX%yh^x"), time.Hour, false, nil)
 	require.EqualError(t, err, "the AES Encryption key must be 32 bytes long, in either plain text or base64-encoded format")
 
-	_, err = NewDynamoDBRegistry(p, "test-owner", api, "test-table", "testPrefix", "testSuffix", "", []string{}, []string{}, []byte(""), time.Hour)
+	_, err = NewDynamoDBRegistry(p, "test-owner", api, "test-table", "testPrefix", "testSuffix", "", []string{}, []string{}, []byte(""), time.Hour, false, nil)
 	require.EqualError(t, err, "txt-prefix and txt-suffix are mutually exclusive")
 }
 
@@ -97,7 +97,7 @@ func TestDynamoDBRegistryNew_EncryptionConfig(t *testing.T) {
 		},
 	}
 	for _, test := range tests {
-		actual, err := NewDynamoDBRegistry(p, "test-owner", api, "test-table", "", "", "", []string{}, []string{}, test.aesKeyRaw, time.Hour)
+		actual, err := NewDynamoDBRegistry(p, "test-owner", api, "test-table", "", "", "", []string{}, []string{}, test.aesKeyRaw, time.Hour, false, nil)
 		if test.errorExpected {
 			require.Error(t, err)
 		} else {
@@ -153,7 +153,7 @@ func TestDynamoDBRegistryRecordsBadTable(t *testing.T) {
 			api, p := newDynamoDBAPIStub(t, nil)
 			tc.setup(&api.tableDescription)
 
-			r, _ := NewDynamoDBRegistry(p, "test-owner", api, "test-table", "", "", "", []string{}, []string{}, nil, time.Hour)
+			r, _ := NewDynamoDBRegistry(p, "test-owner", api, "test-table", "", "", "", []string{}, []string{}, nil, time.Hour, false, nil)
 
 			_, err := r.Records(context.Background())
 			assert.EqualError(t, err, tc.expected)
@@ -239,7 +239,7 @@ func TestDynamoDBRegistryRecords(t *testing.T) {
 		},
 	}
 
-	r, _ := NewDynamoDBRegistry(p, "test-owner", api, "test-table", "txt.", "", "", []string{}, []string{}, nil, time.Hour)
+	r, _ := NewDynamoDBRegistry(p, "test-owner", api, "test-table", "txt.", "", "", []string{}, []string{}, nil, time.Hour, false, nil)
 	_ = p.(*wrappedProvider).Provider.ApplyChanges(context.Background(), &plan.Changes{
 		Create: []*endpoint.Endpoint{
 			endpoint.NewEndpoint("migrate.test-zone.example.org", endpoint.RecordTypeA, "3.3.3.3").WithSetIdentifier("set-3"),
@@ -1079,7 +1079,7 @@ func TestDynamoDBRegistryApplyChanges(t *testing.T) {
 
 			ctx := context.Background()
 
-			r, _ := NewDynamoDBRegistry(p, "test-owner", api, "test-table", "txt.", "", "", []string{}, []string{}, nil, time.Hour)
+			r, _ := NewDynamoDBRegistry(p, "test-owner", api, "test-table", "txt.", "", "", []string{}, []string{}, nil, time.Hour, false, nil)
 			_, err := r.Records(ctx)
 			require.NoError(t, err)
 
@@ -1110,11 +1110,85 @@ func TestDynamoDBRegistryApplyChanges(t *testing.T) {
 	}
 }
 
+func TestDynamoDBRegistryCreateTable(t *testing.T) {
+	api, p := newDynamoDBAPIStub(t, nil)
+	api.tableMissing = true
+
+	r, err := NewDynamoDBRegistry(p, "test-owner", api, "test-table", "", "", "", []string{}, []string{}, nil, time.Hour, false, nil)
+	require.NoError(t, err)
+	_, err = r.Records(context.Background())
+	require.Error(t, err)
+	assert.Nil(t, api.createTableInput)
+
+	r, err = NewDynamoDBRegistry(p, "test-owner", api, "test-table", "", "", "", []string{}, []string{}, nil, time.Hour, true, map[string]string{"team": "dns"})
+	require.NoError(t, err)
+	_, err = r.Records(context.Background())
+	require.NoError(t, err)
+
+	require.NotNil(t, api.createTableInput)
+	assert.Equal(t, dynamodbtypes.BillingModePayPerRequest, api.createTableInput.BillingMode)
+	assert.Equal(t, []dynamodbtypes.KeySchemaElement{{AttributeName: aws.String("k"), KeyType: dynamodbtypes.KeyTypeHash}}, api.createTableInput.KeySchema)
+	assert.Equal(t, []dynamodbtypes.Tag{{Key: aws.String("team"), Value: aws.String("dns")}}, api.createTableInput.Tags)
+}
+
+func TestDynamoDBRegistryApplyChangesConditionalCheckFailed(t *testing.T) {
+	stubConfig := &DynamoDBStubConfig{
+		ExpectUpdateError: map[string]dynamodbtypes.BatchStatementErrorCodeEnum{
+			"bar.test-zone.example.org#CNAME#": dynamodbtypes.BatchStatementErrorCodeEnumConditionalCheckFailed,
+		},
+		ExpectDelete: sets.New("quux.test-zone.example.org#A#set-2"),
+		ExpectDeleteError: map[string]dynamodbtypes.BatchStatementErrorCodeEnum{
+			"baz.test-zone.example.org#A#set-1": dynamodbtypes.BatchStatementErrorCodeEnumConditionalCheckFailed,
+		},
+	}
+	api, p := newDynamoDBAPIStub(t, stubConfig)
+	ctx := context.Background()
+
+	r, err := NewDynamoDBRegistry(p, "test-owner", api, "test-table", "", "", "", []string{}, []string{}, nil, time.Hour, false, nil)
+	require.NoError(t, err)
+	_, err = r.Records(ctx)
+	require.NoError(t, err)
+
+	owned := map[string]string{
+		endpoint.OwnerLabelKey:    "test-owner",
+		endpoint.ResourceLabelKey: "ingress/default/my-ingress",
+	}
+	err = r.ApplyChanges(ctx, &plan.Changes{
+		UpdateOld: []*endpoint.Endpoint{
+			{DNSName: "bar.test-zone.example.org", Targets: endpoint.Targets{"my-domain.com"}, RecordType: endpoint.RecordTypeCNAME, Labels: owned},
+		},
+		UpdateNew: []*endpoint.Endpoint{
+			{DNSName: "bar.test-zone.example.org", Targets: endpoint.Targets{"new-domain.com"}, RecordType: endpoint.RecordTypeCNAME, Labels: map[string]string{
+				endpoint.OwnerLabelKey:    "test-owner",
+				endpoint.ResourceLabelKey: "ingress/default/new-ingress",
+			}},
+		},
+		Delete: []*endpoint.Endpoint{
+			{DNSName: "baz.test-zone.example.org", Targets: endpoint.Targets{"1.1.1.1"}, RecordType: endpoint.RecordTypeA, SetIdentifier: "set-1", Labels: owned},
+		},
+	})
+	require.NoError(t, err)
+	assert.Empty(t, stubConfig.ExpectUpdateError)
+	assert.Empty(t, stubConfig.ExpectDeleteError)
+	assert.Empty(t, stubConfig.ExpectDelete)
+
+	// the record that could not be updated in the table is left alone
+	records, err := p.Records(ctx)
+	require.NoError(t, err)
+	for _, record := range records {
+		if record.DNSName == "bar.test-zone.example.org" {
+			assert.Equal(t, endpoint.Targets{"my-domain.com"}, record.Targets)
+		}
+	}
+}
+
 // DynamoDBAPIStub is a minimal implementation of DynamoDBAPI, used primarily for unit testing.
 type DynamoDBStub struct {
 	t                *testing.T
 	stubConfig       *DynamoDBStubConfig
 	tableDescription dynamodbtypes.TableDescription
+	tableMissing     bool
+	createTableInput *dynamodb.CreateTableInput
 	changesApplied   bool
 }
 
@@ -1124,6 +1198,7 @@ type DynamoDBStubConfig struct {
 	ExpectUpdate      map[string]map[string]string
 	ExpectUpdateError map[string]dynamodbtypes.BatchStatementErrorCodeEnum
 	ExpectDelete      sets.Set[string]
+	ExpectDeleteError map[string]dynamodbtypes.BatchStatementErrorCodeEnum
 }
 
 type wrappedProvider struct {
@@ -1172,9 +1247,22 @@ func newDynamoDBAPIStub(t *testing.T, stubConfig *DynamoDBStubConfig) (*DynamoDB
 	}
 }
 
+func (r *DynamoDBStub) CreateTable(ctx context.Context, input *dynamodb.CreateTableInput, opts ...func(*dynamodb.Options)) (*dynamodb.CreateTableOutput, error) {
+	assert.NotNil(r.t, ctx)
+	assert.Equal(r.t, "test-table", *input.TableName, "table name")
+	assert.True(r.t, r.tableMissing, "unexpected table creation")
+	r.createTableInput = input
+	r.tableMissing = false
+	r.tableDescription.TableStatus = dynamodbtypes.TableStatusActive
+	return &dynamodb.CreateTableOutput{}, nil
+}
+
 func (r *DynamoDBStub) DescribeTable(ctx context.Context, input *dynamodb.DescribeTableInput, opts ...func(*dynamodb.Options)) (*dynamodb.DescribeTableOutput, error) {
 	assert.NotNil(r.t, ctx)
 	assert.Equal(r.t, "test-table", *input.TableName, "table name")
+	if r.tableMissing {
+		return nil, &dynamodbtypes.ResourceNotFoundException{Message: aws.String("table not found")}
+	}
 	return &dynamodb.DescribeTableOutput{
 		Table: &r.tableDescription,
 	}, nil
@@ -1235,6 +1323,16 @@ func (r *DynamoDBStub) BatchExecuteStatement(context context.Context, input *dyn
 
 			var key string
 			require.NoError(r.t, attributevalue.Unmarshal(statement.Parameters[0], &key))
+			if code, exists := r.stubConfig.ExpectDeleteError[key]; exists {
+				delete(r.stubConfig.ExpectDeleteError, key)
+				responses = append(responses, dynamodbtypes.BatchStatementResponse{
+					Error: &dynamodbtypes.BatchStatementError{
+						Code:    code,
+						Message: aws.String("testing error"),
+					},
+				})
+				break
+			}
 			assert.True(r.t, r.stubConfig.ExpectDelete.Has(key), "unexpected delete for key %q", key)
 			r.stubConfig.ExpectDelete.Delete(key)
 
@@ -1285,13 +1383,17 @@ func (r *DynamoDBStub) BatchExecuteStatement(context context.Context, input *dyn
 
 			responses = append(responses, dynamodbtypes.BatchStatementResponse{})
 
-		case "UPDATE \"test-table\" SET \"l\"=? WHERE \"k\"=?":
+		case "UPDATE \"test-table\" SET \"l\"=? WHERE \"k\"=? AND \"o\"=?":
 			assert.False(r.t, r.changesApplied, "unexpected update after provider changes")
 
 			var key string
 			assert.NoError(r.t, attributevalue.Unmarshal(statement.Parameters[1], &key))
+
+			var testOwner string
+			assert.NoError(r.t, attributevalue.Unmarshal(statement.Parameters[2], &testOwner))
+			assert.Equal(r.t, "test-owner", testOwner)
 			if code, exists := r.stubConfig.ExpectUpdateError[key]; exists {
-				delete(r.stubConfig.ExpectInsertError, key)
+				delete(r.stubConfig.ExpectUpdateError, key)
 				responses = append(responses, dynamodbtypes.BatchStatementResponse{
 					Error: &dynamodbtypes.BatchStatementError{
 						Code:    code,
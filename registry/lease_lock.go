@@ -0,0 +1,125 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package registry
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// zoneAnnotation records the zone locked by a Lease, whose name is derived from it.
+const zoneAnnotation = "external-dns.alpha.kubernetes.io/zone"
+
+// LeaseZoneLocker locks the zones with a Lease per zone. The Leases are acquired with conditional
+// writes, so that only one applier can hold a Lease, and expire when their holder doesn't release
+// them, e.g. when it crashes while applying changes.
+type LeaseZoneLocker struct {
+	client    kubernetes.Interface
+	namespace string
+	identity  string
+	duration  time.Duration
+	now       func() time.Time
+}
+
+// NewLeaseZoneLocker returns a LeaseZoneLocker creating the Leases in the namespace. The identity
+// tells the appliers apart, and the duration is how long a Lease is held at most.
+func NewLeaseZoneLocker(client kubernetes.Interface, namespace, identity string, duration time.Duration) *LeaseZoneLocker {
+	return &LeaseZoneLocker{client: client, namespace: namespace, identity: identity, duration: duration, now: time.Now}
+}
+
+// leaseName returns the name of the Lease of a zone, which is valid whatever the zone name.
+func leaseName(zone string) string {
+	sum := sha256.Sum256([]byte(zone))
+	return "external-dns-zone-" + hex.EncodeToString(sum[:8])
+}
+
+// Lock implements ZoneLocker.
+func (l *LeaseZoneLocker) Lock(ctx context.Context, zone string) (func(), error) {
+	leases := l.client.CoordinationV1().Leases(l.namespace)
+	now := metav1.NewMicroTime(l.now())
+	identity, seconds := l.identity, int32(l.duration.Seconds())
+	spec := coordinationv1.LeaseSpec{
+		HolderIdentity:       &identity,
+		LeaseDurationSeconds: &seconds,
+		AcquireTime:          &now,
+		RenewTime:            &now,
+	}
+
+	lease, err := leases.Get(ctx, leaseName(zone), metav1.GetOptions{})
+	switch {
+	case apierrors.IsNotFound(err):
+		lease = &coordinationv1.Lease{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        leaseName(zone),
+				Namespace:   l.namespace,
+				Annotations: map[string]string{zoneAnnotation: zone},
+			},
+			Spec: spec,
+		}
+		lease, err = leases.Create(ctx, lease, metav1.CreateOptions{})
+	case err != nil:
+		return nil, err
+	case l.heldByOther(lease):
+		return nil, fmt.Errorf("%w: lease %s/%s is held by %s", ErrZoneLocked, l.namespace, lease.Name, *lease.Spec.HolderIdentity)
+	default:
+		// the update fails with a conflict if another applier acquired the lease since it was read
+		lease.Spec = spec
+		lease, err = leases.Update(ctx, lease, metav1.UpdateOptions{})
+	}
+	if apierrors.IsAlreadyExists(err) || apierrors.IsConflict(err) {
+		return nil, fmt.Errorf("%w: lease %s/%s was acquired concurrently", ErrZoneLocked, l.namespace, leaseName(zone))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return func() { l.release(lease) }, nil
+}
+
+// heldByOther returns whether the lease is held by another applier and not expired.
+func (l *LeaseZoneLocker) heldByOther(lease *coordinationv1.Lease) bool {
+	spec := lease.Spec
+	if spec.HolderIdentity == nil || *spec.HolderIdentity == "" || *spec.HolderIdentity == l.identity {
+		return false
+	}
+	if spec.RenewTime == nil || spec.LeaseDurationSeconds == nil {
+		return false
+	}
+	expiry := spec.RenewTime.Add(time.Duration(*spec.LeaseDurationSeconds) * time.Second)
+	return l.now().Before(expiry)
+}
+
+// release gives the lease up, so that the other appliers don't wait for it to expire.
+func (l *LeaseZoneLocker) release(lease *coordinationv1.Lease) {
+	lease.Spec.HolderIdentity = nil
+	lease.Spec.RenewTime = nil
+	// a new context, as the lease must be released even when the changes were canceled
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if _, err := l.client.CoordinationV1().Leases(l.namespace).Update(ctx, lease, metav1.UpdateOptions{}); err != nil {
+		log.Warnf("Failed to release lease %s/%s, it expires in %s: %v", l.namespace, lease.Name, l.duration, err)
+	}
+}
@@ -0,0 +1,63 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package registry
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakeKube "k8s.io/client-go/kubernetes/fake"
+)
+
+func TestLeaseZoneLocker(t *testing.T) {
+	ctx := context.Background()
+	client := fakeKube.NewClientset()
+	now := time.Now()
+	first := NewLeaseZoneLocker(client, "kube-system", "owner/pod-1", time.Minute)
+	first.now = func() time.Time { return now }
+	second := NewLeaseZoneLocker(client, "kube-system", "owner/pod-2", time.Minute)
+	second.now = func() time.Time { return now }
+
+	unlock, err := first.Lock(ctx, "example.org")
+	require.NoError(t, err)
+	lease, err := client.CoordinationV1().Leases("kube-system").Get(ctx, leaseName("example.org"), metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "owner/pod-1", *lease.Spec.HolderIdentity)
+	assert.Equal(t, "example.org", lease.Annotations[zoneAnnotation])
+
+	// the lease is held by the first applier until it's released
+	_, err = second.Lock(ctx, "example.org")
+	require.ErrorIs(t, err, ErrZoneLocked)
+	// the other zones are not locked
+	unlockOther, err := second.Lock(ctx, "example.com")
+	require.NoError(t, err)
+	unlockOther()
+
+	unlock()
+	unlock, err = second.Lock(ctx, "example.org")
+	require.NoError(t, err)
+
+	// an expired lease is acquired by another applier
+	first.now = func() time.Time { return now.Add(2 * time.Minute) }
+	_, err = first.Lock(ctx, "example.org")
+	require.NoError(t, err)
+	unlock()
+}
@@ -0,0 +1,139 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package registry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider"
+)
+
+// ErrZoneLocked is returned when the lock of a zone is held by another applier.
+var ErrZoneLocked = errors.New("the zone is locked by another applier")
+
+// ZoneLocker acquires exclusive locks on zones, so that several appliers never write
+// conflicting changes to the same zone at once.
+type ZoneLocker interface {
+	// Lock acquires the lock of a zone, or fails with ErrZoneLocked if another applier holds it.
+	// The returned function releases the lock.
+	Lock(ctx context.Context, zone string) (func(), error)
+}
+
+// LockingRegistry is a Registry which applies the changes of each zone while holding its lock.
+// The lock of an empty zone name covers the registries that don't list their zones.
+type LockingRegistry struct {
+	Registry
+	locker ZoneLocker
+}
+
+// NewLockingRegistry returns a LockingRegistry wrapping the registry.
+func NewLockingRegistry(registry Registry, locker ZoneLocker) *LockingRegistry {
+	return &LockingRegistry{Registry: registry, locker: locker}
+}
+
+// ApplyChanges acquires the locks of the zones of the changes before applying them. No change
+// is applied when a zone is locked by another applier: the changes are planned again by the
+// next synchronization, from the records that applier wrote.
+func (r *LockingRegistry) ApplyChanges(ctx context.Context, changes *plan.Changes) error {
+	zones, err := r.changedZones(ctx, changes)
+	if err != nil {
+		return err
+	}
+	for _, zone := range zones {
+		unlock, err := r.locker.Lock(ctx, zone)
+		if errors.Is(err, ErrZoneLocked) {
+			return provider.NewSoftErrorf("not applying the changes of zone %q: %v", zone, err)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to lock zone %q: %w", zone, err)
+		}
+		defer unlock()
+	}
+	return r.Registry.ApplyChanges(ctx, changes)
+}
+
+// changedZones returns the names of the zones of the changed records, in order.
+func (r *LockingRegistry) changedZones(ctx context.Context, changes *plan.Changes) ([]string, error) {
+	names, err := ZoneNames(ctx, r.Registry)
+	if err != nil {
+		return nil, err
+	}
+	if len(names) == 0 {
+		return []string{""}, nil
+	}
+	zones := provider.ZoneIDName{}
+	for _, name := range names {
+		zones.Add(name, name)
+	}
+	var changed []string
+	for _, records := range [][]*endpoint.Endpoint{changes.Create, changes.UpdateOld, changes.UpdateNew, changes.Delete, changes.Adopt} {
+		for _, ep := range records {
+			if zone, _ := zones.FindZone(ep.DNSName); zone != "" && !slices.Contains(changed, zone) {
+				changed = append(changed, zone)
+			}
+		}
+	}
+	slices.Sort(changed)
+	log.Debugf("Locking zones %v to apply the changes", changed)
+	return changed, nil
+}
+
+// ZoneNames implements StreamingRegistry.
+func (r *LockingRegistry) ZoneNames(ctx context.Context) ([]string, error) {
+	return ZoneNames(ctx, r.Registry)
+}
+
+// StreamRecords implements StreamingRegistry.
+func (r *LockingRegistry) StreamRecords(ctx context.Context, fn func(zone string, records []*endpoint.Endpoint) error) error {
+	return StreamRecords(ctx, r.Registry, fn)
+}
+
+// RecordsFor implements ScopedRegistry.
+func (r *LockingRegistry) RecordsFor(ctx context.Context, filter RecordsFilter) ([]*endpoint.Endpoint, error) {
+	return RecordsFor(ctx, r.Registry, filter)
+}
+
+// OrphanedRecords implements CollectingRegistry.
+func (r *LockingRegistry) OrphanedRecords(ctx context.Context, ownerIDs []string) ([]*endpoint.Endpoint, error) {
+	return OrphanedRecords(ctx, r.Registry, ownerIDs)
+}
+
+// DeleteOrphanedRecords implements CollectingRegistry, holding the locks of the zones of the records.
+func (r *LockingRegistry) DeleteOrphanedRecords(ctx context.Context, records []*endpoint.Endpoint) error {
+	zones, err := r.changedZones(ctx, &plan.Changes{Delete: records})
+	if err != nil {
+		return err
+	}
+	for _, zone := range zones {
+		unlock, err := r.locker.Lock(ctx, zone)
+		if errors.Is(err, ErrZoneLocked) {
+			return provider.NewSoftErrorf("not deleting the orphaned records of zone %q: %v", zone, err)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to lock zone %q: %w", zone, err)
+		}
+		defer unlock()
+	}
+	return DeleteOrphanedRecords(ctx, r.Registry, records)
+}
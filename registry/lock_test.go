@@ -0,0 +1,133 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package registry
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider"
+	"sigs.k8s.io/external-dns/provider/inmemory"
+)
+
+// fakeZoneLocker records the locked zones, and fails to lock the zones held by another applier.
+type fakeZoneLocker struct {
+	held     map[string]bool
+	locked   []string
+	unlocked []string
+}
+
+func (l *fakeZoneLocker) Lock(_ context.Context, zone string) (func(), error) {
+	if l.held[zone] {
+		return nil, ErrZoneLocked
+	}
+	l.locked = append(l.locked, zone)
+	return func() { l.unlocked = append(l.unlocked, zone) }, nil
+}
+
+func TestLockingRegistryApplyChanges(t *testing.T) {
+	ctx := context.Background()
+	p := inmemory.NewInMemoryProvider()
+	require.NoError(t, p.CreateZone("example.org"))
+	require.NoError(t, p.CreateZone("example.com"))
+	require.NoError(t, p.CreateZone("unused.net"))
+	noop, err := NewNoopRegistry(p)
+	require.NoError(t, err)
+
+	locker := &fakeZoneLocker{}
+	r := NewLockingRegistry(noop, locker)
+	require.NoError(t, r.ApplyChanges(ctx, &plan.Changes{
+		Create: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("www.example.org", endpoint.RecordTypeA, "1.2.3.4"),
+			endpoint.NewEndpoint("api.example.org", endpoint.RecordTypeA, "1.2.3.4"),
+			endpoint.NewEndpoint("www.example.com", endpoint.RecordTypeA, "1.2.3.4"),
+		},
+	}))
+	// only the zones of the changes are locked, and released once they are applied
+	assert.Equal(t, []string{"example.com", "example.org"}, locker.locked)
+	assert.ElementsMatch(t, locker.locked, locker.unlocked)
+	records, err := r.Records(ctx)
+	require.NoError(t, err)
+	assert.Len(t, records, 3)
+
+	// no change is applied while a zone is locked by another applier
+	locker = &fakeZoneLocker{held: map[string]bool{"example.org": true}}
+	r = NewLockingRegistry(noop, locker)
+	err = r.ApplyChanges(ctx, &plan.Changes{
+		Create: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("new.example.com", endpoint.RecordTypeA, "1.2.3.4"),
+			endpoint.NewEndpoint("new.example.org", endpoint.RecordTypeA, "1.2.3.4"),
+		},
+	})
+	require.ErrorIs(t, err, provider.SoftError)
+	require.ErrorContains(t, err, `not applying the changes of zone "example.org"`)
+	assert.Equal(t, locker.locked, locker.unlocked)
+	records, err = r.Records(ctx)
+	require.NoError(t, err)
+	assert.Len(t, records, 3)
+}
+
+func TestLockingRegistryWithoutZones(t *testing.T) {
+	locker := &fakeZoneLocker{}
+	r := NewLockingRegistry(unscopedRegistry{}, locker)
+	require.NoError(t, r.ApplyChanges(context.Background(), &plan.Changes{
+		Create: []*endpoint.Endpoint{endpoint.NewEndpoint("www.example.org", endpoint.RecordTypeA, "1.2.3.4")},
+	}))
+	// the registries that don't list their zones are locked as a whole
+	assert.Equal(t, []string{""}, locker.locked)
+}
+
+func TestLockingRegistryDeleteOrphanedRecords(t *testing.T) {
+	ctx := context.Background()
+	p := inmemory.NewInMemoryProvider()
+	require.NoError(t, p.CreateZone("example.org"))
+	require.NoError(t, p.ApplyChanges(ctx, &plan.Changes{
+		Create: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("a-gone.example.org", endpoint.RecordTypeTXT, "\"heritage=external-dns,external-dns/owner=owner\""),
+		},
+	}))
+	txt, err := NewTXTRegistry(p, "", "", "owner", 0, "", nil, nil, false, nil, false)
+	require.NoError(t, err)
+
+	locker := &fakeZoneLocker{}
+	r := NewLockingRegistry(txt, locker)
+	orphans, err := OrphanedRecords(ctx, r, []string{"owner"})
+	require.NoError(t, err)
+	require.Len(t, orphans, 1)
+
+	// the orphans are not deleted while their zone is locked by another applier
+	locker.held = map[string]bool{"example.org": true}
+	require.ErrorIs(t, DeleteOrphanedRecords(ctx, r, orphans), provider.SoftError)
+	locker.held = nil
+	require.NoError(t, DeleteOrphanedRecords(ctx, r, orphans))
+	assert.Equal(t, []string{"example.org"}, locker.locked)
+	records, err := p.Records(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, records)
+
+	// the registries without ownership records have no orphans
+	noop, err := NewNoopRegistry(p)
+	require.NoError(t, err)
+	orphans, err = OrphanedRecords(ctx, NewLockingRegistry(noop, locker), []string{"owner"})
+	require.NoError(t, err)
+	assert.Empty(t, orphans)
+}
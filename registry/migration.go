@@ -0,0 +1,140 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package registry
+
+import (
+	"context"
+	"errors"
+	"maps"
+
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+)
+
+// MigrationRegistry moves the ownership of the records from TXT records to a DynamoDB table
+// without a maintenance window. The ownership is written to both registries, and read from
+// the TXT records until the cutover, then from the table; a record without an owner in one of
+// them is read from the other. The records owned in the TXT records but not in the table are
+// backfilled into the table, so that it holds all the ownership by the cutover.
+type MigrationRegistry struct {
+	dynamodb *DynamoDBRegistry
+	txt      *registryProvider
+	cutover  bool
+}
+
+// NewMigrationRegistry returns a new MigrationRegistry writing to both registries. The DynamoDB
+// registry applies its changes through the TXT registry instead of its provider.
+func NewMigrationRegistry(txt Registry, dynamodb *DynamoDBRegistry, cutover bool) (*MigrationRegistry, error) {
+	if txt.OwnerID() != dynamodb.OwnerID() {
+		return nil, errors.New("the TXT and DynamoDB registries must have the same owner id")
+	}
+	adapter := &registryProvider{registry: txt}
+	dynamodb.provider = adapter
+	return &MigrationRegistry{dynamodb: dynamodb, txt: adapter, cutover: cutover}, nil
+}
+
+func (im *MigrationRegistry) GetDomainFilter() endpoint.DomainFilterInterface {
+	return im.dynamodb.GetDomainFilter()
+}
+
+func (im *MigrationRegistry) OwnerID() string {
+	return im.dynamodb.OwnerID()
+}
+
+// Records returns the records with the ownership of the registry read before the other,
+// backfilling the ownership of the TXT records missing from the table.
+func (im *MigrationRegistry) Records(ctx context.Context) ([]*endpoint.Endpoint, error) {
+	records, err := im.dynamodb.Records(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var backfill []*endpoint.Endpoint
+	for _, record := range records {
+		txtLabels := im.txt.labels[record.Key()]
+		if txtLabels[endpoint.OwnerLabelKey] == im.OwnerID() && record.Labels[endpoint.OwnerLabelKey] == "" {
+			missing := record.DeepCopy()
+			missing.Labels = maps.Clone(txtLabels)
+			backfill = append(backfill, missing)
+		}
+
+		primary, secondary := txtLabels, record.Labels
+		if im.cutover {
+			primary, secondary = secondary, primary
+		}
+		if primary[endpoint.OwnerLabelKey] == "" {
+			primary = secondary
+		}
+		record.Labels = endpoint.NewLabels()
+		maps.Copy(record.Labels, primary)
+	}
+
+	if len(backfill) > 0 {
+		log.Infof("Backfilling the ownership of %d records into the DynamoDB table", len(backfill))
+		if err := im.dynamodb.insertLabels(ctx, backfill); err != nil {
+			return nil, err
+		}
+	}
+	return records, nil
+}
+
+// ApplyChanges writes the changes to the table, then to the provider and the TXT records.
+func (im *MigrationRegistry) ApplyChanges(ctx context.Context, changes *plan.Changes) error {
+	return im.dynamodb.ApplyChanges(ctx, changes)
+}
+
+// AdjustEndpoints modifies the endpoints as needed by the specific provider.
+func (im *MigrationRegistry) AdjustEndpoints(endpoints []*endpoint.Endpoint) ([]*endpoint.Endpoint, error) {
+	return im.dynamodb.AdjustEndpoints(endpoints)
+}
+
+// registryProvider makes a registry the provider of another registry, so that the changes
+// are applied through both of them. It keeps the labels of the records of its registry.
+type registryProvider struct {
+	registry Registry
+	labels   map[endpoint.EndpointKey]endpoint.Labels
+}
+
+// Records returns copies of the records of the registry, whose labels are replaced by the
+// registry on top of it.
+func (p *registryProvider) Records(ctx context.Context) ([]*endpoint.Endpoint, error) {
+	records, err := p.registry.Records(ctx)
+	if err != nil {
+		return nil, err
+	}
+	p.labels = make(map[endpoint.EndpointKey]endpoint.Labels, len(records))
+	copies := make([]*endpoint.Endpoint, 0, len(records))
+	for _, record := range records {
+		p.labels[record.Key()] = record.Labels
+		copies = append(copies, record.DeepCopy())
+	}
+	return copies, nil
+}
+
+func (p *registryProvider) ApplyChanges(ctx context.Context, changes *plan.Changes) error {
+	return p.registry.ApplyChanges(ctx, changes)
+}
+
+func (p *registryProvider) AdjustEndpoints(endpoints []*endpoint.Endpoint) ([]*endpoint.Endpoint, error) {
+	return p.registry.AdjustEndpoints(endpoints)
+}
+
+func (p *registryProvider) GetDomainFilter() endpoint.DomainFilterInterface {
+	return p.registry.GetDomainFilter()
+}
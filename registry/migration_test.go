@@ -0,0 +1,154 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package registry
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider"
+)
+
+// newMigrationRegistryStub returns a MigrationRegistry over the DynamoDB stub, whose foo record
+// is owned by test-owner and bar record by other-owner in the TXT records.
+func newMigrationRegistryStub(t *testing.T, stubConfig *DynamoDBStubConfig, cutover bool) (*MigrationRegistry, provider.Provider) {
+	t.Helper()
+	api, p := newDynamoDBAPIStub(t, stubConfig)
+	require.NoError(t, p.(*wrappedProvider).Provider.ApplyChanges(context.Background(), &plan.Changes{
+		Create: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("foo.test-zone.example.org", endpoint.RecordTypeTXT, `"heritage=external-dns,external-dns/owner=test-owner,external-dns/resource=ingress/default/foo"`),
+			endpoint.NewEndpoint("bar.test-zone.example.org", endpoint.RecordTypeTXT, `"heritage=external-dns,external-dns/owner=other-owner,external-dns/resource=ingress/default/bar"`),
+		},
+	}))
+
+	txt, err := NewTXTRegistry(p, "", "", "test-owner", 0, "", []string{}, []string{}, false, nil, false)
+	require.NoError(t, err)
+	dynamodb, err := NewDynamoDBRegistry(p, "test-owner", api, "test-table", "", "", "", []string{}, []string{}, nil, 0, false, nil)
+	require.NoError(t, err)
+	r, err := NewMigrationRegistry(txt, dynamodb, cutover)
+	require.NoError(t, err)
+	return r, p
+}
+
+func migrationRecordOwners(records []*endpoint.Endpoint) map[string]string {
+	owners := map[string]string{}
+	for _, record := range records {
+		owners[record.DNSName+"/"+record.SetIdentifier] = record.Labels[endpoint.OwnerLabelKey]
+	}
+	return owners
+}
+
+func TestMigrationRegistryOwnerMismatch(t *testing.T) {
+	api, p := newDynamoDBAPIStub(t, &DynamoDBStubConfig{})
+	txt, err := NewTXTRegistry(p, "", "", "txt-owner", 0, "", []string{}, []string{}, false, nil, false)
+	require.NoError(t, err)
+	dynamodb, err := NewDynamoDBRegistry(p, "test-owner", api, "test-table", "", "", "", []string{}, []string{}, nil, 0, false, nil)
+	require.NoError(t, err)
+
+	_, err = NewMigrationRegistry(txt, dynamodb, false)
+	assert.ErrorContains(t, err, "same owner id")
+}
+
+func TestMigrationRegistryRecords(t *testing.T) {
+	for _, tc := range []struct {
+		title    string
+		cutover  bool
+		expected map[string]string
+	}{
+		{
+			title:   "before the cutover",
+			cutover: false,
+			expected: map[string]string{
+				"foo.test-zone.example.org/":      "test-owner",
+				"bar.test-zone.example.org/":      "other-owner",
+				"baz.test-zone.example.org/set-1": "test-owner",
+				"baz.test-zone.example.org/set-2": "test-owner",
+			},
+		},
+		{
+			title:   "after the cutover",
+			cutover: true,
+			expected: map[string]string{
+				"foo.test-zone.example.org/":      "test-owner",
+				"bar.test-zone.example.org/":      "test-owner",
+				"baz.test-zone.example.org/set-1": "test-owner",
+				"baz.test-zone.example.org/set-2": "test-owner",
+			},
+		},
+	} {
+		t.Run(tc.title, func(t *testing.T) {
+			stubConfig := &DynamoDBStubConfig{
+				// foo is only owned in the TXT records.
+				ExpectInsert: map[string]map[string]string{
+					"foo.test-zone.example.org#CNAME#": {endpoint.ResourceLabelKey: "ingress/default/foo"},
+				},
+			}
+			r, _ := newMigrationRegistryStub(t, stubConfig, tc.cutover)
+
+			records, err := r.Records(context.Background())
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, migrationRecordOwners(records))
+			assert.Empty(t, stubConfig.ExpectInsert, "the ownership of foo is backfilled")
+
+			// the backfill is only done once.
+			_, err = r.Records(context.Background())
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestMigrationRegistryApplyChanges(t *testing.T) {
+	stubConfig := &DynamoDBStubConfig{
+		ExpectInsert: map[string]map[string]string{
+			"foo.test-zone.example.org#CNAME#": {endpoint.ResourceLabelKey: "ingress/default/foo"},
+			"new.test-zone.example.org#CNAME#": {endpoint.ResourceLabelKey: "ingress/default/new"},
+		},
+		ExpectDelete: sets.New("quux.test-zone.example.org#A#set-2"),
+	}
+	r, p := newMigrationRegistryStub(t, stubConfig, false)
+
+	_, err := r.Records(context.Background())
+	require.NoError(t, err)
+	require.NoError(t, r.ApplyChanges(context.Background(), &plan.Changes{
+		Create: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("new.test-zone.example.org", endpoint.RecordTypeCNAME, "new.loadbalancer.com").WithLabel(endpoint.ResourceLabelKey, "ingress/default/new"),
+		},
+	}))
+	assert.Empty(t, stubConfig.ExpectInsert)
+	assert.Empty(t, stubConfig.ExpectDelete)
+
+	// the record is created along with its TXT records.
+	records, err := p.Records(context.Background())
+	require.NoError(t, err)
+	names := map[string]bool{}
+	for _, record := range records {
+		names[record.RecordType+" "+record.DNSName] = true
+	}
+	assert.True(t, names["CNAME new.test-zone.example.org"])
+	assert.True(t, names["TXT cname-new.test-zone.example.org"])
+
+	// the new record is owned in both registries.
+	records, err = r.Records(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "test-owner", migrationRecordOwners(records)["new.test-zone.example.org/"])
+}
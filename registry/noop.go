@@ -49,6 +49,26 @@ func (im *NoopRegistry) Records(ctx context.Context) ([]*endpoint.Endpoint, erro
 	return im.provider.Records(ctx)
 }
 
+// RecordsFor returns the records from the dns provider matching the filter, only listing the zone of the filter
+// when the provider supports it
+func (im *NoopRegistry) RecordsFor(ctx context.Context, filter RecordsFilter) ([]*endpoint.Endpoint, error) {
+	records, err := provider.ZoneRecords(ctx, im.provider, filter.Zone)
+	if err != nil {
+		return nil, err
+	}
+	return filterRecords(records, filter), nil
+}
+
+// ZoneNames returns the names of the zones of the dns provider
+func (im *NoopRegistry) ZoneNames(ctx context.Context) ([]string, error) {
+	return provider.ZoneNames(ctx, im.provider)
+}
+
+// StreamRecords calls fn with the records of each zone of the dns provider in turn
+func (im *NoopRegistry) StreamRecords(ctx context.Context, fn func(zone string, records []*endpoint.Endpoint) error) error {
+	return provider.StreamRecords(ctx, im.provider, fn)
+}
+
 // ApplyChanges propagates changes to the dns provider
 func (im *NoopRegistry) ApplyChanges(ctx context.Context, changes *plan.Changes) error {
 	return im.provider.ApplyChanges(ctx, changes)
@@ -34,6 +34,7 @@ var _ Registry = &NoopRegistry{}
 func TestNoopRegistry(t *testing.T) {
 	t.Run("NewNoopRegistry", testNoopInit)
 	t.Run("Records", testNoopRecords)
+	t.Run("RecordsFor", testNoopRecordsFor)
 	t.Run("ApplyChanges", testNoopApplyChanges)
 }
 
@@ -66,6 +67,28 @@ func testNoopRecords(t *testing.T) {
 	assert.True(t, testutils.SameEndpoints(eps, inmemoryRecords))
 }
 
+func testNoopRecordsFor(t *testing.T) {
+	ctx := context.Background()
+	p := inmemory.NewInMemoryProvider()
+	p.CreateZone("org")
+	p.CreateZone("com")
+	p.ApplyChanges(ctx, &plan.Changes{
+		Create: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("foo.example.org", endpoint.RecordTypeA, "1.2.3.4"),
+			endpoint.NewEndpoint("bar.example.org", endpoint.RecordTypeA, "1.2.3.4"),
+			endpoint.NewEndpoint("foo.example.com", endpoint.RecordTypeA, "1.2.3.4"),
+		},
+	})
+
+	r, _ := NewNoopRegistry(p)
+
+	eps, err := r.RecordsFor(ctx, RecordsFilter{Zone: "org", Names: []string{"foo.example.org"}})
+	require.NoError(t, err)
+	assert.True(t, testutils.SameEndpoints(eps, []*endpoint.Endpoint{
+		endpoint.NewEndpoint("foo.example.org", endpoint.RecordTypeA, "1.2.3.4"),
+	}))
+}
+
 func testNoopApplyChanges(t *testing.T) {
 	// do some prep
 	p := inmemory.NewInMemoryProvider()
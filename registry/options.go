@@ -0,0 +1,73 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package registry
+
+import (
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+// Option configures the optional features of the TXT and DynamoDB registries.
+type Option func(*options)
+
+type options struct {
+	txtCipher                endpoint.TextCipher
+	txtNameTemplate          string
+	txtNameTemplateMigration bool
+	ownerIDTemplate          *endpoint.OwnerIDTemplate
+}
+
+func newOptions(opts []Option) options {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// WithTXTCipher encrypts the labels of the TXT records with the cipher, e.g. with data keys
+// wrapped by a key management service, instead of the AES key. The DynamoDB registry decrypts
+// the TXT records it migrates with it.
+func WithTXTCipher(c endpoint.TextCipher) Option {
+	return func(o *options) {
+		o.txtCipher = c
+	}
+}
+
+// WithTXTNameTemplate names the TXT records of the TXT registry after the template instead of
+// the prefix or suffix. It's ignored when empty.
+func WithTXTNameTemplate(template string) Option {
+	return func(o *options) {
+		o.txtNameTemplate = template
+	}
+}
+
+// WithTXTNameTemplateMigration still reads and writes the TXT records in the prefix/suffix format
+// along with those named after the template, so that they can be migrated to it.
+func WithTXTNameTemplateMigration(migration bool) Option {
+	return func(o *options) {
+		o.txtNameTemplateMigration = migration
+	}
+}
+
+// WithOwnerIDTemplate derives the owner IDs of the records of the TXT registry from the owner ID
+// and their resources with the template, so that the records of each tenant are owned by a
+// distinct owner. It's ignored when nil.
+func WithOwnerIDTemplate(t *endpoint.OwnerIDTemplate) Option {
+	return func(o *options) {
+		o.ownerIDTemplate = t
+	}
+}
@@ -18,6 +18,8 @@ package registry
 
 import (
 	"context"
+	"errors"
+	"strings"
 
 	"sigs.k8s.io/external-dns/endpoint"
 	"sigs.k8s.io/external-dns/plan"
@@ -34,3 +36,125 @@ type Registry interface {
 	GetDomainFilter() endpoint.DomainFilterInterface
 	OwnerID() string
 }
+
+// RecordsFilter scopes an ownership lookup to the records of a zone or of a set of names.
+// The zero value matches all the records.
+type RecordsFilter struct {
+	// Zone is the domain whose records, including those of its subdomains, are matched, if not empty
+	Zone string
+	// Names are the DNS names of the matched records, if not empty
+	Names []string
+}
+
+// Match returns whether the filter matches the record.
+func (f RecordsFilter) Match(ep *endpoint.Endpoint) bool {
+	name := normalizeRecordsFilterName(ep.DNSName)
+	if zone := normalizeRecordsFilterName(f.Zone); zone != "" && name != zone && !strings.HasSuffix(name, "."+zone) {
+		return false
+	}
+	if len(f.Names) == 0 {
+		return true
+	}
+	for _, n := range f.Names {
+		if normalizeRecordsFilterName(n) == name {
+			return true
+		}
+	}
+	return false
+}
+
+func normalizeRecordsFilterName(name string) string {
+	return strings.ToLower(strings.TrimSuffix(name, "."))
+}
+
+// ScopedRegistry is implemented by the registries that can look up the ownership of the records
+// of a zone or of a set of names, without reading all the records.
+type ScopedRegistry interface {
+	Registry
+	// RecordsFor returns the records matching the filter, with their owner information.
+	RecordsFor(ctx context.Context, filter RecordsFilter) ([]*endpoint.Endpoint, error)
+}
+
+// RecordsFor returns the records of the registry matching the filter. It filters all the records
+// of the registries that don't implement ScopedRegistry.
+func RecordsFor(ctx context.Context, r Registry, filter RecordsFilter) ([]*endpoint.Endpoint, error) {
+	if s, ok := r.(ScopedRegistry); ok {
+		return s.RecordsFor(ctx, filter)
+	}
+	records, err := r.Records(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return filterRecords(records, filter), nil
+}
+
+// filterRecords returns the records matching the filter.
+func filterRecords(records []*endpoint.Endpoint, filter RecordsFilter) []*endpoint.Endpoint {
+	filtered := make([]*endpoint.Endpoint, 0, len(records))
+	for _, record := range records {
+		if filter.Match(record) {
+			filtered = append(filtered, record)
+		}
+	}
+	return filtered
+}
+
+// StreamingRegistry is implemented by the registries that can return their records one zone at a time,
+// so that the records of all the zones don't have to be held in memory at once.
+type StreamingRegistry interface {
+	Registry
+	// ZoneNames returns the names of the zones of the records.
+	ZoneNames(ctx context.Context) ([]string, error)
+	// StreamRecords calls fn with the records of each zone in turn, with their owner information.
+	StreamRecords(ctx context.Context, fn func(zone string, records []*endpoint.Endpoint) error) error
+}
+
+// ZoneNames returns the names of the zones of a registry, or none for the registries that
+// don't implement StreamingRegistry.
+func ZoneNames(ctx context.Context, r Registry) ([]string, error) {
+	if s, ok := r.(StreamingRegistry); ok {
+		return s.ZoneNames(ctx)
+	}
+	return nil, nil
+}
+
+// StreamRecords calls fn with the records of each zone of a registry in turn. It calls fn once,
+// with an empty zone name and all the records, for the registries that don't implement StreamingRegistry.
+func StreamRecords(ctx context.Context, r Registry, fn func(zone string, records []*endpoint.Endpoint) error) error {
+	if s, ok := r.(StreamingRegistry); ok {
+		return s.StreamRecords(ctx, fn)
+	}
+	records, err := r.Records(ctx)
+	if err != nil {
+		return err
+	}
+	return fn("", records)
+}
+
+// CollectingRegistry is implemented by the registries whose ownership records can be left without
+// their record, e.g. by an interrupted apply or a record deleted by hand, which can find and delete them.
+type CollectingRegistry interface {
+	Registry
+	// OrphanedRecords returns the ownership records of the owners without their record.
+	OrphanedRecords(ctx context.Context, ownerIDs []string) ([]*endpoint.Endpoint, error)
+	// DeleteOrphanedRecords deletes ownership records returned by OrphanedRecords.
+	DeleteOrphanedRecords(ctx context.Context, records []*endpoint.Endpoint) error
+}
+
+// OrphanedRecords returns the orphaned ownership records of the owners of a registry, or none for
+// the registries that don't implement CollectingRegistry.
+func OrphanedRecords(ctx context.Context, r Registry, ownerIDs []string) ([]*endpoint.Endpoint, error) {
+	if c, ok := r.(CollectingRegistry); ok {
+		return c.OrphanedRecords(ctx, ownerIDs)
+	}
+	return nil, nil
+}
+
+// DeleteOrphanedRecords deletes the orphaned ownership records of a registry. It fails for the
+// registries that don't implement CollectingRegistry.
+func DeleteOrphanedRecords(ctx context.Context, r Registry, records []*endpoint.Endpoint) error {
+	if c, ok := r.(CollectingRegistry); ok {
+		return c.DeleteOrphanedRecords(ctx, records)
+	}
+	return errors.New("the registry has no orphaned records")
+}
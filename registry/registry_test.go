@@ -0,0 +1,118 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package registry
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+)
+
+var (
+	_ ScopedRegistry    = &TXTRegistry{}
+	_ ScopedRegistry    = &NoopRegistry{}
+	_ StreamingRegistry = &TXTRegistry{}
+	_ StreamingRegistry = &NoopRegistry{}
+)
+
+func TestRecordsFilterMatch(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		filter   RecordsFilter
+		dnsName  string
+		expected bool
+	}{
+		{name: "zero value", dnsName: "foo.example.org", expected: true},
+		{name: "zone apex", filter: RecordsFilter{Zone: "example.org"}, dnsName: "example.org", expected: true},
+		{name: "zone subdomain", filter: RecordsFilter{Zone: "Example.org."}, dnsName: "foo.example.org", expected: true},
+		{name: "other zone", filter: RecordsFilter{Zone: "example.org"}, dnsName: "foo.example.com", expected: false},
+		{name: "zone suffix", filter: RecordsFilter{Zone: "example.org"}, dnsName: "fooexample.org", expected: false},
+		{name: "name", filter: RecordsFilter{Names: []string{"bar.example.org", "foo.example.org."}}, dnsName: "FOO.example.org", expected: true},
+		{name: "other name", filter: RecordsFilter{Names: []string{"bar.example.org"}}, dnsName: "foo.example.org", expected: false},
+		{name: "name outside zone", filter: RecordsFilter{Zone: "example.com", Names: []string{"foo.example.org"}}, dnsName: "foo.example.org", expected: false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.filter.Match(&endpoint.Endpoint{DNSName: tc.dnsName}))
+		})
+	}
+}
+
+// unscopedRegistry is a Registry that doesn't implement ScopedRegistry
+type unscopedRegistry struct {
+	records []*endpoint.Endpoint
+	err     error
+}
+
+func (r unscopedRegistry) Records(_ context.Context) ([]*endpoint.Endpoint, error) {
+	return r.records, r.err
+}
+
+func (r unscopedRegistry) ApplyChanges(_ context.Context, _ *plan.Changes) error {
+	return nil
+}
+
+func (r unscopedRegistry) AdjustEndpoints(endpoints []*endpoint.Endpoint) ([]*endpoint.Endpoint, error) {
+	return endpoints, nil
+}
+
+func (r unscopedRegistry) GetDomainFilter() endpoint.DomainFilterInterface {
+	return &endpoint.DomainFilter{}
+}
+
+func (r unscopedRegistry) OwnerID() string {
+	return ""
+}
+
+func TestRecordsForFallback(t *testing.T) {
+	r := unscopedRegistry{records: []*endpoint.Endpoint{
+		endpoint.NewEndpoint("foo.example.org", endpoint.RecordTypeA, "1.2.3.4"),
+		endpoint.NewEndpoint("foo.example.com", endpoint.RecordTypeA, "1.2.3.4"),
+	}}
+
+	records, err := RecordsFor(context.Background(), r, RecordsFilter{Zone: "example.org"})
+	require.NoError(t, err)
+	assert.Equal(t, r.records[:1], records)
+
+	_, err = RecordsFor(context.Background(), unscopedRegistry{err: errors.New("failed")}, RecordsFilter{})
+	assert.Error(t, err)
+}
+
+func TestStreamRecordsFallback(t *testing.T) {
+	r := unscopedRegistry{records: []*endpoint.Endpoint{
+		endpoint.NewEndpoint("foo.example.org", endpoint.RecordTypeA, "1.2.3.4"),
+	}}
+
+	names, err := ZoneNames(context.Background(), r)
+	require.NoError(t, err)
+	assert.Empty(t, names)
+
+	calls := 0
+	err = StreamRecords(context.Background(), r, func(zone string, records []*endpoint.Endpoint) error {
+		calls++
+		assert.Empty(t, zone)
+		assert.Equal(t, r.records, records)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 1, calls)
+}
@@ -17,13 +17,14 @@ limitations under the License.
 package registry
 
 import (
+	"cmp"
 	"context"
 	"errors"
+	"maps"
+	"slices"
 	"strings"
 	"time"
 
-	b64 "encoding/base64"
-
 	log "github.com/sirupsen/logrus"
 
 	"sigs.k8s.io/external-dns/endpoint"
@@ -42,6 +43,9 @@ type TXTRegistry struct {
 	ownerID  string // refers to the owner id of the current instance
 	mapper   nameMapper
 
+	// derives the owner ids of the records from their resources, nil to own them all by ownerID
+	ownerIDTemplate *endpoint.OwnerIDTemplate
+
 	// cache the records in memory and update on an interval instead.
 	recordsCache            []*endpoint.Endpoint
 	recordsCacheRefreshTime time.Time
@@ -58,32 +62,39 @@ type TXTRegistry struct {
 	// encrypt text records
 	txtEncryptEnabled bool
 	txtEncryptAESKey  []byte
+	// decrypts the text records, and encrypts them when enabled, nil without encryption key
+	txtCipher endpoint.TextCipher
 
 	newFormatOnly bool
+
+	// maps the TXT records in the prefix/suffix format while migrating to a name template, nil otherwise
+	legacyMapper nameMapper
+
+	// the TXT records of this instance without their record in the last listing, left by an
+	// interrupted apply, by their name and set identifier; they're repaired with the next changes
+	orphanedTXTs map[endpoint.EndpointKey]*endpoint.Endpoint
 }
 
 // NewTXTRegistry returns a new TXTRegistry object. When newFormatOnly is true, it will only
 // generate new format TXT records, otherwise it generates both old and new formats for
-// backwards compatibility.
+// backwards compatibility. The text records are encrypted with the AES key, unless the options
+// set another cipher.
 func NewTXTRegistry(provider provider.Provider, txtPrefix, txtSuffix, ownerID string,
 	cacheInterval time.Duration, txtWildcardReplacement string,
 	managedRecordTypes, excludeRecordTypes []string,
 	txtEncryptEnabled bool, txtEncryptAESKey []byte,
-	newFormatOnly bool) (*TXTRegistry, error) {
+	newFormatOnly bool, opts ...Option) (*TXTRegistry, error) {
 	if ownerID == "" {
 		return nil, errors.New("owner id cannot be empty")
 	}
 
-	if len(txtEncryptAESKey) == 0 {
-		txtEncryptAESKey = nil
-	} else if len(txtEncryptAESKey) != 32 {
-		var err error
-		if txtEncryptAESKey, err = b64.StdEncoding.DecodeString(string(txtEncryptAESKey)); err != nil || len(txtEncryptAESKey) != 32 {
-			return nil, errors.New("the AES Encryption key must be 32 bytes long, in either plain text or base64-encoded format")
-		}
+	o := newOptions(opts)
+	textCipher, txtEncryptAESKey, err := txtCipher(txtEncryptAESKey, o)
+	if err != nil {
+		return nil, err
 	}
 
-	if txtEncryptEnabled && txtEncryptAESKey == nil {
+	if txtEncryptEnabled && textCipher == nil {
 		return nil, errors.New("the AES Encryption key must be set when TXT record encryption is enabled")
 	}
 
@@ -91,18 +102,35 @@ func NewTXTRegistry(provider provider.Provider, txtPrefix, txtSuffix, ownerID st
 		return nil, errors.New("txt-prefix and txt-suffix are mutual exclusive")
 	}
 
-	mapper := newaffixNameMapper(txtPrefix, txtSuffix, txtWildcardReplacement)
+	var mapper nameMapper = newaffixNameMapper(txtPrefix, txtSuffix, txtWildcardReplacement)
+	var legacyMapper nameMapper
+	if o.txtNameTemplate != "" {
+		if !o.txtNameTemplateMigration && (len(txtPrefix) > 0 || len(txtSuffix) > 0) {
+			return nil, errors.New("txt-name-template is mutual exclusive with txt-prefix and txt-suffix, unless migrating from them")
+		}
+		templateMapper, err := newTemplateNameMapper(o.txtNameTemplate, txtWildcardReplacement)
+		if err != nil {
+			return nil, err
+		}
+		if o.txtNameTemplateMigration {
+			legacyMapper = mapper
+		}
+		mapper = templateMapper
+	}
 
 	return &TXTRegistry{
 		provider:            provider,
 		ownerID:             ownerID,
+		ownerIDTemplate:     o.ownerIDTemplate,
 		mapper:              mapper,
+		legacyMapper:        legacyMapper,
 		cacheInterval:       cacheInterval,
 		wildcardReplacement: txtWildcardReplacement,
 		managedRecordTypes:  managedRecordTypes,
 		excludeRecordTypes:  excludeRecordTypes,
 		txtEncryptEnabled:   txtEncryptEnabled,
 		txtEncryptAESKey:    txtEncryptAESKey,
+		txtCipher:           textCipher,
 		newFormatOnly:       newFormatOnly,
 	}, nil
 }
@@ -135,10 +163,62 @@ func (im *TXTRegistry) Records(ctx context.Context) ([]*endpoint.Endpoint, error
 		return nil, err
 	}
 
+	endpoints, err := im.ownedRecords(records)
+	if err != nil {
+		return nil, err
+	}
+
+	// Update the cache.
+	if im.cacheInterval > 0 {
+		im.recordsCache = endpoints
+		im.recordsCacheRefreshTime = time.Now()
+	}
+
+	return endpoints, nil
+}
+
+// RecordsFor returns the records matching the filter, excluding TXT records, with their owner information.
+// Only the zone of the filter is listed when the provider supports it; the records cache is not used.
+func (im *TXTRegistry) RecordsFor(ctx context.Context, filter RecordsFilter) ([]*endpoint.Endpoint, error) {
+	records, err := provider.ZoneRecords(ctx, im.provider, filter.Zone)
+	if err != nil {
+		return nil, err
+	}
+
+	endpoints, err := im.ownedRecords(records)
+	if err != nil {
+		return nil, err
+	}
+	return filterRecords(endpoints, filter), nil
+}
+
+// ZoneNames returns the names of the zones of the provider.
+func (im *TXTRegistry) ZoneNames(ctx context.Context) ([]string, error) {
+	return provider.ZoneNames(ctx, im.provider)
+}
+
+// StreamRecords calls fn with the records of each zone of the provider in turn, excluding TXT records,
+// with their owner information. The records cache is not used.
+func (im *TXTRegistry) StreamRecords(ctx context.Context, fn func(zone string, records []*endpoint.Endpoint) error) error {
+	return provider.StreamRecords(ctx, im.provider, func(zone string, records []*endpoint.Endpoint) error {
+		endpoints, err := im.ownedRecords(records)
+		if err != nil {
+			return err
+		}
+		return fn(zone, endpoints)
+	})
+}
+
+// ownedRecords returns the records excluding the TXT records of the registry, with the labels
+// of their TXT records.
+func (im *TXTRegistry) ownedRecords(records []*endpoint.Endpoint) ([]*endpoint.Endpoint, error) {
 	endpoints := []*endpoint.Endpoint{}
 
 	labelMap := map[endpoint.EndpointKey]endpoint.Labels{}
+	legacyLabelMap := map[endpoint.EndpointKey]endpoint.Labels{}
+	txtLabelMap := map[endpoint.EndpointKey]endpoint.Labels{}
 	txtRecordsMap := map[string]struct{}{}
+	var ownTXTs []*endpoint.Endpoint
 
 	for _, record := range records {
 		if record.RecordType != endpoint.RecordTypeTXT {
@@ -151,7 +231,7 @@ func (im *TXTRegistry) Records(ctx context.Context) ([]*endpoint.Endpoint, error
 			log.Errorf("TXT record has no targets %s", record.DNSName)
 			continue
 		}
-		labels, err := endpoint.NewLabelsFromString(record.Targets[0], im.txtEncryptAESKey)
+		labels, err := endpoint.NewLabelsFromStringWithCipher(record.Targets[0], im.txtCipher)
 		if errors.Is(err, endpoint.ErrInvalidHeritage) {
 			// if no heritage is found or it is invalid
 			// case when value of txt record cannot be identified
@@ -170,7 +250,20 @@ func (im *TXTRegistry) Records(ctx context.Context) ([]*endpoint.Endpoint, error
 			SetIdentifier: record.SetIdentifier,
 		}
 		labelMap[key] = labels
+		if im.ownerIDTemplate.IsOwnedBy(im.ownerID, labels) {
+			ownTXTs = append(ownTXTs, record)
+		}
+		txtLabelMap[endpoint.EndpointKey{DNSName: strings.ToLower(record.DNSName), SetIdentifier: record.SetIdentifier}] = labels
 		txtRecordsMap[record.DNSName] = struct{}{}
+
+		if im.legacyMapper != nil {
+			endpointName, recordType := im.legacyMapper.toEndpointName(record.DNSName)
+			legacyLabelMap[endpoint.EndpointKey{
+				DNSName:       endpointName,
+				RecordType:    recordType,
+				SetIdentifier: record.SetIdentifier,
+			}] = labels
+		}
 	}
 
 	for _, ep := range endpoints {
@@ -195,10 +288,17 @@ func (im *TXTRegistry) Records(ctx context.Context) ([]*endpoint.Endpoint, error
 		}
 
 		// Handle both new and old registry format with the preference for the new one
-		labels, labelsExist := labelMap[key]
-		if !labelsExist && ep.RecordType != endpoint.RecordTypeAAAA {
-			key.RecordType = ""
-			labels, labelsExist = labelMap[key]
+		labels, labelsExist := lookupLabels(labelMap, key)
+		if !labelsExist {
+			// the names of the TXT records of hashed name templates cannot be mapped back to the endpoints
+			txtKey := endpoint.EndpointKey{
+				DNSName:       im.mapper.toNewTXTName(strings.ToLower(ep.DNSName), key.RecordType),
+				SetIdentifier: ep.SetIdentifier,
+			}
+			labels, labelsExist = txtLabelMap[txtKey]
+		}
+		if !labelsExist && im.legacyMapper != nil {
+			labels, labelsExist = lookupLabels(legacyLabelMap, key)
 		}
 		if labelsExist {
 			for k, v := range labels {
@@ -208,7 +308,7 @@ func (im *TXTRegistry) Records(ctx context.Context) ([]*endpoint.Endpoint, error
 
 		// Handle the migration of TXT records created before the new format (introduced in v0.12.0).
 		// The migration is done for the TXT records owned by this instance only.
-		if len(txtRecordsMap) > 0 && ep.Labels[endpoint.OwnerLabelKey] == im.ownerID {
+		if len(txtRecordsMap) > 0 && im.ownerIDTemplate.IsOwnedBy(im.ownerID, ep.Labels) {
 			if plan.IsManagedRecord(ep.RecordType, im.managedRecordTypes, im.excludeRecordTypes) {
 				// Get desired TXT records and detect the missing ones
 				desiredTXTs := im.generateTXTRecord(ep)
@@ -220,29 +320,152 @@ func (im *TXTRegistry) Records(ctx context.Context) ([]*endpoint.Endpoint, error
 			}
 		}
 	}
+	im.orphanedTXTs = im.orphanedTXTRecords(ownTXTs, endpoints)
 
-	// Update the cache.
-	if im.cacheInterval > 0 {
-		im.recordsCache = endpoints
-		im.recordsCacheRefreshTime = time.Now()
+	return endpoints, nil
+}
+
+// OrphanedRecords returns the TXT records of the owners which belong to none of the records, sorted
+// by name. The TXT records of other owners are only found when they're named like those of this one.
+func (im *TXTRegistry) OrphanedRecords(ctx context.Context, ownerIDs []string) ([]*endpoint.Endpoint, error) {
+	records, err := im.provider.Records(ctx)
+	if err != nil {
+		return nil, err
 	}
 
-	return endpoints, nil
+	var txts, others []*endpoint.Endpoint
+	for _, record := range records {
+		if record.RecordType != endpoint.RecordTypeTXT || len(record.Targets) == 0 {
+			others = append(others, record)
+			continue
+		}
+		// the TXT records which aren't ownership records of this registry are records too
+		labels, err := endpoint.NewLabelsFromStringWithCipher(record.Targets[0], im.txtCipher)
+		if err != nil {
+			others = append(others, record)
+			continue
+		}
+		if slices.ContainsFunc(ownerIDs, func(ownerID string) bool { return im.ownerIDTemplate.IsOwnedBy(ownerID, labels) }) {
+			if record.Labels == nil {
+				record.Labels = endpoint.NewLabels()
+			}
+			record.Labels[endpoint.OwnerLabelKey] = labels[endpoint.OwnerLabelKey]
+			txts = append(txts, record)
+		}
+	}
+
+	orphans := slices.Collect(maps.Values(im.orphanedTXTRecords(txts, others)))
+	slices.SortFunc(orphans, func(a, b *endpoint.Endpoint) int {
+		return cmp.Or(cmp.Compare(a.DNSName, b.DNSName), cmp.Compare(a.SetIdentifier, b.SetIdentifier))
+	})
+	return orphans, nil
+}
+
+// DeleteOrphanedRecords deletes TXT records returned by OrphanedRecords.
+func (im *TXTRegistry) DeleteOrphanedRecords(ctx context.Context, records []*endpoint.Endpoint) error {
+	if len(records) == 0 {
+		return nil
+	}
+	return im.provider.ApplyChanges(ctx, &plan.Changes{Delete: records})
+}
+
+// orphanedTXTRecords returns the TXT records of this instance which belong to none of the records,
+// by their name and set identifier. The names of the TXT records of all the supported record types
+// are considered, so that e.g. the TXT records of AWS alias records are never taken for orphans.
+func (im *TXTRegistry) orphanedTXTRecords(txts, records []*endpoint.Endpoint) map[endpoint.EndpointKey]*endpoint.Endpoint {
+	if len(txts) == 0 {
+		return nil
+	}
+	mappers := []nameMapper{im.mapper}
+	if im.legacyMapper != nil {
+		mappers = append(mappers, im.legacyMapper)
+	}
+	used := map[endpoint.EndpointKey]struct{}{}
+	for _, ep := range records {
+		name := strings.ToLower(ep.DNSName)
+		for _, mapper := range mappers {
+			used[endpoint.EndpointKey{DNSName: strings.ToLower(mapper.toTXTName(name)), SetIdentifier: ep.SetIdentifier}] = struct{}{}
+			for _, recordType := range append(getSupportedTypes(), ep.RecordType) {
+				used[endpoint.EndpointKey{DNSName: strings.ToLower(mapper.toNewTXTName(name, recordType)), SetIdentifier: ep.SetIdentifier}] = struct{}{}
+			}
+		}
+	}
+
+	orphans := map[endpoint.EndpointKey]*endpoint.Endpoint{}
+	for _, txt := range txts {
+		key := endpoint.EndpointKey{DNSName: strings.ToLower(txt.DNSName), SetIdentifier: txt.SetIdentifier}
+		if _, ok := used[key]; !ok {
+			log.Debugf("The TXT record %s has no record", txt)
+			orphans[key] = txt
+		}
+	}
+	return orphans
+}
+
+// repairOrphanedTXTs replaces the orphaned TXT records by the TXT records of the records created
+// again, instead of creating them, which would fail. The others are left to the janitor.
+func repairOrphanedTXTs(changes *plan.Changes, orphans map[endpoint.EndpointKey]*endpoint.Endpoint) {
+	if len(orphans) == 0 {
+		return
+	}
+	creates := make([]*endpoint.Endpoint, 0, len(changes.Create))
+	for _, ep := range changes.Create {
+		key := endpoint.EndpointKey{DNSName: strings.ToLower(ep.DNSName), SetIdentifier: ep.SetIdentifier}
+		orphan, ok := orphans[key]
+		if !ok || ep.RecordType != endpoint.RecordTypeTXT || ep.Labels[endpoint.OwnedRecordLabelKey] == "" {
+			creates = append(creates, ep)
+			continue
+		}
+		log.Infof("Replacing the orphaned TXT record %s", orphan)
+		changes.UpdateOld = append(changes.UpdateOld, orphan)
+		changes.UpdateNew = append(changes.UpdateNew, ep)
+		delete(orphans, key)
+	}
+	changes.Create = creates
+}
+
+// lookupLabels returns the labels of an endpoint, falling back to the TXT records without
+// record type, except for AAAA records.
+func lookupLabels(labelMap map[endpoint.EndpointKey]endpoint.Labels, key endpoint.EndpointKey) (endpoint.Labels, bool) {
+	labels, ok := labelMap[key]
+	if !ok && key.RecordType != endpoint.RecordTypeAAAA {
+		key.RecordType = ""
+		labels, ok = labelMap[key]
+	}
+	return labels, ok
 }
 
 // generateTXTRecord generates TXT records in either both formats (old and new) or new format only,
 // depending on the newFormatOnly configuration. The old format is maintained for backwards
-// compatibility but can be disabled to reduce the number of DNS records.
+// compatibility but can be disabled to reduce the number of DNS records. While migrating to a
+// name template, the TXT records in the prefix/suffix format are generated too.
 func (im *TXTRegistry) generateTXTRecord(r *endpoint.Endpoint) []*endpoint.Endpoint {
+	endpoints := im.generateTXTRecordWithMapper(r, im.mapper)
+	if im.legacyMapper != nil {
+		endpoints = append(endpoints, im.generateTXTRecordWithMapper(r, im.legacyMapper)...)
+	}
+	return endpoints
+}
+
+// encryptionCipher returns the cipher encrypting the TXT records, nil when the encryption is disabled.
+func (im *TXTRegistry) encryptionCipher() endpoint.TextCipher {
+	if !im.txtEncryptEnabled {
+		return nil
+	}
+	return im.txtCipher
+}
+
+func (im *TXTRegistry) generateTXTRecordWithMapper(r *endpoint.Endpoint, mapper nameMapper) []*endpoint.Endpoint {
 	endpoints := make([]*endpoint.Endpoint, 0)
 
 	// Create legacy format record by default unless newFormatOnly is true
-	if !im.newFormatOnly && !im.txtEncryptEnabled && !im.mapper.recordTypeInAffix() && r.RecordType != endpoint.RecordTypeAAAA {
+	if !im.newFormatOnly && !im.txtEncryptEnabled && !mapper.recordTypeInAffix() && r.RecordType != endpoint.RecordTypeAAAA {
 		// old TXT record format
-		txt := endpoint.NewEndpoint(im.mapper.toTXTName(r.DNSName), endpoint.RecordTypeTXT, r.Labels.Serialize(true, im.txtEncryptEnabled, im.txtEncryptAESKey))
+		txt := endpoint.NewEndpoint(mapper.toTXTName(r.DNSName), endpoint.RecordTypeTXT, r.Labels.SerializeWithCipher(true, im.encryptionCipher()))
 		if txt != nil {
 			txt.WithSetIdentifier(r.SetIdentifier)
 			txt.Labels[endpoint.OwnedRecordLabelKey] = r.DNSName
+			copyZoneLabels(r, txt)
 			txt.ProviderSpecific = r.ProviderSpecific
 			endpoints = append(endpoints, txt)
 		}
@@ -254,30 +477,43 @@ func (im *TXTRegistry) generateTXTRecord(r *endpoint.Endpoint) []*endpoint.Endpo
 	if isAlias, found := r.GetProviderSpecificProperty("alias"); found && isAlias == "true" && recordType == endpoint.RecordTypeA {
 		recordType = endpoint.RecordTypeCNAME
 	}
-	txtNew := endpoint.NewEndpoint(im.mapper.toNewTXTName(r.DNSName, recordType), endpoint.RecordTypeTXT, r.Labels.Serialize(true, im.txtEncryptEnabled, im.txtEncryptAESKey))
+	txtNew := endpoint.NewEndpoint(mapper.toNewTXTName(r.DNSName, recordType), endpoint.RecordTypeTXT, r.Labels.SerializeWithCipher(true, im.encryptionCipher()))
 	if txtNew != nil {
 		txtNew.WithSetIdentifier(r.SetIdentifier)
 		txtNew.Labels[endpoint.OwnedRecordLabelKey] = r.DNSName
+		copyZoneLabels(r, txtNew)
 		txtNew.ProviderSpecific = r.ProviderSpecific
 		endpoints = append(endpoints, txtNew)
 	}
 	return endpoints
 }
 
+// copyZoneLabels copies the labels choosing the zone of the record to its TXT record, so that
+// they're written to the same zones.
+func copyZoneLabels(r, txt *endpoint.Endpoint) {
+	for _, key := range []string{endpoint.ZoneIDLabelKey, endpoint.ZoneSelectionLabelKey} {
+		if value, ok := r.Labels[key]; ok {
+			txt.Labels[key] = value
+		}
+	}
+}
+
 // ApplyChanges updates dns provider with the changes
 // for each created/deleted record it will also take into account TXT records for creation/deletion
 func (im *TXTRegistry) ApplyChanges(ctx context.Context, changes *plan.Changes) error {
 	filteredChanges := &plan.Changes{
 		Create:    changes.Create,
-		UpdateNew: endpoint.FilterEndpointsByOwnerID(im.ownerID, changes.UpdateNew),
-		UpdateOld: endpoint.FilterEndpointsByOwnerID(im.ownerID, changes.UpdateOld),
-		Delete:    endpoint.FilterEndpointsByOwnerID(im.ownerID, changes.Delete),
+		UpdateNew: im.ownerIDTemplate.FilterEndpointsByOwnerID(im.ownerID, changes.UpdateNew),
+		UpdateOld: im.ownerIDTemplate.FilterEndpointsByOwnerID(im.ownerID, changes.UpdateOld),
+		Delete:    im.ownerIDTemplate.FilterEndpointsByOwnerID(im.ownerID, changes.Delete),
 	}
+	// the TXT records of the records whose TTL only changes are left as they are
+	ttlOnlyUpdates := filteredChanges.TTLOnlyUpdates()
 	for _, r := range filteredChanges.Create {
 		if r.Labels == nil {
 			r.Labels = make(map[string]string)
 		}
-		r.Labels[endpoint.OwnerLabelKey] = im.ownerID
+		r.Labels[endpoint.OwnerLabelKey] = im.ownerIDTemplate.OwnerID(im.ownerID, r.Labels)
 
 		filteredChanges.Create = append(filteredChanges.Create, im.generateTXTRecord(r)...)
 
@@ -286,6 +522,18 @@ func (im *TXTRegistry) ApplyChanges(ctx context.Context, changes *plan.Changes)
 		}
 	}
 
+	// the adopted records already exist, only their ownership records are created
+	for _, r := range im.ownerIDTemplate.FilterEndpointsByOwnerID(im.ownerID, changes.Adopt) {
+		log.Infof("Adopting the record %s", r)
+		filteredChanges.Create = append(filteredChanges.Create, im.generateTXTRecord(r)...)
+
+		// replace the version of the record without owner in the cache
+		if im.cacheInterval > 0 {
+			im.removeFromCache(r)
+			im.addToCache(r)
+		}
+	}
+
 	for _, r := range filteredChanges.Delete {
 		// when we delete TXT records for which value has changed (due to new label) this would still work because
 		// !!! TXT record value is uniquely generated from the Labels of the endpoint. Hence old TXT record can be uniquely reconstructed
@@ -301,7 +549,9 @@ func (im *TXTRegistry) ApplyChanges(ctx context.Context, changes *plan.Changes)
 	for _, r := range filteredChanges.UpdateOld {
 		// when we updateOld TXT records for which value has changed (due to new label) this would still work because
 		// !!! TXT record value is uniquely generated from the Labels of the endpoint. Hence old TXT record can be uniquely reconstructed
-		filteredChanges.UpdateOld = append(filteredChanges.UpdateOld, im.generateTXTRecord(r)...)
+		if !ttlOnlyUpdates[r.Key()] {
+			filteredChanges.UpdateOld = append(filteredChanges.UpdateOld, im.generateTXTRecord(r)...)
+		}
 		// remove old version of record from cache
 		if im.cacheInterval > 0 {
 			im.removeFromCache(r)
@@ -310,13 +560,19 @@ func (im *TXTRegistry) ApplyChanges(ctx context.Context, changes *plan.Changes)
 
 	// make sure TXT records are consistently updated as well
 	for _, r := range filteredChanges.UpdateNew {
-		filteredChanges.UpdateNew = append(filteredChanges.UpdateNew, im.generateTXTRecord(r)...)
+		if !ttlOnlyUpdates[r.Key()] {
+			filteredChanges.UpdateNew = append(filteredChanges.UpdateNew, im.generateTXTRecord(r)...)
+		}
 		// add new version of record to cache
 		if im.cacheInterval > 0 {
 			im.addToCache(r)
 		}
 	}
 
+	// the TXT records left by an interrupted apply are repaired along with the changes
+	repairOrphanedTXTs(filteredChanges, im.orphanedTXTs)
+	im.orphanedTXTs = nil
+
 	// when caching is enabled, disable the provider from using the cache
 	if im.cacheInterval > 0 {
 		ctx = context.WithValue(ctx, provider.RecordsContextKey, nil)
@@ -297,3 +297,55 @@ func newEndpointWithOwnerAndOwnedRecordWithKeyIDLabel(dnsName, target, recordTyp
 	e.Labels["key-id"] = keyId
 	return e
 }
+
+// prefixCipher is a TextCipher "encrypting" the texts by prefixing them, unavailable when it fails.
+type prefixCipher struct {
+	unavailable bool
+}
+
+func (c *prefixCipher) EncryptText(text string, nonceEncoded []byte) (string, error) {
+	return "enc:" + string(nonceEncoded) + ":" + text, nil
+}
+
+func (c *prefixCipher) DecryptText(text string) (string, string, error) {
+	if c.unavailable {
+		return "", "", endpoint.ErrCipherUnavailable
+	}
+	rest, ok := strings.CutPrefix(text, "enc:")
+	if !ok {
+		return "", "", fmt.Errorf("not encrypted: %s", text)
+	}
+	nonce, plain, _ := strings.Cut(rest, ":")
+	return plain, nonce, nil
+}
+
+func TestApplyRecordsWithCipher(t *testing.T) {
+	ctx := context.Background()
+	p := inmemory.NewInMemoryProvider()
+	_ = p.CreateZone("org")
+
+	// the cipher of the options is used instead of the AES key
+	c := &prefixCipher{}
+	r, err := NewTXTRegistry(p, "", "", "owner", 0, "", []string{}, []string{}, true, nil, false, WithTXTCipher(c))
+	require.NoError(t, err)
+	require.NoError(t, r.ApplyChanges(ctx, &plan.Changes{
+		Create: []*endpoint.Endpoint{newEndpointWithOwner("thing.org", "1.2.3.4", endpoint.RecordTypeA, "owner")},
+	}))
+
+	records, err := p.Records(ctx)
+	require.NoError(t, err)
+	for _, record := range records {
+		if record.RecordType == endpoint.RecordTypeTXT {
+			assert.True(t, strings.HasPrefix(record.Targets[0], `"enc:`), record.Targets[0])
+		}
+	}
+	owned, err := r.Records(ctx)
+	require.NoError(t, err)
+	require.Len(t, owned, 1)
+	assert.Equal(t, "owner", owned[0].Labels[endpoint.OwnerLabelKey])
+
+	// the records aren't listed as unowned when the cipher is unavailable
+	c.unavailable = true
+	_, err = r.Records(ctx)
+	require.ErrorIs(t, err, endpoint.ErrCipherUnavailable)
+}
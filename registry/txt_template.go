@@ -0,0 +1,132 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package registry
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"regexp"
+	"strings"
+)
+
+const (
+	hostTemplate   = "%{host}"
+	domainTemplate = "%{domain}"
+	hashTemplate   = "%{hash}"
+
+	// hashLength is the number of hexadecimal characters of the hash placed in the TXT record names
+	hashLength = 32
+)
+
+// templateNameMapper maps the endpoints to the TXT records named after a template, such as
+// `_owner.%{record_type}.%{host}.%{domain}`, where:
+//   - %{record_type} is the lowercase record type of the endpoint
+//   - %{host} is the first label of the endpoint name
+//   - %{domain} is the rest of the endpoint name
+//   - %{hash} is a hash of the record type and name of the endpoint
+type templateNameMapper struct {
+	template            string
+	wildcardReplacement string
+	// pattern matches the TXT record names to extract the endpoint names, nil for hashed templates
+	pattern *regexp.Regexp
+}
+
+var _ nameMapper = templateNameMapper{}
+
+func newTemplateNameMapper(template, wildcardReplacement string) (templateNameMapper, error) {
+	template = strings.ToLower(template)
+	if !strings.Contains(template, domainTemplate) {
+		return templateNameMapper{}, errors.New("the TXT name template must contain " + domainTemplate)
+	}
+	hashed := strings.Contains(template, hashTemplate)
+	if !hashed && !strings.Contains(template, hostTemplate) {
+		return templateNameMapper{}, errors.New("the TXT name template must contain " + hostTemplate + " or " + hashTemplate)
+	}
+	if !hashed && !strings.Contains(template, recordTemplate) {
+		return templateNameMapper{}, errors.New("the TXT name template must contain " + recordTemplate + " or " + hashTemplate)
+	}
+
+	mapper := templateNameMapper{template: template, wildcardReplacement: strings.ToLower(wildcardReplacement)}
+	if !hashed {
+		types := make([]string, 0, len(getSupportedTypes()))
+		for _, t := range getSupportedTypes() {
+			types = append(types, strings.ToLower(t))
+		}
+		pattern := regexp.QuoteMeta(template)
+		pattern = strings.Replace(pattern, regexp.QuoteMeta(recordTemplate), "(?P<type>"+strings.Join(types, "|")+")", 1)
+		pattern = strings.Replace(pattern, regexp.QuoteMeta(hostTemplate), "(?P<host>[^.]+)", 1)
+		pattern = strings.Replace(pattern, `\.`+regexp.QuoteMeta(domainTemplate), `(?:\.(?P<domain>.+))?`, 1)
+		pattern = strings.Replace(pattern, regexp.QuoteMeta(domainTemplate), "(?P<domain>.*)", 1)
+		var err error
+		if mapper.pattern, err = regexp.Compile("^" + pattern + "$"); err != nil {
+			return templateNameMapper{}, err
+		}
+	}
+	return mapper, nil
+}
+
+// toEndpointName returns the endpoint name and record type of a TXT record name, or empty strings
+// when it doesn't match the template or the template is hashed.
+func (tm templateNameMapper) toEndpointName(txtDNSName string) (endpointName string, recordType string) {
+	if tm.pattern == nil {
+		return "", ""
+	}
+	match := tm.pattern.FindStringSubmatch(strings.ToLower(txtDNSName))
+	if match == nil {
+		return "", ""
+	}
+	endpointName = match[tm.pattern.SubexpIndex("host")]
+	if domain := match[tm.pattern.SubexpIndex("domain")]; domain != "" {
+		endpointName += "." + domain
+	}
+	return endpointName, strings.ToUpper(match[tm.pattern.SubexpIndex("type")])
+}
+
+// toTXTName is not used as the template always places the record type.
+func (tm templateNameMapper) toTXTName(endpointDNSName string) string {
+	return tm.toNewTXTName(endpointDNSName, "")
+}
+
+func (tm templateNameMapper) toNewTXTName(endpointDNSName, recordType string) string {
+	DNSName := strings.SplitN(endpointDNSName, ".", 2)
+	recordType = strings.ToLower(recordType)
+
+	// If specified, replace a leading asterisk in the generated txt record name with some other string
+	if tm.wildcardReplacement != "" && DNSName[0] == "*" {
+		DNSName[0] = tm.wildcardReplacement
+	}
+	domain := ""
+	if len(DNSName) == 2 {
+		domain = DNSName[1]
+	}
+
+	name := tm.template
+	if domain == "" {
+		name = strings.Replace(name, "."+domainTemplate, "", 1)
+	}
+	if strings.Contains(name, hashTemplate) {
+		sum := sha256.Sum256([]byte(recordType + ":" + strings.ToLower(endpointDNSName)))
+		name = strings.ReplaceAll(name, hashTemplate, hex.EncodeToString(sum[:])[:hashLength])
+	}
+	return strings.NewReplacer(recordTemplate, recordType, hostTemplate, DNSName[0], domainTemplate, domain).Replace(name)
+}
+
+// recordTypeInAffix is true so that the TXT records without record type aren't generated.
+func (tm templateNameMapper) recordTypeInAffix() bool {
+	return true
+}
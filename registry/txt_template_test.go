@@ -0,0 +1,183 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package registry
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider/inmemory"
+)
+
+func TestNewTemplateNameMapper(t *testing.T) {
+	for _, tc := range []struct {
+		template string
+		wantErr  bool
+	}{
+		{template: "_owner.%{record_type}.%{host}.%{domain}"},
+		{template: "%{host}-%{record_type}.%{domain}"},
+		{template: "_owner-%{hash}.%{domain}"},
+		{template: "_owner.%{record_type}.%{host}", wantErr: true},
+		{template: "_owner.%{record_type}.%{domain}", wantErr: true},
+		{template: "_owner.%{host}.%{domain}", wantErr: true},
+	} {
+		t.Run(tc.template, func(t *testing.T) {
+			_, err := newTemplateNameMapper(tc.template, "")
+			if tc.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestTemplateNameMapper(t *testing.T) {
+	for _, tc := range []struct {
+		name                string
+		template            string
+		wildcardReplacement string
+		endpointName        string
+		recordType          string
+		txtName             string
+	}{
+		{
+			name:         "record type before host",
+			template:     "_owner.%{record_type}.%{host}.%{domain}",
+			endpointName: "www.example.com",
+			recordType:   endpoint.RecordTypeA,
+			txtName:      "_owner.a.www.example.com",
+		},
+		{
+			name:         "record type after host",
+			template:     "%{host}-%{record_type}.%{domain}",
+			endpointName: "www.example.com",
+			recordType:   endpoint.RecordTypeCNAME,
+			txtName:      "www-cname.example.com",
+		},
+		{
+			name:         "apex",
+			template:     "_owner.%{record_type}.%{host}.%{domain}",
+			endpointName: "example",
+			recordType:   endpoint.RecordTypeAAAA,
+			txtName:      "_owner.aaaa.example",
+		},
+		{
+			name:                "wildcard",
+			template:            "_owner.%{record_type}.%{host}.%{domain}",
+			wildcardReplacement: "_wildcard",
+			endpointName:        "*.example.com",
+			recordType:          endpoint.RecordTypeA,
+			txtName:             "_owner.a._wildcard.example.com",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			mapper, err := newTemplateNameMapper(tc.template, tc.wildcardReplacement)
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.txtName, mapper.toNewTXTName(tc.endpointName, tc.recordType))
+
+			endpointName, recordType := mapper.toEndpointName(tc.txtName)
+			expectedName := tc.endpointName
+			if tc.wildcardReplacement != "" {
+				expectedName = tc.wildcardReplacement + expectedName[1:]
+			}
+			assert.Equal(t, expectedName, endpointName)
+			assert.Equal(t, tc.recordType, recordType)
+		})
+	}
+}
+
+func TestTemplateNameMapperHash(t *testing.T) {
+	mapper, err := newTemplateNameMapper("_owner-%{hash}.%{domain}", "")
+	require.NoError(t, err)
+
+	txtName := mapper.toNewTXTName("www.example.com", endpoint.RecordTypeA)
+	assert.Regexp(t, `^_owner-[0-9a-f]{32}\.example\.com$`, txtName)
+	assert.NotEqual(t, txtName, mapper.toNewTXTName("www.example.com", endpoint.RecordTypeAAAA))
+	assert.NotEqual(t, txtName, mapper.toNewTXTName("api.example.com", endpoint.RecordTypeA))
+
+	endpointName, recordType := mapper.toEndpointName(txtName)
+	assert.Empty(t, endpointName)
+	assert.Empty(t, recordType)
+}
+
+func TestTXTRegistryNameTemplate(t *testing.T) {
+	p := inmemory.NewInMemoryProvider()
+	p.CreateZone(testZone)
+	ctx := context.Background()
+
+	r, err := NewTXTRegistry(p, "", "", "owner", time.Hour, "", []string{}, []string{}, false, nil, false, WithTXTNameTemplate("_owner-%{hash}.%{domain}"))
+	require.NoError(t, err)
+
+	record := newEndpointWithOwner("foo.test-zone.example.org", "1.2.3.4", endpoint.RecordTypeA, "")
+	require.NoError(t, r.ApplyChanges(ctx, &plan.Changes{Create: []*endpoint.Endpoint{record}}))
+
+	txtName := r.mapper.toNewTXTName("foo.test-zone.example.org", endpoint.RecordTypeA)
+	all, err := p.Records(ctx)
+	require.NoError(t, err)
+	var txtNames []string
+	for _, ep := range all {
+		if ep.RecordType == endpoint.RecordTypeTXT {
+			txtNames = append(txtNames, ep.DNSName)
+		}
+	}
+	assert.Equal(t, []string{txtName}, txtNames)
+
+	records, err := r.Records(ctx)
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, "owner", records[0].Labels[endpoint.OwnerLabelKey])
+}
+
+func TestTXTRegistryNameTemplateMigration(t *testing.T) {
+	p := inmemory.NewInMemoryProvider()
+	p.CreateZone(testZone)
+	ctx := context.Background()
+	p.ApplyChanges(ctx, &plan.Changes{
+		Create: []*endpoint.Endpoint{
+			newEndpointWithOwner("foo.test-zone.example.org", "1.2.3.4", endpoint.RecordTypeA, ""),
+			newEndpointWithOwner("txt-a-foo.test-zone.example.org", "\"heritage=external-dns,external-dns/owner=owner\"", endpoint.RecordTypeTXT, ""),
+		},
+	})
+
+	r, err := NewTXTRegistry(p, "txt-", "", "owner", time.Hour, "", []string{endpoint.RecordTypeA}, []string{}, false, nil, true, WithTXTNameTemplate("_owner.%{record_type}.%{host}.%{domain}"), WithTXTNameTemplateMigration(true))
+	require.NoError(t, err)
+
+	records, err := r.Records(ctx)
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, "owner", records[0].Labels[endpoint.OwnerLabelKey])
+	// the missing TXT record in the template format is created on the next update
+	value, _ := records[0].GetProviderSpecificProperty(providerSpecificForceUpdate)
+	assert.Equal(t, "true", value)
+
+	txtNames := []string{}
+	for _, ep := range r.generateTXTRecord(records[0]) {
+		txtNames = append(txtNames, ep.DNSName)
+	}
+	assert.Equal(t, []string{"_owner.a.foo.test-zone.example.org", "txt-a-foo.test-zone.example.org"}, txtNames)
+
+	_, err = NewTXTRegistry(p, "txt-", "", "owner", time.Hour, "", []string{}, []string{}, false, nil, true, WithTXTNameTemplate("_owner.%{record_type}.%{host}.%{domain}"))
+	assert.Error(t, err)
+}
@@ -1516,6 +1516,24 @@ func TestGenerateTXT(t *testing.T) {
 	assert.Equal(t, expectedTXT, gotTXT)
 }
 
+func TestGenerateTXTWithZoneID(t *testing.T) {
+	record := newEndpointWithOwner("foo.test-zone.example.org", "1.2.3.4", endpoint.RecordTypeA, "owner").
+		WithLabel(endpoint.ZoneIDLabelKey, "Z0123456789").
+		WithLabel(endpoint.ZoneSelectionLabelKey, "prefer-public")
+	p := inmemory.NewInMemoryProvider()
+	p.CreateZone(testZone)
+	r, _ := NewTXTRegistry(p, "", "", "owner", time.Hour, "", []string{}, []string{}, false, nil, false)
+
+	// the TXT records are pinned to the zone of their record
+	gotTXT := r.generateTXTRecord(record)
+	require.Len(t, gotTXT, 2)
+	for _, txt := range gotTXT {
+		assert.Equal(t, "Z0123456789", txt.Labels[endpoint.ZoneIDLabelKey])
+		assert.Equal(t, "prefer-public", txt.Labels[endpoint.ZoneSelectionLabelKey])
+		assert.Contains(t, txt.Targets[0], "external-dns/zone-id=Z0123456789")
+	}
+}
+
 func TestGenerateTXTForAAAA(t *testing.T) {
 	record := newEndpointWithOwner("foo.test-zone.example.org", "2001:DB8::1", endpoint.RecordTypeAAAA, "owner")
 	expectedTXT := []*endpoint.Endpoint{
@@ -1838,3 +1856,261 @@ func TestTXTRegistryRecordsWithEmptyTargets(t *testing.T) {
 
 	testutils.TestHelperLogContains("TXT record has no targets empty-targets.test-zone.example.org", hook, t)
 }
+
+func TestTXTRegistryRecordsFor(t *testing.T) {
+	ctx := context.Background()
+	p := inmemory.NewInMemoryProvider()
+	p.CreateZone(testZone)
+	p.CreateZone("other-zone.example.org")
+	p.ApplyChanges(ctx, &plan.Changes{
+		Create: []*endpoint.Endpoint{
+			newEndpointWithOwner("foo.test-zone.example.org", "1.2.3.4", endpoint.RecordTypeA, ""),
+			newEndpointWithOwner("a-foo.test-zone.example.org", "\"heritage=external-dns,external-dns/owner=owner\"", endpoint.RecordTypeTXT, ""),
+			newEndpointWithOwner("bar.test-zone.example.org", "1.2.3.4", endpoint.RecordTypeA, ""),
+			newEndpointWithOwner("foo.other-zone.example.org", "1.2.3.4", endpoint.RecordTypeA, ""),
+		},
+	})
+
+	r, _ := NewTXTRegistry(p, "", "", "owner", time.Hour, "", []string{}, []string{}, false, nil, false)
+
+	records, err := r.RecordsFor(ctx, RecordsFilter{Zone: testZone, Names: []string{"foo.test-zone.example.org"}})
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, "foo.test-zone.example.org", records[0].DNSName)
+	assert.Equal(t, "owner", records[0].Labels[endpoint.OwnerLabelKey])
+
+	records, err = r.RecordsFor(ctx, RecordsFilter{Zone: testZone})
+	require.NoError(t, err)
+	assert.Len(t, records, 2)
+
+	// the records cache is left untouched
+	assert.Nil(t, r.recordsCache)
+}
+
+func TestTXTRegistryStreamRecords(t *testing.T) {
+	ctx := context.Background()
+	p := inmemory.NewInMemoryProvider()
+	p.CreateZone(testZone)
+	p.CreateZone("other-zone.example.org")
+	p.ApplyChanges(ctx, &plan.Changes{
+		Create: []*endpoint.Endpoint{
+			newEndpointWithOwner("foo.test-zone.example.org", "1.2.3.4", endpoint.RecordTypeA, ""),
+			newEndpointWithOwner("a-foo.test-zone.example.org", "\"heritage=external-dns,external-dns/owner=owner\"", endpoint.RecordTypeTXT, ""),
+			newEndpointWithOwner("foo.other-zone.example.org", "1.2.3.4", endpoint.RecordTypeA, ""),
+		},
+	})
+
+	r, _ := NewTXTRegistry(p, "", "", "owner", time.Hour, "", []string{}, []string{}, false, nil, false)
+
+	names, err := r.ZoneNames(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"other-zone.example.org", testZone}, names)
+
+	owners := map[string]string{}
+	err = r.StreamRecords(ctx, func(zone string, records []*endpoint.Endpoint) error {
+		require.Len(t, records, 1)
+		owners[zone] = records[0].Labels[endpoint.OwnerLabelKey]
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{testZone: "owner", "other-zone.example.org": ""}, owners)
+	assert.Nil(t, r.recordsCache)
+}
+
+func TestTXTRegistryApplyChangesAdopt(t *testing.T) {
+	ctx := context.Background()
+	p := inmemory.NewInMemoryProvider()
+	p.CreateZone(testZone)
+	p.ApplyChanges(ctx, &plan.Changes{
+		Create: []*endpoint.Endpoint{
+			newEndpointWithOwner("foo.test-zone.example.org", "1.2.3.4", endpoint.RecordTypeA, ""),
+		},
+	})
+
+	r, _ := NewTXTRegistry(p, "", "", "owner", time.Hour, "", []string{}, []string{}, false, nil, false)
+
+	records, err := r.Records(ctx)
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Empty(t, records[0].Labels[endpoint.OwnerLabelKey])
+
+	// only the ownership record of the adopted record is created
+	var applied *plan.Changes
+	p.OnApplyChanges = func(_ context.Context, got *plan.Changes) { applied = got }
+	adopted := newEndpointWithOwner("foo.test-zone.example.org", "1.2.3.4", endpoint.RecordTypeA, "owner")
+	require.NoError(t, r.ApplyChanges(ctx, &plan.Changes{Adopt: []*endpoint.Endpoint{adopted}}))
+	require.NotNil(t, applied)
+	assert.Empty(t, applied.Adopt)
+	var created []string
+	for _, ep := range applied.Create {
+		assert.Equal(t, endpoint.RecordTypeTXT, ep.RecordType)
+		created = append(created, ep.DNSName)
+	}
+	assert.ElementsMatch(t, []string{"foo.test-zone.example.org", "a-foo.test-zone.example.org"}, created)
+
+	records, err = r.Records(ctx)
+	require.NoError(t, err)
+	for _, record := range records {
+		if record.RecordType == endpoint.RecordTypeA {
+			assert.Equal(t, "owner", record.Labels[endpoint.OwnerLabelKey])
+		}
+	}
+}
+
+func TestTXTRegistryOwnerIDTemplate(t *testing.T) {
+	ownerIDTemplate, err := endpoint.NewOwnerIDTemplate("{{ .OwnerID }}-{{ .Namespace }}")
+	require.NoError(t, err)
+	ctx := context.Background()
+	p := inmemory.NewInMemoryProvider()
+	p.CreateZone(testZone)
+
+	r, _ := NewTXTRegistry(p, "", "", "cluster", time.Hour, "", []string{}, []string{}, false, nil, false, WithOwnerIDTemplate(ownerIDTemplate))
+	require.NoError(t, r.ApplyChanges(ctx, &plan.Changes{
+		Create: []*endpoint.Endpoint{
+			newEndpointWithOwnerResource("a.test-zone.example.org", "1.2.3.4", endpoint.RecordTypeA, "", "service/team-a/web"),
+			newEndpointWithOwnerResource("b.test-zone.example.org", "1.2.3.4", endpoint.RecordTypeA, "", "service/team-b/web"),
+		},
+	}))
+
+	// the records of each tenant are owned by the owner ID derived from their resource
+	owners := map[string]string{}
+	records, err := r.Records(ctx)
+	require.NoError(t, err)
+	for _, record := range records {
+		assert.True(t, ownerIDTemplate.IsOwnedBy("cluster", record.Labels))
+		owners[record.DNSName] = record.Labels[endpoint.OwnerLabelKey]
+	}
+	assert.Equal(t, map[string]string{"a.test-zone.example.org": "cluster-team-a", "b.test-zone.example.org": "cluster-team-b"}, owners)
+
+	// the records of a tenant are owned by a separate instance with the owner ID of this tenant
+	r, _ = NewTXTRegistry(p, "", "", "cluster-team-a", time.Hour, "", []string{}, []string{}, false, nil, false)
+	records, err = r.Records(ctx)
+	require.NoError(t, err)
+	var owned []string
+	for _, record := range records {
+		if record.IsOwnedBy("cluster-team-a") {
+			owned = append(owned, record.DNSName)
+		}
+	}
+	assert.Equal(t, []string{"a.test-zone.example.org"}, owned)
+}
+
+func TestTXTRegistryApplyChangesTTLOnly(t *testing.T) {
+	ctx := context.Background()
+	p := inmemory.NewInMemoryProvider()
+	p.CreateZone(testZone)
+	r, _ := NewTXTRegistry(p, "", "", "owner", 0, "", []string{}, []string{}, false, nil, false)
+	require.NoError(t, r.ApplyChanges(ctx, &plan.Changes{
+		Create: []*endpoint.Endpoint{newEndpointWithOwner("foo.test-zone.example.org", "1.2.3.4", endpoint.RecordTypeA, "")},
+	}))
+
+	var applied *plan.Changes
+	p.OnApplyChanges = func(_ context.Context, got *plan.Changes) { applied = got }
+	old := newEndpointWithOwner("foo.test-zone.example.org", "1.2.3.4", endpoint.RecordTypeA, "owner")
+	updated := newEndpointWithOwner("foo.test-zone.example.org", "1.2.3.4", endpoint.RecordTypeA, "owner")
+	updated.RecordTTL = 300
+	require.NoError(t, r.ApplyChanges(ctx, &plan.Changes{
+		UpdateOld: []*endpoint.Endpoint{old},
+		UpdateNew: []*endpoint.Endpoint{updated},
+	}))
+
+	// the ownership records are not rewritten when only the TTL changes
+	require.NotNil(t, applied)
+	require.Len(t, applied.UpdateNew, 1)
+	require.Len(t, applied.UpdateOld, 1)
+	assert.Equal(t, endpoint.RecordTypeA, applied.UpdateNew[0].RecordType)
+	assert.Equal(t, endpoint.TTL(300), applied.UpdateNew[0].RecordTTL)
+}
+
+func TestTXTRegistryApplyChangesOrphanedTXT(t *testing.T) {
+	ctx := context.Background()
+	p := inmemory.NewInMemoryProvider()
+	p.CreateZone(testZone)
+	require.NoError(t, p.ApplyChanges(ctx, &plan.Changes{
+		Create: []*endpoint.Endpoint{
+			// the ownership records of foo, whose record creation was interrupted
+			newEndpointWithOwner("foo.test-zone.example.org", "\"heritage=external-dns,external-dns/owner=owner\"", endpoint.RecordTypeTXT, ""),
+			newEndpointWithOwner("a-foo.test-zone.example.org", "\"heritage=external-dns,external-dns/owner=owner\"", endpoint.RecordTypeTXT, ""),
+			// the ownership record of a record which is gone
+			newEndpointWithOwner("a-gone.test-zone.example.org", "\"heritage=external-dns,external-dns/owner=owner\"", endpoint.RecordTypeTXT, ""),
+			// the ownership record of another instance
+			newEndpointWithOwner("a-other.test-zone.example.org", "\"heritage=external-dns,external-dns/owner=other\"", endpoint.RecordTypeTXT, ""),
+			// a record whose ownership records are kept, whatever their record type
+			newEndpointWithOwner("bar.test-zone.example.org", "bar.loadbalancer.com", endpoint.RecordTypeCNAME, ""),
+			newEndpointWithOwner("cname-bar.test-zone.example.org", "\"heritage=external-dns,external-dns/owner=owner\"", endpoint.RecordTypeTXT, ""),
+			newEndpointWithOwner("a-bar.test-zone.example.org", "\"heritage=external-dns,external-dns/owner=owner\"", endpoint.RecordTypeTXT, ""),
+		},
+	}))
+	r, _ := NewTXTRegistry(p, "", "", "owner", 0, "", []string{}, []string{}, false, nil, false)
+	_, err := r.Records(ctx)
+	require.NoError(t, err)
+
+	var applied *plan.Changes
+	p.OnApplyChanges = func(_ context.Context, got *plan.Changes) { applied = got }
+	require.NoError(t, r.ApplyChanges(ctx, &plan.Changes{
+		Create: []*endpoint.Endpoint{newEndpointWithOwner("foo.test-zone.example.org", "1.2.3.4", endpoint.RecordTypeA, "")},
+	}))
+
+	// the orphaned ownership records of foo are replaced rather than created, the one of gone is
+	// left to the janitor
+	require.NotNil(t, applied)
+	require.Len(t, applied.Create, 1)
+	assert.Equal(t, endpoint.RecordTypeA, applied.Create[0].RecordType)
+	require.Len(t, applied.UpdateOld, 2)
+	require.Len(t, applied.UpdateNew, 2)
+	for i, old := range applied.UpdateOld {
+		assert.Equal(t, old.DNSName, applied.UpdateNew[i].DNSName)
+	}
+	assert.Empty(t, applied.Delete)
+
+	records, err := r.Records(ctx)
+	require.NoError(t, err)
+	owners := map[string]string{}
+	for _, record := range records {
+		owners[record.DNSName] = record.Labels[endpoint.OwnerLabelKey]
+	}
+	assert.Equal(t, map[string]string{
+		"foo.test-zone.example.org": "owner",
+		"bar.test-zone.example.org": "owner",
+	}, owners)
+
+	orphans, err := r.OrphanedRecords(ctx, []string{"owner"})
+	require.NoError(t, err)
+	require.Len(t, orphans, 1)
+	assert.Equal(t, "a-gone.test-zone.example.org", orphans[0].DNSName)
+}
+
+func TestTXTRegistryOrphanedRecords(t *testing.T) {
+	ctx := context.Background()
+	p := inmemory.NewInMemoryProvider()
+	p.CreateZone(testZone)
+	require.NoError(t, p.ApplyChanges(ctx, &plan.Changes{
+		Create: []*endpoint.Endpoint{
+			newEndpointWithOwner("foo.test-zone.example.org", "1.2.3.4", endpoint.RecordTypeA, ""),
+			newEndpointWithOwner("a-foo.test-zone.example.org", "\"heritage=external-dns,external-dns/owner=owner\"", endpoint.RecordTypeTXT, ""),
+			newEndpointWithOwner("a-gone.test-zone.example.org", "\"heritage=external-dns,external-dns/owner=owner\"", endpoint.RecordTypeTXT, ""),
+			newEndpointWithOwner("cname-old.test-zone.example.org", "\"heritage=external-dns,external-dns/owner=old-cluster\"", endpoint.RecordTypeTXT, ""),
+			newEndpointWithOwner("a-other.test-zone.example.org", "\"heritage=external-dns,external-dns/owner=other\"", endpoint.RecordTypeTXT, ""),
+			// a TXT record which isn't an ownership record is a record too
+			newEndpointWithOwner("spf.test-zone.example.org", "v=spf1 -all", endpoint.RecordTypeTXT, ""),
+			newEndpointWithOwner("txt-spf.test-zone.example.org", "\"heritage=external-dns,external-dns/owner=owner\"", endpoint.RecordTypeTXT, ""),
+		},
+	}))
+	r, _ := NewTXTRegistry(p, "", "", "owner", 0, "", []string{}, []string{}, false, nil, false)
+
+	orphans, err := r.OrphanedRecords(ctx, []string{"owner", "old-cluster"})
+	require.NoError(t, err)
+	var names []string
+	for _, orphan := range orphans {
+		names = append(names, orphan.DNSName+"/"+orphan.Labels[endpoint.OwnerLabelKey])
+	}
+	assert.Equal(t, []string{"a-gone.test-zone.example.org/owner", "cname-old.test-zone.example.org/old-cluster"}, names)
+
+	require.NoError(t, r.DeleteOrphanedRecords(ctx, orphans))
+	orphans, err = r.OrphanedRecords(ctx, []string{"owner", "old-cluster"})
+	require.NoError(t, err)
+	assert.Empty(t, orphans)
+	records, err := p.Records(ctx)
+	require.NoError(t, err)
+	assert.Len(t, records, 5)
+}
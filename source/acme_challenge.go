@@ -0,0 +1,163 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	kubeinformers "k8s.io/client-go/informers"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/pkg/decisions"
+	"sigs.k8s.io/external-dns/source/informers"
+)
+
+const (
+	// acmeChallengePrefix is the label of the TXT records checked by the ACME DNS-01 challenges.
+	acmeChallengePrefix = "_acme-challenge."
+	// acmeChallengeTTL is the TTL of the challenge records, short so that a retried
+	// challenge sees its new key quickly.
+	acmeChallengeTTL = endpoint.TTL(60)
+)
+
+// acmeChallengeSource is an implementation of Source that publishes the TXT records of
+// the ACME DNS-01 challenges presented by cert-manager, so that cert-manager doesn't
+// need credentials of its own for the DNS provider.
+type acmeChallengeSource struct {
+	challengeInformer kubeinformers.GenericInformer
+	namespace         string
+	labelSelector     labels.Selector
+	solverName        string
+	filterDecisions   *decisions.Recorder
+	propagatedLabels  PropagatedLabels
+}
+
+// NewACMEChallengeSource creates a new acmeChallengeSource publishing the DNS-01 Challenges
+// of the given namespace. When solverName is set, only the Challenges solved by the webhook
+// solver of that name are published.
+func NewACMEChallengeSource(ctx context.Context, dynamicKubeClient dynamic.Interface, namespace string, labelSelector labels.Selector, solverName string, filterDecisions *decisions.Recorder, resyncPeriod time.Duration, propagatedLabels PropagatedLabels) (Source, error) {
+	informerFactory := dynamicinformer.NewFilteredDynamicSharedInformerFactory(dynamicKubeClient, resyncPeriod, namespace, labelFilterListOptions(labelSelector, filterDecisions))
+	challengeInformer := informerFactory.ForResource(challengeGVR)
+	// Add default resource event handlers to properly initialize informer.
+	_, _ = challengeInformer.Informer().AddEventHandler(eventHandlerFunc(func() {}))
+
+	informerFactory.Start(ctx.Done())
+
+	// wait for the local cache to be populated.
+	if err := informers.WaitForDynamicCacheSync(context.Background(), informerFactory); err != nil {
+		return nil, err
+	}
+
+	if labelSelector == nil {
+		labelSelector = labels.Everything()
+	}
+
+	return &acmeChallengeSource{
+		filterDecisions:   filterDecisions,
+		challengeInformer: challengeInformer,
+		namespace:         namespace,
+		labelSelector:     labelSelector,
+		solverName:        solverName,
+		propagatedLabels:  propagatedLabels,
+	}, nil
+}
+
+// Endpoints returns a TXT endpoint for each name with DNS-01 Challenges, holding the keys
+// of all of its Challenges, e.g. those of a domain and of its wildcard.
+func (as *acmeChallengeSource) Endpoints(_ context.Context) ([]*endpoint.Endpoint, error) {
+	objs, err := as.challengeInformer.Lister().ByNamespace(as.namespace).List(as.labelSelector)
+	if err != nil {
+		return nil, err
+	}
+
+	challenges := make([]*unstructured.Unstructured, 0, len(objs))
+	for _, obj := range objs {
+		u, ok := obj.(*unstructured.Unstructured)
+		if !ok {
+			return nil, errors.New("could not convert")
+		}
+		challenges = append(challenges, u)
+	}
+	// the record of a name shared by several Challenges is labelled with the first one, and gets its
+	// propagated labels.
+	sort.Slice(challenges, func(i, j int) bool {
+		if challenges[i].GetNamespace() != challenges[j].GetNamespace() {
+			return challenges[i].GetNamespace() < challenges[j].GetNamespace()
+		}
+		return challenges[i].GetName() < challenges[j].GetName()
+	})
+
+	byName := map[string]*endpoint.Endpoint{}
+	for _, u := range challenges {
+		resource := fmt.Sprintf("challenge/%s/%s", u.GetNamespace(), u.GetName())
+		if challengeType, _, _ := unstructured.NestedString(u.Object, "spec", "type"); challengeType != "DNS-01" {
+			continue
+		}
+		if as.solverName != "" {
+			solverName, _, _ := unstructured.NestedString(u.Object, "spec", "solver", "dns01", "webhook", "solverName")
+			if solverName != as.solverName {
+				log.Debugf("Skipping %s solved by %q", resource, solverName)
+				continue
+			}
+		}
+		dnsName, _, _ := unstructured.NestedString(u.Object, "spec", "dnsName")
+		key, _, _ := unstructured.NestedString(u.Object, "spec", "key")
+		if dnsName == "" || key == "" {
+			log.Debugf("No endpoints could be generated from %s", resource)
+			continue
+		}
+
+		name := acmeChallengePrefix + strings.ToLower(strings.TrimSuffix(dnsName, "."))
+		target := `"` + key + `"`
+		ep, ok := byName[name]
+		if !ok {
+			ep = endpoint.NewEndpointWithTTL(name, endpoint.RecordTypeTXT, acmeChallengeTTL).WithLabel(endpoint.ResourceLabelKey, resource)
+			as.propagatedLabels.propagate([]*endpoint.Endpoint{ep}, u.GetLabels())
+			byName[name] = ep
+		}
+		if !slices.Contains(ep.Targets, target) {
+			ep.Targets = append(ep.Targets, target)
+		}
+	}
+
+	endpoints := make([]*endpoint.Endpoint, 0, len(byName))
+	for _, ep := range byName {
+		sort.Sort(ep.Targets)
+		endpoints = append(endpoints, ep)
+	}
+	sort.Slice(endpoints, func(i, j int) bool {
+		return endpoints[i].DNSName < endpoints[j].DNSName
+	})
+	return endpoints, nil
+}
+
+func (as *acmeChallengeSource) AddEventHandler(_ context.Context, handler func()) {
+	log.Debug("Adding event handler for ACME challenges")
+
+	_, _ = as.challengeInformer.Informer().AddEventHandler(eventHandlerFunc(handler))
+}
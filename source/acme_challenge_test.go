@@ -0,0 +1,126 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	fakeDynamic "k8s.io/client-go/dynamic/fake"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+// Validates that acmeChallengeSource is a Source
+var _ Source = &acmeChallengeSource{}
+
+func newDNS01Challenge(namespace, name, challengeType, dnsName, key, solverName string) runtime.Object {
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "acme.cert-manager.io/v1",
+		"kind":       "Challenge",
+		"metadata":   map[string]interface{}{"namespace": namespace, "name": name},
+		"spec": map[string]interface{}{
+			"type":    challengeType,
+			"dnsName": dnsName,
+			"key":     key,
+			"solver": map[string]interface{}{
+				"dns01": map[string]interface{}{
+					"webhook": map[string]interface{}{"groupName": "acme.example.org", "solverName": solverName},
+				},
+			},
+		},
+	}}
+}
+
+func TestACMEChallengeSourceEndpoints(t *testing.T) {
+	for _, tc := range []struct {
+		title      string
+		namespace  string
+		solverName string
+		challenges []runtime.Object
+		expected   []*endpoint.Endpoint
+	}{
+		{
+			title: "no challenges",
+		},
+		{
+			title: "dns-01 challenge",
+			challenges: []runtime.Object{
+				newDNS01Challenge("default", "foo", "DNS-01", "Foo.example.org", "key", "external-dns"),
+			},
+			expected: []*endpoint.Endpoint{
+				endpoint.NewEndpointWithTTL("_acme-challenge.foo.example.org", endpoint.RecordTypeTXT, 60, `"key"`).WithLabel(endpoint.ResourceLabelKey, "challenge/default/foo"),
+			},
+		},
+		{
+			title: "domain and wildcard challenges share a record",
+			challenges: []runtime.Object{
+				newDNS01Challenge("default", "foo", "DNS-01", "example.org", "key-2", "external-dns"),
+				newDNS01Challenge("default", "foo-wildcard", "DNS-01", "example.org", "key-1", "external-dns"),
+			},
+			expected: []*endpoint.Endpoint{
+				endpoint.NewEndpointWithTTL("_acme-challenge.example.org", endpoint.RecordTypeTXT, 60, `"key-1"`, `"key-2"`).WithLabel(endpoint.ResourceLabelKey, "challenge/default/foo"),
+			},
+		},
+		{
+			title: "http-01 and incomplete challenges are ignored",
+			challenges: []runtime.Object{
+				newDNS01Challenge("default", "foo", "HTTP-01", "foo.example.org", "key", ""),
+				newDNS01Challenge("default", "bar", "DNS-01", "bar.example.org", "", "external-dns"),
+			},
+		},
+		{
+			title:      "challenges of other solvers are ignored",
+			solverName: "external-dns",
+			challenges: []runtime.Object{
+				newDNS01Challenge("default", "foo", "DNS-01", "foo.example.org", "key", "external-dns"),
+				newDNS01Challenge("default", "bar", "DNS-01", "bar.example.org", "key", "other"),
+			},
+			expected: []*endpoint.Endpoint{
+				endpoint.NewEndpointWithTTL("_acme-challenge.foo.example.org", endpoint.RecordTypeTXT, 60, `"key"`).WithLabel(endpoint.ResourceLabelKey, "challenge/default/foo"),
+			},
+		},
+		{
+			title:     "challenges of other namespaces are ignored",
+			namespace: "default",
+			challenges: []runtime.Object{
+				newDNS01Challenge("default", "foo", "DNS-01", "foo.example.org", "key", "external-dns"),
+				newDNS01Challenge("other", "bar", "DNS-01", "bar.example.org", "key", "external-dns"),
+			},
+			expected: []*endpoint.Endpoint{
+				endpoint.NewEndpointWithTTL("_acme-challenge.foo.example.org", endpoint.RecordTypeTXT, 60, `"key"`).WithLabel(endpoint.ResourceLabelKey, "challenge/default/foo"),
+			},
+		},
+	} {
+		t.Run(tc.title, func(t *testing.T) {
+			dynamicClient := fakeDynamic.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{challengeGVR: "ChallengeList"}, tc.challenges...)
+
+			source, err := NewACMEChallengeSource(context.Background(), dynamicClient, tc.namespace, labels.Everything(), tc.solverName, nil, 0, nil)
+			require.NoError(t, err)
+
+			endpoints, err := source.Endpoints(context.Background())
+			require.NoError(t, err)
+
+			validateEndpoints(t, endpoints, tc.expected)
+		})
+	}
+}
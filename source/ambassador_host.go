@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"sort"
 	"strings"
+	"time"
 
 	ambassador "github.com/datawire/ambassador/pkg/api/getambassador.io/v2"
 	log "github.com/sirupsen/logrus"
@@ -38,6 +39,7 @@ import (
 	"k8s.io/client-go/tools/cache"
 
 	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/pkg/decisions"
 	"sigs.k8s.io/external-dns/source/annotations"
 	"sigs.k8s.io/external-dns/source/informers"
 )
@@ -64,6 +66,8 @@ type ambassadorHostSource struct {
 	ambassadorHostInformer kubeinformers.GenericInformer
 	unstructuredConverter  *unstructuredConverter
 	labelSelector          labels.Selector
+	filterDecisions        *decisions.Recorder
+	propagatedLabels       PropagatedLabels
 }
 
 // NewAmbassadorHostSource creates a new ambassadorHostSource with the given config.
@@ -74,12 +78,16 @@ func NewAmbassadorHostSource(
 	namespace string,
 	annotationFilter string,
 	labelSelector labels.Selector,
+	filterDecisions *decisions.Recorder,
+	resyncPeriod time.Duration,
+	prefixAliases annotations.PrefixAliases,
+	propagatedLabels PropagatedLabels,
 ) (Source, error) {
 	var err error
 
 	// Use shared informer to listen for add/update/delete of Host in the specified namespace.
 	// Set resync period to 0, to prevent processing when nothing has changed.
-	informerFactory := dynamicinformer.NewFilteredDynamicSharedInformerFactory(dynamicKubeClient, 0, namespace, nil)
+	informerFactory := dynamicinformer.NewFilteredDynamicSharedInformerFactory(dynamicKubeClient, resyncPeriod, namespace, labelFilterListOptions(labelSelector, filterDecisions))
 	ambassadorHostInformer := informerFactory.ForResource(ambHostGVR)
 
 	// Add default resource event handlers to properly initialize informer.
@@ -90,6 +98,8 @@ func NewAmbassadorHostSource(
 		},
 	)
 
+	aliasAnnotations(prefixAliases, ambassadorHostInformer.Informer())
+
 	informerFactory.Start(ctx.Done())
 
 	// wait for the local cache to be populated.
@@ -103,6 +113,7 @@ func NewAmbassadorHostSource(
 	}
 
 	return &ambassadorHostSource{
+		filterDecisions:        filterDecisions,
 		dynamicKubeClient:      dynamicKubeClient,
 		kubeClient:             kubeClient,
 		namespace:              namespace,
@@ -110,6 +121,7 @@ func NewAmbassadorHostSource(
 		ambassadorHostInformer: ambassadorHostInformer,
 		unstructuredConverter:  uc,
 		labelSelector:          labelSelector,
+		propagatedLabels:       propagatedLabels,
 	}, nil
 }
 
@@ -174,6 +186,7 @@ func (sc *ambassadorHostSource) Endpoints(ctx context.Context) ([]*endpoint.Endp
 			continue
 		}
 
+		sc.propagatedLabels.propagate(hostEndpoints, host.Labels)
 		log.Debugf("Endpoints generated from Host: %s: %v", fullname, hostEndpoints)
 		endpoints = append(endpoints, hostEndpoints...)
 	}
@@ -0,0 +1,86 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package annotations
+
+import (
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+)
+
+// Prefix is the prefix of the annotations of external-dns.
+const Prefix = "external-dns.alpha.kubernetes.io/"
+
+// PrefixAliases are the annotation prefixes which are aliases of Prefix, e.g. dns.mycorp.io/
+// to read dns.mycorp.io/hostname as external-dns.alpha.kubernetes.io/hostname.
+type PrefixAliases []string
+
+// NewPrefixAliases returns the prefix aliases of the given prefixes, adding the trailing slash
+// of the ones missing it and dropping Prefix itself.
+func NewPrefixAliases(prefixes []string) PrefixAliases {
+	aliases := make(PrefixAliases, 0, len(prefixes))
+	for _, prefix := range prefixes {
+		if !strings.HasSuffix(prefix, "/") {
+			prefix += "/"
+		}
+		if prefix != Prefix {
+			aliases = append(aliases, prefix)
+		}
+	}
+	return aliases
+}
+
+// Alias returns the annotations with the annotations of the alias prefixes renamed to Prefix.
+// The annotations of Prefix take precedence over their aliases, and the aliases over the ones
+// of the following aliases. The annotations are returned as is when there's nothing to rename.
+func (a PrefixAliases) Alias(annotations map[string]string) map[string]string {
+	if len(a) == 0 {
+		return annotations
+	}
+
+	var aliased map[string]string
+	for _, alias := range a {
+		for key, value := range annotations {
+			name, ok := strings.CutPrefix(key, alias)
+			if !ok {
+				continue
+			}
+			if aliased == nil {
+				aliased = make(map[string]string, len(annotations))
+				for k, v := range annotations {
+					aliased[k] = v
+				}
+			}
+			if _, exists := aliased[Prefix+name]; !exists {
+				aliased[Prefix+name] = value
+			}
+		}
+	}
+	if aliased == nil {
+		return annotations
+	}
+	return aliased
+}
+
+// Transform is a transform of the informers, aliasing the annotations of the objects before
+// they are cached.
+func (a PrefixAliases) Transform(obj any) (any, error) {
+	if accessor, err := meta.Accessor(obj); err == nil {
+		accessor.SetAnnotations(a.Alias(accessor.GetAnnotations()))
+	}
+	return obj, nil
+}
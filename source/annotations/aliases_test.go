@@ -0,0 +1,91 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package annotations
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestAlias(t *testing.T) {
+	annotations := map[string]string{"dns.mycorp.io/hostname": "web.example.org"}
+	assert.Equal(t, annotations, PrefixAliases(nil).Alias(annotations), "no aliases")
+
+	aliases := NewPrefixAliases([]string{"dns.mycorp.io", "legacy.mycorp.io/", Prefix})
+	assert.Equal(t, PrefixAliases{"dns.mycorp.io/", "legacy.mycorp.io/"}, aliases)
+	for _, tc := range []struct {
+		title       string
+		annotations map[string]string
+		expected    map[string]string
+	}{
+		{
+			title: "nil",
+		},
+		{
+			title:       "no aliased annotation",
+			annotations: map[string]string{HostnameKey: "web.example.org", "team": "web"},
+			expected:    map[string]string{HostnameKey: "web.example.org", "team": "web"},
+		},
+		{
+			title:       "aliased annotations",
+			annotations: map[string]string{"dns.mycorp.io/hostname": "web.example.org", "legacy.mycorp.io/ttl": "60"},
+			expected: map[string]string{
+				"dns.mycorp.io/hostname": "web.example.org",
+				"legacy.mycorp.io/ttl":   "60",
+				HostnameKey:              "web.example.org",
+				TtlKey:                   "60",
+			},
+		},
+		{
+			title:       "standard annotation takes precedence",
+			annotations: map[string]string{"dns.mycorp.io/hostname": "alias.example.org", HostnameKey: "web.example.org"},
+			expected:    map[string]string{"dns.mycorp.io/hostname": "alias.example.org", HostnameKey: "web.example.org"},
+		},
+		{
+			title:       "first alias takes precedence",
+			annotations: map[string]string{"legacy.mycorp.io/hostname": "legacy.example.org", "dns.mycorp.io/hostname": "web.example.org"},
+			expected: map[string]string{
+				"legacy.mycorp.io/hostname": "legacy.example.org",
+				"dns.mycorp.io/hostname":    "web.example.org",
+				HostnameKey:                 "web.example.org",
+			},
+		},
+	} {
+		t.Run(tc.title, func(t *testing.T) {
+			assert.Equal(t, tc.expected, aliases.Alias(tc.annotations))
+		})
+	}
+}
+
+func TestTransform(t *testing.T) {
+	aliases := NewPrefixAliases([]string{"dns.mycorp.io/"})
+
+	obj, err := aliases.Transform(&corev1.Service{ObjectMeta: metav1.ObjectMeta{
+		Annotations: map[string]string{"dns.mycorp.io/hostname": "web.example.org"},
+	}})
+	require.NoError(t, err)
+	assert.Equal(t, "web.example.org", obj.(*corev1.Service).Annotations[HostnameKey])
+
+	// objects without metadata, such as the tombstones of the deleted objects, are kept as is
+	obj, err = aliases.Transform("tombstone")
+	require.NoError(t, err)
+	assert.Equal(t, "tombstone", obj)
+}
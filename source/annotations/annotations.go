@@ -26,6 +26,7 @@ const (
 
 	AWSPrefix        = "external-dns.alpha.kubernetes.io/aws-"
 	SCWPrefix        = "external-dns.alpha.kubernetes.io/scw-"
+	LinodePrefix     = "external-dns.alpha.kubernetes.io/linode-"
 	WebhookPrefix    = "external-dns.alpha.kubernetes.io/webhook-"
 	CloudflarePrefix = "external-dns.alpha.kubernetes.io/cloudflare-"
 
@@ -47,8 +48,30 @@ const (
 	// The annotation used to determine the source of hostnames for ingresses.  This is an optional field - all
 	// available hostname sources are used if not specified.
 	IngressHostnameSourceKey = "external-dns.alpha.kubernetes.io/ingress-hostname-source"
+	// The annotation used for generating a hostname per path backend of ingresses from a template
+	IngressPathHostnameTemplateKey = "external-dns.alpha.kubernetes.io/ingress-path-hostname-template"
 	// The value of the controller annotation so that we feel responsible
 	ControllerValue = "dns-controller"
 	// The annotation used for defining the desired hostname
 	InternalHostnameKey = "external-dns.alpha.kubernetes.io/internal-hostname"
+	// The annotation used for selecting the nodes whose IPs are published for NodePort services
+	NodePortNodeSelectorKey = "external-dns.alpha.kubernetes.io/nodeport-node-selector"
+	// The annotation used for limiting the number of nodes whose IPs are published for NodePort services
+	NodePortMaxNodesKey = "external-dns.alpha.kubernetes.io/nodeport-max-nodes"
+	// The annotation used for overriding the node address preference of a node
+	NodeAddressPreferenceKey = "external-dns.alpha.kubernetes.io/node-address-preference"
+	// The annotation used for generating a hostname per pod from a template
+	PodHostnameTemplateKey = "external-dns.alpha.kubernetes.io/pod-hostname-template"
+	// The annotation used for setting the weight of the records of a Gateway or of its listeners
+	GatewayWeightKey = "external-dns.alpha.kubernetes.io/gateway-weight"
+	// The annotation used for choosing between A and AAAA records when both exist
+	IPFamilyKey = "external-dns.alpha.kubernetes.io/ip-family"
+	// The annotation of a namespace used for opting out of the default hostnames of its LoadBalancer services
+	DefaultHostnamesKey = "external-dns.alpha.kubernetes.io/default-hostnames"
+	// The annotation used for describing the records, written to the record comments of the providers supporting them
+	CommentKey = "external-dns.alpha.kubernetes.io/comment"
+	// The annotation used for pinning the records to a zone of the provider by its ID, when several zones match them
+	ZoneIDKey = "external-dns.alpha.kubernetes.io/zone-id"
+	// The annotation used for choosing the zones of the records when both public and private zones match them
+	ZoneSelectionKey = "external-dns.alpha.kubernetes.io/zone-selection"
 )
@@ -14,6 +14,7 @@ limitations under the License.
 package annotations
 
 import (
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -21,6 +22,8 @@ import (
 	log "github.com/sirupsen/logrus"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/yaml"
 
 	"sigs.k8s.io/external-dns/endpoint"
 )
@@ -32,11 +35,16 @@ func hasAliasFromAnnotations(annotations map[string]string) bool {
 
 // TTLFromAnnotations extracts the TTL from the annotations of the given resource.
 func TTLFromAnnotations(annotations map[string]string, resource string) endpoint.TTL {
-	ttlNotConfigured := endpoint.TTL(0)
 	ttlAnnotation, ok := annotations[TtlKey]
 	if !ok {
-		return ttlNotConfigured
+		return endpoint.TTL(0)
 	}
+	return ttlFromValue(ttlAnnotation, resource)
+}
+
+// ttlFromValue parses and validates a TTL value of the given resource.
+func ttlFromValue(ttlAnnotation string, resource string) endpoint.TTL {
+	ttlNotConfigured := endpoint.TTL(0)
 	ttlValue, err := parseTTL(ttlAnnotation)
 	if err != nil {
 		log.Warnf("%s: %q is not a valid TTL value: %v", resource, ttlAnnotation, err)
@@ -101,7 +109,133 @@ func TargetsFromTargetAnnotation(annotations map[string]string) endpoint.Targets
 // HostnamesFromAnnotations extracts the hostnames from the given annotations map.
 // It returns a slice of hostnames if the HostnameKey annotation is present, otherwise it returns nil.
 func HostnamesFromAnnotations(input map[string]string) []string {
-	return extractHostnamesFromAnnotations(input, HostnameKey)
+	if !isStructuredHostnameAnnotation(input[HostnameKey]) {
+		return extractHostnamesFromAnnotations(input, HostnameKey)
+	}
+	var hostnames []string
+	for _, entry := range HostnameEntriesFromAnnotations(input, "") {
+		hostnames = append(hostnames, entry.Hostname)
+	}
+	return hostnames
+}
+
+// HostnameEntry is a hostname of the HostnameKey annotation, along with the settings
+// that the structured form of the annotation can set for it.
+type HostnameEntry struct {
+	Hostname string
+	// Targets replace the targets of the resource when not empty.
+	Targets endpoint.Targets
+	// TTL replaces the TTL of the resource when configured.
+	TTL endpoint.TTL
+	// RecordType restricts the records of the hostname to the given type when not empty.
+	RecordType string
+}
+
+// hostnameEntrySpec is the format of an entry of the structured form of the HostnameKey annotation.
+type hostnameEntrySpec struct {
+	Hostname   string              `json:"hostname"`
+	Targets    []string            `json:"targets,omitempty"`
+	TTL        *intstr.IntOrString `json:"ttl,omitempty"`
+	RecordType string              `json:"recordType,omitempty"`
+	Priority   *uint16             `json:"priority,omitempty"`
+}
+
+// hostnameEntryRecordTypes are the record types that an entry of the structured form of the HostnameKey annotation can request.
+var hostnameEntryRecordTypes = []string{
+	endpoint.RecordTypeA,
+	endpoint.RecordTypeAAAA,
+	endpoint.RecordTypeCNAME,
+	endpoint.RecordTypeTXT,
+	endpoint.RecordTypeMX,
+	endpoint.RecordTypeNS,
+	endpoint.RecordTypeSRV,
+	endpoint.RecordTypeNAPTR,
+}
+
+// HostnameEntriesFromAnnotations extracts the hostname entries from the HostnameKey annotation of the given resource.
+// The annotation is either a comma-separated list of hostnames or a JSON or YAML list of objects with
+// a hostname and optional targets, ttl and recordType, as in:
+//
+//	[{"hostname": "www.example.com", "targets": ["10.0.0.1"], "ttl": 60}, {"hostname": "api.example.com", "recordType": "CNAME"}]
+//
+// The priority of an MX entry applies to its targets given without one, as in:
+//
+//	[{"hostname": "example.com", "recordType": "MX", "priority": 10, "targets": ["mail.example.com"]}]
+func HostnameEntriesFromAnnotations(input map[string]string, resource string) []HostnameEntry {
+	annotation, ok := input[HostnameKey]
+	if !ok {
+		return nil
+	}
+	if !isStructuredHostnameAnnotation(annotation) {
+		var entries []HostnameEntry
+		for _, hostname := range SplitHostnameAnnotation(annotation) {
+			entries = append(entries, HostnameEntry{Hostname: hostname})
+		}
+		return entries
+	}
+
+	var specs []hostnameEntrySpec
+	if err := yaml.Unmarshal([]byte(annotation), &specs); err != nil {
+		log.Warnf("%s: %q is not a valid hostname annotation: %v", resource, annotation, err)
+		return nil
+	}
+
+	entries := make([]HostnameEntry, 0, len(specs))
+	for _, spec := range specs {
+		hostname := strings.TrimSpace(spec.Hostname)
+		if hostname == "" {
+			log.Warnf("%s: ignoring hostname annotation entry without hostname", resource)
+			continue
+		}
+		entry := HostnameEntry{Hostname: hostname}
+		for _, target := range spec.Targets {
+			if target = strings.TrimSuffix(strings.TrimSpace(target), "."); target != "" {
+				entry.Targets = append(entry.Targets, target)
+			}
+		}
+		if spec.TTL != nil {
+			entry.TTL = ttlFromValue(spec.TTL.String(), resource)
+		}
+		if spec.RecordType != "" {
+			recordType := strings.ToUpper(spec.RecordType)
+			if slices.Contains(hostnameEntryRecordTypes, recordType) {
+				entry.RecordType = recordType
+			} else {
+				log.Warnf("%s: ignoring unsupported record type %q of hostname %s", resource, spec.RecordType, hostname)
+			}
+		}
+		if entry.RecordType == endpoint.RecordTypeMX {
+			entry.Targets = mxTargets(entry.Targets, spec.Priority, resource, hostname)
+		} else if spec.Priority != nil {
+			log.Warnf("%s: ignoring the priority of hostname %s, which only applies to MX records", resource, hostname)
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+// mxTargets returns the targets of an MX entry of the structured form of the HostnameKey annotation,
+// the hosts given without a priority getting the priority of the entry. The invalid targets are ignored.
+func mxTargets(targets endpoint.Targets, priority *uint16, resource, hostname string) endpoint.Targets {
+	var result endpoint.Targets
+	for _, target := range targets {
+		if priority != nil && len(strings.Fields(target)) == 1 {
+			target = endpoint.NewMXTarget(*priority, target)
+		}
+		if _, err := endpoint.ParseMXTarget(target); err != nil {
+			log.Warnf("%s: ignoring target of hostname %s: %v", resource, hostname, err)
+			continue
+		}
+		result = append(result, target)
+	}
+	return result
+}
+
+// isStructuredHostnameAnnotation returns whether a hostname annotation uses the structured form,
+// a JSON or YAML list, rather than a comma-separated list of hostnames.
+func isStructuredHostnameAnnotation(annotation string) bool {
+	annotation = strings.TrimSpace(annotation)
+	return strings.HasPrefix(annotation, "[") || strings.HasPrefix(annotation, "-")
 }
 
 // InternalHostnamesFromAnnotations extracts the internal hostnames from the given annotations map.
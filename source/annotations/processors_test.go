@@ -261,6 +261,13 @@ func TestHostnamesFromAnnotations(t *testing.T) {
 			},
 			expected: []string{"example.com", "example.org"},
 		},
+		{
+			name: "structured hostname annotation",
+			annotations: map[string]string{
+				HostnameKey: `[{"hostname": "example.com", "targets": ["10.0.0.1"]}, {"hostname": "example.org"}]`,
+			},
+			expected: []string{"example.com", "example.org"},
+		},
 	}
 
 	for _, tt := range tests {
@@ -271,6 +278,72 @@ func TestHostnamesFromAnnotations(t *testing.T) {
 	}
 }
 
+func TestHostnameEntriesFromAnnotations(t *testing.T) {
+	tests := []struct {
+		name       string
+		annotation string
+		expected   []HostnameEntry
+	}{
+		{
+			name:       "comma-separated hostnames",
+			annotation: "example.com, example.org",
+			expected:   []HostnameEntry{{Hostname: "example.com"}, {Hostname: "example.org"}},
+		},
+		{
+			name:       "JSON entries",
+			annotation: `[{"hostname": "example.com", "targets": ["10.0.0.1", "lb.example.com."], "ttl": "1m", "recordType": "a"}]`,
+			expected: []HostnameEntry{
+				{Hostname: "example.com", Targets: endpoint.Targets{"10.0.0.1", "lb.example.com"}, TTL: 60, RecordType: "A"},
+			},
+		},
+		{
+			name: "YAML entries",
+			annotation: `
+- hostname: example.com
+  ttl: 120
+- hostname: example.org
+  recordType: CNAME`,
+			expected: []HostnameEntry{
+				{Hostname: "example.com", TTL: 120},
+				{Hostname: "example.org", RecordType: "CNAME"},
+			},
+		},
+		{
+			name:       "invalid settings are ignored",
+			annotation: `[{"hostname": "example.com", "ttl": "forever", "recordType": "SOA"}, {"targets": ["10.0.0.1"]}]`,
+			expected:   []HostnameEntry{{Hostname: "example.com"}},
+		},
+		{
+			name:       "MX entries with a priority",
+			annotation: `[{"hostname": "example.com", "recordType": "MX", "priority": 10, "targets": ["mail.example.com.", "20 backup.example.com"]}]`,
+			expected: []HostnameEntry{
+				{Hostname: "example.com", Targets: endpoint.Targets{"10 mail.example.com", "20 backup.example.com"}, RecordType: "MX"},
+			},
+		},
+		{
+			name:       "MX entries without a priority",
+			annotation: `[{"hostname": "example.com", "recordType": "MX", "targets": ["mail.example.com", "20 backup.example.com"]}, {"hostname": "example.org", "priority": 10}]`,
+			expected: []HostnameEntry{
+				{Hostname: "example.com", Targets: endpoint.Targets{"20 backup.example.com"}, RecordType: "MX"},
+				{Hostname: "example.org"},
+			},
+		},
+		{
+			name:       "invalid structured annotation",
+			annotation: `[{"hostname": "example.com"`,
+			expected:   nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := HostnameEntriesFromAnnotations(map[string]string{HostnameKey: tt.annotation}, "service/default/test")
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+	assert.Nil(t, HostnameEntriesFromAnnotations(map[string]string{}, "service/default/test"))
+}
+
 func TestSplitHostnameAnnotation(t *testing.T) {
 	tests := []struct {
 		name       string
@@ -33,6 +33,21 @@ func ProviderSpecificAnnotations(annotations map[string]string) (endpoint.Provid
 	for k, v := range annotations {
 		if k == SetIdentifierKey {
 			setIdentifier = v
+		} else if k == CommentKey {
+			providerSpecificAnnotations = append(providerSpecificAnnotations, endpoint.ProviderSpecificProperty{
+				Name:  CommentKey,
+				Value: v,
+			})
+		} else if k == ZoneIDKey || k == ZoneSelectionKey {
+			providerSpecificAnnotations = append(providerSpecificAnnotations, endpoint.ProviderSpecificProperty{
+				Name:  k,
+				Value: v,
+			})
+		} else if k == IPFamilyKey {
+			providerSpecificAnnotations = append(providerSpecificAnnotations, endpoint.ProviderSpecificProperty{
+				Name:  IPFamilyKey,
+				Value: v,
+			})
 		} else if strings.HasPrefix(k, AWSPrefix) {
 			attr := strings.TrimPrefix(k, AWSPrefix)
 			providerSpecificAnnotations = append(providerSpecificAnnotations, endpoint.ProviderSpecificProperty{
@@ -45,6 +60,12 @@ func ProviderSpecificAnnotations(annotations map[string]string) (endpoint.Provid
 				Name:  fmt.Sprintf("scw/%s", attr),
 				Value: v,
 			})
+		} else if strings.HasPrefix(k, LinodePrefix) {
+			attr := strings.TrimPrefix(k, LinodePrefix)
+			providerSpecificAnnotations = append(providerSpecificAnnotations, endpoint.ProviderSpecificProperty{
+				Name:  fmt.Sprintf("linode/%s", attr),
+				Value: v,
+			})
 		} else if strings.HasPrefix(k, WebhookPrefix) {
 			// Support for wildcard annotations for webhook providers
 			attr := strings.TrimPrefix(k, WebhookPrefix)
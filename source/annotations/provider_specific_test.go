@@ -54,6 +54,26 @@ func TestProviderSpecificAnnotations(t *testing.T) {
 			},
 			setIdentifier: "",
 		},
+		{
+			name: "IP family annotation",
+			annotations: map[string]string{
+				IPFamilyKey: "ipv6",
+			},
+			expected: endpoint.ProviderSpecific{
+				{Name: IPFamilyKey, Value: "ipv6"},
+			},
+			setIdentifier: "",
+		},
+		{
+			name: "Comment annotation",
+			annotations: map[string]string{
+				CommentKey: "owned by team a",
+			},
+			expected: endpoint.ProviderSpecific{
+				{Name: CommentKey, Value: "owned by team a"},
+			},
+			setIdentifier: "",
+		},
 		{
 			name: "AWS annotation",
 			annotations: map[string]string{
@@ -300,6 +320,19 @@ func TestGetProviderSpecificIdentifierAnnotations(t *testing.T) {
 			},
 			expectedIdentifier: "id1",
 		},
+		{
+			title: "linode- provider specific annotations are set correctly",
+			annotations: map[string]string{
+				"external-dns.alpha.kubernetes.io/linode-axfr-ips": "192.0.2.1,192.0.2.2",
+				SetIdentifierKey: "id1",
+				"external-dns.alpha.kubernetes.io/linode-domain-ttl": "3600",
+			},
+			expectedResult: map[string]string{
+				"linode/axfr-ips":   "192.0.2.1,192.0.2.2",
+				"linode/domain-ttl": "3600",
+			},
+			expectedIdentifier: "id1",
+		},
 		{
 			title: "webhook- provider specific annotations are set correctly",
 			annotations: map[string]string{
@@ -0,0 +1,201 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	kubeinformers "k8s.io/client-go/informers"
+	"k8s.io/client-go/tools/cache"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/source/informers"
+)
+
+var (
+	certificateGVR = schema.GroupVersionResource{Group: "cert-manager.io", Version: "v1", Resource: "certificates"}
+	challengeGVR   = schema.GroupVersionResource{Group: "acme.cert-manager.io", Version: "v1", Resource: "challenges"}
+)
+
+// The states of a cert-manager Challenge that is no longer being presented.
+var finalChallengeStates = []string{"valid", "invalid", "errored", "expired"}
+
+// certRenewalSource is a Source that shortens the TTL of the endpoints whose
+// certificate is being renewed by cert-manager, so that the target changes
+// following the renewal propagate quickly. The TTL is restored once the
+// renewal is over.
+type certRenewalSource struct {
+	source              Source
+	certificateInformer kubeinformers.GenericInformer
+	challengeInformer   kubeinformers.GenericInformer
+	ttl                 endpoint.TTL
+	lead                time.Duration
+}
+
+// NewCertRenewalSource creates a new certRenewalSource wrapping the provided
+// Source. The TTL of the endpoints is shortened to ttl while an ACME challenge
+// is pending for their name, while their Certificate is being issued and from
+// lead before the renewal time of their Certificate.
+func NewCertRenewalSource(ctx context.Context, dynamicKubeClient dynamic.Interface, source Source, ttl, lead time.Duration) (Source, error) {
+	informerFactory := dynamicinformer.NewFilteredDynamicSharedInformerFactory(dynamicKubeClient, 0, "", nil)
+	certificateInformer := informerFactory.ForResource(certificateGVR)
+	challengeInformer := informerFactory.ForResource(challengeGVR)
+	for _, informer := range []kubeinformers.GenericInformer{certificateInformer, challengeInformer} {
+		informer.Informer().AddEventHandler(
+			cache.ResourceEventHandlerFuncs{
+				AddFunc: func(obj interface{}) {},
+			},
+		)
+	}
+
+	informerFactory.Start(ctx.Done())
+
+	// wait for the local cache to be populated.
+	if err := informers.WaitForDynamicCacheSync(context.Background(), informerFactory); err != nil {
+		return nil, err
+	}
+
+	return &certRenewalSource{
+		source:              source,
+		certificateInformer: certificateInformer,
+		challengeInformer:   challengeInformer,
+		ttl:                 endpoint.TTL(ttl.Seconds()),
+		lead:                lead,
+	}, nil
+}
+
+// Endpoints collects endpoints from its wrapped source and shortens the TTL of
+// the endpoints whose certificate is being renewed.
+func (cs *certRenewalSource) Endpoints(ctx context.Context) ([]*endpoint.Endpoint, error) {
+	endpoints, err := cs.source.Endpoints(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	names, err := cs.renewingNames(time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if len(names) == 0 {
+		return endpoints, nil
+	}
+
+	for _, ep := range endpoints {
+		if !names[normalizeRenewingName(ep.DNSName)] {
+			continue
+		}
+		if ep.RecordTTL.IsConfigured() && ep.RecordTTL <= cs.ttl {
+			continue
+		}
+		log.Debugf("Shortening the TTL of %s to %d while its certificate is renewed", ep.DNSName, cs.ttl)
+		ep.RecordTTL = cs.ttl
+	}
+	return endpoints, nil
+}
+
+// renewingNames returns the DNS names of the pending ACME challenges and of the
+// Certificates being renewed at now.
+func (cs *certRenewalSource) renewingNames(now time.Time) (map[string]bool, error) {
+	names := map[string]bool{}
+
+	challenges, err := cs.challengeInformer.Lister().List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	for _, obj := range challenges {
+		u, ok := obj.(*unstructured.Unstructured)
+		if !ok {
+			return nil, fmt.Errorf("unexpected object type %T", obj)
+		}
+		state, _, _ := unstructured.NestedString(u.Object, "status", "state")
+		if slices.Contains(finalChallengeStates, state) {
+			continue
+		}
+		dnsName, _, _ := unstructured.NestedString(u.Object, "spec", "dnsName")
+		if dnsName == "" {
+			continue
+		}
+		names[normalizeRenewingName(dnsName)] = true
+		if wildcard, _, _ := unstructured.NestedBool(u.Object, "spec", "wildcard"); wildcard {
+			names[normalizeRenewingName("*."+dnsName)] = true
+		}
+	}
+
+	certificates, err := cs.certificateInformer.Lister().List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	for _, obj := range certificates {
+		u, ok := obj.(*unstructured.Unstructured)
+		if !ok {
+			return nil, fmt.Errorf("unexpected object type %T", obj)
+		}
+		if !certificateRenewing(u, now, cs.lead) {
+			continue
+		}
+		dnsNames, _, _ := unstructured.NestedStringSlice(u.Object, "spec", "dnsNames")
+		for _, dnsName := range dnsNames {
+			names[normalizeRenewingName(dnsName)] = true
+		}
+	}
+	return names, nil
+}
+
+// certificateRenewing returns whether a Certificate is being issued, or is
+// within lead of its renewal time.
+func certificateRenewing(u *unstructured.Unstructured, now time.Time, lead time.Duration) bool {
+	conditions, _, _ := unstructured.NestedSlice(u.Object, "status", "conditions")
+	for _, c := range conditions {
+		condition, ok := c.(map[string]interface{})
+		if ok && condition["type"] == "Issuing" && condition["status"] == "True" {
+			return true
+		}
+	}
+
+	renewalTime, ok, _ := unstructured.NestedString(u.Object, "status", "renewalTime")
+	if !ok {
+		return false
+	}
+	t, err := time.Parse(time.RFC3339, renewalTime)
+	if err != nil {
+		log.Warnf("Ignoring the invalid renewal time %q of Certificate %s/%s", renewalTime, u.GetNamespace(), u.GetName())
+		return false
+	}
+	return !now.Before(t.Add(-lead))
+}
+
+func normalizeRenewingName(name string) string {
+	return strings.ToLower(strings.TrimSuffix(name, "."))
+}
+
+func (cs *certRenewalSource) AddEventHandler(ctx context.Context, handler func()) {
+	log.Debug("Adding event handler for cert-manager Certificates and Challenges")
+
+	cs.source.AddEventHandler(ctx, handler)
+	cs.certificateInformer.Informer().AddEventHandler(eventHandlerFunc(handler))
+	cs.challengeInformer.Informer().AddEventHandler(eventHandlerFunc(handler))
+}
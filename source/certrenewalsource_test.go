@@ -0,0 +1,158 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	fakeDynamic "k8s.io/client-go/dynamic/fake"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/internal/testutils"
+)
+
+// Validates that certRenewalSource is a Source
+var _ Source = &certRenewalSource{}
+
+func newChallenge(name, dnsName, state string, wildcard bool) runtime.Object {
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "acme.cert-manager.io/v1",
+		"kind":       "Challenge",
+		"metadata":   map[string]interface{}{"namespace": "default", "name": name},
+		"spec":       map[string]interface{}{"dnsName": dnsName, "wildcard": wildcard},
+		"status":     map[string]interface{}{"state": state},
+	}}
+}
+
+func newCertificate(name string, dnsNames []string, renewalTime time.Time, issuing bool) runtime.Object {
+	names := make([]interface{}, 0, len(dnsNames))
+	for _, dnsName := range dnsNames {
+		names = append(names, dnsName)
+	}
+	status := map[string]interface{}{"renewalTime": renewalTime.UTC().Format(time.RFC3339)}
+	if issuing {
+		status["conditions"] = []interface{}{map[string]interface{}{"type": "Issuing", "status": "True"}}
+	}
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "cert-manager.io/v1",
+		"kind":       "Certificate",
+		"metadata":   map[string]interface{}{"namespace": "default", "name": name},
+		"spec":       map[string]interface{}{"dnsNames": names},
+		"status":     status,
+	}}
+}
+
+func TestCertRenewalSource(t *testing.T) {
+	now := time.Now()
+
+	for _, tc := range []struct {
+		title     string
+		objects   []runtime.Object
+		endpoints []*endpoint.Endpoint
+		expected  []*endpoint.Endpoint
+	}{
+		{
+			title:     "no renewal",
+			objects:   []runtime.Object{newCertificate("foo", []string{"foo.example.org"}, now.Add(24*time.Hour), false)},
+			endpoints: []*endpoint.Endpoint{endpoint.NewEndpoint("foo.example.org", endpoint.RecordTypeA, "1.2.3.4")},
+			expected:  []*endpoint.Endpoint{endpoint.NewEndpoint("foo.example.org", endpoint.RecordTypeA, "1.2.3.4")},
+		},
+		{
+			title:   "pending challenge",
+			objects: []runtime.Object{newChallenge("foo", "foo.example.org", "pending", false)},
+			endpoints: []*endpoint.Endpoint{
+				endpoint.NewEndpoint("Foo.example.org.", endpoint.RecordTypeA, "1.2.3.4"),
+				endpoint.NewEndpoint("bar.example.org", endpoint.RecordTypeA, "1.2.3.4"),
+			},
+			expected: []*endpoint.Endpoint{
+				endpoint.NewEndpointWithTTL("Foo.example.org.", endpoint.RecordTypeA, 30, "1.2.3.4"),
+				endpoint.NewEndpoint("bar.example.org", endpoint.RecordTypeA, "1.2.3.4"),
+			},
+		},
+		{
+			title:   "wildcard challenge",
+			objects: []runtime.Object{newChallenge("foo", "example.org", "", true)},
+			endpoints: []*endpoint.Endpoint{
+				endpoint.NewEndpoint("*.example.org", endpoint.RecordTypeA, "1.2.3.4"),
+				endpoint.NewEndpoint("example.org", endpoint.RecordTypeA, "1.2.3.4"),
+			},
+			expected: []*endpoint.Endpoint{
+				endpoint.NewEndpointWithTTL("*.example.org", endpoint.RecordTypeA, 30, "1.2.3.4"),
+				endpoint.NewEndpointWithTTL("example.org", endpoint.RecordTypeA, 30, "1.2.3.4"),
+			},
+		},
+		{
+			title:     "valid challenge",
+			objects:   []runtime.Object{newChallenge("foo", "foo.example.org", "valid", false)},
+			endpoints: []*endpoint.Endpoint{endpoint.NewEndpoint("foo.example.org", endpoint.RecordTypeA, "1.2.3.4")},
+			expected:  []*endpoint.Endpoint{endpoint.NewEndpoint("foo.example.org", endpoint.RecordTypeA, "1.2.3.4")},
+		},
+		{
+			title:     "certificate being issued",
+			objects:   []runtime.Object{newCertificate("foo", []string{"foo.example.org"}, now.Add(24*time.Hour), true)},
+			endpoints: []*endpoint.Endpoint{endpoint.NewEndpointWithTTL("foo.example.org", endpoint.RecordTypeA, 300, "1.2.3.4")},
+			expected:  []*endpoint.Endpoint{endpoint.NewEndpointWithTTL("foo.example.org", endpoint.RecordTypeA, 30, "1.2.3.4")},
+		},
+		{
+			title:     "certificate within the lead of its renewal",
+			objects:   []runtime.Object{newCertificate("foo", []string{"foo.example.org"}, now.Add(30*time.Minute), false)},
+			endpoints: []*endpoint.Endpoint{endpoint.NewEndpoint("foo.example.org", endpoint.RecordTypeA, "1.2.3.4")},
+			expected:  []*endpoint.Endpoint{endpoint.NewEndpointWithTTL("foo.example.org", endpoint.RecordTypeA, 30, "1.2.3.4")},
+		},
+		{
+			title:     "shorter ttl is kept",
+			objects:   []runtime.Object{newChallenge("foo", "foo.example.org", "pending", false)},
+			endpoints: []*endpoint.Endpoint{endpoint.NewEndpointWithTTL("foo.example.org", endpoint.RecordTypeA, 10, "1.2.3.4")},
+			expected:  []*endpoint.Endpoint{endpoint.NewEndpointWithTTL("foo.example.org", endpoint.RecordTypeA, 10, "1.2.3.4")},
+		},
+	} {
+		t.Run(tc.title, func(t *testing.T) {
+			dynamicClient := fakeDynamic.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+				certificateGVR: "CertificateList",
+				challengeGVR:   "ChallengeList",
+			}, tc.objects...)
+
+			mockSource := new(testutils.MockSource)
+			mockSource.On("Endpoints").Return(tc.endpoints, nil)
+
+			source, err := NewCertRenewalSource(context.Background(), dynamicClient, mockSource, 30*time.Second, time.Hour)
+			require.NoError(t, err)
+
+			endpoints, err := source.Endpoints(context.Background())
+			require.NoError(t, err)
+
+			validateEndpoints(t, endpoints, tc.expected)
+			mockSource.AssertExpectations(t)
+		})
+	}
+}
+
+func TestCertificateRenewing(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	assert.False(t, certificateRenewing(newCertificate("foo", nil, now.Add(2*time.Hour), false).(*unstructured.Unstructured), now, time.Hour))
+	assert.True(t, certificateRenewing(newCertificate("foo", nil, now.Add(time.Hour), false).(*unstructured.Unstructured), now, time.Hour))
+	assert.True(t, certificateRenewing(newCertificate("foo", nil, now.Add(-time.Hour), false).(*unstructured.Unstructured), now, time.Hour))
+	assert.True(t, certificateRenewing(newCertificate("foo", nil, now.Add(2*time.Hour), true).(*unstructured.Unstructured), now, time.Hour))
+}
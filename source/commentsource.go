@@ -0,0 +1,99 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"context"
+	"strings"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/source/annotations"
+)
+
+// maxCommentLength is the length of the comments recorded in the labels, which
+// are stored in TXT records along with the owner and the resource.
+const maxCommentLength = 100
+
+// labelProperties are the provider specific properties of the annotations moved to
+// the labels of the same value, by the label name.
+var labelProperties = map[string]string{
+	annotations.ZoneIDKey:        endpoint.ZoneIDLabelKey,
+	annotations.ZoneSelectionKey: endpoint.ZoneSelectionLabelKey,
+}
+
+// commentSource is a Source that records the comment, zone-id and zone-selection
+// annotations of the resources in the labels of their endpoints, for the registry
+// and the providers.
+type commentSource struct {
+	source Source
+}
+
+// NewCommentSource creates a new commentSource wrapping the provided Source.
+func NewCommentSource(source Source) Source {
+	return &commentSource{source: source}
+}
+
+// Endpoints collects endpoints from its wrapped source and moves their comment, zone
+// ID and zone selection from the provider specific properties to the labels.
+func (s *commentSource) Endpoints(ctx context.Context) ([]*endpoint.Endpoint, error) {
+	endpoints, err := s.source.Endpoints(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, ep := range endpoints {
+		// the properties are not meant for providers, which would never return them
+		for property, label := range labelProperties {
+			if value, ok := ep.GetProviderSpecificProperty(property); ok {
+				ep.DeleteProviderSpecificProperty(property)
+				if value = strings.TrimSpace(value); value != "" {
+					ep.WithLabel(label, value)
+				}
+			}
+		}
+		comment, ok := ep.GetProviderSpecificProperty(annotations.CommentKey)
+		if !ok {
+			continue
+		}
+		ep.DeleteProviderSpecificProperty(annotations.CommentKey)
+		if comment = sanitizeComment(comment); comment != "" {
+			ep.WithLabel(endpoint.CommentLabelKey, comment)
+		}
+	}
+	return endpoints, nil
+}
+
+// sanitizeComment replaces the characters separating the labels in TXT records
+// and truncates the comment.
+func sanitizeComment(comment string) string {
+	comment = strings.Map(func(r rune) rune {
+		switch r {
+		case ',', '=', '"', '\\', '\n', '\r', '\t':
+			return ' '
+		}
+		return r
+	}, comment)
+	comment = strings.Join(strings.Fields(comment), " ")
+	if runes := []rune(comment); len(runes) > maxCommentLength {
+		comment = strings.TrimSpace(string(runes[:maxCommentLength]))
+	}
+	return comment
+}
+
+func (s *commentSource) AddEventHandler(ctx context.Context, handler func()) {
+	s.source.AddEventHandler(ctx, handler)
+}
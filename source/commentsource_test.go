@@ -0,0 +1,88 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/source/annotations"
+)
+
+func TestCommentSource(t *testing.T) {
+	src := NewCommentSource(NewEchoSource([]*endpoint.Endpoint{
+		endpoint.NewEndpoint("web.example.org", endpoint.RecordTypeA, "1.2.3.4").
+			WithLabel(endpoint.ResourceLabelKey, "ingress/default/web").
+			WithProviderSpecific(annotations.CommentKey, "team=web, \"frontend\""),
+		endpoint.NewEndpoint("blank.example.org", endpoint.RecordTypeA, "1.2.3.4").
+			WithProviderSpecific(annotations.CommentKey, " , "),
+		endpoint.NewEndpoint("plain.example.org", endpoint.RecordTypeA, "1.2.3.4"),
+	}))
+
+	endpoints, err := src.Endpoints(t.Context())
+	require.NoError(t, err)
+	validateEndpoints(t, endpoints, []*endpoint.Endpoint{
+		endpoint.NewEndpoint("web.example.org", endpoint.RecordTypeA, "1.2.3.4").
+			WithLabel(endpoint.ResourceLabelKey, "ingress/default/web").
+			WithLabel(endpoint.CommentLabelKey, "team web frontend"),
+		endpoint.NewEndpoint("blank.example.org", endpoint.RecordTypeA, "1.2.3.4"),
+		endpoint.NewEndpoint("plain.example.org", endpoint.RecordTypeA, "1.2.3.4"),
+	})
+	for _, ep := range endpoints {
+		_, ok := ep.GetProviderSpecificProperty(annotations.CommentKey)
+		assert.False(t, ok)
+	}
+}
+
+func TestCommentSourceZoneID(t *testing.T) {
+	src := NewCommentSource(NewEchoSource([]*endpoint.Endpoint{
+		endpoint.NewEndpoint("web.internal.example.org", endpoint.RecordTypeA, "1.2.3.4").
+			WithProviderSpecific(annotations.ZoneIDKey, " Z0123456789 "),
+		endpoint.NewEndpoint("blank.example.org", endpoint.RecordTypeA, "1.2.3.4").
+			WithProviderSpecific(annotations.ZoneIDKey, ""),
+		endpoint.NewEndpoint("split.example.org", endpoint.RecordTypeA, "1.2.3.4").
+			WithProviderSpecific(annotations.ZoneSelectionKey, "prefer-private"),
+	}))
+
+	endpoints, err := src.Endpoints(t.Context())
+	require.NoError(t, err)
+	validateEndpoints(t, endpoints, []*endpoint.Endpoint{
+		endpoint.NewEndpoint("web.internal.example.org", endpoint.RecordTypeA, "1.2.3.4").
+			WithLabel(endpoint.ZoneIDLabelKey, "Z0123456789"),
+		endpoint.NewEndpoint("blank.example.org", endpoint.RecordTypeA, "1.2.3.4"),
+		endpoint.NewEndpoint("split.example.org", endpoint.RecordTypeA, "1.2.3.4").
+			WithLabel(endpoint.ZoneSelectionLabelKey, "prefer-private"),
+	})
+	for _, ep := range endpoints {
+		assert.Empty(t, ep.ProviderSpecific)
+	}
+}
+
+func TestSanitizeComment(t *testing.T) {
+	assert.Equal(t, "a b c", sanitizeComment("a,b=c"))
+	assert.Equal(t, "multi line", sanitizeComment("multi\nline\n"))
+	assert.Len(t, sanitizeComment(strings.Repeat("x", 2*maxCommentLength)), maxCommentLength)
+
+	labels := endpoint.Labels{endpoint.CommentLabelKey: sanitizeComment(`owner="team a", tier=web`)}
+	parsed, err := endpoint.NewLabelsFromStringPlain(labels.SerializePlain(true))
+	require.NoError(t, err)
+	assert.Equal(t, labels, parsed)
+}
@@ -21,19 +21,25 @@ import (
 	"errors"
 	"fmt"
 	"sort"
+	"strings"
 	"text/template"
+	"time"
 
 	projectcontour "github.com/projectcontour/contour/apis/projectcontour/v1"
 	log "github.com/sirupsen/logrus"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/dynamic/dynamicinformer"
 	kubeinformers "k8s.io/client-go/informers"
+	coreinformers "k8s.io/client-go/informers/core/v1"
+	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/cache"
 
 	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/pkg/decisions"
 	"sigs.k8s.io/external-dns/source/annotations"
 	"sigs.k8s.io/external-dns/source/fqdn"
 	"sigs.k8s.io/external-dns/source/informers"
@@ -42,35 +48,58 @@ import (
 // HTTPProxySource is an implementation of Source for ProjectContour HTTPProxy objects.
 // The HTTPProxy implementation uses the spec.virtualHost.fqdn value for the hostname.
 // Use targetAnnotationKey to explicitly set Endpoint.
+// HTTPProxies included by a root HTTPProxy inherit its targets, and HTTPProxies
+// without a load balancer status use the one of the Envoy service.
 type httpProxySource struct {
 	dynamicKubeClient        dynamic.Interface
+	envoyService             *types.NamespacedName
+	envoyServiceInformer     coreinformers.ServiceInformer
 	namespace                string
 	annotationFilter         string
+	labelSelector            labels.Selector
 	fqdnTemplate             *template.Template
 	combineFQDNAnnotation    bool
 	ignoreHostnameAnnotation bool
 	httpProxyInformer        kubeinformers.GenericInformer
 	unstructuredConverter    *UnstructuredConverter
+	filterDecisions          *decisions.Recorder
+	propagatedLabels         PropagatedLabels
 }
 
 // NewContourHTTPProxySource creates a new contourHTTPProxySource with the given config.
 func NewContourHTTPProxySource(
 	ctx context.Context,
 	dynamicKubeClient dynamic.Interface,
+	kubeClient kubernetes.Interface,
 	namespace string,
 	annotationFilter string,
+	labelSelector labels.Selector,
 	fqdnTemplate string,
 	combineFqdnAnnotation bool,
 	ignoreHostnameAnnotation bool,
+	envoyService string,
+	filterDecisions *decisions.Recorder,
+	resyncPeriod time.Duration,
+	prefixAliases annotations.PrefixAliases,
+	propagatedLabels PropagatedLabels,
 ) (Source, error) {
 	tmpl, err := fqdn.ParseTemplate(fqdnTemplate)
 	if err != nil {
 		return nil, err
 	}
 
+	var envoyServiceName *types.NamespacedName
+	if envoyService != "" {
+		svcNamespace, svcName, ok := strings.Cut(envoyService, "/")
+		if !ok || svcNamespace == "" || svcName == "" {
+			return nil, fmt.Errorf("invalid Envoy service %q, expected <namespace>/<name>", envoyService)
+		}
+		envoyServiceName = &types.NamespacedName{Namespace: svcNamespace, Name: svcName}
+	}
+
 	// Use shared informer to listen for add/update/delete of HTTPProxys in the specified namespace.
 	// Set resync period to 0, to prevent processing when nothing has changed.
-	informerFactory := dynamicinformer.NewFilteredDynamicSharedInformerFactory(dynamicKubeClient, 0, namespace, nil)
+	informerFactory := dynamicinformer.NewFilteredDynamicSharedInformerFactory(dynamicKubeClient, resyncPeriod, namespace, labelFilterListOptions(labelSelector, filterDecisions))
 	httpProxyInformer := informerFactory.ForResource(projectcontour.HTTPProxyGVR)
 
 	// Add default resource event handlers to properly initialize informer.
@@ -81,6 +110,8 @@ func NewContourHTTPProxySource(
 		},
 	)
 
+	aliasAnnotations(prefixAliases, httpProxyInformer.Informer())
+
 	informerFactory.Start(ctx.Done())
 
 	// wait for the local cache to be populated.
@@ -88,27 +119,39 @@ func NewContourHTTPProxySource(
 		return nil, err
 	}
 
+	var envoyServiceInformer coreinformers.ServiceInformer
+	if envoyServiceName != nil {
+		if envoyServiceInformer, err = newSingleServiceInformer(ctx, kubeClient, *envoyServiceName, resyncPeriod); err != nil {
+			return nil, err
+		}
+	}
+
 	uc, err := NewUnstructuredConverter()
 	if err != nil {
 		return nil, fmt.Errorf("failed to setup Unstructured Converter: %w", err)
 	}
 
 	return &httpProxySource{
+		filterDecisions:          filterDecisions,
 		dynamicKubeClient:        dynamicKubeClient,
+		envoyService:             envoyServiceName,
+		envoyServiceInformer:     envoyServiceInformer,
 		namespace:                namespace,
 		annotationFilter:         annotationFilter,
+		labelSelector:            labelSelector,
 		fqdnTemplate:             tmpl,
 		combineFQDNAnnotation:    combineFqdnAnnotation,
 		ignoreHostnameAnnotation: ignoreHostnameAnnotation,
 		httpProxyInformer:        httpProxyInformer,
 		unstructuredConverter:    uc,
+		propagatedLabels:         propagatedLabels,
 	}, nil
 }
 
 // Endpoints returns endpoint objects for each host-target combination that should be processed.
 // Retrieves all HTTPProxy resources in the source's namespace(s).
 func (sc *httpProxySource) Endpoints(ctx context.Context) ([]*endpoint.Endpoint, error) {
-	hps, err := sc.httpProxyInformer.Lister().ByNamespace(sc.namespace).List(labels.Everything())
+	hps, err := sc.httpProxyInformer.Lister().ByNamespace(sc.namespace).List(sc.labelSelector)
 	if err != nil {
 		return nil, err
 	}
@@ -128,6 +171,9 @@ func (sc *httpProxySource) Endpoints(ctx context.Context) ([]*endpoint.Endpoint,
 		httpProxies = append(httpProxies, hpConverted)
 	}
 
+	envoyTargets := sc.envoyTargets()
+	inheritedTargets := includedHTTPProxyTargets(httpProxies, envoyTargets)
+
 	httpProxies, err = sc.filterByAnnotations(httpProxies)
 	if err != nil {
 		return nil, fmt.Errorf("failed to filter HTTPProxies: %w", err)
@@ -144,14 +190,19 @@ func (sc *httpProxySource) Endpoints(ctx context.Context) ([]*endpoint.Endpoint,
 			continue
 		}
 
-		hpEndpoints, err := sc.endpointsFromHTTPProxy(hp)
+		defaultTargets, ok := inheritedTargets[namespacedName(hp.Namespace, hp.Name)]
+		if !ok {
+			defaultTargets = envoyTargets
+		}
+
+		hpEndpoints, err := sc.endpointsFromHTTPProxy(hp, defaultTargets)
 		if err != nil {
 			return nil, fmt.Errorf("failed to get endpoints from HTTPProxy: %w", err)
 		}
 
 		// apply template if fqdn is missing on HTTPProxy
 		if (sc.combineFQDNAnnotation || len(hpEndpoints) == 0) && sc.fqdnTemplate != nil {
-			tmplEndpoints, err := sc.endpointsFromTemplate(hp)
+			tmplEndpoints, err := sc.endpointsFromTemplate(hp, defaultTargets)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get endpoints from template: %w", err)
 			}
@@ -168,6 +219,7 @@ func (sc *httpProxySource) Endpoints(ctx context.Context) ([]*endpoint.Endpoint,
 			continue
 		}
 
+		sc.propagatedLabels.propagate(hpEndpoints, hp.Labels)
 		log.Debugf("Endpoints generated from HTTPProxy: %s/%s: %v", hp.Namespace, hp.Name, hpEndpoints)
 		endpoints = append(endpoints, hpEndpoints...)
 	}
@@ -179,7 +231,7 @@ func (sc *httpProxySource) Endpoints(ctx context.Context) ([]*endpoint.Endpoint,
 	return endpoints, nil
 }
 
-func (sc *httpProxySource) endpointsFromTemplate(httpProxy *projectcontour.HTTPProxy) ([]*endpoint.Endpoint, error) {
+func (sc *httpProxySource) endpointsFromTemplate(httpProxy *projectcontour.HTTPProxy, defaultTargets endpoint.Targets) ([]*endpoint.Endpoint, error) {
 	hostnames, err := fqdn.ExecTemplate(sc.fqdnTemplate, httpProxy)
 	if err != nil {
 		return nil, err
@@ -189,17 +241,7 @@ func (sc *httpProxySource) endpointsFromTemplate(httpProxy *projectcontour.HTTPP
 
 	ttl := annotations.TTLFromAnnotations(httpProxy.Annotations, resource)
 
-	targets := annotations.TargetsFromTargetAnnotation(httpProxy.Annotations)
-	if len(targets) == 0 {
-		for _, lb := range httpProxy.Status.LoadBalancer.Ingress {
-			if lb.IP != "" {
-				targets = append(targets, lb.IP)
-			}
-			if lb.Hostname != "" {
-				targets = append(targets, lb.Hostname)
-			}
-		}
-	}
+	targets := httpProxyTargets(httpProxy, defaultTargets)
 
 	providerSpecific, setIdentifier := annotations.ProviderSpecificAnnotations(httpProxy.Annotations)
 
@@ -232,6 +274,8 @@ func (sc *httpProxySource) filterByAnnotations(httpProxies []*projectcontour.HTT
 		// include HTTPProxy if its annotations match the selector
 		if selector.Matches(labels.Set(httpProxy.Annotations)) {
 			filteredList = append(filteredList, httpProxy)
+		} else {
+			recordExcludedByAnnotations(sc.filterDecisions, "HTTPProxy", httpProxy, sc.annotationFilter)
 		}
 	}
 
@@ -239,11 +283,36 @@ func (sc *httpProxySource) filterByAnnotations(httpProxies []*projectcontour.HTT
 }
 
 // endpointsFromHTTPProxyConfig extracts the endpoints from a Contour HTTPProxy object
-func (sc *httpProxySource) endpointsFromHTTPProxy(httpProxy *projectcontour.HTTPProxy) ([]*endpoint.Endpoint, error) {
+func (sc *httpProxySource) endpointsFromHTTPProxy(httpProxy *projectcontour.HTTPProxy, defaultTargets endpoint.Targets) ([]*endpoint.Endpoint, error) {
 	resource := fmt.Sprintf("HTTPProxy/%s/%s", httpProxy.Namespace, httpProxy.Name)
 
 	ttl := annotations.TTLFromAnnotations(httpProxy.Annotations, resource)
 
+	targets := httpProxyTargets(httpProxy, defaultTargets)
+
+	providerSpecific, setIdentifier := annotations.ProviderSpecificAnnotations(httpProxy.Annotations)
+
+	var endpoints []*endpoint.Endpoint
+
+	if virtualHost := httpProxy.Spec.VirtualHost; virtualHost != nil {
+		if fqdn := virtualHost.Fqdn; fqdn != "" {
+			endpoints = append(endpoints, endpointsForHostname(fqdn, targets, ttl, providerSpecific, setIdentifier, resource)...)
+		}
+	}
+
+	// Skip endpoints if we do not want entries from annotations
+	if !sc.ignoreHostnameAnnotation {
+		for _, entry := range annotations.HostnameEntriesFromAnnotations(httpProxy.Annotations, resource) {
+			endpoints = append(endpoints, endpointsForHostnameEntry(entry, targets, ttl, providerSpecific, setIdentifier, resource)...)
+		}
+	}
+
+	return endpoints, nil
+}
+
+// httpProxyTargets returns the targets of an HTTPProxy: the ones of its target
+// annotation, else the ones of its load balancer status, else defaultTargets.
+func httpProxyTargets(httpProxy *projectcontour.HTTPProxy, defaultTargets endpoint.Targets) endpoint.Targets {
 	targets := annotations.TargetsFromTargetAnnotation(httpProxy.Annotations)
 
 	if len(targets) == 0 {
@@ -257,25 +326,73 @@ func (sc *httpProxySource) endpointsFromHTTPProxy(httpProxy *projectcontour.HTTP
 		}
 	}
 
-	providerSpecific, setIdentifier := annotations.ProviderSpecificAnnotations(httpProxy.Annotations)
-
-	var endpoints []*endpoint.Endpoint
+	if len(targets) == 0 {
+		targets = defaultTargets
+	}
+	return targets
+}
 
-	if virtualHost := httpProxy.Spec.VirtualHost; virtualHost != nil {
-		if fqdn := virtualHost.Fqdn; fqdn != "" {
-			endpoints = append(endpoints, endpointsForHostname(fqdn, targets, ttl, providerSpecific, setIdentifier, resource)...)
+// includedHTTPProxyTargets returns the targets the HTTPProxies included, directly
+// or through other HTTPProxies, by a root HTTPProxy inherit from it. An HTTPProxy
+// included by several roots inherits from the first one by namespace and name.
+func includedHTTPProxyTargets(httpProxies []*projectcontour.HTTPProxy, envoyTargets endpoint.Targets) map[types.NamespacedName]endpoint.Targets {
+	byName := make(map[types.NamespacedName]*projectcontour.HTTPProxy, len(httpProxies))
+	var roots []*projectcontour.HTTPProxy
+	for _, hp := range httpProxies {
+		byName[namespacedName(hp.Namespace, hp.Name)] = hp
+		if hp.Spec.VirtualHost != nil {
+			roots = append(roots, hp)
 		}
 	}
-
-	// Skip endpoints if we do not want entries from annotations
-	if !sc.ignoreHostnameAnnotation {
-		hostnameList := annotations.HostnamesFromAnnotations(httpProxy.Annotations)
-		for _, hostname := range hostnameList {
-			endpoints = append(endpoints, endpointsForHostname(hostname, targets, ttl, providerSpecific, setIdentifier, resource)...)
+	sort.Slice(roots, func(i, j int) bool {
+		if roots[i].Namespace != roots[j].Namespace {
+			return roots[i].Namespace < roots[j].Namespace
+		}
+		return roots[i].Name < roots[j].Name
+	})
+
+	inherited := map[types.NamespacedName]endpoint.Targets{}
+	for _, root := range roots {
+		targets := httpProxyTargets(root, envoyTargets)
+		visited := map[types.NamespacedName]bool{namespacedName(root.Namespace, root.Name): true}
+		queue := []*projectcontour.HTTPProxy{root}
+		for len(queue) > 0 {
+			parent := queue[0]
+			queue = queue[1:]
+			for _, include := range parent.Spec.Includes {
+				// Includes default to the namespace of the including HTTPProxy.
+				namespace := include.Namespace
+				if namespace == "" {
+					namespace = parent.Namespace
+				}
+				name := namespacedName(namespace, include.Name)
+				child, ok := byName[name]
+				if !ok || visited[name] {
+					continue
+				}
+				visited[name] = true
+				if _, ok := inherited[name]; !ok && child.Spec.VirtualHost == nil {
+					inherited[name] = targets
+				}
+				queue = append(queue, child)
+			}
 		}
 	}
+	return inherited
+}
 
-	return endpoints, nil
+// envoyTargets returns the load balancer addresses of the Envoy service, or
+// nothing when it is not set or can't be read.
+func (sc *httpProxySource) envoyTargets() endpoint.Targets {
+	if sc.envoyService == nil {
+		return nil
+	}
+	svc, err := sc.envoyServiceInformer.Lister().Services(sc.envoyService.Namespace).Get(sc.envoyService.Name)
+	if err != nil {
+		log.Warnf("Failed to get Envoy service %s: %v", sc.envoyService, err)
+		return nil
+	}
+	return extractLoadBalancerTargets(svc, false)
 }
 
 func (sc *httpProxySource) AddEventHandler(ctx context.Context, handler func()) {
@@ -284,4 +401,7 @@ func (sc *httpProxySource) AddEventHandler(ctx context.Context, handler func())
 	// Right now there is no way to remove event handler from informer, see:
 	// https://github.com/kubernetes/kubernetes/issues/79610
 	sc.httpProxyInformer.Informer().AddEventHandler(eventHandlerFunc(handler))
+	if sc.envoyServiceInformer != nil {
+		sc.envoyServiceInformer.Informer().AddEventHandler(eventHandlerFunc(handler))
+	}
 }
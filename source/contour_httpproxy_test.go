@@ -22,6 +22,7 @@ import (
 	"testing"
 
 	fakeDynamic "k8s.io/client-go/dynamic/fake"
+	fakeKube "k8s.io/client-go/kubernetes/fake"
 
 	projectcontour "github.com/projectcontour/contour/apis/projectcontour/v1"
 	"github.com/stretchr/testify/assert"
@@ -30,6 +31,7 @@ import (
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 
 	"sigs.k8s.io/external-dns/endpoint"
@@ -91,11 +93,18 @@ func (suite *HTTPProxySuite) SetupTest() {
 	suite.source, err = NewContourHTTPProxySource(
 		context.TODO(),
 		fakeDynamicClient,
+		fakeKube.NewClientset(),
 		"default",
 		"",
+		labels.Everything(),
 		"{{.Name}}",
 		false,
 		false,
+		"",
+		nil,
+		0,
+		nil,
+		nil,
 	)
 	suite.NoError(err, "should initialize httpproxy source")
 
@@ -136,6 +145,7 @@ func TestHTTPProxy(t *testing.T) {
 	suite.Run(t, new(HTTPProxySuite))
 	t.Run("endpointsFromHTTPProxy", testEndpointsFromHTTPProxy)
 	t.Run("Endpoints", testHTTPProxyEndpoints)
+	t.Run("IncludedEndpoints", testHTTPProxyIncludedEndpoints)
 }
 
 func TestNewContourHTTPProxySource(t *testing.T) {
@@ -188,11 +198,18 @@ func TestNewContourHTTPProxySource(t *testing.T) {
 			_, err := NewContourHTTPProxySource(
 				context.TODO(),
 				fakeDynamicClient,
+				fakeKube.NewClientset(),
 				"",
 				ti.annotationFilter,
+				labels.Everything(),
 				ti.fqdnTemplate,
 				ti.combineFQDNAndAnnotation,
 				false,
+				"",
+				nil,
+				0,
+				nil,
+				nil,
 			)
 			if ti.expectError {
 				assert.Error(t, err)
@@ -289,7 +306,7 @@ func testEndpointsFromHTTPProxy(t *testing.T) {
 
 			if source, err := newTestHTTPProxySource(); err != nil {
 				require.NoError(t, err)
-			} else if endpoints, err := source.endpointsFromHTTPProxy(ti.httpProxy.HTTPProxy()); err != nil {
+			} else if endpoints, err := source.endpointsFromHTTPProxy(ti.httpProxy.HTTPProxy(), nil); err != nil {
 				require.NoError(t, err)
 			} else {
 				validateEndpoints(t, endpoints, ti.expected)
@@ -1056,11 +1073,18 @@ func testHTTPProxyEndpoints(t *testing.T) {
 			httpProxySource, err := NewContourHTTPProxySource(
 				context.TODO(),
 				fakeDynamicClient,
+				fakeKube.NewClientset(),
 				ti.targetNamespace,
 				ti.annotationFilter,
+				labels.Everything(),
 				ti.fqdnTemplate,
 				ti.combineFQDNAndAnnotation,
 				ti.ignoreHostnameAnnotation,
+				"",
+				nil,
+				0,
+				nil,
+				nil,
 			)
 			require.NoError(t, err)
 
@@ -1076,6 +1100,145 @@ func testHTTPProxyEndpoints(t *testing.T) {
 	}
 }
 
+func testHTTPProxyIncludedEndpoints(t *testing.T) {
+	t.Parallel()
+
+	envoy := fakeLoadBalancerService{namespace: "projectcontour", name: "envoy", ips: []string{"1.2.3.4"}}
+
+	for _, ti := range []struct {
+		title        string
+		httpProxies  []*projectcontour.HTTPProxy
+		envoyService string
+		expected     []*endpoint.Endpoint
+	}{
+		{
+			title: "included proxies inherit the targets of their root",
+			httpProxies: []*projectcontour.HTTPProxy{
+				{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "root"},
+					Spec: projectcontour.HTTPProxySpec{
+						VirtualHost: &projectcontour.VirtualHost{Fqdn: "example.org"},
+						Includes:    []projectcontour.Include{{Name: "api", Namespace: "team-a"}},
+					},
+					Status: projectcontour.HTTPProxyStatus{LoadBalancer: v1.LoadBalancerStatus{Ingress: []v1.LoadBalancerIngress{{Hostname: "lb.com"}}}},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "team-a", Name: "api", Annotations: map[string]string{hostnameAnnotationKey: "api.example.org"}},
+					Spec:       projectcontour.HTTPProxySpec{Includes: []projectcontour.Include{{Name: "v2"}}},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "team-a", Name: "v2", Annotations: map[string]string{hostnameAnnotationKey: "v2.example.org"}},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "team-b", Name: "orphan", Annotations: map[string]string{hostnameAnnotationKey: "orphan.example.org"}},
+				},
+			},
+			expected: []*endpoint.Endpoint{
+				{DNSName: "example.org", RecordType: endpoint.RecordTypeCNAME, Targets: endpoint.Targets{"lb.com"}},
+				{DNSName: "api.example.org", RecordType: endpoint.RecordTypeCNAME, Targets: endpoint.Targets{"lb.com"}},
+				{DNSName: "v2.example.org", RecordType: endpoint.RecordTypeCNAME, Targets: endpoint.Targets{"lb.com"}},
+			},
+		},
+		{
+			title: "proxies without load balancer status use the Envoy service",
+			httpProxies: []*projectcontour.HTTPProxy{
+				{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "root"},
+					Spec: projectcontour.HTTPProxySpec{
+						VirtualHost: &projectcontour.VirtualHost{Fqdn: "example.org"},
+						Includes:    []projectcontour.Include{{Name: "api"}},
+					},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "api", Annotations: map[string]string{
+						hostnameAnnotationKey: "api.example.org",
+						targetAnnotationKey:   "api.lb.com",
+					}},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "team-b", Name: "orphan", Annotations: map[string]string{hostnameAnnotationKey: "orphan.example.org"}},
+				},
+			},
+			envoyService: "projectcontour/envoy",
+			expected: []*endpoint.Endpoint{
+				{DNSName: "example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"1.2.3.4"}},
+				{DNSName: "api.example.org", RecordType: endpoint.RecordTypeCNAME, Targets: endpoint.Targets{"api.lb.com"}},
+				{DNSName: "orphan.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"1.2.3.4"}},
+			},
+		},
+		{
+			title: "missing Envoy service",
+			httpProxies: []*projectcontour.HTTPProxy{
+				{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "root"},
+					Spec:       projectcontour.HTTPProxySpec{VirtualHost: &projectcontour.VirtualHost{Fqdn: "example.org"}},
+				},
+			},
+			envoyService: "projectcontour/missing",
+			expected:     []*endpoint.Endpoint{},
+		},
+		{
+			title: "include cycle",
+			httpProxies: []*projectcontour.HTTPProxy{
+				{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "root"},
+					Spec: projectcontour.HTTPProxySpec{
+						VirtualHost: &projectcontour.VirtualHost{Fqdn: "example.org"},
+						Includes:    []projectcontour.Include{{Name: "a"}},
+					},
+					Status: projectcontour.HTTPProxyStatus{LoadBalancer: v1.LoadBalancerStatus{Ingress: []v1.LoadBalancerIngress{{IP: "8.8.8.8"}}}},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "a", Annotations: map[string]string{hostnameAnnotationKey: "a.example.org"}},
+					Spec:       projectcontour.HTTPProxySpec{Includes: []projectcontour.Include{{Name: "b"}}},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "b"},
+					Spec:       projectcontour.HTTPProxySpec{Includes: []projectcontour.Include{{Name: "a"}}},
+				},
+			},
+			expected: []*endpoint.Endpoint{
+				{DNSName: "example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"8.8.8.8"}},
+				{DNSName: "a.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"8.8.8.8"}},
+			},
+		},
+	} {
+		t.Run(ti.title, func(t *testing.T) {
+			t.Parallel()
+
+			fakeDynamicClient, scheme := newDynamicKubernetesClient()
+			for _, httpProxy := range ti.httpProxies {
+				converted, err := convertHTTPProxyToUnstructured(httpProxy, scheme)
+				require.NoError(t, err)
+				_, err = fakeDynamicClient.Resource(projectcontour.HTTPProxyGVR).Namespace(httpProxy.Namespace).Create(context.Background(), converted, metav1.CreateOptions{})
+				require.NoError(t, err)
+			}
+
+			httpProxySource, err := NewContourHTTPProxySource(
+				context.TODO(),
+				fakeDynamicClient,
+				fakeKube.NewClientset(envoy.Service()),
+				"",
+				"",
+				labels.Everything(),
+				"",
+				false,
+				false,
+				ti.envoyService,
+				nil,
+				0,
+				nil,
+				nil,
+			)
+			require.NoError(t, err)
+
+			res, err := httpProxySource.Endpoints(context.Background())
+			require.NoError(t, err)
+			validateEndpoints(t, res, ti.expected)
+		})
+	}
+}
+
 // httpproxy specific helper functions
 func newTestHTTPProxySource() (*httpProxySource, error) {
 	fakeDynamicClient, _ := newDynamicKubernetesClient()
@@ -1083,11 +1246,18 @@ func newTestHTTPProxySource() (*httpProxySource, error) {
 	src, err := NewContourHTTPProxySource(
 		context.TODO(),
 		fakeDynamicClient,
+		fakeKube.NewClientset(),
 		"default",
 		"",
+		labels.Everything(),
 		"{{.Name}}",
 		false,
 		false,
+		"",
+		nil,
+		0,
+		nil,
+		nil,
 	)
 	if err != nil {
 		return nil, err
@@ -19,13 +19,16 @@ package source
 import (
 	"context"
 	"fmt"
+	"math"
 	"os"
 	"strings"
+	"time"
 
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/client-go/tools/cache"
 
+	"sigs.k8s.io/external-dns/pkg/decisions"
 	"sigs.k8s.io/external-dns/source/annotations"
 
 	log "github.com/sirupsen/logrus"
@@ -52,6 +55,9 @@ type crdSource struct {
 	annotationFilter string
 	labelSelector    labels.Selector
 	informer         *cache.SharedInformer
+	filterDecisions  *decisions.Recorder
+	prefixAliases    annotations.PrefixAliases
+	propagatedLabels PropagatedLabels
 }
 
 func addKnownTypes(scheme *runtime.Scheme, groupVersion schema.GroupVersion) error {
@@ -111,29 +117,39 @@ func NewCRDClientForAPIVersionKind(client kubernetes.Interface, kubeConfig, apiS
 }
 
 // NewCRDSource creates a new crdSource with the given config.
-func NewCRDSource(crdClient rest.Interface, namespace, kind string, annotationFilter string, labelSelector labels.Selector, scheme *runtime.Scheme, startInformer bool) (Source, error) {
+func NewCRDSource(crdClient rest.Interface, namespace, kind string, annotationFilter string, labelSelector labels.Selector, scheme *runtime.Scheme, startInformer bool, filterDecisions *decisions.Recorder, resyncPeriod time.Duration, prefixAliases annotations.PrefixAliases, propagatedLabels PropagatedLabels) (Source, error) {
 	sourceCrd := crdSource{
+		prefixAliases:    prefixAliases,
 		crdResource:      strings.ToLower(kind) + "s",
 		namespace:        namespace,
 		annotationFilter: annotationFilter,
 		labelSelector:    labelSelector,
 		crdClient:        crdClient,
 		codec:            runtime.NewParameterCodec(scheme),
+		filterDecisions:  filterDecisions,
+		propagatedLabels: propagatedLabels,
 	}
 	if startInformer {
+		tweakListOptions := labelFilterListOptions(labelSelector, filterDecisions)
 		// external-dns already runs its sync-handler periodically (controlled by `--interval` flag) to ensure any
-		// missed or dropped events are handled. specify resync period 0 to avoid unnecessary sync handler invocations.
+		// missed or dropped events are handled, so the informer is only resynced when --source-resync-period asks to.
 		informer := cache.NewSharedInformer(
 			&cache.ListWatch{
 				ListWithContextFunc: func(ctx context.Context, lo metav1.ListOptions) (result runtime.Object, err error) {
+					if tweakListOptions != nil {
+						tweakListOptions(&lo)
+					}
 					return sourceCrd.List(ctx, &lo)
 				},
 				WatchFuncWithContext: func(ctx context.Context, lo metav1.ListOptions) (watch.Interface, error) {
+					if tweakListOptions != nil {
+						tweakListOptions(&lo)
+					}
 					return sourceCrd.watch(ctx, &lo)
 				},
 			},
 			&apiv1alpha1.DNSEndpoint{},
-			0)
+			resyncPeriod)
 		sourceCrd.informer = &informer
 		go informer.Run(wait.NeverStop)
 	}
@@ -175,6 +191,9 @@ func (cs *crdSource) Endpoints(ctx context.Context) ([]*endpoint.Endpoint, error
 	if err != nil {
 		return nil, err
 	}
+	for i := range result.Items {
+		result.Items[i].Annotations = cs.prefixAliases.Alias(result.Items[i].Annotations)
+	}
 
 	result, err = cs.filterByAnnotations(result)
 	if err != nil {
@@ -203,12 +222,25 @@ func (cs *crdSource) Endpoints(ctx context.Context) ([]*endpoint.Endpoint, error
 				log.Warnf("Endpoint %s/%s with DNSName %s has an illegal target format.", dnsEndpoint.Namespace, dnsEndpoint.Name, ep.DNSName)
 				continue
 			}
+			if ep.MXPriority != nil {
+				if ep.RecordType != endpoint.RecordTypeMX || *ep.MXPriority < 0 || *ep.MXPriority > math.MaxUint16 {
+					log.Warnf("Endpoint %s/%s with DNSName %s has an invalid MX priority %d.", dnsEndpoint.Namespace, dnsEndpoint.Name, ep.DNSName, *ep.MXPriority)
+					continue
+				}
+				ep.Targets = mxTargets(ep.Targets, uint16(*ep.MXPriority))
+				ep.MXPriority = nil
+			}
+			if !ep.CheckEndpoint() {
+				log.Warnf("Endpoint %s/%s with DNSName %s has an invalid %s target, e.g. without a priority.", dnsEndpoint.Namespace, dnsEndpoint.Name, ep.DNSName, ep.RecordType)
+				continue
+			}
 
 			ep.WithLabel(endpoint.ResourceLabelKey, fmt.Sprintf("crd/%s/%s", dnsEndpoint.Namespace, dnsEndpoint.Name))
 
 			crdEndpoints = append(crdEndpoints, ep)
 		}
 
+		cs.propagatedLabels.propagate(crdEndpoints, dnsEndpoint.Labels)
 		endpoints = append(endpoints, crdEndpoints...)
 
 		if dnsEndpoint.Status.ObservedGeneration == dnsEndpoint.Generation {
@@ -276,8 +308,23 @@ func (cs *crdSource) filterByAnnotations(dnsendpoints *apiv1alpha1.DNSEndpointLi
 		// include dnsendpoint if its annotations match the selector
 		if selector.Matches(labels.Set(dnsendpoint.Annotations)) {
 			filteredList.Items = append(filteredList.Items, dnsendpoint)
+		} else {
+			recordExcludedByAnnotations(cs.filterDecisions, "crd", &dnsendpoint, cs.annotationFilter)
 		}
 	}
 
 	return &filteredList, nil
 }
+
+// mxTargets returns the targets of an MX endpoint of a DNSEndpoint, the hosts given without a
+// priority getting the MX priority of the endpoint.
+func mxTargets(targets endpoint.Targets, priority uint16) endpoint.Targets {
+	result := make(endpoint.Targets, 0, len(targets))
+	for _, target := range targets {
+		if len(strings.Fields(target)) == 1 {
+			target = endpoint.NewMXTarget(priority, target)
+		}
+		result = append(result, target)
+	}
+	return result
+}
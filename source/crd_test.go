@@ -29,6 +29,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -41,6 +42,7 @@ import (
 	"k8s.io/client-go/rest/fake"
 	"k8s.io/client-go/tools/cache"
 	cachetesting "k8s.io/client-go/tools/cache/testing"
+	"k8s.io/utils/ptr"
 	apiv1alpha1 "sigs.k8s.io/external-dns/apis/v1alpha1"
 	"sigs.k8s.io/external-dns/endpoint"
 )
@@ -475,6 +477,69 @@ func testCRDSourceEndpoints(t *testing.T) {
 			expectEndpoints: false,
 			expectError:     false,
 		},
+		{
+			title:                "MX target without priority",
+			registeredAPIVersion: "test.k8s.io/v1alpha1",
+			apiVersion:           "test.k8s.io/v1alpha1",
+			registeredKind:       "DNSEndpoint",
+			kind:                 "DNSEndpoint",
+			namespace:            "foo",
+			registeredNamespace:  "foo",
+			labels:               map[string]string{"test": "that"},
+			labelFilter:          "test=that",
+			endpoints: []*endpoint.Endpoint{
+				{
+					DNSName:    "example.org",
+					Targets:    endpoint.Targets{"10 mail.example.org", "backup.example.org"},
+					RecordType: endpoint.RecordTypeMX,
+					RecordTTL:  180,
+				},
+			},
+			expectEndpoints: false,
+			expectError:     false,
+		},
+		{
+			title:                "MX priority of another record type",
+			registeredAPIVersion: "test.k8s.io/v1alpha1",
+			apiVersion:           "test.k8s.io/v1alpha1",
+			registeredKind:       "DNSEndpoint",
+			kind:                 "DNSEndpoint",
+			namespace:            "foo",
+			registeredNamespace:  "foo",
+			labels:               map[string]string{"test": "that"},
+			labelFilter:          "test=that",
+			endpoints: []*endpoint.Endpoint{
+				{
+					DNSName:    "example.org",
+					Targets:    endpoint.Targets{"1.2.3.4"},
+					RecordType: endpoint.RecordTypeA,
+					MXPriority: ptr.To[int32](10),
+				},
+			},
+			expectEndpoints: false,
+			expectError:     false,
+		},
+		{
+			title:                "MX priority out of range",
+			registeredAPIVersion: "test.k8s.io/v1alpha1",
+			apiVersion:           "test.k8s.io/v1alpha1",
+			registeredKind:       "DNSEndpoint",
+			kind:                 "DNSEndpoint",
+			namespace:            "foo",
+			registeredNamespace:  "foo",
+			labels:               map[string]string{"test": "that"},
+			labelFilter:          "test=that",
+			endpoints: []*endpoint.Endpoint{
+				{
+					DNSName:    "example.org",
+					Targets:    endpoint.Targets{"mail.example.org"},
+					RecordType: endpoint.RecordTypeMX,
+					MXPriority: ptr.To[int32](65536),
+				},
+			},
+			expectEndpoints: false,
+			expectError:     false,
+		},
 	} {
 		t.Run(ti.title, func(t *testing.T) {
 			t.Parallel()
@@ -494,7 +559,7 @@ func testCRDSourceEndpoints(t *testing.T) {
 			// At present, client-go's fake.RESTClient (used by crd_test.go) is known to cause race conditions when used
 			// with informers: https://github.com/kubernetes/kubernetes/issues/95372
 			// So don't start the informer during testing.
-			cs, err := NewCRDSource(restClient, ti.namespace, ti.kind, ti.annotationFilter, labelSelector, scheme, false)
+			cs, err := NewCRDSource(restClient, ti.namespace, ti.kind, ti.annotationFilter, labelSelector, scheme, false, nil, 0, nil, nil)
 			require.NoError(t, err)
 
 			receivedEndpoints, err := cs.Endpoints(t.Context())
@@ -524,6 +589,31 @@ func testCRDSourceEndpoints(t *testing.T) {
 	}
 }
 
+func TestCRDSourceMXPriority(t *testing.T) {
+	endpoints := []*endpoint.Endpoint{
+		{
+			DNSName:    "example.org",
+			Targets:    endpoint.Targets{"mail.example.org", "20 backup.example.org"},
+			RecordType: endpoint.RecordTypeMX,
+			MXPriority: ptr.To[int32](10),
+		},
+	}
+	restClient := fakeRESTClient(endpoints, "test.k8s.io/v1alpha1", "DNSEndpoint", "foo", "test", nil, nil, t)
+	scheme := runtime.NewScheme()
+	require.NoError(t, addKnownTypes(scheme, schema.GroupVersion{Group: "test.k8s.io", Version: "v1alpha1"}))
+
+	cs, err := NewCRDSource(restClient, "foo", "DNSEndpoint", "", labels.Everything(), scheme, false, nil, 0, nil, nil)
+	require.NoError(t, err)
+
+	received, err := cs.Endpoints(t.Context())
+	require.NoError(t, err)
+	validateEndpoints(t, received, []*endpoint.Endpoint{
+		endpoint.NewEndpoint("example.org", endpoint.RecordTypeMX, "10 mail.example.org", "20 backup.example.org").
+			WithLabel(endpoint.ResourceLabelKey, "crd/foo/test"),
+	})
+	assert.Nil(t, received[0].MXPriority)
+}
+
 func TestCRDSource_NoInformer(t *testing.T) {
 	cs := &crdSource{informer: nil}
 	called := false
@@ -0,0 +1,55 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"sigs.k8s.io/external-dns/pkg/decisions"
+)
+
+// objectResource returns the resource of an object as in the resource label of its endpoints,
+// e.g. service/default/web or node/worker-1.
+func objectResource(kind string, obj metav1.Object) string {
+	if obj.GetNamespace() == "" {
+		return fmt.Sprintf("%s/%s", kind, obj.GetName())
+	}
+	return fmt.Sprintf("%s/%s/%s", kind, obj.GetNamespace(), obj.GetName())
+}
+
+// recordExcludedByAnnotations records that an object was excluded by the annotation filter.
+func recordExcludedByAnnotations(filterDecisions *decisions.Recorder, kind string, obj metav1.Object, annotationFilter string) {
+	filterDecisions.Record(decisions.ForResource(decisions.AnnotationFilter, objectResource(kind, obj),
+		fmt.Sprintf("the annotations do not match the annotation filter %q", annotationFilter)))
+}
+
+// recordExcludedByLabels records the objects excluded by the label filter. The listers of the
+// sources only return the matching objects, so the objects must be listed with labels.Everything().
+func recordExcludedByLabels[T metav1.Object](filterDecisions *decisions.Recorder, kind string, objects []T, selector labels.Selector) {
+	if selector == nil || selector.Empty() {
+		return
+	}
+	for _, obj := range objects {
+		if !selector.Matches(labels.Set(obj.GetLabels())) {
+			filterDecisions.Record(decisions.ForResource(decisions.LabelFilter, objectResource(kind, obj),
+				fmt.Sprintf("the labels do not match the label filter %q", selector.String())))
+		}
+	}
+}
@@ -0,0 +1,75 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"sigs.k8s.io/external-dns/pkg/decisions"
+)
+
+func TestIngressSourceRecordsFilterDecisions(t *testing.T) {
+	recorder := decisions.NewRecorder()
+	recorder.Enable()
+
+	kubeClient := fake.NewClientset()
+	for _, ing := range []fakeIngress{
+		{name: "public", namespace: "default", dnsnames: []string{"public.example.org"}, ips: []string{"1.2.3.4"},
+			annotations: map[string]string{"scope": "public"}, labels: map[string]string{"team": "web"}},
+		{name: "private", namespace: "default", dnsnames: []string{"private.example.org"}, ips: []string{"1.2.3.4"},
+			annotations: map[string]string{"scope": "private"}, labels: map[string]string{"team": "web"}},
+		{name: "other-team", namespace: "default", dnsnames: []string{"other.example.org"}, ips: []string{"1.2.3.4"},
+			annotations: map[string]string{"scope": "public"}, labels: map[string]string{"team": "api"}},
+	} {
+		_, err := kubeClient.NetworkingV1().Ingresses(ing.namespace).Create(context.Background(), ing.Ingress(), metav1.CreateOptions{})
+		require.NoError(t, err)
+	}
+
+	src, err := NewIngressSource(context.TODO(), kubeClient, "", "scope=public", "", false, false, false, false,
+		labels.SelectorFromSet(labels.Set{"team": "web"}), nil, recorder, 0, nil, nil)
+	require.NoError(t, err)
+
+	endpoints, err := src.Endpoints(context.Background())
+	require.NoError(t, err)
+	require.Len(t, endpoints, 1)
+	assert.Equal(t, "public.example.org", endpoints[0].DNSName)
+
+	assert.Equal(t, []decisions.Decision{
+		{Filter: decisions.AnnotationFilter, Resource: "ingress/default/private", Reason: `the annotations do not match the annotation filter "scope=public"`},
+		{Filter: decisions.LabelFilter, Resource: "ingress/default/other-team", Reason: `the labels do not match the label filter "team=web"`},
+	}, withoutTime(recorder.Decisions()))
+}
+
+func TestObjectResource(t *testing.T) {
+	assert.Equal(t, "service/default/web", objectResource("service", &metav1.ObjectMeta{Namespace: "default", Name: "web"}))
+	assert.Equal(t, "node/worker-1", objectResource("node", &metav1.ObjectMeta{Name: "worker-1"}))
+}
+
+func withoutTime(ds []decisions.Decision) []decisions.Decision {
+	for i := range ds {
+		ds[i].Time = time.Time{}
+	}
+	return ds
+}
@@ -18,9 +18,6 @@ package source
 
 import (
 	"context"
-	"strings"
-
-	log "github.com/sirupsen/logrus"
 
 	"sigs.k8s.io/external-dns/endpoint"
 )
@@ -35,33 +32,14 @@ func NewDedupSource(source Source) Source {
 	return &dedupSource{source: source}
 }
 
-// Endpoints collects endpoints from its wrapped source and returns them without duplicates.
+// Endpoints collects endpoints from its wrapped source and returns them normalized, without duplicates.
 func (ms *dedupSource) Endpoints(ctx context.Context) ([]*endpoint.Endpoint, error) {
-	result := []*endpoint.Endpoint{}
-	collected := map[string]bool{}
-
 	endpoints, err := ms.source.Endpoints(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	for _, ep := range endpoints {
-		if ep == nil {
-			continue
-		}
-
-		identifier := strings.Join([]string{ep.RecordType, ep.DNSName, ep.SetIdentifier, ep.Targets.String()}, "/")
-
-		if _, ok := collected[identifier]; ok {
-			log.Debugf("Removing duplicate endpoint %s", ep)
-			continue
-		}
-
-		collected[identifier] = true
-		result = append(result, ep)
-	}
-
-	return result, nil
+	return endpoint.NormalizeEndpoints(endpoints), nil
 }
 
 func (ms *dedupSource) AddEventHandler(ctx context.Context, handler func()) {
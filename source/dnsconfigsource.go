@@ -0,0 +1,239 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	kubeinformers "k8s.io/client-go/informers"
+	"k8s.io/client-go/tools/cache"
+
+	"sigs.k8s.io/external-dns/apis/v1alpha1"
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/source/annotations"
+	"sigs.k8s.io/external-dns/source/informers"
+)
+
+// The DNSConfig settings the cluster operator can allow namespace owners to set.
+const (
+	DNSConfigSettingTTL                = "ttl"
+	DNSConfigSettingTargets            = "targets"
+	DNSConfigSettingFQDNTemplateSuffix = "fqdn-template-suffix"
+	DNSConfigSettingProxied            = "proxied"
+)
+
+var dnsConfigGVR = v1alpha1.GroupVersion.WithResource("dnsconfigs")
+
+// dnsConfigSource is a Source that applies the defaults of the DNSConfig of a
+// namespace to the endpoints generated from the resources of that namespace.
+type dnsConfigSource struct {
+	source            Source
+	dnsConfigInformer kubeinformers.GenericInformer
+	allowed           map[string]bool
+}
+
+// NewDNSConfigSource creates a new dnsConfigSource wrapping the provided Source.
+// Only the settings listed in allowed are applied.
+func NewDNSConfigSource(ctx context.Context, dynamicKubeClient dynamic.Interface, source Source, allowed []string) (Source, error) {
+	informerFactory := dynamicinformer.NewFilteredDynamicSharedInformerFactory(dynamicKubeClient, 0, "", nil)
+	dnsConfigInformer := informerFactory.ForResource(dnsConfigGVR)
+	dnsConfigInformer.Informer().AddEventHandler(
+		cache.ResourceEventHandlerFuncs{
+			AddFunc: func(obj interface{}) {},
+		},
+	)
+
+	informerFactory.Start(ctx.Done())
+
+	// wait for the local cache to be populated.
+	if err := informers.WaitForDynamicCacheSync(context.Background(), informerFactory); err != nil {
+		return nil, err
+	}
+
+	allowedSettings := make(map[string]bool, len(allowed))
+	for _, setting := range allowed {
+		allowedSettings[setting] = true
+	}
+	return &dnsConfigSource{source: source, dnsConfigInformer: dnsConfigInformer, allowed: allowedSettings}, nil
+}
+
+// Endpoints collects endpoints from its wrapped source and applies the
+// defaults of the DNSConfig of their namespace.
+func (ds *dnsConfigSource) Endpoints(ctx context.Context) ([]*endpoint.Endpoint, error) {
+	endpoints, err := ds.source.Endpoints(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	configs, err := ds.dnsConfigs()
+	if err != nil {
+		return nil, err
+	}
+	if len(configs) == 0 {
+		return endpoints, nil
+	}
+
+	result := make([]*endpoint.Endpoint, 0, len(endpoints))
+	byNamespace := map[string][]*endpoint.Endpoint{}
+	for _, ep := range endpoints {
+		namespace := endpointNamespace(ep)
+		if _, ok := configs[namespace]; !ok {
+			result = append(result, ep)
+			continue
+		}
+		byNamespace[namespace] = append(byNamespace[namespace], ep)
+	}
+	for _, namespace := range sortedNamespaces(byNamespace) {
+		result = append(result, ds.apply(configs[namespace], byNamespace[namespace])...)
+	}
+	return result, nil
+}
+
+// dnsConfigs returns the spec of the DNSConfig of each namespace. When a
+// namespace has several DNSConfigs, the first one by name is used.
+func (ds *dnsConfigSource) dnsConfigs() (map[string]*v1alpha1.DNSConfigSpec, error) {
+	objects, err := ds.dnsConfigInformer.Lister().List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+
+	var dnsConfigs []*v1alpha1.DNSConfig
+	for _, obj := range objects {
+		u, ok := obj.(*unstructured.Unstructured)
+		if !ok {
+			return nil, fmt.Errorf("unexpected object type %T", obj)
+		}
+		dnsConfig := &v1alpha1.DNSConfig{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.UnstructuredContent(), dnsConfig); err != nil {
+			return nil, fmt.Errorf("failed to convert DNSConfig %s/%s: %w", u.GetNamespace(), u.GetName(), err)
+		}
+		dnsConfigs = append(dnsConfigs, dnsConfig)
+	}
+	sort.Slice(dnsConfigs, func(i, j int) bool {
+		if dnsConfigs[i].Namespace != dnsConfigs[j].Namespace {
+			return dnsConfigs[i].Namespace < dnsConfigs[j].Namespace
+		}
+		return dnsConfigs[i].Name < dnsConfigs[j].Name
+	})
+
+	configs := map[string]*v1alpha1.DNSConfigSpec{}
+	for _, dnsConfig := range dnsConfigs {
+		if _, ok := configs[dnsConfig.Namespace]; ok {
+			log.Warnf("Ignoring DNSConfig %s/%s, namespace %s has several DNSConfigs", dnsConfig.Namespace, dnsConfig.Name, dnsConfig.Namespace)
+			continue
+		}
+		configs[dnsConfig.Namespace] = &dnsConfig.Spec
+	}
+	return configs, nil
+}
+
+// apply applies the allowed settings of spec to the endpoints of a namespace.
+func (ds *dnsConfigSource) apply(spec *v1alpha1.DNSConfigSpec, endpoints []*endpoint.Endpoint) []*endpoint.Endpoint {
+	if ds.allowed[DNSConfigSettingFQDNTemplateSuffix] && spec.FQDNTemplateSuffix != "" {
+		suffix := strings.Trim(spec.FQDNTemplateSuffix, ".")
+		for _, ep := range endpoints {
+			if !strings.Contains(strings.TrimSuffix(ep.DNSName, "."), ".") {
+				ep.DNSName = strings.TrimSuffix(ep.DNSName, ".") + "." + suffix
+			}
+		}
+	}
+	if ds.allowed[DNSConfigSettingTargets] && len(spec.Targets) > 0 {
+		endpoints = overrideTargets(endpoints, spec.Targets)
+	}
+	for _, ep := range endpoints {
+		if ds.allowed[DNSConfigSettingTTL] && spec.RecordTTL != nil && !ep.RecordTTL.IsConfigured() {
+			ep.RecordTTL = endpoint.TTL(*spec.RecordTTL)
+		}
+		if ds.allowed[DNSConfigSettingProxied] && spec.Proxied != nil {
+			if _, ok := ep.GetProviderSpecificProperty(annotations.CloudflareProxiedKey); !ok {
+				ep.SetProviderSpecificProperty(annotations.CloudflareProxiedKey, strconv.FormatBool(*spec.Proxied))
+			}
+		}
+	}
+	return endpoints
+}
+
+// overrideTargets replaces the A, AAAA and CNAME endpoints of each DNS name with
+// endpoints pointing to targets.
+func overrideTargets(endpoints []*endpoint.Endpoint, targets []string) []*endpoint.Endpoint {
+	type key struct {
+		dnsName       string
+		setIdentifier string
+	}
+	var (
+		result []*endpoint.Endpoint
+		keys   []key
+	)
+	overridden := map[key]*endpoint.Endpoint{}
+	for _, ep := range endpoints {
+		switch ep.RecordType {
+		case endpoint.RecordTypeA, endpoint.RecordTypeAAAA, endpoint.RecordTypeCNAME:
+			k := key{dnsName: ep.DNSName, setIdentifier: ep.SetIdentifier}
+			if _, ok := overridden[k]; !ok {
+				overridden[k] = ep
+				keys = append(keys, k)
+			}
+		default:
+			result = append(result, ep)
+		}
+	}
+	for _, k := range keys {
+		ep := overridden[k]
+		for _, override := range endpointsForHostname(ep.DNSName, targets, ep.RecordTTL, ep.ProviderSpecific, ep.SetIdentifier, "") {
+			for label, value := range ep.Labels {
+				override.Labels[label] = value
+			}
+			result = append(result, override)
+		}
+	}
+	return result
+}
+
+// endpointNamespace returns the namespace of the resource an endpoint was generated from.
+func endpointNamespace(ep *endpoint.Endpoint) string {
+	parts := strings.Split(ep.Labels[endpoint.ResourceLabelKey], "/")
+	if len(parts) != 3 {
+		return ""
+	}
+	return parts[1]
+}
+
+func sortedNamespaces(m map[string][]*endpoint.Endpoint) []string {
+	namespaces := make([]string, 0, len(m))
+	for namespace := range m {
+		namespaces = append(namespaces, namespace)
+	}
+	sort.Strings(namespaces)
+	return namespaces
+}
+
+func (ds *dnsConfigSource) AddEventHandler(ctx context.Context, handler func()) {
+	log.Debug("Adding event handler for DNSConfig")
+
+	ds.source.AddEventHandler(ctx, handler)
+	ds.dnsConfigInformer.Informer().AddEventHandler(eventHandlerFunc(handler))
+}
@@ -0,0 +1,165 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	fakeDynamic "k8s.io/client-go/dynamic/fake"
+
+	"sigs.k8s.io/external-dns/apis/v1alpha1"
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/internal/testutils"
+	"sigs.k8s.io/external-dns/source/annotations"
+)
+
+// Validates that dnsConfigSource is a Source
+var _ Source = &dnsConfigSource{}
+
+func newDNSConfig(t *testing.T, namespace, name string, spec v1alpha1.DNSConfigSpec) runtime.Object {
+	t.Helper()
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&v1alpha1.DNSConfig{
+		TypeMeta:   metav1.TypeMeta{APIVersion: v1alpha1.GroupVersion.String(), Kind: "DNSConfig"},
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+		Spec:       spec,
+	})
+	require.NoError(t, err)
+	return &unstructured.Unstructured{Object: content}
+}
+
+func namespacedEndpoint(namespace, dnsName, recordType string, targets ...string) *endpoint.Endpoint {
+	return endpoint.NewEndpoint(dnsName, recordType, targets...).WithLabel(endpoint.ResourceLabelKey, "service/"+namespace+"/svc")
+}
+
+func TestDNSConfigSource(t *testing.T) {
+	ttl := int64(300)
+	proxied := true
+
+	for _, tc := range []struct {
+		title      string
+		allowed    []string
+		dnsConfigs []v1alpha1.DNSConfigSpec
+		endpoints  []*endpoint.Endpoint
+		expected   []*endpoint.Endpoint
+	}{
+		{
+			title:     "no DNSConfig",
+			allowed:   []string{DNSConfigSettingTTL},
+			endpoints: []*endpoint.Endpoint{namespacedEndpoint("team-a", "foo.example.org", endpoint.RecordTypeA, "1.2.3.4")},
+			expected:  []*endpoint.Endpoint{namespacedEndpoint("team-a", "foo.example.org", endpoint.RecordTypeA, "1.2.3.4")},
+		},
+		{
+			title:      "ttl is a default",
+			allowed:    []string{DNSConfigSettingTTL},
+			dnsConfigs: []v1alpha1.DNSConfigSpec{{RecordTTL: &ttl}},
+			endpoints: []*endpoint.Endpoint{
+				namespacedEndpoint("team-a", "foo.example.org", endpoint.RecordTypeA, "1.2.3.4"),
+				endpoint.NewEndpointWithTTL("bar.example.org", endpoint.RecordTypeA, 60, "1.2.3.4").WithLabel(endpoint.ResourceLabelKey, "service/team-a/bar"),
+				namespacedEndpoint("team-b", "baz.example.org", endpoint.RecordTypeA, "1.2.3.4"),
+			},
+			expected: []*endpoint.Endpoint{
+				endpoint.NewEndpointWithTTL("foo.example.org", endpoint.RecordTypeA, 300, "1.2.3.4").WithLabel(endpoint.ResourceLabelKey, "service/team-a/svc"),
+				endpoint.NewEndpointWithTTL("bar.example.org", endpoint.RecordTypeA, 60, "1.2.3.4").WithLabel(endpoint.ResourceLabelKey, "service/team-a/bar"),
+				namespacedEndpoint("team-b", "baz.example.org", endpoint.RecordTypeA, "1.2.3.4"),
+			},
+		},
+		{
+			title:      "settings not allowed are ignored",
+			allowed:    []string{DNSConfigSettingTTL},
+			dnsConfigs: []v1alpha1.DNSConfigSpec{{Targets: []string{"5.6.7.8"}, FQDNTemplateSuffix: "team-a.example.org", Proxied: &proxied}},
+			endpoints:  []*endpoint.Endpoint{namespacedEndpoint("team-a", "foo", endpoint.RecordTypeA, "1.2.3.4")},
+			expected:   []*endpoint.Endpoint{namespacedEndpoint("team-a", "foo", endpoint.RecordTypeA, "1.2.3.4")},
+		},
+		{
+			title:      "targets replace A, AAAA and CNAME endpoints",
+			allowed:    []string{DNSConfigSettingTargets},
+			dnsConfigs: []v1alpha1.DNSConfigSpec{{Targets: []string{"lb.example.org", "2001:db8::1"}}},
+			endpoints: []*endpoint.Endpoint{
+				namespacedEndpoint("team-a", "foo.example.org", endpoint.RecordTypeA, "1.2.3.4"),
+				namespacedEndpoint("team-a", "foo.example.org", endpoint.RecordTypeAAAA, "2001:db8::2"),
+				namespacedEndpoint("team-a", "foo.example.org", endpoint.RecordTypeTXT, "text"),
+			},
+			expected: []*endpoint.Endpoint{
+				namespacedEndpoint("team-a", "foo.example.org", endpoint.RecordTypeCNAME, "lb.example.org"),
+				namespacedEndpoint("team-a", "foo.example.org", endpoint.RecordTypeAAAA, "2001:db8::1"),
+				namespacedEndpoint("team-a", "foo.example.org", endpoint.RecordTypeTXT, "text"),
+			},
+		},
+		{
+			title:      "fqdn template suffix is appended to single labels",
+			allowed:    []string{DNSConfigSettingFQDNTemplateSuffix},
+			dnsConfigs: []v1alpha1.DNSConfigSpec{{FQDNTemplateSuffix: ".team-a.example.org."}},
+			endpoints: []*endpoint.Endpoint{
+				namespacedEndpoint("team-a", "foo", endpoint.RecordTypeA, "1.2.3.4"),
+				namespacedEndpoint("team-a", "bar.example.com", endpoint.RecordTypeA, "1.2.3.4"),
+			},
+			expected: []*endpoint.Endpoint{
+				namespacedEndpoint("team-a", "foo.team-a.example.org", endpoint.RecordTypeA, "1.2.3.4"),
+				namespacedEndpoint("team-a", "bar.example.com", endpoint.RecordTypeA, "1.2.3.4"),
+			},
+		},
+		{
+			title:      "proxied is a default",
+			allowed:    []string{DNSConfigSettingProxied},
+			dnsConfigs: []v1alpha1.DNSConfigSpec{{Proxied: &proxied}},
+			endpoints: []*endpoint.Endpoint{
+				namespacedEndpoint("team-a", "foo.example.org", endpoint.RecordTypeA, "1.2.3.4"),
+				namespacedEndpoint("team-a", "bar.example.org", endpoint.RecordTypeA, "1.2.3.4").WithProviderSpecific(annotations.CloudflareProxiedKey, "false"),
+			},
+			expected: []*endpoint.Endpoint{
+				namespacedEndpoint("team-a", "foo.example.org", endpoint.RecordTypeA, "1.2.3.4").WithProviderSpecific(annotations.CloudflareProxiedKey, "true"),
+				namespacedEndpoint("team-a", "bar.example.org", endpoint.RecordTypeA, "1.2.3.4").WithProviderSpecific(annotations.CloudflareProxiedKey, "false"),
+			},
+		},
+		{
+			title:   "first DNSConfig by name is used",
+			allowed: []string{DNSConfigSettingTTL},
+			dnsConfigs: []v1alpha1.DNSConfigSpec{
+				{RecordTTL: &ttl},
+				{RecordTTL: new(int64)},
+			},
+			endpoints: []*endpoint.Endpoint{namespacedEndpoint("team-a", "foo.example.org", endpoint.RecordTypeA, "1.2.3.4")},
+			expected:  []*endpoint.Endpoint{endpoint.NewEndpointWithTTL("foo.example.org", endpoint.RecordTypeA, 300, "1.2.3.4").WithLabel(endpoint.ResourceLabelKey, "service/team-a/svc")},
+		},
+	} {
+		t.Run(tc.title, func(t *testing.T) {
+			var objects []runtime.Object
+			for i, spec := range tc.dnsConfigs {
+				objects = append(objects, newDNSConfig(t, "team-a", string(rune('a'+i)), spec))
+			}
+			dynamicClient := fakeDynamic.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{dnsConfigGVR: "DNSConfigList"}, objects...)
+
+			mockSource := new(testutils.MockSource)
+			mockSource.On("Endpoints").Return(tc.endpoints, nil)
+
+			source, err := NewDNSConfigSource(context.Background(), dynamicClient, mockSource, tc.allowed)
+			require.NoError(t, err)
+
+			endpoints, err := source.Endpoints(context.Background())
+			require.NoError(t, err)
+
+			validateEndpoints(t, endpoints, tc.expected)
+			mockSource.AssertExpectations(t)
+		})
+	}
+}
@@ -20,6 +20,7 @@ import (
 	coreinformers "k8s.io/client-go/informers/core/v1"
 
 	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/source/annotations"
 )
 
 // endpointsForHostname returns the endpoint objects for each host-target combination.
@@ -81,6 +82,56 @@ func endpointsForHostname(hostname string, targets endpoint.Targets, ttl endpoin
 	return endpoints
 }
 
+// endpointsForHostnameEntry returns the endpoint objects of an entry of the hostname annotation,
+// with the targets of the entry if any, and the given targets otherwise.
+func endpointsForHostnameEntry(entry annotations.HostnameEntry, targets endpoint.Targets, ttl endpoint.TTL, providerSpecific endpoint.ProviderSpecific, setIdentifier string, resource string) []*endpoint.Endpoint {
+	if len(entry.Targets) == 0 {
+		return applyHostnameEntry(entry, endpointsForHostname(entry.Hostname, targets, ttl, providerSpecific, setIdentifier, resource))
+	}
+	if entry.TTL.IsConfigured() {
+		ttl = entry.TTL
+	}
+	if entry.RecordType == "" {
+		return endpointsForHostname(entry.Hostname, entry.Targets, ttl, providerSpecific, setIdentifier, resource)
+	}
+
+	ep := endpoint.NewEndpointWithTTL(entry.Hostname, entry.RecordType, ttl, entry.Targets...)
+	if ep == nil {
+		return nil
+	}
+	ep.ProviderSpecific = providerSpecific
+	ep.SetIdentifier = setIdentifier
+	if resource != "" {
+		ep.Labels[endpoint.ResourceLabelKey] = resource
+	}
+	return []*endpoint.Endpoint{ep}
+}
+
+// hostnameEntriesByHostname returns the entries of the hostname annotation of a resource, keyed by hostname.
+func hostnameEntriesByHostname(input map[string]string, resource string) map[string]annotations.HostnameEntry {
+	entries := map[string]annotations.HostnameEntry{}
+	for _, entry := range annotations.HostnameEntriesFromAnnotations(input, resource) {
+		entries[entry.Hostname] = entry
+	}
+	return entries
+}
+
+// applyHostnameEntry applies the TTL and record type of an entry of the hostname annotation
+// to the endpoints generated for its hostname.
+func applyHostnameEntry(entry annotations.HostnameEntry, endpoints []*endpoint.Endpoint) []*endpoint.Endpoint {
+	var result []*endpoint.Endpoint
+	for _, ep := range endpoints {
+		if entry.RecordType != "" && ep.RecordType != entry.RecordType {
+			continue
+		}
+		if entry.TTL.IsConfigured() {
+			ep.RecordTTL = entry.TTL
+		}
+		result = append(result, ep)
+	}
+	return result
+}
+
 func EndpointTargetsFromServices(svcInformer coreinformers.ServiceInformer, namespace string, selector map[string]string) (endpoint.Targets, error) {
 	targets := endpoint.Targets{}
 
@@ -24,6 +24,7 @@ import (
 	"k8s.io/client-go/kubernetes/fake"
 
 	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/source/annotations"
 )
 
 func TestEndpointsForHostname(t *testing.T) {
@@ -123,6 +124,56 @@ func TestEndpointsForHostname(t *testing.T) {
 	}
 }
 
+func TestEndpointsForHostnameEntry(t *testing.T) {
+	tests := []struct {
+		name     string
+		entry    annotations.HostnameEntry
+		targets  endpoint.Targets
+		expected []*endpoint.Endpoint
+	}{
+		{
+			name:    "entry without settings",
+			entry:   annotations.HostnameEntry{Hostname: "example.com"},
+			targets: endpoint.Targets{"192.0.2.1", "lb.example.com"},
+			expected: []*endpoint.Endpoint{
+				{DNSName: "example.com", Targets: endpoint.Targets{"192.0.2.1"}, RecordType: endpoint.RecordTypeA, RecordTTL: 300, Labels: map[string]string{endpoint.ResourceLabelKey: "resource"}},
+				{DNSName: "example.com", Targets: endpoint.Targets{"lb.example.com"}, RecordType: endpoint.RecordTypeCNAME, RecordTTL: 300, Labels: map[string]string{endpoint.ResourceLabelKey: "resource"}},
+			},
+		},
+		{
+			name:    "entry with targets and TTL",
+			entry:   annotations.HostnameEntry{Hostname: "example.com", Targets: endpoint.Targets{"2001:db8::1"}, TTL: 60},
+			targets: endpoint.Targets{"192.0.2.1"},
+			expected: []*endpoint.Endpoint{
+				{DNSName: "example.com", Targets: endpoint.Targets{"2001:db8::1"}, RecordType: endpoint.RecordTypeAAAA, RecordTTL: 60, Labels: map[string]string{endpoint.ResourceLabelKey: "resource"}},
+			},
+		},
+		{
+			name:    "entry with record type restricting the targets of the resource",
+			entry:   annotations.HostnameEntry{Hostname: "example.com", RecordType: endpoint.RecordTypeCNAME},
+			targets: endpoint.Targets{"192.0.2.1", "lb.example.com"},
+			expected: []*endpoint.Endpoint{
+				{DNSName: "example.com", Targets: endpoint.Targets{"lb.example.com"}, RecordType: endpoint.RecordTypeCNAME, RecordTTL: 300, Labels: map[string]string{endpoint.ResourceLabelKey: "resource"}},
+			},
+		},
+		{
+			name:    "entry with targets and record type",
+			entry:   annotations.HostnameEntry{Hostname: "example.com", Targets: endpoint.Targets{"\"v=spf1 -all\""}, RecordType: endpoint.RecordTypeTXT},
+			targets: endpoint.Targets{"192.0.2.1"},
+			expected: []*endpoint.Endpoint{
+				{DNSName: "example.com", Targets: endpoint.Targets{"\"v=spf1 -all\""}, RecordType: endpoint.RecordTypeTXT, RecordTTL: 300, Labels: map[string]string{endpoint.ResourceLabelKey: "resource"}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := endpointsForHostnameEntry(tt.entry, tt.targets, endpoint.TTL(300), nil, "", "resource")
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
 func TestEndpointTargetsFromServices(t *testing.T) {
 	tests := []struct {
 		name      string
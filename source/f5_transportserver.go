@@ -20,7 +20,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"strings"
+	"time"
 
 	log "github.com/sirupsen/logrus"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -37,6 +37,7 @@ import (
 
 	f5 "github.com/F5Networks/k8s-bigip-ctlr/v2/config/apis/cis/v1"
 
+	"sigs.k8s.io/external-dns/pkg/decisions"
 	"sigs.k8s.io/external-dns/source/informers"
 
 	"sigs.k8s.io/external-dns/endpoint"
@@ -55,8 +56,11 @@ type f5TransportServerSource struct {
 	transportServerInformer kubeinformers.GenericInformer
 	kubeClient              kubernetes.Interface
 	annotationFilter        string
+	labelSelector           labels.Selector
 	namespace               string
 	unstructuredConverter   *unstructuredConverter
+	filterDecisions         *decisions.Recorder
+	propagatedLabels        PropagatedLabels
 }
 
 func NewF5TransportServerSource(
@@ -65,8 +69,13 @@ func NewF5TransportServerSource(
 	kubeClient kubernetes.Interface,
 	namespace string,
 	annotationFilter string,
+	labelSelector labels.Selector,
+	filterDecisions *decisions.Recorder,
+	resyncPeriod time.Duration,
+	prefixAliases annotations.PrefixAliases,
+	propagatedLabels PropagatedLabels,
 ) (Source, error) {
-	informerFactory := dynamicinformer.NewFilteredDynamicSharedInformerFactory(dynamicKubeClient, 0, namespace, nil)
+	informerFactory := dynamicinformer.NewFilteredDynamicSharedInformerFactory(dynamicKubeClient, resyncPeriod, namespace, labelFilterListOptions(labelSelector, filterDecisions))
 	transportServerInformer := informerFactory.ForResource(f5TransportServerGVR)
 
 	transportServerInformer.Informer().AddEventHandler(
@@ -76,6 +85,8 @@ func NewF5TransportServerSource(
 		},
 	)
 
+	aliasAnnotations(prefixAliases, transportServerInformer.Informer())
+
 	informerFactory.Start(ctx.Done())
 
 	// wait for the local cache to be populated.
@@ -89,19 +100,22 @@ func NewF5TransportServerSource(
 	}
 
 	return &f5TransportServerSource{
+		filterDecisions:         filterDecisions,
 		dynamicKubeClient:       dynamicKubeClient,
 		transportServerInformer: transportServerInformer,
 		kubeClient:              kubeClient,
 		namespace:               namespace,
 		annotationFilter:        annotationFilter,
+		labelSelector:           labelSelector,
 		unstructuredConverter:   uc,
+		propagatedLabels:        propagatedLabels,
 	}, nil
 }
 
 // Endpoints returns endpoint objects for each host-target combination that should be processed.
 // Retrieves all TransportServers in the source's namespace(s).
 func (ts *f5TransportServerSource) Endpoints(ctx context.Context) ([]*endpoint.Endpoint, error) {
-	transportServerObjects, err := ts.transportServerInformer.Lister().ByNamespace(ts.namespace).List(labels.Everything())
+	transportServerObjects, err := ts.transportServerInformer.Lister().ByNamespace(ts.namespace).List(ts.labelSelector)
 	if err != nil {
 		return nil, err
 	}
@@ -145,25 +159,26 @@ func (ts *f5TransportServerSource) endpointsFromTransportServers(transportServer
 	var endpoints []*endpoint.Endpoint
 
 	for _, transportServer := range transportServers {
-		if !hasValidTransportServerIP(transportServer) {
+		resource := fmt.Sprintf("f5-transportserver/%s/%s", transportServer.Namespace, transportServer.Name)
+
+		targets := transportServerTargets(transportServer)
+		if len(targets) == 0 && transportServer.Spec.IPAMLabel != "" {
+			// the informer picks the address up once F5 IPAM has assigned it
+			log.Debugf("F5 IPAM has not assigned an address to TransportServer %s/%s yet, skipping endpoint creation.",
+				transportServer.Namespace, transportServer.Name)
+			continue
+		}
+		if len(targets) == 0 {
 			log.Warnf("F5 TransportServer %s/%s is missing a valid IP address, skipping endpoint creation.",
 				transportServer.Namespace, transportServer.Name)
 			continue
 		}
 
-		resource := fmt.Sprintf("f5-transportserver/%s/%s", transportServer.Namespace, transportServer.Name)
-
 		ttl := annotations.TTLFromAnnotations(transportServer.Annotations, resource)
 
-		targets := annotations.TargetsFromTargetAnnotation(transportServer.Annotations)
-		if len(targets) == 0 && transportServer.Spec.VirtualServerAddress != "" {
-			targets = append(targets, transportServer.Spec.VirtualServerAddress)
-		}
-		if len(targets) == 0 && transportServer.Status.VSAddress != "" {
-			targets = append(targets, transportServer.Status.VSAddress)
-		}
-
-		endpoints = append(endpoints, endpointsForHostname(transportServer.Spec.Host, targets, ttl, nil, "", resource)...)
+		serverEndpoints := endpointsForHostname(transportServer.Spec.Host, targets, ttl, nil, "", resource)
+		ts.propagatedLabels.propagate(serverEndpoints, transportServer.Labels)
+		endpoints = append(endpoints, serverEndpoints...)
 	}
 
 	return endpoints, nil
@@ -213,7 +228,17 @@ func (ts *f5TransportServerSource) filterByAnnotations(transportServers []*f5.Tr
 	return filteredList, nil
 }
 
-func hasValidTransportServerIP(vs *f5.TransportServer) bool {
-	normalizedAddress := strings.ToLower(vs.Status.VSAddress)
-	return normalizedAddress != "none" && normalizedAddress != ""
+// transportServerTargets returns the targets of a TransportServer from its target annotation,
+// its virtualServerAddress or the address reported in its status, in that order.
+func transportServerTargets(ts *f5.TransportServer) endpoint.Targets {
+	targets := annotations.TargetsFromTargetAnnotation(ts.Annotations)
+	if len(targets) == 0 && ts.Spec.VirtualServerAddress != "" {
+		targets = append(targets, f5Address(ts.Spec.VirtualServerAddress))
+	}
+	if len(targets) == 0 {
+		if address := f5Address(ts.Status.VSAddress); address != "" {
+			targets = append(targets, address)
+		}
+	}
+	return targets
 }
@@ -25,6 +25,7 @@ import (
 	"github.com/stretchr/testify/require"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	fakeDynamic "k8s.io/client-go/dynamic/fake"
 	fakeKube "k8s.io/client-go/kubernetes/fake"
@@ -146,6 +147,39 @@ func TestF5TransportServerEndpoints(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:             "F5 TransportServer with IPv6 address and route domain from the status field",
+			annotationFilter: "",
+			transportServer: f5.TransportServer{
+				TypeMeta: metav1.TypeMeta{
+					APIVersion: f5TransportServerGVR.GroupVersion().String(),
+					Kind:       "TransportServer",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-vs",
+					Namespace: defaultF5TransportServerNamespace,
+				},
+				Spec: f5.TransportServerSpec{
+					Host:      "www.example.com",
+					IPAMLabel: "test",
+				},
+				Status: f5.CustomResourceStatus{
+					VSAddress: "2001:db8::10%2",
+					Status:    "OK",
+				},
+			},
+			expected: []*endpoint.Endpoint{
+				{
+					DNSName:    "www.example.com",
+					Targets:    []string{"2001:db8::10"},
+					RecordType: endpoint.RecordTypeAAAA,
+					RecordTTL:  0,
+					Labels: endpoint.Labels{
+						"resource": "f5-transportserver/transportserver/test-vs",
+					},
+				},
+			},
+		},
 		{
 			name:             "F5 TransportServer with no IP address set",
 			annotationFilter: "",
@@ -344,7 +378,7 @@ func TestF5TransportServerEndpoints(t *testing.T) {
 			_, err = fakeDynamicClient.Resource(f5TransportServerGVR).Namespace(defaultF5TransportServerNamespace).Create(context.Background(), &transportServer, metav1.CreateOptions{})
 			assert.NoError(t, err)
 
-			source, err := NewF5TransportServerSource(context.TODO(), fakeDynamicClient, fakeKubernetesClient, defaultF5TransportServerNamespace, tc.annotationFilter)
+			source, err := NewF5TransportServerSource(context.TODO(), fakeDynamicClient, fakeKubernetesClient, defaultF5TransportServerNamespace, tc.annotationFilter, labels.Everything(), nil, 0, nil, nil)
 			require.NoError(t, err)
 			assert.NotNil(t, source)
 
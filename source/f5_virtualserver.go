@@ -21,7 +21,9 @@ import (
 	"errors"
 	"fmt"
 	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	log "github.com/sirupsen/logrus"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -39,6 +41,7 @@ import (
 	f5 "github.com/F5Networks/k8s-bigip-ctlr/v2/config/apis/cis/v1"
 
 	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/pkg/decisions"
 	"sigs.k8s.io/external-dns/source/annotations"
 	"sigs.k8s.io/external-dns/source/informers"
 )
@@ -55,8 +58,11 @@ type f5VirtualServerSource struct {
 	virtualServerInformer kubeinformers.GenericInformer
 	kubeClient            kubernetes.Interface
 	annotationFilter      string
+	labelSelector         labels.Selector
 	namespace             string
 	unstructuredConverter *unstructuredConverter
+	filterDecisions       *decisions.Recorder
+	propagatedLabels      PropagatedLabels
 }
 
 func NewF5VirtualServerSource(
@@ -65,8 +71,13 @@ func NewF5VirtualServerSource(
 	kubeClient kubernetes.Interface,
 	namespace string,
 	annotationFilter string,
+	labelSelector labels.Selector,
+	filterDecisions *decisions.Recorder,
+	resyncPeriod time.Duration,
+	prefixAliases annotations.PrefixAliases,
+	propagatedLabels PropagatedLabels,
 ) (Source, error) {
-	informerFactory := dynamicinformer.NewFilteredDynamicSharedInformerFactory(dynamicKubeClient, 0, namespace, nil)
+	informerFactory := dynamicinformer.NewFilteredDynamicSharedInformerFactory(dynamicKubeClient, resyncPeriod, namespace, labelFilterListOptions(labelSelector, filterDecisions))
 	virtualServerInformer := informerFactory.ForResource(f5VirtualServerGVR)
 
 	virtualServerInformer.Informer().AddEventHandler(
@@ -76,6 +87,8 @@ func NewF5VirtualServerSource(
 		},
 	)
 
+	aliasAnnotations(prefixAliases, virtualServerInformer.Informer())
+
 	informerFactory.Start(ctx.Done())
 
 	// wait for the local cache to be populated.
@@ -89,19 +102,22 @@ func NewF5VirtualServerSource(
 	}
 
 	return &f5VirtualServerSource{
+		filterDecisions:       filterDecisions,
 		dynamicKubeClient:     dynamicKubeClient,
 		virtualServerInformer: virtualServerInformer,
 		kubeClient:            kubeClient,
 		namespace:             namespace,
 		annotationFilter:      annotationFilter,
+		labelSelector:         labelSelector,
 		unstructuredConverter: uc,
+		propagatedLabels:      propagatedLabels,
 	}, nil
 }
 
 // Endpoints returns endpoint objects for each host-target combination that should be processed.
 // Retrieves all VirtualServers in the source's namespace(s).
 func (vs *f5VirtualServerSource) Endpoints(ctx context.Context) ([]*endpoint.Endpoint, error) {
-	virtualServerObjects, err := vs.virtualServerInformer.Lister().ByNamespace(vs.namespace).List(labels.Everything())
+	virtualServerObjects, err := vs.virtualServerInformer.Lister().ByNamespace(vs.namespace).List(vs.labelSelector)
 	if err != nil {
 		return nil, err
 	}
@@ -150,26 +166,26 @@ func (vs *f5VirtualServerSource) endpointsFromVirtualServers(virtualServers []*f
 	var endpoints []*endpoint.Endpoint
 
 	for _, virtualServer := range virtualServers {
-		if !hasValidVirtualServerIP(virtualServer) {
+		resource := fmt.Sprintf("f5-virtualserver/%s/%s", virtualServer.Namespace, virtualServer.Name)
+
+		targets := virtualServerTargets(virtualServer)
+		if len(targets) == 0 && virtualServer.Spec.IPAMLabel != "" {
+			// the informer picks the address up once F5 IPAM has assigned it
+			log.Debugf("F5 IPAM has not assigned an address to VirtualServer %s/%s yet, skipping endpoint creation.",
+				virtualServer.Namespace, virtualServer.Name)
+			continue
+		}
+		if len(targets) == 0 {
 			log.Warnf("F5 VirtualServer %s/%s is missing a valid IP address, skipping endpoint creation.",
 				virtualServer.Namespace, virtualServer.Name)
 			continue
 		}
 
-		resource := fmt.Sprintf("f5-virtualserver/%s/%s", virtualServer.Namespace, virtualServer.Name)
-
 		ttl := annotations.TTLFromAnnotations(virtualServer.Annotations, resource)
 
-		targets := annotations.TargetsFromTargetAnnotation(virtualServer.Annotations)
-		if len(targets) == 0 && virtualServer.Spec.VirtualServerAddress != "" {
-			targets = append(targets, virtualServer.Spec.VirtualServerAddress)
-		}
-
-		if len(targets) == 0 && virtualServer.Status.VSAddress != "" {
-			targets = append(targets, virtualServer.Status.VSAddress)
-		}
-
-		endpoints = append(endpoints, endpointsForHostname(virtualServer.Spec.Host, targets, ttl, nil, "", resource)...)
+		serverEndpoints := endpointsForHostname(virtualServer.Spec.Host, targets, ttl, nil, "", resource)
+		vs.propagatedLabels.propagate(serverEndpoints, virtualServer.Labels)
+		endpoints = append(endpoints, serverEndpoints...)
 	}
 
 	return endpoints, nil
@@ -219,7 +235,32 @@ func (vs *f5VirtualServerSource) filterByAnnotations(virtualServers []*f5.Virtua
 	return filteredList, nil
 }
 
-func hasValidVirtualServerIP(vs *f5.VirtualServer) bool {
-	normalizedAddress := strings.ToLower(vs.Status.VSAddress)
-	return normalizedAddress != "none" && normalizedAddress != ""
+// virtualServerTargets returns the targets of a VirtualServer from its target annotation,
+// its virtualServerAddress or the address reported in its status, in that order.
+func virtualServerTargets(vs *f5.VirtualServer) endpoint.Targets {
+	targets := annotations.TargetsFromTargetAnnotation(vs.Annotations)
+	if len(targets) == 0 && vs.Spec.VirtualServerAddress != "" {
+		targets = append(targets, f5Address(vs.Spec.VirtualServerAddress))
+	}
+	if len(targets) == 0 {
+		if address := f5Address(vs.Status.VSAddress); address != "" {
+			targets = append(targets, address)
+		}
+	}
+	return targets
+}
+
+// f5Address normalizes an address reported by F5 CIS, returning an empty string for "None" and
+// dropping the BIG-IP route domain suffix, as in "2001:db8::1%2", so that the address is published as an A or AAAA record.
+func f5Address(address string) string {
+	address = strings.TrimSpace(address)
+	if strings.EqualFold(address, "none") {
+		return ""
+	}
+	if host, routeDomain, found := strings.Cut(address, "%"); found {
+		if _, err := strconv.Atoi(routeDomain); err == nil {
+			return host
+		}
+	}
+	return address
 }
@@ -20,11 +20,13 @@ import (
 	"context"
 	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	fakeDynamic "k8s.io/client-go/dynamic/fake"
 	fakeKube "k8s.io/client-go/kubernetes/fake"
@@ -344,7 +346,7 @@ func TestF5VirtualServerEndpoints(t *testing.T) {
 			_, err = fakeDynamicClient.Resource(f5VirtualServerGVR).Namespace(defaultF5VirtualServerNamespace).Create(context.Background(), &virtualServer, metav1.CreateOptions{})
 			assert.NoError(t, err)
 
-			source, err := NewF5VirtualServerSource(context.TODO(), fakeDynamicClient, fakeKubernetesClient, defaultF5VirtualServerNamespace, tc.annotationFilter)
+			source, err := NewF5VirtualServerSource(context.TODO(), fakeDynamicClient, fakeKubernetesClient, defaultF5VirtualServerNamespace, tc.annotationFilter, labels.Everything(), nil, 0, nil, nil)
 			require.NoError(t, err)
 			assert.NotNil(t, source)
 
@@ -360,3 +362,62 @@ func TestF5VirtualServerEndpoints(t *testing.T) {
 		})
 	}
 }
+
+func TestF5VirtualServerIPAMAddress(t *testing.T) {
+	t.Parallel()
+
+	virtualServer := f5.VirtualServer{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: f5VirtualServerGVR.GroupVersion().String(),
+			Kind:       "VirtualServer",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-vs",
+			Namespace: defaultF5VirtualServerNamespace,
+		},
+		Spec: f5.VirtualServerSpec{
+			Host:      "www.example.com",
+			IPAMLabel: "test",
+		},
+	}
+
+	scheme := runtime.NewScheme()
+	scheme.AddKnownTypes(f5VirtualServerGVR.GroupVersion(), &f5.VirtualServer{}, &f5.VirtualServerList{})
+	fakeDynamicClient := fakeDynamic.NewSimpleDynamicClient(scheme)
+
+	obj := &unstructured.Unstructured{}
+	virtualServerJSON, err := json.Marshal(virtualServer)
+	require.NoError(t, err)
+	require.NoError(t, obj.UnmarshalJSON(virtualServerJSON))
+	_, err = fakeDynamicClient.Resource(f5VirtualServerGVR).Namespace(defaultF5VirtualServerNamespace).Create(context.Background(), obj, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	source, err := NewF5VirtualServerSource(context.TODO(), fakeDynamicClient, fakeKube.NewClientset(), defaultF5VirtualServerNamespace, "", labels.Everything(), nil, 0, nil, nil)
+	require.NoError(t, err)
+
+	// The VirtualServer is skipped until F5 IPAM assigns its address.
+	endpoints, err := source.Endpoints(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, endpoints)
+
+	require.NoError(t, unstructured.SetNestedField(obj.Object, "2001:db8::10%2", "status", "vsAddress"))
+	_, err = fakeDynamicClient.Resource(f5VirtualServerGVR).Namespace(defaultF5VirtualServerNamespace).Update(context.Background(), obj, metav1.UpdateOptions{})
+	require.NoError(t, err)
+
+	// The address is then read from the informer cache.
+	require.Eventually(t, func() bool {
+		endpoints, err = source.Endpoints(context.Background())
+		return err == nil && len(endpoints) > 0
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, []*endpoint.Endpoint{
+		{
+			DNSName:    "www.example.com",
+			Targets:    []string{"2001:db8::10"},
+			RecordType: endpoint.RecordTypeAAAA,
+			RecordTTL:  0,
+			Labels: endpoint.Labels{
+				"resource": "f5-virtualserver/virtualserver/test-vs",
+			},
+		},
+	}, endpoints)
+}
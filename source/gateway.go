@@ -19,10 +19,14 @@ package source
 import (
 	"context"
 	"fmt"
+	"maps"
 	"net/netip"
+	"slices"
 	"sort"
+	"strconv"
 	"strings"
 	"text/template"
+	"time"
 
 	log "github.com/sirupsen/logrus"
 	corev1 "k8s.io/api/core/v1"
@@ -48,6 +52,13 @@ import (
 const (
 	gatewayGroup = "gateway.networking.k8s.io"
 	gatewayKind  = "Gateway"
+
+	gatewayWeightAnnotationKey = annotations.GatewayWeightKey
+	// awsWeightProperty is the provider-specific property of the weight of the weighted records of AWS.
+	awsWeightProperty = "aws/weight"
+	// defaultGatewayWeight is the weight of the Gateways without a weight that
+	// share hostnames with weighted Gateways.
+	defaultGatewayWeight = 1
 )
 
 type gatewayRoute interface {
@@ -72,7 +83,7 @@ type gatewayRouteInformer interface {
 	Informer() cache.SharedIndexInformer
 }
 
-func newGatewayInformerFactory(client gateway.Interface, namespace string, labelSelector labels.Selector) gwinformers.SharedInformerFactory {
+func newGatewayInformerFactory(client gateway.Interface, namespace string, labelSelector labels.Selector, resyncPeriod time.Duration) gwinformers.SharedInformerFactory {
 	var opts []gwinformers.SharedInformerOption
 	if namespace != "" {
 		opts = append(opts, gwinformers.WithNamespace(namespace))
@@ -83,7 +94,7 @@ func newGatewayInformerFactory(client gateway.Interface, namespace string, label
 			o.LabelSelector = lbls
 		}))
 	}
-	return gwinformers.NewSharedInformerFactoryWithOptions(client, 0, opts...)
+	return gwinformers.NewSharedInformerFactoryWithOptions(client, resyncPeriod, opts...)
 }
 
 type gatewayRouteSource struct {
@@ -103,6 +114,20 @@ type gatewayRouteSource struct {
 	fqdnTemplate             *template.Template
 	combineFQDNAnnotation    bool
 	ignoreHostnameAnnotation bool
+	// weightProperty is the provider-specific property of the weight of
+	// weighted records, empty if the provider has no weighted records.
+	weightProperty   string
+	propagatedLabels PropagatedLabels
+}
+
+// GatewayWeightProperty returns the provider-specific property of the weight
+// of the weighted records of the provider, or an empty string if the provider
+// has no weighted records and the weights of the Gateways are ignored.
+func GatewayWeightProperty(provider string) string {
+	if provider == "aws" {
+		return awsWeightProperty
+	}
+	return ""
 }
 
 func newGatewayRouteSource(clients ClientGenerator, config *Config, kind string, newInformerFn newGatewayRouteInformerFunc) (Source, error) {
@@ -130,13 +155,14 @@ func newGatewayRouteSource(clients ClientGenerator, config *Config, kind string,
 		return nil, err
 	}
 
-	informerFactory := newGatewayInformerFactory(client, config.GatewayNamespace, gwLabels)
+	resyncPeriod := config.ResyncPeriods["gateway-"+strings.ToLower(kind)]
+	informerFactory := newGatewayInformerFactory(client, config.GatewayNamespace, gwLabels, resyncPeriod)
 	gwInformer := informerFactory.Gateway().V1beta1().Gateways() // TODO: Gateway informer should be shared across gateway sources.
 	gwInformer.Informer()                                        // Register with factory before starting.
 
 	rtInformerFactory := informerFactory
 	if config.Namespace != config.GatewayNamespace || !selectorsEqual(rtLabels, gwLabels) {
-		rtInformerFactory = newGatewayInformerFactory(client, config.Namespace, rtLabels)
+		rtInformerFactory = newGatewayInformerFactory(client, config.Namespace, rtLabels, resyncPeriod)
 	}
 	rtInformer := newInformerFn(rtInformerFactory)
 	rtInformer.Informer() // Register with factory before starting.
@@ -146,10 +172,12 @@ func newGatewayRouteSource(clients ClientGenerator, config *Config, kind string,
 		return nil, err
 	}
 
-	kubeInformerFactory := kubeinformers.NewSharedInformerFactory(kubeClient, 0)
+	kubeInformerFactory := kubeinformers.NewSharedInformerFactory(kubeClient, resyncPeriod)
 	nsInformer := kubeInformerFactory.Core().V1().Namespaces() // TODO: Namespace informer should be shared across gateway sources.
 	nsInformer.Informer()                                      // Register with factory before starting.
 
+	aliasAnnotations(config.AnnotationPrefixAliases, gwInformer.Informer(), rtInformer.Informer())
+
 	informerFactory.Start(wait.NeverStop)
 	kubeInformerFactory.Start(wait.NeverStop)
 	if rtInformerFactory != informerFactory {
@@ -183,6 +211,8 @@ func newGatewayRouteSource(clients ClientGenerator, config *Config, kind string,
 		fqdnTemplate:             tmpl,
 		combineFQDNAnnotation:    config.CombineFQDNAndAnnotation,
 		ignoreHostnameAnnotation: config.IgnoreHostnameAnnotation,
+		weightProperty:           config.GatewayWeightProperty,
+		propagatedLabels:         config.PropagatedLabels,
 	}
 	return src, nil
 }
@@ -241,9 +271,41 @@ func (src *gatewayRouteSource) Endpoints(ctx context.Context) ([]*endpoint.Endpo
 		resource := fmt.Sprintf("%s/%s/%s", kind, meta.Namespace, meta.Name)
 		providerSpecific, setIdentifier := annotations.ProviderSpecificAnnotations(annots)
 		ttl := annotations.TTLFromAnnotations(annots, resource)
-		for host, targets := range hostTargets {
-			routeEndpoints = append(routeEndpoints, endpointsForHostname(host, targets, ttl, providerSpecific, setIdentifier, resource)...)
+		var entries map[string]annotations.HostnameEntry
+		if !src.ignoreHostnameAnnotation {
+			entries = hostnameEntriesByHostname(annots, resource)
+		}
+		for host, gwTargets := range hostTargets {
+			entry, hasEntry := gatewayHostnameEntry(host, entries, gwTargets)
+			if !gatewayTargetsAreWeighted(gwTargets) {
+				var targets endpoint.Targets
+				for _, gwt := range gwTargets {
+					targets = append(targets, gwt.targets...)
+				}
+				hostTTL, hostProviderSpecific, hostResource := withGatewayDefaults(ttl, providerSpecific, resource, gwTargets)
+				if hasEntry {
+					routeEndpoints = append(routeEndpoints, endpointsForHostnameEntry(entry, uniqueTargets(targets), hostTTL, hostProviderSpecific, setIdentifier, hostResource)...)
+					continue
+				}
+				routeEndpoints = append(routeEndpoints, endpointsForHostname(host, uniqueTargets(targets), hostTTL, hostProviderSpecific, setIdentifier, hostResource)...)
+				continue
+			}
+			// Weighted records need a set identifier per Gateway.
+			for _, gwt := range gwTargets {
+				gwSetIdentifier := gwt.gateway.String()
+				if setIdentifier != "" {
+					gwSetIdentifier = setIdentifier + "/" + gwSetIdentifier
+				}
+				gwTTL, gwProviderSpecific, gwResource := withGatewayDefaults(ttl, providerSpecific, resource, []*gatewayTargets{gwt})
+				gwProviderSpecific = append(endpoint.ProviderSpecific{{Name: src.weightProperty, Value: strconv.FormatInt(gwt.weight, 10)}}, gwProviderSpecific...)
+				if hasEntry {
+					routeEndpoints = append(routeEndpoints, endpointsForHostnameEntry(entry, gwt.targets, gwTTL, gwProviderSpecific, gwSetIdentifier, gwResource)...)
+					continue
+				}
+				routeEndpoints = append(routeEndpoints, endpointsForHostname(host, gwt.targets, gwTTL, gwProviderSpecific, gwSetIdentifier, gwResource)...)
+			}
 		}
+		src.propagatedLabels.propagate(routeEndpoints, meta.Labels)
 		log.Debugf("Endpoints generated from %s %s/%s: %v", src.rtKind, meta.Namespace, meta.Name, routeEndpoints)
 
 		endpoints = append(endpoints, routeEndpoints...)
@@ -262,8 +324,9 @@ type gatewayRouteResolver struct {
 }
 
 type gatewayListeners struct {
-	gateway   *v1beta1.Gateway
-	listeners map[v1.SectionName][]v1.Listener
+	gateway     *v1beta1.Gateway
+	annotations map[string]string
+	listeners   map[v1.SectionName][]v1.Listener
 }
 
 func newGatewayRouteResolver(src *gatewayRouteSource, gateways []*v1beta1.Gateway, namespaces []*corev1.Namespace) *gatewayRouteResolver {
@@ -276,8 +339,9 @@ func newGatewayRouteResolver(src *gatewayRouteSource, gateways []*v1beta1.Gatewa
 		}
 		lss[""] = gw.Spec.Listeners
 		gws[namespacedName(gw.Namespace, gw.Name)] = gatewayListeners{
-			gateway:   gw,
-			listeners: lss,
+			gateway:     gw,
+			annotations: gatewayAnnotations(gw),
+			listeners:   lss,
 		}
 	}
 	// Create Namespace lookup table.
@@ -292,12 +356,38 @@ func newGatewayRouteResolver(src *gatewayRouteSource, gateways []*v1beta1.Gatewa
 	}
 }
 
-func (c *gatewayRouteResolver) resolve(rt gatewayRoute) (map[string]endpoint.Targets, error) {
+// gatewayTargets are the targets of a hostname from one Gateway.
+type gatewayTargets struct {
+	gateway     types.NamespacedName
+	annotations map[string]string
+	targets     endpoint.Targets
+	// fromGateway is whether the hostname is the one of the hostname annotation of the Gateway.
+	fromGateway bool
+	// weight is the weight of the records of the Gateway, set by the
+	// gateway-weight annotation of the Gateway for its matching Listeners.
+	weight   int64
+	weighted bool
+}
+
+// gatewayTargetsAreWeighted returns whether any of the Gateways has a weight.
+func gatewayTargetsAreWeighted(gwTargets []*gatewayTargets) bool {
+	for _, gwt := range gwTargets {
+		if gwt.weighted {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *gatewayRouteResolver) resolve(rt gatewayRoute) (map[string][]*gatewayTargets, error) {
 	rtHosts, err := c.hosts(rt)
 	if err != nil {
 		return nil, err
 	}
-	hostTargets := make(map[string]endpoint.Targets)
+	hostTargets := make(map[string][]*gatewayTargets)
+	// The annotations of the Gateways only apply to the Routes without hostnames
+	// nor annotations of their own, such as most {TCP,UDP}Routes.
+	gatewayHostnames := !c.src.ignoreHostnameAnnotation && len(rtHosts) == 1 && rtHosts[0] == "" && !hasExternalDNSAnnotations(rt.Metadata().Annotations)
 
 	routeParentRefs := rt.ParentRefs()
 
@@ -368,23 +458,41 @@ func (c *gatewayRouteResolver) resolve(rt gatewayRoute) (map[string]endpoint.Tar
 				gwHost = string(*lis.Hostname)
 			}
 			for _, rtHost := range rtHosts {
+				hosts := []string{rtHost}
+				fromGateway := false
 				if gwHost == "" && rtHost == "" {
 					// For {HTTP,TLS}Routes, this means the Route and the Listener both allow _any_ hostnames.
 					// For {TCP,UDP}Routes, this should always happen since neither specifies hostnames.
-					continue
-				}
-				host, ok := gwMatchingHost(gwHost, rtHost)
-				if !ok {
-					continue
+					// Either way, only the hostname annotation of the Gateway applies.
+					if !gatewayHostnames {
+						continue
+					}
+					hosts = nil
+					for _, entry := range annotations.HostnameEntriesFromAnnotations(gw.annotations, fmt.Sprintf("gateway/%s/%s", gw.gateway.Namespace, gw.gateway.Name)) {
+						hosts = append(hosts, entry.Hostname)
+					}
+					fromGateway = true
 				}
-				override := annotations.TargetsFromTargetAnnotation(gw.gateway.Annotations)
-				hostTargets[host] = append(hostTargets[host], override...)
-				if len(override) == 0 {
-					for _, addr := range gw.gateway.Status.Addresses {
-						hostTargets[host] = append(hostTargets[host], addr.Value)
+				for _, host := range hosts {
+					host, ok := gwMatchingHost(gwHost, host)
+					if !ok {
+						continue
+					}
+					gwt := hostGatewayTargets(hostTargets, host, gw)
+					gwt.fromGateway = gwt.fromGateway || fromGateway
+					override := annotations.TargetsFromTargetAnnotation(gw.annotations)
+					gwt.targets = append(gwt.targets, override...)
+					if len(override) == 0 {
+						for _, addr := range gw.gateway.Status.Addresses {
+							gwt.targets = append(gwt.targets, addr.Value)
+						}
 					}
+					// the weights are ignored if the provider has no weighted records
+					if weight, ok := gatewayListenerWeight(gw, lis); ok && c.src.weightProperty != "" && (!gwt.weighted || weight > gwt.weight) {
+						gwt.weight, gwt.weighted = weight, true
+					}
+					match = true
 				}
-				match = true
 			}
 		}
 		if !match {
@@ -393,12 +501,130 @@ func (c *gatewayRouteResolver) resolve(rt gatewayRoute) (map[string]endpoint.Tar
 	}
 	// If a Gateway has multiple matching Listeners for the same host, then we'll
 	// add its IPs to the target list multiple times and should dedupe them.
-	for host, targets := range hostTargets {
-		hostTargets[host] = uniqueTargets(targets)
+	for host, gwTargets := range hostTargets {
+		weighted := gatewayTargetsAreWeighted(gwTargets)
+		for _, gwt := range gwTargets {
+			gwt.targets = uniqueTargets(gwt.targets)
+			// Weighted and unweighted records can't be mixed.
+			if weighted && !gwt.weighted {
+				log.Debugf("Gateway %s has no weight for host %s of %s %s/%s, using a weight of %d", gwt.gateway, host, c.src.rtKind, meta.Namespace, meta.Name, defaultGatewayWeight)
+				gwt.weight, gwt.weighted = defaultGatewayWeight, true
+			}
+		}
 	}
 	return hostTargets, nil
 }
 
+// hostGatewayTargets returns the targets of host from the Gateway, adding them if missing.
+func hostGatewayTargets(hostTargets map[string][]*gatewayTargets, host string, gw gatewayListeners) *gatewayTargets {
+	gateway := namespacedName(gw.gateway.Namespace, gw.gateway.Name)
+	for _, gwt := range hostTargets[host] {
+		if gwt.gateway == gateway {
+			return gwt
+		}
+	}
+	gwt := &gatewayTargets{gateway: gateway, annotations: gw.annotations}
+	hostTargets[host] = append(hostTargets[host], gwt)
+	return gwt
+}
+
+// gatewayAnnotations returns the annotations of a Gateway, together with the
+// annotations of its spec.infrastructure, which the Gateway annotations override.
+// Gateways managed by tooling often can only be annotated through the latter.
+func gatewayAnnotations(gw *v1beta1.Gateway) map[string]string {
+	if gw.Spec.Infrastructure == nil || len(gw.Spec.Infrastructure.Annotations) == 0 {
+		return gw.Annotations
+	}
+	annots := make(map[string]string, len(gw.Spec.Infrastructure.Annotations)+len(gw.Annotations))
+	for k, v := range gw.Spec.Infrastructure.Annotations {
+		annots[string(k)] = string(v)
+	}
+	maps.Copy(annots, gw.Annotations)
+	return annots
+}
+
+// gatewayHostnameEntry returns the entry of the hostname annotation of a Route
+// for one of its hostnames, or of its Gateways for the hostnames of theirs.
+func gatewayHostnameEntry(host string, routeEntries map[string]annotations.HostnameEntry, gwTargets []*gatewayTargets) (annotations.HostnameEntry, bool) {
+	if entry, ok := routeEntries[host]; ok {
+		return entry, true
+	}
+	for _, gwt := range gwTargets {
+		if !gwt.fromGateway {
+			continue
+		}
+		if entry, ok := hostnameEntriesByHostname(gwt.annotations, fmt.Sprintf("gateway/%s", gwt.gateway))[host]; ok {
+			return entry, true
+		}
+	}
+	return annotations.HostnameEntry{}, false
+}
+
+// hasExternalDNSAnnotations returns whether there's any external-dns annotation
+// besides the controller one.
+func hasExternalDNSAnnotations(annots map[string]string) bool {
+	for key := range annots {
+		if strings.HasPrefix(key, annotations.Prefix) && key != controllerAnnotationKey {
+			return true
+		}
+	}
+	return false
+}
+
+// withGatewayDefaults returns the TTL, provider-specific properties and resource
+// of the records of a Route for a hostname. The records of the hostname annotation
+// of Gateways take the TTL and provider-specific properties of the annotations
+// of these Gateways, and are owned by the first of them.
+func withGatewayDefaults(ttl endpoint.TTL, providerSpecific endpoint.ProviderSpecific, resource string, gwTargets []*gatewayTargets) (endpoint.TTL, endpoint.ProviderSpecific, string) {
+	for _, gwt := range gwTargets {
+		if !gwt.fromGateway {
+			return ttl, providerSpecific, resource
+		}
+	}
+	result := slices.Clone(providerSpecific)
+	for _, gwt := range gwTargets {
+		if !ttl.IsConfigured() {
+			ttl = annotations.TTLFromAnnotations(gwt.annotations, fmt.Sprintf("gateway/%s", gwt.gateway))
+		}
+		gwProviderSpecific, _ := annotations.ProviderSpecificAnnotations(gwt.annotations)
+		for _, property := range gwProviderSpecific {
+			if !slices.ContainsFunc(result, func(p endpoint.ProviderSpecificProperty) bool { return p.Name == property.Name }) {
+				result = append(result, property)
+			}
+		}
+	}
+	return ttl, result, fmt.Sprintf("gateway/%s", gwTargets[0].gateway)
+}
+
+// gatewayListenerWeight returns the weight of the records of a Listener of a Gateway,
+// from the gateway-weight annotation of the Gateway. The annotation is either a
+// weight for all the Listeners, or comma-separated <listener>=<weight> pairs.
+func gatewayListenerWeight(gw gatewayListeners, lis *v1.Listener) (int64, bool) {
+	value, ok := gw.annotations[gatewayWeightAnnotationKey]
+	if !ok {
+		return 0, false
+	}
+	if !strings.Contains(value, "=") {
+		return parseGatewayWeight(gw.gateway, value)
+	}
+	for _, pair := range strings.Split(value, ",") {
+		name, weight, _ := strings.Cut(strings.TrimSpace(pair), "=")
+		if v1.SectionName(strings.TrimSpace(name)) == lis.Name {
+			return parseGatewayWeight(gw.gateway, weight)
+		}
+	}
+	return 0, false
+}
+
+func parseGatewayWeight(gw *v1beta1.Gateway, value string) (int64, bool) {
+	weight, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+	if err != nil || weight < 0 {
+		log.Warnf("Ignoring invalid weight %q of Gateway %s/%s", value, gw.Namespace, gw.Name)
+		return 0, false
+	}
+	return weight, true
+}
+
 func (c *gatewayRouteResolver) hosts(rt gatewayRoute) ([]string, error) {
 	var hostnames []string
 	for _, name := range rt.Hostnames() {
@@ -407,7 +633,10 @@ func (c *gatewayRouteResolver) hosts(rt gatewayRoute) ([]string, error) {
 	// TODO: The ignore-hostname-annotation flag help says "valid only when using fqdn-template"
 	// but other sources don't check if fqdn-template is set. Which should it be?
 	if !c.src.ignoreHostnameAnnotation {
-		hostnames = append(hostnames, annotations.HostnamesFromAnnotations(rt.Metadata().Annotations)...)
+		meta := rt.Metadata()
+		for _, entry := range annotations.HostnameEntriesFromAnnotations(meta.Annotations, fmt.Sprintf("%s/%s/%s", strings.ToLower(c.src.rtKind), meta.Namespace, meta.Name)) {
+			hostnames = append(hostnames, entry.Hostname)
+		}
 	}
 	// TODO: The combine-fqdn-annotation flag is similarly vague.
 	if c.src.fqdnTemplate != nil && (len(hostnames) == 0 || c.src.combineFQDNAnnotation) {
@@ -1540,6 +1540,435 @@ func TestGatewayHTTPRouteSourceEndpoints(t *testing.T) {
 				"Parent reference gateway-namespace/other-gateway not found in routeParentRefs for HTTPRoute route-namespace/test",
 			},
 		},
+		{
+			title:      "SameHostnameDifferentGatewayUnweighted",
+			config:     Config{},
+			namespaces: namespaces("default"),
+			gateways: []*v1beta1.Gateway{
+				{
+					ObjectMeta: objectMeta("default", "one"),
+					Spec: v1.GatewaySpec{
+						Listeners: []v1.Listener{{Protocol: v1.HTTPProtocolType}},
+					},
+					Status: gatewayStatus("1.2.3.4"),
+				},
+				{
+					ObjectMeta: objectMeta("default", "two"),
+					Spec: v1.GatewaySpec{
+						Listeners: []v1.Listener{{Protocol: v1.HTTPProtocolType}},
+					},
+					Status: gatewayStatus("2.3.4.5"),
+				},
+			},
+			routes: []*v1beta1.HTTPRoute{{
+				ObjectMeta: objectMeta("default", "test"),
+				Spec: v1.HTTPRouteSpec{
+					CommonRouteSpec: v1.CommonRouteSpec{
+						ParentRefs: []v1.ParentReference{
+							gwParentRef("default", "one"),
+							gwParentRef("default", "two"),
+						},
+					},
+					Hostnames: hostnames("test.example.internal"),
+				},
+				Status: httpRouteStatus(
+					gwParentRef("default", "one"),
+					gwParentRef("default", "two"),
+				),
+			}},
+			endpoints: []*endpoint.Endpoint{
+				newTestEndpoint("test.example.internal", "A", "1.2.3.4", "2.3.4.5"),
+			},
+		},
+		{
+			title:      "WeightedGateways",
+			config:     Config{GatewayWeightProperty: "aws/weight"},
+			namespaces: namespaces("default"),
+			gateways: []*v1beta1.Gateway{
+				{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "one", Annotations: map[string]string{gatewayWeightAnnotationKey: "80"}},
+					Spec: v1.GatewaySpec{
+						Listeners: []v1.Listener{{Protocol: v1.HTTPProtocolType}},
+					},
+					Status: gatewayStatus("1.2.3.4"),
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "two", Annotations: map[string]string{gatewayWeightAnnotationKey: "20"}},
+					Spec: v1.GatewaySpec{
+						Listeners: []v1.Listener{{Protocol: v1.HTTPProtocolType}},
+					},
+					Status: gatewayStatus("2.3.4.5"),
+				},
+			},
+			routes: []*v1beta1.HTTPRoute{{
+				ObjectMeta: objectMeta("default", "test"),
+				Spec: v1.HTTPRouteSpec{
+					CommonRouteSpec: v1.CommonRouteSpec{
+						ParentRefs: []v1.ParentReference{
+							gwParentRef("default", "one"),
+							gwParentRef("default", "two"),
+						},
+					},
+					Hostnames: hostnames("test.example.internal"),
+				},
+				Status: httpRouteStatus(
+					gwParentRef("default", "one"),
+					gwParentRef("default", "two"),
+				),
+			}},
+			endpoints: []*endpoint.Endpoint{
+				newTestEndpoint("test.example.internal", "A", "1.2.3.4").
+					WithProviderSpecific("aws/weight", "80").
+					WithSetIdentifier("default/one"),
+				newTestEndpoint("test.example.internal", "A", "2.3.4.5").
+					WithProviderSpecific("aws/weight", "20").
+					WithSetIdentifier("default/two"),
+			},
+		},
+		{
+			title:      "WeightedGatewaysWithoutWeightedRecords",
+			config:     Config{},
+			namespaces: namespaces("default"),
+			gateways: []*v1beta1.Gateway{
+				{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "one", Annotations: map[string]string{gatewayWeightAnnotationKey: "80"}},
+					Spec: v1.GatewaySpec{
+						Listeners: []v1.Listener{{Protocol: v1.HTTPProtocolType}},
+					},
+					Status: gatewayStatus("1.2.3.4"),
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "two", Annotations: map[string]string{gatewayWeightAnnotationKey: "20"}},
+					Spec: v1.GatewaySpec{
+						Listeners: []v1.Listener{{Protocol: v1.HTTPProtocolType}},
+					},
+					Status: gatewayStatus("2.3.4.5"),
+				},
+			},
+			routes: []*v1beta1.HTTPRoute{{
+				ObjectMeta: objectMeta("default", "test"),
+				Spec: v1.HTTPRouteSpec{
+					CommonRouteSpec: v1.CommonRouteSpec{
+						ParentRefs: []v1.ParentReference{
+							gwParentRef("default", "one"),
+							gwParentRef("default", "two"),
+						},
+					},
+					Hostnames: hostnames("test.example.internal"),
+				},
+				Status: httpRouteStatus(
+					gwParentRef("default", "one"),
+					gwParentRef("default", "two"),
+				),
+			}},
+			endpoints: []*endpoint.Endpoint{
+				newTestEndpoint("test.example.internal", "A", "1.2.3.4", "2.3.4.5"),
+			},
+		},
+		{
+			title:      "WeightedAndUnweightedGateways",
+			config:     Config{GatewayWeightProperty: "aws/weight"},
+			namespaces: namespaces("default"),
+			gateways: []*v1beta1.Gateway{
+				{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "one", Annotations: map[string]string{gatewayWeightAnnotationKey: "80"}},
+					Spec: v1.GatewaySpec{
+						Listeners: []v1.Listener{{Protocol: v1.HTTPProtocolType}},
+					},
+					Status: gatewayStatus("1.2.3.4"),
+				},
+				{
+					ObjectMeta: objectMeta("default", "two"),
+					Spec: v1.GatewaySpec{
+						Listeners: []v1.Listener{{Protocol: v1.HTTPProtocolType}},
+					},
+					Status: gatewayStatus("2.3.4.5"),
+				},
+			},
+			routes: []*v1beta1.HTTPRoute{{
+				ObjectMeta: objectMeta("default", "test"),
+				Spec: v1.HTTPRouteSpec{
+					CommonRouteSpec: v1.CommonRouteSpec{
+						ParentRefs: []v1.ParentReference{
+							gwParentRef("default", "one"),
+							gwParentRef("default", "two"),
+						},
+					},
+					Hostnames: hostnames("test.example.internal"),
+				},
+				Status: httpRouteStatus(
+					gwParentRef("default", "one"),
+					gwParentRef("default", "two"),
+				),
+			}},
+			endpoints: []*endpoint.Endpoint{
+				newTestEndpoint("test.example.internal", "A", "1.2.3.4").
+					WithProviderSpecific("aws/weight", "80").
+					WithSetIdentifier("default/one"),
+				newTestEndpoint("test.example.internal", "A", "2.3.4.5").
+					WithProviderSpecific("aws/weight", "1").
+					WithSetIdentifier("default/two"),
+			},
+		},
+		{
+			title:      "WeightedGatewaysWithSetIdentifier",
+			config:     Config{GatewayWeightProperty: "aws/weight"},
+			namespaces: namespaces("default"),
+			gateways: []*v1beta1.Gateway{
+				{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "one", Annotations: map[string]string{gatewayWeightAnnotationKey: "80"}},
+					Spec: v1.GatewaySpec{
+						Listeners: []v1.Listener{{Protocol: v1.HTTPProtocolType}},
+					},
+					Status: gatewayStatus("1.2.3.4"),
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "two", Annotations: map[string]string{gatewayWeightAnnotationKey: "20"}},
+					Spec: v1.GatewaySpec{
+						Listeners: []v1.Listener{{Protocol: v1.HTTPProtocolType}},
+					},
+					Status: gatewayStatus("2.3.4.5"),
+				},
+			},
+			routes: []*v1beta1.HTTPRoute{{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test", Annotations: map[string]string{annotations.SetIdentifierKey: "blue"}},
+				Spec: v1.HTTPRouteSpec{
+					CommonRouteSpec: v1.CommonRouteSpec{
+						ParentRefs: []v1.ParentReference{
+							gwParentRef("default", "one"),
+							gwParentRef("default", "two"),
+						},
+					},
+					Hostnames: hostnames("test.example.internal"),
+				},
+				Status: httpRouteStatus(
+					gwParentRef("default", "one"),
+					gwParentRef("default", "two"),
+				),
+			}},
+			endpoints: []*endpoint.Endpoint{
+				newTestEndpoint("test.example.internal", "A", "1.2.3.4").
+					WithProviderSpecific("aws/weight", "80").
+					WithSetIdentifier("blue/default/one"),
+				newTestEndpoint("test.example.internal", "A", "2.3.4.5").
+					WithProviderSpecific("aws/weight", "20").
+					WithSetIdentifier("blue/default/two"),
+			},
+		},
+		{
+			title:      "ListenerWeights",
+			config:     Config{GatewayWeightProperty: "aws/weight"},
+			namespaces: namespaces("default"),
+			gateways: []*v1beta1.Gateway{
+				{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "one", Annotations: map[string]string{gatewayWeightAnnotationKey: "http=10, https=30"}},
+					Spec: v1.GatewaySpec{
+						Listeners: []v1.Listener{
+							{Name: "http", Protocol: v1.HTTPProtocolType},
+							{Name: "https", Protocol: v1.HTTPSProtocolType},
+						},
+					},
+					Status: gatewayStatus("1.2.3.4"),
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "two", Annotations: map[string]string{gatewayWeightAnnotationKey: "https=20"}},
+					Spec: v1.GatewaySpec{
+						Listeners: []v1.Listener{
+							{Name: "http", Protocol: v1.HTTPProtocolType},
+							{Name: "https", Protocol: v1.HTTPSProtocolType},
+						},
+					},
+					Status: gatewayStatus("2.3.4.5"),
+				},
+			},
+			routes: []*v1beta1.HTTPRoute{{
+				ObjectMeta: objectMeta("default", "test"),
+				Spec: v1.HTTPRouteSpec{
+					CommonRouteSpec: v1.CommonRouteSpec{
+						ParentRefs: []v1.ParentReference{
+							gwParentRef("default", "one"),
+							gwParentRef("default", "two"),
+						},
+					},
+					Hostnames: hostnames("test.example.internal"),
+				},
+				Status: httpRouteStatus(
+					gwParentRef("default", "one"),
+					gwParentRef("default", "two"),
+				),
+			}},
+			endpoints: []*endpoint.Endpoint{
+				newTestEndpoint("test.example.internal", "A", "1.2.3.4").
+					WithProviderSpecific("aws/weight", "30").
+					WithSetIdentifier("default/one"),
+				newTestEndpoint("test.example.internal", "A", "2.3.4.5").
+					WithProviderSpecific("aws/weight", "20").
+					WithSetIdentifier("default/two"),
+			},
+		},
+		{
+			title:      "InvalidGatewayWeight",
+			config:     Config{},
+			namespaces: namespaces("default"),
+			gateways: []*v1beta1.Gateway{
+				{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "one", Annotations: map[string]string{gatewayWeightAnnotationKey: "heavy"}},
+					Spec: v1.GatewaySpec{
+						Listeners: []v1.Listener{{Protocol: v1.HTTPProtocolType}},
+					},
+					Status: gatewayStatus("1.2.3.4"),
+				},
+				{
+					ObjectMeta: objectMeta("default", "two"),
+					Spec: v1.GatewaySpec{
+						Listeners: []v1.Listener{{Protocol: v1.HTTPProtocolType}},
+					},
+					Status: gatewayStatus("2.3.4.5"),
+				},
+			},
+			routes: []*v1beta1.HTTPRoute{{
+				ObjectMeta: objectMeta("default", "test"),
+				Spec: v1.HTTPRouteSpec{
+					CommonRouteSpec: v1.CommonRouteSpec{
+						ParentRefs: []v1.ParentReference{
+							gwParentRef("default", "one"),
+							gwParentRef("default", "two"),
+						},
+					},
+					Hostnames: hostnames("test.example.internal"),
+				},
+				Status: httpRouteStatus(
+					gwParentRef("default", "one"),
+					gwParentRef("default", "two"),
+				),
+			}},
+			endpoints: []*endpoint.Endpoint{
+				newTestEndpoint("test.example.internal", "A", "1.2.3.4", "2.3.4.5"),
+			},
+		},
+		{
+			title:      "GatewayInfrastructureAnnotations",
+			config:     Config{},
+			namespaces: namespaces("default"),
+			gateways: []*v1beta1.Gateway{{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:   "default",
+					Name:        "test",
+					Annotations: map[string]string{ttlAnnotationKey: "60"},
+				},
+				Spec: v1.GatewaySpec{
+					Listeners: []v1.Listener{{Protocol: v1.HTTPProtocolType}},
+					Infrastructure: &v1.GatewayInfrastructure{
+						Annotations: map[v1.AnnotationKey]v1.AnnotationValue{
+							targetAnnotationKey:   "4.3.2.1",
+							ttlAnnotationKey:      "300",
+							aliasAnnotationKey:    "true",
+							hostnameAnnotationKey: "gateway.example.internal",
+						},
+					},
+				},
+				Status: gatewayStatus("1.2.3.4"),
+			}},
+			routes: []*v1beta1.HTTPRoute{
+				{
+					ObjectMeta: objectMeta("default", "with-hostname"),
+					Spec: v1.HTTPRouteSpec{
+						CommonRouteSpec: v1.CommonRouteSpec{
+							ParentRefs: []v1.ParentReference{gwParentRef("default", "test")},
+						},
+						Hostnames: hostnames("route.example.internal"),
+					},
+					Status: httpRouteStatus(gwParentRef("default", "test")),
+				},
+				{
+					ObjectMeta: objectMeta("default", "without-hostname"),
+					Spec: v1.HTTPRouteSpec{
+						CommonRouteSpec: v1.CommonRouteSpec{
+							ParentRefs: []v1.ParentReference{gwParentRef("default", "test")},
+						},
+					},
+					Status: httpRouteStatus(gwParentRef("default", "test")),
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace:   "default",
+						Name:        "with-annotations",
+						Annotations: map[string]string{ttlAnnotationKey: "15"},
+					},
+					Spec: v1.HTTPRouteSpec{
+						CommonRouteSpec: v1.CommonRouteSpec{
+							ParentRefs: []v1.ParentReference{gwParentRef("default", "test")},
+						},
+					},
+					Status: httpRouteStatus(gwParentRef("default", "test")),
+				},
+			},
+			endpoints: []*endpoint.Endpoint{
+				newTestEndpoint("route.example.internal", "A", "4.3.2.1"),
+				newTestEndpointWithTTL("gateway.example.internal", "A", 60, "4.3.2.1").
+					WithProviderSpecific("alias", "true").
+					WithLabel(endpoint.ResourceLabelKey, "gateway/default/test"),
+			},
+		},
+		{
+			title:      "StructuredHostnameAnnotation",
+			config:     Config{},
+			namespaces: namespaces("default"),
+			gateways: []*v1beta1.Gateway{{
+				ObjectMeta: objectMeta("default", "test"),
+				Spec: v1.GatewaySpec{
+					Listeners: []v1.Listener{{Protocol: v1.HTTPProtocolType}},
+				},
+				Status: gatewayStatus("1.2.3.4"),
+			}},
+			routes: []*v1beta1.HTTPRoute{{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "default",
+					Name:      "test",
+					Annotations: map[string]string{
+						hostnameAnnotationKey: `[{"hostname": "ttl.example.internal", "ttl": 60}, {"hostname": "lb.example.internal", "targets": ["lb.example.com"]}]`,
+					},
+				},
+				Spec: v1.HTTPRouteSpec{
+					CommonRouteSpec: v1.CommonRouteSpec{
+						ParentRefs: []v1.ParentReference{gwParentRef("default", "test")},
+					},
+					Hostnames: hostnames("test.example.internal"),
+				},
+				Status: httpRouteStatus(gwParentRef("default", "test")),
+			}},
+			endpoints: []*endpoint.Endpoint{
+				newTestEndpoint("test.example.internal", "A", "1.2.3.4"),
+				newTestEndpointWithTTL("ttl.example.internal", "A", 60, "1.2.3.4"),
+				newTestEndpoint("lb.example.internal", "CNAME", "lb.example.com"),
+			},
+		},
+		{
+			title:      "GatewayHostnameAnnotationIgnored",
+			config:     Config{IgnoreHostnameAnnotation: true},
+			namespaces: namespaces("default"),
+			gateways: []*v1beta1.Gateway{{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:   "default",
+					Name:        "test",
+					Annotations: map[string]string{hostnameAnnotationKey: "gateway.example.internal"},
+				},
+				Spec: v1.GatewaySpec{
+					Listeners: []v1.Listener{{Protocol: v1.HTTPProtocolType}},
+				},
+				Status: gatewayStatus("1.2.3.4"),
+			}},
+			routes: []*v1beta1.HTTPRoute{{
+				ObjectMeta: objectMeta("default", "test"),
+				Spec: v1.HTTPRouteSpec{
+					CommonRouteSpec: v1.CommonRouteSpec{
+						ParentRefs: []v1.ParentReference{gwParentRef("default", "test")},
+					},
+				},
+				Status: httpRouteStatus(gwParentRef("default", "test")),
+			}},
+			endpoints: []*endpoint.Endpoint{},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.title, func(t *testing.T) {
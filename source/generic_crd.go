@@ -0,0 +1,281 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	kubeinformers "k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/util/jsonpath"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/pkg/decisions"
+	"sigs.k8s.io/external-dns/source/annotations"
+	"sigs.k8s.io/external-dns/source/informers"
+)
+
+// genericCRD describes how the hostnames and targets of the objects of a custom resource are extracted.
+type genericCRD struct {
+	gvk          schema.GroupVersionKind
+	hostnamePath *jsonpath.JSONPath
+	targetPath   *jsonpath.JSONPath
+	informer     kubeinformers.GenericInformer
+}
+
+// genericCRDSource is an implementation of Source that extracts the hostnames and targets
+// of arbitrary custom resources with JSONPath expressions.
+type genericCRDSource struct {
+	crds                     []*genericCRD
+	namespace                string
+	annotationFilter         string
+	labelSelector            labels.Selector
+	ignoreHostnameAnnotation bool
+	filterDecisions          *decisions.Recorder
+	propagatedLabels         PropagatedLabels
+}
+
+// NewGenericCRDSource creates a new genericCRDSource for the given specs, each formatted as
+// `<group>/<version>/<kind>,<hostname JSONPath>[,<target JSONPath>]`.
+func NewGenericCRDSource(ctx context.Context, dynamicKubeClient dynamic.Interface, kubeClient kubernetes.Interface, namespace, annotationFilter string, labelSelector labels.Selector, ignoreHostnameAnnotation bool, specs []string, filterDecisions *decisions.Recorder, resyncPeriod time.Duration, prefixAliases annotations.PrefixAliases, propagatedLabels PropagatedLabels) (Source, error) {
+	if len(specs) == 0 {
+		return nil, errors.New("generic-crd source requires at least one --generic-crd-source")
+	}
+
+	informerFactory := dynamicinformer.NewFilteredDynamicSharedInformerFactory(dynamicKubeClient, resyncPeriod, namespace, labelFilterListOptions(labelSelector, filterDecisions))
+
+	crds := make([]*genericCRD, 0, len(specs))
+	for _, spec := range specs {
+		crd, err := parseGenericCRD(spec)
+		if err != nil {
+			return nil, err
+		}
+		gvr, err := genericCRDResource(kubeClient, crd.gvk)
+		if err != nil {
+			return nil, err
+		}
+		crd.informer = informerFactory.ForResource(gvr)
+		aliasAnnotations(prefixAliases, crd.informer.Informer())
+		// Add default resource event handlers to properly initialize informer.
+		_, _ = crd.informer.Informer().AddEventHandler(eventHandlerFunc(func() {}))
+		crds = append(crds, crd)
+	}
+
+	informerFactory.Start(ctx.Done())
+
+	// wait for the local cache to be populated.
+	if err := informers.WaitForDynamicCacheSync(context.Background(), informerFactory); err != nil {
+		return nil, err
+	}
+
+	if labelSelector == nil {
+		labelSelector = labels.Everything()
+	}
+
+	return &genericCRDSource{
+		filterDecisions:          filterDecisions,
+		crds:                     crds,
+		namespace:                namespace,
+		annotationFilter:         annotationFilter,
+		labelSelector:            labelSelector,
+		ignoreHostnameAnnotation: ignoreHostnameAnnotation,
+		propagatedLabels:         propagatedLabels,
+	}, nil
+}
+
+// parseGenericCRD parses a `<group>/<version>/<kind>,<hostname JSONPath>[,<target JSONPath>]` spec.
+// The hostname JSONPath cannot contain commas; the target JSONPath can.
+func parseGenericCRD(spec string) (*genericCRD, error) {
+	parts := strings.SplitN(spec, ",", 3)
+	if len(parts) < 2 {
+		return nil, fmt.Errorf("invalid generic CRD source %q: expected <group>/<version>/<kind>,<hostname JSONPath>[,<target JSONPath>]", spec)
+	}
+
+	typeName := strings.TrimSpace(parts[0])
+	i := strings.LastIndex(typeName, "/")
+	if i <= 0 || i == len(typeName)-1 {
+		return nil, fmt.Errorf("invalid generic CRD source %q: expected <group>/<version>/<kind>", spec)
+	}
+	gv, err := schema.ParseGroupVersion(typeName[:i])
+	if err != nil {
+		return nil, fmt.Errorf("invalid generic CRD source %q: %w", spec, err)
+	}
+
+	crd := &genericCRD{gvk: gv.WithKind(typeName[i+1:])}
+	if crd.hostnamePath, err = parseGenericCRDPath("hostname", parts[1]); err != nil {
+		return nil, fmt.Errorf("invalid generic CRD source %q: %w", spec, err)
+	}
+	if len(parts) == 3 && strings.TrimSpace(parts[2]) != "" {
+		if crd.targetPath, err = parseGenericCRDPath("target", parts[2]); err != nil {
+			return nil, fmt.Errorf("invalid generic CRD source %q: %w", spec, err)
+		}
+	}
+	return crd, nil
+}
+
+// parseGenericCRDPath parses a JSONPath expression, with or without its surrounding braces.
+func parseGenericCRDPath(name, expression string) (*jsonpath.JSONPath, error) {
+	expression = strings.TrimSpace(expression)
+	if expression == "" {
+		return nil, fmt.Errorf("empty %s JSONPath", name)
+	}
+	if !strings.HasPrefix(expression, "{") {
+		expression = "{" + expression + "}"
+	}
+	path := jsonpath.New(name).AllowMissingKeys(true)
+	if err := path.Parse(expression); err != nil {
+		return nil, fmt.Errorf("invalid %s JSONPath %q: %w", name, expression, err)
+	}
+	return path, nil
+}
+
+// genericCRDResource looks up the resource of the given kind with the discovery API.
+func genericCRDResource(client kubernetes.Interface, gvk schema.GroupVersionKind) (schema.GroupVersionResource, error) {
+	gv := gvk.GroupVersion()
+	apiResourceList, err := client.Discovery().ServerResourcesForGroupVersion(gv.String())
+	if err != nil {
+		return schema.GroupVersionResource{}, fmt.Errorf("error listing resources in GroupVersion %q: %w", gv.String(), err)
+	}
+	for _, apiResource := range apiResourceList.APIResources {
+		// subresources, such as status, share the kind of their resource
+		if apiResource.Kind == gvk.Kind && !strings.Contains(apiResource.Name, "/") {
+			return gv.WithResource(apiResource.Name), nil
+		}
+	}
+	return schema.GroupVersionResource{}, fmt.Errorf("unable to find Resource Kind %q in GroupVersion %q", gvk.Kind, gv.String())
+}
+
+// Endpoints returns endpoint objects for each host-target combination that should be processed.
+// Retrieves the objects of all the configured custom resources in the source's namespace(s).
+func (gs *genericCRDSource) Endpoints(ctx context.Context) ([]*endpoint.Endpoint, error) {
+	selector, err := annotations.ParseFilter(gs.annotationFilter)
+	if err != nil {
+		return nil, err
+	}
+
+	var endpoints []*endpoint.Endpoint
+	for _, crd := range gs.crds {
+		objs, err := crd.informer.Lister().ByNamespace(gs.namespace).List(gs.labelSelector)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, obj := range objs {
+			u, ok := obj.(*unstructured.Unstructured)
+			if !ok {
+				return nil, errors.New("could not convert")
+			}
+			if !selector.Matches(labels.Set(u.GetAnnotations())) {
+				continue
+			}
+			endpoints = append(endpoints, gs.endpointsFromObject(crd, u)...)
+		}
+	}
+
+	for _, ep := range endpoints {
+		sort.Sort(ep.Targets)
+	}
+
+	return endpoints, nil
+}
+
+func (gs *genericCRDSource) AddEventHandler(_ context.Context, handler func()) {
+	log.Debug("Adding event handler for generic CRDs")
+
+	for _, crd := range gs.crds {
+		_, _ = crd.informer.Informer().AddEventHandler(eventHandlerFunc(handler))
+	}
+}
+
+// endpointsFromObject extracts the endpoints of an object of a generic custom resource.
+func (gs *genericCRDSource) endpointsFromObject(crd *genericCRD, u *unstructured.Unstructured) []*endpoint.Endpoint {
+	resource := fmt.Sprintf("%s/%s/%s", strings.ToLower(crd.gvk.Kind), u.GetNamespace(), u.GetName())
+	objAnnotations := u.GetAnnotations()
+
+	ttl := annotations.TTLFromAnnotations(objAnnotations, resource)
+	providerSpecific, setIdentifier := annotations.ProviderSpecificAnnotations(objAnnotations)
+
+	targets := annotations.TargetsFromTargetAnnotation(objAnnotations)
+	if len(targets) == 0 && crd.targetPath != nil {
+		targets = genericCRDValues(crd.targetPath, u, resource)
+	}
+
+	var endpoints []*endpoint.Endpoint
+	if len(targets) > 0 {
+		for _, hostname := range genericCRDValues(crd.hostnamePath, u, resource) {
+			endpoints = append(endpoints, endpointsForHostname(hostname, targets, ttl, providerSpecific, setIdentifier, resource)...)
+		}
+	}
+	if !gs.ignoreHostnameAnnotation {
+		for _, entry := range annotations.HostnameEntriesFromAnnotations(objAnnotations, resource) {
+			endpoints = append(endpoints, endpointsForHostnameEntry(entry, targets, ttl, providerSpecific, setIdentifier, resource)...)
+		}
+	}
+	if len(endpoints) == 0 {
+		log.Debugf("No endpoints could be generated from %s", resource)
+	}
+	gs.propagatedLabels.propagate(endpoints, u.GetLabels())
+	return endpoints
+}
+
+// genericCRDValues returns the non-empty strings found by a JSONPath expression in an object,
+// flattening the lists of strings it finds.
+func genericCRDValues(path *jsonpath.JSONPath, u *unstructured.Unstructured, resource string) []string {
+	results, err := path.FindResults(u.UnstructuredContent())
+	if err != nil {
+		log.Debugf("Failed to evaluate JSONPath on %s: %v", resource, err)
+		return nil
+	}
+
+	var values []string
+	add := func(value interface{}) {
+		s, ok := value.(string)
+		if !ok {
+			log.Debugf("Ignoring non-string value %v of %s", value, resource)
+			return
+		}
+		if s = strings.TrimSpace(s); s != "" {
+			values = append(values, s)
+		}
+	}
+	for _, result := range results {
+		for _, value := range result {
+			if !value.IsValid() || !value.CanInterface() {
+				continue
+			}
+			if list, ok := value.Interface().([]interface{}); ok {
+				for _, item := range list {
+					add(item)
+				}
+				continue
+			}
+			add(value.Interface())
+		}
+	}
+	return values
+}
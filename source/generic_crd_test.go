@@ -0,0 +1,238 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	fakeDiscovery "k8s.io/client-go/discovery/fake"
+	fakeDynamic "k8s.io/client-go/dynamic/fake"
+	fakeKube "k8s.io/client-go/kubernetes/fake"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+var genericCRDTestGVR = schema.GroupVersionResource{
+	Group:    "example.com",
+	Version:  "v1",
+	Resource: "proxies",
+}
+
+func TestParseGenericCRD(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		spec    string
+		gvk     schema.GroupVersionKind
+		target  bool
+		wantErr bool
+	}{
+		{
+			spec:   "example.com/v1/Proxy,{.spec.host},{.status.addresses[*].ip}",
+			gvk:    schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "Proxy"},
+			target: true,
+		},
+		{
+			spec: "v1/Service,.metadata.name",
+			gvk:  schema.GroupVersionKind{Version: "v1", Kind: "Service"},
+		},
+		{
+			spec:   "example.com/v1/Proxy,.spec.host,.status.addresses[0,1]",
+			gvk:    schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "Proxy"},
+			target: true,
+		},
+		{spec: "example.com/v1/Proxy", wantErr: true},
+		{spec: "Proxy,.spec.host", wantErr: true},
+		{spec: "example.com/v1/,.spec.host", wantErr: true},
+		{spec: "example.com/v1/Proxy, ", wantErr: true},
+		{spec: "example.com/v1/Proxy,{.spec.host", wantErr: true},
+	} {
+		t.Run(tc.spec, func(t *testing.T) {
+			crd, err := parseGenericCRD(tc.spec)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.gvk, crd.gvk)
+			assert.NotNil(t, crd.hostnamePath)
+			assert.Equal(t, tc.target, crd.targetPath != nil)
+		})
+	}
+}
+
+func TestGenericCRDSourceEndpoints(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		title                    string
+		specs                    []string
+		objects                  []*unstructured.Unstructured
+		annotationFilter         string
+		labelSelector            labels.Selector
+		ignoreHostnameAnnotation bool
+		expected                 []*endpoint.Endpoint
+		wantErr                  bool
+	}{
+		{
+			title: "hostnames and targets from JSONPath",
+			specs: []string{"example.com/v1/Proxy,{.spec.hosts[*]},{.status.addresses[*].ip}"},
+			objects: []*unstructured.Unstructured{
+				newGenericCRDTestObject("default", "proxy", nil, nil,
+					map[string]interface{}{"hosts": []interface{}{"a.example.com", "b.example.com"}},
+					map[string]interface{}{"addresses": []interface{}{
+						map[string]interface{}{"ip": "1.2.3.4"},
+						map[string]interface{}{"ip": "2001:db8::1"},
+					}}),
+			},
+			expected: []*endpoint.Endpoint{
+				{DNSName: "a.example.com", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"1.2.3.4"}, Labels: endpoint.Labels{endpoint.ResourceLabelKey: "proxy/default/proxy"}},
+				{DNSName: "a.example.com", RecordType: endpoint.RecordTypeAAAA, Targets: endpoint.Targets{"2001:db8::1"}, Labels: endpoint.Labels{endpoint.ResourceLabelKey: "proxy/default/proxy"}},
+				{DNSName: "b.example.com", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"1.2.3.4"}, Labels: endpoint.Labels{endpoint.ResourceLabelKey: "proxy/default/proxy"}},
+				{DNSName: "b.example.com", RecordType: endpoint.RecordTypeAAAA, Targets: endpoint.Targets{"2001:db8::1"}, Labels: endpoint.Labels{endpoint.ResourceLabelKey: "proxy/default/proxy"}},
+			},
+		},
+		{
+			title: "list of strings and annotations",
+			specs: []string{"example.com/v1/Proxy,.spec.hosts,.status.hostname"},
+			objects: []*unstructured.Unstructured{
+				newGenericCRDTestObject("default", "proxy",
+					map[string]string{
+						hostnameAnnotationKey: "c.example.com",
+						ttlAnnotationKey:      "60",
+					}, nil,
+					map[string]interface{}{"hosts": []interface{}{"a.example.com"}},
+					map[string]interface{}{"hostname": "lb.example.com"}),
+			},
+			expected: []*endpoint.Endpoint{
+				{DNSName: "a.example.com", RecordType: endpoint.RecordTypeCNAME, Targets: endpoint.Targets{"lb.example.com"}, RecordTTL: 60},
+				{DNSName: "c.example.com", RecordType: endpoint.RecordTypeCNAME, Targets: endpoint.Targets{"lb.example.com"}, RecordTTL: 60},
+			},
+		},
+		{
+			title: "target annotation takes precedence and hostname annotation is ignored",
+			specs: []string{"example.com/v1/Proxy,.spec.host,.status.hostname"},
+			objects: []*unstructured.Unstructured{
+				newGenericCRDTestObject("default", "proxy",
+					map[string]string{
+						hostnameAnnotationKey: "c.example.com",
+						targetAnnotationKey:   "10.0.0.1",
+					}, nil,
+					map[string]interface{}{"host": "a.example.com"},
+					map[string]interface{}{"hostname": "lb.example.com"}),
+			},
+			ignoreHostnameAnnotation: true,
+			expected: []*endpoint.Endpoint{
+				{DNSName: "a.example.com", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"10.0.0.1"}},
+			},
+		},
+		{
+			title: "objects without targets are skipped",
+			specs: []string{"example.com/v1/Proxy,.spec.host,.status.hostname"},
+			objects: []*unstructured.Unstructured{
+				newGenericCRDTestObject("default", "proxy", nil, nil, map[string]interface{}{"host": "a.example.com"}, nil),
+			},
+			expected: []*endpoint.Endpoint{},
+		},
+		{
+			title: "annotation and label filters",
+			specs: []string{"example.com/v1/Proxy,.spec.host,.status.hostname"},
+			objects: []*unstructured.Unstructured{
+				newGenericCRDTestObject("default", "matching", map[string]string{"team": "a"}, map[string]string{"env": "prod"},
+					map[string]interface{}{"host": "a.example.com"},
+					map[string]interface{}{"hostname": "lb.example.com"}),
+				newGenericCRDTestObject("default", "other-team", map[string]string{"team": "b"}, map[string]string{"env": "prod"},
+					map[string]interface{}{"host": "b.example.com"},
+					map[string]interface{}{"hostname": "lb.example.com"}),
+				newGenericCRDTestObject("default", "other-env", map[string]string{"team": "a"}, map[string]string{"env": "dev"},
+					map[string]interface{}{"host": "c.example.com"},
+					map[string]interface{}{"hostname": "lb.example.com"}),
+			},
+			annotationFilter: "team=a",
+			labelSelector:    labels.SelectorFromSet(labels.Set{"env": "prod"}),
+			expected: []*endpoint.Endpoint{
+				{DNSName: "a.example.com", RecordType: endpoint.RecordTypeCNAME, Targets: endpoint.Targets{"lb.example.com"}},
+			},
+		},
+		{
+			title:   "unknown kind",
+			specs:   []string{"example.com/v1/Unknown,.spec.host"},
+			wantErr: true,
+		},
+		{
+			title:   "no custom resources",
+			wantErr: true,
+		},
+	} {
+		t.Run(tc.title, func(t *testing.T) {
+			t.Parallel()
+
+			kubeClient := fakeKube.NewClientset()
+			kubeClient.Discovery().(*fakeDiscovery.FakeDiscovery).Resources = []*metav1.APIResourceList{
+				{
+					GroupVersion: genericCRDTestGVR.GroupVersion().String(),
+					APIResources: []metav1.APIResource{
+						{Name: "proxies/status", Kind: "Proxy"},
+						{Name: "proxies", Kind: "Proxy", Namespaced: true},
+					},
+				},
+			}
+			dynamicClient := fakeDynamic.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+				map[schema.GroupVersionResource]string{genericCRDTestGVR: "ProxyList"})
+			for _, obj := range tc.objects {
+				_, err := dynamicClient.Resource(genericCRDTestGVR).Namespace(obj.GetNamespace()).Create(context.Background(), obj, metav1.CreateOptions{})
+				require.NoError(t, err)
+			}
+
+			src, err := NewGenericCRDSource(context.TODO(), dynamicClient, kubeClient, "", tc.annotationFilter, tc.labelSelector, tc.ignoreHostnameAnnotation, tc.specs, nil, 0, nil, nil)
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			endpoints, err := src.Endpoints(context.Background())
+			require.NoError(t, err)
+			validateEndpoints(t, endpoints, tc.expected)
+		})
+	}
+}
+
+func newGenericCRDTestObject(namespace, name string, annotations, labels map[string]string, spec, status map[string]interface{}) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{}}
+	obj.SetAPIVersion(genericCRDTestGVR.GroupVersion().String())
+	obj.SetKind("Proxy")
+	obj.SetNamespace(namespace)
+	obj.SetName(name)
+	obj.SetAnnotations(annotations)
+	obj.SetLabels(labels)
+	if spec != nil {
+		obj.Object["spec"] = spec
+	}
+	if status != nil {
+		obj.Object["status"] = status
+	}
+	return obj
+}
@@ -20,6 +20,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"maps"
 	"strings"
 
 	log "github.com/sirupsen/logrus"
@@ -99,15 +100,19 @@ type glooSource struct {
 	dynamicKubeClient dynamic.Interface
 	kubeClient        kubernetes.Interface
 	glooNamespaces    []string
+	prefixAliases     annotations.PrefixAliases
+	propagatedLabels  PropagatedLabels
 }
 
 // NewGlooSource creates a new glooSource with the given config
 func NewGlooSource(dynamicKubeClient dynamic.Interface, kubeClient kubernetes.Interface,
-	glooNamespaces []string) (Source, error) {
+	glooNamespaces []string, prefixAliases annotations.PrefixAliases, propagatedLabels PropagatedLabels) (Source, error) {
 	return &glooSource{
 		dynamicKubeClient,
 		kubeClient,
 		glooNamespaces,
+		prefixAliases,
+		propagatedLabels,
 	}, nil
 }
 
@@ -134,6 +139,7 @@ func (gs *glooSource) Endpoints(ctx context.Context) ([]*endpoint.Endpoint, erro
 				return nil, err
 			}
 			log.Debugf("Gloo: Find %s proxy", proxy.Metadata.Name)
+			proxy.Metadata.Annotations = gs.prefixAliases.Alias(proxy.Metadata.Annotations)
 
 			proxyTargets := annotations.TargetsFromTargetAnnotation(proxy.Metadata.Annotations)
 			if len(proxyTargets) == 0 {
@@ -162,32 +168,37 @@ func (gs *glooSource) generateEndpointsFromProxy(ctx context.Context, proxy *pro
 
 	for _, listener := range proxy.Spec.Listeners {
 		for _, virtualHost := range listener.HTTPListener.VirtualHosts {
-			ants, err := gs.annotationsFromProxySource(ctx, virtualHost)
+			ants, labels, err := gs.metadataFromProxySource(ctx, virtualHost)
 			if err != nil {
 				return nil, err
 			}
 			ttl := annotations.TTLFromAnnotations(ants, resource)
 			providerSpecific, setIdentifier := annotations.ProviderSpecificAnnotations(ants)
+			var hostEndpoints []*endpoint.Endpoint
 			for _, domain := range virtualHost.Domains {
-				endpoints = append(endpoints, endpointsForHostname(strings.TrimSuffix(domain, "."), targets, ttl, providerSpecific, setIdentifier, "")...)
+				hostEndpoints = append(hostEndpoints, endpointsForHostname(strings.TrimSuffix(domain, "."), targets, ttl, providerSpecific, setIdentifier, "")...)
 			}
+			gs.propagatedLabels.propagate(hostEndpoints, labels)
+			endpoints = append(endpoints, hostEndpoints...)
 		}
 	}
 	return endpoints, nil
 }
 
-func (gs *glooSource) annotationsFromProxySource(ctx context.Context, virtualHost proxyVirtualHost) (map[string]string, error) {
+// metadataFromProxySource returns the annotations and the labels of the resources a virtual host
+// of a proxy is generated from, e.g. its VirtualService.
+func (gs *glooSource) metadataFromProxySource(ctx context.Context, virtualHost proxyVirtualHost) (map[string]string, map[string]string, error) {
 	ants := map[string]string{}
+	labels := map[string]string{}
 	for _, src := range virtualHost.Metadata.Source {
 		kind := sourceKind(src.Kind)
 		if kind != nil {
 			source, err := gs.dynamicKubeClient.Resource(*kind).Namespace(src.Namespace).Get(ctx, src.Name, metav1.GetOptions{})
 			if err != nil {
-				return nil, err
-			}
-			for key, value := range source.GetAnnotations() {
-				ants[key] = value
+				return nil, nil, err
 			}
+			maps.Copy(ants, source.GetAnnotations())
+			maps.Copy(labels, source.GetLabels())
 		}
 	}
 	for _, src := range virtualHost.MetadataStatic.Source {
@@ -195,14 +206,13 @@ func (gs *glooSource) annotationsFromProxySource(ctx context.Context, virtualHos
 		if kind != nil {
 			source, err := gs.dynamicKubeClient.Resource(*kind).Namespace(src.ResourceRef.Namespace).Get(ctx, src.ResourceRef.Name, metav1.GetOptions{})
 			if err != nil {
-				return nil, err
-			}
-			for key, value := range source.GetAnnotations() {
-				ants[key] = value
+				return nil, nil, err
 			}
+			maps.Copy(ants, source.GetAnnotations())
+			maps.Copy(labels, source.GetLabels())
 		}
 	}
-	return ants, nil
+	return gs.prefixAliases.Alias(ants), labels, nil
 }
 
 func (gs *glooSource) proxyTargets(ctx context.Context, name string, namespace string) (endpoint.Targets, error) {
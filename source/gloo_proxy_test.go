@@ -401,7 +401,7 @@ func TestGlooSource(t *testing.T) {
 			proxyGVR: "ProxyList",
 		})
 
-	source, err := NewGlooSource(fakeDynamicClient, fakeKubernetesClient, []string{defaultGlooNamespace})
+	source, err := NewGlooSource(fakeDynamicClient, fakeKubernetesClient, []string{defaultGlooNamespace}, nil, nil)
 	assert.NoError(t, err)
 	assert.NotNil(t, source)
 
@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"strings"
 	"time"
 
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -37,7 +38,9 @@ func WaitForCacheSync(ctx context.Context, factory informerFactory) error {
 	timeout := defaultRequestTimeout * time.Second
 	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
+	start := time.Now()
 	for typ, done := range factory.WaitForCacheSync(ctx.Done()) {
+		cacheSyncDuration.SetWithLabels(time.Since(start).Seconds(), strings.TrimPrefix(typ.String(), "*"))
 		if !done {
 			select {
 			case <-ctx.Done():
@@ -58,7 +61,9 @@ func WaitForDynamicCacheSync(ctx context.Context, factory dynamicInformerFactory
 	timeout := defaultRequestTimeout * time.Second
 	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
+	start := time.Now()
 	for typ, done := range factory.WaitForCacheSync(ctx.Done()) {
+		cacheSyncDuration.SetWithLabels(time.Since(start).Seconds(), gvrResource(typ))
 		if !done {
 			select {
 			case <-ctx.Done():
@@ -70,3 +75,11 @@ func WaitForDynamicCacheSync(ctx context.Context, factory dynamicInformerFactory
 	}
 	return nil
 }
+
+// gvrResource returns the resource of a group version resource, e.g. ingresses.networking.k8s.io.
+func gvrResource(gvr schema.GroupVersionResource) string {
+	if gvr.Group == "" {
+		return gvr.Resource
+	}
+	return gvr.Resource + "." + gvr.Group
+}
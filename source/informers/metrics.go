@@ -0,0 +1,124 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package informers
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"sigs.k8s.io/external-dns/pkg/metrics"
+)
+
+var (
+	cacheSyncDuration = metrics.NewGaugedVectorOpts(
+		prometheus.GaugeOpts{
+			Namespace: "external_dns",
+			Subsystem: "source",
+			Name:      "informer_cache_sync_duration_seconds",
+			Help:      "Duration of the last synchronization of the informer caches of a resource when its source was built (vector).",
+		},
+		[]string{"resource"},
+	)
+	listRequestsTotal = metrics.NewCounterVecWithOpts(
+		prometheus.CounterOpts{
+			Namespace: "external_dns",
+			Subsystem: "source",
+			Name:      "informer_list_requests_total",
+			Help:      "Number of full lists of a resource from the Kubernetes API, on start and on each relist of its informers (vector).",
+		},
+		[]string{"resource"},
+	)
+	watchErrorsTotal = metrics.NewCounterVecWithOpts(
+		prometheus.CounterOpts{
+			Namespace: "external_dns",
+			Subsystem: "source",
+			Name:      "informer_watch_errors_total",
+			Help:      "Number of watches of a resource rejected by the Kubernetes API or failing to connect (vector).",
+		},
+		[]string{"resource"},
+	)
+	resyncPeriod = metrics.NewGaugedVectorOpts(
+		prometheus.GaugeOpts{
+			Namespace: "external_dns",
+			Subsystem: "source",
+			Name:      "informer_resync_period_seconds",
+			Help:      "Resync period of the informers of a source, 0 if disabled (vector).",
+		},
+		[]string{"source"},
+	)
+)
+
+func init() {
+	metrics.RegisterMetric.MustRegister(cacheSyncDuration)
+	metrics.RegisterMetric.MustRegister(listRequestsTotal)
+	metrics.RegisterMetric.MustRegister(watchErrorsTotal)
+	metrics.RegisterMetric.MustRegister(resyncPeriod)
+}
+
+// Transport returns a round tripper counting the lists and the failed watches of the requests
+// of the informers to the Kubernetes API, by resource.
+func Transport(rt http.RoundTripper) http.RoundTripper {
+	return &transport{rt: rt}
+}
+
+type transport struct {
+	rt http.RoundTripper
+}
+
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.rt.RoundTrip(req)
+	if req.Method != http.MethodGet {
+		return resp, err
+	}
+	resource, collection := resourceOf(req.URL.Path)
+	if resource == "" || !collection {
+		return resp, err
+	}
+	if req.URL.Query().Get("watch") == "true" || req.URL.Query().Get("watch") == "1" {
+		if err != nil || resp.StatusCode != http.StatusOK {
+			watchErrorsTotal.CounterVec.WithLabelValues(resource).Inc()
+		}
+		return resp, err
+	}
+	listRequestsTotal.CounterVec.WithLabelValues(resource).Inc()
+	return resp, err
+}
+
+// resourceOf returns the resource of a path of the Kubernetes API, e.g. ingresses.networking.k8s.io,
+// and whether the path is the one of a collection rather than of an object.
+func resourceOf(path string) (string, bool) {
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	var group string
+	switch {
+	case len(parts) >= 3 && parts[0] == "api":
+		parts = parts[2:]
+	case len(parts) >= 4 && parts[0] == "apis":
+		group, parts = parts[1], parts[3:]
+	default:
+		return "", false
+	}
+	if len(parts) >= 3 && parts[0] == "namespaces" {
+		parts = parts[2:]
+	}
+	resource := parts[0]
+	if group != "" {
+		resource += "." + group
+	}
+	return resource, len(parts) == 1
+}
@@ -0,0 +1,107 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package informers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	promtestutil "github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestResourceOf(t *testing.T) {
+	for _, tc := range []struct {
+		path       string
+		resource   string
+		collection bool
+	}{
+		{path: "/api/v1/services", resource: "services", collection: true},
+		{path: "/api/v1/namespaces/default/pods", resource: "pods", collection: true},
+		{path: "/api/v1/namespaces/default/pods/web", resource: "pods"},
+		{path: "/api/v1/namespaces", resource: "namespaces", collection: true},
+		{path: "/api/v1/namespaces/default", resource: "namespaces"},
+		{path: "/apis/networking.k8s.io/v1/ingresses", resource: "ingresses.networking.k8s.io", collection: true},
+		{path: "/apis/networking.k8s.io/v1/namespaces/default/ingresses", resource: "ingresses.networking.k8s.io", collection: true},
+		{path: "/apis/networking.k8s.io/v1/namespaces/default/ingresses/web/status", resource: "ingresses.networking.k8s.io"},
+		{path: "/apis/networking.k8s.io"},
+		{path: "/version"},
+	} {
+		t.Run(tc.path, func(t *testing.T) {
+			resource, collection := resourceOf(tc.path)
+			assert.Equal(t, tc.resource, resource)
+			assert.Equal(t, tc.collection, collection)
+		})
+	}
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestTransport(t *testing.T) {
+	const resource = "transporttests.example.org"
+	status := http.StatusOK
+	var err error
+	rt := Transport(roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		if err != nil {
+			return nil, err
+		}
+		return &http.Response{StatusCode: status}, nil
+	}))
+	do := func(method, url string) {
+		_, _ = rt.RoundTrip(httptest.NewRequest(method, url, nil))
+	}
+
+	do(http.MethodGet, "https://k8s/apis/example.org/v1/transporttests?limit=500")
+	do(http.MethodGet, "https://k8s/apis/example.org/v1/transporttests?watch=true")
+	do(http.MethodGet, "https://k8s/apis/example.org/v1/namespaces/default/transporttests/web")
+	do(http.MethodPut, "https://k8s/apis/example.org/v1/namespaces/default/transporttests")
+	assert.InDelta(t, 1, promtestutil.ToFloat64(listRequestsTotal.CounterVec.WithLabelValues(resource)), 0)
+	assert.InDelta(t, 0, promtestutil.ToFloat64(watchErrorsTotal.CounterVec.WithLabelValues(resource)), 0)
+
+	status = http.StatusGone
+	do(http.MethodGet, "https://k8s/apis/example.org/v1/transporttests?watch=1")
+	err = errors.New("connection refused")
+	do(http.MethodGet, "https://k8s/apis/example.org/v1/transporttests?watch=true")
+	do(http.MethodGet, "https://k8s/apis/example.org/v1/transporttests")
+	assert.InDelta(t, 2, promtestutil.ToFloat64(listRequestsTotal.CounterVec.WithLabelValues(resource)), 0)
+	assert.InDelta(t, 2, promtestutil.ToFloat64(watchErrorsTotal.CounterVec.WithLabelValues(resource)), 0)
+}
+
+func TestWaitForCacheSyncDuration(t *testing.T) {
+	cacheSyncDuration.Gauge.Reset()
+	require.NoError(t, WaitForCacheSync(context.Background(), &mockInformerFactory{
+		syncResults: map[reflect.Type]bool{reflect.TypeOf(&http.Request{}): true},
+	}))
+	assert.Equal(t, 1, promtestutil.CollectAndCount(&cacheSyncDuration.Gauge, "external_dns_source_informer_cache_sync_duration_seconds"))
+	assert.GreaterOrEqual(t, promtestutil.ToFloat64(cacheSyncDuration.Gauge.WithLabelValues("http.request")), 0.0)
+
+	require.NoError(t, WaitForDynamicCacheSync(context.Background(), &mockDynamicInformerFactory{
+		syncResults: map[schema.GroupVersionResource]bool{{Group: "example.org", Version: "v1", Resource: "tests"}: true},
+	}))
+	assert.Equal(t, 2, promtestutil.CollectAndCount(&cacheSyncDuration.Gauge, "external_dns_source_informer_cache_sync_duration_seconds"))
+	assert.GreaterOrEqual(t, promtestutil.ToFloat64(cacheSyncDuration.Gauge.WithLabelValues("tests.example.org")), 0.0)
+}
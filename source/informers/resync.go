@@ -0,0 +1,44 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package informers
+
+import (
+	"sync"
+	"time"
+)
+
+var (
+	resyncMu      sync.Mutex
+	resyncSources []string
+)
+
+// ReportResyncPeriods reports the resync periods of the informers of the sources, by source name,
+// replacing the ones reported before. The informers of the other sources are never resynced, which
+// is the default: a resync delivers all the objects of the cache to the event handlers again,
+// without calling the Kubernetes API, so it only helps sources missing events.
+func ReportResyncPeriods(periods map[string]time.Duration) {
+	resyncMu.Lock()
+	defer resyncMu.Unlock()
+	for _, source := range resyncSources {
+		resyncPeriod.Gauge.DeleteLabelValues(source)
+	}
+	resyncSources = make([]string, 0, len(periods))
+	for source, period := range periods {
+		resyncSources = append(resyncSources, source)
+		resyncPeriod.SetWithLabels(period.Seconds(), source)
+	}
+}
@@ -0,0 +1,39 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package informers
+
+import (
+	"testing"
+	"time"
+
+	promtestutil "github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReportResyncPeriods(t *testing.T) {
+	defer ReportResyncPeriods(nil)
+
+	ReportResyncPeriods(map[string]time.Duration{"ingress": 30 * time.Minute, "service": time.Hour})
+	assert.InDelta(t, 1800, promtestutil.ToFloat64(resyncPeriod.Gauge.WithLabelValues("ingress")), 0)
+	assert.InDelta(t, 3600, promtestutil.ToFloat64(resyncPeriod.Gauge.WithLabelValues("service")), 0)
+	assert.Equal(t, 2, promtestutil.CollectAndCount(&resyncPeriod.Gauge))
+
+	// the periods are replaced, not merged
+	ReportResyncPeriods(map[string]time.Duration{"service": time.Minute})
+	assert.InDelta(t, 60, promtestutil.ToFloat64(resyncPeriod.Gauge.WithLabelValues("service")), 0)
+	assert.Equal(t, 1, promtestutil.CollectAndCount(&resyncPeriod.Gauge))
+}
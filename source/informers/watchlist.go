@@ -0,0 +1,50 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package informers
+
+import (
+	clientfeatures "k8s.io/client-go/features"
+)
+
+// watchListGates enables the WatchListClient feature of client-go on top of its other feature gates.
+type watchListGates struct {
+	clientfeatures.Gates
+}
+
+// Enabled returns true for WatchListClient, and the state of the other features in the wrapped gates.
+func (g watchListGates) Enabled(key clientfeatures.Feature) bool {
+	if key == clientfeatures.WatchListClient {
+		return true
+	}
+	return g.Gates.Enabled(key)
+}
+
+// SetWatchList makes the informers created afterwards stream the objects with a watch instead of
+// listing them when enabled, which client-go falls back from when the Kubernetes API server doesn't
+// support it, and restores the feature gates of client-go when disabled. client-go reads it from its feature gates when it
+// creates the reflectors of the informers, so it is process-wide: the shared informers don't expose
+// the option of their reflector.
+func SetWatchList(enabled bool) {
+	gates := clientfeatures.FeatureGates()
+	wrapped, ok := gates.(watchListGates)
+	switch {
+	case enabled && !ok && !gates.Enabled(clientfeatures.WatchListClient):
+		clientfeatures.ReplaceFeatureGates(watchListGates{Gates: gates})
+	case !enabled && ok:
+		clientfeatures.ReplaceFeatureGates(wrapped.Gates)
+	}
+}
@@ -0,0 +1,42 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package informers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	clientfeatures "k8s.io/client-go/features"
+)
+
+func TestSetWatchList(t *testing.T) {
+	gates := clientfeatures.FeatureGates()
+	t.Cleanup(func() { clientfeatures.ReplaceFeatureGates(gates) })
+
+	SetWatchList(true)
+	assert.True(t, clientfeatures.FeatureGates().Enabled(clientfeatures.WatchListClient))
+	assert.Equal(t, gates.Enabled(clientfeatures.InformerResourceVersion), clientfeatures.FeatureGates().Enabled(clientfeatures.InformerResourceVersion))
+
+	// enabling it again doesn't wrap the gates twice
+	enabled := clientfeatures.FeatureGates()
+	SetWatchList(true)
+	assert.Equal(t, enabled, clientfeatures.FeatureGates())
+
+	// disabling it restores the gates of client-go
+	SetWatchList(false)
+	assert.Equal(t, gates, clientfeatures.FeatureGates())
+}
@@ -21,8 +21,10 @@ import (
 	"errors"
 	"fmt"
 	"sort"
+	"strconv"
 	"strings"
 	"text/template"
+	"time"
 
 	log "github.com/sirupsen/logrus"
 	networkv1 "k8s.io/api/networking/v1"
@@ -36,6 +38,7 @@ import (
 	"sigs.k8s.io/external-dns/source/informers"
 
 	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/pkg/decisions"
 	"sigs.k8s.io/external-dns/source/annotations"
 	"sigs.k8s.io/external-dns/source/fqdn"
 )
@@ -64,6 +67,8 @@ type ingressSource struct {
 	ignoreIngressTLSSpec     bool
 	ignoreIngressRulesSpec   bool
 	labelSelector            labels.Selector
+	filterDecisions          *decisions.Recorder
+	propagatedLabels         PropagatedLabels
 }
 
 // NewIngressSource creates a new ingressSource with the given config.
@@ -73,7 +78,7 @@ func NewIngressSource(
 	namespace, annotationFilter, fqdnTemplate string,
 	combineFqdnAnnotation, ignoreHostnameAnnotation, ignoreIngressTLSSpec, ignoreIngressRulesSpec bool,
 	labelSelector labels.Selector,
-	ingressClassNames []string) (Source, error) {
+	ingressClassNames []string, filterDecisions *decisions.Recorder, resyncPeriod time.Duration, prefixAliases annotations.PrefixAliases, propagatedLabels PropagatedLabels) (Source, error) {
 	tmpl, err := fqdn.ParseTemplate(fqdnTemplate)
 	if err != nil {
 		return nil, err
@@ -96,7 +101,7 @@ func NewIngressSource(
 	}
 	// Use shared informer to listen for add/update/delete of ingresses in the specified namespace.
 	// Set resync period to 0, to prevent processing when nothing has changed.
-	informerFactory := kubeinformers.NewSharedInformerFactoryWithOptions(kubeClient, 0, kubeinformers.WithNamespace(namespace))
+	informerFactory := kubeinformers.NewSharedInformerFactoryWithOptions(kubeClient, resyncPeriod, kubeinformers.WithNamespace(namespace), kubeinformers.WithTweakListOptions(labelFilterListOptions(labelSelector, filterDecisions)))
 	ingressInformer := informerFactory.Networking().V1().Ingresses()
 
 	// Add default resource event handlers to properly initialize informer.
@@ -107,6 +112,8 @@ func NewIngressSource(
 		},
 	)
 
+	aliasAnnotations(prefixAliases, ingressInformer.Informer())
+
 	informerFactory.Start(ctx.Done())
 
 	// wait for the local cache to be populated.
@@ -115,6 +122,7 @@ func NewIngressSource(
 	}
 
 	sc := &ingressSource{
+		filterDecisions:          filterDecisions,
 		client:                   kubeClient,
 		namespace:                namespace,
 		annotationFilter:         annotationFilter,
@@ -126,6 +134,7 @@ func NewIngressSource(
 		ignoreIngressTLSSpec:     ignoreIngressTLSSpec,
 		ignoreIngressRulesSpec:   ignoreIngressRulesSpec,
 		labelSelector:            labelSelector,
+		propagatedLabels:         propagatedLabels,
 	}
 	return sc, nil
 }
@@ -137,6 +146,11 @@ func (sc *ingressSource) Endpoints(_ context.Context) ([]*endpoint.Endpoint, err
 	if err != nil {
 		return nil, err
 	}
+	if sc.filterDecisions.Enabled() {
+		if all, err := sc.ingressInformer.Lister().Ingresses(sc.namespace).List(labels.Everything()); err == nil {
+			recordExcludedByLabels(sc.filterDecisions, "ingress", all, sc.labelSelector)
+		}
+	}
 	ingresses, err = sc.filterByAnnotations(ingresses)
 	if err != nil {
 		return nil, err
@@ -174,6 +188,7 @@ func (sc *ingressSource) Endpoints(_ context.Context) ([]*endpoint.Endpoint, err
 			continue
 		}
 
+		sc.propagatedLabels.propagate(ingEndpoints, ing.Labels)
 		log.Debugf("Endpoints generated from ingress: %s/%s: %v", ing.Namespace, ing.Name, ingEndpoints)
 		endpoints = append(endpoints, ingEndpoints...)
 	}
@@ -227,6 +242,8 @@ func (sc *ingressSource) filterByAnnotations(ingresses []*networkv1.Ingress) ([]
 		// include ingress if its annotations match the selector
 		if matchLabelSelector(selector, ingress.Annotations) {
 			filteredList = append(filteredList, ingress)
+		} else {
+			recordExcludedByAnnotations(sc.filterDecisions, "ingress", ingress, sc.annotationFilter)
 		}
 	}
 
@@ -318,7 +335,10 @@ func endpointsFromIngress(ing *networkv1.Ingress, ignoreHostnameAnnotation bool,
 	// Gather endpoints defined on annotations in the ingress
 	var annotationEndpoints []*endpoint.Endpoint
 	if !ignoreHostnameAnnotation {
-		for _, hostname := range annotations.HostnamesFromAnnotations(ing.Annotations) {
+		for _, entry := range annotations.HostnameEntriesFromAnnotations(ing.Annotations, resource) {
+			annotationEndpoints = append(annotationEndpoints, endpointsForHostnameEntry(entry, targets, ttl, providerSpecific, setIdentifier, resource)...)
+		}
+		for _, hostname := range hostnamesFromIngressPaths(ing) {
 			annotationEndpoints = append(annotationEndpoints, endpointsForHostname(hostname, targets, ttl, providerSpecific, setIdentifier, resource)...)
 		}
 	}
@@ -340,6 +360,73 @@ func endpointsFromIngress(ing *networkv1.Ingress, ignoreHostnameAnnotation bool,
 	return endpoints
 }
 
+// ingressPathTemplateData is the data the ingress-path-hostname-template
+// annotation is executed with, once per path of the ingress rules.
+type ingressPathTemplateData struct {
+	*networkv1.Ingress
+	// Host is the host of the rule of the path.
+	Host string
+	// Path is the path.
+	Path string
+	// PathSegment is the first segment of the path, e.g. "api" for "/api/v1".
+	PathSegment string
+	// ServiceName is the name of the service backend of the path.
+	ServiceName string
+	// ServicePort is the name or number of the port of the service backend of the path.
+	ServicePort string
+}
+
+// hostnamesFromIngressPaths returns the distinct hostnames generated by the
+// ingress-path-hostname-template annotation from the paths of the ingress rules.
+func hostnamesFromIngressPaths(ing *networkv1.Ingress) []string {
+	templateAnnotation := ing.Annotations[annotations.IngressPathHostnameTemplateKey]
+	if templateAnnotation == "" {
+		return nil
+	}
+	tmpl, err := fqdn.ParseTemplate(templateAnnotation)
+	if err != nil {
+		log.Warnf("Ignoring invalid path hostname template %q of ingress %s/%s: %v", templateAnnotation, ing.Namespace, ing.Name, err)
+		return nil
+	}
+
+	var hostnames []string
+	seen := map[string]bool{}
+	for _, rule := range ing.Spec.Rules {
+		if rule.HTTP == nil {
+			continue
+		}
+		for _, path := range rule.HTTP.Paths {
+			data := ingressPathTemplateData{
+				Ingress:     ing,
+				Host:        rule.Host,
+				Path:        path.Path,
+				PathSegment: strings.SplitN(strings.TrimPrefix(path.Path, "/"), "/", 2)[0],
+			}
+			if service := path.Backend.Service; service != nil {
+				data.ServiceName = service.Name
+				if service.Port.Name != "" {
+					data.ServicePort = service.Port.Name
+				} else {
+					data.ServicePort = strconv.Itoa(int(service.Port.Number))
+				}
+			}
+			names, err := fqdn.ExecTemplate(tmpl, data)
+			if err != nil {
+				log.Warnf("Ignoring path %q of ingress %s/%s: %v", path.Path, ing.Namespace, ing.Name, err)
+				continue
+			}
+			for _, name := range names {
+				if name == "" || seen[name] {
+					continue
+				}
+				seen[name] = true
+				hostnames = append(hostnames, name)
+			}
+		}
+	}
+	return hostnames
+}
+
 func targetsFromIngressStatus(status networkv1.IngressStatus) endpoint.Targets {
 	var targets endpoint.Targets
 
@@ -72,6 +72,10 @@ func TestIngressSourceNewNodeSourceWithFqdn(t *testing.T) {
 				false,
 				labels.Everything(),
 				[]string{},
+				nil,
+				0,
+				nil,
+				nil,
 			)
 
 			if tt.expectError {
@@ -334,6 +338,10 @@ func TestIngressSourceFqdnTemplatingExamples(t *testing.T) {
 				false,
 				labels.Everything(),
 				[]string{},
+				nil,
+				0,
+				nil,
+				nil,
 			)
 
 			require.NoError(t, err)
@@ -29,6 +29,7 @@ import (
 	"k8s.io/client-go/kubernetes/fake"
 
 	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/source/annotations"
 )
 
 // Validates that ingressSource is a Source
@@ -65,6 +66,10 @@ func (suite *IngressSuite) SetupTest() {
 		false,
 		labels.Everything(),
 		[]string{},
+		nil,
+		0,
+		nil,
+		nil,
 	)
 	suite.NoError(err, "should initialize ingress source")
 }
@@ -82,6 +87,7 @@ func TestIngress(t *testing.T) {
 	suite.Run(t, new(IngressSuite))
 	t.Run("endpointsFromIngress", testEndpointsFromIngress)
 	t.Run("endpointsFromIngressHostnameSourceAnnotation", testEndpointsFromIngressHostnameSourceAnnotation)
+	t.Run("endpointsFromIngressPathHostnameTemplate", testEndpointsFromIngressPathHostnameTemplate)
 	t.Run("Endpoints", testIngressEndpoints)
 }
 
@@ -129,6 +135,10 @@ func TestNewIngressSource(t *testing.T) {
 				false,
 				labels.Everything(),
 				ti.ingressClassNames,
+				nil,
+				0,
+				nil,
+				nil,
 			)
 			if ti.expectError {
 				assert.Error(t, err)
@@ -364,6 +374,78 @@ func testEndpointsFromIngressHostnameSourceAnnotation(t *testing.T) {
 	}
 }
 
+func testEndpointsFromIngressPathHostnameTemplate(t *testing.T) {
+	paths := []networkv1.HTTPIngressPath{
+		{Path: "/api/v1", Backend: networkv1.IngressBackend{Service: &networkv1.IngressServiceBackend{Name: "api", Port: networkv1.ServiceBackendPort{Number: 8080}}}},
+		{Path: "/api/v2", Backend: networkv1.IngressBackend{Service: &networkv1.IngressServiceBackend{Name: "api", Port: networkv1.ServiceBackendPort{Name: "http"}}}},
+		{Path: "/", Backend: networkv1.IngressBackend{Service: &networkv1.IngressServiceBackend{Name: "web", Port: networkv1.ServiceBackendPort{Number: 80}}}},
+	}
+
+	for _, ti := range []struct {
+		title       string
+		annotations map[string]string
+		expected    []*endpoint.Endpoint
+	}{
+		{
+			title:       "hostname per service backend",
+			annotations: map[string]string{annotations.IngressPathHostnameTemplateKey: "{{ .ServiceName }}.example.com"},
+			expected: []*endpoint.Endpoint{
+				{DNSName: "foo.bar", RecordType: endpoint.RecordTypeCNAME, Targets: endpoint.Targets{"lb.com"}},
+				{DNSName: "api.example.com", RecordType: endpoint.RecordTypeCNAME, Targets: endpoint.Targets{"lb.com"}},
+				{DNSName: "web.example.com", RecordType: endpoint.RecordTypeCNAME, Targets: endpoint.Targets{"lb.com"}},
+			},
+		},
+		{
+			title:       "hostname per path segment and rule host",
+			annotations: map[string]string{annotations.IngressPathHostnameTemplateKey: "{{ if .PathSegment }}{{ .PathSegment }}.{{ .Host }}{{ end }}"},
+			expected: []*endpoint.Endpoint{
+				{DNSName: "foo.bar", RecordType: endpoint.RecordTypeCNAME, Targets: endpoint.Targets{"lb.com"}},
+				{DNSName: "api.foo.bar", RecordType: endpoint.RecordTypeCNAME, Targets: endpoint.Targets{"lb.com"}},
+			},
+		},
+		{
+			title:       "hostname per service port",
+			annotations: map[string]string{annotations.IngressPathHostnameTemplateKey: "{{ .ServiceName }}-{{ .ServicePort }}.{{ .Namespace }}.example.com"},
+			expected: []*endpoint.Endpoint{
+				{DNSName: "foo.bar", RecordType: endpoint.RecordTypeCNAME, Targets: endpoint.Targets{"lb.com"}},
+				{DNSName: "api-8080.default.example.com", RecordType: endpoint.RecordTypeCNAME, Targets: endpoint.Targets{"lb.com"}},
+				{DNSName: "api-http.default.example.com", RecordType: endpoint.RecordTypeCNAME, Targets: endpoint.Targets{"lb.com"}},
+				{DNSName: "web-80.default.example.com", RecordType: endpoint.RecordTypeCNAME, Targets: endpoint.Targets{"lb.com"}},
+			},
+		},
+		{
+			title: "defined hosts only",
+			annotations: map[string]string{
+				annotations.IngressPathHostnameTemplateKey: "{{ .ServiceName }}.example.com",
+				ingressHostnameSourceKey:                   "defined-hosts-only",
+			},
+			expected: []*endpoint.Endpoint{
+				{DNSName: "foo.bar", RecordType: endpoint.RecordTypeCNAME, Targets: endpoint.Targets{"lb.com"}},
+			},
+		},
+		{
+			title:       "invalid template",
+			annotations: map[string]string{annotations.IngressPathHostnameTemplateKey: "{{ .ServiceName"},
+			expected: []*endpoint.Endpoint{
+				{DNSName: "foo.bar", RecordType: endpoint.RecordTypeCNAME, Targets: endpoint.Targets{"lb.com"}},
+			},
+		},
+	} {
+		t.Run(ti.title, func(t *testing.T) {
+			ingress := fakeIngress{
+				namespace:   "default",
+				name:        "fan-out",
+				dnsnames:    []string{"foo.bar"},
+				annotations: ti.annotations,
+				hostnames:   []string{"lb.com"},
+			}.Ingress()
+			ingress.Spec.Rules[0].HTTP = &networkv1.HTTPIngressRuleValue{Paths: paths}
+
+			validateEndpoints(t, endpointsFromIngress(ingress, false, false, false), ti.expected)
+		})
+	}
+}
+
 func testIngressEndpoints(t *testing.T) {
 	t.Parallel()
 
@@ -1426,6 +1508,10 @@ func testIngressEndpoints(t *testing.T) {
 				ti.ignoreIngressRulesSpec,
 				ti.ingressLabelSelector,
 				ti.ingressClassNames,
+				nil,
+				0,
+				nil,
+				nil,
 			)
 			// Informer cache has all of the ingresses. Retrieve and validate their endpoints.
 			res, err := source.Endpoints(t.Context())
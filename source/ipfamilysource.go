@@ -0,0 +1,95 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"context"
+	"slices"
+
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/source/annotations"
+)
+
+// ipFamilySource is a Source that publishes either the A or the AAAA endpoints
+// of the DNS names that have both, according to their IP family.
+type ipFamilySource struct {
+	source   Source
+	ipFamily string
+}
+
+// NewIPFamilySource creates a new ipFamilySource wrapping the provided Source.
+// The IP family of an endpoint is set by the ip-family annotation of its
+// resource, and defaults to ipFamily.
+func NewIPFamilySource(source Source, ipFamily string) Source {
+	return &ipFamilySource{source: source, ipFamily: ipFamily}
+}
+
+// Endpoints collects endpoints from its wrapped source and returns them
+// without the records of the IP family that is not published.
+func (s *ipFamilySource) Endpoints(ctx context.Context) ([]*endpoint.Endpoint, error) {
+	endpoints, err := s.source.Endpoints(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	type key struct {
+		dnsName       string
+		setIdentifier string
+	}
+	recordTypes := map[key]map[string]bool{}
+	for _, ep := range endpoints {
+		k := key{dnsName: ep.DNSName, setIdentifier: ep.SetIdentifier}
+		if recordTypes[k] == nil {
+			recordTypes[k] = map[string]bool{}
+		}
+		recordTypes[k][ep.RecordType] = true
+	}
+
+	result := make([]*endpoint.Endpoint, 0, len(endpoints))
+	for _, ep := range endpoints {
+		ipFamily := s.endpointIPFamily(ep)
+		types := recordTypes[key{dnsName: ep.DNSName, setIdentifier: ep.SetIdentifier}]
+		if ep.RecordType == endpoint.RecordTypeA && ipFamily == endpoint.IPFamilyIPv6 && types[endpoint.RecordTypeAAAA] ||
+			ep.RecordType == endpoint.RecordTypeAAAA && ipFamily == endpoint.IPFamilyIPv4 && types[endpoint.RecordTypeA] {
+			log.WithField("endpoint", ep).Debugf("Skipping endpoint because its IP family is not %s", ipFamily)
+			continue
+		}
+		result = append(result, ep)
+	}
+	return result, nil
+}
+
+// endpointIPFamily returns the IP family of an endpoint and removes the
+// annotation setting it, which is not meant for providers.
+func (s *ipFamilySource) endpointIPFamily(ep *endpoint.Endpoint) string {
+	ipFamily, ok := ep.GetProviderSpecificProperty(annotations.IPFamilyKey)
+	if !ok {
+		return s.ipFamily
+	}
+	ep.DeleteProviderSpecificProperty(annotations.IPFamilyKey)
+	if !slices.Contains(endpoint.KnownIPFamilies, ipFamily) {
+		log.Warnf("Ignoring invalid IP family %q of endpoint %s, expected one of %v", ipFamily, ep.DNSName, endpoint.KnownIPFamilies)
+		return s.ipFamily
+	}
+	return ipFamily
+}
+
+func (s *ipFamilySource) AddEventHandler(ctx context.Context, handler func()) {
+	s.source.AddEventHandler(ctx, handler)
+}
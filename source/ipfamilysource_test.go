@@ -0,0 +1,121 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/internal/testutils"
+	"sigs.k8s.io/external-dns/source/annotations"
+)
+
+// Validates that ipFamilySource is a Source
+var _ Source = &ipFamilySource{}
+
+func TestIPFamilySource(t *testing.T) {
+	dualStack := func() []*endpoint.Endpoint {
+		return []*endpoint.Endpoint{
+			endpoint.NewEndpoint("foo.example.org", endpoint.RecordTypeA, "1.2.3.4"),
+			endpoint.NewEndpoint("foo.example.org", endpoint.RecordTypeAAAA, "2001:db8::1"),
+			endpoint.NewEndpoint("v4.example.org", endpoint.RecordTypeA, "1.2.3.5"),
+			endpoint.NewEndpoint("v6.example.org", endpoint.RecordTypeAAAA, "2001:db8::2"),
+		}
+	}
+
+	for _, tc := range []struct {
+		title     string
+		ipFamily  string
+		endpoints []*endpoint.Endpoint
+		expected  []*endpoint.Endpoint
+	}{
+		{
+			title:     "dual publishes both families",
+			ipFamily:  endpoint.IPFamilyDual,
+			endpoints: dualStack(),
+			expected:  dualStack(),
+		},
+		{
+			title:     "ipv4 drops AAAA records of dual-stack names",
+			ipFamily:  endpoint.IPFamilyIPv4,
+			endpoints: dualStack(),
+			expected: []*endpoint.Endpoint{
+				endpoint.NewEndpoint("foo.example.org", endpoint.RecordTypeA, "1.2.3.4"),
+				endpoint.NewEndpoint("v4.example.org", endpoint.RecordTypeA, "1.2.3.5"),
+				endpoint.NewEndpoint("v6.example.org", endpoint.RecordTypeAAAA, "2001:db8::2"),
+			},
+		},
+		{
+			title:     "ipv6 drops A records of dual-stack names",
+			ipFamily:  endpoint.IPFamilyIPv6,
+			endpoints: dualStack(),
+			expected: []*endpoint.Endpoint{
+				endpoint.NewEndpoint("foo.example.org", endpoint.RecordTypeAAAA, "2001:db8::1"),
+				endpoint.NewEndpoint("v4.example.org", endpoint.RecordTypeA, "1.2.3.5"),
+				endpoint.NewEndpoint("v6.example.org", endpoint.RecordTypeAAAA, "2001:db8::2"),
+			},
+		},
+		{
+			title:    "set identifiers are distinct names",
+			ipFamily: endpoint.IPFamilyIPv6,
+			endpoints: []*endpoint.Endpoint{
+				endpoint.NewEndpoint("foo.example.org", endpoint.RecordTypeA, "1.2.3.4").WithSetIdentifier("a"),
+				endpoint.NewEndpoint("foo.example.org", endpoint.RecordTypeAAAA, "2001:db8::1").WithSetIdentifier("b"),
+			},
+			expected: []*endpoint.Endpoint{
+				endpoint.NewEndpoint("foo.example.org", endpoint.RecordTypeA, "1.2.3.4").WithSetIdentifier("a"),
+				endpoint.NewEndpoint("foo.example.org", endpoint.RecordTypeAAAA, "2001:db8::1").WithSetIdentifier("b"),
+			},
+		},
+		{
+			title:    "annotation overrides the default and is removed",
+			ipFamily: endpoint.IPFamilyDual,
+			endpoints: []*endpoint.Endpoint{
+				endpoint.NewEndpoint("foo.example.org", endpoint.RecordTypeA, "1.2.3.4").WithProviderSpecific(annotations.IPFamilyKey, "ipv6"),
+				endpoint.NewEndpoint("foo.example.org", endpoint.RecordTypeAAAA, "2001:db8::1").WithProviderSpecific(annotations.IPFamilyKey, "ipv6"),
+			},
+			expected: []*endpoint.Endpoint{
+				endpoint.NewEndpoint("foo.example.org", endpoint.RecordTypeAAAA, "2001:db8::1"),
+			},
+		},
+		{
+			title:    "invalid annotation falls back to the default",
+			ipFamily: endpoint.IPFamilyIPv4,
+			endpoints: []*endpoint.Endpoint{
+				endpoint.NewEndpoint("foo.example.org", endpoint.RecordTypeA, "1.2.3.4").WithProviderSpecific(annotations.IPFamilyKey, "ipv5"),
+				endpoint.NewEndpoint("foo.example.org", endpoint.RecordTypeAAAA, "2001:db8::1").WithProviderSpecific(annotations.IPFamilyKey, "ipv5"),
+			},
+			expected: []*endpoint.Endpoint{
+				endpoint.NewEndpoint("foo.example.org", endpoint.RecordTypeA, "1.2.3.4"),
+			},
+		},
+	} {
+		t.Run(tc.title, func(t *testing.T) {
+			mockSource := new(testutils.MockSource)
+			mockSource.On("Endpoints").Return(tc.endpoints, nil)
+
+			endpoints, err := NewIPFamilySource(mockSource, tc.ipFamily).Endpoints(context.Background())
+			require.NoError(t, err)
+
+			validateEndpoints(t, endpoints, tc.expected)
+			mockSource.AssertExpectations(t)
+		})
+	}
+}
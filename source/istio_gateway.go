@@ -22,6 +22,7 @@ import (
 	"sort"
 	"strings"
 	"text/template"
+	"time"
 
 	log "github.com/sirupsen/logrus"
 	networkingv1alpha3 "istio.io/client-go/pkg/apis/networking/v1alpha3"
@@ -36,6 +37,7 @@ import (
 	"k8s.io/client-go/tools/cache"
 
 	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/pkg/decisions"
 	"sigs.k8s.io/external-dns/source/annotations"
 	"sigs.k8s.io/external-dns/source/fqdn"
 	"sigs.k8s.io/external-dns/source/informers"
@@ -53,11 +55,15 @@ type gatewaySource struct {
 	istioClient              istioclient.Interface
 	namespace                string
 	annotationFilter         string
+	labelSelector            labels.Selector
 	fqdnTemplate             *template.Template
 	combineFQDNAnnotation    bool
 	ignoreHostnameAnnotation bool
 	serviceInformer          coreinformers.ServiceInformer
 	gatewayInformer          networkingv1alpha3informer.GatewayInformer
+	filterDecisions          *decisions.Recorder
+	prefixAliases            annotations.PrefixAliases
+	propagatedLabels         PropagatedLabels
 }
 
 // NewIstioGatewaySource creates a new gatewaySource with the given config.
@@ -67,20 +73,28 @@ func NewIstioGatewaySource(
 	istioClient istioclient.Interface,
 	namespace string,
 	annotationFilter string,
+	labelSelector labels.Selector,
 	fqdnTemplate string,
 	combineFQDNAnnotation bool,
 	ignoreHostnameAnnotation bool,
+	filterDecisions *decisions.Recorder,
+	resyncPeriod time.Duration,
+	prefixAliases annotations.PrefixAliases,
+	propagatedLabels PropagatedLabels,
 ) (Source, error) {
 	tmpl, err := fqdn.ParseTemplate(fqdnTemplate)
 	if err != nil {
 		return nil, err
 	}
+	if labelSelector == nil {
+		labelSelector = labels.Everything()
+	}
 
 	// Use shared informers to listen for add/update/delete of services/pods/nodes in the specified namespace.
 	// Set resync period to 0, to prevent processing when nothing has changed
-	informerFactory := kubeinformers.NewSharedInformerFactoryWithOptions(kubeClient, 0, kubeinformers.WithNamespace(namespace))
+	informerFactory := kubeinformers.NewSharedInformerFactoryWithOptions(kubeClient, resyncPeriod, kubeinformers.WithNamespace(namespace))
 	serviceInformer := informerFactory.Core().V1().Services()
-	istioInformerFactory := istioinformers.NewSharedInformerFactory(istioClient, 0)
+	istioInformerFactory := istioinformers.NewSharedInformerFactoryWithOptions(istioClient, resyncPeriod, istioinformers.WithTweakListOptions(labelFilterListOptions(labelSelector, filterDecisions)))
 	gatewayInformer := istioInformerFactory.Networking().V1alpha3().Gateways()
 
 	// Add default resource event handlers to properly initialize informer.
@@ -100,6 +114,8 @@ func NewIstioGatewaySource(
 		},
 	)
 
+	aliasAnnotations(prefixAliases, serviceInformer.Informer(), gatewayInformer.Informer())
+
 	informerFactory.Start(ctx.Done())
 	istioInformerFactory.Start(ctx.Done())
 
@@ -112,27 +128,34 @@ func NewIstioGatewaySource(
 	}
 
 	return &gatewaySource{
+		prefixAliases:            prefixAliases,
+		filterDecisions:          filterDecisions,
 		kubeClient:               kubeClient,
 		istioClient:              istioClient,
 		namespace:                namespace,
 		annotationFilter:         annotationFilter,
+		labelSelector:            labelSelector,
 		fqdnTemplate:             tmpl,
 		combineFQDNAnnotation:    combineFQDNAnnotation,
 		ignoreHostnameAnnotation: ignoreHostnameAnnotation,
 		serviceInformer:          serviceInformer,
 		gatewayInformer:          gatewayInformer,
+		propagatedLabels:         propagatedLabels,
 	}, nil
 }
 
 // Endpoints returns endpoint objects for each host-target combination that should be processed.
 // Retrieves all gateway resources in the source's namespace(s).
 func (sc *gatewaySource) Endpoints(ctx context.Context) ([]*endpoint.Endpoint, error) {
-	gwList, err := sc.istioClient.NetworkingV1alpha3().Gateways(sc.namespace).List(ctx, metav1.ListOptions{})
+	gwList, err := sc.istioClient.NetworkingV1alpha3().Gateways(sc.namespace).List(ctx, metav1.ListOptions{LabelSelector: sc.labelSelector.String()})
 	if err != nil {
 		return nil, err
 	}
 
 	gateways := gwList.Items
+	for _, gateway := range gateways {
+		gateway.Annotations = sc.prefixAliases.Alias(gateway.Annotations)
+	}
 	gateways, err = sc.filterByAnnotations(gateways)
 	if err != nil {
 		return nil, err
@@ -183,6 +206,7 @@ func (sc *gatewaySource) Endpoints(ctx context.Context) ([]*endpoint.Endpoint, e
 			continue
 		}
 
+		sc.propagatedLabels.propagate(gwEndpoints, gateway.Labels)
 		log.Debugf("Endpoints generated from gateway: %s/%s: %v", gateway.Namespace, gateway.Name, gwEndpoints)
 		endpoints = append(endpoints, gwEndpoints...)
 	}
@@ -219,6 +243,8 @@ func (sc *gatewaySource) filterByAnnotations(gateways []*networkingv1alpha3.Gate
 		// include if the annotations match the selector
 		if selector.Matches(labels.Set(gw.Annotations)) {
 			filteredList = append(filteredList, gw)
+		} else {
+			recordExcludedByAnnotations(sc.filterDecisions, "gateway", gw, sc.annotationFilter)
 		}
 	}
 
@@ -282,7 +308,15 @@ func (sc *gatewaySource) endpointsFromGateway(ctx context.Context, hostnames []s
 
 	providerSpecific, setIdentifier := annotations.ProviderSpecificAnnotations(gateway.Annotations)
 
+	var entries map[string]annotations.HostnameEntry
+	if !sc.ignoreHostnameAnnotation {
+		entries = hostnameEntriesByHostname(gateway.Annotations, resource)
+	}
 	for _, host := range hostnames {
+		if entry, ok := entries[host]; ok {
+			endpoints = append(endpoints, endpointsForHostnameEntry(entry, targets, ttl, providerSpecific, setIdentifier, resource)...)
+			continue
+		}
 		endpoints = append(endpoints, endpointsForHostname(host, targets, ttl, providerSpecific, setIdentifier, resource)...)
 	}
 
@@ -312,7 +346,9 @@ func (sc *gatewaySource) hostNamesFromGateway(gateway *networkingv1alpha3.Gatewa
 	}
 
 	if !sc.ignoreHostnameAnnotation {
-		hostnames = append(hostnames, annotations.HostnamesFromAnnotations(gateway.Annotations)...)
+		for _, entry := range annotations.HostnameEntriesFromAnnotations(gateway.Annotations, fmt.Sprintf("gateway/%s/%s", gateway.Namespace, gateway.Name)) {
+			hostnames = append(hostnames, entry.Hostname)
+		}
 	}
 
 	return hostnames, nil
@@ -30,6 +30,7 @@ import (
 	v1 "k8s.io/api/core/v1"
 	networkv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/client-go/kubernetes/fake"
 
 	"sigs.k8s.io/external-dns/endpoint"
@@ -96,9 +97,14 @@ func (suite *GatewaySuite) SetupTest() {
 		fakeIstioClient,
 		"",
 		"",
+		labels.Everything(),
 		"{{.Name}}",
 		false,
 		false,
+		nil,
+		0,
+		nil,
+		nil,
 	)
 	suite.NoError(err, "should initialize gateway source")
 	suite.NoError(err, "should succeed")
@@ -170,9 +176,14 @@ func TestNewIstioGatewaySource(t *testing.T) {
 				istiofake.NewSimpleClientset(),
 				"",
 				ti.annotationFilter,
+				labels.Everything(),
 				ti.fqdnTemplate,
 				ti.combineFQDNAndAnnotation,
 				false,
+				nil,
+				0,
+				nil,
+				nil,
 			)
 			if ti.expectError {
 				assert.Error(t, err)
@@ -1104,6 +1115,43 @@ func testGatewayEndpoints(t *testing.T) {
 				},
 			},
 		},
+		{
+			title:           "gateway rules with structured hostname annotation",
+			targetNamespace: "",
+			lbServices: []fakeIngressGatewayService{
+				{
+					ips: []string{"1.2.3.4"},
+				},
+			},
+			configItems: []fakeGatewayConfig{
+				{
+					name:      "fake1",
+					namespace: "",
+					annotations: map[string]string{
+						hostnameAnnotationKey: `[{"hostname": "ttl.example.org", "ttl": 60}, {"hostname": "lb.example.org", "targets": ["lb.example.com"]}]`,
+					},
+					dnsnames: [][]string{{"example.org"}},
+				},
+			},
+			expected: []*endpoint.Endpoint{
+				{
+					DNSName:    "example.org",
+					Targets:    endpoint.Targets{"1.2.3.4"},
+					RecordType: endpoint.RecordTypeA,
+				},
+				{
+					DNSName:    "ttl.example.org",
+					Targets:    endpoint.Targets{"1.2.3.4"},
+					RecordType: endpoint.RecordTypeA,
+					RecordTTL:  60,
+				},
+				{
+					DNSName:    "lb.example.org",
+					Targets:    endpoint.Targets{"lb.example.com"},
+					RecordType: endpoint.RecordTypeCNAME,
+				},
+			},
+		},
 		{
 			title:           "gateway rules with hostname and target annotation",
 			targetNamespace: "",
@@ -1504,9 +1552,14 @@ func testGatewayEndpoints(t *testing.T) {
 				fakeIstioClient,
 				ti.targetNamespace,
 				ti.annotationFilter,
+				labels.Everything(),
 				ti.fqdnTemplate,
 				ti.combineFQDNAndAnnotation,
 				ti.ignoreHostnameAnnotation,
+				nil,
+				0,
+				nil,
+				nil,
 			)
 			require.NoError(t, err)
 
@@ -1548,9 +1601,14 @@ func newTestGatewaySource(loadBalancerList []fakeIngressGatewayService, ingressL
 		fakeIstioClient,
 		"",
 		"",
+		labels.Everything(),
 		"{{.Name}}",
 		false,
 		false,
+		nil,
+		0,
+		nil,
+		nil,
 	)
 	if err != nil {
 		return nil, err
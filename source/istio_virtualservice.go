@@ -23,6 +23,7 @@ import (
 	"sort"
 	"strings"
 	"text/template"
+	"time"
 
 	log "github.com/sirupsen/logrus"
 	networkingv1alpha3 "istio.io/client-go/pkg/apis/networking/v1alpha3"
@@ -38,6 +39,7 @@ import (
 	"k8s.io/client-go/tools/cache"
 
 	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/pkg/decisions"
 	"sigs.k8s.io/external-dns/source/annotations"
 	"sigs.k8s.io/external-dns/source/fqdn"
 	"sigs.k8s.io/external-dns/source/informers"
@@ -54,12 +56,15 @@ type virtualServiceSource struct {
 	istioClient              istioclient.Interface
 	namespace                string
 	annotationFilter         string
+	labelSelector            labels.Selector
 	fqdnTemplate             *template.Template
 	combineFQDNAnnotation    bool
 	ignoreHostnameAnnotation bool
 	serviceInformer          coreinformers.ServiceInformer
 	virtualserviceInformer   networkingv1alpha3informer.VirtualServiceInformer
 	gatewayInformer          networkingv1alpha3informer.GatewayInformer
+	filterDecisions          *decisions.Recorder
+	propagatedLabels         PropagatedLabels
 }
 
 // NewIstioVirtualServiceSource creates a new virtualServiceSource with the given config.
@@ -69,22 +74,32 @@ func NewIstioVirtualServiceSource(
 	istioClient istioclient.Interface,
 	namespace string,
 	annotationFilter string,
+	labelSelector labels.Selector,
 	fqdnTemplate string,
 	combineFQDNAnnotation bool,
 	ignoreHostnameAnnotation bool,
+	filterDecisions *decisions.Recorder,
+	resyncPeriod time.Duration,
+	prefixAliases annotations.PrefixAliases,
+	propagatedLabels PropagatedLabels,
 ) (Source, error) {
 	tmpl, err := fqdn.ParseTemplate(fqdnTemplate)
 	if err != nil {
 		return nil, err
 	}
+	if labelSelector == nil {
+		labelSelector = labels.Everything()
+	}
 
 	// Use shared informers to listen for add/update/delete of services/pods/nodes in the specified namespace.
 	// Set resync period to 0, to prevent processing when nothing has changed
-	informerFactory := kubeinformers.NewSharedInformerFactoryWithOptions(kubeClient, 0, kubeinformers.WithNamespace(namespace))
+	informerFactory := kubeinformers.NewSharedInformerFactoryWithOptions(kubeClient, resyncPeriod, kubeinformers.WithNamespace(namespace))
 	serviceInformer := informerFactory.Core().V1().Services()
-	istioInformerFactory := istioinformers.NewSharedInformerFactoryWithOptions(istioClient, 0, istioinformers.WithNamespace(namespace))
-	virtualServiceInformer := istioInformerFactory.Networking().V1alpha3().VirtualServices()
+	istioInformerFactory := istioinformers.NewSharedInformerFactoryWithOptions(istioClient, resyncPeriod, istioinformers.WithNamespace(namespace))
 	gatewayInformer := istioInformerFactory.Networking().V1alpha3().Gateways()
+	// The label filter doesn't apply to the gateways of the virtual services, so the virtual services have their own factory.
+	virtualServiceInformerFactory := istioinformers.NewSharedInformerFactoryWithOptions(istioClient, resyncPeriod, istioinformers.WithNamespace(namespace), istioinformers.WithTweakListOptions(labelFilterListOptions(labelSelector, filterDecisions)))
+	virtualServiceInformer := virtualServiceInformerFactory.Networking().V1alpha3().VirtualServices()
 
 	// Add default resource event handlers to properly initialize informer.
 	serviceInformer.Informer().AddEventHandler(
@@ -111,8 +126,11 @@ func NewIstioVirtualServiceSource(
 		},
 	)
 
+	aliasAnnotations(prefixAliases, serviceInformer.Informer(), virtualServiceInformer.Informer(), gatewayInformer.Informer())
+
 	informerFactory.Start(ctx.Done())
 	istioInformerFactory.Start(ctx.Done())
+	virtualServiceInformerFactory.Start(ctx.Done())
 
 	// wait for the local cache to be populated.
 	if err := informers.WaitForCacheSync(context.Background(), informerFactory); err != nil {
@@ -121,25 +139,31 @@ func NewIstioVirtualServiceSource(
 	if err := informers.WaitForCacheSync(context.Background(), istioInformerFactory); err != nil {
 		return nil, err
 	}
+	if err := informers.WaitForCacheSync(context.Background(), virtualServiceInformerFactory); err != nil {
+		return nil, err
+	}
 
 	return &virtualServiceSource{
+		filterDecisions:          filterDecisions,
 		kubeClient:               kubeClient,
 		istioClient:              istioClient,
 		namespace:                namespace,
 		annotationFilter:         annotationFilter,
+		labelSelector:            labelSelector,
 		fqdnTemplate:             tmpl,
 		combineFQDNAnnotation:    combineFQDNAnnotation,
 		ignoreHostnameAnnotation: ignoreHostnameAnnotation,
 		serviceInformer:          serviceInformer,
 		virtualserviceInformer:   virtualServiceInformer,
 		gatewayInformer:          gatewayInformer,
+		propagatedLabels:         propagatedLabels,
 	}, nil
 }
 
 // Endpoints returns endpoint objects for each host-target combination that should be processed.
 // Retrieves all VirtualService resources in the source's namespace(s).
 func (sc *virtualServiceSource) Endpoints(ctx context.Context) ([]*endpoint.Endpoint, error) {
-	virtualServices, err := sc.virtualserviceInformer.Lister().VirtualServices(sc.namespace).List(labels.Everything())
+	virtualServices, err := sc.virtualserviceInformer.Lister().VirtualServices(sc.namespace).List(sc.labelSelector)
 	if err != nil {
 		return nil, err
 	}
@@ -183,6 +207,7 @@ func (sc *virtualServiceSource) Endpoints(ctx context.Context) ([]*endpoint.Endp
 			continue
 		}
 
+		sc.propagatedLabels.propagate(gwEndpoints, virtualService.Labels)
 		log.Debugf("Endpoints generated from VirtualService: %s/%s: %v", virtualService.Namespace, virtualService.Name, gwEndpoints)
 		endpoints = append(endpoints, gwEndpoints...)
 	}
@@ -270,6 +295,8 @@ func (sc *virtualServiceSource) filterByAnnotations(virtualservices []*networkin
 		// include if the annotations match the selector
 		if selector.Matches(labels.Set(vs.Annotations)) {
 			filteredList = append(filteredList, vs)
+		} else {
+			recordExcludedByAnnotations(sc.filterDecisions, "virtualservice", vs, sc.annotationFilter)
 		}
 	}
 
@@ -351,16 +378,15 @@ func (sc *virtualServiceSource) endpointsFromVirtualService(ctx context.Context,
 
 	// Skip endpoints if we do not want entries from annotations
 	if !sc.ignoreHostnameAnnotation {
-		hostnameList := annotations.HostnamesFromAnnotations(virtualservice.Annotations)
-		for _, hostname := range hostnameList {
+		for _, entry := range annotations.HostnameEntriesFromAnnotations(virtualservice.Annotations, resource) {
 			targets := targetsFromAnnotation
-			if len(targets) == 0 {
-				targets, err = sc.targetsFromVirtualService(ctx, virtualservice, hostname)
+			if len(targets) == 0 && len(entry.Targets) == 0 {
+				targets, err = sc.targetsFromVirtualService(ctx, virtualservice, entry.Hostname)
 				if err != nil {
 					return endpoints, err
 				}
 			}
-			endpoints = append(endpoints, endpointsForHostname(hostname, targets, ttl, providerSpecific, setIdentifier, resource)...)
+			endpoints = append(endpoints, endpointsForHostnameEntry(entry, targets, ttl, providerSpecific, setIdentifier, resource)...)
 		}
 	}
 
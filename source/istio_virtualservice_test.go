@@ -31,6 +31,7 @@ import (
 	v1 "k8s.io/api/core/v1"
 	networkv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/client-go/kubernetes/fake"
 
 	"sigs.k8s.io/external-dns/endpoint"
@@ -116,9 +117,14 @@ func (suite *VirtualServiceSuite) SetupTest() {
 		fakeIstioClient,
 		"",
 		"",
+		labels.Everything(),
 		"{{.Name}}",
 		false,
 		false,
+		nil,
+		0,
+		nil,
+		nil,
 	)
 	suite.NoError(err, "should initialize virtualservice source")
 }
@@ -193,9 +199,14 @@ func TestNewIstioVirtualServiceSource(t *testing.T) {
 				istiofake.NewSimpleClientset(),
 				"",
 				ti.annotationFilter,
+				labels.Everything(),
 				ti.fqdnTemplate,
 				ti.combineFQDNAndAnnotation,
 				false,
+				nil,
+				0,
+				nil,
+				nil,
 			)
 			if ti.expectError {
 				assert.Error(t, err)
@@ -1990,9 +2001,14 @@ func testVirtualServiceEndpoints(t *testing.T) {
 				fakeIstioClient,
 				ti.targetNamespace,
 				ti.annotationFilter,
+				labels.Everything(),
 				ti.fqdnTemplate,
 				ti.combineFQDNAndAnnotation,
 				ti.ignoreHostnameAnnotation,
+				nil,
+				0,
+				nil,
+				nil,
 			)
 			require.NoError(t, err)
 
@@ -2076,9 +2092,14 @@ func newTestVirtualServiceSource(loadBalancerList []fakeIngressGatewayService, i
 		fakeIstioClient,
 		"",
 		"",
+		labels.Everything(),
 		"{{.Name}}",
 		false,
 		false,
+		nil,
+		0,
+		nil,
+		nil,
 	)
 	if err != nil {
 		return nil, err
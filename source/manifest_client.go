@@ -0,0 +1,242 @@
+//go:build simulate
+
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	cfclient "github.com/cloudfoundry-community/go-cfclient"
+	openshift "github.com/openshift/client-go/route/clientset/versioned"
+	openshiftfake "github.com/openshift/client-go/route/clientset/versioned/fake"
+	openshiftscheme "github.com/openshift/client-go/route/clientset/versioned/scheme"
+	projectcontour "github.com/projectcontour/contour/apis/projectcontour/v1"
+	istioclient "istio.io/client-go/pkg/clientset/versioned"
+	istiofake "istio.io/client-go/pkg/clientset/versioned/fake"
+	istioscheme "istio.io/client-go/pkg/clientset/versioned/scheme"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/yaml"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	"k8s.io/client-go/dynamic"
+	fakedynamic "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes"
+	fakekube "k8s.io/client-go/kubernetes/fake"
+	kubescheme "k8s.io/client-go/kubernetes/scheme"
+	clienttesting "k8s.io/client-go/testing"
+	gateway "sigs.k8s.io/gateway-api/pkg/client/clientset/versioned"
+	gatewayfake "sigs.k8s.io/gateway-api/pkg/client/clientset/versioned/fake"
+	gatewayscheme "sigs.k8s.io/gateway-api/pkg/client/clientset/versioned/scheme"
+)
+
+// manifestListKinds are the list kinds of the custom resources read by the sources with the dynamic client.
+var manifestListKinds = map[schema.GroupVersionResource]string{
+	ambHostGVR:                  "HostList",
+	dnsConfigGVR:                "DNSConfigList",
+	f5TransportServerGVR:        "TransportServerList",
+	f5VirtualServerGVR:          "VirtualServerList",
+	proxyGVR:                    "ProxyList",
+	virtualServiceGVR:           "VirtualServiceList",
+	kongGroupdVersionResource:   "TCPIngressList",
+	ingressrouteGVR:             "IngressRouteList",
+	ingressrouteTCPGVR:          "IngressRouteTCPList",
+	ingressrouteUDPGVR:          "IngressRouteUDPList",
+	oldIngressrouteGVR:          "IngressRouteList",
+	oldIngressrouteTCPGVR:       "IngressRouteTCPList",
+	oldIngressrouteUDPGVR:       "IngressRouteUDPList",
+	projectcontour.HTTPProxyGVR: "HTTPProxyList",
+}
+
+// ManifestClientGenerator is a ClientGenerator whose clients serve the objects of a manifest instead
+// of those of a cluster, e.g. to simulate the records the sources would create for them.
+// The CloudFoundry client is not supported.
+type ManifestClientGenerator struct {
+	kubeClient      *fakekube.Clientset
+	gatewayClient   *gatewayfake.Clientset
+	istioClient     *istiofake.Clientset
+	dynamicClient   *fakedynamic.FakeDynamicClient
+	openshiftClient *openshiftfake.Clientset
+}
+
+// NewManifestClientGenerator reads the YAML or JSON documents of a manifest, which may contain lists,
+// and returns a ManifestClientGenerator serving their objects.
+func NewManifestClientGenerator(manifest io.Reader) (*ManifestClientGenerator, error) {
+	objects, err := readManifest(manifest)
+	if err != nil {
+		return nil, err
+	}
+
+	listKinds := make(map[schema.GroupVersionResource]string, len(manifestListKinds))
+	for gvr, listKind := range manifestListKinds {
+		listKinds[gvr] = listKind
+	}
+	for _, u := range objects {
+		if !recognizedByClients(u.GroupVersionKind()) {
+			listKinds[manifestResource(u.GroupVersionKind())] = u.GetKind() + "List"
+		}
+	}
+
+	m := &ManifestClientGenerator{
+		kubeClient:      fakekube.NewClientset(),
+		gatewayClient:   gatewayfake.NewSimpleClientset(),
+		istioClient:     istiofake.NewSimpleClientset(),
+		dynamicClient:   fakedynamic.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), listKinds),
+		openshiftClient: openshiftfake.NewSimpleClientset(),
+	}
+	// the generic-crd source looks up the resources of the custom resources
+	discovery := m.kubeClient.Discovery().(*fakediscovery.FakeDiscovery)
+	discovered := map[schema.GroupVersionResource]bool{}
+
+	for _, u := range objects {
+		gvk := u.GroupVersionKind()
+		// the fake clients guess wrongly the resources of some kinds, e.g. gatewaies for Gateway
+		gvr := manifestResource(gvk)
+		var (
+			tracker clienttesting.ObjectTracker
+			scheme  *runtime.Scheme
+		)
+		switch {
+		case kubescheme.Scheme.Recognizes(gvk):
+			tracker, scheme = m.kubeClient.Tracker(), kubescheme.Scheme
+		case gatewayscheme.Scheme.Recognizes(gvk):
+			tracker, scheme = m.gatewayClient.Tracker(), gatewayscheme.Scheme
+		case istioscheme.Scheme.Recognizes(gvk):
+			tracker, scheme = m.istioClient.Tracker(), istioscheme.Scheme
+		case openshiftscheme.Scheme.Recognizes(gvk):
+			tracker, scheme = m.openshiftClient.Tracker(), openshiftscheme.Scheme
+		default:
+			if !discovered[gvr] {
+				discovered[gvr] = true
+				discovery.Resources = append(discovery.Resources, &metav1.APIResourceList{
+					GroupVersion: gvk.GroupVersion().String(),
+					APIResources: []metav1.APIResource{{Name: gvr.Resource, Kind: gvk.Kind, Namespaced: u.GetNamespace() != ""}},
+				})
+			}
+			if err := m.dynamicClient.Tracker().Create(gvr, u, u.GetNamespace()); err != nil {
+				return nil, fmt.Errorf("failed to add %s %s/%s: %w", u.GetKind(), u.GetNamespace(), u.GetName(), err)
+			}
+			continue
+		}
+
+		obj, err := typedObject(scheme, u)
+		if err != nil {
+			return nil, err
+		}
+		if err := tracker.Create(gvr, obj, u.GetNamespace()); err != nil {
+			return nil, fmt.Errorf("failed to add %s %s/%s: %w", u.GetKind(), u.GetNamespace(), u.GetName(), err)
+		}
+	}
+
+	return m, nil
+}
+
+// recognizedByClients returns whether a kind is served by the typed clients rather than the dynamic client.
+func recognizedByClients(gvk schema.GroupVersionKind) bool {
+	return kubescheme.Scheme.Recognizes(gvk) || gatewayscheme.Scheme.Recognizes(gvk) ||
+		istioscheme.Scheme.Recognizes(gvk) || openshiftscheme.Scheme.Recognizes(gvk)
+}
+
+// manifestResource guesses the resource of a kind, e.g. gateways for Gateway or proxies for Proxy.
+func manifestResource(gvk schema.GroupVersionKind) schema.GroupVersionResource {
+	gvr, _ := meta.UnsafeGuessKindToResource(gvk)
+	kind := strings.ToLower(gvk.Kind)
+	if len(kind) > 1 && strings.HasSuffix(kind, "y") && strings.ContainsAny(kind[len(kind)-2:len(kind)-1], "aeiou") {
+		gvr.Resource = kind + "s"
+	}
+	return gvr
+}
+
+// readManifest returns the objects of the documents of a manifest, expanding the lists.
+func readManifest(manifest io.Reader) ([]*unstructured.Unstructured, error) {
+	var objects []*unstructured.Unstructured
+	decoder := yaml.NewYAMLOrJSONDecoder(manifest, 4096)
+	for {
+		u := &unstructured.Unstructured{}
+		if err := decoder.Decode(&u.Object); err != nil {
+			if errors.Is(err, io.EOF) {
+				return objects, nil
+			}
+			return nil, fmt.Errorf("failed to read the manifest: %w", err)
+		}
+		if len(u.Object) == 0 {
+			continue
+		}
+		if u.IsList() {
+			list, err := u.ToList()
+			if err != nil {
+				return nil, fmt.Errorf("failed to read the manifest: %w", err)
+			}
+			for i := range list.Items {
+				objects = append(objects, &list.Items[i])
+			}
+			continue
+		}
+		if u.GetKind() == "" || u.GetAPIVersion() == "" {
+			return nil, fmt.Errorf("failed to read the manifest: object %q has no kind or apiVersion", u.GetName())
+		}
+		objects = append(objects, u)
+	}
+}
+
+// typedObject converts an object to its type in the scheme.
+func typedObject(scheme *runtime.Scheme, u *unstructured.Unstructured) (runtime.Object, error) {
+	obj, err := scheme.New(u.GroupVersionKind())
+	if err != nil {
+		return nil, err
+	}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, obj); err != nil {
+		return nil, fmt.Errorf("failed to convert %s %s/%s: %w", u.GetKind(), u.GetNamespace(), u.GetName(), err)
+	}
+	return obj, nil
+}
+
+// KubeClient returns the Kubernetes client serving the core objects of the manifest.
+func (m *ManifestClientGenerator) KubeClient() (kubernetes.Interface, error) {
+	return m.kubeClient, nil
+}
+
+// GatewayClient returns the Gateway API client serving the Gateway API objects of the manifest.
+func (m *ManifestClientGenerator) GatewayClient() (gateway.Interface, error) {
+	return m.gatewayClient, nil
+}
+
+// IstioClient returns the Istio client serving the Istio objects of the manifest.
+func (m *ManifestClientGenerator) IstioClient() (istioclient.Interface, error) {
+	return m.istioClient, nil
+}
+
+// CloudFoundryClient is not supported.
+func (m *ManifestClientGenerator) CloudFoundryClient(_ string, _ string, _ string) (*cfclient.Client, error) {
+	return nil, errors.New("the cloudfoundry source cannot read a manifest")
+}
+
+// DynamicKubernetesClient returns the dynamic client serving the other objects of the manifest.
+func (m *ManifestClientGenerator) DynamicKubernetesClient() (dynamic.Interface, error) {
+	return m.dynamicClient, nil
+}
+
+// OpenShiftClient returns the OpenShift client serving the routes of the manifest.
+func (m *ManifestClientGenerator) OpenShiftClient() (openshift.Interface, error) {
+	return m.openshiftClient, nil
+}
@@ -0,0 +1,97 @@
+//go:build simulate
+
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestManifestClientGenerator(t *testing.T) {
+	ctx := context.Background()
+	m, err := NewManifestClientGenerator(strings.NewReader(`
+apiVersion: v1
+kind: Service
+metadata:
+  name: web
+  namespace: default
+---
+apiVersion: gateway.networking.k8s.io/v1
+kind: Gateway
+metadata:
+  name: gateway
+  namespace: default
+---
+{"apiVersion": "networking.istio.io/v1", "kind": "Gateway", "metadata": {"name": "gateway", "namespace": "default"}}
+---
+apiVersion: example.com/v1
+kind: Proxy
+metadata:
+  name: proxy
+  namespace: default
+`))
+	require.NoError(t, err)
+
+	kubeClient, err := m.KubeClient()
+	require.NoError(t, err)
+	_, err = kubeClient.CoreV1().Services("default").Get(ctx, "web", metav1.GetOptions{})
+	assert.NoError(t, err)
+
+	gatewayClient, err := m.GatewayClient()
+	require.NoError(t, err)
+	_, err = gatewayClient.GatewayV1().Gateways("default").Get(ctx, "gateway", metav1.GetOptions{})
+	assert.NoError(t, err)
+
+	istioClient, err := m.IstioClient()
+	require.NoError(t, err)
+	_, err = istioClient.NetworkingV1().Gateways("default").Get(ctx, "gateway", metav1.GetOptions{})
+	assert.NoError(t, err)
+
+	// the custom resources are served by the dynamic client and listed by the discovery
+	dynamicClient, err := m.DynamicKubernetesClient()
+	require.NoError(t, err)
+	_, err = dynamicClient.Resource(genericCRDTestGVR).Namespace("default").Get(ctx, "proxy", metav1.GetOptions{})
+	assert.NoError(t, err)
+	gvr, err := genericCRDResource(kubeClient, genericCRDTestGVR.GroupVersion().WithKind("Proxy"))
+	require.NoError(t, err)
+	assert.Equal(t, genericCRDTestGVR, gvr)
+
+	// the resources of the sources can be listed without objects
+	_, err = dynamicClient.Resource(ambHostGVR).List(ctx, metav1.ListOptions{})
+	assert.NoError(t, err)
+
+	_, err = m.CloudFoundryClient("", "", "")
+	assert.Error(t, err)
+}
+
+func TestManifestClientGeneratorErrors(t *testing.T) {
+	for _, manifest := range []string{
+		"metadata:\n  name: no-kind\n",
+		"apiVersion: v1\nkind: Service\nspec: [\n",
+		"apiVersion: v1\nkind: Service\nspec:\n  ports: invalid\n",
+	} {
+		_, err := NewManifestClientGenerator(strings.NewReader(manifest))
+		assert.Error(t, err, manifest)
+	}
+}
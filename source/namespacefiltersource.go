@@ -0,0 +1,92 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/pkg/decisions"
+)
+
+// namespaceFilterSource is a Source that removes the endpoints of the resources of the excluded
+// namespaces from its wrapped source.
+type namespaceFilterSource struct {
+	source          Source
+	include         *regexp.Regexp
+	exclude         []string
+	filterDecisions *decisions.Recorder
+}
+
+// NewNamespaceFilterSource creates a new namespaceFilterSource wrapping the provided Source. It
+// keeps the endpoints of the resources whose namespace matches include, if not nil or empty,
+// and isn't one of exclude. The endpoints of cluster-scoped resources are always kept. The dropped
+// endpoints are recorded by filterDecisions.
+func NewNamespaceFilterSource(source Source, include *regexp.Regexp, exclude []string, filterDecisions *decisions.Recorder) Source {
+	if include != nil && include.String() == "" {
+		include = nil
+	}
+	if include == nil && len(exclude) == 0 {
+		return source
+	}
+	return &namespaceFilterSource{source: source, include: include, exclude: exclude, filterDecisions: filterDecisions}
+}
+
+// Endpoints collects endpoints from its wrapped source and returns
+// them without the ones of the resources of the excluded namespaces.
+func (ns *namespaceFilterSource) Endpoints(ctx context.Context) ([]*endpoint.Endpoint, error) {
+	endpoints, err := ns.source.Endpoints(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*endpoint.Endpoint, 0, len(endpoints))
+	for _, ep := range endpoints {
+		namespace := endpointNamespace(ep)
+		if namespace == "" {
+			result = append(result, ep)
+			continue
+		}
+		if reason := ns.excluded(namespace); reason != "" {
+			log.WithField("endpoint", ep).Debugf("Skipping endpoint because %s", reason)
+			ns.filterDecisions.Record(decisions.ForResource(decisions.NamespaceFilter, ep.Labels[endpoint.ResourceLabelKey], reason))
+			continue
+		}
+		result = append(result, ep)
+	}
+	return result, nil
+}
+
+// excluded returns why the namespace is excluded, or an empty string if it isn't.
+func (ns *namespaceFilterSource) excluded(namespace string) string {
+	if slices.Contains(ns.exclude, namespace) {
+		return fmt.Sprintf("the namespace %q is excluded", namespace)
+	}
+	if ns.include != nil && !ns.include.MatchString(namespace) {
+		return fmt.Sprintf("the namespace %q does not match the namespace regex %q", namespace, ns.include)
+	}
+	return ""
+}
+
+func (ns *namespaceFilterSource) AddEventHandler(ctx context.Context, handler func()) {
+	ns.source.AddEventHandler(ctx, handler)
+}
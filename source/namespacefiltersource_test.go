@@ -0,0 +1,101 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/pkg/decisions"
+)
+
+func TestNamespaceFilterSource(t *testing.T) {
+	newEndpoint := func(name, resource string) *endpoint.Endpoint {
+		return endpoint.NewEndpoint(name, endpoint.RecordTypeA, "1.2.3.4").WithLabel(endpoint.ResourceLabelKey, resource)
+	}
+	endpoints := []*endpoint.Endpoint{
+		newEndpoint("web.example.org", "ingress/team-web/web"),
+		newEndpoint("sandbox.example.org", "service/team-sandbox/sandbox"),
+		newEndpoint("dns.example.org", "service/kube-system/dns"),
+		newEndpoint("worker.example.org", "node/worker"),
+		endpoint.NewEndpoint("fake.example.org", endpoint.RecordTypeA, "1.2.3.4"),
+	}
+
+	for _, tc := range []struct {
+		title    string
+		include  *regexp.Regexp
+		exclude  []string
+		expected []string
+	}{
+		{
+			title:    "no filter",
+			include:  regexp.MustCompile(""),
+			expected: []string{"web.example.org", "sandbox.example.org", "dns.example.org", "worker.example.org", "fake.example.org"},
+		},
+		{
+			title:    "excluded namespaces",
+			exclude:  []string{"team-sandbox", "kube-system"},
+			expected: []string{"web.example.org", "worker.example.org", "fake.example.org"},
+		},
+		{
+			title:    "namespace regex",
+			include:  regexp.MustCompile("^team-"),
+			expected: []string{"web.example.org", "sandbox.example.org", "worker.example.org", "fake.example.org"},
+		},
+		{
+			title:    "namespace regex and excluded namespaces",
+			include:  regexp.MustCompile("^team-"),
+			exclude:  []string{"team-sandbox"},
+			expected: []string{"web.example.org", "worker.example.org", "fake.example.org"},
+		},
+	} {
+		t.Run(tc.title, func(t *testing.T) {
+			src := NewNamespaceFilterSource(NewEchoSource(endpoints), tc.include, tc.exclude, nil)
+			result, err := src.Endpoints(context.Background())
+			require.NoError(t, err)
+
+			var names []string
+			for _, ep := range result {
+				names = append(names, ep.DNSName)
+			}
+			assert.Equal(t, tc.expected, names)
+		})
+	}
+}
+
+func TestNamespaceFilterSourceRecordsFilterDecisions(t *testing.T) {
+	recorder := decisions.NewRecorder()
+	recorder.Enable()
+
+	src := NewNamespaceFilterSource(NewEchoSource([]*endpoint.Endpoint{
+		endpoint.NewEndpoint("sandbox.example.org", endpoint.RecordTypeA, "1.2.3.4").WithLabel(endpoint.ResourceLabelKey, "service/team-sandbox/sandbox"),
+		endpoint.NewEndpoint("dns.example.org", endpoint.RecordTypeA, "1.2.3.4").WithLabel(endpoint.ResourceLabelKey, "service/kube-system/dns"),
+	}), regexp.MustCompile("^team-"), []string{"team-sandbox"}, recorder)
+	result, err := src.Endpoints(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, result)
+
+	assert.ElementsMatch(t, []decisions.Decision{
+		{Filter: decisions.NamespaceFilter, Resource: "service/team-sandbox/sandbox", Reason: `the namespace "team-sandbox" is excluded`},
+		{Filter: decisions.NamespaceFilter, Resource: "service/kube-system/dns", Reason: `the namespace "kube-system" does not match the namespace regex "^team-"`},
+	}, withoutTime(recorder.Decisions()))
+}
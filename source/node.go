@@ -19,7 +19,11 @@ package source
 import (
 	"context"
 	"fmt"
+	"net"
+	"slices"
+	"strings"
 	"text/template"
+	"time"
 
 	log "github.com/sirupsen/logrus"
 	v1 "k8s.io/api/core/v1"
@@ -30,6 +34,7 @@ import (
 	"k8s.io/client-go/tools/cache"
 
 	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/pkg/decisions"
 	"sigs.k8s.io/external-dns/source/annotations"
 	"sigs.k8s.io/external-dns/source/fqdn"
 	"sigs.k8s.io/external-dns/source/informers"
@@ -37,6 +42,12 @@ import (
 
 const warningMsg = "The default behavior of exposing internal IPv6 addresses will change in the next minor version. Use --no-expose-internal-ipv6 flag to opt-in to the new behavior."
 
+// NodeAddressProviderID is the node address type of the IP addresses found in the provider ID of a node.
+const NodeAddressProviderID v1.NodeAddressType = "ProviderID"
+
+// NodeAddressTypes are the node address types that can be used in an address preference list.
+var NodeAddressTypes = []string{string(v1.NodeExternalIP), string(v1.NodeInternalIP), string(NodeAddressProviderID)}
+
 type nodeSource struct {
 	client                kubernetes.Interface
 	annotationFilter      string
@@ -47,6 +58,9 @@ type nodeSource struct {
 	labelSelector        labels.Selector
 	excludeUnschedulable bool
 	exposeInternalIPv6   bool
+	addressPreference    []v1.NodeAddressType
+	filterDecisions      *decisions.Recorder
+	propagatedLabels     PropagatedLabels
 }
 
 // NewNodeSource creates a new nodeSource with the given config.
@@ -57,7 +71,8 @@ func NewNodeSource(
 	labelSelector labels.Selector,
 	exposeInternalIPv6,
 	excludeUnschedulable bool,
-	combineFQDNAnnotation bool) (Source, error) {
+	combineFQDNAnnotation bool,
+	addressPreference []string, filterDecisions *decisions.Recorder, resyncPeriod time.Duration, prefixAliases annotations.PrefixAliases, propagatedLabels PropagatedLabels) (Source, error) {
 	tmpl, err := fqdn.ParseTemplate(fqdnTemplate)
 	if err != nil {
 		return nil, err
@@ -65,7 +80,7 @@ func NewNodeSource(
 
 	// Use shared informers to listen for add/update/delete of nodes.
 	// Set resync period to 0, to prevent processing when nothing has changed
-	informerFactory := kubeinformers.NewSharedInformerFactoryWithOptions(kubeClient, 0)
+	informerFactory := kubeinformers.NewSharedInformerFactoryWithOptions(kubeClient, resyncPeriod, kubeinformers.WithTweakListOptions(labelFilterListOptions(labelSelector, filterDecisions)))
 	nodeInformer := informerFactory.Core().V1().Nodes()
 
 	// Add default resource event handler to properly initialize informer.
@@ -77,6 +92,8 @@ func NewNodeSource(
 		},
 	)
 
+	aliasAnnotations(prefixAliases, nodeInformer.Informer())
+
 	informerFactory.Start(ctx.Done())
 
 	// wait for the local cache to be populated.
@@ -85,6 +102,7 @@ func NewNodeSource(
 	}
 
 	return &nodeSource{
+		filterDecisions:       filterDecisions,
 		client:                kubeClient,
 		annotationFilter:      annotationFilter,
 		fqdnTemplate:          tmpl,
@@ -93,6 +111,8 @@ func NewNodeSource(
 		labelSelector:         labelSelector,
 		excludeUnschedulable:  excludeUnschedulable,
 		exposeInternalIPv6:    exposeInternalIPv6,
+		addressPreference:     parseNodeAddressPreference(addressPreference),
+		propagatedLabels:      propagatedLabels,
 	}, nil
 }
 
@@ -102,6 +122,11 @@ func (ns *nodeSource) Endpoints(_ context.Context) ([]*endpoint.Endpoint, error)
 	if err != nil {
 		return nil, err
 	}
+	if ns.filterDecisions.Enabled() {
+		if all, err := ns.nodeInformer.Lister().List(labels.Everything()); err == nil {
+			recordExcludedByLabels(ns.filterDecisions, "node", all, ns.labelSelector)
+		}
+	}
 
 	nodes, err = ns.filterByAnnotations(nodes)
 	if err != nil {
@@ -109,6 +134,8 @@ func (ns *nodeSource) Endpoints(_ context.Context) ([]*endpoint.Endpoint, error)
 	}
 
 	endpoints := map[endpoint.EndpointKey]*endpoint.Endpoint{}
+	// the labels of the nodes of each endpoint, whose common labels are propagated
+	nodeLabels := map[endpoint.EndpointKey][]map[string]string{}
 
 	// create endpoints for all nodes
 	for _, node := range nodes {
@@ -160,12 +187,14 @@ func (ns *nodeSource) Endpoints(_ context.Context) ([]*endpoint.Endpoint, error)
 					endpoints[key] = &epCopy
 				}
 				endpoints[key].Targets = append(endpoints[key].Targets, addr)
+				nodeLabels[key] = append(nodeLabels[key], node.Labels)
 			}
 		}
 	}
 
 	endpointsSlice := []*endpoint.Endpoint{}
-	for _, ep := range endpoints {
+	for key, ep := range endpoints {
+		ns.propagatedLabels.propagate([]*endpoint.Endpoint{ep}, commonLabels(nodeLabels[key]))
 		endpointsSlice = append(endpointsSlice, ep)
 	}
 
@@ -178,6 +207,14 @@ func (ns *nodeSource) AddEventHandler(_ context.Context, _ func()) {
 // nodeAddress returns the node's externalIP and if that's not found, the node's internalIP
 // basically what k8s.io/kubernetes/pkg/util/node.GetPreferredNodeAddress does
 func (ns *nodeSource) nodeAddresses(node *v1.Node) ([]string, error) {
+	preference := ns.addressPreference
+	if value, ok := node.Annotations[nodeAddressPreferenceKey]; ok {
+		preference = parseNodeAddressPreference(strings.Split(value, ","))
+	}
+	if len(preference) > 0 {
+		return preferredNodeAddresses(node, preference)
+	}
+
 	addresses := map[v1.NodeAddressType][]string{
 		v1.NodeExternalIP: {},
 		v1.NodeInternalIP: {},
@@ -208,6 +245,67 @@ func (ns *nodeSource) nodeAddresses(node *v1.Node) ([]string, error) {
 	return nil, fmt.Errorf("could not find node address for %s", node.Name)
 }
 
+// preferredNodeAddresses returns, for each IP family, the node's addresses of
+// the first type of the preference list that has addresses of that family.
+func preferredNodeAddresses(node *v1.Node, preference []v1.NodeAddressType) ([]string, error) {
+	addresses := map[v1.NodeAddressType][]string{
+		NodeAddressProviderID: providerIDAddresses(node.Spec.ProviderID),
+	}
+	for _, addr := range node.Status.Addresses {
+		addresses[addr.Type] = append(addresses[addr.Type], addr.Address)
+	}
+
+	var result []string
+	for _, recordType := range []string{endpoint.RecordTypeA, endpoint.RecordTypeAAAA} {
+		for _, addressType := range preference {
+			var found []string
+			for _, addr := range addresses[addressType] {
+				if suitableType(addr) == recordType {
+					found = append(found, addr)
+				}
+			}
+			if len(found) > 0 {
+				result = append(result, found...)
+				break
+			}
+		}
+	}
+
+	if len(result) == 0 {
+		return nil, fmt.Errorf("could not find node address for %s with address preference %v", node.Name, preference)
+	}
+	return result, nil
+}
+
+// providerIDAddresses returns the IP addresses found in the segments of a
+// provider ID, like the ones of bare-metal providers such as metal://10.0.0.1.
+func providerIDAddresses(providerID string) []string {
+	if i := strings.Index(providerID, "://"); i >= 0 {
+		providerID = providerID[i+len("://"):]
+	}
+	var addresses []string
+	for _, segment := range strings.Split(providerID, "/") {
+		if ip := net.ParseIP(segment); ip != nil {
+			addresses = append(addresses, ip.String())
+		}
+	}
+	return addresses
+}
+
+// parseNodeAddressPreference converts a list of address types, ignoring the invalid ones.
+func parseNodeAddressPreference(values []string) []v1.NodeAddressType {
+	var preference []v1.NodeAddressType
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		if !slices.Contains(NodeAddressTypes, value) {
+			log.Warnf("Ignoring invalid node address type %q, expected one of %v", value, NodeAddressTypes)
+			continue
+		}
+		preference = append(preference, v1.NodeAddressType(value))
+	}
+	return preference
+}
+
 // filterByAnnotations filters a list of nodes by a given annotation selector.
 func (ns *nodeSource) filterByAnnotations(nodes []*v1.Node) ([]*v1.Node, error) {
 	selector, err := annotations.ParseFilter(ns.annotationFilter)
@@ -226,6 +324,8 @@ func (ns *nodeSource) filterByAnnotations(nodes []*v1.Node) ([]*v1.Node, error)
 		// include a node if its annotations match the selector
 		if selector.Matches(labels.Set(node.Annotations)) {
 			filteredList = append(filteredList, node)
+		} else {
+			recordExcludedByAnnotations(ns.filterDecisions, "node", node, ns.annotationFilter)
 		}
 	}
 
@@ -65,6 +65,11 @@ func TestNodeSourceNewNodeSourceWithFqdn(t *testing.T) {
 				true,
 				true,
 				false,
+				nil,
+				nil,
+				0,
+				nil,
+				nil,
 			)
 			if tt.expectError {
 				assert.Error(t, err)
@@ -339,6 +344,11 @@ func TestNodeSourceFqdnTemplatingExamples(t *testing.T) {
 				true,
 				true,
 				tt.combineFQDN,
+				nil,
+				nil,
+				0,
+				nil,
+				nil,
 			)
 			require.NoError(t, err)
 
@@ -46,6 +46,7 @@ func TestNodeSource(t *testing.T) {
 	t.Run("NewNodeSource", testNodeSourceNewNodeSource)
 	t.Run("Endpoints", testNodeSourceEndpoints)
 	t.Run("EndpointsIPv6", testNodeEndpointsWithIPv6)
+	t.Run("EndpointsAddressPreference", testNodeEndpointsWithAddressPreference)
 }
 
 // testNodeSourceNewNodeSource tests that NewNodeService doesn't return an error.
@@ -96,6 +97,11 @@ func testNodeSourceNewNodeSource(t *testing.T) {
 				true,
 				true,
 				false,
+				nil,
+				nil,
+				0,
+				nil,
+				nil,
 			)
 
 			if ti.expectError {
@@ -442,6 +448,11 @@ func testNodeSourceEndpoints(t *testing.T) {
 				tc.exposeInternalIPv6,
 				tc.excludeUnschedulable,
 				false,
+				nil,
+				nil,
+				0,
+				nil,
+				nil,
 			)
 			require.NoError(t, err)
 
@@ -560,6 +571,11 @@ func testNodeEndpointsWithIPv6(t *testing.T) {
 			tc.exposeInternalIPv6,
 			tc.excludeUnschedulable,
 			false,
+			nil,
+			nil,
+			0,
+			nil,
+			nil,
 		)
 		require.NoError(t, err)
 
@@ -584,6 +600,118 @@ func testNodeEndpointsWithIPv6(t *testing.T) {
 	}
 }
 
+func testNodeEndpointsWithAddressPreference(t *testing.T) {
+	mixedAddresses := []v1.NodeAddress{
+		{Type: v1.NodeExternalIP, Address: "1.2.3.4"},
+		{Type: v1.NodeInternalIP, Address: "10.0.0.1"},
+		{Type: v1.NodeInternalIP, Address: "2001:db8::1"},
+	}
+
+	for _, tc := range []struct {
+		title         string
+		preference    []string
+		providerID    string
+		nodeAddresses []v1.NodeAddress
+		annotations   map[string]string
+		expected      []*endpoint.Endpoint
+		expectError   bool
+	}{
+		{
+			title:         "external IP is preferred for IPv4 and internal IP is used for IPv6",
+			preference:    []string{"ExternalIP", "InternalIP"},
+			nodeAddresses: mixedAddresses,
+			expected: []*endpoint.Endpoint{
+				{RecordType: "A", DNSName: "node1", Targets: endpoint.Targets{"1.2.3.4"}},
+				{RecordType: "AAAA", DNSName: "node1", Targets: endpoint.Targets{"2001:db8::1"}},
+			},
+		},
+		{
+			title:         "internal IP is preferred",
+			preference:    []string{"InternalIP", "ExternalIP"},
+			nodeAddresses: mixedAddresses,
+			expected: []*endpoint.Endpoint{
+				{RecordType: "A", DNSName: "node1", Targets: endpoint.Targets{"10.0.0.1"}},
+				{RecordType: "AAAA", DNSName: "node1", Targets: endpoint.Targets{"2001:db8::1"}},
+			},
+		},
+		{
+			title:         "only the listed address types are used",
+			preference:    []string{"ExternalIP"},
+			nodeAddresses: mixedAddresses,
+			expected: []*endpoint.Endpoint{
+				{RecordType: "A", DNSName: "node1", Targets: endpoint.Targets{"1.2.3.4"}},
+			},
+		},
+		{
+			title:         "provider ID addresses",
+			preference:    []string{"ProviderID", "InternalIP"},
+			providerID:    "metal://192.0.2.10",
+			nodeAddresses: mixedAddresses,
+			expected: []*endpoint.Endpoint{
+				{RecordType: "A", DNSName: "node1", Targets: endpoint.Targets{"192.0.2.10"}},
+				{RecordType: "AAAA", DNSName: "node1", Targets: endpoint.Targets{"2001:db8::1"}},
+			},
+		},
+		{
+			title:         "provider ID without address falls back to the next type",
+			preference:    []string{"ProviderID", "ExternalIP"},
+			providerID:    "aws:///us-east-1a/i-0123456789",
+			nodeAddresses: mixedAddresses,
+			expected: []*endpoint.Endpoint{
+				{RecordType: "A", DNSName: "node1", Targets: endpoint.Targets{"1.2.3.4"}},
+			},
+		},
+		{
+			title:         "annotation overrides the preference",
+			preference:    []string{"ExternalIP"},
+			nodeAddresses: mixedAddresses,
+			annotations:   map[string]string{nodeAddressPreferenceKey: "InternalIP, ExternalIP"},
+			expected: []*endpoint.Endpoint{
+				{RecordType: "A", DNSName: "node1", Targets: endpoint.Targets{"10.0.0.1"}},
+				{RecordType: "AAAA", DNSName: "node1", Targets: endpoint.Targets{"2001:db8::1"}},
+			},
+		},
+		{
+			title:         "annotation applies without a preference",
+			nodeAddresses: mixedAddresses,
+			annotations:   map[string]string{nodeAddressPreferenceKey: "InternalIP"},
+			expected: []*endpoint.Endpoint{
+				{RecordType: "A", DNSName: "node1", Targets: endpoint.Targets{"10.0.0.1"}},
+				{RecordType: "AAAA", DNSName: "node1", Targets: endpoint.Targets{"2001:db8::1"}},
+			},
+		},
+		{
+			title:         "no address of the preferred types",
+			preference:    []string{"ProviderID"},
+			nodeAddresses: mixedAddresses,
+			expected:      []*endpoint.Endpoint{},
+			expectError:   true,
+		},
+	} {
+		t.Run(tc.title, func(t *testing.T) {
+			kubeClient := fake.NewClientset()
+			node := &v1.Node{
+				ObjectMeta: metav1.ObjectMeta{Name: "node1", Annotations: tc.annotations},
+				Spec:       v1.NodeSpec{ProviderID: tc.providerID},
+				Status:     v1.NodeStatus{Addresses: tc.nodeAddresses},
+			}
+			_, err := kubeClient.CoreV1().Nodes().Create(t.Context(), node, metav1.CreateOptions{})
+			require.NoError(t, err)
+
+			client, err := NewNodeSource(t.Context(), kubeClient, "", "", labels.Everything(), true, false, false, tc.preference, nil, 0, nil, nil)
+			require.NoError(t, err)
+
+			endpoints, err := client.Endpoints(t.Context())
+			if tc.expectError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			validateEndpoints(t, endpoints, tc.expected)
+		})
+	}
+}
+
 func TestResourceLabelIsSetForEachNodeEndpoint(t *testing.T) {
 	kubeClient := fake.NewClientset()
 
@@ -608,6 +736,11 @@ func TestResourceLabelIsSetForEachNodeEndpoint(t *testing.T) {
 		false,
 		true,
 		false,
+		nil,
+		nil,
+		0,
+		nil,
+		nil,
 	)
 	require.NoError(t, err)
 
@@ -33,6 +33,7 @@ import (
 	"k8s.io/client-go/tools/cache"
 
 	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/pkg/decisions"
 	"sigs.k8s.io/external-dns/source/annotations"
 	"sigs.k8s.io/external-dns/source/fqdn"
 	"sigs.k8s.io/external-dns/source/informers"
@@ -53,6 +54,8 @@ type ocpRouteSource struct {
 	routeInformer            routeInformer.RouteInformer
 	labelSelector            labels.Selector
 	ocpRouterName            string
+	filterDecisions          *decisions.Recorder
+	propagatedLabels         PropagatedLabels
 }
 
 // NewOcpRouteSource creates a new ocpRouteSource with the given config.
@@ -66,6 +69,10 @@ func NewOcpRouteSource(
 	ignoreHostnameAnnotation bool,
 	labelSelector labels.Selector,
 	ocpRouterName string,
+	filterDecisions *decisions.Recorder,
+	resyncPeriod time.Duration,
+	prefixAliases annotations.PrefixAliases,
+	propagatedLabels PropagatedLabels,
 ) (Source, error) {
 	tmpl, err := fqdn.ParseTemplate(fqdnTemplate)
 	if err != nil {
@@ -73,8 +80,7 @@ func NewOcpRouteSource(
 	}
 
 	// Use a shared informer to listen for add/update/delete of Routes in the specified namespace.
-	// Set resync period to 0, to prevent processing when nothing has changed.
-	informerFactory := extInformers.NewFilteredSharedInformerFactory(ocpClient, 0*time.Second, namespace, nil)
+	informerFactory := extInformers.NewFilteredSharedInformerFactory(ocpClient, resyncPeriod, namespace, labelFilterListOptions(labelSelector, filterDecisions))
 	informer := informerFactory.Route().V1().Routes()
 
 	// Add default resource event handlers to properly initialize informer.
@@ -85,6 +91,8 @@ func NewOcpRouteSource(
 		},
 	)
 
+	aliasAnnotations(prefixAliases, informer.Informer())
+
 	informerFactory.Start(ctx.Done())
 
 	// wait for the local cache to be populated.
@@ -93,6 +101,7 @@ func NewOcpRouteSource(
 	}
 
 	return &ocpRouteSource{
+		filterDecisions:          filterDecisions,
 		client:                   ocpClient,
 		namespace:                namespace,
 		annotationFilter:         annotationFilter,
@@ -102,6 +111,7 @@ func NewOcpRouteSource(
 		routeInformer:            informer,
 		labelSelector:            labelSelector,
 		ocpRouterName:            ocpRouterName,
+		propagatedLabels:         propagatedLabels,
 	}, nil
 }
 
@@ -121,6 +131,11 @@ func (ors *ocpRouteSource) Endpoints(ctx context.Context) ([]*endpoint.Endpoint,
 	if err != nil {
 		return nil, err
 	}
+	if ors.filterDecisions.Enabled() {
+		if all, err := ors.routeInformer.Lister().Routes(ors.namespace).List(labels.Everything()); err == nil {
+			recordExcludedByLabels(ors.filterDecisions, "route", all, ors.labelSelector)
+		}
+	}
 
 	ocpRoutes, err = ors.filterByAnnotations(ocpRoutes)
 	if err != nil {
@@ -159,6 +174,7 @@ func (ors *ocpRouteSource) Endpoints(ctx context.Context) ([]*endpoint.Endpoint,
 			continue
 		}
 
+		ors.propagatedLabels.propagate(orEndpoints, ocpRoute.Labels)
 		log.Debugf("Endpoints generated from OpenShift Route: %s/%s: %v", ocpRoute.Namespace, ocpRoute.Name, orEndpoints)
 		endpoints = append(endpoints, orEndpoints...)
 	}
@@ -212,6 +228,8 @@ func (ors *ocpRouteSource) filterByAnnotations(ocpRoutes []*routev1.Route) ([]*r
 		// include ocpRoute if its annotations match the selector
 		if selector.Matches(labels.Set(ocpRoute.Annotations)) {
 			filteredList = append(filteredList, ocpRoute)
+		} else {
+			recordExcludedByAnnotations(ors.filterDecisions, "route", ocpRoute, ors.annotationFilter)
 		}
 	}
 
@@ -241,9 +259,8 @@ func (ors *ocpRouteSource) endpointsFromOcpRoute(ocpRoute *routev1.Route, ignore
 
 	// Skip endpoints if we do not want entries from annotations
 	if !ignoreHostnameAnnotation {
-		hostnameList := annotations.HostnamesFromAnnotations(ocpRoute.Annotations)
-		for _, hostname := range hostnameList {
-			endpoints = append(endpoints, endpointsForHostname(hostname, targets, ttl, providerSpecific, setIdentifier, resource)...)
+		for _, entry := range annotations.HostnameEntriesFromAnnotations(ocpRoute.Annotations, resource) {
+			endpoints = append(endpoints, endpointsForHostnameEntry(entry, targets, ttl, providerSpecific, setIdentifier, resource)...)
 		}
 	}
 	return endpoints
@@ -53,6 +53,10 @@ func (suite *OCPRouteSuite) SetupTest() {
 		false,
 		labels.Everything(),
 		"",
+		nil,
+		0,
+		nil,
+		nil,
 	)
 
 	suite.routeWithTargets = &routev1.Route{
@@ -152,6 +156,10 @@ func testOcpRouteSourceNewOcpRouteSource(t *testing.T) {
 				false,
 				labelSelector,
 				"",
+				nil,
+				0,
+				nil,
+				nil,
 			)
 
 			if ti.expectError {
@@ -537,6 +545,10 @@ func testOcpRouteSourceEndpoints(t *testing.T) {
 				false,
 				labelSelector,
 				tc.ocpRouterName,
+				nil,
+				0,
+				nil,
+				nil,
 			)
 			require.NoError(t, err)
 
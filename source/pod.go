@@ -20,10 +20,14 @@ import (
 	"context"
 	"fmt"
 	"maps"
+	"strings"
 	"text/template"
+	"time"
 
 	log "github.com/sirupsen/logrus"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	kubeinformers "k8s.io/client-go/informers"
 	coreinformers "k8s.io/client-go/informers/core/v1"
@@ -33,6 +37,7 @@ import (
 	"sigs.k8s.io/external-dns/source/fqdn"
 
 	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/pkg/decisions"
 	"sigs.k8s.io/external-dns/source/annotations"
 	"sigs.k8s.io/external-dns/source/informers"
 )
@@ -40,6 +45,7 @@ import (
 type podSource struct {
 	client                kubernetes.Interface
 	namespace             string
+	labelSelector         labels.Selector
 	fqdnTemplate          *template.Template
 	combineFQDNAnnotation bool
 
@@ -48,6 +54,8 @@ type podSource struct {
 	compatibility            string
 	ignoreNonHostNetworkPods bool
 	podSourceDomain          string
+	filterDecisions          *decisions.Recorder
+	propagatedLabels         PropagatedLabels
 }
 
 // NewPodSource creates a new podSource with the given config.
@@ -60,10 +68,21 @@ func NewPodSource(
 	podSourceDomain string,
 	fqdnTemplate string,
 	combineFqdnAnnotation bool,
+	labelSelector labels.Selector,
+	filterDecisions *decisions.Recorder,
+	resyncPeriod time.Duration,
+	prefixAliases annotations.PrefixAliases,
+	propagatedLabels PropagatedLabels,
 ) (Source, error) {
-	informerFactory := kubeinformers.NewSharedInformerFactoryWithOptions(kubeClient, 0, kubeinformers.WithNamespace(namespace))
+	if labelSelector == nil {
+		labelSelector = labels.Everything()
+	}
+
+	informerFactory := kubeinformers.NewSharedInformerFactoryWithOptions(kubeClient, resyncPeriod, kubeinformers.WithNamespace(namespace), kubeinformers.WithTweakListOptions(labelFilterListOptions(labelSelector, filterDecisions)))
 	podInformer := informerFactory.Core().V1().Pods()
-	nodeInformer := informerFactory.Core().V1().Nodes()
+	// The label filter doesn't apply to the nodes, so they have their own factory.
+	nodeInformerFactory := kubeinformers.NewSharedInformerFactoryWithOptions(kubeClient, resyncPeriod)
+	nodeInformer := nodeInformerFactory.Core().V1().Nodes()
 
 	_, _ = podInformer.Informer().AddEventHandler(
 		cache.ResourceEventHandlerFuncs{
@@ -78,12 +97,18 @@ func NewPodSource(
 		},
 	)
 
+	aliasAnnotations(prefixAliases, podInformer.Informer(), nodeInformer.Informer())
+
 	informerFactory.Start(ctx.Done())
+	nodeInformerFactory.Start(ctx.Done())
 
 	// wait for the local cache to be populated.
 	if err := informers.WaitForCacheSync(context.Background(), informerFactory); err != nil {
 		return nil, err
 	}
+	if err := informers.WaitForCacheSync(context.Background(), nodeInformerFactory); err != nil {
+		return nil, err
+	}
 
 	tmpl, err := fqdn.ParseTemplate(fqdnTemplate)
 	if err != nil {
@@ -91,6 +116,7 @@ func NewPodSource(
 	}
 
 	return &podSource{
+		filterDecisions:          filterDecisions,
 		client:                   kubeClient,
 		podInformer:              podInformer,
 		nodeInformer:             nodeInformer,
@@ -100,22 +126,53 @@ func NewPodSource(
 		podSourceDomain:          podSourceDomain,
 		fqdnTemplate:             tmpl,
 		combineFQDNAnnotation:    combineFqdnAnnotation,
+		labelSelector:            labelSelector,
+		propagatedLabels:         propagatedLabels,
 	}, nil
 }
 
-func (*podSource) AddEventHandler(_ context.Context, _ func()) {
+func (ps *podSource) AddEventHandler(_ context.Context, handler func()) {
+	log.Debug("Adding event handler for pod")
+
+	// Pods going in and out of readiness add and remove the records of the
+	// pod-hostname-template annotation, the other pods are only read at each sync.
+	_, _ = ps.podInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+		FilterFunc: hasHostnameTemplateAnnotation,
+		Handler:    eventHandlerFunc(handler),
+	})
+}
+
+// hasHostnameTemplateAnnotation returns whether the object is a pod with the pod-hostname-template annotation.
+func hasHostnameTemplateAnnotation(obj interface{}) bool {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	pod, ok := obj.(*corev1.Pod)
+	return ok && pod.Annotations[podHostnameTemplateKey] != ""
 }
 
 func (ps *podSource) Endpoints(_ context.Context) ([]*endpoint.Endpoint, error) {
-	pods, err := ps.podInformer.Lister().Pods(ps.namespace).List(labels.Everything())
+	pods, err := ps.podInformer.Lister().Pods(ps.namespace).List(ps.labelSelector)
 	if err != nil {
 		return nil, err
 	}
+	if ps.filterDecisions.Enabled() {
+		if all, err := ps.podInformer.Lister().Pods(ps.namespace).List(labels.Everything()); err == nil {
+			recordExcludedByLabels(ps.filterDecisions, "pod", all, ps.labelSelector)
+		}
+	}
 
 	endpointMap := make(map[endpoint.EndpointKey][]string)
+	// the labels of the pods of each endpoint, whose common labels are propagated
+	podLabels := make(map[endpoint.EndpointKey][]map[string]string)
 	for _, pod := range pods {
 		if ps.fqdnTemplate == nil || ps.combineFQDNAnnotation {
-			ps.addPodEndpointsToEndpointMap(endpointMap, pod)
+			podEndpoints := make(map[endpoint.EndpointKey][]string)
+			ps.addPodEndpointsToEndpointMap(podEndpoints, pod)
+			for key, targets := range podEndpoints {
+				endpointMap[key] = append(endpointMap[key], targets...)
+				podLabels[key] = append(podLabels[key], pod.Labels)
+			}
 		}
 
 		if ps.fqdnTemplate != nil {
@@ -124,12 +181,17 @@ func (ps *podSource) Endpoints(_ context.Context) ([]*endpoint.Endpoint, error)
 				return nil, err
 			}
 			maps.Copy(endpointMap, fqdnHosts)
+			for key := range fqdnHosts {
+				podLabels[key] = []map[string]string{pod.Labels}
+			}
 		}
 	}
 
 	var endpoints []*endpoint.Endpoint
 	for key, targets := range endpointMap {
-		endpoints = append(endpoints, endpoint.NewEndpointWithTTL(key.DNSName, key.RecordType, key.RecordTTL, targets...))
+		ep := endpoint.NewEndpointWithTTL(key.DNSName, key.RecordType, key.RecordTTL, targets...)
+		ps.propagatedLabels.propagate([]*endpoint.Endpoint{ep}, commonLabels(podLabels[key]))
+		endpoints = append(endpoints, ep)
 	}
 	return endpoints, nil
 }
@@ -146,6 +208,7 @@ func (ps *podSource) addPodEndpointsToEndpointMap(endpointMap map[endpoint.Endpo
 	ps.addHostnameAnnotationEndpoints(endpointMap, pod, targets)
 	ps.addKopsDNSControllerEndpoints(endpointMap, pod, targets)
 	ps.addPodSourceDomainEndpoints(endpointMap, pod, targets)
+	ps.addHostnameTemplateAnnotationEndpoints(endpointMap, pod, targets)
 }
 
 func (ps *podSource) addInternalHostnameAnnotationEndpoints(endpointMap map[endpoint.EndpointKey][]string, pod *corev1.Pod, targets []string) {
@@ -162,8 +225,8 @@ func (ps *podSource) addInternalHostnameAnnotationEndpoints(endpointMap map[endp
 }
 
 func (ps *podSource) addHostnameAnnotationEndpoints(endpointMap map[endpoint.EndpointKey][]string, pod *corev1.Pod, targets []string) {
-	if domainAnnotation, ok := pod.Annotations[hostnameAnnotationKey]; ok {
-		domainList := annotations.SplitHostnameAnnotation(domainAnnotation)
+	if _, ok := pod.Annotations[hostnameAnnotationKey]; ok {
+		domainList := podHostnames(pod)
 		if len(targets) == 0 {
 			ps.addPodNodeEndpointsToEndpointMap(endpointMap, pod, domainList)
 		} else {
@@ -172,6 +235,21 @@ func (ps *podSource) addHostnameAnnotationEndpoints(endpointMap map[endpoint.End
 	}
 }
 
+// podHostnames returns the hostnames of the hostname annotation of a pod. The
+// entries of the structured form of the annotation setting the targets, TTL or
+// record type of their hostname are not supported by the pod source and skipped.
+func podHostnames(pod *corev1.Pod) []string {
+	var hostnames []string
+	for _, entry := range annotations.HostnameEntriesFromAnnotations(pod.Annotations, fmt.Sprintf("pod/%s/%s", pod.Namespace, pod.Name)) {
+		if len(entry.Targets) > 0 || entry.TTL.IsConfigured() || entry.RecordType != "" {
+			log.Warnf("pod/%s/%s: ignoring hostname annotation entry %q, the pod source doesn't support setting its targets, TTL or record type", pod.Namespace, pod.Name, entry.Hostname)
+			continue
+		}
+		hostnames = append(hostnames, entry.Hostname)
+	}
+	return hostnames
+}
+
 func (ps *podSource) addKopsDNSControllerEndpoints(endpointMap map[endpoint.EndpointKey][]string, pod *corev1.Pod, targets []string) {
 	if ps.compatibility == "kops-dns-controller" {
 		if domainAnnotation, ok := pod.Annotations[kopsDNSControllerInternalHostnameAnnotationKey]; ok {
@@ -198,6 +276,71 @@ func (ps *podSource) addPodSourceDomainEndpoints(endpointMap map[endpoint.Endpoi
 	}
 }
 
+// podHostnameTemplateData is the data the pod-hostname-template annotation is executed with.
+type podHostnameTemplateData struct {
+	*corev1.Pod
+	// Ordinal is the ordinal of the pod in its StatefulSet, empty for other pods.
+	Ordinal string
+	// NodeName is the name of the node the pod runs on.
+	NodeName string
+}
+
+// addHostnameTemplateAnnotationEndpoints adds the records of the hostnames
+// generated for the pod by its pod-hostname-template annotation. Pods that are
+// not ready or are terminating have no records, so the records of a pod are
+// removed when it is evicted. hostNetwork pods use the addresses of their node.
+func (ps *podSource) addHostnameTemplateAnnotationEndpoints(endpointMap map[endpoint.EndpointKey][]string, pod *corev1.Pod, targets []string) {
+	templateAnnotation := pod.Annotations[podHostnameTemplateKey]
+	if templateAnnotation == "" {
+		return
+	}
+	if pod.DeletionTimestamp != nil || !isPodStatusReady(pod.Status) {
+		log.Debugf("skipping pod %s. not ready", pod.Name)
+		return
+	}
+
+	tmpl, err := fqdn.ParseTemplate(templateAnnotation)
+	if err != nil {
+		log.Warnf("Ignoring invalid hostname template %q of pod %s/%s: %v", templateAnnotation, pod.Namespace, pod.Name, err)
+		return
+	}
+	domainList, err := fqdn.ExecTemplate(tmpl, podHostnameTemplateData{
+		Pod:      pod,
+		Ordinal:  podOrdinal(pod),
+		NodeName: pod.Spec.NodeName,
+	})
+	if err != nil {
+		log.Warnf("Ignoring hostname template of pod %s/%s: %v", pod.Namespace, pod.Name, err)
+		return
+	}
+
+	switch {
+	case len(targets) > 0:
+		addTargetsToEndpointMap(endpointMap, pod, targets, domainList...)
+	case pod.Spec.HostNetwork:
+		ps.addPodNodeEndpointsToEndpointMap(endpointMap, pod, domainList)
+	default:
+		for _, domain := range domainList {
+			for _, address := range pod.Status.PodIPs {
+				addToEndpointMap(endpointMap, pod, domain, suitableType(address.IP), address.IP)
+			}
+		}
+	}
+}
+
+// podOrdinal returns the ordinal of a pod of a StatefulSet, from its pod-index
+// label or else from the suffix of its name.
+func podOrdinal(pod *corev1.Pod) string {
+	if ordinal, ok := pod.Labels[appsv1.PodIndexLabel]; ok {
+		return ordinal
+	}
+	owner := metav1.GetControllerOf(pod)
+	if owner == nil || owner.Kind != "StatefulSet" {
+		return ""
+	}
+	return pod.Name[strings.LastIndex(pod.Name, "-")+1:]
+}
+
 func (ps *podSource) addPodNodeEndpointsToEndpointMap(endpointMap map[endpoint.EndpointKey][]string, pod *corev1.Pod, domainList []string) {
 	node, err := ps.nodeInformer.Lister().Get(pod.Spec.NodeName)
 	if err != nil {
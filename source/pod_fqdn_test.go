@@ -23,6 +23,7 @@ import (
 	"github.com/stretchr/testify/require"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/client-go/kubernetes/fake"
 	"sigs.k8s.io/external-dns/endpoint"
 )
@@ -58,7 +59,8 @@ func TestNewPodSourceWithFqdn(t *testing.T) {
 				false,
 				"",
 				tt.fqdnTemplate,
-				false)
+				false,
+				labels.Everything(), nil, 0, nil, nil)
 
 			if tt.expectError {
 				assert.Error(t, err)
@@ -405,7 +407,8 @@ func TestPodSourceFqdnTemplatingExamples(t *testing.T) {
 				false,
 				tt.sourceDomain,
 				tt.fqdnTemplate,
-				tt.combineFQDN)
+				tt.combineFQDN,
+				labels.Everything(), nil, 0, nil, nil)
 			require.NoError(t, err)
 
 			endpoints, err := src.Endpoints(t.Context())
@@ -467,7 +470,8 @@ func TestPodSourceFqdnTemplatingExamples_Failed(t *testing.T) {
 				false,
 				tt.sourceDomain,
 				tt.fqdnTemplate,
-				tt.combineFQDN)
+				tt.combineFQDN,
+				labels.Everything(), nil, 0, nil, nil)
 			require.NoError(t, err)
 
 			_, err = src.Endpoints(t.Context())
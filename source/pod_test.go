@@ -23,14 +23,18 @@ import (
 	"testing"
 
 	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 
 	"sigs.k8s.io/external-dns/endpoint"
 	"sigs.k8s.io/external-dns/internal/testutils"
 
 	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/cache"
 )
 
 // testPodSource tests that various services generate the correct endpoints.
@@ -657,7 +661,7 @@ func TestPodSource(t *testing.T) {
 				}
 			}
 
-			client, err := NewPodSource(ctx, kubernetes, tc.targetNamespace, tc.compatibility, tc.ignoreNonHostNetworkPods, tc.PodSourceDomain, "", false)
+			client, err := NewPodSource(ctx, kubernetes, tc.targetNamespace, tc.compatibility, tc.ignoreNonHostNetworkPods, tc.PodSourceDomain, "", false, labels.Everything(), nil, 0, nil, nil)
 			require.NoError(t, err)
 
 			endpoints, err := client.Endpoints(ctx)
@@ -885,7 +889,7 @@ func TestPodSourceLogs(t *testing.T) {
 				}
 			}
 
-			client, err := NewPodSource(ctx, kubernetes, "", "", tc.ignoreNonHostNetworkPods, "", "", false)
+			client, err := NewPodSource(ctx, kubernetes, "", "", tc.ignoreNonHostNetworkPods, "", "", false, labels.Everything(), nil, 0, nil, nil)
 			require.NoError(t, err)
 
 			hook := testutils.LogsUnderTestWithLogLevel(log.DebugLevel, t)
@@ -909,6 +913,221 @@ func TestPodSourceLogs(t *testing.T) {
 	}
 }
 
+func TestPodSourceHostnameTemplate(t *testing.T) {
+	t.Parallel()
+
+	ready := []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}}
+	notReady := []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionFalse}}
+	controller := true
+	statefulSetOwner := []metav1.OwnerReference{{APIVersion: "apps/v1", Kind: "StatefulSet", Name: "db", Controller: &controller}}
+
+	for _, tc := range []struct {
+		title    string
+		pod      *corev1.Pod
+		expected []*endpoint.Endpoint
+	}{
+		{
+			title: "statefulset ordinal from the pod index label",
+			pod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "db-0",
+					Namespace:   "default",
+					Labels:      map[string]string{"apps.kubernetes.io/pod-index": "0"},
+					Annotations: map[string]string{podHostnameTemplateKey: "db-{{ .Ordinal }}.example.org"},
+				},
+				Spec:   corev1.PodSpec{HostNetwork: true, NodeName: "my-node1"},
+				Status: corev1.PodStatus{Conditions: ready},
+			},
+			expected: []*endpoint.Endpoint{
+				{DNSName: "db-0.example.org", Targets: endpoint.Targets{"54.10.11.1"}, RecordType: endpoint.RecordTypeA},
+			},
+		},
+		{
+			title: "statefulset ordinal from the pod name and node name",
+			pod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:            "db-1",
+					Namespace:       "default",
+					OwnerReferences: statefulSetOwner,
+					Annotations:     map[string]string{podHostnameTemplateKey: "member{{ .Ordinal }}.{{ .NodeName }}.example.org,{{ .Name }}.{{ .Namespace }}.example.org"},
+				},
+				Spec:   corev1.PodSpec{HostNetwork: true, NodeName: "my-node2"},
+				Status: corev1.PodStatus{Conditions: ready},
+			},
+			expected: []*endpoint.Endpoint{
+				{DNSName: "member1.my-node2.example.org", Targets: endpoint.Targets{"54.10.11.2"}, RecordType: endpoint.RecordTypeA},
+				{DNSName: "db-1.default.example.org", Targets: endpoint.Targets{"54.10.11.2"}, RecordType: endpoint.RecordTypeA},
+			},
+		},
+		{
+			title: "pod network uses the pod IPs",
+			pod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "web",
+					Namespace:   "default",
+					Annotations: map[string]string{podHostnameTemplateKey: "{{ .Name }}.example.org"},
+				},
+				Spec:   corev1.PodSpec{NodeName: "my-node1"},
+				Status: corev1.PodStatus{Conditions: ready, PodIPs: []corev1.PodIP{{IP: "10.244.0.1"}, {IP: "2001:db8::1"}}},
+			},
+			expected: []*endpoint.Endpoint{
+				{DNSName: "web.example.org", Targets: endpoint.Targets{"10.244.0.1"}, RecordType: endpoint.RecordTypeA},
+				{DNSName: "web.example.org", Targets: endpoint.Targets{"2001:db8::1"}, RecordType: endpoint.RecordTypeAAAA},
+			},
+		},
+		{
+			title: "target annotation",
+			pod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "db-0",
+					Namespace: "default",
+					Annotations: map[string]string{
+						podHostnameTemplateKey: "{{ .Name }}.example.org",
+						targetAnnotationKey:    "203.0.113.1",
+					},
+				},
+				Spec:   corev1.PodSpec{HostNetwork: true, NodeName: "my-node1"},
+				Status: corev1.PodStatus{Conditions: ready},
+			},
+			expected: []*endpoint.Endpoint{
+				{DNSName: "db-0.example.org", Targets: endpoint.Targets{"203.0.113.1"}, RecordType: endpoint.RecordTypeA},
+			},
+		},
+		{
+			title: "pod not ready",
+			pod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "db-0",
+					Namespace:   "default",
+					Annotations: map[string]string{podHostnameTemplateKey: "{{ .Name }}.example.org"},
+				},
+				Spec:   corev1.PodSpec{HostNetwork: true, NodeName: "my-node1"},
+				Status: corev1.PodStatus{Conditions: notReady},
+			},
+			expected: []*endpoint.Endpoint{},
+		},
+		{
+			title: "evicted pod",
+			pod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "db-0",
+					Namespace:   "default",
+					Annotations: map[string]string{podHostnameTemplateKey: "{{ .Name }}.example.org"},
+				},
+				Spec:   corev1.PodSpec{HostNetwork: true, NodeName: "my-node1"},
+				Status: corev1.PodStatus{Phase: corev1.PodFailed, Reason: "Evicted", Conditions: notReady},
+			},
+			expected: []*endpoint.Endpoint{},
+		},
+		{
+			title: "terminating pod",
+			pod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              "db-0",
+					Namespace:         "default",
+					DeletionTimestamp: &metav1.Time{},
+					Finalizers:        []string{"example.org/finalizer"},
+					Annotations:       map[string]string{podHostnameTemplateKey: "{{ .Name }}.example.org"},
+				},
+				Spec:   corev1.PodSpec{HostNetwork: true, NodeName: "my-node1"},
+				Status: corev1.PodStatus{Conditions: ready},
+			},
+			expected: []*endpoint.Endpoint{},
+		},
+		{
+			title: "invalid template",
+			pod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "db-0",
+					Namespace:   "default",
+					Annotations: map[string]string{podHostnameTemplateKey: "{{ .Name"},
+				},
+				Spec:   corev1.PodSpec{HostNetwork: true, NodeName: "my-node1"},
+				Status: corev1.PodStatus{Conditions: ready},
+			},
+			expected: []*endpoint.Endpoint{},
+		},
+	} {
+		t.Run(tc.title, func(t *testing.T) {
+			kubernetes := fake.NewClientset()
+			ctx := t.Context()
+
+			for _, node := range nodesFixturesIPv4() {
+				_, err := kubernetes.CoreV1().Nodes().Create(ctx, node, metav1.CreateOptions{})
+				require.NoError(t, err)
+			}
+			_, err := kubernetes.CoreV1().Pods(tc.pod.Namespace).Create(ctx, tc.pod, metav1.CreateOptions{})
+			require.NoError(t, err)
+
+			client, err := NewPodSource(ctx, kubernetes, "", "", false, "", "", false, labels.Everything(), nil, 0, nil, nil)
+			require.NoError(t, err)
+
+			endpoints, err := client.Endpoints(ctx)
+			require.NoError(t, err)
+			validateEndpoints(t, endpoints, tc.expected)
+		})
+	}
+}
+
+func TestHasHostnameTemplateAnnotation(t *testing.T) {
+	annotated := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{podHostnameTemplateKey: "{{ .Name }}.example.org"}}}
+	assert.True(t, hasHostnameTemplateAnnotation(annotated))
+	assert.True(t, hasHostnameTemplateAnnotation(cache.DeletedFinalStateUnknown{Obj: annotated}))
+	assert.False(t, hasHostnameTemplateAnnotation(&corev1.Pod{}))
+	assert.False(t, hasHostnameTemplateAnnotation(&corev1.Node{}))
+}
+
+func TestPodSourceLabelFilter(t *testing.T) {
+	kubernetes := fake.NewClientset()
+	ctx := t.Context()
+
+	for _, node := range nodesFixturesIPv4() {
+		_, err := kubernetes.CoreV1().Nodes().Create(ctx, node, metav1.CreateOptions{})
+		require.NoError(t, err)
+	}
+	for _, pod := range []*corev1.Pod{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "web",
+				Namespace:   "default",
+				Labels:      map[string]string{"app": "web"},
+				Annotations: map[string]string{internalHostnameAnnotationKey: "web.internal.example.org"},
+			},
+			Status: corev1.PodStatus{PodIP: "10.0.1.1"},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "api",
+				Namespace:   "default",
+				Labels:      map[string]string{"app": "api"},
+				Annotations: map[string]string{internalHostnameAnnotationKey: "api.internal.example.org"},
+			},
+			Status: corev1.PodStatus{PodIP: "10.0.1.2"},
+		},
+	} {
+		_, err := kubernetes.CoreV1().Pods(pod.Namespace).Create(ctx, pod, metav1.CreateOptions{})
+		require.NoError(t, err)
+	}
+
+	client, err := NewPodSource(ctx, kubernetes, "", "", false, "", "", false, labels.SelectorFromSet(labels.Set{"app": "web"}), nil, 0, nil, nil)
+	require.NoError(t, err)
+
+	endpoints, err := client.Endpoints(ctx)
+	require.NoError(t, err)
+	validateEndpoints(t, endpoints, []*endpoint.Endpoint{
+		endpoint.NewEndpoint("web.internal.example.org", endpoint.RecordTypeA, "10.0.1.1"),
+	})
+
+	// the label filter is pushed down to the pod informer, not to the node one
+	selectors := map[string]string{}
+	for _, action := range kubernetes.Actions() {
+		if list, ok := action.(k8stesting.ListAction); ok {
+			selectors[list.GetResource().Resource] = list.GetListRestrictions().Labels.String()
+		}
+	}
+	assert.Equal(t, map[string]string{"pods": "app=web", "nodes": ""}, selectors)
+}
+
 func nodesFixturesIPv6() []*corev1.Node {
 	return []*corev1.Node{
 		{
@@ -960,3 +1179,19 @@ func nodesFixturesIPv4() []*corev1.Node {
 		},
 	}
 }
+
+func TestPodHostnames(t *testing.T) {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-pod",
+			Namespace: "default",
+			Annotations: map[string]string{
+				hostnameAnnotationKey: `[{"hostname": "a.example.org"}, {"hostname": "b.example.org", "ttl": 60}]`,
+			},
+		},
+	}
+	assert.Equal(t, []string{"a.example.org"}, podHostnames(pod))
+
+	pod.Annotations[hostnameAnnotationKey] = "a.example.org, b.example.org"
+	assert.Equal(t, []string{"a.example.org", "b.example.org"}, podHostnames(pod))
+}
@@ -0,0 +1,75 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"maps"
+	"strings"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+// PropagatedLabels are the names of the Kubernetes labels copied from the resources to the labels
+// of their endpoints, e.g. team or cost-center, so that they're recorded by the registry along
+// with the owner and the resource.
+type PropagatedLabels []string
+
+// NewPropagatedLabels returns the propagated labels of the given names, trimmed and without the
+// empty ones.
+func NewPropagatedLabels(names []string) PropagatedLabels {
+	labels := make(PropagatedLabels, 0, len(names))
+	for _, name := range names {
+		if name = strings.TrimSpace(name); name != "" {
+			labels = append(labels, name)
+		}
+	}
+	return labels
+}
+
+// propagate copies the propagated labels of a resource to the labels of its endpoints.
+func (p PropagatedLabels) propagate(endpoints []*endpoint.Endpoint, labels map[string]string) {
+	for _, name := range p {
+		value, ok := labels[name]
+		if !ok || value == "" {
+			continue
+		}
+		for _, ep := range endpoints {
+			if ep.Labels == nil {
+				ep.Labels = endpoint.NewLabels()
+			}
+			ep.Labels[endpoint.KubernetesLabelPrefix+name] = value
+		}
+	}
+}
+
+// commonLabels returns the labels having the same value on all the resources, which are propagated
+// to the endpoints aggregating the targets of several resources, e.g. the pods or nodes sharing a
+// hostname.
+func commonLabels(resourceLabels []map[string]string) map[string]string {
+	if len(resourceLabels) == 0 {
+		return nil
+	}
+	common := maps.Clone(resourceLabels[0])
+	for _, labels := range resourceLabels[1:] {
+		for name, value := range common {
+			if v, ok := labels[name]; !ok || v != value {
+				delete(common, name)
+			}
+		}
+	}
+	return common
+}
@@ -0,0 +1,180 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	fakeDynamic "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+func TestPropagateLabels(t *testing.T) {
+	propagatedLabels := NewPropagatedLabels([]string{"cost-center", " example.com/billing-team ", ""})
+	assert.Equal(t, PropagatedLabels{"cost-center", "example.com/billing-team"}, propagatedLabels)
+
+	endpoints := []*endpoint.Endpoint{
+		endpoint.NewEndpoint("web.example.org", endpoint.RecordTypeA, "1.2.3.4"),
+		{DNSName: "api.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"1.2.3.4"}},
+	}
+	propagatedLabels.propagate(endpoints, map[string]string{
+		"cost-center":              "cc-1234",
+		"example.com/billing-team": "payments",
+		"app":                      "web",
+		"tier":                     "",
+	})
+
+	for _, ep := range endpoints {
+		assert.Equal(t, map[string]string{"cost-center": "cc-1234", "example.com/billing-team": "payments"}, ep.Labels.KubernetesLabels())
+	}
+
+	// nothing is propagated by default
+	ep := endpoint.NewEndpoint("web.example.org", endpoint.RecordTypeA, "1.2.3.4")
+	PropagatedLabels(nil).propagate([]*endpoint.Endpoint{ep}, map[string]string{"cost-center": "cc-1234"})
+	assert.Empty(t, ep.Labels.KubernetesLabels())
+}
+
+func TestCRDSourcePropagatesLabels(t *testing.T) {
+	restClient := fakeRESTClient([]*endpoint.Endpoint{
+		endpoint.NewEndpoint("web.example.org", endpoint.RecordTypeA, "1.2.3.4"),
+	}, "test.k8s.io/v1alpha1", "DNSEndpoint", "foo", "test", nil, map[string]string{"cost-center": "cc-1234"}, t)
+	scheme := runtime.NewScheme()
+	require.NoError(t, addKnownTypes(scheme, schema.GroupVersion{Group: "test.k8s.io", Version: "v1alpha1"}))
+
+	cs, err := NewCRDSource(restClient, "foo", "DNSEndpoint", "", labels.Everything(), scheme, false, nil, 0, nil, PropagatedLabels{"cost-center"})
+	require.NoError(t, err)
+
+	endpoints, err := cs.Endpoints(t.Context())
+	require.NoError(t, err)
+	require.Len(t, endpoints, 1)
+	assert.Equal(t, "cc-1234", endpoints[0].Labels[endpoint.KubernetesLabelPrefix+"cost-center"])
+}
+
+func TestCommonLabels(t *testing.T) {
+	for _, tc := range []struct {
+		title    string
+		labels   []map[string]string
+		expected map[string]string
+	}{
+		{
+			title: "no resources",
+		},
+		{
+			title:    "single resource",
+			labels:   []map[string]string{{"cost-center": "cc-1234", "app": "web"}},
+			expected: map[string]string{"cost-center": "cc-1234", "app": "web"},
+		},
+		{
+			title: "only the labels with the same value are kept",
+			labels: []map[string]string{
+				{"cost-center": "cc-1234", "app": "web", "tier": ""},
+				{"cost-center": "cc-1234", "app": "api"},
+			},
+			expected: map[string]string{"cost-center": "cc-1234"},
+		},
+	} {
+		t.Run(tc.title, func(t *testing.T) {
+			common := commonLabels(tc.labels)
+			if tc.expected == nil {
+				assert.Empty(t, common)
+				return
+			}
+			assert.Equal(t, tc.expected, common)
+		})
+	}
+}
+
+func TestPodSourcePropagatesLabels(t *testing.T) {
+	kubernetes := fake.NewClientset()
+	for _, pod := range []*corev1.Pod{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "web-1",
+				Namespace:   "default",
+				Labels:      map[string]string{"cost-center": "cc-1234", "app": "web"},
+				Annotations: map[string]string{internalHostnameAnnotationKey: "web.internal.example.org"},
+			},
+			Status: corev1.PodStatus{PodIP: "10.0.1.1"},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "web-2",
+				Namespace:   "default",
+				Labels:      map[string]string{"cost-center": "cc-1234", "app": "web-canary"},
+				Annotations: map[string]string{internalHostnameAnnotationKey: "web.internal.example.org"},
+			},
+			Status: corev1.PodStatus{PodIP: "10.0.1.2"},
+		},
+	} {
+		_, err := kubernetes.CoreV1().Pods(pod.Namespace).Create(t.Context(), pod, metav1.CreateOptions{})
+		require.NoError(t, err)
+	}
+
+	client, err := NewPodSource(t.Context(), kubernetes, "", "", false, "", "", false, labels.Everything(), nil, 0, nil, PropagatedLabels{"cost-center", "app"})
+	require.NoError(t, err)
+
+	endpoints, err := client.Endpoints(t.Context())
+	require.NoError(t, err)
+	require.Len(t, endpoints, 1)
+	assert.Equal(t, map[string]string{"cost-center": "cc-1234"}, endpoints[0].Labels.KubernetesLabels())
+}
+
+func TestNodeSourcePropagatesLabels(t *testing.T) {
+	kubernetes := fake.NewClientset()
+	_, err := kubernetes.CoreV1().Nodes().Create(t.Context(), &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "node1",
+			Labels: map[string]string{"cost-center": "cc-1234"},
+		},
+		Status: corev1.NodeStatus{
+			Addresses: []corev1.NodeAddress{{Type: corev1.NodeExternalIP, Address: "1.2.3.4"}},
+		},
+	}, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	client, err := NewNodeSource(t.Context(), kubernetes, "", "{{.Name}}.example.org", labels.Everything(), false, true, false, nil, nil, 0, nil, PropagatedLabels{"cost-center"})
+	require.NoError(t, err)
+
+	endpoints, err := client.Endpoints(t.Context())
+	require.NoError(t, err)
+	require.Len(t, endpoints, 1)
+	assert.Equal(t, map[string]string{"cost-center": "cc-1234"}, endpoints[0].Labels.KubernetesLabels())
+}
+
+func TestACMEChallengeSourcePropagatesLabels(t *testing.T) {
+	challenge := newDNS01Challenge("default", "foo", "DNS-01", "foo.example.org", "key", "external-dns").(*unstructured.Unstructured)
+	challenge.SetLabels(map[string]string{"cost-center": "cc-1234"})
+	dynamicClient := fakeDynamic.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{challengeGVR: "ChallengeList"}, challenge)
+
+	source, err := NewACMEChallengeSource(t.Context(), dynamicClient, "", labels.Everything(), "", nil, 0, PropagatedLabels{"cost-center"})
+	require.NoError(t, err)
+
+	endpoints, err := source.Endpoints(t.Context())
+	require.NoError(t, err)
+	require.Len(t, endpoints, 1)
+	assert.Equal(t, map[string]string{"cost-center": "cc-1234"}, endpoints[0].Labels.KubernetesLabels())
+}
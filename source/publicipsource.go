@@ -0,0 +1,162 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"context"
+	"net/netip"
+	"slices"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+// PublicIPTarget is the target replaced with the public IP address of the cluster, e.g.
+// set with the external-dns.alpha.kubernetes.io/target annotation.
+const PublicIPTarget = "public-ip"
+
+// PublicIPDiscoverer discovers the public IP address of the cluster.
+type PublicIPDiscoverer interface {
+	Discover(ctx context.Context) (netip.Addr, error)
+}
+
+// publicIPSource is a Source that replaces the public-ip targets with the public IP address
+// of the cluster, making ExternalDNS a dynamic DNS client for clusters behind a NAT.
+type publicIPSource struct {
+	source          Source
+	discoverer      PublicIPDiscoverer
+	refreshInterval time.Duration
+
+	mu           sync.Mutex
+	address      netip.Addr
+	discoveredAt time.Time
+}
+
+// NewPublicIPSource creates a new publicIPSource wrapping the provided Source. The public IP
+// address is discovered again once it is older than refreshInterval.
+func NewPublicIPSource(source Source, discoverer PublicIPDiscoverer, refreshInterval time.Duration) Source {
+	return &publicIPSource{source: source, discoverer: discoverer, refreshInterval: refreshInterval}
+}
+
+// Endpoints collects endpoints from its wrapped source and replaces their public-ip targets
+// with the public IP address. The endpoints are split by record type, as the public-ip
+// targets make them CNAME endpoints.
+func (ps *publicIPSource) Endpoints(ctx context.Context) ([]*endpoint.Endpoint, error) {
+	endpoints, err := ps.source.Endpoints(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*endpoint.Endpoint, 0, len(endpoints))
+	for _, ep := range endpoints {
+		if ep.RecordType != endpoint.RecordTypeCNAME || !slices.Contains(ep.Targets, PublicIPTarget) {
+			result = append(result, ep)
+			continue
+		}
+
+		address, err := ps.publicIP(ctx)
+		if err != nil {
+			return nil, err
+		}
+		var targets endpoint.Targets
+		for _, target := range ep.Targets {
+			if target == PublicIPTarget {
+				target = address.String()
+			}
+			targets = append(targets, target)
+		}
+		for _, resolvedEp := range endpointsForHostname(ep.DNSName, targets, ep.RecordTTL, ep.ProviderSpecific, ep.SetIdentifier, "") {
+			for label, value := range ep.Labels {
+				resolvedEp.Labels[label] = value
+			}
+			result = append(result, resolvedEp)
+		}
+	}
+	return result, nil
+}
+
+// publicIP returns the public IP address, discovering it again when it is older than the
+// refresh interval. The previous address is kept when it can't be discovered, so that the
+// records aren't deleted while the discovery endpoints are unavailable.
+func (ps *publicIPSource) publicIP(ctx context.Context) (netip.Addr, error) {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+
+	if ps.address.IsValid() && time.Since(ps.discoveredAt) < ps.refreshInterval {
+		return ps.address, nil
+	}
+	if _, err := ps.refresh(ctx); err != nil {
+		if !ps.address.IsValid() {
+			return netip.Addr{}, err
+		}
+		log.Warnf("Keeping the public IP address %s: %v", ps.address, err)
+	}
+	return ps.address, nil
+}
+
+// refresh discovers the public IP address and returns whether it changed. It must be
+// called with the lock held.
+func (ps *publicIPSource) refresh(ctx context.Context) (bool, error) {
+	address, err := ps.discoverer.Discover(ctx)
+	if err != nil {
+		return false, err
+	}
+	changed := ps.address.IsValid() && address != ps.address
+	if changed {
+		log.Infof("The public IP address changed from %s to %s", ps.address, address)
+	}
+	ps.address = address
+	ps.discoveredAt = time.Now()
+	return changed, nil
+}
+
+// AddEventHandler adds the handler to the wrapped source, and calls it when the public IP
+// address changes, so that the records are updated without waiting for the next interval.
+func (ps *publicIPSource) AddEventHandler(ctx context.Context, handler func()) {
+	ps.source.AddEventHandler(ctx, handler)
+
+	go func() {
+		ticker := time.NewTicker(ps.refreshInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+			ps.mu.Lock()
+			// the address is only watched once it has been used by the endpoints.
+			var (
+				changed bool
+				err     error
+			)
+			if ps.address.IsValid() {
+				changed, err = ps.refresh(ctx)
+			}
+			ps.mu.Unlock()
+			if err != nil {
+				log.Warnf("Failed to refresh the public IP address: %v", err)
+			}
+			if changed {
+				handler()
+			}
+		}
+	}()
+}
@@ -0,0 +1,166 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"context"
+	"errors"
+	"net/netip"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/internal/testutils"
+)
+
+// Validates that publicIPSource is a Source
+var _ Source = &publicIPSource{}
+
+type fakePublicIPDiscoverer struct {
+	mu      sync.Mutex
+	address netip.Addr
+	err     error
+	calls   int
+}
+
+func (d *fakePublicIPDiscoverer) Discover(_ context.Context) (netip.Addr, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.calls++
+	return d.address, d.err
+}
+
+func (d *fakePublicIPDiscoverer) set(address netip.Addr, err error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.address, d.err = address, err
+}
+
+func TestPublicIPSource(t *testing.T) {
+	for _, tc := range []struct {
+		title     string
+		address   string
+		endpoints []*endpoint.Endpoint
+		expected  []*endpoint.Endpoint
+	}{
+		{
+			title:     "no public-ip target",
+			address:   "203.0.113.10",
+			endpoints: []*endpoint.Endpoint{endpoint.NewEndpoint("foo.example.org", endpoint.RecordTypeCNAME, "lb.example.org")},
+			expected:  []*endpoint.Endpoint{endpoint.NewEndpoint("foo.example.org", endpoint.RecordTypeCNAME, "lb.example.org")},
+		},
+		{
+			title:   "ipv4 public ip",
+			address: "203.0.113.10",
+			endpoints: []*endpoint.Endpoint{
+				endpoint.NewEndpointWithTTL("foo.example.org", endpoint.RecordTypeCNAME, 60, PublicIPTarget).WithLabel(endpoint.ResourceLabelKey, "service/default/foo"),
+			},
+			expected: []*endpoint.Endpoint{
+				endpoint.NewEndpointWithTTL("foo.example.org", endpoint.RecordTypeA, 60, "203.0.113.10").WithLabel(endpoint.ResourceLabelKey, "service/default/foo"),
+			},
+		},
+		{
+			title:   "ipv6 public ip along with other targets",
+			address: "2001:db8::10",
+			endpoints: []*endpoint.Endpoint{
+				endpoint.NewEndpoint("foo.example.org", endpoint.RecordTypeCNAME, PublicIPTarget, "198.51.100.1"),
+			},
+			expected: []*endpoint.Endpoint{
+				endpoint.NewEndpoint("foo.example.org", endpoint.RecordTypeA, "198.51.100.1"),
+				endpoint.NewEndpoint("foo.example.org", endpoint.RecordTypeAAAA, "2001:db8::10"),
+			},
+		},
+	} {
+		t.Run(tc.title, func(t *testing.T) {
+			mockSource := new(testutils.MockSource)
+			mockSource.On("Endpoints").Return(tc.endpoints, nil)
+
+			source := NewPublicIPSource(mockSource, &fakePublicIPDiscoverer{address: netip.MustParseAddr(tc.address)}, time.Minute)
+
+			endpoints, err := source.Endpoints(context.Background())
+			require.NoError(t, err)
+
+			validateEndpoints(t, endpoints, tc.expected)
+			mockSource.AssertExpectations(t)
+		})
+	}
+}
+
+func TestPublicIPSourceDiscovery(t *testing.T) {
+	mockSource := new(testutils.MockSource)
+	mockSource.On("Endpoints").Return([]*endpoint.Endpoint{endpoint.NewEndpoint("foo.example.org", endpoint.RecordTypeCNAME, PublicIPTarget)}, nil)
+	discoverer := &fakePublicIPDiscoverer{err: errors.New("unavailable")}
+	source := NewPublicIPSource(mockSource, discoverer, time.Hour).(*publicIPSource)
+
+	// the sync fails until the address is discovered, instead of deleting the records.
+	_, err := source.Endpoints(context.Background())
+	require.ErrorContains(t, err, "unavailable")
+
+	discoverer.set(netip.MustParseAddr("203.0.113.10"), nil)
+	endpoints, err := source.Endpoints(context.Background())
+	require.NoError(t, err)
+	validateEndpoints(t, endpoints, []*endpoint.Endpoint{endpoint.NewEndpoint("foo.example.org", endpoint.RecordTypeA, "203.0.113.10")})
+
+	// the address is cached for the refresh interval.
+	discoverer.set(netip.MustParseAddr("203.0.113.20"), nil)
+	_, err = source.Endpoints(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, discoverer.calls)
+
+	// the previous address is kept when it can't be discovered again.
+	source.discoveredAt = time.Time{}
+	discoverer.set(netip.Addr{}, errors.New("unavailable"))
+	endpoints, err = source.Endpoints(context.Background())
+	require.NoError(t, err)
+	validateEndpoints(t, endpoints, []*endpoint.Endpoint{endpoint.NewEndpoint("foo.example.org", endpoint.RecordTypeA, "203.0.113.10")})
+}
+
+func TestPublicIPSourceEventHandler(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	mockSource := new(testutils.MockSource)
+	mockSource.On("Endpoints").Return([]*endpoint.Endpoint{endpoint.NewEndpoint("foo.example.org", endpoint.RecordTypeCNAME, PublicIPTarget)}, nil)
+	discoverer := &fakePublicIPDiscoverer{address: netip.MustParseAddr("203.0.113.10")}
+	source := NewPublicIPSource(mockSource, discoverer, 10*time.Millisecond)
+
+	called := make(chan struct{}, 1)
+	source.AddEventHandler(ctx, func() {
+		select {
+		case called <- struct{}{}:
+		default:
+		}
+	})
+
+	_, err := source.Endpoints(ctx)
+	require.NoError(t, err)
+
+	discoverer.set(netip.MustParseAddr("203.0.113.20"), nil)
+	select {
+	case <-called:
+	case <-time.After(time.Second):
+		t.Fatal("the handler wasn't called when the public IP address changed")
+	}
+
+	endpoints, err := source.Endpoints(ctx)
+	require.NoError(t, err)
+	validateEndpoints(t, endpoints, []*endpoint.Endpoint{endpoint.NewEndpoint("foo.example.org", endpoint.RecordTypeA, "203.0.113.20")})
+}
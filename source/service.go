@@ -23,13 +23,16 @@ import (
 	"net"
 	"slices"
 	"sort"
+	"strconv"
 	"strings"
 	"text/template"
+	"time"
 
 	log "github.com/sirupsen/logrus"
 	v1 "k8s.io/api/core/v1"
 	discoveryv1 "k8s.io/api/discovery/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
 	kubeinformers "k8s.io/client-go/informers"
@@ -43,6 +46,7 @@ import (
 	"sigs.k8s.io/external-dns/source/annotations"
 
 	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/pkg/decisions"
 	"sigs.k8s.io/external-dns/source/fqdn"
 )
 
@@ -79,15 +83,18 @@ type serviceSource struct {
 	endpointSlicesInformer         discoveryinformers.EndpointSliceInformer
 	podInformer                    coreinformers.PodInformer
 	nodeInformer                   coreinformers.NodeInformer
+	namespaceInformer              coreinformers.NamespaceInformer
 	serviceTypeFilter              *serviceTypes
 	exposeInternalIPv6             bool
 
 	// process Services with legacy annotations
-	compatibility string
+	compatibility    string
+	filterDecisions  *decisions.Recorder
+	propagatedLabels PropagatedLabels
 }
 
 // NewServiceSource creates a new serviceSource with the given config.
-func NewServiceSource(ctx context.Context, kubeClient kubernetes.Interface, namespace, annotationFilter, fqdnTemplate string, combineFqdnAnnotation bool, compatibility string, publishInternal, publishHostIP, alwaysPublishNotReadyAddresses bool, serviceTypeFilter []string, ignoreHostnameAnnotation bool, labelSelector labels.Selector, resolveLoadBalancerHostname, listenEndpointEvents bool, exposeInternalIPv6 bool) (Source, error) {
+func NewServiceSource(ctx context.Context, kubeClient kubernetes.Interface, namespace, annotationFilter, fqdnTemplate string, combineFqdnAnnotation bool, compatibility string, publishInternal, publishHostIP, alwaysPublishNotReadyAddresses bool, serviceTypeFilter []string, ignoreHostnameAnnotation bool, labelSelector labels.Selector, resolveLoadBalancerHostname, listenEndpointEvents bool, exposeInternalIPv6 bool, defaultHostnames bool, filterDecisions *decisions.Recorder, resyncPeriod time.Duration, prefixAliases annotations.PrefixAliases, propagatedLabels PropagatedLabels) (Source, error) {
 	tmpl, err := fqdn.ParseTemplate(fqdnTemplate)
 	if err != nil {
 		return nil, err
@@ -95,8 +102,10 @@ func NewServiceSource(ctx context.Context, kubeClient kubernetes.Interface, name
 
 	// Use shared informers to listen for add/update/delete of services/pods/nodes in the specified namespace.
 	// Set the resync period to 0 to prevent processing when nothing has changed
-	informerFactory := kubeinformers.NewSharedInformerFactoryWithOptions(kubeClient, 0, kubeinformers.WithNamespace(namespace))
-	serviceInformer := informerFactory.Core().V1().Services()
+	informerFactory := kubeinformers.NewSharedInformerFactoryWithOptions(kubeClient, resyncPeriod, kubeinformers.WithNamespace(namespace))
+	// The services have their own factory, so that the label filter doesn't apply to the other resources.
+	serviceInformerFactory := kubeinformers.NewSharedInformerFactoryWithOptions(kubeClient, resyncPeriod, kubeinformers.WithNamespace(namespace), kubeinformers.WithTweakListOptions(labelFilterListOptions(labelSelector, filterDecisions)))
+	serviceInformer := serviceInformerFactory.Core().V1().Services()
 	endpointSlicesInformer := informerFactory.Discovery().V1().EndpointSlices()
 	podInformer := informerFactory.Core().V1().Pods()
 	nodeInformer := informerFactory.Core().V1().Nodes()
@@ -127,6 +136,13 @@ func NewServiceSource(ctx context.Context, kubeClient kubernetes.Interface, name
 		},
 	)
 
+	// The namespaces can opt out of the default hostnames of their LoadBalancer services.
+	var namespaceInformer coreinformers.NamespaceInformer
+	if defaultHostnames {
+		namespaceInformer = informerFactory.Core().V1().Namespaces()
+		namespaceInformer.Informer()
+	}
+
 	// Add an indexer to the EndpointSlice informer to index by the service name label
 	err = endpointSlicesInformer.Informer().AddIndexers(cache.Indexers{
 		serviceNameIndexKey: func(obj any) ([]string, error) {
@@ -147,12 +163,18 @@ func NewServiceSource(ctx context.Context, kubeClient kubernetes.Interface, name
 		return nil, err
 	}
 
+	aliasAnnotations(prefixAliases, serviceInformer.Informer(), podInformer.Informer(), nodeInformer.Informer())
+
 	informerFactory.Start(ctx.Done())
+	serviceInformerFactory.Start(ctx.Done())
 
 	// wait for the local cache to be populated.
 	if err := informers.WaitForCacheSync(context.Background(), informerFactory); err != nil {
 		return nil, err
 	}
+	if err := informers.WaitForCacheSync(context.Background(), serviceInformerFactory); err != nil {
+		return nil, err
+	}
 
 	// Transform the slice into a map so it will be way much easier and fast to filter later
 	sTypesFilter, err := newServiceTypesFilter(serviceTypeFilter)
@@ -161,6 +183,7 @@ func NewServiceSource(ctx context.Context, kubeClient kubernetes.Interface, name
 	}
 
 	return &serviceSource{
+		filterDecisions:                filterDecisions,
 		client:                         kubeClient,
 		namespace:                      namespace,
 		annotationFilter:               annotationFilter,
@@ -175,11 +198,13 @@ func NewServiceSource(ctx context.Context, kubeClient kubernetes.Interface, name
 		endpointSlicesInformer:         endpointSlicesInformer,
 		podInformer:                    podInformer,
 		nodeInformer:                   nodeInformer,
+		namespaceInformer:              namespaceInformer,
 		serviceTypeFilter:              sTypesFilter,
 		labelSelector:                  labelSelector,
 		resolveLoadBalancerHostname:    resolveLoadBalancerHostname,
 		listenEndpointEvents:           listenEndpointEvents,
 		exposeInternalIPv6:             exposeInternalIPv6,
+		propagatedLabels:               propagatedLabels,
 	}, nil
 }
 
@@ -189,6 +214,11 @@ func (sc *serviceSource) Endpoints(_ context.Context) ([]*endpoint.Endpoint, err
 	if err != nil {
 		return nil, err
 	}
+	if sc.filterDecisions.Enabled() {
+		if all, err := sc.serviceInformer.Lister().Services(sc.namespace).List(labels.Everything()); err == nil {
+			recordExcludedByLabels(sc.filterDecisions, "service", all, sc.labelSelector)
+		}
+	}
 
 	// filter on service types if at least one has been provided
 	services = sc.filterByServiceType(services)
@@ -220,7 +250,7 @@ func (sc *serviceSource) Endpoints(_ context.Context) ([]*endpoint.Endpoint, err
 		}
 
 		// apply template if none of the above is found
-		if (sc.combineFQDNAnnotation || len(svcEndpoints) == 0) && sc.fqdnTemplate != nil {
+		if (sc.combineFQDNAnnotation || len(svcEndpoints) == 0) && sc.fqdnTemplate != nil && sc.templateApplies(svc) {
 			sEndpoints, err := sc.endpointsFromTemplate(svc)
 			if err != nil {
 				return nil, err
@@ -238,6 +268,7 @@ func (sc *serviceSource) Endpoints(_ context.Context) ([]*endpoint.Endpoint, err
 			continue
 		}
 
+		sc.propagatedLabels.propagate(svcEndpoints, svc.Labels)
 		log.Debugf("Endpoints generated from service: %s/%s: %v", svc.Namespace, svc.Name, svcEndpoints)
 		endpoints = append(endpoints, svcEndpoints...)
 	}
@@ -463,6 +494,27 @@ func (sc *serviceSource) endpointsFromTemplate(svc *v1.Service) ([]*endpoint.End
 	return endpoints, nil
 }
 
+// templateApplies returns whether the FQDN template applies to the service. With default hostnames,
+// it only applies to the LoadBalancer services of the namespaces which didn't opt out.
+func (sc *serviceSource) templateApplies(svc *v1.Service) bool {
+	if sc.namespaceInformer == nil {
+		return true
+	}
+	if svc.Spec.Type != v1.ServiceTypeLoadBalancer {
+		return false
+	}
+	namespace, err := sc.namespaceInformer.Lister().Get(svc.Namespace)
+	if err != nil {
+		log.Debugf("Failed to get the namespace of service %s/%s: %v", svc.Namespace, svc.Name, err)
+		return true
+	}
+	if namespace.Annotations[defaultHostnamesAnnotationKey] == "false" {
+		log.Debugf("Skipping the default hostnames of service %s/%s because namespace %s opted out", svc.Namespace, svc.Name, svc.Namespace)
+		return false
+	}
+	return true
+}
+
 // endpointsFromService extracts the endpoints from a service object
 func (sc *serviceSource) endpoints(svc *v1.Service) []*endpoint.Endpoint {
 	var endpoints []*endpoint.Endpoint
@@ -472,12 +524,16 @@ func (sc *serviceSource) endpoints(svc *v1.Service) []*endpoint.Endpoint {
 	}
 
 	providerSpecific, setIdentifier := annotations.ProviderSpecificAnnotations(svc.Annotations)
-	var hostnameList []string
 	var internalHostnameList []string
 
-	hostnameList = annotations.HostnamesFromAnnotations(svc.Annotations)
-	for _, hostname := range hostnameList {
-		endpoints = append(endpoints, sc.generateEndpoints(svc, hostname, providerSpecific, setIdentifier, false)...)
+	resource := fmt.Sprintf("service/%s/%s", svc.Namespace, svc.Name)
+	for _, entry := range annotations.HostnameEntriesFromAnnotations(svc.Annotations, resource) {
+		if len(entry.Targets) > 0 {
+			ttl := annotations.TTLFromAnnotations(svc.Annotations, resource)
+			endpoints = append(endpoints, endpointsForHostnameEntry(entry, nil, ttl, providerSpecific, setIdentifier, resource)...)
+			continue
+		}
+		endpoints = append(endpoints, applyHostnameEntry(entry, sc.generateEndpoints(svc, entry.Hostname, providerSpecific, setIdentifier, false))...)
 	}
 
 	internalHostnameList = annotations.InternalHostnamesFromAnnotations(svc.Annotations)
@@ -506,6 +562,8 @@ func (sc *serviceSource) filterByAnnotations(services []*v1.Service) ([]*v1.Serv
 		// include service if its annotations match the selector
 		if selector.Matches(labels.Set(service.Annotations)) {
 			filteredList = append(filteredList, service)
+		} else {
+			recordExcludedByAnnotations(sc.filterDecisions, "service", service, sc.annotationFilter)
 		}
 	}
 	log.Debugf("filtered %d services out of %d with annotation filter", len(filteredList), len(services))
@@ -619,6 +677,25 @@ func extractLoadBalancerTargets(svc *v1.Service, resolveLoadBalancerHostname boo
 	return targets
 }
 
+// newSingleServiceInformer returns a started informer caching only the Service,
+// e.g. the one of an ingress controller whose load balancer addresses are the
+// default targets of a source.
+func newSingleServiceInformer(ctx context.Context, kubeClient kubernetes.Interface, svc types.NamespacedName, resyncPeriod time.Duration) (coreinformers.ServiceInformer, error) {
+	informerFactory := kubeinformers.NewSharedInformerFactoryWithOptions(kubeClient, resyncPeriod,
+		kubeinformers.WithNamespace(svc.Namespace),
+		kubeinformers.WithTweakListOptions(func(options *metav1.ListOptions) {
+			options.FieldSelector = fields.OneTermEqualSelector("metadata.name", svc.Name).String()
+		}))
+	serviceInformer := informerFactory.Core().V1().Services()
+	serviceInformer.Informer() // Register with factory before starting.
+
+	informerFactory.Start(ctx.Done())
+	if err := informers.WaitForCacheSync(context.Background(), informerFactory); err != nil {
+		return nil, err
+	}
+	return serviceInformer, nil
+}
+
 func isPodStatusReady(status v1.PodStatus) bool {
 	_, condition := getPodCondition(&status, v1.PodReady)
 	return condition != nil && condition.Status == v1.ConditionTrue
@@ -730,6 +807,11 @@ func (sc *serviceSource) extractNodePortTargets(svc *v1.Service) (endpoint.Targe
 		}
 	}
 
+	nodes, err := selectNodePortNodes(svc, nodes)
+	if err != nil {
+		return nil, err
+	}
+
 	for _, node := range nodes {
 		for _, address := range node.Status.Addresses {
 			switch address.Type {
@@ -759,6 +841,60 @@ func (sc *serviceSource) extractNodePortTargets(svc *v1.Service) (endpoint.Targe
 	return internalIPs, nil
 }
 
+// selectNodePortNodes returns the nodes matching the node selector annotation of
+// the service. When the service limits the number of nodes, they are picked in
+// turn from each topology zone, in name order so that the same nodes are
+// published on each synchronization.
+func selectNodePortNodes(svc *v1.Service, nodes []*v1.Node) ([]*v1.Node, error) {
+	if value, ok := svc.Annotations[nodePortNodeSelectorKey]; ok {
+		selector, err := labels.Parse(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s annotation: %w", nodePortNodeSelectorKey, err)
+		}
+		var selected []*v1.Node
+		for _, node := range nodes {
+			if selector.Matches(labels.Set(node.Labels)) {
+				selected = append(selected, node)
+			}
+		}
+		nodes = selected
+	}
+
+	value, ok := svc.Annotations[nodePortMaxNodesKey]
+	if !ok {
+		return nodes, nil
+	}
+	maxNodes, err := strconv.Atoi(value)
+	if err != nil || maxNodes < 1 {
+		return nil, fmt.Errorf("invalid %s annotation %q, expected a positive integer", nodePortMaxNodesKey, value)
+	}
+	if len(nodes) <= maxNodes {
+		return nodes, nil
+	}
+
+	byZone := map[string][]*v1.Node{}
+	for _, node := range nodes {
+		zone := node.Labels[v1.LabelTopologyZone]
+		byZone[zone] = append(byZone[zone], node)
+	}
+	zones := make([]string, 0, len(byZone))
+	for zone, zoneNodes := range byZone {
+		zones = append(zones, zone)
+		sort.Slice(zoneNodes, func(i, j int) bool { return zoneNodes[i].Name < zoneNodes[j].Name })
+	}
+	sort.Strings(zones)
+
+	selected := make([]*v1.Node, 0, maxNodes)
+	for i := 0; len(selected) < maxNodes; i++ {
+		for _, zone := range zones {
+			if i < len(byZone[zone]) && len(selected) < maxNodes {
+				selected = append(selected, byZone[zone][i])
+			}
+		}
+	}
+	return selected, nil
+}
+
 func (sc *serviceSource) extractNodePortEndpoints(svc *v1.Service, hostname string, ttl endpoint.TTL) []*endpoint.Endpoint {
 	var endpoints []*endpoint.Endpoint
 
@@ -810,6 +946,9 @@ func (sc *serviceSource) AddEventHandler(_ context.Context, handler func()) {
 	if sc.listenEndpointEvents {
 		sc.endpointSlicesInformer.Informer().AddEventHandler(eventHandlerFunc(handler))
 	}
+	if sc.namespaceInformer != nil {
+		sc.namespaceInformer.Informer().AddEventHandler(eventHandlerFunc(handler))
+	}
 }
 
 type serviceTypes struct {
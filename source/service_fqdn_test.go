@@ -609,6 +609,11 @@ func TestServiceSourceFqdnTemplatingExamples(t *testing.T) {
 				false,
 				false,
 				true,
+				false,
+				nil,
+				0,
+				nil,
+				nil,
 			)
 			require.NoError(t, err)
 
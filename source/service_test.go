@@ -22,6 +22,7 @@ import (
 	"maps"
 	"math/rand"
 	"net"
+	"slices"
 	"sort"
 	"strings"
 	"testing"
@@ -88,6 +89,11 @@ func (suite *ServiceSuite) SetupTest() {
 		false,
 		false,
 		false,
+		false,
+		nil,
+		0,
+		nil,
+		nil,
 	)
 	suite.NoError(err, "should initialize service source")
 }
@@ -171,6 +177,11 @@ func testServiceSourceNewServiceSource(t *testing.T) {
 				false,
 				false,
 				false,
+				false,
+				nil,
+				0,
+				nil,
+				nil,
 			)
 
 			if ti.expectError {
@@ -392,6 +403,23 @@ func testServiceSourceEndpoints(t *testing.T) {
 				{DNSName: "bar.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"1.2.3.4"}},
 			},
 		},
+		{
+			title:        "annotated services with structured hostnames return endpoints with per-hostname settings",
+			svcNamespace: "testing",
+			svcName:      "foo",
+			svcType:      v1.ServiceTypeLoadBalancer,
+			labels:       map[string]string{},
+			annotations: map[string]string{
+				hostnameAnnotationKey: `[{"hostname": "foo.example.org", "ttl": 60}, {"hostname": "bar.example.org", "targets": ["lb.example.com"]}]`,
+			},
+			externalIPs:        []string{},
+			lbs:                []string{"1.2.3.4"},
+			serviceTypesFilter: []string{},
+			expected: []*endpoint.Endpoint{
+				{DNSName: "foo.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"1.2.3.4"}, RecordTTL: 60},
+				{DNSName: "bar.example.org", RecordType: endpoint.RecordTypeCNAME, Targets: endpoint.Targets{"lb.example.com"}},
+			},
+		},
 		{
 			title:        "annotated services return an endpoint with target hostname",
 			svcNamespace: "testing",
@@ -1144,6 +1172,11 @@ func testServiceSourceEndpoints(t *testing.T) {
 				tc.resolveLoadBalancerHostname,
 				false,
 				false,
+				false,
+				nil,
+				0,
+				nil,
+				nil,
 			)
 
 			require.NoError(t, err)
@@ -1360,6 +1393,11 @@ func testMultipleServicesEndpoints(t *testing.T) {
 				false,
 				false,
 				false,
+				false,
+				nil,
+				0,
+				nil,
+				nil,
 			)
 			require.NoError(t, err)
 
@@ -1665,6 +1703,11 @@ func TestClusterIpServices(t *testing.T) {
 				false,
 				false,
 				false,
+				false,
+				nil,
+				0,
+				nil,
+				nil,
 			)
 			require.NoError(t, err)
 
@@ -2387,6 +2430,11 @@ func TestServiceSourceNodePortServices(t *testing.T) {
 				false,
 				false,
 				tc.exposeInternalIPv6,
+				false,
+				nil,
+				0,
+				nil,
+				nil,
 			)
 			require.NoError(t, err)
 
@@ -2404,6 +2452,80 @@ func TestServiceSourceNodePortServices(t *testing.T) {
 }
 
 // TestHeadlessServices tests that headless services generate the correct endpoints.
+func TestSelectNodePortNodes(t *testing.T) {
+	newNode := func(name, zone, pool string) *v1.Node {
+		return &v1.Node{ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: map[string]string{v1.LabelTopologyZone: zone, "pool": pool},
+		}}
+	}
+	nodes := []*v1.Node{
+		newNode("node-a2", "zone-a", "edge"),
+		newNode("node-a1", "zone-a", "edge"),
+		newNode("node-a3", "zone-a", "default"),
+		newNode("node-b1", "zone-b", "edge"),
+		newNode("node-c1", "zone-c", "edge"),
+		newNode("node-c2", "zone-c", "edge"),
+	}
+
+	for _, tc := range []struct {
+		title       string
+		annotations map[string]string
+		expected    []string
+		err         string
+	}{
+		{
+			title:    "all nodes without annotations",
+			expected: []string{"node-a2", "node-a1", "node-a3", "node-b1", "node-c1", "node-c2"},
+		},
+		{
+			title:       "nodes matching the node selector",
+			annotations: map[string]string{nodePortNodeSelectorKey: "pool=default"},
+			expected:    []string{"node-a3"},
+		},
+		{
+			title:       "max nodes spread across zones",
+			annotations: map[string]string{nodePortMaxNodesKey: "4"},
+			expected:    []string{"node-a1", "node-b1", "node-c1", "node-a2"},
+		},
+		{
+			title:       "max nodes among the selected nodes",
+			annotations: map[string]string{nodePortNodeSelectorKey: "pool=edge", nodePortMaxNodesKey: "2"},
+			expected:    []string{"node-a1", "node-b1"},
+		},
+		{
+			title:       "max nodes above the number of nodes",
+			annotations: map[string]string{nodePortNodeSelectorKey: "pool in (default)", nodePortMaxNodesKey: "3"},
+			expected:    []string{"node-a3"},
+		},
+		{
+			title:       "invalid node selector",
+			annotations: map[string]string{nodePortNodeSelectorKey: "pool in edge"},
+			err:         "invalid external-dns.alpha.kubernetes.io/nodeport-node-selector annotation",
+		},
+		{
+			title:       "invalid max nodes",
+			annotations: map[string]string{nodePortMaxNodesKey: "0"},
+			err:         `invalid external-dns.alpha.kubernetes.io/nodeport-max-nodes annotation "0"`,
+		},
+	} {
+		t.Run(tc.title, func(t *testing.T) {
+			svc := &v1.Service{ObjectMeta: metav1.ObjectMeta{Annotations: tc.annotations}}
+			selected, err := selectNodePortNodes(svc, slices.Clone(nodes))
+			if tc.err != "" {
+				assert.ErrorContains(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			names := make([]string, 0, len(selected))
+			for _, node := range selected {
+				names = append(names, node.Name)
+			}
+			assert.Equal(t, tc.expected, names)
+		})
+	}
+}
+
 func TestHeadlessServices(t *testing.T) {
 	t.Parallel()
 
@@ -3180,6 +3302,11 @@ func TestHeadlessServices(t *testing.T) {
 				false,
 				false,
 				tc.exposeInternalIPv6,
+				false,
+				nil,
+				0,
+				nil,
+				nil,
 			)
 			require.NoError(t, err)
 
@@ -3637,6 +3764,11 @@ func TestHeadlessServicesHostIP(t *testing.T) {
 				false,
 				false,
 				false,
+				false,
+				nil,
+				0,
+				nil,
+				nil,
 			)
 			require.NoError(t, err)
 
@@ -3822,6 +3954,11 @@ func TestExternalServices(t *testing.T) {
 				false,
 				false,
 				false,
+				false,
+				nil,
+				0,
+				nil,
+				nil,
 			)
 			require.NoError(t, err)
 
@@ -3884,6 +4021,11 @@ func BenchmarkServiceEndpoints(b *testing.B) {
 		false,
 		false,
 		false,
+		false,
+		nil,
+		0,
+		nil,
+		nil,
 	)
 	require.NoError(b, err)
 
@@ -3913,6 +4055,11 @@ func TestNewServiceSourceWithServiceTypeFilters_Unsupported(t *testing.T) {
 		false,
 		false,
 		false,
+		false,
+		nil,
+		0,
+		nil,
+		nil,
 	)
 	require.Errorf(t, err, "unsupported service type filter: \"UnknownType\". Supported types are: [\"ClusterIP\" \"NodePort\" \"LoadBalancer\" \"ExternalName\"]")
 	require.Nil(t, svc, "ServiceSource should be nil when an unsupported service type is provided")
@@ -4092,6 +4239,11 @@ func TestEndpointSlicesIndexer(t *testing.T) {
 		false,
 		false,
 		false,
+		false,
+		nil,
+		0,
+		nil,
+		nil,
 	)
 	require.NoError(t, err)
 	ss, ok := src.(*serviceSource)
@@ -4117,6 +4269,76 @@ func TestEndpointSlicesIndexer(t *testing.T) {
 		})
 }
 
+func TestServiceSourceDefaultHostnames(t *testing.T) {
+	ctx := t.Context()
+	fakeClient := fake.NewClientset()
+
+	for _, ns := range []*v1.Namespace{
+		{ObjectMeta: metav1.ObjectMeta{Name: "default"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "opted-out", Annotations: map[string]string{defaultHostnamesAnnotationKey: "false"}}},
+	} {
+		_, err := fakeClient.CoreV1().Namespaces().Create(ctx, ns, metav1.CreateOptions{})
+		require.NoError(t, err)
+	}
+
+	for _, svc := range []*v1.Service{
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "lb"},
+			Spec:       v1.ServiceSpec{Type: v1.ServiceTypeLoadBalancer},
+			Status:     v1.ServiceStatus{LoadBalancer: v1.LoadBalancerStatus{Ingress: []v1.LoadBalancerIngress{{IP: "1.2.3.4"}}}},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "internal"},
+			Spec:       v1.ServiceSpec{Type: v1.ServiceTypeClusterIP, ClusterIP: "10.0.0.1"},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "opted-out", Name: "lb-opted-out"},
+			Spec:       v1.ServiceSpec{Type: v1.ServiceTypeLoadBalancer},
+			Status:     v1.ServiceStatus{LoadBalancer: v1.LoadBalancerStatus{Ingress: []v1.LoadBalancerIngress{{IP: "1.2.3.5"}}}},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "opted-out", Name: "lb-annotated", Annotations: map[string]string{hostnameAnnotationKey: "annotated.example.org"}},
+			Spec:       v1.ServiceSpec{Type: v1.ServiceTypeLoadBalancer},
+			Status:     v1.ServiceStatus{LoadBalancer: v1.LoadBalancerStatus{Ingress: []v1.LoadBalancerIngress{{IP: "1.2.3.6"}}}},
+		},
+	} {
+		_, err := fakeClient.CoreV1().Services(svc.Namespace).Create(ctx, svc, metav1.CreateOptions{})
+		require.NoError(t, err)
+	}
+
+	src, err := NewServiceSource(
+		ctx,
+		fakeClient,
+		"",
+		"",
+		"{{.Name}}.example.org",
+		false,
+		"",
+		true,
+		false,
+		false,
+		[]string{},
+		false,
+		labels.Everything(),
+		false,
+		false,
+		false,
+		true,
+		nil,
+		0,
+		nil,
+		nil,
+	)
+	require.NoError(t, err)
+
+	endpoints, err := src.Endpoints(ctx)
+	require.NoError(t, err)
+	validateEndpoints(t, endpoints, []*endpoint.Endpoint{
+		endpoint.NewEndpoint("lb.example.org", endpoint.RecordTypeA, "1.2.3.4").WithLabel(endpoint.ResourceLabelKey, "service/default/lb"),
+		endpoint.NewEndpoint("annotated.example.org", endpoint.RecordTypeA, "1.2.3.6").WithLabel(endpoint.ResourceLabelKey, "service/opted-out/lb-annotated"),
+	})
+}
+
 // createTestServicesByType creates the requested number of services per type in the given namespace.
 func createTestServicesByType(namespace string, typeCounts map[v1.ServiceType]int) []*v1.Service {
 	var services []*v1.Service
@@ -57,6 +57,8 @@ type routeGroupSource struct {
 	fqdnTemplate             *template.Template
 	combineFQDNAnnotation    bool
 	ignoreHostnameAnnotation bool
+	prefixAliases            annotations.PrefixAliases
+	propagatedLabels         PropagatedLabels
 }
 
 // for testing
@@ -193,7 +195,7 @@ func (cli *routeGroupClient) do(req *http.Request) (*http.Response, error) {
 }
 
 // NewRouteGroupSource creates a new routeGroupSource with the given config.
-func NewRouteGroupSource(timeout time.Duration, token, tokenPath, apiServerURL, namespace, annotationFilter, fqdnTemplate, routegroupVersion string, combineFqdnAnnotation, ignoreHostnameAnnotation bool) (Source, error) {
+func NewRouteGroupSource(timeout time.Duration, token, tokenPath, apiServerURL, namespace, annotationFilter, fqdnTemplate, routegroupVersion string, combineFqdnAnnotation, ignoreHostnameAnnotation bool, prefixAliases annotations.PrefixAliases, propagatedLabels PropagatedLabels) (Source, error) {
 	tmpl, err := fqdn.ParseTemplate(fqdnTemplate)
 	if err != nil {
 		return nil, err
@@ -217,6 +219,7 @@ func NewRouteGroupSource(timeout time.Duration, token, tokenPath, apiServerURL,
 	}
 
 	sc := &routeGroupSource{
+		prefixAliases:            prefixAliases,
 		cli:                      cli,
 		apiServer:                apiServer,
 		namespace:                namespace,
@@ -225,6 +228,7 @@ func NewRouteGroupSource(timeout time.Duration, token, tokenPath, apiServerURL,
 		fqdnTemplate:             tmpl,
 		combineFQDNAnnotation:    combineFqdnAnnotation,
 		ignoreHostnameAnnotation: ignoreHostnameAnnotation,
+		propagatedLabels:         propagatedLabels,
 	}
 	if namespace != "" {
 		sc.apiEndpoint = apiServer + fmt.Sprintf(routeGroupNamespacedResource, routegroupVersion, namespace)
@@ -246,6 +250,9 @@ func (sc *routeGroupSource) Endpoints(ctx context.Context) ([]*endpoint.Endpoint
 		log.Errorf("Failed to get RouteGroup list: %v", err)
 		return nil, err
 	}
+	for _, rg := range rgList.Items {
+		rg.Metadata.Annotations = sc.prefixAliases.Alias(rg.Metadata.Annotations)
+	}
 	rgList, err = sc.filterByAnnotations(rgList)
 	if err != nil {
 		return nil, err
@@ -281,6 +288,7 @@ func (sc *routeGroupSource) Endpoints(ctx context.Context) ([]*endpoint.Endpoint
 			continue
 		}
 
+		sc.propagatedLabels.propagate(eps, rg.Metadata.Labels)
 		log.Debugf("Endpoints generated from ingress: %s/%s: %v", rg.Metadata.Namespace, rg.Metadata.Name, eps)
 		endpoints = append(endpoints, eps...)
 	}
@@ -356,9 +364,8 @@ func (sc *routeGroupSource) endpointsFromRouteGroup(rg *routeGroup) []*endpoint.
 
 	// Skip endpoints if we do not want entries from annotations
 	if !sc.ignoreHostnameAnnotation {
-		hostnameList := annotations.HostnamesFromAnnotations(rg.Metadata.Annotations)
-		for _, hostname := range hostnameList {
-			endpoints = append(endpoints, endpointsForHostname(hostname, targets, ttl, providerSpecific, setIdentifier, resource)...)
+		for _, entry := range annotations.HostnameEntriesFromAnnotations(rg.Metadata.Annotations, resource) {
+			endpoints = append(endpoints, endpointsForHostnameEntry(entry, targets, ttl, providerSpecific, setIdentifier, resource)...)
 		}
 	}
 	return endpoints
@@ -425,6 +432,7 @@ type itemMetadata struct {
 	Namespace   string            `json:"namespace"`
 	Name        string            `json:"name"`
 	Annotations map[string]string `json:"annotations"`
+	Labels      map[string]string `json:"labels"`
 }
 
 type routeGroupSpec struct {
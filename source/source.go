@@ -22,8 +22,10 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/cache"
 
 	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/pkg/decisions"
 	"sigs.k8s.io/external-dns/source/annotations"
 )
 
@@ -38,6 +40,11 @@ const (
 	ingressHostnameSourceKey      = annotations.IngressHostnameSourceKey
 	controllerAnnotationValue     = annotations.ControllerValue
 	internalHostnameAnnotationKey = annotations.InternalHostnameKey
+	nodePortNodeSelectorKey       = annotations.NodePortNodeSelectorKey
+	nodePortMaxNodesKey           = annotations.NodePortMaxNodesKey
+	nodeAddressPreferenceKey      = annotations.NodeAddressPreferenceKey
+	podHostnameTemplateKey        = annotations.PodHostnameTemplateKey
+	defaultHostnamesAnnotationKey = annotations.DefaultHostnamesKey
 
 	EndpointsTypeNodeExternalIP = "NodeExternalIP"
 	EndpointsTypeHostIP         = "HostIP"
@@ -75,6 +82,31 @@ func matchLabelSelector(selector labels.Selector, srcAnnotations map[string]stri
 	return selector.Matches(labels.Set(srcAnnotations))
 }
 
+// labelFilterListOptions returns a function setting the label filter as the label selector of the
+// list and watch requests of an informer, so that the objects it excludes are never cached, or nil
+// if it selects everything. The label filter isn't pushed down to the Kubernetes API when the filter
+// decisions are recorded, since the excluded objects must then be listed to be reported.
+func labelFilterListOptions(selector labels.Selector, filterDecisions *decisions.Recorder) func(*metav1.ListOptions) {
+	if selector == nil || selector.Empty() || filterDecisions.Enabled() {
+		return nil
+	}
+	labelSelector := selector.String()
+	return func(options *metav1.ListOptions) {
+		options.LabelSelector = labelSelector
+	}
+}
+
+// aliasAnnotations makes the informers rename the annotations of the alias prefixes before
+// caching the objects. It must be called before the informers are started.
+func aliasAnnotations(prefixAliases annotations.PrefixAliases, informers ...cache.SharedInformer) {
+	if len(prefixAliases) == 0 {
+		return
+	}
+	for _, informer := range informers {
+		_ = informer.SetTransform(prefixAliases.Transform)
+	}
+}
+
 type eventHandlerFunc func()
 
 func (fn eventHandlerFunc) OnAdd(obj interface{}, isInInitialList bool) { fn() }
@@ -17,10 +17,18 @@ limitations under the License.
 package source
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/pkg/decisions"
+	"sigs.k8s.io/external-dns/source/annotations"
 )
 
 func TestGetLabelSelector(t *testing.T) {
@@ -90,3 +98,40 @@ func TestMatchLabelSelector(t *testing.T) {
 		})
 	}
 }
+
+func TestLabelFilterListOptions(t *testing.T) {
+	assert.Nil(t, labelFilterListOptions(nil, nil))
+	assert.Nil(t, labelFilterListOptions(labels.Everything(), nil))
+
+	tweak := labelFilterListOptions(labels.SelectorFromSet(labels.Set{"team": "web"}), nil)
+	require.NotNil(t, tweak)
+	options := metav1.ListOptions{}
+	tweak(&options)
+	assert.Equal(t, "team=web", options.LabelSelector)
+
+	// the excluded objects are cached to be reported when the filter decisions are recorded
+	recorder := decisions.NewRecorder()
+	recorder.Enable()
+	assert.Nil(t, labelFilterListOptions(labels.SelectorFromSet(labels.Set{"team": "web"}), recorder))
+}
+
+func TestAliasAnnotations(t *testing.T) {
+	kubeClient := fake.NewClientset()
+	ing := fakeIngress{
+		name:        "web",
+		namespace:   "default",
+		ips:         []string{"1.2.3.4"},
+		annotations: map[string]string{"dns.mycorp.io/hostname": "web.example.org", "dns.mycorp.io/ttl": "60"},
+	}
+	_, err := kubeClient.NetworkingV1().Ingresses(ing.namespace).Create(context.Background(), ing.Ingress(), metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	src, err := NewIngressSource(context.TODO(), kubeClient, "", "", "", false, false, false, false, labels.Everything(), nil, nil, 0, annotations.NewPrefixAliases([]string{"dns.mycorp.io/"}), nil)
+	require.NoError(t, err)
+
+	endpoints, err := src.Endpoints(context.Background())
+	require.NoError(t, err)
+	require.Len(t, endpoints, 1)
+	assert.Equal(t, "web.example.org", endpoints[0].DNSName)
+	assert.Equal(t, endpoint.TTL(60), endpoints[0].RecordTTL)
+}
@@ -39,6 +39,9 @@ import (
 	gateway "sigs.k8s.io/gateway-api/pkg/client/clientset/versioned"
 
 	"sigs.k8s.io/external-dns/pkg/apis/externaldns"
+	"sigs.k8s.io/external-dns/pkg/decisions"
+	"sigs.k8s.io/external-dns/source/annotations"
+	"sigs.k8s.io/external-dns/source/informers"
 )
 
 // ErrSourceNotFound is returned when a requested source doesn't exist.
@@ -57,9 +60,11 @@ type Config struct {
 	IgnoreIngressTLSSpec           bool
 	IgnoreIngressRulesSpec         bool
 	ListenEndpointEvents           bool
+	ServiceDefaultHostnames        bool
 	GatewayName                    string
 	GatewayNamespace               string
 	GatewayLabelFilter             string
+	GatewayWeightProperty          string
 	Compatibility                  string
 	PodSourceDomain                string
 	PublishInternal                bool
@@ -68,6 +73,8 @@ type Config struct {
 	ConnectorServer                string
 	CRDSourceAPIVersion            string
 	CRDSourceKind                  string
+	GenericCRDSources              []string
+	ACMEChallengeSolverName        string
 	KubeConfig                     string
 	APIServerURL                   string
 	ServiceTypeFilter              []string
@@ -84,13 +91,34 @@ type Config struct {
 	ResolveLoadBalancerHostname    bool
 	TraefikDisableLegacy           bool
 	TraefikDisableNew              bool
+	TraefikService                 string
+	ContourEnvoyService            string
 	ExcludeUnschedulable           bool
 	ExposeInternalIPv6             bool
+	NodeAddressPreference          []string
+	// FilterDecisions records the objects excluded by the filters of the sources, nil not to
+	// record them.
+	FilterDecisions *decisions.Recorder
+	// ResyncPeriods are the resync periods of the informers of the sources, by source name; the
+	// informers of the other sources are never resynced.
+	ResyncPeriods map[string]time.Duration
+	// AnnotationPrefixAliases are the annotation prefixes read as aliases of the prefix of the
+	// annotations of external-dns.
+	AnnotationPrefixAliases annotations.PrefixAliases
+	// PropagatedLabels are the Kubernetes labels of the resources copied to their endpoints.
+	PropagatedLabels PropagatedLabels
+	// WatchList makes the informers stream the objects with a watch instead of listing them.
+	WatchList bool
 }
 
 func NewSourceConfig(cfg *externaldns.Config) *Config {
 	// error is explicitly ignored because the filter is already validated in validation.ValidateConfig
 	labelSelector, _ := labels.Parse(cfg.LabelFilter)
+	resyncPeriods := make(map[string]time.Duration, len(cfg.SourceResyncPeriods))
+	for name, period := range cfg.SourceResyncPeriods {
+		// error is explicitly ignored because the periods are already validated in validation.ValidateConfig
+		resyncPeriods[name], _ = time.ParseDuration(period)
+	}
 	return &Config{
 		Namespace:                      cfg.Namespace,
 		AnnotationFilter:               cfg.AnnotationFilter,
@@ -103,9 +131,11 @@ func NewSourceConfig(cfg *externaldns.Config) *Config {
 		IgnoreIngressTLSSpec:           cfg.IgnoreIngressTLSSpec,
 		IgnoreIngressRulesSpec:         cfg.IgnoreIngressRulesSpec,
 		ListenEndpointEvents:           cfg.ListenEndpointEvents,
+		ServiceDefaultHostnames:        cfg.ServiceDefaultHostnames,
 		GatewayName:                    cfg.GatewayName,
 		GatewayNamespace:               cfg.GatewayNamespace,
 		GatewayLabelFilter:             cfg.GatewayLabelFilter,
+		GatewayWeightProperty:          GatewayWeightProperty(cfg.Provider),
 		Compatibility:                  cfg.Compatibility,
 		PodSourceDomain:                cfg.PodSourceDomain,
 		PublishInternal:                cfg.PublishInternal,
@@ -114,6 +144,8 @@ func NewSourceConfig(cfg *externaldns.Config) *Config {
 		ConnectorServer:                cfg.ConnectorSourceServer,
 		CRDSourceAPIVersion:            cfg.CRDSourceAPIVersion,
 		CRDSourceKind:                  cfg.CRDSourceKind,
+		GenericCRDSources:              cfg.GenericCRDSources,
+		ACMEChallengeSolverName:        cfg.ACMEChallengeSolverName,
 		KubeConfig:                     cfg.KubeConfig,
 		APIServerURL:                   cfg.APIServerURL,
 		ServiceTypeFilter:              cfg.ServiceTypeFilter,
@@ -130,8 +162,15 @@ func NewSourceConfig(cfg *externaldns.Config) *Config {
 		ResolveLoadBalancerHostname:    cfg.ResolveServiceLoadBalancerHostname,
 		TraefikDisableLegacy:           cfg.TraefikDisableLegacy,
 		TraefikDisableNew:              cfg.TraefikDisableNew,
+		TraefikService:                 cfg.TraefikService,
+		ContourEnvoyService:            cfg.ContourEnvoyService,
 		ExcludeUnschedulable:           cfg.ExcludeUnschedulable,
 		ExposeInternalIPv6:             cfg.ExposeInternalIPV6,
+		NodeAddressPreference:          cfg.NodeAddressPreference,
+		ResyncPeriods:                  resyncPeriods,
+		AnnotationPrefixAliases:        annotations.NewPrefixAliases(cfg.AnnotationPrefixAliases),
+		PropagatedLabels:               NewPropagatedLabels(cfg.PropagateLabels),
+		WatchList:                      cfg.InformerWatchList,
 	}
 }
 
@@ -247,8 +286,11 @@ func (p *SingletonClientGenerator) OpenShiftClient() (openshift.Interface, error
 	return p.openshiftClient, err
 }
 
-// ByNames returns multiple Sources given multiple names.
+// ByNames returns multiple Sources given multiple names. The watch list setting of the config
+// applies to all the informers of the process, see informers.SetWatchList.
 func ByNames(ctx context.Context, p ClientGenerator, names []string, cfg *Config) ([]Source, error) {
+	informers.SetWatchList(cfg.WatchList)
+	informers.ReportResyncPeriods(cfg.ResyncPeriods)
 	sources := []Source{}
 	for _, name := range names {
 		source, err := BuildWithConfig(ctx, name, p, cfg)
@@ -269,25 +311,25 @@ func BuildWithConfig(ctx context.Context, source string, p ClientGenerator, cfg
 		if err != nil {
 			return nil, err
 		}
-		return NewNodeSource(ctx, client, cfg.AnnotationFilter, cfg.FQDNTemplate, cfg.LabelFilter, cfg.ExposeInternalIPv6, cfg.ExcludeUnschedulable, cfg.CombineFQDNAndAnnotation)
+		return NewNodeSource(ctx, client, cfg.AnnotationFilter, cfg.FQDNTemplate, cfg.LabelFilter, cfg.ExposeInternalIPv6, cfg.ExcludeUnschedulable, cfg.CombineFQDNAndAnnotation, cfg.NodeAddressPreference, cfg.FilterDecisions, cfg.ResyncPeriods["node"], cfg.AnnotationPrefixAliases, cfg.PropagatedLabels)
 	case "service":
 		client, err := p.KubeClient()
 		if err != nil {
 			return nil, err
 		}
-		return NewServiceSource(ctx, client, cfg.Namespace, cfg.AnnotationFilter, cfg.FQDNTemplate, cfg.CombineFQDNAndAnnotation, cfg.Compatibility, cfg.PublishInternal, cfg.PublishHostIP, cfg.AlwaysPublishNotReadyAddresses, cfg.ServiceTypeFilter, cfg.IgnoreHostnameAnnotation, cfg.LabelFilter, cfg.ResolveLoadBalancerHostname, cfg.ListenEndpointEvents, cfg.ExposeInternalIPv6)
+		return NewServiceSource(ctx, client, cfg.Namespace, cfg.AnnotationFilter, cfg.FQDNTemplate, cfg.CombineFQDNAndAnnotation, cfg.Compatibility, cfg.PublishInternal, cfg.PublishHostIP, cfg.AlwaysPublishNotReadyAddresses, cfg.ServiceTypeFilter, cfg.IgnoreHostnameAnnotation, cfg.LabelFilter, cfg.ResolveLoadBalancerHostname, cfg.ListenEndpointEvents, cfg.ExposeInternalIPv6, cfg.ServiceDefaultHostnames, cfg.FilterDecisions, cfg.ResyncPeriods["service"], cfg.AnnotationPrefixAliases, cfg.PropagatedLabels)
 	case "ingress":
 		client, err := p.KubeClient()
 		if err != nil {
 			return nil, err
 		}
-		return NewIngressSource(ctx, client, cfg.Namespace, cfg.AnnotationFilter, cfg.FQDNTemplate, cfg.CombineFQDNAndAnnotation, cfg.IgnoreHostnameAnnotation, cfg.IgnoreIngressTLSSpec, cfg.IgnoreIngressRulesSpec, cfg.LabelFilter, cfg.IngressClassNames)
+		return NewIngressSource(ctx, client, cfg.Namespace, cfg.AnnotationFilter, cfg.FQDNTemplate, cfg.CombineFQDNAndAnnotation, cfg.IgnoreHostnameAnnotation, cfg.IgnoreIngressTLSSpec, cfg.IgnoreIngressRulesSpec, cfg.LabelFilter, cfg.IngressClassNames, cfg.FilterDecisions, cfg.ResyncPeriods["ingress"], cfg.AnnotationPrefixAliases, cfg.PropagatedLabels)
 	case "pod":
 		client, err := p.KubeClient()
 		if err != nil {
 			return nil, err
 		}
-		return NewPodSource(ctx, client, cfg.Namespace, cfg.Compatibility, cfg.IgnoreNonHostNetworkPods, cfg.PodSourceDomain, cfg.FQDNTemplate, cfg.CombineFQDNAndAnnotation)
+		return NewPodSource(ctx, client, cfg.Namespace, cfg.Compatibility, cfg.IgnoreNonHostNetworkPods, cfg.PodSourceDomain, cfg.FQDNTemplate, cfg.CombineFQDNAndAnnotation, cfg.LabelFilter, cfg.FilterDecisions, cfg.ResyncPeriods["pod"], cfg.AnnotationPrefixAliases, cfg.PropagatedLabels)
 	case "gateway-httproute":
 		return NewGatewayHTTPRouteSource(p, cfg)
 	case "gateway-grpcroute":
@@ -307,7 +349,7 @@ func BuildWithConfig(ctx context.Context, source string, p ClientGenerator, cfg
 		if err != nil {
 			return nil, err
 		}
-		return NewIstioGatewaySource(ctx, kubernetesClient, istioClient, cfg.Namespace, cfg.AnnotationFilter, cfg.FQDNTemplate, cfg.CombineFQDNAndAnnotation, cfg.IgnoreHostnameAnnotation)
+		return NewIstioGatewaySource(ctx, kubernetesClient, istioClient, cfg.Namespace, cfg.AnnotationFilter, cfg.LabelFilter, cfg.FQDNTemplate, cfg.CombineFQDNAndAnnotation, cfg.IgnoreHostnameAnnotation, cfg.FilterDecisions, cfg.ResyncPeriods["istio-gateway"], cfg.AnnotationPrefixAliases, cfg.PropagatedLabels)
 	case "istio-virtualservice":
 		kubernetesClient, err := p.KubeClient()
 		if err != nil {
@@ -317,7 +359,7 @@ func BuildWithConfig(ctx context.Context, source string, p ClientGenerator, cfg
 		if err != nil {
 			return nil, err
 		}
-		return NewIstioVirtualServiceSource(ctx, kubernetesClient, istioClient, cfg.Namespace, cfg.AnnotationFilter, cfg.FQDNTemplate, cfg.CombineFQDNAndAnnotation, cfg.IgnoreHostnameAnnotation)
+		return NewIstioVirtualServiceSource(ctx, kubernetesClient, istioClient, cfg.Namespace, cfg.AnnotationFilter, cfg.LabelFilter, cfg.FQDNTemplate, cfg.CombineFQDNAndAnnotation, cfg.IgnoreHostnameAnnotation, cfg.FilterDecisions, cfg.ResyncPeriods["istio-virtualservice"], cfg.AnnotationPrefixAliases, cfg.PropagatedLabels)
 	case "cloudfoundry":
 		cfClient, err := p.CloudFoundryClient(cfg.CFAPIEndpoint, cfg.CFUsername, cfg.CFPassword)
 		if err != nil {
@@ -333,13 +375,17 @@ func BuildWithConfig(ctx context.Context, source string, p ClientGenerator, cfg
 		if err != nil {
 			return nil, err
 		}
-		return NewAmbassadorHostSource(ctx, dynamicClient, kubernetesClient, cfg.Namespace, cfg.AnnotationFilter, cfg.LabelFilter)
+		return NewAmbassadorHostSource(ctx, dynamicClient, kubernetesClient, cfg.Namespace, cfg.AnnotationFilter, cfg.LabelFilter, cfg.FilterDecisions, cfg.ResyncPeriods["ambassador-host"], cfg.AnnotationPrefixAliases, cfg.PropagatedLabels)
 	case "contour-httpproxy":
+		kubernetesClient, err := p.KubeClient()
+		if err != nil {
+			return nil, err
+		}
 		dynamicClient, err := p.DynamicKubernetesClient()
 		if err != nil {
 			return nil, err
 		}
-		return NewContourHTTPProxySource(ctx, dynamicClient, cfg.Namespace, cfg.AnnotationFilter, cfg.FQDNTemplate, cfg.CombineFQDNAndAnnotation, cfg.IgnoreHostnameAnnotation)
+		return NewContourHTTPProxySource(ctx, dynamicClient, kubernetesClient, cfg.Namespace, cfg.AnnotationFilter, cfg.LabelFilter, cfg.FQDNTemplate, cfg.CombineFQDNAndAnnotation, cfg.IgnoreHostnameAnnotation, cfg.ContourEnvoyService, cfg.FilterDecisions, cfg.ResyncPeriods["contour-httpproxy"], cfg.AnnotationPrefixAliases, cfg.PropagatedLabels)
 	case "gloo-proxy":
 		kubernetesClient, err := p.KubeClient()
 		if err != nil {
@@ -349,7 +395,7 @@ func BuildWithConfig(ctx context.Context, source string, p ClientGenerator, cfg
 		if err != nil {
 			return nil, err
 		}
-		return NewGlooSource(dynamicClient, kubernetesClient, cfg.GlooNamespaces)
+		return NewGlooSource(dynamicClient, kubernetesClient, cfg.GlooNamespaces, cfg.AnnotationPrefixAliases, cfg.PropagatedLabels)
 	case "traefik-proxy":
 		kubernetesClient, err := p.KubeClient()
 		if err != nil {
@@ -359,13 +405,13 @@ func BuildWithConfig(ctx context.Context, source string, p ClientGenerator, cfg
 		if err != nil {
 			return nil, err
 		}
-		return NewTraefikSource(ctx, dynamicClient, kubernetesClient, cfg.Namespace, cfg.AnnotationFilter, cfg.IgnoreHostnameAnnotation, cfg.TraefikDisableLegacy, cfg.TraefikDisableNew)
+		return NewTraefikSource(ctx, dynamicClient, kubernetesClient, cfg.Namespace, cfg.AnnotationFilter, cfg.LabelFilter, cfg.IgnoreHostnameAnnotation, cfg.TraefikDisableLegacy, cfg.TraefikDisableNew, cfg.TraefikService, cfg.FilterDecisions, cfg.ResyncPeriods["traefik-proxy"], cfg.AnnotationPrefixAliases, cfg.PropagatedLabels)
 	case "openshift-route":
 		ocpClient, err := p.OpenShiftClient()
 		if err != nil {
 			return nil, err
 		}
-		return NewOcpRouteSource(ctx, ocpClient, cfg.Namespace, cfg.AnnotationFilter, cfg.FQDNTemplate, cfg.CombineFQDNAndAnnotation, cfg.IgnoreHostnameAnnotation, cfg.LabelFilter, cfg.OCPRouterName)
+		return NewOcpRouteSource(ctx, ocpClient, cfg.Namespace, cfg.AnnotationFilter, cfg.FQDNTemplate, cfg.CombineFQDNAndAnnotation, cfg.IgnoreHostnameAnnotation, cfg.LabelFilter, cfg.OCPRouterName, cfg.FilterDecisions, cfg.ResyncPeriods["openshift-route"], cfg.AnnotationPrefixAliases, cfg.PropagatedLabels)
 	case "fake":
 		return NewFakeSource(cfg.FQDNTemplate)
 	case "connector":
@@ -379,7 +425,23 @@ func BuildWithConfig(ctx context.Context, source string, p ClientGenerator, cfg
 		if err != nil {
 			return nil, err
 		}
-		return NewCRDSource(crdClient, cfg.Namespace, cfg.CRDSourceKind, cfg.AnnotationFilter, cfg.LabelFilter, scheme, cfg.UpdateEvents)
+		return NewCRDSource(crdClient, cfg.Namespace, cfg.CRDSourceKind, cfg.AnnotationFilter, cfg.LabelFilter, scheme, cfg.UpdateEvents, cfg.FilterDecisions, cfg.ResyncPeriods["crd"], cfg.AnnotationPrefixAliases, cfg.PropagatedLabels)
+	case "generic-crd":
+		kubernetesClient, err := p.KubeClient()
+		if err != nil {
+			return nil, err
+		}
+		dynamicClient, err := p.DynamicKubernetesClient()
+		if err != nil {
+			return nil, err
+		}
+		return NewGenericCRDSource(ctx, dynamicClient, kubernetesClient, cfg.Namespace, cfg.AnnotationFilter, cfg.LabelFilter, cfg.IgnoreHostnameAnnotation, cfg.GenericCRDSources, cfg.FilterDecisions, cfg.ResyncPeriods["generic-crd"], cfg.AnnotationPrefixAliases, cfg.PropagatedLabels)
+	case "acme-challenge":
+		dynamicClient, err := p.DynamicKubernetesClient()
+		if err != nil {
+			return nil, err
+		}
+		return NewACMEChallengeSource(ctx, dynamicClient, cfg.Namespace, cfg.LabelFilter, cfg.ACMEChallengeSolverName, cfg.FilterDecisions, cfg.ResyncPeriods["acme-challenge"], cfg.PropagatedLabels)
 	case "skipper-routegroup":
 		apiServerURL := cfg.APIServerURL
 		tokenPath := ""
@@ -390,7 +452,7 @@ func BuildWithConfig(ctx context.Context, source string, p ClientGenerator, cfg
 			tokenPath = restConfig.BearerTokenFile
 			token = restConfig.BearerToken
 		}
-		return NewRouteGroupSource(cfg.RequestTimeout, token, tokenPath, apiServerURL, cfg.Namespace, cfg.AnnotationFilter, cfg.FQDNTemplate, cfg.SkipperRouteGroupVersion, cfg.CombineFQDNAndAnnotation, cfg.IgnoreHostnameAnnotation)
+		return NewRouteGroupSource(cfg.RequestTimeout, token, tokenPath, apiServerURL, cfg.Namespace, cfg.AnnotationFilter, cfg.FQDNTemplate, cfg.SkipperRouteGroupVersion, cfg.CombineFQDNAndAnnotation, cfg.IgnoreHostnameAnnotation, cfg.AnnotationPrefixAliases, cfg.PropagatedLabels)
 	case "kong-tcpingress":
 		kubernetesClient, err := p.KubeClient()
 		if err != nil {
@@ -400,7 +462,7 @@ func BuildWithConfig(ctx context.Context, source string, p ClientGenerator, cfg
 		if err != nil {
 			return nil, err
 		}
-		return NewKongTCPIngressSource(ctx, dynamicClient, kubernetesClient, cfg.Namespace, cfg.AnnotationFilter, cfg.IgnoreHostnameAnnotation)
+		return NewKongTCPIngressSource(ctx, dynamicClient, kubernetesClient, cfg.Namespace, cfg.AnnotationFilter, cfg.LabelFilter, cfg.IgnoreHostnameAnnotation, cfg.FilterDecisions, cfg.ResyncPeriods["kong-tcpingress"], cfg.AnnotationPrefixAliases, cfg.PropagatedLabels)
 	case "f5-virtualserver":
 		kubernetesClient, err := p.KubeClient()
 		if err != nil {
@@ -410,7 +472,7 @@ func BuildWithConfig(ctx context.Context, source string, p ClientGenerator, cfg
 		if err != nil {
 			return nil, err
 		}
-		return NewF5VirtualServerSource(ctx, dynamicClient, kubernetesClient, cfg.Namespace, cfg.AnnotationFilter)
+		return NewF5VirtualServerSource(ctx, dynamicClient, kubernetesClient, cfg.Namespace, cfg.AnnotationFilter, cfg.LabelFilter, cfg.FilterDecisions, cfg.ResyncPeriods["f5-virtualserver"], cfg.AnnotationPrefixAliases, cfg.PropagatedLabels)
 	case "f5-transportserver":
 		kubernetesClient, err := p.KubeClient()
 		if err != nil {
@@ -420,7 +482,7 @@ func BuildWithConfig(ctx context.Context, source string, p ClientGenerator, cfg
 		if err != nil {
 			return nil, err
 		}
-		return NewF5TransportServerSource(ctx, dynamicClient, kubernetesClient, cfg.Namespace, cfg.AnnotationFilter)
+		return NewF5TransportServerSource(ctx, dynamicClient, kubernetesClient, cfg.Namespace, cfg.AnnotationFilter, cfg.LabelFilter, cfg.FilterDecisions, cfg.ResyncPeriods["f5-transportserver"], cfg.AnnotationPrefixAliases, cfg.PropagatedLabels)
 	}
 
 	return nil, ErrSourceNotFound
@@ -432,12 +494,12 @@ func instrumentedRESTConfig(kubeConfig, apiServerURL string, requestTimeout time
 		return nil, err
 	}
 	config.WrapTransport = func(rt http.RoundTripper) http.RoundTripper {
-		return instrumented_http.NewTransport(rt, &instrumented_http.Callbacks{
+		return informers.Transport(instrumented_http.NewTransport(rt, &instrumented_http.Callbacks{
 			PathProcessor: func(path string) string {
 				parts := strings.Split(path, "/")
 				return parts[len(parts)-1]
 			},
-		})
+		}))
 	}
 	config.Timeout = requestTimeout
 	return config, nil
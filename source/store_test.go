@@ -20,9 +20,11 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	cfclient "github.com/cloudfoundry-community/go-cfclient"
 	openshift "github.com/openshift/client-go/route/clientset/versioned"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 	istioclient "istio.io/client-go/pkg/clientset/versioned"
@@ -34,6 +36,9 @@ import (
 	"k8s.io/client-go/kubernetes"
 	fakeKube "k8s.io/client-go/kubernetes/fake"
 	gateway "sigs.k8s.io/gateway-api/pkg/client/clientset/versioned"
+
+	"sigs.k8s.io/external-dns/pkg/apis/externaldns"
+	"sigs.k8s.io/external-dns/source/annotations"
 )
 
 type MockClientGenerator struct {
@@ -197,8 +202,8 @@ func (suite *ByNamesTestSuite) TestKubeClientFails() {
 
 	sourcesDependentOnKubeClient := []string{
 		"node", "service", "ingress", "pod", "istio-gateway", "istio-virtualservice",
-		"ambassador-host", "gloo-proxy", "traefik-proxy", "crd", "kong-tcpingress",
-		"f5-virtualserver", "f5-transportserver",
+		"ambassador-host", "contour-httpproxy", "gloo-proxy", "traefik-proxy", "crd", "kong-tcpingress",
+		"f5-virtualserver", "f5-transportserver", "generic-crd",
 	}
 
 	for _, source := range sourcesDependentOnKubeClient {
@@ -228,7 +233,7 @@ func (suite *ByNamesTestSuite) TestDynamicKubernetesClientFails() {
 	mockClientGenerator.On("DynamicKubernetesClient").Return(nil, errors.New("foo"))
 
 	sourcesDependentOnDynamicKubernetesClient := []string{"ambassador-host", "contour-httpproxy", "gloo-proxy", "traefik-proxy",
-		"kong-tcpingress", "f5-virtualserver", "f5-transportserver"}
+		"kong-tcpingress", "f5-virtualserver", "f5-transportserver", "generic-crd", "acme-challenge"}
 
 	for _, source := range sourcesDependentOnDynamicKubernetesClient {
 		_, err := ByNames(context.TODO(), mockClientGenerator, []string{source}, &Config{})
@@ -239,3 +244,18 @@ func (suite *ByNamesTestSuite) TestDynamicKubernetesClientFails() {
 func TestByNames(t *testing.T) {
 	suite.Run(t, new(ByNamesTestSuite))
 }
+
+func TestNewSourceConfig(t *testing.T) {
+	cfg := externaldns.NewConfig()
+	cfg.SourceResyncPeriods = map[string]string{"ingress": "30m", "service": "1h"}
+	cfg.InformerWatchList = true
+	cfg.AnnotationPrefixAliases = []string{"dns.mycorp.io"}
+	cfg.PropagateLabels = []string{"cost-center", " "}
+
+	sourceCfg := NewSourceConfig(cfg)
+	assert.Equal(t, map[string]time.Duration{"ingress": 30 * time.Minute, "service": time.Hour}, sourceCfg.ResyncPeriods)
+	assert.Zero(t, sourceCfg.ResyncPeriods["pod"])
+	assert.True(t, sourceCfg.WatchList)
+	assert.Equal(t, annotations.PrefixAliases{"dns.mycorp.io/"}, sourceCfg.AnnotationPrefixAliases)
+	assert.Equal(t, PropagatedLabels{"cost-center"}, sourceCfg.PropagatedLabels)
+}
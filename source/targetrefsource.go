@@ -0,0 +1,281 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/pkg/logging"
+)
+
+// The kinds of objects the targets can refer to, as <kind>/<namespace>/<name>.
+const (
+	TargetRefKindService   = "service"
+	TargetRefKindGateway   = "gateway"
+	TargetRefKindTailscale = "tailscale"
+)
+
+const (
+	// tailscaleLoadBalancerClass is the load balancer class of the Services exposed on the
+	// tailnet by the Tailscale operator, which sets their load balancer status.
+	tailscaleLoadBalancerClass = "tailscale"
+	// tailscaleDeviceIPsKey is the key of the tailnet addresses of a Tailscale operator proxy
+	// in its state Secret, as a JSON list.
+	tailscaleDeviceIPsKey = "device_ips"
+)
+
+// targetRefSource is a Source that replaces the targets referring to other Kubernetes
+// objects with the current addresses of those objects.
+type targetRefSource struct {
+	source             Source
+	clientGenerator    ClientGenerator
+	tailscaleNamespace string
+}
+
+// NewTargetRefSource creates a new targetRefSource wrapping the provided Source.
+// The clients are only created once a target refers to an object. The proxies of
+// the Tailscale operator are looked up in tailscaleNamespace.
+func NewTargetRefSource(source Source, clientGenerator ClientGenerator, tailscaleNamespace string) Source {
+	return &targetRefSource{source: source, clientGenerator: clientGenerator, tailscaleNamespace: tailscaleNamespace}
+}
+
+// targetRef is a target referring to a Kubernetes object.
+type targetRef struct {
+	kind, namespace, name string
+}
+
+func (r targetRef) String() string {
+	return r.kind + "/" + r.namespace + "/" + r.name
+}
+
+// parseTargetRef returns the object a target refers to, if any.
+func parseTargetRef(target string) (targetRef, bool) {
+	parts := strings.Split(target, "/")
+	if len(parts) != 3 || parts[1] == "" || parts[2] == "" {
+		return targetRef{}, false
+	}
+	switch kind := strings.ToLower(parts[0]); kind {
+	case TargetRefKindService, TargetRefKindGateway, TargetRefKindTailscale:
+		return targetRef{kind: kind, namespace: parts[1], name: parts[2]}, true
+	}
+	return targetRef{}, false
+}
+
+// Endpoints collects endpoints from its wrapped source and resolves the targets
+// referring to other objects. The endpoints are split by record type when the
+// addresses of the objects are IP addresses.
+func (ts *targetRefSource) Endpoints(ctx context.Context) ([]*endpoint.Endpoint, error) {
+	endpoints, err := ts.source.Endpoints(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*endpoint.Endpoint, 0, len(endpoints))
+	resolved := map[targetRef]endpoint.Targets{}
+	for _, ep := range endpoints {
+		if ep.RecordType != endpoint.RecordTypeCNAME || !hasTargetRef(ep.Targets) {
+			result = append(result, ep)
+			continue
+		}
+
+		var targets endpoint.Targets
+		for _, target := range ep.Targets {
+			ref, ok := parseTargetRef(target)
+			if !ok {
+				targets = append(targets, target)
+				continue
+			}
+			addresses, ok := resolved[ref]
+			if !ok {
+				addresses, err = ts.resolve(ctx, ref)
+				if err != nil {
+					return nil, err
+				}
+				resolved[ref] = addresses
+			}
+			if len(addresses) == 0 {
+				log.Warnf("Target %s of endpoint %s has no address", ref, ep.DNSName)
+			}
+			targets = append(targets, addresses...)
+		}
+		if len(targets) == 0 {
+			logging.ForRecord(ep).Warnf("Skipping endpoint %s because none of its targets could be resolved", ep.DNSName)
+			continue
+		}
+
+		for _, resolvedEp := range endpointsForHostname(ep.DNSName, targets, ep.RecordTTL, ep.ProviderSpecific, ep.SetIdentifier, "") {
+			for label, value := range ep.Labels {
+				resolvedEp.Labels[label] = value
+			}
+			result = append(result, resolvedEp)
+		}
+	}
+	return result, nil
+}
+
+func hasTargetRef(targets endpoint.Targets) bool {
+	for _, target := range targets {
+		if _, ok := parseTargetRef(target); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// resolve returns the current addresses of the object a target refers to.
+// An object which doesn't exist has no address.
+func (ts *targetRefSource) resolve(ctx context.Context, ref targetRef) (endpoint.Targets, error) {
+	var (
+		addresses endpoint.Targets
+		err       error
+	)
+	switch ref.kind {
+	case TargetRefKindService:
+		addresses, err = ts.serviceAddresses(ctx, ref)
+	case TargetRefKindGateway:
+		addresses, err = ts.gatewayAddresses(ctx, ref)
+	case TargetRefKindTailscale:
+		addresses, err = ts.tailscaleAddresses(ctx, ref)
+	}
+	if apierrors.IsNotFound(err) {
+		log.Warnf("Target %s refers to an object which doesn't exist", ref)
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve target %s: %w", ref, err)
+	}
+	return addresses, nil
+}
+
+// serviceAddresses returns the load balancer addresses of a service, its external name,
+// or else its cluster IP.
+func (ts *targetRefSource) serviceAddresses(ctx context.Context, ref targetRef) (endpoint.Targets, error) {
+	kubeClient, err := ts.clientGenerator.KubeClient()
+	if err != nil {
+		return nil, err
+	}
+	svc, err := kubeClient.CoreV1().Services(ref.namespace).Get(ctx, ref.name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	var addresses endpoint.Targets
+	for _, lb := range svc.Status.LoadBalancer.Ingress {
+		if lb.IP != "" {
+			addresses = append(addresses, lb.IP)
+		} else if lb.Hostname != "" {
+			addresses = append(addresses, lb.Hostname)
+		}
+	}
+	if len(addresses) > 0 {
+		return addresses, nil
+	}
+	switch {
+	case svc.Spec.Type == v1.ServiceTypeExternalName:
+		return endpoint.Targets{strings.TrimSuffix(svc.Spec.ExternalName, ".")}, nil
+	case len(svc.Spec.ClusterIPs) > 0 && svc.Spec.ClusterIP != v1.ClusterIPNone:
+		return endpoint.Targets(svc.Spec.ClusterIPs), nil
+	case svc.Spec.ClusterIP != "" && svc.Spec.ClusterIP != v1.ClusterIPNone:
+		return endpoint.Targets{svc.Spec.ClusterIP}, nil
+	}
+	return nil, nil
+}
+
+// gatewayAddresses returns the addresses of the status of a gateway.
+func (ts *targetRefSource) gatewayAddresses(ctx context.Context, ref targetRef) (endpoint.Targets, error) {
+	gatewayClient, err := ts.clientGenerator.GatewayClient()
+	if err != nil {
+		return nil, err
+	}
+	gw, err := gatewayClient.GatewayV1beta1().Gateways(ref.namespace).Get(ctx, ref.name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	var addresses endpoint.Targets
+	for _, addr := range gw.Status.Addresses {
+		addresses = append(addresses, strings.TrimSuffix(addr.Value, "."))
+	}
+	return addresses, nil
+}
+
+// tailscaleAddresses returns the tailnet addresses of the Tailscale operator proxy exposing a
+// service: its load balancer addresses when it has the tailscale load balancer class, otherwise
+// the device IPs stored by its proxy in its state Secret, e.g. with the tailscale.com/expose
+// annotation.
+func (ts *targetRefSource) tailscaleAddresses(ctx context.Context, ref targetRef) (endpoint.Targets, error) {
+	kubeClient, err := ts.clientGenerator.KubeClient()
+	if err != nil {
+		return nil, err
+	}
+	svc, err := kubeClient.CoreV1().Services(ref.namespace).Get(ctx, ref.name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	var addresses endpoint.Targets
+	if svc.Spec.LoadBalancerClass != nil && *svc.Spec.LoadBalancerClass == tailscaleLoadBalancerClass {
+		for _, lb := range svc.Status.LoadBalancer.Ingress {
+			if lb.IP != "" {
+				addresses = append(addresses, lb.IP)
+			}
+		}
+		return addresses, nil
+	}
+
+	selector := labels.SelectorFromSet(labels.Set{
+		"tailscale.com/managed":              "true",
+		"tailscale.com/parent-resource":      ref.name,
+		"tailscale.com/parent-resource-ns":   ref.namespace,
+		"tailscale.com/parent-resource-type": "svc",
+	})
+	secrets, err := kubeClient.CoreV1().Secrets(ts.tailscaleNamespace).List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(secrets.Items, func(i, j int) bool {
+		return secrets.Items[i].Name < secrets.Items[j].Name
+	})
+	for _, secret := range secrets.Items {
+		deviceIPs, ok := secret.Data[tailscaleDeviceIPsKey]
+		if !ok {
+			continue
+		}
+		var ips []string
+		if err := json.Unmarshal(deviceIPs, &ips); err != nil {
+			log.Warnf("Ignoring the invalid device IPs of the Tailscale proxy state %s/%s: %v", secret.Namespace, secret.Name, err)
+			continue
+		}
+		addresses = append(addresses, ips...)
+	}
+	return addresses, nil
+}
+
+func (ts *targetRefSource) AddEventHandler(ctx context.Context, handler func()) {
+	ts.source.AddEventHandler(ctx, handler)
+}
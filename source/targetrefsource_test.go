@@ -0,0 +1,181 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakeKube "k8s.io/client-go/kubernetes/fake"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	"sigs.k8s.io/gateway-api/apis/v1beta1"
+	gatewayfake "sigs.k8s.io/gateway-api/pkg/client/clientset/versioned/fake"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+func TestParseTargetRef(t *testing.T) {
+	for _, tc := range []struct {
+		target   string
+		expected targetRef
+		ok       bool
+	}{
+		{target: "service/default/web", expected: targetRef{kind: "service", namespace: "default", name: "web"}, ok: true},
+		{target: "Gateway/infra/public", expected: targetRef{kind: "gateway", namespace: "infra", name: "public"}, ok: true},
+		{target: "tailscale/default/web", expected: targetRef{kind: "tailscale", namespace: "default", name: "web"}, ok: true},
+		{target: "ingress/default/web"},
+		{target: "service/default"},
+		{target: "service//web"},
+		{target: "lb.example.org"},
+	} {
+		t.Run(tc.target, func(t *testing.T) {
+			ref, ok := parseTargetRef(tc.target)
+			assert.Equal(t, tc.ok, ok)
+			assert.Equal(t, tc.expected, ref)
+		})
+	}
+}
+
+func TestTargetRefSource(t *testing.T) {
+	kubeClient := fakeKube.NewClientset(
+		&v1.Service{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "lb"},
+			Spec:       v1.ServiceSpec{Type: v1.ServiceTypeLoadBalancer},
+			Status: v1.ServiceStatus{LoadBalancer: v1.LoadBalancerStatus{Ingress: []v1.LoadBalancerIngress{
+				{IP: "1.2.3.4"},
+				{IP: "2001:db8::1"},
+				{Hostname: "lb.cloud.example.com"},
+			}}},
+		},
+		&v1.Service{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "internal"},
+			Spec:       v1.ServiceSpec{Type: v1.ServiceTypeClusterIP, ClusterIP: "10.0.0.1", ClusterIPs: []string{"10.0.0.1"}},
+		},
+		&v1.Service{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "pending"},
+			Spec:       v1.ServiceSpec{Type: v1.ServiceTypeLoadBalancer, ClusterIP: v1.ClusterIPNone},
+		},
+	)
+	gatewayClient := gatewayfake.NewSimpleClientset()
+	_, err := gatewayClient.GatewayV1beta1().Gateways("infra").Create(t.Context(), &v1beta1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "infra", Name: "public"},
+		Status: v1beta1.GatewayStatus{Addresses: []gatewayv1.GatewayStatusAddress{
+			{Value: "5.6.7.8"},
+		}},
+	}, metav1.CreateOptions{})
+	require.NoError(t, err)
+	clientGenerator := new(MockClientGenerator)
+	clientGenerator.On("KubeClient").Return(kubeClient, nil)
+	clientGenerator.On("GatewayClient").Return(gatewayClient, nil)
+
+	newEndpoint := func(name string, targets ...string) *endpoint.Endpoint {
+		return endpoint.NewEndpoint(name, endpoint.RecordTypeCNAME, targets...).WithLabel(endpoint.ResourceLabelKey, "ingress/default/"+name)
+	}
+	src := NewTargetRefSource(NewEchoSource([]*endpoint.Endpoint{
+		newEndpoint("lb.example.org", "service/default/lb"),
+		newEndpoint("internal.example.org", "service/default/internal", "other.example.org"),
+		newEndpoint("gateway.example.org", "gateway/infra/public"),
+		newEndpoint("pending.example.org", "service/default/pending"),
+		newEndpoint("missing.example.org", "service/default/missing"),
+		newEndpoint("plain.example.org", "plain.cloud.example.com"),
+		endpoint.NewEndpoint("txt.example.org", endpoint.RecordTypeTXT, "service/default/lb"),
+	}), clientGenerator, "tailscale")
+
+	endpoints, err := src.Endpoints(t.Context())
+	require.NoError(t, err)
+	validateEndpoints(t, endpoints, []*endpoint.Endpoint{
+		endpoint.NewEndpoint("lb.example.org", endpoint.RecordTypeA, "1.2.3.4").WithLabel(endpoint.ResourceLabelKey, "ingress/default/lb.example.org"),
+		endpoint.NewEndpoint("lb.example.org", endpoint.RecordTypeAAAA, "2001:db8::1").WithLabel(endpoint.ResourceLabelKey, "ingress/default/lb.example.org"),
+		endpoint.NewEndpoint("lb.example.org", endpoint.RecordTypeCNAME, "lb.cloud.example.com").WithLabel(endpoint.ResourceLabelKey, "ingress/default/lb.example.org"),
+		endpoint.NewEndpoint("internal.example.org", endpoint.RecordTypeA, "10.0.0.1").WithLabel(endpoint.ResourceLabelKey, "ingress/default/internal.example.org"),
+		endpoint.NewEndpoint("internal.example.org", endpoint.RecordTypeCNAME, "other.example.org").WithLabel(endpoint.ResourceLabelKey, "ingress/default/internal.example.org"),
+		endpoint.NewEndpoint("gateway.example.org", endpoint.RecordTypeA, "5.6.7.8").WithLabel(endpoint.ResourceLabelKey, "ingress/default/gateway.example.org"),
+		newEndpoint("plain.example.org", "plain.cloud.example.com"),
+		endpoint.NewEndpoint("txt.example.org", endpoint.RecordTypeTXT, "service/default/lb"),
+	})
+}
+
+func TestTargetRefSourceTailscale(t *testing.T) {
+	tailscaleClass := "tailscale"
+	proxyLabels := func(name string) map[string]string {
+		return map[string]string{
+			"tailscale.com/managed":              "true",
+			"tailscale.com/parent-resource":      name,
+			"tailscale.com/parent-resource-ns":   "default",
+			"tailscale.com/parent-resource-type": "svc",
+		}
+	}
+	kubeClient := fakeKube.NewClientset(
+		&v1.Service{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "lb"},
+			Spec:       v1.ServiceSpec{Type: v1.ServiceTypeLoadBalancer, LoadBalancerClass: &tailscaleClass},
+			Status: v1.ServiceStatus{LoadBalancer: v1.LoadBalancerStatus{Ingress: []v1.LoadBalancerIngress{
+				{IP: "100.64.0.1", Hostname: "default-lb.tailnet.ts.net"},
+				{IP: "fd7a:115c:a1e0::1", Hostname: "default-lb.tailnet.ts.net"},
+			}}},
+		},
+		&v1.Service{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "exposed", Annotations: map[string]string{"tailscale.com/expose": "true"}},
+			Spec:       v1.ServiceSpec{Type: v1.ServiceTypeClusterIP, ClusterIP: "10.0.0.1"},
+		},
+		&v1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "tailscale", Name: "ts-exposed-config", Labels: proxyLabels("exposed")},
+			Data:       map[string][]byte{"cap-95.hujson": []byte("{}")},
+		},
+		&v1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "tailscale", Name: "ts-exposed-0", Labels: proxyLabels("exposed")},
+			Data:       map[string][]byte{"device_ips": []byte(`["100.64.0.2","fd7a:115c:a1e0::2"]`)},
+		},
+		&v1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "tailscale", Name: "ts-other-0", Labels: proxyLabels("other")},
+			Data:       map[string][]byte{"device_ips": []byte(`["100.64.0.3"]`)},
+		},
+	)
+	clientGenerator := new(MockClientGenerator)
+	clientGenerator.On("KubeClient").Return(kubeClient, nil)
+
+	src := NewTargetRefSource(NewEchoSource([]*endpoint.Endpoint{
+		endpoint.NewEndpoint("lb.example.org", endpoint.RecordTypeCNAME, "tailscale/default/lb"),
+		endpoint.NewEndpoint("exposed.example.org", endpoint.RecordTypeCNAME, "tailscale/default/exposed"),
+		endpoint.NewEndpoint("missing.example.org", endpoint.RecordTypeCNAME, "tailscale/default/missing"),
+	}), clientGenerator, "tailscale")
+
+	endpoints, err := src.Endpoints(t.Context())
+	require.NoError(t, err)
+	validateEndpoints(t, endpoints, []*endpoint.Endpoint{
+		endpoint.NewEndpoint("lb.example.org", endpoint.RecordTypeA, "100.64.0.1"),
+		endpoint.NewEndpoint("lb.example.org", endpoint.RecordTypeAAAA, "fd7a:115c:a1e0::1"),
+		endpoint.NewEndpoint("exposed.example.org", endpoint.RecordTypeA, "100.64.0.2"),
+		endpoint.NewEndpoint("exposed.example.org", endpoint.RecordTypeAAAA, "fd7a:115c:a1e0::2"),
+	})
+}
+
+func TestTargetRefSourceWithoutReferences(t *testing.T) {
+	clientGenerator := new(MockClientGenerator)
+	src := NewTargetRefSource(NewEchoSource([]*endpoint.Endpoint{
+		endpoint.NewEndpoint("lb.example.org", endpoint.RecordTypeCNAME, "lb.cloud.example.com"),
+	}), clientGenerator, "tailscale")
+
+	endpoints, err := src.Endpoints(t.Context())
+	require.NoError(t, err)
+	assert.Len(t, endpoints, 1)
+	// no client is created without references
+	clientGenerator.AssertNotCalled(t, "KubeClient")
+}
@@ -23,6 +23,7 @@ import (
 	"regexp"
 	"sort"
 	"strings"
+	"time"
 
 	log "github.com/sirupsen/logrus"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -30,14 +31,17 @@ import (
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/dynamic/dynamicinformer"
 	kubeinformers "k8s.io/client-go/informers"
+	coreinformers "k8s.io/client-go/informers/core/v1"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/tools/cache"
 
 	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/pkg/decisions"
 	"sigs.k8s.io/external-dns/source/annotations"
 	"sigs.k8s.io/external-dns/source/informers"
 )
@@ -82,6 +86,7 @@ var (
 
 type traefikSource struct {
 	annotationFilter           string
+	labelSelector              labels.Selector
 	ignoreHostnameAnnotation   bool
 	dynamicKubeClient          dynamic.Interface
 	ingressRouteInformer       kubeinformers.GenericInformer
@@ -90,15 +95,27 @@ type traefikSource struct {
 	oldIngressRouteInformer    kubeinformers.GenericInformer
 	oldIngressRouteTcpInformer kubeinformers.GenericInformer
 	oldIngressRouteUdpInformer kubeinformers.GenericInformer
-	kubeClient                 kubernetes.Interface
+	serviceInformer            coreinformers.ServiceInformer
 	namespace                  string
+	service                    *types.NamespacedName
 	unstructuredConverter      *unstructuredConverter
+	filterDecisions            *decisions.Recorder
+	propagatedLabels           PropagatedLabels
 }
 
-func NewTraefikSource(ctx context.Context, dynamicKubeClient dynamic.Interface, kubeClient kubernetes.Interface, namespace string, annotationFilter string, ignoreHostnameAnnotation bool, disableLegacy bool, disableNew bool) (Source, error) {
+func NewTraefikSource(ctx context.Context, dynamicKubeClient dynamic.Interface, kubeClient kubernetes.Interface, namespace string, annotationFilter string, labelSelector labels.Selector, ignoreHostnameAnnotation bool, disableLegacy bool, disableNew bool, service string, filterDecisions *decisions.Recorder, resyncPeriod time.Duration, prefixAliases annotations.PrefixAliases, propagatedLabels PropagatedLabels) (Source, error) {
+	var traefikService *types.NamespacedName
+	if service != "" {
+		svcNamespace, svcName, ok := strings.Cut(service, "/")
+		if !ok || svcNamespace == "" || svcName == "" {
+			return nil, fmt.Errorf("invalid Traefik service %q, expected <namespace>/<name>", service)
+		}
+		traefikService = &types.NamespacedName{Namespace: svcNamespace, Name: svcName}
+	}
+
 	// Use shared informer to listen for add/update/delete of Host in the specified namespace.
 	// Set resync period to 0, to prevent processing when nothing has changed.
-	informerFactory := dynamicinformer.NewFilteredDynamicSharedInformerFactory(dynamicKubeClient, 0, namespace, nil)
+	informerFactory := dynamicinformer.NewFilteredDynamicSharedInformerFactory(dynamicKubeClient, resyncPeriod, namespace, labelFilterListOptions(labelSelector, filterDecisions))
 	var ingressRouteInformer, ingressRouteTcpInformer, ingressRouteUdpInformer kubeinformers.GenericInformer
 	var oldIngressRouteInformer, oldIngressRouteTcpInformer, oldIngressRouteUdpInformer kubeinformers.GenericInformer
 
@@ -107,6 +124,7 @@ func NewTraefikSource(ctx context.Context, dynamicKubeClient dynamic.Interface,
 		ingressRouteInformer = informerFactory.ForResource(ingressrouteGVR)
 		ingressRouteTcpInformer = informerFactory.ForResource(ingressrouteTCPGVR)
 		ingressRouteUdpInformer = informerFactory.ForResource(ingressrouteUDPGVR)
+		aliasAnnotations(prefixAliases, ingressRouteInformer.Informer(), ingressRouteTcpInformer.Informer(), ingressRouteUdpInformer.Informer())
 		ingressRouteInformer.Informer().AddEventHandler(
 			cache.ResourceEventHandlerFuncs{
 				AddFunc: func(obj interface{}) {},
@@ -127,6 +145,7 @@ func NewTraefikSource(ctx context.Context, dynamicKubeClient dynamic.Interface,
 		oldIngressRouteInformer = informerFactory.ForResource(oldIngressrouteGVR)
 		oldIngressRouteTcpInformer = informerFactory.ForResource(oldIngressrouteTCPGVR)
 		oldIngressRouteUdpInformer = informerFactory.ForResource(oldIngressrouteUDPGVR)
+		aliasAnnotations(prefixAliases, oldIngressRouteInformer.Informer(), oldIngressRouteTcpInformer.Informer(), oldIngressRouteUdpInformer.Informer())
 		oldIngressRouteInformer.Informer().AddEventHandler(
 			cache.ResourceEventHandlerFuncs{
 				AddFunc: func(obj interface{}) {},
@@ -151,13 +170,23 @@ func NewTraefikSource(ctx context.Context, dynamicKubeClient dynamic.Interface,
 		return nil, err
 	}
 
+	var serviceInformer coreinformers.ServiceInformer
+	if traefikService != nil {
+		var err error
+		if serviceInformer, err = newSingleServiceInformer(ctx, kubeClient, *traefikService, resyncPeriod); err != nil {
+			return nil, err
+		}
+	}
+
 	uc, err := newTraefikUnstructuredConverter()
 	if err != nil {
 		return nil, fmt.Errorf("failed to setup Unstructured Converter: %w", err)
 	}
 
 	return &traefikSource{
+		filterDecisions:            filterDecisions,
 		annotationFilter:           annotationFilter,
+		labelSelector:              labelSelector,
 		ignoreHostnameAnnotation:   ignoreHostnameAnnotation,
 		dynamicKubeClient:          dynamicKubeClient,
 		ingressRouteInformer:       ingressRouteInformer,
@@ -166,52 +195,59 @@ func NewTraefikSource(ctx context.Context, dynamicKubeClient dynamic.Interface,
 		oldIngressRouteInformer:    oldIngressRouteInformer,
 		oldIngressRouteTcpInformer: oldIngressRouteTcpInformer,
 		oldIngressRouteUdpInformer: oldIngressRouteUdpInformer,
-		kubeClient:                 kubeClient,
+		serviceInformer:            serviceInformer,
 		namespace:                  namespace,
+		service:                    traefikService,
 		unstructuredConverter:      uc,
+		propagatedLabels:           propagatedLabels,
 	}, nil
 }
 
-func (ts *traefikSource) Endpoints(_ context.Context) ([]*endpoint.Endpoint, error) {
+func (ts *traefikSource) Endpoints(ctx context.Context) ([]*endpoint.Endpoint, error) {
 	var endpoints []*endpoint.Endpoint
 
+	defaultTargets, err := ts.serviceTargets()
+	if err != nil {
+		return nil, err
+	}
+
 	if ts.ingressRouteInformer != nil {
-		ingressRouteEndpoints, err := ts.ingressRouteEndpoints()
+		ingressRouteEndpoints, err := ts.ingressRouteEndpoints(defaultTargets)
 		if err != nil {
 			return nil, err
 		}
 		endpoints = append(endpoints, ingressRouteEndpoints...)
 	}
 	if ts.oldIngressRouteInformer != nil {
-		oldIngressRouteEndpoints, err := ts.oldIngressRouteEndpoints()
+		oldIngressRouteEndpoints, err := ts.oldIngressRouteEndpoints(defaultTargets)
 		if err != nil {
 			return nil, err
 		}
 		endpoints = append(endpoints, oldIngressRouteEndpoints...)
 	}
 	if ts.ingressRouteTcpInformer != nil {
-		ingressRouteTcpEndpoints, err := ts.ingressRouteTCPEndpoints()
+		ingressRouteTcpEndpoints, err := ts.ingressRouteTCPEndpoints(defaultTargets)
 		if err != nil {
 			return nil, err
 		}
 		endpoints = append(endpoints, ingressRouteTcpEndpoints...)
 	}
 	if ts.oldIngressRouteTcpInformer != nil {
-		oldIngressRouteTcpEndpoints, err := ts.oldIngressRouteTCPEndpoints()
+		oldIngressRouteTcpEndpoints, err := ts.oldIngressRouteTCPEndpoints(defaultTargets)
 		if err != nil {
 			return nil, err
 		}
 		endpoints = append(endpoints, oldIngressRouteTcpEndpoints...)
 	}
 	if ts.ingressRouteUdpInformer != nil {
-		ingressRouteUdpEndpoints, err := ts.ingressRouteUDPEndpoints()
+		ingressRouteUdpEndpoints, err := ts.ingressRouteUDPEndpoints(defaultTargets)
 		if err != nil {
 			return nil, err
 		}
 		endpoints = append(endpoints, ingressRouteUdpEndpoints...)
 	}
 	if ts.oldIngressRouteUdpInformer != nil {
-		oldIngressRouteUdpEndpoints, err := ts.oldIngressRouteUDPEndpoints()
+		oldIngressRouteUdpEndpoints, err := ts.oldIngressRouteUDPEndpoints(defaultTargets)
 		if err != nil {
 			return nil, err
 		}
@@ -225,11 +261,26 @@ func (ts *traefikSource) Endpoints(_ context.Context) ([]*endpoint.Endpoint, err
 	return endpoints, nil
 }
 
+// serviceTargets returns the load balancer addresses of the Traefik service,
+// which are the targets of the resources without a target annotation.
+func (ts *traefikSource) serviceTargets() (endpoint.Targets, error) {
+	if ts.service == nil {
+		return nil, nil
+	}
+	svc, err := ts.serviceInformer.Lister().Services(ts.service.Namespace).Get(ts.service.Name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get Traefik service %s: %w", ts.service, err)
+	}
+	return extractLoadBalancerTargets(svc, false), nil
+}
+
 // ingressRouteEndpoints extracts endpoints from all IngressRoute objects
-func (ts *traefikSource) ingressRouteEndpoints() ([]*endpoint.Endpoint, error) {
+func (ts *traefikSource) ingressRouteEndpoints(defaultTargets endpoint.Targets) ([]*endpoint.Endpoint, error) {
 	return extractEndpoints[IngressRoute](
 		ts.ingressRouteInformer.Lister(),
 		ts.namespace,
+		ts.labelSelector,
+		defaultTargets,
 		func(u *unstructured.Unstructured) (*IngressRoute, error) {
 			typed := &IngressRoute{}
 			return typed, ts.unstructuredConverter.scheme.Convert(u, typed, nil)
@@ -238,14 +289,15 @@ func (ts *traefikSource) ingressRouteEndpoints() ([]*endpoint.Endpoint, error) {
 		func(r *IngressRoute, targets endpoint.Targets) []*endpoint.Endpoint {
 			return ts.endpointsFromIngressRoute(r, targets)
 		},
+		ts.propagatedLabels,
 	)
 }
 
 // ingressRouteTCPEndpoints extracts endpoints from all IngressRouteTCP objects
-func (ts *traefikSource) ingressRouteTCPEndpoints() ([]*endpoint.Endpoint, error) {
+func (ts *traefikSource) ingressRouteTCPEndpoints(defaultTargets endpoint.Targets) ([]*endpoint.Endpoint, error) {
 	var endpoints []*endpoint.Endpoint
 
-	irs, err := ts.ingressRouteTcpInformer.Lister().ByNamespace(ts.namespace).List(labels.Everything())
+	irs, err := ts.ingressRouteTcpInformer.Lister().ByNamespace(ts.namespace).List(ts.labelSelector)
 	if err != nil {
 		return nil, err
 	}
@@ -274,6 +326,9 @@ func (ts *traefikSource) ingressRouteTCPEndpoints() ([]*endpoint.Endpoint, error
 		var targets endpoint.Targets
 
 		targets = append(targets, annotations.TargetsFromTargetAnnotation(ingressRouteTCP.Annotations)...)
+		if len(targets) == 0 {
+			targets = defaultTargets
+		}
 
 		fullname := fmt.Sprintf("%s/%s", ingressRouteTCP.Namespace, ingressRouteTCP.Name)
 
@@ -283,6 +338,7 @@ func (ts *traefikSource) ingressRouteTCPEndpoints() ([]*endpoint.Endpoint, error
 			continue
 		}
 
+		ts.propagatedLabels.propagate(ingressEndpoints, ingressRouteTCP.Labels)
 		log.Debugf("Endpoints generated from IngressRouteTCP: %s: %v", fullname, ingressEndpoints)
 		endpoints = append(endpoints, ingressEndpoints...)
 	}
@@ -291,24 +347,29 @@ func (ts *traefikSource) ingressRouteTCPEndpoints() ([]*endpoint.Endpoint, error
 }
 
 // ingressRouteUDPEndpoints extracts endpoints from all IngressRouteUDP objects
-func (ts *traefikSource) ingressRouteUDPEndpoints() ([]*endpoint.Endpoint, error) {
+func (ts *traefikSource) ingressRouteUDPEndpoints(defaultTargets endpoint.Targets) ([]*endpoint.Endpoint, error) {
 	return extractEndpoints[IngressRouteUDP](
 		ts.ingressRouteUdpInformer.Lister(),
 		ts.namespace,
+		ts.labelSelector,
+		defaultTargets,
 		func(u *unstructured.Unstructured) (*IngressRouteUDP, error) {
 			typed := &IngressRouteUDP{}
 			return typed, ts.unstructuredConverter.scheme.Convert(u, typed, nil)
 		},
 		ts.filterIngressRouteUdpByAnnotations,
 		ts.endpointsFromIngressRouteUDP,
+		ts.propagatedLabels,
 	)
 }
 
 // oldIngressRouteEndpoints extracts endpoints from all IngressRoute objects
-func (ts *traefikSource) oldIngressRouteEndpoints() ([]*endpoint.Endpoint, error) {
+func (ts *traefikSource) oldIngressRouteEndpoints(defaultTargets endpoint.Targets) ([]*endpoint.Endpoint, error) {
 	return extractEndpoints[IngressRoute](
 		ts.oldIngressRouteInformer.Lister(),
 		ts.namespace,
+		ts.labelSelector,
+		defaultTargets,
 		func(u *unstructured.Unstructured) (*IngressRoute, error) {
 			typed := &IngressRoute{}
 			return typed, ts.unstructuredConverter.scheme.Convert(u, typed, nil)
@@ -317,34 +378,41 @@ func (ts *traefikSource) oldIngressRouteEndpoints() ([]*endpoint.Endpoint, error
 		func(r *IngressRoute, targets endpoint.Targets) []*endpoint.Endpoint {
 			return ts.endpointsFromIngressRoute(r, targets)
 		},
+		ts.propagatedLabels,
 	)
 }
 
 // oldIngressRouteTCPEndpoints extracts endpoints from all IngressRouteTCP objects
-func (ts *traefikSource) oldIngressRouteTCPEndpoints() ([]*endpoint.Endpoint, error) {
+func (ts *traefikSource) oldIngressRouteTCPEndpoints(defaultTargets endpoint.Targets) ([]*endpoint.Endpoint, error) {
 	return extractEndpoints[IngressRouteTCP](
 		ts.oldIngressRouteTcpInformer.Lister(),
 		ts.namespace,
+		ts.labelSelector,
+		defaultTargets,
 		func(u *unstructured.Unstructured) (*IngressRouteTCP, error) {
 			typed := &IngressRouteTCP{}
 			return typed, ts.unstructuredConverter.scheme.Convert(u, typed, nil)
 		},
 		ts.filterIngressRouteTcpByAnnotations,
 		ts.endpointsFromIngressRouteTCP,
+		ts.propagatedLabels,
 	)
 }
 
 // oldIngressRouteUDPEndpoints extracts endpoints from all IngressRouteUDP objects
-func (ts *traefikSource) oldIngressRouteUDPEndpoints() ([]*endpoint.Endpoint, error) {
+func (ts *traefikSource) oldIngressRouteUDPEndpoints(defaultTargets endpoint.Targets) ([]*endpoint.Endpoint, error) {
 	return extractEndpoints[IngressRouteUDP](
 		ts.oldIngressRouteUdpInformer.Lister(),
 		ts.namespace,
+		ts.labelSelector,
+		defaultTargets,
 		func(u *unstructured.Unstructured) (*IngressRouteUDP, error) {
 			typed := &IngressRouteUDP{}
 			return typed, ts.unstructuredConverter.scheme.Convert(u, typed, nil)
 		},
 		ts.filterIngressRouteUdpByAnnotations,
 		ts.endpointsFromIngressRouteUDP,
+		ts.propagatedLabels,
 	)
 }
 
@@ -380,9 +448,8 @@ func (ts *traefikSource) endpointsFromIngressRoute(ingressRoute *IngressRoute, t
 	providerSpecific, setIdentifier := annotations.ProviderSpecificAnnotations(ingressRoute.Annotations)
 
 	if !ts.ignoreHostnameAnnotation {
-		hostnameList := annotations.HostnamesFromAnnotations(ingressRoute.Annotations)
-		for _, hostname := range hostnameList {
-			endpoints = append(endpoints, endpointsForHostname(hostname, targets, ttl, providerSpecific, setIdentifier, resource)...)
+		for _, entry := range annotations.HostnameEntriesFromAnnotations(ingressRoute.Annotations, resource) {
+			endpoints = append(endpoints, endpointsForHostnameEntry(entry, targets, ttl, providerSpecific, setIdentifier, resource)...)
 		}
 	}
 
@@ -413,9 +480,8 @@ func (ts *traefikSource) endpointsFromIngressRouteTCP(ingressRoute *IngressRoute
 	providerSpecific, setIdentifier := annotations.ProviderSpecificAnnotations(ingressRoute.Annotations)
 
 	if !ts.ignoreHostnameAnnotation {
-		hostnameList := annotations.HostnamesFromAnnotations(ingressRoute.Annotations)
-		for _, hostname := range hostnameList {
-			endpoints = append(endpoints, endpointsForHostname(hostname, targets, ttl, providerSpecific, setIdentifier, resource)...)
+		for _, entry := range annotations.HostnameEntriesFromAnnotations(ingressRoute.Annotations, resource) {
+			endpoints = append(endpoints, endpointsForHostnameEntry(entry, targets, ttl, providerSpecific, setIdentifier, resource)...)
 		}
 	}
 
@@ -446,9 +512,8 @@ func (ts *traefikSource) endpointsFromIngressRouteUDP(ingressRoute *IngressRoute
 	providerSpecific, setIdentifier := annotations.ProviderSpecificAnnotations(ingressRoute.Annotations)
 
 	if !ts.ignoreHostnameAnnotation {
-		hostnameList := annotations.HostnamesFromAnnotations(ingressRoute.Annotations)
-		for _, hostname := range hostnameList {
-			endpoints = append(endpoints, endpointsForHostname(hostname, targets, ttl, providerSpecific, setIdentifier, resource)...)
+		for _, entry := range annotations.HostnameEntriesFromAnnotations(ingressRoute.Annotations, resource) {
+			endpoints = append(endpoints, endpointsForHostnameEntry(entry, targets, ttl, providerSpecific, setIdentifier, resource)...)
 		}
 	}
 
@@ -479,6 +544,10 @@ func (ts *traefikSource) AddEventHandler(ctx context.Context, handler func()) {
 	if ts.oldIngressRouteUdpInformer != nil {
 		ts.oldIngressRouteUdpInformer.Informer().AddEventHandler(eventHandlerFunc(handler))
 	}
+	if ts.serviceInformer != nil {
+		log.Debug("Adding event handler for the Traefik service")
+		ts.serviceInformer.Informer().AddEventHandler(eventHandlerFunc(handler))
+	}
 }
 
 // newTraefikUnstructuredConverter returns a new unstructuredConverter initialized
@@ -842,17 +911,21 @@ func (in *IngressRouteUDPList) DeepCopyObject() runtime.Object {
 // 2. Converts the unstructured objects to the desired type using the convertFunc.
 // 3. Filters the converted objects based on the provided filterFunc.
 // 4. Generates endpoints for each filtered object using the generateEndpoints function.
+// 5. Copies the propagated labels of each object to its endpoints.
 // Returns a list of generated endpoints or an error if any step fails.
 func extractEndpoints[T any](
 	informer cache.GenericLister,
 	namespace string,
+	selector labels.Selector,
+	defaultTargets endpoint.Targets,
 	convertFunc func(*unstructured.Unstructured) (*T, error),
 	filterFunc func([]*T) ([]*T, error),
 	generateEndpoints func(*T, endpoint.Targets) []*endpoint.Endpoint,
+	propagatedLabels PropagatedLabels,
 ) ([]*endpoint.Endpoint, error) {
 	var endpoints []*endpoint.Endpoint
 
-	objs, err := informer.ByNamespace(namespace).List(labels.Everything())
+	objs, err := informer.ByNamespace(namespace).List(selector)
 	if err != nil {
 		return nil, err
 	}
@@ -878,6 +951,9 @@ func extractEndpoints[T any](
 
 	for _, item := range typedObjs {
 		targets := annotations.TargetsFromTargetAnnotation(getAnnotations(item))
+		if len(targets) == 0 {
+			targets = defaultTargets
+		}
 
 		name := getObjectFullName(item)
 		ingressEndpoints := generateEndpoints(item, targets)
@@ -887,6 +963,7 @@ func extractEndpoints[T any](
 			continue
 		}
 
+		propagatedLabels.propagate(ingressEndpoints, getLabels(item))
 		log.Debugf("Endpoints generated from %s: %v", name, ingressEndpoints)
 		endpoints = append(endpoints, ingressEndpoints...)
 	}
@@ -939,6 +1016,19 @@ func getAnnotations(obj interface{}) map[string]string {
 	}
 }
 
+func getLabels(obj interface{}) map[string]string {
+	switch o := obj.(type) {
+	case *IngressRouteUDP:
+		return o.Labels
+	case *IngressRoute:
+		return o.Labels
+	case *IngressRouteTCP:
+		return o.Labels
+	default:
+		return nil
+	}
+}
+
 func getObjectFullName(obj interface{}) string {
 	switch o := obj.(type) {
 	case *IngressRouteUDP:
@@ -24,12 +24,14 @@ import (
 
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/tools/cache"
 
 	"github.com/stretchr/testify/assert"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	fakeDynamic "k8s.io/client-go/dynamic/fake"
 	fakeKube "k8s.io/client-go/kubernetes/fake"
@@ -355,7 +357,7 @@ func TestTraefikProxyIngressRouteEndpoints(t *testing.T) {
 			_, err = fakeDynamicClient.Resource(ingressrouteGVR).Namespace(defaultTraefikNamespace).Create(context.Background(), &ir, metav1.CreateOptions{})
 			assert.NoError(t, err)
 
-			source, err := NewTraefikSource(context.TODO(), fakeDynamicClient, fakeKubernetesClient, defaultTraefikNamespace, "kubernetes.io/ingress.class=traefik", ti.ignoreHostnameAnnotation, false, false)
+			source, err := NewTraefikSource(context.TODO(), fakeDynamicClient, fakeKubernetesClient, defaultTraefikNamespace, "kubernetes.io/ingress.class=traefik", labels.Everything(), ti.ignoreHostnameAnnotation, false, false, "", nil, 0, nil, nil)
 			assert.NoError(t, err)
 			assert.NotNil(t, source)
 
@@ -649,7 +651,7 @@ func TestTraefikProxyIngressRouteTCPEndpoints(t *testing.T) {
 			_, err = fakeDynamicClient.Resource(ingressrouteTCPGVR).Namespace(defaultTraefikNamespace).Create(context.Background(), &ir, metav1.CreateOptions{})
 			require.NoError(t, err)
 
-			source, err := NewTraefikSource(context.TODO(), fakeDynamicClient, fakeKubernetesClient, defaultTraefikNamespace, "kubernetes.io/ingress.class=traefik", ti.ignoreHostnameAnnotation, false, false)
+			source, err := NewTraefikSource(context.TODO(), fakeDynamicClient, fakeKubernetesClient, defaultTraefikNamespace, "kubernetes.io/ingress.class=traefik", labels.Everything(), ti.ignoreHostnameAnnotation, false, false, "", nil, 0, nil, nil)
 			require.NoError(t, err)
 			assert.NotNil(t, source)
 
@@ -791,7 +793,7 @@ func TestTraefikProxyIngressRouteUDPEndpoints(t *testing.T) {
 			_, err = fakeDynamicClient.Resource(ingressrouteUDPGVR).Namespace(defaultTraefikNamespace).Create(context.Background(), &ir, metav1.CreateOptions{})
 			assert.NoError(t, err)
 
-			source, err := NewTraefikSource(context.TODO(), fakeDynamicClient, fakeKubernetesClient, defaultTraefikNamespace, "kubernetes.io/ingress.class=traefik", ti.ignoreHostnameAnnotation, false, false)
+			source, err := NewTraefikSource(context.TODO(), fakeDynamicClient, fakeKubernetesClient, defaultTraefikNamespace, "kubernetes.io/ingress.class=traefik", labels.Everything(), ti.ignoreHostnameAnnotation, false, false, "", nil, 0, nil, nil)
 			assert.NoError(t, err)
 			assert.NotNil(t, source)
 
@@ -1121,7 +1123,7 @@ func TestTraefikProxyOldIngressRouteEndpoints(t *testing.T) {
 			_, err = fakeDynamicClient.Resource(oldIngressrouteGVR).Namespace(defaultTraefikNamespace).Create(context.Background(), &ir, metav1.CreateOptions{})
 			assert.NoError(t, err)
 
-			source, err := NewTraefikSource(context.TODO(), fakeDynamicClient, fakeKubernetesClient, defaultTraefikNamespace, "kubernetes.io/ingress.class=traefik", ti.ignoreHostnameAnnotation, false, false)
+			source, err := NewTraefikSource(context.TODO(), fakeDynamicClient, fakeKubernetesClient, defaultTraefikNamespace, "kubernetes.io/ingress.class=traefik", labels.Everything(), ti.ignoreHostnameAnnotation, false, false, "", nil, 0, nil, nil)
 			assert.NoError(t, err)
 			assert.NotNil(t, source)
 
@@ -1415,7 +1417,7 @@ func TestTraefikProxyOldIngressRouteTCPEndpoints(t *testing.T) {
 			_, err = fakeDynamicClient.Resource(oldIngressrouteTCPGVR).Namespace(defaultTraefikNamespace).Create(context.Background(), &ir, metav1.CreateOptions{})
 			assert.NoError(t, err)
 
-			source, err := NewTraefikSource(context.TODO(), fakeDynamicClient, fakeKubernetesClient, defaultTraefikNamespace, "kubernetes.io/ingress.class=traefik", ti.ignoreHostnameAnnotation, false, false)
+			source, err := NewTraefikSource(context.TODO(), fakeDynamicClient, fakeKubernetesClient, defaultTraefikNamespace, "kubernetes.io/ingress.class=traefik", labels.Everything(), ti.ignoreHostnameAnnotation, false, false, "", nil, 0, nil, nil)
 			assert.NoError(t, err)
 			assert.NotNil(t, source)
 
@@ -1557,7 +1559,7 @@ func TestTraefikProxyOldIngressRouteUDPEndpoints(t *testing.T) {
 			_, err = fakeDynamicClient.Resource(oldIngressrouteUDPGVR).Namespace(defaultTraefikNamespace).Create(context.Background(), &ir, metav1.CreateOptions{})
 			assert.NoError(t, err)
 
-			source, err := NewTraefikSource(context.TODO(), fakeDynamicClient, fakeKubernetesClient, defaultTraefikNamespace, "kubernetes.io/ingress.class=traefik", ti.ignoreHostnameAnnotation, false, false)
+			source, err := NewTraefikSource(context.TODO(), fakeDynamicClient, fakeKubernetesClient, defaultTraefikNamespace, "kubernetes.io/ingress.class=traefik", labels.Everything(), ti.ignoreHostnameAnnotation, false, false, "", nil, 0, nil, nil)
 			assert.NoError(t, err)
 			assert.NotNil(t, source)
 
@@ -1720,7 +1722,7 @@ func TestTraefikAPIGroupDisableFlags(t *testing.T) {
 			_, err = fakeDynamicClient.Resource(ti.gvr).Namespace(defaultTraefikNamespace).Create(context.Background(), &ir, metav1.CreateOptions{})
 			assert.NoError(t, err)
 
-			source, err := NewTraefikSource(context.TODO(), fakeDynamicClient, fakeKubernetesClient, defaultTraefikNamespace, "kubernetes.io/ingress.class=traefik", ti.ignoreHostnameAnnotation, ti.disableLegacy, ti.disableNew)
+			source, err := NewTraefikSource(context.TODO(), fakeDynamicClient, fakeKubernetesClient, defaultTraefikNamespace, "kubernetes.io/ingress.class=traefik", labels.Everything(), ti.ignoreHostnameAnnotation, ti.disableLegacy, ti.disableNew, "", nil, 0, nil, nil)
 			assert.NoError(t, err)
 			assert.NotNil(t, source)
 
@@ -1737,6 +1739,66 @@ func TestTraefikAPIGroupDisableFlags(t *testing.T) {
 	}
 }
 
+func TestTraefikProxyServiceTargets(t *testing.T) {
+	t.Parallel()
+
+	toUnstructured := func(obj runtime.Object) *unstructured.Unstructured {
+		content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+		require.NoError(t, err)
+		return &unstructured.Unstructured{Object: content}
+	}
+	meta := func(name string, annotations map[string]string) metav1.ObjectMeta {
+		annotations["kubernetes.io/ingress.class"] = "traefik"
+		return metav1.ObjectMeta{Name: name, Namespace: defaultTraefikNamespace, Annotations: annotations}
+	}
+
+	scheme := runtime.NewScheme()
+	scheme.AddKnownTypes(ingressrouteGVR.GroupVersion(), &IngressRoute{}, &IngressRouteList{})
+	scheme.AddKnownTypes(ingressrouteTCPGVR.GroupVersion(), &IngressRouteTCP{}, &IngressRouteTCPList{})
+	scheme.AddKnownTypes(ingressrouteUDPGVR.GroupVersion(), &IngressRouteUDP{}, &IngressRouteUDPList{})
+	fakeDynamicClient := fakeDynamic.NewSimpleDynamicClient(scheme,
+		toUnstructured(&IngressRoute{
+			TypeMeta:   metav1.TypeMeta{APIVersion: ingressrouteGVR.GroupVersion().String(), Kind: "IngressRoute"},
+			ObjectMeta: meta("web", map[string]string{"external-dns.alpha.kubernetes.io/target": "target.domain.tld"}),
+			Spec:       traefikIngressRouteSpec{Routes: []traefikRoute{{Match: "Host(`web.example.com`)"}}},
+		}),
+		toUnstructured(&IngressRouteTCP{
+			TypeMeta:   metav1.TypeMeta{APIVersion: ingressrouteTCPGVR.GroupVersion().String(), Kind: "IngressRouteTCP"},
+			ObjectMeta: meta("db", map[string]string{}),
+			Spec:       traefikIngressRouteTCPSpec{Routes: []traefikRouteTCP{{Match: "HostSNI(`db.example.com`) || HostSNI(`*`)"}}},
+		}),
+		toUnstructured(&IngressRouteUDP{
+			TypeMeta:   metav1.TypeMeta{APIVersion: ingressrouteUDPGVR.GroupVersion().String(), Kind: "IngressRouteUDP"},
+			ObjectMeta: meta("dns", map[string]string{"external-dns.alpha.kubernetes.io/hostname": "dns.example.com"}),
+		}),
+	)
+	fakeKubernetesClient := fakeKube.NewClientset(&corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "traefik", Namespace: "kube-system"},
+		Status: corev1.ServiceStatus{LoadBalancer: corev1.LoadBalancerStatus{
+			Ingress: []corev1.LoadBalancerIngress{{IP: "1.2.3.4"}},
+		}},
+	})
+
+	_, err := NewTraefikSource(t.Context(), fakeDynamicClient, fakeKubernetesClient, defaultTraefikNamespace, "kubernetes.io/ingress.class=traefik", labels.Everything(), false, true, false, "traefik", nil, 0, nil, nil)
+	require.Error(t, err)
+
+	source, err := NewTraefikSource(t.Context(), fakeDynamicClient, fakeKubernetesClient, defaultTraefikNamespace, "kubernetes.io/ingress.class=traefik", labels.Everything(), false, true, false, "kube-system/traefik", nil, 0, nil, nil)
+	require.NoError(t, err)
+
+	endpoints, err := source.Endpoints(t.Context())
+	require.NoError(t, err)
+	validateEndpoints(t, endpoints, []*endpoint.Endpoint{
+		{DNSName: "web.example.com", Targets: endpoint.Targets{"target.domain.tld"}, RecordType: endpoint.RecordTypeCNAME},
+		{DNSName: "db.example.com", Targets: endpoint.Targets{"1.2.3.4"}, RecordType: endpoint.RecordTypeA},
+		{DNSName: "dns.example.com", Targets: endpoint.Targets{"1.2.3.4"}, RecordType: endpoint.RecordTypeA},
+	})
+
+	missing, err := NewTraefikSource(t.Context(), fakeDynamicClient, fakeKubernetesClient, defaultTraefikNamespace, "kubernetes.io/ingress.class=traefik", labels.Everything(), false, true, false, "kube-system/missing", nil, 0, nil, nil)
+	require.NoError(t, err)
+	_, err = missing.Endpoints(t.Context())
+	require.Error(t, err)
+}
+
 func TestAddEventHandler_AllBranches(t *testing.T) {
 	ctx := context.Background()
 	handlerCalled := false
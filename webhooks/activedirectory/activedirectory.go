@@ -0,0 +1,318 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package activedirectory
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+
+	"github.com/go-ldap/ldap/v3"
+	"github.com/miekg/dns"
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider"
+)
+
+const (
+	// defaultTTL is the default TTL of the records of an AD-integrated zone.
+	defaultTTL = 3600
+	// apexNodeName is the name of the node of the apex of a zone.
+	apexNodeName = "@"
+	// rootHintsZoneName is the name of the zone of the root hints, which is not a DNS zone.
+	rootHintsZoneName = "RootDNSServers"
+)
+
+var (
+	// ErrNoDNSContainer is returned when there is no DNS container configured.
+	ErrNoDNSContainer = errors.New("no Active Directory DNS container found in the flags")
+	// ErrNoKerberosCredentials is returned when neither or both of a password and a keytab are configured.
+	ErrNoKerberosCredentials = errors.New("the Active Directory provider requires a Kerberos username and either a password or a keytab")
+)
+
+// ActiveDirectoryProvider is an implementation of Provider for the AD-integrated zones of Active
+// Directory, writing their dnsNode objects through LDAP.
+type ActiveDirectoryProvider struct {
+	provider.BaseProvider
+	dir          directory
+	domainFilter *endpoint.DomainFilter
+	dryRun       bool
+}
+
+// ActiveDirectoryConfig is used for configuring an ActiveDirectoryProvider.
+type ActiveDirectoryConfig struct {
+	// The URL of a domain controller, ldaps:// or ldap:// upgraded with StartTLS.
+	LDAPURL string
+	// The DN of the DNS container of the partition holding the zones, e.g.
+	// CN=MicrosoftDNS,DC=DomainDnsZones,DC=corp,DC=example,DC=com.
+	DNSContainerDN string
+	// The Kerberos realm, the default realm of the Kerberos configuration when empty.
+	KerberosRealm string
+	// The Kerberos principal, authenticated with either a password or a keytab.
+	KerberosUsername string
+	KerberosPassword string
+	KerberosKeytab   string
+	// The path of the Kerberos configuration.
+	KerberosConfig string
+	// A filter to apply when looking up and applying records.
+	DomainFilter *endpoint.DomainFilter
+	// Do nothing and log what would have changed to stdout.
+	DryRun bool
+}
+
+// Helper struct for grouping the records of an endpoint.
+type adEntryKey struct {
+	DNSName    string
+	RecordType string
+}
+
+// NewActiveDirectoryProvider initializes a new Active Directory based Provider.
+func NewActiveDirectoryProvider(cfg ActiveDirectoryConfig) (*ActiveDirectoryProvider, error) {
+	if cfg.DNSContainerDN == "" {
+		return nil, ErrNoDNSContainer
+	}
+	dir, err := newLDAPDirectory(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &ActiveDirectoryProvider{dir: dir, domainFilter: cfg.DomainFilter, dryRun: cfg.DryRun}, nil
+}
+
+// Capabilities implements provider.CapabilitiesReporter.
+func (p *ActiveDirectoryProvider) Capabilities() provider.Capabilities {
+	return provider.Capabilities{
+		RecordTypes: []string{endpoint.RecordTypeA, endpoint.RecordTypeAAAA, endpoint.RecordTypeCNAME, endpoint.RecordTypeTXT, endpoint.RecordTypeSRV, endpoint.RecordTypeMX},
+	}
+}
+
+// zones returns the DNs and names of the zones matching the domain filter.
+func (p *ActiveDirectoryProvider) zones(ctx context.Context) (provider.ZoneIDName, error) {
+	zones, err := p.dir.zones(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	zoneNames := provider.ZoneIDName{}
+	for _, z := range zones {
+		// The zones being created or deleted are prefixed with ..InProgress, and the trust anchors
+		// of DNSSEC live in ..TrustAnchors.
+		if z.Name == rootHintsZoneName || strings.HasPrefix(z.Name, "..") {
+			continue
+		}
+		if !p.domainFilter.Match(z.Name) {
+			continue
+		}
+		zoneNames.Add(z.DN, strings.ToLower(z.Name))
+	}
+	return zoneNames, nil
+}
+
+// Records implements Provider, populating a slice of endpoints from the nodes of the zones.
+func (p *ActiveDirectoryProvider) Records(ctx context.Context) ([]*endpoint.Endpoint, error) {
+	zones, err := p.zones(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var endpoints []*endpoint.Endpoint
+	for zoneDN, zoneName := range zones {
+		nodes, err := p.dir.nodes(ctx, zoneDN)
+		if err != nil {
+			return nil, err
+		}
+
+		byKey := make(map[adEntryKey]*endpoint.Endpoint)
+		for _, node := range nodes {
+			if node.Tombstoned {
+				continue
+			}
+			name := nodeDNSName(node.Name, zoneName)
+			for _, value := range node.Records {
+				r, err := decodeRecord(value)
+				if err != nil {
+					log.Warnf("Skipping a record of %s: %v", name, err)
+					continue
+				}
+				target, ok, err := r.target()
+				if err != nil {
+					log.Warnf("Skipping a %s record of %s: %v", dns.TypeToString[r.Type], name, err)
+					continue
+				}
+				if !ok {
+					continue
+				}
+				key := adEntryKey{name, dns.TypeToString[r.Type]}
+				if ep, ok := byKey[key]; ok {
+					ep.Targets = append(ep.Targets, target)
+					continue
+				}
+				ep := endpoint.NewEndpointWithTTL(name, key.RecordType, endpoint.TTL(r.TTL), target)
+				byKey[key] = ep
+				endpoints = append(endpoints, ep)
+			}
+		}
+	}
+	return endpoints, nil
+}
+
+// nodeDNSName returns the DNS name of a node of a zone.
+func nodeDNSName(nodeName, zoneName string) string {
+	if nodeName == apexNodeName {
+		return zoneName
+	}
+	return strings.ToLower(nodeName) + "." + zoneName
+}
+
+// nodeChange is the change of the records of a node.
+type nodeChange struct {
+	zoneDN   string
+	nodeName string
+	// removed are the types whose records are replaced.
+	removed []uint16
+	added   []*endpoint.Endpoint
+}
+
+// ApplyChanges implements Provider, rewriting the records of the nodes which changed.
+//
+// A dnsNode object holds all the records of a name, so each node is read and replaced as a whole.
+func (p *ActiveDirectoryProvider) ApplyChanges(ctx context.Context, changes *plan.Changes) error {
+	zones, err := p.zones(ctx)
+	if err != nil {
+		return err
+	}
+
+	nodes := make(map[string]*nodeChange)
+	change := func(ep *endpoint.Endpoint) *nodeChange {
+		zoneDN, zoneName := zones.FindZone(ep.DNSName)
+		if zoneDN == "" {
+			log.Debugf("Skipping record %s because no zone was found", ep.DNSName)
+			return nil
+		}
+		name := strings.ToLower(strings.TrimSuffix(ep.DNSName, "."))
+		nodeName := strings.TrimSuffix(name, "."+zoneName)
+		if name == zoneName {
+			nodeName = apexNodeName
+		}
+		dn := "DC=" + ldap.EscapeDN(nodeName) + "," + zoneDN
+		if nodes[dn] == nil {
+			nodes[dn] = &nodeChange{zoneDN: zoneDN, nodeName: nodeName}
+		}
+		return nodes[dn]
+	}
+	for _, ep := range slices.Concat(changes.Delete, changes.UpdateOld) {
+		if c := change(ep); c != nil {
+			c.removed = append(c.removed, dns.StringToType[ep.RecordType])
+		}
+	}
+	for _, ep := range slices.Concat(changes.UpdateNew, changes.Create) {
+		if c := change(ep); c != nil {
+			c.removed = append(c.removed, dns.StringToType[ep.RecordType])
+			c.added = append(c.added, ep)
+		}
+	}
+
+	serials := make(map[string]uint32)
+	nodeDNs := make([]string, 0, len(nodes))
+	for dn := range nodes {
+		nodeDNs = append(nodeDNs, dn)
+	}
+	slices.Sort(nodeDNs)
+	for _, dn := range nodeDNs {
+		c := nodes[dn]
+		if _, ok := serials[c.zoneDN]; !ok {
+			serials[c.zoneDN] = p.nextSerial(ctx, c.zoneDN)
+		}
+		if err := p.applyNode(ctx, dn, c, serials[c.zoneDN]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// nextSerial returns the serial to write in the new records of a zone, the one following the
+// serial of its SOA record.
+func (p *ActiveDirectoryProvider) nextSerial(ctx context.Context, zoneDN string) uint32 {
+	apex, err := p.dir.node(ctx, "DC="+apexNodeName+","+zoneDN)
+	if err != nil || apex == nil {
+		log.Debugf("Failed to get the SOA record of %s, using serial 1: %v", zoneDN, err)
+		return 1
+	}
+	for _, value := range apex.Records {
+		if r, err := decodeRecord(value); err == nil {
+			if serial, ok := r.soaSerial(); ok {
+				return serial + 1
+			}
+		}
+	}
+	return 1
+}
+
+// applyNode replaces the records of the changed types of a node.
+func (p *ActiveDirectoryProvider) applyNode(ctx context.Context, dn string, c *nodeChange, serial uint32) error {
+	node, err := p.dir.node(ctx, dn)
+	if err != nil {
+		return err
+	}
+
+	var records [][]byte
+	if node != nil && !node.Tombstoned {
+		for _, value := range node.Records {
+			// The values which cannot be decoded are kept as they are.
+			if r, err := decodeRecord(value); err == nil && slices.Contains(c.removed, r.Type) {
+				continue
+			}
+			records = append(records, value)
+		}
+	}
+	for _, ep := range c.added {
+		ttl := uint32(defaultTTL)
+		if ep.RecordTTL.IsConfigured() {
+			ttl = uint32(ep.RecordTTL)
+		}
+		for _, target := range ep.Targets {
+			r, err := newRecord(ep.RecordType, target, ttl, serial)
+			if err != nil {
+				return provider.NewSoftError(err)
+			}
+			records = append(records, r.encode())
+		}
+	}
+
+	switch {
+	case len(records) == 0 && (node == nil || node.Tombstoned):
+		return nil
+	case len(records) == 0:
+		return p.run("Deleting node "+dn, func() error { return p.dir.deleteNode(ctx, dn) })
+	case node == nil:
+		return p.run("Adding node "+dn, func() error { return p.dir.addNode(ctx, dn, c.nodeName, records) })
+	default:
+		return p.run("Updating node "+dn, func() error { return p.dir.replaceRecords(ctx, dn, records) })
+	}
+}
+
+// run runs an action against the directory, or only logs it in dry-run mode.
+func (p *ActiveDirectoryProvider) run(action string, fn func() error) error {
+	if p.dryRun {
+		log.Infof("DRY RUN: %s", action)
+		return nil
+	}
+	log.Info(action)
+	return fn()
+}
@@ -0,0 +1,314 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package activedirectory
+
+import (
+	"context"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/miekg/dns"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider"
+)
+
+const testContainerDN = "CN=MicrosoftDNS,DC=DomainDnsZones,DC=corp,DC=example,DC=com"
+
+// testDirectory holds the nodes in memory, by DN, and records the operations.
+type testDirectory struct {
+	t          *testing.T
+	zoneList   []dnsZone
+	nodesByDN  map[string]*dnsNode
+	operations []string
+}
+
+func (d *testDirectory) zones(_ context.Context) ([]dnsZone, error) {
+	return d.zoneList, nil
+}
+
+func (d *testDirectory) nodes(_ context.Context, zoneDN string) ([]dnsNode, error) {
+	var nodes []dnsNode
+	for dn, node := range d.nodesByDN {
+		if strings.HasSuffix(dn, ","+zoneDN) {
+			nodes = append(nodes, *node)
+		}
+	}
+	slices.SortFunc(nodes, func(a, b dnsNode) int { return strings.Compare(a.DN, b.DN) })
+	return nodes, nil
+}
+
+func (d *testDirectory) node(_ context.Context, dn string) (*dnsNode, error) {
+	if node, ok := d.nodesByDN[dn]; ok {
+		n := *node
+		return &n, nil
+	}
+	return nil, nil
+}
+
+func (d *testDirectory) addNode(ctx context.Context, dn, name string, records [][]byte) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	d.operations = append(d.operations, "add "+dn+" "+d.targets(records))
+	d.nodesByDN[dn] = &dnsNode{Name: name, DN: dn, Records: records}
+	return nil
+}
+
+func (d *testDirectory) replaceRecords(ctx context.Context, dn string, records [][]byte) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	d.operations = append(d.operations, "replace "+dn+" "+d.targets(records))
+	d.nodesByDN[dn].Records = records
+	d.nodesByDN[dn].Tombstoned = false
+	return nil
+}
+
+func (d *testDirectory) deleteNode(ctx context.Context, dn string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	d.operations = append(d.operations, "delete "+dn)
+	delete(d.nodesByDN, dn)
+	return nil
+}
+
+// targets describes records for the recorded operations.
+func (d *testDirectory) targets(records [][]byte) string {
+	var s []string
+	for _, value := range records {
+		r, err := decodeRecord(value)
+		require.NoError(d.t, err)
+		target, _, _ := r.target()
+		if r.Type == dns.TypeSOA {
+			target = "..."
+		}
+		s = append(s, dns.TypeToString[r.Type]+"="+target)
+	}
+	return strings.Join(s, ",")
+}
+
+// testNode returns a node with the given records, each given as type, TTL and target.
+func testNode(t *testing.T, name, zoneDN string, records ...[3]string) *dnsNode {
+	t.Helper()
+	node := &dnsNode{Name: name, DN: "DC=" + name + "," + zoneDN}
+	for _, r := range records {
+		var value []byte
+		if r[0] == "SOA" {
+			value = dnsRecord{Type: dns.TypeSOA, Serial: 41, TTL: 3600, Data: []byte{0, 0, 0, 41, 0, 0, 0, 0}}.encode()
+		} else {
+			ttl, err := strconv.ParseUint(r[1], 10, 32)
+			require.NoError(t, err)
+			rec, err := newRecord(r[0], r[2], uint32(ttl), 41)
+			require.NoError(t, err)
+			value = rec.encode()
+		}
+		node.Records = append(node.Records, value)
+	}
+	return node
+}
+
+func newTestDirectory(t *testing.T) *testDirectory {
+	zoneDN := "DC=example.com," + testContainerDN
+	nodes := []*dnsNode{
+		testNode(t, "@", zoneDN, [3]string{"SOA"}, [3]string{"MX", "300", "10 mail.example.com"}),
+		testNode(t, "www", zoneDN, [3]string{"A", "300", "192.0.2.1"}, [3]string{"A", "300", "192.0.2.2"}, [3]string{"TXT", "300", "heritage=external-dns"}),
+		testNode(t, "alias", zoneDN, [3]string{"CNAME", "3600", "www.example.com"}),
+		testNode(t, "_sip._tcp", zoneDN, [3]string{"SRV", "300", "10 5 5060 sip.example.com"}),
+		testNode(t, "old", zoneDN, [3]string{"A", "300", "192.0.2.9"}),
+		testNode(t, "printer", "DC=example.org,"+testContainerDN, [3]string{"A", "300", "192.0.2.100"}),
+	}
+	nodes[4].Tombstoned = true
+
+	d := &testDirectory{
+		t: t,
+		zoneList: []dnsZone{
+			{Name: "example.com", DN: "DC=example.com," + testContainerDN},
+			{Name: "example.org", DN: "DC=example.org," + testContainerDN},
+			{Name: "RootDNSServers", DN: "DC=RootDNSServers," + testContainerDN},
+			{Name: "..TrustAnchors", DN: "DC=..TrustAnchors," + testContainerDN},
+		},
+		nodesByDN: map[string]*dnsNode{},
+	}
+	for _, node := range nodes {
+		d.nodesByDN[node.DN] = node
+	}
+	return d
+}
+
+func TestNewActiveDirectoryProvider(t *testing.T) {
+	cfg := ActiveDirectoryConfig{
+		LDAPURL:          "ldaps://dc1.corp.example.com",
+		DNSContainerDN:   testContainerDN,
+		KerberosUsername: "external-dns",
+		KerberosPassword: "secret",
+	}
+	_, err := NewActiveDirectoryProvider(cfg)
+	require.NoError(t, err)
+
+	invalid := cfg
+	invalid.DNSContainerDN = ""
+	_, err = NewActiveDirectoryProvider(invalid)
+	require.ErrorIs(t, err, ErrNoDNSContainer)
+
+	invalid = cfg
+	invalid.LDAPURL = "https://dc1.corp.example.com"
+	_, err = NewActiveDirectoryProvider(invalid)
+	require.ErrorContains(t, err, "invalid Active Directory LDAP URL")
+
+	invalid = cfg
+	invalid.KerberosKeytab = "/etc/krb5.keytab"
+	_, err = NewActiveDirectoryProvider(invalid)
+	require.ErrorIs(t, err, ErrNoKerberosCredentials)
+
+	invalid = cfg
+	invalid.KerberosPassword = ""
+	_, err = NewActiveDirectoryProvider(invalid)
+	require.ErrorIs(t, err, ErrNoKerberosCredentials)
+
+	invalid = cfg
+	invalid.KerberosUsername = ""
+	_, err = NewActiveDirectoryProvider(invalid)
+	require.ErrorIs(t, err, ErrNoKerberosCredentials)
+
+	valid := cfg
+	valid.LDAPURL = "ldap://dc1.corp.example.com:389"
+	_, err = NewActiveDirectoryProvider(valid)
+	require.NoError(t, err)
+
+	valid = cfg
+	valid.KerberosPassword = ""
+	valid.KerberosKeytab = "/etc/krb5.keytab"
+	_, err = NewActiveDirectoryProvider(valid)
+	require.NoError(t, err)
+}
+
+func TestActiveDirectoryZones(t *testing.T) {
+	p := &ActiveDirectoryProvider{dir: newTestDirectory(t), domainFilter: endpoint.NewDomainFilter([]string{"example.com"})}
+
+	zones, err := p.zones(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, provider.ZoneIDName{"DC=example.com," + testContainerDN: "example.com"}, zones)
+}
+
+func TestActiveDirectoryRecords(t *testing.T) {
+	p := &ActiveDirectoryProvider{dir: newTestDirectory(t), domainFilter: endpoint.NewDomainFilter([]string{"example.com"})}
+
+	records, err := p.Records(context.Background())
+	require.NoError(t, err)
+
+	// The SOA records and the tombstoned nodes are left out.
+	assert.Equal(t, []*endpoint.Endpoint{
+		endpoint.NewEndpointWithTTL("example.com", endpoint.RecordTypeMX, 300, "10 mail.example.com"),
+		endpoint.NewEndpointWithTTL("_sip._tcp.example.com", endpoint.RecordTypeSRV, 300, "10 5 5060 sip.example.com"),
+		endpoint.NewEndpointWithTTL("alias.example.com", endpoint.RecordTypeCNAME, 3600, "www.example.com"),
+		endpoint.NewEndpointWithTTL("www.example.com", endpoint.RecordTypeA, 300, "192.0.2.1", "192.0.2.2"),
+		endpoint.NewEndpointWithTTL("www.example.com", endpoint.RecordTypeTXT, 300, "heritage=external-dns"),
+	}, records)
+}
+
+func TestActiveDirectoryApplyChanges(t *testing.T) {
+	dir := newTestDirectory(t)
+	p := &ActiveDirectoryProvider{dir: dir}
+
+	err := p.ApplyChanges(context.Background(), &plan.Changes{
+		Create: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("new.example.com", endpoint.RecordTypeAAAA, "2001:db8::1"),
+			endpoint.NewEndpoint("old.example.com", endpoint.RecordTypeA, "192.0.2.10"),
+			endpoint.NewEndpoint("app.example.net", endpoint.RecordTypeA, "192.0.2.11"),
+		},
+		UpdateOld: []*endpoint.Endpoint{
+			endpoint.NewEndpointWithTTL("www.example.com", endpoint.RecordTypeA, 300, "192.0.2.1", "192.0.2.2"),
+		},
+		UpdateNew: []*endpoint.Endpoint{
+			endpoint.NewEndpointWithTTL("www.example.com", endpoint.RecordTypeA, 60, "192.0.2.3"),
+		},
+		Delete: []*endpoint.Endpoint{
+			endpoint.NewEndpointWithTTL("example.com", endpoint.RecordTypeMX, 300, "10 mail.example.com"),
+			endpoint.NewEndpointWithTTL("alias.example.com", endpoint.RecordTypeCNAME, 3600, "www.example.com"),
+		},
+	})
+	require.NoError(t, err)
+
+	// The record of the name outside of the zones is skipped, and the tombstoned node is revived.
+	assert.Equal(t, []string{
+		"replace DC=@,DC=example.com," + testContainerDN + " SOA=...",
+		"delete DC=alias,DC=example.com," + testContainerDN,
+		"add DC=new,DC=example.com," + testContainerDN + " AAAA=2001:db8::1",
+		"replace DC=old,DC=example.com," + testContainerDN + " A=192.0.2.10",
+		"replace DC=www,DC=example.com," + testContainerDN + " TXT=heritage=external-dns,A=192.0.2.3",
+	}, dir.operations)
+
+	// The new records have the TTL of the endpoint, or the default one, and the serial following
+	// the one of the zone.
+	r, err := decodeRecord(dir.nodesByDN["DC=new,DC=example.com,"+testContainerDN].Records[0])
+	require.NoError(t, err)
+	assert.Equal(t, dnsRecord{Type: dns.TypeAAAA, Serial: 42, TTL: defaultTTL, Data: r.Data}, r)
+	r, err = decodeRecord(dir.nodesByDN["DC=www,DC=example.com,"+testContainerDN].Records[1])
+	require.NoError(t, err)
+	assert.Equal(t, uint32(60), r.TTL)
+}
+
+func TestActiveDirectoryApplyChangesInvalidTarget(t *testing.T) {
+	dir := newTestDirectory(t)
+	p := &ActiveDirectoryProvider{dir: dir}
+
+	err := p.ApplyChanges(context.Background(), &plan.Changes{
+		Create: []*endpoint.Endpoint{endpoint.NewEndpoint("_sip._udp.example.com", endpoint.RecordTypeSRV, "sip.example.com")},
+	})
+	require.ErrorIs(t, err, provider.SoftError)
+	assert.Empty(t, dir.operations)
+}
+
+func TestActiveDirectoryApplyChangesDryRun(t *testing.T) {
+	dir := newTestDirectory(t)
+	p := &ActiveDirectoryProvider{dir: dir, dryRun: true}
+
+	err := p.ApplyChanges(context.Background(), &plan.Changes{
+		Create: []*endpoint.Endpoint{endpoint.NewEndpoint("new.example.com", endpoint.RecordTypeA, "192.0.2.10")},
+		Delete: []*endpoint.Endpoint{endpoint.NewEndpoint("alias.example.com", endpoint.RecordTypeCNAME, "www.example.com")},
+	})
+	require.NoError(t, err)
+	assert.Empty(t, dir.operations)
+}
+
+func TestActiveDirectoryApplyChangesCanceled(t *testing.T) {
+	dir := newTestDirectory(t)
+	p := &ActiveDirectoryProvider{dir: dir}
+
+	// the nodes aren't changed once the synchronization is canceled
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err := p.ApplyChanges(ctx, &plan.Changes{
+		Create: []*endpoint.Endpoint{endpoint.NewEndpoint("new.example.com", endpoint.RecordTypeA, "192.0.2.10")},
+	})
+	require.ErrorIs(t, err, context.Canceled)
+	assert.Empty(t, dir.operations)
+
+	// nor is the domain controller contacted
+	d, err := newLDAPDirectory(ActiveDirectoryConfig{LDAPURL: "ldap://dc.corp.example.com", KerberosUsername: "external-dns", KerberosPassword: "secret"})
+	require.NoError(t, err)
+	_, err = d.zones(ctx)
+	require.ErrorIs(t, err, context.Canceled)
+	assert.Nil(t, d.conn)
+}
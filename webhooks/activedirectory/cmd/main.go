@@ -0,0 +1,52 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// The Active Directory webhook provider of ExternalDNS, managing the records of the
+// Active Directory-integrated zones through LDAP.
+package main
+
+import (
+	"os"
+
+	"github.com/alecthomas/kingpin/v2"
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/webhooks/activedirectory"
+	"sigs.k8s.io/external-dns/webhooks/internal/server"
+)
+
+func main() {
+	var opts server.Options
+	var cfg activedirectory.ActiveDirectoryConfig
+	app := server.NewApp("external-dns-active-directory-webhook", "Webhook provider of ExternalDNS managing the records of the Active Directory-integrated zones through LDAP.", &opts)
+	server.Flag(app, "ad-ldap-url", "The URL of a domain controller, ldaps:// or ldap:// upgraded with StartTLS (required)").Required().StringVar(&cfg.LDAPURL)
+	server.Flag(app, "ad-dns-container-dn", "The DN of the DNS container holding the zones, e.g. CN=MicrosoftDNS,DC=DomainDnsZones,DC=corp,DC=example,DC=com (required)").Required().StringVar(&cfg.DNSContainerDN)
+	server.Flag(app, "ad-kerberos-realm", "The Kerberos realm (default: the default realm of the Kerberos configuration)").StringVar(&cfg.KerberosRealm)
+	server.Flag(app, "ad-kerberos-username", "The Kerberos principal (required)").Required().StringVar(&cfg.KerberosUsername)
+	server.Flag(app, "ad-kerberos-password", "The password of the Kerberos principal (mutually exclusive with --ad-kerberos-keytab)").StringVar(&cfg.KerberosPassword)
+	server.Flag(app, "ad-kerberos-keytab", "The path of the keytab of the Kerberos principal (mutually exclusive with --ad-kerberos-password)").StringVar(&cfg.KerberosKeytab)
+	server.Flag(app, "ad-kerberos-config", "The path of the Kerberos configuration").Default("/etc/krb5.conf").StringVar(&cfg.KerberosConfig)
+	kingpin.MustParse(app.Parse(os.Args[1:]))
+
+	opts.ConfigureLogger()
+	cfg.DomainFilter = opts.NewDomainFilter()
+	cfg.DryRun = opts.DryRun
+	p, err := activedirectory.NewActiveDirectoryProvider(cfg)
+	if err != nil {
+		log.Fatal(err)
+	}
+	server.Run(&opts, p)
+}
@@ -0,0 +1,267 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package activedirectory
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-ldap/ldap/v3"
+	"github.com/go-ldap/ldap/v3/gssapi"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// searchPageSize is the size of the pages of the searches, below the MaxPageSize of AD.
+	searchPageSize = 500
+	// ldapTimeout bounds each LDAP operation.
+	ldapTimeout = 30 * time.Second
+)
+
+// dnsZone is a dnsZone object of the DNS container of a partition.
+type dnsZone struct {
+	Name string
+	DN   string
+}
+
+// dnsNode is a dnsNode object of a zone, holding the records of a name.
+type dnsNode struct {
+	// Name is the name relative to the zone, "@" for the apex.
+	Name       string
+	DN         string
+	Records    [][]byte
+	Tombstoned bool
+}
+
+// directory declares the LDAP operations performed against Active Directory.
+type directory interface {
+	// zones returns the zones of the DNS container.
+	zones(ctx context.Context) ([]dnsZone, error)
+	// nodes returns the nodes of a zone.
+	nodes(ctx context.Context, zoneDN string) ([]dnsNode, error)
+	// node returns a node, or nil if it does not exist.
+	node(ctx context.Context, dn string) (*dnsNode, error)
+	// addNode adds a node with the given records.
+	addNode(ctx context.Context, dn, name string, records [][]byte) error
+	// replaceRecords replaces the records of a node, reviving it if it was tombstoned.
+	replaceRecords(ctx context.Context, dn string, records [][]byte) error
+	// deleteNode deletes a node.
+	deleteNode(ctx context.Context, dn string) error
+}
+
+// ldapDirectory implements the directory over LDAP, binding with Kerberos.
+type ldapDirectory struct {
+	cfg ActiveDirectoryConfig
+
+	mu   sync.Mutex
+	conn *ldap.Conn
+}
+
+// newLDAPDirectory returns a directory connecting to the configured domain controller on first use.
+func newLDAPDirectory(cfg ActiveDirectoryConfig) (*ldapDirectory, error) {
+	u, err := url.Parse(cfg.LDAPURL)
+	if err != nil || (u.Scheme != "ldap" && u.Scheme != "ldaps") || u.Hostname() == "" {
+		return nil, fmt.Errorf("invalid Active Directory LDAP URL %q", cfg.LDAPURL)
+	}
+	if cfg.KerberosUsername == "" || (cfg.KerberosPassword == "") == (cfg.KerberosKeytab == "") {
+		return nil, ErrNoKerberosCredentials
+	}
+	return &ldapDirectory{cfg: cfg}, nil
+}
+
+// connect dials the domain controller and binds with GSSAPI. As the GSSAPI client negotiates no
+// SASL security layer, the connection is always encrypted with TLS, which is what the LDAP signing
+// requirements of AD accept instead. The connection is closed if the context is done before it's bound.
+func (d *ldapDirectory) connect(ctx context.Context) (*ldap.Conn, error) {
+	u, _ := url.Parse(d.cfg.LDAPURL)
+	tlsConfig := &tls.Config{ServerName: u.Hostname(), MinVersion: tls.VersionTLS12}
+
+	dialer := &net.Dialer{Timeout: ldapTimeout}
+	if deadline, ok := ctx.Deadline(); ok {
+		dialer.Deadline = deadline
+	}
+	conn, err := ldap.DialURL(d.cfg.LDAPURL, ldap.DialWithTLSConfig(tlsConfig), ldap.DialWithDialer(dialer))
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to %s: %w", d.cfg.LDAPURL, err)
+	}
+	stop := context.AfterFunc(ctx, func() { conn.Close() })
+	defer stop()
+	conn.SetTimeout(ldapTimeout)
+	if u.Scheme == "ldap" {
+		if err := conn.StartTLS(tlsConfig); err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("failed to start TLS with %s: %w", d.cfg.LDAPURL, err)
+		}
+	}
+
+	var client *gssapi.Client
+	if d.cfg.KerberosKeytab != "" {
+		client, err = gssapi.NewClientWithKeytab(d.cfg.KerberosUsername, d.cfg.KerberosRealm, d.cfg.KerberosKeytab, d.cfg.KerberosConfig)
+	} else {
+		client, err = gssapi.NewClientWithPassword(d.cfg.KerberosUsername, d.cfg.KerberosRealm, d.cfg.KerberosPassword, d.cfg.KerberosConfig)
+	}
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to create the Kerberos client: %w", err)
+	}
+	defer client.Close()
+
+	if err := conn.GSSAPIBind(client, "ldap/"+u.Hostname(), ""); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to bind to %s with Kerberos: %w", d.cfg.LDAPURL, err)
+	}
+	log.Debugf("Bound to %s as %s", d.cfg.LDAPURL, d.cfg.KerberosUsername)
+	return conn, nil
+}
+
+// do runs an operation on the connection, connecting first if needed, and dropping the connection
+// when it failed so that the next operation reconnects. As go-ldap doesn't take contexts, the
+// connection is closed to abort the operation once the context is done.
+func (d *ldapDirectory) do(ctx context.Context, op func(conn *ldap.Conn) error) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if d.conn == nil || d.conn.IsClosing() {
+		conn, err := d.connect(ctx)
+		if err != nil {
+			return err
+		}
+		d.conn = conn
+	}
+	conn := d.conn
+	stop := context.AfterFunc(ctx, func() { conn.Close() })
+	err := op(conn)
+	stop()
+	if ctxErr := ctx.Err(); err != nil && ctxErr != nil {
+		conn.Close()
+		d.conn = nil
+		return fmt.Errorf("%w: %w", ctxErr, err)
+	}
+	if ldap.IsErrorWithCode(err, ldap.ErrorNetwork) {
+		conn.Close()
+		d.conn = nil
+	}
+	return err
+}
+
+func (d *ldapDirectory) search(ctx context.Context, baseDN string, scope int, filter string, attributes []string) ([]*ldap.Entry, error) {
+	var entries []*ldap.Entry
+	err := d.do(ctx, func(conn *ldap.Conn) error {
+		req := ldap.NewSearchRequest(baseDN, scope, ldap.NeverDerefAliases, 0, 0, false, filter, attributes, nil)
+		res, err := conn.SearchWithPaging(req, searchPageSize)
+		if err != nil {
+			return err
+		}
+		entries = res.Entries
+		return nil
+	})
+	return entries, err
+}
+
+func (d *ldapDirectory) zones(ctx context.Context) ([]dnsZone, error) {
+	entries, err := d.search(ctx, d.cfg.DNSContainerDN, ldap.ScopeSingleLevel, "(objectClass=dnsZone)", []string{"dc"})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the zones of %s: %w", d.cfg.DNSContainerDN, err)
+	}
+	zones := make([]dnsZone, 0, len(entries))
+	for _, e := range entries {
+		zones = append(zones, dnsZone{Name: e.GetAttributeValue("dc"), DN: e.DN})
+	}
+	return zones, nil
+}
+
+func (d *ldapDirectory) nodes(ctx context.Context, zoneDN string) ([]dnsNode, error) {
+	entries, err := d.search(ctx, zoneDN, ldap.ScopeSingleLevel, "(objectClass=dnsNode)", []string{"dc", "dnsRecord", "dNSTombstoned"})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the nodes of %s: %w", zoneDN, err)
+	}
+	nodes := make([]dnsNode, 0, len(entries))
+	for _, e := range entries {
+		nodes = append(nodes, entryNode(e))
+	}
+	return nodes, nil
+}
+
+func (d *ldapDirectory) node(ctx context.Context, dn string) (*dnsNode, error) {
+	entries, err := d.search(ctx, dn, ldap.ScopeBaseObject, "(objectClass=dnsNode)", []string{"dc", "dnsRecord", "dNSTombstoned"})
+	if ldap.IsErrorWithCode(err, ldap.LDAPResultNoSuchObject) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the node %s: %w", dn, err)
+	}
+	if len(entries) == 0 {
+		return nil, nil
+	}
+	node := entryNode(entries[0])
+	return &node, nil
+}
+
+func entryNode(e *ldap.Entry) dnsNode {
+	return dnsNode{
+		Name:       e.GetAttributeValue("dc"),
+		DN:         e.DN,
+		Records:    e.GetRawAttributeValues("dnsRecord"),
+		Tombstoned: strings.EqualFold(e.GetAttributeValue("dNSTombstoned"), "TRUE"),
+	}
+}
+
+func (d *ldapDirectory) addNode(ctx context.Context, dn, name string, records [][]byte) error {
+	req := ldap.NewAddRequest(dn, nil)
+	req.Attribute("objectClass", []string{"top", "dnsNode"})
+	req.Attribute("dc", []string{name})
+	req.Attribute("dnsRecord", byteStrings(records))
+	if err := d.do(ctx, func(conn *ldap.Conn) error { return conn.Add(req) }); err != nil {
+		return fmt.Errorf("failed to add the node %s: %w", dn, err)
+	}
+	return nil
+}
+
+func (d *ldapDirectory) replaceRecords(ctx context.Context, dn string, records [][]byte) error {
+	req := ldap.NewModifyRequest(dn, nil)
+	req.Replace("dnsRecord", byteStrings(records))
+	req.Replace("dNSTombstoned", []string{"FALSE"})
+	if err := d.do(ctx, func(conn *ldap.Conn) error { return conn.Modify(req) }); err != nil {
+		return fmt.Errorf("failed to modify the node %s: %w", dn, err)
+	}
+	return nil
+}
+
+func (d *ldapDirectory) deleteNode(ctx context.Context, dn string) error {
+	if err := d.do(ctx, func(conn *ldap.Conn) error { return conn.Del(ldap.NewDelRequest(dn, nil)) }); err != nil {
+		return fmt.Errorf("failed to delete the node %s: %w", dn, err)
+	}
+	return nil
+}
+
+// byteStrings converts binary attribute values to the strings go-ldap takes.
+func byteStrings(values [][]byte) []string {
+	s := make([]string, 0, len(values))
+	for _, v := range values {
+		s = append(s, string(v))
+	}
+	return s
+}
@@ -0,0 +1,227 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package activedirectory
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net/netip"
+	"strings"
+
+	"github.com/miekg/dns"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+// The dnsRecord attribute of a dnsNode object holds a resource record per value, in the format
+// of the DNS_RPC_RECORD structure of MS-DNSP section 2.3.2.2.
+const (
+	recordHeaderLen = 24
+	recordVersion   = 5
+	// rankZone is the rank of the records of a zone the server is authoritative for.
+	rankZone = 0xf0
+)
+
+// errInvalidRecord is returned when a dnsRecord value cannot be decoded.
+var errInvalidRecord = errors.New("invalid dnsRecord value")
+
+// dnsRecord is a decoded value of the dnsRecord attribute.
+type dnsRecord struct {
+	Type   uint16
+	Serial uint32
+	TTL    uint32
+	// Data is the record data, in the format of the type.
+	Data []byte
+}
+
+// decodeRecord decodes a value of the dnsRecord attribute.
+func decodeRecord(b []byte) (dnsRecord, error) {
+	if len(b) < recordHeaderLen {
+		return dnsRecord{}, errInvalidRecord
+	}
+	dataLen := int(binary.LittleEndian.Uint16(b[0:2]))
+	if len(b) < recordHeaderLen+dataLen {
+		return dnsRecord{}, errInvalidRecord
+	}
+	return dnsRecord{
+		Type:   binary.LittleEndian.Uint16(b[2:4]),
+		Serial: binary.LittleEndian.Uint32(b[8:12]),
+		// Unlike the other fields, the TTL is in network byte order.
+		TTL:  binary.BigEndian.Uint32(b[12:16]),
+		Data: b[recordHeaderLen : recordHeaderLen+dataLen],
+	}, nil
+}
+
+// encode encodes the record into a value of the dnsRecord attribute. The records written through
+// LDAP are static, with a zero timestamp, so they are never scavenged.
+func (r dnsRecord) encode() []byte {
+	b := make([]byte, recordHeaderLen, recordHeaderLen+len(r.Data))
+	binary.LittleEndian.PutUint16(b[0:2], uint16(len(r.Data)))
+	binary.LittleEndian.PutUint16(b[2:4], r.Type)
+	b[4] = recordVersion
+	b[5] = rankZone
+	binary.LittleEndian.PutUint32(b[8:12], r.Serial)
+	binary.BigEndian.PutUint32(b[12:16], r.TTL)
+	return append(b, r.Data...)
+}
+
+// target returns the target of an endpoint for the record, if its type is supported.
+func (r dnsRecord) target() (string, bool, error) {
+	d := r.Data
+	switch r.Type {
+	case dns.TypeA, dns.TypeAAAA:
+		ip, ok := netip.AddrFromSlice(d)
+		if !ok || (r.Type == dns.TypeA) != ip.Is4() {
+			return "", false, errInvalidRecord
+		}
+		return ip.String(), true, nil
+	case dns.TypeCNAME:
+		name, err := decodeName(d)
+		return name, err == nil, err
+	case dns.TypeTXT:
+		var sb strings.Builder
+		for len(d) > 0 {
+			n := int(d[0])
+			if len(d) < 1+n {
+				return "", false, errInvalidRecord
+			}
+			sb.Write(d[1 : 1+n])
+			d = d[1+n:]
+		}
+		return sb.String(), true, nil
+	case dns.TypeMX:
+		if len(d) < 2 {
+			return "", false, errInvalidRecord
+		}
+		name, err := decodeName(d[2:])
+		return fmt.Sprintf("%d %s", binary.BigEndian.Uint16(d), name), err == nil, err
+	case dns.TypeSRV:
+		if len(d) < 6 {
+			return "", false, errInvalidRecord
+		}
+		name, err := decodeName(d[6:])
+		return fmt.Sprintf("%d %d %d %s", binary.BigEndian.Uint16(d), binary.BigEndian.Uint16(d[2:]), binary.BigEndian.Uint16(d[4:]), name), err == nil, err
+	default:
+		return "", false, nil
+	}
+}
+
+// soaSerial returns the serial of an SOA record.
+func (r dnsRecord) soaSerial() (uint32, bool) {
+	if r.Type != dns.TypeSOA || len(r.Data) < 4 {
+		return 0, false
+	}
+	return binary.BigEndian.Uint32(r.Data), true
+}
+
+// newRecord returns the record of a target of an endpoint.
+func newRecord(recordType string, target string, ttl, serial uint32) (dnsRecord, error) {
+	r := dnsRecord{Type: dns.StringToType[recordType], Serial: serial, TTL: ttl}
+	switch recordType {
+	case endpoint.RecordTypeA, endpoint.RecordTypeAAAA:
+		ip, err := netip.ParseAddr(target)
+		if err != nil || (recordType == endpoint.RecordTypeA) != ip.Is4() {
+			return dnsRecord{}, fmt.Errorf("invalid %s target %q", recordType, target)
+		}
+		r.Data = ip.AsSlice()
+	case endpoint.RecordTypeCNAME:
+		name, err := encodeName(target)
+		if err != nil {
+			return dnsRecord{}, err
+		}
+		r.Data = name
+	case endpoint.RecordTypeTXT:
+		// The strings of a TXT record are at most 255 bytes long.
+		for s := target; ; s = s[255:] {
+			if len(s) <= 255 {
+				r.Data = append(append(r.Data, byte(len(s))), s...)
+				break
+			}
+			r.Data = append(append(r.Data, 255), s[:255]...)
+		}
+	case endpoint.RecordTypeMX:
+		var preference uint16
+		var host string
+		if _, err := fmt.Sscanf(target, "%d %s", &preference, &host); err != nil {
+			return dnsRecord{}, fmt.Errorf("invalid MX target %q: %w", target, err)
+		}
+		name, err := encodeName(host)
+		if err != nil {
+			return dnsRecord{}, err
+		}
+		r.Data = append(binary.BigEndian.AppendUint16(nil, preference), name...)
+	case endpoint.RecordTypeSRV:
+		var priority, weight, port uint16
+		var host string
+		if _, err := fmt.Sscanf(target, "%d %d %d %s", &priority, &weight, &port, &host); err != nil {
+			return dnsRecord{}, fmt.Errorf("invalid SRV target %q: %w", target, err)
+		}
+		name, err := encodeName(host)
+		if err != nil {
+			return dnsRecord{}, err
+		}
+		r.Data = binary.BigEndian.AppendUint16(nil, priority)
+		r.Data = binary.BigEndian.AppendUint16(r.Data, weight)
+		r.Data = binary.BigEndian.AppendUint16(r.Data, port)
+		r.Data = append(r.Data, name...)
+	default:
+		return dnsRecord{}, fmt.Errorf("unsupported record type %s", recordType)
+	}
+	return r, nil
+}
+
+// encodeName encodes a domain name in the format of the DNS_COUNT_NAME structure of MS-DNSP
+// section 2.2.2.2.2: the length of the raw name, the count of its labels and the raw name in
+// wire format.
+func encodeName(name string) ([]byte, error) {
+	name = strings.TrimSuffix(name, ".")
+	if name == "" {
+		return nil, errors.New("empty domain name")
+	}
+	labels := strings.Split(name, ".")
+	raw := make([]byte, 0, len(name)+2)
+	for _, label := range labels {
+		if label == "" || len(label) > 63 {
+			return nil, fmt.Errorf("invalid domain name %q", name)
+		}
+		raw = append(append(raw, byte(len(label))), label...)
+	}
+	raw = append(raw, 0)
+	if len(raw) > 255 {
+		return nil, fmt.Errorf("domain name %q is too long", name)
+	}
+	return append([]byte{byte(len(raw)), byte(len(labels))}, raw...), nil
+}
+
+// decodeName decodes a domain name in the format of the DNS_COUNT_NAME structure, returning it
+// without the trailing dot.
+func decodeName(b []byte) (string, error) {
+	if len(b) < 2 || len(b) < 2+int(b[0]) {
+		return "", errInvalidRecord
+	}
+	raw := b[2 : 2+int(b[0])]
+	labels := make([]string, 0, b[1])
+	for range int(b[1]) {
+		if len(raw) == 0 || len(raw) < 1+int(raw[0]) {
+			return "", errInvalidRecord
+		}
+		labels = append(labels, string(raw[1:1+int(raw[0])]))
+		raw = raw[1+int(raw[0]):]
+	}
+	return strings.Join(labels, "."), nil
+}
@@ -0,0 +1,114 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package activedirectory
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/miekg/dns"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordEncoding(t *testing.T) {
+	r, err := newRecord("A", "192.0.2.1", 300, 42)
+	require.NoError(t, err)
+
+	// The TTL is in network byte order, the other fields of the header in little endian.
+	b := r.encode()
+	assert.Equal(t, []byte{
+		0x04, 0x00, 0x01, 0x00, 0x05, 0xf0, 0x00, 0x00,
+		0x2a, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x2c,
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0xc0, 0x00, 0x02, 0x01,
+	}, b)
+
+	decoded, err := decodeRecord(b)
+	require.NoError(t, err)
+	assert.Equal(t, r, decoded)
+}
+
+func TestRecordTargets(t *testing.T) {
+	long := strings.Repeat("a", 300)
+	for _, tc := range []struct {
+		recordType string
+		target     string
+		data       []byte
+	}{
+		{"A", "192.0.2.1", []byte{192, 0, 2, 1}},
+		{"AAAA", "2001:db8::1", []byte{0x20, 0x01, 0x0d, 0xb8, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1}},
+		{"CNAME", "www.example.com", []byte{17, 3, 3, 'w', 'w', 'w', 7, 'e', 'x', 'a', 'm', 'p', 'l', 'e', 3, 'c', 'o', 'm', 0}},
+		{"TXT", "heritage=external-dns", append([]byte{21}, "heritage=external-dns"...)},
+		{"TXT", long, append(append(append([]byte{255}, long[:255]...), 45), long[255:]...)},
+		{"MX", "10 mail.example.com", []byte{0, 10, 18, 3, 4, 'm', 'a', 'i', 'l', 7, 'e', 'x', 'a', 'm', 'p', 'l', 'e', 3, 'c', 'o', 'm', 0}},
+		{"SRV", "10 5 5060 sip.example.com", []byte{0, 10, 0, 5, 0x13, 0xc4, 17, 3, 3, 's', 'i', 'p', 7, 'e', 'x', 'a', 'm', 'p', 'l', 'e', 3, 'c', 'o', 'm', 0}},
+	} {
+		t.Run(tc.recordType, func(t *testing.T) {
+			r, err := newRecord(tc.recordType, tc.target, defaultTTL, 1)
+			require.NoError(t, err)
+			assert.Equal(t, tc.data, r.Data)
+
+			target, ok, err := r.target()
+			require.NoError(t, err)
+			assert.True(t, ok)
+			assert.Equal(t, tc.target, target)
+		})
+	}
+}
+
+func TestRecordInvalidTargets(t *testing.T) {
+	for _, tc := range []struct {
+		recordType string
+		target     string
+	}{
+		{"A", "2001:db8::1"},
+		{"AAAA", "192.0.2.1"},
+		{"CNAME", ""},
+		{"CNAME", "www..example.com"},
+		{"MX", "mail.example.com"},
+		{"SRV", "10 5 sip.example.com"},
+		{"NS", "ns.example.com"},
+	} {
+		_, err := newRecord(tc.recordType, tc.target, defaultTTL, 1)
+		assert.Error(t, err, tc.recordType+" "+tc.target)
+	}
+}
+
+func TestRecordDecoding(t *testing.T) {
+	_, err := decodeRecord([]byte{0x04, 0x00, 0x01, 0x00})
+	require.ErrorIs(t, err, errInvalidRecord)
+
+	// The data is shorter than its length.
+	_, err = decodeRecord(append(dnsRecord{Type: dns.TypeA, Data: []byte{192, 0, 2, 1}}.encode()[:24], 192))
+	require.ErrorIs(t, err, errInvalidRecord)
+
+	// The tombstones and the unsupported types are not targets.
+	_, ok, err := dnsRecord{Type: 0, Data: []byte{0, 0, 0, 0, 0, 0, 0, 0}}.target()
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	_, _, err = dnsRecord{Type: dns.TypeA, Data: []byte{192, 0, 2}}.target()
+	require.ErrorIs(t, err, errInvalidRecord)
+
+	_, _, err = dnsRecord{Type: dns.TypeCNAME, Data: []byte{5, 3, 3, 'w', 'w', 'w', 0}}.target()
+	require.ErrorIs(t, err, errInvalidRecord)
+
+	serial, ok := dnsRecord{Type: dns.TypeSOA, Data: []byte{0, 0, 0, 42, 0, 0, 0, 0}}.soaSerial()
+	assert.True(t, ok)
+	assert.Equal(t, uint32(42), serial)
+}
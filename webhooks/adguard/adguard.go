@@ -0,0 +1,223 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package adguard
+
+import (
+	"context"
+	"errors"
+	"net/netip"
+	"slices"
+
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider"
+)
+
+// ErrNoAdGuardServer is returned when there is no AdGuard Home server configured.
+var ErrNoAdGuardServer = errors.New("no AdGuard Home server found in the flags")
+
+// AdGuardProvider is an implementation of Provider for the DNS rewrites of AdGuard Home.
+type AdGuardProvider struct {
+	provider.BaseProvider
+	api          adguardAPI
+	domainFilter *endpoint.DomainFilter
+	dryRun       bool
+}
+
+// AdGuardConfig is used for configuring an AdGuardProvider.
+type AdGuardConfig struct {
+	// The root URL of the AdGuard Home web interface.
+	Server string
+	// The credentials of an AdGuard Home user, if the server is protected.
+	Username string
+	Password string
+	// Disable verification of TLS certificates.
+	TLSInsecureSkipVerify bool
+	// The maximum number of requests per second to the server, unlimited if zero.
+	RateLimit float64
+	// A filter to apply when looking up and applying records.
+	DomainFilter *endpoint.DomainFilter
+	// Do nothing and log what would have changed to stdout.
+	DryRun bool
+}
+
+// Helper struct for grouping the rewrites of a record.
+type adguardEntryKey struct {
+	DNSName    string
+	RecordType string
+}
+
+// NewAdGuardProvider initializes a new AdGuard Home DNS rewrites based Provider.
+func NewAdGuardProvider(cfg AdGuardConfig) (*AdGuardProvider, error) {
+	api, err := newAdGuardClient(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &AdGuardProvider{api: api, domainFilter: cfg.DomainFilter, dryRun: cfg.DryRun}, nil
+}
+
+// Records implements Provider, populating a slice of endpoints from the AdGuard Home DNS rewrites.
+func (p *AdGuardProvider) Records(ctx context.Context) ([]*endpoint.Endpoint, error) {
+	rewrites, err := p.api.listRewrites(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var endpoints []*endpoint.Endpoint
+	byKey := make(map[adguardEntryKey]*endpoint.Endpoint)
+	for _, r := range rewrites {
+		if !p.domainFilter.Match(r.Domain) {
+			continue
+		}
+		recordType := answerRecordType(r.Answer)
+		if recordType == "" {
+			log.Debugf("Skipping rewrite %s keeping the upstream %s records", r.Domain, r.Answer)
+			continue
+		}
+
+		// AdGuard Home has a rewrite per answer of a domain.
+		key := adguardEntryKey{r.Domain, recordType}
+		if ep, ok := byKey[key]; ok {
+			if !slices.Contains(ep.Targets, r.Answer) {
+				ep.Targets = append(ep.Targets, r.Answer)
+			}
+			continue
+		}
+		ep := endpoint.NewEndpoint(r.Domain, recordType, r.Answer)
+		byKey[key] = ep
+		endpoints = append(endpoints, ep)
+	}
+	return endpoints, nil
+}
+
+// answerRecordType returns the type of the records answered by a rewrite, or an empty string for the
+// rewrites keeping the upstream A or AAAA records.
+func answerRecordType(answer string) string {
+	if answer == endpoint.RecordTypeA || answer == endpoint.RecordTypeAAAA {
+		return ""
+	}
+	if addr, err := netip.ParseAddr(answer); err == nil {
+		if addr.Is4() {
+			return endpoint.RecordTypeA
+		}
+		return endpoint.RecordTypeAAAA
+	}
+	return endpoint.RecordTypeCNAME
+}
+
+// AdjustEndpoints implements Provider, dropping the TTLs as AdGuard Home answers the rewrites with its own.
+func (p *AdGuardProvider) AdjustEndpoints(endpoints []*endpoint.Endpoint) ([]*endpoint.Endpoint, error) {
+	for _, ep := range endpoints {
+		ep.RecordTTL = 0
+	}
+	return endpoints, nil
+}
+
+// WildcardSupport implements provider.WildcardSupporter, as AdGuard Home rewrites wildcard domains
+// but has no TXT records.
+func (p *AdGuardProvider) WildcardSupport() provider.WildcardSupport {
+	return provider.WildcardSupport{Records: true}
+}
+
+// Capabilities implements provider.CapabilitiesReporter, as AdGuard Home only rewrites to A, AAAA and CNAME records.
+func (p *AdGuardProvider) Capabilities() provider.Capabilities {
+	return provider.Capabilities{
+		RecordTypes: []string{endpoint.RecordTypeA, endpoint.RecordTypeAAAA, endpoint.RecordTypeCNAME},
+	}
+}
+
+// ApplyChanges implements Provider, syncing the desired state with the AdGuard Home DNS rewrites.
+func (p *AdGuardProvider) ApplyChanges(ctx context.Context, changes *plan.Changes) error {
+	var deletes, adds []rewrite
+
+	for _, ep := range changes.Delete {
+		deletes = append(deletes, rewrites(ep, ep.Targets)...)
+	}
+
+	// There is no rewrite to update in place, only replace the answers which changed.
+	updateNew := make(map[adguardEntryKey]*endpoint.Endpoint)
+	for _, ep := range changes.UpdateNew {
+		updateNew[adguardEntryKey{ep.DNSName, ep.RecordType}] = ep
+	}
+	for _, ep := range changes.UpdateOld {
+		key := adguardEntryKey{ep.DNSName, ep.RecordType}
+		newRecord := updateNew[key]
+		if newRecord == nil {
+			deletes = append(deletes, rewrites(ep, ep.Targets)...)
+			continue
+		}
+		delete(updateNew, key)
+		deletes = append(deletes, rewrites(ep, missingTargets(ep, newRecord))...)
+		adds = append(adds, rewrites(newRecord, missingTargets(newRecord, ep))...)
+	}
+
+	for _, ep := range changes.Create {
+		adds = append(adds, rewrites(ep, ep.Targets)...)
+	}
+	for _, ep := range changes.UpdateNew {
+		if _, ok := updateNew[adguardEntryKey{ep.DNSName, ep.RecordType}]; ok {
+			adds = append(adds, rewrites(ep, ep.Targets)...)
+		}
+	}
+
+	for _, r := range deletes {
+		if err := p.apply(ctx, "Deleting", p.api.deleteRewrite, r); err != nil {
+			return err
+		}
+	}
+	for _, r := range adds {
+		if err := p.apply(ctx, "Adding", p.api.addRewrite, r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *AdGuardProvider) apply(ctx context.Context, action string, fn func(context.Context, rewrite) error, r rewrite) error {
+	if !p.domainFilter.Match(r.Domain) {
+		log.Debugf("Skipping rewrite %s that does not match domain filter", r.Domain)
+		return nil
+	}
+	if p.dryRun {
+		log.Infof("DRY RUN: %s rewrite %s -> %s", action, r.Domain, r.Answer)
+		return nil
+	}
+	log.Infof("%s rewrite %s -> %s", action, r.Domain, r.Answer)
+	return fn(ctx, r)
+}
+
+// rewrites returns the rewrites of the given targets of an endpoint.
+func rewrites(ep *endpoint.Endpoint, targets []string) []rewrite {
+	out := make([]rewrite, 0, len(targets))
+	for _, target := range targets {
+		out = append(out, rewrite{Domain: ep.DNSName, Answer: target})
+	}
+	return out
+}
+
+// missingTargets returns the targets of the endpoint which are not in the other one.
+func missingTargets(ep, other *endpoint.Endpoint) []string {
+	var missing []string
+	for _, target := range ep.Targets {
+		if !slices.Contains(other.Targets, target) {
+			missing = append(missing, target)
+		}
+	}
+	return missing
+}
@@ -0,0 +1,165 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package adguard
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+)
+
+type testAdGuardClient struct {
+	rewrites []rewrite
+	adds     []rewrite
+	deletes  []rewrite
+	err      error
+}
+
+func (t *testAdGuardClient) listRewrites(_ context.Context) ([]rewrite, error) {
+	return t.rewrites, t.err
+}
+
+func (t *testAdGuardClient) addRewrite(_ context.Context, r rewrite) error {
+	t.adds = append(t.adds, r)
+	return t.err
+}
+
+func (t *testAdGuardClient) deleteRewrite(_ context.Context, r rewrite) error {
+	t.deletes = append(t.deletes, r)
+	return t.err
+}
+
+func TestNewAdGuardProvider(t *testing.T) {
+	_, err := NewAdGuardProvider(AdGuardConfig{})
+	require.ErrorIs(t, err, ErrNoAdGuardServer)
+
+	for _, tc := range []struct {
+		cfg   AdGuardConfig
+		valid bool
+	}{
+		{cfg: AdGuardConfig{Server: "http://adguard.local"}, valid: true},
+		{cfg: AdGuardConfig{Server: "https://adguard.example.org:3000/", Username: "admin", Password: "secret"}, valid: true},
+		{cfg: AdGuardConfig{Server: "adguard.local"}},
+		{cfg: AdGuardConfig{Server: "http://adguard.local", Password: "secret"}},
+	} {
+		_, err = NewAdGuardProvider(tc.cfg)
+		if tc.valid {
+			assert.NoError(t, err, tc.cfg.Server)
+		} else {
+			assert.Error(t, err, tc.cfg.Server)
+		}
+	}
+}
+
+func TestAdGuardRecords(t *testing.T) {
+	p := &AdGuardProvider{
+		api: &testAdGuardClient{rewrites: []rewrite{
+			{Domain: "test.example.com", Answer: "192.168.1.1"},
+			{Domain: "test.example.com", Answer: "192.168.1.2"},
+			{Domain: "test.example.com", Answer: "fc00::1"},
+			{Domain: "alias.example.com", Answer: "test.example.com"},
+			{Domain: "*.wildcard.example.com", Answer: "192.168.1.3"},
+			{Domain: "upstream.example.com", Answer: "AAAA"},
+			{Domain: "test.example.org", Answer: "192.168.1.4"},
+		}},
+		domainFilter: endpoint.NewDomainFilter([]string{"example.com"}),
+	}
+
+	records, err := p.Records(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, []*endpoint.Endpoint{
+		endpoint.NewEndpoint("test.example.com", endpoint.RecordTypeA, "192.168.1.1", "192.168.1.2"),
+		endpoint.NewEndpoint("test.example.com", endpoint.RecordTypeAAAA, "fc00::1"),
+		endpoint.NewEndpoint("alias.example.com", endpoint.RecordTypeCNAME, "test.example.com"),
+		endpoint.NewEndpoint("*.wildcard.example.com", endpoint.RecordTypeA, "192.168.1.3"),
+	}, records)
+}
+
+func TestAdGuardRecordsError(t *testing.T) {
+	p := &AdGuardProvider{api: &testAdGuardClient{err: errors.New("unauthorized")}}
+
+	_, err := p.Records(context.Background())
+	require.EqualError(t, err, "unauthorized")
+}
+
+func TestAdGuardApplyChanges(t *testing.T) {
+	api := &testAdGuardClient{}
+	p := &AdGuardProvider{api: api, domainFilter: endpoint.NewDomainFilter([]string{"example.com"})}
+
+	err := p.ApplyChanges(context.Background(), &plan.Changes{
+		Create: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("new.example.com", endpoint.RecordTypeA, "192.168.1.1", "192.168.1.2"),
+			endpoint.NewEndpoint("new.example.org", endpoint.RecordTypeA, "192.168.1.1"),
+		},
+		UpdateOld: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("test.example.com", endpoint.RecordTypeA, "192.168.1.3", "192.168.1.4"),
+			endpoint.NewEndpoint("alias.example.com", endpoint.RecordTypeCNAME, "test.example.com"),
+		},
+		UpdateNew: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("test.example.com", endpoint.RecordTypeA, "192.168.1.4", "192.168.1.5"),
+			endpoint.NewEndpoint("alias.example.com", endpoint.RecordTypeCNAME, "new.example.com"),
+		},
+		Delete: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("old.example.com", endpoint.RecordTypeAAAA, "fc00::1"),
+		},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []rewrite{
+		{Domain: "old.example.com", Answer: "fc00::1"},
+		{Domain: "test.example.com", Answer: "192.168.1.3"},
+		{Domain: "alias.example.com", Answer: "test.example.com"},
+	}, api.deletes)
+	assert.Equal(t, []rewrite{
+		{Domain: "test.example.com", Answer: "192.168.1.5"},
+		{Domain: "alias.example.com", Answer: "new.example.com"},
+		{Domain: "new.example.com", Answer: "192.168.1.1"},
+		{Domain: "new.example.com", Answer: "192.168.1.2"},
+	}, api.adds)
+}
+
+func TestAdGuardApplyChangesDryRun(t *testing.T) {
+	api := &testAdGuardClient{}
+	p := &AdGuardProvider{api: api, dryRun: true}
+
+	err := p.ApplyChanges(context.Background(), &plan.Changes{
+		Create: []*endpoint.Endpoint{endpoint.NewEndpoint("new.example.com", endpoint.RecordTypeA, "192.168.1.1")},
+		Delete: []*endpoint.Endpoint{endpoint.NewEndpoint("old.example.com", endpoint.RecordTypeA, "192.168.1.1")},
+	})
+	require.NoError(t, err)
+
+	assert.Empty(t, api.adds)
+	assert.Empty(t, api.deletes)
+}
+
+func TestAdGuardAdjustEndpoints(t *testing.T) {
+	p := &AdGuardProvider{}
+
+	endpoints, err := p.AdjustEndpoints([]*endpoint.Endpoint{
+		endpoint.NewEndpointWithTTL("test.example.com", endpoint.RecordTypeA, 300, "192.168.1.1"),
+	})
+	require.NoError(t, err)
+
+	assert.False(t, endpoints[0].RecordTTL.IsConfigured())
+}
@@ -0,0 +1,95 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package adguard
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"sigs.k8s.io/external-dns/webhooks/internal/rest"
+)
+
+const (
+	apiRewriteList   = "/control/rewrite/list"
+	apiRewriteAdd    = "/control/rewrite/add"
+	apiRewriteDelete = "/control/rewrite/delete"
+)
+
+// rewrite is a DNS rewrite of AdGuard Home, answering the queries of a domain with an IP address
+// or another domain.
+type rewrite struct {
+	Domain string `json:"domain"`
+	Answer string `json:"answer"`
+}
+
+// adguardAPI declares the "API" actions performed against the AdGuard Home server.
+type adguardAPI interface {
+	// listRewrites returns all the DNS rewrites.
+	listRewrites(ctx context.Context) ([]rewrite, error)
+	// addRewrite adds the given DNS rewrite.
+	addRewrite(ctx context.Context, r rewrite) error
+	// deleteRewrite deletes the given DNS rewrite.
+	deleteRewrite(ctx context.Context, r rewrite) error
+}
+
+// adguardClient implements the adguardAPI.
+type adguardClient struct {
+	rest *rest.Client
+}
+
+// newAdGuardClient creates a new AdGuard Home API client.
+func newAdGuardClient(cfg AdGuardConfig) (adguardAPI, error) {
+	if cfg.Server == "" {
+		return nil, ErrNoAdGuardServer
+	}
+	if cfg.Password != "" && cfg.Username == "" {
+		return nil, errors.New("the AdGuard Home password requires a username")
+	}
+
+	cl, err := rest.NewClient(rest.Config{
+		Name:                  "the AdGuard Home server",
+		BaseURL:               cfg.Server,
+		Auth:                  rest.BasicAuth(cfg.Username, cfg.Password),
+		TLSInsecureSkipVerify: cfg.TLSInsecureSkipVerify,
+		RateLimit:             cfg.RateLimit,
+		// reject the responses which are not from the API, e.g. the login page or the error
+		// pages of a proxy
+		ContentTypes: []string{"application/json"},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &adguardClient{rest: cl}, nil
+}
+
+func (c *adguardClient) listRewrites(ctx context.Context) ([]rewrite, error) {
+	var rewrites []rewrite
+	if err := c.rest.Call(ctx, http.MethodGet, c.rest.URL(apiRewriteList), nil, &rewrites); err != nil {
+		return nil, err
+	}
+	return rewrites, nil
+}
+
+// addRewrite and deleteRewrite are answered with a plain text body, which is discarded.
+func (c *adguardClient) addRewrite(ctx context.Context, r rewrite) error {
+	return c.rest.Call(ctx, http.MethodPost, c.rest.URL(apiRewriteAdd), r, nil)
+}
+
+func (c *adguardClient) deleteRewrite(ctx context.Context, r rewrite) error {
+	return c.rest.Call(ctx, http.MethodPost, c.rest.URL(apiRewriteDelete), r, nil)
+}
@@ -0,0 +1,107 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package adguard
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/pkg/httpbody"
+)
+
+// newTestServer returns an AdGuard Home server protected by basic auth, with the given rewrites.
+func newTestServer(t *testing.T, rewrites *[]rewrite) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if username, password, ok := r.BasicAuth(); !ok || username != "admin" || password != "secret" {
+			w.Header().Set("Content-Type", "text/plain")
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte("Unauthorized"))
+			return
+		}
+
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == apiRewriteList:
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(rewrites)
+		case r.Method == http.MethodPost && (r.URL.Path == apiRewriteAdd || r.URL.Path == apiRewriteDelete):
+			var rw rewrite
+			if err := json.NewDecoder(r.Body).Decode(&rw); err != nil || rw.Domain == "" || rw.Answer == "" {
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte("invalid rewrite"))
+				return
+			}
+			if r.URL.Path == apiRewriteAdd {
+				*rewrites = append(*rewrites, rw)
+			} else {
+				for i, existing := range *rewrites {
+					if existing == rw {
+						*rewrites = append((*rewrites)[:i], (*rewrites)[i+1:]...)
+						break
+					}
+				}
+			}
+			w.Header().Set("Content-Type", "text/plain")
+			w.Write([]byte("OK"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+}
+
+func TestAdGuardClient(t *testing.T) {
+	rewrites := []rewrite{{Domain: "test.example.com", Answer: "192.168.1.1"}}
+	srv := newTestServer(t, &rewrites)
+	defer srv.Close()
+
+	cl, err := newAdGuardClient(AdGuardConfig{Server: srv.URL + "/", Username: "admin", Password: "secret"})
+	require.NoError(t, err)
+
+	listed, err := cl.listRewrites(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []rewrite{{Domain: "test.example.com", Answer: "192.168.1.1"}}, listed)
+
+	require.NoError(t, cl.addRewrite(context.Background(), rewrite{Domain: "alias.example.com", Answer: "test.example.com"}))
+	require.NoError(t, cl.deleteRewrite(context.Background(), rewrite{Domain: "test.example.com", Answer: "192.168.1.1"}))
+	assert.Equal(t, []rewrite{{Domain: "alias.example.com", Answer: "test.example.com"}}, rewrites)
+
+	err = cl.addRewrite(context.Background(), rewrite{Domain: "invalid.example.com"})
+	require.ErrorContains(t, err, "received 400 status code")
+}
+
+func TestAdGuardClientUnauthorized(t *testing.T) {
+	rewrites := []rewrite{}
+	srv := newTestServer(t, &rewrites)
+	defer srv.Close()
+
+	cl, err := newAdGuardClient(AdGuardConfig{Server: srv.URL, Username: "admin", Password: "wrong"})
+	require.NoError(t, err)
+
+	// The error page is not from the API.
+	_, err = cl.listRewrites(context.Background())
+	require.ErrorIs(t, err, httpbody.ErrUnexpectedContentType)
+
+	err = cl.addRewrite(context.Background(), rewrite{Domain: "test.example.com", Answer: "192.168.1.1"})
+	require.ErrorContains(t, err, "received 401 status code")
+	assert.Empty(t, rewrites)
+}
@@ -0,0 +1,49 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// The AdGuard Home webhook provider of ExternalDNS, managing the DNS rewrites of AdGuard Home.
+package main
+
+import (
+	"os"
+
+	"github.com/alecthomas/kingpin/v2"
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/webhooks/adguard"
+	"sigs.k8s.io/external-dns/webhooks/internal/server"
+)
+
+func main() {
+	var opts server.Options
+	var cfg adguard.AdGuardConfig
+	app := server.NewApp("external-dns-adguard-webhook", "Webhook provider of ExternalDNS managing the DNS rewrites of AdGuard Home.", &opts)
+	server.Flag(app, "adguard-server", "The base URL of the AdGuard Home web interface (required)").Required().StringVar(&cfg.Server)
+	server.Flag(app, "adguard-username", "The username to the server if it is protected").StringVar(&cfg.Username)
+	server.Flag(app, "adguard-password", "The password to the server if it is protected").StringVar(&cfg.Password)
+	server.Flag(app, "adguard-tls-skip-verify", "Disable verification of any TLS certificates").BoolVar(&cfg.TLSInsecureSkipVerify)
+	server.Flag(app, "adguard-rate-limit", "The maximum number of requests per second sent to the server (default: 0, unlimited)").Default("0").Float64Var(&cfg.RateLimit)
+	kingpin.MustParse(app.Parse(os.Args[1:]))
+
+	opts.ConfigureLogger()
+	cfg.DomainFilter = opts.NewDomainFilter()
+	cfg.DryRun = opts.DryRun
+	p, err := adguard.NewAdGuardProvider(cfg)
+	if err != nil {
+		log.Fatal(err)
+	}
+	server.Run(&opts, p)
+}
@@ -0,0 +1,317 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ibmcis
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"sigs.k8s.io/external-dns/webhooks/internal/rest"
+)
+
+const (
+	// DefaultEndpoint is the URL of the CIS API.
+	DefaultEndpoint = "https://api.cis.cloud.ibm.com"
+	// DefaultIAMEndpoint is the URL of the IAM API the tokens of the API key are requested from.
+	DefaultIAMEndpoint = "https://iam.cloud.ibm.com"
+
+	apiIAMToken = "/identity/token"
+
+	// zonesPerPage and recordsPerPage are the numbers of objects requested per page of a list.
+	zonesPerPage   = 50
+	recordsPerPage = 1000
+
+	// tokenRefreshMargin is the time before its expiry an IAM token is renewed.
+	tokenRefreshMargin = 5 * time.Minute
+)
+
+// zone is a zone of the CIS instance.
+type zone struct {
+	ID     string `json:"id"`
+	Name   string `json:"name"`
+	Status string `json:"status"`
+}
+
+// dnsRecord is a record of a zone, holding a single value. Its name is fully qualified.
+type dnsRecord struct {
+	ID      string `json:"id,omitempty"`
+	Type    string `json:"type"`
+	Name    string `json:"name"`
+	Content string `json:"content"`
+	// TTL is 1 for the automatic TTL, the only one of the proxied records.
+	TTL      int64 `json:"ttl"`
+	Proxied  bool  `json:"proxied"`
+	Priority *int  `json:"priority,omitempty"`
+}
+
+// loadBalancer is a global load balancer of a zone, answering the queries of its name with the
+// origins of its pools.
+type loadBalancer struct {
+	ID           string   `json:"id,omitempty"`
+	Name         string   `json:"name"`
+	DefaultPools []string `json:"default_pools"`
+	FallbackPool string   `json:"fallback_pool"`
+	Proxied      bool     `json:"proxied"`
+	TTL          int64    `json:"ttl,omitempty"`
+	Enabled      bool     `json:"enabled"`
+}
+
+// pool is an origin pool of the load balancers of the instance.
+type pool struct {
+	ID      string   `json:"id,omitempty"`
+	Name    string   `json:"name"`
+	Enabled bool     `json:"enabled"`
+	Origins []origin `json:"origins"`
+}
+
+// origin is an address of an origin pool, an IP address or a hostname.
+type origin struct {
+	Name    string `json:"name"`
+	Address string `json:"address"`
+	Enabled bool   `json:"enabled"`
+}
+
+// response is the envelope of the responses of the API.
+type response[T any] struct {
+	Result     T `json:"result"`
+	ResultInfo *struct {
+		TotalCount int `json:"total_count"`
+	} `json:"result_info"`
+}
+
+// cisAPI declares the "API" actions performed against the CIS instance.
+type cisAPI interface {
+	// listZones returns all the zones of the instance.
+	listZones(ctx context.Context) ([]zone, error)
+	// listRecords returns all the records of the given zone.
+	listRecords(ctx context.Context, zoneID string) ([]dnsRecord, error)
+	// createRecord creates the given record in the zone.
+	createRecord(ctx context.Context, zoneID string, r dnsRecord) error
+	// updateRecord replaces the record with the ID of the given one.
+	updateRecord(ctx context.Context, zoneID string, r dnsRecord) error
+	// deleteRecord deletes the record with the given ID.
+	deleteRecord(ctx context.Context, zoneID, id string) error
+	// listLoadBalancers returns the global load balancers of the given zone.
+	listLoadBalancers(ctx context.Context, zoneID string) ([]loadBalancer, error)
+	// createLoadBalancer creates the given global load balancer in the zone.
+	createLoadBalancer(ctx context.Context, zoneID string, lb loadBalancer) error
+	// updateLoadBalancer replaces the global load balancer with the ID of the given one.
+	updateLoadBalancer(ctx context.Context, zoneID string, lb loadBalancer) error
+	// deleteLoadBalancer deletes the global load balancer with the given ID.
+	deleteLoadBalancer(ctx context.Context, zoneID, id string) error
+	// listPools returns the origin pools of the instance.
+	listPools(ctx context.Context) ([]pool, error)
+	// createPool creates the given origin pool, and returns it with its ID.
+	createPool(ctx context.Context, p pool) (pool, error)
+	// updatePool replaces the origin pool with the ID of the given one.
+	updatePool(ctx context.Context, p pool) error
+	// deletePool deletes the origin pool with the given ID.
+	deletePool(ctx context.Context, id string) error
+}
+
+// cisClient implements the cisAPI.
+type cisClient struct {
+	crn  string
+	rest *rest.Client
+}
+
+// newCISClient creates a new CIS API client, authenticated with the IAM tokens of the API key.
+func newCISClient(cfg CISConfig) (cisAPI, error) {
+	if cfg.CRN == "" {
+		return nil, ErrNoCISInstance
+	}
+	if cfg.APIKey == "" {
+		return nil, ErrNoCISAPIKey
+	}
+
+	iam, err := rest.NewClient(rest.Config{
+		Name:         "the IAM endpoint",
+		BaseURL:      cfg.IAMEndpoint,
+		ContentTypes: []string{"application/json"},
+	})
+	if err != nil {
+		return nil, err
+	}
+	tokens := &iamTokens{iam: iam, apiKey: cfg.APIKey, now: time.Now}
+
+	cl, err := rest.NewClient(rest.Config{
+		Name:                  "the CIS endpoint",
+		BaseURL:               cfg.Endpoint,
+		Auth:                  tokens.authorize,
+		TLSInsecureSkipVerify: cfg.TLSInsecureSkipVerify,
+		RateLimit:             cfg.RateLimit,
+		ContentTypes:          []string{"application/json"},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &cisClient{crn: cfg.CRN, rest: cl}, nil
+}
+
+func (c *cisClient) listZones(ctx context.Context) ([]zone, error) {
+	return list[zone](ctx, c, c.instanceURL("/zones"), zonesPerPage)
+}
+
+func (c *cisClient) listRecords(ctx context.Context, zoneID string) ([]dnsRecord, error) {
+	return list[dnsRecord](ctx, c, c.zoneURL(zoneID, "/dns_records"), recordsPerPage)
+}
+
+func (c *cisClient) createRecord(ctx context.Context, zoneID string, r dnsRecord) error {
+	return c.rest.Call(ctx, http.MethodPost, c.zoneURL(zoneID, "/dns_records"), r, nil)
+}
+
+func (c *cisClient) updateRecord(ctx context.Context, zoneID string, r dnsRecord) error {
+	return c.rest.Call(ctx, http.MethodPut, c.zoneURL(zoneID, "/dns_records/"+url.PathEscape(r.ID)), r, nil)
+}
+
+func (c *cisClient) deleteRecord(ctx context.Context, zoneID, id string) error {
+	return c.rest.Call(ctx, http.MethodDelete, c.zoneURL(zoneID, "/dns_records/"+url.PathEscape(id)), nil, nil)
+}
+
+func (c *cisClient) listLoadBalancers(ctx context.Context, zoneID string) ([]loadBalancer, error) {
+	var res response[[]loadBalancer]
+	if err := c.rest.Call(ctx, http.MethodGet, c.zoneURL(zoneID, "/load_balancers"), nil, &res); err != nil {
+		return nil, err
+	}
+	return res.Result, nil
+}
+
+func (c *cisClient) createLoadBalancer(ctx context.Context, zoneID string, lb loadBalancer) error {
+	return c.rest.Call(ctx, http.MethodPost, c.zoneURL(zoneID, "/load_balancers"), lb, nil)
+}
+
+func (c *cisClient) updateLoadBalancer(ctx context.Context, zoneID string, lb loadBalancer) error {
+	return c.rest.Call(ctx, http.MethodPut, c.zoneURL(zoneID, "/load_balancers/"+url.PathEscape(lb.ID)), lb, nil)
+}
+
+func (c *cisClient) deleteLoadBalancer(ctx context.Context, zoneID, id string) error {
+	return c.rest.Call(ctx, http.MethodDelete, c.zoneURL(zoneID, "/load_balancers/"+url.PathEscape(id)), nil, nil)
+}
+
+func (c *cisClient) listPools(ctx context.Context) ([]pool, error) {
+	var res response[[]pool]
+	if err := c.rest.Call(ctx, http.MethodGet, c.instanceURL("/load_balancers/pools"), nil, &res); err != nil {
+		return nil, err
+	}
+	return res.Result, nil
+}
+
+func (c *cisClient) createPool(ctx context.Context, p pool) (pool, error) {
+	var res response[pool]
+	if err := c.rest.Call(ctx, http.MethodPost, c.instanceURL("/load_balancers/pools"), p, &res); err != nil {
+		return pool{}, err
+	}
+	return res.Result, nil
+}
+
+func (c *cisClient) updatePool(ctx context.Context, p pool) error {
+	return c.rest.Call(ctx, http.MethodPut, c.instanceURL("/load_balancers/pools/"+url.PathEscape(p.ID)), p, nil)
+}
+
+func (c *cisClient) deletePool(ctx context.Context, id string) error {
+	return c.rest.Call(ctx, http.MethodDelete, c.instanceURL("/load_balancers/pools/"+url.PathEscape(id)), nil, nil)
+}
+
+// instanceURL returns the URL of the given path of the API of the instance.
+func (c *cisClient) instanceURL(path string) string {
+	return c.rest.URL("/v1/" + url.PathEscape(c.crn) + path)
+}
+
+// zoneURL returns the URL of the given path of the API of a zone.
+func (c *cisClient) zoneURL(zoneID, path string) string {
+	return c.instanceURL("/zones/" + url.PathEscape(zoneID) + path)
+}
+
+// list returns the objects of all the pages of a list of the API.
+func list[T any](ctx context.Context, c *cisClient, endpoint string, perPage int) ([]T, error) {
+	pageURL := func(page int) string {
+		return fmt.Sprintf("%s?page=%d&per_page=%d", endpoint, page, perPage)
+	}
+	page := 1
+	return rest.ListPages(ctx, pageURL(page), func(ctx context.Context, u string) ([]T, string, error) {
+		var res response[[]T]
+		if err := c.rest.Call(ctx, http.MethodGet, u, nil, &res); err != nil {
+			return nil, "", err
+		}
+		if res.ResultInfo == nil || page*perPage >= res.ResultInfo.TotalCount {
+			return res.Result, "", nil
+		}
+		page++
+		return res.Result, pageURL(page), nil
+	})
+}
+
+// iamTokens authenticates the requests with the IAM tokens of an API key, renewed before they
+// expire.
+type iamTokens struct {
+	iam    *rest.Client
+	apiKey string
+	now    func() time.Time
+
+	mu     sync.Mutex
+	token  string
+	expiry time.Time
+}
+
+func (t *iamTokens) authorize(req *http.Request) error {
+	token, err := t.get(req.Context())
+	if err != nil {
+		return fmt.Errorf("failed to get an IAM token: %w", err)
+	}
+	req.Header.Set("X-Auth-User-Token", "Bearer "+token)
+	return nil
+}
+
+// get returns the current token, requesting a new one when it's about to expire.
+func (t *iamTokens) get(ctx context.Context) (string, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.token != "" && t.now().Before(t.expiry) {
+		return t.token, nil
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "urn:ibm:params:oauth:grant-type:apikey")
+	form.Set("apikey", t.apiKey)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, t.iam.URL(apiIAMToken), strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	var res struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int64  `json:"expires_in"`
+	}
+	if err := t.iam.Do(req, &res); err != nil {
+		return "", err
+	}
+	lifetime := time.Duration(res.ExpiresIn) * time.Second
+	if lifetime > 2*tokenRefreshMargin {
+		lifetime -= tokenRefreshMargin
+	} else {
+		lifetime /= 2
+	}
+	t.token, t.expiry = res.AccessToken, t.now().Add(lifetime)
+	return t.token, nil
+}
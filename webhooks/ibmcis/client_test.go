@@ -0,0 +1,210 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ibmcis
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/webhooks/internal/rest"
+)
+
+const (
+	testCRN      = "crn:v1:bluemix:public:internet-svcs:global:a/0123:4567::"
+	testInstance = "/v1/crn:v1:bluemix:public:internet-svcs:global:a%2F0123:4567::"
+)
+
+// testCall is a call to the API of the test server.
+type testCall struct {
+	method string
+	uri    string
+	body   string
+}
+
+// newTestServer returns a server of both the IAM and the CIS APIs recording the calls to them,
+// whose zones are listed in two pages.
+func newTestServer(t *testing.T, calls *[]testCall) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		*calls = append(*calls, testCall{r.Method, r.URL.RequestURI(), string(body)})
+
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == apiIAMToken {
+			if r.Method != http.MethodPost || !strings.Contains(string(body), "apikey=secret") {
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(`{"errorMessage": "Provided API key could not be found."}`))
+				return
+			}
+			w.Write([]byte(`{"access_token": "token", "token_type": "Bearer", "expires_in": 3600}`))
+			return
+		}
+		if r.Header.Get("X-Auth-User-Token") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"success": false, "errors": [{"code": 10000, "message": "Authentication error"}]}`))
+			return
+		}
+
+		path := strings.TrimPrefix(r.URL.EscapedPath(), testInstance)
+		switch {
+		case r.Method == http.MethodGet && path == "/zones" && r.URL.Query().Get("page") == "1":
+			w.Write([]byte(`{"result": [{"id": "z1", "name": "example.com", "status": "active"}], "result_info": {"page": 1, "per_page": 50, "total_count": 51}}`))
+		case r.Method == http.MethodGet && path == "/zones":
+			w.Write([]byte(`{"result": [{"id": "z2", "name": "example.org", "status": "pending"}], "result_info": {"page": 2, "per_page": 50, "total_count": 51}}`))
+		case r.Method == http.MethodGet && path == "/zones/z1/dns_records":
+			w.Write([]byte(`{"result": [
+				{"id": "r1", "type": "A", "name": "www.example.com", "content": "192.0.2.1", "ttl": 1, "proxied": true},
+				{"id": "r2", "type": "MX", "name": "example.com", "content": "mail.example.com", "ttl": 300, "proxied": false, "priority": 10}
+			], "result_info": {"page": 1, "per_page": 1000, "total_count": 2}}`))
+		case r.Method == http.MethodPost && path == "/zones/z1/dns_records",
+			r.Method == http.MethodPost && path == "/zones/z1/load_balancers":
+			w.Write([]byte(`{"result": {}}`))
+		case r.Method == http.MethodPut && (path == "/zones/z1/dns_records/r1" || path == "/zones/z1/load_balancers/lb1" || path == "/load_balancers/pools/p1"),
+			r.Method == http.MethodDelete && (path == "/zones/z1/dns_records/r2" || path == "/zones/z1/load_balancers/lb1" || path == "/load_balancers/pools/p1"):
+			w.Write([]byte(`{"result": {}}`))
+		case r.Method == http.MethodGet && path == "/zones/z1/load_balancers":
+			w.Write([]byte(`{"result": [{"id": "lb1", "name": "app.example.com", "default_pools": ["p1"], "fallback_pool": "p1", "proxied": false, "ttl": 30, "enabled": true}]}`))
+		case r.Method == http.MethodGet && path == "/load_balancers/pools":
+			w.Write([]byte(`{"result": [{"id": "p1", "name": "app", "enabled": true, "origins": [{"name": "origin-1", "address": "192.0.2.10", "enabled": true}]}]}`))
+		case r.Method == http.MethodPost && path == "/load_balancers/pools":
+			w.Write([]byte(`{"result": {"id": "p2", "name": "api", "enabled": true, "origins": []}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"success": false, "errors": [{"code": 7003, "message": "Could not route"}]}`))
+		}
+	}))
+}
+
+func TestNewCISClient(t *testing.T) {
+	_, err := newCISClient(CISConfig{APIKey: "secret"})
+	require.ErrorIs(t, err, ErrNoCISInstance)
+
+	_, err = newCISClient(CISConfig{CRN: testCRN})
+	require.ErrorIs(t, err, ErrNoCISAPIKey)
+
+	_, err = newCISClient(CISConfig{CRN: testCRN, APIKey: "secret", Endpoint: "api.cis.cloud.ibm.com", IAMEndpoint: DefaultIAMEndpoint})
+	require.EqualError(t, err, `the CIS endpoint must be an http or https URL, got "api.cis.cloud.ibm.com"`)
+}
+
+func TestCISClient(t *testing.T) {
+	var calls []testCall
+	srv := newTestServer(t, &calls)
+	defer srv.Close()
+
+	cl, err := newCISClient(CISConfig{CRN: testCRN, APIKey: "secret", Endpoint: srv.URL, IAMEndpoint: srv.URL})
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	zones, err := cl.listZones(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []zone{{ID: "z1", Name: "example.com", Status: "active"}, {ID: "z2", Name: "example.org", Status: "pending"}}, zones)
+
+	priority := 10
+	records, err := cl.listRecords(ctx, "z1")
+	require.NoError(t, err)
+	assert.Equal(t, []dnsRecord{
+		{ID: "r1", Type: "A", Name: "www.example.com", Content: "192.0.2.1", TTL: 1, Proxied: true},
+		{ID: "r2", Type: "MX", Name: "example.com", Content: "mail.example.com", TTL: 300, Priority: &priority},
+	}, records)
+
+	lbs, err := cl.listLoadBalancers(ctx, "z1")
+	require.NoError(t, err)
+	assert.Equal(t, []loadBalancer{{ID: "lb1", Name: "app.example.com", DefaultPools: []string{"p1"}, FallbackPool: "p1", TTL: 30, Enabled: true}}, lbs)
+
+	pools, err := cl.listPools(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []pool{{ID: "p1", Name: "app", Enabled: true, Origins: []origin{{Name: "origin-1", Address: "192.0.2.10", Enabled: true}}}}, pools)
+
+	require.NoError(t, cl.createRecord(ctx, "z1", dnsRecord{Type: "CNAME", Name: "alias.example.com", Content: "www.example.com", TTL: 1, Proxied: true}))
+	require.NoError(t, cl.updateRecord(ctx, "z1", dnsRecord{ID: "r1", Type: "A", Name: "www.example.com", Content: "192.0.2.1", TTL: 300}))
+	require.NoError(t, cl.deleteRecord(ctx, "z1", "r2"))
+	created, err := cl.createPool(ctx, pool{Name: "api", Enabled: true, Origins: []origin{{Name: "origin-1", Address: "api.example.net", Enabled: true}}})
+	require.NoError(t, err)
+	assert.Equal(t, "p2", created.ID)
+	require.NoError(t, cl.updatePool(ctx, pool{ID: "p1", Name: "app", Enabled: true}))
+	require.NoError(t, cl.createLoadBalancer(ctx, "z1", loadBalancer{Name: "api.example.com", DefaultPools: []string{"p2"}, FallbackPool: "p2", Proxied: true, Enabled: true}))
+	require.NoError(t, cl.updateLoadBalancer(ctx, "z1", loadBalancer{ID: "lb1", Name: "app.example.com", DefaultPools: []string{"p1"}, FallbackPool: "p1", TTL: 60, Enabled: true}))
+	require.NoError(t, cl.deleteLoadBalancer(ctx, "z1", "lb1"))
+	require.NoError(t, cl.deletePool(ctx, "p1"))
+
+	// The token of the API key is only requested once.
+	assert.Equal(t, []testCall{
+		{http.MethodPost, apiIAMToken, "apikey=secret&grant_type=urn%3Aibm%3Aparams%3Aoauth%3Agrant-type%3Aapikey"},
+		{http.MethodGet, testInstance + "/zones?page=1&per_page=50", ""},
+		{http.MethodGet, testInstance + "/zones?page=2&per_page=50", ""},
+		{http.MethodGet, testInstance + "/zones/z1/dns_records?page=1&per_page=1000", ""},
+		{http.MethodGet, testInstance + "/zones/z1/load_balancers", ""},
+		{http.MethodGet, testInstance + "/load_balancers/pools", ""},
+		{http.MethodPost, testInstance + "/zones/z1/dns_records", `{"type":"CNAME","name":"alias.example.com","content":"www.example.com","ttl":1,"proxied":true}`},
+		{http.MethodPut, testInstance + "/zones/z1/dns_records/r1", `{"id":"r1","type":"A","name":"www.example.com","content":"192.0.2.1","ttl":300,"proxied":false}`},
+		{http.MethodDelete, testInstance + "/zones/z1/dns_records/r2", ""},
+		{http.MethodPost, testInstance + "/load_balancers/pools", `{"name":"api","enabled":true,"origins":[{"name":"origin-1","address":"api.example.net","enabled":true}]}`},
+		{http.MethodPut, testInstance + "/load_balancers/pools/p1", `{"id":"p1","name":"app","enabled":true,"origins":null}`},
+		{http.MethodPost, testInstance + "/zones/z1/load_balancers", `{"name":"api.example.com","default_pools":["p2"],"fallback_pool":"p2","proxied":true,"enabled":true}`},
+		{http.MethodPut, testInstance + "/zones/z1/load_balancers/lb1", `{"id":"lb1","name":"app.example.com","default_pools":["p1"],"fallback_pool":"p1","proxied":false,"ttl":60,"enabled":true}`},
+		{http.MethodDelete, testInstance + "/zones/z1/load_balancers/lb1", ""},
+		{http.MethodDelete, testInstance + "/load_balancers/pools/p1", ""},
+	}, calls)
+
+	err = cl.deleteRecord(ctx, "z1", "r3")
+	require.ErrorIs(t, err, rest.ErrNotFound)
+}
+
+func TestCISClientUnauthorized(t *testing.T) {
+	var calls []testCall
+	srv := newTestServer(t, &calls)
+	defer srv.Close()
+
+	cl, err := newCISClient(CISConfig{CRN: testCRN, APIKey: "wrong", Endpoint: srv.URL, IAMEndpoint: srv.URL})
+	require.NoError(t, err)
+
+	// The API isn't called without a token.
+	_, err = cl.listZones(context.Background())
+	require.ErrorContains(t, err, "failed to get an IAM token: received 400 status code")
+	assert.Len(t, calls, 1)
+}
+
+func TestIAMTokens(t *testing.T) {
+	var calls []testCall
+	srv := newTestServer(t, &calls)
+	defer srv.Close()
+
+	iam, err := rest.NewClient(rest.Config{Name: "the IAM endpoint", BaseURL: srv.URL})
+	require.NoError(t, err)
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	tokens := &iamTokens{iam: iam, apiKey: "secret", now: func() time.Time { return now }}
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	require.NoError(t, tokens.authorize(req))
+	assert.Equal(t, "Bearer token", req.Header.Get("X-Auth-User-Token"))
+
+	// The token is renewed before it expires after an hour.
+	now = now.Add(50 * time.Minute)
+	require.NoError(t, tokens.authorize(req))
+	assert.Len(t, calls, 1)
+	now = now.Add(6 * time.Minute)
+	require.NoError(t, tokens.authorize(req))
+	assert.Len(t, calls, 2)
+}
@@ -0,0 +1,51 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// The IBM Cloud Internet Services webhook provider of ExternalDNS, managing the records and the global load balancers of the zones of a CIS instance.
+package main
+
+import (
+	"os"
+
+	"github.com/alecthomas/kingpin/v2"
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/webhooks/ibmcis"
+	"sigs.k8s.io/external-dns/webhooks/internal/server"
+)
+
+func main() {
+	var opts server.Options
+	var cfg ibmcis.CISConfig
+	app := server.NewApp("external-dns-ibmcis-webhook", "Webhook provider of ExternalDNS managing the records of IBM Cloud Internet Services.", &opts)
+	server.Flag(app, "ibmcis-crn", "The CRN of the CIS instance whose zones are managed (required)").Required().StringVar(&cfg.CRN)
+	server.Flag(app, "ibmcis-api-key", "The IBM Cloud API key of a user or service ID with the Manager role on the instance (required)").Required().StringVar(&cfg.APIKey)
+	server.Flag(app, "ibmcis-endpoint", "The URL of the CIS API").Default(ibmcis.DefaultEndpoint).StringVar(&cfg.Endpoint)
+	server.Flag(app, "ibmcis-iam-endpoint", "The URL of the IAM API the tokens of the API key are requested from").Default(ibmcis.DefaultIAMEndpoint).StringVar(&cfg.IAMEndpoint)
+	server.Flag(app, "ibmcis-proxied", "Proxy the A, AAAA and CNAME records by default, unless their webhook-ibmcis-proxied annotation is false").BoolVar(&cfg.ProxiedByDefault)
+	server.Flag(app, "ibmcis-tls-skip-verify", "Disable verification of any TLS certificates").BoolVar(&cfg.TLSInsecureSkipVerify)
+	server.Flag(app, "ibmcis-rate-limit", "The maximum number of requests per second sent to the CIS API (default: 0, unlimited)").Default("0").Float64Var(&cfg.RateLimit)
+	kingpin.MustParse(app.Parse(os.Args[1:]))
+
+	opts.ConfigureLogger()
+	cfg.DomainFilter = opts.NewDomainFilter()
+	cfg.DryRun = opts.DryRun
+	p, err := ibmcis.NewCISProvider(cfg)
+	if err != nil {
+		log.Fatal(err)
+	}
+	server.Run(&opts, p)
+}
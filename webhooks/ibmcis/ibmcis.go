@@ -0,0 +1,677 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ibmcis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/netip"
+	"regexp"
+	"slices"
+	"strconv"
+
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider"
+)
+
+const (
+	// proxiedKey is the property, set with the webhook-ibmcis-proxied annotation, of the records
+	// proxied by CIS.
+	proxiedKey = "webhook/ibmcis-proxied"
+	// glbPoolKey is the property, set with the webhook-ibmcis-glb-pool annotation, of the records
+	// published as a global load balancer, naming the origin pool of its targets.
+	glbPoolKey = "webhook/ibmcis-glb-pool"
+
+	// autoTTL is the TTL of the records whose TTL is chosen by CIS, the only one of proxied records.
+	autoTTL = 1
+)
+
+// poolName matches the names of the origin pools the provider creates.
+var poolName = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// proxiableTypes are the record types CIS can proxy, and publish as a global load balancer.
+var proxiableTypes = []string{endpoint.RecordTypeA, endpoint.RecordTypeAAAA, endpoint.RecordTypeCNAME}
+
+var (
+	// ErrNoCISInstance is returned when there is no CIS instance configured.
+	ErrNoCISInstance = errors.New("no CIS instance CRN found in the flags")
+	// ErrNoCISAPIKey is returned when there is no API key configured.
+	ErrNoCISAPIKey = errors.New("no IBM Cloud API key found in the environment or flags")
+)
+
+// CISProvider is an implementation of Provider for IBM Cloud Internet Services.
+type CISProvider struct {
+	provider.BaseProvider
+	api              cisAPI
+	domainFilter     *endpoint.DomainFilter
+	proxiedByDefault bool
+	dryRun           bool
+}
+
+// CISConfig is used for configuring a CISProvider.
+type CISConfig struct {
+	// The CRN of the CIS instance whose zones are managed.
+	CRN string
+	// The IBM Cloud API key of a user or service ID with access to the instance.
+	APIKey string
+	// The URL of the CIS API, DefaultEndpoint if empty.
+	Endpoint string
+	// The URL of the IAM API, DefaultIAMEndpoint if empty.
+	IAMEndpoint string
+	// Whether the records are proxied by CIS unless their annotation tells otherwise.
+	ProxiedByDefault bool
+	// Disable verification of TLS certificates.
+	TLSInsecureSkipVerify bool
+	// The maximum number of requests per second to the CIS API, unlimited if zero.
+	RateLimit float64
+	// A filter to apply when looking up and applying records.
+	DomainFilter *endpoint.DomainFilter
+	// Do nothing and log what would have changed to stdout.
+	DryRun bool
+}
+
+// Helper struct for grouping the records of an endpoint.
+type cisEntryKey struct {
+	DNSName    string
+	RecordType string
+}
+
+// NewCISProvider initializes a new IBM Cloud Internet Services based Provider.
+func NewCISProvider(cfg CISConfig) (*CISProvider, error) {
+	if cfg.Endpoint == "" {
+		cfg.Endpoint = DefaultEndpoint
+	}
+	if cfg.IAMEndpoint == "" {
+		cfg.IAMEndpoint = DefaultIAMEndpoint
+	}
+	api, err := newCISClient(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &CISProvider{api: api, domainFilter: cfg.DomainFilter, proxiedByDefault: cfg.ProxiedByDefault, dryRun: cfg.DryRun}, nil
+}
+
+// Capabilities implements provider.CapabilitiesReporter.
+func (p *CISProvider) Capabilities() provider.Capabilities {
+	return provider.Capabilities{
+		RecordTypes: []string{endpoint.RecordTypeA, endpoint.RecordTypeAAAA, endpoint.RecordTypeCNAME, endpoint.RecordTypeTXT, endpoint.RecordTypeMX},
+		Properties: []provider.PropertySchema{
+			{Name: proxiedKey, Type: provider.PropertyTypeBool},
+			{Name: glbPoolKey, Type: provider.PropertyTypeString},
+		},
+		PropertyPrefixes: []string{"webhook/ibmcis-"},
+	}
+}
+
+// AdjustEndpoints implements Provider, setting whether each endpoint is proxied, which CIS
+// reports for all the records, and dropping the TTLs of the proxied ones, chosen by CIS.
+func (p *CISProvider) AdjustEndpoints(endpoints []*endpoint.Endpoint) ([]*endpoint.Endpoint, error) {
+	for _, ep := range endpoints {
+		proxied := p.shouldBeProxied(ep)
+		if proxied {
+			ep.RecordTTL = 0
+		}
+		ep.SetProviderSpecificProperty(proxiedKey, strconv.FormatBool(proxied))
+
+		if name, ok := ep.GetProviderSpecificProperty(glbPoolKey); ok {
+			switch {
+			case !slices.Contains(proxiableTypes, ep.RecordType):
+				log.Warnf("Publishing %s as a record because %s records can't be load balanced", ep.DNSName, ep.RecordType)
+				ep.DeleteProviderSpecificProperty(glbPoolKey)
+			case !poolName.MatchString(name):
+				log.Warnf("Publishing %s as a record because the origin pool name %q is not made of letters, digits, dashes and underscores", ep.DNSName, name)
+				ep.DeleteProviderSpecificProperty(glbPoolKey)
+			}
+		}
+	}
+	return endpoints, nil
+}
+
+// shouldBeProxied returns whether the endpoint is proxied, as set by its annotation or by default,
+// the records of the types CIS can't proxy never being.
+func (p *CISProvider) shouldBeProxied(ep *endpoint.Endpoint) bool {
+	if !slices.Contains(proxiableTypes, ep.RecordType) {
+		return false
+	}
+	proxied := p.proxiedByDefault
+	if v, ok := ep.GetProviderSpecificProperty(proxiedKey); ok {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			log.Errorf("Failed to parse the %s property of %s: %v", proxiedKey, ep.DNSName, err)
+		} else {
+			proxied = b
+		}
+	}
+	return proxied
+}
+
+// zones returns the zones of the instance matching the domain filter, by ID.
+func (p *CISProvider) zones(ctx context.Context) (provider.ZoneIDName, error) {
+	zones, err := p.api.listZones(ctx)
+	if err != nil {
+		return nil, err
+	}
+	result := provider.ZoneIDName{}
+	for _, z := range zones {
+		if !p.domainFilter.Match(z.Name) {
+			continue
+		}
+		result.Add(z.ID, z.Name)
+	}
+	return result, nil
+}
+
+// Records implements Provider, populating a slice of endpoints from the records and the global
+// load balancers of the zones.
+func (p *CISProvider) Records(ctx context.Context) ([]*endpoint.Endpoint, error) {
+	zones, err := p.zones(ctx)
+	if err != nil {
+		return nil, err
+	}
+	pools, err := p.poolsByID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var endpoints []*endpoint.Endpoint
+	for zoneID := range zones {
+		records, err := p.records(ctx, zoneID)
+		if err != nil {
+			return nil, err
+		}
+
+		// The API has a record per value of a name and type.
+		byKey := make(map[cisEntryKey]*endpoint.Endpoint)
+		for _, r := range records {
+			key := cisEntryKey{r.Name, r.Type}
+			target := recordTarget(r)
+			if ep, ok := byKey[key]; ok {
+				ep.Targets = append(ep.Targets, target)
+				continue
+			}
+			ep := endpoint.NewEndpointWithTTL(r.Name, r.Type, recordTTL(r.TTL), target).
+				WithProviderSpecific(proxiedKey, strconv.FormatBool(r.Proxied))
+			byKey[key] = ep
+			endpoints = append(endpoints, ep)
+		}
+
+		lbs, err := p.api.listLoadBalancers(ctx, zoneID)
+		if err != nil {
+			return nil, err
+		}
+		for _, lb := range lbs {
+			if ep := loadBalancerEndpoint(lb, pools); ep != nil {
+				endpoints = append(endpoints, ep)
+			}
+		}
+	}
+	return endpoints, nil
+}
+
+// records returns the records of the zone of the supported types.
+func (p *CISProvider) records(ctx context.Context, zoneID string) ([]dnsRecord, error) {
+	records, err := p.api.listRecords(ctx, zoneID)
+	if err != nil {
+		return nil, err
+	}
+	supported := p.Capabilities().RecordTypes
+	var result []dnsRecord
+	for _, r := range records {
+		if slices.Contains(supported, r.Type) {
+			result = append(result, r)
+		}
+	}
+	return result, nil
+}
+
+// poolsByID returns the origin pools of the instance by ID.
+func (p *CISProvider) poolsByID(ctx context.Context) (map[string]pool, error) {
+	pools, err := p.api.listPools(ctx)
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[string]pool, len(pools))
+	for _, pl := range pools {
+		result[pl.ID] = pl
+	}
+	return result, nil
+}
+
+// loadBalancerEndpoint returns the endpoint of a global load balancer, whose targets are the
+// enabled origins of its default pool, or nil if it has none the provider could have created.
+func loadBalancerEndpoint(lb loadBalancer, pools map[string]pool) *endpoint.Endpoint {
+	if len(lb.DefaultPools) == 0 {
+		return nil
+	}
+	pl, ok := pools[lb.DefaultPools[0]]
+	if !ok {
+		log.Debugf("Skipping load balancer %s because its pool %s was not found", lb.Name, lb.DefaultPools[0])
+		return nil
+	}
+	var targets endpoint.Targets
+	for _, o := range pl.Origins {
+		if o.Enabled {
+			targets = append(targets, o.Address)
+		}
+	}
+	if len(targets) == 0 {
+		return nil
+	}
+	var ttl endpoint.TTL
+	if !lb.Proxied {
+		ttl = endpoint.TTL(lb.TTL)
+	}
+	return endpoint.NewEndpointWithTTL(lb.Name, targetsType(targets), ttl, targets...).
+		WithProviderSpecific(proxiedKey, strconv.FormatBool(lb.Proxied)).
+		WithProviderSpecific(glbPoolKey, pl.Name)
+}
+
+// targetsType returns the record type of the addresses of origins.
+func targetsType(targets endpoint.Targets) string {
+	recordType := endpoint.RecordTypeA
+	for _, t := range targets {
+		addr, err := netip.ParseAddr(t)
+		if err != nil {
+			return endpoint.RecordTypeCNAME
+		}
+		if addr.Is6() {
+			recordType = endpoint.RecordTypeAAAA
+		}
+	}
+	return recordType
+}
+
+// recordTTL returns the TTL of an endpoint for the TTL of a record, the automatic TTL being unset.
+func recordTTL(ttl int64) endpoint.TTL {
+	if ttl == autoTTL {
+		return 0
+	}
+	return endpoint.TTL(ttl)
+}
+
+// recordTarget returns the target of an endpoint for a record.
+func recordTarget(r dnsRecord) string {
+	if r.Type == endpoint.RecordTypeMX && r.Priority != nil {
+		return endpoint.NewMXTarget(uint16(*r.Priority), r.Content)
+	}
+	return r.Content
+}
+
+// newRecord returns the record of a target of an endpoint.
+func newRecord(ep *endpoint.Endpoint, target string, ttl int64, proxied bool) (dnsRecord, error) {
+	r := dnsRecord{Type: ep.RecordType, Name: ep.DNSName, Content: target, TTL: ttl, Proxied: proxied}
+	if ep.RecordType == endpoint.RecordTypeMX {
+		mx, err := endpoint.ParseMXTarget(target)
+		if err != nil {
+			return dnsRecord{}, err
+		}
+		priority := int(mx.Priority)
+		r.Content, r.Priority = mx.Host, &priority
+	}
+	return r, nil
+}
+
+// cisChange is a change of a record of a zone.
+type cisChange struct {
+	action string
+	zoneID string
+	record dnsRecord
+}
+
+// glbChange is a change of the global load balancer of an endpoint, which is deleted when
+// desired is nil.
+type glbChange struct {
+	zoneID  string
+	current *loadBalancer
+	desired *endpoint.Endpoint
+	// oldPool is the name of the pool of the load balancer before the change, if any.
+	oldPool string
+}
+
+// ApplyChanges implements Provider, applying the changes to the records and the global load
+// balancers of the zones.
+func (p *CISProvider) ApplyChanges(ctx context.Context, changes *plan.Changes) error {
+	zones, err := p.zones(ctx)
+	if err != nil {
+		return err
+	}
+
+	// The records and load balancers of the zones are only listed once, when the first change of
+	// a zone is found.
+	existing := make(map[string]map[cisEntryKey][]dnsRecord)
+	current := func(zoneID string, key cisEntryKey) ([]dnsRecord, error) {
+		if _, ok := existing[zoneID]; !ok {
+			records, err := p.records(ctx, zoneID)
+			if err != nil {
+				return nil, err
+			}
+			byKey := make(map[cisEntryKey][]dnsRecord)
+			for _, r := range records {
+				k := cisEntryKey{r.Name, r.Type}
+				byKey[k] = append(byKey[k], r)
+			}
+			existing[zoneID] = byKey
+		}
+		return existing[zoneID][key], nil
+	}
+	balancers := make(map[string][]loadBalancer)
+	currentLB := func(zoneID, name string) (*loadBalancer, error) {
+		if _, ok := balancers[zoneID]; !ok {
+			lbs, err := p.api.listLoadBalancers(ctx, zoneID)
+			if err != nil {
+				return nil, err
+			}
+			balancers[zoneID] = lbs
+		}
+		for _, lb := range balancers[zoneID] {
+			if lb.Name == name {
+				return &lb, nil
+			}
+		}
+		return nil, nil
+	}
+
+	var deletes, updates, creates []cisChange
+	var glbDeletes, glbUpdates []glbChange
+	// reconcile replaces the records of the name and type of the endpoint having one of the old
+	// targets by the records of the targets of the endpoint, unless it is nil.
+	reconcile := func(ep *endpoint.Endpoint, oldTargets []string, desired *endpoint.Endpoint) error {
+		if !slices.Contains(p.Capabilities().RecordTypes, ep.RecordType) {
+			log.Warnf("Skipping record %s because %s records are not supported", ep.DNSName, ep.RecordType)
+			return nil
+		}
+		zoneID, _ := zones.FindZone(ep.DNSName)
+		if zoneID == "" {
+			log.Debugf("Skipping record %s because no zone was found", ep.DNSName)
+			return nil
+		}
+		records, err := current(zoneID, cisEntryKey{ep.DNSName, ep.RecordType})
+		if err != nil {
+			return err
+		}
+
+		var targets []string
+		ttl, proxied := int64(autoTTL), false
+		if desired != nil {
+			targets = desired.Targets
+			proxied = p.shouldBeProxied(desired)
+			if !proxied && desired.RecordTTL.IsConfigured() {
+				ttl = int64(desired.RecordTTL)
+			}
+		}
+
+		kept := make(map[string]bool)
+		for _, r := range records {
+			target := recordTarget(r)
+			switch {
+			case slices.Contains(targets, target) && !kept[target]:
+				kept[target] = true
+				if r.TTL != ttl || r.Proxied != proxied {
+					r.TTL, r.Proxied = ttl, proxied
+					updates = append(updates, cisChange{"Updating", zoneID, r})
+				}
+			case slices.Contains(oldTargets, target):
+				deletes = append(deletes, cisChange{"Deleting", zoneID, r})
+			}
+		}
+		for _, target := range targets {
+			if kept[target] {
+				continue
+			}
+			r, err := newRecord(ep, target, ttl, proxied)
+			if err != nil {
+				return provider.NewSoftError(fmt.Errorf("%s: %w", ep.DNSName, err))
+			}
+			creates = append(creates, cisChange{"Creating", zoneID, r})
+			kept[target] = true
+		}
+		return nil
+	}
+	// balance replaces the global load balancer of the name of the endpoint by the one of the
+	// desired endpoint, unless it is nil.
+	balance := func(ep *endpoint.Endpoint, desired *endpoint.Endpoint) error {
+		zoneID, _ := zones.FindZone(ep.DNSName)
+		if zoneID == "" {
+			log.Debugf("Skipping load balancer %s because no zone was found", ep.DNSName)
+			return nil
+		}
+		lb, err := currentLB(zoneID, ep.DNSName)
+		if err != nil {
+			return err
+		}
+		oldPool, _ := ep.GetProviderSpecificProperty(glbPoolKey)
+		change := glbChange{zoneID: zoneID, current: lb, desired: desired, oldPool: oldPool}
+		if desired == nil {
+			if lb != nil {
+				glbDeletes = append(glbDeletes, change)
+			}
+			return nil
+		}
+		glbUpdates = append(glbUpdates, change)
+		return nil
+	}
+	// change dispatches the change of an endpoint between its records and its load balancer, an
+	// endpoint switching from one to the other being deleted then created.
+	change := func(old, desired *endpoint.Endpoint) error {
+		oldGLB := old != nil && isLoadBalanced(old)
+		desiredGLB := desired != nil && isLoadBalanced(desired)
+		if old != nil && (desired == nil || oldGLB != desiredGLB) {
+			var err error
+			if oldGLB {
+				err = balance(old, nil)
+			} else {
+				err = reconcile(old, old.Targets, nil)
+			}
+			if err != nil {
+				return err
+			}
+			old = nil
+		}
+		switch {
+		case desired == nil:
+			return nil
+		case desiredGLB:
+			if old == nil {
+				return balance(desired, desired)
+			}
+			return balance(old, desired)
+		case old == nil:
+			return reconcile(desired, nil, desired)
+		default:
+			return reconcile(desired, old.Targets, desired)
+		}
+	}
+
+	for _, ep := range changes.Delete {
+		if err := change(ep, nil); err != nil {
+			return err
+		}
+	}
+	updateOld := make(map[cisEntryKey]*endpoint.Endpoint)
+	for _, ep := range changes.UpdateOld {
+		updateOld[cisEntryKey{ep.DNSName, ep.RecordType}] = ep
+	}
+	for _, ep := range changes.UpdateNew {
+		if err := change(updateOld[cisEntryKey{ep.DNSName, ep.RecordType}], ep); err != nil {
+			return err
+		}
+	}
+	for _, ep := range changes.Create {
+		if err := change(nil, ep); err != nil {
+			return err
+		}
+	}
+
+	for _, c := range deletes {
+		if err := p.apply(c, func() error { return p.api.deleteRecord(ctx, c.zoneID, c.record.ID) }); err != nil {
+			return err
+		}
+	}
+	// The load balancers are deleted before the records replacing them are created, and created
+	// after the records they replace are deleted.
+	for _, c := range glbDeletes {
+		if err := p.deleteLoadBalancer(ctx, c); err != nil {
+			return err
+		}
+	}
+	for _, c := range updates {
+		if err := p.apply(c, func() error { return p.api.updateRecord(ctx, c.zoneID, c.record) }); err != nil {
+			return err
+		}
+	}
+	for _, c := range glbUpdates {
+		if err := p.ensureLoadBalancer(ctx, c); err != nil {
+			return err
+		}
+	}
+	for _, c := range creates {
+		if err := p.apply(c, func() error { return p.api.createRecord(ctx, c.zoneID, c.record) }); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// isLoadBalanced returns whether the endpoint is published as a global load balancer.
+func isLoadBalanced(ep *endpoint.Endpoint) bool {
+	_, ok := ep.GetProviderSpecificProperty(glbPoolKey)
+	return ok
+}
+
+func (p *CISProvider) apply(change cisChange, fn func() error) error {
+	r := change.record
+	if p.dryRun {
+		log.Infof("DRY RUN: %s %s IN %s -> %s (proxied: %t)", change.action, r.Name, r.Type, r.Content, r.Proxied)
+		return nil
+	}
+	log.Infof("%s %s IN %s -> %s (proxied: %t)", change.action, r.Name, r.Type, r.Content, r.Proxied)
+	return fn()
+}
+
+// ensureLoadBalancer creates or updates the origin pool and the global load balancer of the
+// desired endpoint of the change, then deletes the pool the load balancer used before, if unused.
+func (p *CISProvider) ensureLoadBalancer(ctx context.Context, c glbChange) error {
+	ep := c.desired
+	name, _ := ep.GetProviderSpecificProperty(glbPoolKey)
+	pools, err := p.api.listPools(ctx)
+	if err != nil {
+		return err
+	}
+
+	desiredPool := pool{Name: name, Enabled: true}
+	targets := slices.Sorted(slices.Values(ep.Targets))
+	for i, target := range targets {
+		desiredPool.Origins = append(desiredPool.Origins, origin{Name: fmt.Sprintf("origin-%d", i+1), Address: target, Enabled: true})
+	}
+	idx := slices.IndexFunc(pools, func(pl pool) bool { return pl.Name == name })
+	switch {
+	case idx < 0:
+		if p.dryRun {
+			log.Infof("DRY RUN: Creating origin pool %s -> %v", name, targets)
+			break
+		}
+		log.Infof("Creating origin pool %s -> %v", name, targets)
+		created, err := p.api.createPool(ctx, desiredPool)
+		if err != nil {
+			return err
+		}
+		desiredPool.ID = created.ID
+	case !slices.Equal(pools[idx].Origins, desiredPool.Origins):
+		desiredPool.ID = pools[idx].ID
+		if p.dryRun {
+			log.Infof("DRY RUN: Updating origin pool %s -> %v", name, targets)
+			break
+		}
+		log.Infof("Updating origin pool %s -> %v", name, targets)
+		if err := p.api.updatePool(ctx, desiredPool); err != nil {
+			return err
+		}
+	default:
+		desiredPool.ID = pools[idx].ID
+	}
+
+	lb := loadBalancer{
+		Name:         ep.DNSName,
+		DefaultPools: []string{desiredPool.ID},
+		FallbackPool: desiredPool.ID,
+		Proxied:      p.shouldBeProxied(ep),
+		Enabled:      true,
+	}
+	if !lb.Proxied && ep.RecordTTL.IsConfigured() {
+		lb.TTL = int64(ep.RecordTTL)
+	}
+	action := "Creating"
+	if c.current != nil {
+		action, lb.ID = "Updating", c.current.ID
+	}
+	if p.dryRun {
+		log.Infof("DRY RUN: %s load balancer %s -> pool %s (proxied: %t)", action, lb.Name, name, lb.Proxied)
+		return nil
+	}
+	log.Infof("%s load balancer %s -> pool %s (proxied: %t)", action, lb.Name, name, lb.Proxied)
+	if c.current != nil {
+		err = p.api.updateLoadBalancer(ctx, c.zoneID, lb)
+	} else {
+		err = p.api.createLoadBalancer(ctx, c.zoneID, lb)
+	}
+	if err != nil {
+		return err
+	}
+	if c.oldPool != "" && c.oldPool != name {
+		p.deletePool(ctx, c.oldPool)
+	}
+	return nil
+}
+
+// deleteLoadBalancer deletes the global load balancer of the change, then its origin pool.
+func (p *CISProvider) deleteLoadBalancer(ctx context.Context, c glbChange) error {
+	if p.dryRun {
+		log.Infof("DRY RUN: Deleting load balancer %s", c.current.Name)
+		return nil
+	}
+	log.Infof("Deleting load balancer %s", c.current.Name)
+	if err := p.api.deleteLoadBalancer(ctx, c.zoneID, c.current.ID); err != nil {
+		return err
+	}
+	if c.oldPool != "" {
+		p.deletePool(ctx, c.oldPool)
+	}
+	return nil
+}
+
+// deletePool deletes the origin pool with the given name. CIS refuses to delete the pools which
+// are still used by a load balancer, e.g. one of another zone, which are only logged as the
+// load balancer changed anyway.
+func (p *CISProvider) deletePool(ctx context.Context, name string) {
+	pools, err := p.api.listPools(ctx)
+	if err != nil {
+		log.Warnf("Failed to delete origin pool %s: %v", name, err)
+		return
+	}
+	idx := slices.IndexFunc(pools, func(pl pool) bool { return pl.Name == name })
+	if idx < 0 {
+		return
+	}
+	log.Infof("Deleting origin pool %s", name)
+	if err := p.api.deletePool(ctx, pools[idx].ID); err != nil {
+		log.Warnf("Failed to delete origin pool %s: %v", name, err)
+	}
+}
@@ -0,0 +1,394 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ibmcis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider"
+)
+
+type testCISClient struct {
+	zones   []zone
+	records map[string][]dnsRecord
+	lbs     map[string][]loadBalancer
+	pools   []pool
+	calls   []string
+	err     error
+}
+
+func (t *testCISClient) listZones(_ context.Context) ([]zone, error) {
+	return t.zones, t.err
+}
+
+func (t *testCISClient) listRecords(_ context.Context, zoneID string) ([]dnsRecord, error) {
+	return t.records[zoneID], t.err
+}
+
+func (t *testCISClient) createRecord(_ context.Context, zoneID string, r dnsRecord) error {
+	call := fmt.Sprintf("create %s %s %s %s ttl=%d proxied=%t", zoneID, r.Name, r.Type, r.Content, r.TTL, r.Proxied)
+	if r.Priority != nil {
+		call += fmt.Sprintf(" priority=%d", *r.Priority)
+	}
+	t.calls = append(t.calls, call)
+	return nil
+}
+
+func (t *testCISClient) updateRecord(_ context.Context, zoneID string, r dnsRecord) error {
+	t.calls = append(t.calls, fmt.Sprintf("update %s %s ttl=%d proxied=%t", zoneID, r.ID, r.TTL, r.Proxied))
+	return nil
+}
+
+func (t *testCISClient) deleteRecord(_ context.Context, zoneID, id string) error {
+	t.calls = append(t.calls, fmt.Sprintf("delete %s %s", zoneID, id))
+	return nil
+}
+
+func (t *testCISClient) listLoadBalancers(_ context.Context, zoneID string) ([]loadBalancer, error) {
+	return t.lbs[zoneID], t.err
+}
+
+func (t *testCISClient) createLoadBalancer(_ context.Context, zoneID string, lb loadBalancer) error {
+	t.calls = append(t.calls, fmt.Sprintf("create lb %s %s pools=%v fallback=%s proxied=%t ttl=%d", zoneID, lb.Name, lb.DefaultPools, lb.FallbackPool, lb.Proxied, lb.TTL))
+	return nil
+}
+
+func (t *testCISClient) updateLoadBalancer(_ context.Context, zoneID string, lb loadBalancer) error {
+	t.calls = append(t.calls, fmt.Sprintf("update lb %s %s pools=%v fallback=%s proxied=%t ttl=%d", zoneID, lb.ID, lb.DefaultPools, lb.FallbackPool, lb.Proxied, lb.TTL))
+	return nil
+}
+
+func (t *testCISClient) deleteLoadBalancer(_ context.Context, zoneID, id string) error {
+	t.calls = append(t.calls, fmt.Sprintf("delete lb %s %s", zoneID, id))
+	t.lbs[zoneID] = slices.DeleteFunc(t.lbs[zoneID], func(lb loadBalancer) bool { return lb.ID == id })
+	return nil
+}
+
+func (t *testCISClient) listPools(_ context.Context) ([]pool, error) {
+	return t.pools, t.err
+}
+
+func (t *testCISClient) createPool(_ context.Context, p pool) (pool, error) {
+	p.ID = fmt.Sprintf("p%d", len(t.pools)+1)
+	t.calls = append(t.calls, fmt.Sprintf("create pool %s %s", p.Name, originAddresses(p)))
+	t.pools = append(t.pools, p)
+	return p, nil
+}
+
+func (t *testCISClient) updatePool(_ context.Context, p pool) error {
+	t.calls = append(t.calls, fmt.Sprintf("update pool %s %s", p.ID, originAddresses(p)))
+	return nil
+}
+
+// deletePool refuses to delete the pools used by a load balancer, as CIS does.
+func (t *testCISClient) deletePool(_ context.Context, id string) error {
+	for _, lbs := range t.lbs {
+		for _, lb := range lbs {
+			if slices.Contains(lb.DefaultPools, id) {
+				return errors.New("pool is in use")
+			}
+		}
+	}
+	t.calls = append(t.calls, fmt.Sprintf("delete pool %s", id))
+	return nil
+}
+
+func originAddresses(p pool) []string {
+	var addresses []string
+	for _, o := range p.Origins {
+		addresses = append(addresses, o.Address)
+	}
+	return addresses
+}
+
+func priority(v int) *int {
+	return &v
+}
+
+func newTestClient() *testCISClient {
+	return &testCISClient{
+		zones: []zone{
+			{ID: "z1", Name: "example.com", Status: "active"},
+			{ID: "z2", Name: "example.org", Status: "active"},
+		},
+		records: map[string][]dnsRecord{
+			"z1": {
+				{ID: "r1", Type: "A", Name: "www.example.com", Content: "192.0.2.1", TTL: 1, Proxied: true},
+				{ID: "r2", Type: "A", Name: "www.example.com", Content: "192.0.2.2", TTL: 1, Proxied: true},
+				{ID: "r3", Type: "TXT", Name: "example.com", Content: "heritage=external-dns", TTL: 1},
+				{ID: "r4", Type: "MX", Name: "example.com", Content: "mail.example.com", TTL: 300, Priority: priority(10)},
+				{ID: "r5", Type: "NS", Name: "example.com", Content: "ns1.cis.cloud.ibm.com", TTL: 86400},
+				{ID: "r6", Type: "CNAME", Name: "old.example.com", Content: "www.example.com", TTL: 300},
+			},
+		},
+		lbs: map[string][]loadBalancer{
+			"z1": {
+				{ID: "lb1", Name: "app.example.com", DefaultPools: []string{"p1"}, FallbackPool: "p1", TTL: 30, Enabled: true},
+				// The load balancer whose pool is not found is skipped.
+				{ID: "lb2", Name: "stale.example.com", DefaultPools: []string{"p9"}, FallbackPool: "p9", TTL: 30, Enabled: true},
+			},
+		},
+		pools: []pool{
+			{ID: "p1", Name: "app", Enabled: true, Origins: []origin{
+				{Name: "origin-1", Address: "192.0.2.10", Enabled: true},
+				{Name: "origin-2", Address: "192.0.2.11", Enabled: false},
+			}},
+		},
+	}
+}
+
+func TestNewCISProvider(t *testing.T) {
+	_, err := NewCISProvider(CISConfig{APIKey: "secret"})
+	require.ErrorIs(t, err, ErrNoCISInstance)
+
+	_, err = NewCISProvider(CISConfig{CRN: testCRN})
+	require.ErrorIs(t, err, ErrNoCISAPIKey)
+
+	_, err = NewCISProvider(CISConfig{CRN: testCRN, APIKey: "secret"})
+	require.NoError(t, err)
+}
+
+func TestCISAdjustEndpoints(t *testing.T) {
+	for _, tc := range []struct {
+		name             string
+		proxiedByDefault bool
+		endpoint         *endpoint.Endpoint
+		expected         *endpoint.Endpoint
+	}{
+		{
+			name:     "not proxied by default",
+			endpoint: endpoint.NewEndpointWithTTL("www.example.com", endpoint.RecordTypeA, 300, "192.0.2.1"),
+			expected: endpoint.NewEndpointWithTTL("www.example.com", endpoint.RecordTypeA, 300, "192.0.2.1").
+				WithProviderSpecific(proxiedKey, "false"),
+		},
+		{
+			name:             "proxied by default",
+			proxiedByDefault: true,
+			endpoint:         endpoint.NewEndpointWithTTL("www.example.com", endpoint.RecordTypeA, 300, "192.0.2.1"),
+			expected: endpoint.NewEndpoint("www.example.com", endpoint.RecordTypeA, "192.0.2.1").
+				WithProviderSpecific(proxiedKey, "true"),
+		},
+		{
+			name:             "proxied by annotation",
+			endpoint:         endpoint.NewEndpoint("alias.example.com", endpoint.RecordTypeCNAME, "www.example.com").WithProviderSpecific(proxiedKey, "true"),
+			proxiedByDefault: false,
+			expected:         endpoint.NewEndpoint("alias.example.com", endpoint.RecordTypeCNAME, "www.example.com").WithProviderSpecific(proxiedKey, "true"),
+		},
+		{
+			name:             "not proxied by annotation",
+			proxiedByDefault: true,
+			endpoint:         endpoint.NewEndpoint("alias.example.com", endpoint.RecordTypeCNAME, "www.example.com").WithProviderSpecific(proxiedKey, "false"),
+			expected:         endpoint.NewEndpoint("alias.example.com", endpoint.RecordTypeCNAME, "www.example.com").WithProviderSpecific(proxiedKey, "false"),
+		},
+		{
+			name:             "TXT records are never proxied",
+			proxiedByDefault: true,
+			endpoint:         endpoint.NewEndpointWithTTL("example.com", endpoint.RecordTypeTXT, 300, "heritage=external-dns").WithProviderSpecific(proxiedKey, "true"),
+			expected:         endpoint.NewEndpointWithTTL("example.com", endpoint.RecordTypeTXT, 300, "heritage=external-dns").WithProviderSpecific(proxiedKey, "false"),
+		},
+		{
+			name:     "load balancer",
+			endpoint: endpoint.NewEndpoint("app.example.com", endpoint.RecordTypeA, "192.0.2.10").WithProviderSpecific(glbPoolKey, "app_1"),
+			expected: endpoint.NewEndpoint("app.example.com", endpoint.RecordTypeA, "192.0.2.10").
+				WithProviderSpecific(glbPoolKey, "app_1").WithProviderSpecific(proxiedKey, "false"),
+		},
+		{
+			name:     "load balancer with an invalid pool name",
+			endpoint: endpoint.NewEndpoint("app.example.com", endpoint.RecordTypeA, "192.0.2.10").WithProviderSpecific(glbPoolKey, "app pool"),
+			expected: endpoint.NewEndpoint("app.example.com", endpoint.RecordTypeA, "192.0.2.10").WithProviderSpecific(proxiedKey, "false"),
+		},
+		{
+			name:     "load balancer of an MX record",
+			endpoint: endpoint.NewEndpoint("example.com", endpoint.RecordTypeMX, "10 mail.example.com").WithProviderSpecific(glbPoolKey, "mail"),
+			expected: endpoint.NewEndpoint("example.com", endpoint.RecordTypeMX, "10 mail.example.com").WithProviderSpecific(proxiedKey, "false"),
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			p := &CISProvider{proxiedByDefault: tc.proxiedByDefault}
+
+			endpoints, err := p.AdjustEndpoints([]*endpoint.Endpoint{tc.endpoint})
+			require.NoError(t, err)
+			assert.Equal(t, []*endpoint.Endpoint{tc.expected}, endpoints)
+		})
+	}
+}
+
+func TestCISRecords(t *testing.T) {
+	p := &CISProvider{api: newTestClient(), domainFilter: endpoint.NewDomainFilter([]string{"example.com"})}
+
+	records, err := p.Records(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, []*endpoint.Endpoint{
+		endpoint.NewEndpoint("www.example.com", endpoint.RecordTypeA, "192.0.2.1", "192.0.2.2").WithProviderSpecific(proxiedKey, "true"),
+		endpoint.NewEndpoint("example.com", endpoint.RecordTypeTXT, "heritage=external-dns").WithProviderSpecific(proxiedKey, "false"),
+		endpoint.NewEndpointWithTTL("example.com", endpoint.RecordTypeMX, 300, "10 mail.example.com").WithProviderSpecific(proxiedKey, "false"),
+		endpoint.NewEndpointWithTTL("old.example.com", endpoint.RecordTypeCNAME, 300, "www.example.com").WithProviderSpecific(proxiedKey, "false"),
+		endpoint.NewEndpointWithTTL("app.example.com", endpoint.RecordTypeA, 30, "192.0.2.10").
+			WithProviderSpecific(proxiedKey, "false").WithProviderSpecific(glbPoolKey, "app"),
+	}, records)
+}
+
+func TestCISRecordsError(t *testing.T) {
+	errAPI := errors.New("api error")
+	p := &CISProvider{api: &testCISClient{err: errAPI}}
+
+	_, err := p.Records(context.Background())
+	require.ErrorIs(t, err, errAPI)
+}
+
+func TestTargetsType(t *testing.T) {
+	assert.Equal(t, endpoint.RecordTypeA, targetsType(endpoint.Targets{"192.0.2.1", "192.0.2.2"}))
+	assert.Equal(t, endpoint.RecordTypeAAAA, targetsType(endpoint.Targets{"2001:db8::1"}))
+	assert.Equal(t, endpoint.RecordTypeCNAME, targetsType(endpoint.Targets{"app.example.net"}))
+}
+
+func TestCISApplyChanges(t *testing.T) {
+	api := newTestClient()
+	p := &CISProvider{api: api}
+
+	err := p.ApplyChanges(context.Background(), &plan.Changes{
+		Create: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("new.example.com", endpoint.RecordTypeA, "192.0.2.20").WithProviderSpecific(proxiedKey, "true"),
+			endpoint.NewEndpoint("example.org", endpoint.RecordTypeMX, "10 mail.example.org."),
+			endpoint.NewEndpoint("api.example.com", endpoint.RecordTypeCNAME, "api.example.net").
+				WithProviderSpecific(proxiedKey, "true").WithProviderSpecific(glbPoolKey, "api"),
+		},
+		UpdateOld: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("www.example.com", endpoint.RecordTypeA, "192.0.2.1", "192.0.2.2").WithProviderSpecific(proxiedKey, "true"),
+			endpoint.NewEndpointWithTTL("old.example.com", endpoint.RecordTypeCNAME, 300, "www.example.com").WithProviderSpecific(proxiedKey, "false"),
+			endpoint.NewEndpointWithTTL("app.example.com", endpoint.RecordTypeA, 30, "192.0.2.10").
+				WithProviderSpecific(proxiedKey, "false").WithProviderSpecific(glbPoolKey, "app"),
+		},
+		UpdateNew: []*endpoint.Endpoint{
+			endpoint.NewEndpointWithTTL("www.example.com", endpoint.RecordTypeA, 300, "192.0.2.2", "192.0.2.5").WithProviderSpecific(proxiedKey, "false"),
+			// The record becomes a load balancer.
+			endpoint.NewEndpoint("old.example.com", endpoint.RecordTypeCNAME, "www.example.com").
+				WithProviderSpecific(proxiedKey, "true").WithProviderSpecific(glbPoolKey, "old"),
+			endpoint.NewEndpointWithTTL("app.example.com", endpoint.RecordTypeA, 60, "192.0.2.12", "192.0.2.10").
+				WithProviderSpecific(proxiedKey, "false").WithProviderSpecific(glbPoolKey, "app"),
+		},
+		Delete: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("example.com", endpoint.RecordTypeTXT, "heritage=external-dns").WithProviderSpecific(proxiedKey, "false"),
+		},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{
+		"delete z1 r3",
+		"delete z1 r1",
+		"delete z1 r6",
+		"update z1 r2 ttl=300 proxied=false",
+		"create pool old [www.example.com]",
+		"create lb z1 old.example.com pools=[p2] fallback=p2 proxied=true ttl=0",
+		"update pool p1 [192.0.2.10 192.0.2.12]",
+		"update lb z1 lb1 pools=[p1] fallback=p1 proxied=false ttl=60",
+		"create pool api [api.example.net]",
+		"create lb z1 api.example.com pools=[p3] fallback=p3 proxied=true ttl=0",
+		"create z1 www.example.com A 192.0.2.5 ttl=300 proxied=false",
+		"create z1 new.example.com A 192.0.2.20 ttl=1 proxied=true",
+		"create z2 example.org MX mail.example.org ttl=1 proxied=false priority=10",
+	}, api.calls)
+}
+
+func TestCISApplyChangesDeleteLoadBalancer(t *testing.T) {
+	api := newTestClient()
+	// Another load balancer uses the pool of the deleted one, which is kept.
+	api.lbs["z2"] = []loadBalancer{{ID: "lb3", Name: "app.example.org", DefaultPools: []string{"p1"}, FallbackPool: "p1", Enabled: true}}
+	p := &CISProvider{api: api}
+
+	err := p.ApplyChanges(context.Background(), &plan.Changes{
+		Delete: []*endpoint.Endpoint{
+			endpoint.NewEndpointWithTTL("app.example.com", endpoint.RecordTypeA, 30, "192.0.2.10").
+				WithProviderSpecific(proxiedKey, "false").WithProviderSpecific(glbPoolKey, "app"),
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"delete lb z1 lb1"}, api.calls)
+
+	api = newTestClient()
+	p = &CISProvider{api: api}
+	err = p.ApplyChanges(context.Background(), &plan.Changes{
+		Delete: []*endpoint.Endpoint{
+			endpoint.NewEndpointWithTTL("app.example.com", endpoint.RecordTypeA, 30, "192.0.2.10").
+				WithProviderSpecific(proxiedKey, "false").WithProviderSpecific(glbPoolKey, "app"),
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"delete lb z1 lb1", "delete pool p1"}, api.calls)
+}
+
+func TestCISApplyChangesLoadBalancerToRecord(t *testing.T) {
+	api := newTestClient()
+	p := &CISProvider{api: api}
+
+	err := p.ApplyChanges(context.Background(), &plan.Changes{
+		UpdateOld: []*endpoint.Endpoint{
+			endpoint.NewEndpointWithTTL("app.example.com", endpoint.RecordTypeA, 30, "192.0.2.10").
+				WithProviderSpecific(proxiedKey, "false").WithProviderSpecific(glbPoolKey, "app"),
+		},
+		UpdateNew: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("app.example.com", endpoint.RecordTypeA, "192.0.2.10").WithProviderSpecific(proxiedKey, "true"),
+		},
+	})
+	require.NoError(t, err)
+
+	// The load balancer is deleted before the record is created.
+	assert.Equal(t, []string{
+		"delete lb z1 lb1",
+		"delete pool p1",
+		"create z1 app.example.com A 192.0.2.10 ttl=1 proxied=true",
+	}, api.calls)
+}
+
+func TestCISApplyChangesInvalidMX(t *testing.T) {
+	api := newTestClient()
+	p := &CISProvider{api: api}
+
+	err := p.ApplyChanges(context.Background(), &plan.Changes{
+		Create: []*endpoint.Endpoint{endpoint.NewEndpoint("example.com", endpoint.RecordTypeMX, "mail.example.com")},
+	})
+	require.ErrorIs(t, err, provider.SoftError)
+	assert.Empty(t, api.calls)
+}
+
+func TestCISApplyChangesDryRun(t *testing.T) {
+	api := newTestClient()
+	p := &CISProvider{api: api, dryRun: true}
+
+	err := p.ApplyChanges(context.Background(), &plan.Changes{
+		Create: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("new.example.com", endpoint.RecordTypeA, "192.0.2.10"),
+			endpoint.NewEndpoint("api.example.com", endpoint.RecordTypeA, "192.0.2.20").WithProviderSpecific(glbPoolKey, "api"),
+		},
+		Delete: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("www.example.com", endpoint.RecordTypeA, "192.0.2.1", "192.0.2.2"),
+			endpoint.NewEndpointWithTTL("app.example.com", endpoint.RecordTypeA, 30, "192.0.2.10").WithProviderSpecific(glbPoolKey, "app"),
+		},
+	})
+	require.NoError(t, err)
+	assert.Empty(t, api.calls)
+}
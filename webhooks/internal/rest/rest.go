@@ -0,0 +1,252 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package rest is the client shared by the webhook providers of this directory speaking to a
+// small JSON REST API: it validates the URL of the server, authenticates and rate limits the
+// requests, instruments them, reads the responses defensively and reports the failed ones with
+// a StatusError, so that the providers only describe the endpoints of their API.
+package rest
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/linki/instrumented_http"
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/time/rate"
+
+	"sigs.k8s.io/external-dns/pkg/httpbody"
+)
+
+var (
+	// ErrUnauthorized matches the errors of the requests rejected with a 401 or 403 status.
+	ErrUnauthorized = errors.New("unauthorized")
+	// ErrNotFound matches the errors of the requests answered with a 404 status.
+	ErrNotFound = errors.New("not found")
+	// ErrRateLimited matches the errors of the requests rejected with a 429 status.
+	ErrRateLimited = errors.New("rate limited")
+	// ErrServer matches the errors of the requests answered with a 5xx status.
+	ErrServer = errors.New("server error")
+)
+
+// StatusError is the error of a request answered with a status other than 2xx.
+type StatusError struct {
+	Method     string
+	URL        string
+	StatusCode int
+	// Body is the beginning of the body of the response, which usually tells the cause.
+	Body string
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("received %d status code from request %s %s: %s", e.StatusCode, e.Method, e.URL, e.Body)
+}
+
+// Is matches the error with the sentinel error of its status.
+func (e *StatusError) Is(target error) bool {
+	switch target {
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized || e.StatusCode == http.StatusForbidden
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	case ErrServer:
+		return e.StatusCode >= 500
+	}
+	return false
+}
+
+// Auth authenticates a request to the API, e.g. with a token it may fail to get.
+type Auth func(req *http.Request) error
+
+// BasicAuth authenticates the requests with the username and the password, or not at all when
+// both are empty.
+func BasicAuth(username, password string) Auth {
+	if username == "" && password == "" {
+		return nil
+	}
+	return func(req *http.Request) error {
+		req.SetBasicAuth(username, password)
+		return nil
+	}
+}
+
+// HeaderAuth authenticates the requests with the value of the header, e.g. a token.
+func HeaderAuth(name, value string) Auth {
+	return func(req *http.Request) error {
+		req.Header.Set(name, value)
+		return nil
+	}
+}
+
+// Config is the configuration of a client.
+type Config struct {
+	// Name names the base URL in the errors, e.g. "the NetBox URL".
+	Name string
+	// BaseURL is the root URL of the server, the paths of the API are relative to.
+	BaseURL string
+	// Auth authenticates the requests, which are sent as they are if nil.
+	Auth Auth
+	// TLSInsecureSkipVerify disables the verification of the TLS certificates of the server.
+	TLSInsecureSkipVerify bool
+	// RateLimit is the maximum number of requests sent per second, unlimited if zero.
+	RateLimit float64
+	// ContentTypes are the media types of the bodies of the API, any if empty.
+	ContentTypes []string
+}
+
+// Client sends the requests to a REST API.
+type Client struct {
+	cfg        Config
+	httpClient *http.Client
+	limiter    *rate.Limiter
+	guard      httpbody.Guard
+}
+
+// NewClient returns a client of the API of the configuration, or an error if its base URL isn't an
+// absolute http or https URL.
+func NewClient(cfg Config) (*Client, error) {
+	if u, err := url.Parse(cfg.BaseURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("%s must be an http or https URL, got %q", cfg.Name, cfg.BaseURL)
+	}
+	if cfg.RateLimit < 0 {
+		return nil, fmt.Errorf("the rate limit must be positive, got %v", cfg.RateLimit)
+	}
+
+	httpClient := &http.Client{
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{
+				InsecureSkipVerify: cfg.TLSInsecureSkipVerify,
+			},
+		},
+	}
+	c := &Client{
+		cfg:        cfg,
+		httpClient: instrumented_http.NewClient(httpClient, &instrumented_http.Callbacks{}),
+		guard:      httpbody.Guard{ContentTypes: cfg.ContentTypes},
+	}
+	if cfg.RateLimit > 0 {
+		c.limiter = rate.NewLimiter(rate.Limit(cfg.RateLimit), 1)
+	}
+	return c, nil
+}
+
+// URL returns the URL of the given path of the API.
+func (c *Client) URL(path string) string {
+	return strings.TrimSuffix(c.cfg.BaseURL, "/") + path
+}
+
+// Call sends a request to the endpoint, with the JSON of in as body unless it is nil, and
+// unmarshals the response into out, unless it is nil.
+func (c *Client) Call(ctx context.Context, method, endpoint string, in, out any) error {
+	var body io.Reader
+	if in != nil {
+		data, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	return c.Do(req, out)
+}
+
+// Do authenticates and sends the request, and unmarshals the JSON of the response into out. The
+// body of the response is discarded when out is nil, whatever its content type, and is rejected
+// otherwise when it's not one of the content types of the API, e.g. a login or error page.
+func (c *Client) Do(req *http.Request, out any) error {
+	log.Debugf("Calling %s %s", req.Method, req.URL)
+
+	if c.limiter != nil {
+		if err := c.limiter.Wait(req.Context()); err != nil {
+			return err
+		}
+	}
+	if c.cfg.Auth != nil {
+		if err := c.cfg.Auth(req); err != nil {
+			return err
+		}
+	}
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+
+	if out == nil {
+		defer res.Body.Close()
+		if !success(res) {
+			body, _ := httpbody.ReadAll(res.Body, httpbody.DefaultMaxBytes)
+			return statusError(req, res, body)
+		}
+		return nil
+	}
+
+	body, err := c.guard.Read(res)
+	if err != nil {
+		return err
+	}
+	if !success(res) {
+		return statusError(req, res, body)
+	}
+	if err := json.Unmarshal(body, out); err != nil {
+		return fmt.Errorf("failed to unmarshal the response of %s: %w", req.URL, err)
+	}
+	return nil
+}
+
+// ListPages returns the items of all the pages of a list, starting with the first URL: page
+// fetches the items of the page at the URL, and the URL of the next page, empty for the last.
+func ListPages[T any](ctx context.Context, first string, page func(ctx context.Context, url string) ([]T, string, error)) ([]T, error) {
+	var items []T
+	for next := first; next != ""; {
+		pageItems, nextURL, err := page(ctx, next)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, pageItems...)
+		next = nextURL
+	}
+	return items, nil
+}
+
+func success(res *http.Response) bool {
+	return res.StatusCode >= 200 && res.StatusCode < 300
+}
+
+func statusError(req *http.Request, res *http.Response, body []byte) error {
+	return &StatusError{
+		Method:     req.Method,
+		URL:        req.URL.String(),
+		StatusCode: res.StatusCode,
+		Body:       strings.TrimSpace(string(body)),
+	}
+}
@@ -0,0 +1,164 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/pkg/httpbody"
+)
+
+func TestNewClient(t *testing.T) {
+	for _, baseURL := range []string{"", "example.org", "ftp://example.org", "https://"} {
+		_, err := NewClient(Config{Name: "the test server", BaseURL: baseURL})
+		require.EqualError(t, err, fmt.Sprintf("the test server must be an http or https URL, got %q", baseURL))
+	}
+	_, err := NewClient(Config{Name: "the test server", BaseURL: "https://example.org", RateLimit: -1})
+	require.Error(t, err)
+
+	c, err := NewClient(Config{Name: "the test server", BaseURL: "https://example.org/api/"})
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.org/api/zones", c.URL("/zones"))
+}
+
+func TestClientCall(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Token secret" {
+			w.Header().Set("Content-Type", "text/html")
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte("<html>login</html>"))
+			return
+		}
+		switch r.URL.Path {
+		case "/items":
+			body, _ := io.ReadAll(r.Body)
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = fmt.Fprintf(w, `{"method":%q,"body":%q}`, r.Method, body)
+		case "/text":
+			// answered with a plain text body, discarded without out
+			w.Header().Set("Content-Type", "text/plain")
+			_, _ = w.Write([]byte("OK"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte("no such path\n"))
+		}
+	}))
+	defer srv.Close()
+
+	c, err := NewClient(Config{
+		Name:         "the test server",
+		BaseURL:      srv.URL,
+		Auth:         HeaderAuth("Authorization", "Token secret"),
+		RateLimit:    1000,
+		ContentTypes: []string{"application/json"},
+	})
+	require.NoError(t, err)
+
+	var out struct {
+		Method string `json:"method"`
+		Body   string `json:"body"`
+	}
+	require.NoError(t, c.Call(context.Background(), http.MethodPost, c.URL("/items"), map[string]int{"ttl": 300}, &out))
+	assert.Equal(t, http.MethodPost, out.Method)
+	assert.JSONEq(t, `{"ttl":300}`, out.Body)
+
+	require.NoError(t, c.Call(context.Background(), http.MethodPost, c.URL("/text"), nil, nil))
+	require.ErrorIs(t, c.Call(context.Background(), http.MethodGet, c.URL("/text"), nil, &out), httpbody.ErrUnexpectedContentType)
+
+	err = c.Call(context.Background(), http.MethodDelete, c.URL("/missing"), nil, nil)
+	require.EqualError(t, err, "received 404 status code from request DELETE "+srv.URL+"/missing: no such path")
+	require.ErrorIs(t, err, ErrNotFound)
+	require.NotErrorIs(t, err, ErrServer)
+
+	// the responses of the error pages of a proxy or of the login page are rejected
+	c, err = NewClient(Config{Name: "the test server", BaseURL: srv.URL, ContentTypes: []string{"application/json"}})
+	require.NoError(t, err)
+	require.ErrorIs(t, c.Call(context.Background(), http.MethodGet, c.URL("/items"), nil, &out), httpbody.ErrUnexpectedContentType)
+	require.ErrorIs(t, c.Call(context.Background(), http.MethodGet, c.URL("/items"), nil, nil), ErrUnauthorized)
+}
+
+func TestClientRateLimit(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {}))
+	defer srv.Close()
+
+	c, err := NewClient(Config{Name: "the test server", BaseURL: srv.URL, RateLimit: 0.001})
+	require.NoError(t, err)
+	require.NoError(t, c.Call(context.Background(), http.MethodGet, c.URL("/"), nil, nil))
+
+	// the next request would wait for longer than the deadline
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	require.ErrorContains(t, c.Call(ctx, http.MethodGet, c.URL("/"), nil, nil), "would exceed context deadline")
+}
+
+func TestBasicAuth(t *testing.T) {
+	assert.Nil(t, BasicAuth("", ""))
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	require.NoError(t, BasicAuth("admin", "secret")(req))
+	username, password, ok := req.BasicAuth()
+	assert.True(t, ok)
+	assert.Equal(t, "admin", username)
+	assert.Equal(t, "secret", password)
+}
+
+func TestStatusError(t *testing.T) {
+	for status, sentinel := range map[int]error{
+		http.StatusUnauthorized:        ErrUnauthorized,
+		http.StatusForbidden:           ErrUnauthorized,
+		http.StatusNotFound:            ErrNotFound,
+		http.StatusTooManyRequests:     ErrRateLimited,
+		http.StatusInternalServerError: ErrServer,
+		http.StatusBadGateway:          ErrServer,
+	} {
+		err := error(&StatusError{Method: http.MethodGet, URL: "https://example.org", StatusCode: status})
+		assert.ErrorIs(t, err, sentinel, status)
+		assert.ErrorIs(t, fmt.Errorf("wrapped: %w", err), sentinel, status)
+	}
+	assert.False(t, errors.Is(&StatusError{StatusCode: http.StatusBadRequest}, ErrServer))
+}
+
+func TestListPages(t *testing.T) {
+	pages := map[string]struct {
+		items []int
+		next  string
+	}{
+		"first":  {[]int{1, 2}, "second"},
+		"second": {[]int{3}, "third"},
+		"third":  {nil, ""},
+	}
+	items, err := ListPages(context.Background(), "first", func(_ context.Context, u string) ([]int, string, error) {
+		return pages[u].items, pages[u].next, nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 3}, items)
+
+	_, err = ListPages(context.Background(), "first", func(_ context.Context, _ string) ([]int, string, error) {
+		return nil, "", errors.New("failed")
+	})
+	require.EqualError(t, err, "failed")
+}
@@ -0,0 +1,108 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package server runs the webhook providers of this directory: it parses the options they share,
+// serves the webhook API of the provider on localhost, and the health and metrics endpoints on all
+// the interfaces, as ExternalDNS expects from a webhook provider running as a sidecar.
+package server
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/alecthomas/kingpin/v2"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/pkg/apis/externaldns"
+	"sigs.k8s.io/external-dns/provider"
+	"sigs.k8s.io/external-dns/provider/webhook/api"
+)
+
+// Options are the options shared by the webhook providers.
+type Options struct {
+	DomainFilter   []string
+	ExcludeDomains []string
+	DryRun         bool
+	LogLevel       string
+	LogFormat      string
+	WebhookAddress string
+	HealthAddress  string
+	ReadTimeout    time.Duration
+	WriteTimeout   time.Duration
+}
+
+// NewApp returns the application parsing the flags of a webhook provider, with the shared options.
+// The provider adds its own flags with Flag.
+func NewApp(name, help string, o *Options) *kingpin.Application {
+	app := kingpin.New(name, help)
+	app.Version(externaldns.Version)
+	Flag(app, "domain-filter", "Limit possible target zones by a domain suffix; specify multiple times for multiple domains (optional)").StringsVar(&o.DomainFilter)
+	Flag(app, "exclude-domains", "Exclude subdomains (optional)").StringsVar(&o.ExcludeDomains)
+	Flag(app, "dry-run", "When enabled, logs the changes instead of applying them (default: disabled)").BoolVar(&o.DryRun)
+	Flag(app, "log-level", "Set the level of logging. (default: info, options: panic, debug, info, warning, error, fatal)").Default("info").EnumVar(&o.LogLevel, "panic", "debug", "info", "warning", "error", "fatal")
+	Flag(app, "log-format", "The format in which log messages are printed (default: text, options: text, json)").Default("text").EnumVar(&o.LogFormat, "text", "json")
+	Flag(app, "webhook-address", "The address the webhook API is served on, which should only be reachable by ExternalDNS (default: 127.0.0.1:8888)").Default("127.0.0.1:8888").StringVar(&o.WebhookAddress)
+	Flag(app, "health-address", "The address the health and metrics endpoints are served on (default: :8080)").Default(":8080").StringVar(&o.HealthAddress)
+	Flag(app, "webhook-read-timeout", "The read timeout of the webhook API (default: 5s)").Default("5s").DurationVar(&o.ReadTimeout)
+	Flag(app, "webhook-write-timeout", "The write timeout of the webhook API (default: 10s)").Default("10s").DurationVar(&o.WriteTimeout)
+	return app
+}
+
+// Flag adds a flag to the application, which can be replaced with an environment variable as the
+// flags of ExternalDNS, e.g. --dry-run with EXTERNAL_DNS_DRY_RUN.
+func Flag(app *kingpin.Application, name, help string) *kingpin.FlagClause {
+	return app.Flag(name, help).Envar("EXTERNAL_DNS_" + strings.ToUpper(strings.ReplaceAll(name, "-", "_")))
+}
+
+// NewDomainFilter returns the domain filter of the options.
+func (o *Options) NewDomainFilter() *endpoint.DomainFilter {
+	return endpoint.NewDomainFilterWithExclusions(o.DomainFilter, o.ExcludeDomains)
+}
+
+// ConfigureLogger configures the format and the level of the logs.
+func (o *Options) ConfigureLogger() {
+	if o.LogFormat == "json" {
+		log.SetFormatter(&log.JSONFormatter{})
+	}
+	ll, err := log.ParseLevel(o.LogLevel)
+	if err != nil {
+		log.Fatalf("failed to parse log level: %v", err)
+	}
+	log.SetLevel(ll)
+}
+
+// Run serves the health and metrics endpoints in the background, and the webhook API of the provider
+// until it fails.
+func Run(o *Options, p provider.Provider) {
+	go func() {
+		log.Fatal(http.ListenAndServe(o.HealthAddress, healthHandler()))
+	}()
+	api.StartHTTPApi(p, nil, o.ReadTimeout, o.WriteTimeout, o.WebhookAddress)
+}
+
+// healthHandler returns the handler of the health and metrics endpoints.
+func healthHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("OK"))
+	})
+	mux.Handle("/metrics", promhttp.Handler())
+	return mux
+}
@@ -0,0 +1,62 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewApp(t *testing.T) {
+	var o Options
+	var token string
+	app := NewApp("test-webhook", "A test webhook provider.", &o)
+	Flag(app, "test-token", "A token.").Required().StringVar(&token)
+
+	t.Setenv("EXTERNAL_DNS_TEST_TOKEN", "secret")
+	t.Setenv("EXTERNAL_DNS_DRY_RUN", "true")
+	_, err := app.Parse([]string{"--domain-filter=example.org", "--domain-filter=example.com", "--exclude-domains=internal.example.org"})
+	require.NoError(t, err)
+
+	assert.Equal(t, "secret", token)
+	assert.Equal(t, Options{
+		DomainFilter:   []string{"example.org", "example.com"},
+		ExcludeDomains: []string{"internal.example.org"},
+		DryRun:         true,
+		LogLevel:       "info",
+		LogFormat:      "text",
+		WebhookAddress: "127.0.0.1:8888",
+		HealthAddress:  ":8080",
+		ReadTimeout:    5 * time.Second,
+		WriteTimeout:   10 * time.Second,
+	}, o)
+	assert.True(t, o.NewDomainFilter().Match("www.example.com"))
+	assert.False(t, o.NewDomainFilter().Match("www.internal.example.org"))
+}
+
+func TestHealthHandler(t *testing.T) {
+	for path, status := range map[string]int{"/healthz": http.StatusOK, "/metrics": http.StatusOK, "/records": http.StatusNotFound} {
+		rec := httptest.NewRecorder()
+		healthHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		assert.Equal(t, status, rec.Code, path)
+	}
+}
@@ -0,0 +1,173 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package netbox
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"sigs.k8s.io/external-dns/webhooks/internal/rest"
+)
+
+const (
+	apiZones   = "/api/plugins/netbox-dns/zones/"
+	apiRecords = "/api/plugins/netbox-dns/records/"
+
+	// pageSize is the number of objects requested per page of a list.
+	pageSize = 1000
+)
+
+// view is a view of the NetBox DNS plugin, holding zones.
+type view struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+// zone is a zone of the NetBox DNS plugin.
+type zone struct {
+	ID     int    `json:"id"`
+	Name   string `json:"name"`
+	Status string `json:"status"`
+	View   *view  `json:"view"`
+}
+
+// tag is a NetBox tag, identified by its slug when written.
+type tag struct {
+	Name string `json:"name,omitempty"`
+	Slug string `json:"slug"`
+}
+
+// record is a record of a zone, holding a single value. Its name is relative to the zone, "@"
+// being the apex, and the names of its value are fully qualified when they end with a dot.
+type record struct {
+	ID     int    `json:"id,omitempty"`
+	Zone   int    `json:"zone"`
+	Type   string `json:"type"`
+	Name   string `json:"name"`
+	Value  string `json:"value"`
+	TTL    *int64 `json:"ttl"`
+	Status string `json:"status"`
+	Tags   []tag  `json:"tags,omitempty"`
+	// Managed records, e.g. the SOA, NS and PTR records, are maintained by the plugin.
+	Managed bool `json:"managed,omitempty"`
+}
+
+// UnmarshalJSON reads the zone of a record from its nested representation.
+func (r *record) UnmarshalJSON(data []byte) error {
+	type plain record
+	var raw struct {
+		plain
+		Zone zone `json:"zone"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	*r = record(raw.plain)
+	r.Zone = raw.Zone.ID
+	return nil
+}
+
+// page is a page of a list of the API.
+type page[T any] struct {
+	Next    string `json:"next"`
+	Results []T    `json:"results"`
+}
+
+// netboxAPI declares the "API" actions performed against the NetBox DNS plugin.
+type netboxAPI interface {
+	// listZones returns all the zones.
+	listZones(ctx context.Context) ([]zone, error)
+	// listRecords returns the records of the given zone, only those with the given tag unless
+	// it is empty.
+	listRecords(ctx context.Context, zoneID int, tagSlug string) ([]record, error)
+	// createRecord creates the given record.
+	createRecord(ctx context.Context, r record) error
+	// updateRecordTTL sets the TTL of the record with the given ID.
+	updateRecordTTL(ctx context.Context, id int, ttl *int64) error
+	// deleteRecord deletes the record with the given ID.
+	deleteRecord(ctx context.Context, id int) error
+}
+
+// netboxClient implements the netboxAPI.
+type netboxClient struct {
+	rest *rest.Client
+}
+
+// newNetBoxClient creates a new NetBox API client.
+func newNetBoxClient(cfg NetBoxConfig) (netboxAPI, error) {
+	if cfg.URL == "" {
+		return nil, ErrNoNetBoxURL
+	}
+	if cfg.Token == "" {
+		return nil, ErrNoNetBoxToken
+	}
+
+	cl, err := rest.NewClient(rest.Config{
+		Name:                  "the NetBox URL",
+		BaseURL:               cfg.URL,
+		Auth:                  rest.HeaderAuth("Authorization", "Token "+cfg.Token),
+		TLSInsecureSkipVerify: cfg.TLSInsecureSkipVerify,
+		RateLimit:             cfg.RateLimit,
+		// reject the responses which are not from the API, e.g. the login page or the error
+		// pages of a proxy
+		ContentTypes: []string{"application/json"},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &netboxClient{rest: cl}, nil
+}
+
+func (c *netboxClient) listZones(ctx context.Context) ([]zone, error) {
+	return list[zone](ctx, c, apiZones, url.Values{})
+}
+
+func (c *netboxClient) listRecords(ctx context.Context, zoneID int, tagSlug string) ([]record, error) {
+	params := url.Values{}
+	params.Set("zone_id", strconv.Itoa(zoneID))
+	if tagSlug != "" {
+		params.Set("tag", tagSlug)
+	}
+	return list[record](ctx, c, apiRecords, params)
+}
+
+func (c *netboxClient) createRecord(ctx context.Context, r record) error {
+	return c.rest.Call(ctx, http.MethodPost, c.rest.URL(apiRecords), r, nil)
+}
+
+func (c *netboxClient) updateRecordTTL(ctx context.Context, id int, ttl *int64) error {
+	return c.rest.Call(ctx, http.MethodPatch, c.rest.URL(apiRecords+strconv.Itoa(id)+"/"), map[string]*int64{"ttl": ttl}, nil)
+}
+
+func (c *netboxClient) deleteRecord(ctx context.Context, id int) error {
+	return c.rest.Call(ctx, http.MethodDelete, c.rest.URL(apiRecords+strconv.Itoa(id)+"/"), nil, nil)
+}
+
+// list returns the objects of all the pages of a list of the API.
+func list[T any](ctx context.Context, c *netboxClient, path string, params url.Values) ([]T, error) {
+	params.Set("limit", strconv.Itoa(pageSize))
+	return rest.ListPages(ctx, c.rest.URL(path)+"?"+params.Encode(), func(ctx context.Context, u string) ([]T, string, error) {
+		var p page[T]
+		if err := c.rest.Call(ctx, http.MethodGet, u, nil, &p); err != nil {
+			return nil, "", err
+		}
+		return p.Results, p.Next, nil
+	})
+}
@@ -0,0 +1,132 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package netbox
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/pkg/httpbody"
+)
+
+// testCall is a call to the API of the test server.
+type testCall struct {
+	method string
+	uri    string
+	body   string
+}
+
+// newTestServer returns a NetBox server recording the calls to its API, whose records are listed
+// in two pages.
+func newTestServer(t *testing.T, calls *[]testCall) *httptest.Server {
+	t.Helper()
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Token secret" {
+			w.Header().Set("Content-Type", "text/html")
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte("<html>Forbidden</html>"))
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		*calls = append(*calls, testCall{r.Method, r.URL.RequestURI(), string(body)})
+
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == apiZones:
+			w.Write([]byte(`{"count": 1, "next": null, "results": [
+				{"id": 1, "name": "example.com", "status": "active", "view": {"id": 1, "name": "_default_"}}
+			]}`))
+		case r.Method == http.MethodGet && r.URL.Path == apiRecords && r.URL.Query().Get("offset") == "":
+			w.Write([]byte(`{"count": 2, "next": "` + srv.URL + apiRecords + `?limit=1&offset=1&zone_id=1", "results": [
+				{"id": 10, "zone": {"id": 1, "name": "example.com"}, "type": "A", "name": "www", "value": "192.0.2.1", "ttl": 300, "status": "active", "managed": false, "tags": [{"id": 1, "name": "Cluster A", "slug": "cluster-a"}]}
+			]}`))
+		case r.Method == http.MethodGet && r.URL.Path == apiRecords:
+			w.Write([]byte(`{"count": 2, "next": null, "results": [
+				{"id": 11, "zone": {"id": 1, "name": "example.com"}, "type": "CNAME", "name": "alias", "value": "www.example.com.", "ttl": null, "status": "active", "managed": false, "tags": []}
+			]}`))
+		case r.Method == http.MethodPost && r.URL.Path == apiRecords:
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"id": 12}`))
+		case r.Method == http.MethodPatch && r.URL.Path == apiRecords+"10/":
+			w.Write([]byte(`{"id": 10}`))
+		case r.Method == http.MethodDelete && r.URL.Path == apiRecords+"11/":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"detail": "Not found."}`))
+		}
+	}))
+	return srv
+}
+
+func TestNetBoxClient(t *testing.T) {
+	var calls []testCall
+	srv := newTestServer(t, &calls)
+	defer srv.Close()
+
+	cl, err := newNetBoxClient(NetBoxConfig{URL: srv.URL + "/", Token: "secret"})
+	require.NoError(t, err)
+
+	zones, err := cl.listZones(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []zone{{ID: 1, Name: "example.com", Status: "active", View: &view{ID: 1, Name: "_default_"}}}, zones)
+
+	ttl := int64(300)
+	records, err := cl.listRecords(context.Background(), 1, "cluster-a")
+	require.NoError(t, err)
+	assert.Equal(t, []record{
+		{ID: 10, Zone: 1, Type: "A", Name: "www", Value: "192.0.2.1", TTL: &ttl, Status: "active", Tags: []tag{{Name: "Cluster A", Slug: "cluster-a"}}},
+		{ID: 11, Zone: 1, Type: "CNAME", Name: "alias", Value: "www.example.com.", Status: "active", Tags: []tag{}},
+	}, records)
+
+	require.NoError(t, cl.createRecord(context.Background(), record{Zone: 1, Type: "A", Name: "new", Value: "192.0.2.2", Status: "active", Tags: []tag{{Slug: "cluster-a"}}}))
+	require.NoError(t, cl.updateRecordTTL(context.Background(), 10, nil))
+	require.NoError(t, cl.deleteRecord(context.Background(), 11))
+
+	assert.Equal(t, []testCall{
+		{http.MethodGet, apiZones + "?limit=1000", ""},
+		{http.MethodGet, apiRecords + "?limit=1000&tag=cluster-a&zone_id=1", ""},
+		{http.MethodGet, apiRecords + "?limit=1&offset=1&zone_id=1", ""},
+		{http.MethodPost, apiRecords, `{"zone":1,"type":"A","name":"new","value":"192.0.2.2","ttl":null,"status":"active","tags":[{"slug":"cluster-a"}]}`},
+		{http.MethodPatch, apiRecords + "10/", `{"ttl":null}`},
+		{http.MethodDelete, apiRecords + "11/", ""},
+	}, calls)
+
+	err = cl.deleteRecord(context.Background(), 12)
+	require.ErrorContains(t, err, `received 404 status code`)
+}
+
+func TestNetBoxClientForbidden(t *testing.T) {
+	var calls []testCall
+	srv := newTestServer(t, &calls)
+	defer srv.Close()
+
+	cl, err := newNetBoxClient(NetBoxConfig{URL: srv.URL, Token: "wrong"})
+	require.NoError(t, err)
+
+	// The error page is not from the API.
+	_, err = cl.listZones(context.Background())
+	require.ErrorIs(t, err, httpbody.ErrUnexpectedContentType)
+	assert.Empty(t, calls)
+}
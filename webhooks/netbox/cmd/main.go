@@ -0,0 +1,50 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// The NetBox webhook provider of ExternalDNS, managing the records of the zones of the NetBox DNS plugin.
+package main
+
+import (
+	"os"
+
+	"github.com/alecthomas/kingpin/v2"
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/webhooks/internal/server"
+	"sigs.k8s.io/external-dns/webhooks/netbox"
+)
+
+func main() {
+	var opts server.Options
+	var cfg netbox.NetBoxConfig
+	app := server.NewApp("external-dns-netbox-webhook", "Webhook provider of ExternalDNS managing the records of the NetBox DNS plugin.", &opts)
+	server.Flag(app, "netbox-url", "The base URL of NetBox (required)").Required().StringVar(&cfg.URL)
+	server.Flag(app, "netbox-token", "The API token of a NetBox user (required)").Required().StringVar(&cfg.Token)
+	server.Flag(app, "netbox-view", "The name of the view of the DNS plugin whose zones are managed (default: the zones of all the views)").StringVar(&cfg.View)
+	server.Flag(app, "netbox-tag", "The slug of an existing tag added to the records created, e.g. identifying the cluster; only the records with this tag are managed (optional)").StringVar(&cfg.Tag)
+	server.Flag(app, "netbox-tls-skip-verify", "Disable verification of any TLS certificates").BoolVar(&cfg.TLSInsecureSkipVerify)
+	server.Flag(app, "netbox-rate-limit", "The maximum number of requests per second sent to the server (default: 0, unlimited)").Default("0").Float64Var(&cfg.RateLimit)
+	kingpin.MustParse(app.Parse(os.Args[1:]))
+
+	opts.ConfigureLogger()
+	cfg.DomainFilter = opts.NewDomainFilter()
+	cfg.DryRun = opts.DryRun
+	p, err := netbox.NewNetBoxProvider(cfg)
+	if err != nil {
+		log.Fatal(err)
+	}
+	server.Run(&opts, p)
+}
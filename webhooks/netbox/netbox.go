@@ -0,0 +1,418 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package netbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider"
+)
+
+const (
+	// apexName is the relative name of the records of the apex of a zone.
+	apexName = "@"
+	// statusActive is the status of the records which are published.
+	statusActive = "active"
+)
+
+// zoneStatuses are the statuses of the zones whose records are managed, the reserved, deprecated
+// and parked zones being skipped.
+var zoneStatuses = []string{"active", "dynamic"}
+
+var (
+	// ErrNoNetBoxURL is returned when there is no NetBox server configured.
+	ErrNoNetBoxURL = errors.New("no NetBox URL found in the flags")
+	// ErrNoNetBoxToken is returned when there is no API token configured.
+	ErrNoNetBoxToken = errors.New("no NetBox API token found in the environment or flags")
+)
+
+// NetBoxProvider is an implementation of Provider for the NetBox DNS plugin.
+type NetBoxProvider struct {
+	provider.BaseProvider
+	api          netboxAPI
+	domainFilter *endpoint.DomainFilter
+	view         string
+	tag          string
+	dryRun       bool
+}
+
+// NetBoxConfig is used for configuring a NetBoxProvider.
+type NetBoxConfig struct {
+	// The root URL of NetBox.
+	URL string
+	// The API token of a NetBox user.
+	Token string
+	// The name of the view whose zones are managed, all of them if empty.
+	View string
+	// The slug of the tag of the records managed by this instance, e.g. named after its owner
+	// ID. The other records are ignored when it is set.
+	Tag string
+	// Disable verification of TLS certificates.
+	TLSInsecureSkipVerify bool
+	// The maximum number of requests per second to the server, unlimited if zero.
+	RateLimit float64
+	// A filter to apply when looking up and applying records.
+	DomainFilter *endpoint.DomainFilter
+	// Do nothing and log what would have changed to stdout.
+	DryRun bool
+}
+
+// Helper struct for grouping the records of an endpoint.
+type netboxEntryKey struct {
+	DNSName    string
+	RecordType string
+}
+
+// NewNetBoxProvider initializes a new NetBox DNS plugin based Provider.
+func NewNetBoxProvider(cfg NetBoxConfig) (*NetBoxProvider, error) {
+	api, err := newNetBoxClient(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &NetBoxProvider{api: api, domainFilter: cfg.DomainFilter, view: cfg.View, tag: cfg.Tag, dryRun: cfg.DryRun}, nil
+}
+
+// Capabilities implements provider.CapabilitiesReporter.
+func (p *NetBoxProvider) Capabilities() provider.Capabilities {
+	return provider.Capabilities{
+		RecordTypes: []string{endpoint.RecordTypeA, endpoint.RecordTypeAAAA, endpoint.RecordTypeCNAME, endpoint.RecordTypeTXT, endpoint.RecordTypeMX, endpoint.RecordTypeSRV},
+	}
+}
+
+// zones returns the zones of the view matching the domain filter, by ID. A zone found in several
+// views is skipped when no view is configured, as its records could not be told apart.
+func (p *NetBoxProvider) zones(ctx context.Context) (provider.ZoneIDName, error) {
+	zones, err := p.api.listZones(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	views := make(map[string]int)
+	var managed []zone
+	for _, z := range zones {
+		if p.view != "" && (z.View == nil || z.View.Name != p.view) {
+			continue
+		}
+		if !slices.Contains(zoneStatuses, z.Status) {
+			log.Debugf("Skipping %s zone %s", z.Status, z.Name)
+			continue
+		}
+		if !p.domainFilter.Match(z.Name) {
+			continue
+		}
+		views[z.Name]++
+		managed = append(managed, z)
+	}
+
+	result := provider.ZoneIDName{}
+	for _, z := range managed {
+		if views[z.Name] > 1 {
+			log.Warnf("Skipping zone %s found in several views, set --netbox-view to manage it", z.Name)
+			continue
+		}
+		result.Add(strconv.Itoa(z.ID), z.Name)
+	}
+	return result, nil
+}
+
+// Records implements Provider, populating a slice of endpoints from the zones.
+func (p *NetBoxProvider) Records(ctx context.Context) ([]*endpoint.Endpoint, error) {
+	zones, err := p.zones(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var endpoints []*endpoint.Endpoint
+	for zoneID, zoneName := range zones {
+		records, err := p.records(ctx, zoneID)
+		if err != nil {
+			return nil, err
+		}
+
+		// The API has a record per value of a name and type.
+		byKey := make(map[netboxEntryKey]*endpoint.Endpoint)
+		for _, r := range records {
+			key := netboxEntryKey{absoluteName(r.Name, zoneName), r.Type}
+			target := recordTarget(r, zoneName)
+			if ep, ok := byKey[key]; ok {
+				ep.Targets = append(ep.Targets, target)
+				continue
+			}
+			var ttl endpoint.TTL
+			if r.TTL != nil {
+				ttl = endpoint.TTL(*r.TTL)
+			}
+			ep := endpoint.NewEndpointWithTTL(key.DNSName, r.Type, ttl, target)
+			byKey[key] = ep
+			endpoints = append(endpoints, ep)
+		}
+	}
+	return endpoints, nil
+}
+
+// records returns the active records of the zone which are not managed by the plugin, only those
+// with the tag of this instance if it is set.
+func (p *NetBoxProvider) records(ctx context.Context, zoneID string) ([]record, error) {
+	id, err := strconv.Atoi(zoneID)
+	if err != nil {
+		return nil, err
+	}
+	records, err := p.api.listRecords(ctx, id, p.tag)
+	if err != nil {
+		return nil, err
+	}
+
+	supported := p.Capabilities().RecordTypes
+	var result []record
+	for _, r := range records {
+		if r.Managed || r.Status != statusActive || !slices.Contains(supported, r.Type) {
+			continue
+		}
+		result = append(result, r)
+	}
+	return result, nil
+}
+
+// absoluteName returns the DNS name of a name relative to a zone.
+func absoluteName(name, zoneName string) string {
+	if name == apexName || name == "" {
+		return zoneName
+	}
+	return name + "." + zoneName
+}
+
+// relativeName returns the name of a DNS name relative to its zone.
+func relativeName(dnsName, zoneName string) string {
+	if dnsName == zoneName {
+		return apexName
+	}
+	return strings.TrimSuffix(dnsName, "."+zoneName)
+}
+
+// recordTarget returns the target of an endpoint for the value of a record, the names of the
+// value relative to the zone being qualified.
+func recordTarget(r record, zoneName string) string {
+	switch r.Type {
+	case endpoint.RecordTypeCNAME, endpoint.RecordTypeMX, endpoint.RecordTypeSRV:
+		fields := strings.Fields(r.Value)
+		if len(fields) == 0 {
+			return r.Value
+		}
+		host := fields[len(fields)-1]
+		if strings.HasSuffix(host, ".") {
+			host = strings.TrimSuffix(host, ".")
+		} else {
+			host = absoluteName(host, zoneName)
+		}
+		fields[len(fields)-1] = host
+		return strings.Join(fields, " ")
+	default:
+		return r.Value
+	}
+}
+
+// recordValue returns the value of a record for a target of an endpoint, the names of the value
+// being fully qualified.
+func recordValue(recordType, target string) (string, error) {
+	switch recordType {
+	case endpoint.RecordTypeA, endpoint.RecordTypeAAAA, endpoint.RecordTypeTXT:
+		return target, nil
+	case endpoint.RecordTypeCNAME:
+		return strings.TrimSuffix(target, ".") + ".", nil
+	case endpoint.RecordTypeMX:
+		var preference int
+		var host string
+		if _, err := fmt.Sscanf(target, "%d %s", &preference, &host); err != nil {
+			return "", fmt.Errorf("invalid MX target %q: %w", target, err)
+		}
+		return fmt.Sprintf("%d %s.", preference, strings.TrimSuffix(host, ".")), nil
+	case endpoint.RecordTypeSRV:
+		var priority, weight, port int
+		var host string
+		if _, err := fmt.Sscanf(target, "%d %d %d %s", &priority, &weight, &port, &host); err != nil {
+			return "", fmt.Errorf("invalid SRV target %q: %w", target, err)
+		}
+		return fmt.Sprintf("%d %d %d %s.", priority, weight, port, strings.TrimSuffix(host, ".")), nil
+	default:
+		return "", fmt.Errorf("unsupported record type %s", recordType)
+	}
+}
+
+// netboxChange is a change of a record of a zone.
+type netboxChange struct {
+	action string
+	zone   string
+	record record
+}
+
+// ApplyChanges implements Provider, applying the changes to the records of the zones.
+func (p *NetBoxProvider) ApplyChanges(ctx context.Context, changes *plan.Changes) error {
+	zones, err := p.zones(ctx)
+	if err != nil {
+		return err
+	}
+
+	// The records of the zones are only listed once, when the first change of a zone is found.
+	existing := make(map[string]map[netboxEntryKey][]record)
+	current := func(zoneID, zoneName string, key netboxEntryKey) ([]record, error) {
+		if _, ok := existing[zoneID]; !ok {
+			records, err := p.records(ctx, zoneID)
+			if err != nil {
+				return nil, err
+			}
+			byKey := make(map[netboxEntryKey][]record)
+			for _, r := range records {
+				k := netboxEntryKey{absoluteName(r.Name, zoneName), r.Type}
+				byKey[k] = append(byKey[k], r)
+			}
+			existing[zoneID] = byKey
+		}
+		return existing[zoneID][key], nil
+	}
+
+	var deletes, updates, creates []netboxChange
+	// reconcile replaces the records of the name and type of the endpoint having one of the old
+	// targets by the records of the targets of the endpoint, unless it is nil.
+	reconcile := func(ep *endpoint.Endpoint, oldTargets []string, desired *endpoint.Endpoint) error {
+		// The record types the provider supports are not told to ExternalDNS through the webhook
+		// API, and the records of the other types would be created again at each synchronization.
+		if !slices.Contains(p.Capabilities().RecordTypes, ep.RecordType) {
+			log.Warnf("Skipping record %s because %s records are not supported", ep.DNSName, ep.RecordType)
+			return nil
+		}
+		zoneID, zoneName := zones.FindZone(ep.DNSName)
+		if zoneID == "" {
+			log.Debugf("Skipping record %s because no zone was found", ep.DNSName)
+			return nil
+		}
+		id, _ := strconv.Atoi(zoneID)
+		key := netboxEntryKey{ep.DNSName, ep.RecordType}
+		records, err := current(zoneID, zoneName, key)
+		if err != nil {
+			return err
+		}
+
+		var ttl *int64
+		var targets []string
+		if desired != nil {
+			targets = desired.Targets
+			if desired.RecordTTL.IsConfigured() {
+				v := int64(desired.RecordTTL)
+				ttl = &v
+			}
+		}
+
+		kept := make(map[string]bool)
+		for _, r := range records {
+			target := recordTarget(r, zoneName)
+			switch {
+			case slices.Contains(targets, target) && !kept[target]:
+				kept[target] = true
+				if !equalTTL(r.TTL, ttl) {
+					r.TTL = ttl
+					updates = append(updates, netboxChange{"Updating TTL of", zoneName, r})
+				}
+			case slices.Contains(oldTargets, target):
+				deletes = append(deletes, netboxChange{"Deleting", zoneName, r})
+			}
+		}
+		for _, target := range targets {
+			if kept[target] {
+				continue
+			}
+			value, err := recordValue(ep.RecordType, target)
+			if err != nil {
+				return provider.NewSoftError(fmt.Errorf("%s: %w", ep.DNSName, err))
+			}
+			r := record{Zone: id, Type: ep.RecordType, Name: relativeName(ep.DNSName, zoneName), Value: value, TTL: ttl, Status: statusActive}
+			if p.tag != "" {
+				r.Tags = []tag{{Slug: p.tag}}
+			}
+			creates = append(creates, netboxChange{"Creating", zoneName, r})
+			kept[target] = true
+		}
+		return nil
+	}
+
+	for _, ep := range changes.Delete {
+		if err := reconcile(ep, ep.Targets, nil); err != nil {
+			return err
+		}
+	}
+	updateOld := make(map[netboxEntryKey]*endpoint.Endpoint)
+	for _, ep := range changes.UpdateOld {
+		updateOld[netboxEntryKey{ep.DNSName, ep.RecordType}] = ep
+	}
+	for _, ep := range changes.UpdateNew {
+		var oldTargets []string
+		if old, ok := updateOld[netboxEntryKey{ep.DNSName, ep.RecordType}]; ok {
+			oldTargets = old.Targets
+		}
+		if err := reconcile(ep, oldTargets, ep); err != nil {
+			return err
+		}
+	}
+	for _, ep := range changes.Create {
+		if err := reconcile(ep, nil, ep); err != nil {
+			return err
+		}
+	}
+
+	for _, change := range deletes {
+		if err := p.apply(change, func() error { return p.api.deleteRecord(ctx, change.record.ID) }); err != nil {
+			return err
+		}
+	}
+	for _, change := range updates {
+		if err := p.apply(change, func() error { return p.api.updateRecordTTL(ctx, change.record.ID, change.record.TTL) }); err != nil {
+			return err
+		}
+	}
+	for _, change := range creates {
+		if err := p.apply(change, func() error { return p.api.createRecord(ctx, change.record) }); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *NetBoxProvider) apply(change netboxChange, fn func() error) error {
+	r := change.record
+	if p.dryRun {
+		log.Infof("DRY RUN: %s %s IN %s -> %s in zone %s", change.action, r.Name, r.Type, r.Value, change.zone)
+		return nil
+	}
+	log.Infof("%s %s IN %s -> %s in zone %s", change.action, r.Name, r.Type, r.Value, change.zone)
+	return fn()
+}
+
+// equalTTL returns whether two TTLs are equal, nil being the default TTL of the zone.
+func equalTTL(a, b *int64) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
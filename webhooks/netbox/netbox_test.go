@@ -0,0 +1,270 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package netbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider"
+)
+
+type testNetBoxClient struct {
+	zones   []zone
+	records []record
+	calls   []string
+	err     error
+}
+
+func (t *testNetBoxClient) listZones(_ context.Context) ([]zone, error) {
+	return t.zones, t.err
+}
+
+func (t *testNetBoxClient) listRecords(_ context.Context, zoneID int, tagSlug string) ([]record, error) {
+	var records []record
+	for _, r := range t.records {
+		if r.Zone == zoneID && (tagSlug == "" || slices.Contains(r.Tags, tag{Slug: tagSlug})) {
+			records = append(records, r)
+		}
+	}
+	return records, t.err
+}
+
+func (t *testNetBoxClient) createRecord(_ context.Context, r record) error {
+	var tags []string
+	for _, tg := range r.Tags {
+		tags = append(tags, tg.Slug)
+	}
+	t.calls = append(t.calls, fmt.Sprintf("create %d %s %s %s ttl=%s tags=%v", r.Zone, r.Name, r.Type, r.Value, formatTTL(r.TTL), tags))
+	return nil
+}
+
+func (t *testNetBoxClient) updateRecordTTL(_ context.Context, id int, ttl *int64) error {
+	t.calls = append(t.calls, fmt.Sprintf("update %d ttl=%s", id, formatTTL(ttl)))
+	return nil
+}
+
+func (t *testNetBoxClient) deleteRecord(_ context.Context, id int) error {
+	t.calls = append(t.calls, fmt.Sprintf("delete %d", id))
+	return nil
+}
+
+func formatTTL(ttl *int64) string {
+	if ttl == nil {
+		return "default"
+	}
+	return fmt.Sprint(*ttl)
+}
+
+func ttl(v int64) *int64 {
+	return &v
+}
+
+func newTestClient() *testNetBoxClient {
+	def, internal := &view{ID: 1, Name: "_default_"}, &view{ID: 2, Name: "internal"}
+	owned := []tag{{Slug: "cluster-a"}}
+	return &testNetBoxClient{
+		zones: []zone{
+			{ID: 1, Name: "example.com", Status: "active", View: def},
+			{ID: 2, Name: "example.org", Status: "dynamic", View: def},
+			{ID: 3, Name: "old.example.com", Status: "deprecated", View: def},
+			{ID: 4, Name: "corp.example.net", Status: "active", View: def},
+			{ID: 5, Name: "corp.example.net", Status: "active", View: internal},
+		},
+		records: []record{
+			{ID: 1, Zone: 1, Type: "SOA", Name: "@", Value: "ns1.example.com. hostmaster.example.com. 1 3600 600 86400 300", Status: "active", Managed: true},
+			{ID: 2, Zone: 1, Type: "NS", Name: "@", Value: "ns1.example.com.", Status: "active"},
+			{ID: 3, Zone: 1, Type: "A", Name: "www", Value: "192.0.2.1", TTL: ttl(300), Status: "active", Tags: owned},
+			{ID: 4, Zone: 1, Type: "A", Name: "www", Value: "192.0.2.2", TTL: ttl(300), Status: "active", Tags: owned},
+			{ID: 5, Zone: 1, Type: "CNAME", Name: "alias", Value: "www", Status: "active", Tags: owned},
+			{ID: 6, Zone: 1, Type: "TXT", Name: "@", Value: "heritage=external-dns", Status: "active", Tags: owned},
+			{ID: 7, Zone: 1, Type: "MX", Name: "@", Value: "10 mail.example.com.", Status: "active", Tags: owned},
+			{ID: 8, Zone: 1, Type: "SRV", Name: "_sip._tcp", Value: "10 5 5060 sip", Status: "active", Tags: owned},
+			{ID: 9, Zone: 1, Type: "A", Name: "off", Value: "192.0.2.3", Status: "inactive", Tags: owned},
+			{ID: 10, Zone: 1, Type: "A", Name: "manual", Value: "192.0.2.4", Status: "active"},
+			{ID: 11, Zone: 5, Type: "A", Name: "app", Value: "10.0.0.1", Status: "active", Tags: owned},
+		},
+	}
+}
+
+func TestNewNetBoxProvider(t *testing.T) {
+	_, err := NewNetBoxProvider(NetBoxConfig{Token: "token"})
+	require.ErrorIs(t, err, ErrNoNetBoxURL)
+
+	_, err = NewNetBoxProvider(NetBoxConfig{URL: "https://netbox.example.com"})
+	require.ErrorIs(t, err, ErrNoNetBoxToken)
+
+	for _, tc := range []struct {
+		cfg   NetBoxConfig
+		valid bool
+	}{
+		{cfg: NetBoxConfig{URL: "https://netbox.example.com", Token: "token"}, valid: true},
+		{cfg: NetBoxConfig{URL: "http://netbox.local:8000/", Token: "token"}, valid: true},
+		{cfg: NetBoxConfig{URL: "netbox.example.com", Token: "token"}},
+	} {
+		_, err = NewNetBoxProvider(tc.cfg)
+		if tc.valid {
+			assert.NoError(t, err, tc.cfg.URL)
+		} else {
+			assert.Error(t, err, tc.cfg.URL)
+		}
+	}
+}
+
+func TestNetBoxZones(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		view     string
+		expected provider.ZoneIDName
+	}{
+		{
+			// The deprecated zone, and the zone found in several views, are skipped.
+			name:     "all views",
+			expected: provider.ZoneIDName{"1": "example.com", "2": "example.org"},
+		},
+		{
+			name:     "view",
+			view:     "internal",
+			expected: provider.ZoneIDName{"5": "corp.example.net"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			p := &NetBoxProvider{api: newTestClient(), view: tc.view}
+
+			zones, err := p.zones(context.Background())
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, zones)
+		})
+	}
+}
+
+func TestNetBoxRecords(t *testing.T) {
+	p := &NetBoxProvider{api: newTestClient(), domainFilter: endpoint.NewDomainFilter([]string{"example.com"})}
+
+	records, err := p.Records(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, []*endpoint.Endpoint{
+		endpoint.NewEndpointWithTTL("www.example.com", endpoint.RecordTypeA, 300, "192.0.2.1", "192.0.2.2"),
+		endpoint.NewEndpoint("alias.example.com", endpoint.RecordTypeCNAME, "www.example.com"),
+		endpoint.NewEndpoint("example.com", endpoint.RecordTypeTXT, "heritage=external-dns"),
+		endpoint.NewEndpoint("example.com", endpoint.RecordTypeMX, "10 mail.example.com"),
+		endpoint.NewEndpoint("_sip._tcp.example.com", endpoint.RecordTypeSRV, "10 5 5060 sip.example.com"),
+		endpoint.NewEndpoint("manual.example.com", endpoint.RecordTypeA, "192.0.2.4"),
+	}, records)
+}
+
+func TestNetBoxRecordsTag(t *testing.T) {
+	p := &NetBoxProvider{api: newTestClient(), view: "internal", tag: "cluster-a"}
+
+	records, err := p.Records(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, []*endpoint.Endpoint{
+		endpoint.NewEndpoint("app.corp.example.net", endpoint.RecordTypeA, "10.0.0.1"),
+	}, records)
+}
+
+func TestNetBoxRecordsError(t *testing.T) {
+	errAPI := errors.New("api error")
+	p := &NetBoxProvider{api: &testNetBoxClient{err: errAPI}}
+
+	_, err := p.Records(context.Background())
+	require.ErrorIs(t, err, errAPI)
+}
+
+func TestNetBoxApplyChanges(t *testing.T) {
+	api := newTestClient()
+	p := &NetBoxProvider{api: api, tag: "cluster-a"}
+
+	err := p.ApplyChanges(context.Background(), &plan.Changes{
+		Create: []*endpoint.Endpoint{
+			endpoint.NewEndpointWithTTL("new.example.com", endpoint.RecordTypeA, 600, "192.0.2.10", "192.0.2.11"),
+			endpoint.NewEndpoint("_sip._udp.example.org", endpoint.RecordTypeSRV, "10 5 5060 sip.example.org"),
+			endpoint.NewEndpoint("example.org", endpoint.RecordTypeMX, "10 mail.example.org."),
+			endpoint.NewEndpoint("app.corp.example.net", endpoint.RecordTypeA, "10.0.0.2"),
+		},
+		UpdateOld: []*endpoint.Endpoint{
+			endpoint.NewEndpointWithTTL("www.example.com", endpoint.RecordTypeA, 300, "192.0.2.1", "192.0.2.2"),
+			endpoint.NewEndpoint("alias.example.com", endpoint.RecordTypeCNAME, "www.example.com"),
+		},
+		UpdateNew: []*endpoint.Endpoint{
+			endpoint.NewEndpointWithTTL("www.example.com", endpoint.RecordTypeA, 300, "192.0.2.2", "192.0.2.5"),
+			endpoint.NewEndpointWithTTL("alias.example.com", endpoint.RecordTypeCNAME, 60, "www.example.com"),
+		},
+		Delete: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("example.com", endpoint.RecordTypeTXT, "heritage=external-dns"),
+			// The record without the tag is not deleted.
+			endpoint.NewEndpoint("manual.example.com", endpoint.RecordTypeA, "192.0.2.4"),
+		},
+	})
+	require.NoError(t, err)
+
+	// The record of the zone found in several views is not created.
+	assert.Equal(t, []string{
+		"delete 6",
+		"delete 3",
+		"update 5 ttl=60",
+		"create 1 www A 192.0.2.5 ttl=300 tags=[cluster-a]",
+		"create 1 new A 192.0.2.10 ttl=600 tags=[cluster-a]",
+		"create 1 new A 192.0.2.11 ttl=600 tags=[cluster-a]",
+		"create 2 _sip._udp SRV 10 5 5060 sip.example.org. ttl=default tags=[cluster-a]",
+		"create 2 @ MX 10 mail.example.org. ttl=default tags=[cluster-a]",
+	}, api.calls)
+}
+
+func TestNetBoxApplyChangesInvalidSRV(t *testing.T) {
+	api := newTestClient()
+	p := &NetBoxProvider{api: api}
+
+	err := p.ApplyChanges(context.Background(), &plan.Changes{
+		Create: []*endpoint.Endpoint{endpoint.NewEndpoint("_sip._udp.example.com", endpoint.RecordTypeSRV, "sip.example.com")},
+	})
+	require.ErrorIs(t, err, provider.SoftError)
+	assert.Empty(t, api.calls)
+}
+
+func TestNetBoxApplyChangesUnsupportedType(t *testing.T) {
+	api := newTestClient()
+	p := &NetBoxProvider{api: api}
+
+	err := p.ApplyChanges(context.Background(), &plan.Changes{
+		Create: []*endpoint.Endpoint{endpoint.NewEndpoint("example.com", endpoint.RecordTypeNS, "ns1.example.com")},
+	})
+	require.NoError(t, err)
+	assert.Empty(t, api.calls)
+}
+
+func TestNetBoxApplyChangesDryRun(t *testing.T) {
+	api := newTestClient()
+	p := &NetBoxProvider{api: api, dryRun: true}
+
+	err := p.ApplyChanges(context.Background(), &plan.Changes{
+		Create: []*endpoint.Endpoint{endpoint.NewEndpoint("new.example.com", endpoint.RecordTypeA, "192.0.2.10")},
+		Delete: []*endpoint.Endpoint{endpoint.NewEndpoint("manual.example.com", endpoint.RecordTypeA, "192.0.2.4")},
+	})
+	require.NoError(t, err)
+	assert.Empty(t, api.calls)
+}
@@ -0,0 +1,208 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package technitium
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"sigs.k8s.io/external-dns/webhooks/internal/rest"
+)
+
+const (
+	apiZonesList     = "/api/zones/list"
+	apiRecordsGet    = "/api/zones/records/get"
+	apiRecordsAdd    = "/api/zones/records/add"
+	apiRecordsDelete = "/api/zones/records/delete"
+)
+
+// ErrInvalidToken is returned when the API token is invalid or expired.
+var ErrInvalidToken = errors.New("technitium: invalid or expired API token")
+
+// zone is a zone of the Technitium DNS Server.
+type zone struct {
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	Disabled bool   `json:"disabled"`
+}
+
+// record is a record of a zone, holding a single value.
+type record struct {
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	TTL      int64  `json:"ttl"`
+	Disabled bool   `json:"disabled"`
+	RData    rData  `json:"rData"`
+}
+
+// rData is the value of a record, with the fields of the supported record types.
+type rData struct {
+	IPAddress string `json:"ipAddress,omitempty"`
+	CName     string `json:"cname,omitempty"`
+	Text      string `json:"text,omitempty"`
+	Priority  int    `json:"priority,omitempty"`
+	Weight    int    `json:"weight,omitempty"`
+	Port      int    `json:"port,omitempty"`
+	Target    string `json:"target,omitempty"`
+}
+
+// apiResponse is the envelope of the responses of the API.
+type apiResponse struct {
+	Status       string          `json:"status"`
+	ErrorMessage string          `json:"errorMessage"`
+	Response     json.RawMessage `json:"response"`
+}
+
+// technitiumAPI declares the "API" actions performed against the Technitium DNS Server.
+type technitiumAPI interface {
+	// listZones returns all the zones of the server.
+	listZones(ctx context.Context) ([]zone, error)
+	// listRecords returns all the records of the given zone.
+	listRecords(ctx context.Context, zoneName string) ([]record, error)
+	// addRecord adds the given record to the zone, next to the records of the same name and type.
+	addRecord(ctx context.Context, zoneName string, r record) error
+	// deleteRecord deletes the given record from the zone.
+	deleteRecord(ctx context.Context, zoneName string, r record) error
+}
+
+// technitiumClient implements the technitiumAPI.
+type technitiumClient struct {
+	cfg  TechnitiumConfig
+	rest *rest.Client
+}
+
+// newTechnitiumClient creates a new Technitium DNS Server API client.
+func newTechnitiumClient(cfg TechnitiumConfig) (technitiumAPI, error) {
+	if cfg.Server == "" {
+		return nil, ErrNoTechnitiumServer
+	}
+	if cfg.Token == "" {
+		return nil, ErrNoTechnitiumToken
+	}
+
+	// The token is sent in the body of the requests rather than in a header, see call.
+	cl, err := rest.NewClient(rest.Config{
+		Name:                  "the Technitium DNS Server",
+		BaseURL:               cfg.Server,
+		TLSInsecureSkipVerify: cfg.TLSInsecureSkipVerify,
+		RateLimit:             cfg.RateLimit,
+		// reject the responses which are not from the API, e.g. the error pages of a proxy
+		ContentTypes: []string{"application/json"},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &technitiumClient{cfg: cfg, rest: cl}, nil
+}
+
+func (c *technitiumClient) listZones(ctx context.Context) ([]zone, error) {
+	var res struct {
+		Zones []zone `json:"zones"`
+	}
+	if err := c.call(ctx, apiZonesList, url.Values{}, &res); err != nil {
+		return nil, err
+	}
+	return res.Zones, nil
+}
+
+func (c *technitiumClient) listRecords(ctx context.Context, zoneName string) ([]record, error) {
+	params := url.Values{}
+	params.Set("domain", zoneName)
+	params.Set("zone", zoneName)
+	params.Set("listZone", "true")
+
+	var res struct {
+		Records []record `json:"records"`
+	}
+	if err := c.call(ctx, apiRecordsGet, params, &res); err != nil {
+		return nil, err
+	}
+	return res.Records, nil
+}
+
+func (c *technitiumClient) addRecord(ctx context.Context, zoneName string, r record) error {
+	params := recordParams(zoneName, r)
+	if r.TTL > 0 {
+		params.Set("ttl", fmt.Sprint(r.TTL))
+	}
+	return c.call(ctx, apiRecordsAdd, params, nil)
+}
+
+func (c *technitiumClient) deleteRecord(ctx context.Context, zoneName string, r record) error {
+	return c.call(ctx, apiRecordsDelete, recordParams(zoneName, r), nil)
+}
+
+// recordParams returns the parameters identifying a record in the API.
+func recordParams(zoneName string, r record) url.Values {
+	params := url.Values{}
+	params.Set("zone", zoneName)
+	params.Set("domain", r.Name)
+	params.Set("type", r.Type)
+	switch r.Type {
+	case "A", "AAAA":
+		params.Set("ipAddress", r.RData.IPAddress)
+	case "CNAME":
+		params.Set("cname", r.RData.CName)
+	case "TXT":
+		params.Set("text", r.RData.Text)
+	case "SRV":
+		params.Set("priority", fmt.Sprint(r.RData.Priority))
+		params.Set("weight", fmt.Sprint(r.RData.Weight))
+		params.Set("port", fmt.Sprint(r.RData.Port))
+		params.Set("target", r.RData.Target)
+	}
+	return params
+}
+
+// call posts the parameters to the given path of the API, with the token, and unmarshals the
+// response into out, unless it is nil.
+func (c *technitiumClient) call(ctx context.Context, path string, params url.Values, out any) error {
+	endpoint := c.rest.URL(path)
+
+	// The token is sent in the body rather than in the URL, to keep it out of the logs.
+	params.Set("token", c.cfg.Token)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(params.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	var apiRes apiResponse
+	if err := c.rest.Do(req, &apiRes); err != nil {
+		return err
+	}
+	switch apiRes.Status {
+	case "ok":
+	case "invalid-token":
+		return ErrInvalidToken
+	default:
+		return fmt.Errorf("request %s failed: %s", endpoint, apiRes.ErrorMessage)
+	}
+
+	if out == nil {
+		return nil
+	}
+	if err := json.Unmarshal(apiRes.Response, out); err != nil {
+		return fmt.Errorf("failed to unmarshal the response of %s: %w", endpoint, err)
+	}
+	return nil
+}
@@ -0,0 +1,120 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package technitium
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestServer returns a Technitium DNS Server recording the parameters of the calls to its API.
+func newTestServer(t *testing.T, calls *[]url.Values) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method != http.MethodPost || r.URL.RawQuery != "" {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		if err := r.ParseForm(); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if r.PostForm.Get("token") != "secret" {
+			w.Write([]byte(`{"status": "invalid-token", "errorMessage": "Invalid token or session expired."}`))
+			return
+		}
+		r.PostForm.Del("token")
+		r.PostForm.Set("path", r.URL.Path)
+		*calls = append(*calls, r.PostForm)
+
+		switch r.URL.Path {
+		case apiZonesList:
+			w.Write([]byte(`{"status": "ok", "response": {"zones": [
+				{"name": "example.com", "type": "Primary", "internal": false, "disabled": false},
+				{"name": "corp.example.net", "type": "Forwarder", "internal": false, "disabled": false}
+			]}}`))
+		case apiRecordsGet:
+			w.Write([]byte(`{"status": "ok", "response": {"zone": {"name": "example.com", "type": "Primary"}, "records": [
+				{"disabled": false, "name": "www.example.com", "type": "A", "ttl": 300, "rData": {"ipAddress": "192.0.2.1"}},
+				{"disabled": false, "name": "_sip._tcp.example.com", "type": "SRV", "ttl": 300, "rData": {"priority": 10, "weight": 5, "port": 5060, "target": "sip.example.com"}}
+			]}}`))
+		case apiRecordsAdd:
+			if r.PostForm.Get("ipAddress") == "" {
+				w.Write([]byte(`{"status": "error", "errorMessage": "Parameter 'ipAddress' missing."}`))
+				return
+			}
+			w.Write([]byte(`{"status": "ok", "response": {}}`))
+		case apiRecordsDelete:
+			w.Write([]byte(`{"status": "ok"}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+}
+
+func TestTechnitiumClient(t *testing.T) {
+	var calls []url.Values
+	srv := newTestServer(t, &calls)
+	defer srv.Close()
+
+	cl, err := newTechnitiumClient(TechnitiumConfig{Server: srv.URL + "/", Token: "secret"})
+	require.NoError(t, err)
+
+	zones, err := cl.listZones(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []zone{{Name: "example.com", Type: "Primary"}, {Name: "corp.example.net", Type: "Forwarder"}}, zones)
+
+	records, err := cl.listRecords(context.Background(), "example.com")
+	require.NoError(t, err)
+	assert.Equal(t, []record{
+		{Name: "www.example.com", Type: "A", TTL: 300, RData: rData{IPAddress: "192.0.2.1"}},
+		{Name: "_sip._tcp.example.com", Type: "SRV", TTL: 300, RData: rData{Priority: 10, Weight: 5, Port: 5060, Target: "sip.example.com"}},
+	}, records)
+
+	require.NoError(t, cl.addRecord(context.Background(), "example.com", record{Name: "new.example.com", Type: "A", TTL: 600, RData: rData{IPAddress: "192.0.2.2"}}))
+	require.NoError(t, cl.deleteRecord(context.Background(), "example.com", record{Name: "_sip._tcp.example.com", Type: "SRV", TTL: 300, RData: rData{Priority: 10, Weight: 5, Port: 5060, Target: "sip.example.com"}}))
+
+	assert.Equal(t, []url.Values{
+		{"path": {apiZonesList}},
+		{"path": {apiRecordsGet}, "domain": {"example.com"}, "zone": {"example.com"}, "listZone": {"true"}},
+		{"path": {apiRecordsAdd}, "zone": {"example.com"}, "domain": {"new.example.com"}, "type": {"A"}, "ipAddress": {"192.0.2.2"}, "ttl": {"600"}},
+		{"path": {apiRecordsDelete}, "zone": {"example.com"}, "domain": {"_sip._tcp.example.com"}, "type": {"SRV"}, "priority": {"10"}, "weight": {"5"}, "port": {"5060"}, "target": {"sip.example.com"}},
+	}, calls)
+
+	err = cl.addRecord(context.Background(), "example.com", record{Name: "new.example.com", Type: "A"})
+	require.EqualError(t, err, "request "+srv.URL+apiRecordsAdd+" failed: Parameter 'ipAddress' missing.")
+}
+
+func TestTechnitiumClientInvalidToken(t *testing.T) {
+	var calls []url.Values
+	srv := newTestServer(t, &calls)
+	defer srv.Close()
+
+	cl, err := newTechnitiumClient(TechnitiumConfig{Server: srv.URL, Token: "expired"})
+	require.NoError(t, err)
+
+	_, err = cl.listZones(context.Background())
+	require.ErrorIs(t, err, ErrInvalidToken)
+	assert.Empty(t, calls)
+}
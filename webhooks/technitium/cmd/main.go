@@ -0,0 +1,48 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// The Technitium DNS Server webhook provider of ExternalDNS, managing the records of the primary zones of Technitium DNS Server.
+package main
+
+import (
+	"os"
+
+	"github.com/alecthomas/kingpin/v2"
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/webhooks/internal/server"
+	"sigs.k8s.io/external-dns/webhooks/technitium"
+)
+
+func main() {
+	var opts server.Options
+	var cfg technitium.TechnitiumConfig
+	app := server.NewApp("external-dns-technitium-webhook", "Webhook provider of ExternalDNS managing the records of a Technitium DNS Server.", &opts)
+	server.Flag(app, "technitium-server", "The base URL of the Technitium DNS Server web console (required)").Required().StringVar(&cfg.Server)
+	server.Flag(app, "technitium-token", "The API token of the server (required)").Required().StringVar(&cfg.Token)
+	server.Flag(app, "technitium-tls-skip-verify", "Disable verification of any TLS certificates").BoolVar(&cfg.TLSInsecureSkipVerify)
+	server.Flag(app, "technitium-rate-limit", "The maximum number of requests per second sent to the server (default: 0, unlimited)").Default("0").Float64Var(&cfg.RateLimit)
+	kingpin.MustParse(app.Parse(os.Args[1:]))
+
+	opts.ConfigureLogger()
+	cfg.DomainFilter = opts.NewDomainFilter()
+	cfg.DryRun = opts.DryRun
+	p, err := technitium.NewTechnitiumProvider(cfg)
+	if err != nil {
+		log.Fatal(err)
+	}
+	server.Run(&opts, p)
+}
@@ -0,0 +1,299 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package technitium
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider"
+)
+
+// zoneTypePrimary is the type of the zones whose records are managed, the secondary, stub and
+// conditional forwarder zones being skipped.
+const zoneTypePrimary = "Primary"
+
+var (
+	// ErrNoTechnitiumServer is returned when there is no Technitium DNS Server configured.
+	ErrNoTechnitiumServer = errors.New("no Technitium DNS Server found in the flags")
+	// ErrNoTechnitiumToken is returned when there is no API token configured.
+	ErrNoTechnitiumToken = errors.New("no Technitium DNS Server API token found in the environment or flags")
+)
+
+// TechnitiumProvider is an implementation of Provider for the Technitium DNS Server.
+type TechnitiumProvider struct {
+	provider.BaseProvider
+	api          technitiumAPI
+	domainFilter *endpoint.DomainFilter
+	dryRun       bool
+}
+
+// TechnitiumConfig is used for configuring a TechnitiumProvider.
+type TechnitiumConfig struct {
+	// The root URL of the Technitium DNS Server web console.
+	Server string
+	// The API token of a user or of a session.
+	Token string
+	// Disable verification of TLS certificates.
+	TLSInsecureSkipVerify bool
+	// The maximum number of requests per second to the server, unlimited if zero.
+	RateLimit float64
+	// A filter to apply when looking up and applying records.
+	DomainFilter *endpoint.DomainFilter
+	// Do nothing and log what would have changed to stdout.
+	DryRun bool
+}
+
+// Helper struct for grouping the records of an endpoint.
+type technitiumEntryKey struct {
+	DNSName    string
+	RecordType string
+}
+
+// NewTechnitiumProvider initializes a new Technitium DNS Server based Provider.
+func NewTechnitiumProvider(cfg TechnitiumConfig) (*TechnitiumProvider, error) {
+	api, err := newTechnitiumClient(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &TechnitiumProvider{api: api, domainFilter: cfg.DomainFilter, dryRun: cfg.DryRun}, nil
+}
+
+// Capabilities implements provider.CapabilitiesReporter.
+func (p *TechnitiumProvider) Capabilities() provider.Capabilities {
+	return provider.Capabilities{
+		RecordTypes: []string{endpoint.RecordTypeA, endpoint.RecordTypeAAAA, endpoint.RecordTypeCNAME, endpoint.RecordTypeTXT, endpoint.RecordTypeSRV},
+	}
+}
+
+// zones returns the names of the enabled primary zones matching the domain filter, and the names
+// of the other zones, whose records must not be written to a parent primary zone.
+func (p *TechnitiumProvider) zones(ctx context.Context) (provider.ZoneIDName, provider.ZoneIDName, error) {
+	zones, err := p.api.listZones(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	primary, skipped := provider.ZoneIDName{}, provider.ZoneIDName{}
+	for _, z := range zones {
+		if z.Type != zoneTypePrimary || z.Disabled {
+			log.Debugf("Skipping %s zone %s", z.Type, z.Name)
+			skipped.Add(z.Name, z.Name)
+			continue
+		}
+		if !p.domainFilter.Match(z.Name) {
+			continue
+		}
+		primary.Add(z.Name, z.Name)
+	}
+	return primary, skipped, nil
+}
+
+// Records implements Provider, populating a slice of endpoints from the primary zones.
+func (p *TechnitiumProvider) Records(ctx context.Context) ([]*endpoint.Endpoint, error) {
+	zones, _, err := p.zones(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var endpoints []*endpoint.Endpoint
+	for zoneName := range zones {
+		records, err := p.api.listRecords(ctx, zoneName)
+		if err != nil {
+			return nil, err
+		}
+
+		// The API has a record per value of a name and type.
+		byKey := make(map[technitiumEntryKey]*endpoint.Endpoint)
+		for _, r := range records {
+			target, ok := recordTarget(r)
+			if r.Disabled || !ok {
+				continue
+			}
+			key := technitiumEntryKey{r.Name, r.Type}
+			if ep, ok := byKey[key]; ok {
+				ep.Targets = append(ep.Targets, target)
+				continue
+			}
+			ep := endpoint.NewEndpointWithTTL(r.Name, r.Type, endpoint.TTL(r.TTL), target)
+			byKey[key] = ep
+			endpoints = append(endpoints, ep)
+		}
+	}
+	return endpoints, nil
+}
+
+// recordTarget returns the target of an endpoint for the value of a record.
+func recordTarget(r record) (string, bool) {
+	switch r.Type {
+	case endpoint.RecordTypeA, endpoint.RecordTypeAAAA:
+		return r.RData.IPAddress, true
+	case endpoint.RecordTypeCNAME:
+		return r.RData.CName, true
+	case endpoint.RecordTypeTXT:
+		return r.RData.Text, true
+	case endpoint.RecordTypeSRV:
+		return fmt.Sprintf("%d %d %d %s", r.RData.Priority, r.RData.Weight, r.RData.Port, r.RData.Target), true
+	default:
+		return "", false
+	}
+}
+
+// newRecord returns the record of a target of an endpoint.
+func newRecord(ep *endpoint.Endpoint, target string) (record, error) {
+	r := record{Name: ep.DNSName, Type: ep.RecordType}
+	if ep.RecordTTL.IsConfigured() {
+		r.TTL = int64(ep.RecordTTL)
+	}
+	switch ep.RecordType {
+	case endpoint.RecordTypeA, endpoint.RecordTypeAAAA:
+		r.RData.IPAddress = target
+	case endpoint.RecordTypeCNAME:
+		r.RData.CName = target
+	case endpoint.RecordTypeTXT:
+		r.RData.Text = target
+	case endpoint.RecordTypeSRV:
+		if _, err := fmt.Sscanf(target, "%d %d %d %s", &r.RData.Priority, &r.RData.Weight, &r.RData.Port, &r.RData.Target); err != nil {
+			return record{}, fmt.Errorf("invalid SRV target %q of %s: %w", target, ep.DNSName, err)
+		}
+	default:
+		return record{}, fmt.Errorf("unsupported record type %s of %s", ep.RecordType, ep.DNSName)
+	}
+	return r, nil
+}
+
+// technitiumChange is a record to add to or delete from a zone.
+type technitiumChange struct {
+	zone   string
+	record record
+}
+
+// ApplyChanges implements Provider, applying the changes to the primary zones.
+func (p *TechnitiumProvider) ApplyChanges(ctx context.Context, changes *plan.Changes) error {
+	zones, skipped, err := p.zones(ctx)
+	if err != nil {
+		return err
+	}
+
+	var deletes, adds []technitiumChange
+	collect := func(changes *[]technitiumChange, ep *endpoint.Endpoint, targets []string) error {
+		zoneName, _ := zones.FindZone(ep.DNSName)
+		skippedName, _ := skipped.FindZone(ep.DNSName)
+		if zoneName == "" || len(skippedName) > len(zoneName) {
+			log.Debugf("Skipping record %s because no primary zone was found", ep.DNSName)
+			return nil
+		}
+		for _, target := range targets {
+			r, err := newRecord(ep, target)
+			if err != nil {
+				return provider.NewSoftError(err)
+			}
+			*changes = append(*changes, technitiumChange{zone: zoneName, record: r})
+		}
+		return nil
+	}
+
+	for _, ep := range changes.Delete {
+		if err := collect(&deletes, ep, ep.Targets); err != nil {
+			return err
+		}
+	}
+
+	// There is no record to update in place, only replace the values which changed, or all of them
+	// when the TTL changed as it is set per value.
+	updateOld := make(map[technitiumEntryKey]*endpoint.Endpoint)
+	for _, ep := range changes.UpdateOld {
+		updateOld[technitiumEntryKey{ep.DNSName, ep.RecordType}] = ep
+	}
+	for _, ep := range changes.UpdateNew {
+		old := updateOld[technitiumEntryKey{ep.DNSName, ep.RecordType}]
+		if old == nil {
+			if err := collect(&adds, ep, ep.Targets); err != nil {
+				return err
+			}
+			continue
+		}
+		delete(updateOld, technitiumEntryKey{ep.DNSName, ep.RecordType})
+		if ep.RecordTTL.IsConfigured() && old.RecordTTL != ep.RecordTTL {
+			if err := collect(&deletes, old, old.Targets); err != nil {
+				return err
+			}
+			if err := collect(&adds, ep, ep.Targets); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := collect(&deletes, old, missingTargets(old, ep)); err != nil {
+			return err
+		}
+		if err := collect(&adds, ep, missingTargets(ep, old)); err != nil {
+			return err
+		}
+	}
+	for _, ep := range changes.UpdateOld {
+		if _, ok := updateOld[technitiumEntryKey{ep.DNSName, ep.RecordType}]; ok {
+			if err := collect(&deletes, ep, ep.Targets); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, ep := range changes.Create {
+		if err := collect(&adds, ep, ep.Targets); err != nil {
+			return err
+		}
+	}
+
+	for _, change := range deletes {
+		if err := p.apply(ctx, "Deleting", p.api.deleteRecord, change); err != nil {
+			return err
+		}
+	}
+	for _, change := range adds {
+		if err := p.apply(ctx, "Adding", p.api.addRecord, change); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *TechnitiumProvider) apply(ctx context.Context, action string, fn func(context.Context, string, record) error, change technitiumChange) error {
+	target, _ := recordTarget(change.record)
+	if p.dryRun {
+		log.Infof("DRY RUN: %s %s IN %s -> %s in zone %s", action, change.record.Name, change.record.Type, target, change.zone)
+		return nil
+	}
+	log.Infof("%s %s IN %s -> %s in zone %s", action, change.record.Name, change.record.Type, target, change.zone)
+	return fn(ctx, change.zone, change.record)
+}
+
+// missingTargets returns the targets of the endpoint which are not in the other one.
+func missingTargets(ep, other *endpoint.Endpoint) []string {
+	var missing []string
+	for _, target := range ep.Targets {
+		if !slices.Contains(other.Targets, target) {
+			missing = append(missing, target)
+		}
+	}
+	return missing
+}
@@ -0,0 +1,210 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package technitium
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider"
+)
+
+type testTechnitiumChange struct {
+	zone   string
+	record record
+}
+
+type testTechnitiumClient struct {
+	zones   []zone
+	records map[string][]record
+	adds    []testTechnitiumChange
+	deletes []testTechnitiumChange
+	err     error
+}
+
+func (t *testTechnitiumClient) listZones(_ context.Context) ([]zone, error) {
+	return t.zones, t.err
+}
+
+func (t *testTechnitiumClient) listRecords(_ context.Context, zoneName string) ([]record, error) {
+	return t.records[zoneName], t.err
+}
+
+func (t *testTechnitiumClient) addRecord(_ context.Context, zoneName string, r record) error {
+	t.adds = append(t.adds, testTechnitiumChange{zoneName, r})
+	return nil
+}
+
+func (t *testTechnitiumClient) deleteRecord(_ context.Context, zoneName string, r record) error {
+	t.deletes = append(t.deletes, testTechnitiumChange{zoneName, r})
+	return nil
+}
+
+func newTestClient() *testTechnitiumClient {
+	return &testTechnitiumClient{
+		zones: []zone{
+			{Name: "example.com", Type: "Primary"},
+			{Name: "example.org", Type: "Primary"},
+			{Name: "forwarded.example.com", Type: "Forwarder"},
+			{Name: "secondary.example.com", Type: "Secondary"},
+			{Name: "disabled.example.com", Type: "Primary", Disabled: true},
+		},
+		records: map[string][]record{
+			"example.com": {
+				{Name: "example.com", Type: "SOA", TTL: 900},
+				{Name: "example.com", Type: "NS", TTL: 3600},
+				{Name: "www.example.com", Type: "A", TTL: 300, RData: rData{IPAddress: "192.0.2.1"}},
+				{Name: "www.example.com", Type: "A", TTL: 300, RData: rData{IPAddress: "192.0.2.2"}},
+				{Name: "www.example.com", Type: "AAAA", TTL: 300, RData: rData{IPAddress: "2001:db8::1"}},
+				{Name: "alias.example.com", Type: "CNAME", TTL: 3600, RData: rData{CName: "www.example.com"}},
+				{Name: "www.example.com", Type: "TXT", TTL: 300, RData: rData{Text: "heritage=external-dns"}},
+				{Name: "_sip._tcp.example.com", Type: "SRV", TTL: 300, RData: rData{Priority: 10, Weight: 5, Port: 5060, Target: "sip.example.com"}},
+				{Name: "off.example.com", Type: "A", TTL: 300, Disabled: true, RData: rData{IPAddress: "192.0.2.3"}},
+			},
+		},
+	}
+}
+
+func TestNewTechnitiumProvider(t *testing.T) {
+	_, err := NewTechnitiumProvider(TechnitiumConfig{Token: "token"})
+	require.ErrorIs(t, err, ErrNoTechnitiumServer)
+
+	_, err = NewTechnitiumProvider(TechnitiumConfig{Server: "http://technitium.local:5380"})
+	require.ErrorIs(t, err, ErrNoTechnitiumToken)
+
+	for _, tc := range []struct {
+		cfg   TechnitiumConfig
+		valid bool
+	}{
+		{cfg: TechnitiumConfig{Server: "http://technitium.local:5380", Token: "token"}, valid: true},
+		{cfg: TechnitiumConfig{Server: "https://dns.example.org/", Token: "token"}, valid: true},
+		{cfg: TechnitiumConfig{Server: "technitium.local:5380", Token: "token"}},
+	} {
+		_, err = NewTechnitiumProvider(tc.cfg)
+		if tc.valid {
+			assert.NoError(t, err, tc.cfg.Server)
+		} else {
+			assert.Error(t, err, tc.cfg.Server)
+		}
+	}
+}
+
+func TestTechnitiumZones(t *testing.T) {
+	p := &TechnitiumProvider{api: newTestClient(), domainFilter: endpoint.NewDomainFilter([]string{"example.com"})}
+
+	zones, skipped, err := p.zones(context.Background())
+	require.NoError(t, err)
+
+	// The conditional forwarder, secondary and disabled zones are skipped.
+	assert.Equal(t, provider.ZoneIDName{"example.com": "example.com"}, zones)
+	assert.Equal(t, provider.ZoneIDName{
+		"forwarded.example.com": "forwarded.example.com",
+		"secondary.example.com": "secondary.example.com",
+		"disabled.example.com":  "disabled.example.com",
+	}, skipped)
+}
+
+func TestTechnitiumRecords(t *testing.T) {
+	p := &TechnitiumProvider{api: newTestClient(), domainFilter: endpoint.NewDomainFilter([]string{"example.com"})}
+
+	records, err := p.Records(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, []*endpoint.Endpoint{
+		endpoint.NewEndpointWithTTL("www.example.com", endpoint.RecordTypeA, 300, "192.0.2.1", "192.0.2.2"),
+		endpoint.NewEndpointWithTTL("www.example.com", endpoint.RecordTypeAAAA, 300, "2001:db8::1"),
+		endpoint.NewEndpointWithTTL("alias.example.com", endpoint.RecordTypeCNAME, 3600, "www.example.com"),
+		endpoint.NewEndpointWithTTL("www.example.com", endpoint.RecordTypeTXT, 300, "heritage=external-dns"),
+		endpoint.NewEndpointWithTTL("_sip._tcp.example.com", endpoint.RecordTypeSRV, 300, "10 5 5060 sip.example.com"),
+	}, records)
+}
+
+func TestTechnitiumRecordsError(t *testing.T) {
+	p := &TechnitiumProvider{api: &testTechnitiumClient{err: ErrInvalidToken}}
+
+	_, err := p.Records(context.Background())
+	require.ErrorIs(t, err, ErrInvalidToken)
+}
+
+func TestTechnitiumApplyChanges(t *testing.T) {
+	api := newTestClient()
+	p := &TechnitiumProvider{api: api}
+
+	err := p.ApplyChanges(context.Background(), &plan.Changes{
+		Create: []*endpoint.Endpoint{
+			endpoint.NewEndpointWithTTL("new.example.com", endpoint.RecordTypeA, 600, "192.0.2.10", "192.0.2.11"),
+			endpoint.NewEndpoint("_sip._udp.example.org", endpoint.RecordTypeSRV, "10 5 5060 sip.example.org"),
+			endpoint.NewEndpoint("app.forwarded.example.com", endpoint.RecordTypeA, "192.0.2.12"),
+		},
+		UpdateOld: []*endpoint.Endpoint{
+			endpoint.NewEndpointWithTTL("www.example.com", endpoint.RecordTypeA, 300, "192.0.2.1", "192.0.2.2"),
+			endpoint.NewEndpointWithTTL("alias.example.com", endpoint.RecordTypeCNAME, 3600, "www.example.com"),
+		},
+		UpdateNew: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("www.example.com", endpoint.RecordTypeA, "192.0.2.2", "192.0.2.3"),
+			endpoint.NewEndpointWithTTL("alias.example.com", endpoint.RecordTypeCNAME, 60, "www.example.com"),
+		},
+		Delete: []*endpoint.Endpoint{
+			endpoint.NewEndpointWithTTL("www.example.com", endpoint.RecordTypeTXT, 300, "heritage=external-dns"),
+		},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []testTechnitiumChange{
+		{"example.com", record{Name: "www.example.com", Type: "TXT", TTL: 300, RData: rData{Text: "heritage=external-dns"}}},
+		{"example.com", record{Name: "www.example.com", Type: "A", TTL: 300, RData: rData{IPAddress: "192.0.2.1"}}},
+		{"example.com", record{Name: "alias.example.com", Type: "CNAME", TTL: 3600, RData: rData{CName: "www.example.com"}}},
+	}, api.deletes)
+	// The record of the conditional forwarder zone is not added to its parent zone.
+	assert.Equal(t, []testTechnitiumChange{
+		{"example.com", record{Name: "www.example.com", Type: "A", RData: rData{IPAddress: "192.0.2.3"}}},
+		{"example.com", record{Name: "alias.example.com", Type: "CNAME", TTL: 60, RData: rData{CName: "www.example.com"}}},
+		{"example.com", record{Name: "new.example.com", Type: "A", TTL: 600, RData: rData{IPAddress: "192.0.2.10"}}},
+		{"example.com", record{Name: "new.example.com", Type: "A", TTL: 600, RData: rData{IPAddress: "192.0.2.11"}}},
+		{"example.org", record{Name: "_sip._udp.example.org", Type: "SRV", RData: rData{Priority: 10, Weight: 5, Port: 5060, Target: "sip.example.org"}}},
+	}, api.adds)
+}
+
+func TestTechnitiumApplyChangesInvalidSRV(t *testing.T) {
+	api := newTestClient()
+	p := &TechnitiumProvider{api: api}
+
+	err := p.ApplyChanges(context.Background(), &plan.Changes{
+		Create: []*endpoint.Endpoint{endpoint.NewEndpoint("_sip._udp.example.com", endpoint.RecordTypeSRV, "sip.example.com")},
+	})
+	require.ErrorIs(t, err, provider.SoftError)
+	assert.Empty(t, api.adds)
+}
+
+func TestTechnitiumApplyChangesDryRun(t *testing.T) {
+	api := newTestClient()
+	p := &TechnitiumProvider{api: api, dryRun: true}
+
+	err := p.ApplyChanges(context.Background(), &plan.Changes{
+		Create: []*endpoint.Endpoint{endpoint.NewEndpoint("new.example.com", endpoint.RecordTypeA, "192.0.2.10")},
+		Delete: []*endpoint.Endpoint{endpoint.NewEndpoint("www.example.com", endpoint.RecordTypeAAAA, "2001:db8::1")},
+	})
+	require.NoError(t, err)
+
+	assert.Empty(t, api.adds)
+	assert.Empty(t, api.deletes)
+}
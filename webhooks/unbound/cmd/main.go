@@ -0,0 +1,49 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// The Unbound webhook provider of ExternalDNS, managing the local data of an Unbound resolver.
+package main
+
+import (
+	"os"
+
+	"github.com/alecthomas/kingpin/v2"
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/webhooks/internal/server"
+	"sigs.k8s.io/external-dns/webhooks/unbound"
+)
+
+func main() {
+	var opts server.Options
+	var cfg unbound.UnboundConfig
+	app := server.NewApp("external-dns-unbound-webhook", "Webhook provider of ExternalDNS managing the local data of an Unbound resolver.", &opts)
+	server.Flag(app, "unbound-control-address", "The address of the remote control interface, host:port or the path of a unix socket").Default("127.0.0.1:8953").StringVar(&cfg.ControlAddress)
+	server.Flag(app, "unbound-control-cert-file", "The certificate of unbound-control, when the remote control uses certificates").StringVar(&cfg.ControlCertFile)
+	server.Flag(app, "unbound-control-key-file", "The key of unbound-control, when the remote control uses certificates").StringVar(&cfg.ControlKeyFile)
+	server.Flag(app, "unbound-server-cert-file", "The certificate of the Unbound server, when the remote control uses certificates").StringVar(&cfg.ServerCertFile)
+	server.Flag(app, "unbound-local-zone-type", "Add a local zone of this type for each domain of --domain-filter (optional, options: transparent, typetransparent, static, redirect)").EnumVar(&cfg.LocalZoneType, "", "transparent", "typetransparent", "static", "redirect")
+	kingpin.MustParse(app.Parse(os.Args[1:]))
+
+	opts.ConfigureLogger()
+	cfg.DomainFilter = opts.NewDomainFilter()
+	cfg.DryRun = opts.DryRun
+	p, err := unbound.NewUnboundProvider(cfg)
+	if err != nil {
+		log.Fatal(err)
+	}
+	server.Run(&opts, p)
+}
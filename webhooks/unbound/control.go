@@ -0,0 +1,171 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package unbound
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/miekg/dns"
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/pkg/tlsutils"
+)
+
+const (
+	// controlVersion is the version of the remote control protocol spoken by unbound-control.
+	controlVersion = 1
+	// controlServerName is the name in the self-signed certificates generated by unbound-control-setup.
+	controlServerName = "unbound"
+	// controlTimeout bounds a command when the context has no deadline.
+	controlTimeout = 30 * time.Second
+	// maxControlResponse bounds the response of a command, the local data of a large zone included.
+	maxControlResponse = 64 << 20
+)
+
+// unboundControl declares the remote control commands run against the Unbound server.
+type unboundControl interface {
+	// listLocalData returns all the local data of the server.
+	listLocalData(ctx context.Context) ([]dns.RR, error)
+	// addLocalData adds the given resource record to the local data.
+	addLocalData(ctx context.Context, rr dns.RR) error
+	// removeLocalData removes all the local data of the given name.
+	removeLocalData(ctx context.Context, name string) error
+	// addLocalZone adds the given local zone, or changes its type if it exists.
+	addLocalZone(ctx context.Context, name, zoneType string) error
+}
+
+// controlClient implements the unboundControl with the remote control protocol of unbound-control,
+// one connection per command.
+type controlClient struct {
+	network   string
+	address   string
+	tlsConfig *tls.Config
+}
+
+// newControlClient creates a new remote control client, over a unix socket when the address is
+// a path, and over TLS when the control certificates are given.
+func newControlClient(cfg UnboundConfig) (*controlClient, error) {
+	if cfg.ControlAddress == "" {
+		return nil, ErrNoControlAddress
+	}
+
+	c := &controlClient{network: "tcp", address: cfg.ControlAddress}
+	if strings.HasPrefix(cfg.ControlAddress, "/") {
+		c.network = "unix"
+	}
+	if cfg.ControlCertFile != "" || cfg.ControlKeyFile != "" || cfg.ServerCertFile != "" {
+		tlsConfig, err := tlsutils.NewTLSConfig(cfg.ControlCertFile, cfg.ControlKeyFile, cfg.ServerCertFile, controlServerName, false, tls.VersionTLS12)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load the Unbound remote control certificates: %w", err)
+		}
+		c.tlsConfig = tlsConfig
+	}
+	return c, nil
+}
+
+func (c *controlClient) listLocalData(ctx context.Context) ([]dns.RR, error) {
+	out, err := c.run(ctx, "list_local_data")
+	if err != nil {
+		return nil, err
+	}
+
+	var rrs []dns.RR
+	scanner := bufio.NewScanner(strings.NewReader(out))
+	scanner.Buffer(nil, dns.MaxMsgSize)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		rr, err := dns.NewRR(line)
+		if err != nil || rr == nil {
+			// The record types unknown to the parser are not managed anyway.
+			log.Debugf("Skipping unparsable local data %q: %v", line, err)
+			continue
+		}
+		rrs = append(rrs, rr)
+	}
+	return rrs, scanner.Err()
+}
+
+func (c *controlClient) addLocalData(ctx context.Context, rr dns.RR) error {
+	_, err := c.run(ctx, "local_data", rr.String())
+	return err
+}
+
+func (c *controlClient) removeLocalData(ctx context.Context, name string) error {
+	_, err := c.run(ctx, "local_data_remove", name)
+	return err
+}
+
+func (c *controlClient) addLocalZone(ctx context.Context, name, zoneType string) error {
+	_, err := c.run(ctx, "local_zone", name, zoneType)
+	return err
+}
+
+// run sends a command to the server and returns its output, failing when the server reports an
+// error.
+func (c *controlClient) run(ctx context.Context, args ...string) (string, error) {
+	command := strings.Join(args, " ")
+	if strings.ContainsAny(command, "\n\r") {
+		return "", fmt.Errorf("invalid Unbound remote control command %q", command)
+	}
+	log.Debugf("Running Unbound remote control command %q", command)
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, c.network, c.address)
+	if err != nil {
+		return "", fmt.Errorf("failed to connect to the Unbound remote control: %w", err)
+	}
+	defer conn.Close()
+
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(controlTimeout)
+	}
+	if err := conn.SetDeadline(deadline); err != nil {
+		return "", err
+	}
+	if c.tlsConfig != nil {
+		conn = tls.Client(conn, c.tlsConfig)
+	}
+
+	if _, err := fmt.Fprintf(conn, "UBCT%d %s\n", controlVersion, command); err != nil {
+		return "", fmt.Errorf("failed to send the Unbound remote control command %s: %w", args[0], err)
+	}
+	body, err := io.ReadAll(io.LimitReader(conn, maxControlResponse+1))
+	if err != nil {
+		return "", fmt.Errorf("failed to read the response of the Unbound remote control command %s: %w", args[0], err)
+	}
+	if len(body) > maxControlResponse {
+		return "", fmt.Errorf("the response of the Unbound remote control command %s is larger than %d bytes", args[0], maxControlResponse)
+	}
+
+	out := string(body)
+	if strings.HasPrefix(out, "error") {
+		return "", errors.New(strings.TrimSpace(out))
+	}
+	return out, nil
+}
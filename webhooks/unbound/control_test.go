@@ -0,0 +1,119 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package unbound
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/miekg/dns"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestServer serves the remote control commands without certificates, replying with the
+// output of the given function, and returns the commands received.
+func newTestServer(t *testing.T, network, address string, reply func(command string) string) (string, *[]string) {
+	t.Helper()
+	l, err := net.Listen(network, address)
+	require.NoError(t, err)
+	t.Cleanup(func() { l.Close() })
+
+	var commands []string
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			line, err := bufio.NewReader(conn).ReadString('\n')
+			if err == nil {
+				command := strings.TrimSuffix(strings.TrimPrefix(line, "UBCT1 "), "\n")
+				commands = append(commands, command)
+				conn.Write([]byte(reply(command)))
+			}
+			conn.Close()
+		}
+	}()
+	return l.Addr().String(), &commands
+}
+
+func TestControlClient(t *testing.T) {
+	address, commands := newTestServer(t, "tcp", "127.0.0.1:0", func(command string) string {
+		switch {
+		case command == "list_local_data":
+			return "localhost.\t10800\tIN\tA\t127.0.0.1\n" +
+				"www.example.com.\t300\tIN\tA\t192.0.2.1\n" +
+				"www.example.com.\t300\tIN\tTXT\t\"heritage=external-dns\"\n" +
+				"example.com.\t300\tIN\tTYPE65534\t\\# 0\n"
+		case strings.HasPrefix(command, "local_data ") && strings.Contains(command, "invalid"):
+			return "error parsing local-data at 0 'invalid.example.com. 300 IN A': Syntax error\n"
+		default:
+			return "ok\n"
+		}
+	})
+
+	c, err := newControlClient(UnboundConfig{ControlAddress: address})
+	require.NoError(t, err)
+
+	rrs, err := c.listLocalData(context.Background())
+	require.NoError(t, err)
+	require.Len(t, rrs, 4)
+	assert.Equal(t, "www.example.com.\t300\tIN\tTXT\t\"heritage=external-dns\"", rrs[2].String())
+
+	rr, err := dns.NewRR("new.example.com. 60 IN AAAA 2001:db8::1")
+	require.NoError(t, err)
+	require.NoError(t, c.addLocalData(context.Background(), rr))
+	require.NoError(t, c.removeLocalData(context.Background(), "www.example.com."))
+	require.NoError(t, c.addLocalZone(context.Background(), "example.com.", "typetransparent"))
+
+	invalid, err := dns.NewRR("invalid.example.com. 300 IN A 192.0.2.1")
+	require.NoError(t, err)
+	err = c.addLocalData(context.Background(), invalid)
+	require.EqualError(t, err, "error parsing local-data at 0 'invalid.example.com. 300 IN A': Syntax error")
+
+	assert.Equal(t, []string{
+		"list_local_data",
+		"local_data new.example.com.\t60\tIN\tAAAA\t2001:db8::1",
+		"local_data_remove www.example.com.",
+		"local_zone example.com. typetransparent",
+		"local_data invalid.example.com.\t300\tIN\tA\t192.0.2.1",
+	}, *commands)
+}
+
+func TestControlClientUnixSocket(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "unbound.ctl")
+	_, commands := newTestServer(t, "unix", path, func(string) string { return "ok\n" })
+
+	c, err := newControlClient(UnboundConfig{ControlAddress: path})
+	require.NoError(t, err)
+
+	require.NoError(t, c.removeLocalData(context.Background(), "www.example.com."))
+	assert.Equal(t, []string{"local_data_remove www.example.com."}, *commands)
+}
+
+func TestControlClientInvalidCommand(t *testing.T) {
+	c, err := newControlClient(UnboundConfig{ControlAddress: "127.0.0.1:0"})
+	require.NoError(t, err)
+
+	err = c.removeLocalData(context.Background(), "www.example.com.\nlocal_zone_remove example.com.")
+	require.ErrorContains(t, err, "invalid Unbound remote control command")
+}
@@ -0,0 +1,267 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package unbound
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/miekg/dns"
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider"
+)
+
+// defaultTTL is the TTL Unbound gives to the local data without one.
+const defaultTTL = 3600
+
+var (
+	// ErrNoControlAddress is returned when there is no remote control address configured.
+	ErrNoControlAddress = errors.New("no Unbound remote control address found in the flags")
+	// ErrNoDomainFilter is returned when no domain filter is configured, which would otherwise
+	// expose the default local zones of Unbound, such as localhost, to the deletions.
+	ErrNoDomainFilter = errors.New("the Unbound provider requires a domain filter")
+	// ErrNoLocalZones is returned when the local zones to add cannot be told from the domain filter.
+	ErrNoLocalZones = errors.New("the Unbound local zone type requires the domains of the domain filter")
+)
+
+// UnboundProvider is an implementation of Provider for the local data of an Unbound resolver.
+type UnboundProvider struct {
+	provider.BaseProvider
+	control       unboundControl
+	domainFilter  *endpoint.DomainFilter
+	localZoneType string
+	dryRun        bool
+}
+
+// UnboundConfig is used for configuring an UnboundProvider.
+type UnboundConfig struct {
+	// The address of the remote control interface, host:port or the path of a unix socket.
+	ControlAddress string
+	// The certificate and key of unbound-control, when the remote control uses certificates.
+	ControlCertFile string
+	ControlKeyFile  string
+	// The certificate of the server, when the remote control uses certificates.
+	ServerCertFile string
+	// The type of the local zones to add for the domains of the domain filter, none when empty.
+	LocalZoneType string
+	// A filter to apply when looking up and applying records.
+	DomainFilter *endpoint.DomainFilter
+	// Do nothing and log what would have changed to stdout.
+	DryRun bool
+}
+
+// Helper struct for grouping the resource records of an endpoint.
+type unboundEntryKey struct {
+	DNSName    string
+	RecordType string
+}
+
+// NewUnboundProvider initializes a new Unbound based Provider.
+func NewUnboundProvider(cfg UnboundConfig) (*UnboundProvider, error) {
+	if !cfg.DomainFilter.IsConfigured() {
+		return nil, ErrNoDomainFilter
+	}
+	if cfg.LocalZoneType != "" && len(cfg.DomainFilter.Filters) == 0 {
+		return nil, ErrNoLocalZones
+	}
+	control, err := newControlClient(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &UnboundProvider{
+		control:       control,
+		domainFilter:  cfg.DomainFilter,
+		localZoneType: cfg.LocalZoneType,
+		dryRun:        cfg.DryRun,
+	}, nil
+}
+
+// Capabilities implements provider.CapabilitiesReporter.
+func (p *UnboundProvider) Capabilities() provider.Capabilities {
+	return provider.Capabilities{
+		RecordTypes: []string{endpoint.RecordTypeA, endpoint.RecordTypeAAAA, endpoint.RecordTypeCNAME, endpoint.RecordTypeTXT, endpoint.RecordTypeSRV, endpoint.RecordTypeMX},
+	}
+}
+
+// Records implements Provider, populating a slice of endpoints from the local data matching the
+// domain filter.
+func (p *UnboundProvider) Records(ctx context.Context) ([]*endpoint.Endpoint, error) {
+	rrs, err := p.control.listLocalData(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var endpoints []*endpoint.Endpoint
+	byKey := make(map[unboundEntryKey]*endpoint.Endpoint)
+	for _, rr := range rrs {
+		name := strings.TrimSuffix(rr.Header().Name, ".")
+		target, ok := rrTarget(rr)
+		if !ok || !p.domainFilter.Match(name) {
+			continue
+		}
+		recordType := dns.TypeToString[rr.Header().Rrtype]
+		key := unboundEntryKey{name, recordType}
+		if ep, ok := byKey[key]; ok {
+			ep.Targets = append(ep.Targets, target)
+			continue
+		}
+		ep := endpoint.NewEndpointWithTTL(name, recordType, endpoint.TTL(rr.Header().Ttl), target)
+		byKey[key] = ep
+		endpoints = append(endpoints, ep)
+	}
+	return endpoints, nil
+}
+
+// rrTarget returns the target of an endpoint for a resource record of a supported type.
+func rrTarget(rr dns.RR) (string, bool) {
+	switch rr := rr.(type) {
+	case *dns.A:
+		return rr.A.String(), true
+	case *dns.AAAA:
+		return rr.AAAA.String(), true
+	case *dns.CNAME:
+		return strings.TrimSuffix(rr.Target, "."), true
+	case *dns.TXT:
+		return strings.Join(rr.Txt, ""), true
+	case *dns.SRV:
+		return fmt.Sprintf("%d %d %d %s", rr.Priority, rr.Weight, rr.Port, strings.TrimSuffix(rr.Target, ".")), true
+	case *dns.MX:
+		return fmt.Sprintf("%d %s", rr.Preference, strings.TrimSuffix(rr.Mx, ".")), true
+	default:
+		return "", false
+	}
+}
+
+// newRR returns the resource record of a target of an endpoint.
+func newRR(ep *endpoint.Endpoint, target string) (dns.RR, error) {
+	ttl := uint32(defaultTTL)
+	if ep.RecordTTL.IsConfigured() {
+		ttl = uint32(ep.RecordTTL)
+	}
+	if ep.RecordType == endpoint.RecordTypeTXT {
+		// The TXT targets are not in the presentation format, which would need quoting.
+		return &dns.TXT{
+			Hdr: dns.RR_Header{Name: dns.Fqdn(ep.DNSName), Rrtype: dns.TypeTXT, Class: dns.ClassINET, Ttl: ttl},
+			Txt: []string{target},
+		}, nil
+	}
+	rr, err := dns.NewRR(fmt.Sprintf("%s %d IN %s %s", dns.Fqdn(ep.DNSName), ttl, ep.RecordType, target))
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s target %q of %s: %w", ep.RecordType, target, ep.DNSName, err)
+	}
+	if rr == nil {
+		return nil, fmt.Errorf("empty %s target of %s", ep.RecordType, ep.DNSName)
+	}
+	return rr, nil
+}
+
+// ApplyChanges implements Provider, rewriting the local data of the names which changed.
+//
+// Unbound only removes all the local data of a name at once, so the remaining resource records
+// of a changed name, whatever their type, are added back after the removal.
+func (p *UnboundProvider) ApplyChanges(ctx context.Context, changes *plan.Changes) error {
+	rrs, err := p.control.listLocalData(ctx)
+	if err != nil {
+		return err
+	}
+	byName := make(map[string][]dns.RR)
+	for _, rr := range rrs {
+		name := strings.ToLower(rr.Header().Name)
+		byName[name] = append(byName[name], rr)
+	}
+
+	changed := make(map[string]bool)
+	remove := func(ep *endpoint.Endpoint) {
+		name := strings.ToLower(dns.Fqdn(ep.DNSName))
+		byName[name] = slices.DeleteFunc(byName[name], func(rr dns.RR) bool {
+			return dns.TypeToString[rr.Header().Rrtype] == ep.RecordType
+		})
+		changed[name] = true
+	}
+	add := func(ep *endpoint.Endpoint) error {
+		name := strings.ToLower(dns.Fqdn(ep.DNSName))
+		for _, target := range ep.Targets {
+			rr, err := newRR(ep, target)
+			if err != nil {
+				return provider.NewSoftError(err)
+			}
+			byName[name] = append(byName[name], rr)
+		}
+		changed[name] = true
+		return nil
+	}
+
+	for _, ep := range slices.Concat(changes.Delete, changes.UpdateOld) {
+		remove(ep)
+	}
+	for _, ep := range slices.Concat(changes.UpdateNew, changes.Create) {
+		if err := add(ep); err != nil {
+			return err
+		}
+	}
+	if len(changed) == 0 {
+		return nil
+	}
+
+	if p.localZoneType != "" {
+		for _, zone := range p.domainFilter.Filters {
+			zone = dns.Fqdn(strings.TrimPrefix(zone, "."))
+			if err := p.run(fmt.Sprintf("Adding local zone %s %s", zone, p.localZoneType), func() error {
+				return p.control.addLocalZone(ctx, zone, p.localZoneType)
+			}); err != nil {
+				return err
+			}
+		}
+	}
+
+	names := make([]string, 0, len(changed))
+	for name := range changed {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		if err := p.run("Removing local data of "+name, func() error {
+			return p.control.removeLocalData(ctx, name)
+		}); err != nil {
+			return err
+		}
+		for _, rr := range byName[name] {
+			if err := p.run("Adding local data "+rr.String(), func() error {
+				return p.control.addLocalData(ctx, rr)
+			}); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// run runs an action against the server, or only logs it in dry-run mode.
+func (p *UnboundProvider) run(action string, fn func() error) error {
+	if p.dryRun {
+		log.Infof("DRY RUN: %s", action)
+		return nil
+	}
+	log.Info(action)
+	return fn()
+}
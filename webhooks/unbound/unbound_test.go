@@ -0,0 +1,193 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package unbound
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/miekg/dns"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider"
+)
+
+// testUnboundControl holds the local data in memory and records the commands run.
+type testUnboundControl struct {
+	t        *testing.T
+	data     []dns.RR
+	zones    map[string]string
+	commands []string
+}
+
+func newTestControl(t *testing.T, data ...string) *testUnboundControl {
+	t.Helper()
+	c := &testUnboundControl{t: t, zones: map[string]string{}}
+	for _, s := range data {
+		rr, err := dns.NewRR(s)
+		require.NoError(t, err)
+		c.data = append(c.data, rr)
+	}
+	return c
+}
+
+func (c *testUnboundControl) listLocalData(_ context.Context) ([]dns.RR, error) {
+	return slices.Clone(c.data), nil
+}
+
+func (c *testUnboundControl) addLocalData(_ context.Context, rr dns.RR) error {
+	c.commands = append(c.commands, "local_data "+strings.ReplaceAll(rr.String(), "\t", " "))
+	c.data = append(c.data, rr)
+	return nil
+}
+
+func (c *testUnboundControl) removeLocalData(_ context.Context, name string) error {
+	c.commands = append(c.commands, "local_data_remove "+name)
+	c.data = slices.DeleteFunc(c.data, func(rr dns.RR) bool { return strings.EqualFold(rr.Header().Name, name) })
+	return nil
+}
+
+func (c *testUnboundControl) addLocalZone(_ context.Context, name, zoneType string) error {
+	c.commands = append(c.commands, "local_zone "+name+" "+zoneType)
+	c.zones[name] = zoneType
+	return nil
+}
+
+func newTestData(t *testing.T) *testUnboundControl {
+	return newTestControl(t,
+		"localhost. 10800 IN A 127.0.0.1",
+		"example.com. 3600 IN SOA ns.example.com. hostmaster.example.com. 1 3600 600 86400 3600",
+		"www.example.com. 300 IN A 192.0.2.1",
+		"www.example.com. 300 IN A 192.0.2.2",
+		"www.example.com. 300 IN TXT \"heritage=external-dns\"",
+		"alias.example.com. 3600 IN CNAME www.example.com.",
+		"_sip._tcp.example.com. 300 IN SRV 10 5 5060 sip.example.com.",
+		"example.com. 300 IN MX 10 mail.example.com.",
+	)
+}
+
+func TestNewUnboundProvider(t *testing.T) {
+	_, err := NewUnboundProvider(UnboundConfig{ControlAddress: "127.0.0.1:8953"})
+	require.ErrorIs(t, err, ErrNoDomainFilter)
+
+	_, err = NewUnboundProvider(UnboundConfig{DomainFilter: endpoint.NewDomainFilter([]string{"example.com"})})
+	require.ErrorIs(t, err, ErrNoControlAddress)
+
+	_, err = NewUnboundProvider(UnboundConfig{
+		ControlAddress: "127.0.0.1:8953",
+		LocalZoneType:  "typetransparent",
+		DomainFilter:   endpoint.NewDomainFilterWithExclusions(nil, []string{"example.org"}),
+	})
+	require.ErrorIs(t, err, ErrNoLocalZones)
+
+	_, err = NewUnboundProvider(UnboundConfig{ControlAddress: "127.0.0.1:8953", ServerCertFile: "/nonexistent/unbound_server.pem", DomainFilter: endpoint.NewDomainFilter([]string{"example.com"})})
+	require.ErrorContains(t, err, "failed to load the Unbound remote control certificates")
+
+	p, err := NewUnboundProvider(UnboundConfig{ControlAddress: "/run/unbound.ctl", DomainFilter: endpoint.NewDomainFilter([]string{"example.com"})})
+	require.NoError(t, err)
+	assert.Equal(t, &controlClient{network: "unix", address: "/run/unbound.ctl"}, p.control)
+}
+
+func TestUnboundRecords(t *testing.T) {
+	p := &UnboundProvider{control: newTestData(t), domainFilter: endpoint.NewDomainFilter([]string{"example.com"})}
+
+	records, err := p.Records(context.Background())
+	require.NoError(t, err)
+
+	// The default local zones of Unbound and the SOA records are left out.
+	assert.Equal(t, []*endpoint.Endpoint{
+		endpoint.NewEndpointWithTTL("www.example.com", endpoint.RecordTypeA, 300, "192.0.2.1", "192.0.2.2"),
+		endpoint.NewEndpointWithTTL("www.example.com", endpoint.RecordTypeTXT, 300, "heritage=external-dns"),
+		endpoint.NewEndpointWithTTL("alias.example.com", endpoint.RecordTypeCNAME, 3600, "www.example.com"),
+		endpoint.NewEndpointWithTTL("_sip._tcp.example.com", endpoint.RecordTypeSRV, 300, "10 5 5060 sip.example.com"),
+		endpoint.NewEndpointWithTTL("example.com", endpoint.RecordTypeMX, 300, "10 mail.example.com"),
+	}, records)
+}
+
+func TestUnboundApplyChanges(t *testing.T) {
+	control := newTestData(t)
+	p := &UnboundProvider{control: control, domainFilter: endpoint.NewDomainFilter([]string{"example.com"}), localZoneType: "typetransparent"}
+
+	err := p.ApplyChanges(context.Background(), &plan.Changes{
+		Create: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("new.example.com", endpoint.RecordTypeAAAA, "2001:db8::1"),
+			endpoint.NewEndpointWithTTL("new.example.com", endpoint.RecordTypeTXT, 60, "heritage=external-dns,external-dns/owner=default"),
+		},
+		UpdateOld: []*endpoint.Endpoint{
+			endpoint.NewEndpointWithTTL("www.example.com", endpoint.RecordTypeA, 300, "192.0.2.1", "192.0.2.2"),
+		},
+		UpdateNew: []*endpoint.Endpoint{
+			endpoint.NewEndpointWithTTL("www.example.com", endpoint.RecordTypeA, 300, "192.0.2.3"),
+		},
+		Delete: []*endpoint.Endpoint{
+			endpoint.NewEndpointWithTTL("example.com", endpoint.RecordTypeMX, 300, "10 mail.example.com"),
+		},
+	})
+	require.NoError(t, err)
+
+	// The remaining local data of the changed names, the SOA and TXT records, is added back.
+	assert.Equal(t, []string{
+		"local_zone example.com. typetransparent",
+		"local_data_remove example.com.",
+		"local_data example.com. 3600 IN SOA ns.example.com. hostmaster.example.com. 1 3600 600 86400 3600",
+		"local_data_remove new.example.com.",
+		"local_data new.example.com. 3600 IN AAAA 2001:db8::1",
+		"local_data new.example.com. 60 IN TXT \"heritage=external-dns,external-dns/owner=default\"",
+		"local_data_remove www.example.com.",
+		"local_data www.example.com. 300 IN TXT \"heritage=external-dns\"",
+		"local_data www.example.com. 300 IN A 192.0.2.3",
+	}, control.commands)
+
+	records, err := p.Records(context.Background())
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []*endpoint.Endpoint{
+		endpoint.NewEndpointWithTTL("www.example.com", endpoint.RecordTypeA, 300, "192.0.2.3"),
+		endpoint.NewEndpointWithTTL("www.example.com", endpoint.RecordTypeTXT, 300, "heritage=external-dns"),
+		endpoint.NewEndpointWithTTL("alias.example.com", endpoint.RecordTypeCNAME, 3600, "www.example.com"),
+		endpoint.NewEndpointWithTTL("_sip._tcp.example.com", endpoint.RecordTypeSRV, 300, "10 5 5060 sip.example.com"),
+		endpoint.NewEndpointWithTTL("new.example.com", endpoint.RecordTypeAAAA, 3600, "2001:db8::1"),
+		endpoint.NewEndpointWithTTL("new.example.com", endpoint.RecordTypeTXT, 60, "heritage=external-dns,external-dns/owner=default"),
+	}, records)
+}
+
+func TestUnboundApplyChangesInvalidTarget(t *testing.T) {
+	control := newTestData(t)
+	p := &UnboundProvider{control: control, domainFilter: endpoint.NewDomainFilter([]string{"example.com"})}
+
+	err := p.ApplyChanges(context.Background(), &plan.Changes{
+		Create: []*endpoint.Endpoint{endpoint.NewEndpoint("_sip._udp.example.com", endpoint.RecordTypeSRV, "sip.example.com")},
+	})
+	require.ErrorIs(t, err, provider.SoftError)
+	assert.Empty(t, control.commands)
+}
+
+func TestUnboundApplyChangesDryRun(t *testing.T) {
+	control := newTestData(t)
+	p := &UnboundProvider{control: control, domainFilter: endpoint.NewDomainFilter([]string{"example.com"}), dryRun: true}
+
+	err := p.ApplyChanges(context.Background(), &plan.Changes{
+		Create: []*endpoint.Endpoint{endpoint.NewEndpoint("new.example.com", endpoint.RecordTypeA, "192.0.2.10")},
+		Delete: []*endpoint.Endpoint{endpoint.NewEndpoint("alias.example.com", endpoint.RecordTypeCNAME, "www.example.com")},
+	})
+	require.NoError(t, err)
+	assert.Empty(t, control.commands)
+}